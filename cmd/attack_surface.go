@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+)
+
+// attackSurfaceFilename holds the form and API endpoint inventory gathered by
+// crawls, accumulated across runs of an engagement.
+const attackSurfaceFilename = "attack_surface.json"
+
+// loadAttackSurface returns the stored inventory, or nil when none exists.
+func loadAttackSurface(resultsDir, engagementID string) (*checker.AttackSurface, error) {
+	path, err := resolveResultsPath(resultsDir, engagementID, attackSurfaceFilename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var surface checker.AttackSurface
+	if err := json.Unmarshal(data, &surface); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", attackSurfaceFilename, err)
+	}
+	return &surface, nil
+}
+
+// saveAttackSurface merges surface into the engagement's stored inventory and
+// returns the file path.
+func saveAttackSurface(resultsDir, engagementID string, surface *checker.AttackSurface) (string, error) {
+	if _, err := ensureResultsDir(resultsDir, engagementID); err != nil {
+		return "", err
+	}
+	merged, err := loadAttackSurface(resultsDir, engagementID)
+	if err != nil {
+		return "", err
+	}
+	if merged == nil {
+		merged = &checker.AttackSurface{}
+	}
+	merged.Merge(surface)
+
+	path, err := resolveResultsPath(resultsDir, engagementID, attackSurfaceFilename)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(merged, jsonPrefix, jsonIndent)
+	if err != nil {
+		return "", err
+	}
+	if err := fileutil.WriteFile(path, data, consts.DefaultFilePerm); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// recordAttackSurface saves the inventory from a crawl and reports what was
+// found. Failures are warnings: the inventory is supplementary to the checks.
+func recordAttackSurface(resultsDir, engagementID string, surface *checker.AttackSurface) {
+	if surface.Empty() {
+		return
+	}
+	path, err := saveAttackSurface(resultsDir, engagementID, surface)
+	if err != nil {
+		cliLog().Warnw("attack_surface_save_failed", "engagement_id", engagementID, "error", err)
+		return
+	}
+	fmt.Printf("%s Attack surface: %d form(s), %d API endpoint(s) → %s\n", colorInfo("→"), len(surface.Forms), len(surface.APIEndpoints), path)
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"crypto/sha512"
@@ -17,8 +18,11 @@ import (
 	"text/tabwriter"
 	"time"
 
+	"github.com/khanhnv2901/seca-cli/internal/domain/audit"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
 	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
 	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
 	"github.com/spf13/cobra"
 )
 
@@ -161,12 +165,7 @@ var auditListCmd = &cobra.Command{
 		for _, entry := range entriesToShow {
 			timestamp := entry.Timestamp.Format("2006-01-02 15:04:05")
 			duration := fmt.Sprintf("%.2fs", entry.DurationSeconds)
-			var statusStr string
-			if entry.Status == "ok" {
-				statusStr = colorSuccess(entry.Status)
-			} else {
-				statusStr = colorError(entry.Status)
-			}
+			statusStr := formatStatusWithColor(entry.Status)
 
 			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
 				timestamp,
@@ -315,7 +314,7 @@ var auditExportCmd = &cobra.Command{
 			outputPath = resolved
 		}
 
-		if err := os.WriteFile(outputPath, data, consts.DefaultFilePerm); err != nil {
+		if err := fileutil.WriteFile(outputPath, data, consts.DefaultFilePerm); err != nil {
 			return fmt.Errorf("failed to write export: %w", err)
 		}
 
@@ -339,14 +338,9 @@ func AppendAuditRow(resultsDir string, engagementID string, operatorName string,
 		exists = false
 	}
 
-	f, err := os.OpenFile(auditPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, consts.DefaultFilePerm)
-	if err != nil {
-		return fmt.Errorf("open audit file failed: %w", err)
-	}
-	defer f.Close()
-
-	writer := csv.NewWriter(f)
-	defer writer.Flush()
+	// Build the rows first so they reach the file in a single synced write.
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
 
 	// if new file, write header first
 	if !exists {
@@ -382,29 +376,47 @@ func AppendAuditRow(resultsDir string, engagementID string, operatorName string,
 		return fmt.Errorf("flush audit data failed: %w", err)
 	}
 
+	if err := fileutil.Append(auditPath, buf.Bytes(), consts.DefaultFilePerm); err != nil {
+		return fmt.Errorf("append audit row failed: %w", err)
+	}
 	return nil
 }
 
-// SaveRawCapture writes a limited raw HTTP response for auditing (be careful with PII)
-func SaveRawCapture(resultsDir string, engamentID, target string, headers map[string][]string, bodySnippet string) error {
-	dir, err := ensureResultsDir(resultsDir, engamentID)
-	if err != nil {
-		return err
+// recordAuditEvent records an engagement action that is not a check, such as
+// a status change, in the audit trail and flushes it to disk.
+func recordAuditEvent(ctx context.Context, appCtx *AppContext, engagementID, command, target, notes string) error {
+	entry := &audit.Entry{
+		Timestamp:    time.Now(),
+		EngagementID: engagementID,
+		Operator:     appCtx.Operator,
+		Command:      command,
+		Target:       target,
+		Status:       "ok",
+		Notes:        notes,
 	}
-	filename := fmt.Sprintf("raw_%d.txt", time.Now().UnixNano())
-	path := filepath.Join(dir, filename)
-	f, err := os.Create(path)
-	if err != nil {
-		return err
+	if err := appCtx.Services.CheckOrchestrator.RecordAuditEntry(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record audit: %w", err)
 	}
-	defer f.Close()
+	if err := appCtx.Services.AuditRepo.Flush(ctx, engagementID); err != nil {
+		return fmt.Errorf("failed to record audit: %w", err)
+	}
+	return nil
+}
 
-	fmt.Fprintf(f, "Target: %s\nCaptureAt: %s\n\nHeaders:\n", target, time.Now().UTC().Format(time.RFC3339))
+// SaveRawCapture writes a limited raw HTTP response for auditing (be careful with PII).
+// The capture is stored as raw_<sha256>.txt and recorded in the evidence index.
+func SaveRawCapture(resultsDir string, engamentID, target string, headers map[string][]string, bodySnippet string) error {
+	capturedAt := time.Now().UTC()
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Target: %s\nCaptureAt: %s\n\nHeaders:\n", target, capturedAt.Format(time.RFC3339))
 	for k, v := range headers {
-		fmt.Fprintf(f, "%s: %s\n", k, v)
+		fmt.Fprintf(&buf, "%s: %s\n", k, v)
 	}
-	fmt.Fprintf(f, "\n--- Body Snippet (max %d bytes) ---\n%s\n", consts.RawCaptureLimitBytes, bodySnippet)
-	return nil
+	fmt.Fprintf(&buf, "\n--- Body Snippet (max %d bytes) ---\n%s\n", consts.RawCaptureLimitBytes, bodySnippet)
+
+	ref := checker.EvidenceRef{Kind: checker.EvidenceRawCapture, Target: target, MediaType: "text/plain", CapturedAt: capturedAt}
+	_, err := writeEvidence(resultsDir, engamentID, ref, "", "raw_", ".txt", buf.Bytes())
+	return err
 }
 
 // HashFileSHA256 computes and writes a .sha256 companion file
@@ -432,7 +444,7 @@ func HashFile(path string, algorithm HashAlgorithm) (string, error) {
 	sum := hex.EncodeToString(hasher.Sum(nil))
 	hashPath := path + algorithm.FileExtension()
 	content := fmt.Sprintf("%s  %s\n", sum, filepath.Base(path))
-	if err := os.WriteFile(hashPath, []byte(content), consts.DefaultFilePerm); err != nil {
+	if err := fileutil.WriteFile(hashPath, []byte(content), consts.DefaultFilePerm); err != nil {
 		return "", err
 	}
 	return sum, nil
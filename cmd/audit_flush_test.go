@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/domain/audit"
+)
+
+func TestFlushAuditEntriesFailsRun(t *testing.T) {
+	defer setupTestAppContextWithServices(t)()
+	appCtx := globalAppContext
+
+	// The engagement directory cannot be created, so the buffered entry
+	// cannot be written.
+	const id = "eng-flush"
+	if err := os.WriteFile(filepath.Join(appCtx.ResultsDir, id), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	entry := audit.NewEntry(time.Now(), id, "alice", "check http", "https://example.com", "ok")
+	if err := appCtx.Services.AuditRepo.AppendEntry(context.Background(), id, entry); err != nil {
+		t.Fatalf("AppendEntry() error = %v", err)
+	}
+
+	var runErr error
+	flushAuditEntries(appCtx, id, &runErr)
+	if runErr == nil || !strings.Contains(runErr.Error(), "failed to write audit trail") {
+		t.Fatalf("expected the flush failure to become the run's error, got %v", runErr)
+	}
+
+	// An earlier error is kept.
+	cause := errors.New("scan failed")
+	runErr = cause
+	flushAuditEntries(appCtx, id, &runErr)
+	if runErr != cause {
+		t.Fatalf("expected the run's own error to be kept, got %v", runErr)
+	}
+}
@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/issuesync"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+	"github.com/spf13/cobra"
+)
+
+// baselineFilename holds the engagement's accepted findings.
+const baselineFilename = "baseline.json"
+
+// FindingsBaseline is the set of findings accepted as the engagement's known
+// state. Reports and runs then highlight only deviations from it.
+type FindingsBaseline struct {
+	SetAt    time.Time         `json:"set_at"`
+	SetBy    string            `json:"set_by"`
+	Note     string            `json:"note,omitempty"`
+	Findings []BaselineFinding `json:"findings"`
+}
+
+// BaselineFinding identifies one finding by the same fingerprint issue sync
+// uses, so a finding keeps its identity across runs.
+type BaselineFinding struct {
+	Fingerprint string `json:"fingerprint"`
+	Name        string `json:"name"`
+	Category    string `json:"category"`
+	Severity    string `json:"severity"`
+}
+
+// BaselineDiff compares current findings with the baseline.
+type BaselineDiff struct {
+	SetAt    time.Time         `json:"set_at"`
+	SetBy    string            `json:"set_by"`
+	Accepted int               `json:"accepted"`
+	New      []BaselineFinding `json:"new"`
+	Resolved []BaselineFinding `json:"resolved"`
+	// IncludeAccepted keeps accepted findings in the report's findings list.
+	IncludeAccepted bool `json:"-"`
+
+	accepted map[string]bool
+}
+
+// baselineFindings returns the open findings among vulns; passed checks and
+// informational entries are not findings.
+func baselineFindings(engagementID string, vulns []checker.Vulnerability) []BaselineFinding {
+	seen := make(map[string]bool)
+	findings := []BaselineFinding{}
+	for _, v := range vulns {
+		if strings.EqualFold(v.Status, "Passed") || strings.EqualFold(v.Status, "Info") {
+			continue
+		}
+		fp := issuesync.Fingerprint(engagementID, issuesync.Finding{Name: v.Name, Category: v.Category})
+		if seen[fp] {
+			continue
+		}
+		seen[fp] = true
+		findings = append(findings, BaselineFinding{Fingerprint: fp, Name: v.Name, Category: v.Category, Severity: v.Severity})
+	}
+	return findings
+}
+
+// diff compares the current vulnerabilities with the baseline.
+func (b *FindingsBaseline) diff(engagementID string, vulns []checker.Vulnerability) *BaselineDiff {
+	d := &BaselineDiff{SetAt: b.SetAt, SetBy: b.SetBy, New: []BaselineFinding{}, Resolved: []BaselineFinding{}, accepted: make(map[string]bool)}
+	inBaseline := make(map[string]bool, len(b.Findings))
+	for _, f := range b.Findings {
+		inBaseline[f.Fingerprint] = true
+	}
+	current := make(map[string]bool)
+	for _, f := range baselineFindings(engagementID, vulns) {
+		current[f.Fingerprint] = true
+		if inBaseline[f.Fingerprint] {
+			d.accepted[f.Fingerprint] = true
+			d.Accepted++
+		} else {
+			d.New = append(d.New, f)
+		}
+	}
+	for _, f := range b.Findings {
+		if !current[f.Fingerprint] {
+			d.Resolved = append(d.Resolved, f)
+		}
+	}
+	return d
+}
+
+// deviations returns a copy of report without the accepted findings. Passed
+// and informational entries are kept.
+func (d *BaselineDiff) deviations(engagementID string, report *checker.VulnerabilityReport) *checker.VulnerabilityReport {
+	filtered := &checker.VulnerabilityReport{
+		ScanURL:  report.ScanURL,
+		ScanDate: report.ScanDate,
+		Duration: report.Duration,
+	}
+	kept := make([]checker.Vulnerability, 0, len(report.Vulnerabilities))
+	for _, v := range report.Vulnerabilities {
+		fp := issuesync.Fingerprint(engagementID, issuesync.Finding{Name: v.Name, Category: v.Category})
+		if d.accepted[fp] && !strings.EqualFold(v.Status, "Passed") && !strings.EqualFold(v.Status, "Info") {
+			continue
+		}
+		kept = append(kept, v)
+	}
+	filtered.Add(kept...)
+	return filtered
+}
+
+// Summary is the one-line baseline note shown in reports.
+func (d *BaselineDiff) Summary() string {
+	accepted := fmt.Sprintf("%d accepted findings hidden", d.Accepted)
+	if d.IncludeAccepted {
+		accepted = fmt.Sprintf("%d accepted findings included", d.Accepted)
+	}
+	return fmt.Sprintf("Compared with the baseline set %s by %s: %d new, %d resolved, %s.",
+		d.SetAt.Format(time.DateOnly), d.SetBy, len(d.New), len(d.Resolved), accepted)
+}
+
+// loadFindingsBaseline returns the engagement's baseline, or nil when none
+// was set.
+func loadFindingsBaseline(resultsDir, engagementID string) (*FindingsBaseline, error) {
+	path, err := resolveResultsPath(resultsDir, engagementID, baselineFilename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var baseline FindingsBaseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", baselineFilename, err)
+	}
+	return &baseline, nil
+}
+
+func saveFindingsBaseline(resultsDir, engagementID string, baseline *FindingsBaseline) error {
+	if _, err := ensureResultsDir(resultsDir, engagementID); err != nil {
+		return err
+	}
+	path, err := resolveResultsPath(resultsDir, engagementID, baselineFilename)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(baseline, jsonPrefix, jsonIndent)
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFile(path, data, consts.DefaultFilePerm)
+}
+
+// trackBaselineDeviations reports the findings of a run that are not in the
+// engagement's baseline.
+func trackBaselineDeviations(resultsDir, engagementID string, results []checker.CheckResult) {
+	baseline, err := loadFindingsBaseline(resultsDir, engagementID)
+	if err != nil {
+		cliLog().Warnw("baseline_load_failed", "engagement_id", engagementID, "error", err)
+		return
+	}
+	if baseline == nil {
+		return
+	}
+	report := checker.BuildVulnerabilityReport(results, "", "", "")
+	diff := baseline.diff(engagementID, report.Vulnerabilities)
+	for _, f := range diff.New {
+		fmt.Printf("%s New finding not in baseline: [%s] %s\n", colorWarn("!"), f.Severity, f.Name)
+		cliLog().Infow("baseline_deviation",
+			"engagement_id", engagementID,
+			"finding", f.Name,
+			"severity", f.Severity,
+			"fingerprint", f.Fingerprint,
+		)
+	}
+}
+
+func writeBaselineFindingsTable(out io.Writer, findings []BaselineFinding) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SEVERITY\tCATEGORY\tFINDING\tFINGERPRINT")
+	for _, f := range findings {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%.12s\n", f.Severity, f.Category, f.Name, f.Fingerprint)
+	}
+	w.Flush()
+}
+
+var baselineCmd = &cobra.Command{
+	Use:   "baseline",
+	Short: "Manage the accepted findings baseline of an engagement",
+	Long: `A baseline records the engagement's current findings as accepted. Later
+runs and reports then highlight only deviations: findings that are new since
+the baseline, and baseline findings that were resolved.`,
+}
+
+var baselineSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Accept the engagement's current findings as its baseline",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return errors.New("--id is required")
+		}
+		note, _ := cmd.Flags().GetString("note")
+		if err := requireWritableEngagement(context.Background(), appCtx, id); err != nil {
+			return err
+		}
+
+		rendered, err := loadEngagementReport(appCtx.ResultsDir, id, "json")
+		if err != nil {
+			return err
+		}
+		// The report sorts vulnerabilities by severity, so the baseline is too.
+		vulns := engagementVulnerabilityReport(rendered.Output, "", "", "").Vulnerabilities
+		findings := baselineFindings(id, vulns)
+
+		baseline := &FindingsBaseline{
+			SetAt:    time.Now().UTC(),
+			SetBy:    appCtx.Operator,
+			Note:     strings.TrimSpace(note),
+			Findings: findings,
+		}
+		if err := saveFindingsBaseline(appCtx.ResultsDir, id, baseline); err != nil {
+			return fmt.Errorf("failed to save baseline: %w", err)
+		}
+		if err := recordAuditEvent(context.Background(), appCtx, id, "baseline set", baselineFilename, fmt.Sprintf("accepted %d findings", len(findings))); err != nil {
+			return err
+		}
+
+		fmt.Printf("%s baseline for engagement %s with %d accepted findings\n", colorSuccess("Set"), id, len(findings))
+		return nil
+	},
+}
+
+var baselineShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the baseline and how current findings deviate from it",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return errors.New("--id is required")
+		}
+		baseline, err := loadFindingsBaseline(appCtx.ResultsDir, id)
+		if err != nil {
+			return err
+		}
+		if baseline == nil {
+			fmt.Printf("No baseline set for engagement %s\n", id)
+			return nil
+		}
+
+		var diff *BaselineDiff
+		if rendered, err := loadEngagementReport(appCtx.ResultsDir, id, "json"); err == nil {
+			diff = rendered.Output.Baseline
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			b, _ := json.MarshalIndent(struct {
+				*FindingsBaseline
+				Diff *BaselineDiff `json:"diff,omitempty"`
+			}{baseline, diff}, jsonPrefix, jsonIndent)
+			fmt.Println(string(b))
+			return nil
+		}
+
+		fmt.Printf("Baseline set %s by %s (%d accepted findings)\n", baseline.SetAt.Format(time.RFC3339), baseline.SetBy, len(baseline.Findings))
+		if baseline.Note != "" {
+			fmt.Printf("Note: %s\n", baseline.Note)
+		}
+		if len(baseline.Findings) > 0 {
+			fmt.Println()
+			writeBaselineFindingsTable(cmd.OutOrStdout(), baseline.Findings)
+		}
+		if diff != nil {
+			fmt.Printf("\n%s %d new, %d resolved, %d still present\n", colorInfo("Current results:"), len(diff.New), len(diff.Resolved), diff.Accepted)
+			for _, f := range diff.New {
+				fmt.Printf("  + [%s] %s\n", f.Severity, f.Name)
+			}
+			for _, f := range diff.Resolved {
+				fmt.Printf("  - [%s] %s\n", f.Severity, f.Name)
+			}
+		}
+		return nil
+	},
+}
+
+var baselineClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove the engagement's baseline",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return errors.New("--id is required")
+		}
+		if err := requireWritableEngagement(context.Background(), appCtx, id); err != nil {
+			return err
+		}
+		path, err := resolveResultsPath(appCtx.ResultsDir, id, baselineFilename)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				fmt.Printf("No baseline set for engagement %s\n", id)
+				return nil
+			}
+			return fmt.Errorf("failed to remove baseline: %w", err)
+		}
+		if err := recordAuditEvent(context.Background(), appCtx, id, "baseline clear", baselineFilename, "baseline removed"); err != nil {
+			return err
+		}
+		fmt.Printf("%s baseline for engagement %s\n", colorSuccess("Cleared"), id)
+		return nil
+	},
+}
+
+func init() {
+	baselineCmd.AddCommand(baselineSetCmd, baselineShowCmd, baselineClearCmd)
+
+	baselineSetCmd.Flags().String("id", "", "Engagement ID")
+	baselineSetCmd.Flags().String("note", "", "Why the current findings are accepted")
+	baselineShowCmd.Flags().String("id", "", "Engagement ID")
+	baselineShowCmd.Flags().Bool("json", false, "Output as JSON")
+	baselineClearCmd.Flags().String("id", "", "Engagement ID")
+}
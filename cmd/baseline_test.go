@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func TestBaselineDiffHidesAcceptedFindings(t *testing.T) {
+	resultsDir := t.TempDir()
+	const id = "eng-baseline"
+	accepted := ManualFinding{ID: "MF-001", Target: "https://app.example.com", Title: "Verbose errors", Severity: "Low", Category: manualFindingCategory}
+	resolved := ManualFinding{ID: "MF-002", Target: "https://app.example.com", Title: "Open redirect", Severity: "Medium", Category: manualFindingCategory}
+	fresh := ManualFinding{ID: "MF-003", Target: "https://app.example.com", Title: "Password reset token in URL", Severity: "High", Category: manualFindingCategory}
+
+	before := &RunOutput{ManualFindings: []ManualFinding{accepted, resolved}}
+	baseline := &FindingsBaseline{
+		SetAt:    time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		SetBy:    "alice",
+		Findings: baselineFindings(id, engagementVulnerabilityReport(before, "", "", "").Vulnerabilities),
+	}
+	if err := saveFindingsBaseline(resultsDir, id, baseline); err != nil {
+		t.Fatalf("saveFindingsBaseline: %v", err)
+	}
+	loaded, err := loadFindingsBaseline(resultsDir, id)
+	if err != nil || loaded == nil || len(loaded.Findings) != 2 {
+		t.Fatalf("loadFindingsBaseline: %+v (%v)", loaded, err)
+	}
+
+	output := &RunOutput{
+		Metadata:       RunMetadata{EngagementID: id, StartAt: time.Now(), CompleteAt: time.Now()},
+		Results:        []checker.CheckResult{{Target: "https://app.example.com", Status: "ok"}},
+		ManualFindings: []ManualFinding{accepted, fresh},
+	}
+	output.Baseline = loaded.diff(id, engagementVulnerabilityReport(output, "", "", "").Vulnerabilities)
+	diff := output.Baseline
+	if diff.Accepted != 1 || len(diff.New) != 1 || diff.New[0].Name != fresh.Title || len(diff.Resolved) != 1 || diff.Resolved[0].Name != resolved.Title {
+		t.Fatalf("unexpected diff: %+v", diff)
+	}
+
+	data := buildTemplateData(output, nil, "%.2f", nil)
+	for _, v := range data.Vulnerabilities {
+		if v.Name == accepted.Title {
+			t.Fatal("accepted finding should be hidden from the report")
+		}
+	}
+	if data.Summary.High != 1 || data.Summary.Low != 0 {
+		t.Fatalf("summary should count deviations only, got %+v", data.Summary)
+	}
+
+	diff.IncludeAccepted = true
+	data = buildTemplateData(output, nil, "%.2f", nil)
+	if data.Summary.Low != 1 {
+		t.Fatalf("expected accepted finding with --include-baseline, got %+v", data.Summary)
+	}
+	if !strings.Contains(diff.Summary(), "1 new, 1 resolved, 1 accepted findings included") {
+		t.Fatalf("unexpected summary: %q", diff.Summary())
+	}
+}
+
+func TestLoadFindingsBaselineMissing(t *testing.T) {
+	baseline, err := loadFindingsBaseline(t.TempDir(), "eng-none")
+	if err != nil || baseline != nil {
+		t.Fatalf("expected no baseline, got %+v (%v)", baseline, err)
+	}
+}
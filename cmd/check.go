@@ -9,6 +9,8 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -16,6 +18,7 @@ import (
 	"github.com/khanhnv2901/seca-cli/internal/domain/audit"
 	"github.com/khanhnv2901/seca-cli/internal/domain/check"
 	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/session"
 	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
 	"github.com/spf13/cobra"
 )
@@ -32,12 +35,46 @@ type RunMetadata struct {
 	HashAlgorithm        string    `json:"hash_algorithm,omitempty"`
 	SignatureFingerprint string    `json:"signature_fingerprint,omitempty"`
 	TotalTargets         int       `json:"total_targets"`
+	StopReason           string    `json:"stop_reason,omitempty"` // Set when a run budget ended the run early
 	// Note: http_results.json hash is stored in http_results.json.<hash> file, not here
 }
 
 type RunOutput struct {
-	Metadata RunMetadata           `json:"metadata"`
-	Results  []checker.CheckResult `json:"results"`
+	// SchemaVersion is the results layout version; see migrateResultsDocument.
+	SchemaVersion  int                     `json:"schema_version"`
+	Metadata       RunMetadata             `json:"metadata"`
+	Results        []checker.CheckResult   `json:"results"`
+	AttackSurface  *checker.AttackSurface  `json:"attack_surface,omitempty"`
+	KeyChanges     []checker.KeyChange     `json:"key_changes,omitempty"`
+	CertDeviations []checker.CertDeviation `json:"cert_deviations,omitempty"`
+	ContentChanges []checker.ContentChange `json:"content_changes,omitempty"`
+	Screenshots    []ScreenshotRecord      `json:"screenshots,omitempty"`
+	ManualFindings []ManualFinding         `json:"manual_findings,omitempty"`
+	// Evidence lists the stored artifacts (raw captures, screenshots, HAR
+	// entries, DNS answers, manual finding files) by hash-addressed path.
+	Evidence []checker.EvidenceRef `json:"evidence,omitempty"`
+	// DomainRegistrations is RDAP data for the scoped domains.
+	DomainRegistrations []checker.DomainRegistration `json:"domain_registrations,omitempty"`
+	// Hosting maps targets to the AS and country of their addresses.
+	Hosting *HostingInventory `json:"hosting,omitempty"`
+	// NetworkPaths is the route captured to each target.
+	NetworkPaths []NetworkPathRecord `json:"network_paths,omitempty"`
+	// Baseline compares the findings with the engagement's accepted baseline.
+	Baseline *BaselineDiff `json:"baseline,omitempty"`
+	// Throttling is the rate limiting and WAF blocking seen by the latest
+	// run of each check type.
+	Throttling []ThrottledRun `json:"throttling,omitempty"`
+	// Revision is the deliverable revision the report belongs to.
+	Revision *ReportRevision `json:"revision,omitempty"`
+	// Classification is the engagement's handling label, e.g. "TLP:AMBER".
+	Classification string `json:"classification,omitempty"`
+	// Notes is the operator's narrative for the engagement.
+	Notes *EngagementNotes `json:"notes,omitempty"`
+	// TargetNames are the display names of the scope entries.
+	TargetNames TargetNames `json:"target_names,omitempty"`
+
+	// stream reads Results back from disk instead, when set; see allResults.
+	stream *resultStream
 }
 
 var checkCmd = &cobra.Command{
@@ -45,24 +82,80 @@ var checkCmd = &cobra.Command{
 	Short: "Run safe, authorized checks against scoped targets (no scanning/exploitation)",
 }
 
-func expandTargetsWithCrawl(ctx context.Context, targets []string, runtimeCfg CheckRuntimeConfig) []string {
-	crawl := runtimeCfg.Crawl
+// crawlExpandOptions configures expandTargetsWithCrawl. Zero fields are
+// left out of the crawl.
+type crawlExpandOptions struct {
+	Config CheckRuntimeConfig
+	// Scope bounds the crawl; nil keeps each crawl on its start host.
+	Scope *checker.CrawlScope
+	// SkipCrawl lists targets that are kept but not crawled.
+	SkipCrawl map[string]bool
+	Headers   http.Header
+	Proxy     *url.URL
+	Dialer    *checker.Dialer
+	Decorate  checker.RequestDecorator
+	// HAR records crawl traffic when set.
+	HAR      *checker.HARRecorder
+	Budget   *checker.Budget
+	Throttle *checker.Throttle
+	// Cache lets targets with a fresh entry skip crawling again.
+	Cache *crawlCache
+}
+
+// expandTargetsWithCrawl adds the pages discovered by crawling each target
+// as configured by opts. It also returns the form and API endpoint
+// inventory when that is enabled.
+func expandTargetsWithCrawl(ctx context.Context, targets []string, opts crawlExpandOptions) ([]string, *checker.AttackSurface) {
+	crawl := opts.Config.Crawl
 	if !crawl.Enabled || crawl.MaxDepth <= 0 || crawl.MaxPages <= 0 {
-		return targets
+		return targets, nil
+	}
+
+	var inventory *checker.Inventory
+	if crawl.Inventory {
+		inventory = checker.NewInventory()
 	}
 
 	crawlOpts := checker.CrawlOptions{
 		MaxDepth:     crawl.MaxDepth,
 		MaxPages:     crawl.MaxPages,
 		SameHostOnly: true,
-		Timeout:      time.Duration(runtimeCfg.TimeoutSecs) * time.Second,
+		Scope:        opts.Scope,
+		Timeout:      time.Duration(opts.Config.TimeoutSecs) * time.Second,
+		IgnoreRobots: crawl.IgnoreRobots,
+		UseSitemaps:  crawl.UseSitemaps,
+		Decorate:     checker.ChainDecorators(checker.HeaderDecorator(opts.Headers), opts.Decorate),
+		Proxy:        opts.Proxy,
+		Dialer:       opts.Dialer,
+		Inventory:    inventory,
+		HAR:          opts.HAR,
+		Budget:       opts.Budget,
+		Throttle:     opts.Throttle,
+	}
+
+	if crawl.IgnoreRobots {
+		cliLog().Warnw("crawl_ignoring_robots", "reason", "--crawl-ignore-robots", "note", "recorded in the audit trail")
 	}
 
 	jsCrawlOpts := checker.JSCrawlOptions{
 		CrawlOptions:     crawlOpts,
 		EnableJavaScript: crawl.EnableJS,
 		WaitTime:         time.Duration(crawl.JSWaitTime) * time.Second,
+		Headers:          opts.Headers,
+	}
+
+	crawlType := "static"
+	if crawl.EnableJS {
+		crawlType = "JavaScript-enabled"
+	} else if crawl.AutoDetectJS {
+		crawlType = "auto-detect"
 	}
+	cacheKey := crawlCacheKey(crawl, crawlType, crawlScopeAuditNote(opts.Scope), opts.Decorate != nil)
+
+	// Crawling stops with the run: on interrupt or when the budget's
+	// duration runs out, the remaining targets are kept but not crawled.
+	ctx, cancel := opts.Budget.WithDeadline(ctx)
+	defer cancel()
 
 	set := newTargetSet()
 	expanded := make([]string, 0, len(targets)+crawl.MaxPages*len(targets))
@@ -71,6 +164,21 @@ func expandTargetsWithCrawl(ctx context.Context, targets []string, runtimeCfg Ch
 		if set.Add(target) {
 			expanded = append(expanded, target)
 		}
+		if opts.SkipCrawl[target] || ctx.Err() != nil {
+			continue
+		}
+
+		if cached, ok := opts.Cache.Lookup(target, cacheKey); ok {
+			appended := 0
+			for _, url := range cached.Discovered {
+				if set.Add(url) {
+					expanded = append(expanded, url)
+					appended++
+				}
+			}
+			fmt.Printf("%s reused %d cached page(s) under %s (crawled %s ago)\n", colorInfo("→"), appended, checker.NormalizeHTTPTarget(target), time.Since(cached.CrawledAt).Round(time.Minute))
+			continue
+		}
 
 		var discovered []string
 		var err error
@@ -84,9 +192,13 @@ func expandTargetsWithCrawl(ctx context.Context, targets []string, runtimeCfg Ch
 		}
 
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: crawl failed for %s: %v\n", target, err)
+			cliLog().Warnw("crawl_failed", "target", target, "error", err)
 			continue
 		}
+		// A crawl cut short is not worth reusing.
+		if ctx.Err() == nil {
+			opts.Cache.Store(target, cacheKey, discovered)
+		}
 
 		appended := 0
 		for _, url := range discovered {
@@ -96,17 +208,42 @@ func expandTargetsWithCrawl(ctx context.Context, targets []string, runtimeCfg Ch
 			}
 		}
 		if appended > 0 {
-			crawlType := "static"
-			if crawl.EnableJS {
-				crawlType = "JavaScript-enabled"
-			} else if crawl.AutoDetectJS {
-				crawlType = "auto-detect"
-			}
 			fmt.Printf("%s discovered %d page(s) under %s [%s]\n", colorInfo("→"), appended, checker.NormalizeHTTPTarget(target), crawlType)
 		}
 	}
 
-	return expanded
+	if err := opts.Cache.Save(); err != nil {
+		cliLog().Warnw("crawl_cache_save_failed", "error", err)
+	}
+
+	if inventory == nil {
+		return expanded, nil
+	}
+	surface := inventory.Snapshot()
+	return expanded, &surface
+}
+
+// crawlAuditNote returns the note added to audit entries when the crawl
+// configuration needs to be on record, or "" when nothing is noteworthy.
+func crawlAuditNote(runtimeCfg CheckRuntimeConfig) string {
+	if runtimeCfg.Crawl.Enabled && runtimeCfg.Crawl.IgnoreRobots {
+		return "crawl ignored robots.txt (operator override)"
+	}
+	return ""
+}
+
+// withAuditNote appends each non-empty note to existing audit notes.
+func withAuditNote(notes string, more ...string) string {
+	for _, note := range more {
+		switch {
+		case note == "":
+		case notes == "":
+			notes = note
+		default:
+			notes += "; " + note
+		}
+	}
+	return notes
 }
 
 type targetSet struct {
@@ -149,10 +286,11 @@ func canonicalTarget(target string) string {
 type resultAdapter struct{}
 
 func (a *resultAdapter) toDomain(target string, checkerResult checker.CheckResult) (*check.Result, error) {
-	var status check.CheckStatus
-	if checkerResult.Status == "ok" {
-		status = check.CheckStatusOK
-	} else {
+	status := check.CheckStatus(checkerResult.Status)
+	switch status {
+	case check.CheckStatusOK, check.CheckStatusWarning, check.CheckStatusBlocked,
+		check.CheckStatusUnreachable, check.CheckStatusSkipped:
+	default:
 		status = check.CheckStatusError
 	}
 
@@ -163,6 +301,15 @@ func (a *resultAdapter) toDomain(target string, checkerResult checker.CheckResul
 
 	result.SetHTTPStatus(checkerResult.HTTPStatus)
 	result.SetResponseTime(checkerResult.ResponseTime)
+	if t := checkerResult.Timings; t != nil {
+		result.SetTimings(check.PhaseTimings{
+			DNS:       t.DNS,
+			Connect:   t.Connect,
+			TLS:       t.TLS,
+			FirstByte: t.FirstByte,
+			Analysis:  t.Analysis,
+		})
+	}
 
 	if checkerResult.TLSExpiry != "" {
 		if expiry, err := time.Parse(time.RFC3339, checkerResult.TLSExpiry); err == nil {
@@ -177,10 +324,111 @@ func (a *resultAdapter) toDomain(target string, checkerResult checker.CheckResul
 	return result, nil
 }
 
+// newEngagementHTTPChecker builds the HTTP checker used by "check http" and the
+// TUI, with custom headers, proxy, and session credentials applied.
+func newEngagementHTTPChecker(resultsDir, engagementID string, runtimeCfg CheckRuntimeConfig, headers http.Header, proxy *url.URL, sess *session.Session, har *checker.HARRecorder) *checker.HTTPChecker {
+	return &checker.HTTPChecker{
+		Timeout:    time.Duration(runtimeCfg.TimeoutSecs) * time.Second,
+		CaptureRaw: runtimeCfg.AuditAppendRaw,
+		RawHandler: func(target string, headers http.Header, bodySnippet string) error {
+			// Session credentials must never reach raw captures.
+			return SaveRawCapture(resultsDir, engagementID, target, sess.RedactHeaders(headers), sess.RedactString(bodySnippet))
+		},
+		Decorate: checker.ChainDecorators(checker.HeaderDecorator(headers), sessionDecorator(sess)),
+		Proxy:    proxy,
+		HAR:      har,
+	}
+}
+
+// httpAuditFunc records each HTTP result in the audit trail and the check run,
+// with runNote added to the notes of every entry, then calls onRecorded
+// (which may be nil).
+func httpAuditFunc(ctx context.Context, appCtx *AppContext, engagementID string, checkRun *check.CheckRun, sess *session.Session, proxy *url.URL, runNote string, onRecorded func(target string, result checker.CheckResult, duration float64)) checker.AuditFunc {
+	adapter := &resultAdapter{}
+
+	return func(target string, checkerResult checker.CheckResult, duration float64) error {
+		entry := &audit.Entry{
+			Timestamp:       time.Now(),
+			EngagementID:    engagementID,
+			Operator:        appCtx.Operator,
+			Command:         "check http",
+			Target:          target,
+			Status:          checkerResult.Status,
+			HTTPStatus:      checkerResult.HTTPStatus,
+			Notes:           withAuditNote(checkerResult.Notes, runNote, sessionAuditNote(sess), proxyAuditNote(proxy)),
+			Error:           checkerResult.Error,
+			DurationSeconds: duration,
+		}
+
+		if checkerResult.TLSExpiry != "" {
+			if expiry, err := time.Parse(time.RFC3339, checkerResult.TLSExpiry); err == nil {
+				entry.TLSExpiry = expiry
+			}
+		}
+
+		if err := appCtx.Services.CheckOrchestrator.RecordAuditEntry(ctx, entry); err != nil {
+			return fmt.Errorf("failed to record audit: %w", err)
+		}
+		cliLog().Debugw("target_checked", "engagement_id", engagementID, "target", target, "status", checkerResult.Status,
+			"http_status", checkerResult.HTTPStatus, "duration_seconds", duration, "error", checkerResult.Error)
+
+		domainResult, err := adapter.toDomain(target, checkerResult)
+		if err != nil {
+			return fmt.Errorf("failed to convert result: %w", err)
+		}
+
+		if err := appCtx.Services.CheckOrchestrator.AddCheckResult(ctx, checkRun, domainResult); err != nil {
+			return fmt.Errorf("failed to add result: %w", err)
+		}
+
+		if onRecorded != nil {
+			onRecorded(target, checkerResult, duration)
+		}
+
+		return nil
+	}
+}
+
+// sealCheckRun seals the engagement's audit trail and finalizes checkRun with
+// the hash. It returns the algorithm used (sha256 when hashAlgo is empty) and
+// the hash.
+func sealCheckRun(ctx context.Context, appCtx *AppContext, engagementID string, checkRun *check.CheckRun, hashAlgo string) (string, string, error) {
+	if hashAlgo == "" {
+		hashAlgo = "sha256"
+	}
+
+	auditHash, err := appCtx.Services.CheckOrchestrator.SealAuditTrail(ctx, engagementID, hashAlgo)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to seal audit trail: %w", err)
+	}
+
+	if err := appCtx.Services.CheckOrchestrator.FinalizeCheckRun(ctx, checkRun, auditHash, hashAlgo); err != nil {
+		return "", "", fmt.Errorf("failed to finalize check run: %w", err)
+	}
+	cliLog().Debugw("check_run_sealed", "engagement_id", engagementID, "hash_algorithm", hashAlgo, "audit_hash", auditHash)
+
+	return hashAlgo, auditHash, nil
+}
+
+// flushAuditEntries writes audit entries still buffered for the engagement,
+// so a run that ends early leaves a complete audit.csv before its lock is
+// released. A failed write becomes the run's error, unless it already has
+// one, so a run whose audit trail is incomplete never exits successfully.
+func flushAuditEntries(appCtx *AppContext, engagementID string, runErr *error) {
+	err := appCtx.Services.AuditRepo.Flush(context.Background(), engagementID)
+	if err == nil {
+		return
+	}
+	cliLog().Warnw("audit_flush_failed", "engagement_id", engagementID, "error", err)
+	if *runErr == nil {
+		*runErr = fmt.Errorf("failed to write audit trail: %w", err)
+	}
+}
+
 var checkHTTPCmd = &cobra.Command{
 	Use:   "http",
 	Short: "Run safe HTTP/TLS checks for an engagement's scope",
-	RunE: func(cmd *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
@@ -212,6 +460,10 @@ var checkHTTPCmd = &cobra.Command{
 			return errors.New("must pass --roe-confirm to run checks")
 		}
 
+		if err := validateCheckConfig(engagementID, runtimeCfg, "http"); err != nil {
+			return err
+		}
+
 		eng, err := appCtx.Services.EngagementService.GetEngagement(ctx, engagementID)
 		if err != nil {
 			if errors.Is(err, sharedErrors.ErrEngagementNotFound) {
@@ -220,112 +472,181 @@ var checkHTTPCmd = &cobra.Command{
 			return fmt.Errorf("failed to get engagement: %w", err)
 		}
 
+		if err := checkWritable(eng); err != nil {
+			return err
+		}
+
 		if err := appCtx.Services.EngagementService.ValidateEngagementForChecks(ctx, engagementID, ""); err != nil {
 			return fmt.Errorf("engagement validation failed: %w", err)
 		}
 
+		lock, err := acquireEngagementLock(ctx, appCtx.ResultsDir, engagementID, "check http", appCtx.Operator, time.Duration(runtimeCfg.LockWaitSecs)*time.Second)
+		if err != nil {
+			return err
+		}
+		defer lock.Release()
+		defer flushAuditEntries(appCtx, engagementID, &err)
+
 		checkRun, err := appCtx.Services.CheckOrchestrator.CreateCheckRun(ctx, engagementID, appCtx.Operator)
 		if err != nil {
 			return fmt.Errorf("failed to create check run: %w", err)
 		}
+		checkRun.SetCheckType(check.CheckTypeHTTP)
+		enableRunSnapshots(appCtx, checkRun, runtimeCfg)
 
 		fmt.Printf("%s Starting HTTP checks for engagement: %s\n", colorInfo("→"), eng.Name())
 		fmt.Printf("%s Targets: %d\n", colorInfo("→"), len(eng.Scope()))
-		fmt.Println()
 
-		httpChecker := &checker.HTTPChecker{
-			Timeout:    time.Duration(runtimeCfg.TimeoutSecs) * time.Second,
-			CaptureRaw: runtimeCfg.AuditAppendRaw,
-			RawHandler: func(target string, headers http.Header, bodySnippet string) error {
-				return SaveRawCapture(appCtx.ResultsDir, engagementID, target, headers, bodySnippet)
-			},
+		headers, err := resolveRequestHeaders(engagementID, runtimeCfg.Request)
+		if err != nil {
+			return err
+		}
+		proxy, err := resolveProxy(engagementID, runtimeCfg.Request)
+		if err != nil {
+			return err
+		}
+		printProxy(proxy)
+		dialer, err := resolveDialer(engagementID, runtimeCfg)
+		if err != nil {
+			return err
+		}
+		printResolution(dialer)
+		phases, err := resolvePhaseTimeouts(runtimeCfg)
+		if err != nil {
+			return err
 		}
+		printPhaseTimeouts(runtimeCfg, phases)
+		budget, storedBudget, err := openRunBudget(appCtx.ResultsDir, engagementID)
+		if err != nil {
+			return err
+		}
+		printRunBudget(storedBudget)
+		headerPolicy, err := loadHeaderPolicy(appCtx.ResultsDir, engagementID)
+		if err != nil {
+			return err
+		}
+		printHeaderPolicy(headerPolicy)
+		favicons, err := loadFaviconDB()
+		if err != nil {
+			return err
+		}
+		var crawlScope *checker.CrawlScope
+		if runtimeCfg.Crawl.Enabled {
+			crawlScope, err = resolveCrawlScope(runtimeCfg.Crawl, eng.Scope())
+			if err != nil {
+				return err
+			}
+		}
+		overrides := newScopeOverrides(eng.Scope(), eng.ScopeOverrides())
+		printScopeOverrides(overrides)
+		loginTransport := checker.WithDecorator(dialer.Apply(checker.NewTransport(proxy)), checker.HeaderDecorator(headers))
+		sess, err := establishEngagementSession(ctx, appCtx.ResultsDir, engagementID, eng.Scope(), time.Duration(runtimeCfg.TimeoutSecs)*time.Second, loginTransport)
+		if err != nil {
+			return err
+		}
+		sess, err = routeScopeSessions(ctx, appCtx.ResultsDir, engagementID, eng.Scope(), time.Duration(runtimeCfg.TimeoutSecs)*time.Second, loginTransport, sess, overrides)
+		if err != nil {
+			return err
+		}
+		fmt.Println()
 
+		har := newHARRecorder(runtimeCfg, sess, headers)
+		httpChecker := newEngagementHTTPChecker(appCtx.ResultsDir, engagementID, runtimeCfg, headers, proxy, sess, har)
+		throttle := newRunThrottle(ctx, appCtx, engagementID, "check http", runtimeCfg)
+		httpChecker.Dialer = dialer
+		httpChecker.Phases = phases
+		httpChecker.Budget = budget
+		httpChecker.Throttle = throttle
+		httpChecker.HeaderPolicy = headerPolicy
+		httpChecker.Favicons = favicons
 		runner := &checker.Runner{
 			Concurrency: runtimeCfg.Concurrency,
 			RateLimit:   runtimeCfg.RateLimit,
-			Timeout:     time.Duration(runtimeCfg.TimeoutSecs) * time.Second,
+			Timeout:     httpCheckTimeout(runtimeCfg, phases),
+			Budget:      budget,
+			Throttle:    throttle,
+			Overrides:   overrides.Lookup(),
+			Phases:      phases,
+			Health:      newHealthCheck(runtimeCfg, phases, dialer, proxy),
 		}
 
+		crawlCache, err := openCrawlCache(appCtx.ResultsDir, engagementID, runtimeCfg.Crawl)
+		if err != nil {
+			return err
+		}
+		targets, surface := expandTargetsWithCrawl(ctx, append([]string(nil), eng.Scope()...), crawlExpandOptions{
+			Config:    runtimeCfg,
+			Scope:     crawlScope,
+			SkipCrawl: overrides.SkipCrawl(),
+			Headers:   headers,
+			Proxy:     proxy,
+			Dialer:    dialer,
+			Decorate:  sessionDecorator(sess),
+			HAR:       har,
+			Budget:    budget,
+			Throttle:  throttle,
+			Cache:     crawlCache,
+		})
+		recordAttackSurface(appCtx.ResultsDir, engagementID, surface)
+
 		var progress *progressPrinter
 		if runtimeCfg.ProgressEnabled {
-			progress = newProgressPrinter(len(eng.Scope()), httpChecker.Name())
+			progress = newProgressPrinter(len(targets), httpChecker.Name())
 			progress.Start()
 		}
 
-		adapter := &resultAdapter{}
-
-		auditFn := func(target string, checkerResult checker.CheckResult, duration float64) error {
-			entry := &audit.Entry{
-				Timestamp:       time.Now(),
-				EngagementID:    engagementID,
-				Operator:        appCtx.Operator,
-				Command:         "check http",
-				Target:          target,
-				Status:          checkerResult.Status,
-				HTTPStatus:      checkerResult.HTTPStatus,
-				Notes:           checkerResult.Notes,
-				Error:           checkerResult.Error,
-				DurationSeconds: duration,
-			}
-
-			if checkerResult.TLSExpiry != "" {
-				if expiry, err := time.Parse(time.RFC3339, checkerResult.TLSExpiry); err == nil {
-					entry.TLSExpiry = expiry
-				}
-			}
-
-			if err := appCtx.Services.CheckOrchestrator.RecordAuditEntry(ctx, entry); err != nil {
-				return fmt.Errorf("failed to record audit: %w", err)
-			}
-
-			domainResult, err := adapter.toDomain(target, checkerResult)
-			if err != nil {
-				return fmt.Errorf("failed to convert result: %w", err)
-			}
-
-			if err := appCtx.Services.CheckOrchestrator.AddCheckResult(ctx, checkRun, domainResult); err != nil {
-				return fmt.Errorf("failed to add result: %w", err)
-			}
-
+		runNote := withAuditNote(crawlAuditNote(runtimeCfg), crawlScopeAuditNote(crawlScope))
+		auditFn := httpAuditFunc(ctx, appCtx, engagementID, checkRun, sess, proxy, runNote, func(_ string, result checker.CheckResult, duration float64) {
 			if progress != nil {
-				progress.Increment(checkerResult.Status == "ok", duration)
+				progress.Increment(result.Succeeded(), duration)
 			}
+		})
 
-			return nil
-		}
-
-		results := runner.RunChecks(ctx, eng.Scope(), httpChecker, auditFn)
+		results := runner.RunChecks(ctx, targets, httpChecker, auditFn)
 
 		if progress != nil {
 			progress.Stop()
 		}
 
+		if runtimeCfg.Screenshots {
+			captureScreenshots(ctx, appCtx.ResultsDir, engagementID, eng.Scope(), results, runtimeCfg, headers, proxy)
+		}
+		recordRunEvidence(appCtx.ResultsDir, engagementID, results, har)
+		recordThrottling(appCtx.ResultsDir, engagementID, httpChecker.Name(), throttle, startTime)
+		trackKeyContinuity(appCtx.ResultsDir, engagementID, results)
+		trackExpectedCertificates(appCtx.ResultsDir, engagementID, results, overrides.ExpectedIssuers())
+		trackContentChanges(appCtx.ResultsDir, engagementID, results)
+
 		runDuration := time.Since(startTime)
 		if runtimeCfg.TelemetryEnabled {
 			if err := recordTelemetry(appCtx, engagementID, httpChecker.Name(), results, runDuration); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to record telemetry: %v\n", err)
+				cliLog().Warnw("telemetry_record_failed", "engagement_id", engagementID, "error", err)
+			} else {
+				notifyTelemetryRegressions(appCtx, engagementID, httpChecker.Name())
 			}
 		}
+		trackBaselineDeviations(appCtx.ResultsDir, engagementID, results)
+		exportTelemetry(cmd, engagementID, httpChecker.Name(), results, startTime, runDuration)
 
 		fmt.Printf("\n%s Check run complete\n", colorSuccess("✓"))
-		fmt.Printf("%s Checked: %d targets\n", colorInfo("→"), len(results))
-
-		hashAlgo := runtimeCfg.HashAlgorithm
-		if hashAlgo == "" {
-			hashAlgo = "sha256"
+		fmt.Printf("%s Checked: %d targets\n", colorInfo("→"), countStatuses(results).Checked())
+		printStatusBreakdown(results)
+		printHeaderConsistency(checkHeaderConsistency(slices.Values(results)))
+		printLoginForms(assessLoginForms(surface, slices.Values(results)))
+		stopReason := finishBudgetedRun(budget, engagementID, countStatuses(results).Checked(), len(targets))
+		if stopReason != "" {
+			checkRun.SetStopReason(stopReason)
+		} else if ctx.Err() != nil {
+			stopReason = "interrupted"
 		}
 
-		auditHash, err := appCtx.Services.CheckOrchestrator.SealAuditTrail(ctx, engagementID, hashAlgo)
+		hashAlgo, auditHash, err := sealCheckRun(ctx, appCtx, engagementID, checkRun, runtimeCfg.HashAlgorithm)
 		if err != nil {
-			return fmt.Errorf("failed to seal audit trail: %w", err)
-		}
-
-		if err := appCtx.Services.CheckOrchestrator.FinalizeCheckRun(ctx, checkRun, auditHash, hashAlgo); err != nil {
-			return fmt.Errorf("failed to finalize check run: %w", err)
+			return err
 		}
+		recordHAR(appCtx.ResultsDir, engagementID, check.CheckTypeHTTP, checkRun.ID(), har)
 
-		resultsPath := filepath.Join(appCtx.ResultsDir, engagementID, "http_results.json")
+		resultsPath := filepath.Join(appCtx.ResultsDir, engagementID, check.ResultsFilename(checkRun.Metadata().CheckType))
 		auditPath := filepath.Join(appCtx.ResultsDir, engagementID, "audit.csv")
 
 		fmt.Println()
@@ -333,14 +654,23 @@ var checkHTTPCmd = &cobra.Command{
 		fmt.Printf("%s Audit: %s\n", colorSuccess("→"), auditPath)
 		fmt.Printf("%s Audit hash (%s): %s\n", colorSuccess("→"), hashAlgo, auditHash)
 
-		return nil
+		return finishCheckRun(cmd, appCtx.ResultsDir, runOutcome{
+			EngagementID:  engagementID,
+			Check:         httpChecker.Name(),
+			Results:       results,
+			StartedAt:     startTime,
+			StopReason:    stopReason,
+			HashAlgorithm: hashAlgo,
+			AuditHash:     auditHash,
+			ResultsFile:   resultsPath,
+		})
 	},
 }
 
 var checkDNSCmd = &cobra.Command{
 	Use:   "dns",
 	Short: "Run DNS checks for an engagement's scope",
-	RunE: func(cmd *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
@@ -372,6 +702,10 @@ var checkDNSCmd = &cobra.Command{
 			return errors.New("must pass --roe-confirm to run checks")
 		}
 
+		if err := validateCheckConfig(engagementID, runtimeCfg, "dns"); err != nil {
+			return err
+		}
+
 		eng, err := appCtx.Services.EngagementService.GetEngagement(ctx, engagementID)
 		if err != nil {
 			if errors.Is(err, sharedErrors.ErrEngagementNotFound) {
@@ -380,28 +714,80 @@ var checkDNSCmd = &cobra.Command{
 			return fmt.Errorf("failed to get engagement: %w", err)
 		}
 
+		if err := checkWritable(eng); err != nil {
+			return err
+		}
+
 		if err := appCtx.Services.EngagementService.ValidateEngagementForChecks(ctx, engagementID, ""); err != nil {
 			return fmt.Errorf("engagement validation failed: %w", err)
 		}
 
+		lock, err := acquireEngagementLock(ctx, appCtx.ResultsDir, engagementID, "check dns", appCtx.Operator, time.Duration(runtimeCfg.LockWaitSecs)*time.Second)
+		if err != nil {
+			return err
+		}
+		defer lock.Release()
+		defer flushAuditEntries(appCtx, engagementID, &err)
+
 		checkRun, err := appCtx.Services.CheckOrchestrator.CreateCheckRun(ctx, engagementID, appCtx.Operator)
 		if err != nil {
 			return fmt.Errorf("failed to create check run: %w", err)
 		}
+		checkRun.SetCheckType(check.CheckTypeDNS)
+		enableRunSnapshots(appCtx, checkRun, runtimeCfg)
 
 		fmt.Printf("%s Starting DNS checks for engagement: %s\n", colorInfo("→"), eng.Name())
 		fmt.Printf("%s Targets: %d\n", colorInfo("→"), len(eng.Scope()))
+		budget, storedBudget, err := openRunBudget(appCtx.ResultsDir, engagementID)
+		if err != nil {
+			return err
+		}
+		printRunBudget(storedBudget)
 		fmt.Println()
 
+		nameservers, err := resolveNameservers(engagementID, runtimeCfg)
+		if err != nil {
+			return err
+		}
+		dnsTimeout := time.Duration(runtimeCfg.DNS.Timeout) * time.Second
 		dnsChecker := &checker.DNSChecker{
-			Timeout:    time.Duration(runtimeCfg.DNS.Timeout) * time.Second,
-			NameServer: runtimeCfg.DNS.Nameservers,
+			Timeout:    dnsTimeout,
+			NameServer: nameservers,
+		}
+		allowedCountries, err := normalizeCountryCodes(runtimeCfg.DNS.AllowedCountries)
+		if err != nil {
+			return err
+		}
+		if runtimeCfg.DNS.GeoIPDatabase != "" {
+			db, err := checker.LoadGeoIPDatabase(runtimeCfg.DNS.GeoIPDatabase)
+			if err != nil {
+				return fmt.Errorf("load GeoIP dataset: %w", err)
+			}
+			dnsChecker.GeoIP = db
+		} else if len(allowedCountries) > 0 {
+			return errors.New("--allowed-countries requires --geoip-db")
+		}
+		rdap := &checker.RDAPClient{Timeout: dnsTimeout}
+		if runtimeCfg.DNS.RDAP {
+			recordDomainRegistrations(ctx, appCtx.ResultsDir, eng, rdap)
+		}
+		checkTimeout := dnsTimeout
+		if runtimeCfg.DNS.DomainHygiene {
+			dnsChecker.Hygiene = &checker.DomainHygieneChecker{
+				Timeout:       dnsTimeout,
+				Resolver:      dnsChecker.Resolver(),
+				ExpiryWarning: time.Duration(runtimeCfg.DNS.ExpiryWarningDays) * 24 * time.Hour,
+				RDAP:          rdap,
+			}
+			// Parent, nameserver and RDAP queries run after the record lookups.
+			checkTimeout *= 3
 		}
 
 		runner := &checker.Runner{
 			Concurrency: runtimeCfg.Concurrency,
 			RateLimit:   runtimeCfg.RateLimit,
-			Timeout:     time.Duration(runtimeCfg.DNS.Timeout) * time.Second,
+			Timeout:     checkTimeout,
+			Budget:      budget,
 		}
 
 		var progress *progressPrinter
@@ -439,7 +825,7 @@ var checkDNSCmd = &cobra.Command{
 			}
 
 			if progress != nil {
-				progress.Increment(checkerResult.Status == "ok", duration)
+				progress.Increment(checkerResult.Succeeded(), duration)
 			}
 
 			return nil
@@ -450,42 +836,43 @@ var checkDNSCmd = &cobra.Command{
 		if progress != nil {
 			progress.Stop()
 		}
+		recordRunEvidence(appCtx.ResultsDir, engagementID, results, nil)
 
 		runDuration := time.Since(startTime)
 		if runtimeCfg.TelemetryEnabled {
 			if err := recordTelemetry(appCtx, engagementID, dnsChecker.Name(), results, runDuration); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to record telemetry: %v\n", err)
-			}
-		}
-
-		okCount := 0
-		errorCount := 0
-		for _, r := range results {
-			if r.Status == "ok" {
-				okCount++
+				cliLog().Warnw("telemetry_record_failed", "engagement_id", engagementID, "error", err)
 			} else {
-				errorCount++
+				notifyTelemetryRegressions(appCtx, engagementID, dnsChecker.Name())
 			}
 		}
+		trackBaselineDeviations(appCtx.ResultsDir, engagementID, results)
+		exportTelemetry(cmd, engagementID, dnsChecker.Name(), results, startTime, runDuration)
 
-		fmt.Printf("\n%s DNS checks complete\n", colorSuccess("✓"))
-		fmt.Printf("%s Success: %d | Errors: %d\n", colorInfo("→"), okCount, errorCount)
+		counts := countStatuses(results)
 
-		hashAlgo := runtimeCfg.HashAlgorithm
-		if hashAlgo == "" {
-			hashAlgo = "sha256"
+		fmt.Printf("\n%s DNS checks complete\n", colorSuccess("✓"))
+		fmt.Printf("%s Success: %d | Errors: %d\n", colorInfo("→"), counts.Succeeded(), counts.Failed())
+		printStatusBreakdown(results)
+		if dnsChecker.Hygiene != nil {
+			printDomainHygiene(results)
 		}
-
-		auditHash, err := appCtx.Services.CheckOrchestrator.SealAuditTrail(ctx, engagementID, hashAlgo)
-		if err != nil {
-			return fmt.Errorf("failed to seal audit trail: %w", err)
+		if dnsChecker.GeoIP != nil {
+			recordHosting(appCtx.ResultsDir, engagementID, dnsChecker.GeoIP, allowedCountries, results)
+		}
+		stopReason := finishBudgetedRun(budget, engagementID, countStatuses(results).Checked(), len(eng.Scope()))
+		if stopReason != "" {
+			checkRun.SetStopReason(stopReason)
+		} else if ctx.Err() != nil {
+			stopReason = "interrupted"
 		}
 
-		if err := appCtx.Services.CheckOrchestrator.FinalizeCheckRun(ctx, checkRun, auditHash, hashAlgo); err != nil {
-			return fmt.Errorf("failed to finalize check run: %w", err)
+		hashAlgo, auditHash, err := sealCheckRun(ctx, appCtx, engagementID, checkRun, runtimeCfg.HashAlgorithm)
+		if err != nil {
+			return err
 		}
 
-		resultsPath := filepath.Join(appCtx.ResultsDir, engagementID, "http_results.json")
+		resultsPath := filepath.Join(appCtx.ResultsDir, engagementID, check.ResultsFilename(checkRun.Metadata().CheckType))
 		auditPath := filepath.Join(appCtx.ResultsDir, engagementID, "audit.csv")
 
 		fmt.Println()
@@ -493,14 +880,23 @@ var checkDNSCmd = &cobra.Command{
 		fmt.Printf("%s Audit: %s\n", colorSuccess("→"), auditPath)
 		fmt.Printf("%s Audit hash (%s): %s\n", colorSuccess("→"), hashAlgo, auditHash)
 
-		return nil
+		return finishCheckRun(cmd, appCtx.ResultsDir, runOutcome{
+			EngagementID:  engagementID,
+			Check:         dnsChecker.Name(),
+			Results:       results,
+			StartedAt:     startTime,
+			StopReason:    stopReason,
+			HashAlgorithm: hashAlgo,
+			AuditHash:     auditHash,
+			ResultsFile:   resultsPath,
+		})
 	},
 }
 
 var checkNetworkCmd = &cobra.Command{
 	Use:   "network",
 	Short: "Run network exposure and takeover checks for an engagement's scope",
-	RunE: func(cmd *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
@@ -532,6 +928,10 @@ var checkNetworkCmd = &cobra.Command{
 			return errors.New("must pass --roe-confirm to run checks")
 		}
 
+		if err := validateCheckConfig(engagementID, runtimeCfg, "network"); err != nil {
+			return err
+		}
+
 		eng, err := appCtx.Services.EngagementService.GetEngagement(ctx, engagementID)
 		if err != nil {
 			if errors.Is(err, sharedErrors.ErrEngagementNotFound) {
@@ -540,19 +940,87 @@ var checkNetworkCmd = &cobra.Command{
 			return fmt.Errorf("failed to get engagement: %w", err)
 		}
 
+		if err := checkWritable(eng); err != nil {
+			return err
+		}
+
 		if err := appCtx.Services.EngagementService.ValidateEngagementForChecks(ctx, engagementID, ""); err != nil {
 			return fmt.Errorf("engagement validation failed: %w", err)
 		}
 
+		pacing, storedPacing, err := openScanPacing(appCtx.ResultsDir, engagementID)
+		if err != nil {
+			return err
+		}
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			printNetworkDryRun(eng.Name(), eng.Scope(), runtimeCfg, pacing, storedPacing)
+			return nil
+		}
+
+		lock, err := acquireEngagementLock(ctx, appCtx.ResultsDir, engagementID, "check network", appCtx.Operator, time.Duration(runtimeCfg.LockWaitSecs)*time.Second)
+		if err != nil {
+			return err
+		}
+		defer lock.Release()
+		defer flushAuditEntries(appCtx, engagementID, &err)
+
 		checkRun, err := appCtx.Services.CheckOrchestrator.CreateCheckRun(ctx, engagementID, appCtx.Operator)
 		if err != nil {
 			return fmt.Errorf("failed to create check run: %w", err)
 		}
+		checkRun.SetCheckType(check.CheckTypeNetwork)
+		enableRunSnapshots(appCtx, checkRun, runtimeCfg)
 
 		fmt.Printf("%s Starting network checks for engagement: %s\n", colorInfo("→"), eng.Name())
 		fmt.Printf("%s Initial targets: %d\n", colorInfo("→"), len(eng.Scope()))
+
+		headers, err := resolveRequestHeaders(engagementID, runtimeCfg.Request)
+		if err != nil {
+			return err
+		}
+		proxy, err := resolveProxy(engagementID, runtimeCfg.Request)
+		if err != nil {
+			return err
+		}
+		printProxy(proxy)
+		dialer, err := resolveDialer(engagementID, runtimeCfg)
+		if err != nil {
+			return err
+		}
+		printResolution(dialer)
+		budget, storedBudget, err := openRunBudget(appCtx.ResultsDir, engagementID)
+		if err != nil {
+			return err
+		}
+		printRunBudget(storedBudget)
+		if runtimeCfg.Network.EnablePortScan && storedPacing != nil && !storedPacing.IsZero() {
+			fmt.Printf("%s Scan pacing: %s\n", colorInfo("→"), storedPacing.Summary())
+		}
+		var crawlScope *checker.CrawlScope
+		if runtimeCfg.Crawl.Enabled {
+			crawlScope, err = resolveCrawlScope(runtimeCfg.Crawl, eng.Scope())
+			if err != nil {
+				return err
+			}
+		}
+		overrides := newScopeOverrides(eng.Scope(), eng.ScopeOverrides())
+		printScopeOverrides(overrides)
+		var sess *session.Session
+		if runtimeCfg.Crawl.Enabled {
+			loginTransport := checker.WithDecorator(dialer.Apply(checker.NewTransport(proxy)), checker.HeaderDecorator(headers))
+			sess, err = establishEngagementSession(ctx, appCtx.ResultsDir, engagementID, eng.Scope(), time.Duration(runtimeCfg.TimeoutSecs)*time.Second, loginTransport)
+			if err != nil {
+				return err
+			}
+			sess, err = routeScopeSessions(ctx, appCtx.ResultsDir, engagementID, eng.Scope(), time.Duration(runtimeCfg.TimeoutSecs)*time.Second, loginTransport, sess, overrides)
+			if err != nil {
+				return err
+			}
+		}
 		fmt.Println()
 
+		har := newHARRecorder(runtimeCfg, sess, headers)
+		throttle := newRunThrottle(ctx, appCtx, engagementID, "check network", runtimeCfg)
 		netCfg := runtimeCfg.Network
 		var ports []int
 		if len(netCfg.Ports) > 0 {
@@ -565,16 +1033,64 @@ var checkNetworkCmd = &cobra.Command{
 			EnablePortScan:  netCfg.EnablePortScan,
 			CommonPorts:     ports,
 			MaxPortWorkers:  netCfg.MaxPortWorkers,
+			Decorate:        checker.HeaderDecorator(headers),
+			Proxy:           proxy,
+			HAR:             har,
+			Budget:          budget,
+			Throttle:        throttle,
+			Pacing:          pacing,
+		}
+		if netCfg.ExposureChecks || netCfg.AdminPanels {
+			// One probe paces and caps both request sets per host.
+			probe := checker.NewExposureProbe()
+			if netCfg.ExposureChecks {
+				networkChecker.Exposure = probe
+			}
+			if netCfg.AdminPanels {
+				networkChecker.AdminPanels = probe
+			}
+		}
+
+		checkTimeout := time.Duration(runtimeCfg.TimeoutSecs) * time.Second
+		if netCfg.Traceroute {
+			tracer, err := newTracer(netCfg)
+			if err != nil {
+				return err
+			}
+			networkChecker.Traceroute = tracer
+			// Silent hops each wait out the probe timeout.
+			checkTimeout += time.Duration(tracer.MaxHops) * tracer.Timeout
 		}
 
 		runner := &checker.Runner{
 			Concurrency: runtimeCfg.Concurrency,
 			RateLimit:   runtimeCfg.RateLimit,
-			Timeout:     time.Duration(runtimeCfg.TimeoutSecs) * time.Second,
+			Timeout:     checkTimeout,
+			Budget:      budget,
+			Throttle:    throttle,
+			Overrides:   overrides.Lookup(),
+		}
+
+		crawlCache, err := openCrawlCache(appCtx.ResultsDir, engagementID, runtimeCfg.Crawl)
+		if err != nil {
+			return err
 		}
 
 		baseTargets := append([]string(nil), eng.Scope()...)
-		targets := expandTargetsWithCrawl(ctx, baseTargets, runtimeCfg)
+		targets, surface := expandTargetsWithCrawl(ctx, baseTargets, crawlExpandOptions{
+			Config:    runtimeCfg,
+			Scope:     crawlScope,
+			SkipCrawl: overrides.SkipCrawl(),
+			Headers:   headers,
+			Proxy:     proxy,
+			Dialer:    dialer,
+			Decorate:  sessionDecorator(sess),
+			HAR:       har,
+			Budget:    budget,
+			Throttle:  throttle,
+			Cache:     crawlCache,
+		})
+		recordAttackSurface(appCtx.ResultsDir, engagementID, surface)
 
 		var progress *progressPrinter
 		if runtimeCfg.ProgressEnabled {
@@ -593,7 +1109,7 @@ var checkNetworkCmd = &cobra.Command{
 				Target:          target,
 				Status:          checkerResult.Status,
 				HTTPStatus:      checkerResult.HTTPStatus,
-				Notes:           checkerResult.Notes,
+				Notes:           withAuditNote(checkerResult.Notes, crawlAuditNote(runtimeCfg), crawlScopeAuditNote(crawlScope), sessionAuditNote(sess), proxyAuditNote(proxy)),
 				Error:           checkerResult.Error,
 				DurationSeconds: duration,
 			}
@@ -618,7 +1134,7 @@ var checkNetworkCmd = &cobra.Command{
 			}
 
 			if progress != nil {
-				progress.Increment(checkerResult.Status == "ok", duration)
+				progress.Increment(checkerResult.Succeeded(), duration)
 			}
 
 			return nil
@@ -630,21 +1146,37 @@ var checkNetworkCmd = &cobra.Command{
 			progress.Stop()
 		}
 
+		if runtimeCfg.Screenshots {
+			captureScreenshots(ctx, appCtx.ResultsDir, engagementID, eng.Scope(), results, runtimeCfg, headers, proxy)
+		}
+		recordRunEvidence(appCtx.ResultsDir, engagementID, results, har)
+		recordThrottling(appCtx.ResultsDir, engagementID, networkChecker.Name(), throttle, startTime)
+
 		runDuration := time.Since(startTime)
 		if runtimeCfg.TelemetryEnabled {
 			if err := recordTelemetry(appCtx, engagementID, networkChecker.Name(), results, runDuration); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to record telemetry: %v\n", err)
+				cliLog().Warnw("telemetry_record_failed", "engagement_id", engagementID, "error", err)
+			} else {
+				notifyTelemetryRegressions(appCtx, engagementID, networkChecker.Name())
 			}
 		}
+		trackBaselineDeviations(appCtx.ResultsDir, engagementID, results)
+		exportTelemetry(cmd, engagementID, networkChecker.Name(), results, startTime, runDuration)
 
 		issues := 0
 		takeovers := 0
 		totalPorts := 0
+		exposures := 0
+		adminPanels := 0
+		serviceCVEs := 0
 		for _, r := range results {
 			if r.NetworkSecurity == nil {
 				continue
 			}
 			totalPorts += len(r.NetworkSecurity.OpenPorts)
+			exposures += len(r.NetworkSecurity.Exposures)
+			adminPanels += len(r.NetworkSecurity.AdminPanels)
+			serviceCVEs += len(r.NetworkSecurity.ServiceCVEs)
 			if len(r.NetworkSecurity.Issues) > 0 {
 				issues++
 			}
@@ -654,24 +1186,36 @@ var checkNetworkCmd = &cobra.Command{
 		}
 
 		fmt.Printf("\n%s Network checks complete\n", colorSuccess("✓"))
-		fmt.Printf("%s Processed: %d target(s)\n", colorInfo("→"), len(results))
+		fmt.Printf("%s Processed: %d target(s)\n", colorInfo("→"), countStatuses(results).Checked())
+		printStatusBreakdown(results)
 		fmt.Printf("%s Issues: %d | Takeover indicators: %d | Open ports: %d\n", colorInfo("→"), issues, takeovers, totalPorts)
-
-		hashAlgo := runtimeCfg.HashAlgorithm
-		if hashAlgo == "" {
-			hashAlgo = "sha256"
+		if networkChecker.EnablePortScan {
+			fmt.Printf("%s Known CVEs from service banners: %d (snapshot %s)\n", colorInfo("→"), serviceCVEs, checker.ServiceCVESnapshotDate())
 		}
-
-		auditHash, err := appCtx.Services.CheckOrchestrator.SealAuditTrail(ctx, engagementID, hashAlgo)
-		if err != nil {
-			return fmt.Errorf("failed to seal audit trail: %w", err)
+		if networkChecker.Exposure != nil {
+			fmt.Printf("%s Exposed listings/files: %d\n", colorInfo("→"), exposures)
+		}
+		if networkChecker.AdminPanels != nil {
+			fmt.Printf("%s Exposed admin interfaces: %d\n", colorInfo("→"), adminPanels)
+		}
+		printLoginForms(assessLoginForms(surface, slices.Values(results)))
+		if networkChecker.Traceroute != nil {
+			recordNetworkPaths(appCtx.ResultsDir, engagementID, results)
+		}
+		stopReason := finishBudgetedRun(budget, engagementID, countStatuses(results).Checked(), len(targets))
+		if stopReason != "" {
+			checkRun.SetStopReason(stopReason)
+		} else if ctx.Err() != nil {
+			stopReason = "interrupted"
 		}
 
-		if err := appCtx.Services.CheckOrchestrator.FinalizeCheckRun(ctx, checkRun, auditHash, hashAlgo); err != nil {
-			return fmt.Errorf("failed to finalize check run: %w", err)
+		hashAlgo, auditHash, err := sealCheckRun(ctx, appCtx, engagementID, checkRun, runtimeCfg.HashAlgorithm)
+		if err != nil {
+			return err
 		}
+		recordHAR(appCtx.ResultsDir, engagementID, check.CheckTypeNetwork, checkRun.ID(), har)
 
-		resultsPath := filepath.Join(appCtx.ResultsDir, engagementID, "http_results.json")
+		resultsPath := filepath.Join(appCtx.ResultsDir, engagementID, check.ResultsFilename(checkRun.Metadata().CheckType))
 		auditPath := filepath.Join(appCtx.ResultsDir, engagementID, "audit.csv")
 
 		fmt.Println()
@@ -679,7 +1223,16 @@ var checkNetworkCmd = &cobra.Command{
 		fmt.Printf("%s Audit: %s\n", colorSuccess("→"), auditPath)
 		fmt.Printf("%s Audit hash (%s): %s\n", colorSuccess("→"), hashAlgo, auditHash)
 
-		return nil
+		return finishCheckRun(cmd, appCtx.ResultsDir, runOutcome{
+			EngagementID:  engagementID,
+			Check:         networkChecker.Name(),
+			Results:       results,
+			StartedAt:     startTime,
+			StopReason:    stopReason,
+			HashAlgorithm: hashAlgo,
+			AuditHash:     auditHash,
+			ResultsFile:   resultsPath,
+		})
 	},
 }
 
@@ -687,11 +1240,30 @@ func init() {
 	checkCmd.PersistentFlags().IntVarP(&cliConfig.Check.Concurrency, "concurrency", "c", cliConfig.Check.Concurrency, "max concurrent requests")
 	checkCmd.PersistentFlags().IntVarP(&cliConfig.Check.RateLimit, "rate", "r", cliConfig.Check.RateLimit, "requests per second (global)")
 	checkCmd.PersistentFlags().IntVarP(&cliConfig.Check.TimeoutSecs, "timeout", "t", cliConfig.Check.TimeoutSecs, "request timeout in seconds")
+	checkCmd.PersistentFlags().IntVar(&cliConfig.Check.Timeouts.Dial, "dial-timeout", 0, "TCP connect timeout in seconds for HTTP requests (default: --timeout)")
+	checkCmd.PersistentFlags().IntVar(&cliConfig.Check.Timeouts.TLSHandshake, "tls-timeout", 0, "TLS handshake timeout in seconds for HTTP requests (default: --timeout)")
+	checkCmd.PersistentFlags().IntVar(&cliConfig.Check.Timeouts.ResponseHeader, "header-timeout", 0, "Seconds to wait for response headers after sending a request (default: --timeout)")
+	checkCmd.PersistentFlags().IntVar(&cliConfig.Check.Timeouts.BodyRead, "body-timeout", 0, "Seconds to read a response body once its headers arrive (default: --timeout)")
 	checkCmd.PersistentFlags().BoolVar(&cliConfig.Check.TelemetryEnabled, "telemetry", cliConfig.Check.TelemetryEnabled, "Record telemetry metrics (durations, success rates)")
+	checkCmd.PersistentFlags().String("pushgateway-url", "", "Push run telemetry to this Prometheus Pushgateway (overrides telemetry.pushgateway_url)")
+	checkCmd.PersistentFlags().String("otlp-endpoint", "", "Export run telemetry as OTLP/HTTP metrics and spans to this collector (overrides telemetry.otlp_endpoint)")
 	checkCmd.PersistentFlags().BoolVar(&cliConfig.Check.ProgressEnabled, "progress", cliConfig.Check.ProgressEnabled, "Display live progress for checks")
+	checkCmd.PersistentFlags().String("summary-file", "", "Write a machine-readable run summary (severity counts, baseline deviations, audit hash, exit code) to this JSON file")
+	checkCmd.PersistentFlags().Var(&failOnValue{}, "fail-on", "Exit with code 3 when findings at or above this severity are found (new findings only once a baseline is set): none|info|low|medium|high|critical")
+	checkCmd.PersistentFlags().Bool("fail-on-errors", false, "Exit with code 4 when any target could not be checked, or 5 when the run stopped early")
 	checkCmd.PersistentFlags().StringVar(&cliConfig.Check.HashAlgorithm, "hash", cliConfig.Check.HashAlgorithm, "Hash algorithm for integrity verification (sha256|sha512)")
 	checkCmd.PersistentFlags().BoolVar(&cliConfig.Check.SecureResults, "secure-results", cliConfig.Check.SecureResults, "Encrypt audit logs with operator GPG key after run")
 	checkCmd.PersistentFlags().IntVar(&cliConfig.Check.RetryCount, "retry", cliConfig.Check.RetryCount, "Number of times to retry failed targets")
+	checkCmd.PersistentFlags().StringArrayVar(&cliConfig.Check.Request.Headers, "header", nil, "Custom request header \"Name: value\" added to every HTTP request (repeatable)")
+	checkCmd.PersistentFlags().StringVar(&cliConfig.Check.Request.UserAgent, "user-agent", "", "User-Agent for every HTTP request (overrides http.user_agent in config)")
+	checkCmd.PersistentFlags().IntVar(&cliConfig.Check.LockWaitSecs, "wait-lock", cliConfig.Check.LockWaitSecs, "Seconds to wait for another run on the same engagement to finish (0 = fail immediately)")
+	checkCmd.PersistentFlags().BoolVar(&cliConfig.Check.AdaptivePause, "adaptive-pause", cliConfig.Check.AdaptivePause, "Pause and slow hosts that answer with HTTP 429, bursts of 403, or WAF challenge pages (events are always recorded)")
+	checkCmd.PersistentFlags().IntVar(&cliConfig.Check.FlushEvery, "flush-every", cliConfig.Check.FlushEvery, "Save the results so far to a snapshot file after this many targets, so a crash keeps them (0 = never)")
+	checkCmd.PersistentFlags().IntVar(&cliConfig.Check.FlushInterval, "flush-interval", cliConfig.Check.FlushInterval, "Save the results so far to a snapshot file at least this often, in seconds (0 = never)")
+	checkCmd.PersistentFlags().IntVar(&cliConfig.Check.MaxPauseSecs, "max-pause", cliConfig.Check.MaxPauseSecs, "Longest pause in seconds for a rate-limited host, Retry-After included")
+	checkCmd.PersistentFlags().StringVar(&cliConfig.Check.Request.Proxy, "proxy", "", "Upstream proxy for HTTP requests (http://, https://, socks5://[user:pass@]host:port, or \"direct\")")
+	checkCmd.PersistentFlags().StringArrayVar(&cliConfig.Check.Request.Resolve, "resolve", nil, "Connect to host at ip instead of resolving it, \"host:ip\" (repeatable; TLS still verifies the host name)")
+	checkCmd.PersistentFlags().StringSliceVar(&cliConfig.Check.DNS.Nameservers, "nameservers", nil, "Custom DNS nameservers (ip[:port]) used to resolve targets (overrides dns.nameservers in config)")
 
 	checkCmd.AddCommand(checkHTTPCmd)
 	checkCmd.AddCommand(checkDNSCmd)
@@ -699,20 +1271,131 @@ func init() {
 
 	checkHTTPCmd.Flags().String("id", "", "Engagement ID")
 	checkHTTPCmd.Flags().Bool("roe-confirm", false, "Confirm ROE and authorization")
+	checkHTTPCmd.Flags().BoolVar(&cliConfig.Check.HAR, "har", cliConfig.Check.HAR, "Record all HTTP requests and responses to a HAR file under har/ (credentials redacted)")
+	checkHTTPCmd.Flags().BoolVar(&cliConfig.Check.Screenshots, "screenshots", cliConfig.Check.Screenshots, "Capture a headless-browser screenshot of each reachable page (saved under screenshots/)")
+	checkHTTPCmd.Flags().BoolVar(&cliConfig.Check.PreCheck, "precheck", cliConfig.Check.PreCheck, "Resolve and TCP-connect to each target first; targets that fail are recorded as unreachable without running the full checks")
+	addCrawlFlags(checkHTTPCmd)
 
 	checkDNSCmd.Flags().String("id", "", "Engagement ID")
 	checkDNSCmd.Flags().Bool("roe-confirm", false, "Confirm ROE and authorization")
+	checkDNSCmd.Flags().BoolVar(&cliConfig.Check.DNS.DomainHygiene, "domain-hygiene", cliConfig.Check.DNS.DomainHygiene, "Check for lame delegation, parent/child NS mismatch and domain expiry (queries nameservers directly and the registry's RDAP server)")
+	checkDNSCmd.Flags().BoolVar(&cliConfig.Check.DNS.RDAP, "rdap", cliConfig.Check.DNS.RDAP, "Record registrar, creation/expiry dates and registrant org for each scoped domain via RDAP (saved to domain_registrations.json)")
+	checkDNSCmd.Flags().StringVar(&cliConfig.Check.DNS.GeoIPDatabase, "geoip-db", cliConfig.Check.DNS.GeoIPDatabase, "Offline IP-to-ASN dataset (iptoasn.com TSV, optionally .gz) used to record the AS and country of resolved addresses")
+	checkDNSCmd.Flags().StringSliceVar(&cliConfig.Check.DNS.AllowedCountries, "allowed-countries", cliConfig.Check.DNS.AllowedCountries, "Approved hosting countries as ISO codes, e.g. SG,VN; other countries are reported (with --geoip-db)")
+	checkDNSCmd.Flags().IntVar(&cliConfig.Check.DNS.ExpiryWarningDays, "domain-expiry-warning", cliConfig.Check.DNS.ExpiryWarningDays, "Days before registration expiry to report a finding (with --domain-hygiene)")
 
 	checkNetworkCmd.Flags().String("id", "", "Engagement ID")
 	checkNetworkCmd.Flags().Bool("roe-confirm", false, "Confirm ROE and authorization")
+	checkNetworkCmd.Flags().Bool("dry-run", false, "Show targets and the expanded port list without sending any traffic")
+	checkNetworkCmd.Flags().BoolVar(&cliConfig.Check.HAR, "har", cliConfig.Check.HAR, "Record all HTTP requests and responses to a HAR file under har/ (credentials redacted)")
+	checkNetworkCmd.Flags().BoolVar(&cliConfig.Check.Screenshots, "screenshots", cliConfig.Check.Screenshots, "Capture a headless-browser screenshot of each reachable page (saved under screenshots/)")
 	checkNetworkCmd.Flags().BoolVar(&cliConfig.Check.Network.EnablePortScan, "enable-port-scan", cliConfig.Check.Network.EnablePortScan, "Scan TCP ports for exposure and banner details")
-	checkNetworkCmd.Flags().IntSliceVar(&cliConfig.Check.Network.Ports, "ports", cliConfig.Check.Network.Ports, "Comma-separated list of TCP ports to scan (defaults to built-in set)")
+	checkNetworkCmd.Flags().Var(newPortSpecValue(&cliConfig.Check.Network.Ports), "ports", "TCP ports to scan: ports, ranges (1-1024) and presets (top100, top1000), comma-separated (defaults to built-in set)")
 	checkNetworkCmd.Flags().IntVar(&cliConfig.Check.Network.PortScanTimeout, "port-scan-timeout", cliConfig.Check.Network.PortScanTimeout, "Per-port scan timeout in seconds")
 	checkNetworkCmd.Flags().IntVar(&cliConfig.Check.Network.MaxPortWorkers, "port-workers", cliConfig.Check.Network.MaxPortWorkers, "Concurrent port scan workers")
-	checkNetworkCmd.Flags().BoolVar(&cliConfig.Check.Crawl.Enabled, "crawl", cliConfig.Check.Crawl.Enabled, "Discover same-host links (auto-detects JavaScript/SPA sites)")
-	checkNetworkCmd.Flags().IntVar(&cliConfig.Check.Crawl.MaxDepth, "crawl-depth", cliConfig.Check.Crawl.MaxDepth, "Maximum link depth to follow per target")
-	checkNetworkCmd.Flags().IntVar(&cliConfig.Check.Crawl.MaxPages, "crawl-max-pages", cliConfig.Check.Crawl.MaxPages, "Maximum additional pages to discover per target")
-	checkNetworkCmd.Flags().BoolVar(&cliConfig.Check.Crawl.EnableJS, "crawl-force-js", cliConfig.Check.Crawl.EnableJS, "Force JavaScript crawler for all targets (overrides auto-detection)")
-	checkNetworkCmd.Flags().IntVar(&cliConfig.Check.Crawl.JSWaitTime, "crawl-js-wait", cliConfig.Check.Crawl.JSWaitTime, "Seconds to wait for JavaScript to render (when JS is used)")
+	checkNetworkCmd.Flags().BoolVar(&cliConfig.Check.Network.ExposureChecks, "exposure-checks", cliConfig.Check.Network.ExposureChecks, "Probe crawled paths for directory listings, .git/config, .env and backup files (rate limited)")
+	checkNetworkCmd.Flags().BoolVar(&cliConfig.Check.Network.AdminPanels, "admin-panels", cliConfig.Check.Network.AdminPanels, "Identify exposed admin interfaces and default pages by status and fingerprint (no login attempts)")
+	checkNetworkCmd.Flags().BoolVar(&cliConfig.Check.Network.Traceroute, "traceroute", cliConfig.Check.Network.Traceroute, "Record the network path (hop count and router addresses) to each target; no raw sockets needed on Linux")
+	checkNetworkCmd.Flags().StringVar(&cliConfig.Check.Network.TraceMethod, "traceroute-method", cliConfig.Check.Network.TraceMethod, "Traceroute probe type: udp or tcp (TCP SYN to --traceroute-port, for paths that drop UDP)")
+	checkNetworkCmd.Flags().IntVar(&cliConfig.Check.Network.TracePort, "traceroute-port", cliConfig.Check.Network.TracePort, "Destination port for TCP traceroute probes")
+	checkNetworkCmd.Flags().IntVar(&cliConfig.Check.Network.TraceMaxHops, "traceroute-max-hops", cliConfig.Check.Network.TraceMaxHops, "Maximum TTL for traceroute probes")
+	addCrawlFlags(checkNetworkCmd)
 	registerPluginCommands()
 }
+
+// addCrawlFlags registers the flags that expand a check's targets with the
+// pages discovered by crawling them.
+func addCrawlFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&cliConfig.Check.Crawl.Enabled, "crawl", cliConfig.Check.Crawl.Enabled, "Discover in-scope links (auto-detects JavaScript/SPA sites)")
+	cmd.Flags().IntVar(&cliConfig.Check.Crawl.MaxDepth, "crawl-depth", cliConfig.Check.Crawl.MaxDepth, "Maximum link depth to follow per target")
+	cmd.Flags().IntVar(&cliConfig.Check.Crawl.MaxPages, "crawl-max-pages", cliConfig.Check.Crawl.MaxPages, "Maximum additional pages to discover per target")
+	cmd.Flags().BoolVar(&cliConfig.Check.Crawl.EnableJS, "crawl-force-js", cliConfig.Check.Crawl.EnableJS, "Force JavaScript crawler for all targets (overrides auto-detection)")
+	cmd.Flags().IntVar(&cliConfig.Check.Crawl.JSWaitTime, "crawl-js-wait", cliConfig.Check.Crawl.JSWaitTime, "Seconds to wait for JavaScript to render (when JS is used)")
+	cmd.Flags().BoolVar(&cliConfig.Check.Crawl.UseSitemaps, "crawl-sitemaps", cliConfig.Check.Crawl.UseSitemaps, "Seed crawl discovery from sitemap.xml and sitemap index files (counts towards --crawl-max-pages)")
+	cmd.Flags().BoolVar(&cliConfig.Check.Crawl.Inventory, "crawl-inventory", cliConfig.Check.Crawl.Inventory, "Record forms and XHR/fetch API endpoints found while crawling (passive; saved to attack_surface.json)")
+	cmd.Flags().StringSliceVar(&cliConfig.Check.Crawl.AllowHosts, "crawl-allow-host", nil, "Additional host to crawl beyond the start host, e.g. api.example.com or *.example.com (repeatable)")
+	cmd.Flags().StringSliceVar(&cliConfig.Check.Crawl.IncludePaths, "crawl-include-path", nil, "Only crawl URLs under this path prefix (repeatable)")
+	cmd.Flags().StringSliceVar(&cliConfig.Check.Crawl.ExcludePaths, "crawl-exclude-path", nil, "Skip URLs under this path prefix, e.g. /logout (repeatable)")
+	cmd.Flags().StringArrayVar(&cliConfig.Check.Crawl.IncludeRegex, "crawl-include-regex", nil, "Only crawl URLs matching this regular expression (repeatable)")
+	cmd.Flags().StringArrayVar(&cliConfig.Check.Crawl.ExcludeRegex, "crawl-exclude-regex", nil, "Skip URLs matching this regular expression (repeatable)")
+	cmd.Flags().BoolVar(&cliConfig.Check.Crawl.Cache, "crawl-cache", cliConfig.Check.Crawl.Cache, "Reuse pages discovered by an earlier crawl of the same target with the same settings (saved to crawl_cache.json)")
+	cmd.Flags().IntVar(&cliConfig.Check.Crawl.CacheTTL, "crawl-cache-ttl", cliConfig.Check.Crawl.CacheTTL, "Hours a cached crawl stays valid")
+	cmd.Flags().BoolVar(&cliConfig.Check.Crawl.IgnoreRobots, "crawl-ignore-robots", cliConfig.Check.Crawl.IgnoreRobots, "Ignore robots.txt Disallow and Crawl-delay rules while crawling (noted in the audit trail)")
+}
+
+// printNetworkDryRun describes what a network check would probe without
+// connecting to any target.
+func printNetworkDryRun(name string, targets []string, cfg CheckRuntimeConfig, pacing *checker.ScanPacing, stored *EngagementPacing) {
+	netCfg := cfg.Network
+	scanner := &checker.NetworkChecker{CommonPorts: netCfg.Ports}
+	ports := scanner.Ports()
+
+	fmt.Printf("%s Dry run for engagement: %s (no traffic sent)\n", colorInfo("→"), name)
+	fmt.Printf("%s Targets: %d\n", colorInfo("→"), len(targets))
+	if cfg.Crawl.Enabled {
+		fmt.Printf("%s Crawl: up to %d additional page(s) per target\n", colorInfo("→"), cfg.Crawl.MaxPages)
+	}
+	if netCfg.Traceroute {
+		fmt.Printf("%s Traceroute: %s probes, up to %d hop(s) per destination\n", colorInfo("→"), strings.ToUpper(netCfg.TraceMethod), netCfg.TraceMaxHops)
+	}
+	if !netCfg.EnablePortScan {
+		fmt.Printf("%s Port scan: disabled (pass --enable-port-scan)\n", colorInfo("→"))
+		return
+	}
+	fmt.Printf("%s Ports: %d (%s)\n", colorInfo("→"), len(ports), summarizePorts(ports, 12))
+	fmt.Printf("%s Connection attempts: up to %d (%d target(s) × %d port(s))\n",
+		colorInfo("→"), len(targets)*len(ports), len(targets), len(ports))
+	if stored != nil && !stored.IsZero() {
+		fmt.Printf("%s Scan pacing: %s\n", colorInfo("→"), stored.Summary())
+		if d := pacing.MinDuration(len(ports)); d > 0 {
+			fmt.Printf("%s Minimum scan time per host: %s\n", colorInfo("→"), d.Round(time.Second))
+		}
+	}
+}
+
+// summarizePorts lists up to limit ports, noting how many were omitted.
+func summarizePorts(ports []int, limit int) string {
+	shown := ports
+	if len(shown) > limit {
+		shown = shown[:limit]
+	}
+	parts := make([]string, len(shown))
+	for i, port := range shown {
+		parts[i] = strconv.Itoa(port)
+	}
+	out := strings.Join(parts, ",")
+	if len(ports) > limit {
+		out += fmt.Sprintf(",… +%d more", len(ports)-limit)
+	}
+	return out
+}
+
+// printDomainHygiene lists delegation and registration findings once per
+// zone, since targets in the same zone share a result.
+func printDomainHygiene(results []checker.CheckResult) {
+	seen := make(map[string]bool)
+	var zones []*checker.DomainHygieneResult
+	for _, r := range results {
+		if h := r.DomainHygiene; h != nil && !seen[h.Zone] {
+			seen[h.Zone] = true
+			zones = append(zones, h)
+		}
+	}
+	findings := 0
+	for _, h := range zones {
+		findings += len(h.Findings)
+	}
+	fmt.Printf("%s Domain hygiene: %d zone(s), %d finding(s)\n", colorInfo("→"), len(zones), findings)
+
+	for _, h := range zones {
+		for _, f := range h.Findings {
+			fmt.Printf("  %s [%s] %s\n", colorWarn("!"), f.Severity, f.Detail)
+		}
+		if reg := h.Registration; reg != nil && reg.Expires != nil {
+			fmt.Printf("  %s %s expires %s (%d days)\n", colorInfo("→"), reg.Domain, reg.Expires.Format("2006-01-02"), reg.DaysLeft)
+		}
+		for _, e := range h.Errors {
+			fmt.Printf("  %s %s: %s\n", colorInfo("→"), h.Zone, e)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// maxCrawlDepth is the deepest --crawl-depth accepted. Deeper crawls are
+// almost always a typo for --crawl-max-pages.
+const maxCrawlDepth = 10
+
+// Check types validated by checkConfigIssues.
+var checkConfigTypes = []string{"http", "dns", "network"}
+
+// checkConfigIssues validates the settings the given checks will run with,
+// from flags, config and defaults, before any target is contacted.
+// Settings shared by every check are always validated. engagementID selects
+// http.engagements.<id>.* overrides; "" validates the shared ones only.
+func checkConfigIssues(engagementID string, cfg CheckRuntimeConfig, checkTypes ...string) []ConfigIssue {
+	var issues []ConfigIssue
+	add := func(key, format string, args ...any) {
+		issues = append(issues, ConfigIssue{Key: key, Message: fmt.Sprintf(format, args...)})
+	}
+	addErr := func(key string, err error) {
+		if err != nil {
+			add(key, "%s", err)
+		}
+	}
+	atLeast := func(key string, value, minimum int) {
+		if value < minimum {
+			add(key, "must be at least %d, got %d", minimum, value)
+		}
+	}
+
+	atLeast("--concurrency", cfg.Concurrency, 1)
+	atLeast("--rate", cfg.RateLimit, 1)
+	atLeast("--timeout", cfg.TimeoutSecs, 1)
+	atLeast("--retry", cfg.RetryCount, 0)
+	atLeast("--wait-lock", cfg.LockWaitSecs, 0)
+	atLeast("--max-pause", cfg.MaxPauseSecs, 0)
+	atLeast("--flush-every", cfg.FlushEvery, 0)
+	atLeast("--flush-interval", cfg.FlushInterval, 0)
+	if _, err := ParseHashAlgorithm(cfg.HashAlgorithm); err != nil {
+		add("--hash", "%s", err)
+	}
+	// The resolvers name the flag or config key at fault in their errors.
+	_, err := resolvePhaseTimeouts(cfg)
+	addErr("timeouts", err)
+	_, err = resolveRequestHeaders(engagementID, cfg.Request)
+	addErr("headers", err)
+	_, err = resolveProxy(engagementID, cfg.Request)
+	addErr("proxy", err)
+	_, err = resolveDialer(engagementID, cfg)
+	addErr("nameservers", err)
+
+	for _, checkType := range checkTypes {
+		switch checkType {
+		case "http":
+			issues = append(issues, crawlConfigIssues(cfg.Crawl)...)
+		case "dns":
+			atLeast("--domain-expiry-warning", cfg.DNS.ExpiryWarningDays, 0)
+			if cfg.DNS.Timeout < 1 {
+				add("dns timeout", "must be at least 1 second, got %d", cfg.DNS.Timeout)
+			}
+			_, err := normalizeCountryCodes(cfg.DNS.AllowedCountries)
+			addErr("--allowed-countries", err)
+		case "network":
+			issues = append(issues, crawlConfigIssues(cfg.Crawl)...)
+			netCfg := cfg.Network
+			for _, port := range netCfg.Ports {
+				if port < 1 || port > 65535 {
+					add("--ports", "port %d out of range (1-65535)", port)
+				}
+			}
+			atLeast("--port-scan-timeout", netCfg.PortScanTimeout, 1)
+			atLeast("--port-workers", netCfg.MaxPortWorkers, 1)
+			_, err := newTracer(netCfg)
+			addErr("traceroute", err)
+		}
+	}
+	return issues
+}
+
+// crawlConfigIssues validates the --crawl-* settings. Limits are only
+// checked when --crawl is set; scope rules are checked either way, since
+// crawl.scope.* in config applies to every crawl.
+func crawlConfigIssues(crawl CrawlConfig) []ConfigIssue {
+	var issues []ConfigIssue
+	if crawl.Enabled {
+		if crawl.MaxDepth < 1 || crawl.MaxDepth > maxCrawlDepth {
+			issues = append(issues, ConfigIssue{Key: "--crawl-depth", Message: fmt.Sprintf("must be between 1 and %d, got %d", maxCrawlDepth, crawl.MaxDepth)})
+		}
+		if crawl.MaxPages < 1 {
+			issues = append(issues, ConfigIssue{Key: "--crawl-max-pages", Message: fmt.Sprintf("must be at least 1, got %d", crawl.MaxPages)})
+		}
+		if crawl.JSWaitTime < 0 {
+			issues = append(issues, ConfigIssue{Key: "--crawl-js-wait", Message: fmt.Sprintf("must not be negative, got %d", crawl.JSWaitTime)})
+		}
+		if crawl.CacheTTL < 0 {
+			issues = append(issues, ConfigIssue{Key: "--crawl-cache-ttl", Message: fmt.Sprintf("must not be negative, got %d", crawl.CacheTTL)})
+		}
+	}
+	if _, err := buildCrawlScope(crawl); err != nil {
+		issues = append(issues, ConfigIssue{Key: "--crawl-*", Message: err.Error()})
+	}
+	return issues
+}
+
+// validateCheckConfig returns an error listing every invalid setting for
+// the checks, so a run fails before it takes the engagement lock rather
+// than partway through.
+func validateCheckConfig(engagementID string, cfg CheckRuntimeConfig, checkTypes ...string) error {
+	issues := dedupeConfigIssues(checkConfigIssues(engagementID, cfg, checkTypes...))
+	if len(issues) == 0 {
+		return nil
+	}
+	lines := make([]string, len(issues))
+	for i, issue := range issues {
+		lines[i] = "  " + issue.String()
+	}
+	return fmt.Errorf("invalid check settings (run 'seca config validate --checks' for details):\n%s", strings.Join(lines, "\n"))
+}
+
+// allCheckConfigIssues validates the check settings for every check type,
+// then the http.engagements.<id> overrides of each engagement in config.
+// Problems an engagement shares with the defaults are reported once.
+func allCheckConfigIssues(cfg CheckRuntimeConfig) []ConfigIssue {
+	issues := dedupeConfigIssues(checkConfigIssues("", cfg, checkConfigTypes...))
+	shared := make(map[ConfigIssue]bool, len(issues))
+	for _, issue := range issues {
+		shared[issue] = true
+	}
+	for _, id := range sortedMapKeys(viper.Get("http.engagements")) {
+		for _, issue := range checkConfigIssues(id, cfg) {
+			if !shared[issue] {
+				issue.Key = "engagement " + id + ": " + issue.Key
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return issues
+}
+
+// dedupeConfigIssues drops repeats, such as crawl issues reported for both
+// http and network checks, keeping the first of each.
+func dedupeConfigIssues(issues []ConfigIssue) []ConfigIssue {
+	seen := make(map[ConfigIssue]bool, len(issues))
+	out := issues[:0]
+	for _, issue := range issues {
+		if !seen[issue] {
+			seen[issue] = true
+			out = append(out, issue)
+		}
+	}
+	return out
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckConfigIssues(t *testing.T) {
+	loadTestConfigFile(t, `
+dns:
+  nameservers: [1.1.1.1, ns1.example.com]
+http:
+  engagements:
+    eng-1:
+      nameservers: [8.8.8.8]
+    eng-2:
+      nameservers: [not-an-ip]
+`)
+
+	cfg := newCLIConfig().Check
+	cfg.TimeoutSecs = -5
+	cfg.Crawl.Enabled = true
+	cfg.Crawl.MaxDepth = 50
+	cfg.Crawl.ExcludeRegex = []string{"("}
+	cfg.Network.Ports = []int{22, 70000}
+	cfg.Network.MaxPortWorkers = 0
+	cfg.Network.TraceMethod = "icmp"
+
+	issues := allCheckConfigIssues(cfg)
+	got := make(map[string]string, len(issues))
+	for _, issue := range issues {
+		if _, dup := got[issue.Key]; dup {
+			t.Errorf("%s reported twice", issue.Key)
+		}
+		got[issue.Key] = issue.Message
+	}
+	want := map[string]string{
+		"--timeout":                     "must be at least 1",
+		"--crawl-depth":                 "between 1 and 10",
+		"--crawl-*":                     "crawl scope",
+		"--ports":                       "port 70000 out of range",
+		"--port-workers":                "must be at least 1",
+		"traceroute":                    "icmp",
+		"nameservers":                   `dns.nameservers: invalid nameserver "ns1.example.com"`,
+		"engagement eng-2: nameservers": "not-an-ip",
+	}
+	for key, fragment := range want {
+		if !strings.Contains(got[key], fragment) {
+			t.Errorf("%s: got %q, want it to mention %q", key, got[key], fragment)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("unexpected issues: %v", issues)
+	}
+
+	// A run fails up front and names every problem.
+	err := validateCheckConfig("eng-1", cfg, "dns")
+	if err == nil || !strings.Contains(err.Error(), "--timeout: must be at least 1") || strings.Contains(err.Error(), "--crawl-depth") {
+		t.Errorf("validateCheckConfig() = %v", err)
+	}
+	if err := validateCheckConfig("", newCLIConfig().Check, checkConfigTypes...); err == nil {
+		t.Error("the invalid dns.nameservers entry should fail every check")
+	}
+}
+
+func TestCheckConfigIssues_Defaults(t *testing.T) {
+	if issues := checkConfigIssues("", newCLIConfig().Check, checkConfigTypes...); len(issues) != 0 {
+		t.Errorf("built-in defaults should be valid, got %v", issues)
+	}
+}
@@ -82,3 +82,33 @@ func TestResultAdapterToDomain_ErrorPropagation(t *testing.T) {
 		t.Fatalf("expected error message to propagate, got %s", domainResult.Error())
 	}
 }
+
+func TestCrawlAuditNote(t *testing.T) {
+	cfg := CheckRuntimeConfig{}
+	if note := crawlAuditNote(cfg); note != "" {
+		t.Fatalf("expected no note when crawling is disabled, got %q", note)
+	}
+
+	cfg.Crawl.IgnoreRobots = true
+	if note := crawlAuditNote(cfg); note != "" {
+		t.Fatalf("expected no note when crawling is disabled, got %q", note)
+	}
+
+	cfg.Crawl.Enabled = true
+	note := crawlAuditNote(cfg)
+	if note == "" {
+		t.Fatal("expected a note when robots.txt is ignored")
+	}
+	if got := withAuditNote("port 22 open", note); got != "port 22 open; "+note {
+		t.Fatalf("unexpected merged notes %q", got)
+	}
+	if got := withAuditNote("", note); got != note {
+		t.Fatalf("unexpected merged notes %q", got)
+	}
+	if got := withAuditNote("existing", ""); got != "existing" {
+		t.Fatalf("unexpected merged notes %q", got)
+	}
+	if got := withAuditNote("", "", note, "", "via proxy"); got != note+"; via proxy" {
+		t.Fatalf("unexpected merged notes %q", got)
+	}
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/khanhnv2901/seca-cli/pkg/checkersdk"
+)
+
+// registerSDKCheckers adds a "seca check <name>" command for every checker
+// compiled in through pkg/checkersdk. It runs from Execute rather than init so
+// that checker packages imported by a custom main are registered first.
+func registerSDKCheckers() {
+	for _, reg := range checkersdk.Registered() {
+		reg := reg
+		err := addCheckerCommand(checkerCommandSpec{
+			Name:        reg.Name,
+			Description: reg.Description,
+			Kind:        "check",
+			Timeout:     reg.Timeout,
+			NewChecker: func(context.Context, *url.URL) (checker.Checker, error) {
+				return reg.Checker, nil
+			},
+		})
+		if err != nil {
+			cliLog().Warnw("sdk_checker_skipped", "checker", reg.Name, "error", err)
+		}
+	}
+}
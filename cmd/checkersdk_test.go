@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/khanhnv2901/seca-cli/pkg/checkersdk"
+)
+
+func TestAddCheckerCommandRegistersSDKChecker(t *testing.T) {
+	sdkChecker := checkersdk.New("sdk-cmd-test", func(ctx context.Context, target string) checkersdk.CheckResult {
+		return checkersdk.NewResult(target).Build()
+	})
+	reg := checkerCommandSpec{
+		Name:        sdkChecker.Name(),
+		Description: "SDK command test",
+		Kind:        "check",
+		NewChecker: func(context.Context, *url.URL) (checkersdk.Checker, error) {
+			return sdkChecker, nil
+		},
+	}
+
+	if err := addCheckerCommand(reg); err != nil {
+		t.Fatalf("addCheckerCommand: %v", err)
+	}
+	t.Cleanup(func() {
+		for _, c := range checkCmd.Commands() {
+			if c.Name() == "sdk-cmd-test" {
+				checkCmd.RemoveCommand(c)
+			}
+		}
+	})
+
+	found, _, err := checkCmd.Find([]string{"sdk-cmd-test"})
+	if err != nil || found.Name() != "sdk-cmd-test" {
+		t.Fatalf("expected check sdk-cmd-test to be registered, got %v (%v)", found, err)
+	}
+	if found.Flags().Lookup("id") == nil || found.Flags().Lookup("roe-confirm") == nil {
+		t.Fatal("expected --id and --roe-confirm flags")
+	}
+
+	if err := addCheckerCommand(reg); err == nil {
+		t.Fatal("expected an error when the command name is already taken")
+	}
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jung-kurt/gofpdf"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+	"github.com/spf13/cobra"
+)
+
+// classificationFilename holds the handling label for an engagement's
+// deliverables.
+const classificationFilename = "classification.json"
+
+// maxClassificationLength keeps labels short enough for a page header.
+const maxClassificationLength = 64
+
+// EngagementClassification is the handling label (e.g. "TLP:AMBER") stamped
+// on every report generated for an engagement.
+type EngagementClassification struct {
+	Label string    `json:"label"`
+	SetAt time.Time `json:"set_at"`
+	SetBy string    `json:"set_by"`
+}
+
+// normalizeClassification trims the label and rejects ones that cannot be
+// printed on a single header line.
+func normalizeClassification(label string) (string, error) {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return "", errors.New("classification label must not be empty")
+	}
+	if len(label) > maxClassificationLength {
+		return "", fmt.Errorf("classification label must be at most %d characters", maxClassificationLength)
+	}
+	for _, r := range label {
+		if !unicode.IsPrint(r) {
+			return "", fmt.Errorf("classification label %q contains non-printable characters", label)
+		}
+	}
+	return label, nil
+}
+
+// loadClassification returns the engagement's classification, or nil when
+// none is set.
+func loadClassification(resultsDir, engagementID string) (*EngagementClassification, error) {
+	path, err := resolveResultsPath(resultsDir, engagementID, classificationFilename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var c EngagementClassification
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", classificationFilename, err)
+	}
+	return &c, nil
+}
+
+func saveClassification(resultsDir, engagementID string, c EngagementClassification) error {
+	if _, err := ensureResultsDir(resultsDir, engagementID); err != nil {
+		return err
+	}
+	path, err := resolveResultsPath(resultsDir, engagementID, classificationFilename)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c, jsonPrefix, jsonIndent)
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFile(path, data, consts.DefaultFilePerm)
+}
+
+// addPDFClassification stamps the label at the top of every page and as a
+// faint diagonal watermark across it, and records it in the document
+// metadata. It must be called before the first page is added.
+func addPDFClassification(pdf *gofpdf.Fpdf, label string) {
+	if label == "" {
+		return
+	}
+	pdf.SetSubject("Classification: "+label, false)
+	pdf.SetKeywords(label, false)
+	pdf.SetHeaderFunc(func() {
+		width, height := pdf.GetPageSize()
+
+		pdf.SetFont("Arial", "B", 60)
+		pdf.SetTextColor(200, 30, 30)
+		pdf.SetAlpha(0.12, "Normal")
+		cx, cy := width/2, height/2
+		pdf.TransformBegin()
+		pdf.TransformRotate(45, cx, cy)
+		pdf.Text(cx-pdf.GetStringWidth(label)/2, cy, label)
+		pdf.TransformEnd()
+		pdf.SetAlpha(1, "Normal")
+
+		pdf.SetFont("Arial", "B", 9)
+		pdf.SetXY(0, 3)
+		pdf.CellFormat(width, 5, label, "", 0, "C", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+		_, top, _, _ := pdf.GetMargins()
+		pdf.SetY(top)
+	})
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Arial", "B", 9)
+		pdf.SetTextColor(200, 30, 30)
+		pdf.CellFormat(0, 5, label, "", 0, "C", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	})
+}
+
+var engagementClassificationCmd = &cobra.Command{
+	Use:   "classification",
+	Short: "Manage the handling label stamped on an engagement's reports",
+	Long: `Set a classification such as "TLP:AMBER" or "Client Confidential" for an
+engagement. Every report generated afterwards carries it: watermarked and in
+the header and footer of each PDF page, as a banner and <meta> tag in HTML, at
+the top and bottom of Markdown, in JSON output, and in PDF document metadata.`,
+}
+
+var engagementClassificationSetCmd = &cobra.Command{
+	Use:     "set",
+	Short:   "Set the classification for an engagement",
+	Example: `  seca engagement classification set --id eng123 --label "TLP:AMBER"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		appCtx := getAppContext(cmd)
+
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return errors.New("--id is required")
+		}
+		raw, _ := cmd.Flags().GetString("label")
+		label, err := normalizeClassification(raw)
+		if err != nil {
+			return err
+		}
+		if err := requireWritableEngagement(ctx, appCtx, id); err != nil {
+			return err
+		}
+
+		previous := "none"
+		if existing, err := loadClassification(appCtx.ResultsDir, id); err != nil {
+			return err
+		} else if existing != nil {
+			previous = existing.Label
+		}
+		c := EngagementClassification{Label: label, SetAt: time.Now().UTC(), SetBy: appCtx.Operator}
+		if err := saveClassification(appCtx.ResultsDir, id, c); err != nil {
+			return err
+		}
+		notes := fmt.Sprintf("classification changed from %s to %s", previous, label)
+		if err := recordAuditEvent(ctx, appCtx, id, "engagement classification", id, notes); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "%s classification for engagement %s: %s\n", colorSuccess("✓"), id, label)
+		return nil
+	},
+}
+
+var engagementClassificationShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the classification for an engagement",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return errors.New("--id is required")
+		}
+		c, err := loadClassification(appCtx.ResultsDir, id)
+		if err != nil {
+			return err
+		}
+		if c == nil {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s no classification set for engagement %s\n", colorInfo("→"), id)
+			return nil
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %s (set by %s at %s)\n", colorInfo("→"), c.Label, c.SetBy, c.SetAt.Format(time.RFC3339))
+		return nil
+	},
+}
+
+var engagementClassificationClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove the classification from an engagement",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return errors.New("--id is required")
+		}
+		if err := requireWritableEngagement(ctx, appCtx, id); err != nil {
+			return err
+		}
+		path, err := resolveResultsPath(appCtx.ResultsDir, id, classificationFilename)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil {
+			if os.IsNotExist(err) {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s no classification set for engagement %s\n", colorInfo("→"), id)
+				return nil
+			}
+			return fmt.Errorf("remove classification: %w", err)
+		}
+		if err := recordAuditEvent(ctx, appCtx, id, "engagement classification", id, "classification cleared"); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s cleared classification for engagement %s\n", colorSuccess("✓"), id)
+		return nil
+	},
+}
+
+func init() {
+	engagementCmd.AddCommand(engagementClassificationCmd)
+	engagementClassificationCmd.AddCommand(engagementClassificationSetCmd)
+	engagementClassificationCmd.AddCommand(engagementClassificationShowCmd)
+	engagementClassificationCmd.AddCommand(engagementClassificationClearCmd)
+
+	engagementClassificationSetCmd.Flags().String("id", "", "Engagement ID")
+	engagementClassificationSetCmd.Flags().String("label", "", `Classification label, e.g. "TLP:AMBER" or "Client Confidential"`)
+	engagementClassificationShowCmd.Flags().String("id", "", "Engagement ID")
+	engagementClassificationClearCmd.Flags().String("id", "", "Engagement ID")
+}
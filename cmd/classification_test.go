@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func TestNormalizeClassification(t *testing.T) {
+	if got, err := normalizeClassification("  TLP:AMBER "); err != nil || got != "TLP:AMBER" {
+		t.Fatalf("normalizeClassification() = %q, %v", got, err)
+	}
+	for _, bad := range []string{"", "   ", "line\nbreak", strings.Repeat("x", maxClassificationLength+1)} {
+		if _, err := normalizeClassification(bad); err == nil {
+			t.Errorf("normalizeClassification(%q): expected error", bad)
+		}
+	}
+}
+
+func TestClassificationStampedOnReports(t *testing.T) {
+	resultsDir := t.TempDir()
+	const id = "eng-classified"
+	start := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+	if _, err := ensureResultsDir(resultsDir, id); err != nil {
+		t.Fatalf("ensureResultsDir() error = %v", err)
+	}
+	writeRunOutputFile(t, resultsDir, id, "http_results.json", RunOutput{
+		Metadata: RunMetadata{EngagementID: id, StartAt: start, CompleteAt: start.Add(time.Minute)},
+		Results:  []checker.CheckResult{{Target: "https://app.example.com", Status: "ok"}},
+	})
+	if c, err := loadClassification(resultsDir, id); err != nil || c != nil {
+		t.Fatalf("expected no classification, got %+v (%v)", c, err)
+	}
+	if err := saveClassification(resultsDir, id, EngagementClassification{Label: "TLP:AMBER", SetAt: start, SetBy: "alice"}); err != nil {
+		t.Fatalf("saveClassification() error = %v", err)
+	}
+
+	wants := map[string][]string{
+		"json": {`"classification": "TLP:AMBER"`},
+		"md":   {"**Classification: TLP:AMBER**", "- **Classification:** TLP:AMBER"},
+		"html": {`<meta name="classification" content="TLP:AMBER">`, `<div class="classification">TLP:AMBER</div>`},
+		"pdf":  {"Classification: TLP:AMBER"},
+	}
+	for format, want := range wants {
+		rendered, err := loadEngagementReport(resultsDir, id, format)
+		if err != nil {
+			t.Fatalf("loadEngagementReport(%s) error = %v", format, err)
+		}
+		var buf bytes.Buffer
+		if err := rendered.Render(&buf); err != nil {
+			t.Fatalf("Render(%s) error = %v", format, err)
+		}
+		for _, w := range want {
+			if !strings.Contains(buf.String(), w) {
+				t.Errorf("%s report missing %q", format, w)
+			}
+		}
+	}
+}
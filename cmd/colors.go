@@ -19,6 +19,8 @@ func formatStatusWithColor(status string) string {
 		return colorSuccess(status)
 	case "error", "fail", "failed":
 		return colorError(status)
+	case "warning", "blocked", "unreachable", "skipped":
+		return colorWarn(status)
 	default:
 		return status
 	}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// registerIDCompletions completes every --id flag in the command tree from the
+// local engagement store. It runs after plugin commands are registered so they
+// get completion too.
+func registerIDCompletions(cmd *cobra.Command) {
+	if cmd.Flags().Lookup("id") != nil {
+		// Only fails when a completion is already registered for the flag.
+		_ = cmd.RegisterFlagCompletionFunc("id", completeEngagementIDs)
+	}
+	for _, sub := range cmd.Commands() {
+		registerIDCompletions(sub)
+	}
+}
+
+// completeEngagementIDs returns the engagement IDs that start with toComplete,
+// described by engagement name and scope size.
+func completeEngagementIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if globalAppContext == nil || globalAppContext.Services == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	engagements, err := listEngagementDTOs(context.Background(), globalAppContext)
+	if err != nil {
+		cobra.CompDebugln(err.Error(), true)
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var completions []string
+	for _, eng := range engagements {
+		if strings.HasPrefix(eng.ID, toComplete) {
+			completions = append(completions, fmt.Sprintf("%s\t%s (%d targets)", eng.ID, eng.Name, len(eng.Scope)))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// isCompletionRequest reports whether cmd generates a completion script or is
+// the hidden command those scripts call.
+func isCompletionRequest(cmd *cobra.Command) bool {
+	switch cmd.Name() {
+	case cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return true
+	}
+	return cmd.HasParent() && cmd.Parent().Name() == "completion"
+}
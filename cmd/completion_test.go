@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestCompleteEngagementIDs(t *testing.T) {
+	defer setupTestAppContextWithServices(t)()
+
+	svc := globalAppContext.Services.EngagementService
+	eng, err := svc.CreateEngagement(context.Background(), "Acme Web", "owner", "ROE", []string{"example.com"})
+	if err != nil {
+		t.Fatalf("CreateEngagement: %v", err)
+	}
+
+	completions, directive := completeEngagementIDs(checkHTTPCmd, nil, eng.ID()[:4])
+	if directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("unexpected directive %v", directive)
+	}
+	if len(completions) != 1 || !strings.HasPrefix(completions[0], eng.ID()+"\tAcme Web") {
+		t.Fatalf("unexpected completions %q", completions)
+	}
+
+	if completions, _ := completeEngagementIDs(checkHTTPCmd, nil, "no-such-id"); len(completions) != 0 {
+		t.Fatalf("expected no completions, got %q", completions)
+	}
+}
+
+func TestRegisterIDCompletions(t *testing.T) {
+	registerIDCompletions(rootCmd)
+	// Registering twice must not panic or fail.
+	registerIDCompletions(rootCmd)
+
+	for _, cmd := range []*cobra.Command{checkHTTPCmd, engagementViewCmd, findingsAddCmd, engagementScopeImportCmd} {
+		if _, ok := cmd.GetFlagCompletionFunc("id"); !ok {
+			t.Errorf("%s: --id has no completion", cmd.CommandPath())
+		}
+	}
+}
+
+func TestIsCompletionRequest(t *testing.T) {
+	root := &cobra.Command{Use: "seca"}
+	completion := &cobra.Command{Use: "completion"}
+	bash := &cobra.Command{Use: "bash"}
+	complete := &cobra.Command{Use: cobra.ShellCompRequestCmd}
+	root.AddCommand(completion, complete)
+	completion.AddCommand(bash)
+
+	if !isCompletionRequest(bash) || !isCompletionRequest(complete) {
+		t.Error("expected completion commands to be recognized")
+	}
+	if isCompletionRequest(checkHTTPCmd) {
+		t.Error("check http is not a completion request")
+	}
+}
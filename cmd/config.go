@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -9,10 +12,22 @@ import (
 )
 
 const (
-	defaultHTTPTimeoutSeconds  = 10
-	defaultDNSTimeoutSeconds   = 10
-	defaultPortScanTimeoutSecs = 2
-	defaultPortScanWorkers     = 10
+	// envPrefix namespaces environment overrides: config key check.concurrency
+	// is read from SECA_CHECK_CONCURRENCY.
+	envPrefix = "SECA"
+	// profileEnvVar selects a config profile when --profile is not given.
+	profileEnvVar = "SECA_PROFILE"
+)
+
+const (
+	defaultHTTPTimeoutSeconds      = 10
+	defaultDNSTimeoutSeconds       = 10
+	defaultDomainExpiryWarningDays = 30
+	defaultPortScanTimeoutSecs     = 2
+	defaultPortScanWorkers         = 10
+	defaultTraceMethod             = "udp"
+	defaultTracePort               = 443
+	defaultTraceMaxHops            = 30
 )
 
 // CLIConfig captures runtime configuration shared across commands.
@@ -43,15 +58,30 @@ type CheckRuntimeConfig struct {
 	HashAlgorithm    string
 	SecureResults    bool
 	RetryCount       int
+	Screenshots      bool // Capture a headless-browser screenshot of each checked page
+	HAR              bool // Record checker and crawler HTTP traffic to a HAR file per run
+	LockWaitSecs     int  // Seconds to wait for another run on the engagement to finish
+	AdaptivePause    bool // Pause and slow hosts that rate limit or block the run
+	MaxPauseSecs     int  // Longest pause of a throttled host
+	PreCheck         bool // Skip full checks of targets that fail a DNS + TCP reachability check
+	FlushEvery       int  // Save the run's results so far after this many new results (0 = never)
+	FlushInterval    int  // Save the run's results so far after this many seconds (0 = never)
 	DNS              DNSConfig
 	Crawl            CrawlConfig
 	Network          NetworkConfig
+	Request          RequestConfig
+	Timeouts         PhaseTimeoutConfig // Per-phase HTTP timeouts; zero uses TimeoutSecs
 }
 
 // DNSConfig groups DNS-specific runtime options.
 type DNSConfig struct {
-	Nameservers []string
-	Timeout     int
+	Nameservers       []string
+	Timeout           int
+	DomainHygiene     bool     // Check NS delegation and registration expiry via RDAP
+	ExpiryWarningDays int      // Flag registrations expiring within this many days
+	RDAP              bool     // Record registration data for scoped domains via RDAP
+	GeoIPDatabase     string   // Offline IP-to-ASN dataset used to enrich resolved addresses
+	AllowedCountries  []string // Approved hosting jurisdictions (ISO 3166-1 alpha-2)
 }
 
 // CrawlConfig captures HTTP crawl/discovery options.
@@ -62,6 +92,17 @@ type CrawlConfig struct {
 	EnableJS     bool
 	JSWaitTime   int // Time in seconds to wait for JavaScript to render
 	AutoDetectJS bool
+	IgnoreRobots bool // Skip robots.txt Disallow/Crawl-delay (recorded in the audit trail)
+	UseSitemaps  bool // Seed discovery from sitemap.xml / sitemap index files
+	Inventory    bool // Record forms and XHR/fetch endpoints found while crawling
+	Cache        bool // Reuse discovered pages from earlier runs (crawl_cache.json)
+	CacheTTL     int  // Hours a cached crawl stays valid
+	// Scope rules; empty means crawl the start host only (see crawl.scope.* in config).
+	AllowHosts   []string
+	IncludePaths []string
+	ExcludePaths []string
+	IncludeRegex []string
+	ExcludeRegex []string
 }
 
 // NetworkConfig captures network checker runtime options.
@@ -70,6 +111,12 @@ type NetworkConfig struct {
 	PortScanTimeout int
 	Ports           []int
 	MaxPortWorkers  int
+	ExposureChecks  bool
+	AdminPanels     bool
+	Traceroute      bool   // Record the network path to each target
+	TraceMethod     string // "udp" or "tcp"
+	TracePort       int    // TCP destination port for --traceroute-method tcp
+	TraceMaxHops    int
 }
 
 type defaultOverrides struct {
@@ -80,6 +127,13 @@ type defaultOverrides struct {
 	RetentionDays    *int
 	HashAlgorithm    string
 	SecureResults    *bool
+	// check.* keys; CheckTimeoutSecs takes precedence over defaults.timeout_secs.
+	Concurrency      *int
+	RateLimit        *int
+	CheckTimeoutSecs *int
+	RetryCount       *int
+	ProgressEnabled  *bool
+	Ports            []int // network.ports
 }
 
 var cliConfig = newCLIConfig()
@@ -100,9 +154,15 @@ func newCLIConfig() *CLIConfig {
 			TelemetryEnabled: false,
 			RetentionDays:    0,
 			HashAlgorithm:    HashAlgorithmSHA256.String(),
+			AdaptivePause:    true,
+			PreCheck:         true,
+			FlushEvery:       defaultFlushEvery,
+			FlushInterval:    defaultFlushInterval,
+			MaxPauseSecs:     defaultMaxPauseSecs,
 			DNS: DNSConfig{
-				Nameservers: []string{},
-				Timeout:     defaultDNSTimeoutSeconds,
+				Nameservers:       []string{},
+				Timeout:           defaultDNSTimeoutSeconds,
+				ExpiryWarningDays: defaultDomainExpiryWarningDays,
 			},
 			Crawl: CrawlConfig{
 				Enabled:      false,
@@ -111,12 +171,20 @@ func newCLIConfig() *CLIConfig {
 				EnableJS:     false,
 				JSWaitTime:   2,
 				AutoDetectJS: true, // Auto-detect by default when crawling is enabled
+				UseSitemaps:  true,
+				Inventory:    true,
+				CacheTTL:     defaultCrawlCacheTTLHours,
 			},
 			Network: NetworkConfig{
 				EnablePortScan:  false,
 				PortScanTimeout: defaultPortScanTimeoutSecs,
 				Ports:           nil,
 				MaxPortWorkers:  defaultPortScanWorkers,
+				ExposureChecks:  false,
+				AdminPanels:     false,
+				TraceMethod:     defaultTraceMethod,
+				TracePort:       defaultTracePort,
+				TraceMaxHops:    defaultTraceMaxHops,
 			},
 		},
 	}
@@ -132,6 +200,52 @@ func detectOperatorFromEnv() string {
 	return ""
 }
 
+// configureEnvOverrides lets SECA_<KEY> environment variables override any
+// config key, with dots and dashes in the key written as underscores.
+func configureEnvOverrides(v *viper.Viper) {
+	v.SetEnvPrefix(envPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+	v.AutomaticEnv()
+}
+
+// activeProfile returns the profile named by --profile, falling back to
+// $SECA_PROFILE.
+func activeProfile(cmd *cobra.Command) string {
+	if flag := cmd.Flags().Lookup("profile"); flag != nil && flag.Changed {
+		return strings.TrimSpace(flag.Value.String())
+	}
+	return strings.TrimSpace(os.Getenv(profileEnvVar))
+}
+
+// applyConfigProfile layers profiles.<name> from the config file over the
+// top-level settings. Environment variables and flags still take precedence.
+func applyConfigProfile(v *viper.Viper, name string) error {
+	if name == "" {
+		return nil
+	}
+	profile := v.GetStringMap("profiles." + name)
+	if len(profile) == 0 {
+		if available := configProfiles(v); len(available) > 0 {
+			return fmt.Errorf("config profile %q not found (available: %s)", name, strings.Join(available, ", "))
+		}
+		return fmt.Errorf("config profile %q not found: define it under profiles.%s in the config file", name, name)
+	}
+	if err := v.MergeConfigMap(profile); err != nil {
+		return fmt.Errorf("apply config profile %q: %w", name, err)
+	}
+	return nil
+}
+
+// configProfiles returns the names of the profiles defined in the config file.
+func configProfiles(v *viper.Viper) []string {
+	var names []string
+	for name := range v.GetStringMap("profiles") {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func loadDefaultOverrides() defaultOverrides {
 	overrides := defaultOverrides{}
 
@@ -164,6 +278,40 @@ func loadDefaultOverrides() defaultOverrides {
 		overrides.SecureResults = &val
 	}
 
+	if viper.IsSet("check.concurrency") {
+		val := viper.GetInt("check.concurrency")
+		overrides.Concurrency = &val
+	}
+
+	if viper.IsSet("check.rate") {
+		val := viper.GetInt("check.rate")
+		overrides.RateLimit = &val
+	}
+
+	if viper.IsSet("check.timeout") {
+		val := viper.GetInt("check.timeout")
+		overrides.CheckTimeoutSecs = &val
+	}
+
+	if viper.IsSet("check.retry") {
+		val := viper.GetInt("check.retry")
+		overrides.RetryCount = &val
+	}
+
+	if viper.IsSet("check.progress") {
+		val := viper.GetBool("check.progress")
+		overrides.ProgressEnabled = &val
+	}
+
+	if viper.IsSet("network.ports") {
+		ports, err := parsePortList(viper.Get("network.ports"))
+		if err != nil {
+			cliLog().Warnw("config_value_ignored", "key", "network.ports", "error", err, "hint", "run 'seca config validate'")
+		} else {
+			overrides.Ports = ports
+		}
+	}
+
 	return overrides
 }
 
@@ -207,6 +355,43 @@ func applyConfigDefaults(cmd *cobra.Command) {
 	if overrides.SecureResults != nil {
 		cliConfig.Check.SecureResults = *overrides.SecureResults
 	}
+
+	checkFlags := checkCmd.PersistentFlags()
+	if overrides.CheckTimeoutSecs != nil {
+		applyIntDefault(checkFlags, "timeout", *overrides.CheckTimeoutSecs, func(v int) {
+			cliConfig.Check.TimeoutSecs = v
+		})
+	}
+
+	if overrides.Concurrency != nil {
+		applyIntDefault(checkFlags, "concurrency", *overrides.Concurrency, func(v int) {
+			cliConfig.Check.Concurrency = v
+		})
+	}
+
+	if overrides.RateLimit != nil {
+		applyIntDefault(checkFlags, "rate", *overrides.RateLimit, func(v int) {
+			cliConfig.Check.RateLimit = v
+		})
+	}
+
+	if overrides.RetryCount != nil {
+		applyIntDefault(checkFlags, "retry", *overrides.RetryCount, func(v int) {
+			cliConfig.Check.RetryCount = v
+		})
+	}
+
+	if overrides.ProgressEnabled != nil {
+		applyBoolDefault(checkFlags, "progress", *overrides.ProgressEnabled, func(v bool) {
+			cliConfig.Check.ProgressEnabled = v
+		})
+	}
+
+	if overrides.Ports != nil {
+		if flag := checkNetworkCmd.Flags().Lookup("ports"); flag == nil || !flag.Changed {
+			cliConfig.Check.Network.Ports = overrides.Ports
+		}
+	}
 }
 
 func applyIntDefault(flags *pflag.FlagSet, name string, value int, setter func(int)) {
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect and validate the CLI configuration",
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the config file for unknown keys and invalid values",
+	Long: `Check the config file against the settings SECA-CLI understands.
+
+Reports unknown keys (usually typos), values of the wrong type, and invalid
+values such as out-of-range ports, in the top-level settings and in every
+profile. Exits non-zero when any problem is found.
+
+With --checks, also validates the settings each checker would run with after
+config, environment and profile overrides are applied: timeouts, port lists,
+nameservers, crawl limits and scope rules, proxies and headers, including the
+http.engagements.<id> overrides. Check commands run the same validation
+before they start.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		settings, path, err := readConfigFileSettings()
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		checks, _ := cmd.Flags().GetBool("checks")
+
+		invalid := false
+		if path == "" || settings == nil {
+			fmt.Fprintf(out, "%s No config file in use; built-in defaults apply\n", colorInfo("→"))
+		} else if issues := validateConfigSettings(settings); len(issues) == 0 {
+			fmt.Fprintf(out, "%s %s is valid\n", colorSuccess("✓"), path)
+		} else {
+			invalid = true
+			fmt.Fprintf(out, "%s %s has %d problem(s):\n", colorError("✗"), path, len(issues))
+			for _, issue := range issues {
+				fmt.Fprintf(out, "  %s\n", issue)
+			}
+		}
+
+		if checks {
+			issues := allCheckConfigIssues(getAppContext(cmd).Config.Check)
+			if len(issues) == 0 {
+				fmt.Fprintf(out, "%s Check settings are valid\n", colorSuccess("✓"))
+			} else {
+				fmt.Fprintf(out, "%s Check settings have %d problem(s):\n", colorError("✗"), len(issues))
+				for _, issue := range issues {
+					fmt.Fprintf(out, "  %s\n", issue)
+				}
+				if !invalid {
+					return errors.New("check settings are invalid")
+				}
+			}
+		}
+
+		if invalid {
+			return fmt.Errorf("config file %s is invalid", path)
+		}
+		return nil
+	},
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the configuration",
+	Long: `Print the config file settings, or with --effective the fully merged
+configuration with the source of each value:
+
+  flag      set on the command line
+  env       set by a SECA_* environment variable
+  profile   set by the active profile (--profile / SECA_PROFILE)
+  file      set in the config file
+  default   built-in default`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+		effective, _ := cmd.Flags().GetBool("effective")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		out := cmd.OutOrStdout()
+
+		settings, path, err := readConfigFileSettings()
+		if err != nil {
+			return err
+		}
+
+		if !effective {
+			if path == "" || settings == nil {
+				fmt.Fprintf(out, "%s No config file in use; built-in defaults apply\n", colorInfo("→"))
+				return nil
+			}
+			if asJSON {
+				data, err := json.MarshalIndent(settings, jsonPrefix, jsonIndent)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(out, string(data))
+				return nil
+			}
+			fmt.Fprintf(out, "# %s\n", path)
+			for _, key := range configLeafKeys(nil, settings) {
+				fmt.Fprintf(out, "%s = %s\n", key, formatConfigValue(redactConfigValue(key, configTreeValue(settings, strings.Split(key, ".")))))
+			}
+			if profiles := sortedMapKeys(configTreeValue(settings, []string{"profiles"})); len(profiles) > 0 {
+				fmt.Fprintf(out, "# profiles: %s\n", strings.Join(profiles, ", "))
+			}
+			return nil
+		}
+
+		profile := activeProfile(cmd)
+		resolved := resolveEffectiveConfig(cmd, settings, profile)
+		// The results directory and operator defaults are computed at startup.
+		for i := range resolved {
+			if resolved[i].Source != configSourceDefault {
+				continue
+			}
+			switch resolved[i].Key {
+			case "results_dir":
+				resolved[i].Value = appCtx.ResultsDir
+			case "defaults.operator":
+				resolved[i].Value = detectOperatorFromEnv()
+			}
+		}
+
+		if asJSON {
+			data, err := json.MarshalIndent(map[string]any{
+				"config_file": path,
+				"profile":     profile,
+				"settings":    resolved,
+			}, jsonPrefix, jsonIndent)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(out, string(data))
+			return nil
+		}
+
+		if path == "" {
+			path = "(none)"
+		}
+		fmt.Fprintf(out, "Config file: %s\n", path)
+		if profile != "" {
+			fmt.Fprintf(out, "Profile:     %s\n", profile)
+		}
+		fmt.Fprintln(out)
+
+		width := 0
+		for _, s := range resolved {
+			width = max(width, len(s.Key))
+		}
+		for _, s := range resolved {
+			fmt.Fprintf(out, "%-*s  %-30s  %s\n", width, s.Key, formatConfigValue(s.Value), s.Source)
+		}
+		return nil
+	},
+}
+
+func formatConfigValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "(unset)"
+	case string:
+		if v == "" {
+			return `""`
+		}
+		return v
+	case []any, []int, []string:
+		return strings.Trim(strings.ReplaceAll(fmt.Sprint(v), " ", ","), "[]")
+	case map[string]any:
+		parts := make([]string, 0, len(v))
+		for _, k := range sortedMapKeys(v) {
+			parts = append(parts, fmt.Sprintf("%s=%v", k, v[k]))
+		}
+		return strings.Join(parts, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func sortedMapKeys(value any) []string {
+	m, ok := asConfigMap(value)
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func init() {
+	configValidateCmd.Flags().Bool("checks", false, "Also validate the effective settings of every checker")
+	configShowCmd.Flags().Bool("effective", false, "Show the merged configuration with the source of each value")
+	configShowCmd.Flags().Bool("json", false, "Output as JSON")
+
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configShowCmd)
+	rootCmd.AddCommand(configCmd)
+}
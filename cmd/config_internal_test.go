@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -202,3 +203,97 @@ func TestApplyConfigDefaults(t *testing.T) {
 		t.Fatalf("expected operator flag to be set by defaults, got %s", got)
 	}
 }
+
+func TestApplyConfigProfile(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(strings.NewReader(`
+defaults:
+  operator: base
+check:
+  concurrency: 2
+  rate: 5
+profiles:
+  client-a:
+    defaults:
+      operator: alice
+    check:
+      concurrency: 8
+`)); err != nil {
+		t.Fatalf("ReadConfig: %v", err)
+	}
+
+	if err := applyConfigProfile(v, ""); err != nil {
+		t.Fatalf("empty profile should be a no-op: %v", err)
+	}
+	if err := applyConfigProfile(v, "client-b"); err == nil || !strings.Contains(err.Error(), "client-a") {
+		t.Fatalf("expected unknown profile error listing client-a, got %v", err)
+	}
+
+	if err := applyConfigProfile(v, "client-a"); err != nil {
+		t.Fatalf("applyConfigProfile: %v", err)
+	}
+	if got := v.GetString("defaults.operator"); got != "alice" {
+		t.Errorf("expected profile operator, got %s", got)
+	}
+	if got := v.GetInt("check.concurrency"); got != 8 {
+		t.Errorf("expected profile concurrency 8, got %d", got)
+	}
+	if got := v.GetInt("check.rate"); got != 5 {
+		t.Errorf("expected top-level rate to be kept, got %d", got)
+	}
+
+	configureEnvOverrides(v)
+	t.Setenv("SECA_CHECK_CONCURRENCY", "16")
+	if got := v.GetInt("check.concurrency"); got != 16 {
+		t.Errorf("expected environment to override profile, got %d", got)
+	}
+}
+
+func TestActiveProfile(t *testing.T) {
+	cmd := &cobra.Command{Use: "root"}
+	cmd.Flags().String("profile", "", "")
+
+	t.Setenv(profileEnvVar, "from-env")
+	if got := activeProfile(cmd); got != "from-env" {
+		t.Fatalf("expected env profile, got %q", got)
+	}
+	if err := cmd.Flags().Set("profile", "from-flag"); err != nil {
+		t.Fatal(err)
+	}
+	if got := activeProfile(cmd); got != "from-flag" {
+		t.Fatalf("expected flag to win, got %q", got)
+	}
+}
+
+func TestApplyConfigDefaults_CheckSettingsFromEnv(t *testing.T) {
+	t.Cleanup(func() {
+		viper.Reset()
+		*cliConfig = *newCLIConfig()
+	})
+	*cliConfig = *newCLIConfig()
+
+	for _, name := range []string{"timeout", "concurrency", "rate", "retry", "progress"} {
+		if flag := checkCmd.PersistentFlags().Lookup(name); flag != nil {
+			flag.Changed = false
+		}
+	}
+
+	configureEnvOverrides(viper.GetViper())
+	viper.Set("defaults.timeout_secs", 20)
+	t.Setenv("SECA_CHECK_CONCURRENCY", "6")
+	t.Setenv("SECA_CHECK_RATE", "12")
+	t.Setenv("SECA_CHECK_TIMEOUT", "45")
+	t.Setenv("SECA_CHECK_RETRY", "2")
+	t.Setenv("SECA_CHECK_PROGRESS", "true")
+
+	applyConfigDefaults(&cobra.Command{Use: "root"})
+
+	check := cliConfig.Check
+	if check.Concurrency != 6 || check.RateLimit != 12 || check.RetryCount != 2 || !check.ProgressEnabled {
+		t.Fatalf("expected env check settings to apply, got %+v", check)
+	}
+	if check.TimeoutSecs != 45 || cliConfig.Defaults.TimeoutSecs != 20 {
+		t.Fatalf("expected check.timeout to override defaults.timeout_secs for checks, got %d/%d", check.TimeoutSecs, cliConfig.Defaults.TimeoutSecs)
+	}
+}
@@ -0,0 +1,667 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// configKind is the value type a config key accepts.
+type configKind string
+
+const (
+	configString     configKind = "string"
+	configInt        configKind = "integer"
+	configBool       configKind = "boolean"
+	configStringList configKind = "list of strings"
+	configStringMap  configKind = "map of strings"
+	configPortList   configKind = "port list"
+)
+
+// configField describes one config key. Keys may contain "*" segments that
+// match any single name, such as an engagement ID or plugin name.
+type configField struct {
+	Kind     configKind
+	Default  any                   // Built-in value when the key is not set anywhere
+	Flag     string                // Flag that overrides the key, if any
+	Validate func(value any) error // Extra checks beyond the type
+}
+
+// configSchema lists every key the CLI reads from the config file. Keys under
+// profiles.<name> follow the same schema.
+var configSchema = map[string]configField{
+	"results_dir": {Kind: configString},
+
+	"defaults.operator":       {Kind: configString, Flag: "operator"},
+	"defaults.timeout_secs":   {Kind: configInt, Default: defaultHTTPTimeoutSeconds, Validate: validatePositiveInt},
+	"defaults.telemetry":      {Kind: configBool, Default: false},
+	"defaults.retention_days": {Kind: configInt, Default: 0},
+	"defaults.hash_algorithm": {Kind: configString, Default: HashAlgorithmSHA256.String(), Validate: validateHashAlgorithm},
+	"defaults.secure_results": {Kind: configBool, Default: false},
+
+	"check.concurrency": {Kind: configInt, Default: 1, Flag: "concurrency", Validate: validatePositiveInt},
+	"check.rate":        {Kind: configInt, Default: 1, Flag: "rate", Validate: validatePositiveInt},
+	"check.timeout":     {Kind: configInt, Default: defaultHTTPTimeoutSeconds, Flag: "timeout", Validate: validatePositiveInt},
+	"check.retry":       {Kind: configInt, Default: 0, Flag: "retry"},
+	"check.progress":    {Kind: configBool, Default: false, Flag: "progress"},
+
+	"network.ports": {Kind: configPortList, Flag: "ports"},
+
+	"log.level": {Kind: configString, Default: defaultLogLevel, Flag: "log-level", Validate: validateLogLevel},
+	"log.file":  {Kind: configString, Flag: "log-file"},
+
+	"crawl.scope.allow_hosts":   {Kind: configStringList},
+	"crawl.scope.include_paths": {Kind: configStringList},
+	"crawl.scope.exclude_paths": {Kind: configStringList},
+	"crawl.scope.include_regex": {Kind: configStringList},
+	"crawl.scope.exclude_regex": {Kind: configStringList},
+
+	"dns.nameservers": {Kind: configStringList, Flag: "nameservers"},
+
+	"http.proxy":                     {Kind: configString, Validate: validateProxyURL},
+	"http.user_agent":                {Kind: configString},
+	"http.headers":                   {Kind: configStringMap},
+	"http.resolve":                   {Kind: configStringList},
+	"http.favicon_fingerprints":      {Kind: configString},
+	"http.timeouts.dial":             {Kind: configInt, Flag: "dial-timeout", Validate: validatePositiveInt},
+	"http.timeouts.tls_handshake":    {Kind: configInt, Flag: "tls-timeout", Validate: validatePositiveInt},
+	"http.timeouts.response_header":  {Kind: configInt, Flag: "header-timeout", Validate: validatePositiveInt},
+	"http.timeouts.body_read":        {Kind: configInt, Flag: "body-timeout", Validate: validatePositiveInt},
+	"http.engagements.*.proxy":       {Kind: configString, Validate: validateProxyURL},
+	"http.engagements.*.user_agent":  {Kind: configString},
+	"http.engagements.*.headers":     {Kind: configStringMap},
+	"http.engagements.*.resolve":     {Kind: configStringList},
+	"http.engagements.*.nameservers": {Kind: configStringList},
+
+	"report.language": {Kind: configString, Default: "en", Validate: validateReportLanguage},
+
+	"smtp.host":         {Kind: configString},
+	"smtp.port":         {Kind: configInt, Default: 587, Validate: validatePort},
+	"smtp.username":     {Kind: configString},
+	"smtp.from":         {Kind: configString},
+	"smtp.password_env": {Kind: configString, Default: "SECA_SMTP_PASSWORD"},
+	"smtp.starttls":     {Kind: configBool, Default: true},
+	"smtp.smime_cert":   {Kind: configString},
+	"smtp.smime_key":    {Kind: configString},
+
+	"issue_sync.provider":  {Kind: configString, Default: "github"},
+	"issue_sync.repo":      {Kind: configString},
+	"issue_sync.base_url":  {Kind: configString},
+	"issue_sync.token_env": {Kind: configString},
+
+	"telemetry.pushgateway_url": {Kind: configString, Flag: "pushgateway-url"},
+	"telemetry.pushgateway_job": {Kind: configString, Default: "seca"},
+	"telemetry.otlp_endpoint":   {Kind: configString, Flag: "otlp-endpoint"},
+	"telemetry.otlp_headers":    {Kind: configStringMap},
+
+	"telemetry.rotate_size_mb":  {Kind: configInt, Default: 10, Validate: validateNonNegativeInt},
+	"telemetry.rotate_age_days": {Kind: configInt, Default: 0, Validate: validateNonNegativeInt},
+	"telemetry.retain_segments": {Kind: configInt, Default: 5, Validate: validateNonNegativeInt},
+
+	"telemetry.regression.success_drop":      {Kind: configInt, Default: 10},
+	"telemetry.regression.duration_increase": {Kind: configInt, Default: 50},
+	"telemetry.regression.window":            {Kind: configInt, Default: 5, Validate: validatePositiveInt},
+
+	"plugin_registry.url":               {Kind: configString},
+	"plugin_registry.public_key":        {Kind: configString},
+	"plugin_registry.require_signature": {Kind: configBool, Default: false},
+
+	"plugins.*": {Kind: configStringMap},
+
+	"secrets.*.provider": {Kind: configString, Validate: validateSecretProvider},
+	"secrets.*.path":     {Kind: configString},
+	"secrets.*.key":      {Kind: configString},
+	"secrets.*.region":   {Kind: configString},
+
+	"vault.address":   {Kind: configString},
+	"vault.token_env": {Kind: configString, Default: defaultVaultTokenEnv},
+	"vault.namespace": {Kind: configString},
+
+	"aws.region":                  {Kind: configString},
+	"aws.secretsmanager_endpoint": {Kind: configString},
+
+	"webhooks.endpoints.*.url":        {Kind: configString, Validate: validateWebhookURL},
+	"webhooks.endpoints.*.secret_env": {Kind: configString},
+	"webhooks.max_attempts":           {Kind: configInt, Default: 5, Validate: validatePositiveInt},
+	"webhooks.backoff_secs":           {Kind: configInt, Default: 2, Validate: validatePositiveInt},
+	"webhooks.timeout_secs":           {Kind: configInt, Default: 10, Validate: validatePositiveInt},
+
+	"schedules.max_concurrent_jobs": {Kind: configInt, Default: 2, Validate: validatePositiveInt},
+
+	"api.keys.*.api_key_env": {Kind: configString},
+	"api.keys.*.role":        {Kind: configString, Validate: validateAPIRole},
+
+	"workspaces.*.api_key_env":                     {Kind: configString},
+	"workspaces.*.keys.*.api_key_env":              {Kind: configString},
+	"workspaces.*.keys.*.role":                     {Kind: configString, Validate: validateAPIRole},
+	"workspaces.*.data_dir":                        {Kind: configString},
+	"workspaces.*.results_dir":                     {Kind: configString},
+	"workspaces.*.webhooks.endpoints.*.url":        {Kind: configString, Validate: validateWebhookURL},
+	"workspaces.*.webhooks.endpoints.*.secret_env": {Kind: configString},
+}
+
+// ConfigIssue is a problem found while validating the config file.
+type ConfigIssue struct {
+	Key     string `json:"key"`
+	Message string `json:"message"`
+}
+
+func (i ConfigIssue) String() string {
+	return fmt.Sprintf("%s: %s", i.Key, i.Message)
+}
+
+// validateConfigSettings checks a decoded config file against configSchema
+// and returns the problems sorted by key.
+func validateConfigSettings(settings map[string]any) []ConfigIssue {
+	var issues []ConfigIssue
+	walkConfigTree(nil, settings, &issues)
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Key < issues[j].Key })
+	return issues
+}
+
+func walkConfigTree(path []string, tree map[string]any, issues *[]ConfigIssue) {
+	for name, value := range tree {
+		keyPath := append(append([]string(nil), path...), name)
+		key := strings.Join(keyPath, ".")
+		schemaPath := keyPath
+
+		// profiles.<name>.* follow the top-level schema; profiles do not nest.
+		if keyPath[0] == "profiles" {
+			if len(keyPath) == 1 || len(keyPath) == 2 {
+				sub, ok := asConfigMap(value)
+				if !ok {
+					*issues = append(*issues, ConfigIssue{Key: key, Message: "expected a map"})
+					continue
+				}
+				walkConfigTree(keyPath, sub, issues)
+				continue
+			}
+			schemaPath = keyPath[2:]
+			if schemaPath[0] == "profiles" {
+				*issues = append(*issues, ConfigIssue{Key: key, Message: "profiles cannot be nested"})
+				continue
+			}
+		}
+
+		if field, ok := lookupConfigField(schemaPath); ok {
+			if err := checkConfigValue(field, value); err != nil {
+				*issues = append(*issues, ConfigIssue{Key: key, Message: err.Error()})
+			}
+			continue
+		}
+
+		if isConfigBranch(schemaPath) {
+			sub, ok := asConfigMap(value)
+			if !ok {
+				*issues = append(*issues, ConfigIssue{Key: key, Message: "expected a map"})
+				continue
+			}
+			walkConfigTree(keyPath, sub, issues)
+			continue
+		}
+
+		message := "unknown key"
+		if suggestion := suggestConfigKey(schemaPath); suggestion != "" {
+			message = fmt.Sprintf("unknown key (did you mean %s?)", suggestion)
+		}
+		*issues = append(*issues, ConfigIssue{Key: key, Message: message})
+	}
+}
+
+// lookupConfigField returns the schema entry whose pattern matches path.
+func lookupConfigField(path []string) (configField, bool) {
+	for pattern, field := range configSchema {
+		if configPatternMatches(strings.Split(pattern, "."), path) {
+			return field, true
+		}
+	}
+	return configField{}, false
+}
+
+// isConfigBranch reports whether path is a parent of some schema key.
+func isConfigBranch(path []string) bool {
+	for pattern := range configSchema {
+		segments := strings.Split(pattern, ".")
+		if len(segments) > len(path) && configPatternMatches(segments[:len(path)], path) {
+			return true
+		}
+	}
+	return false
+}
+
+func configPatternMatches(pattern, path []string) bool {
+	if len(pattern) != len(path) {
+		return false
+	}
+	for i, segment := range pattern {
+		if segment != "*" && !strings.EqualFold(segment, path[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// suggestConfigKey returns a known key under the same parent that differs
+// from path only slightly, to catch typos such as check.concurency.
+func suggestConfigKey(path []string) string {
+	last := strings.ToLower(path[len(path)-1])
+	best, bestDistance := "", 3
+	for pattern := range configSchema {
+		segments := strings.Split(pattern, ".")
+		if len(segments) != len(path) || !configPatternMatches(segments[:len(path)-1], path[:len(path)-1]) {
+			continue
+		}
+		if d := editDistance(last, segments[len(segments)-1]); d < bestDistance || (d == bestDistance && pattern < best) {
+			best, bestDistance = pattern, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	segments := strings.Split(best, ".")
+	return strings.Join(append(append([]string(nil), path[:len(path)-1]...), segments[len(segments)-1]), ".")
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+func checkConfigValue(field configField, value any) error {
+	switch field.Kind {
+	case configString:
+		if _, ok := value.(string); !ok {
+			return configTypeError(field.Kind, value)
+		}
+	case configInt:
+		if _, ok := value.(int); !ok {
+			return configTypeError(field.Kind, value)
+		}
+	case configBool:
+		if _, ok := value.(bool); !ok {
+			return configTypeError(field.Kind, value)
+		}
+	case configStringList:
+		list, ok := value.([]any)
+		if !ok {
+			return configTypeError(field.Kind, value)
+		}
+		for i, item := range list {
+			if _, ok := item.(string); !ok {
+				return fmt.Errorf("item %d: %w", i, configTypeError(configString, item))
+			}
+		}
+	case configStringMap:
+		entries, ok := asConfigMap(value)
+		if !ok {
+			return configTypeError(field.Kind, value)
+		}
+		for name, item := range entries {
+			switch item.(type) {
+			case string, int, bool, float64:
+			default:
+				return fmt.Errorf("%s: %w", name, configTypeError(configString, item))
+			}
+		}
+	case configPortList:
+		if _, err := parsePortList(value); err != nil {
+			return err
+		}
+	}
+	if field.Validate != nil {
+		return field.Validate(value)
+	}
+	return nil
+}
+
+func configTypeError(want configKind, value any) error {
+	return fmt.Errorf("expected %s, got %s", want, describeConfigValue(value))
+}
+
+func describeConfigValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "empty value"
+	case string:
+		return fmt.Sprintf("string %q", v)
+	case int, int64, float64:
+		return fmt.Sprintf("number %v", v)
+	case bool:
+		return fmt.Sprintf("boolean %v", v)
+	case []any:
+		return "list"
+	case map[string]any:
+		return "map"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func asConfigMap(value any) (map[string]any, bool) {
+	switch m := value.(type) {
+	case map[string]any:
+		return m, true
+	case map[any]any:
+		out := make(map[string]any, len(m))
+		for k, v := range m {
+			out[fmt.Sprint(k)] = v
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+// parsePortList accepts a YAML list of ports or a comma-separated string
+// (as read from SECA_NETWORK_PORTS). Entries may be ports, ranges such as
+// "8000-8100", or presets such as "top100"; see checker.ParsePortSpec.
+func parsePortList(value any) ([]int, error) {
+	var items []string
+	switch v := value.(type) {
+	case []any:
+		for _, item := range v {
+			switch item.(type) {
+			case int, string:
+				items = append(items, fmt.Sprint(item))
+			default:
+				return nil, fmt.Errorf("invalid port %v", item)
+			}
+		}
+	case []int:
+		for _, port := range v {
+			items = append(items, strconv.Itoa(port))
+		}
+	case string:
+		items = append(items, v)
+	default:
+		return nil, configTypeError(configPortList, value)
+	}
+	return checker.ParsePortSpec(strings.Join(items, ","))
+}
+
+// portSpecValue is a pflag.Value that expands a port specification into
+// the bound port list.
+type portSpecValue struct {
+	ports *[]int
+	spec  string
+}
+
+func newPortSpecValue(ports *[]int) *portSpecValue {
+	return &portSpecValue{ports: ports}
+}
+
+func (v *portSpecValue) String() string {
+	if v.spec != "" {
+		return v.spec
+	}
+	if len(*v.ports) == 0 {
+		return ""
+	}
+	parts := make([]string, len(*v.ports))
+	for i, port := range *v.ports {
+		parts[i] = strconv.Itoa(port)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (v *portSpecValue) Set(spec string) error {
+	ports, err := checker.ParsePortSpec(spec)
+	if err != nil {
+		return err
+	}
+	v.spec = spec
+	*v.ports = ports
+	return nil
+}
+
+func (v *portSpecValue) Type() string {
+	return "ports"
+}
+
+func validatePositiveInt(value any) error {
+	if n, ok := value.(int); ok && n < 1 {
+		return fmt.Errorf("must be at least 1, got %d", n)
+	}
+	return nil
+}
+
+func validateNonNegativeInt(value any) error {
+	if n, ok := value.(int); ok && n < 0 {
+		return fmt.Errorf("must not be negative, got %d", n)
+	}
+	return nil
+}
+
+func validatePort(value any) error {
+	if n, ok := value.(int); ok && (n < 1 || n > 65535) {
+		return fmt.Errorf("port %d out of range (1-65535)", n)
+	}
+	return nil
+}
+
+func validateLogLevel(value any) error {
+	if s, ok := value.(string); ok {
+		_, err := parseLogLevel(s)
+		return err
+	}
+	return nil
+}
+
+func validateHashAlgorithm(value any) error {
+	if s, ok := value.(string); ok {
+		_, err := ParseHashAlgorithm(s)
+		return err
+	}
+	return nil
+}
+
+func validateProxyURL(value any) error {
+	s, ok := value.(string)
+	if !ok || strings.TrimSpace(s) == "" {
+		return nil
+	}
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid proxy URL %q", s)
+	}
+	return nil
+}
+
+// readConfigFileSettings decodes the config file on its own, without env or
+// profile overlays, so validation and source tracking see exactly what the
+// file says. It returns nil settings when no config file is in use.
+func readConfigFileSettings() (map[string]any, string, error) {
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		return nil, "", nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil, path, nil
+		}
+		return nil, path, err
+	}
+	fileViper := viper.New()
+	fileViper.SetConfigFile(path)
+	if filepath.Ext(path) == "" {
+		fileViper.SetConfigType("yaml")
+	}
+	if err := fileViper.ReadInConfig(); err != nil {
+		return nil, path, fmt.Errorf("read config file %s: %w", path, err)
+	}
+	return fileViper.AllSettings(), path, nil
+}
+
+// EffectiveSetting is one resolved config value and where it came from.
+type EffectiveSetting struct {
+	Key    string `json:"key"`
+	Value  any    `json:"value"`
+	Source string `json:"source"`
+}
+
+const (
+	configSourceDefault = "default"
+	configSourceFile    = "file"
+	configSourceProfile = "profile"
+	configSourceEnv     = "env"
+	configSourceFlag    = "flag"
+)
+
+// resolveEffectiveConfig reports the value of every schema key after flags,
+// SECA_* environment variables, the active profile, and the config file have
+// been applied, along with the layer that supplied it.
+func resolveEffectiveConfig(cmd *cobra.Command, fileSettings map[string]any, profile string) []EffectiveSetting {
+	keys := map[string]configField{}
+	for pattern, field := range configSchema {
+		if !strings.Contains(pattern, "*") {
+			keys[pattern] = field
+		}
+	}
+	// Wildcard keys only appear when the file (or active profile) names them.
+	layers := []map[string]any{fileSettings}
+	if profile != "" {
+		if sub, ok := asConfigMap(configTreeValue(fileSettings, []string{"profiles", profile})); ok {
+			layers = append(layers, sub)
+		}
+	}
+	for _, layer := range layers {
+		for _, key := range configLeafKeys(nil, layer) {
+			if field, ok := lookupConfigField(strings.Split(key, ".")); ok {
+				keys[key] = field
+			}
+		}
+	}
+
+	names := make([]string, 0, len(keys))
+	for key := range keys {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+
+	envKey := strings.NewReplacer(".", "_", "-", "_")
+	settings := make([]EffectiveSetting, 0, len(names))
+	for _, key := range names {
+		field := keys[key]
+		setting := EffectiveSetting{Key: key, Value: field.Default, Source: configSourceDefault}
+		path := strings.Split(key, ".")
+
+		switch {
+		case field.Flag != "" && cmd != nil && cmd.Flags().Lookup(field.Flag) != nil && cmd.Flags().Lookup(field.Flag).Changed:
+			setting.Value, setting.Source = cmd.Flags().Lookup(field.Flag).Value.String(), configSourceFlag
+		case os.Getenv(envPrefix+"_"+strings.ToUpper(envKey.Replace(key))) != "":
+			setting.Value, setting.Source = viper.Get(key), configSourceEnv+" ("+envPrefix+"_"+strings.ToUpper(envKey.Replace(key))+")"
+		case profile != "" && configTreeValue(fileSettings, append([]string{"profiles", profile}, path...)) != nil:
+			setting.Value, setting.Source = configTreeValue(fileSettings, append([]string{"profiles", profile}, path...)), configSourceProfile+" ("+profile+")"
+		case configTreeValue(fileSettings, path) != nil:
+			setting.Value, setting.Source = configTreeValue(fileSettings, path), configSourceFile
+		}
+
+		setting.Value = redactConfigValue(key, setting.Value)
+		settings = append(settings, setting)
+	}
+	return settings
+}
+
+// configTreeValue returns the value at path in a decoded config tree, or nil.
+func configTreeValue(tree map[string]any, path []string) any {
+	var current any = tree
+	for _, segment := range path {
+		m, ok := asConfigMap(current)
+		if !ok {
+			return nil
+		}
+		next, found := m[segment]
+		if !found {
+			// Viper lower-cases keys; engagement IDs and names may not be.
+			for name, v := range m {
+				if strings.EqualFold(name, segment) {
+					next, found = v, true
+					break
+				}
+			}
+		}
+		if !found {
+			return nil
+		}
+		current = next
+	}
+	return current
+}
+
+// configLeafKeys lists the dotted keys of the leaves in tree that match the
+// schema, treating map-valued keys such as http.headers as leaves.
+func configLeafKeys(path []string, tree map[string]any) []string {
+	var keys []string
+	for name, value := range tree {
+		keyPath := append(append([]string(nil), path...), name)
+		if keyPath[0] == "profiles" {
+			continue
+		}
+		if _, ok := lookupConfigField(keyPath); ok {
+			keys = append(keys, strings.Join(keyPath, "."))
+			continue
+		}
+		if sub, ok := asConfigMap(value); ok {
+			keys = append(keys, configLeafKeys(keyPath, sub)...)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sensitiveHeaders are masked when printing the effective config.
+var sensitiveHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+	"x-api-key":           true,
+	"x-auth-token":        true,
+}
+
+func redactConfigValue(key string, value any) any {
+	if strings.HasSuffix(key, ".headers") {
+		if headers, ok := asConfigMap(value); ok {
+			out := make(map[string]any, len(headers))
+			for name, v := range headers {
+				if sensitiveHeaders[strings.ToLower(name)] {
+					v = "[redacted]"
+				}
+				out[name] = v
+			}
+			return out
+		}
+	}
+	if strings.HasSuffix(key, ".proxy") {
+		if s, ok := value.(string); ok {
+			if u, err := url.Parse(s); err == nil && u.User != nil {
+				if _, hasPassword := u.User.Password(); hasPassword {
+					u.User = url.UserPassword(u.User.Username(), "redacted")
+					return u.String()
+				}
+			}
+		}
+	}
+	return value
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func loadTestConfigFile(t *testing.T, body string) map[string]any {
+	t.Helper()
+	t.Cleanup(viper.Reset)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	viper.SetConfigFile(path)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	settings, used, err := readConfigFileSettings()
+	if err != nil {
+		t.Fatalf("readConfigFileSettings: %v", err)
+	}
+	if used != path {
+		t.Fatalf("expected config path %s, got %s", path, used)
+	}
+	return settings
+}
+
+func TestValidateConfigSettings(t *testing.T) {
+	settings := loadTestConfigFile(t, `
+results_dir: /srv/seca
+check:
+  concurency: 4
+  rate: fast
+network:
+  ports: [80, 443, 70000]
+http:
+  proxy: not a url
+  engagements:
+    eng-1:
+      headers:
+        X-Team: red
+plugins:
+  custom:
+    level: high
+profiles:
+  client-a:
+    check:
+      retry: true
+    unknown: 1
+`)
+
+	issues := validateConfigSettings(settings)
+	got := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		got = append(got, issue.String())
+	}
+	want := []string{
+		"check.concurency: unknown key (did you mean check.concurrency?)",
+		`check.rate: expected integer, got string "fast"`,
+		`http.proxy: invalid proxy URL "not a url"`,
+		"network.ports: port 70000 out of range (1-65535)",
+		"profiles.client-a.check.retry: expected integer, got boolean true",
+		"profiles.client-a.unknown: unknown key",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected issues:\n got  %q\n want %q", got, want)
+	}
+}
+
+func TestValidateConfigSettings_Valid(t *testing.T) {
+	settings := loadTestConfigFile(t, `
+defaults:
+  operator: alice
+  hash_algorithm: sha512
+check:
+  concurrency: 4
+network:
+  ports: "22,80,443"
+crawl:
+  scope:
+    allow_hosts: [api.example.com]
+`)
+	if issues := validateConfigSettings(settings); len(issues) != 0 {
+		t.Fatalf("expected no issues, got %v", issues)
+	}
+}
+
+func TestParsePortList(t *testing.T) {
+	ports, err := parsePortList("22, 80,443")
+	if err != nil || !reflect.DeepEqual(ports, []int{22, 80, 443}) {
+		t.Fatalf("unexpected result %v (err %v)", ports, err)
+	}
+	ports, err = parsePortList([]any{"top100", "9001-9002", 22})
+	if err != nil || len(ports) != 102 {
+		t.Fatalf("expected presets and ranges to expand, got %d ports (err %v)", len(ports), err)
+	}
+	for _, bad := range []any{"80,http", []any{0}, "", true, "90-80"} {
+		if _, err := parsePortList(bad); err == nil {
+			t.Fatalf("expected error for %#v", bad)
+		}
+	}
+}
+
+func TestPortSpecValue(t *testing.T) {
+	var ports []int
+	value := newPortSpecValue(&ports)
+	if err := value.Set("1-3,443"); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ports, []int{1, 2, 3, 443}) || value.String() != "1-3,443" {
+		t.Fatalf("unexpected ports %v / %q", ports, value.String())
+	}
+	if err := value.Set("nope"); err == nil {
+		t.Fatal("expected an error for an invalid spec")
+	}
+}
+
+func TestResolveEffectiveConfig_Sources(t *testing.T) {
+	settings := loadTestConfigFile(t, `
+check:
+  concurrency: 2
+  rate: 5
+http:
+  headers:
+    Authorization: Bearer secret
+profiles:
+  client-a:
+    check:
+      rate: 3
+`)
+	t.Setenv("SECA_CHECK_RETRY", "4")
+	configureEnvOverrides(viper.GetViper())
+
+	resolved := map[string]EffectiveSetting{}
+	for _, s := range resolveEffectiveConfig(nil, settings, "client-a") {
+		resolved[s.Key] = s
+	}
+
+	cases := map[string]struct {
+		value  any
+		source string
+	}{
+		"check.concurrency": {2, configSourceFile},
+		"check.rate":        {3, "profile (client-a)"},
+		"check.retry":       {"4", "env (SECA_CHECK_RETRY)"},
+		"check.timeout":     {defaultHTTPTimeoutSeconds, configSourceDefault},
+	}
+	for key, want := range cases {
+		got := resolved[key]
+		if got.Value != want.value || got.Source != want.source {
+			t.Fatalf("%s: expected %v from %s, got %v from %s", key, want.value, want.source, got.Value, got.Source)
+		}
+	}
+
+	headers, ok := resolved["http.headers"].Value.(map[string]any)
+	if !ok || headers["authorization"] != "[redacted]" {
+		t.Fatalf("expected authorization header to be redacted, got %v", resolved["http.headers"].Value)
+	}
+}
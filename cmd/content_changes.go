@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+)
+
+// contentHashesFilename tracks the normalized content fingerprint of each
+// checked URL across runs.
+const contentHashesFilename = "content_hashes.json"
+
+// loadContentStore returns the engagement's content fingerprints, or an
+// empty store when none were recorded.
+func loadContentStore(resultsDir, engagementID string) (*checker.ContentStore, error) {
+	path, err := resolveResultsPath(resultsDir, engagementID, contentHashesFilename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return checker.NewContentStore(), nil
+		}
+		return nil, err
+	}
+	store := checker.NewContentStore()
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", contentHashesFilename, err)
+	}
+	return store, nil
+}
+
+func saveContentStore(resultsDir, engagementID string, store *checker.ContentStore) error {
+	if _, err := ensureResultsDir(resultsDir, engagementID); err != nil {
+		return err
+	}
+	path, err := resolveResultsPath(resultsDir, engagementID, contentHashesFilename)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(store, jsonPrefix, jsonIndent)
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFile(path, data, consts.DefaultFilePerm)
+}
+
+// observeContentHashes records the content fingerprint of every result and
+// returns the URLs whose content changed significantly since the previous
+// run.
+func observeContentHashes(resultsDir, engagementID string, results []checker.CheckResult, at time.Time) ([]checker.ContentChange, error) {
+	store, err := loadContentStore(resultsDir, engagementID)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := append([]checker.CheckResult(nil), results...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Target < sorted[j].Target })
+
+	observed := 0
+	var changes []checker.ContentChange
+	for _, r := range sorted {
+		if r.Content == nil {
+			continue
+		}
+		observed++
+		if change := store.Observe(r.Target, r.Content, at); change != nil {
+			changes = append(changes, *change)
+		}
+	}
+
+	if observed == 0 {
+		return nil, nil
+	}
+	if err := saveContentStore(resultsDir, engagementID, store); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// trackContentChanges observes content fingerprints after a run and tells the
+// operator about significant changes. Failures are logged; they never fail
+// the run.
+func trackContentChanges(resultsDir, engagementID string, results []checker.CheckResult) {
+	changes, err := observeContentHashes(resultsDir, engagementID, results, time.Now().UTC())
+	if err != nil {
+		cliLog().Warnw("content_hashes_save_failed", "engagement_id", engagementID, "error", err)
+		return
+	}
+	for _, c := range changes {
+		fmt.Printf("%s Content of %s changed since %s (%.0f%% similar)\n", colorWarn("!"), c.Target, c.PreviousSeen.Format(time.DateOnly), c.Similarity*100)
+		cliLog().Infow("content_changed",
+			"engagement_id", engagementID,
+			"target", c.Target,
+			"previous", c.Previous,
+			"current", c.Current,
+			"similarity", c.Similarity,
+		)
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func contentResult(target, body string) checker.CheckResult {
+	return checker.CheckResult{Target: target, Status: "ok", Content: checker.FingerprintContent([]byte(body))}
+}
+
+func TestObserveContentHashes(t *testing.T) {
+	resultsDir := t.TempDir()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	home := "<h1>Example Bank</h1><p>Secure online banking for personal and business customers. Log in to manage your accounts and payments.</p>"
+	about := "<h1>About us</h1><p>Founded as a cooperative, we serve members across the country with branches in every major city.</p>"
+
+	first := []checker.CheckResult{
+		contentResult("https://example.com/", home),
+		contentResult("https://example.com/about", about),
+		{Target: "https://example.com/down", Status: "error"},
+	}
+	changes, err := observeContentHashes(resultsDir, "eng-1", first, now)
+	if err != nil || len(changes) != 0 {
+		t.Fatalf("first run should only record baselines, got %v (err %v)", changes, err)
+	}
+
+	second := []checker.CheckResult{
+		contentResult("https://example.com/", "<h1>Defaced</h1><p>This site has been taken over. Nothing here is real anymore, move along.</p>"),
+		contentResult("https://example.com/about", about),
+	}
+	changes, err = observeContentHashes(resultsDir, "eng-1", second, now.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("observeContentHashes: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Target != "https://example.com/" {
+		t.Fatalf("expected one change on the home page, got %+v", changes)
+	}
+
+	store, err := loadContentStore(resultsDir, "eng-1")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(store.Baselines) != 2 || len(store.Changes) != 1 {
+		t.Fatalf("unexpected stored content %+v", store)
+	}
+
+	output := &RunOutput{
+		Metadata:       RunMetadata{EngagementID: "eng-1", StartAt: now, CompleteAt: now},
+		Results:        second,
+		ContentChanges: store.Changes,
+	}
+	data := buildTemplateData(output, nil, "%.2f", nil)
+	found := false
+	for _, v := range data.Vulnerabilities {
+		found = found || v.Name == "Significant Content Change"
+	}
+	if !found {
+		t.Errorf("expected a content change finding, got %+v", data.Vulnerabilities)
+	}
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+)
+
+// crawlCacheFilename holds the pages discovered per target so repeated runs
+// within an engagement can skip the crawl.
+const crawlCacheFilename = "crawl_cache.json"
+
+// defaultCrawlCacheTTLHours is how many hours a cached crawl is reused.
+const defaultCrawlCacheTTLHours = 24
+
+// crawlCacheEntry is the discovery result for one target. Key fingerprints
+// the crawl settings; an entry is only reused by a crawl with the same key.
+type crawlCacheEntry struct {
+	Key        string    `json:"key"`
+	Discovered []string  `json:"discovered"`
+	CrawledAt  time.Time `json:"crawled_at"`
+}
+
+// crawlCache is the per-engagement store of crawl results.
+type crawlCache struct {
+	path    string
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]crawlCacheEntry
+	dirty   bool
+}
+
+// openCrawlCache loads the engagement's crawl cache, or returns nil when
+// caching is disabled.
+func openCrawlCache(resultsDir, engagementID string, crawl CrawlConfig) (*crawlCache, error) {
+	if !crawl.Enabled || !crawl.Cache {
+		return nil, nil
+	}
+	ttlHours := crawl.CacheTTL
+	if ttlHours <= 0 {
+		ttlHours = defaultCrawlCacheTTLHours
+	}
+	if _, err := ensureResultsDir(resultsDir, engagementID); err != nil {
+		return nil, err
+	}
+	path, err := resolveResultsPath(resultsDir, engagementID, crawlCacheFilename)
+	if err != nil {
+		return nil, err
+	}
+	cache := &crawlCache{
+		path:    path,
+		ttl:     time.Duration(ttlHours) * time.Hour,
+		now:     time.Now,
+		entries: make(map[string]crawlCacheEntry),
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cache, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &cache.entries); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", crawlCacheFilename, err)
+	}
+	return cache, nil
+}
+
+// crawlCacheKey fingerprints the settings that change what a crawl discovers:
+// depth and page limits, crawler mode, robots and sitemap handling, scope
+// rules, and whether requests carry session credentials.
+func crawlCacheKey(crawl CrawlConfig, mode, scopeNote string, authenticated bool) string {
+	parts := []string{
+		"depth=" + strconv.Itoa(crawl.MaxDepth),
+		"pages=" + strconv.Itoa(crawl.MaxPages),
+		"mode=" + mode,
+		"robots=" + strconv.FormatBool(!crawl.IgnoreRobots),
+		"sitemaps=" + strconv.FormatBool(crawl.UseSitemaps),
+		"scope=" + scopeNote,
+		"auth=" + strconv.FormatBool(authenticated),
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Lookup returns the cached discovery for target when it was made with the
+// same settings and is younger than the TTL.
+func (c *crawlCache) Lookup(target, key string) (crawlCacheEntry, bool) {
+	if c == nil {
+		return crawlCacheEntry{}, false
+	}
+	entry, ok := c.entries[canonicalTarget(target)]
+	if !ok || entry.Key != key || c.now().Sub(entry.CrawledAt) > c.ttl {
+		return crawlCacheEntry{}, false
+	}
+	return entry, true
+}
+
+// Store records a fresh discovery for target.
+func (c *crawlCache) Store(target, key string, discovered []string) {
+	if c == nil {
+		return
+	}
+	c.entries[canonicalTarget(target)] = crawlCacheEntry{
+		Key:        key,
+		Discovered: append([]string{}, discovered...),
+		CrawledAt:  c.now().UTC(),
+	}
+	c.dirty = true
+}
+
+// Save writes the cache when it changed, dropping expired entries.
+func (c *crawlCache) Save() error {
+	if c == nil || !c.dirty {
+		return nil
+	}
+	for target, entry := range c.entries {
+		if c.now().Sub(entry.CrawledAt) > c.ttl {
+			delete(c.entries, target)
+		}
+	}
+	data, err := json.MarshalIndent(c.entries, jsonPrefix, jsonIndent)
+	if err != nil {
+		return err
+	}
+	if err := fileutil.WriteFile(c.path, data, consts.DefaultFilePerm); err != nil {
+		return err
+	}
+	c.dirty = false
+	return nil
+}
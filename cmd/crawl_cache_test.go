@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCrawlCache_DisabledReturnsNil(t *testing.T) {
+	cache, err := openCrawlCache(t.TempDir(), "eng-cache", CrawlConfig{Enabled: true})
+	if err != nil || cache != nil {
+		t.Fatalf("expected nil cache when disabled, got %v %v", cache, err)
+	}
+	// A nil cache never hits and ignores writes.
+	if _, ok := cache.Lookup("https://example.com", "key"); ok {
+		t.Fatal("nil cache reported a hit")
+	}
+	cache.Store("https://example.com", "key", []string{"https://example.com/a"})
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Save on nil cache: %v", err)
+	}
+}
+
+func TestCrawlCache_RoundTripKeyAndTTL(t *testing.T) {
+	resultsDir := t.TempDir()
+	crawl := CrawlConfig{Enabled: true, Cache: true, CacheTTL: 2, MaxDepth: 2, MaxPages: 10}
+	key := crawlCacheKey(crawl, "static", "", false)
+
+	cache, err := openCrawlCache(resultsDir, "eng-cache", crawl)
+	if err != nil {
+		t.Fatalf("openCrawlCache: %v", err)
+	}
+	cache.Store("https://example.com", key, []string{"https://example.com/about", "https://example.com/app"})
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	reopened, err := openCrawlCache(resultsDir, "eng-cache", crawl)
+	if err != nil {
+		t.Fatalf("openCrawlCache: %v", err)
+	}
+	entry, ok := reopened.Lookup("https://example.com/", key)
+	if !ok || len(entry.Discovered) != 2 {
+		t.Fatalf("expected cached pages for the canonical target, got %+v %v", entry, ok)
+	}
+
+	deeper := crawl
+	deeper.MaxDepth = 3
+	if _, ok := reopened.Lookup("https://example.com", crawlCacheKey(deeper, "static", "", false)); ok {
+		t.Error("expected a miss when the crawl depth changed")
+	}
+	if _, ok := reopened.Lookup("https://example.com", crawlCacheKey(crawl, "static", "", true)); ok {
+		t.Error("expected a miss for an authenticated crawl")
+	}
+
+	reopened.now = func() time.Time { return time.Now().Add(3 * time.Hour) }
+	if _, ok := reopened.Lookup("https://example.com", key); ok {
+		t.Error("expected a miss after the TTL")
+	}
+}
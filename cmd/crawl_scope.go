@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/spf13/viper"
+)
+
+// resolveCrawlScope builds the crawl scope from the --crawl-* scope flags,
+// falling back to crawl.scope.* in config for rules not given on the command
+// line. It returns nil when no rule is set, which keeps crawls same-host.
+// Allowed hosts that are not part of the engagement scope are reported, since
+// crawling them extends testing beyond the listed targets.
+func resolveCrawlScope(crawl CrawlConfig, engagementScope []string) (*checker.CrawlScope, error) {
+	scope, err := buildCrawlScope(crawl)
+	if err != nil || scope == nil {
+		return nil, err
+	}
+
+	for _, rule := range crawlHostsOutsideScope(scope, engagementScope) {
+		cliLog().Warnw("crawl_host_outside_scope", "host", rule, "hint", "confirm it is authorized")
+	}
+	return scope, nil
+}
+
+// buildCrawlScope builds the crawl scope without checking it against an
+// engagement.
+func buildCrawlScope(crawl CrawlConfig) (*checker.CrawlScope, error) {
+	pick := func(values []string, key string) []string {
+		if len(values) > 0 {
+			return values
+		}
+		return viper.GetStringSlice("crawl.scope." + key)
+	}
+
+	scope, err := checker.NewCrawlScope(
+		pick(crawl.AllowHosts, "allow_hosts"),
+		pick(crawl.IncludePaths, "include_paths"),
+		pick(crawl.ExcludePaths, "exclude_paths"),
+		pick(crawl.IncludeRegex, "include_regex"),
+		pick(crawl.ExcludeRegex, "exclude_regex"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("crawl scope: %w", err)
+	}
+	return scope, nil
+}
+
+// crawlHostsOutsideScope returns the allowed-host rules that match no host in
+// the engagement scope.
+func crawlHostsOutsideScope(scope *checker.CrawlScope, engagementScope []string) []string {
+	var outside []string
+	for _, rule := range scope.AllowHosts {
+		single := &checker.CrawlScope{AllowHosts: []string{rule}}
+		covered := false
+		for _, entry := range engagementScope {
+			target := checker.ParseTarget(entry)
+			if target != nil && single.HostAllowed(target.Host) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			outside = append(outside, rule)
+		}
+	}
+	return outside
+}
+
+// crawlScopeAuditNote summarises the crawl scope rules for the audit trail.
+func crawlScopeAuditNote(scope *checker.CrawlScope) string {
+	if scope == nil {
+		return ""
+	}
+	var parts []string
+	if len(scope.AllowHosts) > 0 {
+		parts = append(parts, "hosts="+strings.Join(scope.AllowHosts, ","))
+	}
+	if len(scope.IncludePaths) > 0 {
+		parts = append(parts, "include="+strings.Join(scope.IncludePaths, ","))
+	}
+	if len(scope.ExcludePaths) > 0 {
+		parts = append(parts, "exclude="+strings.Join(scope.ExcludePaths, ","))
+	}
+	if n := len(scope.IncludePatterns) + len(scope.ExcludePatterns); n > 0 {
+		parts = append(parts, fmt.Sprintf("patterns=%d", n))
+	}
+	return "crawl scope: " + strings.Join(parts, " ")
+}
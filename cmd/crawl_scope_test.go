@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestResolveCrawlScope(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	scope, err := resolveCrawlScope(CrawlConfig{}, []string{"app.example.com"})
+	if err != nil || scope != nil {
+		t.Fatalf("expected no scope without rules, got %+v (%v)", scope, err)
+	}
+
+	viper.Set("crawl.scope.allow_hosts", []string{"api.example.com"})
+	viper.Set("crawl.scope.exclude_paths", []string{"/logout"})
+
+	scope, err = resolveCrawlScope(CrawlConfig{ExcludePaths: []string{"/admin"}}, []string{"app.example.com"})
+	if err != nil {
+		t.Fatalf("resolveCrawlScope: %v", err)
+	}
+	if len(scope.AllowHosts) != 1 || scope.AllowHosts[0] != "api.example.com" {
+		t.Fatalf("expected allowed host from config, got %v", scope.AllowHosts)
+	}
+	if len(scope.ExcludePaths) != 1 || scope.ExcludePaths[0] != "/admin" {
+		t.Fatalf("expected flag to override config exclude paths, got %v", scope.ExcludePaths)
+	}
+
+	note := crawlScopeAuditNote(scope)
+	if !strings.Contains(note, "hosts=api.example.com") || !strings.Contains(note, "exclude=/admin") {
+		t.Fatalf("unexpected audit note %q", note)
+	}
+
+	if _, err := resolveCrawlScope(CrawlConfig{IncludeRegex: []string{"[a-"}}, nil); err == nil {
+		t.Fatal("expected error for invalid regex")
+	}
+}
+
+func TestCrawlHostsOutsideScope(t *testing.T) {
+	scope, err := resolveCrawlScope(CrawlConfig{AllowHosts: []string{"*.example.com", "api.example.com", "cdn.other.test"}}, nil)
+	if err != nil {
+		t.Fatalf("resolveCrawlScope: %v", err)
+	}
+
+	outside := crawlHostsOutsideScope(scope, []string{"https://app.example.com/login", "api.example.com:8443"})
+	if len(outside) != 1 || outside[0] != "cdn.other.test" {
+		t.Fatalf("expected only cdn.other.test outside scope, got %v", outside)
+	}
+}
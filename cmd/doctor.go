@@ -0,0 +1,473 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	doctorDefaultTarget = "https://example.com"
+	// Audit timestamps are only as good as the local clock.
+	doctorClockSkewWarn = time.Minute
+	doctorClockSkewFail = 5 * time.Minute
+)
+
+// Doctor check outcomes.
+const (
+	doctorOK   = "ok"
+	doctorWarn = "warn"
+	doctorFail = "fail"
+	doctorSkip = "skip"
+)
+
+// DoctorResult is the outcome of one preflight check.
+type DoctorResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+	Fix    string `json:"fix,omitempty"`
+}
+
+// doctorOptions controls which environment the preflight checks probe.
+type doctorOptions struct {
+	ResultsDir string
+	Target     string   // URL used for DNS, connectivity, and clock checks
+	Proxy      *url.URL // Upstream proxy checks will use
+	Offline    bool
+	Timeout    time.Duration
+	GPGKey     string
+	NeedGPG    bool // Signing or encryption is enabled, so a missing key is fatal
+	Now        func() time.Time
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Run preflight diagnostics before an engagement",
+	Long: `Check that this machine is ready to run an engagement:
+
+  - results directory exists and is writable
+  - DNS resolution works
+  - outbound HTTPS connectivity (through the configured proxy)
+  - a headless Chrome/Chromium is available for JS crawling and screenshots
+  - GPG and the signing key are available
+  - installed plugins pass their handshake
+  - the local clock agrees with the target's clock
+
+Each problem is printed with a suggested fix. Exits non-zero if any check fails.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		engagementID, _ := cmd.Flags().GetString("id")
+		target, _ := cmd.Flags().GetString("target")
+		offline, _ := cmd.Flags().GetBool("offline")
+		timeoutSecs, _ := cmd.Flags().GetInt("timeout")
+		gpgKey, _ := cmd.Flags().GetString("gpg-key")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		if target == "" && engagementID != "" {
+			eng, err := appCtx.Services.EngagementService.GetEngagement(ctx, engagementID)
+			if err != nil {
+				if errors.Is(err, sharedErrors.ErrEngagementNotFound) {
+					return fmt.Errorf("engagement %s not found", engagementID)
+				}
+				return fmt.Errorf("failed to get engagement: %w", err)
+			}
+			if scope := eng.Scope(); len(scope) > 0 {
+				target = scope[0]
+			}
+		}
+		if target == "" {
+			target = doctorDefaultTarget
+		}
+
+		proxy, err := resolveProxy(engagementID, appCtx.Config.Check.Request)
+		if err != nil {
+			return err
+		}
+		if gpgKey == "" {
+			gpgKey = appCtx.Config.Check.GPGKey
+		}
+
+		results := runDoctorChecks(ctx, doctorOptions{
+			ResultsDir: appCtx.ResultsDir,
+			Target:     target,
+			Proxy:      proxy,
+			Offline:    offline,
+			Timeout:    time.Duration(timeoutSecs) * time.Second,
+			GPGKey:     gpgKey,
+			NeedGPG:    appCtx.Config.Check.AutoSign || appCtx.Config.Check.SecureResults,
+			Now:        time.Now,
+		})
+
+		failures := 0
+		for _, r := range results {
+			if r.Status == doctorFail {
+				failures++
+			}
+		}
+
+		out := cmd.OutOrStdout()
+		if asJSON {
+			data, err := json.MarshalIndent(results, jsonPrefix, jsonIndent)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(out, string(data))
+		} else {
+			printDoctorResults(cmd, results)
+		}
+
+		if failures > 0 {
+			return fmt.Errorf("doctor found %d failing check(s)", failures)
+		}
+		return nil
+	},
+}
+
+// runDoctorChecks runs every preflight check in a fixed order.
+func runDoctorChecks(ctx context.Context, opts doctorOptions) []DoctorResult {
+	if opts.Timeout <= 0 {
+		opts.Timeout = time.Duration(defaultHTTPTimeoutSeconds) * time.Second
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	results := []DoctorResult{checkResultsDirWritable(opts.ResultsDir)}
+
+	if opts.Offline {
+		for _, name := range []string{"DNS resolution", "Outbound connectivity", "Clock skew"} {
+			results = append(results, DoctorResult{Name: name, Status: doctorSkip, Detail: "skipped (--offline)"})
+		}
+	} else {
+		host := doctorTargetHost(opts.Target)
+		results = append(results, checkDNSResolution(ctx, host, opts.Timeout))
+		connectivity, serverDate := checkOutboundConnectivity(ctx, opts.Target, opts.Proxy, opts.Timeout, opts.Now)
+		results = append(results, connectivity, checkClockSkew(serverDate, opts.Now()))
+	}
+
+	results = append(results,
+		checkHeadlessBrowser(),
+		checkGPG(ctx, opts.GPGKey, opts.NeedGPG),
+	)
+	results = append(results, checkPluginHealth(ctx)...)
+	return results
+}
+
+func printDoctorResults(cmd *cobra.Command, results []DoctorResult) {
+	out := cmd.OutOrStdout()
+	fmt.Fprintln(out, "SECA-CLI Preflight Checks")
+	fmt.Fprintln(out, "=========================")
+	fmt.Fprintln(out)
+
+	width := 0
+	for _, r := range results {
+		width = max(width, len(r.Name))
+	}
+
+	counts := map[string]int{}
+	for _, r := range results {
+		counts[r.Status]++
+		var icon string
+		switch r.Status {
+		case doctorOK:
+			icon = colorSuccess("✓")
+		case doctorWarn:
+			icon = colorWarn("!")
+		case doctorFail:
+			icon = colorError("✗")
+		default:
+			icon = colorInfo("-")
+		}
+		fmt.Fprintf(out, "%s %-*s  %s\n", icon, width, r.Name, r.Detail)
+		if r.Fix != "" && (r.Status == doctorWarn || r.Status == doctorFail) {
+			fmt.Fprintf(out, "  %*s  fix: %s\n", width, "", r.Fix)
+		}
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "%d ok, %d warning(s), %d failed, %d skipped\n", counts[doctorOK], counts[doctorWarn], counts[doctorFail], counts[doctorSkip])
+}
+
+func checkResultsDirWritable(dir string) DoctorResult {
+	result := DoctorResult{Name: "Results directory"}
+	info, err := os.Stat(dir)
+	if err != nil {
+		result.Status, result.Detail = doctorFail, fmt.Sprintf("%s: %v", dir, err)
+		result.Fix = "create it or set results_dir in ~/.seca-cli.yaml to a writable location"
+		return result
+	}
+	if !info.IsDir() {
+		result.Status, result.Detail = doctorFail, dir+" is not a directory"
+		result.Fix = "point results_dir at a directory"
+		return result
+	}
+
+	probe, err := os.CreateTemp(dir, ".seca-doctor-*")
+	if err != nil {
+		result.Status, result.Detail = doctorFail, fmt.Sprintf("%s is not writable: %v", dir, err)
+		result.Fix = fmt.Sprintf("fix ownership/permissions (e.g. chmod u+rwx %s) or choose another results_dir", dir)
+		return result
+	}
+	name := probe.Name()
+	_ = probe.Close()
+	_ = os.Remove(name)
+
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o002 != 0 {
+		result.Status = doctorWarn
+		result.Detail = fmt.Sprintf("%s is world-writable (%s); evidence could be altered by other users", dir, info.Mode().Perm())
+		result.Fix = fmt.Sprintf("chmod o-w %s", dir)
+		return result
+	}
+
+	result.Status, result.Detail = doctorOK, dir+" is writable"
+	return result
+}
+
+// doctorTargetHost extracts the host name from a URL or bare host[:port].
+func doctorTargetHost(target string) string {
+	if u, err := url.Parse(target); err == nil && u.Hostname() != "" {
+		return u.Hostname()
+	}
+	if host, _, err := net.SplitHostPort(target); err == nil {
+		return host
+	}
+	return strings.TrimSpace(target)
+}
+
+// doctorTargetURL turns a scope entry into an HTTPS URL to probe.
+func doctorTargetURL(target string) string {
+	if strings.Contains(target, "://") {
+		return target
+	}
+	return "https://" + strings.TrimSpace(target)
+}
+
+func checkDNSResolution(ctx context.Context, host string, timeout time.Duration) DoctorResult {
+	result := DoctorResult{Name: "DNS resolution"}
+	if ip := net.ParseIP(host); ip != nil {
+		result.Status, result.Detail = doctorSkip, host+" is an IP address"
+		return result
+	}
+
+	lookupCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupHost(lookupCtx, host)
+	if err != nil {
+		result.Status, result.Detail = doctorFail, fmt.Sprintf("lookup %s: %v", host, err)
+		result.Fix = "check /etc/resolv.conf or VPN DNS settings, or pass --nameservers to check dns"
+		return result
+	}
+	result.Status, result.Detail = doctorOK, fmt.Sprintf("%s resolves to %s", host, strings.Join(addrs, ", "))
+	return result
+}
+
+// checkOutboundConnectivity sends a HEAD request to target through proxy and
+// returns the server's Date header for the clock check.
+func checkOutboundConnectivity(ctx context.Context, target string, proxy *url.URL, timeout time.Duration, now func() time.Time) (DoctorResult, time.Time) {
+	result := DoctorResult{Name: "Outbound connectivity"}
+	targetURL := doctorTargetURL(target)
+	via := "direct"
+	if proxy != nil {
+		via = "via proxy " + proxy.Redacted()
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodHead, targetURL, nil)
+	if err != nil {
+		result.Status, result.Detail = doctorFail, fmt.Sprintf("invalid target %s: %v", targetURL, err)
+		result.Fix = "pass a URL or host name with --target"
+		return result, time.Time{}
+	}
+
+	client := &http.Client{Transport: checker.NewTransport(proxy), Timeout: timeout}
+	start := now()
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Status, result.Detail = doctorFail, fmt.Sprintf("%s (%s): %v", targetURL, via, err)
+		if proxy != nil {
+			result.Fix = "check that the proxy is running and reachable, or set http.proxy to \"direct\""
+		} else {
+			result.Fix = "check firewall/VPN rules for outbound HTTPS, or configure http.proxy"
+		}
+		return result, time.Time{}
+	}
+	_ = resp.Body.Close()
+
+	result.Status = doctorOK
+	result.Detail = fmt.Sprintf("%s answered %d in %s (%s)", targetURL, resp.StatusCode, now().Sub(start).Round(time.Millisecond), via)
+
+	serverDate, _ := http.ParseTime(resp.Header.Get("Date"))
+	return result, serverDate
+}
+
+func checkClockSkew(serverDate, local time.Time) DoctorResult {
+	result := DoctorResult{Name: "Clock skew"}
+	if serverDate.IsZero() {
+		result.Status, result.Detail = doctorSkip, "no server Date header to compare against"
+		return result
+	}
+
+	skew := local.Sub(serverDate)
+	if skew < 0 {
+		skew = -skew
+	}
+	// Date has one-second resolution.
+	skew = skew.Truncate(time.Second)
+	detail := fmt.Sprintf("local clock differs from server by %s", skew)
+
+	switch {
+	case skew >= doctorClockSkewFail:
+		result.Status, result.Detail = doctorFail, detail
+	case skew >= doctorClockSkewWarn:
+		result.Status, result.Detail = doctorWarn, detail
+	default:
+		result.Status, result.Detail = doctorOK, detail
+		return result
+	}
+	result.Fix = "enable NTP (e.g. timedatectl set-ntp true) so audit timestamps are accurate"
+	return result
+}
+
+// chromeCandidates mirrors the executables chromedp searches for.
+func chromeCandidates() []string {
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{
+			"/Applications/Chromium.app/Contents/MacOS/Chromium",
+			"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
+			"chromium", "google-chrome",
+		}
+	case "windows":
+		return []string{
+			"chrome", "chrome.exe",
+			`C:\Program Files\Google\Chrome\Application\chrome.exe`,
+			`C:\Program Files (x86)\Google\Chrome\Application\chrome.exe`,
+		}
+	default:
+		return []string{
+			"headless_shell", "headless-shell", "chromium", "chromium-browser",
+			"google-chrome", "google-chrome-stable", "google-chrome-beta", "google-chrome-unstable",
+			"/usr/bin/google-chrome", "/usr/local/bin/chrome", "/snap/bin/chromium", "chrome",
+		}
+	}
+}
+
+func findChromeExecutable() string {
+	for _, candidate := range chromeCandidates() {
+		if path, err := exec.LookPath(candidate); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+func checkHeadlessBrowser() DoctorResult {
+	result := DoctorResult{Name: "Headless browser"}
+	if path := findChromeExecutable(); path != "" {
+		result.Status, result.Detail = doctorOK, path
+		return result
+	}
+	result.Status = doctorWarn
+	result.Detail = "Chrome/Chromium not found; JavaScript crawling and --screenshots will not work"
+	result.Fix = "install chromium (e.g. apt install chromium) or Google Chrome and ensure it is on PATH"
+	return result
+}
+
+func checkGPG(ctx context.Context, key string, required bool) DoctorResult {
+	result := DoctorResult{Name: "GPG signing key"}
+	missing := doctorWarn
+	if required {
+		missing = doctorFail
+	}
+
+	gpgPath, err := exec.LookPath("gpg")
+	if err != nil {
+		result.Status, result.Detail = missing, "gpg not found on PATH"
+		result.Fix = "install GnuPG to sign or encrypt audit evidence (--auto-sign, --secure-results)"
+		return result
+	}
+	if key == "" {
+		if required {
+			result.Status, result.Detail = doctorFail, "signing is enabled but no key is configured"
+			result.Fix = "pass --gpg-key with the key ID or email to sign with"
+			return result
+		}
+		result.Status, result.Detail = doctorSkip, fmt.Sprintf("gpg found at %s; no key configured (pass --gpg-key to verify one)", gpgPath)
+		return result
+	}
+	if err := validateGPGKey(key); err != nil {
+		result.Status, result.Detail = doctorFail, err.Error()
+		result.Fix = "pass a key ID, fingerprint, or email with --gpg-key"
+		return result
+	}
+
+	listCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(listCtx, gpgPath, "--batch", "--list-secret-keys", "--with-colons", key) // #nosec G204 -- fixed binary; key is validated and passed without a shell.
+	if err := cmd.Run(); err != nil {
+		result.Status, result.Detail = missing, fmt.Sprintf("no secret key for %q in the local keyring", key)
+		result.Fix = fmt.Sprintf("import the key (gpg --import) or generate one (gpg --quick-generate-key %s)", key)
+		return result
+	}
+	result.Status, result.Detail = doctorOK, fmt.Sprintf("secret key %s available", key)
+	return result
+}
+
+func checkPluginHealth(ctx context.Context) []DoctorResult {
+	loaded, dir, err := loadPluginManifests()
+	if err != nil {
+		return []DoctorResult{{Name: "Plugins", Status: doctorFail, Detail: err.Error(), Fix: "check permissions on the plugins directory"}}
+	}
+	if len(loaded) == 0 {
+		return []DoctorResult{{Name: "Plugins", Status: doctorSkip, Detail: "no plugins installed"}}
+	}
+
+	results := make([]DoctorResult, 0, len(loaded))
+	for _, l := range loaded {
+		name := strings.TrimSuffix(filepath.Base(l.Path), filepath.Ext(l.Path))
+		if l.Manifest != nil {
+			name = l.Manifest.Name
+		}
+		result := DoctorResult{Name: "Plugin " + name}
+		if err := validatePlugin(ctx, l); err != nil {
+			result.Status, result.Detail = doctorFail, err.Error()
+			result.Fix = fmt.Sprintf("run 'seca plugin validate %s' for details, or remove %s", name, filepath.Join(dir, filepath.Base(l.Path)))
+		} else {
+			result.Status, result.Detail = doctorOK, "handshake succeeded"
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func init() {
+	doctorCmd.Flags().String("id", "", "Engagement ID whose first scope target is used for network checks")
+	doctorCmd.Flags().String("target", "", "URL or host used for DNS, connectivity, and clock checks (default "+doctorDefaultTarget+")")
+	doctorCmd.Flags().Bool("offline", false, "Skip checks that need network access")
+	doctorCmd.Flags().Int("timeout", defaultHTTPTimeoutSeconds, "Timeout in seconds for each network check")
+	doctorCmd.Flags().String("gpg-key", "", "GPG key ID or email expected in the local keyring")
+	doctorCmd.Flags().Bool("json", false, "Output results as JSON")
+
+	rootCmd.AddCommand(doctorCmd)
+}
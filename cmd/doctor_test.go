@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestCheckResultsDirWritable(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Chmod(dir, 0o700); err != nil {
+		t.Fatalf("chmod: %v", err)
+	}
+	if r := checkResultsDirWritable(dir); r.Status != doctorOK {
+		t.Fatalf("expected ok, got %+v", r)
+	}
+
+	if r := checkResultsDirWritable(filepath.Join(dir, "missing")); r.Status != doctorFail || r.Fix == "" {
+		t.Fatalf("expected failure with fix for missing dir, got %+v", r)
+	}
+
+	if runtime.GOOS != "windows" {
+		if err := os.Chmod(dir, 0o777); err != nil {
+			t.Fatalf("chmod: %v", err)
+		}
+		if r := checkResultsDirWritable(dir); r.Status != doctorWarn {
+			t.Fatalf("expected warning for world-writable dir, got %+v", r)
+		}
+	}
+}
+
+func TestCheckClockSkew(t *testing.T) {
+	server := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		local time.Time
+		want  string
+	}{
+		{server.Add(2 * time.Second), doctorOK},
+		{server.Add(-90 * time.Second), doctorWarn},
+		{server.Add(10 * time.Minute), doctorFail},
+	}
+	for _, tc := range cases {
+		if r := checkClockSkew(server, tc.local); r.Status != tc.want {
+			t.Fatalf("skew %s: expected %s, got %+v", tc.local.Sub(server), tc.want, r)
+		}
+	}
+	if r := checkClockSkew(time.Time{}, server); r.Status != doctorSkip {
+		t.Fatalf("expected skip without server date, got %+v", r)
+	}
+}
+
+func TestCheckOutboundConnectivity(t *testing.T) {
+	serverTime := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("expected HEAD, got %s", r.Method)
+		}
+		w.Header().Set("Date", serverTime.Format(http.TimeFormat))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	result, date := checkOutboundConnectivity(context.Background(), srv.URL, nil, 5*time.Second, time.Now)
+	if result.Status != doctorOK {
+		t.Fatalf("expected ok, got %+v", result)
+	}
+	if !date.Equal(serverTime) {
+		t.Fatalf("expected server date %s, got %s", serverTime, date)
+	}
+
+	srv.Close()
+	if result, _ := checkOutboundConnectivity(context.Background(), srv.URL, nil, time.Second, time.Now); result.Status != doctorFail || result.Fix == "" {
+		t.Fatalf("expected failure with fix once server is down, got %+v", result)
+	}
+}
+
+func TestDoctorTargetHost(t *testing.T) {
+	cases := map[string]string{
+		"https://api.example.com/v1": "api.example.com",
+		"example.com:8443":           "example.com",
+		"example.com":                "example.com",
+		"10.0.0.5":                   "10.0.0.5",
+	}
+	for in, want := range cases {
+		if got := doctorTargetHost(in); got != want {
+			t.Fatalf("doctorTargetHost(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRunDoctorChecks_Offline(t *testing.T) {
+	setupTestAppContextWithServices(t)
+
+	results := runDoctorChecks(context.Background(), doctorOptions{
+		ResultsDir: globalAppContext.ResultsDir,
+		Offline:    true,
+	})
+
+	byName := map[string]DoctorResult{}
+	for _, r := range results {
+		byName[r.Name] = r
+	}
+	for _, name := range []string{"DNS resolution", "Outbound connectivity", "Clock skew"} {
+		if byName[name].Status != doctorSkip {
+			t.Fatalf("expected %s to be skipped offline, got %+v", name, byName[name])
+		}
+	}
+	if byName["Results directory"].Status == doctorFail {
+		t.Fatalf("expected results directory to pass, got %+v", byName["Results directory"])
+	}
+	if _, ok := byName["Headless browser"]; !ok {
+		t.Fatalf("expected headless browser check in %+v", results)
+	}
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/khanhnv2901/seca-cli/internal/domain/engagement"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+)
+
+// domainRegistrationsFilename holds RDAP registration data for the
+// engagement's scoped domains, kept as report context.
+const domainRegistrationsFilename = "domain_registrations.json"
+
+// registrationExpiryGrace extends the engagement window when flagging
+// registrations: a domain that lapses shortly after fieldwork still breaks
+// retesting and remediation.
+const registrationExpiryGrace = 30 * 24 * time.Hour
+
+// loadDomainRegistrations returns the stored registrations, or nil when none
+// were recorded.
+func loadDomainRegistrations(resultsDir, engagementID string) ([]checker.DomainRegistration, error) {
+	path, err := resolveResultsPath(resultsDir, engagementID, domainRegistrationsFilename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var regs []checker.DomainRegistration
+	if err := json.Unmarshal(data, &regs); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", domainRegistrationsFilename, err)
+	}
+	return regs, nil
+}
+
+// saveDomainRegistrations merges regs into the stored registrations, newer
+// lookups replacing older ones for the same domain, and returns the path.
+func saveDomainRegistrations(resultsDir, engagementID string, regs []checker.DomainRegistration) (string, error) {
+	if _, err := ensureResultsDir(resultsDir, engagementID); err != nil {
+		return "", err
+	}
+	existing, err := loadDomainRegistrations(resultsDir, engagementID)
+	if err != nil {
+		return "", err
+	}
+	byDomain := make(map[string]checker.DomainRegistration, len(existing)+len(regs))
+	for _, reg := range append(existing, regs...) {
+		byDomain[reg.Domain] = reg
+	}
+	merged := make([]checker.DomainRegistration, 0, len(byDomain))
+	for _, reg := range byDomain {
+		merged = append(merged, reg)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Domain < merged[j].Domain })
+
+	path, err := resolveResultsPath(resultsDir, engagementID, domainRegistrationsFilename)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(merged, jsonPrefix, jsonIndent)
+	if err != nil {
+		return "", err
+	}
+	if err := fileutil.WriteFile(path, data, consts.DefaultFilePerm); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// scopedDomainNames returns the distinct host names in scope. IP addresses
+// and CIDR ranges have no registration and are skipped.
+func scopedDomainNames(scope []string) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, entry := range scope {
+		if strings.Contains(entry, "/") && !strings.Contains(entry, "://") {
+			continue // CIDR
+		}
+		host := strings.TrimPrefix(strings.ToLower(checker.ExtractHost(entry)), "*.")
+		if host == "" || net.ParseIP(host) != nil || !strings.Contains(host, ".") || seen[host] {
+			continue
+		}
+		seen[host] = true
+		names = append(names, host)
+	}
+	return names
+}
+
+// registrationDeadline is the date before which an expiring registration is
+// flagged: the engagement end (or now, when no end is set) plus the grace
+// period.
+func registrationDeadline(eng *engagement.Engagement, now time.Time) time.Time {
+	end := eng.End()
+	if end.IsZero() || end.Before(now) {
+		end = now
+	}
+	return end.Add(registrationExpiryGrace)
+}
+
+// recordDomainRegistrations looks up the registration behind each scoped
+// domain, saves them for reports, and warns about registrations that lapse
+// during or shortly after the engagement. Failures are warnings: the data is
+// context for the checks, not a check itself.
+func recordDomainRegistrations(ctx context.Context, resultsDir string, eng *engagement.Engagement, client *checker.RDAPClient) {
+	deadline := registrationDeadline(eng, time.Now())
+	seen := make(map[string]bool)
+	var regs []checker.DomainRegistration
+	for _, name := range scopedDomainNames(eng.Scope()) {
+		reg, err := client.Lookup(ctx, name)
+		if err != nil {
+			cliLog().Warnw("rdap_lookup_failed", "engagement_id", eng.ID(), "domain", name, "error", err)
+			fmt.Printf("%s RDAP lookup failed for %s: %v\n", colorWarn("!"), name, err)
+			continue
+		}
+		if seen[reg.Domain] {
+			continue
+		}
+		seen[reg.Domain] = true
+
+		entry := *reg
+		entry.ExpiresDuringEngagement = entry.Expires != nil && entry.Expires.Before(deadline)
+		regs = append(regs, entry)
+	}
+	if len(regs) == 0 {
+		return
+	}
+
+	path, err := saveDomainRegistrations(resultsDir, eng.ID(), regs)
+	if err != nil {
+		cliLog().Warnw("domain_registrations_save_failed", "engagement_id", eng.ID(), "error", err)
+		return
+	}
+	fmt.Printf("%s Domain registrations: %d domain(s) → %s\n", colorInfo("→"), len(regs), path)
+	for _, reg := range regs {
+		if !reg.ExpiresDuringEngagement {
+			continue
+		}
+		when := fmt.Sprintf("within %d days of the engagement end", int(registrationExpiryGrace.Hours()/24))
+		if end := eng.End(); !end.IsZero() && reg.Expires.Before(end) {
+			when = "before the engagement ends on " + end.Format("2006-01-02")
+		}
+		if reg.Expires.Before(time.Now()) {
+			when = "already expired"
+		}
+		fmt.Printf("  %s %s registration expires %s (%s)\n", colorWarn("!"), reg.Domain, reg.Expires.Format("2006-01-02"), when)
+	}
+}
+
+// formatDate renders an optional date for report tables.
+func formatDate(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02")
+}
+
+// writeDomainRegistrationsPDF lists registrar and expiry data per domain.
+func writeDomainRegistrationsPDF(pdf *gofpdf.Fpdf, regs []checker.DomainRegistration) {
+	if pdf.GetY() > 240 {
+		pdf.AddPage()
+	}
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, "Domain Registrations", "", 1, "", false, 0, "")
+	pdf.SetFont("Arial", "I", 8)
+	pdf.MultiCell(0, 4, "Registry data retrieved over RDAP for the scoped domains.", "", "", false)
+	pdf.Ln(2)
+
+	pdf.SetFont("Arial", "", 8)
+	for _, reg := range regs {
+		if pdf.GetY() > 270 {
+			pdf.AddPage()
+		}
+		line := fmt.Sprintf("  - %s: registrar %s; created %s; expires %s", reg.Domain, orDash(reg.Registrar), formatDate(reg.Created), formatDate(reg.Expires))
+		if reg.RegistrantOrg != "" {
+			line += "; registrant " + reg.RegistrantOrg
+		}
+		if reg.ExpiresDuringEngagement {
+			line += " (expires during the engagement)"
+		}
+		pdf.MultiCell(0, 4, line, "", "", false)
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
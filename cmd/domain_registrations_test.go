@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/domain/engagement"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func TestScopedDomainNames(t *testing.T) {
+	scope := []string{
+		"https://www.Example.com/login",
+		"example.com",
+		"www.example.com",
+		"*.example.org",
+		"10.0.0.0/24",
+		"192.0.2.10",
+		"https://[2001:db8::1]/",
+		"localhost",
+	}
+	got := strings.Join(scopedDomainNames(scope), ",")
+	if got != "www.example.com,example.com,example.org" {
+		t.Fatalf("scopedDomainNames = %s", got)
+	}
+}
+
+func TestRegistrationDeadline(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := now.Add(14 * 24 * time.Hour)
+
+	eng := engagement.Reconstruct("eng-1", "Test", "owner", "roe", nil, true, now, end, now)
+	if got := registrationDeadline(eng, now); !got.Equal(end.Add(registrationExpiryGrace)) {
+		t.Errorf("deadline with end = %v", got)
+	}
+	open := engagement.Reconstruct("eng-2", "Test", "owner", "roe", nil, true, now, time.Time{}, now)
+	if got := registrationDeadline(open, now); !got.Equal(now.Add(registrationExpiryGrace)) {
+		t.Errorf("deadline without end = %v", got)
+	}
+}
+
+func TestDomainRegistrations_SaveMergeAndReport(t *testing.T) {
+	resultsDir := t.TempDir()
+	created := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+	expires := time.Now().UTC().Add(20 * 24 * time.Hour)
+
+	if _, err := saveDomainRegistrations(resultsDir, "eng-rdap", []checker.DomainRegistration{
+		{Domain: "example.org", Registrar: "Old Registrar"},
+		{Domain: "example.com", Registrar: "Example Registrar", Created: &created},
+	}); err != nil {
+		t.Fatalf("saveDomainRegistrations: %v", err)
+	}
+	if _, err := saveDomainRegistrations(resultsDir, "eng-rdap", []checker.DomainRegistration{
+		{Domain: "example.org", Registrar: "New Registrar", RegistrantOrg: "Example Org", Expires: &expires, ExpiresDuringEngagement: true},
+	}); err != nil {
+		t.Fatalf("saveDomainRegistrations: %v", err)
+	}
+
+	regs, err := loadDomainRegistrations(resultsDir, "eng-rdap")
+	if err != nil {
+		t.Fatalf("loadDomainRegistrations: %v", err)
+	}
+	if len(regs) != 2 || regs[0].Domain != "example.com" || regs[1].Registrar != "New Registrar" {
+		t.Fatalf("expected merged, sorted registrations, got %+v", regs)
+	}
+	if missing, err := loadDomainRegistrations(resultsDir, "eng-none"); err != nil || missing != nil {
+		t.Fatalf("expected nil for missing file, got %v %v", missing, err)
+	}
+
+	output := &RunOutput{
+		Metadata:            RunMetadata{EngagementID: "eng-rdap", StartAt: time.Now(), CompleteAt: time.Now()},
+		Results:             []checker.CheckResult{{Target: "example.com", Status: "ok"}},
+		DomainRegistrations: regs,
+	}
+	data := buildTemplateData(output, nil, "%.2f", nil)
+	if len(data.Vulnerabilities) != 1 || data.Vulnerabilities[0].Name != "Domain Registration Expiring Soon" {
+		t.Errorf("expected an expiry finding, got %+v", data.Vulnerabilities)
+	}
+	report, err := generateMarkdownReport(data)
+	if err != nil {
+		t.Fatalf("Failed to generate markdown report: %v", err)
+	}
+	for _, want := range []string{
+		"## Domain Registrations",
+		"| example.com | Example Registrar | - | 2001-01-01 | - |",
+		"| example.org | New Registrar | Example Org | - | " + expires.Format("2006-01-02") + " ⚠ during engagement |",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected report to contain %q", want)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/khanhnv2901/seca-cli/internal/domain/engagement"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
 	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
 	"github.com/spf13/cobra"
 )
@@ -25,6 +26,11 @@ type engagementDTO struct {
 	ROE       string    `json:"roe,omitempty"`
 	ROEAgree  bool      `json:"roe_agree"`
 	CreatedAt time.Time `json:"created_at"`
+	Status    string    `json:"status"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	Expired   bool      `json:"expired,omitempty"`
+	// TargetOverrides holds the per-target overrides of scope entries.
+	TargetOverrides map[string]engagement.TargetOverrides `json:"target_overrides,omitempty"`
 }
 
 func engagementToDTO(eng *engagement.Engagement) engagementDTO {
@@ -38,6 +44,11 @@ func engagementToDTO(eng *engagement.Engagement) engagementDTO {
 		ROE:       eng.ROE(),
 		ROEAgree:  eng.ROEAgreed(),
 		CreatedAt: eng.CreatedAt(),
+		Status:    string(eng.Status()),
+		ExpiresAt: eng.ExpiresAt(),
+		Expired:   eng.IsExpired(),
+
+		TargetOverrides: eng.ScopeOverrides(),
 	}
 }
 
@@ -67,16 +78,37 @@ var engagementCreateCmd = &cobra.Command{
 			return errors.New("ROE must be agreed (--roe-agree)")
 		}
 
+		var expiresAt time.Time
+		if raw, _ := cmd.Flags().GetString("expires-at"); raw != "" {
+			t, err := parseExpiry(raw, time.Now())
+			if err != nil {
+				return err
+			}
+			if !t.After(time.Now()) {
+				return fmt.Errorf("--expires-at must be in the future")
+			}
+			expiresAt = t
+		}
+
 		eng, err := appCtx.Services.EngagementService.CreateEngagement(ctx, name, owner, roe, scopeFlag)
 		if err != nil {
 			return fmt.Errorf("failed to create engagement: %w", err)
 		}
 
+		if !expiresAt.IsZero() {
+			if err := appCtx.Services.EngagementService.SetExpiry(ctx, eng.ID(), expiresAt); err != nil {
+				return fmt.Errorf("failed to set expiry: %w", err)
+			}
+		}
+
 		if err := appCtx.Services.EngagementService.AcknowledgeROE(ctx, eng.ID()); err != nil {
 			return fmt.Errorf("failed to acknowledge ROE: %w", err)
 		}
 
 		fmt.Printf("%s engagement %s (id=%s)\n", colorSuccess("Created"), name, eng.ID())
+		if !expiresAt.IsZero() {
+			fmt.Printf("%s Authorization expires %s\n", colorInfo("→"), expiresAt.Format(time.RFC3339))
+		}
 		return nil
 	},
 }
@@ -88,14 +120,26 @@ var engagementListCmd = &cobra.Command{
 		ctx := context.Background()
 		appCtx := getAppContext(cmd)
 
+		var status engagement.Status
+		if raw, _ := cmd.Flags().GetString("status"); raw != "" {
+			parsed, err := engagement.ParseStatus(strings.ToLower(raw))
+			if err != nil {
+				return err
+			}
+			status = parsed
+		}
+
 		engagements, err := appCtx.Services.EngagementService.ListEngagements(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to list engagements: %w", err)
 		}
 
-		dtos := make([]engagementDTO, len(engagements))
-		for i, eng := range engagements {
-			dtos[i] = engagementToDTO(eng)
+		dtos := make([]engagementDTO, 0, len(engagements))
+		for _, eng := range engagements {
+			if status != "" && eng.Status() != status {
+				continue
+			}
+			dtos = append(dtos, engagementToDTO(eng))
 		}
 
 		b, _ := json.MarshalIndent(dtos, jsonPrefix, jsonIndent)
@@ -229,6 +273,9 @@ func init() {
 	engagementCreateCmd.Flags().String("roe", "", "Rules of Engagement")
 	engagementCreateCmd.Flags().Bool("roe-agree", false, "Acknowledge ROE")
 	engagementCreateCmd.Flags().StringSlice("scope", nil, "Initial scope entries")
+	engagementCreateCmd.Flags().String("expires-at", "", "When authorization lapses: YYYY-MM-DD, RFC 3339 timestamp, or duration from now (e.g. 720h); checks are refused afterwards")
+
+	engagementListCmd.Flags().String("status", "", "Only list engagements with this status: draft|active|paused|closed")
 
 	engagementViewCmd.Flags().String("id", "", "Engagement ID")
 
@@ -331,7 +378,8 @@ func isValidHostOrIP(host string) bool {
 	if net.ParseIP(host) != nil {
 		return true
 	}
-	return isValidHostname(host)
+	// Internationalized hosts are valid when their punycode form is.
+	return isValidHostname(checker.ASCIIHost(host))
 }
 
 func isValidHostname(host string) bool {
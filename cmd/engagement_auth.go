@@ -0,0 +1,383 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/domain/engagement"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/session"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/spf13/cobra"
+)
+
+const (
+	engagementAuthFilename = "auth.enc"
+	authKeyFilename        = "auth.key"
+	// authKeyEnvVar overrides the generated key file with a base64-encoded 32-byte key.
+	authKeyEnvVar = "SECA_AUTH_KEY"
+)
+
+var engagementAuthCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage authenticated-session credentials for an engagement",
+	Long: `Configure how HTTP checks and the crawler authenticate against an
+engagement's targets so post-login pages can be assessed.
+
+Credentials are encrypted (AES-256-GCM) in the engagement results directory
+with a key stored in the data directory (or $SECA_AUTH_KEY). They are only
+sent to hosts in the engagement scope and are redacted from raw captures.
+
+Credentials can instead be kept in an external store (HashiCorp Vault, AWS
+Secrets Manager, or an environment variable) and referenced by the name of a
+secrets.<name> entry in config. Only the name is stored; the value is read
+when a check logs in.`,
+}
+
+var engagementAuthSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Store static cookies, a bearer token, or a form login for an engagement",
+	Example: `  # Static session cookie
+  seca engagement auth set --id eng123 --type cookies --cookie session=abc123
+
+  # Bearer token read from the environment
+  seca engagement auth set --id eng123 --type bearer --token-env API_TOKEN
+
+  # Scripted form login; the password is read from $APP_PASSWORD
+  seca engagement auth set --id eng123 --type form \
+    --login-url https://app.example.com/login \
+    --field username=auditor --field-env password=APP_PASSWORD \
+    --csrf-field csrf_token --success-text "Sign out"
+
+  # Bearer token and form password kept in a secret store (secrets.* in config)
+  seca engagement auth set --id eng123 --type bearer --token-secret api_token
+  seca engagement auth set --id eng123 --type form \
+    --login-url https://app.example.com/login \
+    --field username=auditor --field-secret password=app_password`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		appCtx := getAppContext(cmd)
+
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		if _, err := appCtx.Services.EngagementService.GetEngagement(ctx, id); err != nil {
+			return fmt.Errorf("failed to get engagement: %w", err)
+		}
+
+		profile, err := authProfileFlag(cmd)
+		if err != nil {
+			return err
+		}
+		cfg, err := authConfigFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if err := checkSecretsDefined(cfg); err != nil {
+			return err
+		}
+
+		key, err := loadAuthKey()
+		if err != nil {
+			return err
+		}
+		if _, err := ensureResultsDir(appCtx.ResultsDir, id); err != nil {
+			return err
+		}
+		path, err := resolveResultsPath(appCtx.ResultsDir, id, authFilename(profile))
+		if err != nil {
+			return err
+		}
+		if err := session.Save(path, id, cfg, key, consts.DefaultFilePerm); err != nil {
+			return fmt.Errorf("save credentials: %w", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "%s stored %s for engagement %s%s\n", colorSuccess("✓"), cfg.Summary(), id, authProfileSuffix(profile))
+		return nil
+	},
+}
+
+var engagementAuthShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the configured authentication type (credential values are never printed)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		profile, err := authProfileFlag(cmd)
+		if err != nil {
+			return err
+		}
+		cfg, err := loadEngagementAuthProfile(appCtx.ResultsDir, id, profile)
+		if err != nil {
+			return err
+		}
+		if cfg == nil {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s no authentication configured for engagement %s%s\n", colorInfo("→"), id, authProfileSuffix(profile))
+			return nil
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", colorInfo("→"), cfg.Summary())
+
+		if check, _ := cmd.Flags().GetBool("check-secrets"); check {
+			resolver := newSecretResolver()
+			failed := 0
+			for _, name := range cfg.SecretNames() {
+				if _, err := resolver.Lookup(cmd.Context(), name); err != nil {
+					failed++
+					fmt.Fprintf(cmd.OutOrStdout(), "  %s %v\n", colorError("✗"), err)
+					continue
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "  %s secret %s resolves\n", colorSuccess("✓"), name)
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d secret(s) could not be read", failed)
+			}
+		}
+		return nil
+	},
+}
+
+var engagementAuthClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove stored credentials for an engagement",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		profile, err := authProfileFlag(cmd)
+		if err != nil {
+			return err
+		}
+		path, err := resolveResultsPath(appCtx.ResultsDir, id, authFilename(profile))
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove credentials: %w", err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s cleared authentication for engagement %s%s\n", colorSuccess("✓"), id, authProfileSuffix(profile))
+		return nil
+	},
+}
+
+// authProfileFlag returns the validated --profile, or "" for the
+// engagement's default credentials.
+func authProfileFlag(cmd *cobra.Command) (string, error) {
+	profile, _ := cmd.Flags().GetString("profile")
+	profile = strings.ToLower(strings.TrimSpace(profile))
+	if profile == "" {
+		return "", nil
+	}
+	if profile == engagement.AuthProfileNone || !engagement.ValidAuthProfile(profile) {
+		return "", fmt.Errorf("invalid --profile %q (lowercase letters, digits, - and _; \"none\" is reserved)", profile)
+	}
+	return profile, nil
+}
+
+// authFilename returns the credential file of profile; "" names the
+// engagement's default credentials.
+func authFilename(profile string) string {
+	if profile == "" {
+		return engagementAuthFilename
+	}
+	return "auth-" + profile + ".enc"
+}
+
+func authProfileSuffix(profile string) string {
+	if profile == "" {
+		return ""
+	}
+	return " (profile " + profile + ")"
+}
+
+func authConfigFromFlags(cmd *cobra.Command) (*session.Config, error) {
+	authType, _ := cmd.Flags().GetString("type")
+	cfg := &session.Config{Type: strings.ToLower(strings.TrimSpace(authType))}
+
+	switch cfg.Type {
+	case session.TypeCookies:
+		cookies, _ := cmd.Flags().GetStringToString("cookie")
+		cfg.Cookies = cookies
+		cfg.CookieSecrets, _ = cmd.Flags().GetStringToString("cookie-secret")
+	case session.TypeBearer:
+		tokenEnv, _ := cmd.Flags().GetString("token-env")
+		tokenSecret, _ := cmd.Flags().GetString("token-secret")
+		if tokenSecret = strings.TrimSpace(tokenSecret); tokenSecret != "" {
+			if tokenEnv != "" {
+				return nil, fmt.Errorf("use either --token-env or --token-secret, not both")
+			}
+			cfg.TokenSecret = tokenSecret
+			break
+		}
+		if tokenEnv == "" {
+			return nil, fmt.Errorf("--token-env or --token-secret is required for bearer authentication")
+		}
+		cfg.Token = strings.TrimSpace(os.Getenv(tokenEnv))
+		if cfg.Token == "" {
+			return nil, fmt.Errorf("bearer token not found in $%s", tokenEnv)
+		}
+	case session.TypeForm:
+		loginURL, _ := cmd.Flags().GetString("login-url")
+		fields, _ := cmd.Flags().GetStringToString("field")
+		fieldEnvs, _ := cmd.Flags().GetStringToString("field-env")
+		csrfField, _ := cmd.Flags().GetString("csrf-field")
+		successText, _ := cmd.Flags().GetString("success-text")
+
+		merged := make(map[string]string, len(fields)+len(fieldEnvs))
+		for name, value := range fields {
+			merged[name] = value
+		}
+		for name, envVar := range fieldEnvs {
+			value := os.Getenv(envVar)
+			if value == "" {
+				return nil, fmt.Errorf("form field %s: $%s is empty", name, envVar)
+			}
+			merged[name] = value
+		}
+		fieldSecrets, _ := cmd.Flags().GetStringToString("field-secret")
+		cfg.Form = &session.FormLogin{
+			URL:          loginURL,
+			Fields:       merged,
+			CSRFField:    csrfField,
+			SuccessText:  successText,
+			FieldSecrets: fieldSecrets,
+		}
+	}
+	return cfg, nil
+}
+
+func loadAuthKey() ([]byte, error) {
+	dataDir, err := getDataDir()
+	if err != nil {
+		return nil, err
+	}
+	key, err := session.LoadOrCreateKey(filepath.Join(dataDir, authKeyFilename), os.Getenv(authKeyEnvVar))
+	if err != nil {
+		return nil, fmt.Errorf("load credential key: %w", err)
+	}
+	return key, nil
+}
+
+// loadEngagementAuth returns the stored authentication config, or nil when none is set.
+func loadEngagementAuth(resultsDir, engagementID string) (*session.Config, error) {
+	return loadEngagementAuthProfile(resultsDir, engagementID, "")
+}
+
+// loadEngagementAuthProfile returns the credentials stored under profile, or
+// nil when none are set.
+func loadEngagementAuthProfile(resultsDir, engagementID, profile string) (*session.Config, error) {
+	path, err := resolveResultsPath(resultsDir, engagementID, authFilename(profile))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+	key, err := loadAuthKey()
+	if err != nil {
+		return nil, err
+	}
+	return session.Load(path, engagementID, key)
+}
+
+// establishEngagementSession logs in with the engagement's stored credentials,
+// sending login requests through transport (custom headers and proxy). It
+// returns nil when the engagement has no authentication configured.
+func establishEngagementSession(ctx context.Context, resultsDir, engagementID string, scope []string, timeout time.Duration, transport http.RoundTripper) (*session.Session, error) {
+	sess, cfg, err := openEngagementSession(ctx, resultsDir, engagementID, scope, timeout, transport)
+	if err != nil || sess == nil {
+		return nil, err
+	}
+	fmt.Printf("%s Authenticated session: %s\n", colorInfo("→"), cfg.Summary())
+	return sess, nil
+}
+
+// openEngagementSession is establishEngagementSession without console output,
+// for callers that own the terminal such as the TUI.
+func openEngagementSession(ctx context.Context, resultsDir, engagementID string, scope []string, timeout time.Duration, transport http.RoundTripper) (*session.Session, *session.Config, error) {
+	cfg, err := loadEngagementAuth(resultsDir, engagementID)
+	if err != nil || cfg == nil {
+		return nil, nil, err
+	}
+	resolved, err := resolveAuthSecrets(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sess, err := session.Establish(ctx, resolved, scopeHosts(scope), &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("establish authenticated session: %w", err)
+	}
+	return sess, cfg, nil
+}
+
+// scopeHosts returns the hosts of the scope entries, to which credentials
+// are confined.
+func scopeHosts(scope []string) []string {
+	hosts := make([]string, 0, len(scope))
+	for _, entry := range scope {
+		if info := checker.ParseTarget(entry); info != nil && info.Host != "" {
+			hosts = append(hosts, info.Host)
+		}
+	}
+	return hosts
+}
+
+// sessionDecorator returns the request decorator for sess, or nil without a session.
+func sessionDecorator(sess *session.Session) checker.RequestDecorator {
+	if sess == nil {
+		return nil
+	}
+	return sess.Decorate
+}
+
+// sessionAuditNote records in the audit trail that a check ran authenticated.
+func sessionAuditNote(sess *session.Session) string {
+	if sess == nil {
+		return ""
+	}
+	return fmt.Sprintf("authenticated session (%s)", sess.Type())
+}
+
+func init() {
+	engagementCmd.AddCommand(engagementAuthCmd)
+	engagementAuthCmd.AddCommand(engagementAuthSetCmd)
+	engagementAuthCmd.AddCommand(engagementAuthShowCmd)
+	engagementAuthCmd.AddCommand(engagementAuthClearCmd)
+
+	engagementAuthSetCmd.Flags().String("id", "", "Engagement ID")
+	engagementAuthSetCmd.Flags().String("type", "", "Authentication type: cookies, bearer, or form")
+	engagementAuthSetCmd.Flags().StringToString("cookie", nil, "Static cookie name=value (repeatable)")
+	engagementAuthSetCmd.Flags().String("token-env", "", "Environment variable holding the bearer token")
+	engagementAuthSetCmd.Flags().String("token-secret", "", "Name of the secret holding the bearer token (secrets.<name> in config; read at check time)")
+	engagementAuthSetCmd.Flags().StringToString("cookie-secret", nil, "Cookie name=SECRET whose value is read from a secret store at check time (repeatable)")
+	engagementAuthSetCmd.Flags().String("login-url", "", "Form login URL")
+	engagementAuthSetCmd.Flags().StringToString("field", nil, "Form field name=value (repeatable)")
+	engagementAuthSetCmd.Flags().StringToString("field-env", nil, "Form field name=ENV_VAR read from the environment (repeatable; use for passwords)")
+	engagementAuthSetCmd.Flags().StringToString("field-secret", nil, "Form field name=SECRET whose value is read from a secret store at check time (repeatable)")
+	engagementAuthSetCmd.Flags().String("csrf-field", "", "Hidden input copied from the login page (e.g. csrf_token)")
+	engagementAuthSetCmd.Flags().String("success-text", "", "Text that must appear in the login response")
+
+	engagementAuthShowCmd.Flags().String("id", "", "Engagement ID")
+	engagementAuthShowCmd.Flags().Bool("check-secrets", false, "Read each referenced secret from its store and report whether it resolves (values are never printed)")
+	engagementAuthClearCmd.Flags().String("id", "", "Engagement ID")
+	for _, c := range []*cobra.Command{engagementAuthSetCmd, engagementAuthShowCmd, engagementAuthClearCmd} {
+		c.Flags().String("profile", "", "Named credential profile used by scope entries with --auth-profile (default: the engagement's credentials)")
+	}
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/session"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+)
+
+func TestEstablishEngagementSessionScopesCredentials(t *testing.T) {
+	defer setupTestAppContext(t)()
+	resultsDir := globalAppContext.ResultsDir
+
+	sess, err := establishEngagementSession(context.Background(), resultsDir, "eng-auth", []string{"https://app.example.com"}, time.Second, nil)
+	if err != nil || sess != nil {
+		t.Fatalf("expected no session without stored credentials, got %v (%v)", sess, err)
+	}
+
+	key, err := loadAuthKey()
+	if err != nil {
+		t.Fatalf("loadAuthKey: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(os.Getenv(dataDirEnvVar), authKeyFilename)); err != nil {
+		t.Fatalf("expected key file in data dir: %v", err)
+	}
+	if _, err := ensureResultsDir(resultsDir, "eng-auth"); err != nil {
+		t.Fatal(err)
+	}
+	path, _ := resolveResultsPath(resultsDir, "eng-auth", engagementAuthFilename)
+	if err := session.Save(path, "eng-auth", &session.Config{Type: session.TypeBearer, Token: "tok-abc"}, key, consts.DefaultFilePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	sess, err = establishEngagementSession(context.Background(), resultsDir, "eng-auth", []string{"https://app.example.com", "api.example.com:8443"}, time.Second, nil)
+	if err != nil || sess == nil {
+		t.Fatalf("expected session, got %v (%v)", sess, err)
+	}
+
+	decorate := sessionDecorator(sess)
+	for target, want := range map[string]string{
+		"https://app.example.com/account": "Bearer tok-abc",
+		"https://api.example.com:8443/v1": "Bearer tok-abc",
+		"https://evil.example.net/":       "",
+	} {
+		req, _ := http.NewRequest(http.MethodGet, target, nil)
+		decorate(req)
+		if got := req.Header.Get("Authorization"); got != want {
+			t.Errorf("%s: Authorization = %q, want %q", target, got, want)
+		}
+	}
+
+	if note := sessionAuditNote(sess); note != "authenticated session (bearer)" {
+		t.Fatalf("unexpected audit note %q", note)
+	}
+	if sessionDecorator(nil) != nil || sessionAuditNote(nil) != "" {
+		t.Fatal("expected nil session helpers to be no-ops")
+	}
+}
+
+func TestEstablishEngagementSessionResolvesSecrets(t *testing.T) {
+	defer setupTestAppContext(t)()
+	resultsDir := globalAppContext.ResultsDir
+	loadTestConfigFile(t, `
+secrets:
+  api_token:
+    provider: env
+    path: SECA_TEST_API_TOKEN
+`)
+	t.Setenv("SECA_TEST_API_TOKEN", "tok-from-store")
+
+	cfg := &session.Config{Type: session.TypeBearer, TokenSecret: "api_token"}
+	if err := checkSecretsDefined(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkSecretsDefined(&session.Config{Type: session.TypeBearer, TokenSecret: "other"}); err == nil {
+		t.Fatal("a secret missing from config should be rejected when credentials are set")
+	}
+
+	key, err := loadAuthKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ensureResultsDir(resultsDir, "eng-vault"); err != nil {
+		t.Fatal(err)
+	}
+	path, _ := resolveResultsPath(resultsDir, "eng-vault", engagementAuthFilename)
+	if err := session.Save(path, "eng-vault", cfg, key, consts.DefaultFilePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	sess, err := establishEngagementSession(context.Background(), resultsDir, "eng-vault", []string{"https://app.example.com"}, time.Second, nil)
+	if err != nil || sess == nil {
+		t.Fatalf("expected session, got %v (%v)", sess, err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://app.example.com/", nil)
+	sessionDecorator(sess)(req)
+	if got := req.Header.Get("Authorization"); got != "Bearer tok-from-store" {
+		t.Errorf("Authorization = %q", got)
+	}
+
+	// Only the secret's name is stored.
+	stored, err := loadEngagementAuth(resultsDir, "eng-vault")
+	if err != nil || stored.Token != "" || stored.TokenSecret != "api_token" {
+		t.Errorf("stored config should hold the reference only, got %+v (%v)", stored, err)
+	}
+
+	t.Setenv("SECA_TEST_API_TOKEN", "")
+	if _, err := establishEngagementSession(context.Background(), resultsDir, "eng-vault", nil, time.Second, nil); err == nil {
+		t.Error("expected an error when the secret cannot be read")
+	}
+}
@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+	"github.com/spf13/cobra"
+)
+
+// engagementBudgetFilename holds the per-run limits applied to every check
+// run of an engagement.
+const engagementBudgetFilename = "budget.json"
+
+// EngagementBudget caps what a single check run may spend. Zero values are
+// unlimited.
+type EngagementBudget struct {
+	MaxRequests int64  `json:"max_requests,omitempty"`
+	MaxBytes    int64  `json:"max_bytes,omitempty"`
+	MaxDuration string `json:"max_duration,omitempty"`
+}
+
+// IsZero reports whether the budget sets no limits.
+func (b EngagementBudget) IsZero() bool {
+	return b.MaxRequests == 0 && b.MaxBytes == 0 && b.MaxDuration == ""
+}
+
+// Validate checks that every limit is non-negative and the duration parses.
+func (b EngagementBudget) Validate() error {
+	if b.MaxRequests < 0 {
+		return fmt.Errorf("max requests must not be negative")
+	}
+	if b.MaxBytes < 0 {
+		return fmt.Errorf("max bytes must not be negative")
+	}
+	if b.MaxDuration != "" {
+		d, err := time.ParseDuration(b.MaxDuration)
+		if err != nil {
+			return fmt.Errorf("invalid max duration %q: %w", b.MaxDuration, err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("max duration must be positive")
+		}
+	}
+	return nil
+}
+
+// Summary describes the limits for console output and audit notes.
+func (b EngagementBudget) Summary() string {
+	var parts []string
+	if b.MaxRequests > 0 {
+		parts = append(parts, fmt.Sprintf("%d requests", b.MaxRequests))
+	}
+	if b.MaxBytes > 0 {
+		parts = append(parts, formatByteSize(b.MaxBytes)+" downloaded")
+	}
+	if b.MaxDuration != "" {
+		parts = append(parts, b.MaxDuration+" wall-clock")
+	}
+	if len(parts) == 0 {
+		return "no limits"
+	}
+	return "max " + strings.Join(parts, ", ")
+}
+
+// RunBudget returns a fresh checker budget for one run, or nil when no
+// limits are set.
+func (b *EngagementBudget) RunBudget() (*checker.Budget, error) {
+	if b == nil || b.IsZero() {
+		return nil, nil
+	}
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	budget := &checker.Budget{MaxRequests: b.MaxRequests, MaxBytes: b.MaxBytes}
+	if b.MaxDuration != "" {
+		budget.MaxDuration, _ = time.ParseDuration(b.MaxDuration)
+	}
+	return budget, nil
+}
+
+var engagementBudgetCmd = &cobra.Command{
+	Use:   "budget",
+	Short: "Manage per-run request, download, and time limits for an engagement",
+	Long: `Limit how much traffic and time a single check run may spend against an
+engagement's targets.
+
+When a limit is reached the run stops starting new targets, lets in-flight
+checks finish, and seals the partial results with the reason recorded in the
+run metadata.`,
+}
+
+var engagementBudgetSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set run budget limits for an engagement",
+	Example: `  # At most 5000 requests, 500MB downloaded, and 30 minutes per run
+  seca engagement budget set --id eng123 --max-requests 5000 --max-bytes 500MB --max-duration 30m`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		appCtx := getAppContext(cmd)
+
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		if err := requireWritableEngagement(ctx, appCtx, id); err != nil {
+			return err
+		}
+
+		budget, err := engagementBudgetFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if budget.IsZero() {
+			return fmt.Errorf("set at least one of --max-requests, --max-bytes, or --max-duration")
+		}
+		if err := budget.Validate(); err != nil {
+			return err
+		}
+		if err := saveEngagementBudget(appCtx.ResultsDir, id, budget); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "%s run budget for engagement %s: %s\n", colorSuccess("✓"), id, budget.Summary())
+		return nil
+	},
+}
+
+var engagementBudgetShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the run budget for an engagement",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		budget, err := loadEngagementBudget(appCtx.ResultsDir, id)
+		if err != nil {
+			return err
+		}
+		if budget == nil {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s no run budget configured for engagement %s\n", colorInfo("→"), id)
+			return nil
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", colorInfo("→"), budget.Summary())
+		return nil
+	},
+}
+
+var engagementBudgetClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove the run budget for an engagement",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		path, err := resolveResultsPath(appCtx.ResultsDir, id, engagementBudgetFilename)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove run budget: %w", err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s cleared run budget for engagement %s\n", colorSuccess("✓"), id)
+		return nil
+	},
+}
+
+func engagementBudgetFromFlags(cmd *cobra.Command) (EngagementBudget, error) {
+	var budget EngagementBudget
+	budget.MaxRequests, _ = cmd.Flags().GetInt64("max-requests")
+	if raw, _ := cmd.Flags().GetString("max-bytes"); raw != "" {
+		n, err := parseByteSize(raw)
+		if err != nil {
+			return budget, fmt.Errorf("--max-bytes: %w", err)
+		}
+		budget.MaxBytes = n
+	}
+	budget.MaxDuration, _ = cmd.Flags().GetString("max-duration")
+	budget.MaxDuration = strings.TrimSpace(budget.MaxDuration)
+	return budget, nil
+}
+
+// byteUnits are 1024-based, matching how operators size downloads.
+var byteUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// parseByteSize accepts a plain byte count or a size such as 512KB, 500MB, or 2GB.
+func parseByteSize(raw string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(raw))
+	multiplier := int64(1)
+	for _, unit := range byteUnits {
+		if strings.HasSuffix(s, unit.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix))
+			multiplier = unit.size
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < 0 || math.IsInf(n, 0) || math.IsNaN(n) {
+		return 0, fmt.Errorf("invalid size %q (use e.g. 500MB)", raw)
+	}
+	total := n * float64(multiplier)
+	if total > math.MaxInt64 {
+		return 0, fmt.Errorf("size %q is too large", raw)
+	}
+	return int64(total), nil
+}
+
+// formatByteSize renders n with the largest unit that keeps it readable.
+func formatByteSize(n int64) string {
+	for _, unit := range byteUnits[:3] {
+		if n >= unit.size {
+			return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(n)/float64(unit.size)), ".0") + unit.suffix
+		}
+	}
+	return fmt.Sprintf("%dB", n)
+}
+
+// loadEngagementBudget returns the stored run budget, or nil when none is set.
+func loadEngagementBudget(resultsDir, engagementID string) (*EngagementBudget, error) {
+	path, err := resolveResultsPath(resultsDir, engagementID, engagementBudgetFilename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var budget EngagementBudget
+	if err := json.Unmarshal(data, &budget); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", engagementBudgetFilename, err)
+	}
+	return &budget, nil
+}
+
+func saveEngagementBudget(resultsDir, engagementID string, budget EngagementBudget) error {
+	if _, err := ensureResultsDir(resultsDir, engagementID); err != nil {
+		return err
+	}
+	path, err := resolveResultsPath(resultsDir, engagementID, engagementBudgetFilename)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(budget, jsonPrefix, jsonIndent)
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFile(path, data, consts.DefaultFilePerm)
+}
+
+// openRunBudget loads the engagement's run budget for a new run and starts
+// its clock. The budget is nil when none is configured.
+func openRunBudget(resultsDir, engagementID string) (*checker.Budget, *EngagementBudget, error) {
+	stored, err := loadEngagementBudget(resultsDir, engagementID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load run budget: %w", err)
+	}
+	budget, err := stored.RunBudget()
+	if err != nil {
+		return nil, nil, fmt.Errorf("run budget: %w", err)
+	}
+	// The clock starts now so crawling and session setup count against it.
+	budget.Start()
+	return budget, stored, nil
+}
+
+func printRunBudget(stored *EngagementBudget) {
+	if stored != nil && !stored.IsZero() {
+		fmt.Printf("%s Run budget: %s\n", colorInfo("→"), stored.Summary())
+	}
+}
+
+// budgetStopReason describes why a run ended early, or "" when the budget
+// was not exhausted.
+func budgetStopReason(budget *checker.Budget, checked, total int) string {
+	reason := budget.Exceeded()
+	if reason == "" {
+		return ""
+	}
+	return fmt.Sprintf("budget: %s (%d of %d targets checked)", reason, checked, total)
+}
+
+// finishBudgetedRun records a budget stop on the check run and tells the
+// operator how much was spent. It returns the stop reason, or "".
+func finishBudgetedRun(budget *checker.Budget, engagementID string, checked, total int) string {
+	reason := budgetStopReason(budget, checked, total)
+	if reason == "" {
+		return ""
+	}
+	usage := budget.Usage()
+	fmt.Printf("%s Run stopped early: %s\n", colorWarn("!"), reason)
+	fmt.Printf("  Spent %d requests, %s downloaded in %s\n", usage.Requests, formatByteSize(usage.Bytes), usage.Elapsed.Round(time.Second))
+	cliLog().Warnw("run_budget_exceeded",
+		"engagement_id", engagementID,
+		"reason", reason,
+		"requests", usage.Requests,
+		"bytes", usage.Bytes,
+		"elapsed", usage.Elapsed.String(),
+		"checked", checked,
+		"total", total,
+	)
+	return reason
+}
+
+func init() {
+	engagementCmd.AddCommand(engagementBudgetCmd)
+	engagementBudgetCmd.AddCommand(engagementBudgetSetCmd)
+	engagementBudgetCmd.AddCommand(engagementBudgetShowCmd)
+	engagementBudgetCmd.AddCommand(engagementBudgetClearCmd)
+
+	engagementBudgetSetCmd.Flags().String("id", "", "Engagement ID")
+	engagementBudgetSetCmd.Flags().Int64("max-requests", 0, "Maximum HTTP requests per run (0 = unlimited)")
+	engagementBudgetSetCmd.Flags().String("max-bytes", "", "Maximum response bytes downloaded per run, e.g. 500MB")
+	engagementBudgetSetCmd.Flags().String("max-duration", "", "Maximum wall-clock time per run, e.g. 30m")
+
+	engagementBudgetShowCmd.Flags().String("id", "", "Engagement ID")
+	engagementBudgetClearCmd.Flags().String("id", "", "Engagement ID")
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func TestParseByteSize(t *testing.T) {
+	cases := map[string]int64{
+		"2048":   2048,
+		"512KB":  512 << 10,
+		"500mb":  500 << 20,
+		"1.5 GB": 3 << 29,
+		"10B":    10,
+	}
+	for in, want := range cases {
+		got, err := parseByteSize(in)
+		if err != nil || got != want {
+			t.Fatalf("parseByteSize(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "MB", "-1KB", "lots"} {
+		if _, err := parseByteSize(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestFormatByteSize(t *testing.T) {
+	cases := map[int64]string{
+		500:       "500B",
+		2048:      "2KB",
+		500 << 20: "500MB",
+		3 << 29:   "1.5GB",
+	}
+	for in, want := range cases {
+		if got := formatByteSize(in); got != want {
+			t.Fatalf("formatByteSize(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestEngagementBudgetRoundTrip(t *testing.T) {
+	resultsDir := t.TempDir()
+
+	if budget, err := loadEngagementBudget(resultsDir, "eng-1"); err != nil || budget != nil {
+		t.Fatalf("expected no budget, got %+v (err %v)", budget, err)
+	}
+
+	stored := EngagementBudget{MaxRequests: 100, MaxBytes: 1 << 20, MaxDuration: "15m"}
+	if err := saveEngagementBudget(resultsDir, "eng-1", stored); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	run, loaded, err := openRunBudget(resultsDir, "eng-1")
+	if err != nil {
+		t.Fatalf("openRunBudget: %v", err)
+	}
+	if loaded == nil || *loaded != stored {
+		t.Fatalf("expected %+v, got %+v", stored, loaded)
+	}
+	if run.MaxRequests != 100 || run.MaxBytes != 1<<20 || run.MaxDuration != 15*time.Minute {
+		t.Fatalf("unexpected run budget %+v", run)
+	}
+	if got := stored.Summary(); got != "max 100 requests, 1MB downloaded, 15m wall-clock" {
+		t.Fatalf("unexpected summary %q", got)
+	}
+}
+
+func TestEngagementBudgetValidate(t *testing.T) {
+	for _, b := range []EngagementBudget{
+		{MaxRequests: -1},
+		{MaxBytes: -1},
+		{MaxDuration: "soon"},
+		{MaxDuration: "0s"},
+	} {
+		if err := b.Validate(); err == nil {
+			t.Fatalf("expected validation error for %+v", b)
+		}
+	}
+}
+
+func TestBudgetStopReason(t *testing.T) {
+	if reason := budgetStopReason(nil, 3, 3); reason != "" {
+		t.Fatalf("expected no reason without a budget, got %q", reason)
+	}
+
+	budget := &checker.Budget{MaxDuration: time.Nanosecond}
+	budget.Start()
+	time.Sleep(time.Millisecond)
+	reason := budgetStopReason(budget, 2, 5)
+	if reason != "budget: max duration of 1ns reached (2 of 5 targets checked)" {
+		t.Fatalf("unexpected reason %q", reason)
+	}
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/domain/engagement"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/issuesync"
+	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
+)
+
+// Audit seal states shown by the engagement dashboard.
+const (
+	auditSealNone     = "none" // Nothing audited yet
+	auditSealUnsealed = "unsealed"
+	auditSealSealed   = "sealed"
+	auditSealSigned   = "signed"
+)
+
+// EngagementHealth is one engagement's row in "seca engagement status".
+type EngagementHealth struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	// LastRunAt and SuccessRate describe the latest results; both are
+	// omitted when the engagement has not been checked.
+	LastRunAt            *time.Time  `json:"last_run_at,omitempty"`
+	SuccessRate          *float64    `json:"success_rate,omitempty"`
+	OpenCriticalFindings int         `json:"open_critical_findings"`
+	CertExpiringSoonest  *CertExpiry `json:"cert_expiring_soonest,omitempty"`
+	AuditSeal            string      `json:"audit_seal"`
+	// Error explains why part of the row could not be read.
+	Error string `json:"error,omitempty"`
+}
+
+// CertExpiry is the certificate of one target.
+type CertExpiry struct {
+	Target   string    `json:"target"`
+	NotAfter time.Time `json:"not_after"`
+	DaysLeft int       `json:"days_left"` // Negative once expired
+}
+
+// engagementHealth summarizes every engagement, those needing attention
+// first: most open critical findings, then the soonest certificate expiry.
+func engagementHealth(ctx context.Context, appCtx *AppContext, now time.Time) ([]EngagementHealth, error) {
+	engagements, err := appCtx.Services.EngagementService.ListEngagements(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list engagements: %w", err)
+	}
+
+	rows := make([]EngagementHealth, 0, len(engagements))
+	for _, eng := range engagements {
+		rows = append(rows, summarizeEngagementHealth(ctx, appCtx, eng, now))
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if a.OpenCriticalFindings != b.OpenCriticalFindings {
+			return a.OpenCriticalFindings > b.OpenCriticalFindings
+		}
+		if (a.CertExpiringSoonest == nil) != (b.CertExpiringSoonest == nil) {
+			return a.CertExpiringSoonest != nil
+		}
+		if a.CertExpiringSoonest != nil && !a.CertExpiringSoonest.NotAfter.Equal(b.CertExpiringSoonest.NotAfter) {
+			return a.CertExpiringSoonest.NotAfter.Before(b.CertExpiringSoonest.NotAfter)
+		}
+		return a.ID < b.ID
+	})
+	return rows, nil
+}
+
+func summarizeEngagementHealth(ctx context.Context, appCtx *AppContext, eng *engagement.Engagement, now time.Time) EngagementHealth {
+	row := EngagementHealth{ID: eng.ID(), Name: eng.Name(), Status: string(eng.Status()), AuditSeal: auditSealNone}
+	var problems []string
+
+	switch trail, err := appCtx.Services.AuditService.GetAuditTrail(ctx, eng.ID()); {
+	case errors.Is(err, sharedErrors.ErrAuditTrailNotFound):
+	case err != nil:
+		problems = append(problems, "audit: "+err.Error())
+	case trail.IsSigned():
+		row.AuditSeal = auditSealSigned
+	case trail.IsSealed():
+		row.AuditSeal = auditSealSealed
+	case len(trail.Entries()) > 0:
+		row.AuditSeal = auditSealUnsealed
+	}
+
+	findings, err := summarizeLatestResults(appCtx.ResultsDir, eng.ID(), &row, now)
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+	accepted := map[string]bool{}
+	if baseline, err := loadFindingsBaseline(appCtx.ResultsDir, eng.ID()); err != nil {
+		problems = append(problems, err.Error())
+	} else if baseline != nil {
+		for _, f := range baseline.Findings {
+			accepted[f.Fingerprint] = true
+		}
+	}
+	for _, f := range findings {
+		if strings.EqualFold(f.Severity, "critical") && !accepted[issuesync.Fingerprint(eng.ID(), f)] {
+			row.OpenCriticalFindings++
+		}
+	}
+
+	row.Error = strings.Join(problems, "; ")
+	return row
+}
+
+// summarizeLatestResults fills in the row's last run, success rate and
+// certificate expiry from the latest results, and returns the engagement's
+// automated and manual findings.
+func summarizeLatestResults(resultsDir, engagementID string, row *EngagementHealth, now time.Time) ([]issuesync.Finding, error) {
+	manual, err := loadManualFindings(resultsDir, engagementID)
+	if err != nil {
+		return nil, err
+	}
+	findings := manualIssueFindings(manual)
+
+	files, err := discoverResultFiles(resultsDir, engagementID)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && len(files) == 0) {
+		return findings, nil
+	}
+	if err != nil {
+		return findings, err
+	}
+	output, _, err := aggregateRunOutputs(resultsDir, engagementID)
+	if err != nil {
+		return findings, err
+	}
+
+	lastRun := output.Metadata.CompleteAt
+	if lastRun.IsZero() {
+		lastRun = output.Metadata.StartAt
+	}
+	if !lastRun.IsZero() {
+		row.LastRunAt = &lastRun
+	}
+	rate := countStatuses(output.Results).SuccessRate()
+	row.SuccessRate = &rate
+
+	for _, r := range output.Results {
+		notAfter, err := time.Parse(time.RFC3339, r.TLSExpiry)
+		if err != nil {
+			continue
+		}
+		if row.CertExpiringSoonest == nil || notAfter.Before(row.CertExpiringSoonest.NotAfter) {
+			row.CertExpiringSoonest = &CertExpiry{
+				Target:   r.Target,
+				NotAfter: notAfter,
+				DaysLeft: int(notAfter.Sub(now).Hours() / 24),
+			}
+		}
+	}
+	return append(findings, findingsFromResults(output.Results)...), nil
+}
+
+func writeEngagementHealthTable(out io.Writer, rows []EngagementHealth) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tSTATUS\tLAST RUN\tSUCCESS\tOPEN CRITICAL\tCERT EXPIRES\tAUDIT")
+	for _, row := range rows {
+		lastRun, success, cert := "never", "-", "-"
+		if row.LastRunAt != nil {
+			lastRun = row.LastRunAt.Local().Format("2006-01-02 15:04")
+		}
+		if row.SuccessRate != nil {
+			success = formatSuccessRate(*row.SuccessRate)
+		}
+		if c := row.CertExpiringSoonest; c != nil {
+			cert = fmt.Sprintf("%dd (%s)", c.DaysLeft, c.Target)
+		}
+		audit := row.AuditSeal
+		if row.Error != "" {
+			audit += " (error: " + row.Error + ")"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\n", row.ID, row.Name, row.Status, lastRun, success, row.OpenCriticalFindings, cert, audit)
+	}
+	w.Flush()
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/domain/engagement"
+	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
+	"github.com/spf13/cobra"
+)
+
+// parseExpiry accepts a date (expiring at the start of that day, UTC), an
+// RFC 3339 timestamp, or a duration from now such as 720h.
+func parseExpiry(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t.UTC(), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return now.Add(d).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid expiry %q (use YYYY-MM-DD, RFC 3339, or a duration like 720h)", value)
+}
+
+// checkWritable refuses changes to a closed engagement, or to one whose
+// authorization has lapsed (pointing at engagement extend).
+func checkWritable(eng *engagement.Engagement) error {
+	if eng.IsClosed() {
+		return fmt.Errorf("engagement %s: %w", eng.ID(), sharedErrors.ErrEngagementClosed)
+	}
+	if !eng.IsExpired() {
+		return nil
+	}
+	return fmt.Errorf("engagement %s expired on %s: %w; run 'engagement extend --id %s --until <date> --roe-confirm' to re-authorize",
+		eng.ID(), eng.ExpiresAt().Format(time.RFC3339), sharedErrors.ErrEngagementExpired, eng.ID())
+}
+
+// requireWritableEngagement loads the engagement and refuses the change when
+// it is closed or has expired.
+func requireWritableEngagement(ctx context.Context, appCtx *AppContext, id string) error {
+	eng, err := appCtx.Services.EngagementService.GetEngagement(ctx, id)
+	if err != nil {
+		if errors.Is(err, sharedErrors.ErrEngagementNotFound) {
+			return fmt.Errorf("engagement %s not found", id)
+		}
+		return fmt.Errorf("failed to get engagement: %w", err)
+	}
+	return checkWritable(eng)
+}
+
+var engagementExtendCmd = &cobra.Command{
+	Use:   "extend",
+	Short: "Extend an engagement's authorization (requires re-confirming ROE)",
+	Long: `Move an engagement's expiry to a later date. Extending authorizes further
+testing, so the rules of engagement must be confirmed again with --roe-confirm.
+This also lifts the read-only state of an expired engagement.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		appCtx := getAppContext(cmd)
+
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return errors.New("--id is required")
+		}
+		untilFlag, _ := cmd.Flags().GetString("until")
+		if untilFlag == "" {
+			return errors.New("--until is required")
+		}
+		roeConfirm, _ := cmd.Flags().GetBool("roe-confirm")
+		if !roeConfirm {
+			return errors.New("must pass --roe-confirm to extend the engagement's authorization")
+		}
+		until, err := parseExpiry(untilFlag, time.Now())
+		if err != nil {
+			return err
+		}
+
+		eng, err := appCtx.Services.EngagementService.GetEngagement(ctx, id)
+		if err != nil {
+			if errors.Is(err, sharedErrors.ErrEngagementNotFound) {
+				return fmt.Errorf("engagement %s not found", id)
+			}
+			return fmt.Errorf("failed to get engagement: %w", err)
+		}
+		previous := "never"
+		if !eng.ExpiresAt().IsZero() {
+			previous = eng.ExpiresAt().Format(time.RFC3339)
+		}
+
+		if err := appCtx.Services.EngagementService.ExtendEngagement(ctx, id, until, roeConfirm); err != nil {
+			return err
+		}
+
+		notes := fmt.Sprintf("ROE re-confirmed; expiry moved from %s to %s", previous, until.Format(time.RFC3339))
+		if err := recordAuditEvent(ctx, appCtx, id, "engagement extend", id, notes); err != nil {
+			return err
+		}
+
+		fmt.Printf("%s engagement %s until %s\n", colorSuccess("Extended"), id, until.Format(time.RFC3339))
+		return nil
+	},
+}
+
+func init() {
+	engagementCmd.AddCommand(engagementExtendCmd)
+
+	engagementExtendCmd.Flags().String("id", "", "Engagement ID")
+	engagementExtendCmd.Flags().String("until", "", "New expiry: YYYY-MM-DD, RFC 3339 timestamp, or duration from now (e.g. 720h)")
+	engagementExtendCmd.Flags().Bool("roe-confirm", false, "Re-confirm the Rules of Engagement for the extended period")
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
+)
+
+func TestParseExpiry(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2026-04-01", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"2026-04-01T09:30:00+02:00", time.Date(2026, 4, 1, 7, 30, 0, 0, time.UTC)},
+		{"48h", now.Add(48 * time.Hour)},
+	}
+	for _, tt := range tests {
+		got, err := parseExpiry(tt.in, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseExpiry(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "next week", "-1h"} {
+		if _, err := parseExpiry(bad, now); err == nil {
+			t.Errorf("parseExpiry(%q): expected error", bad)
+		}
+	}
+}
+
+func TestExpiredEngagementIsReadOnly(t *testing.T) {
+	defer setupTestAppContextWithServices(t)()
+
+	ctx := context.Background()
+	svc := globalAppContext.Services.EngagementService
+
+	created, err := svc.CreateEngagement(ctx, "Expiry Test", "owner@example.com", "Test ROE", []string{"https://example.com"})
+	if err != nil {
+		t.Fatalf("CreateEngagement() error = %v", err)
+	}
+	if err := svc.AcknowledgeROE(ctx, created.ID()); err != nil {
+		t.Fatalf("AcknowledgeROE() error = %v", err)
+	}
+	expiredAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	if err := svc.SetExpiry(ctx, created.ID(), expiredAt); err != nil {
+		t.Fatalf("SetExpiry() error = %v", err)
+	}
+
+	fetched, err := svc.GetEngagement(ctx, created.ID())
+	if err != nil {
+		t.Fatalf("GetEngagement() error = %v", err)
+	}
+	if !fetched.ExpiresAt().Equal(expiredAt) || !fetched.IsExpired() {
+		t.Fatalf("expected persisted expiry %v, got %v (expired=%v)", expiredAt, fetched.ExpiresAt(), fetched.IsExpired())
+	}
+	if err := checkWritable(fetched); !errors.Is(err, sharedErrors.ErrEngagementExpired) {
+		t.Fatalf("checkWritable() error = %v", err)
+	}
+
+	if err := svc.ValidateEngagementForChecks(ctx, created.ID(), ""); !errors.Is(err, sharedErrors.ErrEngagementExpired) {
+		t.Fatalf("ValidateEngagementForChecks() error = %v, want expired", err)
+	}
+	if err := svc.AddToScope(ctx, created.ID(), []string{"https://api.example.com"}); !errors.Is(err, sharedErrors.ErrEngagementExpired) {
+		t.Fatalf("AddToScope() error = %v, want expired", err)
+	}
+
+	until := time.Now().Add(24 * time.Hour)
+	if err := svc.ExtendEngagement(ctx, created.ID(), until, false); err == nil {
+		t.Fatal("expected extend without ROE confirmation to fail")
+	}
+	if err := svc.ExtendEngagement(ctx, created.ID(), until, true); err != nil {
+		t.Fatalf("ExtendEngagement() error = %v", err)
+	}
+	if err := svc.ValidateEngagementForChecks(ctx, created.ID(), ""); err != nil {
+		t.Fatalf("ValidateEngagementForChecks() after extend error = %v", err)
+	}
+	if err := svc.SetExpiry(ctx, created.ID(), until.Add(time.Hour)); err == nil {
+		t.Fatal("expected SetExpiry to refuse pushing the expiry back")
+	}
+}
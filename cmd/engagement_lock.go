@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"syscall"
+	"time"
+)
+
+// engagementLockFilename marks a check run in progress. Only one run per
+// engagement may write audit.csv and the results files at a time.
+const engagementLockFilename = "run.lock"
+
+// engagementLockPoll is how often a waiting run retries the lock.
+const engagementLockPoll = 500 * time.Millisecond
+
+// engagementLockGrace is how long a lockfile may stay unreadable while its
+// holder writes it. Older unreadable lockfiles were left by a run that
+// crashed between creating and writing them.
+const engagementLockGrace = 10 * time.Second
+
+// errEngagementLocked is returned when another run holds the engagement lock.
+var errEngagementLocked = errors.New("engagement is locked by another run")
+
+// engagementLockInfo is the lockfile content, shown to runs that find the
+// engagement busy.
+type engagementLockInfo struct {
+	PID        int       `json:"pid"`
+	Host       string    `json:"host"`
+	Operator   string    `json:"operator,omitempty"`
+	Command    string    `json:"command"`
+	AcquiredAt time.Time `json:"acquired_at"`
+
+	// unreadable is set for a lockfile that could not be parsed, with
+	// AcquiredAt taken from its modification time.
+	unreadable bool
+	// raw is the lockfile content the holder was read from.
+	raw []byte
+}
+
+func (i engagementLockInfo) String() string {
+	if i.PID == 0 {
+		return "an unidentified run"
+	}
+	who := fmt.Sprintf("pid %d on %s", i.PID, i.Host)
+	if i.Operator != "" {
+		who = i.Operator + ", " + who
+	}
+	return fmt.Sprintf("%q (%s, since %s)", i.Command, who, i.AcquiredAt.Local().Format("2006-01-02 15:04:05"))
+}
+
+// engagementLock is a held engagement lock.
+type engagementLock struct {
+	path string
+	info engagementLockInfo
+}
+
+// acquireEngagementLock takes the engagement's run lock. When another run
+// holds it, acquireEngagementLock waits up to wait for it to be released,
+// or fails at once when wait is zero. Locks left by a process that no longer
+// runs on this host are removed.
+func acquireEngagementLock(ctx context.Context, resultsDir, engagementID, command, operator string, wait time.Duration) (*engagementLock, error) {
+	if _, err := ensureResultsDir(resultsDir, engagementID); err != nil {
+		return nil, err
+	}
+	path, err := resolveResultsPath(resultsDir, engagementID, engagementLockFilename)
+	if err != nil {
+		return nil, err
+	}
+	host, _ := os.Hostname()
+	lock := &engagementLock{path: path, info: engagementLockInfo{
+		PID:      os.Getpid(),
+		Host:     host,
+		Operator: operator,
+		Command:  command,
+	}}
+
+	deadline := time.Now().Add(wait)
+	announced := false
+	for {
+		holder, err := lock.tryAcquire()
+		if err != nil {
+			return nil, err
+		}
+		if holder == nil {
+			return lock, nil
+		}
+		if holder.unreadable && time.Since(holder.AcquiredAt) > engagementLockGrace {
+			cliLog().Warnw("engagement_lock_unreadable", "engagement_id", engagementID, "path", path)
+			if err := reclaimEngagementLock(path, holder); err != nil {
+				return nil, fmt.Errorf("remove unreadable lock %s: %w", path, err)
+			}
+			continue
+		}
+		if !holder.unreadable && holder.Host == host && !processAlive(holder.PID) {
+			cliLog().Warnw("engagement_lock_stale", "engagement_id", engagementID, "pid", holder.PID, "path", path)
+			if err := reclaimEngagementLock(path, holder); err != nil {
+				return nil, fmt.Errorf("remove stale lock: %w", err)
+			}
+			continue
+		}
+		if !time.Now().Before(deadline) {
+			if holder.unreadable {
+				return nil, fmt.Errorf("%w: lockfile %s is unreadable; if no run is in progress on this engagement, delete it and retry", errEngagementLocked, path)
+			}
+			hint := "wait for it to finish or pass --wait-lock"
+			if wait > 0 {
+				hint = fmt.Sprintf("gave up after %s", wait)
+			}
+			return nil, fmt.Errorf("%w: %s holds %s; %s", errEngagementLocked, holder, path, hint)
+		}
+		if !announced {
+			fmt.Printf("%s Waiting for %s to finish on engagement %s\n", colorWarn("!"), holder, engagementID)
+			announced = true
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(engagementLockPoll):
+		}
+	}
+}
+
+// tryAcquire creates the lockfile, or returns the current holder when it
+// already exists. A holder whose lockfile cannot be parsed, because it is
+// still being written or was left empty by a crash, is marked unreadable.
+func (l *engagementLock) tryAcquire() (*engagementLockInfo, error) {
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("create lock: %w", err)
+		}
+		data, readErr := os.ReadFile(l.path)
+		if errors.Is(readErr, os.ErrNotExist) {
+			return l.tryAcquire() // released between the two calls
+		}
+		var holder engagementLockInfo
+		if readErr != nil || json.Unmarshal(data, &holder) != nil || holder.PID == 0 {
+			holder = engagementLockInfo{unreadable: true, AcquiredAt: time.Now()}
+			if fi, err := os.Stat(l.path); err == nil {
+				holder.AcquiredAt = fi.ModTime()
+			}
+		}
+		holder.raw = data
+		return &holder, nil
+	}
+	defer f.Close()
+
+	l.info.AcquiredAt = time.Now().UTC()
+	data, err := json.MarshalIndent(l.info, jsonPrefix, jsonIndent)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = os.Remove(l.path)
+		return nil, fmt.Errorf("write lock: %w", err)
+	}
+	return nil, nil
+}
+
+// reclaimEngagementLock removes the lockfile at path left by holder. Other
+// runs may be reclaiming the same lockfile, and one of them may already have
+// replaced it with its own, so the lockfile is first moved aside under a name
+// unique to this run and only deleted when it is still the one holder was
+// read from. Any other lockfile is put back.
+func reclaimEngagementLock(path string, holder *engagementLockInfo) error {
+	aside := fmt.Sprintf("%s.%d-%d.stale", path, os.Getpid(), time.Now().UnixNano())
+	if err := os.Rename(path, aside); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil // released or reclaimed by another run
+		}
+		return err
+	}
+	data, err := os.ReadFile(aside)
+	if err == nil && bytes.Equal(data, holder.raw) {
+		return os.Remove(aside)
+	}
+
+	// Linking, unlike renaming, never replaces a lockfile a third run created
+	// in the meantime.
+	if err := os.Link(aside, path); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			cliLog().Warnw("engagement_lock_reclaim_conflict", "path", path)
+			return os.Remove(aside)
+		}
+		return os.Rename(aside, path)
+	}
+	return os.Remove(aside)
+}
+
+// Release removes the lockfile if this process still owns it.
+func (l *engagementLock) Release() {
+	if l == nil {
+		return
+	}
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return
+	}
+	var holder engagementLockInfo
+	if json.Unmarshal(data, &holder) != nil || holder.PID != l.info.PID || !holder.AcquiredAt.Equal(l.info.AcquiredAt) {
+		return
+	}
+	if err := os.Remove(l.path); err != nil {
+		cliLog().Warnw("engagement_lock_release_failed", "path", l.path, "error", err)
+	}
+}
+
+// processAlive reports whether pid is a running process on this host.
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		// FindProcess fails for exited processes on Windows.
+		return true
+	}
+	err = proc.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEngagementLock_SecondRunFails(t *testing.T) {
+	resultsDir := t.TempDir()
+	ctx := context.Background()
+
+	lock, err := acquireEngagementLock(ctx, resultsDir, "eng-1", "check http", "alice", 0)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	if _, err := acquireEngagementLock(ctx, resultsDir, "eng-1", "check dns", "bob", 0); !errors.Is(err, errEngagementLocked) {
+		t.Fatalf("expected errEngagementLocked, got %v", err)
+	}
+	if other, err := acquireEngagementLock(ctx, resultsDir, "eng-2", "check dns", "bob", 0); err != nil {
+		t.Fatalf("other engagements must not be blocked: %v", err)
+	} else {
+		other.Release()
+	}
+
+	lock.Release()
+	if _, err := os.Stat(filepath.Join(resultsDir, "eng-1", engagementLockFilename)); !os.IsNotExist(err) {
+		t.Fatalf("expected lockfile removed, got %v", err)
+	}
+	again, err := acquireEngagementLock(ctx, resultsDir, "eng-1", "check dns", "bob", 0)
+	if err != nil {
+		t.Fatalf("acquire after release: %v", err)
+	}
+	again.Release()
+}
+
+func TestEngagementLock_WaitsForRelease(t *testing.T) {
+	resultsDir := t.TempDir()
+	ctx := context.Background()
+
+	lock, err := acquireEngagementLock(ctx, resultsDir, "eng-1", "check http", "", 0)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	go func() {
+		time.Sleep(2 * engagementLockPoll)
+		lock.Release()
+	}()
+
+	queued, err := acquireEngagementLock(ctx, resultsDir, "eng-1", "check network", "", 10*time.Second)
+	if err != nil {
+		t.Fatalf("expected the queued run to get the lock: %v", err)
+	}
+	queued.Release()
+}
+
+func TestEngagementLock_RemovesStaleLock(t *testing.T) {
+	resultsDir := t.TempDir()
+	if _, err := ensureResultsDir(resultsDir, "eng-1"); err != nil {
+		t.Fatal(err)
+	}
+	host, _ := os.Hostname()
+	// PIDs above the kernel maximum are never running.
+	stale, _ := json.Marshal(engagementLockInfo{PID: 1 << 30, Host: host, Command: "check http", AcquiredAt: time.Now()})
+	if err := os.WriteFile(filepath.Join(resultsDir, "eng-1", engagementLockFilename), stale, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	lock, err := acquireEngagementLock(context.Background(), resultsDir, "eng-1", "check dns", "", 0)
+	if err != nil {
+		t.Fatalf("expected the stale lock to be replaced: %v", err)
+	}
+	lock.Release()
+}
+
+func TestEngagementLock_ConcurrentReclaim(t *testing.T) {
+	resultsDir := t.TempDir()
+	if _, err := ensureResultsDir(resultsDir, "eng-1"); err != nil {
+		t.Fatal(err)
+	}
+	host, _ := os.Hostname()
+	stale, _ := json.Marshal(engagementLockInfo{PID: 1 << 30, Host: host, Command: "check http", AcquiredAt: time.Now()})
+	path := filepath.Join(resultsDir, "eng-1", engagementLockFilename)
+	if err := os.WriteFile(path, stale, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every run sees the same stale lock; only one may end up holding it.
+	const runs = 16
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		locks []*engagementLock
+	)
+	start := make(chan struct{})
+	for range runs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			lock, err := acquireEngagementLock(context.Background(), resultsDir, "eng-1", "check dns", "", 0)
+			if err == nil {
+				mu.Lock()
+				locks = append(locks, lock)
+				mu.Unlock()
+			} else if !errors.Is(err, errEngagementLocked) {
+				t.Errorf("acquire: %v", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	if len(locks) != 1 {
+		t.Fatalf("expected exactly one run to take the lock, got %d", len(locks))
+	}
+	locks[0].Release()
+
+	leftovers, _ := filepath.Glob(path + ".*")
+	if len(leftovers) != 0 {
+		t.Fatalf("expected no lockfiles moved aside to remain, got %v", leftovers)
+	}
+}
+
+func TestReclaimEngagementLock_KeepsReplacedLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), engagementLockFilename)
+	stale := &engagementLockInfo{PID: 1 << 30, raw: []byte(`{"pid":1073741824}`)}
+	current := []byte(`{"pid":42}`)
+	if err := os.WriteFile(path, current, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another run already replaced the stale lock with its own.
+	if err := reclaimEngagementLock(path, stale); err != nil {
+		t.Fatalf("reclaim: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != string(current) {
+		t.Fatalf("expected the replacement lock to be kept, got %q (%v)", data, err)
+	}
+
+	if err := os.WriteFile(path, stale.raw, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := reclaimEngagementLock(path, stale); err != nil {
+		t.Fatalf("reclaim: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected the stale lock removed, got %v", err)
+	}
+}
+
+func TestEngagementLock_EmptyLockfile(t *testing.T) {
+	resultsDir := t.TempDir()
+	if _, err := ensureResultsDir(resultsDir, "eng-1"); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(resultsDir, "eng-1", engagementLockFilename)
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// A fresh empty lockfile may still be being written by its holder.
+	_, err := acquireEngagementLock(context.Background(), resultsDir, "eng-1", "check dns", "", 0)
+	if !errors.Is(err, errEngagementLocked) || !strings.Contains(err.Error(), path) || !strings.Contains(err.Error(), "delete it") {
+		t.Fatalf("expected an error naming the lockfile, got %v", err)
+	}
+
+	// Once past the grace period it was left by a crash and is reclaimed.
+	old := time.Now().Add(-2 * engagementLockGrace)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+	lock, err := acquireEngagementLock(context.Background(), resultsDir, "eng-1", "check dns", "", 0)
+	if err != nil {
+		t.Fatalf("expected the empty lockfile to be reclaimed: %v", err)
+	}
+	lock.Release()
+}
@@ -0,0 +1,322 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// notesFilename holds the operator's narrative for an engagement.
+	notesFilename = "notes.json"
+	// maxNotesLength bounds the notes so they fit in a report.
+	maxNotesLength = 256 << 10
+	// notesAuditCommand names notes changes in the audit trail.
+	notesAuditCommand = "engagement notes"
+)
+
+// EngagementNotes is free-form narrative written by the operator, such as
+// methodology notes, caveats and client communications. It appears in its
+// own report section. Every change records SHA256 in the audit trail, so a
+// sealed audit trail covers the notes as they were when it was sealed.
+type EngagementNotes struct {
+	Text      string    `json:"text"`
+	SHA256    string    `json:"sha256"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedBy string    `json:"updated_by"`
+}
+
+// normalizeNotes converts line endings and trims surrounding blank space.
+func normalizeNotes(text string) (string, error) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.TrimSpace(text)
+	if len(text) > maxNotesLength {
+		return "", fmt.Errorf("notes must be at most %d KiB", maxNotesLength>>10)
+	}
+	return text, nil
+}
+
+func notesDigest(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// loadEngagementNotes returns the engagement's notes, or nil when none are
+// written.
+func loadEngagementNotes(resultsDir, engagementID string) (*EngagementNotes, error) {
+	path, err := resolveResultsPath(resultsDir, engagementID, notesFilename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var n EngagementNotes
+	if err := json.Unmarshal(data, &n); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", notesFilename, err)
+	}
+	return &n, nil
+}
+
+func saveEngagementNotes(resultsDir, engagementID string, n EngagementNotes) error {
+	if _, err := ensureResultsDir(resultsDir, engagementID); err != nil {
+		return err
+	}
+	path, err := resolveResultsPath(resultsDir, engagementID, notesFilename)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(n, jsonPrefix, jsonIndent)
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFile(path, data, consts.DefaultFilePerm)
+}
+
+// updateEngagementNotes replaces the notes with text, removing them when text
+// is blank, and records the change in the audit trail. It reports false when
+// the notes were already text.
+func updateEngagementNotes(ctx context.Context, appCtx *AppContext, id, text string) (bool, error) {
+	text, err := normalizeNotes(text)
+	if err != nil {
+		return false, err
+	}
+	existing, err := loadEngagementNotes(appCtx.ResultsDir, id)
+	if err != nil {
+		return false, err
+	}
+	if (existing == nil && text == "") || (existing != nil && existing.Text == text) {
+		return false, nil
+	}
+
+	if text == "" {
+		path, err := resolveResultsPath(appCtx.ResultsDir, id, notesFilename)
+		if err != nil {
+			return false, err
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return false, fmt.Errorf("remove notes: %w", err)
+		}
+		return true, recordAuditEvent(ctx, appCtx, id, notesAuditCommand, id, "notes cleared")
+	}
+
+	n := EngagementNotes{Text: text, SHA256: notesDigest(text), UpdatedAt: time.Now().UTC(), UpdatedBy: appCtx.Operator}
+	if err := saveEngagementNotes(appCtx.ResultsDir, id, n); err != nil {
+		return false, err
+	}
+	notes := fmt.Sprintf("notes updated (%d bytes, sha256=%s)", len(text), n.SHA256)
+	return true, recordAuditEvent(ctx, appCtx, id, notesAuditCommand, id, notes)
+}
+
+// auditedNotesDigest returns the digest of the notes as last recorded in the
+// audit trail, or "" when they were never recorded or were last cleared.
+func auditedNotesDigest(ctx context.Context, appCtx *AppContext, id string) (string, error) {
+	trail, err := appCtx.Services.AuditService.GetAuditTrail(ctx, id)
+	if errors.Is(err, sharedErrors.ErrAuditTrailNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	digest := ""
+	for _, e := range trail.Entries() {
+		if e.Command != notesAuditCommand {
+			continue
+		}
+		digest = ""
+		if _, after, ok := strings.Cut(e.Notes, "sha256="); ok {
+			digest = strings.TrimSuffix(after, ")")
+		}
+	}
+	return digest, nil
+}
+
+func writeNotesPDF(pdf *gofpdf.Fpdf, n *EngagementNotes) {
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, "Operator Notes", "", 1, "", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	pdf.MultiCell(0, 5, n.Text, "", "", false)
+	pdf.SetFont("Arial", "I", 9)
+	pdf.CellFormat(0, 6, fmt.Sprintf("Last edited by %s at %s", n.UpdatedBy, formatShortTimestamp(n.UpdatedAt)), "", 1, "", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	pdf.Ln(5)
+}
+
+// editInEditor opens initial in $VISUAL or $EDITOR (vi when neither is set)
+// and returns the saved text.
+func editInEditor(initial string) (string, error) {
+	editor := strings.Fields(os.Getenv("VISUAL"))
+	if len(editor) == 0 {
+		editor = strings.Fields(os.Getenv("EDITOR"))
+	}
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+
+	f, err := os.CreateTemp("", "seca-notes-*.md")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(initial); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	// #nosec G204 -- the editor is chosen by the operator running the command.
+	c := exec.Command(editor[0], append(editor[1:], f.Name())...)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := c.Run(); err != nil {
+		return "", fmt.Errorf("editor %s: %w", editor[0], err)
+	}
+	data, err := os.ReadFile(f.Name())
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+var engagementNotesCmd = &cobra.Command{
+	Use:   "notes",
+	Short: "Edit the operator's narrative included in an engagement's reports",
+	Long: `Keep free-form notes for an engagement: methodology, caveats, client
+communications, anything the report should say that the checks cannot. The
+notes appear in their own section of every report format. Each change is
+recorded in the audit trail with the SHA-256 of the notes, so sealing the
+audit trail also covers them.`,
+}
+
+var engagementNotesEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit an engagement's notes in $VISUAL or $EDITOR",
+	Long: `Open the engagement's notes in $VISUAL or $EDITOR (vi when neither is set).
+Saving an empty file removes the notes. With --file the notes are replaced by
+the file's contents ("-" reads standard input) without opening an editor.`,
+	Example: `  seca engagement notes edit --id eng123
+  seca engagement notes edit --id eng123 --file methodology.md
+  echo "Testing paused 14:00-15:00 at the client's request" | seca engagement notes edit --id eng123 --file -`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		appCtx := getAppContext(cmd)
+
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return errors.New("--id is required")
+		}
+		if err := requireWritableEngagement(ctx, appCtx, id); err != nil {
+			return err
+		}
+
+		var text string
+		switch file, _ := cmd.Flags().GetString("file"); file {
+		case "":
+			existing, err := loadEngagementNotes(appCtx.ResultsDir, id)
+			if err != nil {
+				return err
+			}
+			initial := ""
+			if existing != nil {
+				initial = existing.Text + "\n"
+			}
+			if text, err = editInEditor(initial); err != nil {
+				return err
+			}
+		case "-":
+			data, err := io.ReadAll(io.LimitReader(cmd.InOrStdin(), maxNotesLength+1))
+			if err != nil {
+				return fmt.Errorf("read notes: %w", err)
+			}
+			text = string(data)
+		default:
+			data, err := os.ReadFile(file) // #nosec G304 -- path supplied by the operator.
+			if err != nil {
+				return fmt.Errorf("read notes: %w", err)
+			}
+			text = string(data)
+		}
+
+		changed, err := updateEngagementNotes(ctx, appCtx, id, text)
+		if err != nil {
+			return err
+		}
+		switch {
+		case !changed:
+			fmt.Fprintf(cmd.OutOrStdout(), "%s notes for engagement %s unchanged\n", colorInfo("→"), id)
+		case strings.TrimSpace(text) == "":
+			fmt.Fprintf(cmd.OutOrStdout(), "%s cleared notes for engagement %s\n", colorSuccess("✓"), id)
+		default:
+			fmt.Fprintf(cmd.OutOrStdout(), "%s saved notes for engagement %s\n", colorSuccess("✓"), id)
+		}
+		return nil
+	},
+}
+
+var engagementNotesShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show an engagement's notes",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return errors.New("--id is required")
+		}
+		n, err := loadEngagementNotes(appCtx.ResultsDir, id)
+		if err != nil {
+			return err
+		}
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			b, _ := json.MarshalIndent(n, jsonPrefix, jsonIndent)
+			fmt.Fprintln(cmd.OutOrStdout(), string(b))
+			return nil
+		}
+		if n == nil {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s no notes for engagement %s\n", colorInfo("→"), id)
+			return nil
+		}
+
+		out := cmd.OutOrStdout()
+		fmt.Fprintln(out, n.Text)
+		fmt.Fprintf(out, "\n%s edited by %s at %s\n", colorInfo("→"), n.UpdatedBy, n.UpdatedAt.Format(time.RFC3339))
+		audited, err := auditedNotesDigest(ctx, appCtx, id)
+		if err != nil {
+			return err
+		}
+		if audited != notesDigest(n.Text) {
+			fmt.Fprintf(out, "%s notes differ from those recorded in the audit trail; they were changed outside seca\n", colorWarn("!"))
+		}
+		return nil
+	},
+}
+
+func init() {
+	engagementCmd.AddCommand(engagementNotesCmd)
+	engagementNotesCmd.AddCommand(engagementNotesEditCmd)
+	engagementNotesCmd.AddCommand(engagementNotesShowCmd)
+
+	engagementNotesEditCmd.Flags().String("id", "", "Engagement ID")
+	engagementNotesEditCmd.Flags().String("file", "", `Replace the notes with this file instead of opening an editor ("-" for stdin)`)
+	engagementNotesShowCmd.Flags().String("id", "", "Engagement ID")
+	engagementNotesShowCmd.Flags().Bool("json", false, "Output notes as JSON")
+}
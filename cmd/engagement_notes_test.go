@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/domain/engagement"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func TestEngagementNotesAreAudited(t *testing.T) {
+	defer setupTestAppContextWithServices(t)()
+
+	ctx := context.Background()
+	appCtx := globalAppContext
+	const id = "eng-notes"
+	now := time.Now()
+	eng := engagement.Reconstruct(id, "Notes", "owner", "Test ROE", nil, true, now, time.Time{}, now)
+	if err := appCtx.Services.EngagementRepo.Save(ctx, eng); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	changed, err := updateEngagementNotes(ctx, appCtx, id, "Tested from the client VPN.\r\nLogin rate limits were raised for us.\n\n")
+	if err != nil || !changed {
+		t.Fatalf("updateEngagementNotes() = %v, %v", changed, err)
+	}
+	n, err := loadEngagementNotes(appCtx.ResultsDir, id)
+	if err != nil || n == nil {
+		t.Fatalf("loadEngagementNotes() = %+v, %v", n, err)
+	}
+	if n.Text != "Tested from the client VPN.\nLogin rate limits were raised for us." || n.UpdatedBy != appCtx.Operator {
+		t.Errorf("unexpected notes %+v", n)
+	}
+	if audited, err := auditedNotesDigest(ctx, appCtx, id); err != nil || audited != n.SHA256 || audited != notesDigest(n.Text) {
+		t.Errorf("audited digest = %q (%v), want %q", audited, err, n.SHA256)
+	}
+
+	if changed, err := updateEngagementNotes(ctx, appCtx, id, n.Text+"\n"); err != nil || changed {
+		t.Errorf("expected unchanged notes not to be recorded again, got %v, %v", changed, err)
+	}
+	if _, err := updateEngagementNotes(ctx, appCtx, id, strings.Repeat("x", maxNotesLength+1)); err == nil {
+		t.Error("expected oversized notes to be rejected")
+	}
+
+	if changed, err := updateEngagementNotes(ctx, appCtx, id, "  \n"); err != nil || !changed {
+		t.Fatalf("clearing notes = %v, %v", changed, err)
+	}
+	if n, _ := loadEngagementNotes(appCtx.ResultsDir, id); n != nil {
+		t.Errorf("expected notes to be removed, got %+v", n)
+	}
+	if audited, _ := auditedNotesDigest(ctx, appCtx, id); audited != "" {
+		t.Errorf("expected no audited notes after clearing, got %q", audited)
+	}
+}
+
+func TestEngagementNotesInReports(t *testing.T) {
+	resultsDir := t.TempDir()
+	const id = "eng-notes"
+	start := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+	if _, err := ensureResultsDir(resultsDir, id); err != nil {
+		t.Fatalf("ensureResultsDir() error = %v", err)
+	}
+	writeRunOutputFile(t, resultsDir, id, "http_results.json", RunOutput{
+		Metadata: RunMetadata{EngagementID: id, StartAt: start, CompleteAt: start.Add(time.Minute)},
+		Results:  []checker.CheckResult{{Target: "https://app.example.com", Status: "ok"}},
+	})
+	text := "Staging only; production was out of scope."
+	if err := saveEngagementNotes(resultsDir, id, EngagementNotes{Text: text, SHA256: notesDigest(text), UpdatedAt: start, UpdatedBy: "alice"}); err != nil {
+		t.Fatalf("saveEngagementNotes() error = %v", err)
+	}
+
+	wants := map[string][]string{
+		"json": {`"text": "` + text + `"`, `"sha256": "` + notesDigest(text) + `"`},
+		"md":   {"## Operator Notes\n\n" + text, "Last edited by alice"},
+		"html": {`<div class="notes-text">` + text + `</div>`},
+	}
+	for format, want := range wants {
+		rendered, err := loadEngagementReport(resultsDir, id, format)
+		if err != nil {
+			t.Fatalf("loadEngagementReport(%s) error = %v", format, err)
+		}
+		var buf bytes.Buffer
+		if err := rendered.Render(&buf); err != nil {
+			t.Fatalf("Render(%s) error = %v", format, err)
+		}
+		for _, w := range want {
+			if !strings.Contains(buf.String(), w) {
+				t.Errorf("%s report missing %q", format, w)
+			}
+		}
+	}
+}
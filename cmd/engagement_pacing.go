@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+	"github.com/spf13/cobra"
+)
+
+// engagementPacingFilename holds the port scan politeness limits agreed for
+// an engagement.
+const engagementPacingFilename = "scan_pacing.json"
+
+// EngagementPacing limits port scan traffic per host. Zero values are
+// unlimited.
+type EngagementPacing struct {
+	MaxPerHost     int    `json:"max_per_host,omitempty"`
+	ProbeDelay     string `json:"probe_delay,omitempty"`
+	RandomizePorts bool   `json:"randomize_ports,omitempty"`
+}
+
+// IsZero reports whether the pacing sets no limits.
+func (p EngagementPacing) IsZero() bool {
+	return p.MaxPerHost == 0 && p.ProbeDelay == "" && !p.RandomizePorts
+}
+
+// Validate checks that the limits are non-negative and the delay parses.
+func (p EngagementPacing) Validate() error {
+	if p.MaxPerHost < 0 {
+		return fmt.Errorf("max per host must not be negative")
+	}
+	if p.ProbeDelay != "" {
+		d, err := time.ParseDuration(p.ProbeDelay)
+		if err != nil {
+			return fmt.Errorf("invalid probe delay %q: %w", p.ProbeDelay, err)
+		}
+		if d < 0 {
+			return fmt.Errorf("probe delay must not be negative")
+		}
+	}
+	return nil
+}
+
+// Summary describes the limits for console output and audit notes.
+func (p EngagementPacing) Summary() string {
+	var parts []string
+	if p.MaxPerHost > 0 {
+		parts = append(parts, fmt.Sprintf("%d concurrent probe(s) per host", p.MaxPerHost))
+	}
+	if p.ProbeDelay != "" {
+		parts = append(parts, p.ProbeDelay+" between probes")
+	}
+	if p.RandomizePorts {
+		parts = append(parts, "randomized port order")
+	}
+	if len(parts) == 0 {
+		return "no limits"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ScanPacing returns a fresh checker pacing for one run, or nil when no
+// limits are set.
+func (p *EngagementPacing) ScanPacing() (*checker.ScanPacing, error) {
+	if p == nil || p.IsZero() {
+		return nil, nil
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	pacing := &checker.ScanPacing{MaxPerHost: p.MaxPerHost, Randomize: p.RandomizePorts}
+	if p.ProbeDelay != "" {
+		pacing.Delay, _ = time.ParseDuration(p.ProbeDelay)
+	}
+	return pacing, nil
+}
+
+var engagementPacingCmd = &cobra.Command{
+	Use:   "pacing",
+	Short: "Manage port scan politeness limits for an engagement",
+	Long: `Keep port scans of sensitive hosts within agreed traffic limits.
+
+Limits apply per host across every target of a run, so crawled pages on the
+same host share one allowance. --port-workers still caps concurrency per
+target; the lower of the two wins.`,
+}
+
+var engagementPacingSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set port scan pacing for an engagement",
+	Example: `  # One probe at a time, 250ms apart, in random port order
+  seca engagement pacing set --id eng123 --max-per-host 1 --probe-delay 250ms --randomize-ports`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		appCtx := getAppContext(cmd)
+
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		if err := requireWritableEngagement(ctx, appCtx, id); err != nil {
+			return err
+		}
+
+		var pacing EngagementPacing
+		pacing.MaxPerHost, _ = cmd.Flags().GetInt("max-per-host")
+		pacing.ProbeDelay, _ = cmd.Flags().GetString("probe-delay")
+		pacing.ProbeDelay = strings.TrimSpace(pacing.ProbeDelay)
+		pacing.RandomizePorts, _ = cmd.Flags().GetBool("randomize-ports")
+		if pacing.IsZero() {
+			return fmt.Errorf("set at least one of --max-per-host, --probe-delay, or --randomize-ports")
+		}
+		if err := pacing.Validate(); err != nil {
+			return err
+		}
+		if err := saveEngagementPacing(appCtx.ResultsDir, id, pacing); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "%s scan pacing for engagement %s: %s\n", colorSuccess("✓"), id, pacing.Summary())
+		return nil
+	},
+}
+
+var engagementPacingShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show port scan pacing for an engagement",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		pacing, err := loadEngagementPacing(appCtx.ResultsDir, id)
+		if err != nil {
+			return err
+		}
+		if pacing == nil {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s no scan pacing configured for engagement %s\n", colorInfo("→"), id)
+			return nil
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", colorInfo("→"), pacing.Summary())
+		return nil
+	},
+}
+
+var engagementPacingClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove port scan pacing for an engagement",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		path, err := resolveResultsPath(appCtx.ResultsDir, id, engagementPacingFilename)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove scan pacing: %w", err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s cleared scan pacing for engagement %s\n", colorSuccess("✓"), id)
+		return nil
+	},
+}
+
+// loadEngagementPacing returns the stored scan pacing, or nil when none is set.
+func loadEngagementPacing(resultsDir, engagementID string) (*EngagementPacing, error) {
+	path, err := resolveResultsPath(resultsDir, engagementID, engagementPacingFilename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var pacing EngagementPacing
+	if err := json.Unmarshal(data, &pacing); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", engagementPacingFilename, err)
+	}
+	return &pacing, nil
+}
+
+func saveEngagementPacing(resultsDir, engagementID string, pacing EngagementPacing) error {
+	if _, err := ensureResultsDir(resultsDir, engagementID); err != nil {
+		return err
+	}
+	path, err := resolveResultsPath(resultsDir, engagementID, engagementPacingFilename)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(pacing, jsonPrefix, jsonIndent)
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFile(path, data, consts.DefaultFilePerm)
+}
+
+// openScanPacing loads the engagement's scan pacing for a new run. The
+// pacing is nil when none is configured.
+func openScanPacing(resultsDir, engagementID string) (*checker.ScanPacing, *EngagementPacing, error) {
+	stored, err := loadEngagementPacing(resultsDir, engagementID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load scan pacing: %w", err)
+	}
+	pacing, err := stored.ScanPacing()
+	if err != nil {
+		return nil, nil, fmt.Errorf("scan pacing: %w", err)
+	}
+	return pacing, stored, nil
+}
+
+func init() {
+	engagementCmd.AddCommand(engagementPacingCmd)
+	engagementPacingCmd.AddCommand(engagementPacingSetCmd)
+	engagementPacingCmd.AddCommand(engagementPacingShowCmd)
+	engagementPacingCmd.AddCommand(engagementPacingClearCmd)
+
+	engagementPacingSetCmd.Flags().String("id", "", "Engagement ID")
+	engagementPacingSetCmd.Flags().Int("max-per-host", 0, "Maximum concurrent port probes per host across all targets (0 = unlimited)")
+	engagementPacingSetCmd.Flags().String("probe-delay", "", "Minimum delay between port probes to one host, e.g. 250ms")
+	engagementPacingSetCmd.Flags().Bool("randomize-ports", false, "Probe ports in random order")
+
+	engagementPacingShowCmd.Flags().String("id", "", "Engagement ID")
+	engagementPacingClearCmd.Flags().String("id", "", "Engagement ID")
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEngagementPacingRoundTrip(t *testing.T) {
+	resultsDir := t.TempDir()
+
+	if pacing, stored, err := openScanPacing(resultsDir, "eng-1"); err != nil || pacing != nil || stored != nil {
+		t.Fatalf("expected no pacing, got %+v / %+v (err %v)", pacing, stored, err)
+	}
+
+	want := EngagementPacing{MaxPerHost: 2, ProbeDelay: "250ms", RandomizePorts: true}
+	if err := saveEngagementPacing(resultsDir, "eng-1", want); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	pacing, stored, err := openScanPacing(resultsDir, "eng-1")
+	if err != nil {
+		t.Fatalf("openScanPacing: %v", err)
+	}
+	if *stored != want {
+		t.Fatalf("stored = %+v, want %+v", *stored, want)
+	}
+	if pacing.MaxPerHost != 2 || pacing.Delay != 250*time.Millisecond || !pacing.Randomize {
+		t.Fatalf("unexpected run pacing %+v", pacing)
+	}
+	if got := pacing.MinDuration(5); got != time.Second {
+		t.Fatalf("MinDuration(5) = %v, want 1s", got)
+	}
+	if summary := stored.Summary(); summary != "2 concurrent probe(s) per host, 250ms between probes, randomized port order" {
+		t.Fatalf("Summary() = %q", summary)
+	}
+}
+
+func TestEngagementPacingValidate(t *testing.T) {
+	for _, bad := range []EngagementPacing{
+		{MaxPerHost: -1},
+		{ProbeDelay: "soon"},
+		{ProbeDelay: "-1s"},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Fatalf("expected error for %+v", bad)
+		}
+	}
+}
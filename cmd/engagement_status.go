@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/domain/engagement"
+	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
+	"github.com/spf13/cobra"
+)
+
+var engagementStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Summarize all engagements, or show or change one engagement's status",
+	Long: `Without --id, lists every engagement with its last run, success rate, open
+critical findings (not accepted in the baseline), the certificate expiring
+soonest, and whether its audit trail is sealed. Engagements with the most open
+critical findings, then the soonest certificate expiry, come first.
+
+With --id, shows or changes that engagement's status. Engagements move through
+draft -> active <-> paused -> closed. Checks run only while an engagement is
+active; acknowledging the ROE activates a draft. Closing is final: a closed
+engagement's scope, findings, and settings can no longer change, while its
+reports and audit trail stay available.`,
+	Example: `  # Triage the portfolio
+  seca engagement status
+  seca engagement status --json
+
+  # Pause testing during a change freeze, then resume
+  seca engagement status --id eng123 --set paused --reason "change freeze"
+  seca engagement status --id eng123 --set active`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		appCtx := getAppContext(cmd)
+
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			if set, _ := cmd.Flags().GetString("set"); set != "" {
+				return errors.New("--set requires --id")
+			}
+			rows, err := engagementHealth(ctx, appCtx, time.Now())
+			if err != nil {
+				return err
+			}
+			if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+				b, _ := json.MarshalIndent(rows, jsonPrefix, jsonIndent)
+				fmt.Fprintln(cmd.OutOrStdout(), string(b))
+				return nil
+			}
+			if len(rows) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No engagements")
+				return nil
+			}
+			writeEngagementHealthTable(cmd.OutOrStdout(), rows)
+			return nil
+		}
+
+		raw, _ := cmd.Flags().GetString("set")
+		if raw == "" {
+			eng, err := appCtx.Services.EngagementService.GetEngagement(ctx, id)
+			if err != nil {
+				if errors.Is(err, sharedErrors.ErrEngagementNotFound) {
+					return fmt.Errorf("engagement %s not found", id)
+				}
+				return fmt.Errorf("failed to get engagement: %w", err)
+			}
+			fmt.Printf("%s %s\n", colorInfo("Status:"), eng.Status())
+			return nil
+		}
+
+		to, err := engagement.ParseStatus(strings.ToLower(strings.TrimSpace(raw)))
+		if err != nil {
+			return err
+		}
+		from, err := appCtx.Services.EngagementService.TransitionStatus(ctx, id, to)
+		if err != nil {
+			return err
+		}
+
+		notes := fmt.Sprintf("status changed from %s to %s", from, to)
+		if reason, _ := cmd.Flags().GetString("reason"); strings.TrimSpace(reason) != "" {
+			notes += "; reason: " + strings.TrimSpace(reason)
+		}
+		if err := recordAuditEvent(ctx, appCtx, id, "engagement status", id, notes); err != nil {
+			return err
+		}
+
+		fmt.Printf("%s engagement %s: %s -> %s\n", colorSuccess("Updated"), id, from, to)
+		return nil
+	},
+}
+
+func init() {
+	engagementCmd.AddCommand(engagementStatusCmd)
+
+	engagementStatusCmd.Flags().String("id", "", "Engagement ID (omit to summarize all engagements)")
+	engagementStatusCmd.Flags().String("set", "", "New status: active|paused|closed")
+	engagementStatusCmd.Flags().String("reason", "", "Why the status changed (recorded in the audit trail)")
+	engagementStatusCmd.Flags().Bool("json", false, "Output the summary of all engagements as JSON")
+}
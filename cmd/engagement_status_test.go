@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/domain/engagement"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
+)
+
+func TestEngagementStatusWorkflow(t *testing.T) {
+	defer setupTestAppContextWithServices(t)()
+
+	ctx := context.Background()
+	svc := globalAppContext.Services.EngagementService
+
+	created, err := svc.CreateEngagement(ctx, "Status Test", "owner@example.com", "Test ROE", []string{"https://example.com"})
+	if err != nil {
+		t.Fatalf("CreateEngagement() error = %v", err)
+	}
+	if created.Status() != engagement.StatusDraft {
+		t.Fatalf("new engagement status = %s, want draft", created.Status())
+	}
+	if _, err := svc.TransitionStatus(ctx, created.ID(), engagement.StatusActive); err == nil {
+		t.Fatal("expected activation without ROE to fail")
+	}
+	if err := svc.AcknowledgeROE(ctx, created.ID()); err != nil {
+		t.Fatalf("AcknowledgeROE() error = %v", err)
+	}
+	if err := svc.ValidateEngagementForChecks(ctx, created.ID(), ""); err != nil {
+		t.Fatalf("active engagement should allow checks: %v", err)
+	}
+
+	if from, err := svc.TransitionStatus(ctx, created.ID(), engagement.StatusPaused); err != nil || from != engagement.StatusActive {
+		t.Fatalf("pause: from=%s err=%v", from, err)
+	}
+	if err := svc.ValidateEngagementForChecks(ctx, created.ID(), ""); !errors.Is(err, sharedErrors.ErrEngagementNotActive) {
+		t.Fatalf("paused engagement: got %v, want not active", err)
+	}
+	if _, err := svc.TransitionStatus(ctx, created.ID(), engagement.StatusDraft); err == nil {
+		t.Fatal("expected paused -> draft to be rejected")
+	}
+
+	if _, err := svc.TransitionStatus(ctx, created.ID(), engagement.StatusClosed); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	fetched, err := svc.GetEngagement(ctx, created.ID())
+	if err != nil || fetched.Status() != engagement.StatusClosed {
+		t.Fatalf("expected persisted closed status, got %v (%v)", fetched, err)
+	}
+	if err := checkWritable(fetched); !errors.Is(err, sharedErrors.ErrEngagementClosed) {
+		t.Fatalf("checkWritable() = %v, want closed", err)
+	}
+	if err := svc.AddToScope(ctx, created.ID(), []string{"https://api.example.com"}); !errors.Is(err, sharedErrors.ErrEngagementClosed) {
+		t.Fatalf("AddToScope() on closed engagement = %v", err)
+	}
+	if _, err := svc.TransitionStatus(ctx, created.ID(), engagement.StatusActive); !errors.Is(err, sharedErrors.ErrEngagementClosed) {
+		t.Fatalf("reopening a closed engagement = %v", err)
+	}
+}
+
+func TestParseEngagementStatus(t *testing.T) {
+	if s, err := engagement.ParseStatus("paused"); err != nil || s != engagement.StatusPaused {
+		t.Fatalf("ParseStatus(paused) = %s, %v", s, err)
+	}
+	if _, err := engagement.ParseStatus("archived"); err == nil {
+		t.Fatal("expected error for unknown status")
+	}
+}
+
+func TestEngagementHealth(t *testing.T) {
+	defer setupTestAppContextWithServices(t)()
+
+	ctx := context.Background()
+	appCtx := globalAppContext
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// Engagements created in the same second share an ID, so save them directly
+	quiet := engagement.Reconstruct("eng-quiet", "Quiet", "owner", "Test ROE", nil, true, now, time.Time{}, now)
+	busy := engagement.Reconstruct("eng-busy", "Busy", "owner", "Test ROE", []string{"https://example.com"}, true, now, time.Time{}, now)
+	for _, eng := range []*engagement.Engagement{quiet, busy} {
+		if err := appCtx.Services.EngagementRepo.Save(ctx, eng); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	completed := now.Add(-time.Hour)
+	if _, err := ensureResultsDir(appCtx.ResultsDir, busy.ID()); err != nil {
+		t.Fatalf("ensureResultsDir() error = %v", err)
+	}
+	writeRunOutputFile(t, appCtx.ResultsDir, busy.ID(), "http_results.json", RunOutput{
+		Metadata: RunMetadata{EngagementID: busy.ID(), StartAt: completed.Add(-time.Minute), CompleteAt: completed},
+		Results: []checker.CheckResult{
+			{Target: "https://example.com", Status: checker.StatusOK, TLSExpiry: now.Add(20 * 24 * time.Hour).Format(time.RFC3339)},
+			{Target: "https://www.example.com", Status: checker.StatusOK, TLSExpiry: now.Add(5 * 24 * time.Hour).Format(time.RFC3339)},
+			{Target: "https://down.example.com", Status: checker.StatusUnreachable},
+		},
+	})
+	err := saveManualFindings(appCtx.ResultsDir, busy.ID(), []ManualFinding{
+		{ID: "M-1", Target: "https://example.com", Title: "Default admin password", Severity: "Critical", Category: manualFindingCategory},
+		{ID: "M-2", Target: "https://example.com", Title: "Verbose errors", Severity: "Low", Category: manualFindingCategory},
+	})
+	if err != nil {
+		t.Fatalf("saveManualFindings() error = %v", err)
+	}
+	if err := recordAuditEvent(ctx, appCtx, busy.ID(), "engagement status", busy.ID(), "test"); err != nil {
+		t.Fatalf("recordAuditEvent() error = %v", err)
+	}
+
+	rows, err := engagementHealth(ctx, appCtx, now)
+	if err != nil {
+		t.Fatalf("engagementHealth() error = %v", err)
+	}
+	if len(rows) != 2 || rows[0].ID != busy.ID() || rows[1].ID != quiet.ID() {
+		t.Fatalf("expected the engagement with critical findings first, got %+v", rows)
+	}
+
+	got := rows[0]
+	if got.LastRunAt == nil || !got.LastRunAt.Equal(completed) {
+		t.Errorf("last run = %v, want %v", got.LastRunAt, completed)
+	}
+	if got.SuccessRate == nil || *got.SuccessRate < 66 || *got.SuccessRate > 67 {
+		t.Errorf("success rate = %v, want 2 of 3", got.SuccessRate)
+	}
+	if got.OpenCriticalFindings != 1 {
+		t.Errorf("open critical findings = %d, want 1", got.OpenCriticalFindings)
+	}
+	if c := got.CertExpiringSoonest; c == nil || c.Target != "https://www.example.com" || c.DaysLeft != 5 {
+		t.Errorf("unexpected certificate expiry: %+v", c)
+	}
+	if got.AuditSeal != auditSealUnsealed || got.Error != "" {
+		t.Errorf("unexpected audit state %q (error %q)", got.AuditSeal, got.Error)
+	}
+
+	if q := rows[1]; q.LastRunAt != nil || q.SuccessRate != nil || q.AuditSeal != auditSealNone || q.Error != "" {
+		t.Errorf("expected an unchecked engagement to show no run, got %+v", q)
+	}
+
+	// Accepting the finding in the baseline closes it
+	output, _, err := aggregateRunOutputs(appCtx.ResultsDir, busy.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	manual, _ := loadManualFindings(appCtx.ResultsDir, busy.ID())
+	vulns := append(manualVulnerabilities(manual), checker.BuildVulnerabilityReport(output.Results, "", "", "").Vulnerabilities...)
+	if err := saveFindingsBaseline(appCtx.ResultsDir, busy.ID(), &FindingsBaseline{SetAt: now, Findings: baselineFindings(busy.ID(), vulns)}); err != nil {
+		t.Fatal(err)
+	}
+	rows, _ = engagementHealth(ctx, appCtx, now)
+	if rows[0].ID != busy.ID() || rows[0].OpenCriticalFindings != 0 {
+		t.Errorf("expected accepted findings not to count, got %+v", rows[0])
+	}
+}
@@ -238,6 +238,7 @@ func TestValidateScopeEntry(t *testing.T) {
 		"example.com",
 		"sub.domain.com",
 		"192.168.0.1",
+		"https://bücher.example/shop",
 	}
 	for _, entry := range valid {
 		if err := validateScopeEntry(entry); err != nil {
@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+)
+
+const (
+	// evidenceDirName holds files attached to manual findings, HAR entries
+	// and DNS answer dumps, each named by its SHA-256. Raw captures stay at
+	// the top of the results directory (raw_<sha256>.txt) and screenshots
+	// under screenshots/, where retention scripts already expect them.
+	evidenceDirName = "evidence"
+	// evidenceIndexFilename lists every stored artifact with its target.
+	evidenceIndexFilename = "evidence.json"
+	// evidenceEmbedLimit is the largest text artifact embedded in HTML
+	// reports; larger ones are linked.
+	evidenceEmbedLimit = 16 * 1024
+)
+
+// evidenceMu serializes evidence index updates: raw captures are stored by
+// concurrent check workers.
+var evidenceMu sync.Mutex
+
+// loadEvidenceIndex returns the stored evidence index, or nil when no
+// evidence was stored.
+func loadEvidenceIndex(resultsDir, engagementID string) ([]checker.EvidenceRef, error) {
+	path, err := resolveResultsPath(resultsDir, engagementID, evidenceIndexFilename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var refs []checker.EvidenceRef
+	if err := json.Unmarshal(data, &refs); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", evidenceIndexFilename, err)
+	}
+	return refs, nil
+}
+
+// writeEvidence writes an artifact to dir/<prefix><sha256><ext> and records
+// it in the evidence index. Identical content is written once.
+func writeEvidence(resultsDir, engagementID string, ref checker.EvidenceRef, dir, prefix, ext string, data []byte) (checker.EvidenceRef, error) {
+	sum := sha256.Sum256(data)
+	ref.SHA256 = hex.EncodeToString(sum[:])
+	ref.Size = int64(len(data))
+	if ref.CapturedAt.IsZero() {
+		ref.CapturedAt = time.Now().UTC()
+	}
+	name := prefix + ref.SHA256 + ext
+	ref.File = filepath.ToSlash(filepath.Join(dir, name))
+
+	path, err := resolveResultsPath(resultsDir, engagementID, dir, name)
+	if err != nil {
+		return ref, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), consts.DefaultDirPerm); err != nil {
+		return ref, fmt.Errorf("create evidence directory: %w", err)
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		if err := fileutil.WriteFile(path, data, consts.DefaultFilePerm); err != nil {
+			return ref, fmt.Errorf("write evidence: %w", err)
+		}
+	}
+
+	evidenceMu.Lock()
+	defer evidenceMu.Unlock()
+	refs, err := loadEvidenceIndex(resultsDir, engagementID)
+	if err != nil {
+		return ref, err
+	}
+	for _, existing := range refs {
+		if existing.Kind == ref.Kind && existing.Target == ref.Target && existing.SHA256 == ref.SHA256 {
+			return existing, nil
+		}
+	}
+	refs = append(refs, ref)
+	sort.SliceStable(refs, func(i, j int) bool {
+		if refs[i].Target != refs[j].Target {
+			return refs[i].Target < refs[j].Target
+		}
+		return refs[i].CapturedAt.Before(refs[j].CapturedAt)
+	})
+	indexPath, err := resolveResultsPath(resultsDir, engagementID, evidenceIndexFilename)
+	if err != nil {
+		return ref, err
+	}
+	out, err := json.MarshalIndent(refs, jsonPrefix, jsonIndent)
+	if err != nil {
+		return ref, err
+	}
+	if err := fileutil.WriteFile(indexPath, out, consts.DefaultFilePerm); err != nil {
+		return ref, err
+	}
+	return ref, nil
+}
+
+// engagementEvidence returns the stored artifacts of an engagement: the
+// evidence index plus the screenshot index.
+func engagementEvidence(resultsDir, engagementID string) ([]checker.EvidenceRef, error) {
+	refs, err := loadEvidenceIndex(resultsDir, engagementID)
+	if err != nil {
+		return nil, err
+	}
+	shots, err := loadScreenshotIndex(resultsDir, engagementID)
+	if err != nil {
+		return nil, err
+	}
+	for _, shot := range shots {
+		refs = append(refs, checker.EvidenceRef{
+			Kind:       checker.EvidenceScreenshot,
+			Target:     shot.URL,
+			File:       shot.File,
+			SHA256:     shot.SHA256,
+			MediaType:  "image/png",
+			CapturedAt: shot.CapturedAt,
+		})
+	}
+	return refs, nil
+}
+
+// targetEvidence returns the latest artifact of each kind captured for
+// target. Artifacts without a target belong to a single manual finding and
+// are never shared.
+func targetEvidence(refs []checker.EvidenceRef, target string) []checker.EvidenceRef {
+	if target == "" {
+		return nil
+	}
+	canonical := canonicalTarget(target)
+	latest := make(map[string]checker.EvidenceRef)
+	var kinds []string
+	for _, ref := range refs {
+		if ref.Target == "" || (ref.Target != target && canonicalTarget(ref.Target) != canonical) {
+			continue
+		}
+		prev, seen := latest[ref.Kind]
+		if !seen {
+			kinds = append(kinds, ref.Kind)
+		}
+		if !seen || ref.CapturedAt.After(prev.CapturedAt) {
+			latest[ref.Kind] = ref
+		}
+	}
+	sort.Strings(kinds)
+	out := make([]checker.EvidenceRef, 0, len(kinds))
+	for _, kind := range kinds {
+		out = append(out, latest[kind])
+	}
+	return out
+}
+
+// attachEvidence links each vulnerability to the evidence captured on its
+// affected targets, keeping attachments it already has (manual findings).
+func attachEvidence(vulns []checker.Vulnerability, refs []checker.EvidenceRef) {
+	if len(refs) == 0 {
+		return
+	}
+	for i := range vulns {
+		for _, target := range vulns[i].AffectedURLs {
+			for _, ref := range targetEvidence(refs, target) {
+				vulns[i].Attachments = appendEvidence(vulns[i].Attachments, ref)
+			}
+		}
+	}
+}
+
+func appendEvidence(list []checker.EvidenceRef, ref checker.EvidenceRef) []checker.EvidenceRef {
+	for _, existing := range list {
+		if existing.File == ref.File {
+			return list
+		}
+	}
+	return append(list, ref)
+}
+
+// recordRunEvidence stores the per-target artifacts of a finished run (HAR
+// entries when recording, DNS answers) and attaches every artifact of a
+// target, including raw captures and screenshots, to its findings. Failures
+// are warnings: evidence supplements the results and audit trail.
+func recordRunEvidence(resultsDir, engagementID string, results []checker.CheckResult, har *checker.HARRecorder) {
+	var entries []checker.HAREntry
+	if har != nil {
+		entries = har.HAR(Version).Log.Entries
+	}
+	for _, r := range results {
+		if len(r.DNSRecords) > 0 {
+			data, err := json.MarshalIndent(map[string]interface{}{"target": r.Target, "checked_at": r.CheckedAt, "records": r.DNSRecords}, jsonPrefix, jsonIndent)
+			if err == nil {
+				ref := checker.EvidenceRef{Kind: checker.EvidenceDNS, Target: r.Target, MediaType: "application/json", CapturedAt: r.CheckedAt}
+				_, err = writeEvidence(resultsDir, engagementID, ref, evidenceDirName, "", ".json", data)
+			}
+			if err != nil {
+				cliLog().Warnw("evidence_save_failed", "engagement_id", engagementID, "target", r.Target, "kind", checker.EvidenceDNS, "error", err)
+			}
+		}
+		if matched := harEntriesFor(entries, r.Target); len(matched) > 0 {
+			doc := checker.HAR{Log: checker.HARLog{Version: "1.2", Creator: checker.HARCreator{Name: "seca-cli", Version: Version}, Entries: matched}}
+			data, err := json.MarshalIndent(doc, jsonPrefix, jsonIndent)
+			if err == nil {
+				ref := checker.EvidenceRef{Kind: checker.EvidenceHAR, Target: r.Target, MediaType: "application/har+json", CapturedAt: r.CheckedAt}
+				_, err = writeEvidence(resultsDir, engagementID, ref, evidenceDirName, "", ".har", data)
+			}
+			if err != nil {
+				cliLog().Warnw("evidence_save_failed", "engagement_id", engagementID, "target", r.Target, "kind", checker.EvidenceHAR, "error", err)
+			}
+		}
+	}
+
+	refs, err := engagementEvidence(resultsDir, engagementID)
+	if err != nil {
+		cliLog().Warnw("evidence_load_failed", "engagement_id", engagementID, "error", err)
+		return
+	}
+	for i := range results {
+		attached := targetEvidence(refs, results[i].Target)
+		for j := range results[i].Findings {
+			results[i].Findings[j].Attachments = attached
+		}
+	}
+}
+
+// harEntriesFor returns the recorded exchanges with the target's host.
+func harEntriesFor(entries []checker.HAREntry, target string) []checker.HAREntry {
+	host := checker.ExtractHost(target)
+	if host == "" {
+		return nil
+	}
+	var matched []checker.HAREntry
+	for _, e := range entries {
+		if strings.EqualFold(checker.ExtractHost(e.Request.URL), host) {
+			matched = append(matched, e)
+		}
+	}
+	return matched
+}
+
+// evidenceTexts reads the text artifacts small enough to embed in a report,
+// keyed by file.
+func evidenceTexts(resultsDir, engagementID string, refs []checker.EvidenceRef) map[string]string {
+	texts := make(map[string]string)
+	for _, ref := range refs {
+		if ref.Size == 0 || ref.Size > evidenceEmbedLimit || !evidenceIsText(ref) {
+			continue
+		}
+		path, err := resolveResultsPath(resultsDir, engagementID, filepath.FromSlash(ref.File))
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			cliLog().Warnw("evidence_read_failed", "engagement_id", engagementID, "file", ref.File, "error", err)
+			continue
+		}
+		texts[ref.File] = string(data)
+	}
+	return texts
+}
+
+func evidenceIsText(ref checker.EvidenceRef) bool {
+	return strings.HasPrefix(ref.MediaType, "text/") || ref.MediaType == "application/json"
+}
+
+// evidenceIsImage reports whether an artifact can be shown inline.
+func evidenceIsImage(ref checker.EvidenceRef) bool {
+	return strings.HasPrefix(ref.MediaType, "image/")
+}
+
+// EvidenceEntry is an open finding in a report with its attached evidence.
+type EvidenceEntry struct {
+	Finding     string
+	Attachments []checker.EvidenceRef
+}
+
+// evidenceEntries lists the open findings that have evidence, in report
+// order.
+func evidenceEntries(vulns []checker.Vulnerability) []EvidenceEntry {
+	var entries []EvidenceEntry
+	for _, v := range vulns {
+		if strings.EqualFold(v.Status, "Passed") || len(v.Attachments) == 0 {
+			continue
+		}
+		entries = append(entries, EvidenceEntry{Finding: v.Name, Attachments: v.Attachments})
+	}
+	return entries
+}
+
+// writeEvidencePDF lists each finding's evidence with its SHA-256 so the
+// artifacts in the results directory or bundle can be verified.
+func writeEvidencePDF(pdf *gofpdf.Fpdf, entries []EvidenceEntry) {
+	if pdf.GetY() > 240 {
+		pdf.AddPage()
+	}
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, "Evidence", "", 1, "", false, 0, "")
+	for _, entry := range entries {
+		if pdf.GetY() > 265 {
+			pdf.AddPage()
+		}
+		pdf.SetFont("Arial", "B", 8)
+		pdf.MultiCell(0, 4, entry.Finding, "", "", false)
+		pdf.SetFont("Arial", "", 7)
+		for _, ref := range entry.Attachments {
+			line := fmt.Sprintf("  %s: %s  SHA-256 %s", ref.Kind, ref.File, ref.SHA256)
+			if ref.Target != "" {
+				line += "  (" + ref.Target + ")"
+			}
+			pdf.MultiCell(0, 4, line, "", "", false)
+		}
+	}
+	pdf.Ln(3)
+}
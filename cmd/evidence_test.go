@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/khanhnv2901/seca-cli/internal/shared/i18n"
+)
+
+func TestWriteEvidenceIsHashAddressed(t *testing.T) {
+	resultsDir := t.TempDir()
+	const id = "eng-evidence"
+	if _, err := ensureResultsDir(resultsDir, id); err != nil {
+		t.Fatalf("ensureResultsDir() error = %v", err)
+	}
+	ref := checker.EvidenceRef{Kind: checker.EvidenceDNS, Target: "example.com", MediaType: "application/json"}
+	first, err := writeEvidence(resultsDir, id, ref, evidenceDirName, "", ".json", []byte(`{"a":1}`))
+	if err != nil {
+		t.Fatalf("writeEvidence() error = %v", err)
+	}
+	if first.File != "evidence/"+first.SHA256+".json" || first.Size != 7 {
+		t.Fatalf("unexpected ref: %+v", first)
+	}
+	data, err := os.ReadFile(filepath.Join(resultsDir, id, filepath.FromSlash(first.File)))
+	if err != nil || string(data) != `{"a":1}` {
+		t.Fatalf("stored evidence = %q (%v)", data, err)
+	}
+
+	again, err := writeEvidence(resultsDir, id, ref, evidenceDirName, "", ".json", []byte(`{"a":1}`))
+	if err != nil {
+		t.Fatalf("writeEvidence() error = %v", err)
+	}
+	if again.CapturedAt != first.CapturedAt {
+		t.Error("identical evidence should keep its first index entry")
+	}
+	if _, err := writeEvidence(resultsDir, id, ref, evidenceDirName, "", ".json", []byte(`{"a":2}`)); err != nil {
+		t.Fatalf("writeEvidence() error = %v", err)
+	}
+	refs, err := loadEvidenceIndex(resultsDir, id)
+	if err != nil || len(refs) != 2 {
+		t.Fatalf("expected two indexed artifacts, got %+v (%v)", refs, err)
+	}
+}
+
+func TestTargetEvidenceLatestPerKind(t *testing.T) {
+	old := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	refs := []checker.EvidenceRef{
+		{Kind: checker.EvidenceRawCapture, Target: "https://app.example.com", File: "raw_old.txt", CapturedAt: old},
+		{Kind: checker.EvidenceRawCapture, Target: "https://app.example.com/", File: "raw_new.txt", CapturedAt: old.Add(time.Hour)},
+		{Kind: checker.EvidenceScreenshot, Target: "https://app.example.com", File: "screenshots/a.png", CapturedAt: old},
+		{Kind: checker.EvidenceRawCapture, Target: "https://other.example.com", File: "raw_other.txt", CapturedAt: old},
+		{Kind: checker.EvidenceFile, File: "evidence/manual.png", CapturedAt: old},
+	}
+	got := targetEvidence(refs, "https://app.example.com")
+	if len(got) != 2 || got[0].File != "raw_new.txt" || got[1].File != "screenshots/a.png" {
+		t.Fatalf("targetEvidence() = %+v", got)
+	}
+
+	vulns := []checker.Vulnerability{
+		{Name: "X-Frame-Options", Status: "Failed", AffectedURLs: []string{"https://app.example.com"}},
+		{Name: "Manual", Status: "Failed", Attachments: []checker.EvidenceRef{refs[4]}},
+	}
+	attachEvidence(vulns, refs)
+	if len(vulns[0].Attachments) != 2 || len(vulns[1].Attachments) != 1 {
+		t.Fatalf("unexpected attachments: %+v", vulns)
+	}
+}
+
+func writeEvidenceTestEngagement(t *testing.T, resultsDir, id string) {
+	t.Helper()
+	if _, err := ensureResultsDir(resultsDir, id); err != nil {
+		t.Fatalf("ensureResultsDir() error = %v", err)
+	}
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	writeRunOutputFile(t, resultsDir, id, "http_results.json", RunOutput{
+		Metadata: RunMetadata{EngagementID: id, StartAt: start, CompleteAt: start.Add(time.Minute)},
+		Results: []checker.CheckResult{{
+			Target: "https://app.example.com",
+			Status: "ok",
+			SecurityHeaders: &checker.SecurityHeadersResult{
+				Headers: map[string]checker.HeaderStatus{
+					"X-Frame-Options": {Present: false, Severity: "high", Recommendation: "Add 'X-Frame-Options: DENY'"},
+				},
+				Missing: []string{"X-Frame-Options"},
+			},
+		}},
+	})
+	if err := SaveRawCapture(resultsDir, id, "https://app.example.com", map[string][]string{"Server": {"nginx"}}, "<html>hello</html>"); err != nil {
+		t.Fatalf("SaveRawCapture() error = %v", err)
+	}
+}
+
+func TestReportEmbedsEvidence(t *testing.T) {
+	resultsDir := t.TempDir()
+	const id = "eng-evidence-report"
+	writeEvidenceTestEngagement(t, resultsDir, id)
+
+	for format, want := range map[string]string{"html": "&lt;html&gt;hello&lt;/html&gt;", "md": "## Evidence"} {
+		rendered, err := loadEngagementReport(resultsDir, id, format)
+		if err != nil {
+			t.Fatalf("loadEngagementReport() error = %v", err)
+		}
+		var buf bytes.Buffer
+		if err := rendered.Render(&buf); err != nil {
+			t.Fatalf("Render(%s) error = %v", format, err)
+		}
+		if !strings.Contains(buf.String(), want) || !strings.Contains(buf.String(), "raw_") {
+			t.Errorf("%s report should show the raw capture (%q)", format, want)
+		}
+	}
+}
+
+func TestWriteEvidenceBundle(t *testing.T) {
+	resultsDir := t.TempDir()
+	const id = "eng-bundle"
+	writeEvidenceTestEngagement(t, resultsDir, id)
+	if err := os.WriteFile(filepath.Join(resultsDir, id, engagementAuthFilename), []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	manifest, err := writeEvidenceBundle(&buf, resultsDir, id, "alice", i18n.Default())
+	if err != nil {
+		t.Fatalf("writeEvidenceBundle() error = %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+	names := make(map[string]bool)
+	for _, f := range zr.File {
+		names[f.Name] = true
+	}
+	for _, want := range []string{"http_results.json", evidenceIndexFilename, bundleReportFilename, bundleManifestFilename} {
+		if !names[want] {
+			t.Errorf("bundle missing %s", want)
+		}
+	}
+	if names[engagementAuthFilename] {
+		t.Error("credentials must not be bundled")
+	}
+	refs, _ := loadEvidenceIndex(resultsDir, id)
+	if len(refs) != 1 || !names[refs[0].File] {
+		t.Errorf("bundle should contain the raw capture %+v", refs)
+	}
+
+	mf, err := zr.Open(bundleManifestFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mf.Close()
+	data, _ := io.ReadAll(mf)
+	var stored BundleManifest
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatalf("parse manifest: %v", err)
+	}
+	if len(stored.Files) != len(manifest.Files) || len(stored.Files) != len(zr.File)-1 {
+		t.Errorf("manifest lists %d files, bundle has %d", len(stored.Files), len(zr.File))
+	}
+}
@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+	"github.com/spf13/cobra"
+)
+
+// expectedCertsFilename holds the expected certificate issuer/SANs per host.
+const expectedCertsFilename = "expected_certs.json"
+
+// loadCertExpectations returns the engagement's certificate expectations, or
+// an empty set when none were configured.
+func loadCertExpectations(resultsDir, engagementID string) (*checker.CertExpectations, error) {
+	path, err := resolveResultsPath(resultsDir, engagementID, expectedCertsFilename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return checker.NewCertExpectations(), nil
+		}
+		return nil, err
+	}
+	expectations := checker.NewCertExpectations()
+	if err := json.Unmarshal(data, expectations); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", expectedCertsFilename, err)
+	}
+	if expectations.Hosts == nil {
+		expectations.Hosts = make(map[string]checker.ExpectedCertificate)
+	}
+	return expectations, nil
+}
+
+func saveCertExpectations(resultsDir, engagementID string, expectations *checker.CertExpectations) error {
+	if _, err := ensureResultsDir(resultsDir, engagementID); err != nil {
+		return err
+	}
+	path, err := resolveResultsPath(resultsDir, engagementID, expectedCertsFilename)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(expectations, jsonPrefix, jsonIndent)
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFile(path, data, consts.DefaultFilePerm)
+}
+
+// checkExpectedCertificates compares every TLS result with the host's
+// expected certificate and stores the run's deviations for the report.
+// issuers holds the expected issuers set by scope overrides, per host key;
+// they apply to hosts whose recorded expectation names no issuer.
+func checkExpectedCertificates(resultsDir, engagementID string, results []checker.CheckResult, issuers map[string][]string, at time.Time) ([]checker.CertDeviation, error) {
+	expectations, err := loadCertExpectations(resultsDir, engagementID)
+	if err != nil {
+		return nil, err
+	}
+	effective := &checker.CertExpectations{Hosts: make(map[string]checker.ExpectedCertificate, len(expectations.Hosts)+len(issuers))}
+	for host, expected := range expectations.Hosts {
+		effective.Hosts[host] = expected
+	}
+	for host, list := range issuers {
+		expected := effective.Hosts[host]
+		if len(expected.Issuers) == 0 {
+			expected.Issuers = list
+		}
+		effective.Hosts[host] = expected
+	}
+	if len(effective.Hosts) == 0 {
+		return nil, nil
+	}
+
+	// Several URLs can share a host; check each host once.
+	sorted := append([]checker.CheckResult(nil), results...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Target < sorted[j].Target })
+
+	seen := make(map[string]bool)
+	deviations := []checker.CertDeviation{}
+	for _, r := range sorted {
+		if r.TLSCompliance == nil || r.TLSCompliance.CertificateInfo == nil {
+			continue
+		}
+		host := checker.HostKey(r.Target)
+		if seen[host] {
+			continue
+		}
+		seen[host] = true
+		if d := effective.Check(r.Target, r.TLSCompliance.CertificateInfo, at); d != nil {
+			deviations = append(deviations, *d)
+		}
+	}
+
+	if len(seen) == 0 {
+		return nil, nil
+	}
+	expectations.Deviations = deviations
+	if err := saveCertExpectations(resultsDir, engagementID, expectations); err != nil {
+		return nil, err
+	}
+	return deviations, nil
+}
+
+// trackExpectedCertificates checks certificates after a run and reports
+// deviations. Failures are logged; they never fail the run.
+func trackExpectedCertificates(resultsDir, engagementID string, results []checker.CheckResult, issuers map[string][]string) {
+	deviations, err := checkExpectedCertificates(resultsDir, engagementID, results, issuers, time.Now().UTC())
+	if err != nil {
+		cliLog().Warnw("expected_certs_check_failed", "engagement_id", engagementID, "error", err)
+		return
+	}
+	for _, d := range deviations {
+		fmt.Printf("%s Unexpected TLS certificate on %s: %s\n", colorWarn("!"), d.Host, strings.Join(d.Problems, "; "))
+		cliLog().Warnw("tls_cert_unexpected",
+			"engagement_id", engagementID,
+			"host", d.Host,
+			"issuer", d.Issuer,
+			"problems", d.Problems,
+		)
+	}
+}
+
+var engagementCertsCmd = &cobra.Command{
+	Use:   "certs",
+	Short: "Manage the certificates each host is expected to present",
+	Long: `Record which CA may issue each host's certificate and which names it must
+cover. HTTP check runs compare the certificate every host presents with its
+expectation and report deviations as high-severity findings: a certificate
+from an unexpected CA may indicate interception or misrouted DNS.`,
+}
+
+var engagementCertsSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set the expected issuer and names for a host",
+	Example: `  seca engagement certs set --id eng123 --host app.example.com \
+    --issuer "Let's Encrypt" --san app.example.com --san "*.app.example.com"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		host, _ := cmd.Flags().GetString("host")
+		issuers, _ := cmd.Flags().GetStringArray("issuer")
+		sans, _ := cmd.Flags().GetStringSlice("san")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		if host == "" {
+			return fmt.Errorf("--host is required")
+		}
+		if len(issuers) == 0 && len(sans) == 0 {
+			return fmt.Errorf("at least one --issuer or --san is required")
+		}
+		if _, err := appCtx.Services.EngagementService.GetEngagement(ctx, id); err != nil {
+			return fmt.Errorf("failed to get engagement: %w", err)
+		}
+
+		expectations, err := loadCertExpectations(appCtx.ResultsDir, id)
+		if err != nil {
+			return err
+		}
+		key := checker.HostKey(host)
+		expectations.Hosts[key] = checker.ExpectedCertificate{Issuers: issuers, SANs: sans}
+		if err := saveCertExpectations(appCtx.ResultsDir, id, expectations); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s expected certificate set for %s\n", colorSuccess("✓"), key)
+		return nil
+	},
+}
+
+var engagementCertsShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show expected certificates and the latest run's deviations",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		expectations, err := loadCertExpectations(appCtx.ResultsDir, id)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if len(expectations.Hosts) == 0 {
+			fmt.Fprintf(out, "%s no expected certificates configured for engagement %s\n", colorInfo("→"), id)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "HOST\tISSUERS\tSANS")
+		for _, host := range slices.Sorted(maps.Keys(expectations.Hosts)) {
+			expected := expectations.Hosts[host]
+			fmt.Fprintf(w, "%s\t%s\t%s\n", host, joinOrDash(expected.Issuers), joinOrDash(expected.SANs))
+		}
+		_ = w.Flush()
+
+		if len(expectations.Deviations) > 0 {
+			fmt.Fprintln(out)
+			fmt.Fprintln(out, "Deviations in the latest run:")
+			for _, d := range expectations.Deviations {
+				fmt.Fprintf(out, "  %s %s: %s\n", colorError("!"), d.Host, strings.Join(d.Problems, "; "))
+			}
+		}
+		return nil
+	},
+}
+
+var engagementCertsClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove the expectation for one host, or all hosts",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		host, _ := cmd.Flags().GetString("host")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+
+		if host == "" {
+			path, err := resolveResultsPath(appCtx.ResultsDir, id, expectedCertsFilename)
+			if err != nil {
+				return err
+			}
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s removed expected certificates for engagement %s\n", colorSuccess("✓"), id)
+			return nil
+		}
+
+		expectations, err := loadCertExpectations(appCtx.ResultsDir, id)
+		if err != nil {
+			return err
+		}
+		key := checker.HostKey(host)
+		delete(expectations.Hosts, key)
+		expectations.Deviations = slices.DeleteFunc(expectations.Deviations, func(d checker.CertDeviation) bool { return d.Host == key })
+		if err := saveCertExpectations(appCtx.ResultsDir, id, expectations); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s removed expected certificate for %s\n", colorSuccess("✓"), key)
+		return nil
+	},
+}
+
+func joinOrDash(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ", ")
+}
+
+func init() {
+	engagementCmd.AddCommand(engagementCertsCmd)
+	engagementCertsCmd.AddCommand(engagementCertsSetCmd)
+	engagementCertsCmd.AddCommand(engagementCertsShowCmd)
+	engagementCertsCmd.AddCommand(engagementCertsClearCmd)
+
+	engagementCertsSetCmd.Flags().String("id", "", "Engagement ID")
+	engagementCertsSetCmd.Flags().String("host", "", "Host (or target URL) the expectation applies to")
+	engagementCertsSetCmd.Flags().StringArray("issuer", nil, "Accepted issuer, matched against the issuer DN (repeatable)")
+	engagementCertsSetCmd.Flags().StringSlice("san", nil, "Name the certificate must cover (repeatable)")
+
+	engagementCertsShowCmd.Flags().String("id", "", "Engagement ID")
+
+	engagementCertsClearCmd.Flags().String("id", "", "Engagement ID")
+	engagementCertsClearCmd.Flags().String("host", "", "Host to clear (all hosts when omitted)")
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func certResult(target, issuer string) checker.CheckResult {
+	return checker.CheckResult{
+		Target: target,
+		Status: "ok",
+		TLSCompliance: &checker.TLSComplianceResult{
+			CertificateInfo: &checker.CertificateInfo{Issuer: issuer, DNSNames: []string{"app.example.com"}},
+		},
+	}
+}
+
+func TestCheckExpectedCertificates(t *testing.T) {
+	resultsDir := t.TempDir()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	results := []checker.CheckResult{
+		certResult("https://app.example.com/a", "CN=Proxy CA"),
+		certResult("https://app.example.com/b", "CN=Proxy CA"),
+		certResult("https://api.example.com", "CN=Proxy CA"),
+	}
+
+	// No expectations: nothing is checked or written.
+	deviations, err := checkExpectedCertificates(resultsDir, "eng-1", results, nil, now)
+	if err != nil || deviations != nil {
+		t.Fatalf("expected no deviations without expectations, got %v (err %v)", deviations, err)
+	}
+
+	expectations := checker.NewCertExpectations()
+	expectations.Hosts["app.example.com"] = checker.ExpectedCertificate{Issuers: []string{"Let's Encrypt"}, SANs: []string{"app.example.com"}}
+	if err := saveCertExpectations(resultsDir, "eng-1", expectations); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	deviations, err = checkExpectedCertificates(resultsDir, "eng-1", results, nil, now)
+	if err != nil {
+		t.Fatalf("checkExpectedCertificates: %v", err)
+	}
+	if len(deviations) != 1 || deviations[0].Host != "app.example.com" || len(deviations[0].Problems) != 1 {
+		t.Fatalf("expected one issuer deviation on app.example.com, got %+v", deviations)
+	}
+
+	stored, err := loadCertExpectations(resultsDir, "eng-1")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(stored.Deviations) != 1 {
+		t.Fatalf("expected the deviation to be stored for the report, got %+v", stored)
+	}
+
+	// A later clean run clears the stored deviations.
+	results = []checker.CheckResult{certResult("https://app.example.com", "CN=R11,O=Let's Encrypt")}
+	if _, err := checkExpectedCertificates(resultsDir, "eng-1", results, nil, now); err != nil {
+		t.Fatalf("checkExpectedCertificates: %v", err)
+	}
+	stored, _ = loadCertExpectations(resultsDir, "eng-1")
+	if len(stored.Deviations) != 0 {
+		t.Fatalf("expected deviations cleared, got %+v", stored.Deviations)
+	}
+}
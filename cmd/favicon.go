@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"iter"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/spf13/viper"
+)
+
+// loadFaviconDB returns the bundled favicon fingerprints, plus those in the
+// file named by http.favicon_fingerprints when it is set.
+func loadFaviconDB() (checker.FaviconDB, error) {
+	path := strings.TrimSpace(viper.GetString("http.favicon_fingerprints"))
+	if path == "" {
+		return checker.BundledFavicons(), nil
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- the path comes from the operator's config file.
+	if err != nil {
+		return nil, fmt.Errorf("http.favicon_fingerprints: %w", err)
+	}
+	extra, err := checker.ParseFaviconDB(data)
+	if err != nil {
+		return nil, fmt.Errorf("http.favicon_fingerprints %s: %w", path, err)
+	}
+	return checker.BundledFavicons().With(extra), nil
+}
+
+// TechnologyEntry is what a report shows about the software behind a host:
+// the Server header and the favicon, with its hashes for Shodan and Censys
+// pivots and the products it matched.
+type TechnologyEntry struct {
+	Host       string
+	Server     string
+	FaviconURL string
+	MMH3       int32
+	MD5        string
+	Products   []string
+}
+
+// buildTechnologyInventory collects one entry per host and favicon from the
+// results; crawled pages of a host usually share both.
+func buildTechnologyInventory(results iter.Seq[checker.CheckResult]) []TechnologyEntry {
+	var entries []TechnologyEntry
+	seen := make(map[string]bool)
+	for r := range results {
+		if r.Favicon == nil {
+			continue
+		}
+		host := checker.ExtractHost(r.Target)
+		key := host + "\x00" + r.Favicon.MD5
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		entries = append(entries, TechnologyEntry{
+			Host:       host,
+			Server:     r.ServerHeader,
+			FaviconURL: r.Favicon.URL,
+			MMH3:       r.Favicon.MMH3,
+			MD5:        r.Favicon.MD5,
+			Products:   r.Favicon.Products,
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Host < entries[j].Host })
+	return entries
+}
+
+// writeTechnologyPDF lists the technology inventory.
+func writeTechnologyPDF(pdf *gofpdf.Fpdf, entries []TechnologyEntry) {
+	if pdf.GetY() > 240 {
+		pdf.AddPage()
+	}
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, "Technology", "", 1, "", false, 0, "")
+	pdf.SetFont("Arial", "I", 8)
+	pdf.MultiCell(0, 4, "Favicon hashes can be searched with http.favicon.hash:<mmh3> on Shodan and the MD5 on Censys to find other hosts serving the same icon.", "", "", false)
+	pdf.Ln(2)
+
+	pdf.SetFont("Arial", "", 8)
+	for _, e := range entries {
+		if pdf.GetY() > 270 {
+			pdf.AddPage()
+		}
+		line := fmt.Sprintf("  - %s: %s; favicon mmh3 %d, md5 %s", e.Host, orDash(strings.Join(e.Products, ", ")), e.MMH3, e.MD5)
+		if e.Server != "" {
+			line += "; Server: " + e.Server
+		}
+		pdf.MultiCell(0, 4, line, "", "", false)
+	}
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/spf13/viper"
+)
+
+func TestLoadFaviconDB(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	db, err := loadFaviconDB()
+	if err != nil || len(db.Match(81586312)) == 0 {
+		t.Fatalf("expected the bundled fingerprints, got %v (err %v)", db, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "favicons.json")
+	if err := os.WriteFile(path, []byte(`{"fingerprints": [{"mmh3": 42, "product": "Internal Portal"}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("http.favicon_fingerprints", path)
+	db, err = loadFaviconDB()
+	if err != nil {
+		t.Fatalf("loadFaviconDB: %v", err)
+	}
+	if got := db.Match(42); len(got) != 1 || got[0] != "Internal Portal" || len(db.Match(81586312)) == 0 {
+		t.Errorf("expected bundled and custom fingerprints, got %v", db)
+	}
+
+	viper.Set("http.favicon_fingerprints", filepath.Join(t.TempDir(), "missing.json"))
+	if _, err := loadFaviconDB(); err == nil {
+		t.Error("expected an error for a missing fingerprint file")
+	}
+}
+
+func TestTechnologyReportSection(t *testing.T) {
+	favicon := &checker.FaviconResult{URL: "https://ci.example.com/favicon.ico", MMH3: 81586312, MD5: "0123456789abcdef0123456789abcdef", Products: []string{"Jenkins"}}
+	output := &RunOutput{
+		Metadata: RunMetadata{EngagementID: "eng-tech", StartAt: time.Now(), CompleteAt: time.Now()},
+		Results: []checker.CheckResult{
+			{Target: "https://ci.example.com", Status: "ok", ServerHeader: "Jetty(10.0.13)", Favicon: favicon},
+			{Target: "https://ci.example.com/login", Status: "ok", ServerHeader: "Jetty(10.0.13)", Favicon: favicon},
+			{Target: "https://www.example.com", Status: "ok"},
+		},
+	}
+	data := buildTemplateData(output, nil, "%.2f", nil)
+	if len(data.Technologies) != 1 {
+		t.Fatalf("expected crawled pages of a host to share an entry, got %+v", data.Technologies)
+	}
+
+	markdown, err := generateMarkdownReport(data)
+	if err != nil {
+		t.Fatalf("Failed to generate markdown report: %v", err)
+	}
+	if want := "| ci.example.com | Jenkins | Jetty(10.0.13) | `81586312` | `0123456789abcdef0123456789abcdef` |"; !strings.Contains(markdown, want) {
+		t.Errorf("expected markdown report to contain %q", want)
+	}
+	html, err := generateHTMLReport(data)
+	if err != nil {
+		t.Fatalf("Failed to generate HTML report: %v", err)
+	}
+	if !strings.Contains(html, "<code>81586312</code>") {
+		t.Error("expected HTML report to contain the favicon hash")
+	}
+}
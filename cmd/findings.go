@@ -0,0 +1,367 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/compliance"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/issuesync"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// manualFindingsFilename holds findings recorded by operators with
+	// "seca findings add".
+	manualFindingsFilename = "manual_findings.json"
+	manualFindingCategory  = "Manual Finding"
+)
+
+var manualSeverities = map[string]string{
+	"critical": "Critical",
+	"high":     "High",
+	"medium":   "Medium",
+	"low":      "Low",
+	"info":     "Info",
+}
+
+// ManualFinding is an observation recorded by an operator during an
+// engagement. It appears in reports and issue sync next to automated findings.
+type ManualFinding struct {
+	ID             string         `json:"id"`
+	Target         string         `json:"target"`
+	Title          string         `json:"title"`
+	Severity       string         `json:"severity"`
+	Category       string         `json:"category"`
+	Description    string         `json:"description,omitempty"`
+	Recommendation string         `json:"recommendation,omitempty"`
+	Control        string         `json:"control,omitempty"` // Security check name used for compliance mapping
+	CVSSVector     string         `json:"cvss_vector,omitempty"`
+	Evidence       []EvidenceFile `json:"evidence,omitempty"`
+	Operator       string         `json:"operator"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+// EvidenceFile is a file attached to a manual finding.
+type EvidenceFile struct {
+	File         string `json:"file"` // Relative to the engagement results directory
+	OriginalName string `json:"original_name"`
+	SHA256       string `json:"sha256"`
+}
+
+// Vulnerability converts the finding for the report's findings table. Evidence
+// is listed in the description and attached; compliance requirements come
+// from Control.
+func (f ManualFinding) Vulnerability() checker.Vulnerability {
+	description := f.Description
+	if len(f.Evidence) > 0 {
+		lines := make([]string, 0, len(f.Evidence))
+		for _, ev := range f.Evidence {
+			lines = append(lines, fmt.Sprintf("%s (SHA-256 %s)", ev.File, ev.SHA256))
+		}
+		description = strings.TrimSpace(description + "\n\nEvidence: " + strings.Join(lines, "; "))
+	}
+
+	vuln := checker.Vulnerability{
+		Name:           f.Title,
+		Category:       f.Category,
+		Severity:       f.Severity,
+		Status:         "Failed",
+		Description:    description,
+		Recommendation: f.Recommendation,
+		AffectedURLs:   []string{f.Target},
+	}
+	for _, ev := range f.Evidence {
+		vuln.Attachments = append(vuln.Attachments, checker.EvidenceRef{
+			Kind:       checker.EvidenceFile,
+			File:       ev.File,
+			SHA256:     ev.SHA256,
+			MediaType:  mime.TypeByExtension(path.Ext(ev.File)),
+			CapturedAt: f.CreatedAt,
+		})
+	}
+	if f.CVSSVector != "" {
+		if score, err := checker.NewCVSSScore(f.CVSSVector); err == nil {
+			vuln.CVSS = score
+		}
+	}
+	if f.Control != "" {
+		if mapping := compliance.GetMappingForCheck(f.Control); mapping != nil {
+			vuln.ComplianceMapping = make(map[string]checker.ComplianceDetails, len(mapping.Frameworks))
+			for framework, requirements := range mapping.Frameworks {
+				vuln.ComplianceMapping[framework] = checker.ComplianceDetails{
+					Requirements: requirements,
+					Priority:     mapping.Priority[framework],
+				}
+			}
+		}
+	}
+	return vuln
+}
+
+// manualVulnerabilities converts manual findings for the findings table.
+func manualVulnerabilities(findings []ManualFinding) []checker.Vulnerability {
+	vulns := make([]checker.Vulnerability, 0, len(findings))
+	for _, f := range findings {
+		vulns = append(vulns, f.Vulnerability())
+	}
+	return vulns
+}
+
+// manualIssueFindings converts manual findings for issue tracker sync.
+func manualIssueFindings(findings []ManualFinding) []issuesync.Finding {
+	out := make([]issuesync.Finding, 0, len(findings))
+	for _, f := range findings {
+		out = append(out, issueFinding(f.Vulnerability()))
+	}
+	return out
+}
+
+// loadManualFindings returns the engagement's manual findings, or nil when
+// none were recorded.
+func loadManualFindings(resultsDir, engagementID string) ([]ManualFinding, error) {
+	path, err := resolveResultsPath(resultsDir, engagementID, manualFindingsFilename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var findings []ManualFinding
+	if err := json.Unmarshal(data, &findings); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", manualFindingsFilename, err)
+	}
+	return findings, nil
+}
+
+func saveManualFindings(resultsDir, engagementID string, findings []ManualFinding) error {
+	if _, err := ensureResultsDir(resultsDir, engagementID); err != nil {
+		return err
+	}
+	path, err := resolveResultsPath(resultsDir, engagementID, manualFindingsFilename)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(findings, jsonPrefix, jsonIndent)
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFile(path, data, consts.DefaultFilePerm)
+}
+
+// addManualFinding validates f, copies its evidence files into the results
+// directory, and appends it to the store with the next MF-### ID.
+func addManualFinding(resultsDir, engagementID string, f ManualFinding, evidencePaths []string) (ManualFinding, error) {
+	f.Title = strings.TrimSpace(f.Title)
+	f.Target = strings.TrimSpace(f.Target)
+	if f.Title == "" {
+		return f, errors.New("--title is required")
+	}
+	if f.Target == "" {
+		return f, errors.New("--target is required")
+	}
+	severity, ok := manualSeverities[strings.ToLower(strings.TrimSpace(f.Severity))]
+	if !ok {
+		return f, fmt.Errorf("invalid severity %q (must be critical, high, medium, low, or info)", f.Severity)
+	}
+	f.Severity = severity
+	if f.Category == "" {
+		f.Category = manualFindingCategory
+	}
+	if f.CVSSVector != "" {
+		score, err := checker.NewCVSSScore(f.CVSSVector)
+		if err != nil {
+			return f, err
+		}
+		f.CVSSVector = score.Vector
+	}
+	if f.Control != "" && compliance.GetMappingForCheck(f.Control) == nil {
+		return f, fmt.Errorf("unknown control %q (use a security check name from docs/materials/list-of-security-check.md)", f.Control)
+	}
+
+	findings, err := loadManualFindings(resultsDir, engagementID)
+	if err != nil {
+		return f, err
+	}
+
+	for _, path := range evidencePaths {
+		ev, err := storeEvidence(resultsDir, engagementID, path)
+		if err != nil {
+			return f, err
+		}
+		f.Evidence = append(f.Evidence, ev)
+	}
+
+	f.ID = fmt.Sprintf("MF-%03d", len(findings)+1)
+	if f.CreatedAt.IsZero() {
+		f.CreatedAt = time.Now().UTC()
+	}
+	findings = append(findings, f)
+	if err := saveManualFindings(resultsDir, engagementID, findings); err != nil {
+		return f, err
+	}
+	return f, nil
+}
+
+// storeEvidence copies src to evidence/<sha256><ext>.
+func storeEvidence(resultsDir, engagementID, src string) (EvidenceFile, error) {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return EvidenceFile{}, fmt.Errorf("read evidence: %w", err)
+	}
+	ext := strings.ToLower(filepath.Ext(src))
+	ref := checker.EvidenceRef{Kind: checker.EvidenceFile, MediaType: mime.TypeByExtension(ext)}
+	ref, err = writeEvidence(resultsDir, engagementID, ref, evidenceDirName, "", ext, data)
+	if err != nil {
+		return EvidenceFile{}, err
+	}
+	return EvidenceFile{
+		File:         ref.File,
+		OriginalName: filepath.Base(src),
+		SHA256:       ref.SHA256,
+	}, nil
+}
+
+var findingsCmd = &cobra.Command{
+	Use:   "findings",
+	Short: "Record and list manual findings for an engagement",
+}
+
+var findingsAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Record a manual finding (appears in reports and issue sync)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		appCtx := getAppContext(cmd)
+
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return errors.New("--id is required")
+		}
+		eng, err := appCtx.Services.EngagementService.GetEngagement(ctx, id)
+		if err != nil {
+			if errors.Is(err, sharedErrors.ErrEngagementNotFound) {
+				return fmt.Errorf("engagement %s not found", id)
+			}
+			return fmt.Errorf("failed to get engagement: %w", err)
+		}
+		if err := checkWritable(eng); err != nil {
+			return err
+		}
+
+		f := ManualFinding{Operator: appCtx.Operator}
+		f.Target, _ = cmd.Flags().GetString("target")
+		f.Title, _ = cmd.Flags().GetString("title")
+		f.Severity, _ = cmd.Flags().GetString("severity")
+		f.Category, _ = cmd.Flags().GetString("category")
+		f.Description, _ = cmd.Flags().GetString("description")
+		f.Recommendation, _ = cmd.Flags().GetString("recommendation")
+		f.Control, _ = cmd.Flags().GetString("control")
+		f.CVSSVector, _ = cmd.Flags().GetString("cvss")
+		evidence, _ := cmd.Flags().GetStringArray("evidence")
+
+		if !targetInScope(f.Target, eng.Scope()) {
+			cliLog().Warnw("finding_target_outside_scope", "engagement_id", id, "target", f.Target)
+		}
+
+		f, err = addManualFinding(appCtx.ResultsDir, id, f, evidence)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s finding %s [%s] %s on %s\n", colorSuccess("Recorded"), f.ID, f.Severity, f.Title, f.Target)
+		for _, ev := range f.Evidence {
+			fmt.Printf("%s Evidence: %s (sha256 %s)\n", colorInfo("→"), ev.File, ev.SHA256)
+		}
+		return nil
+	},
+}
+
+var findingsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List manual findings for an engagement",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return errors.New("--id is required")
+		}
+		findings, err := loadManualFindings(appCtx.ResultsDir, id)
+		if err != nil {
+			return err
+		}
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			if findings == nil {
+				findings = []ManualFinding{}
+			}
+			b, _ := json.MarshalIndent(findings, jsonPrefix, jsonIndent)
+			fmt.Println(string(b))
+			return nil
+		}
+		if len(findings) == 0 {
+			fmt.Printf("No manual findings for engagement %s\n", id)
+			return nil
+		}
+		writeManualFindingsTable(cmd.OutOrStdout(), findings)
+		return nil
+	},
+}
+
+func writeManualFindingsTable(out io.Writer, findings []ManualFinding) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSEVERITY\tTARGET\tTITLE\tEVIDENCE\tOPERATOR")
+	for _, f := range findings {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n", f.ID, f.Severity, f.Target, f.Title, len(f.Evidence), f.Operator)
+	}
+	w.Flush()
+}
+
+// targetInScope reports whether target's host matches an engagement scope entry.
+func targetInScope(target string, scope []string) bool {
+	info := checker.ParseTarget(target)
+	if info == nil {
+		return false
+	}
+	for _, entry := range scope {
+		if scoped := checker.ParseTarget(entry); scoped != nil && strings.EqualFold(scoped.Host, info.Host) {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	findingsCmd.AddCommand(findingsAddCmd)
+	findingsCmd.AddCommand(findingsListCmd)
+
+	findingsAddCmd.Flags().String("id", "", "Engagement ID")
+	findingsAddCmd.Flags().String("target", "", "Affected target (URL or host)")
+	findingsAddCmd.Flags().String("title", "", "Finding title")
+	findingsAddCmd.Flags().String("severity", "", "Severity: critical, high, medium, low, or info")
+	findingsAddCmd.Flags().String("category", manualFindingCategory, "Finding category")
+	findingsAddCmd.Flags().String("description", "", "What was observed and how to reproduce it")
+	findingsAddCmd.Flags().String("recommendation", "", "How to remediate")
+	findingsAddCmd.Flags().String("control", "", "Security check the finding relates to, for compliance mapping (e.g. \"Anti-CSRF Tokens\")")
+	findingsAddCmd.Flags().String("cvss", "", "CVSS v3.1 base vector, e.g. \"CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:U/C:H/I:N/A:N\"")
+	findingsAddCmd.Flags().StringArray("evidence", nil, "Evidence file to attach, e.g. a screenshot or request dump (repeatable)")
+
+	findingsListCmd.Flags().String("id", "", "Engagement ID")
+	findingsListCmd.Flags().Bool("json", false, "Output findings as JSON")
+}
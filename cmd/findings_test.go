@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func TestAddManualFinding(t *testing.T) {
+	resultsDir := t.TempDir()
+	evidence := filepath.Join(t.TempDir(), "Login.PNG")
+	if err := os.WriteFile(evidence, []byte("screenshot"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := addManualFinding(resultsDir, "eng-manual", ManualFinding{
+		Target:   "https://app.example.com/login",
+		Title:    "Password reset token in URL",
+		Severity: "HIGH",
+		Control:  "Anti-CSRF Tokens",
+		Operator: "alice",
+	}, []string{evidence})
+	if err != nil {
+		t.Fatalf("addManualFinding: %v", err)
+	}
+	if f.ID != "MF-001" || f.Severity != "High" || f.Category != manualFindingCategory {
+		t.Fatalf("unexpected finding: %+v", f)
+	}
+	if len(f.Evidence) != 1 || !strings.HasPrefix(f.Evidence[0].File, "evidence/") || !strings.HasSuffix(f.Evidence[0].File, ".png") {
+		t.Fatalf("unexpected evidence: %+v", f.Evidence)
+	}
+	if _, err := os.Stat(filepath.Join(resultsDir, "eng-manual", filepath.FromSlash(f.Evidence[0].File))); err != nil {
+		t.Fatalf("evidence not copied: %v", err)
+	}
+
+	second, err := addManualFinding(resultsDir, "eng-manual", ManualFinding{
+		Target: "app.example.com", Title: "Verbose errors", Severity: "low",
+		CVSSVector: "CVSS:3.1/C:L/I:N/A:N/AV:N/AC:L/PR:N/UI:N/S:U",
+	}, nil)
+	if err != nil || second.ID != "MF-002" {
+		t.Fatalf("expected MF-002, got %+v (%v)", second, err)
+	}
+	if second.CVSSVector != "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:L/I:N/A:N" {
+		t.Fatalf("expected canonical vector, got %q", second.CVSSVector)
+	}
+	if v := second.Vulnerability(); v.CVSS == nil || v.CVSS.BaseScore != 5.3 {
+		t.Fatalf("expected CVSS 5.3, got %+v", v.CVSS)
+	}
+
+	stored, err := loadManualFindings(resultsDir, "eng-manual")
+	if err != nil || len(stored) != 2 {
+		t.Fatalf("loadManualFindings: %v (%d findings)", err, len(stored))
+	}
+
+	for _, bad := range []ManualFinding{
+		{Target: "a.example.com", Title: "x", Severity: "severe"},
+		{Target: "a.example.com", Severity: "low"},
+		{Title: "x", Severity: "low"},
+		{Target: "a.example.com", Title: "x", Severity: "low", Control: "Not a check"},
+		{Target: "a.example.com", Title: "x", Severity: "low", CVSSVector: "CVSS:3.1/AV:N/AC:L"},
+	} {
+		if _, err := addManualFinding(resultsDir, "eng-manual", bad, nil); err == nil {
+			t.Errorf("expected error for %+v", bad)
+		}
+	}
+}
+
+func TestManualFindingsInReport(t *testing.T) {
+	finding := ManualFinding{
+		ID:       "MF-001",
+		Target:   "https://app.example.com/login",
+		Title:    "Password reset token in URL",
+		Severity: "High",
+		Category: manualFindingCategory,
+		Control:  "Anti-CSRF Tokens",
+		Evidence: []EvidenceFile{{File: "evidence/abc.png", SHA256: "abc"}},
+	}
+
+	vuln := finding.Vulnerability()
+	if len(vuln.ComplianceMapping) == 0 {
+		t.Fatal("expected compliance mapping from control")
+	}
+	if !strings.Contains(vuln.Description, "evidence/abc.png (SHA-256 abc)") {
+		t.Fatalf("expected evidence in description, got %q", vuln.Description)
+	}
+
+	output := &RunOutput{
+		Metadata:       RunMetadata{EngagementID: "eng-manual", StartAt: time.Now(), CompleteAt: time.Now()},
+		Results:        []checker.CheckResult{{Target: "https://app.example.com", Status: "ok"}},
+		ManualFindings: []ManualFinding{finding},
+	}
+	data := buildTemplateData(output, nil, "%.2f", nil)
+	if data.Summary.High != 1 || len(data.Vulnerabilities) != 1 || data.Vulnerabilities[0].Name != finding.Title {
+		t.Fatalf("expected manual finding in report data, got %+v", data.Summary)
+	}
+
+	if issues := manualIssueFindings(output.ManualFindings); len(issues) != 1 || issues[0].Severity != "High" {
+		t.Fatalf("unexpected issue findings: %+v", issues)
+	}
+}
+
+func TestTargetInScope(t *testing.T) {
+	scope := []string{"https://app.example.com", "api.example.com:8443"}
+	if !targetInScope("https://app.example.com/login", scope) || !targetInScope("api.example.com", scope) {
+		t.Fatal("expected scoped targets to match")
+	}
+	if targetInScope("https://other.example.com", scope) {
+		t.Fatal("unexpected match for out-of-scope target")
+	}
+}
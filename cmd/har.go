@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/session"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+)
+
+// harDirName holds one HAR file per check run.
+const harDirName = "har"
+
+// newHARRecorder returns a recorder when --har is set, or nil. Session
+// credentials and the values of the configured request headers (--header,
+// http.headers) are masked in everything it records; only User-Agent is
+// kept.
+func newHARRecorder(runtimeCfg CheckRuntimeConfig, sess *session.Session, headers http.Header) *checker.HARRecorder {
+	if !runtimeCfg.HAR {
+		return nil
+	}
+	rec := &checker.HARRecorder{}
+	var secrets []string
+	for name, values := range headers {
+		if strings.EqualFold(name, "User-Agent") {
+			continue
+		}
+		rec.SecretHeaders = append(rec.SecretHeaders, name)
+		for _, v := range values {
+			if v != "" {
+				secrets = append(secrets, v)
+			}
+		}
+	}
+	if sess != nil {
+		rec.RedactHeaders = sess.RedactHeaders
+	}
+	rec.RedactString = func(s string) string {
+		for _, secret := range secrets {
+			s = strings.ReplaceAll(s, secret, session.Redacted)
+		}
+		if sess != nil {
+			s = sess.RedactString(s)
+		}
+		return s
+	}
+	return rec
+}
+
+// saveHAR writes the recorded traffic to har/<checker>_<run-id>.har and
+// returns the path.
+func saveHAR(resultsDir, engagementID, checkerName, runID string, rec *checker.HARRecorder) (string, error) {
+	dir, err := resolveResultsPath(resultsDir, engagementID, harDirName)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, consts.DefaultDirPerm); err != nil {
+		return "", fmt.Errorf("create HAR directory: %w", err)
+	}
+	path, err := resolveResultsPath(resultsDir, engagementID, harDirName, fmt.Sprintf("%s_%s.har", checkerName, runID))
+	if err != nil {
+		return "", err
+	}
+
+	err = fileutil.WriteWith(path, consts.DefaultFilePerm, func(w io.Writer) error {
+		return rec.WriteTo(w, Version)
+	})
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// recordHAR saves the run's HTTP traffic when recording is enabled. Failures
+// are warnings: the HAR is supplementary to the results and audit trail.
+func recordHAR(resultsDir, engagementID, checkType, runID string, rec *checker.HARRecorder) {
+	if rec == nil {
+		return
+	}
+	path, err := saveHAR(resultsDir, engagementID, checkType, runID, rec)
+	if err != nil {
+		cliLog().Warnw("har_save_failed", "engagement_id", engagementID, "run_id", runID, "error", err)
+		return
+	}
+	fmt.Printf("%s HAR: %d request(s) → %s\n", colorSuccess("→"), rec.Len(), path)
+}
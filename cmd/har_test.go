@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/domain/engagement"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/session"
+)
+
+func TestSaveHAR(t *testing.T) {
+	if newHARRecorder(CheckRuntimeConfig{}, nil, nil) != nil {
+		t.Fatal("expected no recorder without --har")
+	}
+	rec := newHARRecorder(CheckRuntimeConfig{HAR: true}, nil, nil)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+	resp, err := (&http.Client{Transport: rec.Wrap(nil)}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	resultsDir := t.TempDir()
+	path, err := saveHAR(resultsDir, "eng-har", "http", "run-1", rec)
+	if err != nil {
+		t.Fatalf("saveHAR: %v", err)
+	}
+	if want := filepath.Join(resultsDir, "eng-har", harDirName, "http_run-1.har"); path != want {
+		t.Fatalf("path = %s, want %s", path, want)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read HAR: %v", err)
+	}
+	var har checker.HAR
+	if err := json.Unmarshal(data, &har); err != nil {
+		t.Fatalf("parse HAR: %v", err)
+	}
+	if len(har.Log.Entries) != 1 || har.Log.Creator.Name != "seca-cli" {
+		t.Fatalf("unexpected HAR log: %+v", har.Log)
+	}
+}
+
+func TestCheckHTTPRecordsHAR(t *testing.T) {
+	defer setupTestAppContextWithServices(t)()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "sid", Value: "s3cret-session-id"})
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+
+	appCtx := globalAppContext
+	appCtx.Config.Check.HAR = true
+	appCtx.Config.Check.Request.Headers = []string{"X-API-Key: s3cret-key-value"}
+	t.Cleanup(func() { appCtx.Config.Check.Request.Headers = nil })
+	appCtx.Config.Check.ProgressEnabled = false
+	const id = "eng-har-run"
+	now := time.Now()
+	eng := engagement.Reconstruct(id, "HAR", "owner", "Test ROE", []string{server.URL}, true, now, time.Time{}, now)
+	if err := appCtx.Services.EngagementRepo.Save(t.Context(), eng); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	cmd := checkHTTPCmd
+	cmd.Flags().Set("id", id)
+	cmd.Flags().Set("roe-confirm", "true")
+	t.Cleanup(func() {
+		cmd.Flags().Set("id", "")
+		cmd.Flags().Set("roe-confirm", "false")
+	})
+	captureStdout(t, func() {
+		if err := cmd.RunE(cmd, nil); err != nil {
+			t.Fatalf("check http: %v", err)
+		}
+	})
+
+	files, err := filepath.Glob(filepath.Join(appCtx.ResultsDir, id, harDirName, "http_*.har"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected one HAR file, got %v (%v)", files, err)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("read HAR: %v", err)
+	}
+	var har checker.HAR
+	if err := json.Unmarshal(data, &har); err != nil {
+		t.Fatalf("parse HAR: %v", err)
+	}
+	if len(har.Log.Entries) == 0 {
+		t.Fatal("expected the checker's requests in the HAR file")
+	}
+	for _, secret := range []string{"s3cret-key-value", "s3cret-session-id"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("HAR file leaked %q", secret)
+		}
+	}
+	var sentKey bool
+	for _, h := range har.Log.Entries[0].Request.Headers {
+		if strings.EqualFold(h.Name, "X-API-Key") {
+			sentKey = h.Value == session.Redacted
+		}
+	}
+	if !sentKey {
+		t.Errorf("expected the --header to be recorded masked, got %+v", har.Log.Entries[0].Request.Headers)
+	}
+}
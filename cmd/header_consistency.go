@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"iter"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+// maxGapPagesShown caps the pages listed per header in console and PDF
+// output; reports in md and html list them all.
+const maxGapPagesShown = 5
+
+// HostHeaderConsistency lists the security headers an origin sends on some
+// of its checked pages but not on others, which a single-page check of the
+// origin does not reveal.
+type HostHeaderConsistency struct {
+	Origin string      `json:"origin"`
+	Pages  int         `json:"pages"`
+	Gaps   []HeaderGap `json:"gaps"`
+}
+
+// HeaderGap is one security header missing from some pages of an origin.
+type HeaderGap struct {
+	Header    string   `json:"header"`
+	Severity  string   `json:"severity,omitempty"`
+	PresentOn []string `json:"present_on"`
+	MissingOn []string `json:"missing_on"`
+}
+
+// headerGapSeverityOrder sorts gaps so the headers that matter most come
+// first.
+var headerGapSeverityOrder = map[string]int{"critical": 0, "high": 1, "medium": 2, "low": 3}
+
+// checkHeaderConsistency compares the security headers of the pages checked
+// on each origin (scheme, host, and port, so HSTS on https:// is not
+// compared with http://). Only pages that answered are compared, and the
+// latest result of a page wins.
+func checkHeaderConsistency(results iter.Seq[checker.CheckResult]) []HostHeaderConsistency {
+	var hosts []HostHeaderConsistency
+	for origin, byPage := range latestPageHeaders(results) {
+		if len(byPage) < 2 {
+			continue
+		}
+		gaps := headerGaps(byPage)
+		if len(gaps) == 0 {
+			continue
+		}
+		hosts = append(hosts, HostHeaderConsistency{Origin: origin, Pages: len(byPage), Gaps: gaps})
+	}
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i].Origin < hosts[j].Origin })
+	return hosts
+}
+
+// pageHeaders is the latest security headers checked on a page.
+type pageHeaders struct {
+	checkedAt time.Time
+	headers   *checker.SecurityHeadersResult
+}
+
+// latestPageHeaders maps each origin to the latest security headers of its
+// pages that answered. Nothing else of the results is kept.
+func latestPageHeaders(results iter.Seq[checker.CheckResult]) map[string]map[string]pageHeaders {
+	pages := make(map[string]map[string]pageHeaders)
+	for r := range results {
+		if !r.Succeeded() || r.SecurityHeaders == nil {
+			continue
+		}
+		origin, page := headerOrigin(r.Target)
+		if origin == "" {
+			continue
+		}
+		if pages[origin] == nil {
+			pages[origin] = make(map[string]pageHeaders)
+		}
+		if prev, ok := pages[origin][page]; !ok || r.CheckedAt.After(prev.checkedAt) {
+			pages[origin][page] = pageHeaders{checkedAt: r.CheckedAt, headers: r.SecurityHeaders}
+		}
+	}
+	return pages
+}
+
+// headerOrigin splits target into its origin and the page path, query
+// included, that identifies it within the origin.
+func headerOrigin(target string) (origin, page string) {
+	info := checker.ParseTarget(target)
+	if info == nil || info.FullURL == "" {
+		return "", ""
+	}
+	u, err := url.Parse(info.FullURL)
+	if err != nil || u.Host == "" {
+		return "", ""
+	}
+	page = u.EscapedPath()
+	if page == "" {
+		page = "/"
+	}
+	if u.RawQuery != "" {
+		page += "?" + u.RawQuery
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host), page
+}
+
+// headerGaps returns the headers present on some of the pages and missing
+// on others.
+func headerGaps(byPage map[string]pageHeaders) []HeaderGap {
+	names := make(map[string]string)
+	for _, p := range byPage {
+		for name, status := range p.headers.Headers {
+			if status.Present || names[name] == "" {
+				names[name] = status.Severity
+			}
+		}
+	}
+
+	var gaps []HeaderGap
+	for name, severity := range names {
+		gap := HeaderGap{Header: name, Severity: severity}
+		for page, p := range byPage {
+			if p.headers.Headers[name].Present {
+				gap.PresentOn = append(gap.PresentOn, page)
+			} else {
+				gap.MissingOn = append(gap.MissingOn, page)
+			}
+		}
+		if len(gap.PresentOn) == 0 || len(gap.MissingOn) == 0 {
+			continue
+		}
+		sort.Strings(gap.PresentOn)
+		sort.Strings(gap.MissingOn)
+		gaps = append(gaps, gap)
+	}
+	sort.Slice(gaps, func(i, j int) bool {
+		oi, oj := severityRank(gaps[i].Severity), severityRank(gaps[j].Severity)
+		if oi != oj {
+			return oi < oj
+		}
+		return gaps[i].Header < gaps[j].Header
+	})
+	return gaps
+}
+
+func severityRank(severity string) int {
+	if rank, ok := headerGapSeverityOrder[strings.ToLower(severity)]; ok {
+		return rank
+	}
+	return len(headerGapSeverityOrder)
+}
+
+// listPages joins up to maxGapPagesShown pages, noting how many were
+// omitted.
+func listPages(pages []string) string {
+	if len(pages) <= maxGapPagesShown {
+		return strings.Join(pages, ", ")
+	}
+	return fmt.Sprintf("%s, ... +%d more", strings.Join(pages[:maxGapPagesShown], ", "), len(pages)-maxGapPagesShown)
+}
+
+// printHeaderConsistency lists the origins whose pages disagree on their
+// security headers.
+func printHeaderConsistency(hosts []HostHeaderConsistency) {
+	if len(hosts) == 0 {
+		return
+	}
+	gaps := 0
+	for _, h := range hosts {
+		gaps += len(h.Gaps)
+	}
+	fmt.Printf("%s Header consistency: %d header(s) set on only some pages of %d origin(s)\n", colorWarn("!"), gaps, len(hosts))
+	for _, h := range hosts {
+		for _, g := range h.Gaps {
+			fmt.Printf("  %s %s %s: missing on %s (present on %d of %d page(s))\n", colorWarn("!"), h.Origin, g.Header, listPages(g.MissingOn), len(g.PresentOn), h.Pages)
+		}
+	}
+}
+
+// writeHeaderConsistencyPDF lists the headers set on only some pages of
+// each origin.
+func writeHeaderConsistencyPDF(pdf *gofpdf.Fpdf, hosts []HostHeaderConsistency) {
+	if pdf.GetY() > 240 {
+		pdf.AddPage()
+	}
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, "Header Consistency", "", 1, "", false, 0, "")
+	pdf.SetFont("Arial", "I", 8)
+	pdf.MultiCell(0, 4, "Security headers sent on some pages of a site but not on others; per-path server or proxy rules are a common cause.", "", "", false)
+	pdf.Ln(2)
+
+	for _, h := range hosts {
+		if pdf.GetY() > 265 {
+			pdf.AddPage()
+		}
+		pdf.SetFont("Arial", "B", 9)
+		pdf.CellFormat(0, 5, fmt.Sprintf("%s (%d pages)", h.Origin, h.Pages), "", 1, "", false, 0, "")
+		pdf.SetFont("Arial", "", 8)
+		for _, g := range h.Gaps {
+			if pdf.GetY() > 270 {
+				pdf.AddPage()
+			}
+			pdf.MultiCell(0, 4, fmt.Sprintf("  - %s [%s]: missing on %s; present on %d page(s)", g.Header, orDash(g.Severity), listPages(g.MissingOn), len(g.PresentOn)), "", "", false)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+// pageResult is an answered page sending the named headers.
+func pageResult(target string, present ...string) checker.CheckResult {
+	headers := make(map[string]string)
+	for _, name := range present {
+		headers[name] = "set"
+	}
+	r := headerResult(target, time.Now(), headers)
+	r.Status = "ok"
+	return r
+}
+
+func TestCheckHeaderConsistency(t *testing.T) {
+	results := []checker.CheckResult{
+		pageResult("https://app.example.com", "Content-Security-Policy", "Strict-Transport-Security"),
+		pageResult("https://app.example.com/login", "Strict-Transport-Security"),
+		pageResult("https://app.example.com/account?tab=1", "Strict-Transport-Security"),
+		// Different origin: not compared with the https:// pages.
+		pageResult("http://app.example.com/legacy"),
+		// A single page has nothing to be inconsistent with.
+		pageResult("https://www.example.com", "Content-Security-Policy"),
+		{Target: "https://app.example.com/down", Status: "error"},
+	}
+
+	hosts := checkHeaderConsistency(slices.Values(results))
+	if len(hosts) != 1 {
+		t.Fatalf("expected one inconsistent origin, got %+v", hosts)
+	}
+	h := hosts[0]
+	if h.Origin != "https://app.example.com" || h.Pages != 3 {
+		t.Errorf("unexpected origin %+v", h)
+	}
+	if len(h.Gaps) != 1 {
+		t.Fatalf("expected only the CSP gap, got %+v", h.Gaps)
+	}
+	gap := h.Gaps[0]
+	if gap.Header != "Content-Security-Policy" || gap.Severity != "high" {
+		t.Errorf("unexpected gap %+v", gap)
+	}
+	if strings.Join(gap.PresentOn, ",") != "/" || strings.Join(gap.MissingOn, ",") != "/account?tab=1,/login" {
+		t.Errorf("unexpected pages: present %v, missing %v", gap.PresentOn, gap.MissingOn)
+	}
+}
+
+func TestHeaderConsistencyReportSection(t *testing.T) {
+	output := &RunOutput{
+		Metadata: RunMetadata{EngagementID: "eng-headers", StartAt: time.Now(), CompleteAt: time.Now()},
+		Results: []checker.CheckResult{
+			pageResult("https://app.example.com", "Content-Security-Policy"),
+			pageResult("https://app.example.com/login"),
+		},
+	}
+	data := buildTemplateData(output, nil, "%.2f", nil)
+	if len(data.HeaderConsistency) != 1 {
+		t.Fatalf("expected one origin in the template data, got %+v", data.HeaderConsistency)
+	}
+
+	markdown, err := generateMarkdownReport(data)
+	if err != nil {
+		t.Fatalf("Failed to generate markdown report: %v", err)
+	}
+	if want := "| https://app.example.com | Content-Security-Policy | high | /login | 1 of 2 page(s) |"; !strings.Contains(markdown, want) {
+		t.Errorf("markdown report missing %q:\n%s", want, markdown)
+	}
+
+	html, err := generateHTMLReport(data)
+	if err != nil {
+		t.Fatalf("Failed to generate HTML report: %v", err)
+	}
+	if !strings.Contains(html, "Header Consistency") || !strings.Contains(html, "<td>/login</td>") {
+		t.Error("HTML report missing the header consistency section")
+	}
+}
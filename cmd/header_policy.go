@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+	"github.com/spf13/cobra"
+)
+
+// headerPolicyFilename holds the engagement's response header baseline.
+const headerPolicyFilename = "header_policy.json"
+
+// parseHeaderPolicy decodes and validates a header policy document. Unknown
+// fields are rejected so a typo does not silently disable a rule.
+func parseHeaderPolicy(data []byte) (*checker.HeaderPolicy, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var policy checker.HeaderPolicy
+	if err := dec.Decode(&policy); err != nil {
+		return nil, fmt.Errorf("parse header policy: %w", err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	if policy.IsZero() {
+		return nil, errors.New("header policy has no rules")
+	}
+	return &policy, nil
+}
+
+// loadHeaderPolicy returns the engagement's header policy, or nil when none
+// is configured.
+func loadHeaderPolicy(resultsDir, engagementID string) (*checker.HeaderPolicy, error) {
+	path, err := resolveResultsPath(resultsDir, engagementID, headerPolicyFilename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	policy, err := parseHeaderPolicy(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", headerPolicyFilename, err)
+	}
+	return policy, nil
+}
+
+func saveHeaderPolicy(resultsDir, engagementID string, policy *checker.HeaderPolicy) error {
+	if _, err := ensureResultsDir(resultsDir, engagementID); err != nil {
+		return err
+	}
+	path, err := resolveResultsPath(resultsDir, engagementID, headerPolicyFilename)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(policy, jsonPrefix, jsonIndent)
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFile(path, data, consts.DefaultFilePerm)
+}
+
+// headerPolicySummary describes the policy's rules in one line.
+func headerPolicySummary(policy *checker.HeaderPolicy) string {
+	return fmt.Sprintf("%d required header(s), %d required CSP directive(s), %d allowed CORS origin(s)",
+		len(policy.RequiredHeaders), len(policy.RequiredCSPDirectives), len(policy.AllowedCORSOrigins))
+}
+
+// printHeaderPolicy tells the operator that a header policy applies to the run.
+func printHeaderPolicy(policy *checker.HeaderPolicy) {
+	if policy == nil {
+		return
+	}
+	fmt.Printf("%s Header policy: %s\n", colorInfo("→"), headerPolicySummary(policy))
+}
+
+var engagementHeadersCmd = &cobra.Command{
+	Use:   "headers",
+	Short: "Manage the response header policy for an engagement",
+	Long: `An engagement's header policy lists the headers every response must carry,
+the CSP directives the policy must contain, and the only origins CORS may
+allow. HTTP checks evaluate it in addition to the generic header best
+practices and report violations as separate "Header Policy Violation"
+findings.
+
+Policy file format (JSON):
+
+  {
+    "required_headers": ["Strict-Transport-Security", "Content-Security-Policy"],
+    "required_csp_directives": ["frame-ancestors 'none'", "object-src 'none'"],
+    "allowed_cors_origins": ["https://app.example.com"]
+  }`,
+}
+
+var engagementHeadersSetCmd = &cobra.Command{
+	Use:     "set",
+	Short:   "Validate a header policy file and store it for an engagement",
+	Example: `  seca engagement headers set --id eng123 --file header-policy.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		file, _ := cmd.Flags().GetString("file")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		if file == "" {
+			return fmt.Errorf("--file is required")
+		}
+		if _, err := appCtx.Services.EngagementService.GetEngagement(ctx, id); err != nil {
+			return fmt.Errorf("failed to get engagement: %w", err)
+		}
+
+		data, err := os.ReadFile(file) // #nosec G304 -- operator-supplied policy file
+		if err != nil {
+			return fmt.Errorf("read header policy: %w", err)
+		}
+		policy, err := parseHeaderPolicy(data)
+		if err != nil {
+			return err
+		}
+		if err := saveHeaderPolicy(appCtx.ResultsDir, id, policy); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s stored header policy for engagement %s: %s\n", colorSuccess("✓"), id, headerPolicySummary(policy))
+		return nil
+	},
+}
+
+var engagementHeadersShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the engagement's header policy",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		policy, err := loadHeaderPolicy(appCtx.ResultsDir, id)
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		if policy == nil {
+			fmt.Fprintf(out, "%s no header policy configured for engagement %s\n", colorInfo("→"), id)
+			return nil
+		}
+		data, err := json.MarshalIndent(policy, jsonPrefix, jsonIndent)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	},
+}
+
+var engagementHeadersClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove the engagement's header policy",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		path, err := resolveResultsPath(appCtx.ResultsDir, id, headerPolicyFilename)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s removed header policy for engagement %s\n", colorSuccess("✓"), id)
+		return nil
+	},
+}
+
+func init() {
+	engagementCmd.AddCommand(engagementHeadersCmd)
+	engagementHeadersCmd.AddCommand(engagementHeadersSetCmd)
+	engagementHeadersCmd.AddCommand(engagementHeadersShowCmd)
+	engagementHeadersCmd.AddCommand(engagementHeadersClearCmd)
+
+	engagementHeadersSetCmd.Flags().String("id", "", "Engagement ID")
+	engagementHeadersSetCmd.Flags().String("file", "", "Path to the header policy JSON file")
+
+	engagementHeadersShowCmd.Flags().String("id", "", "Engagement ID")
+
+	engagementHeadersClearCmd.Flags().String("id", "", "Engagement ID")
+}
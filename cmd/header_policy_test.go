@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseHeaderPolicy(t *testing.T) {
+	policy, err := parseHeaderPolicy([]byte(`{"required_headers": ["X-Frame-Options"], "allowed_cors_origins": ["https://app.example.com"]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(policy.RequiredHeaders) != 1 || len(policy.AllowedCORSOrigins) != 1 {
+		t.Fatalf("unexpected policy %+v", policy)
+	}
+
+	for _, doc := range []string{
+		`{"required_header": ["X-Frame-Options"]}`,
+		`{}`,
+		`{"allowed_cors_origins": ["app.example.com"]}`,
+	} {
+		if _, err := parseHeaderPolicy([]byte(doc)); err == nil {
+			t.Errorf("expected error for %s", doc)
+		}
+	}
+}
+
+func TestLoadHeaderPolicy(t *testing.T) {
+	resultsDir := t.TempDir()
+	policy, err := loadHeaderPolicy(resultsDir, "eng-1")
+	if err != nil || policy != nil {
+		t.Fatalf("expected no policy, got %+v (err %v)", policy, err)
+	}
+
+	stored, _ := parseHeaderPolicy([]byte(`{"required_csp_directives": ["object-src 'none'"]}`))
+	if err := saveHeaderPolicy(resultsDir, "eng-1", stored); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	policy, err = loadHeaderPolicy(resultsDir, "eng-1")
+	if err != nil || policy == nil || policy.RequiredCSPDirectives[0] != "object-src 'none'" {
+		t.Fatalf("unexpected policy %+v (err %v)", policy, err)
+	}
+
+	// A hand-edited, broken policy fails the run instead of being ignored.
+	path := filepath.Join(resultsDir, "eng-1", headerPolicyFilename)
+	if err := os.WriteFile(path, []byte(`{"required_headers": ["Bad Header"]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadHeaderPolicy(resultsDir, "eng-1"); err == nil || !strings.Contains(err.Error(), headerPolicyFilename) {
+		t.Fatalf("expected error naming the policy file, got %v", err)
+	}
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+)
+
+// hostingFilename holds the AS and country of each target's resolved
+// addresses, kept for the report's hosting distribution.
+const hostingFilename = "hosting.json"
+
+// HostingInventory records where the engagement's targets are hosted.
+type HostingInventory struct {
+	Dataset          string                         `json:"dataset"`
+	AllowedCountries []string                       `json:"allowed_countries,omitempty"`
+	Targets          map[string][]checker.IPHosting `json:"targets"`
+	UpdatedAt        time.Time                      `json:"updated_at"`
+}
+
+// HostingShare is one row of the hosting distribution.
+type HostingShare struct {
+	Label   string
+	Count   int
+	Outside bool // hosted outside the approved countries
+}
+
+// ByCountry counts distinct addresses per country.
+func (inv *HostingInventory) ByCountry() []HostingShare {
+	return inv.distribution(func(h checker.IPHosting) (string, bool) {
+		if h.Country == "" {
+			return "Unknown", false
+		}
+		return h.Country, checker.OutsideJurisdiction(h, inv.AllowedCountries)
+	})
+}
+
+// ByASN counts distinct addresses per autonomous system.
+func (inv *HostingInventory) ByASN() []HostingShare {
+	return inv.distribution(func(h checker.IPHosting) (string, bool) {
+		if h.ASN == 0 {
+			return "Unknown", false
+		}
+		return strings.TrimSpace(fmt.Sprintf("AS%d %s", h.ASN, h.ASOrg)), false
+	})
+}
+
+func (inv *HostingInventory) distribution(key func(checker.IPHosting) (string, bool)) []HostingShare {
+	counts := make(map[string]*HostingShare)
+	seen := make(map[string]bool)
+	for _, hosts := range inv.Targets {
+		for _, h := range hosts {
+			if seen[h.IP] {
+				continue
+			}
+			seen[h.IP] = true
+			label, outside := key(h)
+			share := counts[label]
+			if share == nil {
+				share = &HostingShare{Label: label}
+				counts[label] = share
+			}
+			share.Count++
+			share.Outside = share.Outside || outside
+		}
+	}
+	shares := make([]HostingShare, 0, len(counts))
+	for _, share := range counts {
+		shares = append(shares, *share)
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].Count != shares[j].Count {
+			return shares[i].Count > shares[j].Count
+		}
+		return shares[i].Label < shares[j].Label
+	})
+	return shares
+}
+
+// normalizeCountryCodes upper-cases ISO 3166-1 alpha-2 codes and rejects
+// anything else.
+func normalizeCountryCodes(codes []string) ([]string, error) {
+	var out []string
+	for _, code := range codes {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code == "" {
+			continue
+		}
+		if len(code) != 2 || code[0] < 'A' || code[0] > 'Z' || code[1] < 'A' || code[1] > 'Z' {
+			return nil, fmt.Errorf("invalid country code %q (use ISO 3166-1 alpha-2, e.g. SG)", code)
+		}
+		out = append(out, code)
+	}
+	return out, nil
+}
+
+// loadHostingInventory returns the stored inventory, or nil when none was
+// recorded.
+func loadHostingInventory(resultsDir, engagementID string) (*HostingInventory, error) {
+	path, err := resolveResultsPath(resultsDir, engagementID, hostingFilename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var inv HostingInventory
+	if err := json.Unmarshal(data, &inv); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", hostingFilename, err)
+	}
+	return &inv, nil
+}
+
+// saveHostingInventory merges the addresses in results into the stored
+// inventory and returns it with its path. Targets resolved again replace
+// their earlier addresses; the dataset and allow list of the latest run win.
+func saveHostingInventory(resultsDir, engagementID, dataset string, allowed []string, results []checker.CheckResult) (*HostingInventory, string, error) {
+	if _, err := ensureResultsDir(resultsDir, engagementID); err != nil {
+		return nil, "", err
+	}
+	inv, err := loadHostingInventory(resultsDir, engagementID)
+	if err != nil {
+		return nil, "", err
+	}
+	if inv == nil {
+		inv = &HostingInventory{}
+	}
+	if inv.Targets == nil {
+		inv.Targets = make(map[string][]checker.IPHosting)
+	}
+	for _, r := range results {
+		if len(r.Hosting) > 0 {
+			inv.Targets[r.Target] = r.Hosting
+		}
+	}
+	inv.Dataset = dataset
+	inv.AllowedCountries = allowed
+	inv.UpdatedAt = time.Now().UTC()
+
+	path, err := resolveResultsPath(resultsDir, engagementID, hostingFilename)
+	if err != nil {
+		return nil, "", err
+	}
+	data, err := json.MarshalIndent(inv, jsonPrefix, jsonIndent)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := fileutil.WriteFile(path, data, consts.DefaultFilePerm); err != nil {
+		return nil, "", err
+	}
+	return inv, path, nil
+}
+
+// recordHosting saves the hosting details gathered during a DNS run and
+// prints the distribution, warning about addresses outside the approved
+// countries.
+func recordHosting(resultsDir, engagementID string, db *checker.GeoIPDatabase, allowed []string, results []checker.CheckResult) {
+	inv, path, err := saveHostingInventory(resultsDir, engagementID, db.Source, allowed, results)
+	if err != nil {
+		cliLog().Warnw("hosting_save_failed", "engagement_id", engagementID, "error", err)
+		return
+	}
+	var countries []string
+	for _, share := range inv.ByCountry() {
+		countries = append(countries, fmt.Sprintf("%s %d", share.Label, share.Count))
+	}
+	fmt.Printf("%s Hosting: %s → %s\n", colorInfo("→"), strings.Join(countries, ", "), path)
+
+	for _, r := range results {
+		for _, h := range r.Hosting {
+			if checker.OutsideJurisdiction(h, allowed) {
+				fmt.Printf("  %s %s → %s hosted in %s (AS%d %s), outside %s\n", colorWarn("!"), r.Target, h.IP, h.Country, h.ASN, h.ASOrg, strings.Join(allowed, ", "))
+			}
+		}
+	}
+}
+
+// writeHostingPDF prints the hosting distribution by country and AS.
+func writeHostingPDF(pdf *gofpdf.Fpdf, inv *HostingInventory) {
+	if pdf.GetY() > 240 {
+		pdf.AddPage()
+	}
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, "Hosting Distribution", "", 1, "", false, 0, "")
+	pdf.SetFont("Arial", "I", 8)
+	note := "Resolved addresses mapped with the offline dataset " + inv.Dataset + "."
+	if len(inv.AllowedCountries) > 0 {
+		note += " Approved jurisdictions: " + strings.Join(inv.AllowedCountries, ", ") + "."
+	}
+	pdf.MultiCell(0, 4, note, "", "", false)
+	pdf.Ln(2)
+
+	pdf.SetFont("Arial", "", 8)
+	for _, group := range []struct {
+		title  string
+		shares []HostingShare
+	}{{"By country", inv.ByCountry()}, {"By autonomous system", inv.ByASN()}} {
+		pdf.CellFormat(0, 5, group.title+":", "", 1, "", false, 0, "")
+		for _, share := range group.shares {
+			if pdf.GetY() > 270 {
+				pdf.AddPage()
+			}
+			line := fmt.Sprintf("  - %s: %d address(es)", share.Label, share.Count)
+			if share.Outside {
+				line += " (outside approved jurisdictions)"
+			}
+			pdf.MultiCell(0, 4, line, "", "", false)
+		}
+		pdf.Ln(1)
+	}
+}
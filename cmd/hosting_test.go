@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func TestNormalizeCountryCodes(t *testing.T) {
+	got, err := normalizeCountryCodes([]string{" sg", "VN", ""})
+	if err != nil || strings.Join(got, ",") != "SG,VN" {
+		t.Fatalf("normalizeCountryCodes = %v, %v", got, err)
+	}
+	for _, bad := range []string{"SGP", "S1", "Singapore"} {
+		if _, err := normalizeCountryCodes([]string{bad}); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestHostingInventory_SaveAndReport(t *testing.T) {
+	resultsDir := t.TempDir()
+	sg := checker.IPHosting{IP: "103.1.2.3", ASN: 4773, ASOrg: "MOBILEONE", Country: "SG"}
+	us := checker.IPHosting{IP: "1.0.0.1", ASN: 13335, ASOrg: "CLOUDFLARENET", Country: "US"}
+
+	if _, _, err := saveHostingInventory(resultsDir, "eng-geo", "old.tsv", nil, []checker.CheckResult{
+		{Target: "app.example.com", Hosting: []checker.IPHosting{us}},
+	}); err != nil {
+		t.Fatalf("saveHostingInventory: %v", err)
+	}
+	inv, _, err := saveHostingInventory(resultsDir, "eng-geo", "ip2asn-combined.tsv", []string{"SG"}, []checker.CheckResult{
+		{Target: "app.example.com", Hosting: []checker.IPHosting{sg}},
+		{Target: "cdn.example.com", Hosting: []checker.IPHosting{us, {IP: "9.9.9.9"}}},
+		{Target: "down.example.com", Status: "error"},
+	})
+	if err != nil {
+		t.Fatalf("saveHostingInventory: %v", err)
+	}
+	if len(inv.Targets) != 2 || inv.Targets["app.example.com"][0].Country != "SG" {
+		t.Fatalf("expected re-resolved target to be replaced, got %+v", inv.Targets)
+	}
+
+	countries := inv.ByCountry()
+	if len(countries) != 3 || countries[0].Label != "SG" && countries[0].Label != "US" {
+		t.Fatalf("ByCountry = %+v", countries)
+	}
+	for _, share := range countries {
+		if share.Outside != (share.Label == "US") {
+			t.Errorf("share %+v: unexpected outside flag", share)
+		}
+	}
+
+	stored, err := loadHostingInventory(resultsDir, "eng-geo")
+	if err != nil || stored == nil || stored.Dataset != "ip2asn-combined.tsv" {
+		t.Fatalf("loadHostingInventory = %+v, %v", stored, err)
+	}
+
+	output := &RunOutput{
+		Metadata: RunMetadata{EngagementID: "eng-geo", StartAt: time.Now(), CompleteAt: time.Now()},
+		Results:  []checker.CheckResult{{Target: "app.example.com", Status: "ok"}},
+		Hosting:  stored,
+	}
+	data := buildTemplateData(output, nil, "%.2f", nil)
+	if len(data.Vulnerabilities) != 1 || data.Vulnerabilities[0].Name != "Hosting Outside Approved Jurisdiction" {
+		t.Errorf("expected a jurisdiction finding, got %+v", data.Vulnerabilities)
+	}
+	report, err := generateMarkdownReport(data)
+	if err != nil {
+		t.Fatalf("Failed to generate markdown report: %v", err)
+	}
+	for _, want := range []string{
+		"## Hosting Distribution",
+		"Approved jurisdictions: SG.",
+		"| US ⚠ outside approved jurisdictions | 1 |",
+		"| AS4773 MOBILEONE | 1 |",
+		"| Unknown | 1 |",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected report to contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/issuesync"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const issueSyncStateFilename = "issue_sync.json"
+
+var reportSyncIssuesCmd = &cobra.Command{
+	Use:   "sync-issues",
+	Short: "Open/close GitHub or GitLab issues for high-severity findings",
+	Long: `Synchronize engagement findings with an issue tracker.
+
+New findings at or above --min-severity open an issue (labelled by severity and
+category). Issues for findings that no longer appear in the latest results are
+commented on and closed. Sync state is stored in the engagement results
+directory so repeated runs are idempotent.
+
+Settings may also be provided in ~/.seca-cli.yaml:
+  issue_sync:
+    provider: github
+    repo: acme/security-findings
+    base_url: https://github.example.com/api/v3
+    token_env: GITHUB_TOKEN`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		minSeverity, _ := cmd.Flags().GetString("min-severity")
+		labelPrefix, _ := cmd.Flags().GetString("label-prefix")
+
+		provider := issueSyncSetting(cmd, "provider")
+		repo := issueSyncSetting(cmd, "repo")
+		baseURL := issueSyncSetting(cmd, "base-url")
+		tokenEnv := issueSyncSetting(cmd, "token-env")
+		if tokenEnv == "" {
+			tokenEnv = defaultIssueTokenEnv(provider)
+		}
+		if repo == "" {
+			return fmt.Errorf("--repo is required (or set issue_sync.repo in config)")
+		}
+
+		output, _, err := loadAggregatedRunOutput(appCtx.ResultsDir, id)
+		if err != nil {
+			return err
+		}
+		findings := findingsFromResults(output.Results)
+		manual, err := loadManualFindings(appCtx.ResultsDir, id)
+		if err != nil {
+			return err
+		}
+		findings = append(findings, manualIssueFindings(manual)...)
+
+		statePath, err := resolveResultsPath(appCtx.ResultsDir, id, issueSyncStateFilename)
+		if err != nil {
+			return fmt.Errorf("resolve issue sync state path: %w", err)
+		}
+		state, err := issuesync.LoadState(statePath)
+		if err != nil {
+			return err
+		}
+		if state.Repo != "" && (state.Repo != repo || !strings.EqualFold(state.Provider, provider)) {
+			return fmt.Errorf("engagement %s is already synced with %s %s; refusing to sync with %s %s", id, state.Provider, state.Repo, provider, repo)
+		}
+
+		names, err := loadTargetNames(appCtx.ResultsDir, id)
+		if err != nil {
+			return err
+		}
+		opts := issuesync.Options{
+			EngagementID: id,
+			MinSeverity:  minSeverity,
+			LabelPrefix:  labelPrefix,
+			DryRun:       dryRun,
+			TargetLabel:  names.Label,
+		}
+		actions := issuesync.Plan(state, findings, opts)
+
+		out := cmd.OutOrStdout()
+		if len(actions) == 0 {
+			fmt.Fprintf(out, "%s issues already in sync with %s\n", colorSuccess("✓"), repo)
+			return nil
+		}
+		for _, action := range actions {
+			switch action.Type {
+			case issuesync.ActionOpen:
+				fmt.Fprintf(out, "%s open  [%s] %s\n", colorWarn("+"), action.Finding.Severity, action.Finding.Name)
+			case issuesync.ActionClose:
+				fmt.Fprintf(out, "%s close #%d %s\n", colorSuccess("-"), action.Issue.Number, action.Issue.Finding)
+			}
+		}
+
+		if dryRun {
+			fmt.Fprintf(out, "%s dry run: %d action(s) not applied\n", colorInfo("→"), len(actions))
+			return nil
+		}
+
+		token := strings.TrimSpace(os.Getenv(tokenEnv))
+		if token == "" {
+			return fmt.Errorf("API token not found in $%s", tokenEnv)
+		}
+		tracker, err := issuesync.NewTracker(issuesync.TrackerConfig{
+			Provider: provider,
+			Repo:     repo,
+			BaseURL:  baseURL,
+			Token:    token,
+		})
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		applyErr := issuesync.Apply(ctx, tracker, state, actions, opts)
+		state.Provider = tracker.Name()
+		state.Repo = repo
+		if err := issuesync.SaveState(statePath, state, consts.DefaultFilePerm); err != nil {
+			return fmt.Errorf("save issue sync state: %w", err)
+		}
+		if applyErr != nil {
+			return fmt.Errorf("issue sync incomplete: %w", applyErr)
+		}
+
+		fmt.Fprintf(out, "%s applied %d action(s) to %s\n", colorSuccess("✓"), len(actions), repo)
+		return nil
+	},
+}
+
+// issueSyncSetting returns the flag value when set, otherwise the issue_sync config key.
+func issueSyncSetting(cmd *cobra.Command, flagName string) string {
+	value, _ := cmd.Flags().GetString(flagName)
+	if flag := cmd.Flags().Lookup(flagName); flag != nil && flag.Changed {
+		return strings.TrimSpace(value)
+	}
+	key := "issue_sync." + strings.ReplaceAll(flagName, "-", "_")
+	if viper.IsSet(key) {
+		return strings.TrimSpace(viper.GetString(key))
+	}
+	return strings.TrimSpace(value)
+}
+
+func defaultIssueTokenEnv(provider string) string {
+	if strings.EqualFold(provider, "gitlab") {
+		return "GITLAB_TOKEN"
+	}
+	return "GITHUB_TOKEN"
+}
+
+// findingsFromResults converts aggregated check results into issue sync findings.
+func findingsFromResults(results []checker.CheckResult) []issuesync.Finding {
+	report := checker.BuildVulnerabilityReport(results, "", "", "")
+	findings := make([]issuesync.Finding, 0, len(report.Vulnerabilities))
+	for _, v := range report.Vulnerabilities {
+		if strings.EqualFold(v.Status, "Passed") || strings.EqualFold(v.Status, "Info") {
+			continue
+		}
+		findings = append(findings, issueFinding(v))
+	}
+	return findings
+}
+
+// issueFinding converts a report vulnerability for issue sync.
+func issueFinding(v checker.Vulnerability) issuesync.Finding {
+	f := issuesync.Finding{
+		Name:           v.Name,
+		Category:       v.Category,
+		Severity:       v.Severity,
+		Description:    v.Description,
+		Recommendation: v.Recommendation,
+		AffectedURLs:   v.AffectedURLs,
+	}
+	if v.CVSS != nil {
+		f.CVSSVector = v.CVSS.Vector
+		f.CVSSScore = v.CVSS.BaseScore
+	}
+	return f
+}
+
+func init() {
+	reportSyncIssuesCmd.Flags().String("id", "", "Engagement ID")
+	reportSyncIssuesCmd.Flags().String("provider", "github", "Issue tracker provider: github|gitlab")
+	reportSyncIssuesCmd.Flags().String("repo", "", "Target repository (owner/name or GitLab project path)")
+	reportSyncIssuesCmd.Flags().String("base-url", "", "API base URL override (GitHub Enterprise / self-hosted GitLab)")
+	reportSyncIssuesCmd.Flags().String("token-env", "", "Environment variable holding the API token (default GITHUB_TOKEN/GITLAB_TOKEN)")
+	reportSyncIssuesCmd.Flags().String("min-severity", "high", "Lowest severity that opens an issue (low|medium|high|critical)")
+	reportSyncIssuesCmd.Flags().String("label-prefix", "seca", "Prefix for generated issue labels")
+	reportSyncIssuesCmd.Flags().Bool("dry-run", false, "Show planned issue changes without contacting the tracker")
+	reportCmd.AddCommand(reportSyncIssuesCmd)
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func TestFindingsFromResultsSkipsPassedChecks(t *testing.T) {
+	results := []checker.CheckResult{
+		{
+			Target: "https://example.com",
+			Status: "ok",
+			SecurityHeaders: checker.AnalyzeSecurityHeaders(map[string][]string{
+				"X-Content-Type-Options": {"nosniff"},
+			}),
+		},
+	}
+
+	findings := findingsFromResults(results)
+	if len(findings) == 0 {
+		t.Fatal("expected findings for missing security headers")
+	}
+	for _, f := range findings {
+		if f.Name == "" || f.Severity == "" {
+			t.Fatalf("finding missing name/severity: %+v", f)
+		}
+		if len(f.AffectedURLs) == 0 || f.AffectedURLs[0] != "https://example.com" {
+			t.Fatalf("expected affected URL to propagate, got %v", f.AffectedURLs)
+		}
+	}
+}
+
+func TestDefaultIssueTokenEnv(t *testing.T) {
+	if got := defaultIssueTokenEnv("gitlab"); got != "GITLAB_TOKEN" {
+		t.Fatalf("gitlab token env = %s", got)
+	}
+	if got := defaultIssueTokenEnv("github"); got != "GITHUB_TOKEN" {
+		t.Fatalf("github token env = %s", got)
+	}
+}
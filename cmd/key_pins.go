@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+	"github.com/spf13/cobra"
+)
+
+// keyPinsFilename tracks the TLS public key seen on each endpoint across runs.
+const keyPinsFilename = "key_pins.json"
+
+// loadKeyPinStore returns the engagement's key pins, or an empty store when
+// none were recorded.
+func loadKeyPinStore(resultsDir, engagementID string) (*checker.KeyPinStore, error) {
+	path, err := resolveResultsPath(resultsDir, engagementID, keyPinsFilename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return checker.NewKeyPinStore(), nil
+		}
+		return nil, err
+	}
+	store := checker.NewKeyPinStore()
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", keyPinsFilename, err)
+	}
+	return store, nil
+}
+
+func saveKeyPinStore(resultsDir, engagementID string, store *checker.KeyPinStore) error {
+	if _, err := ensureResultsDir(resultsDir, engagementID); err != nil {
+		return err
+	}
+	path, err := resolveResultsPath(resultsDir, engagementID, keyPinsFilename)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(store, jsonPrefix, jsonIndent)
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFile(path, data, consts.DefaultFilePerm)
+}
+
+// observeKeyPins records the certificate key of every TLS result and returns
+// the endpoints whose key changed since the previous run.
+func observeKeyPins(resultsDir, engagementID string, results []checker.CheckResult, at time.Time) ([]checker.KeyChange, error) {
+	store, err := loadKeyPinStore(resultsDir, engagementID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Several URLs can share an endpoint; observe each endpoint once.
+	sorted := append([]checker.CheckResult(nil), results...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Target < sorted[j].Target })
+
+	seen := make(map[string]bool)
+	var changes []checker.KeyChange
+	for _, r := range sorted {
+		if r.TLSCompliance == nil || r.TLSCompliance.CertificateInfo == nil {
+			continue
+		}
+		_, endpoint := checker.TLSAddress(r.Target)
+		if seen[endpoint] {
+			continue
+		}
+		seen[endpoint] = true
+		info := r.TLSCompliance.CertificateInfo
+		if change := store.Observe(r.Target, info.PublicKeySHA256, info.Subject, at); change != nil {
+			changes = append(changes, *change)
+		}
+	}
+
+	if len(seen) == 0 {
+		return nil, nil
+	}
+	if err := saveKeyPinStore(resultsDir, engagementID, store); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// reportKeyChanges tells the operator about key changes found by a run.
+func reportKeyChanges(engagementID string, changes []checker.KeyChange) {
+	for _, c := range changes {
+		if c.Expected {
+			fmt.Printf("%s TLS key rotated as expected on %s\n", colorInfo("→"), c.Endpoint)
+			continue
+		}
+		fmt.Printf("%s TLS public key changed on %s (was %s, now %s)\n", colorWarn("!"), c.Endpoint, c.Previous, c.Current)
+		cliLog().Warnw("tls_key_changed",
+			"engagement_id", engagementID,
+			"endpoint", c.Endpoint,
+			"previous", c.Previous,
+			"current", c.Current,
+		)
+	}
+}
+
+// trackKeyContinuity observes key pins after a run and reports changes.
+// Failures are logged; they never fail the run.
+func trackKeyContinuity(resultsDir, engagementID string, results []checker.CheckResult) {
+	changes, err := observeKeyPins(resultsDir, engagementID, results, time.Now().UTC())
+	if err != nil {
+		cliLog().Warnw("key_pins_save_failed", "engagement_id", engagementID, "error", err)
+		return
+	}
+	reportKeyChanges(engagementID, changes)
+}
+
+var engagementPinsCmd = &cobra.Command{
+	Use:   "pins",
+	Short: "Manage TLS public-key continuity tracking for an engagement",
+	Long: `Every HTTP check run records the public key (SPKI SHA-256) presented by
+each TLS endpoint. When a later run sees a different key, the change is
+reported as a high-severity finding: it may indicate interception or an
+unplanned certificate re-key.
+
+Allowlist the new key before a planned rotation to record it as expected.`,
+}
+
+var engagementPinsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List pinned keys, allowlisted rotations, and detected changes",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		store, err := loadKeyPinStore(appCtx.ResultsDir, id)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if len(store.Pins) == 0 {
+			fmt.Fprintf(out, "%s no keys pinned for engagement %s yet\n", colorInfo("→"), id)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ENDPOINT\tKEY\tFIRST SEEN\tLAST SEEN\tALLOWED NEXT")
+		for _, endpoint := range slices.Sorted(maps.Keys(store.Pins)) {
+			pin := store.Pins[endpoint]
+			allowed := "-"
+			if next := store.Allowed[endpoint]; len(next) > 0 {
+				allowed = strings.Join(next, ", ")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", endpoint, pin.Hash, pin.FirstSeen.Format(time.DateOnly), pin.LastSeen.Format(time.DateOnly), allowed)
+		}
+		_ = w.Flush()
+
+		if len(store.Changes) > 0 {
+			fmt.Fprintln(out)
+			fmt.Fprintln(out, "Detected changes:")
+			for _, c := range store.Changes {
+				label := colorError("unexpected")
+				if c.Expected {
+					label = colorSuccess("expected")
+				}
+				fmt.Fprintf(out, "  %s %s %s: %s -> %s\n", c.DetectedAt.Format(time.DateOnly), c.Endpoint, label, c.Previous, c.Current)
+			}
+		}
+		return nil
+	},
+}
+
+var engagementPinsAllowCmd = &cobra.Command{
+	Use:   "allow",
+	Short: "Allowlist the key an endpoint will rotate to",
+	Example: `  seca engagement pins allow --id eng123 --target app.example.com \
+    --hash sha256/YLh1dUR9y6Kja30RrAn7JKnbQG/uEtLMkBgFF2Fuihg=`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		target, _ := cmd.Flags().GetString("target")
+		hash, _ := cmd.Flags().GetString("hash")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		if target == "" || hash == "" {
+			return fmt.Errorf("--target and --hash are required")
+		}
+		if !strings.HasPrefix(hash, "sha256/") {
+			return fmt.Errorf("--hash must be an SPKI hash in the form sha256/<base64>")
+		}
+		if _, err := appCtx.Services.EngagementService.GetEngagement(ctx, id); err != nil {
+			return fmt.Errorf("failed to get engagement: %w", err)
+		}
+
+		store, err := loadKeyPinStore(appCtx.ResultsDir, id)
+		if err != nil {
+			return err
+		}
+		endpoint := store.Allow(target, hash)
+		if err := saveKeyPinStore(appCtx.ResultsDir, id, store); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %s may rotate to %s\n", colorSuccess("✓"), endpoint, hash)
+		return nil
+	},
+}
+
+var engagementPinsResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Forget the pinned key for an endpoint so the next run trusts its current key",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		target, _ := cmd.Flags().GetString("target")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		if target == "" {
+			return fmt.Errorf("--target is required")
+		}
+
+		store, err := loadKeyPinStore(appCtx.ResultsDir, id)
+		if err != nil {
+			return err
+		}
+		endpoint := store.Reset(target)
+		if err := saveKeyPinStore(appCtx.ResultsDir, id, store); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s reset key pin for %s\n", colorSuccess("✓"), endpoint)
+		return nil
+	},
+}
+
+func init() {
+	engagementCmd.AddCommand(engagementPinsCmd)
+	engagementPinsCmd.AddCommand(engagementPinsListCmd)
+	engagementPinsCmd.AddCommand(engagementPinsAllowCmd)
+	engagementPinsCmd.AddCommand(engagementPinsResetCmd)
+
+	engagementPinsListCmd.Flags().String("id", "", "Engagement ID")
+
+	engagementPinsAllowCmd.Flags().String("id", "", "Engagement ID")
+	engagementPinsAllowCmd.Flags().String("target", "", "Target or host[:port] whose key will rotate")
+	engagementPinsAllowCmd.Flags().String("hash", "", "SPKI SHA-256 of the new key (sha256/<base64>)")
+
+	engagementPinsResetCmd.Flags().String("id", "", "Engagement ID")
+	engagementPinsResetCmd.Flags().String("target", "", "Target or host[:port] to forget")
+}
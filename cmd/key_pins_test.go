@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func tlsResult(target, hash string) checker.CheckResult {
+	return checker.CheckResult{
+		Target: target,
+		Status: "ok",
+		TLSCompliance: &checker.TLSComplianceResult{
+			CertificateInfo: &checker.CertificateInfo{Subject: "CN=" + target, PublicKeySHA256: hash},
+		},
+	}
+}
+
+func TestObserveKeyPins(t *testing.T) {
+	resultsDir := t.TempDir()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	first := []checker.CheckResult{
+		tlsResult("https://app.example.com/a", "sha256/A"),
+		tlsResult("https://app.example.com/b", "sha256/A"),
+		tlsResult("https://api.example.com", "sha256/X"),
+		{Target: "http://plain.example.com", Status: "ok"},
+	}
+	changes, err := observeKeyPins(resultsDir, "eng-1", first, now)
+	if err != nil || len(changes) != 0 {
+		t.Fatalf("first run should only pin keys, got %v (err %v)", changes, err)
+	}
+
+	second := []checker.CheckResult{
+		tlsResult("https://app.example.com/a", "sha256/B"),
+		tlsResult("https://api.example.com", "sha256/X"),
+	}
+	changes, err = observeKeyPins(resultsDir, "eng-1", second, now.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("observeKeyPins: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Endpoint != "app.example.com:443" || changes[0].Expected {
+		t.Fatalf("expected one unexpected change on app.example.com, got %+v", changes)
+	}
+
+	store, err := loadKeyPinStore(resultsDir, "eng-1")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(store.Pins) != 2 || store.Pins["app.example.com:443"].Hash != "sha256/B" || len(store.Changes) != 1 {
+		t.Fatalf("unexpected stored pins %+v", store)
+	}
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const defaultLogLevel = "info"
+
+// cliLogger is shared by every command and the API server. It starts with
+// the default level on stderr and is reconfigured from --log-level and
+// --log-file once flags are parsed.
+var (
+	cliLogger    = newCLILogger(zapcore.InfoLevel, os.Stderr, nil).Sugar()
+	cliLogCloser io.Closer
+)
+
+// cliLog returns the structured logger for diagnostics. Command output meant
+// for the operator still goes to stdout; warnings, failures, and debug detail
+// go here so they share one machine-parsable format.
+func cliLog() *zap.SugaredLogger {
+	return cliLogger
+}
+
+// parseLogLevel accepts debug, info, warn, and error.
+func parseLogLevel(raw string) (zapcore.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "debug":
+		return zapcore.DebugLevel, nil
+	case "info", "":
+		return zapcore.InfoLevel, nil
+	case "warn", "warning":
+		return zapcore.WarnLevel, nil
+	case "error":
+		return zapcore.ErrorLevel, nil
+	default:
+		return zapcore.InfoLevel, fmt.Errorf("invalid log level %q (use debug, info, warn, or error)", raw)
+	}
+}
+
+// newCLILogger writes JSON lines at level to stderr. When file is set, the
+// file receives everything at level and stderr only warnings and errors, so a
+// debug log does not drown the terminal.
+func newCLILogger(level zapcore.Level, stderr io.Writer, file io.Writer) *zap.Logger {
+	encCfg := zap.NewProductionEncoderConfig()
+	encCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	stderrLevel := level
+	var cores []zapcore.Core
+	if file != nil {
+		cores = append(cores, zapcore.NewCore(zapcore.NewJSONEncoder(encCfg), zapcore.AddSync(file), level))
+		stderrLevel = max(level, zapcore.WarnLevel)
+	}
+	cores = append(cores, zapcore.NewCore(zapcore.NewJSONEncoder(encCfg), zapcore.Lock(zapcore.AddSync(stderr)), stderrLevel))
+	return zap.New(zapcore.NewTee(cores...), zap.AddCaller())
+}
+
+// configureLogging applies --log-level/--log-file, falling back to log.level
+// and log.file in config (or SECA_LOG_LEVEL / SECA_LOG_FILE).
+func configureLogging(cmd *cobra.Command) error {
+	levelName := viper.GetString("log.level")
+	if flag := cmd.Flags().Lookup("log-level"); flag != nil && (flag.Changed || levelName == "") {
+		levelName = flag.Value.String()
+	}
+	level, err := parseLogLevel(levelName)
+	if err != nil {
+		return err
+	}
+
+	path := viper.GetString("log.file")
+	if flag := cmd.Flags().Lookup("log-file"); flag != nil && flag.Changed {
+		path = flag.Value.String()
+	}
+
+	closeLogFile()
+	var file io.Writer
+	if path = strings.TrimSpace(path); path != "" {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600) // #nosec G304 -- log path is chosen by the operator.
+		if err != nil {
+			return fmt.Errorf("open log file: %w", err)
+		}
+		file, cliLogCloser = f, f
+	}
+
+	cliLogger = newCLILogger(level, os.Stderr, file).Sugar()
+	return nil
+}
+
+// syncLogger flushes buffered log entries and closes the log file.
+func syncLogger() {
+	_ = cliLogger.Sync()
+	closeLogFile()
+}
+
+func closeLogFile() {
+	if cliLogCloser != nil {
+		_ = cliLogCloser.Close()
+		cliLogCloser = nil
+	}
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	cases := map[string]zapcore.Level{
+		"debug":   zapcore.DebugLevel,
+		"INFO":    zapcore.InfoLevel,
+		"":        zapcore.InfoLevel,
+		"warning": zapcore.WarnLevel,
+		"error":   zapcore.ErrorLevel,
+	}
+	for raw, want := range cases {
+		got, err := parseLogLevel(raw)
+		if err != nil || got != want {
+			t.Fatalf("parseLogLevel(%q) = %v, %v; want %v", raw, got, err, want)
+		}
+	}
+	if _, err := parseLogLevel("verbose"); err == nil {
+		t.Fatal("expected error for unknown level")
+	}
+}
+
+func TestNewCLILogger_FileReceivesDebugStderrOnlyWarnings(t *testing.T) {
+	var stderr, file bytes.Buffer
+	logger := newCLILogger(zapcore.DebugLevel, &stderr, &file).Sugar()
+
+	logger.Debugw("target_checked", "target", "example.com")
+	logger.Warnw("crawl_failed", "target", "example.com")
+
+	fileLines := strings.Split(strings.TrimSpace(file.String()), "\n")
+	if len(fileLines) != 2 {
+		t.Fatalf("expected 2 lines in file, got %q", file.String())
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(fileLines[0]), &entry); err != nil {
+		t.Fatalf("log line is not JSON: %v", err)
+	}
+	if entry["msg"] != "target_checked" || entry["target"] != "example.com" || entry["level"] != "debug" {
+		t.Fatalf("unexpected entry %v", entry)
+	}
+
+	if strings.Contains(stderr.String(), "target_checked") || !strings.Contains(stderr.String(), "crawl_failed") {
+		t.Fatalf("expected only the warning on stderr, got %q", stderr.String())
+	}
+}
+
+func TestConfigureLogging(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	original := cliLogger
+	t.Cleanup(func() {
+		closeLogFile()
+		cliLogger = original
+	})
+
+	logPath := filepath.Join(t.TempDir(), "seca.log")
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("log-level", defaultLogLevel, "")
+	cmd.Flags().String("log-file", "", "")
+	viper.Set("log.level", "debug")
+	if err := cmd.Flags().Set("log-file", logPath); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := configureLogging(cmd); err != nil {
+		t.Fatalf("configureLogging: %v", err)
+	}
+	cliLog().Debugw("from_config_level")
+	syncLogger()
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	if !strings.Contains(string(data), "from_config_level") {
+		t.Fatalf("expected debug entry from log.level in file, got %q", data)
+	}
+
+	if err := cmd.Flags().Set("log-level", "loud"); err != nil {
+		t.Fatal(err)
+	}
+	if err := configureLogging(cmd); err == nil {
+		t.Fatal("expected error for invalid --log-level")
+	}
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+// assessLoginForms checks the password forms of the crawl inventory, using
+// the security headers checked on their pages where there are any.
+func assessLoginForms(surface *checker.AttackSurface, results iter.Seq[checker.CheckResult]) []checker.LoginFormAssessment {
+	if surface.Empty() {
+		return nil
+	}
+	return checker.AssessLoginForms(surface.Forms, pageHeaderLookup(results))
+}
+
+// pageHeaderLookup returns the latest security headers checked on each
+// page, matched by origin and path.
+func pageHeaderLookup(results iter.Seq[checker.CheckResult]) func(page string) *checker.SecurityHeadersResult {
+	latest := latestPageHeaders(results)
+	return func(target string) *checker.SecurityHeadersResult {
+		origin, page := headerOrigin(target)
+		if p, ok := latest[origin][page]; ok {
+			return p.headers
+		}
+		return nil
+	}
+}
+
+// printLoginForms summarizes the issues found on login forms.
+func printLoginForms(assessments []checker.LoginFormAssessment) {
+	if len(assessments) == 0 {
+		return
+	}
+	issues := 0
+	for _, a := range assessments {
+		issues += len(a.Issues)
+	}
+	fmt.Printf("%s Authentication surface: %d login form(s), %d issue(s)\n", colorInfo("→"), len(assessments), issues)
+	for _, a := range assessments {
+		for _, issue := range a.Issues {
+			fmt.Printf("  %s [%s] %s: %s\n", colorWarn("!"), issue.Severity, a.Page, issue.Detail)
+		}
+	}
+}
+
+// writeLoginFormsPDF lists the login forms and their issues.
+func writeLoginFormsPDF(pdf *gofpdf.Fpdf, assessments []checker.LoginFormAssessment) {
+	if pdf.GetY() > 240 {
+		pdf.AddPage()
+	}
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, "Authentication Surface", "", 1, "", false, 0, "")
+	pdf.SetFont("Arial", "I", 8)
+	pdf.MultiCell(0, 4, "Password forms found while crawling, checked for HTTPS transport, credential exposure in URLs, password manager hints, and CSP protection.", "", "", false)
+	pdf.Ln(2)
+
+	for _, a := range assessments {
+		if pdf.GetY() > 265 {
+			pdf.AddPage()
+		}
+		pdf.SetFont("Arial", "B", 9)
+		pdf.MultiCell(0, 5, fmt.Sprintf("%s (%s %s)", a.Page, a.Method, a.Action), "", "", false)
+		pdf.SetFont("Arial", "", 8)
+		if len(a.Issues) == 0 {
+			pdf.MultiCell(0, 4, "  No issues found.", "", "", false)
+		}
+		for _, issue := range a.Issues {
+			if pdf.GetY() > 270 {
+				pdf.AddPage()
+			}
+			pdf.MultiCell(0, 4, fmt.Sprintf("  - [%s] %s", issue.Severity, issue.Detail), "", "", false)
+		}
+		if !a.HeadersChecked {
+			pdf.SetFont("Arial", "I", 8)
+			pdf.MultiCell(0, 4, "  Page headers not checked; run check http --crawl to include CSP and framing.", "", "", false)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func TestPageHeaderLookup(t *testing.T) {
+	lookup := pageHeaderLookup(slices.Values([]checker.CheckResult{
+		pageResult("https://app.example.com", "Content-Security-Policy"),
+		pageResult("https://app.example.com/login"),
+		{Target: "https://app.example.com/down", Status: "error", SecurityHeaders: &checker.SecurityHeadersResult{}},
+	}))
+
+	// A scope entry and the URL the crawler records for it are one page.
+	if sh := lookup("https://app.example.com/"); sh == nil || !sh.Headers["Content-Security-Policy"].Present {
+		t.Errorf("expected the root page's headers, got %+v", sh)
+	}
+	if sh := lookup("https://app.example.com/login"); sh == nil || sh.Headers["Content-Security-Policy"].Present {
+		t.Errorf("expected the login page's headers, got %+v", sh)
+	}
+	if sh := lookup("https://app.example.com/down"); sh != nil {
+		t.Errorf("expected no headers for a page that did not answer, got %+v", sh)
+	}
+}
+
+func TestAuthSurfaceReportSection(t *testing.T) {
+	output := &RunOutput{
+		Metadata: RunMetadata{EngagementID: "eng-auth", StartAt: time.Now(), CompleteAt: time.Now()},
+		Results:  []checker.CheckResult{pageResult("https://app.example.com/login", "X-Frame-Options")},
+		AttackSurface: &checker.AttackSurface{Forms: []checker.FormInfo{{
+			Page: "https://app.example.com/login", Action: "https://app.example.com/session", Method: "POST",
+			HasPassword: true, PasswordAutocomplete: []string{"current-password"},
+		}}},
+	}
+	data := buildTemplateData(output, nil, "%.2f", nil)
+	if len(data.LoginForms) != 1 || !data.LoginForms[0].HeadersChecked {
+		t.Fatalf("expected the login form assessed with its page headers, got %+v", data.LoginForms)
+	}
+
+	markdown, err := generateMarkdownReport(data)
+	if err != nil {
+		t.Fatalf("Failed to generate markdown report: %v", err)
+	}
+	if want := "| https://app.example.com/login | POST https://app.example.com/session | medium | No Content-Security-Policy;"; !strings.Contains(markdown, want) {
+		t.Errorf("markdown report missing %q:\n%s", want, markdown)
+	}
+
+	html, err := generateHTMLReport(data)
+	if err != nil {
+		t.Fatalf("Failed to generate HTML report: %v", err)
+	}
+	if !strings.Contains(html, "Authentication Surface") {
+		t.Error("HTML report missing the authentication surface section")
+	}
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+)
+
+// networkPathsFilename holds the route to each target captured with
+// --traceroute, for engagements that must document the testing path.
+const networkPathsFilename = "network_paths.json"
+
+// NetworkPathRecord is the path observed to one target.
+type NetworkPathRecord struct {
+	Target string `json:"target"`
+	checker.NetworkPath
+}
+
+// newTracer builds the traceroute prober from the network options.
+func newTracer(cfg NetworkConfig) (*checker.Tracer, error) {
+	method := strings.ToLower(cfg.TraceMethod)
+	if method != checker.TracerouteUDP && method != checker.TracerouteTCP {
+		return nil, fmt.Errorf("invalid --traceroute-method %q (must be udp or tcp)", cfg.TraceMethod)
+	}
+	if cfg.TracePort < 1 || cfg.TracePort > 65535 {
+		return nil, fmt.Errorf("invalid --traceroute-port %d", cfg.TracePort)
+	}
+	if cfg.TraceMaxHops < 1 || cfg.TraceMaxHops > 64 {
+		return nil, fmt.Errorf("--traceroute-max-hops must be between 1 and 64")
+	}
+	return &checker.Tracer{
+		Method:  method,
+		Port:    cfg.TracePort,
+		MaxHops: cfg.TraceMaxHops,
+		Timeout: time.Duration(cfg.PortScanTimeout) * time.Second,
+	}, nil
+}
+
+// loadNetworkPaths returns the stored paths, or nil when none were recorded.
+func loadNetworkPaths(resultsDir, engagementID string) ([]NetworkPathRecord, error) {
+	path, err := resolveResultsPath(resultsDir, engagementID, networkPathsFilename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var records []NetworkPathRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", networkPathsFilename, err)
+	}
+	return records, nil
+}
+
+// saveNetworkPaths merges the paths in results into the stored records,
+// replacing earlier captures for the same target, and returns the path.
+func saveNetworkPaths(resultsDir, engagementID string, results []checker.CheckResult) ([]NetworkPathRecord, string, error) {
+	if _, err := ensureResultsDir(resultsDir, engagementID); err != nil {
+		return nil, "", err
+	}
+	existing, err := loadNetworkPaths(resultsDir, engagementID)
+	if err != nil {
+		return nil, "", err
+	}
+	byTarget := make(map[string]NetworkPathRecord, len(existing)+len(results))
+	for _, rec := range existing {
+		byTarget[rec.Target] = rec
+	}
+	for _, r := range results {
+		if r.NetworkSecurity != nil && r.NetworkSecurity.Path != nil {
+			byTarget[r.Target] = NetworkPathRecord{Target: r.Target, NetworkPath: *r.NetworkSecurity.Path}
+		}
+	}
+	records := make([]NetworkPathRecord, 0, len(byTarget))
+	for _, rec := range byTarget {
+		records = append(records, rec)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Target < records[j].Target })
+
+	path, err := resolveResultsPath(resultsDir, engagementID, networkPathsFilename)
+	if err != nil {
+		return nil, "", err
+	}
+	data, err := json.MarshalIndent(records, jsonPrefix, jsonIndent)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := fileutil.WriteFile(path, data, consts.DefaultFilePerm); err != nil {
+		return nil, "", err
+	}
+	return records, path, nil
+}
+
+// recordNetworkPaths saves the captured paths and prints one line per
+// destination.
+func recordNetworkPaths(resultsDir, engagementID string, results []checker.CheckResult) {
+	_, path, err := saveNetworkPaths(resultsDir, engagementID, results)
+	if err != nil {
+		cliLog().Warnw("network_paths_save_failed", "engagement_id", engagementID, "error", err)
+		return
+	}
+	seen := make(map[string]bool)
+	fmt.Printf("%s Network paths → %s\n", colorInfo("→"), path)
+	for _, r := range results {
+		if r.NetworkSecurity == nil || r.NetworkSecurity.Path == nil {
+			continue
+		}
+		p := r.NetworkSecurity.Path
+		if seen[p.Destination] {
+			continue
+		}
+		seen[p.Destination] = true
+		fmt.Printf("  %s %s: %s\n", colorInfo("•"), orDash(p.Destination), p.Summary())
+	}
+}
+
+// writeNetworkPathsPDF lists the route captured to each target.
+func writeNetworkPathsPDF(pdf *gofpdf.Fpdf, records []NetworkPathRecord) {
+	if pdf.GetY() > 240 {
+		pdf.AddPage()
+	}
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, "Network Paths", "", 1, "", false, 0, "")
+	pdf.SetFont("Arial", "I", 8)
+	pdf.MultiCell(0, 4, "Route from the scanning host to each target, captured with TTL-limited probes. * marks hops that did not answer.", "", "", false)
+	pdf.Ln(2)
+
+	pdf.SetFont("Arial", "", 8)
+	for _, rec := range records {
+		if pdf.GetY() > 270 {
+			pdf.AddPage()
+		}
+		line := fmt.Sprintf("  - %s (%s, %s/%d, %s): %s", rec.Target, orDash(rec.Destination), strings.ToUpper(rec.Method), rec.Port, rec.CapturedAt.Format("2006-01-02 15:04 UTC"), rec.Summary())
+		pdf.MultiCell(0, 4, line, "", "", false)
+	}
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func TestNewTracer(t *testing.T) {
+	cfg := NetworkConfig{TraceMethod: "TCP", TracePort: 8443, TraceMaxHops: 12, PortScanTimeout: 1}
+	tracer, err := newTracer(cfg)
+	if err != nil {
+		t.Fatalf("newTracer: %v", err)
+	}
+	if tracer.Method != checker.TracerouteTCP || tracer.Port != 8443 || tracer.MaxHops != 12 || tracer.Timeout != time.Second {
+		t.Errorf("unexpected tracer %+v", tracer)
+	}
+
+	for _, bad := range []NetworkConfig{
+		{TraceMethod: "icmp", TracePort: 443, TraceMaxHops: 30},
+		{TraceMethod: "udp", TracePort: 0, TraceMaxHops: 30},
+		{TraceMethod: "udp", TracePort: 443, TraceMaxHops: 100},
+	} {
+		if _, err := newTracer(bad); err == nil {
+			t.Errorf("expected error for %+v", bad)
+		}
+	}
+}
+
+func TestNetworkPaths_SaveAndReport(t *testing.T) {
+	resultsDir := t.TempDir()
+	captured := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	path := &checker.NetworkPath{
+		Destination: "192.0.2.10",
+		Method:      checker.TracerouteUDP,
+		Port:        33434,
+		Hops: []checker.PathHop{
+			{TTL: 1, Address: "10.0.0.1", RTT: 0.4},
+			{TTL: 2},
+			{TTL: 3, Address: "192.0.2.10", RTT: 11.2},
+		},
+		HopCount:   3,
+		Reached:    true,
+		CapturedAt: captured,
+	}
+	results := []checker.CheckResult{
+		{Target: "https://example.com", NetworkSecurity: &checker.NetworkSecurityResult{Path: path}},
+		{Target: "https://nopath.example.com", NetworkSecurity: &checker.NetworkSecurityResult{}},
+	}
+	if _, _, err := saveNetworkPaths(resultsDir, "eng-path", results); err != nil {
+		t.Fatalf("saveNetworkPaths: %v", err)
+	}
+	// A later run replaces the record for the same target.
+	path.Hops[1].Address = "10.0.1.1"
+	records, _, err := saveNetworkPaths(resultsDir, "eng-path", results[:1])
+	if err != nil {
+		t.Fatalf("saveNetworkPaths: %v", err)
+	}
+	if len(records) != 1 || records[0].Hops[1].Address != "10.0.1.1" {
+		t.Fatalf("unexpected records %+v", records)
+	}
+
+	stored, err := loadNetworkPaths(resultsDir, "eng-path")
+	if err != nil || len(stored) != 1 {
+		t.Fatalf("loadNetworkPaths = %+v, %v", stored, err)
+	}
+
+	output := &RunOutput{
+		Metadata:     RunMetadata{EngagementID: "eng-path", StartAt: time.Now(), CompleteAt: time.Now()},
+		Results:      []checker.CheckResult{{Target: "https://example.com", Status: "ok"}},
+		NetworkPaths: stored,
+	}
+	data := buildTemplateData(output, nil, "%.2f", nil)
+	markdown, err := generateMarkdownReport(data)
+	if err != nil {
+		t.Fatalf("Failed to generate markdown report: %v", err)
+	}
+	if want := "| https://example.com | 192.0.2.10 | UDP/33434 | 3 | 3 hops: 10.0.0.1 → 10.0.1.1 → 192.0.2.10 |"; !strings.Contains(markdown, want) {
+		t.Errorf("expected markdown report to contain %q", want)
+	}
+	html, err := generateHTMLReport(data)
+	if err != nil {
+		t.Fatalf("Failed to generate HTML report: %v", err)
+	}
+	if want := "3 hops: 10.0.0.1 → 10.0.1.1 → 192.0.2.10"; !strings.Contains(html, want) {
+		t.Errorf("expected HTML report to contain %q", want)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
 	"github.com/khanhnv2901/seca-cli/internal/shared/security"
 )
 
@@ -96,10 +97,9 @@ func getEngagementsFilePath() (string, error) {
 		// Migrate from old location to new location
 		if err := migrateEngagementsFile(oldPath, newPath); err != nil {
 			// If migration fails, log warning but continue with new path
-			fmt.Fprintf(os.Stderr, "Warning: Could not migrate engagements.json: %v\n", err)
-			fmt.Fprintf(os.Stderr, "Using new location: %s\n", newPath)
+			cliLog().Warnw("engagements_migration_failed", "error", err, "using", newPath)
 		} else {
-			fmt.Fprintf(os.Stderr, "Migrated engagements.json from %s to %s\n", oldPath, newPath)
+			cliLog().Infow("engagements_migrated", "from", oldPath, "to", newPath)
 		}
 	}
 
@@ -124,7 +124,7 @@ func migrateEngagementsFile(oldPath, newPath string) error {
 	}
 
 	// Write to new location
-	if err := os.WriteFile(newAbs, data, consts.DefaultFilePerm); err != nil {
+	if err := fileutil.WriteFile(newAbs, data, consts.DefaultFilePerm); err != nil {
 		return fmt.Errorf("failed to write to new location: %w", err)
 	}
 
@@ -137,7 +137,7 @@ func migrateEngagementsFile(oldPath, newPath string) error {
 		return nil
 	}
 
-	fmt.Fprintf(os.Stderr, "Created backup: %s\n", backupPath)
+	cliLog().Infow("engagements_backup_created", "path", backupPath)
 	return nil
 }
 
@@ -0,0 +1,635 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/plugin"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Install, inspect, and validate checker plugins",
+}
+
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List installed checker plugins",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		loaded, dir, err := loadPluginManifests()
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if len(loaded) == 0 {
+			fmt.Fprintf(out, "No plugins found in %s\n", dir)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tVERSION\tAPI\tCHECK TYPES\tSTATUS")
+		for _, l := range loaded {
+			if l.Manifest == nil {
+				fmt.Fprintf(w, "%s\t-\t-\t-\t%s\n", filepath.Base(l.Path), colorError("invalid"))
+				continue
+			}
+			status := colorSuccess("ok")
+			if l.Err != nil {
+				status = colorError("invalid")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n",
+				l.Manifest.Name,
+				valueOrDash(l.Manifest.Version),
+				l.Manifest.APIVersion,
+				valueOrDash(strings.Join(l.Manifest.CheckTypes, ",")),
+				status)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		for _, l := range loaded {
+			if l.Err != nil {
+				fmt.Fprintf(os.Stderr, "%s %s: %v\n", colorWarn("!"), filepath.Base(l.Path), l.Err)
+			}
+		}
+		return nil
+	},
+}
+
+var pluginInfoCmd = &cobra.Command{
+	Use:   "info <name>",
+	Short: "Show a plugin manifest",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l, err := findPluginManifest(args[0])
+		if err != nil {
+			return err
+		}
+		m := l.Manifest
+
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "Name:         %s\n", m.Name)
+		fmt.Fprintf(out, "Version:      %s\n", valueOrDash(m.Version))
+		fmt.Fprintf(out, "Description:  %s\n", valueOrDash(m.Description))
+		fmt.Fprintf(out, "API version:  %d\n", m.APIVersion)
+		fmt.Fprintf(out, "Command:      %s %s\n", m.Command, strings.Join(m.Args, " "))
+		fmt.Fprintf(out, "Timeout:      %ds\n", m.TimeoutSeconds)
+		fmt.Fprintf(out, "Check types:  %s\n", valueOrDash(strings.Join(m.CheckTypes, ", ")))
+		fmt.Fprintf(out, "Results file: %s\n", m.ResultsFilename)
+		fmt.Fprintf(out, "Manifest:     %s\n", l.Path)
+		if p := m.Provenance; p != nil {
+			signed := "unsigned"
+			if p.Signed {
+				signed = "signed, key " + p.KeyID
+			}
+			fmt.Fprintf(out, "Installed:    %s from %s (%s)\n", p.InstalledAt.Format(time.RFC3339), p.Source, signed)
+			fmt.Fprintf(out, "SHA256:       %s\n", p.SHA256)
+		}
+
+		if len(m.ConfigSchema) > 0 {
+			fmt.Fprintln(out, "\nConfiguration (plugins."+m.Name+".<key>):")
+			keys := make([]string, 0, len(m.ConfigSchema))
+			for k := range m.ConfigSchema {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			for _, k := range keys {
+				field := m.ConfigSchema[k]
+				required := "optional"
+				if field.Required {
+					required = "required"
+				}
+				def := ""
+				if field.Default != "" && !field.Secret {
+					def = " (default " + field.Default + ")"
+				}
+				fmt.Fprintf(w, "  %s\t%s\t%s%s\t%s\n", k, field.Type, required, def, field.Description)
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+
+		if l.Err != nil {
+			fmt.Fprintf(out, "\n%s Manifest is invalid:\n%s\n", colorError("✗"), indentLines(l.Err.Error(), "  "))
+		}
+		return nil
+	},
+}
+
+var pluginValidateCmd = &cobra.Command{
+	Use:   "validate [name|manifest.json ...]",
+	Short: "Validate plugin manifests and perform the version handshake",
+	Long: `Validate plugin manifests and, for api_version 2 plugins, run the handshake
+to confirm the executable speaks a compatible protocol version and implements
+the declared check types. Configuration in plugins.<name>.* is checked against
+the manifest config schema.
+
+With no arguments every installed plugin is validated. Arguments may be plugin
+names or paths to manifest files (useful while developing a plugin).`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var targets []plugin.Loaded
+		if len(args) == 0 {
+			loaded, dir, err := loadPluginManifests()
+			if err != nil {
+				return err
+			}
+			if len(loaded) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "No plugins found in %s\n", dir)
+				return nil
+			}
+			targets = loaded
+		} else {
+			for _, arg := range args {
+				l, err := resolvePluginArg(arg)
+				if err != nil {
+					return err
+				}
+				targets = append(targets, *l)
+			}
+		}
+
+		out := cmd.OutOrStdout()
+		failed := 0
+		for _, l := range targets {
+			label := filepath.Base(l.Path)
+			if l.Manifest != nil && l.Manifest.Name != "" {
+				label = l.Manifest.Name
+			}
+			if err := validatePlugin(cmd.Context(), l); err != nil {
+				failed++
+				fmt.Fprintf(out, "%s %s\n%s\n", colorError("✗"), label, indentLines(err.Error(), "    "))
+				continue
+			}
+			fmt.Fprintf(out, "%s %s\n", colorSuccess("✓"), label)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d plugin(s) failed validation", failed)
+		}
+		return nil
+	},
+}
+
+var pluginInstallCmd = &cobra.Command{
+	Use:   "install <name|url>",
+	Short: "Install a plugin from the registry or a release URL",
+	Long: `Download a plugin release, verify its sha256 checksum (and ed25519
+signature when a registry key is configured), and install it into the plugins
+directory. The installed manifest records where the plugin came from so it can
+be updated later.
+
+A name is looked up in the registry index; an https URL is treated as a single
+release document.
+
+Registry settings may be provided in ~/.seca-cli.yaml:
+  plugin_registry:
+    url: https://plugins.example.com/index.json
+    public_key: <base64 ed25519 public key>
+    require_signature: true`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		installer, err := newPluginInstaller(cmd)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		kind, source := plugin.SourceRegistry, ""
+		name := args[0]
+		if strings.HasPrefix(name, "https://") || strings.HasPrefix(name, "http://") {
+			kind, source, name = plugin.SourceURL, name, ""
+		} else {
+			source = pluginRegistrySetting(cmd, "registry", "url")
+			if source == "" {
+				return fmt.Errorf("no plugin registry configured (use --registry or set plugin_registry.url)")
+			}
+		}
+
+		rel, err := installer.Resolve(ctx, kind, source, name)
+		if err != nil {
+			return err
+		}
+		manifest, err := installer.Install(ctx, rel, kind, source)
+		if err != nil {
+			return err
+		}
+		printPluginInstalled(cmd, "Installed", manifest)
+		return nil
+	},
+}
+
+var pluginUpdateCmd = &cobra.Command{
+	Use:   "update [name...]",
+	Short: "Update registry-installed plugins to their latest release",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		installer, err := newPluginInstaller(cmd)
+		if err != nil {
+			return err
+		}
+
+		names := args
+		if len(names) == 0 {
+			loaded, _, err := loadPluginManifests()
+			if err != nil {
+				return err
+			}
+			for _, l := range loaded {
+				if l.Manifest != nil && l.Manifest.Provenance != nil {
+					names = append(names, l.Manifest.Name)
+				}
+			}
+			if len(names) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No registry-installed plugins to update")
+				return nil
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		failed := 0
+		for _, name := range names {
+			if err := updatePlugin(ctx, cmd, installer, name); err != nil {
+				failed++
+				fmt.Fprintf(cmd.OutOrStdout(), "%s %s: %v\n", colorError("✗"), name, err)
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d plugin(s) failed to update", failed)
+		}
+		return nil
+	},
+}
+
+var pluginRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove an installed plugin",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := getPluginsDir()
+		if err != nil {
+			return err
+		}
+		installer := &plugin.Installer{PluginsDir: dir}
+		removed, err := installer.Remove(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s Removed plugin %s (%s)\n", colorSuccess("✓"), args[0], removed.Path)
+		return nil
+	},
+}
+
+func updatePlugin(ctx context.Context, cmd *cobra.Command, installer *plugin.Installer, name string) error {
+	installed, err := installer.Installed(name)
+	if err != nil {
+		return err
+	}
+	prov := installed.Manifest.Provenance
+	if prov == nil {
+		return plugin.ErrNotFromRegistry
+	}
+
+	rel, err := installer.Resolve(ctx, prov.Kind, prov.Source, name)
+	if err != nil {
+		return err
+	}
+	if plugin.CompareVersions(rel.Version, installed.Manifest.Version) <= 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %s is up to date (%s)\n", colorInfo("→"), name, installed.Manifest.Version)
+		return nil
+	}
+
+	manifest, err := installer.Install(ctx, rel, prov.Kind, prov.Source)
+	if err != nil {
+		return err
+	}
+	printPluginInstalled(cmd, "Updated", manifest)
+	return nil
+}
+
+func printPluginInstalled(cmd *cobra.Command, verb string, m *plugin.Manifest) {
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "%s %s plugin %s %s\n", colorSuccess("✓"), verb, m.Name, m.Version)
+	fmt.Fprintf(out, "  sha256: %s\n", m.Provenance.SHA256)
+	if m.Provenance.Signed {
+		fmt.Fprintf(out, "  signature: verified (key %s)\n", m.Provenance.KeyID)
+	} else {
+		fmt.Fprintf(out, "  signature: %s\n", colorWarn("not verified"))
+	}
+}
+
+// newPluginInstaller builds an installer from plugin_registry.* config and flags.
+func newPluginInstaller(cmd *cobra.Command) (*plugin.Installer, error) {
+	dir, err := getPluginsDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, consts.DefaultDirPerm); err != nil {
+		return nil, fmt.Errorf("create plugins directory: %w", err)
+	}
+
+	publicKey, err := plugin.ParsePublicKey(pluginRegistrySetting(cmd, "public-key", "public_key"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid plugin registry public key: %w", err)
+	}
+
+	requireSignature := viper.GetBool("plugin_registry.require_signature")
+	if flag := cmd.Flags().Lookup("require-signature"); flag != nil && flag.Changed {
+		requireSignature, _ = cmd.Flags().GetBool("require-signature")
+	}
+
+	return &plugin.Installer{
+		PluginsDir:       dir,
+		PublicKey:        publicKey,
+		RequireSignature: requireSignature,
+	}, nil
+}
+
+// pluginRegistrySetting returns the flag value when set, otherwise plugin_registry.<key>.
+func pluginRegistrySetting(cmd *cobra.Command, flagName, key string) string {
+	if flag := cmd.Flags().Lookup(flagName); flag != nil && flag.Changed {
+		value, _ := cmd.Flags().GetString(flagName)
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(viper.GetString("plugin_registry." + key))
+}
+
+var pluginSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema plugin output must satisfy",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := cmd.OutOrStdout().Write(plugin.CheckResultSchema())
+		return err
+	},
+}
+
+var pluginTestCmd = &cobra.Command{
+	Use:   "test [name|manifest.json] --fixture <file>",
+	Short: "Validate plugin output against the CheckResult schema",
+	Long: `Test plugin output against the published CheckResult JSON Schema.
+
+Without a plugin argument the fixture is captured plugin output (a JSON object,
+or an array of objects) and is validated directly.
+
+With a plugin name or manifest path the fixture lists test cases; the plugin is
+run for each target, its output is validated against the schema, and any
+expected fields are compared:
+
+  {"cases": [{"name": "healthy site", "target": "example.com", "expect": {"status": "ok"}}]}`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fixturePath, _ := cmd.Flags().GetString("fixture")
+		if fixturePath == "" {
+			return fmt.Errorf("--fixture is required")
+		}
+		data, err := os.ReadFile(fixturePath) // #nosec G304 -- fixture path is supplied by the operator.
+		if err != nil {
+			return fmt.Errorf("read fixture: %w", err)
+		}
+
+		if len(args) == 0 {
+			return testPluginOutputFixture(cmd, data)
+		}
+
+		l, err := resolvePluginArg(args[0])
+		if err != nil {
+			return err
+		}
+		if l.Err != nil {
+			return fmt.Errorf("invalid plugin manifest: %w", l.Err)
+		}
+		return testPluginCases(cmd, l.Manifest, data)
+	},
+}
+
+type pluginFixture struct {
+	Cases []pluginFixtureCase `json:"cases"`
+}
+
+type pluginFixtureCase struct {
+	Name   string                 `json:"name"`
+	Target string                 `json:"target"`
+	Expect map[string]interface{} `json:"expect"`
+}
+
+// testPluginOutputFixture validates captured plugin output.
+func testPluginOutputFixture(cmd *cobra.Command, data []byte) error {
+	var docs []json.RawMessage
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &docs); err != nil {
+			return fmt.Errorf("parse fixture: %w", err)
+		}
+	} else {
+		docs = []json.RawMessage{trimmed}
+	}
+
+	out := cmd.OutOrStdout()
+	failed := 0
+	for i, doc := range docs {
+		if err := plugin.ValidateCheckResult(doc); err != nil {
+			failed++
+			fmt.Fprintf(out, "%s result %d\n%s\n", colorError("✗"), i, indentLines(err.Error(), "    "))
+			continue
+		}
+		fmt.Fprintf(out, "%s result %d\n", colorSuccess("✓"), i)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d result(s) failed schema validation", failed, len(docs))
+	}
+	return nil
+}
+
+// testPluginCases runs the plugin for each fixture case and checks its output.
+func testPluginCases(cmd *cobra.Command, m *plugin.Manifest, data []byte) error {
+	var fixture pluginFixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return fmt.Errorf("parse fixture: %w", err)
+	}
+	if len(fixture.Cases) == 0 {
+		return fmt.Errorf("fixture contains no cases")
+	}
+
+	ctx := context.Background()
+	externalChecker, err := newPluginChecker(ctx, m, nil)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	failed := 0
+	for i, tc := range fixture.Cases {
+		label := tc.Name
+		if label == "" {
+			label = fmt.Sprintf("case %d (%s)", i, tc.Target)
+		}
+		if tc.Target == "" {
+			failed++
+			fmt.Fprintf(out, "%s %s\n    target is required\n", colorError("✗"), label)
+			continue
+		}
+
+		result := externalChecker.Check(ctx, tc.Target)
+		problems := compareFixtureExpectations(result, tc.Expect)
+		if len(problems) > 0 {
+			failed++
+			fmt.Fprintf(out, "%s %s\n%s\n", colorError("✗"), label, indentLines(strings.Join(problems, "\n"), "    "))
+			continue
+		}
+		fmt.Fprintf(out, "%s %s\n", colorSuccess("✓"), label)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d case(s) failed", failed, len(fixture.Cases))
+	}
+	return nil
+}
+
+// compareFixtureExpectations reports expected fields that differ from the result.
+func compareFixtureExpectations(result checker.CheckResult, expect map[string]interface{}) []string {
+	if strings.HasPrefix(result.Error, "invalid plugin output") {
+		return []string{result.Error}
+	}
+
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	var actual map[string]interface{}
+	if err := json.Unmarshal(encoded, &actual); err != nil {
+		return []string{err.Error()}
+	}
+
+	keys := make([]string, 0, len(expect))
+	for k := range expect {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var problems []string
+	for _, key := range keys {
+		if got := actual[key]; !reflect.DeepEqual(got, expect[key]) {
+			problems = append(problems, fmt.Sprintf("$.%s: expected %v, got %v", key, expect[key], got))
+		}
+	}
+	if len(problems) > 0 && result.Error != "" {
+		problems = append(problems, "plugin error: "+strings.TrimSpace(result.Error))
+	}
+	return problems
+}
+
+// validatePlugin checks the manifest, resolved configuration, and handshake.
+func validatePlugin(ctx context.Context, l plugin.Loaded) error {
+	if l.Err != nil {
+		return l.Err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	m := l.Manifest
+
+	var errs []error
+	if _, err := m.ResolveConfig(pluginConfigValues(m.Name)); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := plugin.Handshake(ctx, m); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func loadPluginManifests() ([]plugin.Loaded, string, error) {
+	dir, err := getPluginsDir()
+	if err != nil {
+		return nil, "", err
+	}
+	loaded, err := plugin.LoadDir(dir)
+	if err != nil {
+		return nil, dir, fmt.Errorf("failed to load plugins: %w", err)
+	}
+	return loaded, dir, nil
+}
+
+// findPluginManifest locates an installed plugin by name.
+func findPluginManifest(name string) (*plugin.Loaded, error) {
+	loaded, dir, err := loadPluginManifests()
+	if err != nil {
+		return nil, err
+	}
+	for i := range loaded {
+		if loaded[i].Manifest != nil && loaded[i].Manifest.Name == name {
+			return &loaded[i], nil
+		}
+	}
+	return nil, fmt.Errorf("plugin %s not found in %s", name, dir)
+}
+
+// resolvePluginArg accepts either an installed plugin name or a manifest path.
+func resolvePluginArg(arg string) (*plugin.Loaded, error) {
+	if strings.HasSuffix(arg, plugin.ManifestExtension) {
+		if _, err := os.Stat(arg); err == nil {
+			l := &plugin.Loaded{Path: arg}
+			l.Manifest, l.Err = plugin.ReadManifest(arg)
+			if l.Err == nil {
+				l.Err = l.Manifest.Validate()
+			}
+			return l, nil
+		}
+	}
+	return findPluginManifest(arg)
+}
+
+func valueOrDash(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "-"
+	}
+	return s
+}
+
+func indentLines(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginListCmd)
+	pluginCmd.AddCommand(pluginInfoCmd)
+	pluginCmd.AddCommand(pluginValidateCmd)
+
+	for _, c := range []*cobra.Command{pluginInstallCmd, pluginUpdateCmd} {
+		c.Flags().String("public-key", "", "Registry ed25519 public key (overrides plugin_registry.public_key)")
+		c.Flags().Bool("require-signature", false, "Refuse releases without a valid signature")
+	}
+	pluginInstallCmd.Flags().String("registry", "", "Registry index URL (overrides plugin_registry.url)")
+	pluginCmd.AddCommand(pluginInstallCmd)
+	pluginCmd.AddCommand(pluginUpdateCmd)
+	pluginCmd.AddCommand(pluginRemoveCmd)
+
+	pluginTestCmd.Flags().String("fixture", "", "Fixture file: captured plugin output, or test cases when a plugin is given")
+	pluginCmd.AddCommand(pluginSchemaCmd)
+	pluginCmd.AddCommand(pluginTestCmd)
+	rootCmd.AddCommand(pluginCmd)
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func writePluginManifest(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPluginListShowsInvalidManifests(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv(dataDirEnvVar, dataDir)
+	pluginsDir := filepath.Join(dataDir, "plugins")
+	writePluginManifest(t, pluginsDir, "good.json", `{"name":"good","command":"/bin/true"}`)
+	writePluginManifest(t, pluginsDir, "bad.json", `{"name":"bad","api_version":2,"command":"/bin/true"}`)
+
+	var out bytes.Buffer
+	pluginListCmd.SetOut(&out)
+	defer pluginListCmd.SetOut(nil)
+	if err := pluginListCmd.RunE(pluginListCmd, nil); err != nil {
+		t.Fatalf("plugin list error = %v", err)
+	}
+	if !strings.Contains(out.String(), "good") || !strings.Contains(out.String(), "invalid") {
+		t.Fatalf("unexpected list output:\n%s", out.String())
+	}
+}
+
+func TestResolvePluginArgManifestPath(t *testing.T) {
+	path := writePluginManifest(t, t.TempDir(), "dev.json", `{"name":"dev","api_version":2,"version":"0.1.0","command":"/bin/true"}`)
+
+	l, err := resolvePluginArg(path)
+	if err != nil {
+		t.Fatalf("resolvePluginArg() error = %v", err)
+	}
+	if l.Err == nil || !strings.Contains(l.Err.Error(), "check_types is required") {
+		t.Fatalf("expected check_types validation error, got %v", l.Err)
+	}
+	if err := validatePlugin(context.Background(), *l); err == nil {
+		t.Fatal("validatePlugin should surface manifest errors")
+	}
+}
+
+func TestPluginTestOutputFixture(t *testing.T) {
+	var out bytes.Buffer
+	pluginTestCmd.SetOut(&out)
+	defer pluginTestCmd.SetOut(nil)
+
+	err := testPluginOutputFixture(pluginTestCmd, []byte(`[{"status":"ok"},{"status":"ok","http_status":"x"}]`))
+	if err == nil || !strings.Contains(err.Error(), "1 of 2") {
+		t.Fatalf("expected one failing result, got %v", err)
+	}
+	if !strings.Contains(out.String(), "$.http_status: expected integer") {
+		t.Fatalf("expected field path in output:\n%s", out.String())
+	}
+}
+
+func TestCompareFixtureExpectations(t *testing.T) {
+	result := checker.CheckResult{Status: "ok", HTTPStatus: 200}
+	if problems := compareFixtureExpectations(result, map[string]interface{}{"status": "ok", "http_status": float64(200)}); len(problems) != 0 {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+	problems := compareFixtureExpectations(result, map[string]interface{}{"status": "fail"})
+	if len(problems) != 1 || !strings.Contains(problems[0], "expected fail, got ok") {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+}
@@ -2,9 +2,9 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -13,110 +13,134 @@ import (
 	"time"
 
 	"github.com/khanhnv2901/seca-cli/internal/domain/audit"
+	"github.com/khanhnv2901/seca-cli/internal/domain/check"
 	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/plugin"
 	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
-	"github.com/khanhnv2901/seca-cli/internal/shared/security"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
-type checkerPluginDefinition struct {
-	Name            string            `json:"name"`
-	Description     string            `json:"description"`
-	Command         string            `json:"command"`
-	Args            []string          `json:"args"`
-	Env             map[string]string `json:"env"`
-	TimeoutSeconds  int               `json:"timeout"`
-	ResultsFilename string            `json:"results_filename"`
-	APIVersion      int               `json:"api_version"`
-}
-
-const currentPluginAPIVersion = 1
-
 func registerPluginCommands() {
-	defs, err := loadCheckerPlugins()
+	manifests, err := loadCheckerPlugins()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: unable to load plugins: %v\n", err)
+		cliLog().Warnw("plugins_load_failed", "error", err)
 		return
 	}
 
-	for _, def := range defs {
-		if err := addPluginCommand(def); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: skipping plugin %s: %v\n", def.Name, err)
+	for _, m := range manifests {
+		if err := addPluginCommand(m); err != nil {
+			cliLog().Warnw("plugin_skipped", "plugin", m.Name, "error", err)
 		}
 	}
 }
 
-func loadCheckerPlugins() ([]checkerPluginDefinition, error) {
+// getPluginsDir returns the directory holding plugin manifests.
+func getPluginsDir() (string, error) {
 	dataDir, err := getDataDir()
 	if err != nil {
-		return nil, err
+		return "", err
 	}
+	return filepath.Join(dataDir, "plugins"), nil
+}
 
-	pluginsDir := filepath.Join(dataDir, "plugins")
-	if _, err := os.Stat(pluginsDir); os.IsNotExist(err) {
-		return nil, nil
+// loadCheckerPlugins returns the valid plugin manifests, warning about the rest.
+func loadCheckerPlugins() ([]*plugin.Manifest, error) {
+	pluginsDir, err := getPluginsDir()
+	if err != nil {
+		return nil, err
 	}
 
-	entries, err := os.ReadDir(pluginsDir)
+	loaded, err := plugin.LoadDir(pluginsDir)
 	if err != nil {
 		return nil, err
 	}
 
-	defs := make([]checkerPluginDefinition, 0, len(entries))
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
-			continue
-		}
-		path, err := security.ResolveWithin(pluginsDir, entry.Name())
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: invalid plugin path %s: %v\n", entry.Name(), err)
-			continue
-		}
-		data, err := os.ReadFile(path)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to read plugin %s: %v\n", entry.Name(), err)
+	manifests := make([]*plugin.Manifest, 0, len(loaded))
+	for _, l := range loaded {
+		if l.Err != nil {
+			cliLog().Warnw("plugin_invalid", "manifest", filepath.Base(l.Path), "error", l.Err)
 			continue
 		}
+		manifests = append(manifests, l.Manifest)
+	}
 
-		var def checkerPluginDefinition
-		if err := json.Unmarshal(data, &def); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to parse plugin %s: %v\n", entry.Name(), err)
-			continue
-		}
+	return manifests, nil
+}
 
-		if def.APIVersion == 0 {
-			def.APIVersion = currentPluginAPIVersion
-		}
+// pluginConfigValues returns the operator-supplied config for a plugin (plugins.<name>.* in config).
+func pluginConfigValues(name string) map[string]string {
+	return viper.GetStringMapString("plugins." + name)
+}
 
-		if def.APIVersion != currentPluginAPIVersion {
-			fmt.Fprintf(os.Stderr, "Warning: unsupported plugin API version %d in %s (expected %d)\n", def.APIVersion, entry.Name(), currentPluginAPIVersion)
-			continue
-		}
+// newPluginChecker resolves plugin configuration, performs the handshake, and
+// returns a checker whose output is validated against the CheckResult schema.
+// When proxy is set, the plugin receives the standard *_PROXY variables.
+func newPluginChecker(ctx context.Context, def *plugin.Manifest, proxy *url.URL) (*checker.ExternalChecker, error) {
+	configEnv, err := def.ResolveConfig(pluginConfigValues(def.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid plugin configuration: %w", err)
+	}
 
-		if def.Name == "" || def.Command == "" {
-			fmt.Fprintf(os.Stderr, "Warning: invalid plugin %s (name and command required)\n", entry.Name())
-			continue
-		}
+	negotiated, err := plugin.Handshake(ctx, def)
+	if err != nil {
+		return nil, err
+	}
 
-		if def.TimeoutSeconds <= 0 {
-			def.TimeoutSeconds = 10
+	proxyEnv := checker.ProxyEnv(proxy)
+	env := make(map[string]string, len(def.Env)+len(configEnv)+len(proxyEnv)+2)
+	for _, extra := range []map[string]string{def.Env, configEnv, proxyEnv, negotiated.InvocationEnv()} {
+		for k, v := range extra {
+			env[k] = v
 		}
+	}
 
-		if def.ResultsFilename == "" {
-			def.ResultsFilename = fmt.Sprintf("%s_results.json", def.Name)
-		}
+	return checker.NewExternalChecker(checker.ExternalCheckerConfig{
+		Name:           def.Name,
+		Command:        def.Command,
+		Args:           def.Args,
+		Env:            env,
+		TimeoutSeconds: def.TimeoutSeconds,
+		ValidateOutput: plugin.ValidateCheckResult,
+	}), nil
+}
 
-		defs = append(defs, def)
-	}
+func addPluginCommand(def *plugin.Manifest) error {
+	return addCheckerCommand(checkerCommandSpec{
+		Name:        def.Name,
+		Description: def.Description,
+		Kind:        "plugin",
+		Timeout:     time.Duration(def.TimeoutSeconds) * time.Second,
+		NewChecker: func(ctx context.Context, proxy *url.URL) (checker.Checker, error) {
+			return newPluginChecker(ctx, def, proxy)
+		},
+	})
+}
 
-	return defs, nil
+// checkerCommandSpec describes a "seca check <name>" subcommand backed by a
+// plugin or a checker compiled in through pkg/checkersdk.
+type checkerCommandSpec struct {
+	Name        string
+	Description string
+	Kind        string // "plugin" or "check"; used in output and the audit trail
+	Timeout     time.Duration
+	// NewChecker builds the checker for one run; proxy is the engagement's
+	// upstream proxy, or nil for direct connections.
+	NewChecker func(ctx context.Context, proxy *url.URL) (checker.Checker, error)
 }
 
-func addPluginCommand(def checkerPluginDefinition) error {
+func addCheckerCommand(spec checkerCommandSpec) error {
+	for _, existing := range checkCmd.Commands() {
+		if existing.Name() == spec.Name {
+			return fmt.Errorf("check %s already exists", spec.Name)
+		}
+	}
+	label := strings.ToUpper(spec.Kind[:1]) + spec.Kind[1:]
+
 	cmd := &cobra.Command{
-		Use:   def.Name,
-		Short: def.Description,
-		RunE: func(c *cobra.Command, args []string) error {
+		Use:   spec.Name,
+		Short: spec.Description,
+		RunE: func(c *cobra.Command, args []string) (err error) {
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
@@ -148,6 +172,10 @@ func addPluginCommand(def checkerPluginDefinition) error {
 				return errors.New("must pass --roe-confirm to run checks")
 			}
 
+			if err := validateCheckConfig(engagementID, runtimeCfg); err != nil {
+				return err
+			}
+
 			eng, err := appCtx.Services.EngagementService.GetEngagement(ctx, engagementID)
 			if err != nil {
 				if errors.Is(err, sharedErrors.ErrEngagementNotFound) {
@@ -156,28 +184,61 @@ func addPluginCommand(def checkerPluginDefinition) error {
 				return fmt.Errorf("failed to get engagement: %w", err)
 			}
 
+			if err := checkWritable(eng); err != nil {
+				return err
+			}
+
 			if err := appCtx.Services.EngagementService.ValidateEngagementForChecks(ctx, engagementID, ""); err != nil {
 				return fmt.Errorf("engagement validation failed: %w", err)
 			}
 
+			lock, err := acquireEngagementLock(ctx, appCtx.ResultsDir, engagementID, fmt.Sprintf("%s %s", spec.Kind, spec.Name), appCtx.Operator, time.Duration(runtimeCfg.LockWaitSecs)*time.Second)
+			if err != nil {
+				return err
+			}
+			defer lock.Release()
+			defer flushAuditEntries(appCtx, engagementID, &err)
+
 			checkRun, err := appCtx.Services.CheckOrchestrator.CreateCheckRun(ctx, engagementID, appCtx.Operator)
 			if err != nil {
 				return fmt.Errorf("failed to create check run: %w", err)
 			}
+			checkRun.SetCheckType(spec.Name)
+			enableRunSnapshots(appCtx, checkRun, runtimeCfg)
+
+			headers, err := resolveRequestHeaders(engagementID, runtimeCfg.Request)
+			if err != nil {
+				return err
+			}
+			proxy, err := resolveProxy(engagementID, runtimeCfg.Request)
+			if err != nil {
+				return err
+			}
+
+			var crawlScope *checker.CrawlScope
+			if runtimeCfg.Crawl.Enabled {
+				crawlScope, err = resolveCrawlScope(runtimeCfg.Crawl, eng.Scope())
+				if err != nil {
+					return err
+				}
+			}
 
-			fmt.Printf("%s Starting plugin %s for engagement: %s\n", colorInfo("→"), def.Name, eng.Name())
+			selectedChecker, err := spec.NewChecker(ctx, proxy)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("%s Starting %s %s for engagement: %s\n", colorInfo("→"), spec.Kind, spec.Name, eng.Name())
 			fmt.Printf("%s Targets: %d\n", colorInfo("→"), len(eng.Scope()))
+			printProxy(proxy)
+			budget, storedBudget, err := openRunBudget(appCtx.ResultsDir, engagementID)
+			if err != nil {
+				return err
+			}
+			printRunBudget(storedBudget)
 			fmt.Println()
 
-			externalChecker := checker.NewExternalChecker(checker.ExternalCheckerConfig{
-				Name:           def.Name,
-				Command:        def.Command,
-				Args:           def.Args,
-				Env:            def.Env,
-				TimeoutSeconds: def.TimeoutSeconds,
-			})
-
-			timeout := time.Duration(def.TimeoutSeconds) * time.Second
+			timeout := spec.Timeout
 			if timeout <= 0 {
 				timeout = time.Duration(runtimeCfg.TimeoutSecs) * time.Second
 			}
@@ -186,14 +247,28 @@ func addPluginCommand(def checkerPluginDefinition) error {
 				Concurrency: runtimeCfg.Concurrency,
 				RateLimit:   runtimeCfg.RateLimit,
 				Timeout:     timeout,
+				Budget:      budget,
+			}
+
+			crawlCache, err := openCrawlCache(appCtx.ResultsDir, engagementID, runtimeCfg.Crawl)
+			if err != nil {
+				return err
 			}
 
 			baseTargets := append([]string(nil), eng.Scope()...)
-			targets := expandTargetsWithCrawl(ctx, baseTargets, runtimeCfg)
+			targets, surface := expandTargetsWithCrawl(ctx, baseTargets, crawlExpandOptions{
+				Config:  runtimeCfg,
+				Scope:   crawlScope,
+				Headers: headers,
+				Proxy:   proxy,
+				Budget:  budget,
+				Cache:   crawlCache,
+			})
+			recordAttackSurface(appCtx.ResultsDir, engagementID, surface)
 
 			var progress *progressPrinter
 			if runtimeCfg.ProgressEnabled {
-				progress = newProgressPrinter(len(targets), externalChecker.Name())
+				progress = newProgressPrinter(len(targets), selectedChecker.Name())
 				progress.Start()
 			}
 
@@ -204,11 +279,11 @@ func addPluginCommand(def checkerPluginDefinition) error {
 					Timestamp:       time.Now(),
 					EngagementID:    engagementID,
 					Operator:        appCtx.Operator,
-					Command:         fmt.Sprintf("plugin %s", def.Name),
+					Command:         fmt.Sprintf("%s %s", spec.Kind, spec.Name),
 					Target:          target,
 					Status:          checkerResult.Status,
 					HTTPStatus:      checkerResult.HTTPStatus,
-					Notes:           checkerResult.Notes,
+					Notes:           withAuditNote(checkerResult.Notes, crawlAuditNote(runtimeCfg), crawlScopeAuditNote(crawlScope), proxyAuditNote(proxy)),
 					Error:           checkerResult.Error,
 					DurationSeconds: duration,
 				}
@@ -233,42 +308,44 @@ func addPluginCommand(def checkerPluginDefinition) error {
 				}
 
 				if progress != nil {
-					progress.Increment(checkerResult.Status == "ok", duration)
+					progress.Increment(checkerResult.Succeeded(), duration)
 				}
 
 				return nil
 			}
 
-			results := runner.RunChecks(ctx, targets, externalChecker, auditFn)
+			results := runner.RunChecks(ctx, targets, selectedChecker, auditFn)
 
 			if progress != nil {
 				progress.Stop()
 			}
+			recordRunEvidence(appCtx.ResultsDir, engagementID, results, nil)
 
 			runDuration := time.Since(startTime)
 			if runtimeCfg.TelemetryEnabled {
-				if err := recordTelemetry(appCtx, engagementID, externalChecker.Name(), results, runDuration); err != nil {
-					fmt.Fprintf(os.Stderr, "Warning: failed to record telemetry: %v\n", err)
+				if err := recordTelemetry(appCtx, engagementID, selectedChecker.Name(), results, runDuration); err != nil {
+					cliLog().Warnw("telemetry_record_failed", "engagement_id", engagementID, "error", err)
+				} else {
+					notifyTelemetryRegressions(appCtx, engagementID, selectedChecker.Name())
 				}
 			}
-
-			fmt.Printf("\n%s Plugin %s run complete (%d target(s))\n", colorSuccess("✓"), def.Name, len(results))
-
-			hashAlgo := runtimeCfg.HashAlgorithm
-			if hashAlgo == "" {
-				hashAlgo = "sha256"
+			trackBaselineDeviations(appCtx.ResultsDir, engagementID, results)
+			exportTelemetry(c, engagementID, selectedChecker.Name(), results, startTime, runDuration)
+
+			fmt.Printf("\n%s %s %s run complete (%d target(s))\n", colorSuccess("✓"), label, spec.Name, countStatuses(results).Checked())
+			stopReason := finishBudgetedRun(budget, engagementID, countStatuses(results).Checked(), len(targets))
+			if stopReason != "" {
+				checkRun.SetStopReason(stopReason)
+			} else if ctx.Err() != nil {
+				stopReason = "interrupted"
 			}
 
-			auditHash, err := appCtx.Services.CheckOrchestrator.SealAuditTrail(ctx, engagementID, hashAlgo)
+			hashAlgo, auditHash, err := sealCheckRun(ctx, appCtx, engagementID, checkRun, runtimeCfg.HashAlgorithm)
 			if err != nil {
-				return fmt.Errorf("failed to seal audit trail: %w", err)
-			}
-
-			if err := appCtx.Services.CheckOrchestrator.FinalizeCheckRun(ctx, checkRun, auditHash, hashAlgo); err != nil {
-				return fmt.Errorf("failed to finalize check run: %w", err)
+				return err
 			}
 
-			resultsPath := filepath.Join(appCtx.ResultsDir, engagementID, "http_results.json")
+			resultsPath := filepath.Join(appCtx.ResultsDir, engagementID, check.ResultsFilename(checkRun.Metadata().CheckType))
 			auditPath := filepath.Join(appCtx.ResultsDir, engagementID, "audit.csv")
 
 			fmt.Println()
@@ -276,7 +353,16 @@ func addPluginCommand(def checkerPluginDefinition) error {
 			fmt.Printf("%s Audit: %s\n", colorSuccess("→"), auditPath)
 			fmt.Printf("%s Audit hash (%s): %s\n", colorSuccess("→"), hashAlgo, auditHash)
 
-			return nil
+			return finishCheckRun(c, appCtx.ResultsDir, runOutcome{
+				EngagementID:  engagementID,
+				Check:         selectedChecker.Name(),
+				Results:       results,
+				StartedAt:     startTime,
+				StopReason:    stopReason,
+				HashAlgorithm: hashAlgo,
+				AuditHash:     auditHash,
+				ResultsFile:   resultsPath,
+			})
 		},
 	}
 
@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+// newHealthCheck returns the reachability pre-check for a run, or nil when
+// it is disabled. Behind a proxy the proxy resolves and connects to
+// targets, so a direct pre-check would test the wrong path and is skipped.
+func newHealthCheck(runtimeCfg CheckRuntimeConfig, phases checker.PhaseTimeouts, dialer *checker.Dialer, proxy *url.URL) *checker.HealthCheck {
+	if !runtimeCfg.PreCheck || proxy != nil {
+		return nil
+	}
+	timeout := phases.WithDefault(time.Duration(runtimeCfg.TimeoutSecs) * time.Second).Dial
+	return &checker.HealthCheck{Timeout: timeout, Dialer: dialer}
+}
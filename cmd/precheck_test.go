@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func TestNewHealthCheck(t *testing.T) {
+	phases := checker.PhaseTimeouts{Dial: 3 * time.Second}
+	if h := newHealthCheck(CheckRuntimeConfig{PreCheck: true}, phases, nil, nil); h == nil || h.Timeout != 3*time.Second {
+		t.Errorf("expected a pre-check limited by the dial timeout, got %+v", h)
+	}
+	if h := newHealthCheck(CheckRuntimeConfig{PreCheck: true, TimeoutSecs: 7}, checker.PhaseTimeouts{}, nil, nil); h == nil || h.Timeout != 7*time.Second {
+		t.Errorf("expected a pre-check limited by --timeout without --dial-timeout, got %+v", h)
+	}
+	if h := newHealthCheck(CheckRuntimeConfig{PreCheck: false}, phases, nil, nil); h != nil {
+		t.Error("--precheck=false should disable the pre-check")
+	}
+	proxy, _ := url.Parse("http://127.0.0.1:8080")
+	if h := newHealthCheck(CheckRuntimeConfig{PreCheck: true}, phases, nil, proxy); h != nil {
+		t.Error("the pre-check should be skipped behind a proxy")
+	}
+}
+
+func TestReportSeparatesUnreachableTargets(t *testing.T) {
+	output := &RunOutput{
+		Metadata: RunMetadata{EngagementID: "eng-down", StartAt: time.Now(), CompleteAt: time.Now()},
+		Results: []checker.CheckResult{
+			{Target: "https://up.example.com", Status: checker.StatusOK},
+			{Target: "https://broken.example.com", Status: checker.StatusError, Error: "tls: handshake failure"},
+			{Target: "https://down.example.com", Status: checker.StatusUnreachable, Error: "DNS lookup of down.example.com failed: no such host"},
+		},
+	}
+	data := buildTemplateData(output, nil, "%.2f", nil)
+	if data.SuccessCount != 1 || data.ErrorCount != 1 || data.UnreachableCount != 1 {
+		t.Fatalf("unexpected counts: ok %d, error %d, unreachable %d", data.SuccessCount, data.ErrorCount, data.UnreachableCount)
+	}
+	markdown, err := generateMarkdownReport(data)
+	if err != nil {
+		t.Fatalf("Failed to generate markdown report: %v", err)
+	}
+	if !strings.Contains(markdown, "- **Unreachable:** 1") {
+		t.Errorf("markdown summary should list unreachable targets:\n%s", markdown)
+	}
+
+	summary := newRunSummary(t.TempDir(), runOutcome{EngagementID: "eng-down", Results: output.Results, StartedAt: time.Now()})
+	summary.applyExitPolicy(failOnNone, true)
+	if summary.Failed != 2 || summary.Unreachable != 1 || !strings.Contains(summary.ExitReason, "(1 unreachable)") {
+		t.Errorf("unexpected run summary: %+v", summary)
+	}
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/spf13/viper"
+)
+
+// proxyDirect disables a proxy configured at a lower precedence level.
+const proxyDirect = "direct"
+
+// resolveProxy returns the upstream proxy for an engagement, or nil for direct
+// connections. Precedence: --proxy, http.engagements.<id>.proxy, http.proxy.
+func resolveProxy(engagementID string, reqCfg RequestConfig) (*url.URL, error) {
+	raw, source := strings.TrimSpace(reqCfg.Proxy), "--proxy"
+	if raw == "" && engagementID != "" {
+		raw, source = strings.TrimSpace(viper.GetString("http.engagements."+engagementID+".proxy")), "http.engagements."+engagementID+".proxy"
+	}
+	if raw == "" {
+		raw, source = strings.TrimSpace(viper.GetString("http.proxy")), "http.proxy"
+	}
+	if raw == "" || strings.EqualFold(raw, proxyDirect) {
+		return nil, nil
+	}
+	proxy, err := checker.ParseProxyURL(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	return proxy, nil
+}
+
+// proxyAuditNote records in the audit trail that traffic went through a proxy.
+// Proxy credentials are masked.
+func proxyAuditNote(proxy *url.URL) string {
+	if proxy == nil {
+		return ""
+	}
+	return "via proxy " + proxy.Redacted()
+}
+
+// printProxy reports the upstream proxy, if any, with credentials masked.
+func printProxy(proxy *url.URL) {
+	if proxy != nil {
+		fmt.Printf("%s Proxy: %s\n", colorInfo("→"), proxy.Redacted())
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"html/template"
+	"io"
+	"iter"
 	"math"
 	"os"
 	"sort"
@@ -17,6 +19,8 @@ import (
 	"github.com/jung-kurt/gofpdf"
 	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
 	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+	"github.com/khanhnv2901/seca-cli/internal/shared/i18n"
 	"github.com/spf13/cobra"
 )
 
@@ -52,10 +56,15 @@ var (
 		"join":                strings.Join,
 		"headersPresentCount": headersPresentCount,
 		"formatTime":          formatShortTimestamp,
+		"formatDate":          formatDate,
 		"formatDuration":      formatDurationLabel,
 		"formatSuccess":       formatSuccessRate,
 		"lower":               strings.ToLower,
+		"upper":               strings.ToUpper,
 		"riskBadgeClass":      riskBadgeClass,
+		"guide":               findingGuide,
+		"evidenceImage":       evidenceIsImage,
+		"displayTarget":       checker.DisplayTarget,
 	}
 
 	markdownTemplateFuncs = template.FuncMap{
@@ -66,15 +75,20 @@ var (
 		"mediumSeverityMissing":  missingMediumSeverityHeaders,
 		"hasHighSeverityMissing": hasCriticalMissingHeaders,
 		"formatTime":             formatShortTimestamp,
+		"formatDate":             formatDate,
 		"formatDuration":         formatDurationLabel,
 		"formatSuccess":          formatSuccessRate,
+		"upper":                  strings.ToUpper,
+		"verbatim":               verbatimMarkdown,
+		"displayTarget":          checker.DisplayTarget,
+		"enumerate":              enumerateResults,
 	}
 
 	htmlReportTemplate = template.Must(
-		template.New("report.html").Funcs(htmlTemplateFuncs).ParseFS(reportTemplateFS, htmlTemplatePath),
+		template.New("report.html").Funcs(htmlTemplateFuncs).Funcs(localizedFuncs(i18n.Default())).ParseFS(reportTemplateFS, htmlTemplatePath),
 	)
 	markdownReportTemplate = template.Must(
-		template.New("report.md").Funcs(markdownTemplateFuncs).ParseFS(reportTemplateFS, markdownTemplatePath),
+		template.New("report.md").Funcs(markdownTemplateFuncs).Funcs(localizedFuncs(i18n.Default())).ParseFS(reportTemplateFS, markdownTemplatePath),
 	)
 )
 
@@ -96,87 +110,261 @@ var reportGenerateCmd = &cobra.Command{
 		if id == "" {
 			return fmt.Errorf("--id is required")
 		}
-
-		// Validate format
-		format = strings.ToLower(format)
-		if format != "json" && format != "md" && format != "html" && format != "pdf" {
-			return fmt.Errorf("invalid format: %s (must be json, md, html, or pdf)", format)
-		}
-
-		output, sources, err := loadAggregatedRunOutput(appCtx.ResultsDir, id)
+		catalog, err := reportLanguage(cmd)
 		if err != nil {
 			return err
 		}
-		normalizeRunMetadata(&output.Metadata)
-
-		// Generate report based on format
-		var reportContent string
-		var filename string
-
-		trendHistory, histErr := loadTelemetryHistory(appCtx.ResultsDir, output.Metadata.EngagementID, 8)
-		if histErr != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to load telemetry history: %v\n", histErr)
-		}
-
-		switch format {
-		case "json":
-			reportContent, err = generateJSONReport(output)
-			filename = "report.json"
-		case "md":
-			data := buildTemplateData(output, sources, "%.2f", trendHistory)
-			reportContent, err = generateMarkdownReport(data)
-			filename = "report.md"
-		case "html":
-			data := buildTemplateData(output, sources, "%.1f", trendHistory)
-			reportContent, err = generateHTMLReport(data)
-			filename = "report.html"
-		case "pdf":
-			data := buildTemplateData(output, sources, "%.1f", trendHistory)
-			pdfBytes, perr := generatePDFReportBytes(data)
-			if perr != nil {
-				return fmt.Errorf("failed to generate PDF report: %w", perr)
-			}
-			filename = "report.pdf"
-			reportPath, err := resolveResultsPath(appCtx.ResultsDir, id, filename)
-			if err != nil {
-				return fmt.Errorf("resolve report path: %w", err)
-			}
-			if err := os.WriteFile(reportPath, pdfBytes, consts.DefaultFilePerm); err != nil {
-				return fmt.Errorf("failed to write report: %w", err)
-			}
-			fmt.Printf("Report generated: %s\n", reportPath)
-			fmt.Printf("Format: %s\n", format)
-			fmt.Printf("Total targets: %d\n", output.Metadata.TotalTargets)
-			if len(sources) > 0 {
-				fmt.Printf("Result files included: %s\n", strings.Join(sources, ", "))
-			}
-			return nil
-		}
 
+		rendered, err := loadEngagementReport(appCtx.ResultsDir, id, format)
 		if err != nil {
-			return fmt.Errorf("failed to generate report: %w", err)
+			return err
+		}
+		rendered.Catalog = catalog
+		warnUntranslatedPDF(rendered.Format, catalog)
+		if includeBaseline, _ := cmd.Flags().GetBool("include-baseline"); includeBaseline && rendered.Output.Baseline != nil {
+			rendered.Output.Baseline.IncludeAccepted = true
 		}
 
-		// Write report to file
-		reportPath, err := resolveResultsPath(appCtx.ResultsDir, id, filename)
+		// Render straight into the report file
+		reportPath, err := resolveResultsPath(appCtx.ResultsDir, id, rendered.Filename)
 		if err != nil {
 			return fmt.Errorf("resolve report path: %w", err)
 		}
-		if err := os.WriteFile(reportPath, []byte(reportContent), consts.DefaultFilePerm); err != nil {
+		if err := fileutil.WriteWith(reportPath, consts.DefaultFilePerm, rendered.Render); err != nil {
 			return fmt.Errorf("failed to write report: %w", err)
 		}
 
 		fmt.Printf("Report generated: %s\n", reportPath)
-		fmt.Printf("Format: %s\n", format)
-		fmt.Printf("Total targets: %d\n", output.Metadata.TotalTargets)
-		if len(sources) > 0 {
-			fmt.Printf("Result files included: %s\n", strings.Join(sources, ", "))
+		fmt.Printf("Format: %s\n", rendered.Format)
+		fmt.Printf("Total targets: %d\n", rendered.Output.Metadata.TotalTargets)
+		if len(rendered.Sources) > 0 {
+			fmt.Printf("Result files included: %s\n", strings.Join(rendered.Sources, ", "))
+		}
+		if b := rendered.Output.Baseline; b != nil {
+			fmt.Printf("Baseline: %d new, %d resolved, %d accepted\n", len(b.New), len(b.Resolved), b.Accepted)
 		}
 
 		return nil
 	},
 }
 
+// renderedReport is a loaded report, ready to be rendered, written or
+// delivered.
+type renderedReport struct {
+	Format   string
+	Filename string
+	// Content is the rendered report, set by renderEngagementReport.
+	Content []byte
+	Output  *RunOutput
+	Sources []string
+	// Catalog translates the md and html output; nil renders English.
+	Catalog *i18n.Catalog
+
+	trendHistory []TelemetryRecord
+	// evidenceTexts holds the small text artifacts embedded in HTML reports.
+	evidenceTexts map[string]string
+}
+
+// renderEngagementReport loads all result files for an engagement and renders
+// them in the requested format (json, md, html, or pdf) into memory.
+func renderEngagementReport(resultsDir, id, format string, catalog *i18n.Catalog) (*renderedReport, error) {
+	rendered, err := loadEngagementReport(resultsDir, id, format)
+	if err != nil {
+		return nil, err
+	}
+	rendered.Catalog = catalog
+	var buf bytes.Buffer
+	if err := rendered.Render(&buf); err != nil {
+		return nil, err
+	}
+	rendered.Content = buf.Bytes()
+	return rendered, nil
+}
+
+// loadEngagementReport loads all result files and report data for an
+// engagement without rendering it.
+func loadEngagementReport(resultsDir, id, format string) (*renderedReport, error) {
+	format = strings.ToLower(format)
+	if format != "json" && format != "md" && format != "html" && format != "pdf" {
+		return nil, fmt.Errorf("invalid format: %s (must be json, md, html, or pdf)", format)
+	}
+
+	// Results stay on disk and are read back by each part of the report
+	// that needs them.
+	output, resultSources, err := streamRunOutputs(resultsDir, id)
+	if err != nil {
+		return nil, err
+	}
+	sources := resultSourceNames(resultSources)
+	normalizeRunMetadata(&output.Metadata)
+
+	surface, err := loadAttackSurface(resultsDir, id)
+	if err != nil {
+		cliLog().Warnw("attack_surface_load_failed", "engagement_id", id, "error", err)
+	} else if !surface.Empty() {
+		output.AttackSurface = surface
+	}
+
+	manual, err := loadManualFindings(resultsDir, id)
+	if err != nil {
+		cliLog().Warnw("manual_findings_load_failed", "engagement_id", id, "error", err)
+	} else {
+		output.ManualFindings = manual
+	}
+
+	pins, err := loadKeyPinStore(resultsDir, id)
+	if err != nil {
+		cliLog().Warnw("key_pins_load_failed", "engagement_id", id, "error", err)
+	} else {
+		output.KeyChanges = pins.Changes
+	}
+
+	contents, err := loadContentStore(resultsDir, id)
+	if err != nil {
+		cliLog().Warnw("content_hashes_load_failed", "engagement_id", id, "error", err)
+	} else {
+		output.ContentChanges = contents.Changes
+	}
+
+	expectations, err := loadCertExpectations(resultsDir, id)
+	if err != nil {
+		cliLog().Warnw("expected_certs_load_failed", "engagement_id", id, "error", err)
+	} else {
+		output.CertDeviations = expectations.Deviations
+	}
+
+	registrations, err := loadDomainRegistrations(resultsDir, id)
+	if err != nil {
+		cliLog().Warnw("domain_registrations_load_failed", "engagement_id", id, "error", err)
+	} else {
+		output.DomainRegistrations = registrations
+	}
+
+	hosting, err := loadHostingInventory(resultsDir, id)
+	if err != nil {
+		cliLog().Warnw("hosting_load_failed", "engagement_id", id, "error", err)
+	} else {
+		output.Hosting = hosting
+	}
+
+	paths, err := loadNetworkPaths(resultsDir, id)
+	if err != nil {
+		cliLog().Warnw("network_paths_load_failed", "engagement_id", id, "error", err)
+	} else {
+		output.NetworkPaths = paths
+	}
+
+	screenshots, err := loadScreenshotIndex(resultsDir, id)
+	if err != nil {
+		cliLog().Warnw("screenshot_index_load_failed", "engagement_id", id, "error", err)
+	} else {
+		output.Screenshots = screenshots
+	}
+
+	throttling, err := loadThrottledRuns(resultsDir, id)
+	if err != nil {
+		cliLog().Warnw("throttling_load_failed", "engagement_id", id, "error", err)
+	} else {
+		output.Throttling = throttling
+	}
+
+	evidence, err := engagementEvidence(resultsDir, id)
+	if err != nil {
+		cliLog().Warnw("evidence_load_failed", "engagement_id", id, "error", err)
+	} else {
+		output.Evidence = evidence
+	}
+
+	baseline, err := loadFindingsBaseline(resultsDir, id)
+	if err != nil {
+		cliLog().Warnw("baseline_load_failed", "engagement_id", id, "error", err)
+	} else if baseline != nil {
+		output.Baseline = baseline.diff(id, engagementVulnerabilityReport(output, "", "", "").Vulnerabilities)
+	}
+
+	classification, err := loadClassification(resultsDir, id)
+	if err != nil {
+		cliLog().Warnw("classification_load_failed", "engagement_id", id, "error", err)
+	} else if classification != nil {
+		output.Classification = classification.Label
+	}
+
+	notes, err := loadEngagementNotes(resultsDir, id)
+	if err != nil {
+		cliLog().Warnw("notes_load_failed", "engagement_id", id, "error", err)
+	} else {
+		output.Notes = notes
+	}
+
+	names, err := loadTargetNames(resultsDir, id)
+	if err != nil {
+		cliLog().Warnw("target_names_load_failed", "engagement_id", id, "error", err)
+	} else {
+		output.TargetNames = names
+	}
+
+	revision, err := draftRevision(resultsDir, id)
+	if err != nil {
+		cliLog().Warnw("revision_load_failed", "engagement_id", id, "error", err)
+	} else {
+		output.Revision = revision
+	}
+
+	if err := output.resultsErr(); err != nil {
+		return nil, fmt.Errorf("failed to read results: %w", err)
+	}
+
+	trendHistory, histErr := loadTelemetryHistory(resultsDir, output.Metadata.EngagementID, 8)
+	if histErr != nil {
+		cliLog().Warnw("telemetry_history_load_failed", "engagement_id", id, "error", histErr)
+	}
+
+	return &renderedReport{
+		Format:        format,
+		Filename:      "report." + format,
+		Output:        output,
+		Sources:       sources,
+		trendHistory:  trendHistory,
+		evidenceTexts: evidenceTexts(resultsDir, id, output.Evidence),
+	}, nil
+}
+
+// Render writes the report to w in its format. Templates and the PDF are
+// rendered straight into w rather than into an intermediate string.
+func (r *renderedReport) Render(w io.Writer) error {
+	var err error
+	switch r.Format {
+	case "json":
+		err = writeRunOutputJSON(w, r.Output)
+	case "md":
+		err = executeTemplateTo(w, markdownReportTemplate, r.templateData("%.2f"))
+	case "html":
+		err = executeTemplateTo(w, htmlReportTemplate, r.templateData("%.1f"))
+	case "pdf":
+		if err := writePDFReport(w, buildTemplateData(r.Output, r.Sources, "%.1f", r.trendHistory)); err != nil {
+			return fmt.Errorf("failed to generate PDF report: %w", err)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to generate report: %w", err)
+	}
+	if err := r.Output.resultsErr(); err != nil {
+		return fmt.Errorf("failed to read results: %w", err)
+	}
+	return nil
+}
+
+// templateData builds the template data in the report's language.
+func (r *renderedReport) templateData(successRateFmt string) TemplateData {
+	data := buildTemplateData(r.Output, r.Sources, successRateFmt, r.trendHistory)
+	data.EvidenceTexts = r.evidenceTexts
+	if r.Catalog != nil {
+		data.Lang = r.Catalog.Language()
+		data.catalog = r.Catalog
+	}
+	return data
+}
+
 func generateJSONReport(output *RunOutput) (string, error) {
 	data, err := json.MarshalIndent(output, jsonPrefix, jsonIndent)
 	if err != nil {
@@ -226,7 +414,71 @@ func discoverResultFiles(resultsDir, engagementID string) ([]string, error) {
 	return ordered, nil
 }
 
+// resultSource is a results file that contributed to an aggregated run and
+// how many results it added. Results keep file order, so each source's
+// results are a contiguous run of the aggregate.
+type resultSource struct {
+	Name    string
+	Results int
+
+	path string
+}
+
+// Checker returns the check type that wrote the file, e.g. "dns" for
+// dns_results.json.
+func (s resultSource) Checker() string {
+	return strings.TrimSuffix(s.Name, "_results.json")
+}
+
 func loadAggregatedRunOutput(resultsDir, engagementID string) (*RunOutput, []string, error) {
+	output, sources, err := aggregateRunOutputs(resultsDir, engagementID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return output, resultSourceNames(sources), nil
+}
+
+func resultSourceNames(sources []resultSource) []string {
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		names[i] = source.Name
+	}
+	return names
+}
+
+// aggregateRunOutputs merges every results file of an engagement into one
+// RunOutput.
+func aggregateRunOutputs(resultsDir, engagementID string) (*RunOutput, []resultSource, error) {
+	var results []checker.CheckResult
+	aggregated, sources, err := scanRunOutputs(resultsDir, engagementID, func(r checker.CheckResult) {
+		results = append(results, r)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	aggregated.Results = results
+	return aggregated, sources, nil
+}
+
+// streamRunOutputs is aggregateRunOutputs without holding the results: they
+// are only counted, and read back from the results files each time the
+// output's allResults is ranged over.
+func streamRunOutputs(resultsDir, engagementID string) (*RunOutput, []resultSource, error) {
+	aggregated, sources, err := scanRunOutputs(resultsDir, engagementID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	stream := &resultStream{}
+	for _, source := range sources {
+		stream.paths = append(stream.paths, source.path)
+	}
+	aggregated.stream = stream
+	return aggregated, sources, nil
+}
+
+// scanRunOutputs reads every results file of an engagement once, merging
+// their metadata and passing each result to add when it is set.
+func scanRunOutputs(resultsDir, engagementID string, add func(checker.CheckResult)) (*RunOutput, []resultSource, error) {
 	files, err := discoverResultFiles(resultsDir, engagementID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("discover result files: %w", err)
@@ -236,9 +488,10 @@ func loadAggregatedRunOutput(resultsDir, engagementID string) (*RunOutput, []str
 	}
 
 	var aggregated *RunOutput
+	var total int
 	var earliestStart time.Time
 	var latestComplete time.Time
-	sourcesUsed := make([]string, 0, len(files))
+	sourcesUsed := make([]resultSource, 0, len(files))
 
 	for _, name := range files {
 		path, err := resolveResultsPath(resultsDir, engagementID, name)
@@ -246,7 +499,7 @@ func loadAggregatedRunOutput(resultsDir, engagementID string) (*RunOutput, []str
 			return nil, nil, fmt.Errorf("resolve results path for %s: %w", name, err)
 		}
 
-		data, err := os.ReadFile(path)
+		f, err := os.Open(path)
 		if err != nil {
 			if errors.Is(err, os.ErrNotExist) {
 				continue
@@ -254,27 +507,38 @@ func loadAggregatedRunOutput(resultsDir, engagementID string) (*RunOutput, []str
 			return nil, nil, fmt.Errorf("read %s: %w", path, err)
 		}
 
+		// Results are decoded one at a time and handed to add so large
+		// runs are never buffered whole.
 		var current RunOutput
-		if err := json.Unmarshal(data, &current); err != nil {
+		count := 0
+		err = decodeRunOutput(f, &current, func(r checker.CheckResult) bool {
+			count++
+			if add != nil {
+				add(r)
+			}
+			return true
+		})
+		f.Close()
+		if err != nil {
 			return nil, nil, fmt.Errorf("parse %s: %w", name, err)
 		}
-		if len(current.Results) == 0 {
+		if count == 0 {
 			continue
 		}
 
-		sourcesUsed = append(sourcesUsed, name)
+		total += count
+		sourcesUsed = append(sourcesUsed, resultSource{Name: name, Results: count, path: path})
 
 		if aggregated == nil {
-			aggregated = &RunOutput{
-				Metadata: current.Metadata,
-				Results:  append([]checker.CheckResult(nil), current.Results...),
-			}
+			aggregated = &RunOutput{SchemaVersion: current.SchemaVersion, Metadata: current.Metadata}
 			earliestStart = current.Metadata.StartAt
 			latestComplete = current.Metadata.CompleteAt
 			continue
 		}
 
-		aggregated.Results = append(aggregated.Results, current.Results...)
+		if aggregated.Metadata.StopReason == "" {
+			aggregated.Metadata.StopReason = current.Metadata.StopReason
+		}
 		if isEarlier(current.Metadata.StartAt, earliestStart) {
 			earliestStart = current.Metadata.StartAt
 		}
@@ -293,7 +557,7 @@ func loadAggregatedRunOutput(resultsDir, engagementID string) (*RunOutput, []str
 	if !latestComplete.IsZero() && latestComplete.After(aggregated.Metadata.CompleteAt) {
 		aggregated.Metadata.CompleteAt = latestComplete
 	}
-	aggregated.Metadata.TotalTargets = len(aggregated.Results)
+	aggregated.Metadata.TotalTargets = total
 
 	return aggregated, sourcesUsed, nil
 }
@@ -327,15 +591,19 @@ func generateMarkdownReport(data TemplateData) (string, error) {
 // TemplateData holds the data for HTML/PDF/Markdown template rendering
 type TemplateData struct {
 	Metadata           RunMetadata
-	Results            []checker.CheckResult
+	Results            iter.Seq[checker.CheckResult] // Read back from disk each time it is ranged over
 	ResultSources      []string
 	CheckCatalog       []SecurityCheckSpec
 	GeneratedAt        string
 	StartedAt          string
 	CompletedAt        string
 	Duration           string
-	SuccessCount       int
-	ErrorCount         int
+	SuccessCount       int // Targets whose checks ran, warnings included
+	WarningCount       int // Targets checked, but part of the check did not complete
+	ErrorCount         int // Targets whose checks failed; blocked and unreachable ones are counted apart
+	BlockedCount       int // Targets answered by a WAF block page
+	UnreachableCount   int // Targets that failed the reachability pre-check
+	SkippedCount       int // Targets the run never started
 	SuccessRate        string
 	FooterDate         string
 	TrendHistory       []TelemetryRecord
@@ -348,10 +616,53 @@ type TemplateData struct {
 	Status          string
 	Summary         checker.VulnerabilitySummary
 	Vulnerabilities []checker.Vulnerability
+	AttackSurface   *checker.AttackSurface
+	Screenshots     []ScreenshotRecord
+	// DomainRegistrations is RDAP data for the scoped domains.
+	DomainRegistrations []checker.DomainRegistration
+	// Hosting is the AS and country of each target's addresses.
+	Hosting *HostingInventory
+	// NetworkPaths is the route captured to each target.
+	NetworkPaths []NetworkPathRecord
+	// Technologies identifies the software behind each host.
+	Technologies []TechnologyEntry
+	// HeaderConsistency lists security headers set on only some pages of
+	// an origin.
+	HeaderConsistency []HostHeaderConsistency
+	// LoginForms is the assessment of the password forms found while
+	// crawling.
+	LoginForms []checker.LoginFormAssessment
+	// Charts are inline SVG charts for the HTML report.
+	Charts ReportCharts
+	// Baseline compares the findings with the accepted baseline, if one is set.
+	Baseline *BaselineDiff
+	// Remediation is the knowledge-base guidance for the open findings.
+	Remediation []RemediationEntry
+	// Throttled lists the hosts that rate limited or blocked a run, whose
+	// results may be incomplete.
+	Throttled []ThrottleCaveat
+	// Evidence lists the artifacts attached to each open finding, and
+	// EvidenceTexts the content of those small enough to embed, by file.
+	Evidence      []EvidenceEntry
+	EvidenceTexts map[string]string
+	// Revision is the deliverable revision and its sign-off, if any.
+	Revision *ReportRevision
+	// Classification is the handling label stamped on the deliverable.
+	Classification string
+	// Notes is the operator's narrative, if any.
+	Notes *EngagementNotes
+	// TargetNames label targets with their display names; displayTarget
+	// applies them in the templates.
+	TargetNames TargetNames
+	// Lang is the report language code; catalog translates headings,
+	// severities and recommendations.
+	Lang    string
+	catalog *i18n.Catalog
 }
 
 type reportStatsEntry struct {
 	Target     string `json:"target"`
+	Name       string `json:"name,omitempty"`
 	Status     string `json:"status"`
 	HTTPStatus int    `json:"http_status,omitempty"`
 	Notes      string `json:"notes,omitempty"`
@@ -359,12 +670,29 @@ type reportStatsEntry struct {
 }
 
 type reportStatsSummary struct {
-	EngagementID string             `json:"engagement_id"`
-	Total        int                `json:"total"`
-	Success      int                `json:"success"`
-	Fail         int                `json:"fail"`
-	TLSSoon      int                `json:"tls_expiring"`
-	Results      []reportStatsEntry `json:"results"`
+	EngagementID string                `json:"engagement_id"`
+	Total        int                   `json:"total"`
+	Success      int                   `json:"success"` // Warnings included
+	Fail         int                   `json:"fail"`    // Blocked and unreachable included
+	Warning      int                   `json:"warning,omitempty"`
+	Blocked      int                   `json:"blocked,omitempty"`
+	Unreachable  int                   `json:"unreachable,omitempty"`
+	Skipped      int                   `json:"skipped,omitempty"`
+	TLSSoon      int                   `json:"tls_expiring"`
+	Checkers     []reportStatsChecker  `json:"checkers,omitempty"`
+	Latency      *reportLatencySummary `json:"latency,omitempty"`
+	Results      []reportStatsEntry    `json:"results"`
+}
+
+// reportStatsChecker summarizes the results one checker contributed.
+type reportStatsChecker struct {
+	Checker     string  `json:"checker"`
+	Total       int     `json:"total"`
+	Success     int     `json:"success"`
+	Fail        int     `json:"fail"`
+	SuccessRate float64 `json:"success_rate"`
+	Issues      int     `json:"issues"`
+	OpenPorts   int     `json:"open_ports,omitempty"`
 }
 
 type TrendSummary struct {
@@ -376,6 +704,20 @@ func generateHTMLReport(data TemplateData) (string, error) {
 	return executeTemplate(htmlReportTemplate, data)
 }
 
+// enumerateResults numbers results from zero for templates, which cannot
+// count the iterations of a range.
+func enumerateResults(results iter.Seq[checker.CheckResult]) iter.Seq2[int, checker.CheckResult] {
+	return func(yield func(int, checker.CheckResult) bool) {
+		i := 0
+		for r := range results {
+			if !yield(i, r) {
+				return
+			}
+			i++
+		}
+	}
+}
+
 func addInts(a, b int) int {
 	return a + b
 }
@@ -471,8 +813,10 @@ func riskBadgeClass(risk string) string {
 	}
 }
 
-func generatePDFReportBytes(data TemplateData) ([]byte, error) {
+// writePDFReport renders data as a PDF into w.
+func writePDFReport(w io.Writer, data TemplateData) error {
 	pdf := gofpdf.New("P", "mm", "A4", "")
+	addPDFClassification(pdf, data.Classification)
 	pdf.AddPage()
 
 	// Title
@@ -489,16 +833,45 @@ func generatePDFReportBytes(data TemplateData) ([]byte, error) {
 	if len(data.ResultSources) > 0 {
 		pdf.CellFormat(0, 6, fmt.Sprintf("Result files: %s", strings.Join(data.ResultSources, ", ")), "", 1, "", false, 0, "")
 	}
+	if data.Revision != nil {
+		pdf.CellFormat(0, 6, data.Revision.Label(), "", 1, "", false, 0, "")
+	}
 	pdf.Ln(5)
 
 	// Summary section
 	pdf.SetFont("Arial", "B", 12)
 	pdf.CellFormat(0, 8, "Summary", "", 1, "", false, 0, "")
 	pdf.SetFont("Arial", "", 10)
-	pdf.CellFormat(0, 6, fmt.Sprintf("Success: %d | Errors: %d | Success Rate: %s",
-		data.SuccessCount, data.ErrorCount, data.SuccessRate), "", 1, "", false, 0, "")
+	summary := fmt.Sprintf("Success: %d | Errors: %d", data.SuccessCount, data.ErrorCount)
+	for _, extra := range []struct {
+		label string
+		n     int
+	}{
+		{"Warnings", data.WarningCount},
+		{"Blocked", data.BlockedCount},
+		{"Unreachable", data.UnreachableCount},
+		{"Skipped", data.SkippedCount},
+	} {
+		if extra.n > 0 {
+			summary += fmt.Sprintf(" | %s: %d", extra.label, extra.n)
+		}
+	}
+	summary += " | Success Rate: " + data.SuccessRate
+	pdf.CellFormat(0, 6, summary, "", 1, "", false, 0, "")
+	if data.Baseline != nil {
+		pdf.MultiCell(0, 6, data.Baseline.Summary(), "", "", false)
+	}
+	if len(data.Throttled) > 0 {
+		writeThrottlingPDF(pdf, data.Throttled)
+	}
 	pdf.Ln(5)
 
+	if data.Notes != nil {
+		writeNotesPDF(pdf, data.Notes)
+	}
+
+	writePDFCharts(pdf, data)
+
 	// Security check catalog
 	if len(data.CheckCatalog) > 0 {
 		pdf.SetFont("Arial", "B", 12)
@@ -533,13 +906,15 @@ func generatePDFReportBytes(data TemplateData) ([]byte, error) {
 	pdf.CellFormat(0, 8, "Detailed Security Analysis", "", 1, "", false, 0, "")
 	pdf.Ln(2)
 
+	// Results past the first maxResults are only counted.
 	maxResults := 50
-	for i, r := range data.Results {
-		if i == maxResults {
-			pdf.SetFont("Arial", "I", 9)
-			pdf.CellFormat(0, 6, fmt.Sprintf("... %d additional targets omitted ...", len(data.Results)-maxResults), "", 1, "", false, 0, "")
-			break
+	shown, omitted := 0, 0
+	for r := range data.Results {
+		if shown == maxResults {
+			omitted++
+			continue
 		}
+		shown++
 
 		// Check if we need a new page before adding content
 		if pdf.GetY() > 250 {
@@ -551,7 +926,11 @@ func generatePDFReportBytes(data TemplateData) ([]byte, error) {
 		// Target header with status
 		pdf.SetFont("Arial", "B", 11)
 		pdf.SetFillColor(240, 240, 240)
-		pdf.CellFormat(0, 7, fmt.Sprintf("%s - %s", r.Target, status), "", 1, "", true, 0, "")
+		target := r.Target
+		if name := data.TargetNames.Name(r.Target); name != "" {
+			target = name + " (" + r.Target + ")"
+		}
+		pdf.CellFormat(0, 7, fmt.Sprintf("%s - %s", target, status), "", 1, "", true, 0, "")
 		pdf.Ln(1)
 
 		// Basic information
@@ -628,6 +1007,14 @@ func generatePDFReportBytes(data TemplateData) ([]byte, error) {
 				pdf.MultiCell(0, 4, fmt.Sprintf("  - %s", issue), "", "", false)
 			}
 		}
+		if r.CachePolicy != nil && len(r.CachePolicy.Findings) > 0 {
+			pdf.SetFont("Arial", "B", 9)
+			pdf.CellFormat(0, 5, "Cache Deception/Poisoning:", "", 1, "", false, 0, "")
+			pdf.SetFont("Arial", "", 8)
+			for _, f := range r.CachePolicy.Findings {
+				pdf.MultiCell(0, 4, fmt.Sprintf("  - [%s] %s (%s)", f.Severity, f.Description, f.Evidence), "", "", false)
+			}
+		}
 
 		// Notes
 		notes := strings.TrimSpace(r.Notes)
@@ -638,25 +1025,113 @@ func generatePDFReportBytes(data TemplateData) ([]byte, error) {
 
 		pdf.Ln(3) // Gap between targets
 	}
+	if omitted > 0 {
+		pdf.SetFont("Arial", "I", 9)
+		pdf.CellFormat(0, 6, fmt.Sprintf("... %d additional targets omitted ...", omitted), "", 1, "", false, 0, "")
+	}
 
-	// Generate PDF bytes
-	var buf bytes.Buffer
-	err := pdf.Output(&buf)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	if !data.AttackSurface.Empty() {
+		writeAttackSurfacePDF(pdf, data.AttackSurface)
+	}
+	if len(data.LoginForms) > 0 {
+		writeLoginFormsPDF(pdf, data.LoginForms)
+	}
+	if len(data.HeaderConsistency) > 0 {
+		writeHeaderConsistencyPDF(pdf, data.HeaderConsistency)
+	}
+	if len(data.Technologies) > 0 {
+		writeTechnologyPDF(pdf, data.Technologies)
+	}
+	if len(data.DomainRegistrations) > 0 {
+		writeDomainRegistrationsPDF(pdf, data.DomainRegistrations)
+	}
+	if data.Hosting != nil {
+		writeHostingPDF(pdf, data.Hosting)
+	}
+	if len(data.NetworkPaths) > 0 {
+		writeNetworkPathsPDF(pdf, data.NetworkPaths)
+	}
+	if len(data.Remediation) > 0 {
+		writeRemediationPDF(pdf, data.Remediation)
+	}
+	if len(data.Evidence) > 0 {
+		writeEvidencePDF(pdf, data.Evidence)
 	}
+	if len(data.Screenshots) > 0 {
+		writeScreenshotsPDF(pdf, data.Screenshots)
+	}
+
+	if err := pdf.Output(w); err != nil {
+		return fmt.Errorf("failed to generate PDF: %w", err)
+	}
+	return nil
+}
 
-	return buf.Bytes(), nil
+// writeScreenshotsPDF lists captured screenshots with their SHA-256 so the
+// PNG files in the results directory can be verified against the report.
+func writeScreenshotsPDF(pdf *gofpdf.Fpdf, records []ScreenshotRecord) {
+	if pdf.GetY() > 240 {
+		pdf.AddPage()
+	}
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, "Screenshots", "", 1, "", false, 0, "")
+	for _, rec := range records {
+		pdf.SetFont("Arial", "B", 8)
+		pdf.MultiCell(0, 4, rec.URL, "", "", false)
+		pdf.SetFont("Arial", "", 7)
+		pdf.MultiCell(0, 4, fmt.Sprintf("  %s  SHA-256 %s  (%s)", rec.File, rec.SHA256, rec.CapturedAt.Format(time.RFC3339)), "", "", false)
+	}
+	pdf.Ln(3)
+}
+
+// writeAttackSurfacePDF lists the crawl's form and API endpoint inventory.
+func writeAttackSurfacePDF(pdf *gofpdf.Fpdf, surface *checker.AttackSurface) {
+	if pdf.GetY() > 240 {
+		pdf.AddPage()
+	}
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, "Attack Surface Inventory", "", 1, "", false, 0, "")
+	pdf.SetFont("Arial", "I", 8)
+	pdf.MultiCell(0, 4, "Recorded passively while crawling; no form was submitted and no endpoint was called.", "", "", false)
+	pdf.Ln(2)
+
+	if len(surface.Forms) > 0 {
+		pdf.SetFont("Arial", "B", 9)
+		pdf.CellFormat(0, 5, fmt.Sprintf("Forms (%d):", len(surface.Forms)), "", 1, "", false, 0, "")
+		pdf.SetFont("Arial", "", 8)
+		for _, f := range surface.Forms {
+			if pdf.GetY() > 270 {
+				pdf.AddPage()
+			}
+			csrf := "no CSRF token"
+			if f.HasCSRFToken {
+				csrf = "CSRF token"
+			}
+			pdf.MultiCell(0, 4, fmt.Sprintf("  - %s %s [%s] (%s)", f.Method, f.Action, strings.Join(f.Inputs, ", "), csrf), "", "", false)
+		}
+		pdf.Ln(2)
+	}
+
+	if len(surface.APIEndpoints) > 0 {
+		pdf.SetFont("Arial", "B", 9)
+		pdf.CellFormat(0, 5, fmt.Sprintf("API endpoints (%d):", len(surface.APIEndpoints)), "", 1, "", false, 0, "")
+		pdf.SetFont("Arial", "", 8)
+		for _, e := range surface.APIEndpoints {
+			if pdf.GetY() > 270 {
+				pdf.AddPage()
+			}
+			method := e.Method
+			if method == "" {
+				method = "?"
+			}
+			pdf.MultiCell(0, 4, fmt.Sprintf("  - %s %s (%s, from %s)", method, e.URL, e.Via, e.Source), "", "", false)
+		}
+	}
 }
 
 func buildTemplateData(output *RunOutput, sources []string, successRateFmt string, trends []TelemetryRecord) TemplateData {
 	normalizeRunMetadata(&output.Metadata)
-	okCount, errorCount := summarizeResults(output.Results)
-	total := len(output.Results)
-	successRate := 0.0
-	if total > 0 {
-		successRate = float64(okCount) / float64(total) * 100
-	}
+	counts := countStatusSeq(output.allResults())
 
 	now := time.Now()
 	duration := output.Metadata.CompleteAt.Sub(output.Metadata.StartAt)
@@ -671,46 +1146,78 @@ func buildTemplateData(output *RunOutput, sources []string, successRateFmt strin
 	}
 
 	durationLabel := duration.Round(time.Second).String()
-	vulnReport := checker.BuildVulnerabilityReport(
-		output.Results,
-		scanURL,
-		scanDate,
-		durationLabel,
-	)
+	vulnReport := engagementVulnerabilityReport(output, scanURL, scanDate, durationLabel)
+	if output.Baseline != nil && !output.Baseline.IncludeAccepted {
+		vulnReport = output.Baseline.deviations(output.Metadata.EngagementID, vulnReport)
+	}
 
-	return TemplateData{
-		Metadata:           output.Metadata,
-		Results:            output.Results,
-		ResultSources:      append([]string(nil), sources...),
-		CheckCatalog:       getSecurityCheckCatalog(),
-		GeneratedAt:        now.Format(time.RFC3339),
-		StartedAt:          output.Metadata.StartAt.Format(time.RFC3339),
-		CompletedAt:        output.Metadata.CompleteAt.Format(time.RFC3339),
-		Duration:           durationLabel,
-		SuccessCount:       okCount,
-		ErrorCount:         errorCount,
-		SuccessRate:        fmt.Sprintf(successRateFmt, successRate),
-		FooterDate:         now.Format("2006-01-02 15:04:05"),
-		TrendHistory:       trends,
-		TrendSummary:       summarizeTrendHistory(trends),
-		HashAlgorithmLabel: strings.ToUpper(output.Metadata.HashAlgorithm),
-		ScanDate:           scanDate,
-		ScanURL:            scanURL,
-		Status:             deriveRunStatus(okCount, errorCount, total),
-		Summary:            vulnReport.Summary,
-		Vulnerabilities:    vulnReport.Vulnerabilities,
-	}
-}
-
-func summarizeResults(results []checker.CheckResult) (okCount, errorCount int) {
-	for _, r := range results {
-		if r.Status == "ok" {
-			okCount++
-		} else {
-			errorCount++
-		}
-	}
-	return okCount, errorCount
+	status := deriveRunStatus(counts.Succeeded(), counts.Failed(), counts.Checked())
+	if output.Metadata.StopReason != "" {
+		status = "Stopped early: " + output.Metadata.StopReason
+	}
+
+	data := TemplateData{
+		Metadata:            output.Metadata,
+		Results:             output.allResults(),
+		ResultSources:       append([]string(nil), sources...),
+		CheckCatalog:        getSecurityCheckCatalog(),
+		GeneratedAt:         now.Format(time.RFC3339),
+		StartedAt:           output.Metadata.StartAt.Format(time.RFC3339),
+		CompletedAt:         output.Metadata.CompleteAt.Format(time.RFC3339),
+		Duration:            durationLabel,
+		SuccessCount:        counts.Succeeded(),
+		WarningCount:        counts.Warning,
+		ErrorCount:          counts.Error,
+		BlockedCount:        counts.Blocked,
+		UnreachableCount:    counts.Unreachable,
+		SkippedCount:        counts.Skipped,
+		SuccessRate:         fmt.Sprintf(successRateFmt, counts.SuccessRate()),
+		FooterDate:          now.Format("2006-01-02 15:04:05"),
+		TrendHistory:        trends,
+		TrendSummary:        summarizeTrendHistory(trends),
+		HashAlgorithmLabel:  strings.ToUpper(output.Metadata.HashAlgorithm),
+		ScanDate:            scanDate,
+		ScanURL:             scanURL,
+		Status:              status,
+		Summary:             vulnReport.Summary,
+		Vulnerabilities:     vulnReport.Vulnerabilities,
+		AttackSurface:       output.AttackSurface,
+		Screenshots:         output.Screenshots,
+		DomainRegistrations: output.DomainRegistrations,
+		Hosting:             output.Hosting,
+		NetworkPaths:        output.NetworkPaths,
+		Technologies:        buildTechnologyInventory(output.allResults()),
+		HeaderConsistency:   checkHeaderConsistency(output.allResults()),
+		LoginForms:          assessLoginForms(output.AttackSurface, output.allResults()),
+		Baseline:            output.Baseline,
+		Remediation:         remediationGuidance(vulnReport.Vulnerabilities),
+		Throttled:           throttleCaveats(output.Throttling),
+		Evidence:            evidenceEntries(vulnReport.Vulnerabilities),
+		Revision:            output.Revision,
+		Classification:      output.Classification,
+		Notes:               output.Notes,
+		TargetNames:         output.TargetNames,
+		Lang:                i18n.DefaultLanguage,
+	}
+	data.Charts = buildReportCharts(data)
+	return data
+}
+
+// engagementVulnerabilityReport collects the findings of every source a
+// report shows: check results, manual findings, and the tracked changes,
+// with the evidence captured on their targets attached.
+func engagementVulnerabilityReport(output *RunOutput, scanURL, scanDate, duration string) *checker.VulnerabilityReport {
+	vulnReport := checker.BuildVulnerabilityReportSeq(output.allResults(), scanURL, scanDate, duration)
+	vulnReport.Add(manualVulnerabilities(output.ManualFindings)...)
+	vulnReport.Add(checker.KeyChangeVulnerabilities(output.KeyChanges)...)
+	vulnReport.Add(checker.CertDeviationVulnerabilities(output.CertDeviations)...)
+	vulnReport.Add(checker.ContentChangeVulnerabilities(output.ContentChanges)...)
+	vulnReport.Add(checker.RegistrationVulnerabilities(output.DomainRegistrations)...)
+	if output.Hosting != nil {
+		vulnReport.Add(checker.JurisdictionVulnerabilities(output.Hosting.Targets, output.Hosting.AllowedCountries)...)
+	}
+	attachEvidence(vulnReport.Vulnerabilities, output.Evidence)
+	return vulnReport
 }
 
 func summarizeTrendHistory(trends []TelemetryRecord) TrendSummary {
@@ -732,14 +1239,34 @@ func summarizeTrendHistory(trends []TelemetryRecord) TrendSummary {
 
 func executeTemplate(tmpl *template.Template, data TemplateData) (string, error) {
 	var buf strings.Builder
-	if err := tmpl.Execute(&buf, data); err != nil {
-		return "", fmt.Errorf("failed to execute %s template: %w", tmpl.Name(), err)
+	if err := executeTemplateTo(&buf, tmpl, data); err != nil {
+		return "", err
 	}
 	return buf.String(), nil
 }
 
+// executeTemplateTo renders tmpl straight into w, so large reports are not
+// buffered in memory before being written.
+func executeTemplateTo(w io.Writer, tmpl *template.Template, data TemplateData) error {
+	// Execute a clone bound to the report's language; the parsed template
+	// itself is never executed so it stays clonable.
+	localized, err := tmpl.Clone()
+	if err != nil {
+		return fmt.Errorf("failed to prepare %s template: %w", tmpl.Name(), err)
+	}
+	catalog := data.catalog
+	if catalog == nil {
+		catalog = i18n.Default()
+	}
+	localized.Funcs(template.FuncMap{"displayTarget": data.TargetNames.Label})
+	if err := localized.Funcs(localizedFuncs(catalog)).Execute(w, data); err != nil {
+		return fmt.Errorf("failed to execute %s template: %w", tmpl.Name(), err)
+	}
+	return nil
+}
+
 func deriveScanURL(output *RunOutput) string {
-	for _, result := range output.Results {
+	for result := range output.allResults() {
 		if strings.TrimSpace(result.Target) != "" {
 			return result.Target
 		}
@@ -769,22 +1296,18 @@ func deriveRunStatus(okCount, errorCount, total int) string {
 func summarizeReportStats(output *RunOutput) reportStatsSummary {
 	summary := reportStatsSummary{
 		EngagementID: output.Metadata.EngagementID,
-		Results:      make([]reportStatsEntry, 0, len(output.Results)),
+		Results:      make([]reportStatsEntry, 0, output.Metadata.TotalTargets),
 	}
 
-	for _, r := range output.Results {
+	for r := range output.allResults() {
 		entry := reportStatsEntry{
 			Target:     r.Target,
+			Name:       output.TargetNames.Name(r.Target),
 			Status:     r.Status,
 			HTTPStatus: r.HTTPStatus,
 			Notes:      r.Notes,
 		}
 		summary.Total++
-		if strings.EqualFold(r.Status, "ok") {
-			summary.Success++
-		} else {
-			summary.Fail++
-		}
 		if r.TLSExpiry != "" {
 			if t, err := time.Parse(time.RFC3339, r.TLSExpiry); err == nil && time.Until(t) < statsTLSSoonWindow {
 				entry.TLSSoon = true
@@ -793,10 +1316,44 @@ func summarizeReportStats(output *RunOutput) reportStatsSummary {
 		}
 		summary.Results = append(summary.Results, entry)
 	}
+	counts := countStatusSeq(output.allResults())
+	summary.Success, summary.Fail = counts.Succeeded(), counts.Failed()
+	summary.Warning, summary.Blocked = counts.Warning, counts.Blocked
+	summary.Unreachable, summary.Skipped = counts.Unreachable, counts.Skipped
 
 	return summary
 }
 
+// summarizeCheckerStats breaks results down by the checker that produced
+// them. Issues counts the distinct failed or warning findings in a
+// checker's results.
+func summarizeCheckerStats(results []checker.CheckResult, sources []resultSource) []reportStatsChecker {
+	stats := make([]reportStatsChecker, 0, len(sources))
+	offset := 0
+	for _, source := range sources {
+		end := min(offset+source.Results, len(results))
+		part := results[offset:end]
+		offset = end
+
+		counts := countStatuses(part)
+		entry := reportStatsChecker{
+			Checker:     source.Checker(),
+			Total:       len(part),
+			Success:     counts.Succeeded(),
+			Fail:        counts.Failed(),
+			SuccessRate: counts.SuccessRate(),
+		}
+		for _, r := range part {
+			if r.NetworkSecurity != nil {
+				entry.OpenPorts += len(r.NetworkSecurity.OpenPorts)
+			}
+		}
+		entry.Issues = len(findingsFromResults(part))
+		stats = append(stats, entry)
+	}
+	return stats
+}
+
 func printStatsText(summary reportStatsSummary) {
 	fmt.Println(colorInfo("Summary"))
 	fmt.Printf("Targets: %d | OK: %s | Fail: %s | TLS <30d: %s\n",
@@ -805,6 +1362,33 @@ func printStatsText(summary reportStatsSummary) {
 		colorError(fmt.Sprintf("%d", summary.Fail)),
 		colorWarn(fmt.Sprintf("%d", summary.TLSSoon)),
 	)
+	if summary.Warning+summary.Blocked+summary.Unreachable+summary.Skipped > 0 {
+		fmt.Printf("Warnings: %d | Blocked: %d | Unreachable: %d | Skipped: %d\n",
+			summary.Warning, summary.Blocked, summary.Unreachable, summary.Skipped)
+	}
+	printCheckerStats(summary.Checkers)
+}
+
+// printCheckerStats prints the per-checker breakdown when results came from
+// more than one checker.
+func printCheckerStats(checkers []reportStatsChecker) {
+	if len(checkers) < 2 {
+		return
+	}
+	fmt.Println()
+	fmt.Println(colorInfo("By checker"))
+	tw := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHECKER\tTARGETS\tOK\tFAIL\tSUCCESS\tISSUES\tOPEN PORTS")
+	for _, c := range checkers {
+		ports := "-"
+		if c.OpenPorts > 0 {
+			ports = fmt.Sprintf("%d", c.OpenPorts)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.1f%%\t%d\t%s\n", c.Checker, c.Total, c.Success, c.Fail, c.SuccessRate, c.Issues, ports)
+	}
+	if err := tw.Flush(); err != nil {
+		cliLog().Warnw("stats_table_flush_failed", "error", err)
+	}
 }
 
 func printStatsTable(summary reportStatsSummary) {
@@ -825,11 +1409,12 @@ func printStatsTable(summary reportStatsSummary) {
 		if notes == "" {
 			notes = "-"
 		}
-		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", entry.Target, status, entry.HTTPStatus, tlsCol, notes)
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", labelTarget(entry.Target, entry.Name), status, entry.HTTPStatus, tlsCol, notes)
 	}
 	if err := tw.Flush(); err != nil {
-		fmt.Fprintf(os.Stderr, "failed to flush stats table: %v\n", err)
+		cliLog().Warnw("stats_table_flush_failed", "error", err)
 	}
+	printCheckerStats(summary.Checkers)
 }
 
 func printTelemetryASCII(records []TelemetryRecord) {
@@ -870,22 +1455,30 @@ var reportStatsCmd = &cobra.Command{
 		if format == "" {
 			format = "text"
 		}
-
-		path, err := resolveResultsPath(appCtx.ResultsDir, id, "http_results.json")
-		if err != nil {
-			return fmt.Errorf("resolve results path: %w", err)
+		slowest, _ := cmd.Flags().GetInt("slowest")
+		if slowest < 0 {
+			return fmt.Errorf("--slowest must be zero or positive")
 		}
-		data, err := os.ReadFile(path)
+
+		output, sources, err := aggregateRunOutputs(appCtx.ResultsDir, id)
 		if err != nil {
 			return err
 		}
-		var output RunOutput
-		if err := json.Unmarshal(data, &output); err != nil {
-			return err
-		}
 		normalizeRunMetadata(&output.Metadata)
+		if output.TargetNames, err = loadTargetNames(appCtx.ResultsDir, id); err != nil {
+			cliLog().Warnw("target_names_load_failed", "engagement_id", id, "error", err)
+		}
 
-		summary := summarizeReportStats(&output)
+		summary := summarizeReportStats(output)
+		summary.Checkers = summarizeCheckerStats(output.Results, sources)
+		if slowest > 0 {
+			summary.Latency = summarizeLatency(output.Results, sources, slowest)
+			if summary.Latency != nil {
+				for i, s := range summary.Latency.Slowest {
+					summary.Latency.Slowest[i].Name = output.TargetNames.Name(s.Target)
+				}
+			}
+		}
 
 		switch format {
 		case "json":
@@ -901,13 +1494,16 @@ var reportStatsCmd = &cobra.Command{
 		default:
 			return fmt.Errorf("unsupported format %q (use text|table|json)", format)
 		}
+		if slowest > 0 && format != "json" {
+			printLatencyStats(summary.Latency)
+		}
 		return nil
 	},
 }
 
 var reportTelemetryCmd = &cobra.Command{
 	Use:   "telemetry",
-	Short: "Graph telemetry success rate trend for an engagement",
+	Short: "Graph telemetry trends and flag regressions for an engagement",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		appCtx := getAppContext(cmd)
 
@@ -919,7 +1515,12 @@ var reportTelemetryCmd = &cobra.Command{
 			return fmt.Errorf("--id is required")
 		}
 
-		history, err := loadTelemetryHistory(appCtx.ResultsDir, id, limit)
+		if limit <= 0 {
+			limit = 10
+		}
+		// Read enough earlier runs to give the oldest displayed run a baseline.
+		thresholds := regressionThresholdsForCommand(cmd)
+		history, err := loadTelemetryHistory(appCtx.ResultsDir, id, limit+thresholds.Window)
 		if err != nil {
 			return err
 		}
@@ -927,18 +1528,34 @@ var reportTelemetryCmd = &cobra.Command{
 			fmt.Printf("%s telemetry records found for engagement %s\n", colorWarn("No"), id)
 			return nil
 		}
+		entries := annotateTelemetryHistory(history, detectTelemetryRegressions(history, thresholds))
+		if len(entries) > limit {
+			entries = entries[len(entries)-limit:]
+			history = history[len(history)-limit:]
+		}
 
 		switch strings.ToLower(format) {
 		case "json":
-			out, err := json.MarshalIndent(history, jsonPrefix, jsonIndent)
+			out, err := json.MarshalIndent(entries, jsonPrefix, jsonIndent)
 			if err != nil {
 				return fmt.Errorf("marshal telemetry: %w", err)
 			}
 			fmt.Println(string(out))
+		case "svg":
+			out := cmd.OutOrStdout()
+			if _, err := out.Write(successTrendChart(history).SVG()); err != nil {
+				return err
+			}
+			fmt.Fprintln(out)
 		case "ascii":
 			printTelemetryASCII(history)
+			var regressions []TelemetryRegression
+			for _, entry := range entries {
+				regressions = append(regressions, entry.Regressions...)
+			}
+			printTelemetryRegressions(cmd.OutOrStdout(), regressions)
 		default:
-			return fmt.Errorf("unsupported format %s (use ascii or json)", format)
+			return fmt.Errorf("unsupported format %s (use ascii, json or svg)", format)
 		}
 
 		return nil
@@ -948,11 +1565,16 @@ var reportTelemetryCmd = &cobra.Command{
 func init() {
 	reportGenerateCmd.Flags().String("id", "", "Engagement ID")
 	reportGenerateCmd.Flags().String("format", "md", "Output format: json|md|html|pdf")
+	reportGenerateCmd.Flags().Bool("include-baseline", false, "List findings accepted in the engagement baseline alongside new ones")
+	addReportLanguageFlag(reportGenerateCmd)
 	reportStatsCmd.Flags().String("id", "", "Engagement ID")
 	reportStatsCmd.Flags().String("format", "text", "Output format: text|table|json")
+	reportStatsCmd.Flags().Int("slowest", 0, "Show the latency histogram and the N slowest targets with phase timings")
 	reportTelemetryCmd.Flags().String("id", "", "Engagement ID")
-	reportTelemetryCmd.Flags().String("format", "ascii", "Output format: ascii|json")
+	reportTelemetryCmd.Flags().String("format", "ascii", "Output format: ascii|json|svg")
 	reportTelemetryCmd.Flags().Int("limit", 10, "Number of recent runs to display")
+	reportTelemetryCmd.Flags().Float64("success-drop", defaultRegressionThresholds.SuccessDrop, "Flag runs whose success rate drops more than this many points below the baseline")
+	reportTelemetryCmd.Flags().Float64("duration-increase", defaultRegressionThresholds.DurationIncrease, "Flag runs that take more than this percent longer than the baseline")
 	reportCmd.AddCommand(reportGenerateCmd)
 	reportCmd.AddCommand(reportStatsCmd)
 	reportCmd.AddCommand(reportTelemetryCmd)
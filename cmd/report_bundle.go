@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+	"github.com/khanhnv2901/seca-cli/internal/shared/i18n"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// bundleFilename is the default name of an engagement's evidence bundle.
+	bundleFilename = "evidence_bundle.zip"
+	// bundleManifestFilename lists the bundled files and their SHA-256.
+	bundleManifestFilename = "manifest.json"
+	// bundleReportFilename is the HTML report rendered into the bundle. It
+	// sits at the root so its evidence links resolve inside the archive.
+	bundleReportFilename = "report.html"
+)
+
+// bundleSidecars are the engagement files bundled alongside the results
+// when present. Credentials (auth.enc, auth.key) are never bundled.
+var bundleSidecars = []string{
+	"audit.csv",
+	"audit.csv" + HashAlgorithmSHA256.FileExtension(),
+	"audit.csv" + HashAlgorithmSHA512.FileExtension(),
+	manualFindingsFilename,
+	evidenceIndexFilename,
+	screenshotIndexFilename,
+}
+
+// BundleManifest describes an evidence bundle: every file it contains with
+// its SHA-256, and the evidence that was referenced but could not be read.
+type BundleManifest struct {
+	EngagementID  string         `json:"engagement_id"`
+	CreatedAt     time.Time      `json:"created_at"`
+	CreatedBy     string         `json:"created_by"`
+	HashAlgorithm string         `json:"hash_algorithm"`
+	Files         []RevisionFile `json:"files"`
+	Missing       []string       `json:"missing,omitempty"`
+}
+
+// writeEvidenceBundle writes a zip of the engagement's results, audit trail,
+// evidence artifacts and a rendered HTML report, with the manifest last.
+// Paths inside the archive match the results directory, so references in
+// the results files and the report stay valid.
+func writeEvidenceBundle(w io.Writer, resultsDir, engagementID, operator string, catalog *i18n.Catalog) (*BundleManifest, error) {
+	rendered, err := loadEngagementReport(resultsDir, engagementID, "html")
+	if err != nil {
+		return nil, err
+	}
+	rendered.Catalog = catalog
+
+	manifest := &BundleManifest{
+		EngagementID:  engagementID,
+		CreatedAt:     time.Now().UTC(),
+		CreatedBy:     operator,
+		HashAlgorithm: HashAlgorithmSHA256.String(),
+	}
+	zw := zip.NewWriter(w)
+	add := func(name string, data []byte) error {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: manifest.CreatedAt})
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(data); err != nil {
+			return fmt.Errorf("write %s: %w", name, err)
+		}
+		sum := sha256.Sum256(data)
+		manifest.Files = append(manifest.Files, RevisionFile{Name: name, Hash: hex.EncodeToString(sum[:]), Size: int64(len(data))})
+		return nil
+	}
+	// addFile bundles a file of the results directory; absent files are
+	// skipped.
+	addFile := func(name string) (bool, error) {
+		path, err := resolveResultsPath(resultsDir, engagementID, filepath.FromSlash(name))
+		if err != nil {
+			return false, err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, add(name, data)
+	}
+
+	var names []string
+	names = append(names, rendered.Sources...)
+	names = append(names, bundleSidecars...)
+	for _, name := range names {
+		if _, err := addFile(name); err != nil {
+			return nil, err
+		}
+	}
+
+	bundled := make(map[string]bool)
+	for _, ref := range rendered.Output.Evidence {
+		if bundled[ref.File] {
+			continue
+		}
+		bundled[ref.File] = true
+		found, err := addFile(ref.File)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			manifest.Missing = append(manifest.Missing, ref.File)
+		}
+	}
+
+	var report bytes.Buffer
+	if err := rendered.Render(&report); err != nil {
+		return nil, fmt.Errorf("render report: %w", err)
+	}
+	if err := add(bundleReportFilename, report.Bytes()); err != nil {
+		return nil, err
+	}
+
+	body, err := json.MarshalIndent(manifest, jsonPrefix, jsonIndent)
+	if err != nil {
+		return nil, err
+	}
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: bundleManifestFilename, Method: zip.Deflate, Modified: manifest.CreatedAt})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fw.Write(body); err != nil {
+		return nil, fmt.Errorf("write %s: %w", bundleManifestFilename, err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+var reportBundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Export the results, audit trail and evidence as a zip",
+	Long: `Bundle packages an engagement for hand-over: its results files, audit trail
+and hash companions, manual findings, every evidence artifact referenced by
+findings (raw captures, screenshots, HAR entries, DNS answers, attached
+files), and a rendered HTML report whose evidence links resolve inside the
+archive. manifest.json lists each file with its SHA-256. The export is
+recorded in the audit trail with the bundle's hash.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		appCtx := getAppContext(cmd)
+
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return errors.New("--id is required")
+		}
+		output, _ := cmd.Flags().GetString("output")
+		if output == "" {
+			path, err := resolveResultsPath(appCtx.ResultsDir, id, bundleFilename)
+			if err != nil {
+				return err
+			}
+			output = path
+		}
+		catalog, err := reportLanguage(cmd)
+		if err != nil {
+			return err
+		}
+
+		var manifest *BundleManifest
+		err = fileutil.WriteWith(output, consts.DefaultFilePerm, func(w io.Writer) error {
+			var err error
+			manifest, err = writeEvidenceBundle(w, appCtx.ResultsDir, id, appCtx.Operator, catalog)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("write bundle: %w", err)
+		}
+		sum, err := HashFile(output, HashAlgorithmSHA256)
+		if err != nil {
+			return fmt.Errorf("hash bundle: %w", err)
+		}
+
+		notes := fmt.Sprintf("%d files; sha256=%s", len(manifest.Files), sum)
+		if len(manifest.Missing) > 0 {
+			notes += fmt.Sprintf("; %d evidence files missing", len(manifest.Missing))
+		}
+		if err := recordAuditEvent(ctx, appCtx, id, "report bundle", filepath.Base(output), notes); err != nil {
+			return err
+		}
+
+		fmt.Printf("%s %s (%d files)\n", colorSuccess("Bundle written:"), output, len(manifest.Files))
+		fmt.Printf("%s SHA-256: %s\n", colorInfo("→"), sum)
+		for _, name := range manifest.Missing {
+			fmt.Printf("%s evidence file missing: %s\n", colorWarn("!"), name)
+		}
+		return nil
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(reportBundleCmd)
+
+	reportBundleCmd.Flags().String("id", "", "Engagement ID")
+	reportBundleCmd.Flags().String("output", "", "Bundle path (default: <results>/<id>/"+bundleFilename+")")
+	addReportLanguageFlag(reportBundleCmd)
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"iter"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/chart"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+// severityColors match the severity badges in the HTML report.
+var severityColors = map[string]string{
+	"Critical": "#b91c1c",
+	"High":     "#ea580c",
+	"Medium":   "#d97706",
+	"Low":      "#2563eb",
+	"Info":     "#6b7280",
+}
+
+// headerGrades are the security header grades in display order.
+var headerGrades = []struct {
+	Grade string
+	Color string
+}{
+	{"A+", "#15803d"}, {"A", "#16a34a"}, {"B", "#65a30d"}, {"C", "#d97706"}, {"D", "#ea580c"}, {"F", "#b91c1c"},
+}
+
+// ReportCharts holds the inline SVG charts of the HTML report. Empty fields
+// had no data to chart.
+type ReportCharts struct {
+	SuccessTrend template.HTML
+	Severity     template.HTML
+	HeaderGrades template.HTML
+}
+
+// successTrendChart charts the success rate of recent runs, or returns nil
+// when there is no history.
+func successTrendChart(history []TelemetryRecord) *chart.Line {
+	if len(history) == 0 {
+		return nil
+	}
+	points := make([]chart.Point, len(history))
+	for i, rec := range history {
+		points[i] = chart.Point{Label: rec.Timestamp.Format("01-02 15:04"), Value: rec.SuccessRate}
+	}
+	return &chart.Line{Title: "Success rate by run", Points: points, Max: 100, Unit: "%", Color: "#16a34a"}
+}
+
+// severityChart charts findings by severity, or returns nil without findings.
+func severityChart(summary checker.VulnerabilitySummary) *chart.Donut {
+	if summary.Total == 0 {
+		return nil
+	}
+	counts := []struct {
+		label string
+		value int
+	}{
+		{"Critical", summary.Critical}, {"High", summary.High}, {"Medium", summary.Medium}, {"Low", summary.Low}, {"Info", summary.Info},
+	}
+	donut := &chart.Donut{Title: "Findings by severity"}
+	for _, c := range counts {
+		donut.Slices = append(donut.Slices, chart.Slice{Label: c.label, Value: float64(c.value), Color: severityColors[c.label]})
+	}
+	return donut
+}
+
+// headerGradeChart charts how many targets received each security header
+// grade, or returns nil when no target was graded.
+func headerGradeChart(results iter.Seq[checker.CheckResult]) *chart.Bar {
+	counts := make(map[string]int)
+	graded := 0
+	for r := range results {
+		if r.SecurityHeaders == nil || r.SecurityHeaders.Grade == "" {
+			continue
+		}
+		counts[strings.ToUpper(r.SecurityHeaders.Grade)]++
+		graded++
+	}
+	if graded == 0 {
+		return nil
+	}
+	bars := &chart.Bar{Title: "Security header grades"}
+	for _, g := range headerGrades {
+		bars.Bars = append(bars.Bars, chart.Slice{Label: g.Grade, Value: float64(counts[g.Grade]), Color: g.Color})
+	}
+	return bars
+}
+
+func buildReportCharts(data TemplateData) ReportCharts {
+	var charts ReportCharts
+	if c := successTrendChart(data.TrendHistory); c != nil {
+		charts.SuccessTrend = template.HTML(c.SVG())
+	}
+	if c := severityChart(data.Summary); c != nil {
+		charts.Severity = template.HTML(c.SVG())
+	}
+	if c := headerGradeChart(data.Results); c != nil {
+		charts.HeaderGrades = template.HTML(c.SVG())
+	}
+	return charts
+}
+
+// writePDFCharts places the report charts as PNG images, with titles and
+// legends drawn as PDF text.
+func writePDFCharts(pdf *gofpdf.Fpdf, data TemplateData) {
+	type pdfChart struct {
+		name   string
+		title  string
+		chart  chart.Chart
+		legend []chart.Slice
+		width  float64
+	}
+	var charts []pdfChart
+	if c := successTrendChart(data.TrendHistory); c != nil {
+		charts = append(charts, pdfChart{name: "success-trend", title: c.Title, chart: c, width: 120})
+	}
+	if c := severityChart(data.Summary); c != nil {
+		charts = append(charts, pdfChart{name: "severity", title: c.Title, chart: c, legend: c.Slices, width: 55})
+	}
+	if c := headerGradeChart(data.Results); c != nil {
+		charts = append(charts, pdfChart{name: "header-grades", title: c.Title, chart: c, legend: c.Bars, width: 120})
+	}
+	if len(charts) == 0 {
+		return
+	}
+
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, "Charts", "", 1, "", false, 0, "")
+	for _, c := range charts {
+		img, err := c.chart.PNG()
+		if err != nil {
+			cliLog().Warnw("report_chart_render_failed", "chart", c.name, "error", err)
+			continue
+		}
+		pdf.SetFont("Arial", "B", 10)
+		pdf.CellFormat(0, 6, c.title, "", 1, "", false, 0, "")
+		opts := gofpdf.ImageOptions{ImageType: "PNG"}
+		pdf.RegisterImageOptionsReader(c.name, opts, bytes.NewReader(img))
+		pdf.ImageOptions(c.name, pdf.GetX(), pdf.GetY(), c.width, 0, true, opts, 0, "")
+
+		if len(c.legend) > 0 {
+			parts := make([]string, 0, len(c.legend))
+			for _, s := range c.legend {
+				parts = append(parts, fmt.Sprintf("%s: %g", s.Label, s.Value))
+			}
+			pdf.SetFont("Arial", "", 9)
+			pdf.MultiCell(0, 5, strings.Join(parts, " | "), "", "", false)
+		}
+		pdf.Ln(3)
+	}
+	pdf.Ln(2)
+}
@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/spf13/cobra"
+)
+
+// Kinds of header difference between two engagements.
+const (
+	headerDiffMissing = "missing" // Present in --from, absent in --to
+	headerDiffAdded   = "added"   // Absent in --from, present in --to
+	headerDiffChanged = "changed" // Present in both with different values
+)
+
+// headerDiffOrder sorts differences so the ones that block a release come
+// first.
+var headerDiffOrder = map[string]int{headerDiffMissing: 0, headerDiffChanged: 1, headerDiffAdded: 2}
+
+// HeaderComparison is the security header configuration of the hostnames
+// two engagements have in common, diffed from one to the other.
+type HeaderComparison struct {
+	From  string           `json:"from"`
+	To    string           `json:"to"`
+	Hosts []HostHeaderDiff `json:"hosts"`
+	// OnlyInFrom and OnlyInTo list hostnames checked in one engagement only.
+	OnlyInFrom []string `json:"only_in_from,omitempty"`
+	OnlyInTo   []string `json:"only_in_to,omitempty"`
+}
+
+// HostHeaderDiff lists the headers that differ for one hostname. Hosts
+// whose headers match have no differences.
+type HostHeaderDiff struct {
+	Host        string             `json:"host"`
+	FromTarget  string             `json:"from_target"`
+	ToTarget    string             `json:"to_target"`
+	Differences []HeaderDifference `json:"differences,omitempty"`
+}
+
+// HeaderDifference is one header that differs between the engagements.
+type HeaderDifference struct {
+	Header    string `json:"header"`
+	Kind      string `json:"kind"`
+	Severity  string `json:"severity,omitempty"`
+	FromValue string `json:"from_value,omitempty"`
+	ToValue   string `json:"to_value,omitempty"`
+}
+
+// MissingCount returns how many headers are missing in the --to engagement.
+func (c *HeaderComparison) MissingCount() int {
+	count := 0
+	for _, host := range c.Hosts {
+		for _, d := range host.Differences {
+			if d.Kind == headerDiffMissing {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// hostHeaderResults returns the latest security header result of each
+// hostname, keyed by lowercase host.
+func hostHeaderResults(results []checker.CheckResult) map[string]checker.CheckResult {
+	latest := make(map[string]checker.CheckResult)
+	for _, r := range results {
+		if r.SecurityHeaders == nil {
+			continue
+		}
+		host := strings.ToLower(checker.ExtractHost(r.Target))
+		if host == "" {
+			continue
+		}
+		if prev, ok := latest[host]; !ok || r.CheckedAt.After(prev.CheckedAt) {
+			latest[host] = r
+		}
+	}
+	return latest
+}
+
+// compareSecurityHeaders diffs the security headers of the hostnames checked
+// in both result sets.
+func compareSecurityHeaders(fromID string, from []checker.CheckResult, toID string, to []checker.CheckResult) *HeaderComparison {
+	fromHosts := hostHeaderResults(from)
+	toHosts := hostHeaderResults(to)
+	comparison := &HeaderComparison{From: fromID, To: toID}
+
+	for host, fromResult := range fromHosts {
+		toResult, ok := toHosts[host]
+		if !ok {
+			comparison.OnlyInFrom = append(comparison.OnlyInFrom, host)
+			continue
+		}
+		comparison.Hosts = append(comparison.Hosts, HostHeaderDiff{
+			Host:        host,
+			FromTarget:  fromResult.Target,
+			ToTarget:    toResult.Target,
+			Differences: diffHeaderStatuses(fromResult.SecurityHeaders.Headers, toResult.SecurityHeaders.Headers),
+		})
+	}
+	for host := range toHosts {
+		if _, ok := fromHosts[host]; !ok {
+			comparison.OnlyInTo = append(comparison.OnlyInTo, host)
+		}
+	}
+
+	sort.Slice(comparison.Hosts, func(i, j int) bool { return comparison.Hosts[i].Host < comparison.Hosts[j].Host })
+	sort.Strings(comparison.OnlyInFrom)
+	sort.Strings(comparison.OnlyInTo)
+	return comparison
+}
+
+// diffHeaderStatuses compares two analyzed header sets. A header the
+// analyzer did not record on one side counts as absent there.
+func diffHeaderStatuses(from, to map[string]checker.HeaderStatus) []HeaderDifference {
+	names := make(map[string]struct{}, len(from)+len(to))
+	for name := range from {
+		names[name] = struct{}{}
+	}
+	for name := range to {
+		names[name] = struct{}{}
+	}
+
+	var diffs []HeaderDifference
+	for name := range names {
+		f, t := from[name], to[name]
+		severity := f.Severity
+		if severity == "" {
+			severity = t.Severity
+		}
+		d := HeaderDifference{Header: name, Severity: severity, FromValue: f.Value, ToValue: t.Value}
+		switch {
+		case f.Present && !t.Present:
+			d.Kind = headerDiffMissing
+		case !f.Present && t.Present:
+			d.Kind = headerDiffAdded
+		case f.Present && t.Present && f.Value != t.Value:
+			d.Kind = headerDiffChanged
+		default:
+			continue
+		}
+		diffs = append(diffs, d)
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Kind != diffs[j].Kind {
+			return headerDiffOrder[diffs[i].Kind] < headerDiffOrder[diffs[j].Kind]
+		}
+		return diffs[i].Header < diffs[j].Header
+	})
+	return diffs
+}
+
+// printHeaderComparison writes the comparison as one table row per
+// differing header, missing-in---to headers first for each host.
+func printHeaderComparison(out io.Writer, c *HeaderComparison) {
+	fmt.Fprintf(out, "%s %s → %s\n", colorInfo("Security headers:"), c.From, c.To)
+	differing := 0
+	for _, host := range c.Hosts {
+		if len(host.Differences) > 0 {
+			differing++
+		}
+	}
+	fmt.Fprintf(out, "Hosts compared: %d | with differences: %d | missing in %s: %d\n", len(c.Hosts), differing, c.To, c.MissingCount())
+
+	if differing > 0 {
+		fmt.Fprintln(out)
+		tw := tabwriter.NewWriter(out, 2, 4, 2, ' ', 0)
+		fmt.Fprintf(tw, "HOST\tHEADER\tDIFF\tSEVERITY\t%s\t%s\n", strings.ToUpper(c.From), strings.ToUpper(c.To))
+		for _, host := range c.Hosts {
+			for _, d := range host.Differences {
+				kind := d.Kind
+				if kind == headerDiffMissing {
+					kind = colorError("MISSING")
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", host.Host, d.Header, kind, d.Severity, headerValueCell(d.FromValue), headerValueCell(d.ToValue))
+			}
+		}
+		tw.Flush()
+	}
+
+	if len(c.OnlyInFrom) > 0 {
+		fmt.Fprintf(out, "\n%s only checked in %s: %s\n", colorWarn("!"), c.From, strings.Join(c.OnlyInFrom, ", "))
+	}
+	if len(c.OnlyInTo) > 0 {
+		fmt.Fprintf(out, "%s only checked in %s: %s\n", colorWarn("!"), c.To, strings.Join(c.OnlyInTo, ", "))
+	}
+}
+
+// headerValueCell shortens a header value for the comparison table.
+func headerValueCell(value string) string {
+	const maxLen = 48
+	if value == "" {
+		return "-"
+	}
+	if len(value) > maxLen {
+		return value[:maxLen-3] + "..."
+	}
+	return value
+}
+
+var reportCompareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Compare the results of two engagements",
+	Long: `Compare diffs the results of two engagements for the hostnames both checked,
+for example staging (--from) against production (--to) before a release.
+
+Modes:
+  headers  Security response headers of each hostname: headers missing in
+           --to, headers only --to sends, and headers whose values differ.
+
+Each hostname is compared using its latest HTTP result in each engagement.
+Hostnames checked in only one engagement are listed separately.`,
+	Example: `  seca report compare --from staging-2026q3 --to prod-2026q3 --mode headers
+  seca report compare --from staging --to prod --fail-on-missing`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		if from == "" || to == "" {
+			return errors.New("--from and --to are required")
+		}
+		mode, _ := cmd.Flags().GetString("mode")
+		if mode = strings.ToLower(strings.TrimSpace(mode)); mode != "headers" {
+			return fmt.Errorf("unsupported mode %q (use headers)", mode)
+		}
+		format, _ := cmd.Flags().GetString("format")
+		format = strings.ToLower(strings.TrimSpace(format))
+		if format != "text" && format != "json" {
+			return fmt.Errorf("unsupported format %q (use text|json)", format)
+		}
+		failOnMissing, _ := cmd.Flags().GetBool("fail-on-missing")
+
+		fromOutput, _, err := aggregateRunOutputs(appCtx.ResultsDir, from)
+		if err != nil {
+			return err
+		}
+		toOutput, _, err := aggregateRunOutputs(appCtx.ResultsDir, to)
+		if err != nil {
+			return err
+		}
+		comparison := compareSecurityHeaders(from, fromOutput.Results, to, toOutput.Results)
+
+		out := cmd.OutOrStdout()
+		if format == "json" {
+			payload, err := json.MarshalIndent(comparison, jsonPrefix, jsonIndent)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(out, string(payload))
+		} else {
+			printHeaderComparison(out, comparison)
+		}
+
+		if missing := comparison.MissingCount(); failOnMissing && missing > 0 {
+			return &exitCodeError{code: exitFindings, err: fmt.Errorf("%d security header(s) missing in %s", missing, to)}
+		}
+		return nil
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(reportCompareCmd)
+
+	reportCompareCmd.Flags().String("from", "", "Reference engagement ID (e.g. staging)")
+	reportCompareCmd.Flags().String("to", "", "Engagement ID to review against the reference (e.g. production)")
+	reportCompareCmd.Flags().String("mode", "headers", "What to compare: headers")
+	reportCompareCmd.Flags().String("format", "text", "Output format: text|json")
+	reportCompareCmd.Flags().Bool("fail-on-missing", false, "Exit with code 3 when a header present in --from is missing in --to")
+}
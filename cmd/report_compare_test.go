@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func headerResult(target string, at time.Time, headers map[string]string) checker.CheckResult {
+	statuses := make(map[string]checker.HeaderStatus)
+	for _, name := range []string{"Strict-Transport-Security", "Content-Security-Policy", "X-Frame-Options"} {
+		statuses[name] = checker.HeaderStatus{Severity: "high"}
+	}
+	for name, value := range headers {
+		statuses[name] = checker.HeaderStatus{Present: true, Value: value, Severity: "high"}
+	}
+	return checker.CheckResult{Target: target, CheckedAt: at, SecurityHeaders: &checker.SecurityHeadersResult{Headers: statuses}}
+}
+
+func TestCompareSecurityHeaders(t *testing.T) {
+	at := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	staging := []checker.CheckResult{
+		headerResult("https://app.example.com/", at, map[string]string{
+			"Strict-Transport-Security": "max-age=31536000",
+			"Content-Security-Policy":   "default-src 'self'",
+		}),
+		headerResult("https://old.example.com/", at, nil),
+	}
+	prod := []checker.CheckResult{
+		// An earlier result for the same host is superseded by the later one.
+		headerResult("https://APP.example.com/login", at.Add(-time.Hour), nil),
+		headerResult("https://app.example.com/", at.Add(time.Hour), map[string]string{
+			"Content-Security-Policy": "default-src *",
+			"X-Frame-Options":         "DENY",
+		}),
+		headerResult("https://new.example.com/", at, nil),
+		{Target: "example.com", CheckedAt: at}, // DNS result, no headers
+	}
+
+	c := compareSecurityHeaders("staging", staging, "prod", prod)
+	if len(c.Hosts) != 1 || c.Hosts[0].Host != "app.example.com" {
+		t.Fatalf("expected one common host, got %+v", c.Hosts)
+	}
+	diffs := c.Hosts[0].Differences
+	if len(diffs) != 3 {
+		t.Fatalf("expected three differences, got %+v", diffs)
+	}
+	if diffs[0].Kind != headerDiffMissing || diffs[0].Header != "Strict-Transport-Security" {
+		t.Errorf("missing headers should come first, got %+v", diffs[0])
+	}
+	if diffs[1].Kind != headerDiffChanged || diffs[1].FromValue != "default-src 'self'" || diffs[1].ToValue != "default-src *" {
+		t.Errorf("unexpected changed header %+v", diffs[1])
+	}
+	if diffs[2].Kind != headerDiffAdded || diffs[2].Header != "X-Frame-Options" {
+		t.Errorf("unexpected added header %+v", diffs[2])
+	}
+	if c.MissingCount() != 1 {
+		t.Errorf("MissingCount() = %d, want 1", c.MissingCount())
+	}
+	if len(c.OnlyInFrom) != 1 || c.OnlyInFrom[0] != "old.example.com" || len(c.OnlyInTo) != 1 || c.OnlyInTo[0] != "new.example.com" {
+		t.Errorf("unexpected unmatched hosts from=%v to=%v", c.OnlyInFrom, c.OnlyInTo)
+	}
+
+	var out bytes.Buffer
+	printHeaderComparison(&out, c)
+	for _, want := range []string{"missing in prod: 1", "Strict-Transport-Security", "only checked in staging: old.example.com"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestCompareSecurityHeadersIdentical(t *testing.T) {
+	at := time.Now()
+	headers := map[string]string{"X-Frame-Options": "DENY"}
+	c := compareSecurityHeaders("a", []checker.CheckResult{headerResult("https://x.example/", at, headers)},
+		"b", []checker.CheckResult{headerResult("https://x.example/", at, headers)})
+	if len(c.Hosts) != 1 || len(c.Hosts[0].Differences) != 0 || c.MissingCount() != 0 {
+		t.Fatalf("expected no differences, got %+v", c.Hosts)
+	}
+}
@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/domain/engagement"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+	"github.com/khanhnv2901/seca-cli/internal/shared/i18n"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// revisionsDirName holds one directory per finalized report revision.
+	revisionsDirName = "revisions"
+	// revisionManifestFilename lists a revision's files and their hashes.
+	revisionManifestFilename = "manifest.json"
+	// revisionFindingsFilename is the frozen findings set of a revision.
+	revisionFindingsFilename = "findings.json"
+)
+
+// reportDeliverableFormats are rendered for every finalized revision.
+var reportDeliverableFormats = []string{"json", "md", "html", "pdf"}
+
+// ReportRevision identifies which revision of an engagement's deliverables a
+// report belongs to. Reports generated after a revision was finalized are
+// drafts of the next revision.
+type ReportRevision struct {
+	Number      int       `json:"number"`
+	Finalized   bool      `json:"finalized"`
+	Reviewer    string    `json:"reviewer,omitempty"`
+	Approver    string    `json:"approver,omitempty"`
+	FinalizedAt time.Time `json:"finalized_at,omitempty"`
+}
+
+// Label describes the revision for report headers.
+func (r *ReportRevision) Label() string {
+	if !r.Finalized {
+		return fmt.Sprintf("Revision %d (draft)", r.Number)
+	}
+	return fmt.Sprintf("Revision %d (final, %s), reviewed by %s, approved by %s",
+		r.Number, r.FinalizedAt.Format(time.DateOnly), r.Reviewer, r.Approver)
+}
+
+// RevisionManifest records a finalized revision: who signed it off, and the
+// hash of every deliverable so later tampering is detectable.
+type RevisionManifest struct {
+	Revision       int            `json:"revision"`
+	EngagementID   string         `json:"engagement_id"`
+	FinalizedAt    time.Time      `json:"finalized_at"`
+	FinalizedBy    string         `json:"finalized_by"`
+	Reviewer       string         `json:"reviewer"`
+	Approver       string         `json:"approver"`
+	Classification string         `json:"classification,omitempty"`
+	Language       string         `json:"language"`
+	AuditHash      string         `json:"audit_hash,omitempty"`
+	HashAlgorithm  string         `json:"hash_algorithm"`
+	Files          []RevisionFile `json:"files"`
+}
+
+// RevisionFile is one hashed file of a finalized revision.
+type RevisionFile struct {
+	Name string `json:"name"`
+	Hash string `json:"hash"`
+	Size int64  `json:"size"`
+}
+
+// frozenFindings is the findings set of a revision as the report showed it.
+type frozenFindings struct {
+	Summary         checker.VulnerabilitySummary `json:"summary"`
+	Vulnerabilities []checker.Vulnerability      `json:"vulnerabilities"`
+}
+
+// loadRevisionManifests returns the engagement's finalized revisions, oldest
+// first.
+func loadRevisionManifests(resultsDir, engagementID string) ([]RevisionManifest, error) {
+	dir, err := resolveResultsPath(resultsDir, engagementID, revisionsDirName)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var manifests []RevisionManifest
+	for _, entry := range entries {
+		if _, err := strconv.Atoi(entry.Name()); err != nil || !entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name(), revisionManifestFilename))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue // an interrupted finalize; the revision was never signed off
+			}
+			return nil, err
+		}
+		var m RevisionManifest
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("parse revision %s %s: %w", entry.Name(), revisionManifestFilename, err)
+		}
+		manifests = append(manifests, m)
+	}
+	sort.Slice(manifests, func(i, j int) bool { return manifests[i].Revision < manifests[j].Revision })
+	return manifests, nil
+}
+
+// draftRevision returns the revision that new reports belong to, or nil when
+// nothing was finalized yet.
+func draftRevision(resultsDir, engagementID string) (*ReportRevision, error) {
+	manifests, err := loadRevisionManifests(resultsDir, engagementID)
+	if err != nil || len(manifests) == 0 {
+		return nil, err
+	}
+	return &ReportRevision{Number: manifests[len(manifests)-1].Revision + 1}, nil
+}
+
+// finalizeReport freezes the current findings as the next revision, renders
+// every deliverable into its directory, and writes the hashed manifest last,
+// so only a complete revision counts as finalized.
+func finalizeReport(resultsDir, engagementID, operator, reviewer, approver string, algo HashAlgorithm, catalog *i18n.Catalog) (*RevisionManifest, error) {
+	manifests, err := loadRevisionManifests(resultsDir, engagementID)
+	if err != nil {
+		return nil, err
+	}
+	number := 1
+	if len(manifests) > 0 {
+		number = manifests[len(manifests)-1].Revision + 1
+	}
+
+	rendered, err := loadEngagementReport(resultsDir, engagementID, "json")
+	if err != nil {
+		return nil, err
+	}
+	rendered.Catalog = catalog
+	now := time.Now().UTC()
+	rendered.Output.Revision = &ReportRevision{
+		Number:      number,
+		Finalized:   true,
+		Reviewer:    reviewer,
+		Approver:    approver,
+		FinalizedAt: now,
+	}
+
+	dir, err := resolveResultsPath(resultsDir, engagementID, revisionsDirName, strconv.Itoa(number))
+	if err != nil {
+		return nil, err
+	}
+	// Leftovers of an interrupted finalize are replaced; a signed-off
+	// revision has a manifest and was skipped above.
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("reset revision directory: %w", err)
+	}
+	if err := os.MkdirAll(dir, consts.DefaultDirPerm); err != nil {
+		return nil, fmt.Errorf("create revision directory: %w", err)
+	}
+
+	data := buildTemplateData(rendered.Output, rendered.Sources, "%.1f", rendered.trendHistory)
+	findings, err := json.MarshalIndent(frozenFindings{Summary: data.Summary, Vulnerabilities: data.Vulnerabilities}, jsonPrefix, jsonIndent)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{revisionFindingsFilename}
+	if err := fileutil.WriteFile(filepath.Join(dir, revisionFindingsFilename), findings, consts.DefaultFilePerm); err != nil {
+		return nil, fmt.Errorf("write frozen findings: %w", err)
+	}
+	for _, format := range reportDeliverableFormats {
+		rendered.Format = format
+		rendered.Filename = "report." + format
+		if err := fileutil.WriteWith(filepath.Join(dir, rendered.Filename), consts.DefaultFilePerm, rendered.Render); err != nil {
+			return nil, fmt.Errorf("write %s: %w", rendered.Filename, err)
+		}
+		names = append(names, rendered.Filename)
+	}
+
+	manifest := &RevisionManifest{
+		Revision:       number,
+		EngagementID:   engagementID,
+		FinalizedAt:    now,
+		FinalizedBy:    operator,
+		Reviewer:       reviewer,
+		Approver:       approver,
+		Classification: rendered.Output.Classification,
+		Language:       catalog.Language(),
+		AuditHash:      rendered.Output.Metadata.AuditHash,
+		HashAlgorithm:  algo.String(),
+	}
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		sum, err := HashFile(path, algo)
+		if err != nil {
+			return nil, fmt.Errorf("hash %s: %w", name, err)
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, RevisionFile{Name: name, Hash: sum, Size: info.Size()})
+	}
+	body, err := json.MarshalIndent(manifest, jsonPrefix, jsonIndent)
+	if err != nil {
+		return nil, err
+	}
+	if err := fileutil.WriteFile(filepath.Join(dir, revisionManifestFilename), body, consts.DefaultFilePerm); err != nil {
+		return nil, fmt.Errorf("write manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+var reportFinalizeCmd = &cobra.Command{
+	Use:   "finalize",
+	Short: "Freeze the findings and sign off the report as a new revision",
+	Long: `Finalize freezes the engagement's current findings, renders every deliverable
+(JSON, Markdown, HTML, PDF) stamped with the reviewer and approver, and stores
+them with a hashed manifest under revisions/<n>/. The sign-off is recorded in
+the audit trail. Finalized revisions are never rewritten: later runs and
+reports become drafts of the next revision. Pass --close to also close the
+engagement once the final revision is delivered.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		appCtx := getAppContext(cmd)
+
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return errors.New("--id is required")
+		}
+		reviewer, _ := cmd.Flags().GetString("reviewer")
+		approver, _ := cmd.Flags().GetString("approver")
+		reviewer, approver = strings.TrimSpace(reviewer), strings.TrimSpace(approver)
+		if reviewer == "" || approver == "" {
+			return errors.New("--reviewer and --approver are required")
+		}
+		closeEng, _ := cmd.Flags().GetBool("close")
+
+		eng, err := appCtx.Services.EngagementService.GetEngagement(ctx, id)
+		if err != nil {
+			if errors.Is(err, sharedErrors.ErrEngagementNotFound) {
+				return fmt.Errorf("engagement %s not found", id)
+			}
+			return fmt.Errorf("failed to get engagement: %w", err)
+		}
+		// Reports may be finalized after authorization expired, but a closed
+		// engagement's deliverables are final.
+		if eng.IsClosed() {
+			return fmt.Errorf("engagement %s: %w", id, sharedErrors.ErrEngagementClosed)
+		}
+
+		algo, err := ParseHashAlgorithm(appCtx.Config.Check.HashAlgorithm)
+		if err != nil {
+			return err
+		}
+		catalog, err := reportLanguage(cmd)
+		if err != nil {
+			return err
+		}
+		warnUntranslatedPDF("pdf", catalog)
+		manifest, err := finalizeReport(appCtx.ResultsDir, id, appCtx.Operator, reviewer, approver, algo, catalog)
+		if err != nil {
+			return err
+		}
+
+		manifestPath, err := resolveResultsPath(appCtx.ResultsDir, id, revisionsDirName, strconv.Itoa(manifest.Revision), revisionManifestFilename)
+		if err != nil {
+			return err
+		}
+		manifestHash, err := HashFile(manifestPath, algo)
+		if err != nil {
+			return fmt.Errorf("hash manifest: %w", err)
+		}
+		notes := fmt.Sprintf("revision %d signed off; reviewer=%s; approver=%s; manifest %s=%s",
+			manifest.Revision, reviewer, approver, algo, manifestHash)
+		target := filepath.ToSlash(filepath.Join(revisionsDirName, strconv.Itoa(manifest.Revision)))
+		if err := recordAuditEvent(ctx, appCtx, id, "report finalize", target, notes); err != nil {
+			return err
+		}
+
+		fmt.Printf("%s revision %d of engagement %s\n", colorSuccess("Finalized"), manifest.Revision, id)
+		for _, f := range manifest.Files {
+			fmt.Printf("  %s  %s\n", f.Hash, f.Name)
+		}
+		fmt.Printf("%s Manifest (%s): %s\n", colorInfo("→"), algo, manifestHash)
+
+		if closeEng {
+			if _, err := appCtx.Services.EngagementService.TransitionStatus(ctx, id, engagement.StatusClosed); err != nil {
+				return fmt.Errorf("revision %d finalized, but closing the engagement failed: %w", manifest.Revision, err)
+			}
+			if err := recordAuditEvent(ctx, appCtx, id, "engagement status", id, fmt.Sprintf("status changed from %s to closed; reason: report revision %d finalized", eng.Status(), manifest.Revision)); err != nil {
+				return err
+			}
+			fmt.Printf("%s Engagement %s closed\n", colorInfo("→"), id)
+		}
+		return nil
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(reportFinalizeCmd)
+
+	reportFinalizeCmd.Flags().String("id", "", "Engagement ID")
+	reportFinalizeCmd.Flags().String("reviewer", "", "Name of the reviewer who checked the findings")
+	reportFinalizeCmd.Flags().String("approver", "", "Name of the approver who signs off the deliverable")
+	addReportLanguageFlag(reportFinalizeCmd)
+	reportFinalizeCmd.Flags().Bool("close", false, "Close the engagement after finalizing (no further changes or runs)")
+}
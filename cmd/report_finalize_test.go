@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/khanhnv2901/seca-cli/internal/shared/i18n"
+)
+
+func TestFinalizeReportCreatesRevisions(t *testing.T) {
+	resultsDir := t.TempDir()
+	const id = "eng-finalize"
+	if _, err := ensureResultsDir(resultsDir, id); err != nil {
+		t.Fatalf("ensureResultsDir() error = %v", err)
+	}
+	start := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+	writeRunOutputFile(t, resultsDir, id, "http_results.json", RunOutput{
+		Metadata: RunMetadata{EngagementID: id, StartAt: start, CompleteAt: start.Add(time.Minute)},
+		Results:  []checker.CheckResult{{Target: "https://app.example.com", Status: "ok"}},
+	})
+
+	if rev, err := draftRevision(resultsDir, id); err != nil || rev != nil {
+		t.Fatalf("expected no revision before finalizing, got %+v (%v)", rev, err)
+	}
+
+	manifest, err := finalizeReport(resultsDir, id, "alice", "Bob Reviewer", "Carol Approver", HashAlgorithmSHA256, i18n.Default())
+	if err != nil {
+		t.Fatalf("finalizeReport() error = %v", err)
+	}
+	if manifest.Revision != 1 || manifest.Reviewer != "Bob Reviewer" || manifest.Approver != "Carol Approver" {
+		t.Fatalf("unexpected manifest: %+v", manifest)
+	}
+	if len(manifest.Files) != 1+len(reportDeliverableFormats) {
+		t.Fatalf("expected findings plus %d deliverables, got %+v", len(reportDeliverableFormats), manifest.Files)
+	}
+	dir := filepath.Join(resultsDir, id, revisionsDirName, "1")
+	for _, f := range manifest.Files {
+		sum, err := HashFile(filepath.Join(dir, f.Name), HashAlgorithmSHA256)
+		if err != nil || sum != f.Hash {
+			t.Fatalf("hash mismatch for %s: %s vs %s (%v)", f.Name, sum, f.Hash, err)
+		}
+	}
+	md, err := os.ReadFile(filepath.Join(dir, "report.md"))
+	if err != nil {
+		t.Fatalf("read report.md: %v", err)
+	}
+	if !strings.Contains(string(md), "Revision 1 (final") || !strings.Contains(string(md), "approved by Carol Approver") {
+		t.Fatalf("finalized report should carry the sign-off:\n%s", md)
+	}
+
+	// Later reports are drafts of the next revision; the next finalize does
+	// not touch revision 1.
+	rendered, err := loadEngagementReport(resultsDir, id, "md")
+	if err != nil {
+		t.Fatalf("loadEngagementReport() error = %v", err)
+	}
+	if rendered.Output.Revision == nil || rendered.Output.Revision.Number != 2 || rendered.Output.Revision.Finalized {
+		t.Fatalf("expected draft revision 2, got %+v", rendered.Output.Revision)
+	}
+	second, err := finalizeReport(resultsDir, id, "alice", "Bob Reviewer", "Dan Approver", HashAlgorithmSHA256, i18n.Default())
+	if err != nil || second.Revision != 2 {
+		t.Fatalf("second finalize: %+v (%v)", second, err)
+	}
+	after, err := os.ReadFile(filepath.Join(dir, "report.md"))
+	if err != nil || string(after) != string(md) {
+		t.Fatal("revision 1 must not change after it was finalized")
+	}
+	manifests, err := loadRevisionManifests(resultsDir, id)
+	if err != nil || len(manifests) != 2 {
+		t.Fatalf("expected 2 revisions, got %d (%v)", len(manifests), err)
+	}
+}
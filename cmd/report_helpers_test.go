@@ -259,3 +259,33 @@ func TestDeriveScanURL(t *testing.T) {
 		t.Fatalf("expected default fallback, got %s", got)
 	}
 }
+
+func TestSummarizeCheckerStats(t *testing.T) {
+	results := []checker.CheckResult{
+		{Target: "https://a.example.com", Status: "ok"},
+		{Target: "https://b.example.com", Status: "error"},
+		{Target: "a.example.com", Status: "ok"},
+		{Target: "a.example.com", Status: "ok", NetworkSecurity: &checker.NetworkSecurityResult{
+			OpenPorts: []checker.PortInfo{{Port: 22}, {Port: 443}},
+		}},
+	}
+	sources := []resultSource{
+		{Name: "http_results.json", Results: 2},
+		{Name: "dns_results.json", Results: 1},
+		{Name: "network_results.json", Results: 1},
+	}
+
+	stats := summarizeCheckerStats(results, sources)
+	if len(stats) != 3 {
+		t.Fatalf("expected three checkers, got %+v", stats)
+	}
+	if http := stats[0]; http.Checker != "http" || http.Total != 2 || http.Fail != 1 || http.SuccessRate != 50 {
+		t.Errorf("unexpected http stats %+v", http)
+	}
+	if dns := stats[1]; dns.Checker != "dns" || dns.Total != 1 || dns.SuccessRate != 100 {
+		t.Errorf("unexpected dns stats %+v", dns)
+	}
+	if network := stats[2]; network.Checker != "network" || network.OpenPorts != 2 {
+		t.Errorf("unexpected network stats %+v", network)
+	}
+}
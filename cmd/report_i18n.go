@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/khanhnv2901/seca-cli/internal/shared/i18n"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// localizedFuncs returns the template functions that translate report text
+// into the catalog's language. The templates are parsed with the English
+// versions and re-bound per render.
+func localizedFuncs(c *i18n.Catalog) template.FuncMap {
+	return template.FuncMap{
+		"t":              c.T,
+		"severity":       c.Severity,
+		"status":         c.Status,
+		"recommendation": c.Recommendation,
+		// Passed findings keep their confirmation text; only remediation
+		// advice is translated.
+		"findingRecommendation": func(v checker.Vulnerability) string {
+			if strings.EqualFold(v.Status, "Passed") {
+				return v.Recommendation
+			}
+			return c.FindingRecommendation(v.Name, v.Recommendation)
+		},
+	}
+}
+
+// reportLanguage resolves --lang, falling back to report.language in the
+// config file and then English.
+func reportLanguage(cmd *cobra.Command) (*i18n.Catalog, error) {
+	lang, _ := cmd.Flags().GetString("lang")
+	if flag := cmd.Flags().Lookup("lang"); (flag == nil || !flag.Changed) && viper.IsSet("report.language") {
+		lang = viper.GetString("report.language")
+	}
+	return i18n.Load(lang)
+}
+
+// warnUntranslatedPDF notes that PDF output stays in English: the built-in
+// PDF fonts cannot render Japanese, Korean or Vietnamese text.
+func warnUntranslatedPDF(format string, c *i18n.Catalog) {
+	if format == "pdf" && c.Language() != i18n.DefaultLanguage {
+		fmt.Printf("%s PDF reports are rendered in English; use --format html or md for a %s report\n", colorWarn("!"), c.Language())
+	}
+}
+
+func validateReportLanguage(value any) error {
+	if s, ok := value.(string); ok {
+		_, err := i18n.Load(s)
+		return err
+	}
+	return nil
+}
+
+func addReportLanguageFlag(cmd *cobra.Command) {
+	cmd.Flags().String("lang", "", "Report language: "+strings.Join(i18n.Languages(), "|")+" (default: report.language, then en)")
+}
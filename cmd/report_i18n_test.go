@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/khanhnv2901/seca-cli/internal/shared/i18n"
+)
+
+func TestReportTemplatesUseKnownMessages(t *testing.T) {
+	en := i18n.Default()
+	keyPattern := regexp.MustCompile(`\{\{t "([a-z_.]+)"`)
+	for _, path := range []string{htmlTemplatePath, markdownTemplatePath} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read %s: %v", path, err)
+		}
+		for _, m := range keyPattern.FindAllStringSubmatch(string(data), -1) {
+			if en.T(m[1]) == m[1] {
+				t.Errorf("%s uses unknown message %q", path, m[1])
+			}
+		}
+	}
+}
+
+func TestLocalizedReports(t *testing.T) {
+	ja, err := i18n.Load("ja")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+	output := &RunOutput{
+		Metadata: RunMetadata{EngagementID: "eng-ja", EngagementName: "Tokyo", StartAt: start, CompleteAt: start.Add(time.Minute)},
+		Results: []checker.CheckResult{{
+			Target: "https://app.example.jp",
+			Status: "ok",
+			SecurityHeaders: &checker.SecurityHeadersResult{
+				Headers: map[string]checker.HeaderStatus{
+					"X-Content-Type-Options": {Present: false, Severity: "high", Recommendation: "Add 'X-Content-Type-Options: nosniff'"},
+				},
+				Missing: []string{"X-Content-Type-Options"},
+			},
+		}},
+	}
+	rendered := &renderedReport{Output: output, Catalog: ja}
+
+	md, err := executeTemplate(markdownReportTemplate, rendered.templateData("%.2f"))
+	if err != nil {
+		t.Fatalf("markdown: %v", err)
+	}
+	for _, want := range []string{"# エンゲージメントレポート: Tokyo", "## 概要", "nosniff&#39; を追加してください", "深刻度: 高"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown report missing %q", want)
+		}
+	}
+
+	html, err := executeTemplate(htmlReportTemplate, rendered.templateData("%.1f"))
+	if err != nil {
+		t.Fatalf("html: %v", err)
+	}
+	for _, want := range []string{`<html lang="ja">`, "スキャン結果", "検出事項"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("html report missing %q", want)
+		}
+	}
+
+	// The English templates are unaffected by an earlier localized render.
+	rendered.Catalog = nil
+	md, err = executeTemplate(markdownReportTemplate, rendered.templateData("%.2f"))
+	if err != nil || !strings.Contains(md, "## Summary") {
+		t.Fatalf("expected English report after a localized one (%v)", err)
+	}
+}
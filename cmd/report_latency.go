@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+// latencyBucketBounds are the upper bounds (ms) of the latency histogram;
+// a final bucket collects everything slower.
+var latencyBucketBounds = []float64{100, 250, 500, 1000, 2500, 5000}
+
+// reportLatencySummary is the latency distribution of an engagement's
+// results and its slowest targets.
+type reportLatencySummary struct {
+	Samples int                   `json:"samples"`
+	P50     float64               `json:"p50_ms"`
+	P90     float64               `json:"p90_ms"`
+	P99     float64               `json:"p99_ms"`
+	Max     float64               `json:"max_ms"`
+	Buckets []reportLatencyBucket `json:"buckets"`
+	Slowest []reportSlowTarget    `json:"slowest"`
+}
+
+// reportLatencyBucket counts results up to UpperMs; the last bucket has no
+// upper bound.
+type reportLatencyBucket struct {
+	Label   string  `json:"label"`
+	UpperMs float64 `json:"upper_ms,omitempty"`
+	Count   int     `json:"count"`
+}
+
+// reportSlowTarget is one row of the slowest-targets view.
+type reportSlowTarget struct {
+	Target       string                `json:"target"`
+	Name         string                `json:"name,omitempty"` // Display name, if any
+	Checker      string                `json:"checker"`
+	ResponseTime float64               `json:"response_time_ms"`
+	Timings      *checker.PhaseTimings `json:"timings,omitempty"`
+}
+
+// summarizeLatency builds the latency histogram and the limit slowest
+// targets. Results without a recorded response time (DNS lookups, for
+// example) are left out. It returns nil when no result was timed.
+func summarizeLatency(results []checker.CheckResult, sources []resultSource, limit int) *reportLatencySummary {
+	checkers := resultCheckers(len(results), sources)
+	timed := make([]reportSlowTarget, 0, len(results))
+	for i, r := range results {
+		if r.ResponseTime <= 0 {
+			continue
+		}
+		timed = append(timed, reportSlowTarget{
+			Target:       r.Target,
+			Checker:      checkers[i],
+			ResponseTime: r.ResponseTime,
+			Timings:      r.Timings,
+		})
+	}
+	if len(timed) == 0 {
+		return nil
+	}
+	sort.SliceStable(timed, func(i, j int) bool { return timed[i].ResponseTime > timed[j].ResponseTime })
+
+	summary := &reportLatencySummary{
+		Samples: len(timed),
+		P50:     latencyPercentile(timed, 50),
+		P90:     latencyPercentile(timed, 90),
+		P99:     latencyPercentile(timed, 99),
+		Max:     timed[0].ResponseTime,
+		Slowest: timed[:min(limit, len(timed))],
+	}
+
+	lower := "0"
+	for _, bound := range latencyBucketBounds {
+		summary.Buckets = append(summary.Buckets, reportLatencyBucket{Label: fmt.Sprintf("%s-%s", lower, formatMillis(bound)), UpperMs: bound})
+		lower = formatMillis(bound)
+	}
+	summary.Buckets = append(summary.Buckets, reportLatencyBucket{Label: ">" + lower})
+	for _, t := range timed {
+		idx := sort.SearchFloat64s(latencyBucketBounds, t.ResponseTime)
+		summary.Buckets[idx].Count++
+	}
+	return summary
+}
+
+// latencyPercentile returns the nearest-rank percentile of timed, which is
+// sorted slowest first.
+func latencyPercentile(timed []reportSlowTarget, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(timed))))
+	rank = max(rank, 1)
+	return timed[len(timed)-rank].ResponseTime
+}
+
+// resultCheckers returns the checker that produced each of n results, using
+// the per-file counts in sources.
+func resultCheckers(n int, sources []resultSource) []string {
+	checkers := make([]string, n)
+	offset := 0
+	for _, source := range sources {
+		end := min(offset+source.Results, n)
+		for i := offset; i < end; i++ {
+			checkers[i] = source.Checker()
+		}
+		offset = end
+	}
+	return checkers
+}
+
+func formatMillis(ms float64) string {
+	if ms >= 1000 {
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", ms/1000), ".0") + "s"
+	}
+	return fmt.Sprintf("%.0fms", ms)
+}
+
+func formatPhase(ms float64) string {
+	if ms <= 0 {
+		return "-"
+	}
+	return formatMillis(ms)
+}
+
+func printLatencyStats(latency *reportLatencySummary) {
+	fmt.Println()
+	fmt.Println(colorInfo("Latency"))
+	if latency == nil {
+		fmt.Println(colorWarn("No timed results found."))
+		return
+	}
+	fmt.Printf("Samples: %d | p50: %s | p90: %s | p99: %s | max: %s\n",
+		latency.Samples, formatMillis(latency.P50), formatMillis(latency.P90), formatMillis(latency.P99), formatMillis(latency.Max))
+
+	const barWidth = 30
+	for _, b := range latency.Buckets {
+		barLen := int(math.Round(float64(b.Count) / float64(latency.Samples) * barWidth))
+		if barLen == 0 && b.Count > 0 {
+			barLen = 1
+		}
+		fmt.Printf("%12s | %-*s %d\n", b.Label, barWidth, strings.Repeat("#", barLen), b.Count)
+	}
+
+	fmt.Println()
+	fmt.Println(colorInfo(fmt.Sprintf("Slowest %d target(s)", len(latency.Slowest))))
+	tw := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TARGET\tCHECKER\tTOTAL\tDNS\tCONNECT\tTLS\tFIRST BYTE\tANALYSIS")
+	for _, s := range latency.Slowest {
+		t := checker.PhaseTimings{}
+		if s.Timings != nil {
+			t = *s.Timings
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", labelTarget(s.Target, s.Name), s.Checker, formatMillis(s.ResponseTime),
+			formatPhase(t.DNS), formatPhase(t.Connect), formatPhase(t.TLS), formatPhase(t.FirstByte), formatPhase(t.Analysis))
+	}
+	if err := tw.Flush(); err != nil {
+		cliLog().Warnw("stats_table_flush_failed", "error", err)
+	}
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func TestSummarizeLatency(t *testing.T) {
+	results := []checker.CheckResult{
+		{Target: "https://a.example/", ResponseTime: 80},
+		{Target: "https://b.example/", ResponseTime: 3200, Timings: &checker.PhaseTimings{DNS: 5, FirstByte: 3000}},
+		{Target: "https://c.example/", ResponseTime: 450},
+		{Target: "https://d.example/", ResponseTime: 900},
+		{Target: "example.com"}, // DNS result, not timed
+	}
+	sources := []resultSource{{Name: "http_results.json", Results: 4}, {Name: "dns_results.json", Results: 1}}
+
+	latency := summarizeLatency(results, sources, 2)
+	if latency == nil {
+		t.Fatal("expected a latency summary")
+	}
+	if latency.Samples != 4 {
+		t.Fatalf("expected 4 timed samples, got %d", latency.Samples)
+	}
+	if latency.P50 != 450 || latency.Max != 3200 {
+		t.Errorf("unexpected percentiles p50=%v max=%v", latency.P50, latency.Max)
+	}
+	if len(latency.Slowest) != 2 || latency.Slowest[0].Target != "https://b.example/" || latency.Slowest[1].Target != "https://d.example/" {
+		t.Fatalf("unexpected slowest targets %+v", latency.Slowest)
+	}
+	if latency.Slowest[0].Checker != "http" || latency.Slowest[0].Timings.FirstByte != 3000 {
+		t.Errorf("expected checker and phase timings on slowest entry, got %+v", latency.Slowest[0])
+	}
+
+	counts := map[string]int{}
+	for _, b := range latency.Buckets {
+		counts[b.Label] = b.Count
+	}
+	if counts["0-100ms"] != 1 || counts["250ms-500ms"] != 1 || counts["500ms-1s"] != 1 || counts["2.5s-5s"] != 1 {
+		t.Errorf("unexpected histogram %v", counts)
+	}
+}
+
+func TestSummarizeLatencyWithoutTimedResults(t *testing.T) {
+	if latency := summarizeLatency([]checker.CheckResult{{Target: "example.com"}}, nil, 5); latency != nil {
+		t.Fatalf("expected nil summary, got %+v", latency)
+	}
+}
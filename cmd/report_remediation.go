@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/remediation"
+)
+
+// RemediationEntry is one remediation guide in a report together with the
+// findings it addresses.
+type RemediationEntry struct {
+	Guide    remediation.Guide
+	Findings []string
+}
+
+// findingGuide returns the remediation guide for a finding that still needs
+// fixing, or nil when the finding passed or has no guide.
+func findingGuide(v checker.Vulnerability) *remediation.Guide {
+	if strings.EqualFold(v.Status, "Passed") {
+		return nil
+	}
+	g, ok := remediation.Lookup(v.Name)
+	if !ok {
+		return nil
+	}
+	return g
+}
+
+// remediationGuidance collects the guides for the report's open findings,
+// one entry per guide in the order its first finding appears.
+func remediationGuidance(vulns []checker.Vulnerability) []RemediationEntry {
+	var entries []RemediationEntry
+	index := make(map[string]int)
+	for _, v := range vulns {
+		g := findingGuide(v)
+		if g == nil {
+			continue
+		}
+		i, ok := index[g.ID]
+		if !ok {
+			i = len(entries)
+			index[g.ID] = i
+			entries = append(entries, RemediationEntry{Guide: *g})
+		}
+		entries[i].Findings = appendUnique(entries[i].Findings, v.Name)
+	}
+	return entries
+}
+
+// verbatimMarkdown emits a configuration snippet inside a Markdown code fence
+// without HTML escaping, so it can be copied as-is.
+func verbatimMarkdown(s string) template.HTML {
+	return template.HTML(strings.ReplaceAll(s, "```", "` ` `"))
+}
+
+func appendUnique(list []string, value string) []string {
+	for _, existing := range list {
+		if existing == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
+
+// writeRemediationPDF prints each guide's impact, fix steps per platform and
+// references after the detailed analysis.
+func writeRemediationPDF(pdf *gofpdf.Fpdf, entries []RemediationEntry) {
+	if pdf.GetY() > 240 {
+		pdf.AddPage()
+	}
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, "Remediation Guidance", "", 1, "", false, 0, "")
+	for _, entry := range entries {
+		g := entry.Guide
+		if pdf.GetY() > 250 {
+			pdf.AddPage()
+		}
+		pdf.SetFont("Arial", "B", 10)
+		pdf.SetFillColor(240, 240, 240)
+		pdf.CellFormat(0, 6, g.Title, "", 1, "", true, 0, "")
+		pdf.SetFont("Arial", "I", 8)
+		pdf.MultiCell(0, 4, "Findings: "+strings.Join(entry.Findings, ", "), "", "", false)
+		pdf.SetFont("Arial", "", 8)
+		pdf.MultiCell(0, 4, "Impact: "+g.Impact, "", "", false)
+		pdf.MultiCell(0, 4, "Fix: "+g.Summary, "", "", false)
+		for _, fix := range g.Fixes {
+			if pdf.GetY() > 270 {
+				pdf.AddPage()
+			}
+			pdf.SetFont("Arial", "B", 8)
+			pdf.CellFormat(0, 4, "  "+fix.Platform+":", "", 1, "", false, 0, "")
+			pdf.SetFont("Arial", "", 8)
+			for i, step := range fix.Steps {
+				pdf.MultiCell(0, 4, fmt.Sprintf("    %d. %s", i+1, step), "", "", false)
+			}
+			if fix.Example != "" {
+				pdf.SetFont("Courier", "", 7)
+				pdf.MultiCell(0, 3.5, fix.Example, "", "", false)
+			}
+		}
+		pdf.SetFont("Arial", "I", 7)
+		for _, ref := range g.References {
+			pdf.MultiCell(0, 4, fmt.Sprintf("  - %s: %s", ref.Title, ref.URL), "", "", false)
+		}
+		pdf.Ln(2)
+	}
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func TestRemediationGuidance(t *testing.T) {
+	vulns := []checker.Vulnerability{
+		{Name: "Content Security Policy (CSP)", Status: "Failed"},
+		{Name: "X-Frame-Options", Status: "Passed"},
+		{Name: "Content Security Policy (CSP) Configuration Issue", Status: "Warning"},
+		{Name: "Custom Manual Finding", Status: "Failed"},
+	}
+	entries := remediationGuidance(vulns)
+	if len(entries) != 1 {
+		t.Fatalf("expected one guide for the CSP findings, got %+v", entries)
+	}
+	if entries[0].Guide.ID != "content-security-policy" || len(entries[0].Findings) != 2 {
+		t.Fatalf("unexpected entry: %+v", entries[0])
+	}
+}
+
+func TestRemediationGuidanceInReports(t *testing.T) {
+	start := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+	output := &RunOutput{
+		Metadata: RunMetadata{EngagementID: "eng-fix", StartAt: start, CompleteAt: start.Add(time.Minute)},
+		Results: []checker.CheckResult{{
+			Target: "https://app.example.com",
+			Status: "ok",
+			SecurityHeaders: &checker.SecurityHeadersResult{
+				Headers: map[string]checker.HeaderStatus{
+					"X-Frame-Options": {Present: false, Severity: "high", Recommendation: "Add 'X-Frame-Options: DENY'"},
+				},
+				Missing: []string{"X-Frame-Options"},
+			},
+		}},
+	}
+	wants := map[string][]string{
+		"md": {
+			"## Remediation Guidance",
+			"### Clickjacking protection",
+			"add_header X-Frame-Options \"DENY\" always;",
+			"[OWASP Clickjacking Defense Cheat Sheet](https://cheatsheetseries.owasp.org/cheatsheets/Clickjacking_Defense_Cheat_Sheet.html)",
+		},
+		"html": {"<h3>How to Fix</h3>", "<h4>nginx</h4>", "Clickjacking_Defense_Cheat_Sheet.html"},
+		"pdf":  nil, // compressed; rendering must still succeed
+	}
+	for format, want := range wants {
+		rendered := &renderedReport{Format: format, Output: output}
+		var buf bytes.Buffer
+		if err := rendered.Render(&buf); err != nil {
+			t.Fatalf("Render(%s) error = %v", format, err)
+		}
+		for _, w := range want {
+			if !strings.Contains(buf.String(), w) {
+				t.Errorf("%s report missing %q", format, w)
+			}
+		}
+	}
+}
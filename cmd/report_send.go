@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/mailer"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var reportContentTypes = map[string]string{
+	"json": "application/json",
+	"md":   "text/markdown; charset=utf-8",
+	"html": "text/html; charset=utf-8",
+	"pdf":  "application/pdf",
+}
+
+var reportSendCmd = &cobra.Command{
+	Use:   "send",
+	Short: "Render an engagement report and email it via SMTP",
+	Long: `Render the engagement report and deliver it as an email attachment.
+
+The message body includes the audit hash recorded for the engagement so
+recipients can verify the report against the sealed audit trail.
+
+SMTP settings are read from ~/.seca-cli.yaml and may be overridden by flags:
+  smtp:
+    host: smtp.example.com
+    port: 587
+    username: seca@example.com
+    password_env: SECA_SMTP_PASSWORD
+    from: seca@example.com
+    starttls: true
+    smime_cert: /path/to/signer.pem   # optional S/MIME signing
+    smime_key: /path/to/signer.key`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		recipients, _ := cmd.Flags().GetStringSlice("to")
+		if len(recipients) == 0 {
+			return fmt.Errorf("--to is required")
+		}
+		format, _ := cmd.Flags().GetString("format")
+		catalog, err := reportLanguage(cmd)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := smtpConfigFromCommand(cmd)
+		if err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid SMTP configuration: %w", err)
+		}
+
+		warnUntranslatedPDF(format, catalog)
+		rendered, err := renderEngagementReport(appCtx.ResultsDir, id, format, catalog)
+		if err != nil {
+			return err
+		}
+
+		subject, _ := cmd.Flags().GetString("subject")
+		if subject == "" {
+			subject = fmt.Sprintf("SECA report: %s", reportDisplayName(&rendered.Output.Metadata, id))
+			if label := rendered.Output.Classification; label != "" {
+				subject = "[" + label + "] " + subject
+			}
+		}
+		msg := mailer.Message{
+			To:      recipients,
+			Subject: subject,
+			Body:    buildReportEmailBody(rendered, id),
+			Attachments: []mailer.Attachment{{
+				Filename:    fmt.Sprintf("%s_%s", id, rendered.Filename),
+				ContentType: reportContentTypes[rendered.Format],
+				Data:        rendered.Content,
+			}},
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		if err := mailer.Send(ctx, cfg, msg); err != nil {
+			return fmt.Errorf("send report: %w", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "%s Report (%s) sent to %s\n", colorSuccess("✓"), rendered.Format, strings.Join(recipients, ", "))
+		return nil
+	},
+}
+
+// smtpConfigFromCommand merges smtp.* config keys with flag overrides.
+func smtpConfigFromCommand(cmd *cobra.Command) (mailer.Config, error) {
+	port := viper.GetInt("smtp.port")
+	if flag := cmd.Flags().Lookup("smtp-port"); flag != nil && flag.Changed {
+		port, _ = cmd.Flags().GetInt("smtp-port")
+	}
+	if port == 0 {
+		port = 587
+	}
+
+	startTLS := true
+	if viper.IsSet("smtp.starttls") {
+		startTLS = viper.GetBool("smtp.starttls")
+	}
+	if flag := cmd.Flags().Lookup("no-starttls"); flag != nil && flag.Changed {
+		disabled, _ := cmd.Flags().GetBool("no-starttls")
+		startTLS = !disabled
+	}
+
+	cfg := mailer.Config{
+		Host:      smtpSetting(cmd, "smtp-host", "smtp.host"),
+		Port:      port,
+		Username:  smtpSetting(cmd, "smtp-username", "smtp.username"),
+		From:      smtpSetting(cmd, "from", "smtp.from"),
+		StartTLS:  startTLS,
+		SMIMECert: smtpSetting(cmd, "smime-cert", "smtp.smime_cert"),
+		SMIMEKey:  smtpSetting(cmd, "smime-key", "smtp.smime_key"),
+	}
+	if cfg.From == "" {
+		cfg.From = cfg.Username
+	}
+
+	if cfg.Username != "" {
+		passwordEnv := smtpSetting(cmd, "smtp-password-env", "smtp.password_env")
+		if passwordEnv == "" {
+			passwordEnv = "SECA_SMTP_PASSWORD"
+		}
+		cfg.Password = os.Getenv(passwordEnv)
+		if cfg.Password == "" {
+			return cfg, fmt.Errorf("SMTP password not found in $%s", passwordEnv)
+		}
+	}
+	return cfg, nil
+}
+
+// smtpSetting returns the flag value when set, otherwise the given config key.
+func smtpSetting(cmd *cobra.Command, flagName, key string) string {
+	value, _ := cmd.Flags().GetString(flagName)
+	if flag := cmd.Flags().Lookup(flagName); flag != nil && flag.Changed {
+		return strings.TrimSpace(value)
+	}
+	if viper.IsSet(key) {
+		return strings.TrimSpace(viper.GetString(key))
+	}
+	return strings.TrimSpace(value)
+}
+
+func reportDisplayName(meta *RunMetadata, id string) string {
+	if meta.EngagementName != "" {
+		return meta.EngagementName
+	}
+	return id
+}
+
+// buildReportEmailBody renders the plain-text message body, including the audit
+// hash so recipients can verify the attachment against the sealed audit trail.
+func buildReportEmailBody(rendered *renderedReport, id string) string {
+	meta := rendered.Output.Metadata
+	var b strings.Builder
+	fmt.Fprintf(&b, "Security check report for engagement %s", reportDisplayName(&meta, id))
+	if meta.EngagementName != "" {
+		fmt.Fprintf(&b, " (%s)", id)
+	}
+	b.WriteString(".\n\n")
+	if meta.Operator != "" {
+		fmt.Fprintf(&b, "Operator:      %s\n", meta.Operator)
+	}
+	if !meta.CompleteAt.IsZero() {
+		fmt.Fprintf(&b, "Completed:     %s\n", meta.CompleteAt.UTC().Format(time.RFC3339))
+	}
+	fmt.Fprintf(&b, "Total targets: %d\n", meta.TotalTargets)
+	fmt.Fprintf(&b, "Format:        %s\n\n", rendered.Format)
+
+	if meta.AuditHash != "" {
+		algorithm := meta.HashAlgorithm
+		if algorithm == "" {
+			algorithm = HashAlgorithmSHA256.String()
+		}
+		fmt.Fprintf(&b, "Audit hash (%s): %s\n", algorithm, meta.AuditHash)
+		if meta.SignatureFingerprint != "" {
+			fmt.Fprintf(&b, "Signature fingerprint: %s\n", meta.SignatureFingerprint)
+		}
+		fmt.Fprintf(&b, "Verify with: seca audit verify --id %s\n", id)
+	} else {
+		b.WriteString("Audit hash: not recorded (audit trail has not been sealed)\n")
+	}
+	return b.String()
+}
+
+func init() {
+	reportSendCmd.Flags().String("id", "", "Engagement ID")
+	reportSendCmd.Flags().StringSlice("to", nil, "Recipient email address (repeatable or comma-separated)")
+	reportSendCmd.Flags().String("format", "pdf", "Report format: json|md|html|pdf")
+	reportSendCmd.Flags().String("subject", "", "Email subject (default: SECA report: <engagement>)")
+	addReportLanguageFlag(reportSendCmd)
+	reportSendCmd.Flags().String("from", "", "Sender address (default smtp.from or smtp.username)")
+	reportSendCmd.Flags().String("smtp-host", "", "SMTP server host (overrides smtp.host)")
+	reportSendCmd.Flags().Int("smtp-port", 587, "SMTP server port (overrides smtp.port)")
+	reportSendCmd.Flags().String("smtp-username", "", "SMTP username (overrides smtp.username)")
+	reportSendCmd.Flags().String("smtp-password-env", "", "Environment variable holding the SMTP password (default SECA_SMTP_PASSWORD)")
+	reportSendCmd.Flags().Bool("no-starttls", false, "Disable STARTTLS (only allowed without credentials)")
+	reportSendCmd.Flags().String("smime-cert", "", "PEM certificate used to S/MIME-sign the message")
+	reportSendCmd.Flags().String("smime-key", "", "PEM private key used to S/MIME-sign the message")
+	reportCmd.AddCommand(reportSendCmd)
+}
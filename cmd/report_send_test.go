@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildReportEmailBodyIncludesAuditHash(t *testing.T) {
+	rendered := &renderedReport{
+		Format: "pdf",
+		Output: &RunOutput{Metadata: RunMetadata{
+			EngagementName: "Acme",
+			Operator:       "alice",
+			AuditHash:      "deadbeef",
+			HashAlgorithm:  "sha512",
+			TotalTargets:   3,
+		}},
+	}
+
+	body := buildReportEmailBody(rendered, "eng-1")
+	for _, want := range []string{"Acme (eng-1)", "Audit hash (sha512): deadbeef", "seca audit verify --id eng-1", "Total targets: 3"} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("email body missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestBuildReportEmailBodyWithoutAuditHash(t *testing.T) {
+	rendered := &renderedReport{Format: "md", Output: &RunOutput{}}
+	body := buildReportEmailBody(rendered, "eng-2")
+	if !strings.Contains(body, "not recorded") {
+		t.Fatalf("expected unsealed notice, got:\n%s", body)
+	}
+}
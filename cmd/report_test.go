@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -782,7 +785,7 @@ func TestTemplateData_Structure(t *testing.T) {
 	// Verify TemplateData has all required fields
 	data := TemplateData{
 		Metadata:     RunMetadata{EngagementID: "test"},
-		Results:      []checker.CheckResult{},
+		Results:      slices.Values([]checker.CheckResult{}),
 		GeneratedAt:  "2025-01-01T00:00:00Z",
 		StartedAt:    "2025-01-01T00:00:00Z",
 		CompletedAt:  "2025-01-01T00:05:00Z",
@@ -809,3 +812,122 @@ func TestTemplateData_Structure(t *testing.T) {
 		t.Error("TemplateData.SuccessRate should be accessible")
 	}
 }
+
+func TestGenerateMarkdownReport_AttackSurface(t *testing.T) {
+	resultsDir := t.TempDir()
+	surface := &checker.AttackSurface{
+		Forms: []checker.FormInfo{{Page: "https://example.com/", Action: "https://example.com/login", Method: "POST", Inputs: []string{"password", "username"}}},
+	}
+	if _, err := saveAttackSurface(resultsDir, "eng-surface", surface); err != nil {
+		t.Fatalf("saveAttackSurface: %v", err)
+	}
+	more := &checker.AttackSurface{
+		APIEndpoints: []checker.APIEndpoint{{URL: "https://example.com/api/v1/me", Method: "GET", Via: "fetch", Source: "https://example.com/app.js"}},
+	}
+	if _, err := saveAttackSurface(resultsDir, "eng-surface", more); err != nil {
+		t.Fatalf("saveAttackSurface: %v", err)
+	}
+
+	stored, err := loadAttackSurface(resultsDir, "eng-surface")
+	if err != nil || stored == nil {
+		t.Fatalf("loadAttackSurface: %v", err)
+	}
+	if len(stored.Forms) != 1 || len(stored.APIEndpoints) != 1 {
+		t.Fatalf("expected inventories to be merged, got %+v", stored)
+	}
+
+	output := &RunOutput{
+		Metadata:      RunMetadata{EngagementID: "eng-surface", StartAt: time.Now(), CompleteAt: time.Now()},
+		Results:       []checker.CheckResult{{Target: "https://example.com", Status: "ok"}},
+		AttackSurface: stored,
+	}
+	report, err := generateMarkdownReport(buildTemplateData(output, nil, "%.2f", nil))
+	if err != nil {
+		t.Fatalf("Failed to generate markdown report: %v", err)
+	}
+	for _, want := range []string{
+		"## Attack Surface Inventory",
+		"| https://example.com/login | POST | password, username | no | https://example.com/ |",
+		"| https://example.com/api/v1/me | GET | fetch | https://example.com/app.js |",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected report to contain %q", want)
+		}
+	}
+}
+
+func TestGenerateHTMLReport_Screenshots(t *testing.T) {
+	resultsDir := t.TempDir()
+	shot := &checker.Screenshot{
+		URL:        "https://example.com/",
+		CapturedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		PNG:        []byte("png-bytes"),
+		Thumbnail:  []byte("jpeg-bytes"),
+		SHA256:     "abc123",
+	}
+	if _, err := saveScreenshots(resultsDir, "eng-shots", []*checker.Screenshot{shot}); err != nil {
+		t.Fatalf("saveScreenshots: %v", err)
+	}
+	png, err := os.ReadFile(filepath.Join(resultsDir, "eng-shots", "screenshots", "abc123.png"))
+	if err != nil || string(png) != "png-bytes" {
+		t.Fatalf("expected PNG to be stored, got %q (%v)", png, err)
+	}
+
+	records, err := loadScreenshotIndex(resultsDir, "eng-shots")
+	if err != nil || len(records) != 1 {
+		t.Fatalf("loadScreenshotIndex: %v (%d records)", err, len(records))
+	}
+
+	output := &RunOutput{
+		Metadata:    RunMetadata{EngagementID: "eng-shots", StartAt: time.Now(), CompleteAt: time.Now()},
+		Results:     []checker.CheckResult{{Target: "https://example.com", Status: "ok"}},
+		Screenshots: records,
+	}
+	report, err := generateHTMLReport(buildTemplateData(output, nil, "%.2f", nil))
+	if err != nil {
+		t.Fatalf("Failed to generate HTML report: %v", err)
+	}
+	for _, want := range []string{
+		`<img src="data:image/jpeg;base64,anBlZy1ieXRlcw=="`,
+		`href="screenshots/abc123.png"`,
+		"SHA-256 abc123",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected report to contain %q", want)
+		}
+	}
+}
+
+func TestReportChartsEmbedded(t *testing.T) {
+	output := &RunOutput{
+		Metadata: RunMetadata{EngagementID: "eng-charts", StartAt: time.Now(), CompleteAt: time.Now()},
+		Results: []checker.CheckResult{
+			{Target: "https://a.example", Status: "ok", SecurityHeaders: &checker.SecurityHeadersResult{Grade: "A"}},
+			{Target: "https://b.example", Status: "ok", SecurityHeaders: &checker.SecurityHeadersResult{Grade: "F", Missing: []string{"Strict-Transport-Security"}}},
+		},
+	}
+	trends := []TelemetryRecord{
+		{Timestamp: time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC), SuccessRate: 100},
+		{Timestamp: time.Date(2026, 5, 2, 9, 0, 0, 0, time.UTC), SuccessRate: 50},
+	}
+	data := buildTemplateData(output, nil, "%.1f", trends)
+	if data.Charts.SuccessTrend == "" || data.Charts.HeaderGrades == "" {
+		t.Fatalf("expected trend and header grade charts, got %+v", data.Charts)
+	}
+
+	report, err := generateHTMLReport(data)
+	if err != nil {
+		t.Fatalf("Failed to generate HTML report: %v", err)
+	}
+	if !strings.Contains(report, `<figure class="chart"><svg xmlns="http://www.w3.org/2000/svg"`) {
+		t.Errorf("expected inline SVG charts in the HTML report")
+	}
+
+	var pdf bytes.Buffer
+	if err := writePDFReport(&pdf, data); err != nil {
+		t.Fatalf("writePDFReport: %v", err)
+	}
+	if !bytes.Contains(pdf.Bytes(), []byte("/Subtype /Image")) {
+		t.Errorf("expected chart images in the PDF report")
+	}
+}
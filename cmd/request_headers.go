@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Headers the transport manages itself; overriding them breaks requests.
+var reservedRequestHeaders = map[string]struct{}{
+	"Host":              {},
+	"Content-Length":    {},
+	"Transfer-Encoding": {},
+	"Connection":        {},
+}
+
+var headerNamePattern = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+// RequestConfig holds custom headers, the upstream proxy, and host pins
+// applied to every HTTP request made by checkers and the crawler.
+type RequestConfig struct {
+	Headers   []string // "Name: value" entries from --header
+	UserAgent string
+	Proxy     string   // --proxy URL, or "direct" to bypass a configured proxy
+	Resolve   []string // "host:ip" pins from --resolve
+}
+
+// resolveRequestHeaders merges custom headers from, in increasing precedence:
+// http.headers / http.user_agent in config, the engagement's
+// http.engagements.<id> overrides, and the --header / --user-agent flags.
+func resolveRequestHeaders(engagementID string, reqCfg RequestConfig) (http.Header, error) {
+	headers := http.Header{}
+
+	layers := []string{"http"}
+	if engagementID != "" {
+		layers = append(layers, "http.engagements."+engagementID)
+	}
+	for _, prefix := range layers {
+		configured := viper.GetStringMapString(prefix + ".headers")
+		names := make([]string, 0, len(configured))
+		for name := range configured {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if err := setCustomHeader(headers, name, configured[name]); err != nil {
+				return nil, fmt.Errorf("%s.headers: %w", prefix, err)
+			}
+		}
+		if ua := strings.TrimSpace(viper.GetString(prefix + ".user_agent")); ua != "" {
+			if err := setCustomHeader(headers, "User-Agent", ua); err != nil {
+				return nil, fmt.Errorf("%s.user_agent: %w", prefix, err)
+			}
+		}
+	}
+
+	for _, raw := range reqCfg.Headers {
+		name, value, ok := strings.Cut(raw, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid --header %q (expected \"Name: value\")", raw)
+		}
+		if err := setCustomHeader(headers, name, value); err != nil {
+			return nil, fmt.Errorf("--header: %w", err)
+		}
+	}
+	if ua := strings.TrimSpace(reqCfg.UserAgent); ua != "" {
+		if err := setCustomHeader(headers, "User-Agent", ua); err != nil {
+			return nil, fmt.Errorf("--user-agent: %w", err)
+		}
+	}
+
+	return headers, nil
+}
+
+func setCustomHeader(headers http.Header, name, value string) error {
+	name = strings.TrimSpace(name)
+	value = strings.TrimSpace(value)
+	if !headerNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid header name %q", name)
+	}
+	canonical := http.CanonicalHeaderKey(name)
+	if _, reserved := reservedRequestHeaders[canonical]; reserved {
+		return fmt.Errorf("header %s cannot be overridden", canonical)
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Errorf("header %s value must not contain line breaks", canonical)
+	}
+	headers.Set(canonical, value)
+	return nil
+}
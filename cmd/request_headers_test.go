@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestResolveRequestHeadersPrecedence(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	viper.Set("http.headers", map[string]string{"x-pentest-id": "default", "X-Team": "red"})
+	viper.Set("http.user_agent", "seca-default")
+	viper.Set("http.engagements.eng-1.headers", map[string]string{"X-Pentest-ID": "ENG-1"})
+
+	headers, err := resolveRequestHeaders("eng-1", RequestConfig{UserAgent: "roe-agent/2.0"})
+	if err != nil {
+		t.Fatalf("resolveRequestHeaders: %v", err)
+	}
+	if got := headers.Get("X-Pentest-ID"); got != "ENG-1" {
+		t.Fatalf("expected engagement override, got %q", got)
+	}
+	if got := headers.Get("X-Team"); got != "red" {
+		t.Fatalf("expected default header, got %q", got)
+	}
+	if got := headers.Get("User-Agent"); got != "roe-agent/2.0" {
+		t.Fatalf("expected --user-agent to win, got %q", got)
+	}
+
+	headers, err = resolveRequestHeaders("eng-1", RequestConfig{Headers: []string{"X-Pentest-ID:  cli-value "}})
+	if err != nil {
+		t.Fatalf("resolveRequestHeaders: %v", err)
+	}
+	if got := headers.Get("X-Pentest-ID"); got != "cli-value" {
+		t.Fatalf("expected --header to win, got %q", got)
+	}
+}
+
+func TestResolveRequestHeadersRejectsInvalid(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	cases := map[string]string{
+		"missing colon":    "X-Pentest-ID",
+		"bad name":         "X Pentest: 1",
+		"reserved":         "Host: evil.example.com",
+		"header splitting": "X-Test: a\r\nX-Injected: b",
+	}
+	for name, raw := range cases {
+		if _, err := resolveRequestHeaders("", RequestConfig{Headers: []string{raw}}); err == nil {
+			t.Errorf("%s: expected error for %q", name, raw)
+		}
+	}
+
+	viper.Set("http.headers", map[string]string{"Content-Length": "0"})
+	_, err := resolveRequestHeaders("", RequestConfig{})
+	if err == nil || !strings.Contains(err.Error(), "http.headers") {
+		t.Fatalf("expected config key in error, got %v", err)
+	}
+}
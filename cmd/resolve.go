@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/spf13/viper"
+)
+
+// defaultDNSPort is added to nameservers given without a port.
+const defaultDNSPort = "53"
+
+// resolveNameservers returns the nameservers for an engagement, or nil for
+// the system resolver. Precedence: --nameservers,
+// http.engagements.<id>.nameservers, dns.nameservers.
+func resolveNameservers(engagementID string, runtimeCfg CheckRuntimeConfig) ([]string, error) {
+	raw, source := runtimeCfg.DNS.Nameservers, "--nameservers"
+	if len(raw) == 0 && engagementID != "" {
+		raw, source = viper.GetStringSlice("http.engagements."+engagementID+".nameservers"), "http.engagements."+engagementID+".nameservers"
+	}
+	if len(raw) == 0 {
+		raw, source = viper.GetStringSlice("dns.nameservers"), "dns.nameservers"
+	}
+	var servers []string
+	for _, entry := range raw {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		server, err := normalizeNameserver(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		servers = append(servers, server)
+	}
+	return servers, nil
+}
+
+// normalizeNameserver validates a nameserver IP, adding port 53 when none is
+// given.
+func normalizeNameserver(entry string) (string, error) {
+	host, port, err := net.SplitHostPort(entry)
+	if err != nil {
+		host, port = strings.TrimSuffix(strings.TrimPrefix(entry, "["), "]"), defaultDNSPort
+	}
+	if net.ParseIP(host) == nil {
+		return "", fmt.Errorf("invalid nameserver %q (expected ip or ip:port)", entry)
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// resolveDialer returns the dialer for an engagement's HTTP traffic, or nil
+// when neither nameservers nor pins are configured. Pins come from
+// http.resolve, http.engagements.<id>.resolve and --resolve; a host pinned
+// at a higher level replaces the lower level's addresses.
+func resolveDialer(engagementID string, runtimeCfg CheckRuntimeConfig) (*checker.Dialer, error) {
+	servers, err := resolveNameservers(engagementID, runtimeCfg)
+	if err != nil {
+		return nil, err
+	}
+	dialer := &checker.Dialer{
+		Nameservers: servers,
+		Timeout:     time.Duration(runtimeCfg.TimeoutSecs) * time.Second,
+	}
+
+	type layer struct {
+		source string
+		pins   []string
+	}
+	layers := []layer{{"http.resolve", viper.GetStringSlice("http.resolve")}}
+	if engagementID != "" {
+		key := "http.engagements." + engagementID + ".resolve"
+		layers = append(layers, layer{key, viper.GetStringSlice(key)})
+	}
+	layers = append(layers, layer{"--resolve", runtimeCfg.Request.Resolve})
+	for _, l := range layers {
+		pinned := make(map[string]bool)
+		for _, raw := range l.pins {
+			host, ip, err := checker.ParseResolvePin(raw)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", l.source, err)
+			}
+			if !pinned[host] {
+				pinned[host] = true
+				delete(dialer.Pins, host)
+			}
+			dialer.Pin(host, ip)
+		}
+	}
+
+	if dialer.IsZero() {
+		return nil, nil
+	}
+	return dialer, nil
+}
+
+// printResolution reports custom nameservers and pinned hosts, if any.
+func printResolution(dialer *checker.Dialer) {
+	if dialer.IsZero() {
+		return
+	}
+	if len(dialer.Nameservers) > 0 {
+		fmt.Printf("%s Nameservers: %s\n", colorInfo("→"), strings.Join(dialer.Nameservers, ", "))
+	}
+	for _, host := range dialer.PinnedHosts() {
+		fmt.Printf("%s Pinned: %s -> %s\n", colorInfo("→"), host, strings.Join(dialer.Pinned(host), ", "))
+	}
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestResolveDialerPrecedence(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	viper.Set("dns.nameservers", []string{"10.0.0.53"})
+	viper.Set("http.engagements.eng-1.nameservers", []string{"192.0.2.53:5353"})
+	viper.Set("http.resolve", []string{"app.example.com:203.0.113.10", "api.example.com:203.0.113.11"})
+	viper.Set("http.engagements.eng-1.resolve", []string{"app.example.com:10.1.0.5"})
+
+	dialer, err := resolveDialer("eng-0", CheckRuntimeConfig{})
+	if err != nil || dialer == nil {
+		t.Fatalf("expected a dialer, got %v (%v)", dialer, err)
+	}
+	if !reflect.DeepEqual(dialer.Nameservers, []string{"10.0.0.53:53"}) {
+		t.Errorf("expected the default nameserver with port 53, got %v", dialer.Nameservers)
+	}
+	if got := dialer.Pinned("app.example.com"); !reflect.DeepEqual(got, []string{"203.0.113.10"}) {
+		t.Errorf("expected the default pin, got %v", got)
+	}
+
+	cfg := CheckRuntimeConfig{Request: RequestConfig{Resolve: []string{"api.example.com:10.1.0.6", "api.example.com:2001:db8::6"}}}
+	dialer, err = resolveDialer("eng-1", cfg)
+	if err != nil {
+		t.Fatalf("resolveDialer() error = %v", err)
+	}
+	if !reflect.DeepEqual(dialer.Nameservers, []string{"192.0.2.53:5353"}) {
+		t.Errorf("expected the engagement nameserver, got %v", dialer.Nameservers)
+	}
+	if got := dialer.Pinned("app.example.com"); !reflect.DeepEqual(got, []string{"10.1.0.5"}) {
+		t.Errorf("expected the engagement pin to replace the default, got %v", got)
+	}
+	if got := dialer.Pinned("api.example.com"); !reflect.DeepEqual(got, []string{"10.1.0.6", "2001:db8::6"}) {
+		t.Errorf("expected --resolve pins, got %v", got)
+	}
+
+	if _, err := resolveDialer("eng-1", CheckRuntimeConfig{Request: RequestConfig{Resolve: []string{"app.example.com"}}}); err == nil {
+		t.Error("expected an error for a pin without an address")
+	}
+	cfg = CheckRuntimeConfig{DNS: DNSConfig{Nameservers: []string{"ns1.example.com"}}}
+	if _, err := resolveDialer("eng-1", cfg); err == nil {
+		t.Error("expected an error for a nameserver that is not an IP")
+	}
+
+	viper.Reset()
+	if dialer, err := resolveDialer("eng-1", CheckRuntimeConfig{}); err != nil || dialer != nil {
+		t.Errorf("expected no dialer without configuration, got %v (%v)", dialer, err)
+	}
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+	"github.com/spf13/cobra"
+)
+
+// legacyResultsSchemaVersion is assumed for results files written before
+// schema_version existed.
+const legacyResultsSchemaVersion = 1
+
+// resultsMigration upgrades a results document from version from to from+1.
+// Migrations work on the raw top-level fields so anything they do not touch,
+// including fields this build does not know, is preserved.
+type resultsMigration struct {
+	from  int
+	apply func(doc map[string]json.RawMessage) error
+}
+
+var resultsMigrations = []resultsMigration{
+	// v1 → v2: copy run identity into metadata, rename audit_sha256.
+	{from: 1, apply: migrateResultsV1},
+}
+
+// runIdentityFields are written at the top level of version 1 files, where
+// reports never saw them; version 2 also records them in metadata.
+var runIdentityFields = []string{"operator", "engagement_id", "engagement_name", "started_at", "completed_at"}
+
+func migrateResultsV1(doc map[string]json.RawMessage) error {
+	metadata := make(map[string]json.RawMessage)
+	if raw, ok := doc["metadata"]; ok && !isJSONNull(raw) {
+		if err := json.Unmarshal(raw, &metadata); err != nil {
+			return fmt.Errorf("metadata: %w", err)
+		}
+	}
+	for _, field := range runIdentityFields {
+		if value, ok := doc[field]; ok && isJSONEmpty(metadata[field]) {
+			metadata[field] = value
+		}
+	}
+	if legacy, ok := metadata["audit_sha256"]; ok {
+		if isJSONEmpty(metadata["audit_hash"]) {
+			metadata["audit_hash"] = legacy
+		}
+		delete(metadata, "audit_sha256")
+	}
+	raw, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	doc["metadata"] = raw
+	return nil
+}
+
+// resultsDocumentVersion returns the schema_version of a results document.
+func resultsDocumentVersion(doc map[string]json.RawMessage) (int, error) {
+	raw, ok := doc["schema_version"]
+	if !ok || isJSONNull(raw) {
+		return legacyResultsSchemaVersion, nil
+	}
+	var version int
+	if err := json.Unmarshal(raw, &version); err != nil {
+		return 0, fmt.Errorf("schema_version: %w", err)
+	}
+	return version, nil
+}
+
+// migrateResultsDocument upgrades the top-level fields of a results document
+// to consts.ResultsSchemaVersion in place and returns the version it started
+// from. Documents from a newer seca-cli are rejected rather than misread.
+func migrateResultsDocument(doc map[string]json.RawMessage) (int, error) {
+	from, err := resultsDocumentVersion(doc)
+	if err != nil {
+		return 0, err
+	}
+	if from > consts.ResultsSchemaVersion {
+		return from, fmt.Errorf("results schema version %d is newer than this seca-cli supports (%d); upgrade seca-cli", from, consts.ResultsSchemaVersion)
+	}
+	for _, m := range resultsMigrations {
+		if m.from < from {
+			continue
+		}
+		if err := m.apply(doc); err != nil {
+			return from, fmt.Errorf("migrate results from version %d: %w", m.from, err)
+		}
+	}
+	doc["schema_version"] = json.RawMessage(fmt.Sprint(consts.ResultsSchemaVersion))
+	return from, nil
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+}
+
+// isJSONEmpty reports whether raw is missing, null, or an empty string.
+func isJSONEmpty(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return len(trimmed) == 0 || isJSONNull(trimmed) || bytes.Equal(trimmed, []byte(`""`))
+}
+
+// migrateResultsFile upgrades one results file in place. It returns the
+// version the file was at; files already current are left untouched.
+func migrateResultsFile(path string, dryRun bool) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return 0, err
+	}
+	from, err := migrateResultsDocument(doc)
+	if err != nil || from == consts.ResultsSchemaVersion || dryRun {
+		return from, err
+	}
+	data, err = json.MarshalIndent(doc, jsonPrefix, jsonIndent)
+	if err != nil {
+		return from, err
+	}
+	return from, fileutil.WriteFile(path, data, consts.DefaultFilePerm)
+}
+
+var resultsCmd = &cobra.Command{
+	Use:   "results",
+	Short: "Maintain stored results files",
+}
+
+var resultsMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade stored results files to the current schema version",
+	Long: `Results files record the schema_version they were written with. Reports
+upgrade older files in memory when loading them; migrate rewrites the files
+in place so other tools reading them see the current layout too.`,
+	Example: `  seca results migrate --id eng123
+  seca results migrate --all --dry-run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		all, _ := cmd.Flags().GetBool("all")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if (id == "") == !all {
+			return fmt.Errorf("pass either --id or --all")
+		}
+
+		ids := []string{id}
+		if all {
+			entries, err := os.ReadDir(appCtx.ResultsDir)
+			if err != nil {
+				return fmt.Errorf("read results directory: %w", err)
+			}
+			ids = ids[:0]
+			for _, entry := range entries {
+				if entry.IsDir() {
+					ids = append(ids, entry.Name())
+				}
+			}
+		}
+
+		out := cmd.OutOrStdout()
+		migrated, failed := 0, 0
+		for _, engagementID := range ids {
+			files, err := discoverResultFiles(appCtx.ResultsDir, engagementID)
+			if err != nil {
+				if all {
+					continue
+				}
+				return fmt.Errorf("discover result files: %w", err)
+			}
+			for _, name := range files {
+				path, err := resolveResultsPath(appCtx.ResultsDir, engagementID, name)
+				if err != nil {
+					return err
+				}
+				from, err := migrateResultsFile(path, dryRun)
+				switch {
+				case err != nil:
+					failed++
+					fmt.Fprintf(out, "%s %s/%s: %v\n", colorWarn("!"), engagementID, name, err)
+				case from == consts.ResultsSchemaVersion:
+					fmt.Fprintf(out, "%s %s/%s is up to date (v%d)\n", colorInfo("→"), engagementID, name, from)
+				default:
+					migrated++
+					verb := "migrated"
+					if dryRun {
+						verb = "would migrate"
+					}
+					fmt.Fprintf(out, "%s %s %s/%s from v%d to v%d\n", colorSuccess("✓"), verb, engagementID, name, from, consts.ResultsSchemaVersion)
+				}
+			}
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d results file(s) could not be migrated", failed)
+		}
+		if migrated == 0 {
+			fmt.Fprintf(out, "%s nothing to migrate\n", colorInfo("→"))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(resultsCmd)
+	resultsCmd.AddCommand(resultsMigrateCmd)
+
+	resultsMigrateCmd.Flags().String("id", "", "Engagement ID")
+	resultsMigrateCmd.Flags().Bool("all", false, "Migrate every engagement under the results directory")
+	resultsMigrateCmd.Flags().Bool("dry-run", false, "Report what would change without writing")
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+)
+
+// legacyResultsJSON is an unversioned results file: run identity at the top
+// level and the hash under its pre-rename key.
+const legacyResultsJSON = `{
+  "id": "run-1",
+  "engagement_id": "eng-1",
+  "engagement_name": "Legacy",
+  "operator": "alice",
+  "started_at": "2024-05-01T10:00:00Z",
+  "status": "completed",
+  "results": [{"target": "https://example.com/", "status": "ok"}],
+  "metadata": {"audit_sha256": "abc123", "total_targets": 1}
+}`
+
+func TestDecodeRunOutput_MigratesLegacyLayout(t *testing.T) {
+	var out RunOutput
+	if err := decodeRunOutput(strings.NewReader(legacyResultsJSON), &out, func(checker.CheckResult) bool { return true }); err != nil {
+		t.Fatalf("decodeRunOutput: %v", err)
+	}
+	if out.SchemaVersion != consts.ResultsSchemaVersion {
+		t.Errorf("expected schema version %d, got %d", consts.ResultsSchemaVersion, out.SchemaVersion)
+	}
+	meta := out.Metadata
+	if meta.Operator != "alice" || meta.EngagementID != "eng-1" || meta.EngagementName != "Legacy" || meta.StartAt.IsZero() {
+		t.Errorf("run identity was not migrated into metadata: %+v", meta)
+	}
+	if meta.AuditHash != "abc123" {
+		t.Errorf("expected audit_sha256 to become audit_hash, got %q", meta.AuditHash)
+	}
+}
+
+func TestMigrateResultsDocument_RejectsNewerVersion(t *testing.T) {
+	doc := map[string]json.RawMessage{"schema_version": json.RawMessage("99")}
+	if _, err := migrateResultsDocument(doc); err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Fatalf("expected a newer-version error, got %v", err)
+	}
+}
+
+func TestResultsMigrateCommand(t *testing.T) {
+	defer setupTestAppContext(t)()
+
+	dir := filepath.Join(globalAppContext.ResultsDir, "eng-1")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "http_results.json")
+	if err := os.WriteFile(path, []byte(legacyResultsJSON), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := resultsMigrateCmd
+		for _, name := range []string{"id", "all", "dry-run"} {
+			flag := cmd.Flags().Lookup(name)
+			_ = flag.Value.Set(flag.DefValue)
+		}
+		if err := cmd.ParseFlags(args); err != nil {
+			t.Fatal(err)
+		}
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		if err := cmd.RunE(cmd, nil); err != nil {
+			t.Fatalf("results migrate %v: %v", args, err)
+		}
+		return out.String()
+	}
+
+	if out := run("--id", "eng-1", "--dry-run"); !strings.Contains(out, "would migrate eng-1/http_results.json from v1") {
+		t.Fatalf("unexpected dry-run output %q", out)
+	}
+	if data, _ := os.ReadFile(path); string(data) != legacyResultsJSON {
+		t.Fatal("dry run must not rewrite the file")
+	}
+
+	if out := run("--all"); !strings.Contains(out, "migrated eng-1/http_results.json") {
+		t.Fatalf("unexpected output %q", out)
+	}
+	var migrated struct {
+		SchemaVersion int         `json:"schema_version"`
+		ID            string      `json:"id"`
+		Metadata      RunMetadata `json:"metadata"`
+	}
+	data, _ := os.ReadFile(path)
+	if err := json.Unmarshal(data, &migrated); err != nil {
+		t.Fatal(err)
+	}
+	if migrated.SchemaVersion != consts.ResultsSchemaVersion || migrated.ID != "run-1" || migrated.Metadata.Operator != "alice" {
+		t.Fatalf("unexpected migrated file %s", data)
+	}
+
+	if out := run("--id", "eng-1"); !strings.Contains(out, "up to date") {
+		t.Fatalf("expected a second migration to be a no-op, got %q", out)
+	}
+}
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+// resultsReadBufferSize is the read buffer used when streaming results files.
+const resultsReadBufferSize = 64 << 10
+
+// errStopDecoding ends decodeRunOutput once add asks for no more results.
+var errStopDecoding = errors.New("stop decoding")
+
+// decodeRunOutput reads a results file incrementally. Each entry of the
+// "results" array is decoded on its own and passed to add, so a run with tens
+// of thousands of results is never held in memory as raw JSON next to its
+// decoded form. The remaining top-level fields are upgraded to the current
+// schema version and decoded into out; its Results are left untouched. When
+// add returns false, decodeRunOutput stops reading and returns nil, leaving
+// out incomplete.
+func decodeRunOutput(r io.Reader, out *RunOutput, add func(checker.CheckResult) bool) error {
+	dec := json.NewDecoder(bufio.NewReaderSize(r, resultsReadBufferSize))
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	rest := make(map[string]json.RawMessage)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+		if !strings.EqualFold(key, "results") {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return fmt.Errorf("field %q: %w", key, err)
+			}
+			rest[key] = raw
+			continue
+		}
+		if err := decodeResultsArray(dec, add); err != nil {
+			if errors.Is(err, errStopDecoding) {
+				return nil
+			}
+			return err
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return err
+	}
+
+	// Migrations only rewrite top-level fields, so results stream as-is.
+	if _, err := migrateResultsDocument(rest); err != nil {
+		return err
+	}
+	// The remaining fields are small; round-trip them through the struct
+	// so its JSON tags and custom unmarshalers apply.
+	data, err := json.Marshal(rest)
+	if err != nil {
+		return err
+	}
+	results := out.Results
+	if err := json.Unmarshal(data, out); err != nil {
+		return err
+	}
+	out.Results = results
+	return nil
+}
+
+// decodeResultsArray streams the elements of a results array (or null) to add.
+func decodeResultsArray(dec *json.Decoder, add func(checker.CheckResult) bool) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("results: expected array, got %v", tok)
+	}
+	for i := 0; dec.More(); i++ {
+		var result checker.CheckResult
+		if err := dec.Decode(&result); err != nil {
+			return fmt.Errorf("results[%d]: %w", i, err)
+		}
+		if !add(result) {
+			return errStopDecoding
+		}
+	}
+	return expectDelim(dec, ']')
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("expected %q, got %v", want, tok)
+	}
+	return nil
+}
+
+// resultStream reads an engagement's check results back from its results
+// files each time it is ranged over, so reports over tens of thousands of
+// results never hold them all in memory. A read error ends the iteration
+// and is kept in err.
+type resultStream struct {
+	paths []string
+	err   error
+}
+
+func (s *resultStream) all(yield func(checker.CheckResult) bool) {
+	for _, path := range s.paths {
+		f, err := os.Open(path)
+		if err != nil {
+			s.err = err
+			return
+		}
+		stopped := false
+		err = decodeRunOutput(f, &RunOutput{}, func(r checker.CheckResult) bool {
+			stopped = !yield(r)
+			return !stopped
+		})
+		f.Close()
+		if err != nil {
+			s.err = fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+			return
+		}
+		if stopped {
+			return
+		}
+	}
+}
+
+// allResults yields the run's results, read back from the results files
+// when the output was loaded by streamRunOutputs.
+func (o *RunOutput) allResults() iter.Seq[checker.CheckResult] {
+	if o.stream != nil {
+		return o.stream.all
+	}
+	return slices.Values(o.Results)
+}
+
+// resultsErr returns the error that cut reading the results short, if any.
+func (o *RunOutput) resultsErr() error {
+	if o.stream != nil {
+		return o.stream.err
+	}
+	return nil
+}
+
+// writeRunOutputJSON writes output as indented JSON, the same as an indented
+// json.Encoder would, but encodes its results one at a time as they are read.
+func writeRunOutputJSON(w io.Writer, output *RunOutput) error {
+	if output.stream == nil {
+		enc := json.NewEncoder(w)
+		enc.SetIndent(jsonPrefix, jsonIndent)
+		return enc.Encode(output)
+	}
+
+	// Encode everything else around a null results field, then stream the
+	// array in its place. Only the top-level field has this indentation.
+	shell := *output
+	shell.Results, shell.stream = nil, nil
+	data, err := json.MarshalIndent(&shell, jsonPrefix, jsonIndent)
+	if err != nil {
+		return err
+	}
+	const field = "\n" + jsonPrefix + jsonIndent + `"results": `
+	head, tail, ok := bytes.Cut(data, []byte(field+"null"))
+	if !ok {
+		return errors.New("results field not found")
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.Write(head)
+	bw.WriteString(field + "[")
+	itemIndent := jsonPrefix + jsonIndent + jsonIndent
+	n := 0
+	for r := range output.allResults() {
+		item, err := json.MarshalIndent(r, itemIndent, jsonIndent)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			bw.WriteString(",")
+		}
+		bw.WriteString("\n" + itemIndent)
+		bw.Write(item)
+		n++
+	}
+	if n > 0 {
+		bw.WriteString("\n" + jsonPrefix + jsonIndent)
+	}
+	bw.WriteString("]")
+	bw.Write(tail)
+	bw.WriteString("\n")
+	return bw.Flush()
+}
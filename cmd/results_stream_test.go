@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func TestDecodeRunOutput_StreamsResults(t *testing.T) {
+	// Results before metadata, as hand-edited or third-party files may have.
+	input := `{
+  "results": [
+    {"target": "https://a.example.com/", "status": "ok"},
+    {"target": "https://b.example.com/", "status": "error", "error": "timeout"}
+  ],
+  "metadata": {"engagement_id": "eng-1", "operator": "alice", "total_targets": 2}
+}`
+
+	var out RunOutput
+	var got []checker.CheckResult
+	if err := decodeRunOutput(strings.NewReader(input), &out, func(r checker.CheckResult) bool {
+		got = append(got, r)
+		return true
+	}); err != nil {
+		t.Fatalf("decodeRunOutput: %v", err)
+	}
+	if len(got) != 2 || got[1].Target != "https://b.example.com/" || got[1].Error != "timeout" {
+		t.Fatalf("unexpected results %+v", got)
+	}
+	if out.Metadata.EngagementID != "eng-1" || out.Metadata.Operator != "alice" {
+		t.Fatalf("unexpected metadata %+v", out.Metadata)
+	}
+	if out.Results != nil {
+		t.Fatalf("results must only be passed to add, got %d on out", len(out.Results))
+	}
+}
+
+func TestDecodeRunOutput_NullResultsAndErrors(t *testing.T) {
+	var out RunOutput
+	calls := 0
+	add := func(checker.CheckResult) bool { calls++; return true }
+	if err := decodeRunOutput(strings.NewReader(`{"metadata":{},"results":null}`), &out, add); err != nil || calls != 0 {
+		t.Fatalf("expected null results to decode cleanly, got %v (%d calls)", err, calls)
+	}
+
+	for _, input := range []string{`[]`, `{"results": {}}`, `{"results": [{"target": 1}]}`, `{"results": [`} {
+		if err := decodeRunOutput(strings.NewReader(input), &RunOutput{}, add); err == nil {
+			t.Errorf("expected an error for %s", input)
+		}
+	}
+}
+
+// writeStreamFixture writes n results split over the http and dns results
+// files of an engagement.
+func writeStreamFixture(t *testing.T, resultsDir, id string, n int) {
+	t.Helper()
+	if _, err := ensureResultsDir(resultsDir, id); err != nil {
+		t.Fatal(err)
+	}
+	var http, dns []checker.CheckResult
+	for i := range n {
+		r := checker.CheckResult{Target: fmt.Sprintf("https://host%d.example.com", i), Status: "ok", Notes: "<b>a & b</b>"}
+		if i%2 == 0 {
+			http = append(http, r)
+		} else {
+			r.Status = "error"
+			dns = append(dns, r)
+		}
+	}
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	writeRunOutputFile(t, resultsDir, id, "http_results.json", RunOutput{
+		Metadata: RunMetadata{EngagementID: id, StartAt: start, CompleteAt: start.Add(time.Minute)},
+		Results:  http,
+	})
+	writeRunOutputFile(t, resultsDir, id, "dns_results.json", RunOutput{
+		Metadata: RunMetadata{EngagementID: id, StartAt: start, CompleteAt: start.Add(2 * time.Minute)},
+		Results:  dns,
+	})
+}
+
+func TestStreamRunOutputs_ReadsResultsFromDisk(t *testing.T) {
+	resultsDir := t.TempDir()
+	const id = "eng-stream"
+	writeStreamFixture(t, resultsDir, id, 200)
+
+	rendered, err := loadEngagementReport(resultsDir, id, "md")
+	if err != nil {
+		t.Fatalf("loadEngagementReport() error = %v", err)
+	}
+	if rendered.Output.Results != nil || rendered.Output.Metadata.TotalTargets != 200 {
+		t.Fatalf("expected 200 results left on disk, got %d held (%d total)", len(rendered.Output.Results), rendered.Output.Metadata.TotalTargets)
+	}
+	var buf bytes.Buffer
+	if err := rendered.Render(&buf); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "### 200. https://host199.example.com\n") {
+		t.Fatal("expected every result in the report")
+	}
+
+	// Each pass reads the files again, and stopping early leaves the rest
+	// of a file unread: a corrupt tail is only reported when reached.
+	path := filepath.Join(resultsDir, id, "http_results.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cut := bytes.Index(data, []byte("https://host2.example.com"))
+	if err := os.WriteFile(path, data[:cut], 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for range rendered.Output.allResults() {
+		break
+	}
+	if err := rendered.Output.resultsErr(); err != nil {
+		t.Fatalf("expected an early stop to read no further, got %v", err)
+	}
+	if err := rendered.Render(io.Discard); err == nil || !strings.Contains(err.Error(), "http_results.json") {
+		t.Fatalf("expected the truncated file to fail the report, got %v", err)
+	}
+}
+
+func TestWriteRunOutputJSON_MatchesEncoder(t *testing.T) {
+	resultsDir := t.TempDir()
+	const id = "eng-json"
+	writeStreamFixture(t, resultsDir, id, 5)
+
+	streamed, _, err := streamRunOutputs(resultsDir, id)
+	if err != nil {
+		t.Fatalf("streamRunOutputs() error = %v", err)
+	}
+	aggregated, _, err := aggregateRunOutputs(resultsDir, id)
+	if err != nil {
+		t.Fatalf("aggregateRunOutputs() error = %v", err)
+	}
+
+	var got, want bytes.Buffer
+	if err := writeRunOutputJSON(&got, streamed); err != nil {
+		t.Fatalf("writeRunOutputJSON() error = %v", err)
+	}
+	enc := json.NewEncoder(&want)
+	enc.SetIndent(jsonPrefix, jsonIndent)
+	if err := enc.Encode(aggregated); err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != want.String() {
+		t.Fatalf("streamed JSON differs from encoded JSON:\n%s\nwant:\n%s", got.String(), want.String())
+	}
+}
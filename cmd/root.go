@@ -57,12 +57,22 @@ Documentation:
 		}
 
 		// Config file is optional, but we should be aware if there's an error reading it
-		if err := viper.ReadInConfig(); err != nil {
-			// Only log if a config file was explicitly specified
-			if cfgFile != "" {
-				fmt.Fprintf(os.Stderr, "Warning: Error reading config file %s: %v\n", cfgFile, err)
-			}
-			// Otherwise, it's fine if no config file exists (using defaults)
+		configErr := viper.ReadInConfig()
+
+		// Precedence: flags > SECA_* environment > profile > config file > defaults
+		configureEnvOverrides(viper.GetViper())
+		profile := activeProfile(cmd)
+		if err := applyConfigProfile(viper.GetViper(), profile); err != nil {
+			return err
+		}
+
+		if err := configureLogging(cmd); err != nil {
+			return err
+		}
+		// Only log if a config file was explicitly specified; otherwise it's
+		// fine if no config file exists (using defaults)
+		if configErr != nil && cfgFile != "" {
+			cliLog().Warnw("config_read_failed", "path", cfgFile, "error", configErr)
 		}
 
 		applyConfigDefaults(cmd)
@@ -79,8 +89,7 @@ Documentation:
 			dataDir, err := getResultsDir()
 			if err != nil {
 				// Fallback to old behavior if data directory fails
-				fmt.Fprintf(os.Stderr, "Warning: Could not get data directory: %v\n", err)
-				fmt.Fprintf(os.Stderr, "Falling back to ./results\n")
+				cliLog().Warnw("data_dir_unavailable", "error", err, "fallback", "./results")
 				appCtx.ResultsDir = "./results"
 			} else {
 				appCtx.ResultsDir = dataDir
@@ -92,9 +101,7 @@ Documentation:
 			return fmt.Errorf("failed to create results directory: %s", err.Error())
 		}
 
-		// init logger
-		l, _ := zap.NewProduction()
-		appCtx.Logger = l.Sugar()
+		appCtx.Logger = cliLog()
 
 		// Get operator from flag
 		operatorFlag, _ := cmd.Flags().GetString("operator")
@@ -112,7 +119,9 @@ Documentation:
 				appCtx.Operator = env
 			}
 		}
-		if appCtx.Operator == "" {
+		// Shell completion only reads the engagement store, so it must not
+		// fail for want of an operator.
+		if appCtx.Operator == "" && !isCompletionRequest(cmd) {
 			return fmt.Errorf("operator identity is required (use --operator or set USER env)")
 		}
 
@@ -121,7 +130,7 @@ Documentation:
 			appCtx.ResultsDir = abs
 		}
 
-		appCtx.Logger.Infof("operator=%s results_dir=%s", appCtx.Operator, appCtx.ResultsDir)
+		appCtx.Logger.Infow("session_started", "command", cmd.CommandPath(), "operator", appCtx.Operator, "results_dir", appCtx.ResultsDir, "profile", profile)
 
 		// Initialize DDD services
 		dataDir, err := getDataDir()
@@ -146,9 +155,26 @@ Documentation:
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	registerSDKCheckers()
+	registerIDCompletions(rootCmd)
+	err := rootCmd.Execute()
+	if err != nil {
+		cliLog().Debugw("command_failed", "error", err)
+	}
+	if globalAppContext != nil && globalAppContext.Services != nil {
+		// Close writes audit entries still buffered; losing them fails the
+		// command even when it otherwise succeeded.
+		if closeErr := globalAppContext.Services.Close(); closeErr != nil {
+			cliLog().Warnw("services_close_failed", "error", closeErr)
+			if err == nil {
+				err = fmt.Errorf("failed to write audit trail: %w", closeErr)
+			}
+		}
+	}
+	syncLogger()
+	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		os.Exit(exitCodeFor(err))
 	}
 }
 
@@ -187,12 +213,21 @@ func init() {
 	// operator persistent flag (default from USER env)
 	defaultOperator := cliConfig.Defaults.Operator
 	rootCmd.PersistentFlags().StringP("operator", "o", defaultOperator, "operator name (or set via USER env)")
+	rootCmd.PersistentFlags().String("log-level", defaultLogLevel, "log verbosity: debug|info|warn|error (or log.level in config)")
+	rootCmd.PersistentFlags().String("log-file", "", "append JSON log lines to this file; stderr then shows only warnings and errors")
+	rootCmd.PersistentFlags().String("profile", "", "config profile to apply from profiles.<name> in the config file (or set via SECA_PROFILE env)")
+
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &exitCodeError{code: exitUsage, err: err}
+	})
 
 	// add subcommands
 	rootCmd.AddCommand(engagementCmd)
 	rootCmd.AddCommand(checkCmd)
 	rootCmd.AddCommand(auditCmd)
 	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(findingsCmd)
+	rootCmd.AddCommand(baselineCmd)
 	rootCmd.AddCommand(tuiCmd)
 	rootCmd.AddCommand(versionCmd)
 }
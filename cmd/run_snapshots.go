@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	checkapp "github.com/khanhnv2901/seca-cli/internal/application/check"
+	"github.com/khanhnv2901/seca-cli/internal/domain/check"
+)
+
+const (
+	// defaultFlushEvery is how many results a run records between saves of
+	// its snapshot file.
+	defaultFlushEvery = 25
+	// defaultFlushInterval is the longest, in seconds, a run goes without
+	// saving new results to its snapshot file.
+	defaultFlushInterval = 60
+)
+
+// enableRunSnapshots saves checkRun's results to a snapshot file while it
+// runs, every --flush-every results or --flush-interval seconds, so a crash
+// keeps the checks already in the audit trail. It first warns about
+// snapshots left behind by earlier runs of the same check that never
+// finished. Call it after SetCheckType.
+func enableRunSnapshots(appCtx *AppContext, checkRun *check.CheckRun, runtimeCfg CheckRuntimeConfig) {
+	checkType := checkRun.Metadata().CheckType
+	for _, path := range unfinishedRunSnapshots(appCtx.ResultsDir, checkRun.EngagementID(), checkType) {
+		fmt.Printf("%s An earlier %s run did not finish; its completed results are in %s\n", colorWarn("!"), checkType, path)
+	}
+
+	appCtx.Services.CheckOrchestrator.EnableSnapshots(checkRun, runSnapshotPolicy(checkRun.EngagementID(), runtimeCfg))
+}
+
+// runSnapshotPolicy is the snapshot policy set by --flush-every and
+// --flush-interval. Failed saves are logged.
+func runSnapshotPolicy(engagementID string, runtimeCfg CheckRuntimeConfig) checkapp.SnapshotPolicy {
+	return checkapp.SnapshotPolicy{
+		Every:    runtimeCfg.FlushEvery,
+		Interval: time.Duration(runtimeCfg.FlushInterval) * time.Second,
+		OnError: func(err error) {
+			cliLog().Warnw("run_snapshot_failed", "engagement_id", engagementID, "error", err)
+		},
+	}
+}
+
+// unfinishedRunSnapshots lists the snapshot files of checkType runs on the
+// engagement, oldest run first. A finished run removes its snapshot.
+func unfinishedRunSnapshots(resultsDir, engagementID, checkType string) []string {
+	prefix := strings.TrimSuffix(check.ResultsFilename(checkType), ".json") + "."
+	matches, err := filepath.Glob(filepath.Join(resultsDir, engagementID, prefix+"*.partial.json"))
+	if err != nil {
+		return nil
+	}
+	return matches
+}
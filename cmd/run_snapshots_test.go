@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/khanhnv2901/seca-cli/internal/domain/check"
+)
+
+func TestRunSnapshotsKeepCompletedResults(t *testing.T) {
+	cleanup := setupTestAppContextWithServices(t)
+	defer cleanup()
+	appCtx := globalAppContext
+	ctx := context.Background()
+	orchestrator := appCtx.Services.CheckOrchestrator
+
+	run, err := check.NewCheckRun("eng-snap", "Snapshot", "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	run.SetCheckType(check.CheckTypeHTTP)
+	if err := run.Start(); err != nil {
+		t.Fatal(err)
+	}
+	cfg := appCtx.Config.Check
+	cfg.FlushEvery, cfg.FlushInterval = 2, 0
+	enableRunSnapshots(appCtx, run, cfg)
+
+	add := func(target string) {
+		t.Helper()
+		result, err := check.NewResult(target, check.CheckStatusOK)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := orchestrator.AddCheckResult(ctx, run, result); err != nil {
+			t.Fatal(err)
+		}
+	}
+	snapshot := filepath.Join(appCtx.ResultsDir, "eng-snap", check.SnapshotFilename(check.CheckTypeHTTP, run.ID()))
+
+	add("https://a.example.com")
+	if _, err := os.Stat(snapshot); !os.IsNotExist(err) {
+		t.Fatalf("no snapshot expected before --flush-every results, got %v", err)
+	}
+	add("https://b.example.com")
+	add("https://c.example.com")
+
+	data, err := os.ReadFile(snapshot)
+	if err != nil {
+		t.Fatalf("expected a snapshot after 2 results: %v", err)
+	}
+	var saved struct {
+		Status  string            `json:"status"`
+		Results []json.RawMessage `json:"results"`
+	}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+	if saved.Status != string(check.RunStatusRunning) || len(saved.Results) != 2 {
+		t.Errorf("snapshot should hold the 2 results saved while running, got %s with %d", saved.Status, len(saved.Results))
+	}
+
+	// The next run of the same check is told about the unfinished one.
+	if got := unfinishedRunSnapshots(appCtx.ResultsDir, "eng-snap", check.CheckTypeHTTP); len(got) != 1 || got[0] != snapshot {
+		t.Errorf("unfinishedRunSnapshots() = %v", got)
+	}
+	if got := unfinishedRunSnapshots(appCtx.ResultsDir, "eng-snap", check.CheckTypeDNS); len(got) != 0 {
+		t.Errorf("snapshots of other check types should not be listed, got %v", got)
+	}
+
+	if err := orchestrator.FinalizeCheckRun(ctx, run, "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(snapshot); !os.IsNotExist(err) {
+		t.Errorf("finalizing should remove the snapshot, got %v", err)
+	}
+	final, err := os.ReadFile(filepath.Join(appCtx.ResultsDir, "eng-snap", "http_results.json"))
+	if err != nil || !strings.Contains(string(final), "https://c.example.com") {
+		t.Errorf("results file should hold every result: %v", err)
+	}
+}
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/issuesync"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+	"github.com/spf13/cobra"
+)
+
+// Process exit codes. Scripts and the GitHub Action rely on them, so existing
+// codes must keep their meaning; see "Exit Codes" in the command reference.
+const (
+	exitOK           = 0
+	exitError        = 1
+	exitUsage        = 2
+	exitFindings     = 3
+	exitTargetErrors = 4
+	exitStoppedEarly = 5
+)
+
+// exitCodeError is a command error that ends the process with a specific
+// exit code instead of the general exitError.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string { return e.err.Error() }
+func (e *exitCodeError) Unwrap() error { return e.err }
+
+// exitCodeFor returns the process exit code for a command's error.
+func exitCodeFor(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	var coded *exitCodeError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+	return exitError
+}
+
+// runSummaryVersion is bumped only for incompatible changes to RunSummary;
+// new fields may be added without a bump.
+const runSummaryVersion = 1
+
+// RunSummary is the machine-readable outcome of a check run, written with
+// --summary-file.
+type RunSummary struct {
+	Version         int                 `json:"version"`
+	EngagementID    string              `json:"engagement_id"`
+	Check           string              `json:"check"`
+	StartedAt       time.Time           `json:"started_at"`
+	DurationSeconds float64             `json:"duration_seconds"`
+	Targets         int                 `json:"targets"`
+	Succeeded       int                 `json:"succeeded"` // Warnings included
+	Failed          int                 `json:"failed"`    // Blocked and unreachable targets included
+	Warnings        int                 `json:"warnings,omitempty"`
+	Blocked         int                 `json:"blocked,omitempty"`
+	Unreachable     int                 `json:"unreachable,omitempty"`
+	Skipped         int                 `json:"skipped,omitempty"`
+	StopReason      string              `json:"stop_reason,omitempty"`
+	Findings        RunSummaryFindings  `json:"findings"`
+	Baseline        *RunSummaryBaseline `json:"baseline,omitempty"`
+	HashAlgorithm   string              `json:"hash_algorithm"`
+	AuditHash       string              `json:"audit_hash"`
+	ResultsFile     string              `json:"results_file"`
+	FailOn          string              `json:"fail_on"`
+	ExitCode        int                 `json:"exit_code"`
+	ExitReason      string              `json:"exit_reason,omitempty"`
+
+	// gated are the findings --fail-on is applied to: the new findings when
+	// a baseline is set, otherwise all findings.
+	gated []string
+}
+
+// RunSummaryFindings counts the open findings of a run by severity.
+type RunSummaryFindings struct {
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Medium   int `json:"medium"`
+	Low      int `json:"low"`
+	Info     int `json:"info"`
+	Total    int `json:"total"`
+}
+
+func (f *RunSummaryFindings) add(severity string) {
+	switch strings.ToLower(severity) {
+	case "critical":
+		f.Critical++
+	case "high":
+		f.High++
+	case "medium":
+		f.Medium++
+	case "low":
+		f.Low++
+	case "info":
+		f.Info++
+	}
+	f.Total++
+}
+
+// RunSummaryBaseline compares the run's findings with the engagement baseline.
+type RunSummaryBaseline struct {
+	SetAt       time.Time          `json:"set_at"`
+	New         RunSummaryFindings `json:"new"`
+	Accepted    int                `json:"accepted"`
+	NewFindings []BaselineFinding  `json:"new_findings"`
+}
+
+// runOutcome is what a check command knows once its run is sealed.
+type runOutcome struct {
+	EngagementID  string
+	Check         string
+	Results       []checker.CheckResult
+	StartedAt     time.Time
+	StopReason    string
+	HashAlgorithm string
+	AuditHash     string
+	ResultsFile   string
+}
+
+func newRunSummary(resultsDir string, run runOutcome) *RunSummary {
+	counts := countStatuses(run.Results)
+	s := &RunSummary{
+		Version:         runSummaryVersion,
+		EngagementID:    run.EngagementID,
+		Check:           run.Check,
+		StartedAt:       run.StartedAt.UTC(),
+		DurationSeconds: time.Since(run.StartedAt).Seconds(),
+		Targets:         len(run.Results),
+		Succeeded:       counts.Succeeded(),
+		Failed:          counts.Failed(),
+		Warnings:        counts.Warning,
+		Blocked:         counts.Blocked,
+		Unreachable:     counts.Unreachable,
+		Skipped:         counts.Skipped,
+		StopReason:      run.StopReason,
+		HashAlgorithm:   run.HashAlgorithm,
+		AuditHash:       run.AuditHash,
+		ResultsFile:     run.ResultsFile,
+	}
+
+	findings := findingsFromResults(run.Results)
+	for _, f := range findings {
+		s.Findings.add(f.Severity)
+		s.gated = append(s.gated, f.Severity)
+	}
+
+	baseline, err := loadFindingsBaseline(resultsDir, run.EngagementID)
+	if err != nil {
+		cliLog().Warnw("baseline_load_failed", "engagement_id", run.EngagementID, "error", err)
+	} else if baseline != nil {
+		diff := baseline.diff(run.EngagementID, checker.BuildVulnerabilityReport(run.Results, "", "", "").Vulnerabilities)
+		s.Baseline = &RunSummaryBaseline{SetAt: diff.SetAt, Accepted: diff.Accepted, NewFindings: diff.New}
+		s.gated = s.gated[:0]
+		for _, f := range diff.New {
+			s.Baseline.New.add(f.Severity)
+			s.gated = append(s.gated, f.Severity)
+		}
+	}
+	return s
+}
+
+// applyExitPolicy sets the summary's exit code. Conditions are checked in
+// exit code order, so findings win over target errors.
+func (s *RunSummary) applyExitPolicy(failOn string, failOnErrors bool) {
+	s.FailOn = failOn
+	s.ExitCode, s.ExitReason = exitOK, ""
+
+	if failOn != failOnNone {
+		count := 0
+		for _, severity := range s.gated {
+			if issuesync.SeverityAtLeast(severity, failOn) {
+				count++
+			}
+		}
+		if count > 0 {
+			scope := "findings"
+			if s.Baseline != nil {
+				scope = "new findings (not in baseline)"
+			}
+			s.ExitCode = exitFindings
+			s.ExitReason = fmt.Sprintf("%d %s at or above %s severity", count, scope, failOn)
+			return
+		}
+	}
+	if !failOnErrors {
+		return
+	}
+	if s.Failed > 0 {
+		s.ExitCode = exitTargetErrors
+		s.ExitReason = fmt.Sprintf("%d of %d targets could not be checked", s.Failed, s.Targets)
+		if breakdown := (statusCounts{Blocked: s.Blocked, Unreachable: s.Unreachable}).failureBreakdown(); breakdown != "" {
+			s.ExitReason += " (" + breakdown + ")"
+		}
+		return
+	}
+	if s.StopReason != "" {
+		s.ExitCode = exitStoppedEarly
+		s.ExitReason = "run stopped early: " + s.StopReason
+	}
+}
+
+// finishCheckRun writes the run summary requested with --summary-file and
+// turns a met --fail-on condition into the command's exit code.
+func finishCheckRun(cmd *cobra.Command, resultsDir string, run runOutcome) error {
+	summary := newRunSummary(resultsDir, run)
+	failOn := cmd.Flag("fail-on").Value.String()
+	failOnErrors, _ := cmd.Flags().GetBool("fail-on-errors")
+	summary.applyExitPolicy(failOn, failOnErrors)
+
+	if path, _ := cmd.Flags().GetString("summary-file"); path != "" {
+		data, err := json.MarshalIndent(summary, jsonPrefix, jsonIndent)
+		if err != nil {
+			return err
+		}
+		if err := fileutil.WriteFile(path, data, consts.DefaultFilePerm); err != nil {
+			return fmt.Errorf("failed to write summary file: %w", err)
+		}
+		fmt.Printf("%s Summary: %s\n", colorSuccess("→"), path)
+	}
+
+	if summary.ExitCode != exitOK {
+		return &exitCodeError{code: summary.ExitCode, err: errors.New(summary.ExitReason)}
+	}
+	return nil
+}
+
+// failOnNone disables the findings exit condition.
+const failOnNone = "none"
+
+// failOnValue is a pflag.Value that accepts a severity threshold for
+// --fail-on, so a typo is rejected before any target is checked.
+type failOnValue struct {
+	severity string
+}
+
+func (v *failOnValue) String() string {
+	if v.severity == "" {
+		return failOnNone
+	}
+	return v.severity
+}
+
+func (v *failOnValue) Set(s string) error {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case failOnNone, "info", "low", "medium", "high", "critical":
+		v.severity = s
+		return nil
+	}
+	return fmt.Errorf("must be none, info, low, medium, high, or critical")
+}
+
+func (v *failOnValue) Type() string {
+	return "severity"
+}
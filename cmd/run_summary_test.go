@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func TestNewRunSummaryCountsFindingsAndBaseline(t *testing.T) {
+	resultsDir := t.TempDir()
+	const id = "eng-summary"
+	results := []checker.CheckResult{
+		{
+			Target:          "https://example.com",
+			Status:          "ok",
+			SecurityHeaders: checker.AnalyzeSecurityHeaders(map[string][]string{"X-Content-Type-Options": {"nosniff"}}),
+		},
+		{Target: "https://down.example.com", Status: "error", Error: "timeout"},
+	}
+	run := runOutcome{EngagementID: id, Check: "http", Results: results, StartedAt: time.Now(), HashAlgorithm: "sha256", AuditHash: "abc"}
+
+	summary := newRunSummary(resultsDir, run)
+	if summary.Version != runSummaryVersion || summary.Targets != 2 || summary.Succeeded != 1 || summary.Failed != 1 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	if summary.Findings.Total == 0 || summary.Baseline != nil {
+		t.Fatalf("expected findings and no baseline, got %+v", summary)
+	}
+
+	// Accept everything but the first finding; only it is new.
+	all := baselineFindings(id, checker.BuildVulnerabilityReport(results, "", "", "").Vulnerabilities)
+	if err := saveFindingsBaseline(resultsDir, id, &FindingsBaseline{SetAt: time.Now(), Findings: all[1:]}); err != nil {
+		t.Fatal(err)
+	}
+	summary = newRunSummary(resultsDir, run)
+	if summary.Baseline == nil || summary.Baseline.New.Total != 1 || summary.Baseline.Accepted != len(all)-1 {
+		t.Fatalf("unexpected baseline section: %+v", summary.Baseline)
+	}
+	if len(summary.gated) != 1 || summary.gated[0] != all[0].Severity {
+		t.Fatalf("expected only the new finding to be gated, got %v", summary.gated)
+	}
+}
+
+func TestApplyExitPolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		summary      RunSummary
+		failOn       string
+		failOnErrors bool
+		want         int
+	}{
+		{"no policy", RunSummary{gated: []string{"Critical"}, Failed: 1}, failOnNone, false, exitOK},
+		{"finding at threshold", RunSummary{gated: []string{"Low", "High"}}, "high", false, exitFindings},
+		{"finding below threshold", RunSummary{gated: []string{"Medium"}}, "high", false, exitOK},
+		{"findings win over errors", RunSummary{gated: []string{"Critical"}, Failed: 1}, "critical", true, exitFindings},
+		{"target errors", RunSummary{Failed: 2, Targets: 3}, failOnNone, true, exitTargetErrors},
+		{"stopped early", RunSummary{StopReason: "interrupted"}, failOnNone, true, exitStoppedEarly},
+		{"errors ignored without flag", RunSummary{Failed: 2, StopReason: "budget"}, "low", false, exitOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.summary.applyExitPolicy(tt.failOn, tt.failOnErrors)
+			if tt.summary.ExitCode != tt.want {
+				t.Fatalf("exit code = %d (%s), want %d", tt.summary.ExitCode, tt.summary.ExitReason, tt.want)
+			}
+			if (tt.want == exitOK) != (tt.summary.ExitReason == "") {
+				t.Fatalf("unexpected exit reason %q", tt.summary.ExitReason)
+			}
+		})
+	}
+}
+
+func TestExitCodeFor(t *testing.T) {
+	if got := exitCodeFor(nil); got != exitOK {
+		t.Fatalf("nil error: got %d", got)
+	}
+	if got := exitCodeFor(errors.New("boom")); got != exitError {
+		t.Fatalf("plain error: got %d", got)
+	}
+	wrapped := fmt.Errorf("run: %w", &exitCodeError{code: exitFindings, err: errors.New("findings")})
+	if got := exitCodeFor(wrapped); got != exitFindings {
+		t.Fatalf("wrapped exit code error: got %d", got)
+	}
+}
+
+func TestFailOnValue(t *testing.T) {
+	var v failOnValue
+	if v.String() != failOnNone {
+		t.Fatalf("default = %q", v.String())
+	}
+	if err := v.Set(" HIGH "); err != nil || v.String() != "high" {
+		t.Fatalf("Set(HIGH) = %v, value %q", err, v.String())
+	}
+	if err := v.Set("severe"); err == nil {
+		t.Fatal("expected error for unknown severity")
+	}
+}
@@ -0,0 +1,507 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/domain/audit"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+	"github.com/spf13/cobra"
+)
+
+// scopeTagsFilename maps scope entries to the tags given in imported CSV files
+// or derived from nmap service names.
+const scopeTagsFilename = "scope_tags.json"
+
+const (
+	scopeFormatList = "list"
+	scopeFormatCSV  = "csv"
+	scopeFormatNmap = "nmap"
+)
+
+// ImportedTarget is one scope entry read from an import file.
+type ImportedTarget struct {
+	Target string
+	Tags   []string
+}
+
+// detectScopeFormat picks the import format from the file extension, falling
+// back to sniffing for nmap XML.
+func detectScopeFormat(path string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return scopeFormatCSV
+	case ".xml":
+		return scopeFormatNmap
+	}
+	head := bytes.TrimSpace(data)
+	if bytes.HasPrefix(head, []byte("<?xml")) || bytes.HasPrefix(head, []byte("<nmaprun")) {
+		return scopeFormatNmap
+	}
+	return scopeFormatList
+}
+
+// parseScopeImport reads targets in the given format. Entries are returned in
+// file order with duplicates inside the file merged.
+func parseScopeImport(data []byte, format string) ([]ImportedTarget, error) {
+	var (
+		targets []ImportedTarget
+		err     error
+	)
+	switch format {
+	case scopeFormatList:
+		targets, err = parseTargetList(data)
+	case scopeFormatCSV:
+		targets, err = parseTargetCSV(data)
+	case scopeFormatNmap:
+		targets, err = parseNmapXML(data)
+	default:
+		return nil, fmt.Errorf("unsupported format %q (use list, csv, or nmap)", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mergeImportedTargets(targets), nil
+}
+
+// parseTargetList reads one target per line; blank lines and lines starting
+// with # are skipped.
+func parseTargetList(data []byte) ([]ImportedTarget, error) {
+	var targets []ImportedTarget
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		targets = append(targets, ImportedTarget{Target: line})
+	}
+	return targets, scanner.Err()
+}
+
+var (
+	csvTargetColumns = []string{"target", "host", "hostname", "url", "scope", "asset"}
+	csvTagsColumns   = []string{"tags", "tag", "labels"}
+)
+
+// parseTargetCSV reads a CSV file of targets and tags. With a header row the
+// target and tags columns are found by name; without one the first column is
+// the target and every other non-empty column is a tag. Tags inside a cell are
+// separated by ";" or "|".
+func parseTargetCSV(data []byte) ([]ImportedTarget, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	r.Comment = '#'
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parse CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	targetCol, tagCols := 0, []int(nil)
+	if col := csvColumn(records[0], csvTargetColumns); col >= 0 {
+		targetCol = col
+		if tc := csvColumn(records[0], csvTagsColumns); tc >= 0 {
+			tagCols = []int{tc}
+		}
+		records = records[1:]
+	} else {
+		for i := 1; i < len(records[0]); i++ {
+			tagCols = append(tagCols, i)
+		}
+	}
+
+	var targets []ImportedTarget
+	for _, rec := range records {
+		if targetCol >= len(rec) || strings.TrimSpace(rec[targetCol]) == "" {
+			continue
+		}
+		t := ImportedTarget{Target: strings.TrimSpace(rec[targetCol])}
+		cols := tagCols
+		if cols == nil {
+			for i := range rec {
+				if i != targetCol {
+					cols = append(cols, i)
+				}
+			}
+		}
+		for _, c := range cols {
+			if c < len(rec) {
+				t.Tags = append(t.Tags, splitTags(rec[c])...)
+			}
+		}
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
+
+func csvColumn(header []string, names []string) int {
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(h))
+		for _, name := range names {
+			if h == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func splitTags(cell string) []string {
+	var tags []string
+	for _, tag := range strings.FieldsFunc(cell, func(r rune) bool { return r == ';' || r == '|' }) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// nmapRun is the subset of nmap's -oX output used for scope import.
+type nmapRun struct {
+	Hosts []nmapHost `xml:"host"`
+}
+
+type nmapHost struct {
+	Status struct {
+		State string `xml:"state,attr"`
+	} `xml:"status"`
+	Addresses []struct {
+		Addr     string `xml:"addr,attr"`
+		AddrType string `xml:"addrtype,attr"`
+	} `xml:"address"`
+	Hostnames []struct {
+		Name string `xml:"name,attr"`
+		Type string `xml:"type,attr"`
+	} `xml:"hostnames>hostname"`
+	Ports []struct {
+		Protocol string `xml:"protocol,attr"`
+		PortID   int    `xml:"portid,attr"`
+		State    struct {
+			State string `xml:"state,attr"`
+		} `xml:"state"`
+		Service struct {
+			Name   string `xml:"name,attr"`
+			Tunnel string `xml:"tunnel,attr"`
+		} `xml:"service"`
+	} `xml:"ports>port"`
+}
+
+// name returns the hostname the operator scanned, then the PTR name, then the
+// IP address.
+func (h nmapHost) name() string {
+	for _, hn := range h.Hostnames {
+		if hn.Type == "user" && hn.Name != "" {
+			return hn.Name
+		}
+	}
+	for _, hn := range h.Hostnames {
+		if hn.Name != "" {
+			return hn.Name
+		}
+	}
+	for _, a := range h.Addresses {
+		if a.AddrType != "mac" && a.Addr != "" {
+			return a.Addr
+		}
+	}
+	return ""
+}
+
+// parseNmapXML turns hosts that are up into scope entries. Open HTTP(S) ports
+// become URLs, other open ports become host:port, and hosts without open ports
+// are imported as bare hosts. Service names become tags.
+func parseNmapXML(data []byte) ([]ImportedTarget, error) {
+	var run nmapRun
+	if err := xml.Unmarshal(data, &run); err != nil {
+		return nil, fmt.Errorf("parse nmap XML: %w", err)
+	}
+
+	var targets []ImportedTarget
+	for _, h := range run.Hosts {
+		if h.Status.State != "" && h.Status.State != "up" {
+			continue
+		}
+		host := h.name()
+		if host == "" {
+			continue
+		}
+		open := 0
+		for _, p := range h.Ports {
+			if p.State.State != "open" || p.PortID == 0 {
+				continue
+			}
+			open++
+			t := ImportedTarget{Target: nmapPortTarget(host, p.PortID, p.Service.Name, p.Service.Tunnel)}
+			if p.Service.Name != "" {
+				t.Tags = []string{p.Service.Name}
+			}
+			targets = append(targets, t)
+		}
+		if open == 0 {
+			targets = append(targets, ImportedTarget{Target: host})
+		}
+	}
+	return targets, nil
+}
+
+func nmapPortTarget(host string, port int, service, tunnel string) string {
+	scheme := ""
+	switch {
+	case service == "https" || (service == "http" && tunnel == "ssl"):
+		scheme = "https"
+	case service == "http" || strings.HasPrefix(service, "http-"):
+		scheme = "http"
+	}
+	if scheme == "" {
+		return net.JoinHostPort(host, strconv.Itoa(port))
+	}
+	if (scheme == "http" && port == 80) || (scheme == "https" && port == 443) {
+		if strings.Contains(host, ":") {
+			return scheme + "://[" + host + "]"
+		}
+		return scheme + "://" + host
+	}
+	return scheme + "://" + net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+func scopeKey(entry string) string {
+	return strings.ToLower(strings.TrimRight(strings.TrimSpace(entry), "/"))
+}
+
+func mergeImportedTargets(targets []ImportedTarget) []ImportedTarget {
+	index := make(map[string]int, len(targets))
+	var out []ImportedTarget
+	for _, t := range targets {
+		key := scopeKey(t.Target)
+		if i, ok := index[key]; ok {
+			out[i].Tags = append(out[i].Tags, t.Tags...)
+			continue
+		}
+		index[key] = len(out)
+		out = append(out, t)
+	}
+	for i := range out {
+		out[i].Tags = uniqueSorted(out[i].Tags)
+	}
+	return out
+}
+
+// splitNewTargets separates imported targets into those not yet in scope and a
+// count of ones that already are.
+func splitNewTargets(existing []string, imported []ImportedTarget) ([]ImportedTarget, int) {
+	inScope := make(map[string]struct{}, len(existing))
+	for _, entry := range existing {
+		inScope[scopeKey(entry)] = struct{}{}
+	}
+	var fresh []ImportedTarget
+	for _, t := range imported {
+		if _, ok := inScope[scopeKey(t.Target)]; ok {
+			continue
+		}
+		fresh = append(fresh, t)
+	}
+	return fresh, len(imported) - len(fresh)
+}
+
+func uniqueSorted(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(values))
+	var out []string
+	for _, v := range values {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// loadScopeTags returns the engagement's scope tags, or nil when none exist.
+func loadScopeTags(resultsDir, engagementID string) (map[string][]string, error) {
+	path, err := resolveResultsPath(resultsDir, engagementID, scopeTagsFilename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var tags map[string][]string
+	if err := json.Unmarshal(data, &tags); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", scopeTagsFilename, err)
+	}
+	return tags, nil
+}
+
+// mergeScopeTags adds the tags of imported targets to the stored tags.
+func mergeScopeTags(resultsDir, engagementID string, targets []ImportedTarget) error {
+	tags, err := loadScopeTags(resultsDir, engagementID)
+	if err != nil {
+		return err
+	}
+	if tags == nil {
+		tags = make(map[string][]string)
+	}
+	changed := false
+	for _, t := range targets {
+		if len(t.Tags) == 0 {
+			continue
+		}
+		tags[t.Target] = uniqueSorted(append(tags[t.Target], t.Tags...))
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	if _, err := ensureResultsDir(resultsDir, engagementID); err != nil {
+		return err
+	}
+	path, err := resolveResultsPath(resultsDir, engagementID, scopeTagsFilename)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(tags, jsonPrefix, jsonIndent)
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFile(path, data, consts.DefaultFilePerm)
+}
+
+var engagementScopeCmd = &cobra.Command{
+	Use:   "scope",
+	Short: "Manage engagement scope in bulk",
+}
+
+var engagementScopeImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import scope entries from a target list, CSV file, or nmap XML",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		appCtx := getAppContext(cmd)
+
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		file, _ := cmd.Flags().GetString("file")
+		if file == "" {
+			return fmt.Errorf("--file is required")
+		}
+		format, _ := cmd.Flags().GetString("format")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		eng, err := appCtx.Services.EngagementService.GetEngagement(ctx, id)
+		if err != nil {
+			if errors.Is(err, sharedErrors.ErrEngagementNotFound) {
+				return fmt.Errorf("engagement %s not found", id)
+			}
+			return fmt.Errorf("failed to get engagement: %w", err)
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("read import file: %w", err)
+		}
+		if format == "" || format == "auto" {
+			format = detectScopeFormat(file, data)
+		}
+		imported, err := parseScopeImport(data, format)
+		if err != nil {
+			return err
+		}
+		if len(imported) == 0 {
+			return fmt.Errorf("no targets found in %s", file)
+		}
+
+		fresh, duplicates := splitNewTargets(eng.Scope(), imported)
+		entries := make([]string, len(fresh))
+		for i, t := range fresh {
+			entries[i] = t.Target
+		}
+		normalized, err := normalizeScopeEntries(id, entries)
+		if err != nil {
+			return fmt.Errorf("invalid scope entries: %w", err)
+		}
+
+		if dryRun {
+			fmt.Printf("%s %d new scope entries from %s file (%d already in scope)\n", colorInfo("Dry run:"), len(normalized), format, duplicates)
+			for _, t := range fresh {
+				if len(t.Tags) > 0 {
+					fmt.Printf("  + %s [%s]\n", t.Target, strings.Join(t.Tags, ", "))
+				} else {
+					fmt.Printf("  + %s\n", t.Target)
+				}
+			}
+			return nil
+		}
+
+		if len(normalized) > 0 {
+			if err := appCtx.Services.EngagementService.AddToScope(ctx, id, normalized); err != nil {
+				return fmt.Errorf("failed to add scope: %w", err)
+			}
+		}
+		if err := mergeScopeTags(appCtx.ResultsDir, id, imported); err != nil {
+			cliLog().Warnw("scope_tags_save_failed", "engagement_id", id, "error", err)
+		}
+
+		sum := sha256.Sum256(data)
+		entry := &audit.Entry{
+			Timestamp:    time.Now(),
+			EngagementID: id,
+			Operator:     appCtx.Operator,
+			Command:      "engagement scope import",
+			Target:       filepath.Base(file),
+			Status:       "ok",
+			Notes: fmt.Sprintf("imported %d scope entries from %s file (%d already in scope); sha256=%s",
+				len(normalized), format, duplicates, hex.EncodeToString(sum[:])),
+		}
+		if err := appCtx.Services.CheckOrchestrator.RecordAuditEntry(ctx, entry); err != nil {
+			return fmt.Errorf("failed to record audit: %w", err)
+		}
+		if err := appCtx.Services.AuditRepo.Flush(ctx, id); err != nil {
+			return fmt.Errorf("failed to record audit: %w", err)
+		}
+
+		fmt.Printf("%s imported %d scope entries to engagement %s (%d already in scope)\n", colorSuccess("Success:"), len(normalized), id, duplicates)
+		return nil
+	},
+}
+
+func init() {
+	engagementCmd.AddCommand(engagementScopeCmd)
+	engagementScopeCmd.AddCommand(engagementScopeImportCmd)
+
+	engagementScopeImportCmd.Flags().String("id", "", "Engagement ID")
+	engagementScopeImportCmd.Flags().String("file", "", "Target list (.txt), CSV with tags (.csv), or nmap XML (.xml)")
+	engagementScopeImportCmd.Flags().String("format", "auto", "Import format: auto, list, csv, or nmap")
+	engagementScopeImportCmd.Flags().Bool("dry-run", false, "Show what would be imported without changing the scope")
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testNmapXML = `<?xml version="1.0"?>
+<nmaprun>
+  <host>
+    <status state="up"/>
+    <address addr="10.0.0.5" addrtype="ipv4"/>
+    <hostnames><hostname name="app.example.com" type="user"/></hostnames>
+    <ports>
+      <port protocol="tcp" portid="443"><state state="open"/><service name="http" tunnel="ssl"/></port>
+      <port protocol="tcp" portid="8080"><state state="open"/><service name="http-proxy"/></port>
+      <port protocol="tcp" portid="22"><state state="open"/><service name="ssh"/></port>
+      <port protocol="tcp" portid="25"><state state="closed"/><service name="smtp"/></port>
+    </ports>
+  </host>
+  <host>
+    <status state="up"/>
+    <address addr="10.0.0.6" addrtype="ipv4"/>
+  </host>
+  <host>
+    <status state="down"/>
+    <address addr="10.0.0.7" addrtype="ipv4"/>
+  </host>
+</nmaprun>`
+
+func importedTargetNames(targets []ImportedTarget) []string {
+	names := make([]string, len(targets))
+	for i, t := range targets {
+		names[i] = t.Target
+	}
+	return names
+}
+
+func TestParseScopeImport(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   string
+		data   string
+		want   []string
+		tagsOf map[string][]string
+	}{
+		{
+			name: "plain list",
+			file: "targets.txt",
+			data: "# staging\nexample.com\n\nhttps://api.example.com/\nEXAMPLE.com\n",
+			want: []string{"example.com", "https://api.example.com/"},
+		},
+		{
+			name:   "csv with header",
+			file:   "targets.csv",
+			data:   "owner,target,tags\nweb,app.example.com,prod;external\nweb,app.example.com,pci\n",
+			want:   []string{"app.example.com"},
+			tagsOf: map[string][]string{"app.example.com": {"external", "pci", "prod"}},
+		},
+		{
+			name:   "csv without header",
+			file:   "targets.csv",
+			data:   "app.example.com,prod,external\n10.0.0.1\n",
+			want:   []string{"app.example.com", "10.0.0.1"},
+			tagsOf: map[string][]string{"app.example.com": {"external", "prod"}},
+		},
+		{
+			name:   "nmap xml",
+			file:   "scan.xml",
+			data:   testNmapXML,
+			want:   []string{"https://app.example.com", "http://app.example.com:8080", "app.example.com:22", "10.0.0.6"},
+			tagsOf: map[string][]string{"app.example.com:22": {"ssh"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targets, err := parseScopeImport([]byte(tt.data), detectScopeFormat(tt.file, []byte(tt.data)))
+			if err != nil {
+				t.Fatalf("parseScopeImport: %v", err)
+			}
+			if got := importedTargetNames(targets); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("targets = %v, want %v", got, tt.want)
+			}
+			for _, target := range targets {
+				if want, ok := tt.tagsOf[target.Target]; ok && !reflect.DeepEqual(target.Tags, want) {
+					t.Errorf("tags of %s = %v, want %v", target.Target, target.Tags, want)
+				}
+			}
+		})
+	}
+
+	if format := detectScopeFormat("scan.out", []byte(testNmapXML)); format != scopeFormatNmap {
+		t.Errorf("expected nmap XML to be sniffed, got %s", format)
+	}
+	if _, err := parseScopeImport([]byte("x"), "yaml"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestSplitNewTargets(t *testing.T) {
+	imported := []ImportedTarget{{Target: "Example.com"}, {Target: "https://api.example.com/"}, {Target: "new.example.com"}}
+	fresh, duplicates := splitNewTargets([]string{"example.com", "https://api.example.com"}, imported)
+	if duplicates != 2 || len(fresh) != 1 || fresh[0].Target != "new.example.com" {
+		t.Fatalf("unexpected split: %v (%d duplicates)", fresh, duplicates)
+	}
+}
+
+func TestEngagementScopeImport(t *testing.T) {
+	defer setupTestAppContextWithServices(t)()
+
+	svc := globalAppContext.Services.EngagementService
+	eng, err := svc.CreateEngagement(t.Context(), "Import", "owner@example.com", "ROE", []string{"app.example.com"})
+	if err != nil {
+		t.Fatalf("CreateEngagement: %v", err)
+	}
+
+	file := filepath.Join(t.TempDir(), "targets.csv")
+	if err := os.WriteFile(file, []byte("target,tags\napp.example.com,prod\napi.example.com,prod;api\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := engagementScopeImportCmd
+	cmd.Flags().Set("id", eng.ID())
+	cmd.Flags().Set("file", file)
+	if err := cmd.RunE(cmd, nil); err != nil {
+		t.Fatalf("scope import: %v", err)
+	}
+
+	updated, err := svc.GetEngagement(t.Context(), eng.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := updated.Scope(); !reflect.DeepEqual(got, []string{"app.example.com", "api.example.com"}) {
+		t.Fatalf("unexpected scope %v", got)
+	}
+
+	tags, err := loadScopeTags(globalAppContext.ResultsDir, eng.ID())
+	if err != nil || !reflect.DeepEqual(tags["api.example.com"], []string{"api", "prod"}) {
+		t.Fatalf("unexpected tags %v (%v)", tags, err)
+	}
+
+	auditLog, err := os.ReadFile(filepath.Join(globalAppContext.ResultsDir, eng.ID(), "audit.csv"))
+	if err != nil {
+		t.Fatalf("read audit log: %v", err)
+	}
+	if !strings.Contains(string(auditLog), "engagement scope import") || !strings.Contains(string(auditLog), "1 already in scope") {
+		t.Fatalf("import not recorded in audit trail:\n%s", auditLog)
+	}
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/domain/engagement"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/session"
+	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
+	"github.com/spf13/cobra"
+)
+
+// scopeOverrides resolves the per-target overrides of an engagement's scope
+// entries for the targets of a run, which include crawled pages.
+type scopeOverrides struct {
+	entries map[string]engagement.TargetOverrides
+	scope   []string
+}
+
+func newScopeOverrides(scope []string, entries map[string]engagement.TargetOverrides) *scopeOverrides {
+	if len(entries) == 0 {
+		return nil
+	}
+	return &scopeOverrides{entries: entries, scope: scope}
+}
+
+// For returns the overrides of the scope entry target falls under: the entry
+// itself, or else the entry on the same host with the longest path prefix of
+// the target's path. Entries without overrides take part in the match, so a
+// page under an entry with no overrides gets none.
+func (s *scopeOverrides) For(target string) (engagement.TargetOverrides, bool) {
+	if s == nil {
+		return engagement.TargetOverrides{}, false
+	}
+	if o, ok := s.entries[target]; ok {
+		return o, true
+	}
+	info := checker.ParseTarget(target)
+	best, bestLen := "", -1
+	for _, entry := range s.scope {
+		e := checker.ParseTarget(entry)
+		if !strings.EqualFold(e.Host, info.Host) {
+			continue
+		}
+		prefix := strings.TrimSuffix(e.Path, "/")
+		if !pathUnder(info.Path, prefix) || len(prefix) <= bestLen {
+			continue
+		}
+		best, bestLen = entry, len(prefix)
+	}
+	o, ok := s.entries[best]
+	return o, ok
+}
+
+// pathUnder reports whether path is prefix or lies below it.
+func pathUnder(path, prefix string) bool {
+	if prefix == "" {
+		return true
+	}
+	rest, ok := strings.CutPrefix(path, prefix)
+	return ok && (rest == "" || strings.HasPrefix(rest, "/"))
+}
+
+// Lookup adapts s for checker.Runner.
+func (s *scopeOverrides) Lookup() checker.OverrideLookup {
+	if s == nil {
+		return nil
+	}
+	return func(target string) (checker.TargetOverride, bool) {
+		o, ok := s.For(target)
+		if !ok {
+			return checker.TargetOverride{}, false
+		}
+		return checker.TargetOverride{
+			Ports:       o.Ports,
+			Timeout:     time.Duration(o.TimeoutSecs) * time.Second,
+			AuthProfile: o.AuthProfile,
+		}, true
+	}
+}
+
+// SkipCrawl returns the scope entries the crawler must not start from.
+func (s *scopeOverrides) SkipCrawl() map[string]bool {
+	if s == nil {
+		return nil
+	}
+	skip := make(map[string]bool)
+	for target, o := range s.entries {
+		if o.SkipCrawl {
+			skip[target] = true
+		}
+	}
+	return skip
+}
+
+// AuthProfiles returns the stored credential profiles named by the
+// overrides, "none" excluded, in name order.
+func (s *scopeOverrides) AuthProfiles() []string {
+	if s == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var profiles []string
+	for _, o := range s.entries {
+		if o.AuthProfile != "" && o.AuthProfile != engagement.AuthProfileNone && !seen[o.AuthProfile] {
+			seen[o.AuthProfile] = true
+			profiles = append(profiles, o.AuthProfile)
+		}
+	}
+	sort.Strings(profiles)
+	return profiles
+}
+
+// ExpectedIssuers returns the expected certificate issuer per host key.
+func (s *scopeOverrides) ExpectedIssuers() map[string][]string {
+	if s == nil {
+		return nil
+	}
+	issuers := make(map[string][]string)
+	for target, o := range s.entries {
+		if o.ExpectedIssuer == "" {
+			continue
+		}
+		host := checker.HostKey(target)
+		issuers[host] = append(issuers[host], o.ExpectedIssuer)
+	}
+	return issuers
+}
+
+// pick names the auth profile of a request: the one carried by the check's
+// context, or else that of the scope entry the request URL falls under.
+func (s *scopeOverrides) pick(req *http.Request) (string, bool) {
+	if o, ok := checker.TargetOverrideFrom(req.Context()); ok {
+		return o.AuthProfile, o.AuthProfile != ""
+	}
+	if o, ok := s.For(req.URL.String()); ok {
+		return o.AuthProfile, o.AuthProfile != ""
+	}
+	return "", false
+}
+
+// routeScopeSessions logs in with every auth profile named by the overrides
+// and returns a session that sends each target the credentials of its
+// profile, and sess's otherwise. Without profiles it returns sess.
+func routeScopeSessions(ctx context.Context, resultsDir, engagementID string, scope []string, timeout time.Duration, transport http.RoundTripper, sess *session.Session, overrides *scopeOverrides) (*session.Session, error) {
+	if overrides == nil {
+		return sess, nil
+	}
+	profiles := make(map[string]*session.Session)
+	for _, o := range overrides.entries {
+		if o.AuthProfile == engagement.AuthProfileNone {
+			// Routed to no session: the request goes out unauthenticated.
+			profiles[engagement.AuthProfileNone] = nil
+		}
+	}
+	for _, name := range overrides.AuthProfiles() {
+		cfg, err := loadEngagementAuthProfile(resultsDir, engagementID, name)
+		if err != nil {
+			return nil, err
+		}
+		if cfg == nil {
+			return nil, fmt.Errorf("auth profile %q used by scope overrides is not configured (seca engagement auth set --profile %s)", name, name)
+		}
+		if cfg, err = resolveAuthSecrets(ctx, cfg); err != nil {
+			return nil, fmt.Errorf("auth profile %s: %w", name, err)
+		}
+		profile, err := session.Establish(ctx, cfg, scopeHosts(scope), &http.Client{Timeout: timeout, Transport: transport})
+		if err != nil {
+			return nil, fmt.Errorf("establish auth profile %s: %w", name, err)
+		}
+		profiles[name] = profile
+	}
+	return session.Route(sess, profiles, overrides.pick), nil
+}
+
+// printScopeOverrides reports the scope entries with overrides.
+func printScopeOverrides(overrides *scopeOverrides) {
+	if overrides == nil {
+		return
+	}
+	fmt.Printf("%s Target overrides: %d scope entr(ies)\n", colorInfo("→"), len(overrides.entries))
+}
+
+var engagementSetOverridesCmd = &cobra.Command{
+	Use:   "set-overrides",
+	Short: "Set per-target overrides on a scope entry",
+	Long: `Override run settings for one scope entry: the ports scanned, the check
+timeout, whether the crawler starts from it, the stored credentials it is
+checked with, and the CA expected to issue its certificate. Pages found
+under the entry by the crawler inherit its overrides. Unset flags clear the
+corresponding override.`,
+	Example: `  seca engagement set-overrides --id eng123 --target https://admin.example.com \
+    --ports 443,8443 --timeout 60 --skip-crawl --auth-profile admin \
+    --expected-issuer "Let's Encrypt"
+
+  # Remove every override from the entry
+  seca engagement set-overrides --id eng123 --target https://admin.example.com`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		appCtx := getAppContext(cmd)
+
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return fmt.Errorf("--id is required")
+		}
+		target, _ := cmd.Flags().GetString("target")
+		if target == "" {
+			return fmt.Errorf("--target is required")
+		}
+		portsFlag, _ := cmd.Flags().GetString("ports")
+		var ports []int
+		if portsFlag != "" {
+			var err error
+			if ports, err = checker.ParsePortSpec(portsFlag); err != nil {
+				return fmt.Errorf("--ports: %w", err)
+			}
+		}
+		timeout, _ := cmd.Flags().GetInt("timeout")
+		skipCrawl, _ := cmd.Flags().GetBool("skip-crawl")
+		authProfile, _ := cmd.Flags().GetString("auth-profile")
+		issuer, _ := cmd.Flags().GetString("expected-issuer")
+		overrides := engagement.TargetOverrides{
+			Ports:          ports,
+			TimeoutSecs:    timeout,
+			SkipCrawl:      skipCrawl,
+			AuthProfile:    strings.ToLower(strings.TrimSpace(authProfile)),
+			ExpectedIssuer: strings.TrimSpace(issuer),
+		}
+
+		if err := appCtx.Services.EngagementService.SetTargetOverrides(ctx, id, target, overrides); err != nil {
+			if errors.Is(err, sharedErrors.ErrEngagementNotFound) {
+				return fmt.Errorf("engagement %s not found", id)
+			}
+			return fmt.Errorf("failed to set overrides: %w", err)
+		}
+
+		if overrides.IsZero() {
+			fmt.Printf("%s cleared overrides for %s\n", colorSuccess("Success:"), target)
+			return nil
+		}
+		fmt.Printf("%s set overrides for %s\n", colorSuccess("Success:"), target)
+		return nil
+	},
+}
+
+func init() {
+	engagementCmd.AddCommand(engagementSetOverridesCmd)
+
+	engagementSetOverridesCmd.Flags().String("id", "", "Engagement ID")
+	engagementSetOverridesCmd.Flags().String("target", "", "Scope entry to override, exactly as listed in the scope")
+	engagementSetOverridesCmd.Flags().String("ports", "", "Ports to scan on this target instead of --ports (e.g. 443,8443,9000-9010)")
+	engagementSetOverridesCmd.Flags().Int("timeout", 0, "Check timeout in seconds for this target instead of --timeout")
+	engagementSetOverridesCmd.Flags().Bool("skip-crawl", false, "Check this target without crawling from it")
+	engagementSetOverridesCmd.Flags().String("auth-profile", "", "Stored credentials to check this target with (see engagement auth set --profile), or \"none\"")
+	engagementSetOverridesCmd.Flags().String("expected-issuer", "", "CA expected to issue this target's certificate; other issuers are reported as findings")
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/domain/engagement"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func TestScopeOverridesFor(t *testing.T) {
+	scope := []string{"https://app.example.com", "https://app.example.com/admin", "https://api.example.com"}
+	overrides := newScopeOverrides(scope, map[string]engagement.TargetOverrides{
+		"https://app.example.com/admin": {TimeoutSecs: 60, AuthProfile: "admin"},
+		"https://api.example.com":       {Ports: []int{443, 8443}, SkipCrawl: true, ExpectedIssuer: "Let's Encrypt"},
+	})
+
+	cases := []struct {
+		target  string
+		timeout int
+		ok      bool
+	}{
+		{"https://app.example.com/admin", 60, true},
+		{"https://app.example.com/admin/users", 60, true},
+		// The /admin entry does not cover /administrator, and the root
+		// entry it falls under has no overrides.
+		{"https://app.example.com/administrator", 0, false},
+		{"https://app.example.com/login", 0, false},
+		{"https://other.example.com/admin", 0, false},
+	}
+	for _, tc := range cases {
+		o, ok := overrides.For(tc.target)
+		if ok != tc.ok || o.TimeoutSecs != tc.timeout {
+			t.Errorf("For(%s) = %+v, %v; want timeout %d, %v", tc.target, o, ok, tc.timeout, tc.ok)
+		}
+	}
+
+	o, ok := overrides.Lookup()("https://api.example.com/v1/users")
+	if !ok || len(o.Ports) != 2 {
+		t.Errorf("expected crawled pages to inherit the entry's ports, got %+v", o)
+	}
+	if o, _ := overrides.Lookup()("https://app.example.com/admin"); o.Timeout != time.Minute || o.AuthProfile != "admin" {
+		t.Errorf("unexpected override %+v", o)
+	}
+	if skip := overrides.SkipCrawl(); len(skip) != 1 || !skip["https://api.example.com"] {
+		t.Errorf("unexpected skip-crawl set %v", skip)
+	}
+	if profiles := overrides.AuthProfiles(); len(profiles) != 1 || profiles[0] != "admin" {
+		t.Errorf("unexpected auth profiles %v", profiles)
+	}
+
+	var none *scopeOverrides
+	if newScopeOverrides(scope, nil) != none || none.Lookup() != nil || none.SkipCrawl() != nil {
+		t.Error("engagements without overrides should produce no lookup")
+	}
+}
+
+func TestScopeOverridesExpectedIssuer(t *testing.T) {
+	resultsDir := t.TempDir()
+	overrides := newScopeOverrides([]string{"https://app.example.com"}, map[string]engagement.TargetOverrides{
+		"https://app.example.com": {ExpectedIssuer: "Let's Encrypt"},
+	})
+	results := []checker.CheckResult{certResult("https://app.example.com/login", "CN=Proxy CA")}
+
+	deviations, err := checkExpectedCertificates(resultsDir, "eng-1", results, overrides.ExpectedIssuers(), time.Now())
+	if err != nil {
+		t.Fatalf("checkExpectedCertificates: %v", err)
+	}
+	if len(deviations) != 1 || deviations[0].Host != "app.example.com" {
+		t.Fatalf("expected an issuer deviation from the scope override, got %+v", deviations)
+	}
+}
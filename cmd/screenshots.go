@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+)
+
+const (
+	// screenshotsDirName holds one PNG per captured page, named by its SHA-256.
+	screenshotsDirName = "screenshots"
+	// screenshotIndexFilename maps captured URLs to their PNG, hash, and
+	// report thumbnail; the latest capture of a URL replaces earlier ones.
+	screenshotIndexFilename = "screenshots.json"
+)
+
+// ScreenshotRecord is one entry of the engagement's screenshot index.
+type ScreenshotRecord struct {
+	URL        string    `json:"url"`
+	File       string    `json:"file"` // Relative to the engagement results directory
+	SHA256     string    `json:"sha256"`
+	CapturedAt time.Time `json:"captured_at"`
+	Thumbnail  string    `json:"thumbnail,omitempty"` // Base64 JPEG embedded in HTML reports
+}
+
+// ThumbnailDataURI returns the thumbnail as an inline image source. The value
+// is base64 we encoded ourselves, so it is marked safe for html/template.
+func (r ScreenshotRecord) ThumbnailDataURI() template.URL {
+	if r.Thumbnail == "" {
+		return ""
+	}
+	return template.URL("data:image/jpeg;base64," + r.Thumbnail)
+}
+
+// loadScreenshotIndex returns the stored screenshot index sorted by URL, or
+// nil when no screenshots were captured.
+func loadScreenshotIndex(resultsDir, engagementID string) ([]ScreenshotRecord, error) {
+	path, err := resolveResultsPath(resultsDir, engagementID, screenshotIndexFilename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var records []ScreenshotRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", screenshotIndexFilename, err)
+	}
+	return records, nil
+}
+
+// saveScreenshots writes each PNG under screenshots/ and merges the entries
+// into the index. It returns the index path.
+func saveScreenshots(resultsDir, engagementID string, shots []*checker.Screenshot) (string, error) {
+	dir, err := resolveResultsPath(resultsDir, engagementID, screenshotsDirName)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, consts.DefaultDirPerm); err != nil {
+		return "", fmt.Errorf("create screenshots directory: %w", err)
+	}
+
+	existing, err := loadScreenshotIndex(resultsDir, engagementID)
+	if err != nil {
+		return "", err
+	}
+	byURL := make(map[string]ScreenshotRecord, len(existing)+len(shots))
+	for _, rec := range existing {
+		byURL[rec.URL] = rec
+	}
+
+	for _, shot := range shots {
+		name := shot.SHA256 + ".png"
+		if err := fileutil.WriteFile(filepath.Join(dir, name), shot.PNG, consts.DefaultFilePerm); err != nil {
+			return "", fmt.Errorf("write screenshot: %w", err)
+		}
+		byURL[shot.URL] = ScreenshotRecord{
+			URL:        shot.URL,
+			File:       filepath.ToSlash(filepath.Join(screenshotsDirName, name)),
+			SHA256:     shot.SHA256,
+			CapturedAt: shot.CapturedAt,
+			Thumbnail:  base64.StdEncoding.EncodeToString(shot.Thumbnail),
+		}
+	}
+
+	records := make([]ScreenshotRecord, 0, len(byURL))
+	for _, rec := range byURL {
+		records = append(records, rec)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].URL < records[j].URL })
+
+	path, err := resolveResultsPath(resultsDir, engagementID, screenshotIndexFilename)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(records, jsonPrefix, jsonIndent)
+	if err != nil {
+		return "", err
+	}
+	if err := fileutil.WriteFile(path, data, consts.DefaultFilePerm); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// screenshotCapturer captures pages; checker.ScreenshotCapturer is the real one.
+type screenshotCapturer interface {
+	Capture(target string) (*checker.Screenshot, error)
+}
+
+// captureScreenshots renders every successfully checked target in the headless
+// browser and stores the captures. Pages that redirect off the engagement's
+// scope are not captured. Failures are warnings: screenshots are
+// supplementary evidence.
+func captureScreenshots(ctx context.Context, resultsDir, engagementID string, scope []string, results []checker.CheckResult, runtimeCfg CheckRuntimeConfig, headers http.Header, proxy *url.URL) {
+	var targets []string
+	for _, r := range results {
+		if r.Succeeded() {
+			targets = append(targets, r.Target)
+		}
+	}
+	if len(targets) == 0 || ctx.Err() != nil {
+		return
+	}
+
+	capturer, err := checker.NewScreenshotCapturer(ctx, checker.ScreenshotOptions{
+		WaitTime: time.Duration(runtimeCfg.Crawl.JSWaitTime) * time.Second,
+		Timeout:  time.Duration(runtimeCfg.TimeoutSecs+runtimeCfg.Crawl.JSWaitTime) * time.Second,
+		Headers:  headers,
+		Proxy:    proxy,
+		InScope:  func(pageURL string) bool { return targetInScope(pageURL, scope) },
+	})
+	if err != nil {
+		cliLog().Warnw("screenshots_disabled", "error", err)
+		return
+	}
+	defer capturer.Close()
+
+	fmt.Printf("%s Capturing screenshots of %d page(s)...\n", colorInfo("→"), len(targets))
+	recordScreenshots(ctx, resultsDir, engagementID, targets, capturer)
+}
+
+// recordScreenshots captures each target with capturer and saves those that
+// succeeded.
+func recordScreenshots(ctx context.Context, resultsDir, engagementID string, targets []string, capturer screenshotCapturer) {
+	var shots []*checker.Screenshot
+	for _, target := range targets {
+		if ctx.Err() != nil {
+			break
+		}
+		shot, err := capturer.Capture(target)
+		switch {
+		case errors.Is(err, checker.ErrScreenshotOutOfScope):
+			cliLog().Warnw("screenshot_out_of_scope", "target", target, "error", err)
+			continue
+		case err != nil:
+			cliLog().Warnw("screenshot_failed", "target", target, "error", err)
+			continue
+		}
+		shots = append(shots, shot)
+	}
+	if len(shots) == 0 {
+		return
+	}
+
+	path, err := saveScreenshots(resultsDir, engagementID, shots)
+	if err != nil {
+		cliLog().Warnw("screenshots_save_failed", "engagement_id", engagementID, "error", err)
+		return
+	}
+	fmt.Printf("%s Screenshots: %d captured → %s\n", colorInfo("→"), len(shots), path)
+}
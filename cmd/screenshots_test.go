@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+// fakeCapturer fails the targets listed in errs and captures the rest.
+type fakeCapturer struct {
+	errs map[string]error
+}
+
+func (c fakeCapturer) Capture(target string) (*checker.Screenshot, error) {
+	if err, ok := c.errs[target]; ok {
+		return nil, err
+	}
+	return &checker.Screenshot{URL: target, PNG: []byte(target), SHA256: fmt.Sprintf("%x", target), CapturedAt: time.Now()}, nil
+}
+
+func TestRecordScreenshots(t *testing.T) {
+	resultsDir := t.TempDir()
+	const id = "eng-shots"
+	capturer := fakeCapturer{errs: map[string]error{
+		"https://slow.example.com":     errors.New("screenshot https://slow.example.com: timed out after 10s"),
+		"https://redirect.example.com": fmt.Errorf("screenshot https://redirect.example.com: %w (https://sso.other.com)", checker.ErrScreenshotOutOfScope),
+	}}
+	targets := []string{"https://app.example.com", "https://slow.example.com", "https://redirect.example.com"}
+
+	captureStdout(t, func() {
+		recordScreenshots(context.Background(), resultsDir, id, targets, capturer)
+	})
+	records, err := loadScreenshotIndex(resultsDir, id)
+	if err != nil {
+		t.Fatalf("loadScreenshotIndex() error = %v", err)
+	}
+	if len(records) != 1 || records[0].URL != "https://app.example.com" {
+		t.Fatalf("expected only the captured page to be stored, got %+v", records)
+	}
+
+	// Nothing is written when every capture fails.
+	captureStdout(t, func() {
+		recordScreenshots(context.Background(), resultsDir, "eng-none", targets[1:], capturer)
+	})
+	if records, _ := loadScreenshotIndex(resultsDir, "eng-none"); records != nil {
+		t.Fatalf("expected no screenshot index, got %+v", records)
+	}
+
+	// A cancelled run stops capturing.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	recordScreenshots(ctx, resultsDir, "eng-cancelled", targets, capturer)
+	if records, _ := loadScreenshotIndex(resultsDir, "eng-cancelled"); records != nil {
+		t.Fatalf("expected no screenshots after cancellation, got %+v", records)
+	}
+}
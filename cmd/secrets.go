@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/secrets"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/session"
+	"github.com/spf13/viper"
+)
+
+const defaultVaultTokenEnv = "VAULT_TOKEN"
+
+// newSecretResolver returns a resolver for the secrets.<name> entries in
+// config. Store settings come from vault.* and aws.* in config, falling
+// back to the standard VAULT_* and AWS_* environment variables.
+func newSecretResolver() *secrets.Resolver {
+	refs := make(map[string]secrets.Ref)
+	for _, name := range sortedMapKeys(viper.Get("secrets")) {
+		key := "secrets." + name + "."
+		refs[name] = secrets.Ref{
+			Provider: strings.ToLower(strings.TrimSpace(viper.GetString(key + "provider"))),
+			Path:     strings.TrimSpace(viper.GetString(key + "path")),
+			Key:      strings.TrimSpace(viper.GetString(key + "key")),
+			Region:   strings.TrimSpace(viper.GetString(key + "region")),
+		}
+	}
+
+	tokenEnv := firstNonEmpty(viper.GetString("vault.token_env"), defaultVaultTokenEnv)
+	return secrets.NewResolver(secrets.Config{
+		Refs: refs,
+		Vault: secrets.VaultConfig{
+			Address:   firstNonEmpty(viper.GetString("vault.address"), os.Getenv("VAULT_ADDR")),
+			Token:     strings.TrimSpace(os.Getenv(tokenEnv)),
+			Namespace: firstNonEmpty(viper.GetString("vault.namespace"), os.Getenv("VAULT_NAMESPACE")),
+		},
+		AWS: secrets.AWSConfig{
+			Region:          firstNonEmpty(viper.GetString("aws.region"), os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION")),
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+			Endpoint:        viper.GetString("aws.secretsmanager_endpoint"),
+		},
+	})
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// resolveAuthSecrets fills in the secrets cfg refers to from their stores.
+// The values are only held in memory for the run.
+func resolveAuthSecrets(ctx context.Context, cfg *session.Config) (*session.Config, error) {
+	if len(cfg.SecretNames()) == 0 {
+		return cfg, nil
+	}
+	resolved, err := cfg.ResolveSecrets(ctx, newSecretResolver().Lookup)
+	if err != nil {
+		return nil, fmt.Errorf("resolve credentials: %w", err)
+	}
+	return resolved, nil
+}
+
+// checkSecretsDefined returns an error naming the first secret cfg refers
+// to that config does not define.
+func checkSecretsDefined(cfg *session.Config) error {
+	resolver := newSecretResolver()
+	for _, name := range cfg.SecretNames() {
+		ref, ok := resolver.Ref(name)
+		if !ok {
+			return fmt.Errorf("secret %q is not defined (add secrets.%s to the config file)", name, name)
+		}
+		if err := ref.Validate(); err != nil {
+			return fmt.Errorf("secrets.%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func validateSecretProvider(value any) error {
+	s, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case secrets.ProviderEnv, secrets.ProviderVault, secrets.ProviderAWS:
+		return nil
+	}
+	return fmt.Errorf("unknown secret provider %q (expected %s, %s, or %s)", s, secrets.ProviderEnv, secrets.ProviderVault, secrets.ProviderAWS)
+}
@@ -25,7 +25,18 @@ var securityCheckCatalog = []SecurityCheckSpec{
 	{Name: "Content Security Policy (CSP) Bypass", Category: "Content Security Policy (CSP)"},
 	{Name: "Set-Cookie headers (Secure/HttpOnly)", Category: "Cookie Security"},
 	{Name: "Open Ports", Category: "Network Security"},
+	{Name: "Known Vulnerable Service Versions", Category: "Network Security"},
 	{Name: "Subdomain Takeover", Category: "Network Security"},
+	{Name: "Exposed Admin Interface", Category: "Network Security"},
+	{Name: "Lame DNS Delegation", Category: "DNS"},
+	{Name: "Inconsistent NS Delegation", Category: "DNS"},
+	{Name: "Domain Registration Expiring Soon", Category: "DNS"},
+	{Name: "Domain Registration Expired", Category: "DNS"},
+	{Name: "Hosting Outside Approved Jurisdiction", Category: "Data Residency"},
+	{Name: "Directory Listing Enabled", Category: "Information Disclosure"},
+	{Name: "Exposed Git Repository (.git/config)", Category: "Information Disclosure"},
+	{Name: "Exposed Environment File (.env)", Category: "Information Disclosure"},
+	{Name: "Exposed Backup File", Category: "Information Disclosure"},
 	{Name: "Permissions-Policy header", Category: "Miscellaneous Headers"},
 	{Name: "Referrer Policy", Category: "Miscellaneous Headers"},
 	{Name: "Server information disclosure", Category: "Miscellaneous Headers"},
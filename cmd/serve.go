@@ -3,21 +3,26 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/khanhnv2901/seca-cli/internal/domain/engagement"
 	"github.com/khanhnv2901/seca-cli/internal/infrastructure/api"
 	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"go.uber.org/zap"
 )
 
@@ -30,40 +35,76 @@ var serveCmd = &cobra.Command{
 		authToken, _ := cmd.Flags().GetString("auth-token")
 		telemetryLimit, _ := cmd.Flags().GetInt("telemetry-limit")
 		shutdownTimeout, _ := cmd.Flags().GetDuration("shutdown-timeout")
+		drainTimeout, _ := cmd.Flags().GetDuration("drain-timeout")
 		corsOrigins, _ := cmd.Flags().GetStringSlice("cors-origins")
 		rateLimit, _ := cmd.Flags().GetInt("rate-limit")
 		rateBurst, _ := cmd.Flags().GetInt("rate-burst")
-
-		// Initialize structured logger
-		logger, err := zap.NewProduction()
+		proxyList, _ := cmd.Flags().GetStringSlice("trusted-proxies")
+		trustedProxies, err := api.ParseTrustedProxies(proxyList)
 		if err != nil {
-			return fmt.Errorf("failed to create logger: %w", err)
+			return err
 		}
-		defer func() {
-			if err := logger.Sync(); err != nil {
-				fmt.Fprintf(os.Stderr, "failed to sync logger: %v\n", err)
-			}
-		}()
 
-		jobManager := api.NewJobManager()
+		// Share the CLI logger so --log-level and --log-file apply to the API
+		logger := cliLog().Desugar().Named("api")
+
 		runner, err := newCliCheckRunner()
 		if err != nil {
 			return err
 		}
+		webhooks, err := newWebhookDispatcher(logger, "webhooks")
+		if err != nil {
+			return err
+		}
+		var deliveries api.DeliveryService
+		if webhooks != nil {
+			defer webhooks.Close()
+			deliveries = webhooks
+		}
+		dataDir, err := getDataDir()
+		if err != nil {
+			return fmt.Errorf("failed to get data directory: %w", err)
+		}
+		engagements := &engagementAPIService{appCtx: appCtx}
+		jobs := newJobAPIService(runner, appCtx, webhooks, dataDir)
+		scheduler, err := newScheduler(jobs, engagements, dataDir, logger)
+		if err != nil {
+			return err
+		}
+		defer scheduler.Close()
+		// Keys may not repeat across the default services and workspaces
+		usedKeys := map[string]string{}
+		if authToken != "" {
+			usedKeys[authToken] = "--auth-token"
+		}
+		apiKeys, err := newAPIKeys("api.keys", usedKeys)
+		if err != nil {
+			return err
+		}
+		workspaces, closeWorkspaces, err := newAPIWorkspaces(appCtx, runner, authToken, apiKeys, logger)
+		if err != nil {
+			return err
+		}
+		defer closeWorkspaces()
 
 		// Use DDD application services for the API
 		server := api.NewServer(api.Config{
-			Engagements:    &engagementAPIService{appCtx: appCtx},
+			Engagements:    engagements,
 			Results:        &resultsAPIService{appCtx: appCtx},
 			Telemetry:      &telemetryAPIService{appCtx: appCtx},
-			Health:         &healthAPIService{appCtx: appCtx},
-			Jobs:           &jobAPIService{manager: jobManager, runner: runner, appCtx: appCtx},
+			Health:         &healthAPIService{appCtx: appCtx, jobs: jobs, scheduler: scheduler},
+			Jobs:           jobs,
+			Deliveries:     deliveries,
+			Schedules:      &auditedScheduler{Scheduler: scheduler, appCtx: appCtx},
 			AuthToken:      authToken,
+			APIKeys:        apiKeys,
 			TelemetryLimit: telemetryLimit,
 			Logger:         logger,
 			CORSOrigins:    corsOrigins,
 			RateLimit:      rateLimit,
 			RateBurst:      rateBurst,
+			TrustedProxies: trustedProxies,
+			Workspaces:     workspaces,
 		})
 
 		httpServer := &http.Server{
@@ -80,6 +121,9 @@ var serveCmd = &cobra.Command{
 		// Start server in a goroutine
 		go func() {
 			fmt.Printf("%s API server listening on %s (results dir: %s)\n", colorInfo("→"), addr, appCtx.ResultsDir)
+			for _, ws := range workspaces {
+				fmt.Printf("%s Workspace %s\n", colorInfo("→"), ws.Name)
+			}
 			fmt.Printf("%s Press Ctrl+C to gracefully shutdown\n", colorInfo("→"))
 			serverErrors <- httpServer.ListenAndServe()
 		}()
@@ -97,6 +141,13 @@ var serveCmd = &cobra.Command{
 		case sig := <-shutdown:
 			fmt.Printf("\n%s Received signal %v, initiating graceful shutdown...\n", colorInfo("→"), sig)
 
+			// Stop taking jobs and let running ones finish before closing
+			// connections; job streams get a final event
+			if interrupted := drainAPI(server, jobs, scheduler, workspaces, drainTimeout, shutdown); interrupted > 0 {
+				fmt.Printf("%s Interrupted %d running job(s)\n", colorWarn("!"), interrupted)
+			}
+			server.CloseStreams()
+
 			// Create context with timeout for shutdown
 			ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 			defer cancel()
@@ -122,12 +173,66 @@ func init() {
 	serveCmd.Flags().String("auth-token", "", "Optional shared secret for API requests")
 	serveCmd.Flags().Int("telemetry-limit", 10, "Default telemetry entries to return")
 	serveCmd.Flags().Duration("shutdown-timeout", 30*time.Second, "Graceful shutdown timeout")
+	serveCmd.Flags().Duration("drain-timeout", 60*time.Second, "How long running jobs may finish at shutdown before they are interrupted")
 	serveCmd.Flags().StringSlice("cors-origins", []string{}, "Allowed CORS origins (empty = allow all)")
 	serveCmd.Flags().Int("rate-limit", 10, "Rate limit per IP (requests/second, 0 = disabled)")
 	serveCmd.Flags().Int("rate-burst", 20, "Rate limit burst size")
+	serveCmd.Flags().StringSlice("trusted-proxies", []string{}, "Reverse proxy CIDRs or IPs whose X-Forwarded-For is used to identify clients (empty = ignore the header)")
 	rootCmd.AddCommand(serveCmd)
 }
 
+// drainAPI stops the server and every workspace from taking jobs, stops
+// the schedulers, and waits up to timeout for running jobs, which are then
+// interrupted and checkpointed. Another signal ends the wait early. It
+// returns how many jobs were interrupted.
+func drainAPI(server *api.Server, jobs *jobAPIService, scheduler *api.Scheduler, workspaces []api.Workspace, timeout time.Duration, signals <-chan os.Signal) int {
+	server.Drain()
+	services := []*jobAPIService{jobs}
+	scheduler.Close()
+	for _, ws := range workspaces {
+		if s, ok := ws.Schedules.(interface{ Close() }); ok {
+			s.Close()
+		}
+		if j, ok := ws.Jobs.(*jobAPIService); ok {
+			services = append(services, j)
+		}
+	}
+
+	running := 0
+	for _, s := range services {
+		running += s.activeJobs()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if running > 0 {
+		fmt.Printf("%s Waiting up to %s for %d running job(s); signal again to stop them now\n", colorInfo("→"), timeout, running)
+		go func() {
+			select {
+			case <-signals:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	var wg sync.WaitGroup
+	interrupted := make([]int, len(services))
+	for i, s := range services {
+		wg.Add(1)
+		go func(i int, s *jobAPIService) {
+			defer wg.Done()
+			interrupted[i] = s.drain(ctx)
+		}(i, s)
+	}
+	wg.Wait()
+
+	total := 0
+	for _, n := range interrupted {
+		total += n
+	}
+	return total
+}
+
 type engagementAPIService struct {
 	appCtx *AppContext
 }
@@ -141,15 +246,7 @@ func (s *engagementAPIService) ListEngagements(ctx context.Context) ([]api.Engag
 
 	resp := make([]api.Engagement, 0, len(engagements))
 	for _, e := range engagements {
-		resp = append(resp, api.Engagement{
-			ID:        e.ID(),
-			Name:      e.Name(),
-			Owner:     e.Owner(),
-			Scope:     e.Scope(),
-			ROE:       e.ROE(),
-			ROEAgree:  e.ROEAgreed(),
-			CreatedAt: e.CreatedAt(),
-		})
+		resp = append(resp, toAPIEngagement(e))
 	}
 	return resp, nil
 }
@@ -161,25 +258,20 @@ func (s *engagementAPIService) GetEngagement(ctx context.Context, id string) (*a
 		return nil, fmt.Errorf("failed to get engagement: %w", err)
 	}
 
-	result := api.Engagement{
-		ID:        eng.ID(),
-		Name:      eng.Name(),
-		Owner:     eng.Owner(),
-		Scope:     eng.Scope(),
-		ROE:       eng.ROE(),
-		ROEAgree:  eng.ROEAgreed(),
-		CreatedAt: eng.CreatedAt(),
-	}
+	result := toAPIEngagement(eng)
 	return &result, nil
 }
 
 func (s *engagementAPIService) CreateEngagement(ctx context.Context, req api.EngagementCreateRequest) (*api.Engagement, error) {
 	// Validate request
-	if req.Name == "" || req.Owner == "" {
-		return nil, fmt.Errorf("name and owner are required")
+	if req.Name == "" {
+		return nil, api.NewFieldError("name", "is required")
+	}
+	if req.Owner == "" {
+		return nil, api.NewFieldError("owner", "is required")
 	}
 	if !req.ROEAgree {
-		return nil, fmt.Errorf("roe_agree must be true")
+		return nil, api.NewFieldError("roe_agree", "must be true")
 	}
 
 	// Normalize scope if provided
@@ -189,7 +281,7 @@ func (s *engagementAPIService) CreateEngagement(ctx context.Context, req api.Eng
 		tempID := fmt.Sprintf("%d", time.Now().UnixNano())
 		normalized, err := normalizeScopeEntries(tempID, req.Scope)
 		if err != nil {
-			return nil, fmt.Errorf("invalid scope: %w", err)
+			return nil, api.NewFieldError("scope", "%v", err)
 		}
 		scope = normalized
 	}
@@ -205,19 +297,36 @@ func (s *engagementAPIService) CreateEngagement(ctx context.Context, req api.Eng
 		return nil, fmt.Errorf("failed to acknowledge ROE: %w", err)
 	}
 
-	// Convert to API response
-	result := api.Engagement{
-		ID:        eng.ID(),
-		Name:      eng.Name(),
-		Owner:     eng.Owner(),
-		Scope:     eng.Scope(),
-		ROE:       eng.ROE(),
-		ROEAgree:  eng.ROEAgreed(),
-		CreatedAt: eng.CreatedAt(),
+	// Reload so the response shows the acknowledged ROE and active status
+	if updated, err := s.appCtx.Services.EngagementService.GetEngagement(ctx, eng.ID()); err == nil {
+		eng = updated
 	}
+
+	// The engagement exists now; a failed audit write is logged rather than
+	// failing a request the client would retry
+	notes := fmt.Sprintf("owner=%s scope_entries=%d roe_acknowledged=true", eng.Owner(), len(eng.Scope()))
+	if err := recordAPIAuditEvent(ctx, s.appCtx, eng.ID(), "api engagement create", eng.Name(), notes); err != nil {
+		cliLog().Errorw("api_audit_failed", "engagement_id", eng.ID(), "error", err)
+	}
+
+	// Convert to API response
+	result := toAPIEngagement(eng)
 	return &result, nil
 }
 
+func toAPIEngagement(e *engagement.Engagement) api.Engagement {
+	return api.Engagement{
+		ID:        e.ID(),
+		Name:      e.Name(),
+		Owner:     e.Owner(),
+		Scope:     e.Scope(),
+		ROE:       e.ROE(),
+		ROEAgree:  e.ROEAgreed(),
+		Status:    string(e.Status()),
+		CreatedAt: e.CreatedAt(),
+	}
+}
+
 type resultsAPIService struct {
 	appCtx *AppContext
 }
@@ -257,7 +366,9 @@ func (s *telemetryAPIService) GetTelemetry(ctx context.Context, id string, limit
 }
 
 type healthAPIService struct {
-	appCtx *AppContext
+	appCtx    *AppContext
+	jobs      *jobAPIService
+	scheduler *api.Scheduler
 }
 
 func (s *healthAPIService) Check(ctx context.Context) error {
@@ -267,38 +378,119 @@ func (s *healthAPIService) Check(ctx context.Context) error {
 	return nil
 }
 
-func (s *healthAPIService) Ready(ctx context.Context) error {
-	// Check if results directory exists and is accessible
-	if s.appCtx.ResultsDir == "" {
-		return fmt.Errorf("results directory not configured")
+// Ready checks each dependency the API needs to run scans: a writable
+// results directory, the engagement store, the scheduler and the check
+// runner.
+func (s *healthAPIService) Ready(ctx context.Context) (*api.Readiness, error) {
+	checks := []api.DependencyCheck{
+		{Name: "results_dir", Check: s.checkResultsDir},
+		{Name: "storage", Check: s.checkStorage},
+	}
+	if s.scheduler != nil {
+		checks = append(checks, api.DependencyCheck{Name: "scheduler", Check: s.checkScheduler})
+	}
+	if s.jobs != nil {
+		checks = append(checks, api.DependencyCheck{Name: "workers", Check: s.checkWorkers})
 	}
+	return api.CheckDependencies(ctx, checks), nil
+}
 
-	// Check if results directory is accessible
-	if _, err := os.Stat(s.appCtx.ResultsDir); err != nil {
-		return fmt.Errorf("results directory not accessible: %w", err)
+func (s *healthAPIService) checkResultsDir(ctx context.Context) (string, string) {
+	if s.appCtx.ResultsDir == "" {
+		return api.DependencyDown, "results directory not configured"
 	}
+	// Write a probe file: a directory can exist and still reject writes
+	f, err := os.CreateTemp(s.appCtx.ResultsDir, ".ready-*")
+	if err != nil {
+		return api.DependencyDown, fmt.Sprintf("results directory not writable: %v", err)
+	}
+	name := f.Name()
+	_, writeErr := f.WriteString("ok")
+	closeErr := f.Close()
+	_ = os.Remove(name)
+	if writeErr != nil || closeErr != nil {
+		return api.DependencyDown, fmt.Sprintf("results directory not writable: %v", errors.Join(writeErr, closeErr))
+	}
+	return api.DependencyOK, s.appCtx.ResultsDir
+}
 
-	// Check if engagements file exists (indicates system is initialized)
-	engagementsPath := filepath.Join(s.appCtx.ResultsDir, "engagements.json")
-	if _, err := os.Stat(engagementsPath); err != nil {
-		// File doesn't exist yet - this is OK for a new installation
-		// We'll return nil to indicate ready, but we could also initialize it here
-		// For now, we'll consider the system ready if the directory exists
-		return nil
+func (s *healthAPIService) checkStorage(ctx context.Context) (string, string) {
+	if s.appCtx.Services == nil {
+		return api.DependencyDown, "engagement store not initialized"
+	}
+	engagements, err := s.appCtx.Services.EngagementService.ListEngagements(ctx)
+	if err != nil {
+		return api.DependencyDown, fmt.Sprintf("engagement store unavailable: %v", err)
 	}
+	return api.DependencyOK, fmt.Sprintf("%d engagements", len(engagements))
+}
 
-	// Verify engagements file is readable
-	if _, err := os.ReadFile(engagementsPath); err != nil {
-		return fmt.Errorf("engagements file not readable: %w", err)
+func (s *healthAPIService) checkScheduler(ctx context.Context) (string, string) {
+	if err := s.scheduler.Check(); err != nil {
+		return api.DependencyDown, err.Error()
 	}
+	schedules, _ := s.scheduler.ListSchedules(ctx)
+	return api.DependencyOK, fmt.Sprintf("%d schedules", len(schedules))
+}
 
-	return nil
+// checkWorkers reports the check runner missing as down, and all job slots
+// in use as degraded since scheduled scans then wait.
+func (s *healthAPIService) checkWorkers(ctx context.Context) (string, string) {
+	if runner, ok := s.jobs.runner.(*cliCheckRunner); ok {
+		if _, err := os.Stat(runner.executable); err != nil {
+			return api.DependencyDown, fmt.Sprintf("check runner unavailable: %v", err)
+		}
+	}
+	active := s.jobs.activeJobs()
+	if s.scheduler == nil {
+		return api.DependencyOK, fmt.Sprintf("%d jobs running", active)
+	}
+	slots := s.scheduler.MaxConcurrent()
+	if active >= slots {
+		return api.DependencyDegraded, fmt.Sprintf("%d of %d job slots in use; scheduled scans are waiting", active, slots)
+	}
+	return api.DependencyOK, fmt.Sprintf("%d of %d job slots in use", active, slots)
 }
 
 type jobAPIService struct {
-	manager *api.JobManager
-	runner  jobRunner
-	appCtx  *AppContext
+	manager    *api.JobManager
+	runner     jobRunner
+	appCtx     *AppContext
+	webhooks   *api.WebhookDispatcher // nil when no webhooks are configured
+	checkpoint string                 // File jobs are saved to at shutdown ("" = none)
+
+	// Jobs run under ctx, which drain cancels when its wait is over
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	draining bool
+	cancels  map[string]context.CancelCauseFunc // Unfinished jobs, by ID
+}
+
+// errJobCancelled is the cancellation cause of a job stopped through the API.
+var errJobCancelled = errors.New(api.JobCancelled)
+
+// newJobAPIService returns a job service that checkpoints its jobs to
+// <dataDir>/jobs.json at shutdown, restoring the previous checkpoint.
+func newJobAPIService(runner jobRunner, appCtx *AppContext, webhooks *api.WebhookDispatcher, dataDir string) *jobAPIService {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &jobAPIService{
+		manager:  api.NewJobManager(),
+		runner:   runner,
+		appCtx:   appCtx,
+		webhooks: webhooks,
+		ctx:      ctx,
+		cancel:   cancel,
+		cancels:  make(map[string]context.CancelCauseFunc),
+	}
+	if dataDir != "" {
+		s.checkpoint = filepath.Join(dataDir, "jobs.json")
+		if _, err := s.manager.Restore(s.checkpoint); err != nil {
+			cliLog().Warnw("job_checkpoint_restore_failed", "path", s.checkpoint, "error", err)
+		}
+	}
+	return s
 }
 
 type jobRunner interface {
@@ -310,56 +502,243 @@ func (s *jobAPIService) StartJob(ctx context.Context, req api.JobRequest) (*api.
 	if jobType == "" {
 		jobType = "http"
 	}
-	if req.EngagementID == "" {
-		return nil, fmt.Errorf("engagement_id required")
-	}
 	if err := validateEngagementID(req.EngagementID); err != nil {
-		return nil, fmt.Errorf("invalid engagement_id: %w", err)
+		return nil, api.NewFieldError("engagement_id", "%v", err)
 	}
 	if jobType != "http" {
-		return nil, fmt.Errorf("unsupported job type %s", req.Type)
+		return nil, api.NewFieldError("type", "unsupported job type %q", req.Type)
 	}
 	if _, err := s.appCtx.Services.EngagementService.GetEngagement(ctx, req.EngagementID); err != nil {
 		if errors.Is(err, sharedErrors.ErrEngagementNotFound) {
-			return nil, fmt.Errorf("engagement %s not found", req.EngagementID)
+			return nil, api.NewFieldError("engagement_id", "engagement %s not found", req.EngagementID)
 		}
 		return nil, fmt.Errorf("failed to get engagement: %w", err)
 	}
+
+	s.mu.Lock()
+	if s.draining {
+		s.mu.Unlock()
+		return nil, api.ErrShuttingDown
+	}
 	job := s.manager.CreateJob(jobType, req.EngagementID)
-	go s.execute(job, req)
+	jobCtx, cancelJob := context.WithCancelCause(s.ctx)
+	s.cancels[job.ID] = cancelJob
+	s.wg.Add(1)
+	s.mu.Unlock()
+
+	// A scan that cannot be audited does not run
+	if err := recordAPIAuditEvent(ctx, s.appCtx, req.EngagementID, "api job start", job.ID, "type="+jobType); err != nil {
+		s.finish(job.ID, "error", err.Error())
+		s.wg.Done()
+		return nil, err
+	}
+	go s.execute(jobCtx, job, req)
 	return job, nil
 }
 
-func (s *jobAPIService) execute(job *api.Job, req api.JobRequest) {
+// CancelJob stops a pending or running job. The job is recorded as an
+// error with the message "cancelled" once its check process has exited.
+func (s *jobAPIService) CancelJob(ctx context.Context, id string) (*api.Job, error) {
+	s.mu.Lock()
+	job := s.manager.GetJob(id)
+	cancelJob, running := s.cancels[id]
+	s.mu.Unlock()
+	if job == nil {
+		return nil, api.ErrJobNotFound
+	}
+	if !running {
+		return nil, api.ErrJobFinished
+	}
+	if err := recordAPIAuditEvent(ctx, s.appCtx, job.ResultID, "api job cancel", job.ID, ""); err != nil {
+		return nil, err
+	}
+	cancelJob(errJobCancelled)
+	return job, nil
+}
+
+func (s *jobAPIService) execute(jobCtx context.Context, job *api.Job, req api.JobRequest) {
+	defer s.wg.Done()
 	now := time.Now()
 	s.manager.UpdateJob(job.ID, func(j *api.Job) {
 		j.Status = "running"
 		j.StartedAt = &now
 	})
 	// Set reasonable timeout for job execution (90 seconds)
-	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	ctx, cancel := context.WithTimeout(jobCtx, 90*time.Second)
 	defer cancel()
 
 	if err := s.runner.RunHTTP(ctx, req.EngagementID); err != nil {
-		errTime := time.Now()
-		s.manager.UpdateJob(job.ID, func(j *api.Job) {
-			j.Status = "error"
-			j.Error = err.Error()
-			j.FinishedAt = &errTime
-		})
+		message := err.Error()
+		switch {
+		case errors.Is(context.Cause(jobCtx), errJobCancelled):
+			message = api.JobCancelled
+		case s.ctx.Err() != nil:
+			message = api.JobInterrupted
+		}
+		s.finish(job.ID, "error", message)
+		s.notify(api.EventRunFailed, job.ID)
 		return
 	}
-	doneTime := time.Now()
-	s.manager.UpdateJob(job.ID, func(j *api.Job) {
-		j.Status = "done"
-		j.FinishedAt = &doneTime
+	s.finish(job.ID, "done", "")
+	s.notify(api.EventRunCompleted, job.ID)
+}
+
+// finish records the outcome of a job; it can no longer be cancelled.
+func (s *jobAPIService) finish(id, status, message string) {
+	s.mu.Lock()
+	if cancelJob, ok := s.cancels[id]; ok {
+		cancelJob(nil)
+		delete(s.cancels, id)
+	}
+	s.mu.Unlock()
+	finished := time.Now()
+	s.manager.UpdateJob(id, func(j *api.Job) {
+		j.Status = status
+		j.Error = message
+		j.FinishedAt = &finished
 	})
 }
 
+// drain stops new jobs and waits for running ones until ctx is done. Jobs
+// still running then are cancelled and recorded as interrupted. The jobs are
+// checkpointed last; drain returns how many were interrupted.
+func (s *jobAPIService) drain(ctx context.Context) int {
+	s.mu.Lock()
+	s.draining = true
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	interrupted := 0
+	select {
+	case <-done:
+	case <-ctx.Done():
+		interrupted = s.activeJobs()
+		s.cancel()
+		<-done
+	}
+
+	if s.checkpoint != "" {
+		if err := s.manager.Checkpoint(s.checkpoint); err != nil {
+			cliLog().Warnw("job_checkpoint_failed", "path", s.checkpoint, "error", err)
+		}
+	}
+	return interrupted
+}
+
+// activeJobs counts the jobs that have not finished.
+func (s *jobAPIService) activeJobs() int {
+	active := 0
+	for _, job := range s.manager.ListJobs(0) {
+		if job.Status == "pending" || job.Status == "running" {
+			active++
+		}
+	}
+	return active
+}
+
+// notify pushes a finished job to the configured webhooks, with the run
+// results when it succeeded.
+func (s *jobAPIService) notify(event, jobID string) {
+	if s.webhooks == nil {
+		return
+	}
+	job := s.manager.GetJob(jobID)
+	if job == nil {
+		return
+	}
+	payload := api.RunEvent{Job: *job, EngagementID: job.ResultID}
+	if names, err := loadTargetNames(s.appCtx.ResultsDir, job.ResultID); err != nil {
+		cliLog().Warnw("target_names_load_failed", "engagement_id", job.ResultID, "error", err)
+	} else {
+		payload.TargetNames = names
+	}
+	if event == api.EventRunCompleted {
+		results := &resultsAPIService{appCtx: s.appCtx}
+		data, err := results.GetResults(context.Background(), job.ResultID)
+		switch {
+		case err != nil:
+			cliLog().Warnw("webhook_results_unavailable", "job_id", job.ID, "error", err)
+		case json.Valid(data):
+			payload.Results = data
+		}
+	}
+	if _, err := s.webhooks.Dispatch(event, job.ID, payload); err != nil {
+		cliLog().Warnw("webhook_dispatch_failed", "job_id", job.ID, "error", err)
+	}
+}
+
+// newWebhookDispatcher returns a dispatcher for the <prefix>.endpoints.<name>
+// entries in config, or nil when there are none. Each endpoint's signing
+// secret is read from the environment variable named by its secret_env.
+// The retry policy always comes from webhooks.*.
+func newWebhookDispatcher(logger *zap.Logger, prefix string) (*api.WebhookDispatcher, error) {
+	names := sortedMapKeys(viper.Get(prefix + ".endpoints"))
+	if len(names) == 0 {
+		return nil, nil
+	}
+	endpoints := make([]api.WebhookEndpoint, 0, len(names))
+	for _, name := range names {
+		key := prefix + ".endpoints." + name + "."
+		rawURL := strings.TrimSpace(viper.GetString(key + "url"))
+		if err := validateWebhookURL(rawURL); err != nil || rawURL == "" {
+			return nil, fmt.Errorf("%surl: invalid webhook URL %q", key, rawURL)
+		}
+		secretEnv := strings.TrimSpace(viper.GetString(key + "secret_env"))
+		if secretEnv == "" {
+			return nil, fmt.Errorf("%ssecret_env is required so deliveries can be signed", key)
+		}
+		secret := os.Getenv(secretEnv)
+		if secret == "" {
+			return nil, fmt.Errorf("webhook %s: $%s is not set", name, secretEnv)
+		}
+		endpoints = append(endpoints, api.WebhookEndpoint{Name: name, URL: rawURL, Secret: secret})
+	}
+	return api.NewWebhookDispatcher(api.WebhookConfig{
+		Endpoints:      endpoints,
+		MaxAttempts:    viper.GetInt("webhooks.max_attempts"),
+		InitialBackoff: time.Duration(viper.GetInt("webhooks.backoff_secs")) * time.Second,
+		Timeout:        time.Duration(viper.GetInt("webhooks.timeout_secs")) * time.Second,
+		Logger:         logger.Named("webhooks"),
+	}), nil
+}
+
+// newScheduler loads the schedules kept in dataDir and starts running them
+// through jobs, the same path as POST /api/v1/jobs.
+func newScheduler(jobs api.JobService, engagements api.EngagementService, dataDir string, logger *zap.Logger) (*api.Scheduler, error) {
+	scheduler, err := api.NewScheduler(api.SchedulerConfig{
+		Path:          filepath.Join(dataDir, "schedules.json"),
+		Jobs:          jobs,
+		Engagements:   engagements,
+		MaxConcurrent: viper.GetInt("schedules.max_concurrent_jobs"),
+		Logger:        logger.Named("scheduler"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	scheduler.Start()
+	return scheduler, nil
+}
+
+func validateWebhookURL(value any) error {
+	s, ok := value.(string)
+	if !ok || strings.TrimSpace(s) == "" {
+		return nil
+	}
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook URL %q (expected http or https)", s)
+	}
+	return nil
+}
+
 func (s *jobAPIService) GetJob(ctx context.Context, id string) (*api.Job, error) {
 	job := s.manager.GetJob(id)
 	if job == nil {
-		return nil, fmt.Errorf("job not found")
+		return nil, api.ErrJobNotFound
 	}
 	return job, nil
 }
@@ -375,6 +754,7 @@ func (s *jobAPIService) Subscribe() (chan api.Job, func()) {
 
 type cliCheckRunner struct {
 	executable string
+	env        []string // Extra environment for the check process, e.g. a workspace's data dir
 }
 
 func newCliCheckRunner() (*cliCheckRunner, error) {
@@ -391,6 +771,11 @@ func (r *cliCheckRunner) RunHTTP(ctx context.Context, engagementID string) error
 	}
 	args := []string{"check", "http", "--id", engagementID, "--roe-confirm", "--progress=false"}
 	cmd := exec.CommandContext(ctx, r.executable, args...) // #nosec G204 -- executable is trusted binary and args are fixed with validated engagement ID.
+	if len(r.env) > 0 {
+		cmd.Env = append(os.Environ(), r.env...)
+	}
+	// Once the check is cancelled, do not wait long for its output to close
+	cmd.WaitDelay = 5 * time.Second
 
 	// Create limited buffers (1MB max each) to prevent memory exhaustion
 	// If output exceeds this, command will block until buffer space is available
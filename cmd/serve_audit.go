@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/domain/audit"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/api"
+)
+
+// schedulerOperator is the audit operator of actions the server starts on
+// its own, such as scheduled scans.
+const schedulerOperator = "api/scheduler"
+
+// recordAPIAuditEvent records an API action in the engagement's audit trail,
+// as recordAuditEvent does for CLI commands. The operator is the API
+// identity behind ctx, and its role, the request ID and client address are
+// added to the notes.
+func recordAPIAuditEvent(ctx context.Context, appCtx *AppContext, engagementID, command, target, notes string) error {
+	operator := schedulerOperator
+	if id, ok := api.IdentityFromContext(ctx); ok {
+		operator = id.Operator()
+		notes = strings.TrimSpace(fmt.Sprintf("%s role=%s request_id=%s remote_addr=%s", notes, id.Role, id.RequestID, id.RemoteAddr))
+	}
+	entry := &audit.Entry{
+		Timestamp:    time.Now(),
+		EngagementID: engagementID,
+		Operator:     operator,
+		Command:      command,
+		Target:       target,
+		Status:       "ok",
+		Notes:        notes,
+	}
+	if err := appCtx.Services.CheckOrchestrator.RecordAuditEntry(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record audit: %w", err)
+	}
+	if err := appCtx.Services.AuditRepo.Flush(ctx, engagementID); err != nil {
+		return fmt.Errorf("failed to record audit: %w", err)
+	}
+	return nil
+}
+
+// auditedScheduler records schedule changes in the audit trail of the
+// schedule's engagement.
+type auditedScheduler struct {
+	*api.Scheduler
+	appCtx *AppContext
+}
+
+func (s *auditedScheduler) CreateSchedule(ctx context.Context, req api.ScheduleRequest) (*api.Schedule, error) {
+	sc, err := s.Scheduler.CreateSchedule(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.record(ctx, "api schedule create", sc)
+	return sc, nil
+}
+
+func (s *auditedScheduler) UpdateSchedule(ctx context.Context, id string, req api.ScheduleRequest) (*api.Schedule, error) {
+	sc, err := s.Scheduler.UpdateSchedule(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+	s.record(ctx, "api schedule update", sc)
+	return sc, nil
+}
+
+func (s *auditedScheduler) DeleteSchedule(ctx context.Context, id string) error {
+	sc, err := s.Scheduler.GetSchedule(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.Scheduler.DeleteSchedule(ctx, id); err != nil {
+		return err
+	}
+	s.record(ctx, "api schedule delete", sc)
+	return nil
+}
+
+func (s *auditedScheduler) record(ctx context.Context, command string, sc *api.Schedule) {
+	notes := fmt.Sprintf("name=%q cron=%q timezone=%s enabled=%t blackouts=%d", sc.Name, sc.Cron, sc.Timezone, sc.Enabled, len(sc.Blackouts))
+	if err := recordAPIAuditEvent(ctx, s.appCtx, sc.EngagementID, command, sc.ID, notes); err != nil {
+		cliLog().Errorw("api_audit_failed", "schedule_id", sc.ID, "error", err)
+	}
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/api"
+	"go.uber.org/zap"
+)
+
+func TestHealthAPIServiceReady(t *testing.T) {
+	cleanup := setupTestAppContextWithServices(t)
+	defer cleanup()
+	appCtx := globalAppContext
+
+	jobs := newJobAPIService(&cliCheckRunner{executable: os.Args[0]}, appCtx, nil, "")
+	scheduler, err := newScheduler(jobs, &engagementAPIService{appCtx: appCtx}, t.TempDir(), zap.NewNop())
+	if err != nil {
+		t.Fatalf("newScheduler() error = %v", err)
+	}
+	health := &healthAPIService{appCtx: appCtx, jobs: jobs, scheduler: scheduler}
+
+	statuses := func() (string, map[string]string) {
+		report, err := health.Ready(context.Background())
+		if err != nil {
+			t.Fatalf("Ready() error = %v", err)
+		}
+		byName := map[string]string{}
+		for _, c := range report.Checks {
+			byName[c.Name] = c.Status
+		}
+		return report.Status, byName
+	}
+
+	status, checks := statuses()
+	if status != api.StatusReady || len(checks) != 4 {
+		t.Fatalf("expected four passing checks, got %s %v", status, checks)
+	}
+	for name, s := range checks {
+		if s != api.DependencyOK {
+			t.Errorf("expected %s to be ok, got %s", name, s)
+		}
+	}
+
+	appCtx.ResultsDir = filepath.Join(t.TempDir(), "missing")
+	scheduler.Close()
+	status, checks = statuses()
+	if status != api.StatusNotReady || checks["results_dir"] != api.DependencyDown || checks["scheduler"] != api.DependencyDown {
+		t.Errorf("expected the results dir and scheduler to be down, got %s %v", status, checks)
+	}
+}
+
+// blockingRunner runs checks that only end when cancelled.
+type blockingRunner struct{ started chan struct{} }
+
+func (r *blockingRunner) RunHTTP(ctx context.Context, engagementID string) error {
+	r.started <- struct{}{}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestJobAPIServiceDrain(t *testing.T) {
+	cleanup := setupTestAppContextWithServices(t)
+	defer cleanup()
+	appCtx := globalAppContext
+	ctx := context.Background()
+
+	eng, err := appCtx.Services.EngagementService.CreateEngagement(ctx, "Drain test", "owner", "Standard ROE", nil)
+	if err != nil {
+		t.Fatalf("CreateEngagement() error = %v", err)
+	}
+	dataDir := t.TempDir()
+	runner := &blockingRunner{started: make(chan struct{}, 1)}
+	jobs := newJobAPIService(runner, appCtx, nil, dataDir)
+	job, err := jobs.StartJob(ctx, api.JobRequest{EngagementID: eng.ID()})
+	if err != nil {
+		t.Fatalf("StartJob() error = %v", err)
+	}
+	<-runner.started
+
+	drainCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if interrupted := jobs.drain(drainCtx); interrupted != 1 {
+		t.Errorf("expected 1 interrupted job, got %d", interrupted)
+	}
+	if got := jobs.manager.GetJob(job.ID); got.Status != "error" || got.Error != api.JobInterrupted {
+		t.Errorf("expected the job to be recorded as interrupted, got %+v", got)
+	}
+	if _, err := jobs.StartJob(ctx, api.JobRequest{EngagementID: eng.ID()}); !errors.Is(err, api.ErrShuttingDown) {
+		t.Errorf("expected new jobs to be refused while draining, got %v", err)
+	}
+
+	// The checkpoint lets the next server report the job
+	restored := newJobAPIService(runner, appCtx, nil, dataDir)
+	if got := restored.manager.GetJob(job.ID); got == nil || got.Error != api.JobInterrupted {
+		t.Errorf("expected the job to be restored from the checkpoint, got %+v", got)
+	}
+}
+
+func TestAPIMutationsAreAudited(t *testing.T) {
+	cleanup := setupTestAppContextWithServices(t)
+	defer cleanup()
+	appCtx := globalAppContext
+	ctx := context.Background()
+
+	eng, err := appCtx.Services.EngagementService.CreateEngagement(ctx, "Audit test", "owner", "Standard ROE", nil)
+	if err != nil {
+		t.Fatalf("CreateEngagement() error = %v", err)
+	}
+	runner := &blockingRunner{started: make(chan struct{}, 1)}
+	jobs := newJobAPIService(runner, appCtx, nil, "")
+	srv := api.NewServer(api.Config{Jobs: jobs, AuthToken: "secret"})
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("X-Auth-Token", "secret")
+		req.Header.Set(api.HeaderUser, "alice@example.com")
+		rr := httptest.NewRecorder()
+		srv.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := do(http.MethodPost, "/api/v1/jobs", `{"engagement_id":"`+eng.ID()+`"}`)
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var job api.Job
+	if err := json.Unmarshal(rr.Body.Bytes(), &job); err != nil {
+		t.Fatal(err)
+	}
+	<-runner.started
+
+	if rr := do(http.MethodDelete, "/api/v1/jobs/"+job.ID, ""); rr.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", rr.Code, rr.Body.String())
+	}
+	jobs.wg.Wait()
+	if got := jobs.manager.GetJob(job.ID); got.Status != "error" || got.Error != api.JobCancelled {
+		t.Errorf("expected the job to be recorded as cancelled, got %+v", got)
+	}
+	if rr := do(http.MethodDelete, "/api/v1/jobs/"+job.ID, ""); rr.Code != http.StatusConflict {
+		t.Errorf("expected 409 for a finished job, got %d", rr.Code)
+	}
+
+	trail, err := appCtx.Services.AuditRepo.FindByEngagementID(ctx, eng.ID())
+	if err != nil {
+		t.Fatalf("FindByEngagementID() error = %v", err)
+	}
+	operator := "alice@example.com via api/default/key-" + api.KeyFingerprint("secret")
+	var commands []string
+	for _, entry := range trail.Entries() {
+		if entry.Operator != operator {
+			t.Errorf("expected operator %q, got %q", operator, entry.Operator)
+		}
+		commands = append(commands, entry.Command)
+	}
+	if strings.Join(commands, ",") != "api job start,api job cancel" {
+		t.Errorf("unexpected audit commands: %v", commands)
+	}
+}
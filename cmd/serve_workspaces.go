@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/khanhnv2901/seca-cli/internal/application"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/api"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+var workspaceNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// newAPIWorkspaces builds the workspaces.<name> entries in config for the API
+// server. Each workspace gets its own data and results directories, job
+// queue, schedules and webhooks, and is reached with the key in
+// $<api_key_env>. The returned function releases their services.
+func newAPIWorkspaces(appCtx *AppContext, runner *cliCheckRunner, authToken string, apiKeys []api.APIKey, logger *zap.Logger) ([]api.Workspace, func(), error) {
+	var closers []func()
+	closeAll := func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i]()
+		}
+	}
+
+	names := sortedMapKeys(viper.Get("workspaces"))
+	if len(names) == 0 {
+		return nil, closeAll, nil
+	}
+	baseDataDir, err := getDataDir()
+	if err != nil {
+		return nil, closeAll, fmt.Errorf("failed to get data directory: %w", err)
+	}
+
+	// Directories and keys already claimed, so no two workspaces share data
+	dirs := map[string]string{
+		filepath.Clean(baseDataDir):       api.DefaultWorkspace,
+		filepath.Clean(appCtx.ResultsDir): api.DefaultWorkspace,
+	}
+	keys := map[string]string{}
+	if authToken != "" {
+		keys[authToken] = "--auth-token"
+	}
+	for _, k := range apiKeys {
+		keys[k.Key] = "api.keys." + k.Name
+	}
+
+	workspaces := make([]api.Workspace, 0, len(names))
+	for _, name := range names {
+		ws, closeWorkspace, err := newAPIWorkspace(appCtx, runner, name, baseDataDir, dirs, keys, logger)
+		if err != nil {
+			closeAll()
+			return nil, func() {}, err
+		}
+		closers = append(closers, closeWorkspace)
+		workspaces = append(workspaces, ws)
+	}
+	return workspaces, closeAll, nil
+}
+
+func newAPIWorkspace(appCtx *AppContext, runner *cliCheckRunner, name, baseDataDir string, dirs, keys map[string]string, logger *zap.Logger) (api.Workspace, func(), error) {
+	prefix := "workspaces." + name + "."
+	if !workspaceNamePattern.MatchString(name) || name == api.DefaultWorkspace {
+		return api.Workspace{}, nil, fmt.Errorf("invalid workspace name %q (letters, digits, '.', '_' and '-'; %q is reserved)", name, api.DefaultWorkspace)
+	}
+
+	keyEnv := strings.TrimSpace(viper.GetString(prefix + "api_key_env"))
+	if keyEnv == "" {
+		return api.Workspace{}, nil, fmt.Errorf("%sapi_key_env is required", prefix)
+	}
+	apiKey := strings.TrimSpace(os.Getenv(keyEnv))
+	if apiKey == "" {
+		return api.Workspace{}, nil, fmt.Errorf("workspace %s: $%s is not set", name, keyEnv)
+	}
+	if other, ok := keys[apiKey]; ok {
+		return api.Workspace{}, nil, fmt.Errorf("workspace %s: API key is also used by %s", name, other)
+	}
+	keys[apiKey] = "workspace " + name
+	roleKeys, err := newAPIKeys(prefix+"keys", keys)
+	if err != nil {
+		return api.Workspace{}, nil, fmt.Errorf("workspace %s: %w", name, err)
+	}
+
+	dataDir := strings.TrimSpace(viper.GetString(prefix + "data_dir"))
+	if dataDir == "" {
+		dataDir = filepath.Join(baseDataDir, "workspaces", name)
+	}
+	resultsDir := strings.TrimSpace(viper.GetString(prefix + "results_dir"))
+	if resultsDir == "" {
+		resultsDir = filepath.Join(dataDir, "results")
+	}
+	for _, dir := range []*string{&dataDir, &resultsDir} {
+		abs, err := filepath.Abs(*dir)
+		if err != nil {
+			return api.Workspace{}, nil, fmt.Errorf("workspace %s: %w", name, err)
+		}
+		*dir = filepath.Clean(abs)
+		if owner, ok := dirs[*dir]; ok {
+			return api.Workspace{}, nil, fmt.Errorf("workspace %s: directory %s is already used by %s", name, *dir, owner)
+		}
+		dirs[*dir] = "workspace " + name
+		if err := os.MkdirAll(*dir, consts.DefaultDirPerm); err != nil {
+			return api.Workspace{}, nil, fmt.Errorf("workspace %s: %w", name, err)
+		}
+	}
+
+	services, err := application.NewContainer(dataDir, resultsDir)
+	if err != nil {
+		return api.Workspace{}, nil, fmt.Errorf("workspace %s: failed to initialize services: %w", name, err)
+	}
+	webhooks, err := newWebhookDispatcher(logger, "workspaces."+name+".webhooks")
+	if err != nil {
+		_ = services.Close()
+		return api.Workspace{}, nil, err
+	}
+
+	wsCtx := &AppContext{
+		Logger:     cliLog().With("workspace", name),
+		Operator:   appCtx.Operator,
+		ResultsDir: resultsDir,
+		Config:     appCtx.Config,
+		Services:   services,
+	}
+	// Checks run in a child process; point it at the workspace's directories
+	wsRunner := &cliCheckRunner{
+		executable: runner.executable,
+		env: []string{
+			dataDirEnvVar + "=" + dataDir,
+			envPrefix + "_RESULTS_DIR=" + resultsDir,
+		},
+	}
+	engagements := &engagementAPIService{appCtx: wsCtx}
+	jobs := newJobAPIService(wsRunner, wsCtx, webhooks, dataDir)
+	scheduler, err := newScheduler(jobs, engagements, dataDir, logger.With(zap.String("workspace", name)))
+	if err != nil {
+		if webhooks != nil {
+			webhooks.Close()
+		}
+		_ = services.Close()
+		return api.Workspace{}, nil, fmt.Errorf("workspace %s: %w", name, err)
+	}
+	ws := api.Workspace{
+		Name:        name,
+		APIKey:      apiKey,
+		Keys:        roleKeys,
+		Engagements: engagements,
+		Results:     &resultsAPIService{appCtx: wsCtx},
+		Telemetry:   &telemetryAPIService{appCtx: wsCtx},
+		Health:      &healthAPIService{appCtx: wsCtx, jobs: jobs, scheduler: scheduler},
+		Jobs:        jobs,
+		Schedules:   &auditedScheduler{Scheduler: scheduler, appCtx: wsCtx},
+	}
+	if webhooks != nil {
+		ws.Deliveries = webhooks
+	}
+	closeWorkspace := func() {
+		scheduler.Close()
+		if webhooks != nil {
+			webhooks.Close()
+		}
+		if err := services.Close(); err != nil {
+			cliLog().Warnw("services_close_failed", "workspace", name, "error", err)
+		}
+	}
+	return ws, closeWorkspace, nil
+}
+
+// newAPIKeys reads the keys.<name> entries under prefix: each is a key in
+// $<api_key_env> limited to a role, viewer unless set. keys maps every key
+// already in use to its owner, so none is given two roles.
+func newAPIKeys(prefix string, keys map[string]string) ([]api.APIKey, error) {
+	names := sortedMapKeys(viper.Get(prefix))
+	apiKeys := make([]api.APIKey, 0, len(names))
+	for _, name := range names {
+		entry := prefix + "." + name
+		keyEnv := strings.TrimSpace(viper.GetString(entry + ".api_key_env"))
+		if keyEnv == "" {
+			return nil, fmt.Errorf("%s.api_key_env is required", entry)
+		}
+		key := strings.TrimSpace(os.Getenv(keyEnv))
+		if key == "" {
+			return nil, fmt.Errorf("%s: $%s is not set", entry, keyEnv)
+		}
+		if other, ok := keys[key]; ok {
+			return nil, fmt.Errorf("%s: API key is also used by %s", entry, other)
+		}
+		keys[key] = entry
+
+		role := api.RoleViewer
+		if value := viper.GetString(entry + ".role"); value != "" {
+			parsed, err := api.ParseRole(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s.role: %w", entry, err)
+			}
+			role = parsed
+		}
+		apiKeys = append(apiKeys, api.APIKey{Name: name, Key: key, Role: role})
+	}
+	return apiKeys, nil
+}
+
+func validateAPIRole(value any) error {
+	s, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	_, err := api.ParseRole(s)
+	return err
+}
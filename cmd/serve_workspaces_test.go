@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/api"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+func TestNewAPIWorkspacesIsolatesData(t *testing.T) {
+	cleanup := setupTestAppContextWithServices(t)
+	defer cleanup()
+	t.Cleanup(viper.Reset)
+	appCtx := globalAppContext
+
+	t.Setenv("TEAM_A_KEY", "key-a")
+	t.Setenv("TEAM_B_KEY", "key-b")
+	viper.Set("workspaces.team-a.api_key_env", "TEAM_A_KEY")
+	viper.Set("workspaces.team-b.api_key_env", "TEAM_B_KEY")
+	t.Setenv("TEAM_A_CI_KEY", "key-a-ci")
+	viper.Set("workspaces.team-a.keys.ci.api_key_env", "TEAM_A_CI_KEY")
+	viper.Set("workspaces.team-a.keys.ci.role", "operator")
+
+	runner := &cliCheckRunner{executable: "seca"}
+	workspaces, closeAll, err := newAPIWorkspaces(appCtx, runner, "admin", nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("newAPIWorkspaces() error = %v", err)
+	}
+	defer closeAll()
+	if len(workspaces) != 2 || workspaces[0].Name != "team-a" || workspaces[0].APIKey != "key-a" {
+		t.Fatalf("unexpected workspaces: %+v", workspaces)
+	}
+	if keys := workspaces[0].Keys; len(keys) != 1 || keys[0].Name != "ci" || keys[0].Key != "key-a-ci" || keys[0].Role != api.RoleOperator {
+		t.Errorf("unexpected role keys: %+v", keys)
+	}
+
+	ctx := context.Background()
+	created, err := workspaces[0].Engagements.CreateEngagement(ctx, api.EngagementCreateRequest{Name: "Team A audit", Owner: "team-a", ROE: "Standard ROE", ROEAgree: true})
+	if err != nil {
+		t.Fatalf("CreateEngagement() error = %v", err)
+	}
+	if _, err := workspaces[1].Engagements.GetEngagement(ctx, created.ID); err == nil {
+		t.Error("team-b must not see team-a's engagement")
+	}
+	if _, err := appCtx.Services.EngagementService.GetEngagement(ctx, created.ID); err == nil {
+		t.Error("the default workspace must not see team-a's engagement")
+	}
+
+	jobs := workspaces[0].Jobs.(*jobAPIService)
+	wsRunner := jobs.runner.(*cliCheckRunner)
+	wantResults := filepath.Join(filepath.Dir(appCtx.ResultsDir), "workspaces", "team-a", "results")
+	if jobs.appCtx.ResultsDir != wantResults {
+		t.Errorf("expected results dir %s, got %s", wantResults, jobs.appCtx.ResultsDir)
+	}
+	if !strings.Contains(strings.Join(wsRunner.env, " "), "SECA_RESULTS_DIR="+wantResults) {
+		t.Errorf("expected the check process to use the workspace results dir, got env %v", wsRunner.env)
+	}
+}
+
+func TestNewAPIWorkspacesRejectsSharedKeysAndDirs(t *testing.T) {
+	cleanup := setupTestAppContextWithServices(t)
+	defer cleanup()
+	runner := &cliCheckRunner{executable: "seca"}
+	t.Setenv("TEAM_A_KEY", "key-a")
+	t.Setenv("TEAM_B_KEY", "key-b")
+	t.Setenv("TEAM_C_KEY", "key-c")
+	sharedDir := filepath.Join(t.TempDir(), "shared")
+
+	tests := []struct {
+		name     string
+		settings map[string]any
+		want     string
+	}{
+		{
+			name:     "key reused as auth token",
+			settings: map[string]any{"workspaces.team-a.api_key_env": "TEAM_A_KEY"},
+			want:     "also used by --auth-token",
+		},
+		{
+			name: "shared results dir",
+			settings: map[string]any{
+				"workspaces.team-b.api_key_env": "TEAM_B_KEY",
+				"workspaces.team-c.api_key_env": "TEAM_C_KEY",
+				"workspaces.team-b.results_dir": sharedDir,
+				"workspaces.team-c.results_dir": sharedDir,
+			},
+			want: "already used by workspace team-b",
+		},
+		{
+			name: "role key reused as workspace key",
+			settings: map[string]any{
+				"workspaces.team-b.api_key_env":         "TEAM_B_KEY",
+				"workspaces.team-b.keys.ci.api_key_env": "TEAM_B_KEY",
+			},
+			want: "workspaces.team-b.keys.ci: API key is also used by workspace team-b",
+		},
+		{
+			name: "unknown role",
+			settings: map[string]any{
+				"workspaces.team-b.api_key_env":         "TEAM_B_KEY",
+				"workspaces.team-b.keys.ci.api_key_env": "TEAM_C_KEY",
+				"workspaces.team-b.keys.ci.role":        "owner",
+			},
+			want: `unknown role "owner"`,
+		},
+		{
+			name:     "missing key",
+			settings: map[string]any{"workspaces.team-b.api_key_env": "UNSET_KEY"},
+			want:     "$UNSET_KEY is not set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			t.Cleanup(viper.Reset)
+			for k, v := range tt.settings {
+				viper.Set(k, v)
+			}
+			_, _, err := newAPIWorkspaces(globalAppContext, runner, "key-a", nil, zap.NewNop())
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
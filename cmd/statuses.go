@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+// statusCounts counts check results by status. Results with a status this
+// version does not know count as errors.
+type statusCounts struct {
+	OK          int
+	Warning     int
+	Error       int
+	Blocked     int
+	Unreachable int
+	Skipped     int
+}
+
+func countStatuses(results []checker.CheckResult) statusCounts {
+	return countStatusSeq(slices.Values(results))
+}
+
+// countStatusSeq is countStatuses for results read one at a time.
+func countStatusSeq(results iter.Seq[checker.CheckResult]) statusCounts {
+	var c statusCounts
+	for r := range results {
+		switch r.Status {
+		case checker.StatusOK:
+			c.OK++
+		case checker.StatusWarning:
+			c.Warning++
+		case checker.StatusBlocked:
+			c.Blocked++
+		case checker.StatusUnreachable:
+			c.Unreachable++
+		case checker.StatusSkipped:
+			c.Skipped++
+		default:
+			c.Error++
+		}
+	}
+	return c
+}
+
+// Succeeded counts the targets whose checks ran, warnings included.
+func (c statusCounts) Succeeded() int { return c.OK + c.Warning }
+
+// Failed counts the targets started but not checked: checker errors, WAF
+// blocks, and unreachable hosts.
+func (c statusCounts) Failed() int { return c.Error + c.Blocked + c.Unreachable }
+
+// Checked counts the targets the run started, i.e. all but skipped ones.
+func (c statusCounts) Checked() int { return c.Succeeded() + c.Failed() }
+
+// SuccessRate is the percentage of started targets whose checks ran.
+func (c statusCounts) SuccessRate() float64 {
+	if c.Checked() == 0 {
+		return 0
+	}
+	return float64(c.Succeeded()) / float64(c.Checked()) * 100
+}
+
+// failureBreakdown says which failures were not checker errors, e.g.
+// "2 blocked, 1 unreachable", or "" when all were.
+func (c statusCounts) failureBreakdown() string {
+	var parts []string
+	if c.Blocked > 0 {
+		parts = append(parts, fmt.Sprintf("%d blocked", c.Blocked))
+	}
+	if c.Unreachable > 0 {
+		parts = append(parts, fmt.Sprintf("%d unreachable", c.Unreachable))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printStatusBreakdown reports the targets that were not cleanly checked,
+// listing the blocked and unreachable ones with the reason.
+func printStatusBreakdown(results []checker.CheckResult) {
+	counts := countStatuses(results)
+	if counts.Warning > 0 {
+		fmt.Printf("%s Warnings: %d target(s) checked, but part of the check did not complete\n", colorWarn("!"), counts.Warning)
+	}
+	for _, status := range []struct {
+		name, label string
+		n           int
+	}{
+		{checker.StatusBlocked, "Blocked: %d target(s) answered by a WAF block page, full checks skipped", counts.Blocked},
+		{checker.StatusUnreachable, "Unreachable: %d target(s), full checks skipped", counts.Unreachable},
+	} {
+		if status.n == 0 {
+			continue
+		}
+		fmt.Printf("%s "+status.label+"\n", colorWarn("!"), status.n)
+		for _, r := range results {
+			if r.Status == status.name {
+				fmt.Printf("  %s %s: %s\n", colorWarn("!"), r.Target, r.Error)
+			}
+		}
+	}
+	if counts.Skipped > 0 {
+		fmt.Printf("%s Skipped: %d target(s) not started\n", colorWarn("!"), counts.Skipped)
+	}
+}
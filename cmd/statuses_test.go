@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func mixedStatusResults() []checker.CheckResult {
+	return []checker.CheckResult{
+		{Target: "https://ok.example.com", Status: checker.StatusOK},
+		{Target: "https://partial.example.com", Status: checker.StatusWarning, Notes: "warning: failed to read response body: EOF"},
+		{Target: "https://broken.example.com", Status: checker.StatusError, Error: "tls: handshake failure"},
+		{Target: "https://waf.example.com", Status: checker.StatusBlocked, Error: "blocked by Cloudflare (HTTP 403)"},
+		{Target: "https://down.example.com", Status: checker.StatusUnreachable, Error: "DNS lookup of down.example.com failed: no such host"},
+		{Target: "https://late.example.com", Status: checker.StatusSkipped, Error: "budget: max requests of 10 reached"},
+		{Target: "https://future.example.com", Status: "teapot"},
+	}
+}
+
+func TestCountStatuses(t *testing.T) {
+	counts := countStatuses(mixedStatusResults())
+	want := statusCounts{OK: 1, Warning: 1, Error: 2, Blocked: 1, Unreachable: 1, Skipped: 1}
+	if counts != want {
+		t.Fatalf("countStatuses() = %+v, want %+v", counts, want)
+	}
+	if counts.Succeeded() != 2 || counts.Failed() != 4 || counts.Checked() != 6 {
+		t.Errorf("succeeded %d, failed %d, checked %d", counts.Succeeded(), counts.Failed(), counts.Checked())
+	}
+	// Skipped targets were never started and do not lower the success rate.
+	if rate := counts.SuccessRate(); rate < 33.3 || rate > 33.4 {
+		t.Errorf("SuccessRate() = %.2f, want 33.33", rate)
+	}
+	if got := counts.failureBreakdown(); got != "1 blocked, 1 unreachable" {
+		t.Errorf("failureBreakdown() = %q", got)
+	}
+}
+
+func TestStatusesPropagateToReportAndSummary(t *testing.T) {
+	output := &RunOutput{
+		Metadata: RunMetadata{EngagementID: "eng-mixed", StartAt: time.Now(), CompleteAt: time.Now()},
+		Results:  mixedStatusResults(),
+	}
+	data := buildTemplateData(output, nil, "%.2f", nil)
+	if data.SuccessCount != 2 || data.WarningCount != 1 || data.ErrorCount != 2 || data.BlockedCount != 1 || data.SkippedCount != 1 {
+		t.Fatalf("unexpected report counts: %+v", data)
+	}
+	markdown, err := generateMarkdownReport(data)
+	if err != nil {
+		t.Fatalf("Failed to generate markdown report: %v", err)
+	}
+	for _, line := range []string{"- **Completed With Warnings:** 1", "- **Blocked by WAF:** 1", "- **Skipped:** 1"} {
+		if !strings.Contains(markdown, line) {
+			t.Errorf("markdown summary is missing %q", line)
+		}
+	}
+
+	stats := summarizeReportStats(output)
+	if stats.Success != 2 || stats.Fail != 4 || stats.Blocked != 1 || stats.Skipped != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	summary := newRunSummary(t.TempDir(), runOutcome{EngagementID: "eng-mixed", Results: output.Results, StartedAt: time.Now()})
+	summary.applyExitPolicy(failOnNone, true)
+	if summary.ExitCode != exitTargetErrors || summary.Warnings != 1 || summary.Skipped != 1 {
+		t.Errorf("unexpected run summary: %+v", summary)
+	}
+	if !strings.HasSuffix(summary.ExitReason, "(1 blocked, 1 unreachable)") {
+		t.Errorf("exit reason should break down the failures, got %q", summary.ExitReason)
+	}
+}
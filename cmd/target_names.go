@@ -0,0 +1,301 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+	"github.com/spf13/cobra"
+)
+
+// targetNamesFilename holds the display names of an engagement's scope
+// entries.
+const targetNamesFilename = "target_names.json"
+
+// maxTargetNameLength keeps names short enough for table columns.
+const maxTargetNameLength = 64
+
+// TargetNames maps scope entries to display names such as "Customer Portal".
+// Names only label targets in reports, stats tables, issues and notifications;
+// checks always use the scope entry itself.
+type TargetNames map[string]string
+
+// Name returns the display name of target: that of the scope entry itself,
+// or else that of the named entry on the same host with the longest path
+// prefix of the target's path, so crawled pages carry the name of the entry
+// they were found under.
+func (n TargetNames) Name(target string) string {
+	if len(n) == 0 {
+		return ""
+	}
+	if name, ok := n[target]; ok {
+		return name
+	}
+	info := checker.ParseTarget(target)
+	if info == nil {
+		return ""
+	}
+	name, bestLen := "", -1
+	for entry, entryName := range n {
+		e := checker.ParseTarget(entry)
+		if e == nil || !strings.EqualFold(e.Host, info.Host) {
+			continue
+		}
+		prefix := strings.TrimSuffix(e.Path, "/")
+		if !pathUnder(info.Path, prefix) || len(prefix) < bestLen || (len(prefix) == bestLen && entryName > name) {
+			continue
+		}
+		name, bestLen = entryName, len(prefix)
+	}
+	return name
+}
+
+// Label shows target with its display name, e.g. "Customer Portal
+// (https://portal.example.com)", or as checker.DisplayTarget does when it
+// has none.
+func (n TargetNames) Label(target string) string {
+	return labelTarget(target, n.Name(target))
+}
+
+func labelTarget(target, name string) string {
+	display := checker.DisplayTarget(target)
+	if name == "" {
+		return display
+	}
+	return name + " (" + display + ")"
+}
+
+// normalizeTargetName trims the name and rejects ones that cannot be shown
+// on a single table line.
+func normalizeTargetName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("display name must not be empty")
+	}
+	if len(name) > maxTargetNameLength {
+		return "", fmt.Errorf("display name must be at most %d characters", maxTargetNameLength)
+	}
+	for _, r := range name {
+		if !unicode.IsPrint(r) {
+			return "", fmt.Errorf("display name %q contains non-printable characters", name)
+		}
+	}
+	return name, nil
+}
+
+// loadTargetNames returns the engagement's display names, or nil when none
+// are set.
+func loadTargetNames(resultsDir, engagementID string) (TargetNames, error) {
+	path, err := resolveResultsPath(resultsDir, engagementID, targetNamesFilename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var names TargetNames
+	if err := json.Unmarshal(data, &names); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", targetNamesFilename, err)
+	}
+	return names, nil
+}
+
+// saveTargetNames writes names, removing the file when there are none.
+func saveTargetNames(resultsDir, engagementID string, names TargetNames) error {
+	if _, err := ensureResultsDir(resultsDir, engagementID); err != nil {
+		return err
+	}
+	path, err := resolveResultsPath(resultsDir, engagementID, targetNamesFilename)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(names, jsonPrefix, jsonIndent)
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFile(path, data, consts.DefaultFilePerm)
+}
+
+var engagementNamesCmd = &cobra.Command{
+	Use:   "names",
+	Short: "Manage display names for an engagement's scope entries",
+	Long: `Give scope entries display names, such as "Customer Portal" for
+https://portal.example.com. Reports, "seca report stats" tables, synced
+issues and webhook notifications show the name next to the target; pages
+crawled under an entry carry its name too. Checks always use the scope entry itself.`,
+}
+
+var engagementNamesSetCmd = &cobra.Command{
+	Use:     "set",
+	Short:   "Set the display name of a scope entry",
+	Example: `  seca engagement names set --id eng123 --target https://portal.example.com --name "Customer Portal"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		appCtx := getAppContext(cmd)
+
+		id, _ := cmd.Flags().GetString("id")
+		target, _ := cmd.Flags().GetString("target")
+		if id == "" {
+			return errors.New("--id is required")
+		}
+		if target == "" {
+			return errors.New("--target is required")
+		}
+		raw, _ := cmd.Flags().GetString("name")
+		name, err := normalizeTargetName(raw)
+		if err != nil {
+			return err
+		}
+
+		eng, err := appCtx.Services.EngagementService.GetEngagement(ctx, id)
+		if err != nil {
+			if errors.Is(err, sharedErrors.ErrEngagementNotFound) {
+				return fmt.Errorf("engagement %s not found", id)
+			}
+			return fmt.Errorf("failed to get engagement: %w", err)
+		}
+		if err := checkWritable(eng); err != nil {
+			return err
+		}
+		if !slices.Contains(eng.Scope(), target) {
+			return fmt.Errorf("target %s is not in scope (use the scope entry exactly as listed)", target)
+		}
+
+		names, err := loadTargetNames(appCtx.ResultsDir, id)
+		if err != nil {
+			return err
+		}
+		if names == nil {
+			names = TargetNames{}
+		}
+		names[target] = name
+		if err := saveTargetNames(appCtx.ResultsDir, id, names); err != nil {
+			return err
+		}
+		notes := fmt.Sprintf("display name set to %q", name)
+		if err := recordAuditEvent(ctx, appCtx, id, "engagement names", target, notes); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %s is shown as %q\n", colorSuccess("✓"), target, name)
+		return nil
+	},
+}
+
+var engagementNamesShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the display names of an engagement's scope entries",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			return errors.New("--id is required")
+		}
+		names, err := loadTargetNames(appCtx.ResultsDir, id)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			if names == nil {
+				names = TargetNames{}
+			}
+			b, _ := json.MarshalIndent(names, jsonPrefix, jsonIndent)
+			fmt.Fprintln(out, string(b))
+			return nil
+		}
+		if len(names) == 0 {
+			fmt.Fprintf(out, "%s no display names set for engagement %s\n", colorInfo("→"), id)
+			return nil
+		}
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TARGET\tNAME")
+		for _, target := range slices.Sorted(maps.Keys(names)) {
+			fmt.Fprintf(w, "%s\t%s\n", target, names[target])
+		}
+		return w.Flush()
+	},
+}
+
+var engagementNamesClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove the display name of one scope entry, or all entries",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		appCtx := getAppContext(cmd)
+		id, _ := cmd.Flags().GetString("id")
+		target, _ := cmd.Flags().GetString("target")
+		if id == "" {
+			return errors.New("--id is required")
+		}
+		if err := requireWritableEngagement(ctx, appCtx, id); err != nil {
+			return err
+		}
+
+		names, err := loadTargetNames(appCtx.ResultsDir, id)
+		if err != nil {
+			return err
+		}
+		auditTarget, notes := id, "display names cleared"
+		if target != "" {
+			if _, ok := names[target]; !ok {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s no display name set for %s\n", colorInfo("→"), target)
+				return nil
+			}
+			delete(names, target)
+			auditTarget, notes = target, "display name cleared"
+		} else {
+			names = nil
+		}
+		if err := saveTargetNames(appCtx.ResultsDir, id, names); err != nil {
+			return err
+		}
+		if err := recordAuditEvent(ctx, appCtx, id, "engagement names", auditTarget, notes); err != nil {
+			return err
+		}
+		if target != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s removed the display name of %s\n", colorSuccess("✓"), target)
+			return nil
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s removed display names for engagement %s\n", colorSuccess("✓"), id)
+		return nil
+	},
+}
+
+func init() {
+	engagementCmd.AddCommand(engagementNamesCmd)
+	engagementNamesCmd.AddCommand(engagementNamesSetCmd)
+	engagementNamesCmd.AddCommand(engagementNamesShowCmd)
+	engagementNamesCmd.AddCommand(engagementNamesClearCmd)
+
+	engagementNamesSetCmd.Flags().String("id", "", "Engagement ID")
+	engagementNamesSetCmd.Flags().String("target", "", "Scope entry to name, exactly as listed in the scope")
+	engagementNamesSetCmd.Flags().String("name", "", `Display name, e.g. "Customer Portal"`)
+
+	engagementNamesShowCmd.Flags().String("id", "", "Engagement ID")
+	engagementNamesShowCmd.Flags().Bool("json", false, "Output display names as JSON")
+
+	engagementNamesClearCmd.Flags().String("id", "", "Engagement ID")
+	engagementNamesClearCmd.Flags().String("target", "", "Scope entry to clear (all entries when omitted)")
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func TestTargetNames(t *testing.T) {
+	names := TargetNames{
+		"https://portal.example.com":       "Customer Portal",
+		"https://portal.example.com/admin": "Portal Admin",
+		"api.example.com":                  "Public API",
+	}
+	tests := map[string]string{
+		"https://portal.example.com":                "Customer Portal",
+		"https://portal.example.com/account":        "Customer Portal",
+		"https://portal.example.com/admin/users":    "Portal Admin",
+		"https://portal.example.com/administrators": "Customer Portal",
+		"https://api.example.com/v1/orders":         "Public API",
+		"https://www.example.com":                   "",
+	}
+	for target, want := range tests {
+		if got := names.Name(target); got != want {
+			t.Errorf("Name(%q) = %q, want %q", target, got, want)
+		}
+	}
+
+	if got := names.Label("https://portal.example.com/login"); got != "Customer Portal (https://portal.example.com/login)" {
+		t.Errorf("Label() = %q", got)
+	}
+	if got := TargetNames(nil).Label("https://xn--bcher-kva.example"); got != "https://bücher.example" {
+		t.Errorf("Label() without names = %q", got)
+	}
+
+	for _, bad := range []string{"", "  ", "tab\there", strings.Repeat("x", maxTargetNameLength+1)} {
+		if _, err := normalizeTargetName(bad); err == nil {
+			t.Errorf("normalizeTargetName(%q): expected error", bad)
+		}
+	}
+}
+
+func TestTargetNamesInReports(t *testing.T) {
+	resultsDir := t.TempDir()
+	const id = "eng-named"
+	start := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+	if _, err := ensureResultsDir(resultsDir, id); err != nil {
+		t.Fatalf("ensureResultsDir() error = %v", err)
+	}
+	writeRunOutputFile(t, resultsDir, id, "http_results.json", RunOutput{
+		Metadata: RunMetadata{EngagementID: id, StartAt: start, CompleteAt: start.Add(time.Minute)},
+		Results: []checker.CheckResult{
+			{Target: "https://portal.example.com", Status: "ok"},
+			{Target: "https://www.example.com", Status: "ok"},
+		},
+	})
+	if err := saveTargetNames(resultsDir, id, TargetNames{"https://portal.example.com": "Customer Portal"}); err != nil {
+		t.Fatalf("saveTargetNames() error = %v", err)
+	}
+
+	wants := map[string][]string{
+		"json": {`"target_names": {`, `"https://portal.example.com": "Customer Portal"`},
+		"md":   {"| Customer Portal (https://portal.example.com) | ok |", "### 2. https://www.example.com"},
+	}
+	for format, want := range wants {
+		rendered, err := loadEngagementReport(resultsDir, id, format)
+		if err != nil {
+			t.Fatalf("loadEngagementReport(%s) error = %v", format, err)
+		}
+		var buf bytes.Buffer
+		if err := rendered.Render(&buf); err != nil {
+			t.Fatalf("Render(%s) error = %v", format, err)
+		}
+		for _, w := range want {
+			if !strings.Contains(buf.String(), w) {
+				t.Errorf("%s report missing %q", format, w)
+			}
+		}
+	}
+
+	rendered, _ := loadEngagementReport(resultsDir, id, "json")
+	summary := summarizeReportStats(rendered.Output)
+	if summary.Results[0].Name != "Customer Portal" || summary.Results[1].Name != "" {
+		t.Errorf("unexpected stats names: %+v", summary.Results)
+	}
+
+	// Clearing the last name removes the file
+	if err := saveTargetNames(resultsDir, id, nil); err != nil {
+		t.Fatalf("saveTargetNames() error = %v", err)
+	}
+	if names, err := loadTargetNames(resultsDir, id); err != nil || names != nil {
+		t.Errorf("expected no names after clearing, got %v (%v)", names, err)
+	}
+}
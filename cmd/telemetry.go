@@ -1,16 +1,23 @@
 package cmd
 
 import (
-	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
-	"os"
+	"strings"
 	"time"
 
 	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/telemetryexport"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/telemetrylog"
 	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
+// telemetryExportTimeout bounds the time a run spends pushing telemetry.
+const telemetryExportTimeout = 15 * time.Second
+
 type TelemetryRecord struct {
 	Timestamp           time.Time `json:"timestamp"`
 	Command             string    `json:"command"`
@@ -21,20 +28,25 @@ type TelemetryRecord struct {
 	SuccessRate         float64   `json:"success_rate"`
 	DurationSeconds     float64   `json:"duration_seconds"`
 	AvgDurationPerCheck float64   `json:"avg_duration_per_check"`
+	Findings            int       `json:"findings"`
+	CriticalFindings    int       `json:"critical_findings"`
 }
 
 func recordTelemetry(appCtx *AppContext, engagementID string, command string, results []checker.CheckResult, duration time.Duration) error {
-	okCount, errorCount := summarizeStatuses(results)
+	counts := countStatuses(results)
 	total := len(results)
 
-	successRate := 0.0
-	if total > 0 {
-		successRate = (float64(okCount) / float64(total)) * 100
+	avgDuration := 0.0
+	if checked := counts.Checked(); checked > 0 {
+		avgDuration = duration.Seconds() / float64(checked)
 	}
 
-	avgDuration := 0.0
-	if total > 0 {
-		avgDuration = duration.Seconds() / float64(total)
+	findings := findingsFromResults(results)
+	critical := 0
+	for _, f := range findings {
+		if strings.EqualFold(f.Severity, "critical") {
+			critical++
+		}
 	}
 
 	record := TelemetryRecord{
@@ -42,11 +54,13 @@ func recordTelemetry(appCtx *AppContext, engagementID string, command string, re
 		Command:             command,
 		EngagementID:        engagementID,
 		TargetCount:         total,
-		SuccessCount:        okCount,
-		ErrorCount:          errorCount,
-		SuccessRate:         successRate,
+		SuccessCount:        counts.Succeeded(),
+		ErrorCount:          counts.Failed(),
+		SuccessRate:         counts.SuccessRate(),
 		DurationSeconds:     duration.Seconds(),
 		AvgDurationPerCheck: avgDuration,
+		Findings:            len(findings),
+		CriticalFindings:    critical,
 	}
 
 	data, err := json.Marshal(record)
@@ -58,69 +72,126 @@ func recordTelemetry(appCtx *AppContext, engagementID string, command string, re
 		return fmt.Errorf("prepare telemetry directory: %w", err)
 	}
 
-	telemetryPath, err := resolveResultsPath(appCtx.ResultsDir, engagementID, "telemetry.jsonl")
+	history, err := openTelemetryLog(appCtx.ResultsDir, engagementID)
 	if err != nil {
 		return fmt.Errorf("determine telemetry path: %w", err)
 	}
-	f, err := os.OpenFile(telemetryPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, consts.DefaultFilePerm)
+	if err := history.Append(data); err != nil {
+		return fmt.Errorf("write telemetry: %w", err)
+	}
+
+	return nil
+}
+
+// openTelemetryLog returns the engagement's telemetry history, rotated per
+// the telemetry.rotate_* and telemetry.retain_segments config keys.
+func openTelemetryLog(resultsDir, engagementID string) (*telemetrylog.Log, error) {
+	telemetryPath, err := resolveResultsPath(resultsDir, engagementID, "telemetry.jsonl")
 	if err != nil {
-		return fmt.Errorf("open telemetry file: %w", err)
+		return nil, err
 	}
-	defer f.Close()
+	opts := telemetrylog.Options{
+		MaxBytes: telemetrylog.DefaultMaxBytes,
+		MaxAge:   time.Duration(viper.GetInt("telemetry.rotate_age_days")) * 24 * time.Hour,
+		Retain:   telemetrylog.DefaultRetain,
+		Perm:     consts.DefaultFilePerm,
+	}
+	if viper.IsSet("telemetry.rotate_size_mb") {
+		opts.MaxBytes = int64(viper.GetInt("telemetry.rotate_size_mb")) << 20
+	}
+	if viper.IsSet("telemetry.retain_segments") {
+		opts.Retain = viper.GetInt("telemetry.retain_segments")
+	}
+	return telemetrylog.Open(telemetryPath, opts), nil
+}
 
-	if _, err := f.Write(append(data, '\n')); err != nil {
-		return fmt.Errorf("write telemetry: %w", err)
+// telemetryExportConfig resolves the exporters from --pushgateway-url and
+// --otlp-endpoint, falling back to the telemetry.* config keys.
+func telemetryExportConfig(cmd *cobra.Command) telemetryexport.Config {
+	return telemetryexport.Config{
+		PushgatewayURL: smtpSetting(cmd, "pushgateway-url", "telemetry.pushgateway_url"),
+		PushgatewayJob: viper.GetString("telemetry.pushgateway_job"),
+		OTLPEndpoint:   smtpSetting(cmd, "otlp-endpoint", "telemetry.otlp_endpoint"),
+		OTLPHeaders:    viper.GetStringMapString("telemetry.otlp_headers"),
+		Timeout:        telemetryExportTimeout,
 	}
+}
 
-	return nil
+// exportTelemetry pushes a finished run to the configured Pushgateway and
+// OTLP collector. Exporters run independently of --telemetry, which only
+// controls the local telemetry.jsonl history; failures are logged and never
+// fail the run.
+func exportTelemetry(cmd *cobra.Command, engagementID, command string, results []checker.CheckResult, startTime time.Time, duration time.Duration) {
+	cfg := telemetryExportConfig(cmd)
+	if !cfg.Enabled() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), telemetryExportTimeout)
+	defer cancel()
+	if err := telemetryexport.Export(ctx, cfg, telemetryRun(engagementID, command, results, startTime, duration)); err != nil {
+		cliLog().Warnw("telemetry_export_failed", "engagement_id", engagementID, "error", err)
+	}
 }
 
-func summarizeStatuses(results []checker.CheckResult) (okCount, errorCount int) {
+// telemetryRun converts check results into the exporter's run model.
+func telemetryRun(engagementID, command string, results []checker.CheckResult, startTime time.Time, duration time.Duration) telemetryexport.Run {
+	counts := countStatuses(results)
+	run := telemetryexport.Run{
+		EngagementID: engagementID,
+		Command:      command,
+		StartedAt:    startTime.UTC(),
+		Duration:     duration,
+		Targets:      len(results),
+		Success:      counts.Succeeded(),
+		Errors:       counts.Failed(),
+		Checks:       make([]telemetryexport.Check, 0, len(results)),
+	}
 	for _, r := range results {
-		if r.Status == "ok" {
-			okCount++
-		} else {
-			errorCount++
-		}
+		findings := len(checker.ResultFindings(r))
+		run.Findings += findings
+		run.Checks = append(run.Checks, telemetryexport.Check{
+			Target:    r.Target,
+			Status:    r.Status,
+			Error:     r.Error,
+			StartedAt: r.CheckedAt,
+			Duration:  time.Duration(r.ResponseTime * float64(time.Millisecond)),
+			Findings:  findings,
+		})
 	}
-	return okCount, errorCount
+	return run
 }
 
+// loadTelemetryHistory returns the engagement's last limit telemetry
+// records, oldest first. The history's index means only the newest records
+// are read.
 func loadTelemetryHistory(resultsDir, engagementID string, limit int) ([]TelemetryRecord, error) {
 	if limit <= 0 {
 		limit = 5
 	}
 
-	telemetryPath, err := resolveResultsPath(resultsDir, engagementID, "telemetry.jsonl")
+	history, err := openTelemetryLog(resultsDir, engagementID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid telemetry path: %w", err)
 	}
-	f, err := os.Open(telemetryPath)
-	if os.IsNotExist(err) {
-		return nil, nil
+	decode := func(line []byte) (TelemetryRecord, bool) {
+		var rec TelemetryRecord
+		if err := json.Unmarshal(line, &rec); err != nil || rec.EngagementID != engagementID {
+			return rec, false
+		}
+		return rec, true
 	}
+	lines, err := history.Tail(limit, func(line []byte) bool {
+		_, ok := decode(line)
+		return ok
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
 
-	records := make([]TelemetryRecord, 0, limit)
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		var rec TelemetryRecord
-		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
-			continue
-		}
-		if rec.EngagementID != engagementID {
-			continue
-		}
+	records := make([]TelemetryRecord, 0, len(lines))
+	for _, line := range lines {
+		rec, _ := decode(line)
 		records = append(records, rec)
 	}
-
-	// Keep only most recent limit entries
-	if len(records) > limit {
-		records = records[len(records)-limit:]
-	}
-
-	return records, scanner.Err()
+	return records, nil
 }
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Regression kinds flagged by detectTelemetryRegressions.
+const (
+	regressionSuccessDrop      = "success_rate_drop"
+	regressionDurationIncrease = "duration_increase"
+	regressionCriticalFindings = "new_critical_findings"
+)
+
+// regressionHistoryLimit is how many telemetry records are read when looking
+// for regressions after a run.
+const regressionHistoryLimit = 50
+
+// regressionThresholds decides when a run counts as a regression against the
+// runs of the same command before it.
+type regressionThresholds struct {
+	SuccessDrop      float64 // success rate drop, in percentage points
+	DurationIncrease float64 // duration increase over the baseline, in percent
+	Window           int     // earlier runs averaged into the baseline
+}
+
+var defaultRegressionThresholds = regressionThresholds{SuccessDrop: 10, DurationIncrease: 50, Window: 5}
+
+// TelemetryRegression is a run that got worse than its recent baseline.
+type TelemetryRegression struct {
+	Timestamp time.Time `json:"timestamp"`
+	Command   string    `json:"command"`
+	Kind      string    `json:"kind"`
+	Baseline  float64   `json:"baseline"`
+	Current   float64   `json:"current"`
+	Message   string    `json:"message"`
+}
+
+// telemetryHistoryEntry is a telemetry record together with the regressions
+// it introduced, as shown by "report telemetry".
+type telemetryHistoryEntry struct {
+	TelemetryRecord
+	Regressions []TelemetryRegression `json:"regressions,omitempty"`
+}
+
+// regressionThresholdsFromConfig applies telemetry.regression.* from config
+// to the defaults.
+func regressionThresholdsFromConfig() regressionThresholds {
+	th := defaultRegressionThresholds
+	if viper.IsSet("telemetry.regression.success_drop") {
+		th.SuccessDrop = viper.GetFloat64("telemetry.regression.success_drop")
+	}
+	if viper.IsSet("telemetry.regression.duration_increase") {
+		th.DurationIncrease = viper.GetFloat64("telemetry.regression.duration_increase")
+	}
+	if viper.IsSet("telemetry.regression.window") {
+		th.Window = viper.GetInt("telemetry.regression.window")
+	}
+	if th.Window <= 0 {
+		th.Window = defaultRegressionThresholds.Window
+	}
+	return th
+}
+
+// regressionThresholdsForCommand lets --success-drop and --duration-increase
+// override the configured thresholds.
+func regressionThresholdsForCommand(cmd *cobra.Command) regressionThresholds {
+	th := regressionThresholdsFromConfig()
+	if flag := cmd.Flags().Lookup("success-drop"); flag != nil && flag.Changed {
+		th.SuccessDrop, _ = cmd.Flags().GetFloat64("success-drop")
+	}
+	if flag := cmd.Flags().Lookup("duration-increase"); flag != nil && flag.Changed {
+		th.DurationIncrease, _ = cmd.Flags().GetFloat64("duration-increase")
+	}
+	return th
+}
+
+// detectTelemetryRegressions compares every record with the average of up to
+// th.Window earlier records of the same command, and with the record right
+// before it for critical findings. records must be in chronological order.
+func detectTelemetryRegressions(records []TelemetryRecord, th regressionThresholds) []TelemetryRegression {
+	var regressions []TelemetryRegression
+	previous := make(map[string][]TelemetryRecord)
+	for _, rec := range records {
+		earlier := previous[rec.Command]
+		previous[rec.Command] = append(earlier, rec)
+		if len(earlier) == 0 {
+			continue
+		}
+		baseline := earlier[max(0, len(earlier)-th.Window):]
+
+		flag := func(kind string, base, current float64, message string) {
+			regressions = append(regressions, TelemetryRegression{
+				Timestamp: rec.Timestamp,
+				Command:   rec.Command,
+				Kind:      kind,
+				Baseline:  base,
+				Current:   current,
+				Message:   message,
+			})
+		}
+
+		trend := summarizeTrendHistory(baseline)
+		if drop := trend.AverageSuccess - rec.SuccessRate; drop > th.SuccessDrop {
+			flag(regressionSuccessDrop, trend.AverageSuccess, rec.SuccessRate,
+				fmt.Sprintf("success rate fell %.1f points to %.1f%% (baseline %.1f%%)", drop, rec.SuccessRate, trend.AverageSuccess))
+		}
+		if trend.AverageDuration > 0 {
+			increase := (rec.DurationSeconds - trend.AverageDuration) / trend.AverageDuration * 100
+			if increase > th.DurationIncrease {
+				flag(regressionDurationIncrease, trend.AverageDuration, rec.DurationSeconds,
+					fmt.Sprintf("run took %.1fs, %.0f%% longer than the %.1fs baseline", rec.DurationSeconds, increase, trend.AverageDuration))
+			}
+		}
+		last := earlier[len(earlier)-1]
+		if added := rec.CriticalFindings - last.CriticalFindings; added > 0 {
+			flag(regressionCriticalFindings, float64(last.CriticalFindings), float64(rec.CriticalFindings),
+				fmt.Sprintf("%d new critical finding(s) (%d, was %d)", added, rec.CriticalFindings, last.CriticalFindings))
+		}
+	}
+	return regressions
+}
+
+// annotateTelemetryHistory attaches each regression to the record it was
+// found in.
+func annotateTelemetryHistory(records []TelemetryRecord, regressions []TelemetryRegression) []telemetryHistoryEntry {
+	entries := make([]telemetryHistoryEntry, len(records))
+	for i, rec := range records {
+		entries[i].TelemetryRecord = rec
+		for _, r := range regressions {
+			if r.Command == rec.Command && r.Timestamp.Equal(rec.Timestamp) {
+				entries[i].Regressions = append(entries[i].Regressions, r)
+			}
+		}
+	}
+	return entries
+}
+
+// latestTelemetryRegressions returns the regressions of the most recent run
+// of command.
+func latestTelemetryRegressions(records []TelemetryRecord, command string, th regressionThresholds) []TelemetryRegression {
+	var latest time.Time
+	for _, rec := range records {
+		if rec.Command == command {
+			latest = rec.Timestamp
+		}
+	}
+	var out []TelemetryRegression
+	for _, r := range detectTelemetryRegressions(records, th) {
+		if r.Command == command && r.Timestamp.Equal(latest) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// notifyTelemetryRegressions warns when the run just recorded for command
+// regressed against earlier runs. Each regression is also logged, so log
+// shippers can alert on telemetry_regression.
+func notifyTelemetryRegressions(appCtx *AppContext, engagementID, command string) {
+	history, err := loadTelemetryHistory(appCtx.ResultsDir, engagementID, regressionHistoryLimit)
+	if err != nil {
+		cliLog().Warnw("telemetry_history_load_failed", "engagement_id", engagementID, "error", err)
+		return
+	}
+	for _, r := range latestTelemetryRegressions(history, command, regressionThresholdsFromConfig()) {
+		fmt.Printf("%s Regression (%s): %s\n", colorWarn("!"), command, r.Message)
+		cliLog().Warnw("telemetry_regression",
+			"engagement_id", engagementID,
+			"command", command,
+			"kind", r.Kind,
+			"baseline", r.Baseline,
+			"current", r.Current,
+		)
+	}
+}
+
+func printTelemetryRegressions(w io.Writer, regressions []TelemetryRegression) {
+	fmt.Fprintln(w)
+	if len(regressions) == 0 {
+		fmt.Fprintf(w, "%s no regressions detected\n", colorSuccess("✓"))
+		return
+	}
+	fmt.Fprintln(w, colorWarn(fmt.Sprintf("Regressions (%d)", len(regressions))))
+	for _, r := range regressions {
+		fmt.Fprintf(w, "%s %s %s: %s\n", colorWarn("!"), r.Timestamp.Format("2006-01-02 15:04"), r.Command, r.Message)
+	}
+}
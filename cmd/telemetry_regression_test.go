@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func telemetrySample(command string, hour int, successRate, duration float64, critical int) TelemetryRecord {
+	return TelemetryRecord{
+		Timestamp:        time.Date(2026, 5, 1, hour, 0, 0, 0, time.UTC),
+		Command:          command,
+		SuccessRate:      successRate,
+		DurationSeconds:  duration,
+		CriticalFindings: critical,
+	}
+}
+
+func TestDetectTelemetryRegressions(t *testing.T) {
+	records := []TelemetryRecord{
+		telemetrySample("check http", 1, 100, 10, 0),
+		telemetrySample("check dns", 2, 100, 2, 0),
+		telemetrySample("check http", 3, 96, 10, 0),
+		// Compared with the two http runs above: 98% over 10s on average.
+		telemetrySample("check http", 4, 80, 16, 2),
+		// dns runs are only compared with dns runs.
+		telemetrySample("check dns", 5, 100, 2.5, 0),
+	}
+
+	regressions := detectTelemetryRegressions(records, defaultRegressionThresholds)
+	kinds := map[string]TelemetryRegression{}
+	for _, r := range regressions {
+		if r.Command != "check http" || !r.Timestamp.Equal(records[3].Timestamp) {
+			t.Fatalf("unexpected regression %+v", r)
+		}
+		kinds[r.Kind] = r
+	}
+	if len(kinds) != 3 {
+		t.Fatalf("expected success, duration and critical findings regressions, got %+v", regressions)
+	}
+	if r := kinds[regressionSuccessDrop]; r.Baseline != 98 || r.Current != 80 {
+		t.Errorf("unexpected success drop %+v", r)
+	}
+	if r := kinds[regressionCriticalFindings]; r.Current != 2 || !strings.Contains(r.Message, "2 new critical") {
+		t.Errorf("unexpected critical findings regression %+v", r)
+	}
+}
+
+func TestDetectTelemetryRegressionsRespectsThresholds(t *testing.T) {
+	records := []TelemetryRecord{
+		telemetrySample("check http", 1, 100, 10, 0),
+		telemetrySample("check http", 2, 85, 14, 0),
+	}
+	lenient := regressionThresholds{SuccessDrop: 20, DurationIncrease: 50, Window: 5}
+	if got := detectTelemetryRegressions(records, lenient); len(got) != 0 {
+		t.Fatalf("expected no regressions under lenient thresholds, got %+v", got)
+	}
+	strict := regressionThresholds{SuccessDrop: 10, DurationIncrease: 30, Window: 5}
+	if got := detectTelemetryRegressions(records, strict); len(got) != 2 {
+		t.Fatalf("expected two regressions under strict thresholds, got %+v", got)
+	}
+}
+
+func TestLatestTelemetryRegressionsOnlyReportsLastRun(t *testing.T) {
+	records := []TelemetryRecord{
+		telemetrySample("check http", 1, 100, 10, 0),
+		telemetrySample("check http", 2, 50, 10, 0),
+		telemetrySample("check http", 3, 75, 10, 0),
+	}
+	// The 50% run regressed; the latest run recovered above its baseline.
+	got := latestTelemetryRegressions(records, "check http", regressionThresholds{SuccessDrop: 10, DurationIncrease: 50, Window: 1})
+	if len(got) != 0 {
+		t.Fatalf("expected no regressions for the latest run, got %+v", got)
+	}
+}
+
+func TestPrintTelemetryRegressions(t *testing.T) {
+	var buf bytes.Buffer
+	printTelemetryRegressions(&buf, nil)
+	if !strings.Contains(buf.String(), "no regressions detected") {
+		t.Fatalf("expected all-clear message, got %q", buf.String())
+	}
+
+	buf.Reset()
+	printTelemetryRegressions(&buf, []TelemetryRegression{{Timestamp: time.Date(2026, 5, 1, 4, 0, 0, 0, time.UTC), Command: "check http", Message: "success rate fell"}})
+	if !strings.Contains(buf.String(), "2026-05-01 04:00 check http: success rate fell") {
+		t.Fatalf("expected regression line, got %q", buf.String())
+	}
+}
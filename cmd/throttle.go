@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/khanhnv2901/seca-cli/internal/domain/audit"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+)
+
+const (
+	// throttlingFilename records the rate limiting and WAF blocks seen by
+	// the latest run of each check type.
+	throttlingFilename = "throttling.json"
+	// defaultMaxPauseSecs caps how long a throttled host is paused.
+	defaultMaxPauseSecs = 120
+	// throttleAuditStatus marks throttling rows in the audit trail.
+	throttleAuditStatus = "throttled"
+)
+
+// ThrottledRun is the throttling seen by one run. A later run of the same
+// check type replaces it, as it replaces that check's results file.
+type ThrottledRun struct {
+	Check     string                  `json:"check"`
+	StartedAt time.Time               `json:"started_at"`
+	Events    []checker.ThrottleEvent `json:"events"`
+}
+
+// Hosts returns the throttled hosts in name order.
+func (r ThrottledRun) Hosts() []string {
+	seen := make(map[string]bool)
+	var hosts []string
+	for _, e := range r.Events {
+		if !seen[e.Host] {
+			seen[e.Host] = true
+			hosts = append(hosts, e.Host)
+		}
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// newRunThrottle returns the throttle shared by every request of a run.
+// Each event is written to the audit trail as it is detected. With
+// --adaptive-pause=false events are still detected and reported, but hosts
+// are neither paused nor slowed.
+func newRunThrottle(ctx context.Context, appCtx *AppContext, engagementID, command string, runtimeCfg CheckRuntimeConfig) *checker.Throttle {
+	maxPause := runtimeCfg.MaxPauseSecs
+	if maxPause <= 0 {
+		maxPause = defaultMaxPauseSecs
+	}
+	return &checker.Throttle{
+		MaxPause:   time.Duration(maxPause) * time.Second,
+		DetectOnly: !runtimeCfg.AdaptivePause,
+		OnEvent: func(e checker.ThrottleEvent) {
+			cliLog().Warnw("host_throttled",
+				"engagement_id", engagementID,
+				"host", e.Host,
+				"url", e.URL,
+				"reason", e.Reason,
+				"status", e.StatusCode,
+				"waf", e.WAF,
+				"pause_seconds", e.PauseSeconds,
+			)
+			entry := &audit.Entry{
+				Timestamp:    time.Now(),
+				EngagementID: engagementID,
+				Operator:     appCtx.Operator,
+				Command:      command,
+				Target:       e.URL,
+				Status:       throttleAuditStatus,
+				HTTPStatus:   e.StatusCode,
+				Notes:        e.Describe(),
+			}
+			if err := appCtx.Services.CheckOrchestrator.RecordAuditEntry(ctx, entry); err != nil {
+				cliLog().Warnw("throttle_audit_failed", "engagement_id", engagementID, "host", e.Host, "error", err)
+			}
+		},
+	}
+}
+
+// loadThrottledRuns returns the recorded throttling, or nil when no run was
+// throttled.
+func loadThrottledRuns(resultsDir, engagementID string) ([]ThrottledRun, error) {
+	path, err := resolveResultsPath(resultsDir, engagementID, throttlingFilename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var runs []ThrottledRun
+	if err := json.Unmarshal(data, &runs); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", throttlingFilename, err)
+	}
+	return runs, nil
+}
+
+// saveThrottledRun replaces the check type's entry with run, removing it
+// when the run was not throttled.
+func saveThrottledRun(resultsDir, engagementID string, run ThrottledRun) error {
+	runs, err := loadThrottledRuns(resultsDir, engagementID)
+	if err != nil {
+		return err
+	}
+	kept := runs[:0]
+	for _, r := range runs {
+		if r.Check != run.Check {
+			kept = append(kept, r)
+		}
+	}
+	if len(run.Events) > 0 {
+		kept = append(kept, run)
+	}
+	if len(kept) == 0 && len(runs) == 0 {
+		return nil
+	}
+	sort.Slice(kept, func(i, j int) bool { return kept[i].Check < kept[j].Check })
+
+	if _, err := ensureResultsDir(resultsDir, engagementID); err != nil {
+		return err
+	}
+	path, err := resolveResultsPath(resultsDir, engagementID, throttlingFilename)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(kept, jsonPrefix, jsonIndent)
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFile(path, data, consts.DefaultFilePerm)
+}
+
+// recordThrottling saves the run's throttle events to throttling.json and
+// warns the operator that results may be incomplete. Failures are logged;
+// they never fail the run.
+func recordThrottling(resultsDir, engagementID, check string, throttle *checker.Throttle, startedAt time.Time) {
+	if throttle == nil {
+		return
+	}
+	run := ThrottledRun{Check: check, StartedAt: startedAt.UTC(), Events: throttle.Events()}
+	if err := saveThrottledRun(resultsDir, engagementID, run); err != nil {
+		cliLog().Warnw("throttling_save_failed", "engagement_id", engagementID, "error", err)
+	}
+	if len(run.Events) == 0 {
+		return
+	}
+
+	hosts := run.Hosts()
+	fmt.Printf("%s %d host(s) rate limited or blocked the run (%d event(s)): %s\n",
+		colorWarn("!"), len(hosts), len(run.Events), strings.Join(hosts, ", "))
+	if throttle.DetectOnly {
+		fmt.Printf("%s Adaptive pause is off; results for these hosts may be incomplete\n", colorWarn("!"))
+	} else {
+		fmt.Printf("%s These hosts were paused and slowed; results for them may be incomplete\n", colorWarn("!"))
+	}
+}
+
+// ThrottleCaveat is a host whose results a report must qualify because the
+// host rate limited or blocked the run.
+type ThrottleCaveat struct {
+	Host    string
+	Check   string
+	Events  int
+	Reasons string
+}
+
+// throttleCaveats lists the throttled hosts of every recorded run.
+func throttleCaveats(runs []ThrottledRun) []ThrottleCaveat {
+	var caveats []ThrottleCaveat
+	for _, run := range runs {
+		byHost := make(map[string][]checker.ThrottleEvent)
+		for _, e := range run.Events {
+			byHost[e.Host] = append(byHost[e.Host], e)
+		}
+		for _, host := range run.Hosts() {
+			events := byHost[host]
+			var reasons []string
+			seen := make(map[string]bool)
+			for _, e := range events {
+				desc := throttleReasonLabel(e)
+				if !seen[desc] {
+					seen[desc] = true
+					reasons = append(reasons, desc)
+				}
+			}
+			caveats = append(caveats, ThrottleCaveat{Host: host, Check: run.Check, Events: len(events), Reasons: strings.Join(reasons, ", ")})
+		}
+	}
+	return caveats
+}
+
+func throttleReasonLabel(e checker.ThrottleEvent) string {
+	switch e.Reason {
+	case checker.ThrottleRateLimited:
+		return "HTTP 429"
+	case checker.ThrottleForbiddenBurst:
+		return fmt.Sprintf("HTTP %d burst", e.StatusCode)
+	case checker.ThrottleWAFChallenge:
+		if e.WAF != "" {
+			return e.WAF + " challenge"
+		}
+		return "WAF challenge"
+	}
+	return e.Reason
+}
+
+// writeThrottlingPDF notes, under the summary, the hosts whose results may
+// be incomplete.
+func writeThrottlingPDF(pdf *gofpdf.Fpdf, caveats []ThrottleCaveat) {
+	pdf.SetFont("Arial", "B", 10)
+	pdf.MultiCell(0, 6, "Checks were throttled: these hosts rate limited or blocked the run, so their results may be incomplete.", "", "", false)
+	pdf.SetFont("Arial", "", 9)
+	for _, c := range caveats {
+		pdf.MultiCell(0, 5, fmt.Sprintf("  %s (%s): %s, %d event(s)", c.Host, c.Check, c.Reasons, c.Events), "", "", false)
+	}
+	pdf.SetFont("Arial", "", 10)
+}
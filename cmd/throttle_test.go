@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func TestSaveThrottledRunReplacesCheck(t *testing.T) {
+	resultsDir := t.TempDir()
+	const id = "eng-throttle"
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	httpRun := ThrottledRun{Check: "check http", StartedAt: at, Events: []checker.ThrottleEvent{
+		{Host: "b.example.com", Reason: checker.ThrottleRateLimited, StatusCode: 429},
+		{Host: "a.example.com", Reason: checker.ThrottleWAFChallenge, StatusCode: 403, WAF: "Cloudflare"},
+		{Host: "b.example.com", Reason: checker.ThrottleRateLimited, StatusCode: 429},
+	}}
+	networkRun := ThrottledRun{Check: "check network", StartedAt: at, Events: []checker.ThrottleEvent{
+		{Host: "a.example.com", Reason: checker.ThrottleForbiddenBurst, StatusCode: 403},
+	}}
+	for _, run := range []ThrottledRun{httpRun, networkRun} {
+		if err := saveThrottledRun(resultsDir, id, run); err != nil {
+			t.Fatalf("saveThrottledRun() error = %v", err)
+		}
+	}
+
+	runs, err := loadThrottledRuns(resultsDir, id)
+	if err != nil || len(runs) != 2 {
+		t.Fatalf("expected two runs, got %+v (%v)", runs, err)
+	}
+	caveats := throttleCaveats(runs)
+	if len(caveats) != 3 {
+		t.Fatalf("expected three caveats, got %+v", caveats)
+	}
+	if c := caveats[1]; c.Host != "b.example.com" || c.Events != 2 || c.Reasons != "HTTP 429" {
+		t.Errorf("unexpected caveat %+v", c)
+	}
+	if c := caveats[0]; c.Reasons != "Cloudflare challenge" {
+		t.Errorf("unexpected caveat %+v", c)
+	}
+
+	// A clean HTTP run clears the HTTP entry and keeps the network one.
+	if err := saveThrottledRun(resultsDir, id, ThrottledRun{Check: "check http", StartedAt: at.Add(time.Hour)}); err != nil {
+		t.Fatalf("saveThrottledRun() error = %v", err)
+	}
+	runs, err = loadThrottledRuns(resultsDir, id)
+	if err != nil || len(runs) != 1 || runs[0].Check != "check network" {
+		t.Fatalf("expected only the network run, got %+v (%v)", runs, err)
+	}
+}
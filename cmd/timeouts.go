@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/spf13/viper"
+)
+
+// PhaseTimeoutConfig holds the per-phase HTTP timeouts in seconds from the
+// --*-timeout flags; zero leaves the phase to config or --timeout.
+type PhaseTimeoutConfig struct {
+	Dial           int
+	TLSHandshake   int
+	ResponseHeader int
+	BodyRead       int
+}
+
+// resolvePhaseTimeouts returns the per-phase HTTP timeouts set by
+// --dial-timeout (and friends) or, failing that, http.timeouts.<phase>.
+// Phases left zero fall back to the target's timeout when checked.
+func resolvePhaseTimeouts(runtimeCfg CheckRuntimeConfig) (checker.PhaseTimeouts, error) {
+	var timeouts checker.PhaseTimeouts
+	phases := []struct {
+		flag, key string
+		value     int
+		target    *time.Duration
+	}{
+		{"--dial-timeout", "http.timeouts.dial", runtimeCfg.Timeouts.Dial, &timeouts.Dial},
+		{"--tls-timeout", "http.timeouts.tls_handshake", runtimeCfg.Timeouts.TLSHandshake, &timeouts.TLSHandshake},
+		{"--header-timeout", "http.timeouts.response_header", runtimeCfg.Timeouts.ResponseHeader, &timeouts.ResponseHeader},
+		{"--body-timeout", "http.timeouts.body_read", runtimeCfg.Timeouts.BodyRead, &timeouts.BodyRead},
+	}
+	for _, p := range phases {
+		secs, source := p.value, p.flag
+		if secs == 0 && viper.IsSet(p.key) {
+			secs, source = viper.GetInt(p.key), p.key
+		}
+		if secs < 0 {
+			return checker.PhaseTimeouts{}, fmt.Errorf("%s: must be at least 1 second, got %d", source, secs)
+		}
+		*p.target = time.Duration(secs) * time.Second
+	}
+	return timeouts, nil
+}
+
+// httpCheckTimeout is the time one HTTP check may take; see
+// checker.PhaseTimeouts.CheckTimeout.
+func httpCheckTimeout(runtimeCfg CheckRuntimeConfig, phases checker.PhaseTimeouts) time.Duration {
+	return phases.CheckTimeout(time.Duration(runtimeCfg.TimeoutSecs) * time.Second)
+}
+
+// printPhaseTimeouts reports the per-phase timeouts when any is set.
+func printPhaseTimeouts(runtimeCfg CheckRuntimeConfig, phases checker.PhaseTimeouts) {
+	if phases == (checker.PhaseTimeouts{}) {
+		return
+	}
+	phases = phases.WithDefault(time.Duration(runtimeCfg.TimeoutSecs) * time.Second)
+	fmt.Printf("%s Timeouts: dial %s, TLS %s, headers %s, body %s\n", colorInfo("→"),
+		phases.Dial, phases.TLSHandshake, phases.ResponseHeader, phases.BodyRead)
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	"github.com/spf13/viper"
+)
+
+func TestResolvePhaseTimeouts(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set("http.timeouts.dial", 3)
+	viper.Set("http.timeouts.body_read", 60)
+
+	cfg := CheckRuntimeConfig{TimeoutSecs: 10, Timeouts: PhaseTimeoutConfig{BodyRead: 120}}
+	phases, err := resolvePhaseTimeouts(cfg)
+	if err != nil {
+		t.Fatalf("resolvePhaseTimeouts: %v", err)
+	}
+	// The flag beats config, and unset phases are left to --timeout.
+	if phases.Dial != 3*time.Second || phases.BodyRead != 120*time.Second {
+		t.Errorf("unexpected dial/body timeouts: %+v", phases)
+	}
+	if phases.TLSHandshake != 0 || phases.ResponseHeader != 0 {
+		t.Errorf("unset phases should stay zero: %+v", phases)
+	}
+	if got := httpCheckTimeout(cfg, phases); got != 153*time.Second {
+		t.Errorf("httpCheckTimeout = %s, want every phase plus --timeout", got)
+	}
+	if got := httpCheckTimeout(cfg, checker.PhaseTimeouts{}); got != 10*time.Second {
+		t.Errorf("httpCheckTimeout = %s, want --timeout when no phase is set", got)
+	}
+
+	viper.Set("http.timeouts.tls_handshake", -1)
+	if _, err := resolvePhaseTimeouts(cfg); err == nil || !strings.Contains(err.Error(), "http.timeouts.tls_handshake") {
+		t.Errorf("expected an error naming the config key, got %v", err)
+	}
+}
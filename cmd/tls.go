@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/domain/audit"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultCertWarnDays     = 30
+	defaultCertCriticalDays = 7
+)
+
+// Certificate expiry states, from healthy to unknown.
+const (
+	certExpiryOK       = "ok"
+	certExpiryWarning  = "warning"
+	certExpiryCritical = "critical"
+	certExpiryExpired  = "expired"
+	certExpiryError    = "error"
+)
+
+// CertExpiryStatus is the expiry state of one target's certificate.
+type CertExpiryStatus struct {
+	Target   string `json:"target"`
+	Status   string `json:"status"`
+	NotAfter string `json:"not_after,omitempty"`
+	DaysLeft int    `json:"days_left"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+// crossed reports whether the certificate is at or past the warning threshold.
+func (s CertExpiryStatus) crossed() bool {
+	switch s.Status {
+	case certExpiryWarning, certExpiryCritical, certExpiryExpired:
+		return true
+	}
+	return false
+}
+
+var tlsCmd = &cobra.Command{
+	Use:   "tls",
+	Short: "Lightweight TLS certificate monitoring",
+}
+
+var tlsExpiryCmd = &cobra.Command{
+	Use:   "expiry",
+	Short: "Check certificate expiry across an engagement's scope",
+	Long: `Connect to each target's TLS endpoint (port 443 unless the target names
+a port) and report how many days its certificate has left. No HTTP requests
+or other checks are made, so a full scope finishes quickly.
+
+The command exits non-zero when any certificate is within --warn days of
+expiry, which makes it suitable for a daily cron job separate from full runs.
+Targets that cannot be reached are reported but do not fail the run.`,
+	Example: `  seca tls expiry --id eng123 --warn 30 --critical 7
+  seca tls expiry --id eng123 --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		appCtx := getAppContext(cmd)
+
+		engagementID, _ := cmd.Flags().GetString("id")
+		warnDays, _ := cmd.Flags().GetInt("warn")
+		criticalDays, _ := cmd.Flags().GetInt("critical")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		if engagementID == "" {
+			return errors.New("--id is required")
+		}
+		if criticalDays < 0 || warnDays < criticalDays {
+			return fmt.Errorf("thresholds must satisfy 0 <= --critical (%d) <= --warn (%d)", criticalDays, warnDays)
+		}
+
+		eng, err := appCtx.Services.EngagementService.GetEngagement(ctx, engagementID)
+		if err != nil {
+			if errors.Is(err, sharedErrors.ErrEngagementNotFound) {
+				return fmt.Errorf("engagement %s not found", engagementID)
+			}
+			return fmt.Errorf("failed to get engagement: %w", err)
+		}
+		if err := checkWritable(eng); err != nil {
+			return err
+		}
+
+		if err := appCtx.Services.EngagementService.ValidateEngagementForChecks(ctx, engagementID, ""); err != nil {
+			return fmt.Errorf("engagement validation failed: %w", err)
+		}
+
+		runtimeCfg := appCtx.Config.Check
+		timeout := time.Duration(runtimeCfg.TimeoutSecs) * time.Second
+		runner := &checker.Runner{
+			Concurrency: runtimeCfg.Concurrency,
+			RateLimit:   runtimeCfg.RateLimit,
+			Timeout:     timeout,
+		}
+		expiryChecker := &checker.CertExpiryChecker{Timeout: timeout}
+
+		auditFn := func(target string, result checker.CheckResult, duration float64) error {
+			entry := &audit.Entry{
+				Timestamp:       time.Now(),
+				EngagementID:    engagementID,
+				Operator:        appCtx.Operator,
+				Command:         expiryChecker.Name(),
+				Target:          target,
+				Status:          result.Status,
+				Notes:           result.Notes,
+				Error:           result.Error,
+				DurationSeconds: duration,
+			}
+			if expiry, err := time.Parse(time.RFC3339, result.TLSExpiry); err == nil {
+				entry.TLSExpiry = expiry
+			}
+			if err := appCtx.Services.CheckOrchestrator.RecordAuditEntry(ctx, entry); err != nil {
+				return fmt.Errorf("failed to record audit: %w", err)
+			}
+			return nil
+		}
+
+		results := runner.RunChecks(ctx, eng.Scope(), expiryChecker, auditFn)
+		if err := appCtx.Services.AuditRepo.Flush(ctx, engagementID); err != nil {
+			return fmt.Errorf("failed to write audit trail: %w", err)
+		}
+		statuses := classifyCertExpiry(results, warnDays, criticalDays, time.Now())
+
+		out := cmd.OutOrStdout()
+		if asJSON {
+			data, err := json.MarshalIndent(statuses, jsonPrefix, jsonIndent)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(out, string(data))
+		} else {
+			printCertExpiry(out, statuses)
+		}
+
+		crossed := 0
+		for _, s := range statuses {
+			if s.crossed() {
+				crossed++
+			}
+		}
+		if crossed > 0 {
+			return fmt.Errorf("%d certificate(s) expire within %d days", crossed, warnDays)
+		}
+		return nil
+	},
+}
+
+// classifyCertExpiry grades each result against the thresholds and sorts the
+// soonest expiry first, with unreachable targets last.
+func classifyCertExpiry(results []checker.CheckResult, warnDays, criticalDays int, now time.Time) []CertExpiryStatus {
+	statuses := make([]CertExpiryStatus, 0, len(results))
+	for _, r := range results {
+		status := CertExpiryStatus{Target: r.Target, Detail: r.Notes}
+		notAfter, err := time.Parse(time.RFC3339, r.TLSExpiry)
+		if !r.Succeeded() || err != nil {
+			status.Status = certExpiryError
+			status.Detail = r.Error
+			statuses = append(statuses, status)
+			continue
+		}
+
+		status.NotAfter = r.TLSExpiry
+		status.DaysLeft = checker.DaysUntil(notAfter, now)
+		switch {
+		case !now.Before(notAfter):
+			status.Status = certExpiryExpired
+		case status.DaysLeft <= criticalDays:
+			status.Status = certExpiryCritical
+		case status.DaysLeft <= warnDays:
+			status.Status = certExpiryWarning
+		default:
+			status.Status = certExpiryOK
+		}
+		statuses = append(statuses, status)
+	}
+
+	sort.SliceStable(statuses, func(i, j int) bool {
+		a, b := statuses[i], statuses[j]
+		if (a.Status == certExpiryError) != (b.Status == certExpiryError) {
+			return b.Status == certExpiryError
+		}
+		if a.DaysLeft != b.DaysLeft {
+			return a.DaysLeft < b.DaysLeft
+		}
+		return a.Target < b.Target
+	})
+	return statuses
+}
+
+func printCertExpiry(out io.Writer, statuses []CertExpiryStatus) {
+	if len(statuses) == 0 {
+		fmt.Fprintf(out, "%s No targets in scope\n", colorInfo("→"))
+		return
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TARGET\tSTATUS\tEXPIRES\tDAYS LEFT\tDETAIL")
+	for _, s := range statuses {
+		expires, days := "-", "-"
+		if s.Status != certExpiryError {
+			expires = s.NotAfter[:len("2006-01-02")]
+			days = fmt.Sprintf("%d", s.DaysLeft)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", s.Target, certExpiryLabel(s.Status), expires, days, s.Detail)
+	}
+	_ = w.Flush()
+}
+
+func certExpiryLabel(status string) string {
+	label := strings.ToUpper(status)
+	switch status {
+	case certExpiryOK:
+		return colorSuccess(label)
+	case certExpiryWarning:
+		return colorWarn(label)
+	case certExpiryCritical, certExpiryExpired, certExpiryError:
+		return colorError(label)
+	}
+	return label
+}
+
+func init() {
+	rootCmd.AddCommand(tlsCmd)
+	tlsCmd.AddCommand(tlsExpiryCmd)
+
+	tlsExpiryCmd.Flags().String("id", "", "Engagement ID")
+	tlsExpiryCmd.Flags().Int("warn", defaultCertWarnDays, "Warn when a certificate expires within this many days")
+	tlsExpiryCmd.Flags().Int("critical", defaultCertCriticalDays, "Critical when a certificate expires within this many days")
+	tlsExpiryCmd.Flags().Bool("json", false, "Output as JSON")
+}
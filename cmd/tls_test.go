@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func TestClassifyCertExpiry(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiring := func(target string, in time.Duration) checker.CheckResult {
+		return checker.CheckResult{Target: target, Status: "ok", TLSExpiry: now.Add(in).Format(time.RFC3339)}
+	}
+
+	results := []checker.CheckResult{
+		expiring("healthy.example.com", 90*24*time.Hour),
+		{Target: "down.example.com", Status: "error", Error: "connection refused"},
+		expiring("soon.example.com", 20*24*time.Hour),
+		expiring("gone.example.com", -48*time.Hour),
+		expiring("urgent.example.com", 3*24*time.Hour),
+	}
+
+	statuses := classifyCertExpiry(results, 30, 7, now)
+
+	var got []string
+	for _, s := range statuses {
+		got = append(got, s.Target+"="+s.Status)
+	}
+	want := []string{
+		"gone.example.com=expired",
+		"urgent.example.com=critical",
+		"soon.example.com=warning",
+		"healthy.example.com=ok",
+		"down.example.com=error",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected classification:\n got  %v\n want %v", got, want)
+	}
+
+	if statuses[0].DaysLeft != -2 || statuses[4].Detail != "connection refused" {
+		t.Fatalf("unexpected details %+v", statuses)
+	}
+
+	crossed := 0
+	for _, s := range statuses {
+		if s.crossed() {
+			crossed++
+		}
+	}
+	if crossed != 3 {
+		t.Fatalf("expected 3 certificates past the warning threshold, got %d", crossed)
+	}
+}
@@ -1,136 +1,578 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
 	"fmt"
-	"os"
-	"strconv"
+	"sort"
 	"strings"
+	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
 	"github.com/spf13/cobra"
 )
 
 var tuiCmd = &cobra.Command{
 	Use:   "tui",
-	Short: "Interactive terminal UI for engagement management",
+	Short: "Interactive terminal UI for engagements and live check runs",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runTUI(getAppContext(cmd))
+		appCtx := getAppContext(cmd)
+		if appCtx.Services == nil || appCtx.Services.EngagementService == nil {
+			return fmt.Errorf("engagement services not initialized")
+		}
+
+		model := newTUIModel(context.Background(), appCtx)
+		final, err := tea.NewProgram(model, tea.WithAltScreen()).Run()
+		if err != nil {
+			return fmt.Errorf("tui: %w", err)
+		}
+		// A run that was cancelled by quitting still seals its audit trail;
+		// wait for that before exiting.
+		if m, ok := final.(*tuiModel); ok && m.run != nil && !m.run.done {
+			for msg := range m.run.events {
+				if done, ok := msg.(tuiRunDoneMsg); ok && done.err != nil {
+					return done.err
+				}
+			}
+		}
+		return nil
 	},
 }
 
-func runTUI(appCtx *AppContext) error {
-	if appCtx.Services == nil || appCtx.Services.EngagementService == nil {
-		return fmt.Errorf("engagement services not initialized")
-	}
+type tuiView int
 
-	reader := bufio.NewReader(os.Stdin)
-	ctx := context.Background()
+const (
+	tuiViewEngagements tuiView = iota
+	tuiViewConfirm
+	tuiViewAddScope
+	tuiViewRun
+	tuiViewResult
+)
 
-	for {
-		dtos, err := listEngagementDTOs(ctx, appCtx)
-		if err != nil {
-			return err
+// tuiFindingsShown is how many of the most recent findings the run view lists.
+const tuiFindingsShown = 8
+
+type tuiTargetState int
+
+const (
+	tuiTargetPending tuiTargetState = iota
+	tuiTargetRunning
+	tuiTargetDone
+)
+
+// tuiTarget is one row of the run view.
+type tuiTarget struct {
+	name     string
+	state    tuiTargetState
+	started  time.Time
+	result   checker.CheckResult
+	duration float64
+	findings []checker.Finding
+}
+
+// tuiFinding is a finding in the run's stream, in the order it arrived.
+type tuiFinding struct {
+	target  string
+	finding checker.Finding
+}
+
+// tuiRun is the state of the check run shown in the run view.
+type tuiRun struct {
+	engagement engagementDTO
+	targets    []*tuiTarget
+	byName     map[string]*tuiTarget
+	findings   []tuiFinding
+	started    time.Time
+	finished   time.Time
+	events     <-chan tea.Msg
+	cancel     context.CancelFunc
+	cancelled  bool
+	done       bool
+	hashAlgo   string
+	auditHash  string
+	err        error
+}
+
+func (r *tuiRun) completed() (ok, failed int) {
+	for _, t := range r.targets {
+		if t.state != tuiTargetDone {
+			continue
+		}
+		if t.result.Succeeded() {
+			ok++
+		} else {
+			failed++
 		}
+	}
+	return ok, failed
+}
+
+type tuiModel struct {
+	ctx    context.Context
+	appCtx *AppContext
+
+	view        tuiView
+	engagements []engagementDTO
+	cursor      int
+	run         *tuiRun
+	runCursor   int
+	scopeInput  string
+	status      string
+	width       int
+	height      int
+	err         error
+}
+
+type (
+	tuiEngagementsMsg struct {
+		engagements []engagementDTO
+		err         error
+	}
+	tuiTickMsg struct{}
+)
+
+func newTUIModel(ctx context.Context, appCtx *AppContext) *tuiModel {
+	return &tuiModel{ctx: ctx, appCtx: appCtx}
+}
+
+func (m *tuiModel) Init() tea.Cmd {
+	return m.loadEngagements
+}
+
+func (m *tuiModel) loadEngagements() tea.Msg {
+	engagements, err := listEngagementDTOs(m.ctx, m.appCtx)
+	return tuiEngagementsMsg{engagements: engagements, err: err}
+}
+
+func tuiTick() tea.Cmd {
+	return tea.Tick(500*time.Millisecond, func(time.Time) tea.Msg { return tuiTickMsg{} })
+}
 
-		fmt.Println("=== SECA Engagements ===")
-		if len(dtos) == 0 {
-			fmt.Println("No engagements found. Use `seca engagement create` to add one.")
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case tuiEngagementsMsg:
+		m.engagements, m.err = msg.engagements, msg.err
+		if m.cursor >= len(m.engagements) {
+			m.cursor = max(len(m.engagements)-1, 0)
+		}
+		return m, nil
+	case tuiTickMsg:
+		if m.run != nil && !m.run.done {
+			return m, tuiTick()
 		}
-		for i, eng := range dtos {
-			fmt.Printf("[%d] %s (Owner: %s, Scope: %d target(s))\n", i+1, eng.Name, eng.Owner, len(eng.Scope))
+		return m, nil
+	case tuiTargetStartedMsg:
+		if t := m.run.byName[msg.target]; t != nil {
+			t.state, t.started = tuiTargetRunning, time.Now()
 		}
-		fmt.Println("[a] Add scope    [r] Refresh    [q] Quit")
-		fmt.Print("Select engagement: ")
-		input, _ := reader.ReadString('\n')
-		input = strings.TrimSpace(input)
+		return m, waitForRunEvent(m.run.events)
+	case tuiTargetDoneMsg:
+		m.recordResult(msg)
+		return m, waitForRunEvent(m.run.events)
+	case tuiRunDoneMsg:
+		m.run.done, m.run.finished = true, time.Now()
+		m.run.hashAlgo, m.run.auditHash, m.run.err = msg.hashAlgo, msg.auditHash, msg.err
+		m.run.cancel()
+		return m, m.loadEngagements
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m *tuiModel) recordResult(msg tuiTargetDoneMsg) {
+	t := m.run.byName[msg.target]
+	if t == nil {
+		return
+	}
+	t.state, t.result, t.duration = tuiTargetDone, msg.result, msg.duration
+	t.findings = checker.ResultFindings(msg.result)
+	for _, f := range t.findings {
+		m.run.findings = append(m.run.findings, tuiFinding{target: t.name, finding: f})
+	}
+}
+
+func (m *tuiModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == "ctrl+c" {
+		return m.quit()
+	}
 
-		switch strings.ToLower(input) {
+	switch m.view {
+	case tuiViewEngagements:
+		switch key {
 		case "q":
-			return nil
-		case "r", "":
-			continue
+			return m.quit()
+		case "up", "k":
+			m.cursor = max(m.cursor-1, 0)
+		case "down", "j":
+			m.cursor = min(m.cursor+1, max(len(m.engagements)-1, 0))
+		case "r":
+			return m, m.loadEngagements
 		case "a":
-			if err := handleAddScope(ctx, reader, appCtx); err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			if len(m.engagements) > 0 {
+				m.view, m.scopeInput, m.status = tuiViewAddScope, "", ""
 			}
-			continue
-		default:
-			index, err := strconv.Atoi(input)
-			if err != nil || index < 1 || index > len(dtos) {
-				fmt.Println("Invalid selection")
-				continue
+		case "enter":
+			if len(m.engagements) > 0 && len(m.engagements[m.cursor].Scope) > 0 {
+				m.view = tuiViewConfirm
+			}
+		case "v":
+			if m.run != nil {
+				m.view = tuiViewRun
+			}
+		}
+	case tuiViewConfirm:
+		switch key {
+		case "y":
+			return m.startRun(m.engagements[m.cursor])
+		case "n", "esc":
+			m.view = tuiViewEngagements
+		}
+	case tuiViewAddScope:
+		switch msg.Type {
+		case tea.KeyEnter:
+			return m.addScope()
+		case tea.KeyEsc:
+			m.view = tuiViewEngagements
+		case tea.KeyBackspace:
+			if r := []rune(m.scopeInput); len(r) > 0 {
+				m.scopeInput = string(r[:len(r)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			m.scopeInput += string(msg.Runes)
+		}
+	case tuiViewRun:
+		switch key {
+		case "q":
+			return m.quit()
+		case "up", "k":
+			m.runCursor = max(m.runCursor-1, 0)
+		case "down", "j":
+			m.runCursor = min(m.runCursor+1, len(m.run.targets)-1)
+		case "enter":
+			if m.run.targets[m.runCursor].state == tuiTargetDone {
+				m.view = tuiViewResult
+			}
+		case "c":
+			if !m.run.done {
+				m.run.cancelled = true
+				m.run.cancel()
 			}
-			showEngagementDetail(reader, dtos[index-1])
+		case "esc":
+			m.view = tuiViewEngagements
+		}
+	case tuiViewResult:
+		switch key {
+		case "q":
+			return m.quit()
+		case "esc", "backspace", "enter":
+			m.view = tuiViewRun
 		}
 	}
+	return m, nil
 }
 
-func listEngagementDTOs(ctx context.Context, appCtx *AppContext) ([]engagementDTO, error) {
-	engagements, err := appCtx.Services.EngagementService.ListEngagements(ctx)
+// addScope adds the comma-separated entries typed in the add-scope prompt to
+// the selected engagement.
+func (m *tuiModel) addScope() (tea.Model, tea.Cmd) {
+	eng := m.engagements[m.cursor]
+	var entries []string
+	for _, entry := range strings.Split(m.scopeInput, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) == 0 {
+		m.status = colorError("scope required")
+		return m, nil
+	}
+
+	normalized, err := normalizeScopeEntries(eng.ID, entries)
+	if err == nil {
+		err = m.appCtx.Services.EngagementService.AddToScope(m.ctx, eng.ID, normalized)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to list engagements: %w", err)
+		m.status = colorError(err.Error())
+		return m, nil
 	}
 
-	dtos := make([]engagementDTO, len(engagements))
-	for i, eng := range engagements {
-		dtos[i] = engagementToDTO(eng)
+	m.view = tuiViewEngagements
+	m.status = fmt.Sprintf("%s %d scope entries to %s", colorSuccess("Added"), len(normalized), eng.Name)
+	return m, m.loadEngagements
+}
+
+func (m *tuiModel) quit() (tea.Model, tea.Cmd) {
+	if m.run != nil && !m.run.done {
+		m.run.cancelled = true
+		m.run.cancel()
 	}
-	return dtos, nil
+	return m, tea.Quit
 }
 
-func handleAddScope(ctx context.Context, reader *bufio.Reader, appCtx *AppContext) error {
-	fmt.Print("Enter engagement ID: ")
-	id, err := reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("failed to read engagement ID: %w", err)
+func (m *tuiModel) startRun(eng engagementDTO) (tea.Model, tea.Cmd) {
+	if m.run != nil && !m.run.done {
+		m.view = tuiViewRun
+		return m, nil
+	}
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	run := &tuiRun{
+		engagement: eng,
+		byName:     make(map[string]*tuiTarget, len(eng.Scope)),
+		started:    time.Now(),
+		cancel:     cancel,
 	}
-	id = strings.TrimSpace(id)
-	if id == "" {
-		return fmt.Errorf("ID required")
+	for _, name := range eng.Scope {
+		t := &tuiTarget{name: name}
+		run.targets = append(run.targets, t)
+		run.byName[name] = t
 	}
+	run.events = startTUICheckRun(ctx, m.appCtx, eng)
 
-	fmt.Print("Enter scope entries (comma separated): ")
-	scopeLine, err := reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("failed to read scope entries: %w", err)
+	m.run, m.runCursor, m.view = run, 0, tuiViewRun
+	return m, tea.Batch(waitForRunEvent(run.events), tuiTick())
+}
+
+func (m *tuiModel) View() string {
+	switch m.view {
+	case tuiViewConfirm:
+		return m.confirmView()
+	case tuiViewAddScope:
+		return m.addScopeView()
+	case tuiViewRun:
+		return m.runView()
+	case tuiViewResult:
+		return m.resultView()
+	default:
+		return m.engagementsView()
+	}
+}
+
+func (m *tuiModel) engagementsView() string {
+	var b strings.Builder
+	b.WriteString(colorInfo("SECA Engagements") + "\n\n")
+	if m.err != nil {
+		fmt.Fprintf(&b, "%s %v\n\n", colorError("Error:"), m.err)
 	}
-	scopeLine = strings.TrimSpace(scopeLine)
-	if scopeLine == "" {
-		return fmt.Errorf("scope required")
+	if len(m.engagements) == 0 {
+		b.WriteString("No engagements found. Use `seca engagement create` to add one.\n")
 	}
+	for i, eng := range m.engagements {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = colorInfo("> ")
+		}
+		roe := colorWarn("ROE pending")
+		if eng.ROEAgree {
+			roe = colorSuccess("ROE agreed")
+		}
+		fmt.Fprintf(&b, "%s%-28s %-24s %3d target(s)  %s\n", cursor, truncate(eng.Name, 28), truncate(eng.Owner, 24), len(eng.Scope), roe)
+	}
+	if m.status != "" {
+		b.WriteString("\n" + m.status + "\n")
+	}
+	help := "\n↑/↓ select • enter run HTTP checks • a add scope • r refresh • q quit"
+	if m.run != nil {
+		help += " • v view last run"
+	}
+	return b.String() + help + "\n"
+}
 
-	entries := strings.Split(scopeLine, ",")
-	for i := range entries {
-		entries[i] = strings.TrimSpace(entries[i])
+func (m *tuiModel) confirmView() string {
+	eng := m.engagements[m.cursor]
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", colorWarn("Confirm check run"))
+	fmt.Fprintf(&b, "Engagement: %s (%s)\nOwner:      %s\nTargets:    %d\n", eng.Name, eng.ID, eng.Owner, len(eng.Scope))
+	if eng.ROE != "" {
+		fmt.Fprintf(&b, "ROE:        %s\n", eng.ROE)
 	}
+	b.WriteString("\nRun HTTP checks against every scope entry? Confirming acknowledges the Rules of\nEngagement, as --roe-confirm does on the command line.\n\n")
+	b.WriteString("y confirm • n cancel\n")
+	return b.String()
+}
 
-	normalized, err := normalizeScopeEntries(id, entries)
-	if err != nil {
-		return err
+func (m *tuiModel) addScopeView() string {
+	eng := m.engagements[m.cursor]
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n\n", colorInfo("Add scope to"), eng.Name)
+	b.WriteString("Entries (comma separated URLs, hosts, or IPs):\n")
+	fmt.Fprintf(&b, "> %s█\n", m.scopeInput)
+	if m.status != "" {
+		b.WriteString("\n" + m.status + "\n")
+	}
+	return b.String() + "\nenter add • esc cancel\n"
+}
+
+func (m *tuiModel) runView() string {
+	run := m.run
+	ok, failed := run.completed()
+	total := len(run.targets)
+	elapsed := time.Since(run.started)
+	if run.done {
+		elapsed = run.finished.Sub(run.started)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s — %s\n", colorInfo("HTTP checks:"), run.engagement.Name, tuiRunStatus(run))
+	fmt.Fprintf(&b, "%s %d/%d  OK:%d  Fail:%d  Findings:%d  Elapsed:%s\n\n",
+		progressBar(ok+failed, total, 30), ok+failed, total, ok, failed, len(run.findings), elapsed.Round(time.Second))
+
+	// Keep the selected row visible on short terminals.
+	rows := total
+	if m.height > 0 {
+		rows = max(m.height-tuiFindingsShown-10, 3)
+	}
+	first := 0
+	if m.runCursor >= rows {
+		first = m.runCursor - rows + 1
+	}
+	for i := first; i < total && i < first+rows; i++ {
+		t := run.targets[i]
+		cursor := "  "
+		if i == m.runCursor {
+			cursor = colorInfo("> ")
+		}
+		fmt.Fprintf(&b, "%s%-44s %s\n", cursor, truncate(t.name, 44), tuiTargetSummary(t))
 	}
 
-	if err := appCtx.Services.EngagementService.AddToScope(ctx, id, normalized); err != nil {
-		return fmt.Errorf("failed to add scope entries: %w", err)
+	b.WriteString("\n" + colorInfo("Findings") + "\n")
+	if len(run.findings) == 0 {
+		b.WriteString("  none yet\n")
+	}
+	start := max(len(run.findings)-tuiFindingsShown, 0)
+	for _, f := range run.findings[start:] {
+		fmt.Fprintf(&b, "  %s %s — %s\n", severityLabel(f.finding.Severity), f.finding.Check, truncate(f.target, 40))
 	}
 
-	fmt.Printf("%s scope %v to engagement %s\n", colorSuccess("Added"), normalized, id)
-	return nil
+	if run.done {
+		b.WriteString("\n")
+		if run.err != nil {
+			fmt.Fprintf(&b, "%s %v\n", colorError("Error:"), run.err)
+		} else {
+			fmt.Fprintf(&b, "%s Audit hash (%s): %s\n", colorSuccess("✓"), run.hashAlgo, run.auditHash)
+		}
+	}
+
+	help := "\n↑/↓ select • enter view result • esc engagements • q quit"
+	if !run.done {
+		help += " • c cancel run"
+	}
+	return b.String() + help + "\n"
 }
 
-func showEngagementDetail(reader *bufio.Reader, eng engagementDTO) {
-	fmt.Println("--------------------------------------------------")
-	fmt.Printf("Name  : %s\n", eng.Name)
-	fmt.Printf("ID    : %s\n", eng.ID)
-	fmt.Printf("Owner : %s\n", eng.Owner)
-	fmt.Printf("Scope (%d):\n", len(eng.Scope))
-	for _, s := range eng.Scope {
-		fmt.Printf("  - %s\n", s)
+func (m *tuiModel) resultView() string {
+	t := m.run.targets[m.runCursor]
+	r := t.result
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n\n", colorInfo("Result:"), t.name)
+	fmt.Fprintf(&b, "Status:       %s\n", formatStatusWithColor(r.Status))
+	if r.HTTPStatus != 0 {
+		fmt.Fprintf(&b, "HTTP status:  %d\n", r.HTTPStatus)
 	}
-	fmt.Printf("Created: %s\n", eng.CreatedAt.Format("2006-01-02 15:04:05"))
-	if eng.ROE != "" {
-		fmt.Printf("ROE   : %s\n", eng.ROE)
+	fmt.Fprintf(&b, "Duration:     %.2fs\n", t.duration)
+	if r.ServerHeader != "" {
+		fmt.Fprintf(&b, "Server:       %s\n", r.ServerHeader)
+	}
+	if r.TLSExpiry != "" {
+		fmt.Fprintf(&b, "TLS expiry:   %s\n", r.TLSExpiry)
+	}
+	if r.SecurityHeaders != nil {
+		fmt.Fprintf(&b, "Headers:      grade %s (%d/%d)\n", r.SecurityHeaders.Grade, r.SecurityHeaders.Score, r.SecurityHeaders.MaxScore)
+	}
+	if r.Notes != "" {
+		fmt.Fprintf(&b, "Notes:        %s\n", r.Notes)
 	}
-	fmt.Println("Press Enter to return...")
-	_, _ = reader.ReadString('\n')
+	if r.Error != "" {
+		fmt.Fprintf(&b, "Error:        %s\n", colorError(r.Error))
+	}
+
+	fmt.Fprintf(&b, "\n%s (%d)\n", colorInfo("Findings"), len(t.findings))
+	for _, f := range t.findings {
+		fmt.Fprintf(&b, "  %s %s\n", severityLabel(f.Severity), f.Check)
+		for _, e := range f.Evidence {
+			fmt.Fprintf(&b, "      %s\n", truncate(e, 100))
+		}
+		if rec, _, _ := strings.Cut(strings.TrimSpace(f.Remediation), "\n"); rec != "" {
+			fmt.Fprintf(&b, "      → %s\n", truncate(rec, 100))
+		}
+	}
+	return b.String() + "\nesc back • q quit\n"
+}
+
+func tuiRunStatus(run *tuiRun) string {
+	switch {
+	case !run.done && run.cancelled:
+		return colorWarn("cancelling, sealing partial results...")
+	case !run.done:
+		return colorInfo("running")
+	case run.err != nil:
+		return colorError("failed")
+	case run.cancelled:
+		return colorWarn("cancelled (partial results sealed)")
+	default:
+		return colorSuccess("complete")
+	}
+}
+
+func tuiTargetSummary(t *tuiTarget) string {
+	switch t.state {
+	case tuiTargetRunning:
+		return colorInfo(fmt.Sprintf("running %s", time.Since(t.started).Round(time.Second)))
+	case tuiTargetDone:
+		summary := fmt.Sprintf("%s %.2fs", formatStatusWithColor(t.result.Status), t.duration)
+		if t.result.HTTPStatus != 0 {
+			summary += fmt.Sprintf(" HTTP %d", t.result.HTTPStatus)
+		}
+		if n := len(t.findings); n > 0 {
+			summary += fmt.Sprintf(" %s", colorWarn(fmt.Sprintf("%d finding(s)", n)))
+		}
+		return summary
+	default:
+		return "pending"
+	}
+}
+
+func severityLabel(severity string) string {
+	label := fmt.Sprintf("[%-8s]", severity)
+	switch strings.ToLower(severity) {
+	case "critical", "high":
+		return colorError(label)
+	case "medium":
+		return colorWarn(label)
+	default:
+		return colorInfo(label)
+	}
+}
+
+func progressBar(done, total, width int) string {
+	if total <= 0 {
+		total = 1
+	}
+	filled := min(done*width/total, width)
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
+
+func truncate(s string, n int) string {
+	if len([]rune(s)) <= n {
+		return s
+	}
+	return string([]rune(s)[:n-1]) + "…"
+}
+
+func listEngagementDTOs(ctx context.Context, appCtx *AppContext) ([]engagementDTO, error) {
+	engagements, err := appCtx.Services.EngagementService.ListEngagements(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list engagements: %w", err)
+	}
+
+	dtos := make([]engagementDTO, len(engagements))
+	for i, eng := range engagements {
+		dtos[i] = engagementToDTO(eng)
+	}
+	sort.Slice(dtos, func(i, j int) bool { return dtos[i].CreatedAt.After(dtos[j].CreatedAt) })
+	return dtos, nil
 }
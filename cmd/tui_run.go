@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/khanhnv2901/seca-cli/internal/domain/check"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+// Messages sent from a TUI check run to the model.
+type (
+	tuiTargetStartedMsg struct{ target string }
+	tuiTargetDoneMsg    struct {
+		target   string
+		result   checker.CheckResult
+		duration float64
+	}
+	tuiRunDoneMsg struct {
+		hashAlgo  string
+		auditHash string
+		err       error
+	}
+)
+
+// notifyingChecker reports when the runner picks up a target, so the TUI can
+// show which targets are in flight.
+type notifyingChecker struct {
+	checker.Checker
+	onStart func(target string)
+}
+
+func (c notifyingChecker) Check(ctx context.Context, target string) checker.CheckResult {
+	c.onStart(target)
+	return c.Checker.Check(ctx, target)
+}
+
+// startTUICheckRun runs HTTP checks over the engagement scope in the
+// background, recording results and the audit trail exactly as "check http"
+// does. Progress is delivered on the returned channel, which is closed after
+// the final tuiRunDoneMsg.
+func startTUICheckRun(ctx context.Context, appCtx *AppContext, eng engagementDTO) <-chan tea.Msg {
+	// Each target sends a started and a done message; the buffer ensures the
+	// run never blocks on a slow UI.
+	events := make(chan tea.Msg, 2*len(eng.Scope)+1)
+
+	go func() {
+		defer close(events)
+		hashAlgo, auditHash, err := runTUIChecks(ctx, appCtx, eng, events)
+		events <- tuiRunDoneMsg{hashAlgo: hashAlgo, auditHash: auditHash, err: err}
+	}()
+
+	return events
+}
+
+func runTUIChecks(ctx context.Context, appCtx *AppContext, eng engagementDTO, events chan<- tea.Msg) (hashAlgo, auditHash string, err error) {
+	runtimeCfg := appCtx.Config.Check
+
+	if err := validateCheckConfig(eng.ID, runtimeCfg, "http"); err != nil {
+		return "", "", err
+	}
+	if err := appCtx.Services.EngagementService.ValidateEngagementForChecks(ctx, eng.ID, ""); err != nil {
+		return "", "", fmt.Errorf("engagement validation failed: %w", err)
+	}
+
+	lock, err := acquireEngagementLock(ctx, appCtx.ResultsDir, eng.ID, "tui check http", appCtx.Operator, 0)
+	if err != nil {
+		return "", "", err
+	}
+	defer lock.Release()
+	defer flushAuditEntries(appCtx, eng.ID, &err)
+
+	checkRun, err := appCtx.Services.CheckOrchestrator.CreateCheckRun(ctx, eng.ID, appCtx.Operator)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create check run: %w", err)
+	}
+	checkRun.SetCheckType(check.CheckTypeHTTP)
+	appCtx.Services.CheckOrchestrator.EnableSnapshots(checkRun, runSnapshotPolicy(eng.ID, runtimeCfg))
+
+	headers, err := resolveRequestHeaders(eng.ID, runtimeCfg.Request)
+	if err != nil {
+		return "", "", err
+	}
+	proxy, err := resolveProxy(eng.ID, runtimeCfg.Request)
+	if err != nil {
+		return "", "", err
+	}
+	dialer, err := resolveDialer(eng.ID, runtimeCfg)
+	if err != nil {
+		return "", "", err
+	}
+	phases, err := resolvePhaseTimeouts(runtimeCfg)
+	if err != nil {
+		return "", "", err
+	}
+	overrides := newScopeOverrides(eng.Scope, eng.TargetOverrides)
+	loginTransport := checker.WithDecorator(dialer.Apply(checker.NewTransport(proxy)), checker.HeaderDecorator(headers))
+	sess, _, err := openEngagementSession(ctx, appCtx.ResultsDir, eng.ID, eng.Scope, time.Duration(runtimeCfg.TimeoutSecs)*time.Second, loginTransport)
+	if err != nil {
+		return "", "", err
+	}
+	sess, err = routeScopeSessions(ctx, appCtx.ResultsDir, eng.ID, eng.Scope, time.Duration(runtimeCfg.TimeoutSecs)*time.Second, loginTransport, sess, overrides)
+	if err != nil {
+		return "", "", err
+	}
+
+	budget, _, err := openRunBudget(appCtx.ResultsDir, eng.ID)
+	if err != nil {
+		return "", "", err
+	}
+	headerPolicy, err := loadHeaderPolicy(appCtx.ResultsDir, eng.ID)
+	if err != nil {
+		return "", "", err
+	}
+	favicons, err := loadFaviconDB()
+	if err != nil {
+		return "", "", err
+	}
+
+	engagementChecker := newEngagementHTTPChecker(appCtx.ResultsDir, eng.ID, runtimeCfg, headers, proxy, sess, nil)
+	engagementChecker.Dialer = dialer
+	engagementChecker.Phases = phases
+	engagementChecker.Budget = budget
+	engagementChecker.HeaderPolicy = headerPolicy
+	engagementChecker.Favicons = favicons
+	httpChecker := notifyingChecker{
+		Checker: engagementChecker,
+		onStart: func(target string) { events <- tuiTargetStartedMsg{target: target} },
+	}
+	runner := &checker.Runner{
+		Concurrency: runtimeCfg.Concurrency,
+		RateLimit:   runtimeCfg.RateLimit,
+		Timeout:     httpCheckTimeout(runtimeCfg, phases),
+		Budget:      budget,
+		Overrides:   overrides.Lookup(),
+		Phases:      phases,
+		Health:      newHealthCheck(runtimeCfg, phases, dialer, proxy),
+	}
+
+	auditFn := httpAuditFunc(ctx, appCtx, eng.ID, checkRun, sess, proxy, "", func(target string, result checker.CheckResult, duration float64) {
+		events <- tuiTargetDoneMsg{target: target, result: result, duration: duration}
+	})
+	results := runner.RunChecks(ctx, eng.Scope, httpChecker, auditFn)
+	if reason := budgetStopReason(budget, countStatuses(results).Checked(), len(eng.Scope)); reason != "" {
+		checkRun.SetStopReason(reason)
+	}
+	if _, err := observeKeyPins(appCtx.ResultsDir, eng.ID, results, time.Now().UTC()); err != nil {
+		cliLog().Warnw("key_pins_save_failed", "engagement_id", eng.ID, "error", err)
+	}
+	if _, err := checkExpectedCertificates(appCtx.ResultsDir, eng.ID, results, overrides.ExpectedIssuers(), time.Now().UTC()); err != nil {
+		cliLog().Warnw("expected_certs_check_failed", "engagement_id", eng.ID, "error", err)
+	}
+	if _, err := observeContentHashes(appCtx.ResultsDir, eng.ID, results, time.Now().UTC()); err != nil {
+		cliLog().Warnw("content_hashes_save_failed", "engagement_id", eng.ID, "error", err)
+	}
+
+	// Seal even after cancellation so partial results are preserved, as the
+	// CLI does on interrupt.
+	return sealCheckRun(context.WithoutCancel(ctx), appCtx, eng.ID, checkRun, runtimeCfg.HashAlgorithm)
+}
+
+// waitForRunEvent delivers the next message from a check run to the model.
+func waitForRunEvent(events <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-events
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
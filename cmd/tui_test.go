@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+func tuiKey(key string) tea.KeyMsg {
+	switch key {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+func TestTUIModelRunFlow(t *testing.T) {
+	m := newTUIModel(context.Background(), &AppContext{})
+	eng := engagementDTO{ID: "eng-1", Name: "Web", Owner: "alice", Scope: []string{"https://a.example.com", "https://b.example.com"}}
+	m.Update(tuiEngagementsMsg{engagements: []engagementDTO{eng}})
+
+	m.Update(tuiKey("enter"))
+	if m.view != tuiViewConfirm || !strings.Contains(m.View(), "Confirm check run") {
+		t.Fatalf("expected ROE confirmation before a run, got view %d", m.view)
+	}
+	m.Update(tuiKey("n"))
+	if m.view != tuiViewEngagements {
+		t.Fatalf("expected cancel to return to engagements, got view %d", m.view)
+	}
+
+	// Drive a run by hand instead of starting real checks.
+	events := make(chan tea.Msg)
+	m.run = &tuiRun{engagement: eng, byName: map[string]*tuiTarget{}, events: events, cancel: func() {}}
+	for _, name := range eng.Scope {
+		target := &tuiTarget{name: name}
+		m.run.targets = append(m.run.targets, target)
+		m.run.byName[name] = target
+	}
+	m.view = tuiViewRun
+
+	m.Update(tuiTargetStartedMsg{target: "https://a.example.com"})
+	if m.run.targets[0].state != tuiTargetRunning || !strings.Contains(m.View(), "running") {
+		t.Fatal("expected target to be shown as running")
+	}
+
+	result := checker.CheckResult{
+		Target:     "https://a.example.com",
+		Status:     "ok",
+		HTTPStatus: 200,
+		SecurityHeaders: &checker.SecurityHeadersResult{Headers: map[string]checker.HeaderStatus{
+			"Content-Security-Policy": {Present: false},
+		}},
+	}
+	m.Update(tuiTargetDoneMsg{target: "https://a.example.com", result: result, duration: 0.4})
+	if len(m.run.findings) == 0 || !strings.Contains(m.View(), "Content Security Policy") {
+		t.Fatalf("expected findings to stream into the run view:\n%s", m.View())
+	}
+	if ok, failed := m.run.completed(); ok != 1 || failed != 0 {
+		t.Fatalf("completed() = %d, %d", ok, failed)
+	}
+
+	m.Update(tuiKey("enter"))
+	if m.view != tuiViewResult || !strings.Contains(m.View(), "HTTP status:  200") {
+		t.Fatalf("expected drill-down into the result, got view %d", m.view)
+	}
+	m.Update(tuiKey("esc"))
+
+	// Pending targets cannot be opened.
+	m.Update(tuiKey("down"))
+	m.Update(tuiKey("enter"))
+	if m.view != tuiViewRun {
+		t.Fatalf("expected pending target to stay in run view, got %d", m.view)
+	}
+
+	m.Update(tuiRunDoneMsg{hashAlgo: "sha256", auditHash: "abc123"})
+	if !m.run.done || !strings.Contains(m.View(), "abc123") {
+		t.Fatalf("expected completed run with audit hash:\n%s", m.View())
+	}
+}
+
+func TestProgressBarAndTruncate(t *testing.T) {
+	if got := progressBar(1, 4, 8); got != "[██░░░░░░]" {
+		t.Errorf("progressBar = %q", got)
+	}
+	if got := truncate("engagement", 5); got != "enga…" {
+		t.Errorf("truncate = %q", got)
+	}
+}
+
+func TestTUIModelAddScope(t *testing.T) {
+	defer setupTestAppContextWithServices(t)()
+
+	svc := globalAppContext.Services.EngagementService
+	eng, err := svc.CreateEngagement(context.Background(), "TUI", "owner", "ROE", nil)
+	if err != nil {
+		t.Fatalf("CreateEngagement: %v", err)
+	}
+
+	m := newTUIModel(context.Background(), globalAppContext)
+	m.Update(m.loadEngagements())
+	m.Update(tuiKey("a"))
+	for _, key := range []string{"app.example.com, ", "ftp://bad"} {
+		m.Update(tuiKey(key))
+	}
+	m.Update(tuiKey("enter"))
+	if m.view != tuiViewAddScope || !strings.Contains(m.View(), "unsupported scheme") {
+		t.Fatalf("expected invalid entry to be rejected in the prompt:\n%s", m.View())
+	}
+
+	m.scopeInput = "app.example.com, api.example.com"
+	_, cmd := m.Update(tuiKey("enter"))
+	if m.view != tuiViewEngagements || cmd == nil {
+		t.Fatalf("expected return to engagements with a refresh, got view %d", m.view)
+	}
+	m.Update(cmd())
+
+	updated, err := svc.GetEngagement(context.Background(), eng.ID())
+	if err != nil || len(updated.Scope()) != 2 {
+		t.Fatalf("expected 2 scope entries, got %v (%v)", updated.Scope(), err)
+	}
+	if len(m.engagements) != 1 || len(m.engagements[0].Scope) != 2 {
+		t.Fatalf("expected engagement list to refresh, got %+v", m.engagements)
+	}
+}
@@ -3,6 +3,8 @@ package check
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/khanhnv2901/seca-cli/internal/domain/audit"
 	"github.com/khanhnv2901/seca-cli/internal/domain/check"
@@ -14,6 +16,29 @@ type Orchestrator struct {
 	engagementRepo engagement.Repository
 	checkRunRepo   check.Repository
 	auditRepo      audit.Repository
+
+	snapshotsMu sync.Mutex
+	snapshots   map[string]*snapshotState // by check run ID
+}
+
+// SnapshotPolicy says when AddCheckResult saves a running check run's
+// results to its snapshot file, so a crash or forced kill keeps the checks
+// the audit trail already records: after Every new results or once Interval
+// has passed since the last save, whichever comes first. A zero field never
+// triggers a save.
+type SnapshotPolicy struct {
+	Every    int
+	Interval time.Duration
+	// OnError, when set, is told about failed saves; the run carries on.
+	OnError func(err error)
+}
+
+type snapshotState struct {
+	policy SnapshotPolicy
+
+	mu      sync.Mutex // held while saving, so an older snapshot never replaces a newer one
+	pending int
+	last    time.Time
 }
 
 // NewOrchestrator creates a new check orchestrator
@@ -26,6 +51,7 @@ func NewOrchestrator(
 		engagementRepo: engagementRepo,
 		checkRunRepo:   checkRunRepo,
 		auditRepo:      auditRepo,
+		snapshots:      make(map[string]*snapshotState),
 	}
 }
 
@@ -45,6 +71,10 @@ func (o *Orchestrator) CreateCheckRun(ctx context.Context, engagementID, operato
 		return nil, fmt.Errorf("engagement is not active")
 	}
 
+	if !eng.CanRunChecks() {
+		return nil, fmt.Errorf("engagement status %s does not allow checks", eng.Status())
+	}
+
 	// Create check run
 	checkRun, err := check.NewCheckRun(engagementID, eng.Name(), operator)
 	if err != nil {
@@ -59,15 +89,55 @@ func (o *Orchestrator) CreateCheckRun(ctx context.Context, engagementID, operato
 	return checkRun, nil
 }
 
+// EnableSnapshots makes AddCheckResult save checkRun's results so far
+// according to policy until the run is finalized
+func (o *Orchestrator) EnableSnapshots(checkRun *check.CheckRun, policy SnapshotPolicy) {
+	if policy.Every <= 0 && policy.Interval <= 0 {
+		return
+	}
+	o.snapshotsMu.Lock()
+	defer o.snapshotsMu.Unlock()
+	o.snapshots[checkRun.ID()] = &snapshotState{policy: policy, last: time.Now()}
+}
+
 // AddCheckResult adds a result to a check run
 func (o *Orchestrator) AddCheckResult(ctx context.Context, checkRun *check.CheckRun, result *check.Result) error {
 	if err := checkRun.AddResult(result); err != nil {
 		return fmt.Errorf("failed to add result: %w", err)
 	}
 
+	o.snapshotsMu.Lock()
+	state := o.snapshots[checkRun.ID()]
+	o.snapshotsMu.Unlock()
+	if state != nil {
+		state.recorded(ctx, o.checkRunRepo, checkRun)
+	}
+
 	return nil
 }
 
+// recorded counts a new result and saves a snapshot when the policy says so
+func (s *snapshotState) recorded(ctx context.Context, repo check.Repository, checkRun *check.CheckRun) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending++
+	due := (s.policy.Every > 0 && s.pending >= s.policy.Every) ||
+		(s.policy.Interval > 0 && time.Since(s.last) >= s.policy.Interval)
+	if !due {
+		return
+	}
+	// A canceled run still snapshots the results it finished.
+	if err := repo.SaveSnapshot(context.WithoutCancel(ctx), checkRun); err != nil {
+		if s.policy.OnError != nil {
+			s.policy.OnError(err)
+		}
+		return
+	}
+	s.pending = 0
+	s.last = time.Now()
+}
+
 // FinalizeCheckRun completes a check run and persists it
 func (o *Orchestrator) FinalizeCheckRun(ctx context.Context, checkRun *check.CheckRun, auditHash, hashAlgorithm string) error {
 	// Complete the check run
@@ -82,7 +152,17 @@ func (o *Orchestrator) FinalizeCheckRun(ctx context.Context, checkRun *check.Che
 		}
 	}
 
-	// Save the check run
+	// Save the check run; the repository removes its snapshot
+	o.snapshotsMu.Lock()
+	state := o.snapshots[checkRun.ID()]
+	delete(o.snapshots, checkRun.ID())
+	o.snapshotsMu.Unlock()
+	if state != nil {
+		// Let a snapshot being saved finish before the results file
+		// replaces it.
+		state.mu.Lock()
+		defer state.mu.Unlock()
+	}
 	if err := o.checkRunRepo.Save(ctx, checkRun); err != nil {
 		return fmt.Errorf("failed to save check run: %w", err)
 	}
@@ -2,6 +2,7 @@ package application
 
 import (
 	"fmt"
+	"io"
 
 	auditapp "github.com/khanhnv2901/seca-cli/internal/application/audit"
 	checkapp "github.com/khanhnv2901/seca-cli/internal/application/check"
@@ -58,3 +59,12 @@ func NewContainer(dataDir, resultsDir string) (*Container, error) {
 		AuditService:      auditService,
 	}, nil
 }
+
+// Close releases resources held by the repositories, writing any buffered
+// audit entries.
+func (c *Container) Close() error {
+	if closer, ok := c.AuditRepo.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
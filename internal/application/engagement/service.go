@@ -57,9 +57,9 @@ func (s *Service) ListEngagements(ctx context.Context) ([]*engagement.Engagement
 
 // AcknowledgeROE acknowledges the rules of engagement for an engagement
 func (s *Service) AcknowledgeROE(ctx context.Context, id string) error {
-	eng, err := s.repo.FindByID(ctx, id)
+	eng, err := s.findWritable(ctx, id)
 	if err != nil {
-		return fmt.Errorf("failed to get engagement: %w", err)
+		return err
 	}
 
 	if err := eng.AcknowledgeROE(); err != nil {
@@ -75,9 +75,9 @@ func (s *Service) AcknowledgeROE(ctx context.Context, id string) error {
 
 // AddToScope adds targets to an engagement's scope
 func (s *Service) AddToScope(ctx context.Context, id string, targets []string) error {
-	eng, err := s.repo.FindByID(ctx, id)
+	eng, err := s.findWritable(ctx, id)
 	if err != nil {
-		return fmt.Errorf("failed to get engagement: %w", err)
+		return err
 	}
 
 	for _, target := range targets {
@@ -95,9 +95,9 @@ func (s *Service) AddToScope(ctx context.Context, id string, targets []string) e
 
 // RemoveFromScope removes targets from an engagement's scope
 func (s *Service) RemoveFromScope(ctx context.Context, id string, targets []string) error {
-	eng, err := s.repo.FindByID(ctx, id)
+	eng, err := s.findWritable(ctx, id)
 	if err != nil {
-		return fmt.Errorf("failed to get engagement: %w", err)
+		return err
 	}
 
 	for _, target := range targets {
@@ -113,11 +113,30 @@ func (s *Service) RemoveFromScope(ctx context.Context, id string, targets []stri
 	return nil
 }
 
+// SetTargetOverrides replaces the overrides of one of an engagement's scope
+// entries; zero overrides remove them.
+func (s *Service) SetTargetOverrides(ctx context.Context, id, target string, overrides engagement.TargetOverrides) error {
+	eng, err := s.findWritable(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := eng.SetTargetOverrides(target, overrides); err != nil {
+		return err
+	}
+
+	if err := s.repo.Save(ctx, eng); err != nil {
+		return fmt.Errorf("failed to save engagement: %w", err)
+	}
+
+	return nil
+}
+
 // SetTimeRange sets the time range for an engagement
 func (s *Service) SetTimeRange(ctx context.Context, id string, start, end time.Time) error {
-	eng, err := s.repo.FindByID(ctx, id)
+	eng, err := s.findWritable(ctx, id)
 	if err != nil {
-		return fmt.Errorf("failed to get engagement: %w", err)
+		return err
 	}
 
 	if err := eng.SetTimeRange(start, end); err != nil {
@@ -131,6 +150,70 @@ func (s *Service) SetTimeRange(ctx context.Context, id string, start, end time.T
 	return nil
 }
 
+// SetExpiry sets when an engagement's authorization lapses. It can only
+// bring the expiry forward; ExtendEngagement pushes it back.
+func (s *Service) SetExpiry(ctx context.Context, id string, expiresAt time.Time) error {
+	eng, err := s.findWritable(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := eng.SetExpiry(expiresAt); err != nil {
+		return fmt.Errorf("failed to set expiry: %w", err)
+	}
+
+	if err := s.repo.Save(ctx, eng); err != nil {
+		return fmt.Errorf("failed to save engagement: %w", err)
+	}
+
+	return nil
+}
+
+// ExtendEngagement moves an engagement's expiry to until, which also lifts
+// the read-only state of an expired engagement. roeConfirmed must be true.
+func (s *Service) ExtendEngagement(ctx context.Context, id string, until time.Time, roeConfirmed bool) error {
+	eng, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get engagement: %w", err)
+	}
+	if eng.IsClosed() {
+		return sharedErrors.ErrEngagementClosed
+	}
+
+	if err := eng.Extend(until, roeConfirmed); err != nil {
+		return fmt.Errorf("failed to extend engagement: %w", err)
+	}
+
+	if err := s.repo.Save(ctx, eng); err != nil {
+		return fmt.Errorf("failed to save engagement: %w", err)
+	}
+
+	return nil
+}
+
+// TransitionStatus moves an engagement to another status, returning the
+// status it left.
+func (s *Service) TransitionStatus(ctx context.Context, id string, to engagement.Status) (engagement.Status, error) {
+	eng, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("failed to get engagement: %w", err)
+	}
+	if eng.IsClosed() {
+		return "", sharedErrors.ErrEngagementClosed
+	}
+
+	from := eng.Status()
+	if err := eng.TransitionTo(to); err != nil {
+		return "", fmt.Errorf("failed to change status: %w", err)
+	}
+
+	if err := s.repo.Save(ctx, eng); err != nil {
+		return "", fmt.Errorf("failed to save engagement: %w", err)
+	}
+
+	return from, nil
+}
+
 // DeleteEngagement deletes an engagement
 func (s *Service) DeleteEngagement(ctx context.Context, id string) error {
 	if err := s.repo.Delete(ctx, id); err != nil {
@@ -152,6 +235,16 @@ func (s *Service) ValidateEngagementForChecks(ctx context.Context, id string, ta
 		return sharedErrors.ErrEngagementUnauthorized
 	}
 
+	// Expired engagements are read-only
+	if eng.IsExpired() {
+		return sharedErrors.ErrEngagementExpired
+	}
+
+	// Checks only run in the active status
+	if !eng.CanRunChecks() {
+		return fmt.Errorf("%w: status is %s", sharedErrors.ErrEngagementNotActive, eng.Status())
+	}
+
 	// Check if engagement is active
 	if !eng.IsActive() {
 		return sharedErrors.ErrEngagementInactive
@@ -164,3 +257,19 @@ func (s *Service) ValidateEngagementForChecks(ctx context.Context, id string, ta
 
 	return nil
 }
+
+// findWritable loads an engagement for a change, refusing closed and expired
+// ones.
+func (s *Service) findWritable(ctx context.Context, id string) (*engagement.Engagement, error) {
+	eng, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get engagement: %w", err)
+	}
+	if eng.IsClosed() {
+		return nil, sharedErrors.ErrEngagementClosed
+	}
+	if eng.IsExpired() {
+		return nil, sharedErrors.ErrEngagementExpired
+	}
+	return eng, nil
+}
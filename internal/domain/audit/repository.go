@@ -13,6 +13,9 @@ type Repository interface {
 	// AppendEntry appends a single entry to an existing audit trail
 	AppendEntry(ctx context.Context, engagementID string, entry *Entry) error
 
+	// Flush writes any appended entries that are still buffered
+	Flush(ctx context.Context, engagementID string) error
+
 	// ComputeHash calculates the hash of the audit trail file
 	ComputeHash(ctx context.Context, engagementID, algorithm string) (string, error)
 
@@ -2,12 +2,15 @@ package check
 
 import (
 	"errors"
+	"strings"
+	"sync"
 	"time"
 )
 
 // CheckRun represents an execution of security checks against an engagement's scope
 // It serves as an aggregate root that owns CheckResults and AuditTrail
 type CheckRun struct {
+	mu             sync.Mutex // guards results and metadata while checks record concurrently
 	id             string
 	engagementID   string
 	engagementName string
@@ -35,6 +38,34 @@ type Metadata struct {
 	HashAlgorithm        string
 	SignatureFingerprint string
 	TotalTargets         int
+	StopReason           string // Why the run ended before checking every target, if it did
+	CheckType            string // Checker that produced the results: http, dns, network or a plugin name
+}
+
+// Built-in check types. Plugin runs use the plugin name. CheckTypeHTTP is
+// assumed for runs recorded before check types existed.
+const (
+	CheckTypeHTTP    = "http"
+	CheckTypeDNS     = "dns"
+	CheckTypeNetwork = "network"
+)
+
+// ResultsFilename returns the file a run of checkType stores its results in,
+// e.g. http_results.json or dns_results.json.
+func ResultsFilename(checkType string) string {
+	checkType = strings.ToLower(strings.TrimSpace(checkType))
+	if checkType == "" {
+		checkType = CheckTypeHTTP
+	}
+	return checkType + "_results.json"
+}
+
+// SnapshotFilename returns the file a run in progress saves the results it
+// has so far to, e.g. http_results.run-20261016090000-123456.partial.json.
+// The snapshot is removed once the run's results file is saved, so one left
+// behind belongs to a run that never finished.
+func SnapshotFilename(checkType, runID string) string {
+	return strings.TrimSuffix(ResultsFilename(checkType), ".json") + "." + runID + ".partial.json"
 }
 
 // NewCheckRun creates a new check run
@@ -112,6 +143,8 @@ func (cr *CheckRun) AddResult(result *Result) error {
 		return errors.New("cannot add results to a finished check run")
 	}
 
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
 	cr.results = append(cr.results, result)
 	cr.metadata.TotalTargets = len(cr.results)
 	return nil
@@ -126,13 +159,31 @@ func (cr *CheckRun) SetAuditHash(hash, algorithm string) error {
 		return errors.New("unsupported hash algorithm")
 	}
 
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
 	cr.metadata.AuditHash = hash
 	cr.metadata.HashAlgorithm = algorithm
 	return nil
 }
 
+// SetStopReason records why the run stopped early (e.g. a budget was spent).
+func (cr *CheckRun) SetStopReason(reason string) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	cr.metadata.StopReason = reason
+}
+
+// SetCheckType records which checker produced the run's results.
+func (cr *CheckRun) SetCheckType(checkType string) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	cr.metadata.CheckType = checkType
+}
+
 // SetSignature sets the GPG signature fingerprint
 func (cr *CheckRun) SetSignature(fingerprint string) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
 	cr.metadata.SignatureFingerprint = fingerprint
 }
 
@@ -167,6 +218,8 @@ func (cr *CheckRun) Status() RunStatus {
 }
 
 func (cr *CheckRun) Results() []*Result {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
 	// Return a copy to prevent external modification
 	resultsCopy := make([]*Result, len(cr.results))
 	copy(resultsCopy, cr.results)
@@ -174,6 +227,8 @@ func (cr *CheckRun) Results() []*Result {
 }
 
 func (cr *CheckRun) Metadata() Metadata {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
 	return cr.metadata
 }
 
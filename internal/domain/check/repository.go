@@ -7,6 +7,10 @@ type Repository interface {
 	// Save persists a check run with all its results
 	Save(ctx context.Context, checkRun *CheckRun) error
 
+	// SaveSnapshot persists the results a running check run has recorded
+	// so far to its snapshot file; Save removes the snapshot
+	SaveSnapshot(ctx context.Context, checkRun *CheckRun) error
+
 	// FindByID retrieves a check run by its ID
 	FindByID(ctx context.Context, id string) (*CheckRun, error)
 
@@ -13,6 +13,7 @@ type Result struct {
 	tlsExpiry    time.Time
 	checkedAt    time.Time
 	responseTime float64
+	timings      PhaseTimings
 	error        string
 	findings     Findings
 }
@@ -23,8 +24,38 @@ type CheckStatus string
 const (
 	CheckStatusOK    CheckStatus = "ok"
 	CheckStatusError CheckStatus = "error"
+	// CheckStatusWarning marks a target that was checked, but part of the
+	// check could not complete
+	CheckStatusWarning CheckStatus = "warning"
+	// CheckStatusBlocked marks a target whose WAF answered with a block or
+	// challenge page instead of the site
+	CheckStatusBlocked CheckStatus = "blocked"
+	// CheckStatusUnreachable marks a target that failed the reachability
+	// pre-check, so no checks ran against it
+	CheckStatusUnreachable CheckStatus = "unreachable"
+	// CheckStatusSkipped marks a target the run never started
+	CheckStatusSkipped CheckStatus = "skipped"
 )
 
+// Succeeded reports whether the checks ran against the target
+func (s CheckStatus) Succeeded() bool {
+	return s == CheckStatusOK || s == CheckStatusWarning
+}
+
+// PhaseTimings breaks a check's duration into phases, in milliseconds
+type PhaseTimings struct {
+	DNS       float64
+	Connect   float64
+	TLS       float64
+	FirstByte float64
+	Analysis  float64
+}
+
+// IsZero reports whether no phase was recorded
+func (t PhaseTimings) IsZero() bool {
+	return t == PhaseTimings{}
+}
+
 // Findings contains all security findings for a target
 type Findings struct {
 	SecurityHeaders  *SecurityHeadersResult
@@ -149,10 +180,17 @@ func (r *Result) SetResponseTime(ms float64) {
 	r.responseTime = ms
 }
 
-// SetError sets the error message
+// SetTimings sets the per-phase timings
+func (r *Result) SetTimings(timings PhaseTimings) {
+	r.timings = timings
+}
+
+// SetError sets the error message; an ok or warning result becomes an error
 func (r *Result) SetError(err string) {
 	r.error = err
-	r.status = CheckStatusError
+	if r.status.Succeeded() {
+		r.status = CheckStatusError
+	}
 }
 
 // AddSecurityHeadersFindings adds security header findings
@@ -238,6 +276,10 @@ func (r *Result) ResponseTime() float64 {
 	return r.responseTime
 }
 
+func (r *Result) Timings() PhaseTimings {
+	return r.timings
+}
+
 func (r *Result) Error() string {
 	return r.error
 }
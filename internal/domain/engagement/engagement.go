@@ -2,9 +2,41 @@ package engagement
 
 import (
 	"errors"
+	"fmt"
 	"time"
 )
 
+// Status is the lifecycle state of an engagement.
+type Status string
+
+const (
+	// StatusDraft is a new engagement whose ROE has not been acknowledged.
+	StatusDraft Status = "draft"
+	// StatusActive is the only status in which checks may run.
+	StatusActive Status = "active"
+	// StatusPaused suspends testing without closing the engagement.
+	StatusPaused Status = "paused"
+	// StatusClosed is final: the engagement can no longer change.
+	StatusClosed Status = "closed"
+)
+
+// statusTransitions lists the statuses each status may move to.
+var statusTransitions = map[Status][]Status{
+	StatusDraft:  {StatusActive, StatusClosed},
+	StatusActive: {StatusPaused, StatusClosed},
+	StatusPaused: {StatusActive, StatusClosed},
+	StatusClosed: nil,
+}
+
+// ParseStatus validates a status name.
+func ParseStatus(s string) (Status, error) {
+	status := Status(s)
+	if _, ok := statusTransitions[status]; !ok {
+		return "", fmt.Errorf("unknown engagement status %q (must be draft, active, paused, or closed)", s)
+	}
+	return status, nil
+}
+
 // Engagement represents an authorized security testing engagement
 // It serves as an aggregate root in the DDD context
 type Engagement struct {
@@ -14,9 +46,12 @@ type Engagement struct {
 	start     time.Time
 	end       time.Time
 	scope     []string
+	overrides map[string]TargetOverrides
 	roe       string
 	roeAgree  bool
 	createdAt time.Time
+	expiresAt time.Time
+	status    Status
 }
 
 // NewEngagement creates a new engagement with validation
@@ -40,10 +75,12 @@ func NewEngagement(name, owner, roe string, scope []string) (*Engagement, error)
 		scope:     scope,
 		roeAgree:  false,
 		createdAt: now,
+		status:    StatusDraft,
 	}, nil
 }
 
-// Reconstruct creates an engagement from persisted data (for repository use)
+// Reconstruct creates an engagement from persisted data (for repository use).
+// The engagement is active; use RestoreStatus for a stored status.
 func Reconstruct(id, name, owner, roe string, scope []string, roeAgree bool, start, end, createdAt time.Time) *Engagement {
 	return &Engagement{
 		id:        id,
@@ -55,20 +92,68 @@ func Reconstruct(id, name, owner, roe string, scope []string, roeAgree bool, sta
 		roe:       roe,
 		roeAgree:  roeAgree,
 		createdAt: createdAt,
+		status:    StatusActive,
 	}
 }
 
+// RestoreStatus sets a persisted status without transition checks (for
+// repository use).
+func (e *Engagement) RestoreStatus(status Status) error {
+	if _, err := ParseStatus(string(status)); err != nil {
+		return err
+	}
+	e.status = status
+	return nil
+}
+
 // Business methods
 
-// AcknowledgeROE marks that the rules of engagement have been acknowledged
+// AcknowledgeROE marks that the rules of engagement have been acknowledged,
+// which activates a draft engagement
 func (e *Engagement) AcknowledgeROE() error {
 	if e.roeAgree {
 		return errors.New("ROE already acknowledged")
 	}
 	e.roeAgree = true
+	if e.status == StatusDraft {
+		e.status = StatusActive
+	}
+	return nil
+}
+
+// TransitionTo moves the engagement to another status. Activating requires
+// an acknowledged ROE, and a closed engagement cannot move at all.
+func (e *Engagement) TransitionTo(to Status) error {
+	if _, err := ParseStatus(string(to)); err != nil {
+		return err
+	}
+	allowed := false
+	for _, next := range statusTransitions[e.status] {
+		if next == to {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("cannot move engagement from %s to %s", e.status, to)
+	}
+	if to == StatusActive && !e.roeAgree {
+		return errors.New("ROE must be acknowledged before the engagement is activated")
+	}
+	e.status = to
 	return nil
 }
 
+// CanRunChecks checks if the engagement's status allows running checks
+func (e *Engagement) CanRunChecks() bool {
+	return e.status == StatusActive
+}
+
+// IsClosed checks if the engagement is closed and therefore immutable
+func (e *Engagement) IsClosed() bool {
+	return e.status == StatusClosed
+}
+
 // IsAuthorized checks if the engagement is authorized to run checks
 func (e *Engagement) IsAuthorized() bool {
 	return e.roeAgree
@@ -96,6 +181,7 @@ func (e *Engagement) RemoveFromScope(target string) error {
 	for i, s := range e.scope {
 		if s == target {
 			e.scope = append(e.scope[:i], e.scope[i+1:]...)
+			delete(e.overrides, target)
 			return nil
 		}
 	}
@@ -145,6 +231,38 @@ func (e *Engagement) IsActive() bool {
 	return now.Before(e.end)
 }
 
+// SetExpiry sets when authorization for the engagement lapses. It can only
+// bring the expiry forward; use Extend to push it back.
+func (e *Engagement) SetExpiry(expiresAt time.Time) error {
+	if expiresAt.IsZero() {
+		return errors.New("expiry cannot be empty")
+	}
+	if !e.expiresAt.IsZero() && expiresAt.After(e.expiresAt) {
+		return errors.New("expiry can only be extended with re-confirmed ROE")
+	}
+	e.expiresAt = expiresAt
+	return nil
+}
+
+// Extend moves the expiry to until. Extending re-authorizes testing, so the
+// rules of engagement must be confirmed again.
+func (e *Engagement) Extend(until time.Time, roeConfirmed bool) error {
+	if !roeConfirmed {
+		return errors.New("extending an engagement requires re-confirming the ROE")
+	}
+	if !until.After(time.Now()) {
+		return errors.New("new expiry must be in the future")
+	}
+	e.expiresAt = until
+	return nil
+}
+
+// IsExpired checks if the engagement's authorization has lapsed. Expired
+// engagements are read-only: checks are refused and scope cannot change.
+func (e *Engagement) IsExpired() bool {
+	return !e.expiresAt.IsZero() && !time.Now().Before(e.expiresAt)
+}
+
 // Getters (exposing internal state)
 
 func (e *Engagement) ID() string {
@@ -186,6 +304,15 @@ func (e *Engagement) CreatedAt() time.Time {
 	return e.createdAt
 }
 
+func (e *Engagement) Status() Status {
+	return e.status
+}
+
+// ExpiresAt returns when authorization lapses, or the zero time if never.
+func (e *Engagement) ExpiresAt() time.Time {
+	return e.expiresAt
+}
+
 // Helper function to generate engagement IDs
 func generateID() string {
 	return time.Now().Format("20060102150405") + "-" + time.Now().Format("000000000")[0:6]
@@ -0,0 +1,92 @@
+package engagement
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// AuthProfileNone runs a target unauthenticated even when the engagement
+// has stored credentials.
+const AuthProfileNone = "none"
+
+var authProfilePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// TargetOverrides adjusts how checks treat one scope entry. Zero fields
+// leave the run's flags in effect for that target.
+type TargetOverrides struct {
+	// Ports replaces the port list scanned on the target.
+	Ports []int `json:"ports,omitempty"`
+	// TimeoutSecs replaces the per-check timeout.
+	TimeoutSecs int `json:"timeout_secs,omitempty"`
+	// SkipCrawl keeps the crawler off the target; the entry itself is still checked.
+	SkipCrawl bool `json:"skip_crawl,omitempty"`
+	// AuthProfile names the stored credentials used for the target, or
+	// "none" to check it unauthenticated.
+	AuthProfile string `json:"auth_profile,omitempty"`
+	// ExpectedIssuer is the CA expected to issue the target's certificate.
+	ExpectedIssuer string `json:"expected_issuer,omitempty"`
+}
+
+// IsZero reports whether the overrides change nothing.
+func (o TargetOverrides) IsZero() bool {
+	return len(o.Ports) == 0 && o.TimeoutSecs == 0 && !o.SkipCrawl && o.AuthProfile == "" && o.ExpectedIssuer == ""
+}
+
+// Validate checks ports, timeout, and the auth profile name.
+func (o TargetOverrides) Validate() error {
+	for _, port := range o.Ports {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("port %d out of range (1-65535)", port)
+		}
+	}
+	if o.TimeoutSecs < 0 {
+		return errors.New("timeout cannot be negative")
+	}
+	if o.AuthProfile != "" && !ValidAuthProfile(o.AuthProfile) {
+		return fmt.Errorf("invalid auth profile %q (lowercase letters, digits, - and _)", o.AuthProfile)
+	}
+	return nil
+}
+
+// ValidAuthProfile reports whether name can name stored credentials.
+func ValidAuthProfile(name string) bool {
+	return authProfilePattern.MatchString(name)
+}
+
+// SetTargetOverrides replaces the overrides of a scope entry. Zero
+// overrides remove them.
+func (e *Engagement) SetTargetOverrides(target string, o TargetOverrides) error {
+	if !e.IsInScope(target) {
+		return fmt.Errorf("target %s is not in scope", target)
+	}
+	if err := o.Validate(); err != nil {
+		return fmt.Errorf("target %s: %w", target, err)
+	}
+	if o.IsZero() {
+		delete(e.overrides, target)
+		return nil
+	}
+	if e.overrides == nil {
+		e.overrides = make(map[string]TargetOverrides)
+	}
+	o.Ports = append([]int(nil), o.Ports...)
+	e.overrides[target] = o
+	return nil
+}
+
+// TargetOverrides returns the overrides of a scope entry, if it has any.
+func (e *Engagement) TargetOverrides(target string) (TargetOverrides, bool) {
+	o, ok := e.overrides[target]
+	return o, ok
+}
+
+// ScopeOverrides returns the overrides of every scope entry that has them.
+func (e *Engagement) ScopeOverrides() map[string]TargetOverrides {
+	overrides := make(map[string]TargetOverrides, len(e.overrides))
+	for target, o := range e.overrides {
+		o.Ports = append([]int(nil), o.Ports...)
+		overrides[target] = o
+	}
+	return overrides
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServer_ClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.0.2.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	trusting := NewServer(Config{TrustedProxies: proxies})
+	untrusting := NewServer(Config{})
+
+	tests := []struct {
+		name       string
+		srv        *Server
+		remoteAddr string
+		forwarded  string
+		want       string
+	}{
+		{"header ignored without trusted proxies", untrusting, "10.1.1.1:5000", "203.0.113.7", "10.1.1.1"},
+		{"header ignored from an untrusted peer", trusting, "198.51.100.9:5000", "203.0.113.7", "198.51.100.9"},
+		{"client behind a trusted proxy", trusting, "10.1.1.1:5000", "203.0.113.7", "203.0.113.7"},
+		{"spoofed entries left of the client", trusting, "10.1.1.1:5000", "1.1.1.1, 203.0.113.7", "203.0.113.7"},
+		{"chain of trusted proxies", trusting, "192.0.2.1:443", "203.0.113.7, 10.2.2.2", "203.0.113.7"},
+		{"malformed entry", trusting, "10.1.1.1:5000", "203.0.113.7, not-an-ip", "10.1.1.1"},
+		{"IPv6 client", trusting, "10.1.1.1:5000", "2001:db8::1", "2001:db8::1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/health", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			if got := tt.srv.clientIP(req); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseTrustedProxiesRejectsInvalid(t *testing.T) {
+	for _, entry := range []string{"10.0.0.0/33", "proxy.internal", "10.0.0"} {
+		if _, err := ParseTrustedProxies([]string{entry}); err == nil {
+			t.Errorf("expected %q to be rejected", entry)
+		}
+	}
+}
+
+func TestServer_RateLimitSpoofedForwardedFor(t *testing.T) {
+	srv := NewServer(Config{Health: &mockHealthService{}, RateLimit: 1, RateBurst: 1})
+	for i, forwarded := range []string{"203.0.113.1", "203.0.113.2"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/health", nil)
+		req.RemoteAddr = "198.51.100.9:5000"
+		req.Header.Set("X-Forwarded-For", forwarded)
+		rr := httptest.NewRecorder()
+		srv.ServeHTTP(rr, req)
+		if want := []int{http.StatusOK, http.StatusTooManyRequests}[i]; rr.Code != want {
+			t.Errorf("request %d: expected %d, got %d", i+1, want, rr.Code)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	// Wednesday
+	base := time.Date(2025, 6, 4, 10, 17, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{expr: "*/15 * * * *", from: base, want: time.Date(2025, 6, 4, 10, 30, 0, 0, time.UTC)},
+		{expr: "30 2 * * 1-5", from: base, want: time.Date(2025, 6, 5, 2, 30, 0, 0, time.UTC)},
+		{expr: "0 9 * * 6,7", from: base, want: time.Date(2025, 6, 7, 9, 0, 0, 0, time.UTC)},
+		{expr: "@monthly", from: base, want: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "@hourly", from: time.Date(2025, 12, 31, 23, 0, 0, 0, time.UTC), want: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", from: base, want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either may match
+		{expr: "0 0 13 * 5", from: base, want: time.Date(2025, 6, 6, 0, 0, 0, 0, time.UTC)},
+		// A stepped day of month still restricts the day
+		{expr: "0 0 */10 * *", from: base, want: time.Date(2025, 6, 11, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 31 2 *", from: base, want: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			spec, err := parseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("parseCron: %v", err)
+			}
+			if got := spec.next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCronNextUsesLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	spec, err := parseCron("0 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := spec.next(time.Date(2025, 6, 4, 8, 0, 0, 0, time.UTC).In(loc))
+	if want := time.Date(2025, 6, 5, 7, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("expected 09:00 UTC+2 (%v), got %v", want, got.UTC())
+	}
+}
+
+func TestParseCronRejectsInvalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "5-1 * * * *", "*/0 * * * *", "a * * * *", "@yearly"} {
+		if _, err := parseCron(expr); err == nil {
+			t.Errorf("expected %q to be rejected", expr)
+		}
+	}
+}
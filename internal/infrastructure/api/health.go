@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Readiness statuses.
+const (
+	StatusReady    = "ready"
+	StatusNotReady = "not_ready"
+)
+
+// Dependency statuses. A degraded dependency works but needs attention; it
+// does not make the server unready.
+const (
+	DependencyOK       = "ok"
+	DependencyDegraded = "degraded"
+	DependencyDown     = "down"
+)
+
+// dependencyTimeout bounds each dependency check.
+const dependencyTimeout = 2 * time.Second
+
+// DependencyStatus is the result of checking one dependency.
+type DependencyStatus struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	Detail    string  `json:"detail,omitempty"`
+	LatencyMS float64 `json:"latency_ms"`
+}
+
+// Readiness is the /ready payload.
+type Readiness struct {
+	Status string             `json:"status"`
+	Checks []DependencyStatus `json:"checks,omitempty"`
+}
+
+// DependencyCheck checks one dependency. It returns the status and a short
+// human-readable detail.
+type DependencyCheck struct {
+	Name  string
+	Check func(ctx context.Context) (status, detail string)
+}
+
+// CheckDependencies runs checks in order, each with its own timeout, and
+// reports the server ready unless one of them is down.
+func CheckDependencies(ctx context.Context, checks []DependencyCheck) *Readiness {
+	report := &Readiness{Status: StatusReady, Checks: make([]DependencyStatus, 0, len(checks))}
+	for _, c := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, dependencyTimeout)
+		start := time.Now()
+		status, detail := c.Check(checkCtx)
+		timedOut := checkCtx.Err() == context.DeadlineExceeded
+		cancel()
+		if timedOut && status != DependencyDown {
+			status, detail = DependencyDown, fmt.Sprintf("timed out after %s", dependencyTimeout)
+		}
+		report.Checks = append(report.Checks, DependencyStatus{
+			Name:      c.Name,
+			Status:    status,
+			Detail:    detail,
+			LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+		})
+		if status == DependencyDown {
+			report.Status = StatusNotReady
+		}
+	}
+	return report
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCheckDependencies(t *testing.T) {
+	report := CheckDependencies(context.Background(), []DependencyCheck{
+		{Name: "storage", Check: func(ctx context.Context) (string, string) { return DependencyOK, "3 engagements" }},
+		{Name: "workers", Check: func(ctx context.Context) (string, string) { return DependencyDegraded, "2 of 2 job slots in use" }},
+	})
+	if report.Status != StatusReady || len(report.Checks) != 2 || report.Checks[1].Detail != "2 of 2 job slots in use" {
+		t.Fatalf("expected a degraded dependency to leave the server ready, got %+v", report)
+	}
+
+	report = CheckDependencies(context.Background(), []DependencyCheck{
+		{Name: "storage", Check: func(ctx context.Context) (string, string) {
+			<-ctx.Done()
+			return DependencyOK, ""
+		}},
+	})
+	if report.Status != StatusNotReady || report.Checks[0].Status != DependencyDown {
+		t.Errorf("expected a check that outlives its timeout to be down, got %+v", report)
+	}
+}
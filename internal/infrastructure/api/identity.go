@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/api/middleware"
+)
+
+// HeaderUser lets a client name the person behind a request, e.g. the user
+// signed in to a frontend sharing one API key. It is recorded as claimed,
+// not verified.
+const HeaderUser = "X-Seca-User"
+
+// Identity describes who made an API request, for audit records.
+type Identity struct {
+	Workspace  string
+	KeyID      string // Fingerprint of the API key; never the key itself
+	User       string // From X-Seca-User, unverified
+	Role       Role
+	RemoteAddr string // Client address, read through trusted proxies
+	RequestID  string
+}
+
+type identityKey struct{}
+
+// withIdentity records the caller's key fingerprint and claimed user in
+// the request context. The workspace is added by withWorkspace further in.
+func (s *Server) withIdentity(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := Identity{
+			KeyID:      KeyFingerprint(r.Header.Get("X-Auth-Token")),
+			User:       strings.TrimSpace(r.Header.Get(HeaderUser)),
+			RemoteAddr: s.clientIP(r),
+		}
+		if len(id.User) > 200 {
+			id.User = id.User[:200]
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), identityKey{}, id)))
+	})
+}
+
+// IdentityFromContext returns the identity of the API request behind ctx.
+// It reports false for work the server started itself, such as scheduled
+// scans.
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	if !ok {
+		return Identity{}, false
+	}
+	id.Workspace = WorkspaceFromContext(ctx)
+	id.RequestID = middleware.GetRequestID(ctx)
+	id.Role, _ = roleFromContext(ctx)
+	return id, true
+}
+
+// Operator names the identity as an audit trail operator, e.g.
+// "alice@example.com via api/default/key-1a2b3c4d5e6f".
+func (id Identity) Operator() string {
+	who := "api/" + id.Workspace
+	if id.KeyID != "" {
+		who += "/key-" + id.KeyID
+	}
+	if id.User != "" {
+		return id.User + " via " + who
+	}
+	return who
+}
+
+// KeyFingerprint identifies an API key in logs and audit records without
+// revealing it. It returns "" for no key.
+func KeyFingerprint(key string) string {
+	if key == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:6])
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIdentityOperator(t *testing.T) {
+	tests := []struct {
+		id   Identity
+		want string
+	}{
+		{id: Identity{Workspace: "default"}, want: "api/default"},
+		{id: Identity{Workspace: "acme", KeyID: "1a2b3c4d5e6f"}, want: "api/acme/key-1a2b3c4d5e6f"},
+		{id: Identity{Workspace: "acme", KeyID: "1a2b3c4d5e6f", User: "alice"}, want: "alice via api/acme/key-1a2b3c4d5e6f"},
+	}
+	for _, tt := range tests {
+		if got := tt.id.Operator(); got != tt.want {
+			t.Errorf("expected %q, got %q", tt.want, got)
+		}
+	}
+}
+
+func TestKeyFingerprint(t *testing.T) {
+	if got := KeyFingerprint(""); got != "" {
+		t.Errorf("expected no fingerprint without a key, got %q", got)
+	}
+	if got := KeyFingerprint("secret"); len(got) != 12 || got == KeyFingerprint("other") {
+		t.Errorf("expected distinct 12-character fingerprints, got %q", got)
+	}
+	if _, ok := IdentityFromContext(context.Background()); ok {
+		t.Error("expected no identity outside a request")
+	}
+}
+
+func TestIdentityRemoteAddrBehindTrustedProxy(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.0/8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := NewServer(Config{TrustedProxies: proxies})
+
+	var got Identity
+	handler := srv.withIdentity(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = IdentityFromContext(r.Context())
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/api/jobs", nil)
+	req.RemoteAddr = "10.1.1.1:5000"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got.RemoteAddr != "203.0.113.7" {
+		t.Errorf("expected the client address behind the proxy, got %q", got.RemoteAddr)
+	}
+}
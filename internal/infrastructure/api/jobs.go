@@ -3,10 +3,27 @@ package api
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"sort"
 	"sync"
 	"time"
+
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+)
+
+// Errors recorded for jobs that were stopped before they finished.
+const (
+	JobInterrupted = "interrupted by server shutdown"
+	JobCancelled   = "cancelled"
+)
+
+var (
+	ErrJobNotFound = errors.New("job not found")
+	ErrJobFinished = errors.New("job has already finished")
 )
 
 type Job struct {
@@ -42,6 +59,8 @@ func NewJobManager() *JobManager {
 	return m
 }
 
+// CreateJob adds a pending job and returns a copy of it, so callers can
+// read it while the job is updated.
 func (m *JobManager) CreateJob(jobType, resultID string) *Job {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -53,9 +72,12 @@ func (m *JobManager) CreateJob(jobType, resultID string) *Job {
 	}
 	m.jobs[job.ID] = job
 	m.broadcast(*job)
-	return job
+	copy := *job
+	return &copy
 }
 
+// UpdateJob applies update to the job under the lock and returns a copy of
+// the result, or nil when the job does not exist.
 func (m *JobManager) UpdateJob(id string, update func(*Job)) *Job {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -65,7 +87,8 @@ func (m *JobManager) UpdateJob(id string, update func(*Job)) *Job {
 	}
 	update(job)
 	m.broadcast(*job)
-	return job
+	copy := *job
+	return &copy
 }
 
 func (m *JobManager) GetJob(id string) *Job {
@@ -206,6 +229,52 @@ func (m *JobManager) cleanupLoop() {
 	}
 }
 
+// Checkpoint writes every job to path so a restarted server can still
+// report them.
+func (m *JobManager) Checkpoint(path string) error {
+	jobs := m.ListJobs(0)
+	data, err := json.MarshalIndent(jobs, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := fileutil.WriteFile(path, data, consts.DefaultFilePerm); err != nil {
+		return fmt.Errorf("failed to checkpoint jobs: %w", err)
+	}
+	return nil
+}
+
+// Restore loads the jobs written by Checkpoint and returns how many there
+// were. Jobs that were still pending or running are recorded as
+// interrupted, since the process running them is gone. A missing file is
+// not an error.
+func (m *JobManager) Restore(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read job checkpoint: %w", err)
+	}
+	var jobs []Job
+	if err := json.Unmarshal(data, &jobs); err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range jobs {
+		job := jobs[i]
+		if job.Status == "pending" || job.Status == "running" {
+			job.Status = "error"
+			job.Error = JobInterrupted
+		}
+		if _, exists := m.jobs[job.ID]; !exists {
+			m.jobs[job.ID] = &job
+		}
+	}
+	return len(jobs), nil
+}
+
 // SetMaxJobs configures the maximum number of jobs to retain in memory
 func (m *JobManager) SetMaxJobs(max int) {
 	m.mu.Lock()
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -73,6 +74,9 @@ func TestJobManager_UpdateJob(t *testing.T) {
 	if updated.StartedAt == nil {
 		t.Error("expected StartedAt to be set")
 	}
+	if job.Status != "pending" {
+		t.Errorf("CreateJob should return a copy, but it changed to %s", job.Status)
+	}
 
 	// Update non-existent job
 	nonExistent := jm.UpdateJob("non-existent-id", func(j *Job) {
@@ -362,3 +366,31 @@ func TestJobLifecycle(t *testing.T) {
 		t.Errorf("expected final status completed, got %s", final.Status)
 	}
 }
+
+func TestJobManager_CheckpointRestore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	jm := NewJobManager()
+	done := jm.CreateJob("http", "eng-1")
+	jm.UpdateJob(done.ID, func(j *Job) { j.Status = "done" })
+	running := jm.CreateJob("http", "eng-2")
+	jm.UpdateJob(running.ID, func(j *Job) { j.Status = "running" })
+	if err := jm.Checkpoint(path); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := NewJobManager()
+	n, err := restored.Restore(path)
+	if err != nil || n != 2 {
+		t.Fatalf("expected 2 jobs restored, got %d (%v)", n, err)
+	}
+	if got := restored.GetJob(done.ID); got == nil || got.Status != "done" {
+		t.Errorf("expected the finished job to be restored unchanged, got %+v", got)
+	}
+	if got := restored.GetJob(running.ID); got == nil || got.Status != "error" || got.Error != JobInterrupted {
+		t.Errorf("expected the running job to be restored as interrupted, got %+v", got)
+	}
+
+	if n, err := NewJobManager().Restore(filepath.Join(t.TempDir(), "missing.json")); err != nil || n != 0 {
+		t.Errorf("expected a missing checkpoint to be ignored, got %d (%v)", n, err)
+	}
+}
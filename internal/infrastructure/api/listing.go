@@ -0,0 +1,168 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 500
+)
+
+// HeaderTotalCount carries the number of items matching a list request
+// before pagination.
+const HeaderTotalCount = "X-Total-Count"
+
+// engagementQuery is the pagination, sorting and filtering of
+// GET /api/v1/engagements.
+type engagementQuery struct {
+	limit  int
+	offset int
+	sort   string // "created_at" or "name"
+	desc   bool
+	owner  string
+	status string
+	search string
+}
+
+// parseEngagementQuery reads the query parameters of an engagement listing.
+// Invalid parameters are reported as a *ValidationError.
+func parseEngagementQuery(values url.Values) (engagementQuery, error) {
+	q := engagementQuery{limit: defaultPageLimit, sort: "created_at", desc: true}
+	var fields []FieldError
+	invalid := func(field, format string, args ...any) {
+		fields = append(fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if v := values.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxPageLimit {
+			invalid("limit", "must be an integer from 1 to %d", maxPageLimit)
+		}
+		q.limit = n
+	}
+	if v := values.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			invalid("offset", "must be a non-negative integer")
+		}
+		q.offset = n
+	}
+	if v := values.Get("sort"); v != "" {
+		switch v {
+		case "created_at", "name":
+			q.sort = v
+			// Names read best A-Z, dates newest first
+			q.desc = v == "created_at"
+		default:
+			invalid("sort", "must be one of [created_at name]")
+		}
+	}
+	switch v := values.Get("order"); v {
+	case "":
+	case "asc":
+		q.desc = false
+	case "desc":
+		q.desc = true
+	default:
+		invalid("order", "must be one of [asc desc]")
+	}
+	q.owner = strings.TrimSpace(values.Get("owner"))
+	q.status = strings.ToLower(strings.TrimSpace(values.Get("status")))
+	q.search = strings.ToLower(strings.TrimSpace(values.Get("q")))
+
+	if len(fields) > 0 {
+		return q, &ValidationError{Fields: fields}
+	}
+	return q, nil
+}
+
+// apply filters, sorts and pages items. It returns the page and the number
+// of items that matched the filters.
+func (q engagementQuery) apply(items []Engagement) ([]Engagement, int) {
+	matched := make([]Engagement, 0, len(items))
+	for _, e := range items {
+		if q.matches(e) {
+			matched = append(matched, e)
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		a, b := matched[i], matched[j]
+		if q.desc {
+			a, b = b, a
+		}
+		switch q.sort {
+		case "name":
+			an, bn := strings.ToLower(a.Name), strings.ToLower(b.Name)
+			if an != bn {
+				return an < bn
+			}
+		default:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+		}
+		// Break ties by ID so pages do not overlap
+		return a.ID < b.ID
+	})
+
+	total := len(matched)
+	if q.offset >= total {
+		return []Engagement{}, total
+	}
+	end := q.offset + q.limit
+	if end > total {
+		end = total
+	}
+	return matched[q.offset:end], total
+}
+
+func (q engagementQuery) matches(e Engagement) bool {
+	if q.owner != "" && !strings.EqualFold(e.Owner, q.owner) {
+		return false
+	}
+	if q.status != "" && e.Status != q.status {
+		return false
+	}
+	if q.search == "" {
+		return true
+	}
+	for _, field := range append([]string{e.ID, e.Name, e.Owner}, e.Scope...) {
+		if strings.Contains(strings.ToLower(field), q.search) {
+			return true
+		}
+	}
+	return false
+}
+
+// setPageHeaders sets X-Total-Count and a Link header with the next and
+// previous pages of the request.
+func setPageHeaders(w http.ResponseWriter, r *http.Request, offset, limit, total int) {
+	w.Header().Set(HeaderTotalCount, strconv.Itoa(total))
+	page := func(offset int, rel string) string {
+		values := r.URL.Query()
+		values.Set("offset", strconv.Itoa(offset))
+		values.Set("limit", strconv.Itoa(limit))
+		return fmt.Sprintf(`<%s?%s>; rel="%s"`, r.URL.Path, values.Encode(), rel)
+	}
+	var links []string
+	if offset+limit < total {
+		links = append(links, page(offset+limit, "next"))
+	}
+	if offset > 0 {
+		prev := offset - limit
+		if prev < 0 {
+			prev = 0
+		}
+		links = append(links, page(prev, "prev"))
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+}
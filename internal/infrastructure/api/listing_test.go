@@ -0,0 +1,101 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func listingFixture() []Engagement {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := make([]Engagement, 0, 250)
+	for i := 0; i < 250; i++ {
+		owner, status := "alice@example.com", "active"
+		if i%2 == 1 {
+			owner, status = "bob@example.com", "closed"
+		}
+		items = append(items, Engagement{
+			ID:        fmt.Sprintf("eng-%03d", i),
+			Name:      fmt.Sprintf("Audit %03d", i),
+			Owner:     owner,
+			Status:    status,
+			Scope:     []string{fmt.Sprintf("https://app%d.example.com", i)},
+			CreatedAt: base.Add(time.Duration(i) * time.Hour),
+		})
+	}
+	return items
+}
+
+func listEngagements(t *testing.T, srv *Server, query string) (*httptest.ResponseRecorder, []Engagement) {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	srv.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/engagements"+query, nil))
+	var items []Engagement
+	if rr.Code == http.StatusOK {
+		if err := json.Unmarshal(rr.Body.Bytes(), &items); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return rr, items
+}
+
+func TestEngagementListing(t *testing.T) {
+	srv := NewServer(Config{Engagements: &mockEngagementService{items: listingFixture()}})
+
+	tests := []struct {
+		name      string
+		query     string
+		wantTotal string
+		wantIDs   []string // First and last IDs of the page
+		wantCount int
+		wantLink  string
+	}{
+		{name: "default page is newest first", query: "", wantTotal: "250", wantCount: 100, wantIDs: []string{"eng-249", "eng-150"}, wantLink: `rel="next"`},
+		{name: "offset and limit", query: "?limit=10&offset=240", wantTotal: "250", wantCount: 10, wantIDs: []string{"eng-009", "eng-000"}, wantLink: `offset=230`},
+		{name: "sort by name ascending", query: "?sort=name&limit=2", wantTotal: "250", wantCount: 2, wantIDs: []string{"eng-000", "eng-001"}},
+		{name: "sort by name descending", query: "?sort=name&order=desc&limit=2", wantTotal: "250", wantCount: 2, wantIDs: []string{"eng-249", "eng-248"}},
+		{name: "owner and status filters", query: "?owner=BOB@example.com&status=closed&sort=created_at&order=asc&limit=500", wantTotal: "125", wantCount: 125, wantIDs: []string{"eng-001", "eng-249"}},
+		{name: "text search matches scope", query: "?q=APP42.example", wantTotal: "1", wantCount: 1, wantIDs: []string{"eng-042", "eng-042"}},
+		{name: "offset past the end", query: "?offset=900", wantTotal: "250", wantCount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr, items := listEngagements(t, srv, tt.query)
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+			if got := rr.Header().Get(HeaderTotalCount); got != tt.wantTotal {
+				t.Errorf("expected total %s, got %s", tt.wantTotal, got)
+			}
+			if len(items) != tt.wantCount {
+				t.Fatalf("expected %d items, got %d", tt.wantCount, len(items))
+			}
+			if tt.wantIDs != nil && (items[0].ID != tt.wantIDs[0] || items[len(items)-1].ID != tt.wantIDs[1]) {
+				t.Errorf("expected page %v, got %s..%s", tt.wantIDs, items[0].ID, items[len(items)-1].ID)
+			}
+			if tt.wantLink != "" && !strings.Contains(rr.Header().Get("Link"), tt.wantLink) {
+				t.Errorf("expected Link containing %q, got %q", tt.wantLink, rr.Header().Get("Link"))
+			}
+		})
+	}
+}
+
+func TestEngagementListingRejectsBadParams(t *testing.T) {
+	srv := NewServer(Config{Engagements: &mockEngagementService{}})
+	rr, _ := listEngagements(t, srv, "?limit=0&offset=-1&sort=owner&order=up")
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rr.Code)
+	}
+	var p Problem
+	if err := json.Unmarshal(rr.Body.Bytes(), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Type != ProblemValidation || len(p.Errors) != 4 {
+		t.Errorf("expected four field errors, got %+v", p)
+	}
+}
@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// minCompressBytes is the smallest body worth compressing.
+const minCompressBytes = 1024
+
+// Cacheable adds conditional GET and compression to a handler of JSON
+// documents. Successful GET responses are buffered and given a weak ETag of
+// their content; a request whose If-None-Match matches gets 304 Not
+// Modified. Bodies are compressed with brotli or gzip when the client
+// accepts it. Do not wrap streaming handlers.
+func Cacheable(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		buf := &bufferedResponse{header: make(http.Header), status: http.StatusOK}
+		next.ServeHTTP(buf, r)
+
+		for key, values := range buf.header {
+			w.Header()[key] = values
+		}
+		if buf.status != http.StatusOK {
+			w.WriteHeader(buf.status)
+			_, _ = w.Write(buf.body.Bytes())
+			return
+		}
+
+		body := buf.body.Bytes()
+		sum := sha256.Sum256(body)
+		etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+		w.Header().Set("ETag", etag)
+		// Authenticated data: browsers may keep it but must revalidate
+		w.Header().Set("Cache-Control", "private, no-cache")
+		w.Header().Add("Vary", "Accept-Encoding")
+		if etagMatches(r.Header.Get("If-None-Match"), etag) {
+			w.Header().Del("Content-Length")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		encoding := ""
+		if len(body) >= minCompressBytes {
+			encoding = negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		}
+		if encoding != "" {
+			var compressed bytes.Buffer
+			if err := compress(&compressed, encoding, body); err == nil {
+				body = compressed.Bytes()
+				w.Header().Set("Content-Encoding", encoding)
+			}
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(body)
+		}
+	})
+}
+
+func compress(dst *bytes.Buffer, encoding string, body []byte) error {
+	switch encoding {
+	case "br":
+		bw := brotli.NewWriterLevel(dst, 5)
+		if _, err := bw.Write(body); err != nil {
+			return err
+		}
+		return bw.Close()
+	default:
+		gw := gzip.NewWriter(dst)
+		if _, err := gw.Write(body); err != nil {
+			return err
+		}
+		return gw.Close()
+	}
+}
+
+// negotiateEncoding picks "br" or "gzip" from an Accept-Encoding header,
+// preferring brotli at equal quality. It returns "" for identity.
+func negotiateEncoding(header string) string {
+	quality := map[string]float64{}
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		quality[name] = q
+	}
+	best, bestQ := "", 0.0
+	for _, name := range []string{"br", "gzip"} {
+		q, ok := quality[name]
+		if !ok {
+			q, ok = quality["*"]
+		}
+		if ok && q > bestQ {
+			best, bestQ = name, q
+		}
+	}
+	return best
+}
+
+// etagMatches reports whether an If-None-Match header lists etag. ETags are
+// compared weakly, as RFC 9110 requires for If-None-Match.
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedResponse collects a handler's response so it can be hashed and
+// compressed before it is sent.
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+	wrote  bool
+}
+
+func (b *bufferedResponse) Header() http.Header { return b.header }
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	if !b.wrote {
+		b.status = status
+		b.wrote = true
+	}
+}
+
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	b.wrote = true
+	return b.body.Write(p)
+}
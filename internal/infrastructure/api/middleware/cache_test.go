@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+func TestCacheable(t *testing.T) {
+	doc := `{"results":[` + strings.Repeat(`{"target":"https://app.example.com","status":"ok"},`, 200) + `{}]}`
+	handler := Cacheable(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, doc)
+	}))
+
+	serve := func(headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/results/eng-1", nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("gzip", func(t *testing.T) {
+		rec := serve(map[string]string{"Accept-Encoding": "gzip, deflate"})
+		if rec.Header().Get("Content-Encoding") != "gzip" {
+			t.Fatalf("expected gzip, got %q", rec.Header().Get("Content-Encoding"))
+		}
+		zr, err := gzip.NewReader(rec.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if body, _ := io.ReadAll(zr); string(body) != doc {
+			t.Error("gzip body does not round-trip")
+		}
+	})
+
+	t.Run("brotli preferred", func(t *testing.T) {
+		rec := serve(map[string]string{"Accept-Encoding": "gzip, br"})
+		if rec.Header().Get("Content-Encoding") != "br" {
+			t.Fatalf("expected br, got %q", rec.Header().Get("Content-Encoding"))
+		}
+		if rec.Body.Len() >= len(doc) {
+			t.Errorf("expected a smaller body, got %d bytes for %d", rec.Body.Len(), len(doc))
+		}
+		if body, _ := io.ReadAll(brotli.NewReader(rec.Body)); string(body) != doc {
+			t.Error("brotli body does not round-trip")
+		}
+	})
+
+	t.Run("identity", func(t *testing.T) {
+		rec := serve(map[string]string{"Accept-Encoding": "br;q=0, gzip;q=0"})
+		if rec.Header().Get("Content-Encoding") != "" || rec.Body.String() != doc {
+			t.Errorf("expected an uncompressed body, got encoding %q", rec.Header().Get("Content-Encoding"))
+		}
+	})
+
+	t.Run("conditional GET", func(t *testing.T) {
+		first := serve(map[string]string{"Accept-Encoding": "gzip"})
+		etag := first.Header().Get("ETag")
+		if etag == "" {
+			t.Fatal("expected an ETag")
+		}
+		// The ETag describes the content, whatever the encoding
+		if got := serve(nil).Header().Get("ETag"); got != etag {
+			t.Errorf("expected the same ETag without compression, got %s and %s", etag, got)
+		}
+		rec := serve(map[string]string{"If-None-Match": `"other", ` + etag})
+		if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+			t.Errorf("expected an empty 304, got %d with %d bytes", rec.Code, rec.Body.Len())
+		}
+		if rec := serve(map[string]string{"If-None-Match": `"other"`}); rec.Code != http.StatusOK {
+			t.Errorf("expected 200 for a stale ETag, got %d", rec.Code)
+		}
+	})
+}
+
+func TestCacheablePassesErrorsThrough(t *testing.T) {
+	handler := Cacheable(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"error":"not found"}`)
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/results/missing", nil)
+	req.Header.Set("If-None-Match", "*")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound || rec.Header().Get("ETag") != "" {
+		t.Errorf("expected the 404 unchanged, got %d with ETag %q", rec.Code, rec.Header().Get("ETag"))
+	}
+}
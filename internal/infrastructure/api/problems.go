@@ -0,0 +1,109 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/api/middleware"
+)
+
+// Problem types returned in the "type" member of error responses. Other
+// errors use "about:blank" with the HTTP status text as the title.
+const (
+	ProblemValidation    = "urn:seca:problem:validation-error"
+	ProblemMalformedBody = "urn:seca:problem:malformed-body"
+	ProblemBodyTooLarge  = "urn:seca:problem:body-too-large"
+)
+
+const problemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details response.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	// Error repeats Detail for clients written against the earlier
+	// {"error": "..."} body.
+	Error string `json:"error"`
+}
+
+// FieldError is one invalid field of a request body. Field is the path of the
+// field, e.g. "name" or "scope[2]".
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError reports request fields that failed validation. Services
+// may return it so the response names the offending fields.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		parts = append(parts, f.Field+": "+f.Message)
+	}
+	return "invalid request: " + strings.Join(parts, "; ")
+}
+
+// NewFieldError returns a ValidationError for a single field.
+func NewFieldError(field, format string, args ...any) *ValidationError {
+	return &ValidationError{Fields: []FieldError{{Field: field, Message: fmt.Sprintf(format, args...)}}}
+}
+
+// malformedBodyError is a body that is not JSON of the expected shape.
+type malformedBodyError struct {
+	err error
+}
+
+func (e *malformedBodyError) Error() string {
+	return "malformed JSON body: " + e.err.Error()
+}
+
+func (e *malformedBodyError) Unwrap() error { return e.err }
+
+// newProblem describes err as a problem with the given status.
+func newProblem(r *http.Request, status int, err error) Problem {
+	p := Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    err.Error(),
+		Instance:  r.URL.Path,
+		RequestID: middleware.GetRequestID(r.Context()),
+	}
+	var validation *ValidationError
+	var malformed *malformedBodyError
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &validation):
+		p.Type = ProblemValidation
+		p.Title = "Request validation failed"
+		p.Errors = validation.Fields
+	case errors.As(err, &tooLarge):
+		p.Type = ProblemBodyTooLarge
+		p.Status = http.StatusRequestEntityTooLarge
+		p.Title = http.StatusText(p.Status)
+		p.Detail = fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit)
+	case errors.As(err, &malformed):
+		p.Type = ProblemMalformedBody
+		p.Title = "Malformed request body"
+	}
+	p.Error = p.Detail
+	return p
+}
+
+func writeProblem(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Role is what an API key may do. Each role may do everything the roles
+// before it may.
+type Role string
+
+const (
+	// RoleViewer reads engagements, results, jobs and schedules.
+	RoleViewer Role = "viewer"
+	// RoleOperator also starts and cancels jobs and manages schedules.
+	RoleOperator Role = "operator"
+	// RoleAdmin also creates engagements and deletes schedules.
+	RoleAdmin Role = "admin"
+)
+
+var roleRanks = map[Role]int{RoleViewer: 1, RoleOperator: 2, RoleAdmin: 3}
+
+// ParseRole returns the role named s.
+func ParseRole(s string) (Role, error) {
+	role := Role(strings.ToLower(strings.TrimSpace(s)))
+	if _, ok := roleRanks[role]; !ok {
+		return "", fmt.Errorf("unknown role %q (expected viewer, operator or admin)", s)
+	}
+	return role, nil
+}
+
+// Allows reports whether the role may do what required may.
+func (r Role) Allows(required Role) bool {
+	return roleRanks[r] >= roleRanks[required]
+}
+
+// APIKey is an additional key for a workspace, limited to Role. The
+// workspace's own key, and Config.AuthToken, act as admin.
+type APIKey struct {
+	Name string // Shown in logs, never the key itself
+	Key  string
+	Role Role
+}
+
+// endpointRoles lists the role needed for each change an endpoint makes,
+// keyed by method and route relative to /api or /api/v1. Reads need
+// RoleViewer; changes not listed here need RoleAdmin.
+var endpointRoles = map[string]Role{
+	"POST /engagements":  RoleAdmin,
+	"POST /jobs":         RoleOperator,
+	"DELETE /jobs/":      RoleOperator,
+	"POST /schedules":    RoleOperator,
+	"PUT /schedules/":    RoleOperator,
+	"DELETE /schedules/": RoleAdmin,
+}
+
+// requiredRole returns the least role allowed to make r.
+func requiredRole(r *http.Request) Role {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return RoleViewer
+	}
+	// "/api/v1/jobs/job_1" is the route "/jobs/"
+	route := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/api"), "/v1")
+	if i := strings.Index(strings.TrimPrefix(route, "/"), "/"); i >= 0 {
+		route = route[:i+2]
+	}
+	if role, ok := endpointRoles[r.Method+" "+route]; ok {
+		return role
+	}
+	return RoleAdmin
+}
+
+type roleKey struct{}
+
+// roleFromContext returns the role of the key that authenticated the
+// request, once withWorkspace or withAuth has matched it.
+func roleFromContext(ctx context.Context) (Role, bool) {
+	role, ok := ctx.Value(roleKey{}).(Role)
+	return role, ok
+}
+
+// matchKey returns the key among keys equal to token, or false if none is.
+// Every key is compared so the time taken does not reveal which matched.
+func matchKey(token string, keys []APIKey) (APIKey, bool) {
+	var match APIKey
+	found := false
+	for _, k := range keys {
+		if token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(k.Key)) == 1 {
+			match, found = k, true
+		}
+	}
+	return match, found
+}
+
+// authorize answers 403, and logs the denial, unless role may make r.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request, role Role) bool {
+	required := requiredRole(r)
+	if role.Allows(required) {
+		return true
+	}
+	s.requestLogger(r).Warn("access_denied",
+		zap.String("workspace", WorkspaceFromContext(r.Context())),
+		zap.String("key_id", KeyFingerprint(r.Header.Get("X-Auth-Token"))),
+		zap.String("role", string(role)),
+		zap.String("required_role", string(required)),
+		zap.String("client_ip", s.clientIP(r)),
+	)
+	s.writeError(w, r, http.StatusForbidden, fmt.Errorf("role %s may not %s %s (requires %s)", role, r.Method, r.URL.Path, required))
+	return false
+}
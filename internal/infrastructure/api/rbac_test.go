@@ -0,0 +1,73 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequiredRole(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         Role
+	}{
+		{http.MethodGet, "/api/v1/engagements", RoleViewer},
+		{http.MethodGet, "/api/jobs-stream", RoleViewer},
+		{http.MethodPost, "/api/v1/jobs", RoleOperator},
+		{http.MethodDelete, "/api/v1/jobs/job_1", RoleOperator},
+		{http.MethodPut, "/api/schedules/sch_1", RoleOperator},
+		{http.MethodDelete, "/api/v1/schedules/sch_1", RoleAdmin},
+		{http.MethodPost, "/api/engagements", RoleAdmin},
+		{http.MethodPatch, "/api/v1/jobs/job_1", RoleAdmin},
+	}
+	for _, tt := range tests {
+		if got := requiredRole(httptest.NewRequest(tt.method, tt.path, nil)); got != tt.want {
+			t.Errorf("%s %s: expected %s, got %s", tt.method, tt.path, tt.want, got)
+		}
+	}
+}
+
+func TestServer_RoleKeys(t *testing.T) {
+	engagement := `{"name":"Audit","owner":"ops","roe":"Standard ROE","roe_agree":true}`
+	srv := NewServer(Config{
+		AuthToken:   "admin-token",
+		APIKeys:     []APIKey{{Name: "dashboard", Key: "viewer-token", Role: RoleViewer}, {Name: "ci", Key: "operator-token", Role: RoleOperator}},
+		Engagements: &mockEngagementService{},
+		Jobs:        &mockJobService{},
+		Workspaces: []Workspace{{
+			Name: "team-a", APIKey: "key-a", Keys: []APIKey{{Name: "auditor", Key: "key-a-viewer", Role: RoleViewer}},
+			Engagements: &mockEngagementService{}, Jobs: &mockJobService{},
+		}},
+	})
+
+	tests := []struct {
+		name       string
+		token      string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{"viewer reads", "viewer-token", http.MethodGet, "/api/v1/engagements", "", http.StatusOK},
+		{"viewer may not start jobs", "viewer-token", http.MethodPost, "/api/v1/jobs", `{"engagement_id":"eng-1"}`, http.StatusForbidden},
+		{"operator starts jobs", "operator-token", http.MethodPost, "/api/v1/jobs", `{"engagement_id":"eng-1"}`, http.StatusAccepted},
+		{"operator may not create engagements", "operator-token", http.MethodPost, "/api/v1/engagements", engagement, http.StatusForbidden},
+		// The mock cannot create engagements: 400 means the request was authorized
+		{"auth token is admin", "admin-token", http.MethodPost, "/api/v1/engagements", engagement, http.StatusBadRequest},
+		{"unknown key", "other-token", http.MethodGet, "/api/v1/engagements", "", http.StatusUnauthorized},
+		{"workspace viewer key", "key-a-viewer", http.MethodPost, "/api/v1/jobs", `{"engagement_id":"eng-1"}`, http.StatusForbidden},
+		{"workspace key is admin", "key-a", http.MethodPost, "/api/v1/engagements", engagement, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("X-Auth-Token", tt.token)
+			rr := httptest.NewRecorder()
+			srv.ServeHTTP(rr, req)
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected %d, got %d: %s", tt.wantStatus, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
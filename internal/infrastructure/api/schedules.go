@@ -0,0 +1,554 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+	"go.uber.org/zap"
+)
+
+// Outcomes recorded in Schedule.LastStatus for each occurrence.
+const (
+	ScheduleStarted         = "started"
+	ScheduleFailed          = "failed"           // The job could not be started
+	ScheduleSkippedBlackout = "skipped_blackout" // The occurrence fell in a blackout window
+	ScheduleSkippedRunning  = "skipped_running"  // The previous run was still going
+)
+
+// Statuses of calendar entries.
+const (
+	CalendarScheduled = "scheduled"
+	CalendarBlackout  = "blackout"
+)
+
+const (
+	defaultScheduleConcurrency = 2
+	defaultScheduleInterval    = 15 * time.Second
+	maxCalendarSpan            = 31 * 24 * time.Hour
+	maxCalendarRunsPerSchedule = 1000
+)
+
+// ErrScheduleNotFound is returned for an unknown schedule ID.
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+// Blackout is a window in which a schedule does not start scans, such as a
+// change freeze agreed with the client.
+type Blackout struct {
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// Schedule runs a scan of an engagement on a cron schedule.
+type Schedule struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	EngagementID string     `json:"engagement_id"`
+	Type         string     `json:"type"`
+	Cron         string     `json:"cron"`
+	Timezone     string     `json:"timezone"`
+	Enabled      bool       `json:"enabled"`
+	Blackouts    []Blackout `json:"blackouts,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	NextRunAt    *time.Time `json:"next_run_at,omitempty"`
+	LastRunAt    *time.Time `json:"last_run_at,omitempty"`
+	LastJobID    string     `json:"last_job_id,omitempty"`
+	LastStatus   string     `json:"last_status,omitempty"`
+	LastError    string     `json:"last_error,omitempty"`
+}
+
+// ScheduleRequest creates a schedule or replaces one with PUT.
+type ScheduleRequest struct {
+	Name         string     `json:"name"`
+	EngagementID string     `json:"engagement_id"`
+	Type         string     `json:"type"`
+	Cron         string     `json:"cron"`
+	Timezone     string     `json:"timezone"`
+	Enabled      *bool      `json:"enabled"` // Defaults to true
+	Blackouts    []Blackout `json:"blackouts"`
+}
+
+// ScheduledRun is one upcoming occurrence of a schedule.
+type ScheduledRun struct {
+	ScheduleID   string    `json:"schedule_id"`
+	Name         string    `json:"name"`
+	EngagementID string    `json:"engagement_id"`
+	At           time.Time `json:"at"`
+	Status       string    `json:"status"`
+	Reason       string    `json:"reason,omitempty"`
+}
+
+// SchedulerConfig captures the scheduler's dependencies and limits.
+type SchedulerConfig struct {
+	Path          string            // JSON file the schedules are kept in
+	Jobs          JobService        // Starts the scans, as POST /jobs does
+	Engagements   EngagementService // Checks engagement IDs (optional)
+	MaxConcurrent int               // Scans running at once before due runs wait (default 2)
+	Interval      time.Duration     // How often due schedules are checked (default 15s)
+	Logger        *zap.Logger
+}
+
+// Scheduler starts scans when their schedules fall due. Occurrences inside
+// a blackout window, or while the schedule's previous scan is still
+// running, are skipped. When MaxConcurrent jobs are already running, due
+// scans wait for a free slot. Runs missed while the server was down are
+// not caught up.
+type Scheduler struct {
+	cfg    SchedulerConfig
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	running  atomic.Bool
+	lastTick atomic.Int64 // Unix nanoseconds of the last completed check
+
+	mu        sync.Mutex
+	schedules map[string]*Schedule
+}
+
+// NewScheduler loads the schedules in cfg.Path, applying the defaults to
+// unset fields. Call Start to begin running them.
+func NewScheduler(cfg SchedulerConfig) (*Scheduler, error) {
+	if cfg.Jobs == nil {
+		return nil, errors.New("scheduler requires a job service")
+	}
+	if cfg.MaxConcurrent <= 0 {
+		cfg.MaxConcurrent = defaultScheduleConcurrency
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultScheduleInterval
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = zap.NewNop()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Scheduler{cfg: cfg, ctx: ctx, cancel: cancel, schedules: make(map[string]*Schedule)}
+	if err := s.load(time.Now()); err != nil {
+		cancel()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Start checks for due schedules every Interval until Close is called.
+func (s *Scheduler) Start() {
+	s.running.Store(true)
+	s.lastTick.Store(time.Now().UnixNano())
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer s.running.Store(false)
+		ticker := time.NewTicker(s.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				s.tick(now)
+				s.lastTick.Store(time.Now().UnixNano())
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Close stops the scheduler. Scans already started keep running.
+func (s *Scheduler) Close() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// Check reports an error when the scheduler is not running or has not
+// completed a check of its schedules for three intervals, e.g. because a
+// job service call is hanging.
+func (s *Scheduler) Check() error {
+	if !s.running.Load() {
+		return errors.New("scheduler is not running")
+	}
+	since := time.Since(time.Unix(0, s.lastTick.Load()))
+	if since > 3*s.cfg.Interval {
+		return fmt.Errorf("scheduler has not run for %s", since.Round(time.Second))
+	}
+	return nil
+}
+
+// MaxConcurrent returns the number of jobs that may run at once before due
+// schedules wait.
+func (s *Scheduler) MaxConcurrent() int {
+	return s.cfg.MaxConcurrent
+}
+
+func (s *Scheduler) ListSchedules(ctx context.Context) ([]Schedule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := make([]Schedule, 0, len(s.schedules))
+	for _, sc := range s.schedules {
+		items = append(items, *sc)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].CreatedAt.Equal(items[j].CreatedAt) {
+			return items[i].CreatedAt.Before(items[j].CreatedAt)
+		}
+		return items[i].ID < items[j].ID
+	})
+	return items, nil
+}
+
+func (s *Scheduler) GetSchedule(ctx context.Context, id string) (*Schedule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sc, ok := s.schedules[id]
+	if !ok {
+		return nil, ErrScheduleNotFound
+	}
+	copy := *sc
+	return &copy, nil
+}
+
+func (s *Scheduler) CreateSchedule(ctx context.Context, req ScheduleRequest) (*Schedule, error) {
+	if err := s.validate(ctx, &req); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	sc := &Schedule{ID: generateID("sch"), CreatedAt: now}
+	sc.apply(req, now)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.schedules[sc.ID] = sc
+	if err := s.save(); err != nil {
+		delete(s.schedules, sc.ID)
+		return nil, err
+	}
+	copy := *sc
+	return &copy, nil
+}
+
+func (s *Scheduler) UpdateSchedule(ctx context.Context, id string, req ScheduleRequest) (*Schedule, error) {
+	s.mu.Lock()
+	_, ok := s.schedules[id]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrScheduleNotFound
+	}
+	if err := s.validate(ctx, &req); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sc, ok := s.schedules[id]
+	if !ok {
+		return nil, ErrScheduleNotFound
+	}
+	previous := *sc
+	sc.apply(req, time.Now().UTC())
+	if err := s.save(); err != nil {
+		*sc = previous
+		return nil, err
+	}
+	copy := *sc
+	return &copy, nil
+}
+
+func (s *Scheduler) DeleteSchedule(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sc, ok := s.schedules[id]
+	if !ok {
+		return ErrScheduleNotFound
+	}
+	delete(s.schedules, id)
+	if err := s.save(); err != nil {
+		s.schedules[id] = sc
+		return err
+	}
+	return nil
+}
+
+// Calendar lists the occurrences of enabled schedules from from up to to,
+// earliest first. Occurrences in a blackout window are listed with status
+// "blackout".
+func (s *Scheduler) Calendar(ctx context.Context, from, to time.Time) ([]ScheduledRun, error) {
+	if !to.After(from) {
+		return nil, NewFieldError("to", "must be after from")
+	}
+	if to.Sub(from) > maxCalendarSpan {
+		return nil, NewFieldError("to", "must be within 31 days of from")
+	}
+	items, _ := s.ListSchedules(ctx)
+
+	runs := []ScheduledRun{}
+	for _, sc := range items {
+		if !sc.Enabled {
+			continue
+		}
+		spec, loc, err := sc.spec()
+		if err != nil {
+			continue
+		}
+		// next returns times after its argument; step back so an
+		// occurrence exactly at from is included
+		t := from.In(loc).Add(-time.Minute)
+		for n := 0; n < maxCalendarRunsPerSchedule; n++ {
+			t = spec.next(t)
+			if t.IsZero() || !t.Before(to) {
+				break
+			}
+			if t.Before(from) {
+				continue
+			}
+			run := ScheduledRun{ScheduleID: sc.ID, Name: sc.Name, EngagementID: sc.EngagementID, At: t, Status: CalendarScheduled}
+			if b := blackoutAt(sc.Blackouts, t); b != nil {
+				run.Status = CalendarBlackout
+				run.Reason = b.Reason
+			}
+			runs = append(runs, run)
+		}
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		if !runs[i].At.Equal(runs[j].At) {
+			return runs[i].At.Before(runs[j].At)
+		}
+		return runs[i].ScheduleID < runs[j].ScheduleID
+	})
+	return runs, nil
+}
+
+// tick starts the schedules due at now, earliest first.
+func (s *Scheduler) tick(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []*Schedule
+	for _, sc := range s.schedules {
+		if sc.Enabled && sc.NextRunAt != nil && !sc.NextRunAt.After(now) {
+			due = append(due, sc)
+		}
+	}
+	if len(due) == 0 {
+		return
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].NextRunAt.Equal(*due[j].NextRunAt) {
+			return due[i].NextRunAt.Before(*due[j].NextRunAt)
+		}
+		// Older schedules first
+		if !due[i].CreatedAt.Equal(due[j].CreatedAt) {
+			return due[i].CreatedAt.Before(due[j].CreatedAt)
+		}
+		return due[i].ID < due[j].ID
+	})
+
+	running := s.runningJobs()
+	changed := false
+	for _, sc := range due {
+		occurrence := *sc.NextRunAt
+		logger := s.cfg.Logger.With(zap.String("schedule_id", sc.ID), zap.Time("occurrence", occurrence))
+		switch {
+		case blackoutAt(sc.Blackouts, occurrence) != nil:
+			sc.record(now, ScheduleSkippedBlackout, "", "")
+			logger.Info("schedule_skipped_blackout")
+		case sc.LastJobID != "" && running[sc.LastJobID]:
+			sc.record(now, ScheduleSkippedRunning, "", "")
+			logger.Info("schedule_skipped_running", zap.String("job_id", sc.LastJobID))
+		case len(running) >= s.cfg.MaxConcurrent:
+			// Leave it due; it starts when a slot frees up
+			continue
+		default:
+			job, err := s.cfg.Jobs.StartJob(s.ctx, JobRequest{Type: sc.Type, EngagementID: sc.EngagementID})
+			if err != nil {
+				sc.record(now, ScheduleFailed, "", err.Error())
+				logger.Warn("schedule_start_failed", zap.Error(err))
+				break
+			}
+			running[job.ID] = true
+			sc.record(now, ScheduleStarted, job.ID, "")
+			logger.Info("schedule_started", zap.String("job_id", job.ID))
+		}
+		sc.advance(now)
+		changed = true
+	}
+	if changed {
+		if err := s.save(); err != nil {
+			s.cfg.Logger.Error("schedule_save_failed", zap.Error(err))
+		}
+	}
+}
+
+// runningJobs returns the IDs of jobs that have not finished.
+func (s *Scheduler) runningJobs() map[string]bool {
+	running := map[string]bool{}
+	jobs, err := s.cfg.Jobs.ListJobs(s.ctx, 0)
+	if err != nil {
+		s.cfg.Logger.Warn("schedule_jobs_unavailable", zap.Error(err))
+		return running
+	}
+	for _, job := range jobs {
+		if job.Status == "pending" || job.Status == "running" {
+			running[job.ID] = true
+		}
+	}
+	return running
+}
+
+// validate normalizes req and checks what the schema cannot.
+func (s *Scheduler) validate(ctx context.Context, req *ScheduleRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Cron = strings.TrimSpace(req.Cron)
+	req.Timezone = strings.TrimSpace(req.Timezone)
+	req.Type = strings.ToLower(strings.TrimSpace(req.Type))
+	if req.Type == "" {
+		req.Type = "http"
+	}
+	if req.Timezone == "" {
+		req.Timezone = "UTC"
+	}
+
+	var fields []FieldError
+	if req.Name == "" {
+		fields = append(fields, FieldError{Field: "name", Message: "is required"})
+	}
+	if req.Type != "http" {
+		fields = append(fields, FieldError{Field: "type", Message: fmt.Sprintf("unsupported job type %q", req.Type)})
+	}
+	spec, err := parseCron(req.Cron)
+	if err != nil {
+		fields = append(fields, FieldError{Field: "cron", Message: err.Error()})
+	}
+	loc, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		fields = append(fields, FieldError{Field: "timezone", Message: fmt.Sprintf("unknown time zone %q", req.Timezone)})
+	}
+	if spec != nil && loc != nil && spec.next(time.Now().In(loc)).IsZero() {
+		fields = append(fields, FieldError{Field: "cron", Message: "never matches a date"})
+	}
+	for i, b := range req.Blackouts {
+		if !b.End.After(b.Start) {
+			fields = append(fields, FieldError{Field: fmt.Sprintf("blackouts[%d].end", i), Message: "must be after start"})
+		}
+	}
+	if s.cfg.Engagements != nil && req.EngagementID != "" {
+		if _, err := s.cfg.Engagements.GetEngagement(ctx, req.EngagementID); err != nil {
+			fields = append(fields, FieldError{Field: "engagement_id", Message: fmt.Sprintf("engagement %s not found", req.EngagementID)})
+		}
+	}
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+func (s *Scheduler) load(now time.Time) error {
+	data, err := os.ReadFile(s.cfg.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read schedules: %w", err)
+	}
+	var items []*Schedule
+	if err := json.Unmarshal(data, &items); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", s.cfg.Path, err)
+	}
+	for _, sc := range items {
+		// Drop occurrences missed while the server was down
+		if sc.NextRunAt == nil || sc.NextRunAt.Before(now) {
+			sc.advance(now)
+		}
+		s.schedules[sc.ID] = sc
+	}
+	return nil
+}
+
+// save writes the schedules to cfg.Path. Callers hold s.mu.
+func (s *Scheduler) save() error {
+	items := make([]*Schedule, 0, len(s.schedules))
+	for _, sc := range s.schedules {
+		items = append(items, sc)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := fileutil.WriteFile(s.cfg.Path, data, consts.DefaultFilePerm); err != nil {
+		return fmt.Errorf("failed to save schedules: %w", err)
+	}
+	return nil
+}
+
+// apply copies a validated request into sc and reschedules it.
+func (sc *Schedule) apply(req ScheduleRequest, now time.Time) {
+	sc.Name = req.Name
+	sc.EngagementID = req.EngagementID
+	sc.Type = req.Type
+	sc.Cron = req.Cron
+	sc.Timezone = req.Timezone
+	sc.Enabled = req.Enabled == nil || *req.Enabled
+	sc.Blackouts = req.Blackouts
+	sc.UpdatedAt = now
+	sc.advance(now)
+}
+
+// advance sets NextRunAt to the first occurrence after now, or clears it
+// when the schedule is disabled.
+func (sc *Schedule) advance(now time.Time) {
+	sc.NextRunAt = nil
+	if !sc.Enabled {
+		return
+	}
+	spec, loc, err := sc.spec()
+	if err != nil {
+		return
+	}
+	if next := spec.next(now.In(loc)); !next.IsZero() {
+		next = next.UTC()
+		sc.NextRunAt = &next
+	}
+}
+
+// record notes the outcome of an occurrence. LastJobID keeps the last job
+// started, so a later occurrence can tell whether it is still running.
+func (sc *Schedule) record(now time.Time, status, jobID, errMsg string) {
+	at := now.UTC()
+	sc.LastRunAt = &at
+	sc.LastStatus = status
+	sc.LastError = errMsg
+	if jobID != "" {
+		sc.LastJobID = jobID
+	}
+}
+
+func (sc *Schedule) spec() (*cronSpec, *time.Location, error) {
+	spec, err := parseCron(sc.Cron)
+	if err != nil {
+		return nil, nil, err
+	}
+	loc, err := time.LoadLocation(sc.Timezone)
+	if err != nil {
+		return nil, nil, err
+	}
+	return spec, loc, nil
+}
+
+// blackoutAt returns the blackout window containing t, if any.
+func blackoutAt(blackouts []Blackout, t time.Time) *Blackout {
+	for i := range blackouts {
+		if !t.Before(blackouts[i].Start) && t.Before(blackouts[i].End) {
+			return &blackouts[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeJobs starts jobs that stay running until finish is called.
+type fakeJobs struct {
+	mu   sync.Mutex
+	jobs []*Job
+}
+
+func (f *fakeJobs) StartJob(ctx context.Context, req JobRequest) (*Job, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	job := &Job{ID: fmt.Sprintf("job_%d", len(f.jobs)+1), Type: req.Type, Status: "running", ResultID: req.EngagementID}
+	f.jobs = append(f.jobs, job)
+	return job, nil
+}
+
+func (f *fakeJobs) GetJob(ctx context.Context, id string) (*Job, error) { return nil, nil }
+
+func (f *fakeJobs) ListJobs(ctx context.Context, limit int) ([]Job, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	jobs := make([]Job, 0, len(f.jobs))
+	for _, job := range f.jobs {
+		jobs = append(jobs, *job)
+	}
+	return jobs, nil
+}
+
+func (f *fakeJobs) CancelJob(ctx context.Context, id string) (*Job, error) {
+	return nil, ErrJobNotFound
+}
+
+func (f *fakeJobs) Subscribe() (chan Job, func()) { return make(chan Job), func() {} }
+
+func (f *fakeJobs) finish(id string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, job := range f.jobs {
+		if job.ID == id {
+			job.Status = "done"
+		}
+	}
+}
+
+func (f *fakeJobs) started() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.jobs)
+}
+
+func newTestScheduler(t *testing.T, jobs JobService, maxConcurrent int) *Scheduler {
+	t.Helper()
+	s, err := NewScheduler(SchedulerConfig{
+		Path:          filepath.Join(t.TempDir(), "schedules.json"),
+		Jobs:          jobs,
+		Engagements:   &mockEngagementService{items: []Engagement{{ID: "eng-1"}, {ID: "eng-2"}}},
+		MaxConcurrent: maxConcurrent,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func mustCreateSchedule(t *testing.T, s *Scheduler, req ScheduleRequest) *Schedule {
+	t.Helper()
+	sc, err := s.CreateSchedule(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sc
+}
+
+func TestSchedulerRunsDueSchedules(t *testing.T) {
+	jobs := &fakeJobs{}
+	s := newTestScheduler(t, jobs, 1)
+	ctx := context.Background()
+
+	first := mustCreateSchedule(t, s, ScheduleRequest{Name: "every minute", EngagementID: "eng-1", Cron: "* * * * *"})
+	second := mustCreateSchedule(t, s, ScheduleRequest{Name: "also every minute", EngagementID: "eng-2", Cron: "* * * * *"})
+	due := *second.NextRunAt // Not before the first, should a minute pass between them
+
+	// Only one slot: the second schedule waits
+	s.tick(due)
+	if jobs.started() != 1 {
+		t.Fatalf("expected 1 job, got %d", jobs.started())
+	}
+	got, _ := s.GetSchedule(ctx, first.ID)
+	if got.LastStatus != ScheduleStarted || got.LastJobID != "job_1" || !got.NextRunAt.After(due) {
+		t.Fatalf("expected the first schedule to start job_1 and advance, got %+v", got)
+	}
+	if got, _ := s.GetSchedule(ctx, second.ID); got.LastStatus != "" || !got.NextRunAt.Equal(due) {
+		t.Fatalf("expected the second schedule to stay due, got %+v", got)
+	}
+
+	// The first schedule's job is still running at its next occurrence
+	s.tick(due.Add(time.Minute))
+	if got, _ := s.GetSchedule(ctx, first.ID); got.LastStatus != ScheduleSkippedRunning || got.LastJobID != "job_1" {
+		t.Fatalf("expected an overlapping run to be skipped, got %+v", got)
+	}
+
+	jobs.finish("job_1")
+	s.tick(due.Add(time.Minute + time.Second))
+	if got, _ := s.GetSchedule(ctx, second.ID); got.LastStatus != ScheduleStarted || got.LastJobID != "job_2" {
+		t.Fatalf("expected the waiting schedule to start once a slot freed, got %+v", got)
+	}
+}
+
+func TestSchedulerSkipsBlackouts(t *testing.T) {
+	jobs := &fakeJobs{}
+	s := newTestScheduler(t, jobs, 2)
+	now := time.Now().UTC()
+	sc := mustCreateSchedule(t, s, ScheduleRequest{
+		Name: "hourly", EngagementID: "eng-1", Cron: "@hourly",
+		Blackouts: []Blackout{{Start: now, End: now.Add(2 * time.Hour), Reason: "change freeze"}},
+	})
+
+	s.tick(*sc.NextRunAt)
+	got, _ := s.GetSchedule(context.Background(), sc.ID)
+	if jobs.started() != 0 || got.LastStatus != ScheduleSkippedBlackout {
+		t.Fatalf("expected the occurrence to be skipped, got %d jobs and %+v", jobs.started(), got)
+	}
+
+	runs, err := s.Calendar(context.Background(), now, now.Add(3*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 3 || runs[0].Status != CalendarBlackout || runs[0].Reason != "change freeze" || runs[2].Status != CalendarScheduled {
+		t.Errorf("unexpected calendar: %+v", runs)
+	}
+}
+
+func TestSchedulerPersistsSchedules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedules.json")
+	cfg := SchedulerConfig{Path: path, Jobs: &fakeJobs{}}
+	s, err := NewScheduler(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	disabled := false
+	sc := mustCreateSchedule(t, s, ScheduleRequest{Name: "weekly", EngagementID: "eng-1", Cron: "@weekly", Timezone: "UTC", Enabled: &disabled})
+	if sc.NextRunAt != nil {
+		t.Errorf("expected no next run for a disabled schedule, got %v", sc.NextRunAt)
+	}
+
+	reloaded, err := NewScheduler(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, _ := reloaded.ListSchedules(context.Background())
+	if len(items) != 1 || items[0].ID != sc.ID || items[0].Enabled {
+		t.Errorf("expected the schedule to be reloaded, got %+v", items)
+	}
+}
+
+func TestScheduleAPI(t *testing.T) {
+	s := newTestScheduler(t, &fakeJobs{}, 2)
+	srv := NewServer(Config{Schedules: s})
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		srv.ServeHTTP(rr, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rr
+	}
+
+	rr := do(http.MethodPost, "/api/v1/schedules", `{"name":"nightly","engagement_id":"eng-9","cron":"61 * * * *","timezone":"Mars/Olympus"}`)
+	var p Problem
+	_ = json.Unmarshal(rr.Body.Bytes(), &p)
+	if rr.Code != http.StatusBadRequest || len(p.Errors) != 3 {
+		t.Fatalf("expected three field errors, got %d %+v", rr.Code, p)
+	}
+
+	rr = do(http.MethodPost, "/api/v1/schedules", `{"name":"nightly","engagement_id":"eng-1","cron":"30 2 * * *"}`)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var created Schedule
+	if err := json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+		t.Fatal(err)
+	}
+	if created.Timezone != "UTC" || !created.Enabled || created.NextRunAt == nil {
+		t.Errorf("expected defaults to be applied, got %+v", created)
+	}
+
+	rr = do(http.MethodPut, "/api/v1/schedules/"+created.ID, `{"name":"weekly","engagement_id":"eng-1","cron":"@weekly","enabled":false}`)
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"enabled":false`) {
+		t.Fatalf("expected the schedule to be replaced, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = do(http.MethodGet, "/api/v1/schedules/calendar?from=2025-06-01T00:00:00Z&to=2025-08-01T00:00:00Z", "")
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a window over 31 days, got %d", rr.Code)
+	}
+
+	if rr := do(http.MethodDelete, "/api/v1/schedules/"+created.ID, ""); rr.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", rr.Code)
+	}
+	if rr := do(http.MethodGet, "/api/schedules/"+created.ID, ""); rr.Code != http.StatusNotFound {
+		t.Errorf("expected 404 after delete, got %d", rr.Code)
+	}
+}
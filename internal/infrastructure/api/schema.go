@@ -0,0 +1,246 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+// maxBodyBytes caps POST bodies.
+const maxBodyBytes = 1 << 20
+
+// jsonSchema is the subset of JSON Schema used to validate request bodies.
+// It marshals to a valid JSON Schema document.
+type jsonSchema struct {
+	Type                 string                 `json:"type"`
+	Description          string                 `json:"description,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Const                any                    `json:"const,omitempty"`
+	MinLength            int                    `json:"minLength,omitempty"`
+	MaxLength            int                    `json:"maxLength,omitempty"`
+	MaxItems             int                    `json:"maxItems,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+var noAdditionalProperties = new(bool)
+
+// engagementCreateSchema validates POST /api/v1/engagements.
+var engagementCreateSchema = compileSchema(&jsonSchema{
+	Type:                 "object",
+	Required:             []string{"name", "owner", "roe", "roe_agree"},
+	AdditionalProperties: noAdditionalProperties,
+	Properties: map[string]*jsonSchema{
+		"name":      {Type: "string", MinLength: 1, MaxLength: 200},
+		"owner":     {Type: "string", MinLength: 1, MaxLength: 200},
+		"roe":       {Type: "string", MinLength: 1, MaxLength: 20000, Description: "Rules of engagement"},
+		"roe_agree": {Type: "boolean", Const: true, Description: "Must be true to acknowledge the rules of engagement"},
+		"scope": {
+			Type:     "array",
+			MaxItems: 1000,
+			Items:    &jsonSchema{Type: "string", MinLength: 1, MaxLength: 2048},
+		},
+	},
+})
+
+// jobRequestSchema validates POST /api/v1/jobs.
+var jobRequestSchema = compileSchema(&jsonSchema{
+	Type:                 "object",
+	Required:             []string{"engagement_id"},
+	AdditionalProperties: noAdditionalProperties,
+	Properties: map[string]*jsonSchema{
+		"type":          {Type: "string", Enum: []string{"http"}, Description: "Defaults to http"},
+		"engagement_id": {Type: "string", MinLength: 1, MaxLength: 128, Pattern: `^[^/\\]+$`},
+	},
+})
+
+// rfc3339Pattern matches timestamps such as 2025-06-01T00:00:00Z.
+const rfc3339Pattern = `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$`
+
+// scheduleSchema validates POST /api/v1/schedules and PUT /api/v1/schedules/{id}.
+var scheduleSchema = compileSchema(&jsonSchema{
+	Type:                 "object",
+	Required:             []string{"name", "engagement_id", "cron"},
+	AdditionalProperties: noAdditionalProperties,
+	Properties: map[string]*jsonSchema{
+		"name":          {Type: "string", MinLength: 1, MaxLength: 200},
+		"engagement_id": {Type: "string", MinLength: 1, MaxLength: 128, Pattern: `^[^/\\]+$`},
+		"type":          {Type: "string", Enum: []string{"http"}, Description: "Defaults to http"},
+		"cron":          {Type: "string", MinLength: 1, MaxLength: 100, Description: "Five-field cron expression, or @hourly, @daily, @weekly or @monthly"},
+		"timezone":      {Type: "string", MaxLength: 64, Description: "IANA time zone of the cron expression, defaults to UTC"},
+		"enabled":       {Type: "boolean", Description: "Defaults to true"},
+		"blackouts": {
+			Type:     "array",
+			MaxItems: 100,
+			Items: &jsonSchema{
+				Type:                 "object",
+				Required:             []string{"start", "end"},
+				AdditionalProperties: noAdditionalProperties,
+				Properties: map[string]*jsonSchema{
+					"start":  {Type: "string", Pattern: rfc3339Pattern},
+					"end":    {Type: "string", Pattern: rfc3339Pattern},
+					"reason": {Type: "string", MaxLength: 500},
+				},
+			},
+		},
+	},
+})
+
+func compileSchema(s *jsonSchema) *jsonSchema {
+	if s.Pattern != "" {
+		s.pattern = regexp.MustCompile(s.Pattern)
+	}
+	for _, prop := range s.Properties {
+		compileSchema(prop)
+	}
+	if s.Items != nil {
+		compileSchema(s.Items)
+	}
+	return s
+}
+
+// decodeBody reads a JSON request body, checks it against schema, and
+// decodes it into dst. It returns a *ValidationError listing every invalid
+// field, or a malformed body error.
+func decodeBody(w http.ResponseWriter, r *http.Request, schema *jsonSchema, dst any) error {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		return &malformedBodyError{err: err}
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		if err == io.EOF {
+			err = fmt.Errorf("body is empty")
+		}
+		return &malformedBodyError{err: err}
+	}
+	if dec.More() {
+		return &malformedBodyError{err: fmt.Errorf("unexpected data after the JSON value")}
+	}
+
+	var fields []FieldError
+	schema.validate("", value, &fields)
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		return &malformedBodyError{err: err}
+	}
+	return nil
+}
+
+// validate appends a FieldError for each way value breaks s.
+func (s *jsonSchema) validate(path string, value any, errs *[]FieldError) {
+	fail := func(format string, args ...any) {
+		field := path
+		if field == "" {
+			field = "(body)"
+		}
+		*errs = append(*errs, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			fail("must be an object")
+			return
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				*errs = append(*errs, FieldError{Field: joinPath(path, name), Message: "is required"})
+			}
+		}
+		names := make([]string, 0, len(obj))
+		for name := range obj {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			prop, ok := s.Properties[name]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					*errs = append(*errs, FieldError{Field: joinPath(path, name), Message: "is not a known field"})
+				}
+				continue
+			}
+			prop.validate(joinPath(path, name), obj[name], errs)
+		}
+
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			fail("must be an array")
+			return
+		}
+		if s.MaxItems > 0 && len(items) > s.MaxItems {
+			fail("must have at most %d items", s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range items {
+				s.Items.validate(path+"["+strconv.Itoa(i)+"]", item, errs)
+			}
+		}
+
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			fail("must be a string")
+			return
+		}
+		n := utf8.RuneCountInString(str)
+		switch {
+		case n < s.MinLength && n == 0:
+			fail("must not be empty")
+		case n < s.MinLength:
+			fail("must be at least %d characters", s.MinLength)
+		case s.MaxLength > 0 && n > s.MaxLength:
+			fail("must be at most %d characters", s.MaxLength)
+		}
+		if len(s.Enum) > 0 && !containsString(s.Enum, str) {
+			fail("must be one of %v", s.Enum)
+		}
+		if s.pattern != nil && n > 0 && !s.pattern.MatchString(str) {
+			fail("must match %s", s.Pattern)
+		}
+
+	case "boolean":
+		b, ok := value.(bool)
+		if !ok {
+			fail("must be a boolean")
+			return
+		}
+		if s.Const != nil && s.Const != b {
+			fail("must be %v", s.Const)
+		}
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
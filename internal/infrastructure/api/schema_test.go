@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type recordingEngagementService struct {
+	mockEngagementService
+	created *EngagementCreateRequest
+}
+
+func (m *recordingEngagementService) CreateEngagement(ctx context.Context, req EngagementCreateRequest) (*Engagement, error) {
+	m.created = &req
+	if strings.Contains(strings.Join(req.Scope, ","), "localhost") {
+		return nil, NewFieldError("scope", "localhost is not allowed")
+	}
+	return &Engagement{ID: "eng-1", Name: req.Name}, nil
+}
+
+func postProblem(t *testing.T, srv *Server, path, body string) (*httptest.ResponseRecorder, Problem) {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	srv.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	var p Problem
+	if rr.Code >= 400 {
+		if got := rr.Header().Get("Content-Type"); got != problemContentType {
+			t.Fatalf("expected %s, got %s", problemContentType, got)
+		}
+		if err := json.Unmarshal(rr.Body.Bytes(), &p); err != nil {
+			t.Fatalf("invalid problem body %s: %v", rr.Body.String(), err)
+		}
+	}
+	return rr, p
+}
+
+func TestEngagementCreateValidation(t *testing.T) {
+	svc := &recordingEngagementService{}
+	srv := NewServer(Config{Engagements: svc})
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantType   string
+		wantFields []string
+	}{
+		{
+			name:       "valid",
+			body:       `{"name":"Audit","owner":"sec@example.com","roe":"Standard","roe_agree":true,"scope":["https://app.example.com"]}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "missing and wrong typed fields",
+			body:       `{"name":"","roe":42,"roe_agree":false,"scope":["ok",""],"extra":1}`,
+			wantStatus: http.StatusBadRequest,
+			wantType:   ProblemValidation,
+			wantFields: []string{"owner", "extra", "name", "roe", "roe_agree", "scope[1]"},
+		},
+		{
+			name:       "not an object",
+			body:       `["Audit"]`,
+			wantStatus: http.StatusBadRequest,
+			wantType:   ProblemValidation,
+			wantFields: []string{"(body)"},
+		},
+		{
+			name:       "malformed JSON",
+			body:       `{"name":`,
+			wantStatus: http.StatusBadRequest,
+			wantType:   ProblemMalformedBody,
+		},
+		{
+			name:       "service field error",
+			body:       `{"name":"Audit","owner":"o","roe":"r","roe_agree":true,"scope":["localhost"]}`,
+			wantStatus: http.StatusBadRequest,
+			wantType:   ProblemValidation,
+			wantFields: []string{"scope"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr, p := postProblem(t, srv, "/api/v1/engagements", tt.body)
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rr.Code, rr.Body.String())
+			}
+			if tt.wantType == "" {
+				return
+			}
+			if p.Type != tt.wantType || p.Status != tt.wantStatus || p.Instance != "/api/v1/engagements" || p.Error == "" {
+				t.Errorf("unexpected problem: %+v", p)
+			}
+			var fields []string
+			for _, f := range p.Errors {
+				fields = append(fields, f.Field)
+			}
+			if strings.Join(fields, ",") != strings.Join(tt.wantFields, ",") {
+				t.Errorf("expected fields %v, got %+v", tt.wantFields, p.Errors)
+			}
+		})
+	}
+}
+
+func TestJobRequestValidation(t *testing.T) {
+	srv := NewServer(Config{Jobs: &mockJobService{}})
+
+	_, p := postProblem(t, srv, "/api/v1/jobs", `{"type":"dns","engagement_id":"../eng"}`)
+	if p.Type != ProblemValidation || len(p.Errors) != 2 {
+		t.Fatalf("unexpected problem: %+v", p)
+	}
+	if p.Errors[0].Field != "engagement_id" || p.Errors[1].Field != "type" {
+		t.Errorf("unexpected field errors: %+v", p.Errors)
+	}
+
+	rr, p := postProblem(t, srv, "/api/v1/jobs", `{"engagement_id":"`+strings.Repeat("a", maxBodyBytes)+`"}`)
+	if rr.Code != http.StatusRequestEntityTooLarge || p.Type != ProblemBodyTooLarge {
+		t.Errorf("expected a body-too-large problem, got %d %+v", rr.Code, p)
+	}
+
+	rr, _ = postProblem(t, srv, "/api/v1/jobs", `{"engagement_id":"eng-1"}`)
+	if rr.Code != http.StatusAccepted {
+		t.Errorf("expected 202 for a valid job, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+type mockJobService struct{}
+
+func (m *mockJobService) StartJob(ctx context.Context, req JobRequest) (*Job, error) {
+	return &Job{ID: "job_1", Type: "http", Status: "pending", ResultID: req.EngagementID}, nil
+}
+
+func (m *mockJobService) GetJob(ctx context.Context, id string) (*Job, error) { return nil, nil }
+
+func (m *mockJobService) ListJobs(ctx context.Context, limit int) ([]Job, error) { return nil, nil }
+
+func (m *mockJobService) CancelJob(ctx context.Context, id string) (*Job, error) {
+	return nil, ErrJobNotFound
+}
+
+func (m *mockJobService) Subscribe() (chan Job, func()) { return make(chan Job), func() {} }
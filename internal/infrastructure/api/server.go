@@ -2,13 +2,14 @@ package api
 
 import (
 	"context"
-	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"net/http"
+	"net/netip"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/khanhnv2901/seca-cli/internal/infrastructure/api/middleware"
@@ -23,6 +24,7 @@ type Engagement struct {
 	Scope     []string  `json:"scope,omitempty"`
 	ROE       string    `json:"roe,omitempty"`
 	ROEAgree  bool      `json:"roe_agree"`
+	Status    string    `json:"status,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -62,74 +64,140 @@ type TelemetryService interface {
 
 type HealthService interface {
 	Check(ctx context.Context) error
-	Ready(ctx context.Context) error
+	// Ready reports the status of each dependency. A nil report with a nil
+	// error means ready.
+	Ready(ctx context.Context) (*Readiness, error)
 }
 
 type JobService interface {
 	StartJob(ctx context.Context, req JobRequest) (*Job, error)
 	GetJob(ctx context.Context, id string) (*Job, error)
 	ListJobs(ctx context.Context, limit int) ([]Job, error)
+	// CancelJob stops a pending or running job. It returns ErrJobNotFound
+	// or ErrJobFinished when there is nothing to cancel.
+	CancelJob(ctx context.Context, id string) (*Job, error)
 	Subscribe() (chan Job, func())
 }
 
+type DeliveryService interface {
+	ListDeliveries(ctx context.Context, limit int, status string) ([]Delivery, error)
+}
+
+// ScheduleService manages recurring scans and their calendar.
+type ScheduleService interface {
+	ListSchedules(ctx context.Context) ([]Schedule, error)
+	GetSchedule(ctx context.Context, id string) (*Schedule, error)
+	CreateSchedule(ctx context.Context, req ScheduleRequest) (*Schedule, error)
+	UpdateSchedule(ctx context.Context, id string, req ScheduleRequest) (*Schedule, error)
+	DeleteSchedule(ctx context.Context, id string) error
+	Calendar(ctx context.Context, from, to time.Time) ([]ScheduledRun, error)
+}
+
 type Config struct {
 	Engagements    EngagementService
 	Results        ResultsService
 	Telemetry      TelemetryService
 	Health         HealthService
 	Jobs           JobService
+	Deliveries     DeliveryService // Webhook deliveries (nil = webhooks not configured)
+	Schedules      ScheduleService
 	AuthToken      string
+	APIKeys        []APIKey // Keys with limited roles; like AuthToken they reach the default services
 	TelemetryLimit int
 	Logger         *zap.Logger
 	CORSOrigins    []string // Allowed CORS origins (empty = allow all)
 	RateLimit      int      // Requests per second per IP (0 = disabled)
 	RateBurst      int      // Burst size for rate limiter
+	// TrustedProxies are the reverse proxies whose X-Forwarded-For the rate
+	// limiter believes (empty = ignore X-Forwarded-For)
+	TrustedProxies []netip.Prefix
+	Workspaces     []Workspace
 }
 
+// ErrShuttingDown is returned for new jobs while the server drains.
+var ErrShuttingDown = errors.New("server is shutting down")
+
 type Server struct {
-	cfg      Config
-	mux      *http.ServeMux
-	limiters *rateLimiterMap
+	cfg        Config
+	mux        *http.ServeMux
+	limiters   *rateLimiterMap
+	workspaces []*workspaceRoute
+
+	draining    atomic.Bool
+	streamsDone chan struct{} // Closed to end job streams
+	closeOnce   sync.Once
 }
 
 func NewServer(cfg Config) *Server {
 	srv := &Server{
-		cfg:      cfg,
-		mux:      http.NewServeMux(),
-		limiters: newRateLimiterMap(),
+		cfg:         cfg,
+		mux:         http.NewServeMux(),
+		limiters:    newRateLimiterMap(),
+		streamsDone: make(chan struct{}),
 	}
 	srv.routes()
+	for _, ws := range cfg.Workspaces {
+		srv.workspaces = append(srv.workspaces, newWorkspaceRoute(cfg, ws))
+	}
 	return srv
 }
 
+// Drain stops the server, and its workspaces, from accepting jobs: POST
+// /jobs answers 503 and /ready reports the server draining. Other requests
+// are still served so clients can follow running jobs.
+func (s *Server) Drain() {
+	s.draining.Store(true)
+	for _, ws := range s.workspaces {
+		ws.server.Drain()
+	}
+}
+
+// CloseStreams ends every job stream with a final "shutdown" event. Call it
+// before http.Server.Shutdown, which otherwise waits for the streams.
+func (s *Server) CloseStreams() {
+	s.closeOnce.Do(func() { close(s.streamsDone) })
+	for _, ws := range s.workspaces {
+		ws.server.CloseStreams()
+	}
+}
+
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// Apply middleware chain: RequestID -> CORS -> RateLimit -> Logging -> Auth -> Handler
-	handler := middleware.RequestID(s.withLogging(s.withRateLimit(s.withCORS(s.mux))))
+	// Apply middleware chain: RequestID -> CORS -> RateLimit -> Logging -> Identity -> Workspace -> Auth -> Handler
+	handler := middleware.RequestID(s.withLogging(s.withRateLimit(s.withCORS(s.withIdentity(s.withWorkspace(s.mux))))))
 	handler.ServeHTTP(w, r)
 }
 
 func (s *Server) routes() {
-	// Version 1 API routes (primary)
+	// Version 1 API routes (primary). Document endpoints support ETag
+	// revalidation and compression; the job stream must not be buffered.
 	s.mux.Handle("/api/v1/health", s.withAuth(http.HandlerFunc(s.handleHealth)))
 	s.mux.Handle("/api/v1/ready", s.withAuth(http.HandlerFunc(s.handleReady)))
-	s.mux.Handle("/api/v1/engagements", s.withAuth(http.HandlerFunc(s.handleEngagements)))
-	s.mux.Handle("/api/v1/engagements/", s.withAuth(http.HandlerFunc(s.handleEngagementByID)))
-	s.mux.Handle("/api/v1/results/", s.withAuth(http.HandlerFunc(s.handleResults)))
-	s.mux.Handle("/api/v1/telemetry/", s.withAuth(http.HandlerFunc(s.handleTelemetry)))
+	s.mux.Handle("/api/v1/engagements", s.withAuth(middleware.Cacheable(http.HandlerFunc(s.handleEngagements))))
+	s.mux.Handle("/api/v1/engagements/", s.withAuth(middleware.Cacheable(http.HandlerFunc(s.handleEngagementByID))))
+	s.mux.Handle("/api/v1/results/", s.withAuth(middleware.Cacheable(http.HandlerFunc(s.handleResults))))
+	s.mux.Handle("/api/v1/telemetry/", s.withAuth(middleware.Cacheable(http.HandlerFunc(s.handleTelemetry))))
 	s.mux.Handle("/api/v1/jobs", s.withAuth(http.HandlerFunc(s.handleJobs)))
 	s.mux.Handle("/api/v1/jobs/", s.withAuth(http.HandlerFunc(s.handleJobByID)))
 	s.mux.Handle("/api/v1/jobs-stream", s.withAuth(http.HandlerFunc(s.handleJobStream)))
+	s.mux.Handle("/api/v1/deliveries", s.withAuth(http.HandlerFunc(s.handleDeliveries)))
+	s.mux.Handle("/api/v1/schedules", s.withAuth(http.HandlerFunc(s.handleSchedules)))
+	s.mux.Handle("/api/v1/schedules/", s.withAuth(http.HandlerFunc(s.handleScheduleByID)))
+	s.mux.Handle("/api/v1/schedules/calendar", s.withAuth(http.HandlerFunc(s.handleScheduleCalendar)))
 
 	// Unversioned routes (backward compatibility - alias to v1)
 	s.mux.Handle("/api/health", s.withAuth(http.HandlerFunc(s.handleHealth)))
 	s.mux.Handle("/api/ready", s.withAuth(http.HandlerFunc(s.handleReady)))
-	s.mux.Handle("/api/engagements", s.withAuth(http.HandlerFunc(s.handleEngagements)))
-	s.mux.Handle("/api/engagements/", s.withAuth(http.HandlerFunc(s.handleEngagementByID)))
-	s.mux.Handle("/api/results/", s.withAuth(http.HandlerFunc(s.handleResults)))
-	s.mux.Handle("/api/telemetry/", s.withAuth(http.HandlerFunc(s.handleTelemetry)))
+	s.mux.Handle("/api/engagements", s.withAuth(middleware.Cacheable(http.HandlerFunc(s.handleEngagements))))
+	s.mux.Handle("/api/engagements/", s.withAuth(middleware.Cacheable(http.HandlerFunc(s.handleEngagementByID))))
+	s.mux.Handle("/api/results/", s.withAuth(middleware.Cacheable(http.HandlerFunc(s.handleResults))))
+	s.mux.Handle("/api/telemetry/", s.withAuth(middleware.Cacheable(http.HandlerFunc(s.handleTelemetry))))
 	s.mux.Handle("/api/jobs", s.withAuth(http.HandlerFunc(s.handleJobs)))
 	s.mux.Handle("/api/jobs/", s.withAuth(http.HandlerFunc(s.handleJobByID)))
 	s.mux.Handle("/api/jobs-stream", s.withAuth(http.HandlerFunc(s.handleJobStream)))
+	s.mux.Handle("/api/deliveries", s.withAuth(http.HandlerFunc(s.handleDeliveries)))
+	s.mux.Handle("/api/schedules", s.withAuth(http.HandlerFunc(s.handleSchedules)))
+	s.mux.Handle("/api/schedules/", s.withAuth(http.HandlerFunc(s.handleScheduleByID)))
+	s.mux.Handle("/api/schedules/calendar", s.withAuth(http.HandlerFunc(s.handleScheduleCalendar)))
 }
 
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
@@ -151,28 +219,49 @@ func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
 		s.methodNotAllowed(w, r)
 		return
 	}
+	if s.draining.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, &Readiness{Status: StatusNotReady, Checks: []DependencyStatus{
+			{Name: "server", Status: DependencyDown, Detail: "draining for shutdown"},
+		}})
+		return
+	}
+	report := &Readiness{Status: StatusReady}
 	if s.cfg.Health != nil {
-		if err := s.cfg.Health.Ready(r.Context()); err != nil {
+		checked, err := s.cfg.Health.Ready(r.Context())
+		if err != nil {
 			s.writeError(w, r, http.StatusServiceUnavailable, err)
 			return
 		}
+		if checked != nil {
+			report = checked
+		}
+	}
+	status := http.StatusOK
+	if report.Status != StatusReady {
+		status = http.StatusServiceUnavailable
 	}
-	writeJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+	writeJSON(w, status, report)
 }
 
 func (s *Server) handleEngagements(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
+		query, err := parseEngagementQuery(r.URL.Query())
+		if err != nil {
+			s.writeError(w, r, http.StatusBadRequest, err)
+			return
+		}
 		items, err := s.cfg.Engagements.ListEngagements(r.Context())
 		if err != nil {
 			s.writeError(w, r, http.StatusInternalServerError, err)
 			return
 		}
-		writeJSON(w, http.StatusOK, items)
+		page, total := query.apply(items)
+		setPageHeaders(w, r, query.offset, query.limit, total)
+		writeJSON(w, http.StatusOK, page)
 	case http.MethodPost:
-		r.Body = http.MaxBytesReader(w, r.Body, 1048576) // 1MB limit
 		var req EngagementCreateRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if err := decodeBody(w, r, engagementCreateSchema, &req); err != nil {
 			s.writeError(w, r, http.StatusBadRequest, err)
 			return
 		}
@@ -275,13 +364,20 @@ func (s *Server) handleJobs(w http.ResponseWriter, r *http.Request) {
 		}
 		writeJSON(w, http.StatusOK, jobs)
 	case http.MethodPost:
-		r.Body = http.MaxBytesReader(w, r.Body, 1048576) // 1MB limit
+		if s.draining.Load() {
+			s.writeShuttingDown(w, r)
+			return
+		}
 		var req JobRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if err := decodeBody(w, r, jobRequestSchema, &req); err != nil {
 			s.writeError(w, r, http.StatusBadRequest, err)
 			return
 		}
 		job, err := s.cfg.Jobs.StartJob(r.Context(), req)
+		if errors.Is(err, ErrShuttingDown) {
+			s.writeShuttingDown(w, r)
+			return
+		}
 		if err != nil {
 			s.writeError(w, r, http.StatusBadRequest, err)
 			return
@@ -297,21 +393,34 @@ func (s *Server) handleJobByID(w http.ResponseWriter, r *http.Request) {
 		s.writeError(w, r, http.StatusNotFound, errors.New("job service not available"))
 		return
 	}
-	if r.Method != http.MethodGet {
-		s.methodNotAllowed(w, r)
-		return
-	}
-	id := strings.TrimPrefix(r.URL.Path, "/api/jobs/")
+	id := r.URL.Path[strings.LastIndex(r.URL.Path, "/jobs/")+len("/jobs/"):]
 	if id == "" {
 		s.writeError(w, r, http.StatusNotFound, errors.New("job ID required"))
 		return
 	}
-	job, err := s.cfg.Jobs.GetJob(r.Context(), id)
-	if err != nil || job == nil {
-		s.writeError(w, r, http.StatusNotFound, errors.New("job not found"))
-		return
+	switch r.Method {
+	case http.MethodGet:
+		job, err := s.cfg.Jobs.GetJob(r.Context(), id)
+		if err != nil || job == nil {
+			s.writeError(w, r, http.StatusNotFound, ErrJobNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, job)
+	case http.MethodDelete:
+		job, err := s.cfg.Jobs.CancelJob(r.Context(), id)
+		switch {
+		case errors.Is(err, ErrJobNotFound):
+			s.writeError(w, r, http.StatusNotFound, err)
+		case errors.Is(err, ErrJobFinished):
+			s.writeError(w, r, http.StatusConflict, err)
+		case err != nil:
+			s.writeError(w, r, http.StatusInternalServerError, err)
+		default:
+			writeJSON(w, http.StatusAccepted, job)
+		}
+	default:
+		s.methodNotAllowed(w, r)
 	}
-	writeJSON(w, http.StatusOK, job)
 }
 
 func (s *Server) handleJobStream(w http.ResponseWriter, r *http.Request) {
@@ -329,6 +438,9 @@ func (s *Server) handleJobStream(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Connection", "keep-alive")
 	updates, unsubscribe := s.cfg.Jobs.Subscribe()
 	defer unsubscribe()
+	// Send the headers now so clients see the stream open before any update
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
 	ctx := r.Context()
 	for {
 		select {
@@ -356,12 +468,171 @@ func (s *Server) handleJobStream(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			flusher.Flush()
+		case <-s.streamsDone:
+			if s.writeStreamChunk(w, []byte("event: shutdown\ndata: {\"reason\":\"server shutting down\"}\n\n")) {
+				flusher.Flush()
+			}
+			return
 		case <-ctx.Done():
 			return
 		}
 	}
 }
 
+func (s *Server) handleDeliveries(w http.ResponseWriter, r *http.Request) {
+	if s.cfg.Deliveries == nil {
+		s.writeError(w, r, http.StatusNotFound, errors.New("webhooks not configured"))
+		return
+	}
+	if r.Method != http.MethodGet {
+		s.methodNotAllowed(w, r)
+		return
+	}
+	limit := 50
+	if q := r.URL.Query().Get("limit"); q != "" {
+		if parsed, err := strconv.Atoi(q); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", DeliveryPending, DeliveryRetrying, DeliveryDelivered, DeliveryDead:
+	default:
+		s.writeError(w, r, http.StatusBadRequest, errors.New("invalid status filter"))
+		return
+	}
+	deliveries, err := s.cfg.Deliveries.ListDeliveries(r.Context(), limit, status)
+	if err != nil {
+		s.writeError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, deliveries)
+}
+
+func (s *Server) handleSchedules(w http.ResponseWriter, r *http.Request) {
+	if s.cfg.Schedules == nil {
+		s.writeError(w, r, http.StatusNotFound, errors.New("schedule service not available"))
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		items, err := s.cfg.Schedules.ListSchedules(r.Context())
+		if err != nil {
+			s.writeError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, items)
+	case http.MethodPost:
+		var req ScheduleRequest
+		if err := decodeBody(w, r, scheduleSchema, &req); err != nil {
+			s.writeError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		created, err := s.cfg.Schedules.CreateSchedule(r.Context(), req)
+		if err != nil {
+			s.writeError(w, r, scheduleErrorStatus(err), err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, created)
+	default:
+		s.methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) handleScheduleByID(w http.ResponseWriter, r *http.Request) {
+	if s.cfg.Schedules == nil {
+		s.writeError(w, r, http.StatusNotFound, errors.New("schedule service not available"))
+		return
+	}
+	id := r.URL.Path[strings.LastIndex(r.URL.Path, "/schedules/")+len("/schedules/"):]
+	if id == "" || strings.Contains(id, "/") {
+		s.writeError(w, r, http.StatusNotFound, ErrScheduleNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		sc, err := s.cfg.Schedules.GetSchedule(r.Context(), id)
+		if err != nil {
+			s.writeError(w, r, scheduleErrorStatus(err), err)
+			return
+		}
+		writeJSON(w, http.StatusOK, sc)
+	case http.MethodPut:
+		var req ScheduleRequest
+		if err := decodeBody(w, r, scheduleSchema, &req); err != nil {
+			s.writeError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		sc, err := s.cfg.Schedules.UpdateSchedule(r.Context(), id, req)
+		if err != nil {
+			s.writeError(w, r, scheduleErrorStatus(err), err)
+			return
+		}
+		writeJSON(w, http.StatusOK, sc)
+	case http.MethodDelete:
+		if err := s.cfg.Schedules.DeleteSchedule(r.Context(), id); err != nil {
+			s.writeError(w, r, scheduleErrorStatus(err), err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.methodNotAllowed(w, r)
+	}
+}
+
+// handleScheduleCalendar lists upcoming scheduled runs between the from and
+// to query parameters (RFC 3339, default: the next 7 days).
+func (s *Server) handleScheduleCalendar(w http.ResponseWriter, r *http.Request) {
+	if s.cfg.Schedules == nil {
+		s.writeError(w, r, http.StatusNotFound, errors.New("schedule service not available"))
+		return
+	}
+	if r.Method != http.MethodGet {
+		s.methodNotAllowed(w, r)
+		return
+	}
+	from := time.Now().UTC()
+	var fields []FieldError
+	if v := r.URL.Query().Get("from"); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			fields = append(fields, FieldError{Field: "from", Message: "must be an RFC 3339 timestamp"})
+		}
+		from = parsed
+	}
+	to := from.Add(7 * 24 * time.Hour)
+	if v := r.URL.Query().Get("to"); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			fields = append(fields, FieldError{Field: "to", Message: "must be an RFC 3339 timestamp"})
+		}
+		to = parsed
+	}
+	if len(fields) > 0 {
+		s.writeError(w, r, http.StatusBadRequest, &ValidationError{Fields: fields})
+		return
+	}
+	runs, err := s.cfg.Schedules.Calendar(r.Context(), from, to)
+	if err != nil {
+		s.writeError(w, r, scheduleErrorStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, runs)
+}
+
+// scheduleErrorStatus maps schedule service errors to HTTP statuses.
+func scheduleErrorStatus(err error) int {
+	var validation *ValidationError
+	switch {
+	case errors.Is(err, ErrScheduleNotFound):
+		return http.StatusNotFound
+	case errors.As(err, &validation):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 func (s *Server) withRateLimit(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Skip rate limiting if disabled
@@ -370,20 +641,7 @@ func (s *Server) withRateLimit(next http.Handler) http.Handler {
 			return
 		}
 
-		// Extract client IP (handle X-Forwarded-For for proxied requests)
-		clientIP := r.RemoteAddr
-		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
-			// Use first IP in X-Forwarded-For chain
-			if idx := strings.Index(forwarded, ","); idx > 0 {
-				clientIP = strings.TrimSpace(forwarded[:idx])
-			} else {
-				clientIP = strings.TrimSpace(forwarded)
-			}
-		}
-		// Remove port if present
-		if idx := strings.LastIndex(clientIP, ":"); idx > 0 {
-			clientIP = clientIP[:idx]
-		}
+		clientIP := s.clientIP(r)
 
 		// Get or create limiter for this IP
 		limiter := s.limiters.getLimiter(clientIP, s.cfg.RateLimit, s.cfg.RateBurst)
@@ -430,7 +688,8 @@ func (s *Server) withCORS(next http.Handler) http.Handler {
 		if allowOrigin != "" {
 			w.Header().Set("Access-Control-Allow-Origin", allowOrigin)
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-Auth-Token")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-Auth-Token, If-None-Match, "+HeaderUser)
+			w.Header().Set("Access-Control-Expose-Headers", HeaderWorkspace+", "+HeaderTotalCount+", Link, ETag")
 			w.Header().Set("Access-Control-Max-Age", "3600")
 		}
 
@@ -463,6 +722,7 @@ func (s *Server) withLogging(next http.Handler) http.Handler {
 				zap.String("method", r.Method),
 				zap.String("path", r.URL.Path),
 				zap.String("remote_addr", r.RemoteAddr),
+				zap.String("client_ip", s.clientIP(r)),
 				zap.Int("status", lrw.statusCode),
 				zap.Duration("duration", duration),
 				zap.Int64("bytes", lrw.bytesWritten),
@@ -471,15 +731,29 @@ func (s *Server) withLogging(next http.Handler) http.Handler {
 	})
 }
 
+// withAuth checks the request's key, unless withWorkspace has already
+// matched it, and that the key's role may make the request. A server with
+// neither AuthToken nor APIKeys serves everyone as admin.
 func (s *Server) withAuth(next http.Handler) http.Handler {
-	if s.cfg.AuthToken == "" {
-		return next
+	keys := s.cfg.APIKeys
+	if s.cfg.AuthToken != "" {
+		keys = append([]APIKey{{Name: "auth-token", Key: s.cfg.AuthToken, Role: RoleAdmin}}, keys...)
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		token := r.Header.Get("X-Auth-Token")
-		// Use constant-time comparison to prevent timing attacks
-		if subtle.ConstantTimeCompare([]byte(token), []byte(s.cfg.AuthToken)) != 1 {
-			s.writeError(w, r, http.StatusUnauthorized, errors.New("unauthorized"))
+		role, ok := roleFromContext(r.Context())
+		if !ok {
+			role = RoleAdmin
+			if len(keys) > 0 {
+				key, found := matchKey(r.Header.Get("X-Auth-Token"), keys)
+				if !found {
+					s.writeError(w, r, http.StatusUnauthorized, errors.New("unauthorized"))
+					return
+				}
+				role = key.Role
+			}
+			r = r.WithContext(context.WithValue(r.Context(), roleKey{}, role))
+		}
+		if !s.authorize(w, r, role) {
 			return
 		}
 		next.ServeHTTP(w, r)
@@ -504,17 +778,24 @@ func (lrw *loggingResponseWriter) Write(b []byte) (int, error) {
 	return n, err
 }
 
+// Flush lets the job stream push events through the logging middleware.
+func (lrw *loggingResponseWriter) Flush() {
+	if f, ok := lrw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
 func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	_ = json.NewEncoder(w).Encode(payload)
 }
 
+// writeError responds with an RFC 7807 problem describing err. Validation
+// errors list the offending fields.
 func (s *Server) writeError(w http.ResponseWriter, r *http.Request, status int, err error) {
-	// Sanitize error messages to prevent information disclosure
-	msg := err.Error()
-
 	// For 5xx errors, return generic message and log details server-side
+	// to prevent information disclosure
 	if status >= 500 {
 		if s.cfg.Logger != nil {
 			// Log with request context
@@ -524,10 +805,10 @@ func (s *Server) writeError(w http.ResponseWriter, r *http.Request, status int,
 				zap.Int("status", status),
 			)
 		}
-		msg = "internal server error"
+		err = errors.New("internal server error")
 	}
 
-	writeJSON(w, status, map[string]string{"error": msg})
+	writeProblem(w, newProblem(r, status, err))
 }
 
 // requestLogger creates a logger with request context (request ID, method, path)
@@ -544,6 +825,13 @@ func (s *Server) requestLogger(r *http.Request) *zap.Logger {
 	)
 }
 
+// writeShuttingDown rejects a new job while the server drains. Unlike other
+// 5xx errors the reason is not hidden, so clients know to retry elsewhere.
+func (s *Server) writeShuttingDown(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Retry-After", "30")
+	writeProblem(w, newProblem(r, http.StatusServiceUnavailable, ErrShuttingDown))
+}
+
 func (s *Server) methodNotAllowed(w http.ResponseWriter, r *http.Request) {
 	s.writeError(w, r, http.StatusMethodNotAllowed, errors.New("method not allowed"))
 }
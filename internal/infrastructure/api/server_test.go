@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -98,14 +99,15 @@ func (f *failingWriter) WriteHeader(statusCode int) {}
 type mockHealthService struct {
 	err      error
 	readyErr error
+	report   *Readiness
 }
 
 func (m *mockHealthService) Check(ctx context.Context) error {
 	return m.err
 }
 
-func (m *mockHealthService) Ready(ctx context.Context) error {
-	return m.readyErr
+func (m *mockHealthService) Ready(ctx context.Context) (*Readiness, error) {
+	return m.report, m.readyErr
 }
 
 func TestNewServer(t *testing.T) {
@@ -173,6 +175,7 @@ func TestServer_HandleReady(t *testing.T) {
 	tests := []struct {
 		name       string
 		readyErr   error
+		report     *Readiness
 		wantStatus int
 		wantBody   string
 	}{
@@ -186,7 +189,15 @@ func TestServer_HandleReady(t *testing.T) {
 			name:       "not ready",
 			readyErr:   errors.New("database down"),
 			wantStatus: http.StatusServiceUnavailable,
-			wantBody:   `{"error":"internal server error"}`,
+			wantBody:   `"error":"internal server error"}`,
+		},
+		{
+			name: "dependency down",
+			report: &Readiness{Status: StatusNotReady, Checks: []DependencyStatus{
+				{Name: "results_dir", Status: DependencyDown, Detail: "not writable"},
+			}},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   `"status":"not_ready","checks":[{"name":"results_dir","status":"down","detail":"not writable"`,
 		},
 	}
 
@@ -194,7 +205,7 @@ func TestServer_HandleReady(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := Config{
 				Logger: logger,
-				Health: &mockHealthService{readyErr: tt.readyErr},
+				Health: &mockHealthService{readyErr: tt.readyErr, report: tt.report},
 			}
 			srv := NewServer(cfg)
 
@@ -417,3 +428,36 @@ func TestRateLimiterMap_GetLimiter(t *testing.T) {
 		t.Error("expected different limiter for different IP")
 	}
 }
+
+func TestServer_Drain(t *testing.T) {
+	srv := NewServer(Config{Jobs: &mockJobService{}, Health: &mockHealthService{}})
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/v1/jobs-stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	srv.Drain()
+	rr := httptest.NewRecorder()
+	srv.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/v1/jobs", strings.NewReader(`{"engagement_id":"eng-1"}`)))
+	if rr.Code != http.StatusServiceUnavailable || rr.Header().Get("Retry-After") == "" || !strings.Contains(rr.Body.String(), ErrShuttingDown.Error()) {
+		t.Errorf("expected new jobs to be refused, got %d %v %s", rr.Code, rr.Header(), rr.Body.String())
+	}
+	rr = httptest.NewRecorder()
+	srv.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/ready", nil))
+	if rr.Code != http.StatusServiceUnavailable || !strings.Contains(rr.Body.String(), "draining") {
+		t.Errorf("expected /ready to report draining, got %d %s", rr.Code, rr.Body.String())
+	}
+
+	srv.CloseStreams()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(body), "event: shutdown\n") {
+		t.Errorf("expected the stream to end with a shutdown event, got %q", body)
+	}
+}
@@ -0,0 +1,335 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Webhook events.
+const (
+	EventRunCompleted = "run.completed"
+	EventRunFailed    = "run.failed"
+)
+
+// Delivery statuses. A delivery that used up its attempts is "dead" and is
+// kept as the dead-letter record.
+const (
+	DeliveryPending   = "pending"
+	DeliveryRetrying  = "retrying"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// Webhook request headers. The signature is the hex HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the endpoint secret.
+const (
+	HeaderWebhookEvent     = "X-Seca-Event"
+	HeaderWebhookDelivery  = "X-Seca-Delivery"
+	HeaderWebhookTimestamp = "X-Seca-Timestamp"
+	HeaderWebhookSignature = "X-Seca-Signature"
+)
+
+const (
+	defaultWebhookAttempts = 5
+	defaultWebhookBackoff  = 2 * time.Second
+	defaultWebhookMaxWait  = 5 * time.Minute
+	defaultWebhookTimeout  = 10 * time.Second
+	maxDeliveryRecords     = 1000
+)
+
+// WebhookEndpoint is a receiver of run results.
+type WebhookEndpoint struct {
+	Name   string
+	URL    string
+	Secret string
+}
+
+// WebhookConfig captures the endpoints and retry policy.
+type WebhookConfig struct {
+	Endpoints      []WebhookEndpoint
+	MaxAttempts    int           // Attempts per delivery before it is dead-lettered (default 5)
+	InitialBackoff time.Duration // Wait before the first retry, doubled each time (default 2s)
+	MaxBackoff     time.Duration // Cap on the wait between retries (default 5m)
+	Timeout        time.Duration // Per-request timeout (default 10s)
+	Logger         *zap.Logger
+}
+
+// Delivery records one event sent to one endpoint.
+type Delivery struct {
+	ID            string     `json:"id"`
+	Event         string     `json:"event"`
+	Endpoint      string     `json:"endpoint"`
+	URL           string     `json:"url"`
+	JobID         string     `json:"job_id,omitempty"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastStatus    int        `json:"last_status_code,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`
+}
+
+// RunEvent is the data of run.completed and run.failed events.
+type RunEvent struct {
+	Job          Job             `json:"job"`
+	EngagementID string          `json:"engagement_id"`
+	Results      json.RawMessage `json:"results,omitempty"`
+	// TargetNames maps scope entries to their display names.
+	TargetNames map[string]string `json:"target_names,omitempty"`
+}
+
+// webhookEnvelope is the body POSTed to every endpoint.
+type webhookEnvelope struct {
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// WebhookDispatcher pushes events to the configured endpoints. Each delivery
+// is retried with exponential backoff in the background; failed deliveries
+// stay listed with status "dead" as a dead-letter record.
+type WebhookDispatcher struct {
+	cfg    WebhookConfig
+	client *http.Client
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu         sync.RWMutex
+	deliveries map[string]*Delivery
+	order      []string // Delivery IDs, oldest first
+}
+
+// NewWebhookDispatcher returns a dispatcher for cfg, applying the default
+// retry policy to unset fields.
+func NewWebhookDispatcher(cfg WebhookConfig) *WebhookDispatcher {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultWebhookAttempts
+	}
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = defaultWebhookBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaultWebhookMaxWait
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultWebhookTimeout
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = zap.NewNop()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &WebhookDispatcher{
+		cfg:        cfg,
+		client:     &http.Client{Timeout: cfg.Timeout},
+		ctx:        ctx,
+		cancel:     cancel,
+		deliveries: make(map[string]*Delivery),
+	}
+}
+
+// Dispatch queues event for every endpoint and returns the new deliveries.
+// jobID, if set, is recorded on each delivery.
+func (d *WebhookDispatcher) Dispatch(event, jobID string, data any) ([]Delivery, error) {
+	body, err := json.Marshal(webhookEnvelope{Event: event, CreatedAt: time.Now().UTC(), Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("encode webhook payload: %w", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.ctx.Err() != nil {
+		return nil, errors.New("webhook dispatcher is closed")
+	}
+	created := make([]Delivery, 0, len(d.cfg.Endpoints))
+	for _, ep := range d.cfg.Endpoints {
+		delivery := &Delivery{
+			ID:        generateID("dlv"),
+			Event:     event,
+			Endpoint:  ep.Name,
+			URL:       ep.URL,
+			JobID:     jobID,
+			Status:    DeliveryPending,
+			CreatedAt: time.Now(),
+		}
+		d.deliveries[delivery.ID] = delivery
+		d.order = append(d.order, delivery.ID)
+		created = append(created, *delivery)
+
+		d.wg.Add(1)
+		go d.deliver(delivery.ID, ep, event, body)
+	}
+	d.trim()
+	return created, nil
+}
+
+// ListDeliveries returns deliveries newest first, optionally only those with
+// the given status.
+func (d *WebhookDispatcher) ListDeliveries(ctx context.Context, limit int, status string) ([]Delivery, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	items := make([]Delivery, 0, len(d.order))
+	for i := len(d.order) - 1; i >= 0; i-- {
+		delivery := d.deliveries[d.order[i]]
+		if status != "" && delivery.Status != status {
+			continue
+		}
+		items = append(items, *delivery)
+		if limit > 0 && len(items) == limit {
+			break
+		}
+	}
+	return items, nil
+}
+
+// Close stops retrying and waits for in-flight requests to end. Deliveries
+// that were still queued are dead-lettered.
+func (d *WebhookDispatcher) Close() {
+	d.mu.Lock()
+	d.cancel()
+	d.mu.Unlock()
+	d.wg.Wait()
+}
+
+func (d *WebhookDispatcher) deliver(id string, ep WebhookEndpoint, event string, body []byte) {
+	defer d.wg.Done()
+	logger := d.cfg.Logger.With(zap.String("delivery_id", id), zap.String("endpoint", ep.Name), zap.String("event", event))
+
+	backoff := d.cfg.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		code, err := d.post(ep, id, event, body)
+		if err == nil {
+			now := time.Now()
+			d.update(id, func(del *Delivery) {
+				del.Status = DeliveryDelivered
+				del.Attempts = attempt
+				del.LastStatus = code
+				del.LastError = ""
+				del.NextAttemptAt = nil
+				del.DeliveredAt = &now
+			})
+			return
+		}
+		if d.ctx.Err() != nil {
+			err = errors.New("server shut down before the delivery succeeded")
+		}
+
+		if attempt >= d.cfg.MaxAttempts || d.ctx.Err() != nil {
+			d.update(id, func(del *Delivery) {
+				del.Status = DeliveryDead
+				del.Attempts = attempt
+				del.LastStatus = code
+				del.LastError = err.Error()
+				del.NextAttemptAt = nil
+			})
+			logger.Warn("webhook_dead_letter", zap.Int("attempts", attempt), zap.Error(err))
+			return
+		}
+
+		next := time.Now().Add(backoff)
+		d.update(id, func(del *Delivery) {
+			del.Status = DeliveryRetrying
+			del.Attempts = attempt
+			del.LastStatus = code
+			del.LastError = err.Error()
+			del.NextAttemptAt = &next
+		})
+		logger.Info("webhook_retry", zap.Int("attempt", attempt), zap.Duration("backoff", backoff), zap.Error(err))
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-d.ctx.Done():
+			timer.Stop()
+			d.update(id, func(del *Delivery) {
+				del.Status = DeliveryDead
+				del.LastError = "server shut down before the delivery succeeded (last error: " + err.Error() + ")"
+				del.NextAttemptAt = nil
+			})
+			return
+		}
+		backoff *= 2
+		if backoff > d.cfg.MaxBackoff {
+			backoff = d.cfg.MaxBackoff
+		}
+	}
+}
+
+// post sends one attempt. Any response other than 2xx is an error.
+func (d *WebhookDispatcher) post(ep WebhookEndpoint, id, event string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, ep.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "seca-webhooks/1")
+	req.Header.Set(HeaderWebhookEvent, event)
+	req.Header.Set(HeaderWebhookDelivery, id)
+	req.Header.Set(HeaderWebhookTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderWebhookSignature, SignWebhook(ep.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint returned %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+func (d *WebhookDispatcher) update(id string, fn func(*Delivery)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if delivery, ok := d.deliveries[id]; ok {
+		fn(delivery)
+	}
+}
+
+// trim drops the oldest finished deliveries beyond maxDeliveryRecords.
+// Callers must hold d.mu.
+func (d *WebhookDispatcher) trim() {
+	excess := len(d.order) - maxDeliveryRecords
+	if excess <= 0 {
+		return
+	}
+	kept := d.order[:0]
+	for _, id := range d.order {
+		status := d.deliveries[id].Status
+		if excess > 0 && (status == DeliveryDelivered || status == DeliveryDead) {
+			delete(d.deliveries, id)
+			excess--
+			continue
+		}
+		kept = append(kept, id)
+	}
+	d.order = kept
+}
+
+// SignWebhook returns the X-Seca-Signature value for body sent at timestamp
+// (Unix seconds). Receivers recompute it with their copy of the secret and
+// compare with hmac.Equal, rejecting stale timestamps to stop replays.
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func waitForDelivery(t *testing.T, d *WebhookDispatcher, status string) Delivery {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		items, _ := d.ListDeliveries(context.Background(), 0, status)
+		if len(items) > 0 {
+			return items[0]
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("no delivery reached status %q", status)
+	return Delivery{}
+}
+
+func TestWebhookDispatcher_SignsAndRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		ts, err := strconv.ParseInt(r.Header.Get(HeaderWebhookTimestamp), 10, 64)
+		if err != nil || r.Header.Get(HeaderWebhookSignature) != SignWebhook("s3cret", ts, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var envelope struct {
+			Event string   `json:"event"`
+			Data  RunEvent `json:"data"`
+		}
+		if err := json.Unmarshal(body, &envelope); err != nil || envelope.Event != EventRunCompleted || envelope.Data.EngagementID != "eng-1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	d := NewWebhookDispatcher(WebhookConfig{
+		Endpoints:      []WebhookEndpoint{{Name: "siem", URL: server.URL, Secret: "s3cret"}},
+		InitialBackoff: time.Millisecond,
+	})
+	defer d.Close()
+
+	created, err := d.Dispatch(EventRunCompleted, "job_1", RunEvent{EngagementID: "eng-1", Results: json.RawMessage(`{"results":[]}`)})
+	if err != nil || len(created) != 1 {
+		t.Fatalf("Dispatch() = %v, %v", created, err)
+	}
+	got := waitForDelivery(t, d, DeliveryDelivered)
+	if got.Attempts != 3 || got.LastStatus != http.StatusNoContent || got.JobID != "job_1" || got.DeliveredAt == nil {
+		t.Errorf("unexpected delivery record: %+v", got)
+	}
+}
+
+func TestWebhookDispatcher_DeadLetter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	d := NewWebhookDispatcher(WebhookConfig{
+		Endpoints:      []WebhookEndpoint{{Name: "siem", URL: server.URL, Secret: "s3cret"}},
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+	})
+	defer d.Close()
+
+	if _, err := d.Dispatch(EventRunFailed, "job_2", RunEvent{EngagementID: "eng-1"}); err != nil {
+		t.Fatal(err)
+	}
+	got := waitForDelivery(t, d, DeliveryDead)
+	if got.Attempts != 3 || calls.Load() != 3 || got.LastStatus != http.StatusInternalServerError || got.LastError == "" {
+		t.Errorf("unexpected dead letter: %+v (calls %d)", got, calls.Load())
+	}
+
+	srv := NewServer(Config{Deliveries: d})
+	rr := httptest.NewRecorder()
+	srv.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/deliveries?status=dead", nil))
+	var listed []Delivery
+	if rr.Code != http.StatusOK || json.Unmarshal(rr.Body.Bytes(), &listed) != nil || len(listed) != 1 || listed[0].ID != got.ID {
+		t.Errorf("GET /api/v1/deliveries = %d %s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	srv.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/deliveries?status=lost", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown status filter, got %d", rr.Code)
+	}
+}
+
+func TestWebhookDispatcher_CloseDeadLettersQueued(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	d := NewWebhookDispatcher(WebhookConfig{
+		Endpoints:      []WebhookEndpoint{{Name: "siem", URL: server.URL, Secret: "s3cret"}},
+		InitialBackoff: time.Hour,
+	})
+	if _, err := d.Dispatch(EventRunCompleted, "", RunEvent{}); err != nil {
+		t.Fatal(err)
+	}
+	waitForDelivery(t, d, DeliveryRetrying)
+	d.Close()
+
+	items, _ := d.ListDeliveries(context.Background(), 0, DeliveryDead)
+	if len(items) != 1 {
+		t.Fatalf("expected the queued delivery to be dead-lettered on close, got %+v", items)
+	}
+	if _, err := d.Dispatch(EventRunCompleted, "", RunEvent{}); err == nil {
+		t.Error("expected Dispatch to fail after Close")
+	}
+}
+
+func TestServer_DeliveriesNotConfigured(t *testing.T) {
+	rr := httptest.NewRecorder()
+	NewServer(Config{}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/deliveries", nil))
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected 404 without webhooks, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// HeaderWorkspace names the workspace that served a response.
+const HeaderWorkspace = "X-Seca-Workspace"
+
+// DefaultWorkspace is the name reported for requests served by the server's
+// own services, authenticated with Config.AuthToken.
+const DefaultWorkspace = "default"
+
+// Workspace is one tenant of the server, such as a consulting team or a
+// client. Requests carrying its API key are served by its own services, so
+// they only see that workspace's engagements, results, jobs, schedules and
+// webhook deliveries.
+type Workspace struct {
+	Name   string
+	APIKey string   // Acts as admin
+	Keys   []APIKey // Further keys with limited roles
+
+	Engagements EngagementService
+	Results     ResultsService
+	Telemetry   TelemetryService
+	Health      HealthService
+	Jobs        JobService
+	Deliveries  DeliveryService
+	Schedules   ScheduleService
+}
+
+type workspaceKey struct{}
+
+// WorkspaceFromContext returns the workspace serving the request.
+func WorkspaceFromContext(ctx context.Context) string {
+	if name, ok := ctx.Value(workspaceKey{}).(string); ok {
+		return name
+	}
+	return DefaultWorkspace
+}
+
+type workspaceRoute struct {
+	name   string
+	keys   []APIKey
+	server *Server
+}
+
+// newWorkspaceRoute returns a route to a server for ws that shares the
+// server-wide settings of parent. Its routes skip the auth check, which
+// withWorkspace has already done by matching the key.
+func newWorkspaceRoute(parent Config, ws Workspace) *workspaceRoute {
+	cfg := parent
+	cfg.Engagements = ws.Engagements
+	cfg.Results = ws.Results
+	cfg.Telemetry = ws.Telemetry
+	cfg.Health = ws.Health
+	cfg.Jobs = ws.Jobs
+	cfg.Deliveries = ws.Deliveries
+	cfg.Schedules = ws.Schedules
+	cfg.AuthToken = ""
+	cfg.APIKeys = nil
+	cfg.Workspaces = nil
+	srv := &Server{cfg: cfg, mux: http.NewServeMux(), streamsDone: make(chan struct{})}
+	srv.routes()
+	keys := append([]APIKey{{Name: "api-key", Key: ws.APIKey, Role: RoleAdmin}}, ws.Keys...)
+	return &workspaceRoute{name: ws.Name, keys: keys, server: srv}
+}
+
+// withWorkspace sends each request to the workspace whose API key it
+// carries. Other requests reach the default services, which still require
+// Config.AuthToken or one of Config.APIKeys; with workspaces configured and
+// no such key there is no default workspace and they are rejected.
+func (s *Server) withWorkspace(next http.Handler) http.Handler {
+	if len(s.workspaces) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("X-Auth-Token")
+		var match *workspaceRoute
+		var role Role
+		for _, ws := range s.workspaces {
+			// Compare against every workspace so the time taken does not
+			// reveal which matched
+			if key, ok := matchKey(token, ws.keys); ok {
+				match, role = ws, key.Role
+			}
+		}
+		if match != nil {
+			w.Header().Set(HeaderWorkspace, match.name)
+			ctx := context.WithValue(r.Context(), workspaceKey{}, match.name)
+			ctx = context.WithValue(ctx, roleKey{}, role)
+			match.server.mux.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+		if s.cfg.AuthToken == "" && len(s.cfg.APIKeys) == 0 {
+			s.writeError(w, r, http.StatusUnauthorized, errors.New("unauthorized"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
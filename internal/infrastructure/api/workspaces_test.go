@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type mockEngagementService struct {
+	items []Engagement
+}
+
+func (m *mockEngagementService) ListEngagements(ctx context.Context) ([]Engagement, error) {
+	return m.items, nil
+}
+
+func (m *mockEngagementService) GetEngagement(ctx context.Context, id string) (*Engagement, error) {
+	for _, e := range m.items {
+		if e.ID == id {
+			return &e, nil
+		}
+	}
+	return nil, errors.New("engagement not found")
+}
+
+func (m *mockEngagementService) CreateEngagement(ctx context.Context, req EngagementCreateRequest) (*Engagement, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestServer_WorkspaceIsolation(t *testing.T) {
+	srv := NewServer(Config{
+		AuthToken:   "admin-token",
+		Engagements: &mockEngagementService{items: []Engagement{{ID: "eng-default"}}},
+		Workspaces: []Workspace{
+			{Name: "team-a", APIKey: "key-a", Engagements: &mockEngagementService{items: []Engagement{{ID: "eng-a"}}}},
+			{Name: "team-b", APIKey: "key-b", Engagements: &mockEngagementService{items: []Engagement{{ID: "eng-b"}}}},
+		},
+	})
+
+	tests := []struct {
+		name          string
+		token         string
+		path          string
+		wantStatus    int
+		wantWorkspace string
+		wantIDs       []string
+	}{
+		{name: "team a lists its engagements", token: "key-a", path: "/api/v1/engagements", wantStatus: http.StatusOK, wantWorkspace: "team-a", wantIDs: []string{"eng-a"}},
+		{name: "team b lists its engagements", token: "key-b", path: "/api/v1/engagements", wantStatus: http.StatusOK, wantWorkspace: "team-b", wantIDs: []string{"eng-b"}},
+		{name: "auth token serves the default workspace", token: "admin-token", path: "/api/v1/engagements", wantStatus: http.StatusOK, wantIDs: []string{"eng-default"}},
+		{name: "team a cannot read team b engagement", token: "key-a", path: "/api/engagements/eng-b", wantStatus: http.StatusNotFound, wantWorkspace: "team-a"},
+		{name: "team b reads its engagement", token: "key-b", path: "/api/engagements/eng-b", wantStatus: http.StatusOK, wantWorkspace: "team-b"},
+		{name: "unknown key", token: "key-c", path: "/api/v1/engagements", wantStatus: http.StatusUnauthorized},
+		{name: "missing key", path: "/api/v1/engagements", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("X-Auth-Token", tt.token)
+			}
+			rr := httptest.NewRecorder()
+			srv.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d (%s)", tt.wantStatus, rr.Code, rr.Body.String())
+			}
+			if got := rr.Header().Get(HeaderWorkspace); got != tt.wantWorkspace {
+				t.Errorf("expected workspace header %q, got %q", tt.wantWorkspace, got)
+			}
+			if tt.wantIDs == nil {
+				return
+			}
+			var items []Engagement
+			if err := json.Unmarshal(rr.Body.Bytes(), &items); err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != len(tt.wantIDs) || items[0].ID != tt.wantIDs[0] {
+				t.Errorf("expected engagements %v, got %+v", tt.wantIDs, items)
+			}
+		})
+	}
+}
+
+func TestServer_WorkspacesWithoutAuthToken(t *testing.T) {
+	srv := NewServer(Config{
+		Health:     &mockHealthService{},
+		Workspaces: []Workspace{{Name: "team-a", APIKey: "key-a", Health: &mockHealthService{}}},
+	})
+
+	rr := httptest.NewRecorder()
+	srv.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/health", nil))
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without a workspace key, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,60 @@
+package chart
+
+import "fmt"
+
+// Bar is a vertical bar chart of labelled counts, such as targets per
+// header grade.
+type Bar struct {
+	Title string
+	Bars  []Slice
+}
+
+const barGap = 0.3 // fraction of each slot left empty between bars
+
+func (b *Bar) yMax() float64 {
+	top := 0.0
+	for _, bar := range b.Bars {
+		top = max(top, bar.Value)
+	}
+	if top == 0 {
+		return 1
+	}
+	return top
+}
+
+// rect returns the pixel bounds of bar i.
+func (b *Bar) rect(i int) (x, y, w, h float64) {
+	plotW := float64(width - padLeft - padRight)
+	plotH := float64(height - padTop - padBottom)
+	slot := plotW / float64(len(b.Bars))
+	w = slot * (1 - barGap)
+	x = float64(padLeft) + slot*float64(i) + (slot-w)/2
+	h = plotH * max(b.Bars[i].Value, 0) / b.yMax()
+	y = float64(height-padBottom) - h
+	return x, y, w, h
+}
+
+// SVG renders the bars with their values above and labels below.
+func (b *Bar) SVG() []byte {
+	s := newSVG(width, height, b.Title)
+	s.line(padLeft, height-padBottom, width-padRight, height-padBottom, axisColor, 1)
+	for i, bar := range b.Bars {
+		x, y, w, h := b.rect(i)
+		fmt.Fprintf(s, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"><title>%s: %g</title></rect>`,
+			num(x), num(y), num(w), num(h), colorOr(bar.Color), esc(bar.Label), bar.Value)
+		s.text(x+w/2, y-4, "middle", 10, fmt.Sprintf("%g", bar.Value))
+		s.text(x+w/2, height-padBottom+16, "middle", 11, bar.Label)
+	}
+	return s.close()
+}
+
+// PNG renders the bars and the baseline without labels.
+func (b *Bar) PNG() ([]byte, error) {
+	c := newCanvas(width, height)
+	c.line(padLeft, height-padBottom, width-padRight, height-padBottom, 1, parseColor(axisColor))
+	for i, bar := range b.Bars {
+		x, y, w, h := b.rect(i)
+		c.fillRect(int(x), int(y), int(x+w), int(y+h), parseColor(colorOr(bar.Color)))
+	}
+	return c.encode()
+}
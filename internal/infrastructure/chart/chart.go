@@ -0,0 +1,164 @@
+// Package chart renders the small charts embedded in reports: a line chart
+// for success-rate trends, a donut for severity distributions, and a bar
+// chart for header grades.
+//
+// Charts are drawn locally with the standard library only. SVG output is
+// self-contained and suitable for inlining into HTML; PNG output carries the
+// same graphics without text (gofpdf cannot place SVG), so PDF reports draw
+// titles and legends themselves.
+package chart
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Point is one value of a line chart.
+type Point struct {
+	Label string
+	Value float64
+}
+
+// Slice is one labelled, coloured value of a donut or bar chart. Color is a
+// #rrggbb hex string.
+type Slice struct {
+	Label string
+	Value float64
+	Color string
+}
+
+// Chart is a chart that can be rendered as SVG or PNG.
+type Chart interface {
+	SVG() []byte
+	PNG() ([]byte, error)
+}
+
+// DefaultColor is used for series and slices without a color.
+const DefaultColor = "#2563eb"
+
+const (
+	width     = 480
+	height    = 220
+	padLeft   = 44
+	padRight  = 16
+	padTop    = 32
+	padBottom = 36
+	gridColor = "#e5e7eb"
+	axisColor = "#9ca3af"
+	textColor = "#374151"
+	fontStyle = `font-family="Helvetica, Arial, sans-serif"`
+)
+
+// svgWriter accumulates SVG markup.
+type svgWriter struct {
+	bytes.Buffer
+}
+
+func newSVG(w, h int, title string) *svgWriter {
+	s := &svgWriter{}
+	fmt.Fprintf(s, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d" role="img" aria-label="%s">`, w, h, w, h, esc(title))
+	fmt.Fprintf(s, `<title>%s</title>`, esc(title))
+	if title != "" {
+		fmt.Fprintf(s, `<text x="%d" y="20" %s font-size="14" font-weight="bold" fill="%s">%s</text>`, padLeft, fontStyle, textColor, esc(title))
+	}
+	return s
+}
+
+func (s *svgWriter) text(x, y float64, anchor string, size int, label string) {
+	fmt.Fprintf(s, `<text x="%s" y="%s" text-anchor="%s" %s font-size="%d" fill="%s">%s</text>`, num(x), num(y), anchor, fontStyle, size, textColor, esc(label))
+}
+
+func (s *svgWriter) line(x1, y1, x2, y2 float64, stroke string, strokeWidth float64) {
+	fmt.Fprintf(s, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s" stroke-width="%s"/>`, num(x1), num(y1), num(x2), num(y2), stroke, num(strokeWidth))
+}
+
+func (s *svgWriter) close() []byte {
+	s.WriteString(`</svg>`)
+	return s.Bytes()
+}
+
+func esc(s string) string {
+	return html.EscapeString(s)
+}
+
+// num formats a coordinate with at most two decimals.
+func num(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+// parseColor turns #rrggbb into a color, falling back to DefaultColor.
+func parseColor(hex string) color.RGBA {
+	hex = strings.TrimPrefix(strings.TrimSpace(hex), "#")
+	if len(hex) != 6 {
+		hex = strings.TrimPrefix(DefaultColor, "#")
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return parseColor(DefaultColor)
+	}
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}
+}
+
+func colorOr(c string) string {
+	if strings.TrimSpace(c) == "" {
+		return DefaultColor
+	}
+	return c
+}
+
+// canvas is a white RGBA image with simple drawing primitives.
+type canvas struct {
+	*image.RGBA
+}
+
+func newCanvas(w, h int) canvas {
+	c := canvas{image.NewRGBA(image.Rect(0, 0, w, h))}
+	c.fillRect(0, 0, w, h, color.RGBA{0xff, 0xff, 0xff, 0xff})
+	return c
+}
+
+func (c canvas) fillRect(x0, y0, x1, y1 int, col color.RGBA) {
+	r := image.Rect(x0, y0, x1, y1).Intersect(c.Bounds())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			c.SetRGBA(x, y, col)
+		}
+	}
+}
+
+// line draws a line of the given thickness by stamping squares along it.
+func (c canvas) line(x0, y0, x1, y1 float64, thickness int, col color.RGBA) {
+	dx, dy := x1-x0, y1-y0
+	steps := int(max(abs(dx), abs(dy)))
+	if steps == 0 {
+		steps = 1
+	}
+	half := thickness / 2
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		x, y := int(x0+dx*t+0.5), int(y0+dy*t+0.5)
+		c.fillRect(x-half, y-half, x-half+thickness, y-half+thickness, col)
+	}
+}
+
+func (c canvas) encode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, c.RGBA); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
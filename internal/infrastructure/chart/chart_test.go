@@ -0,0 +1,78 @@
+package chart
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+)
+
+func charts() map[string]Chart {
+	return map[string]Chart{
+		"line": &Line{Title: "Success <rate>", Max: 100, Unit: "%", Points: []Point{
+			{Label: "05-01", Value: 100}, {Label: "05-02", Value: 80}, {Label: "05-03", Value: 95},
+		}},
+		"donut": &Donut{Title: "Severity", Slices: []Slice{
+			{Label: "Critical", Value: 2, Color: "#b91c1c"}, {Label: "Low", Value: 6}, {Label: "Info", Value: 0},
+		}},
+		"bar": &Bar{Title: "Grades", Bars: []Slice{{Label: "A", Value: 3}, {Label: "F", Value: 1, Color: "#b91c1c"}}},
+	}
+}
+
+func TestChartsRenderWellFormedSVG(t *testing.T) {
+	for name, c := range charts() {
+		svg := c.SVG()
+		dec := xml.NewDecoder(bytes.NewReader(svg))
+		for {
+			_, err := dec.Token()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("%s: invalid SVG: %v\n%s", name, err, svg)
+			}
+		}
+		if !bytes.HasPrefix(svg, []byte("<svg ")) {
+			t.Errorf("%s: expected an <svg> root element", name)
+		}
+	}
+}
+
+func TestChartsRenderPNG(t *testing.T) {
+	for name, c := range charts() {
+		data, err := c.PNG()
+		if err != nil {
+			t.Fatalf("%s: PNG: %v", name, err)
+		}
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: decode PNG: %v", name, err)
+		}
+		if img.Bounds().Dx() == 0 {
+			t.Errorf("%s: empty image", name)
+		}
+	}
+}
+
+func TestDonutPNGUsesSliceColors(t *testing.T) {
+	d := &Donut{Slices: []Slice{{Label: "Critical", Value: 1, Color: "#b91c1c"}}}
+	data, err := d.PNG()
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, _ := png.Decode(bytes.NewReader(data))
+	// A point on the ring straight above the centre.
+	r, g, b, _ := img.At(donutCenter, donutCenter-int((donutOuter+donutInner)/2)).RGBA()
+	if r>>8 != 0xb9 || g>>8 != 0x1c || b>>8 != 0x1c {
+		t.Fatalf("expected the slice color on the ring, got %02x%02x%02x", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestSVGEscapesLabels(t *testing.T) {
+	svg := string((&Line{Title: "a<b", Points: []Point{{Label: `x"y`, Value: 1}}}).SVG())
+	if strings.Contains(svg, "a<b") || !strings.Contains(svg, "a&lt;b") {
+		t.Fatalf("expected escaped title, got %s", svg)
+	}
+}
@@ -0,0 +1,120 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+)
+
+// Donut is a ring chart of parts of a whole, such as findings by severity.
+type Donut struct {
+	Title  string
+	Slices []Slice
+}
+
+const (
+	donutSize   = 220
+	donutWidth  = 420
+	donutOuter  = 80.0
+	donutInner  = 48.0
+	donutCenter = donutSize / 2
+)
+
+func (d *Donut) total() float64 {
+	total := 0.0
+	for _, s := range d.Slices {
+		total += max(s.Value, 0)
+	}
+	return total
+}
+
+// SVG renders the ring with the total in the middle and a legend.
+func (d *Donut) SVG() []byte {
+	s := newSVG(donutWidth, donutSize, d.Title)
+	total := d.total()
+	cx, cy := float64(donutCenter), float64(donutCenter)+8
+
+	if total == 0 {
+		fmt.Fprintf(s, `<circle cx="%s" cy="%s" r="%s" fill="none" stroke="%s" stroke-width="%s"/>`,
+			num(cx), num(cy), num((donutOuter+donutInner)/2), gridColor, num(donutOuter-donutInner))
+	}
+	start := -math.Pi / 2
+	for _, slice := range d.Slices {
+		if slice.Value <= 0 || total == 0 {
+			continue
+		}
+		sweep := 2 * math.Pi * slice.Value / total
+		fmt.Fprintf(s, `<path d="%s" fill="%s"><title>%s: %g</title></path>`,
+			arcPath(cx, cy, start, sweep), colorOr(slice.Color), esc(slice.Label), slice.Value)
+		start += sweep
+	}
+	s.text(cx, cy+6, "middle", 18, fmt.Sprintf("%g", total))
+
+	y := 56.0
+	for _, slice := range d.Slices {
+		fmt.Fprintf(s, `<rect x="%d" y="%s" width="12" height="12" fill="%s"/>`, donutSize+10, num(y-10), colorOr(slice.Color))
+		s.text(donutSize+28, y, "start", 12, fmt.Sprintf("%s: %g", slice.Label, slice.Value))
+		y += 22
+	}
+	return s.close()
+}
+
+// arcPath returns a closed path for a ring segment. A full circle is drawn
+// as two halves, since a single SVG arc cannot start and end at one point.
+func arcPath(cx, cy, start, sweep float64) string {
+	if sweep >= 2*math.Pi-1e-9 {
+		return arcPath(cx, cy, start, math.Pi) + " " + arcPath(cx, cy, start+math.Pi, math.Pi)
+	}
+	end := start + sweep
+	large := 0
+	if sweep > math.Pi {
+		large = 1
+	}
+	pt := func(r, a float64) string {
+		return num(cx+r*math.Cos(a)) + " " + num(cy+r*math.Sin(a))
+	}
+	return fmt.Sprintf("M %s A %g %g 0 %d 1 %s L %s A %g %g 0 %d 0 %s Z",
+		pt(donutOuter, start), donutOuter, donutOuter, large, pt(donutOuter, end),
+		pt(donutInner, end), donutInner, donutInner, large, pt(donutInner, start))
+}
+
+// PNG renders the ring only; the legend is left to the caller.
+func (d *Donut) PNG() ([]byte, error) {
+	c := newCanvas(donutSize, donutSize)
+	total := d.total()
+	cx, cy := float64(donutCenter), float64(donutCenter)
+
+	// Cumulative end angle of each slice, clockwise from 12 o'clock.
+	ends := make([]float64, len(d.Slices))
+	acc := 0.0
+	for i, slice := range d.Slices {
+		if total > 0 {
+			acc += max(slice.Value, 0) / total * 2 * math.Pi
+		}
+		ends[i] = acc
+	}
+	empty := parseColor(gridColor)
+	for y := 0; y < donutSize; y++ {
+		for x := 0; x < donutSize; x++ {
+			dx, dy := float64(x)+0.5-cx, float64(y)+0.5-cy
+			r := math.Hypot(dx, dy)
+			if r < donutInner || r > donutOuter {
+				continue
+			}
+			if total == 0 {
+				c.SetRGBA(x, y, empty)
+				continue
+			}
+			angle := math.Atan2(dy, dx) + math.Pi/2
+			if angle < 0 {
+				angle += 2 * math.Pi
+			}
+			for i, end := range ends {
+				if angle <= end && d.Slices[i].Value > 0 {
+					c.SetRGBA(x, y, parseColor(colorOr(d.Slices[i].Color)))
+					break
+				}
+			}
+		}
+	}
+	return c.encode()
+}
@@ -0,0 +1,110 @@
+package chart
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Line is a line chart of values over an ordered series, such as success
+// rates of successive runs.
+type Line struct {
+	Title  string
+	Points []Point
+	Max    float64 // top of the Y axis; the largest value when zero
+	Unit   string  // suffix for Y axis labels, e.g. "%"
+	Color  string
+}
+
+// maxXLabels caps the X axis labels so they do not overlap.
+const maxXLabels = 6
+
+func (l *Line) yMax() float64 {
+	if l.Max > 0 {
+		return l.Max
+	}
+	top := 0.0
+	for _, p := range l.Points {
+		top = max(top, p.Value)
+	}
+	if top == 0 {
+		return 1
+	}
+	return top
+}
+
+// coords returns the pixel position of every point in the plot area.
+func (l *Line) coords() (xs, ys []float64) {
+	plotW := float64(width - padLeft - padRight)
+	plotH := float64(height - padTop - padBottom)
+	top := l.yMax()
+	for i, p := range l.Points {
+		x := float64(padLeft) + plotW/2
+		if len(l.Points) > 1 {
+			x = float64(padLeft) + plotW*float64(i)/float64(len(l.Points)-1)
+		}
+		v := min(max(p.Value, 0), top)
+		xs = append(xs, x)
+		ys = append(ys, float64(padTop)+plotH*(1-v/top))
+	}
+	return xs, ys
+}
+
+// SVG renders the chart with grid lines, axis labels, and point markers.
+func (l *Line) SVG() []byte {
+	s := newSVG(width, height, l.Title)
+	top := l.yMax()
+	plotH := float64(height - padTop - padBottom)
+	for _, frac := range []float64{0, 0.5, 1} {
+		y := float64(padTop) + plotH*(1-frac)
+		s.line(padLeft, y, width-padRight, y, gridColor, 1)
+		s.text(padLeft-6, y+4, "end", 10, fmt.Sprintf("%g%s", roundLabel(top*frac), l.Unit))
+	}
+
+	xs, ys := l.coords()
+	stroke := colorOr(l.Color)
+	if len(xs) > 0 {
+		pts := make([]string, len(xs))
+		for i := range xs {
+			pts[i] = num(xs[i]) + "," + num(ys[i])
+		}
+		fmt.Fprintf(s, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`, strings.Join(pts, " "), stroke)
+		for i := range xs {
+			fmt.Fprintf(s, `<circle cx="%s" cy="%s" r="3" fill="%s"><title>%s: %g%s</title></circle>`,
+				num(xs[i]), num(ys[i]), stroke, esc(l.Points[i].Label), roundLabel(l.Points[i].Value), esc(l.Unit))
+		}
+	}
+	step := max(1, (len(l.Points)+maxXLabels-1)/maxXLabels)
+	for i := 0; i < len(l.Points); i += step {
+		s.text(xs[i], height-padBottom+16, "middle", 10, l.Points[i].Label)
+	}
+	return s.close()
+}
+
+// PNG renders the grid and the line without labels.
+func (l *Line) PNG() ([]byte, error) {
+	c := newCanvas(width, height)
+	plotH := float64(height - padTop - padBottom)
+	for _, frac := range []float64{0, 0.5, 1} {
+		y := float64(padTop) + plotH*(1-frac)
+		c.line(padLeft, y, width-padRight, y, 1, parseColor(gridColor))
+	}
+	c.line(padLeft, padTop, padLeft, float64(height-padBottom), 1, parseColor(axisColor))
+
+	xs, ys := l.coords()
+	col := parseColor(colorOr(l.Color))
+	for i := 1; i < len(xs); i++ {
+		c.line(xs[i-1], ys[i-1], xs[i], ys[i], 2, col)
+	}
+	for i := range xs {
+		c.line(xs[i], ys[i], xs[i], ys[i], 6, col)
+	}
+	return c.encode()
+}
+
+// roundLabel keeps axis and tooltip values short.
+func roundLabel(v float64) float64 {
+	if v >= 10 {
+		return float64(int(v + 0.5))
+	}
+	return float64(int(v*10+0.5)) / 10
+}
@@ -0,0 +1,319 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Admin panel kinds.
+const (
+	AdminPanelKindAdmin       = "admin"        // Application or server administration UI
+	AdminPanelKindDevice      = "device"       // Router, firewall or appliance management UI
+	AdminPanelKindDefaultPage = "default_page" // Unconfigured web server welcome page
+)
+
+// AdminPanelFinding is an administration interface reachable from the
+// scanner. It records only what the unauthenticated response revealed; no
+// credentials are ever submitted.
+type AdminPanelFinding struct {
+	Provider           string `json:"provider"` // e.g., "Apache Tomcat Manager", "phpMyAdmin"
+	Kind               string `json:"kind"`     // "admin", "device", "default_page"
+	URL                string `json:"url"`
+	HTTPStatusCode     int    `json:"http_status_code"`
+	Fingerprint        string `json:"fingerprint"`
+	Confidence         string `json:"confidence"` // "high", "medium"
+	DefaultCredentials bool   `json:"default_credentials,omitempty"`
+}
+
+// adminPanelFingerprint identifies a product by the paths it serves and the
+// markers in its response body or headers.
+type adminPanelFingerprint struct {
+	Kind               string
+	Paths              []string
+	Patterns           []string
+	DefaultCredentials bool // Ships with well-known credentials
+}
+
+// getAdminPanelFingerprints returns known admin interface fingerprints.
+// Patterns are matched case-insensitively against the body and the
+// "Name: value" header lines.
+func getAdminPanelFingerprints() map[string]adminPanelFingerprint {
+	return map[string]adminPanelFingerprint{
+		"Apache Tomcat Manager": {
+			Kind:               AdminPanelKindAdmin,
+			Paths:              []string{"/manager/html", "/host-manager/html"},
+			Patterns:           []string{"Tomcat Manager Application", "Tomcat Host Manager Application", "Tomcat Web Application Manager"},
+			DefaultCredentials: true,
+		},
+		"phpMyAdmin": {
+			Kind:               AdminPanelKindAdmin,
+			Paths:              []string{"/phpmyadmin/", "/phpMyAdmin/", "/pma/"},
+			Patterns:           []string{"<title>phpMyAdmin", "pma_username", "phpmyadmin.css"},
+			DefaultCredentials: true,
+		},
+		"Adminer": {
+			Kind:     AdminPanelKindAdmin,
+			Paths:    []string{"/adminer.php"},
+			Patterns: []string{"<title>Login - Adminer", "adminer.org"},
+		},
+		"JBoss/WildFly Console": {
+			Kind:               AdminPanelKindAdmin,
+			Paths:              []string{"/jmx-console/", "/admin-console/", "/console/"},
+			Patterns:           []string{"<title>JBoss", "<title>WildFly", "HAL Management Console", "JMX Agent View"},
+			DefaultCredentials: true,
+		},
+		"Jenkins": {
+			Kind:     AdminPanelKindAdmin,
+			Paths:    []string{"/login", "/jenkins/login"},
+			Patterns: []string{"X-Jenkins:", "<title>Sign in [Jenkins]"},
+		},
+		"Grafana": {
+			Kind:               AdminPanelKindAdmin,
+			Paths:              []string{"/login", "/grafana/login"},
+			Patterns:           []string{"<title>Grafana</title>", "grafana-app"},
+			DefaultCredentials: true,
+		},
+		"Kibana": {
+			Kind:     AdminPanelKindAdmin,
+			Paths:    []string{"/app/kibana", "/login"},
+			Patterns: []string{"Kbn-Name:", "kbn-injected-metadata"},
+		},
+		"WordPress Admin": {
+			Kind:     AdminPanelKindAdmin,
+			Paths:    []string{"/wp-login.php"},
+			Patterns: []string{"wp-submit", "wp-login.php?action=lostpassword"},
+		},
+		"Joomla Administrator": {
+			Kind:     AdminPanelKindAdmin,
+			Paths:    []string{"/administrator/"},
+			Patterns: []string{"Joomla", "mod-login-username"},
+		},
+		"MikroTik RouterOS": {
+			Kind:               AdminPanelKindDevice,
+			Paths:              []string{"/", "/webfig/"},
+			Patterns:           []string{"RouterOS router configuration page", "<title>RouterOS"},
+			DefaultCredentials: true,
+		},
+		"pfSense": {
+			Kind:               AdminPanelKindDevice,
+			Paths:              []string{"/"},
+			Patterns:           []string{"Login to pfSense", "<title>pfSense"},
+			DefaultCredentials: true,
+		},
+		"Ubiquiti UniFi": {
+			Kind:     AdminPanelKindDevice,
+			Paths:    []string{"/manage/account/login"},
+			Patterns: []string{"UniFi Network", "<title>UniFi"},
+		},
+		"TP-Link": {
+			Kind:               AdminPanelKindDevice,
+			Paths:              []string{"/"},
+			Patterns:           []string{"<title>TP-LINK", "tplinkwifi.net"},
+			DefaultCredentials: true,
+		},
+		"Hikvision": {
+			Kind:               AdminPanelKindDevice,
+			Paths:              []string{"/doc/page/login.asp"},
+			Patterns:           []string{"<title>Hikvision", "webComponents/plugin"},
+			DefaultCredentials: true,
+		},
+		"nginx Default Page": {
+			Kind:     AdminPanelKindDefaultPage,
+			Paths:    []string{"/"},
+			Patterns: []string{"<title>Welcome to nginx!</title>"},
+		},
+		"Apache Default Page": {
+			Kind:     AdminPanelKindDefaultPage,
+			Paths:    []string{"/"},
+			Patterns: []string{"Apache2 Ubuntu Default Page", "Apache2 Debian Default Page", "Test Page for the Apache HTTP Server"},
+		},
+		"IIS Default Page": {
+			Kind:     AdminPanelKindDefaultPage,
+			Paths:    []string{"/"},
+			Patterns: []string{"<title>IIS Windows Server</title>", "iisstart.png"},
+		},
+	}
+}
+
+// genericAdminPaths are checked for a login form when no product matches.
+var genericAdminPaths = []string{"/admin/", "/admin/login", "/administrator/", "/manage/", "/console/"}
+
+// ProbeAdminPanels requests the fingerprint paths on the target's origin
+// and reports admin interfaces that answer 200 or 401. Each origin is probed
+// once per run. No form is submitted and no credentials are tried.
+func (p *ExposureProbe) ProbeAdminPanels(ctx context.Context, client *http.Client, target string) []AdminPanelFinding {
+	u, err := url.Parse(ParseTarget(target).FullURL)
+	if err != nil || u.Host == "" {
+		return nil
+	}
+	origin := &url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/"}
+	if !p.claim("admin " + origin.String()) {
+		return nil
+	}
+
+	fingerprints := getAdminPanelFingerprints()
+	providers := make([]string, 0, len(fingerprints))
+	for provider := range fingerprints {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+
+	responses := make(map[string]*exposureResponse)
+	get := func(path string) *exposureResponse {
+		if resp, ok := responses[path]; ok {
+			return resp
+		}
+		resp, _ := p.fetch(ctx, client, origin, strings.TrimPrefix(path, "/"))
+		responses[path] = resp
+		return resp
+	}
+
+	var findings []AdminPanelFinding
+	found := make(map[string]bool)
+	for _, provider := range providers {
+		fp := fingerprints[provider]
+		for _, path := range fp.Paths {
+			resp := get(path)
+			if resp == nil || !adminPanelReachable(resp.status) {
+				continue
+			}
+			if pattern := matchAdminPanel(resp, fp.Patterns); pattern != "" {
+				findings = append(findings, AdminPanelFinding{
+					Provider:           provider,
+					Kind:               fp.Kind,
+					URL:                resp.url,
+					HTTPStatusCode:     resp.status,
+					Fingerprint:        pattern,
+					Confidence:         "high",
+					DefaultCredentials: fp.DefaultCredentials,
+				})
+				found[path] = true
+				break
+			}
+		}
+	}
+
+	// A login form on a conventional admin path is reported without a
+	// product name, unless the server answers 200 for any path.
+	if soft := get("/seca-" + randomToken()); soft != nil && soft.status == http.StatusOK {
+		return findings
+	}
+	for _, path := range genericAdminPaths {
+		if found[path] {
+			continue
+		}
+		resp := get(path)
+		if resp == nil || !adminPanelReachable(resp.status) {
+			continue
+		}
+		fingerprint := ""
+		switch {
+		case resp.status == http.StatusUnauthorized && resp.header.Get("WWW-Authenticate") != "":
+			fingerprint = "WWW-Authenticate: " + resp.header.Get("WWW-Authenticate")
+		case strings.Contains(strings.ToLower(resp.body), `type="password"`):
+			fingerprint = "password field"
+		default:
+			continue
+		}
+		findings = append(findings, AdminPanelFinding{
+			Provider:       "Unidentified admin panel",
+			Kind:           AdminPanelKindAdmin,
+			URL:            resp.url,
+			HTTPStatusCode: resp.status,
+			Fingerprint:    fingerprint,
+			Confidence:     "medium",
+		})
+		break
+	}
+	return findings
+}
+
+// adminPanelReachable reports whether status means the interface is served:
+// a page (200) or an authentication challenge (401). 403 and redirects are
+// treated as access control working.
+func adminPanelReachable(status int) bool {
+	return status == http.StatusOK || status == http.StatusUnauthorized
+}
+
+// matchAdminPanel returns the first pattern found in resp, or "".
+func matchAdminPanel(resp *exposureResponse, patterns []string) string {
+	var headers strings.Builder
+	for name, values := range resp.header {
+		for _, value := range values {
+			fmt.Fprintf(&headers, "%s: %s\n", name, value)
+		}
+	}
+	haystack := strings.ToLower(resp.body + "\n" + headers.String())
+	for _, pattern := range patterns {
+		if strings.Contains(haystack, strings.ToLower(pattern)) {
+			return pattern
+		}
+	}
+	return ""
+}
+
+// analyzeAdminPanels converts admin panel findings into one vulnerability
+// per provider.
+func analyzeAdminPanels(panels []AdminPanelFinding) []Vulnerability {
+	var vulns []Vulnerability
+	for _, panel := range panels {
+		severity, status, cvssScore := "Medium", "Warning", 5.3
+		vector := "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:L/I:N/A:N"
+		description := fmt.Sprintf("The %s administration interface is reachable at %s (HTTP %d, matched %q).",
+			panel.Provider, panel.URL, panel.HTTPStatusCode, panel.Fingerprint)
+		switch {
+		case panel.Kind == AdminPanelKindDefaultPage:
+			severity, status, cvssScore = "Low", "Warning", 3.7
+			vector = "CVSS:3.1/AV:N/AC:H/PR:N/UI:N/S:U/C:L/I:N/A:N"
+			description = fmt.Sprintf("%s is served at %s, indicating an unconfigured or forgotten server.", panel.Provider, panel.URL)
+		case panel.DefaultCredentials:
+			severity, status, cvssScore = "High", "Failed", 8.1
+			vector = "CVSS:3.1/AV:N/AC:H/PR:N/UI:N/S:U/C:H/I:H/A:H"
+			description += " This product ships with well-known default credentials; they were not tested."
+		}
+
+		vulns = append(vulns, Vulnerability{
+			Name:        "Exposed Admin Interface: " + panel.Provider,
+			Category:    "Network Security",
+			Severity:    severity,
+			Score:       0,
+			MaxScore:    10,
+			Status:      status,
+			Description: description,
+			Recommendation: fmt.Sprintf(`%s: Administration interface exposed.
+
+Detected:
+• Provider: %s
+• URL: %s
+• HTTP Status: %d
+• Fingerprint: %s
+• Confidence: %s
+
+Recommended Actions:
+1. Restrict the interface to a management network, VPN or IP allowlist
+2. Confirm default accounts are disabled or their passwords changed
+3. Require multi-factor authentication where the product supports it
+4. Remove the interface or default page if it is not needed
+
+Verification:
+Request %s from an external network and confirm it returns 403/404 or times out.`,
+				strings.ToUpper(severity), panel.Provider, panel.URL, panel.HTTPStatusCode,
+				panel.Fingerprint, panel.Confidence, panel.URL),
+			CVSS: &CVSSScore{
+				BaseScore: cvssScore,
+				Severity:  strings.ToUpper(severity),
+				Vector:    vector,
+				Version:   "3.1",
+			},
+			References: []string{
+				"https://owasp.org/www-project-web-security-testing-guide/latest/4-Web_Application_Security_Testing/02-Configuration_and_Deployment_Management_Testing/05-Enumerate_Infrastructure_and_Application_Admin_Interfaces",
+				"https://cwe.mitre.org/data/definitions/1392.html",
+			},
+		})
+	}
+	return vulns
+}
@@ -0,0 +1,86 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestProbeAdminPanels_IdentifiesProviders(t *testing.T) {
+	var posts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			posts.Add(1)
+		}
+		switch r.URL.Path {
+		case "/manager/html":
+			w.Header().Set("WWW-Authenticate", `Basic realm="Tomcat Manager Application"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/phpmyadmin/":
+			_, _ = w.Write([]byte(`<html><title>phpMyAdmin</title><input name="pma_username"></html>`))
+		case "/pma/":
+			// Access control in place: not reported.
+			w.WriteHeader(http.StatusForbidden)
+		case "/admin/":
+			_, _ = w.Write([]byte(`<form><input type="password" name="pw"></form>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	probe := &ExposureProbe{}
+	panels := probe.ProbeAdminPanels(context.Background(), server.Client(), server.URL+"/some/page")
+
+	byProvider := make(map[string]AdminPanelFinding)
+	for _, panel := range panels {
+		byProvider[panel.Provider] = panel
+	}
+	if tomcat, ok := byProvider["Apache Tomcat Manager"]; !ok || tomcat.HTTPStatusCode != http.StatusUnauthorized || !tomcat.DefaultCredentials {
+		t.Errorf("expected Tomcat Manager behind a 401 challenge, got %+v", panels)
+	}
+	if _, ok := byProvider["phpMyAdmin"]; !ok {
+		t.Errorf("expected phpMyAdmin, got %+v", panels)
+	}
+	if generic, ok := byProvider["Unidentified admin panel"]; !ok || generic.Confidence != "medium" {
+		t.Errorf("expected a generic login form at /admin/, got %+v", panels)
+	}
+	if len(panels) != 3 {
+		t.Errorf("expected exactly three panels, got %+v", panels)
+	}
+	if got := posts.Load(); got != 0 {
+		t.Fatalf("probe must only send GET requests, saw %d others", got)
+	}
+
+	if again := probe.ProbeAdminPanels(context.Background(), server.Client(), server.URL+"/other"); len(again) != 0 {
+		t.Fatalf("origin should be probed once per run, got %+v", again)
+	}
+
+	vulns := analyzeAdminPanels(panels)
+	for _, v := range vulns {
+		if v.Name == "Exposed Admin Interface: Apache Tomcat Manager" && v.Severity != "High" {
+			t.Errorf("default-credential products should be High, got %s", v.Severity)
+		}
+		if v.Name == "Exposed Admin Interface: Unidentified admin panel" && v.Severity != "Medium" {
+			t.Errorf("generic panels should be Medium, got %s", v.Severity)
+		}
+	}
+}
+
+func TestProbeAdminPanels_SoftNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html><title>Welcome to nginx!</title><input type="password"></html>`))
+	}))
+	defer server.Close()
+
+	panels := (&ExposureProbe{}).ProbeAdminPanels(context.Background(), server.Client(), server.URL)
+
+	if len(panels) != 1 || panels[0].Provider != "nginx Default Page" || panels[0].Kind != AdminPanelKindDefaultPage {
+		t.Fatalf("catch-all server should only match its fingerprint, got %+v", panels)
+	}
+	if vulns := analyzeAdminPanels(panels); vulns[0].Severity != "Low" {
+		t.Fatalf("default pages should be Low, got %+v", vulns[0])
+	}
+}
@@ -0,0 +1,186 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrBudgetExceeded is returned by budgeted transports once a run budget has
+// been spent. Check errors wrap it so callers can tell a budget stop apart
+// from a target failure.
+var ErrBudgetExceeded = errors.New("run budget exceeded")
+
+// Budget caps the traffic a single run may generate. Zero limits are
+// unlimited. Requests and bytes are counted for HTTP traffic sent through
+// Wrap (checks, crawling, banner fingerprinting); MaxDuration applies to the
+// whole run, and contexts from WithDeadline are canceled when it runs out.
+// A nil *Budget imposes no limits.
+type Budget struct {
+	MaxRequests int64         // Total HTTP requests
+	MaxBytes    int64         // Total response body bytes downloaded
+	MaxDuration time.Duration // Wall-clock time from Start
+
+	mu       sync.Mutex
+	started  time.Time
+	requests int64
+	bytes    int64
+	reason   string
+}
+
+// BudgetUsage reports what a run consumed against its budget.
+type BudgetUsage struct {
+	Requests int64         `json:"requests"`
+	Bytes    int64         `json:"bytes"`
+	Elapsed  time.Duration `json:"elapsed"`
+}
+
+// Start begins the wall-clock budget. Calling it again has no effect.
+func (b *Budget) Start() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.started.IsZero() {
+		b.started = time.Now()
+	}
+}
+
+// Deadline returns when MaxDuration runs out. ok is false when the budget
+// has no MaxDuration or has not been started.
+func (b *Budget) Deadline() (deadline time.Time, ok bool) {
+	if b == nil || b.MaxDuration <= 0 {
+		return time.Time{}, false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.started.IsZero() {
+		return time.Time{}, false
+	}
+	return b.started.Add(b.MaxDuration), true
+}
+
+// WithDeadline returns a copy of ctx that is canceled when MaxDuration runs
+// out, so work in flight stops with the run instead of outliving it. Without
+// a deadline the copy is only canceled by cancel or its parent.
+func (b *Budget) WithDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if deadline, ok := b.Deadline(); ok {
+		return context.WithDeadline(ctx, deadline)
+	}
+	return context.WithCancel(ctx)
+}
+
+// Exceeded returns why the budget is spent, or "" while it still has room.
+func (b *Budget) Exceeded() string {
+	if b == nil {
+		return ""
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.checkLocked()
+}
+
+func (b *Budget) checkLocked() string {
+	if b.reason != "" {
+		return b.reason
+	}
+	if b.MaxDuration > 0 && !b.started.IsZero() && time.Since(b.started) >= b.MaxDuration {
+		b.reason = fmt.Sprintf("max duration of %s reached", b.MaxDuration)
+	}
+	return b.reason
+}
+
+// Usage returns the requests, bytes, and time consumed so far.
+func (b *Budget) Usage() BudgetUsage {
+	if b == nil {
+		return BudgetUsage{}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	usage := BudgetUsage{Requests: b.requests, Bytes: b.bytes}
+	if !b.started.IsZero() {
+		usage.Elapsed = time.Since(b.started)
+	}
+	return usage
+}
+
+// reserveRequest counts one request, refusing it once the budget is spent.
+func (b *Budget) reserveRequest() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if reason := b.checkLocked(); reason != "" {
+		return fmt.Errorf("%w: %s", ErrBudgetExceeded, reason)
+	}
+	if b.MaxRequests > 0 && b.requests >= b.MaxRequests {
+		b.reason = fmt.Sprintf("max requests of %d reached", b.MaxRequests)
+		return fmt.Errorf("%w: %s", ErrBudgetExceeded, b.reason)
+	}
+	b.requests++
+	return nil
+}
+
+// addBytes counts downloaded bytes and reports whether the byte budget is
+// now spent.
+func (b *Budget) addBytes(n int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bytes += int64(n)
+	if b.MaxBytes > 0 && b.bytes > b.MaxBytes {
+		if b.reason == "" {
+			b.reason = fmt.Sprintf("max download of %d bytes reached", b.MaxBytes)
+		}
+		return fmt.Errorf("%w: %s", ErrBudgetExceeded, b.reason)
+	}
+	return nil
+}
+
+// Wrap returns a transport that counts requests and response bytes against
+// the budget and refuses further requests once it is spent.
+func (b *Budget) Wrap(rt http.RoundTripper) http.RoundTripper {
+	if b == nil {
+		return rt
+	}
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &budgetTransport{base: rt, budget: b}
+}
+
+type budgetTransport struct {
+	base   http.RoundTripper
+	budget *Budget
+}
+
+func (t *budgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.budget.reserveRequest(); err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		return resp, err
+	}
+	resp.Body = &budgetBody{ReadCloser: resp.Body, budget: t.budget}
+	return resp, nil
+}
+
+// budgetBody stops a download as soon as it pushes the run over its byte
+// budget.
+type budgetBody struct {
+	io.ReadCloser
+	budget *Budget
+}
+
+func (b *budgetBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		if budgetErr := b.budget.addBytes(n); budgetErr != nil {
+			return n, budgetErr
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,179 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBudgetRequestLimit(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	budget := &Budget{MaxRequests: 2}
+	client := &http.Client{Transport: budget.Wrap(nil)}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+	if reason := budget.Exceeded(); reason != "" {
+		t.Fatalf("budget should not be spent before a refused request, got %q", reason)
+	}
+
+	if _, err := client.Get(server.URL); !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("expected ErrBudgetExceeded, got %v", err)
+	}
+	if hits.Load() != 2 {
+		t.Fatalf("expected refused request not to reach the server, got %d hits", hits.Load())
+	}
+	if reason := budget.Exceeded(); !strings.Contains(reason, "max requests of 2") {
+		t.Fatalf("unexpected reason %q", reason)
+	}
+	if usage := budget.Usage(); usage.Requests != 2 || usage.Bytes != 4 {
+		t.Fatalf("unexpected usage %+v", usage)
+	}
+}
+
+func TestBudgetByteLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, strings.Repeat("x", 4096))
+	}))
+	defer server.Close()
+
+	budget := &Budget{MaxBytes: 1024}
+	client := &http.Client{Transport: budget.Wrap(nil)}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	_, err = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("expected body read to stop with ErrBudgetExceeded, got %v", err)
+	}
+	if reason := budget.Exceeded(); !strings.Contains(reason, "1024 bytes") {
+		t.Fatalf("unexpected reason %q", reason)
+	}
+	if _, err := client.Get(server.URL); !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("expected further requests to be refused, got %v", err)
+	}
+}
+
+func TestBudgetDurationLimit(t *testing.T) {
+	budget := &Budget{MaxDuration: 10 * time.Millisecond}
+	if reason := budget.Exceeded(); reason != "" {
+		t.Fatalf("budget should not expire before Start, got %q", reason)
+	}
+	budget.Start()
+	time.Sleep(20 * time.Millisecond)
+	if reason := budget.Exceeded(); !strings.Contains(reason, "max duration") {
+		t.Fatalf("unexpected reason %q", reason)
+	}
+}
+
+func TestNilBudget(t *testing.T) {
+	var budget *Budget
+	budget.Start()
+	if budget.Exceeded() != "" || budget.Usage() != (BudgetUsage{}) {
+		t.Fatal("nil budget should impose no limits")
+	}
+	if rt := budget.Wrap(http.DefaultTransport); rt != http.DefaultTransport {
+		t.Fatal("nil budget should return the transport unchanged")
+	}
+}
+
+type countingChecker struct {
+	budget *Budget
+	calls  atomic.Int32
+}
+
+func (c *countingChecker) Name() string { return "counting" }
+
+func (c *countingChecker) Check(ctx context.Context, target string) CheckResult {
+	c.calls.Add(1)
+	// Each target spends one request of the budget.
+	_ = c.budget.reserveRequest()
+	return CheckResult{Target: target, Status: "ok"}
+}
+
+func TestRunnerStopsWhenBudgetSpent(t *testing.T) {
+	budget := &Budget{MaxRequests: 2}
+	c := &countingChecker{budget: budget}
+	runner := &Runner{Concurrency: 1, RateLimit: 100, Timeout: time.Second, Budget: budget}
+
+	targets := []string{"a", "b", "c", "d", "e"}
+	results := runner.RunChecks(context.Background(), targets, c, nil)
+
+	// The third target spends the budget; the rest are never started and
+	// are recorded as skipped.
+	if got := c.calls.Load(); got != 3 {
+		t.Fatalf("expected 3 checks to start, got %d", got)
+	}
+	if len(results) != 5 {
+		t.Fatalf("expected a result for every target, got %d", len(results))
+	}
+	skipped := 0
+	for _, r := range results {
+		if r.Status == StatusSkipped {
+			skipped++
+			if !strings.HasPrefix(r.Error, "budget: ") {
+				t.Errorf("skipped result should give the budget stop reason, got %q", r.Error)
+			}
+		}
+	}
+	if skipped != 2 {
+		t.Fatalf("expected 2 skipped targets, got %d", skipped)
+	}
+	if budget.Exceeded() == "" {
+		t.Fatal("expected budget to report a stop reason")
+	}
+}
+
+type blockingChecker struct{}
+
+func (blockingChecker) Name() string { return "blocking" }
+
+func (blockingChecker) Check(ctx context.Context, target string) CheckResult {
+	<-ctx.Done()
+	return CheckResult{Target: target, Status: StatusError, Error: ctx.Err().Error()}
+}
+
+func TestRunnerCancelsChecksAtBudgetDeadline(t *testing.T) {
+	budget := &Budget{MaxDuration: 50 * time.Millisecond}
+	runner := &Runner{Concurrency: 2, RateLimit: 100, Timeout: time.Minute, Budget: budget}
+
+	start := time.Now()
+	results := runner.RunChecks(context.Background(), []string{"a", "b"}, blockingChecker{}, nil)
+
+	// The per-target timeout is a minute; the run deadline must win.
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("checks in flight outlived the budget deadline: %s", elapsed)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for _, r := range results {
+		if r.Status != StatusError || !strings.Contains(r.Error, "stopped: budget: max duration") {
+			t.Errorf("%s: got %q (%s), want the budget stop reason", r.Target, r.Status, r.Error)
+		}
+	}
+	if deadline, ok := budget.Deadline(); !ok || deadline.Before(start) {
+		t.Errorf("Deadline() = %v, %v", deadline, ok)
+	}
+}
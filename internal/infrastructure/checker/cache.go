@@ -1,10 +1,35 @@
 package checker
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// Cache finding types.
+const (
+	CacheFindingDeception     = "cache_deception"
+	CacheFindingUnkeyedHeader = "unkeyed_header"
+	CacheFindingHostHeader    = "host_header"
+)
+
+// cacheHitHeaders are headers CDNs and proxies use to report a cache hit.
+var cacheHitHeaders = []string{"X-Cache", "CF-Cache-Status", "X-Cache-Status", "X-Proxy-Cache", "Akamai-Cache-Status", "X-Served-By-Cache"}
+
+// unkeyedProbeHeaders are request headers commonly honoured by applications
+// but left out of cache keys. Each is sent with a harmless canary value.
+var unkeyedProbeHeaders = []string{"X-Forwarded-Host", "X-Host", "X-Forwarded-Server", "X-Original-Host", "X-Forwarded-Prefix"}
+
+// cacheBusterParam keeps probe responses under a cache key of their own, so a
+// reflected canary can never be served to real visitors.
+const cacheBusterParam = "seca_cb"
+
 // AnalyzeCachePolicy extracts cache headers for visibility/compliance.
 func AnalyzeCachePolicy(h http.Header) *CachePolicy {
 	if h == nil {
@@ -37,6 +62,9 @@ func AnalyzeCachePolicy(h http.Header) *CachePolicy {
 		policy.Issues = append(policy.Issues, "Pragma: no-cache detected (legacy caching directive)")
 	}
 
+	policy.SharedCacheable = isSharedCacheable(h, false)
+	policy.CacheHit = cacheHitEvidence(h)
+
 	if policy.CacheControl == "" && policy.Expires == "" && policy.Pragma == "" {
 		// Nothing to report beyond the default issue
 		return policy
@@ -44,3 +72,310 @@ func AnalyzeCachePolicy(h http.Header) *CachePolicy {
 
 	return policy
 }
+
+// cacheDirectives parses Cache-Control into lowercase directive names and
+// values.
+func cacheDirectives(cacheControl string) map[string]string {
+	directives := make(map[string]string)
+	for _, part := range strings.Split(cacheControl, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if name != "" {
+			directives[strings.ToLower(name)] = strings.Trim(value, `"`)
+		}
+	}
+	return directives
+}
+
+// isSharedCacheable reports whether a shared cache (CDN, proxy) may store the
+// response (RFC 9111 §3). Responses to requests carrying Authorization are
+// stored only when explicitly allowed.
+func isSharedCacheable(h http.Header, authorized bool) bool {
+	cc := cacheDirectives(h.Get("Cache-Control"))
+	if _, ok := cc["no-store"]; ok {
+		return false
+	}
+	if _, ok := cc["private"]; ok {
+		return false
+	}
+	_, public := cc["public"]
+	_, mustRevalidate := cc["must-revalidate"]
+	sMaxAge, _ := strconv.Atoi(cc["s-maxage"])
+	if authorized {
+		return public || sMaxAge > 0 || mustRevalidate
+	}
+	if public || sMaxAge > 0 {
+		return true
+	}
+	if maxAge, err := strconv.Atoi(cc["max-age"]); err == nil {
+		return maxAge > 0
+	}
+	if expires, err := http.ParseTime(h.Get("Expires")); err == nil {
+		return expires.After(time.Now())
+	}
+	return false
+}
+
+// cacheHitEvidence returns the header showing the response came from a
+// cache, or "" when none does.
+func cacheHitEvidence(h http.Header) string {
+	for _, name := range cacheHitHeaders {
+		if v := h.Get(name); strings.Contains(strings.ToLower(v), "hit") {
+			return fmt.Sprintf("%s: %s", name, v)
+		}
+	}
+	if age := h.Get("Age"); age != "" && age != "0" {
+		return "Age: " + age
+	}
+	return ""
+}
+
+// cacheEvidence summarises the caching headers behind a finding.
+func cacheEvidence(policy *CachePolicy, extra ...string) string {
+	parts := []string{}
+	if policy.CacheControl != "" {
+		parts = append(parts, "Cache-Control: "+policy.CacheControl)
+	} else if policy.Expires != "" {
+		parts = append(parts, "Expires: "+policy.Expires)
+	}
+	if policy.CacheHit != "" {
+		parts = append(parts, policy.CacheHit)
+	}
+	parts = append(parts, extra...)
+	return strings.Join(parts, "; ")
+}
+
+// varies reports whether the response's Vary header lists name.
+func varies(h http.Header, name string) bool {
+	for _, v := range h.Values("Vary") {
+		for _, field := range strings.Split(v, ",") {
+			field = strings.TrimSpace(field)
+			if field == "*" || strings.EqualFold(field, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AnalyzeCacheDeception flags web cache deception risks: responses to
+// authenticated requests, or responses setting cookies, that a shared cache
+// may store and serve to other users.
+func AnalyzeCacheDeception(policy *CachePolicy, resp *http.Response) {
+	if policy == nil || resp == nil {
+		return
+	}
+	req := resp.Request
+
+	switch {
+	case req != nil && req.Header.Get("Authorization") != "" && isSharedCacheable(resp.Header, true):
+		policy.Findings = append(policy.Findings, CacheFinding{
+			Type:        CacheFindingDeception,
+			Severity:    "high",
+			Header:      "Authorization",
+			Description: "A response to a request authenticated with Authorization is explicitly cacheable by shared caches, so one user's content may be served to others",
+			Evidence:    cacheEvidence(policy),
+		})
+	case req != nil && req.Header.Get("Cookie") != "" && policy.SharedCacheable && !varies(resp.Header, "Cookie"):
+		policy.Findings = append(policy.Findings, CacheFinding{
+			Type:        CacheFindingDeception,
+			Severity:    "high",
+			Header:      "Cookie",
+			Description: "A response to a cookie-authenticated request is cacheable by shared caches and does not vary on Cookie, so session content may be served to other users",
+			Evidence:    cacheEvidence(policy),
+		})
+	}
+
+	if len(resp.Header.Values("Set-Cookie")) > 0 && policy.SharedCacheable {
+		policy.Findings = append(policy.Findings, CacheFinding{
+			Type:        CacheFindingDeception,
+			Severity:    "medium",
+			Header:      "Set-Cookie",
+			Description: "A response that sets cookies is cacheable by shared caches, which may hand the same cookie to every visitor",
+			Evidence:    cacheEvidence(policy, fmt.Sprintf("%d Set-Cookie header(s)", len(resp.Header.Values("Set-Cookie")))),
+		})
+	}
+}
+
+// cacheProbeResponse is what an unkeyed-header probe observed.
+type cacheProbeResponse struct {
+	status int
+	header http.Header
+	body   string
+}
+
+// ProbeUnkeyedHeaders sends the target once plainly and once per header in
+// unkeyedProbeHeaders with a canary value, each under a unique cache-buster
+// query parameter. A canary reflected in the response, or a status change,
+// indicates input the cache does not key on: high when the response is also
+// cacheable, medium otherwise. Headers listed in Vary are keyed and skipped.
+func ProbeUnkeyedHeaders(ctx context.Context, client *http.Client, target string, policy *CachePolicy) {
+	if policy == nil {
+		return
+	}
+	baseline, err := sendCacheProbe(ctx, client, target, "", "")
+	if err != nil {
+		return
+	}
+
+	for _, header := range unkeyedProbeHeaders {
+		if varies(baseline.header, header) {
+			continue
+		}
+		canary := "seca-" + randomToken() + ".invalid"
+		value := canary
+		if header == "X-Forwarded-Prefix" {
+			value = "/" + canary
+		}
+		probe, err := sendCacheProbe(ctx, client, target, header, value)
+		if err != nil {
+			continue
+		}
+
+		reflected := reflectedIn(probe, canary)
+		statusChanged := probe.status != baseline.status
+		if reflected == "" && !statusChanged {
+			continue
+		}
+
+		cacheable := isSharedCacheable(probe.header, false)
+		severity := "medium"
+		if cacheable {
+			severity = "high"
+		}
+		finding := CacheFinding{
+			Type:     CacheFindingUnkeyedHeader,
+			Severity: severity,
+			Header:   header,
+		}
+		if reflected != "" {
+			finding.Description = fmt.Sprintf("The value of the unkeyed %s request header is reflected in the %s", header, reflected)
+			finding.Evidence = fmt.Sprintf("%s: %s reflected in %s", header, value, reflected)
+		} else {
+			finding.Description = fmt.Sprintf("Sending %s changes the response status, which a cache may store and serve to every visitor (cache-poisoned denial of service)", header)
+			finding.Evidence = fmt.Sprintf("status %d without %s, %d with %s: %s", baseline.status, header, probe.status, header, value)
+		}
+		if cacheable {
+			finding.Description += "; the response is cacheable by shared caches"
+			finding.Evidence += "; " + cacheEvidence(&CachePolicy{CacheControl: probe.header.Get("Cache-Control"), Expires: probe.header.Get("Expires"), CacheHit: cacheHitEvidence(probe.header)})
+		}
+		policy.Findings = append(policy.Findings, finding)
+	}
+}
+
+// ProbeHostHeader sends the target with a benign alternate Host header (a
+// canary under .invalid) and a unique cache-buster; the connection and TLS
+// server name still use the real host. A canary reflected in Location or in
+// absolute URLs in the page means the application builds links from the
+// Host header (host header injection, e.g. password reset poisoning). When
+// it is reflected, the same cache key is fetched again with the real Host:
+// a canary served back confirms a cache keyed without the host. Servers
+// that reject the unknown host are not reported.
+func ProbeHostHeader(ctx context.Context, client *http.Client, target string, policy *CachePolicy) {
+	if policy == nil {
+		return
+	}
+	canary := "seca-" + randomToken() + ".invalid"
+	buster := randomToken()
+	probe, err := sendKeyedProbe(ctx, client, target, buster, "Host", canary)
+	// An error page echoing the rejected host (400, 421) is not link building.
+	if err != nil || probe.status >= http.StatusBadRequest {
+		return
+	}
+	reflected := hostReflection(probe, canary)
+	if reflected == "" {
+		return
+	}
+
+	finding := CacheFinding{
+		Type:        CacheFindingHostHeader,
+		Severity:    "medium",
+		Header:      "Host",
+		Description: fmt.Sprintf("An alternate Host header is reflected in the %s, so the application builds URLs from the untrusted Host header", reflected),
+		Evidence:    fmt.Sprintf("Host: %s reflected in %s (HTTP %d)", canary, reflected, probe.status),
+	}
+	if followUp, err := sendKeyedProbe(ctx, client, target, buster, "", ""); err == nil && reflectedIn(followUp, canary) != "" {
+		finding.Severity = "high"
+		finding.Description += "; a later request with the real Host received the cached response, so the cache does not key on the host"
+		finding.Evidence += fmt.Sprintf("; canary served again without the alternate Host under %s=%s", cacheBusterParam, buster)
+	} else if isSharedCacheable(probe.header, false) {
+		finding.Severity = "high"
+		finding.Description += "; the response is cacheable by shared caches"
+		finding.Evidence += "; " + cacheEvidence(&CachePolicy{CacheControl: probe.header.Get("Cache-Control"), Expires: probe.header.Get("Expires"), CacheHit: cacheHitEvidence(probe.header)})
+	}
+	policy.Findings = append(policy.Findings, finding)
+}
+
+// hostReflection names where a Host canary appears in the probe response,
+// preferring the places that turn into links, or returns "" when it does
+// not appear.
+func hostReflection(probe *cacheProbeResponse, canary string) string {
+	if strings.Contains(probe.header.Get("Location"), canary) {
+		return "Location response header"
+	}
+	lower := strings.ToLower(probe.body)
+	if strings.Contains(lower, "//"+canary) {
+		return "absolute URLs in the response body"
+	}
+	return reflectedIn(probe, canary)
+}
+
+// sendCacheProbe fetches target under a fresh cache-buster, optionally with
+// one extra header.
+func sendCacheProbe(ctx context.Context, client *http.Client, target, header, value string) (*cacheProbeResponse, error) {
+	return sendKeyedProbe(ctx, client, target, randomToken(), header, value)
+}
+
+// sendKeyedProbe fetches target under the given cache-buster, optionally
+// with one extra header. A "Host" header replaces the request's Host.
+func sendKeyedProbe(ctx context.Context, client *http.Client, target, buster, header, value string) (*cacheProbeResponse, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	q.Set(cacheBusterParam, buster)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case header == "Host":
+		req.Host = value
+	case header != "":
+		req.Header.Set(header, value)
+	}
+	// Do not follow redirects: a reflected canary in Location is the signal.
+	noRedirect := *client
+	noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	resp, err := noRedirect.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, _ := readBodySnippet(resp.Body, bodySnippetLimit)
+	return &cacheProbeResponse{status: resp.StatusCode, header: resp.Header, body: string(body)}, nil
+}
+
+// reflectedIn names where canary appears in the probe response, or returns ""
+// when it does not.
+func reflectedIn(probe *cacheProbeResponse, canary string) string {
+	for name, values := range probe.header {
+		for _, v := range values {
+			if strings.Contains(v, canary) {
+				return name + " response header"
+			}
+		}
+	}
+	if strings.Contains(probe.body, canary) {
+		return "response body"
+	}
+	return ""
+}
+
+func randomToken() string {
+	b := make([]byte, 6)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
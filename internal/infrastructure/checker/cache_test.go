@@ -1,7 +1,10 @@
 package checker
 
 import (
+	"context"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -31,3 +34,186 @@ func TestAnalyzeCachePolicy_WithHeaders(t *testing.T) {
 		t.Errorf("unexpected cache-control value: %s", policy.CacheControl)
 	}
 }
+
+func TestIsSharedCacheable(t *testing.T) {
+	tests := []struct {
+		cacheControl string
+		authorized   bool
+		want         bool
+	}{
+		{"public, max-age=60", false, true},
+		{"max-age=60", false, true},
+		{"max-age=60", true, false},
+		{"s-maxage=60", true, true},
+		{"private, max-age=60", false, false},
+		{"no-store", false, false},
+		{"", false, false},
+	}
+	for _, tt := range tests {
+		h := http.Header{}
+		if tt.cacheControl != "" {
+			h.Set("Cache-Control", tt.cacheControl)
+		}
+		if got := isSharedCacheable(h, tt.authorized); got != tt.want {
+			t.Errorf("isSharedCacheable(%q, %v) = %v, want %v", tt.cacheControl, tt.authorized, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzeCacheDeception(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/account", nil)
+	req.Header.Set("Cookie", "session=abc")
+	resp := &http.Response{Header: http.Header{}, Request: req}
+	resp.Header.Set("Cache-Control", "public, max-age=600")
+	resp.Header.Set("X-Cache", "HIT")
+	resp.Header.Add("Set-Cookie", "tracking=1")
+
+	policy := AnalyzeCachePolicy(resp.Header)
+	AnalyzeCacheDeception(policy, resp)
+
+	if len(policy.Findings) != 2 {
+		t.Fatalf("expected cookie and Set-Cookie findings, got %+v", policy.Findings)
+	}
+	if f := policy.Findings[0]; f.Severity != "high" || f.Header != "Cookie" || !strings.Contains(f.Evidence, "X-Cache: HIT") {
+		t.Errorf("unexpected cookie finding: %+v", f)
+	}
+
+	resp.Header.Set("Vary", "Accept-Encoding, Cookie")
+	resp.Header.Del("Set-Cookie")
+	policy = AnalyzeCachePolicy(resp.Header)
+	AnalyzeCacheDeception(policy, resp)
+	if len(policy.Findings) != 0 {
+		t.Fatalf("Vary: Cookie should key the cache on the session, got %+v", policy.Findings)
+	}
+}
+
+func TestProbeUnkeyedHeaders(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.URL.Query().Get(cacheBusterParam))
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		w.Header().Set("Vary", "X-Host")
+		if host := r.Header.Get("X-Forwarded-Host"); host != "" {
+			w.Write([]byte(`<script src="https://` + host + `/app.js"></script>`))
+			return
+		}
+		if r.Header.Get("X-Original-Host") != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte("<html></html>"))
+	}))
+	defer server.Close()
+
+	policy := &CachePolicy{}
+	ProbeUnkeyedHeaders(context.Background(), server.Client(), server.URL+"/?page=1", policy)
+
+	if len(policy.Findings) != 2 {
+		t.Fatalf("expected reflection and status-change findings, got %+v", policy.Findings)
+	}
+	reflected := policy.Findings[0]
+	if reflected.Header != "X-Forwarded-Host" || reflected.Severity != "high" || !strings.Contains(reflected.Evidence, "response body") {
+		t.Errorf("unexpected reflection finding: %+v", reflected)
+	}
+	if policy.Findings[1].Header != "X-Original-Host" || !strings.Contains(policy.Findings[1].Evidence, "status 200") {
+		t.Errorf("unexpected status finding: %+v", policy.Findings[1])
+	}
+
+	// Every request, including the baseline, uses its own cache buster; the
+	// keyed X-Host header is not probed.
+	if len(seen) != len(unkeyedProbeHeaders) {
+		t.Fatalf("expected %d requests, got %d", len(unkeyedProbeHeaders), len(seen))
+	}
+	unique := map[string]bool{}
+	for _, cb := range seen {
+		if cb == "" || unique[cb] {
+			t.Fatalf("cache buster missing or reused: %v", seen)
+		}
+		unique[cb] = true
+	}
+}
+
+func TestProbeHostHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  func(cached map[string]string) http.HandlerFunc
+		severity string
+		evidence string
+	}{
+		{
+			name: "reflected in Location",
+			handler: func(map[string]string) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Cache-Control", "no-store")
+					http.Redirect(w, r, "https://"+r.Host+"/login", http.StatusFound)
+				}
+			},
+			severity: "medium",
+			evidence: "Location",
+		},
+		{
+			name: "unknown host rejected",
+			handler: func(map[string]string) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					if !strings.HasPrefix(r.Host, "127.0.0.1") {
+						http.Error(w, "misdirected request for "+r.Host, http.StatusMisdirectedRequest)
+						return
+					}
+					w.Write([]byte("<html></html>"))
+				}
+			},
+		},
+		{
+			name: "cached without the host",
+			handler: func(cached map[string]string) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					key := r.URL.RawQuery
+					body, ok := cached[key]
+					if !ok {
+						body = `<link rel="canonical" href="https://` + r.Host + `/">`
+						cached[key] = body
+					}
+					w.Write([]byte(body))
+				}
+			},
+			severity: "high",
+			evidence: "canary served again",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler(map[string]string{}))
+			defer server.Close()
+
+			policy := &CachePolicy{}
+			ProbeHostHeader(context.Background(), server.Client(), server.URL+"/", policy)
+
+			if tt.severity == "" {
+				if len(policy.Findings) != 0 {
+					t.Fatalf("expected no finding, got %+v", policy.Findings)
+				}
+				return
+			}
+			if len(policy.Findings) != 1 {
+				t.Fatalf("expected one finding, got %+v", policy.Findings)
+			}
+			f := policy.Findings[0]
+			if f.Type != CacheFindingHostHeader || f.Severity != tt.severity || !strings.Contains(f.Evidence, tt.evidence) {
+				t.Errorf("unexpected finding: %+v", f)
+			}
+			if !strings.Contains(f.Evidence, ".invalid") {
+				t.Errorf("the canary should be under .invalid: %q", f.Evidence)
+			}
+
+			vulns := analyzeCachePolicy(policy, server.URL)
+			want := "Host Header Injection"
+			if tt.severity == "high" {
+				want = "Web Cache Poisoning: Host Header"
+			}
+			if len(vulns) != 1 || vulns[0].Name != want {
+				t.Errorf("expected a %q vulnerability, got %+v", want, vulns)
+			}
+		})
+	}
+}
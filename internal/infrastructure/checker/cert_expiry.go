@@ -0,0 +1,97 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"math"
+	"net"
+	"time"
+)
+
+// CertExpiryChecker only performs a TLS handshake with each target and
+// reports when its leaf certificate expires. It is much cheaper than a full
+// HTTP check, for daily expiry monitoring.
+type CertExpiryChecker struct {
+	Timeout time.Duration
+}
+
+// Name returns the checker name
+func (c *CertExpiryChecker) Name() string {
+	return "tls expiry"
+}
+
+// Check fetches the target's leaf certificate and records its expiry in
+// TLSExpiry.
+func (c *CertExpiryChecker) Check(ctx context.Context, target string) CheckResult {
+	result := CheckResult{
+		Target:    target,
+		CheckedAt: time.Now().UTC(),
+	}
+
+	cert, err := FetchLeafCertificate(ctx, target, c.Timeout)
+	if err != nil {
+		result.Status = "error"
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Status = "ok"
+	result.TLSExpiry = cert.NotAfter.Format(time.RFC3339)
+	result.Notes = fmt.Sprintf("subject=%s; issuer=%s", cert.Subject.CommonName, cert.Issuer.CommonName)
+	return result
+}
+
+// TLSAddress returns the host and host:port of target's TLS endpoint. Targets
+// without an explicit port use 443, whatever their scheme.
+func TLSAddress(target string) (string, string) {
+	info := ParseTarget(target)
+	port := info.Port
+	if port == "" {
+		port = "443"
+	}
+	return info.Host, net.JoinHostPort(info.Host, port)
+}
+
+// FetchLeafCertificate performs a TLS handshake with target and returns the
+// certificate it presents. The chain is not verified so expired and
+// self-signed certificates are still reported.
+func FetchLeafCertificate(ctx context.Context, target string, timeout time.Duration) (*x509.Certificate, error) {
+	host, addr := TLSAddress(target)
+	if host == "" {
+		return nil, fmt.Errorf("invalid target %q", target)
+	}
+
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: timeout},
+		Config: &tls.Config{
+			ServerName: host,
+			// #nosec G402 -- only the certificate's dates are read; trust is not implied.
+			InsecureSkipVerify: true,
+		},
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("tls handshake with %s: %w", addr, err)
+	}
+	defer conn.Close()
+
+	state := conn.(*tls.Conn).ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return nil, fmt.Errorf("%s presented no certificate", addr)
+	}
+	return state.PeerCertificates[0], nil
+}
+
+// DaysUntil returns the whole days from now until t, negative once t has
+// passed.
+func DaysUntil(t, now time.Time) int {
+	return int(math.Floor(t.Sub(now).Hours() / 24))
+}
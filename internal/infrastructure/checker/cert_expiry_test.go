@@ -0,0 +1,63 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCertExpiryChecker(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("expiry check must not send HTTP requests, got %s %s", r.Method, r.URL)
+	}))
+	defer server.Close()
+
+	c := &CertExpiryChecker{Timeout: 5 * time.Second}
+	result := c.Check(context.Background(), server.URL)
+	if result.Status != "ok" {
+		t.Fatalf("expected ok, got %+v", result)
+	}
+
+	want := server.Certificate().NotAfter.Format(time.RFC3339)
+	if result.TLSExpiry != want {
+		t.Fatalf("expected expiry %s, got %s", want, result.TLSExpiry)
+	}
+	if c.Name() != "tls expiry" {
+		t.Fatalf("unexpected name %q", c.Name())
+	}
+}
+
+func TestCertExpiryChecker_Unreachable(t *testing.T) {
+	c := &CertExpiryChecker{Timeout: time.Second}
+	result := c.Check(context.Background(), "127.0.0.1:1")
+	if result.Status != "error" || !strings.Contains(result.Error, "127.0.0.1:1") {
+		t.Fatalf("expected handshake error, got %+v", result)
+	}
+}
+
+func TestTLSAddress(t *testing.T) {
+	cases := map[string]string{
+		"example.com":               "example.com:443",
+		"http://example.com":        "example.com:443",
+		"https://example.com:8443/": "example.com:8443",
+		"10.0.0.5:9443":             "10.0.0.5:9443",
+	}
+	for in, want := range cases {
+		if _, got := TLSAddress(in); got != want {
+			t.Fatalf("TLSAddress(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDaysUntil(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	if got := DaysUntil(now.Add(36*time.Hour), now); got != 1 {
+		t.Fatalf("expected 1 day, got %d", got)
+	}
+	if got := DaysUntil(now.Add(-time.Hour), now); got != -1 {
+		t.Fatalf("expected -1 day for an expired certificate, got %d", got)
+	}
+}
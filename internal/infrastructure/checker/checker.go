@@ -2,6 +2,8 @@ package checker
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -17,7 +19,10 @@ type CheckResult struct {
 	ServerHeader      string                  `json:"server_header,omitempty"`
 	TLSExpiry         string                  `json:"tls_expiry,omitempty"`
 	DNSRecords        map[string]interface{}  `json:"dns_records,omitempty"`
+	DomainHygiene     *DomainHygieneResult    `json:"domain_hygiene,omitempty"`
+	Hosting           []IPHosting             `json:"hosting,omitempty"`
 	ResponseTime      float64                 `json:"response_time_ms,omitempty"`
+	Timings           *PhaseTimings           `json:"timings,omitempty"`
 	SecurityHeaders   *SecurityHeadersResult  `json:"security_headers,omitempty"`
 	TLSCompliance     *TLSComplianceResult    `json:"tls_compliance,omitempty"`
 	CookieFindings    []CookieFinding         `json:"cookie_findings,omitempty"`
@@ -26,8 +31,43 @@ type CheckResult struct {
 	NetworkSecurity   *NetworkSecurityResult  `json:"network_security,omitempty"`
 	ClientSecurity    *ClientSecurityResult   `json:"client_security,omitempty"`
 	ThirdPartyScripts []string                `json:"third_party_scripts,omitempty"`
+	Favicon           *FaviconResult          `json:"favicon,omitempty"`
+	RobotsSitemap     *RobotsSitemapAnalysis  `json:"robots_sitemap,omitempty"`
+	Smuggling         *SmugglingPosture       `json:"smuggling_posture,omitempty"`
+	Content           *ContentFingerprint     `json:"content,omitempty"`
 	Notes             string                  `json:"notes,omitempty"`
 	Error             string                  `json:"error,omitempty"`
+	// Findings are the result's failed and warning findings, recorded by
+	// the Runner after the check; see ResultFindings.
+	Findings []Finding `json:"normalized_findings,omitempty"`
+}
+
+// Result statuses. Only "ok" and "warning" mean the checks ran; the others
+// say why a target was not (fully) checked, so a WAF block or a dead host is
+// not mistaken for a checker failure.
+const (
+	StatusOK          = "ok"
+	StatusWarning     = "warning"     // checked, but part of the check could not complete; see Notes
+	StatusError       = "error"       // the checker ran and failed
+	StatusBlocked     = "blocked"     // a WAF answered with its block or challenge page
+	StatusUnreachable = "unreachable" // failed the health pre-check, so the checker never ran
+	StatusSkipped     = "skipped"     // never started: the run was stopped or its budget spent
+)
+
+// Succeeded reports whether the checks ran against the target.
+func (r CheckResult) Succeeded() bool {
+	return r.Status == StatusOK || r.Status == StatusWarning
+}
+
+// SkippedResult is the result recorded for a target the run never started.
+func SkippedResult(target, reason string) CheckResult {
+	return CheckResult{
+		Target:     target,
+		CheckedAt:  time.Now().UTC(),
+		Status:     StatusSkipped,
+		Error:      reason,
+		DNSRecords: make(map[string]interface{}),
+	}
 }
 
 // SecurityHeadersResult contains security headers analysis
@@ -39,6 +79,10 @@ type SecurityHeadersResult struct {
 	Missing         []string                `json:"missing"`
 	Warnings        []string                `json:"warnings,omitempty"`
 	Recommendations []string                `json:"recommendations,omitempty"`
+	// PolicyViolations lists breaches of the engagement's header policy.
+	PolicyViolations []HeaderPolicyViolation `json:"policy_violations,omitempty"`
+	// CSPFindings are the per-directive results of evaluating the CSP.
+	CSPFindings []CSPFinding `json:"csp_findings,omitempty"`
 }
 
 // HeaderStatus represents the status of a single security header
@@ -64,6 +108,8 @@ type TLSComplianceResult struct {
 	CertificateInfo *CertificateInfo    `json:"certificate_info,omitempty"`
 	MixedContent    *MixedContentCheck  `json:"mixed_content,omitempty"`
 	OCSPStapling    bool                `json:"ocsp_stapling"`
+	Extensions      *TLSExtensions      `json:"extensions,omitempty"`
+	DHE             *DHEParameters      `json:"dhe,omitempty"`
 }
 
 // MixedContentCheck detects mixed content vulnerabilities (HTTP resources on HTTPS pages)
@@ -84,6 +130,7 @@ type ClientSecurityResult struct {
 	VulnerableLibraries []VulnerableLibrary `json:"vulnerable_libraries,omitempty"`
 	CSRFProtection      *CSRFCheck          `json:"csrf_protection,omitempty"`
 	TrustedTypes        bool                `json:"trusted_types"`
+	LookalikeLinks      []LookalikeLink     `json:"lookalike_links,omitempty"`
 	Issues              []string            `json:"issues,omitempty"`
 	Recommendations     []string            `json:"recommendations,omitempty"`
 }
@@ -97,6 +144,7 @@ type VulnerableLibrary struct {
 	Description      string   `json:"description"`
 	Recommendation   string   `json:"recommendation"`
 	CVSS             float64  `json:"cvss,omitempty"`
+	CVSSVector       string   `json:"cvss_vector,omitempty"`
 }
 
 // CSRFCheck analyzes CSRF protection mechanisms
@@ -140,6 +188,21 @@ type CachePolicy struct {
 	Expires      string   `json:"expires,omitempty"`
 	Pragma       string   `json:"pragma,omitempty"`
 	Issues       []string `json:"issues,omitempty"`
+	// SharedCacheable is set when a CDN or proxy may store the response.
+	SharedCacheable bool `json:"shared_cacheable,omitempty"`
+	// CacheHit is the header showing the response was served from a cache.
+	CacheHit string `json:"cache_hit,omitempty"`
+	// Findings are cache deception and poisoning indicators.
+	Findings []CacheFinding `json:"findings,omitempty"`
+}
+
+// CacheFinding is a web cache deception or poisoning indicator.
+type CacheFinding struct {
+	Type        string `json:"type"`     // "cache_deception", "unkeyed_header", "host_header"
+	Severity    string `json:"severity"` // "high", "medium"
+	Header      string `json:"header,omitempty"`
+	Description string `json:"description"`
+	Evidence    string `json:"evidence"`
 }
 
 // ComplianceIssue represents a compliance violation
@@ -180,6 +243,7 @@ type CertificateInfo struct {
 	SignatureAlg    string   `json:"signature_algorithm"`
 	PublicKeyAlg    string   `json:"public_key_algorithm"`
 	KeySize         int      `json:"key_size,omitempty"`
+	PublicKeySHA256 string   `json:"public_key_sha256,omitempty"` // SPKI hash, "sha256/<base64>"
 	ChainDepth      int      `json:"chain_depth,omitempty"`
 	ChainSubjects   []string `json:"chain_subjects,omitempty"`
 	VerifiedChains  int      `json:"verified_chains,omitempty"`
@@ -202,12 +266,36 @@ type Runner struct {
 	Concurrency int           // Maximum number of concurrent checks
 	RateLimit   int           // Requests per second (global)
 	Timeout     time.Duration // Timeout for each check
+	// Budget, when set, stops the run from starting new targets once it is
+	// spent. Targets already in flight finish and are reported; the rest
+	// are recorded (and audited) as skipped.
+	Budget *Budget
+	// Throttle, when set, holds a target back while its host is paused for
+	// rate limiting, before the check's timeout starts.
+	Throttle *Throttle
+	// Overrides, when set, returns per-target settings. An override's
+	// Timeout replaces Timeout, and the override is passed to the checker
+	// in the check's context.
+	Overrides OverrideLookup
+	// Phases are the per-phase HTTP timeouts the checker was given. An
+	// override's Timeout is widened by them as PhaseTimeouts.CheckTimeout
+	// describes; Timeout is expected to be widened already.
+	Phases PhaseTimeouts
+	// Health, when set, pre-checks each target; a target that fails is
+	// recorded as unreachable without running the checker.
+	Health *HealthCheck
 }
 
 // RunChecks executes checks against multiple targets using a worker pool
 func (r *Runner) RunChecks(ctx context.Context, targets []string, checker Checker, auditFn AuditFunc) []CheckResult {
 	// Rate limiter
 	limiter := rate.NewLimiter(rate.Limit(r.RateLimit), r.RateLimit)
+	r.Budget.Start()
+
+	// Every target's context ends at the run deadline as well as its own
+	// timeout, so checks in flight stop when the budget runs out.
+	ctx, cancel := r.Budget.WithDeadline(ctx)
+	defer cancel()
 
 	// Worker pool
 	sem := make(chan struct{}, r.Concurrency)
@@ -227,16 +315,15 @@ func (r *Runner) RunChecks(ctx context.Context, targets []string, checker Checke
 			// Wait for rate limiter
 			_ = limiter.Wait(ctx)
 
-			start := time.Now()
-
-			// Create context with timeout
-			checkCtx, cancel := context.WithTimeout(ctx, r.Timeout)
-			defer cancel()
-
-			// Perform the check
-			result := checker.Check(checkCtx, t)
-
-			duration := time.Since(start).Seconds()
+			var result CheckResult
+			var duration float64
+			if reason := r.skipReason(ctx, t); reason != "" {
+				result = SkippedResult(t, reason)
+			} else {
+				start := time.Now()
+				result = r.check(ctx, t, checker)
+				duration = time.Since(start).Seconds()
+			}
 
 			// Call audit function if provided
 			if auditFn != nil {
@@ -253,3 +340,64 @@ func (r *Runner) RunChecks(ctx context.Context, targets []string, checker Checke
 	wg.Wait()
 	return results
 }
+
+// check runs checker against target under the target's timeout and
+// overrides, unless the health pre-check finds the target down.
+func (r *Runner) check(ctx context.Context, target string, checker Checker) CheckResult {
+	runCtx := ctx
+	timeout := r.Timeout
+	if r.Overrides != nil {
+		if o, ok := r.Overrides(target); ok {
+			if o.Timeout > 0 {
+				timeout = r.Phases.CheckTimeout(o.Timeout)
+			}
+			ctx = WithTargetOverride(ctx, o)
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := r.Health.Probe(ctx, target); err != nil {
+		return UnreachableResult(target, err)
+	}
+	result := checker.Check(ctx, target)
+	result.Findings = AnalyzeFindings(result)
+	if !result.Succeeded() && runCtx.Err() != nil {
+		// The run ended under the check; say so rather than leave only the
+		// context error the checker saw.
+		result.Status = StatusError
+		stopped := "stopped: " + r.stopReason(runCtx)
+		if result.Error == "" {
+			result.Error = stopped
+		} else {
+			result.Error = fmt.Sprintf("%s (%s)", result.Error, stopped)
+		}
+	}
+	return result
+}
+
+// stopReason says why the run context ctx ended.
+func (r *Runner) stopReason(ctx context.Context) string {
+	if reason := r.Budget.Exceeded(); reason != "" {
+		return "budget: " + reason
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "run deadline reached"
+	}
+	return "run canceled"
+}
+
+// skipReason returns why target should not be started, or "" to check it.
+// It waits out a throttle pause on the target's host.
+func (r *Runner) skipReason(ctx context.Context, target string) string {
+	if reason := r.Budget.Exceeded(); reason != "" {
+		return "budget: " + reason
+	}
+	if ctx.Err() != nil {
+		return r.stopReason(ctx)
+	}
+	if err := r.Throttle.Wait(ctx, ExtractHost(target)); err != nil {
+		return err.Error()
+	}
+	return ""
+}
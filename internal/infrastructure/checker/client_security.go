@@ -108,6 +108,7 @@ func checkLibraryVulnerability(library, version string) *VulnerableLibrary {
 				Description:      "jQuery versions before 3.5.0 contain XSS vulnerabilities in htmlPrefilter",
 				Recommendation:   "Update jQuery to version 3.5.0 or later",
 				CVSS:             6.1,
+				CVSSVector:       "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N",
 			}
 		}
 
@@ -122,6 +123,7 @@ func checkLibraryVulnerability(library, version string) *VulnerableLibrary {
 				Description:      "AngularJS versions before 1.7.9 contain prototype pollution vulnerability",
 				Recommendation:   "Update AngularJS to 1.7.9+ or migrate to Angular 2+",
 				CVSS:             7.5,
+				CVSSVector:       "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:H/A:N",
 			}
 		}
 
@@ -136,6 +138,7 @@ func checkLibraryVulnerability(library, version string) *VulnerableLibrary {
 				Description:      "Lodash versions before 4.17.12 contain prototype pollution vulnerability",
 				Recommendation:   "Update Lodash to version 4.17.12 or later",
 				CVSS:             9.1,
+				CVSSVector:       "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:H/A:H",
 			}
 		}
 
@@ -150,6 +153,7 @@ func checkLibraryVulnerability(library, version string) *VulnerableLibrary {
 				Description:      "Moment.js versions before 2.29.2 contain ReDoS vulnerability",
 				Recommendation:   "Update to 2.29.2+ or consider migrating to modern alternatives like date-fns or Luxon",
 				CVSS:             7.5,
+				CVSSVector:       "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:H/A:N",
 			}
 		}
 
@@ -164,6 +168,7 @@ func checkLibraryVulnerability(library, version string) *VulnerableLibrary {
 				Description:      "Bootstrap versions before 3.4.0 contain XSS vulnerability in tooltip/popover",
 				Recommendation:   "Update Bootstrap to version 3.4.0 or later",
 				CVSS:             6.1,
+				CVSSVector:       "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N",
 			}
 		}
 	}
@@ -0,0 +1,207 @@
+package checker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"html"
+	"math/bits"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContentChangeThreshold is the similarity below which a content change is
+// reported. Pages that differ only in timestamps, tokens, or a rotating
+// banner stay above it.
+const ContentChangeThreshold = 0.75
+
+var (
+	contentDropBlocks = regexp.MustCompile(`(?is)<script\b.*?</script>|<style\b.*?</style>|<noscript\b.*?</noscript>|<!--.*?-->`)
+	contentTags       = regexp.MustCompile(`(?s)<[^>]*>`)
+	// Long hex or base64-looking runs are nonces, CSRF tokens and cache busters.
+	contentTokens = regexp.MustCompile(`\b[A-Za-z0-9+/_=-]{24,}\b|\b[0-9a-fA-F]{16,}\b`)
+	contentDigits = regexp.MustCompile(`[0-9]+`)
+)
+
+// ContentFingerprint summarises a response body after normalization, so
+// volatile markup does not register as a change.
+type ContentFingerprint struct {
+	Hash    string `json:"hash"`    // SHA-256 of the normalized text
+	SimHash string `json:"simhash"` // 64-bit SimHash of the normalized words, hex
+	Words   int    `json:"words"`
+}
+
+// NormalizeContent reduces an HTML body to its visible text: scripts,
+// styles, comments and tags are dropped, entities decoded, tokens and digits
+// masked, and whitespace collapsed.
+func NormalizeContent(body string) string {
+	text := contentDropBlocks.ReplaceAllString(body, " ")
+	text = contentTags.ReplaceAllString(text, " ")
+	text = html.UnescapeString(text)
+	text = contentTokens.ReplaceAllString(text, " ")
+	text = contentDigits.ReplaceAllString(text, "0")
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
+
+// FingerprintContent returns the fingerprint of body, or nil when nothing
+// is left after normalization.
+func FingerprintContent(body []byte) *ContentFingerprint {
+	text := NormalizeContent(string(body))
+	if text == "" {
+		return nil
+	}
+	words := strings.Fields(text)
+	sum := sha256.Sum256([]byte(text))
+	return &ContentFingerprint{
+		Hash:    "sha256:" + hex.EncodeToString(sum[:]),
+		SimHash: fmt.Sprintf("%016x", simHash(words)),
+		Words:   len(words),
+	}
+}
+
+// simHash hashes overlapping word pairs so that reordering counts as change.
+func simHash(words []string) uint64 {
+	var weights [64]int
+	add := func(feature string) {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(feature))
+		v := h.Sum64()
+		for i := range weights {
+			if v&(1<<uint(i)) != 0 {
+				weights[i]++
+			} else {
+				weights[i]--
+			}
+		}
+	}
+	if len(words) == 1 {
+		add(words[0])
+	}
+	for i := 0; i+1 < len(words); i++ {
+		add(words[i] + " " + words[i+1])
+	}
+	var out uint64
+	for i, w := range weights {
+		if w > 0 {
+			out |= 1 << uint(i)
+		}
+	}
+	return out
+}
+
+// Similarity returns how alike two fingerprints are, from 0 to 1. Equal
+// hashes are 1; otherwise it is the share of matching SimHash bits.
+func (f *ContentFingerprint) Similarity(other *ContentFingerprint) float64 {
+	if f == nil || other == nil {
+		return 0
+	}
+	if f.Hash == other.Hash {
+		return 1
+	}
+	a, errA := strconv.ParseUint(f.SimHash, 16, 64)
+	b, errB := strconv.ParseUint(other.SimHash, 16, 64)
+	if errA != nil || errB != nil {
+		return 0
+	}
+	return 1 - float64(bits.OnesCount64(a^b))/64
+}
+
+// ContentBaseline is the fingerprint last seen for a URL.
+type ContentBaseline struct {
+	ContentFingerprint
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// ContentChange records a URL whose content changed significantly since the
+// previous run.
+type ContentChange struct {
+	Target        string    `json:"target"`
+	Previous      string    `json:"previous"`
+	Current       string    `json:"current"`
+	Similarity    float64   `json:"similarity"`
+	PreviousWords int       `json:"previous_words"`
+	CurrentWords  int       `json:"current_words"`
+	PreviousSeen  time.Time `json:"previous_seen"`
+	DetectedAt    time.Time `json:"detected_at"`
+}
+
+// ContentStore tracks the content fingerprint of each URL across runs.
+type ContentStore struct {
+	Baselines map[string]ContentBaseline `json:"baselines"`
+	Changes   []ContentChange            `json:"changes,omitempty"`
+}
+
+// NewContentStore returns an empty store.
+func NewContentStore() *ContentStore {
+	return &ContentStore{Baselines: make(map[string]ContentBaseline)}
+}
+
+// Observe records fp for target and returns the change when the content is
+// less similar than ContentChangeThreshold to the previous run. The new
+// fingerprint becomes the baseline either way, so a change is reported once
+// and gradual edits do not accumulate into a finding.
+func (s *ContentStore) Observe(target string, fp *ContentFingerprint, at time.Time) *ContentChange {
+	if fp == nil {
+		return nil
+	}
+	if s.Baselines == nil {
+		s.Baselines = make(map[string]ContentBaseline)
+	}
+
+	prev, ok := s.Baselines[target]
+	if !ok {
+		s.Baselines[target] = ContentBaseline{ContentFingerprint: *fp, FirstSeen: at, LastSeen: at}
+		return nil
+	}
+	if prev.Hash == fp.Hash {
+		prev.LastSeen = at
+		s.Baselines[target] = prev
+		return nil
+	}
+
+	s.Baselines[target] = ContentBaseline{ContentFingerprint: *fp, FirstSeen: at, LastSeen: at}
+	similarity := prev.Similarity(fp)
+	if similarity >= ContentChangeThreshold {
+		return nil
+	}
+	change := ContentChange{
+		Target:        target,
+		Previous:      prev.Hash,
+		Current:       fp.Hash,
+		Similarity:    similarity,
+		PreviousWords: prev.Words,
+		CurrentWords:  fp.Words,
+		PreviousSeen:  prev.LastSeen,
+		DetectedAt:    at,
+	}
+	s.Changes = append(s.Changes, change)
+	return &change
+}
+
+// Vulnerability converts the change into an informational report finding.
+func (c ContentChange) Vulnerability() Vulnerability {
+	return Vulnerability{
+		Name:     "Significant Content Change",
+		Category: "Content Integrity",
+		Severity: "Info",
+		Status:   "Info",
+		Description: fmt.Sprintf("The visible content of %s changed between the run on %s and the run on %s (%.0f%% similar; %d words before, %d after). "+
+			"Unplanned changes can indicate defacement, a compromised deployment, or a DNS or routing change serving different content.",
+			c.Target, c.PreviousSeen.Format(time.RFC3339), c.DetectedAt.Format(time.RFC3339), c.Similarity*100, c.PreviousWords, c.CurrentWords),
+		Recommendation: "Confirm with the system owner that the change was a planned release. If it was not, compare the page with a known-good copy and review recent deployments and access to the web server.",
+		AffectedURLs:   []string{c.Target},
+	}
+}
+
+// ContentChangeVulnerabilities converts recorded content changes into findings.
+func ContentChangeVulnerabilities(changes []ContentChange) []Vulnerability {
+	vulns := make([]Vulnerability, 0, len(changes))
+	for _, c := range changes {
+		vulns = append(vulns, c.Vulnerability())
+	}
+	return vulns
+}
@@ -0,0 +1,67 @@
+package checker
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+const contentPage = `<html><head><title>Example Store</title><style>body{color:red}</style>
+<script>var nonce = "%s";</script></head><body>
+<!-- build %s -->
+<h1>Welcome to Example Store</h1>
+<p>Browse our catalogue of handmade furniture, lighting and textiles, shipped
+from our workshop to customers across the region since 2009.</p>
+<p>Opening hours: Monday to Friday, 9am to 6pm. Visit the showroom or call us.</p>
+<form><input type="hidden" name="csrf" value="%s"></form>
+<footer>Rendered at %s</footer></body></html>`
+
+func storePage(nonce, build, csrf, at string) []byte {
+	return []byte(fmt.Sprintf(contentPage, nonce, build, csrf, at))
+}
+
+func TestNormalizeContent_DropsVolatileMarkup(t *testing.T) {
+	got := NormalizeContent(`<p>Order &amp; pay</p><script>x()</script><!-- c --><span data-t="1">Token 9f8e7d6c5b4a39281706f5e4d3c2b1a0 at 12:34</span>`)
+	if got != "order & pay token at 0:0" {
+		t.Fatalf("NormalizeContent = %q", got)
+	}
+	if FingerprintContent([]byte("<html><script>only()</script></html>")) != nil {
+		t.Error("expected no fingerprint for a page without text")
+	}
+}
+
+func TestFingerprintContent_IgnoresTokensAndTimestamps(t *testing.T) {
+	a := FingerprintContent(storePage("a1b2c3d4e5f6a7b8c9d0", "2024-01-01", "Zm9vYmFyYmF6cXV4cXV1eHF1dXg=", "10:00:01"))
+	b := FingerprintContent(storePage("0f9e8d7c6b5a49382716", "2024-02-03", "cXV1eHF1dXhiYXpmb29iYXJiYXo=", "17:45:59"))
+	if a == nil || b == nil || a.Hash != b.Hash {
+		t.Fatalf("expected equal fingerprints, got %+v and %+v", a, b)
+	}
+}
+
+func TestContentStore_Observe(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	original := FingerprintContent(storePage("n", "b", "c", "t"))
+	tweaked := FingerprintContent([]byte(strings.Replace(string(storePage("n", "b", "c", "t")), "6pm", "7pm", 1)))
+	defaced := FingerprintContent([]byte("<html><body><h1>Hacked by nobody</h1><p>Your security is a joke. Greetings to all my friends.</p></body></html>"))
+
+	store := NewContentStore()
+	if change := store.Observe("https://example.com", original, now); change != nil {
+		t.Fatalf("first observation should set the baseline, got %+v", change)
+	}
+	if change := store.Observe("https://example.com", tweaked, now.Add(time.Hour)); change != nil {
+		t.Fatalf("a small edit should not be reported, got %+v (similarity %.2f)", change, original.Similarity(tweaked))
+	}
+	change := store.Observe("https://example.com", defaced, now.Add(2*time.Hour))
+	if change == nil || change.Similarity >= ContentChangeThreshold || change.Previous != tweaked.Hash {
+		t.Fatalf("expected a significant change from the tweaked baseline, got %+v", change)
+	}
+	if store.Observe("https://example.com", defaced, now.Add(3*time.Hour)) != nil || len(store.Changes) != 1 {
+		t.Fatalf("a change should be reported once, got %+v", store.Changes)
+	}
+
+	vuln := change.Vulnerability()
+	if vuln.Severity != "Info" || vuln.Name != "Significant Content Change" || vuln.AffectedURLs[0] != "https://example.com" {
+		t.Errorf("unexpected finding %+v", vuln)
+	}
+}
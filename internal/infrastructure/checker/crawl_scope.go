@@ -0,0 +1,166 @@
+package checker
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// CrawlScope decides which discovered URLs a crawl may follow. A URL is in
+// scope when its host is the start host or matches AllowHosts, its path
+// matches IncludePaths (when any are set) and no ExcludePaths, and the full
+// URL matches IncludePatterns (when any are set) and no ExcludePatterns.
+type CrawlScope struct {
+	// AllowHosts lists extra hosts to crawl: exact names ("api.example.com")
+	// or wildcards ("*.example.com", which also matches example.com).
+	AllowHosts      []string
+	IncludePaths    []string
+	ExcludePaths    []string
+	IncludePatterns []*regexp.Regexp
+	ExcludePatterns []*regexp.Regexp
+}
+
+// NewCrawlScope builds a CrawlScope from raw host, path-prefix, and regex
+// rules. It returns nil when no rule is set, which keeps crawls same-host.
+func NewCrawlScope(allowHosts, includePaths, excludePaths, includeRegex, excludeRegex []string) (*CrawlScope, error) {
+	scope := &CrawlScope{}
+
+	for _, raw := range allowHosts {
+		host := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(raw), "."))
+		if host == "" {
+			continue
+		}
+		name := strings.TrimPrefix(host, "*.")
+		if name == "" || strings.ContainsAny(name, "*/:") {
+			return nil, fmt.Errorf("invalid allowed host %q (expected host or *.domain)", raw)
+		}
+		scope.AllowHosts = append(scope.AllowHosts, strings.TrimSuffix(host, name)+strings.ToLower(ASCIIHost(name)))
+	}
+	scope.IncludePaths = normalizePathPrefixes(includePaths)
+	scope.ExcludePaths = normalizePathPrefixes(excludePaths)
+
+	var err error
+	if scope.IncludePatterns, err = compilePatterns(includeRegex); err != nil {
+		return nil, err
+	}
+	if scope.ExcludePatterns, err = compilePatterns(excludeRegex); err != nil {
+		return nil, err
+	}
+
+	if len(scope.AllowHosts) == 0 && len(scope.IncludePaths) == 0 && len(scope.ExcludePaths) == 0 &&
+		len(scope.IncludePatterns) == 0 && len(scope.ExcludePatterns) == 0 {
+		return nil, nil
+	}
+	return scope, nil
+}
+
+// Allows reports whether u may be crawled from a crawl started at root.
+func (s *CrawlScope) Allows(root, u *url.URL) bool {
+	if s == nil {
+		return hostsMatch(root, u)
+	}
+	if !hostsMatch(root, u) && !s.HostAllowed(u.Hostname()) {
+		return false
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if len(s.IncludePaths) > 0 && !hasPathPrefix(path, s.IncludePaths) {
+		return false
+	}
+	if hasPathPrefix(path, s.ExcludePaths) {
+		return false
+	}
+
+	full := u.String()
+	if len(s.IncludePatterns) > 0 && !matchesAny(full, s.IncludePatterns) {
+		return false
+	}
+	return !matchesAny(full, s.ExcludePatterns)
+}
+
+// HostAllowed reports whether host matches one of the AllowHosts rules.
+func (s *CrawlScope) HostAllowed(host string) bool {
+	if s == nil {
+		return false
+	}
+	host = strings.ToLower(ASCIIHost(strings.TrimSuffix(host, ".")))
+	if host == "" {
+		return false
+	}
+	for _, rule := range s.AllowHosts {
+		if suffix, ok := strings.CutPrefix(rule, "*."); ok {
+			if host == suffix || strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+			continue
+		}
+		if host == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// inScope applies opts.Scope, falling back to SameHostOnly when no scope rules
+// are configured.
+func (opts CrawlOptions) inScope(root, u *url.URL) bool {
+	if opts.Scope != nil {
+		return opts.Scope.Allows(root, u)
+	}
+	return !opts.SameHostOnly || hostsMatch(root, u)
+}
+
+func normalizePathPrefixes(raw []string) []string {
+	var prefixes []string
+	for _, p := range raw {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if !strings.HasPrefix(p, "/") {
+			p = "/" + p
+		}
+		prefixes = append(prefixes, p)
+	}
+	return prefixes
+}
+
+// hasPathPrefix matches whole path segments, so "/admin" covers "/admin" and
+// "/admin/users" but not "/administrator".
+func hasPathPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		trimmed := strings.TrimSuffix(prefix, "/")
+		if trimmed == "" || path == trimmed || strings.HasPrefix(path, trimmed+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func compilePatterns(raw []string) ([]*regexp.Regexp, error) {
+	var patterns []*regexp.Regexp
+	for _, expr := range raw {
+		if strings.TrimSpace(expr) == "" {
+			continue
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid crawl pattern %q: %w", expr, err)
+		}
+		patterns = append(patterns, re)
+	}
+	return patterns, nil
+}
+
+func matchesAny(s string, patterns []*regexp.Regexp) bool {
+	for _, re := range patterns {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
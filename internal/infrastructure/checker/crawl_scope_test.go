@@ -0,0 +1,125 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewCrawlScope(t *testing.T) {
+	scope, err := NewCrawlScope(nil, []string{" "}, nil, nil, nil)
+	if err != nil || scope != nil {
+		t.Fatalf("expected nil scope without rules, got %+v (%v)", scope, err)
+	}
+
+	if _, err := NewCrawlScope([]string{"https://api.example.com"}, nil, nil, nil, nil); err == nil {
+		t.Fatal("expected error for URL used as allowed host")
+	}
+	if _, err := NewCrawlScope(nil, nil, nil, nil, []string{"("}); err == nil {
+		t.Fatal("expected error for invalid regex")
+	}
+
+	scope, err = NewCrawlScope([]string{"API.Example.com."}, []string{"app"}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("NewCrawlScope: %v", err)
+	}
+	if scope.AllowHosts[0] != "api.example.com" || scope.IncludePaths[0] != "/app" {
+		t.Fatalf("rules not normalised: %+v", scope)
+	}
+}
+
+func TestCrawlScopeAllows(t *testing.T) {
+	scope, err := NewCrawlScope(
+		[]string{"*.example.com"},
+		[]string{"/app", "/api/"},
+		[]string{"/app/logout"},
+		nil,
+		[]string{`\.pdf$`, `[?&]delete=`},
+	)
+	if err != nil {
+		t.Fatalf("NewCrawlScope: %v", err)
+	}
+
+	root, _ := url.Parse("https://app.example.com/app")
+	cases := map[string]bool{
+		"https://app.example.com/app":            true,
+		"https://app.example.com/app/settings":   true,
+		"https://api.example.com/api/v1/users":   true,
+		"https://example.com/app":                true,
+		"https://app.example.com/application":    false,
+		"https://app.example.com/app/logout":     false,
+		"https://app.example.com/app/report.pdf": false,
+		"https://app.example.com/app?delete=1":   false,
+		"https://other.test/app":                 false,
+		"https://example.com.evil.test/app":      false,
+	}
+	for raw, want := range cases {
+		u, _ := url.Parse(raw)
+		if got := scope.Allows(root, u); got != want {
+			t.Errorf("Allows(%s) = %v, want %v", raw, got, want)
+		}
+	}
+
+	var nilScope *CrawlScope
+	other, _ := url.Parse("https://api.example.com/")
+	if nilScope.Allows(root, other) {
+		t.Error("nil scope should only allow the start host")
+	}
+}
+
+func TestDiscoverInScopeLinks_AllowedSubdomain(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			fmt.Fprint(w, "User-agent: *\nDisallow: /private\n")
+		default:
+			fmt.Fprint(w, `<a href="/private/keys">Keys</a>`)
+		}
+	}))
+	defer api.Close()
+	// Reach the second server through a different host name so it counts as
+	// another host.
+	apiURL := strings.Replace(api.URL, "127.0.0.1", "localhost", 1)
+
+	app := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			fmt.Fprint(w, "User-agent: *\nAllow: /\n")
+		default:
+			fmt.Fprintf(w, `<a href="/dashboard">Dashboard</a><a href="%s/v1/docs">API</a><a href="/logout">Logout</a>`, apiURL)
+		}
+	}))
+	defer app.Close()
+
+	opts := CrawlOptions{MaxDepth: 2, MaxPages: 10, SameHostOnly: true, Timeout: time.Second}
+	links, err := DiscoverInScopeLinks(context.Background(), app.URL, opts)
+	if err != nil {
+		t.Fatalf("DiscoverInScopeLinks: %v", err)
+	}
+	for _, link := range links {
+		if strings.HasPrefix(link, apiURL) {
+			t.Fatalf("same-host crawl followed %s", link)
+		}
+	}
+
+	opts.Scope, err = NewCrawlScope([]string{"localhost"}, nil, []string{"/logout"}, nil, nil)
+	if err != nil {
+		t.Fatalf("NewCrawlScope: %v", err)
+	}
+	links, err = DiscoverInScopeLinks(context.Background(), app.URL, opts)
+	if err != nil {
+		t.Fatalf("DiscoverInScopeLinks: %v", err)
+	}
+	sort.Strings(links)
+	want := []string{app.URL + "/dashboard", apiURL + "/v1/docs"}
+	sort.Strings(want)
+	if fmt.Sprint(links) != fmt.Sprint(want) {
+		t.Fatalf("links = %v, want %v (robots.txt of the allowed host must apply)", links, want)
+	}
+}
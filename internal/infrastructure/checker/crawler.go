@@ -2,7 +2,6 @@ package checker
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
 	"io"
 	"net/http"
@@ -18,7 +17,31 @@ type CrawlOptions struct {
 	MaxDepth     int
 	MaxPages     int
 	SameHostOnly bool
-	Timeout      time.Duration
+	// Scope, when set, replaces SameHostOnly with host, path, and URL pattern
+	// rules (e.g. to crawl app. and api. subdomains as one site).
+	Scope   *CrawlScope
+	Timeout time.Duration
+	// IgnoreRobots disables robots.txt Disallow rules and Crawl-delay.
+	IgnoreRobots bool
+	// UseSitemaps seeds discovery with in-scope pages listed in sitemap.xml
+	// and sitemap index files. Seeds count towards MaxPages.
+	UseSitemaps bool
+	// Decorate, when set, is applied to every crawl request (e.g. session credentials).
+	Decorate RequestDecorator
+	// Proxy, when set, routes crawl requests through an HTTP(S) or SOCKS5 proxy.
+	Proxy *url.URL
+	// Dialer, when set, resolves crawled hosts through custom nameservers and pins.
+	Dialer *Dialer
+	// Inventory, when set, records forms and XHR/fetch endpoints found on
+	// crawled pages and same-host scripts. Nothing is submitted or called.
+	Inventory *Inventory
+	// HAR, when set, records every crawl request and response. Pages rendered
+	// by the headless browser are not recorded.
+	HAR *HARRecorder
+	// Budget, when set, counts crawl requests and bytes against the run budget.
+	Budget *Budget
+	// Throttle, when set, backs off from hosts that rate limit or block.
+	Throttle *Throttle
 }
 
 const maxCrawlBodyBytes = 512 * 1024
@@ -50,7 +73,7 @@ var assetExtensions = map[string]struct{}{
 	".tar":         {},
 }
 
-// DiscoverInScopeLinks crawls within the same host starting from startURL and returns
+// DiscoverInScopeLinks crawls within opts.Scope (or the same host) starting from startURL and returns
 // up to MaxPages canonical URLs discovered within MaxDepth hops.
 func DiscoverInScopeLinks(ctx context.Context, startURL string, opts CrawlOptions) ([]string, error) {
 	if opts.MaxDepth <= 0 || opts.MaxPages <= 0 {
@@ -71,14 +94,14 @@ func DiscoverInScopeLinks(ctx context.Context, startURL string, opts CrawlOption
 		return nil, err
 	}
 
-	client := &http.Client{
-		Timeout: opts.Timeout,
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: false,
-				MinVersion:         tls.VersionTLS12,
-			},
-		},
+	client := newCrawlClient(opts)
+
+	gate, err := newRobotsGate(ctx, client, root, opts)
+	if err != nil {
+		return nil, err
+	}
+	if !gate.allowed(root) {
+		return nil, nil
 	}
 
 	type queueItem struct {
@@ -90,6 +113,12 @@ func DiscoverInScopeLinks(ctx context.Context, startURL string, opts CrawlOption
 	seen := map[string]struct{}{canonicalURL(root): {}}
 	discovered := make([]string, 0, opts.MaxPages)
 
+	if opts.UseSitemaps {
+		discovered = seedFromSitemaps(ctx, client, root, gate, opts, seen, discovered, func(u *url.URL) {
+			queue = append(queue, queueItem{url: u, depth: 1})
+		})
+	}
+
 	for len(queue) > 0 && len(discovered) < opts.MaxPages {
 		if err := ctx.Err(); err != nil {
 			return discovered, err
@@ -102,21 +131,32 @@ func DiscoverInScopeLinks(ctx context.Context, startURL string, opts CrawlOption
 			continue
 		}
 
+		if err := gate.wait(ctx); err != nil {
+			return discovered, err
+		}
+
 		body, contentType, err := fetchPage(ctx, client, item.url.String())
 		if err != nil || !isHTML(contentType) {
 			continue
 		}
 
+		if opts.Inventory != nil {
+			opts.Inventory.AddPage(item.url, body)
+			if err := inventoryScripts(ctx, client, gate, opts.Inventory, item.url, body); err != nil {
+				return discovered, err
+			}
+		}
+
 		links := extractLinks(item.url, body)
 		for _, raw := range links {
 			u, err := url.Parse(raw)
 			if err != nil {
 				continue
 			}
-			if opts.SameHostOnly && !hostsMatch(root, u) {
+			if !opts.inScope(root, u) {
 				continue
 			}
-			if looksLikeAsset(u.Path) {
+			if looksLikeAsset(u.Path) || !gate.allowed(u) {
 				continue
 			}
 			key := canonicalURL(u)
@@ -140,17 +180,35 @@ func DiscoverInScopeLinks(ctx context.Context, startURL string, opts CrawlOption
 	return discovered, nil
 }
 
+// inventoryScripts fetches the same-host scripts referenced by page (within the
+// inventory's budget) and records the API endpoints they call.
+func inventoryScripts(ctx context.Context, client *http.Client, gate *robotsGate, inv *Inventory, page *url.URL, body []byte) error {
+	for _, script := range sameHostScripts(page, body) {
+		if !gate.allowed(script) || !inv.claimScript(script.String()) {
+			continue
+		}
+		if err := gate.wait(ctx); err != nil {
+			return err
+		}
+		data, _, err := fetchPage(ctx, client, script.String())
+		if err != nil {
+			continue
+		}
+		inv.AddScript(page, script, data)
+	}
+	return nil
+}
+
+func newCrawlClient(opts CrawlOptions) *http.Client {
+	return &http.Client{
+		Timeout:   opts.Timeout,
+		Transport: WithDecorator(opts.Throttle.Wrap(opts.Budget.Wrap(opts.HAR.Wrap(opts.Dialer.Apply(NewTransport(opts.Proxy))))), opts.Decorate),
+	}
+}
+
 func fetchPage(ctx context.Context, client *http.Client, target string) ([]byte, string, error) {
 	if client == nil {
-		client = &http.Client{
-			Timeout: 10 * time.Second,
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: false,
-					MinVersion:         tls.VersionTLS12,
-				},
-			},
-		}
+		client = newCrawlClient(CrawlOptions{Timeout: 10 * time.Second})
 	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
 	if err != nil {
@@ -228,6 +286,9 @@ func resolveLink(base *url.URL, href string) *url.URL {
 	if ref.Scheme != "http" && ref.Scheme != "https" {
 		return nil
 	}
+	// Links may spell internationalized hosts in Unicode; crawl and
+	// deduplicate them in the punycode form DNS resolves.
+	asciiURLHost(ref)
 
 	if strings.HasPrefix(ref.Fragment, "/") {
 		ref.Path = ensureLeadingSlash(ref.Fragment)
@@ -286,7 +347,7 @@ func canonicalURL(u *url.URL) string {
 }
 
 func hostsMatch(a, b *url.URL) bool {
-	return !sameHostEmpty(a) && !sameHostEmpty(b) && strings.EqualFold(a.Hostname(), b.Hostname())
+	return !sameHostEmpty(a) && !sameHostEmpty(b) && strings.EqualFold(ASCIIHost(a.Hostname()), ASCIIHost(b.Hostname()))
 }
 
 func sameHostEmpty(u *url.URL) bool {
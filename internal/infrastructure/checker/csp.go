@@ -0,0 +1,348 @@
+package checker
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CSP finding severities, in decreasing order of impact.
+const (
+	CSPSeverityHigh   = "high"
+	CSPSeverityMedium = "medium"
+	CSPSeverityLow    = "low"
+	CSPSeverityInfo   = "info"
+)
+
+// minCSPNonceLength is the shortest nonce considered unguessable.
+const minCSPNonceLength = 8
+
+// CSPDirective is one directive of a parsed policy. Names are lowercase;
+// sources keep their original case because nonces and hashes are
+// case-sensitive.
+type CSPDirective struct {
+	Name    string   `json:"name"`
+	Sources []string `json:"sources,omitempty"`
+}
+
+// CSPPolicy is a parsed Content-Security-Policy.
+type CSPPolicy struct {
+	Directives []CSPDirective `json:"directives"`
+	// Duplicates lists repeated directives; browsers honour only the first.
+	Duplicates []string `json:"duplicates,omitempty"`
+}
+
+// CSPFinding is a problem with one directive of a policy, in the spirit of
+// Google's CSP Evaluator.
+type CSPFinding struct {
+	Directive   string `json:"directive"`
+	Severity    string `json:"severity"` // "high", "medium", "low", "info"
+	Description string `json:"description"`
+	Value       string `json:"value,omitempty"`
+}
+
+// String formats the finding as a one-line issue.
+func (f CSPFinding) String() string {
+	return fmt.Sprintf("%s: %s", f.Directive, f.Description)
+}
+
+// cspFallbacks lists, for each directive, the directives consulted when it is
+// absent (CSP Level 3 §6.8.3). Directives not listed here have no fallback.
+var cspFallbacks = map[string][]string{
+	"script-src":      {"default-src"},
+	"script-src-elem": {"script-src", "default-src"},
+	"script-src-attr": {"script-src", "default-src"},
+	"style-src":       {"default-src"},
+	"style-src-elem":  {"style-src", "default-src"},
+	"style-src-attr":  {"style-src", "default-src"},
+	"worker-src":      {"child-src", "script-src", "default-src"},
+	"frame-src":       {"child-src", "default-src"},
+	"child-src":       {"default-src"},
+	"img-src":         {"default-src"},
+	"font-src":        {"default-src"},
+	"connect-src":     {"default-src"},
+	"media-src":       {"default-src"},
+	"object-src":      {"default-src"},
+	"manifest-src":    {"default-src"},
+}
+
+// cspKnownDirectives are the directives defined by CSP Level 3 and its
+// companion specs.
+var cspKnownDirectives = []string{
+	"default-src", "script-src", "script-src-elem", "script-src-attr",
+	"style-src", "style-src-elem", "style-src-attr", "worker-src", "frame-src",
+	"child-src", "img-src", "font-src", "connect-src", "media-src", "object-src",
+	"manifest-src", "base-uri", "form-action", "frame-ancestors", "sandbox",
+	"report-uri", "report-to", "upgrade-insecure-requests",
+	"require-trusted-types-for", "trusted-types", "webrtc", "fenced-frame-src",
+}
+
+// cspDeprecatedDirectives are no longer supported by browsers.
+var cspDeprecatedDirectives = []string{
+	"block-all-mixed-content", "plugin-types", "reflected-xss", "referrer",
+	"prefetch-src", "navigate-to", "disown-opener",
+}
+
+// cspBareKeywords are keywords that only take effect when single-quoted.
+var cspBareKeywords = []string{
+	"self", "none", "unsafe-inline", "unsafe-eval", "strict-dynamic",
+	"unsafe-hashes", "report-sample", "wasm-unsafe-eval",
+}
+
+// ParseCSP parses a serialized policy. Directive names are matched
+// case-insensitively and only the first occurrence of a directive counts,
+// as in browsers.
+func ParseCSP(value string) *CSPPolicy {
+	policy := &CSPPolicy{}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ";") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		name := strings.ToLower(fields[0])
+		if seen[name] {
+			policy.Duplicates = append(policy.Duplicates, name)
+			continue
+		}
+		seen[name] = true
+		policy.Directives = append(policy.Directives, CSPDirective{Name: name, Sources: fields[1:]})
+	}
+	return policy
+}
+
+// Directive returns the sources of the named directive and whether it is
+// present.
+func (p *CSPPolicy) Directive(name string) ([]string, bool) {
+	for _, d := range p.Directives {
+		if d.Name == name {
+			return d.Sources, true
+		}
+	}
+	return nil, false
+}
+
+// Has reports whether the policy contains the named directive.
+func (p *CSPPolicy) Has(name string) bool {
+	_, ok := p.Directive(name)
+	return ok
+}
+
+// EffectiveSources resolves the sources governing the named directive,
+// following the CSP fallback chain. It returns the directive that supplied
+// them, or ok=false when nothing restricts it.
+func (p *CSPPolicy) EffectiveSources(name string) (from string, sources []string, ok bool) {
+	if sources, ok := p.Directive(name); ok {
+		return name, sources, true
+	}
+	for _, fallback := range cspFallbacks[name] {
+		if sources, ok := p.Directive(fallback); ok {
+			return fallback, sources, true
+		}
+	}
+	return "", nil, false
+}
+
+// EvaluateCSP reports the policy's weaknesses per directive.
+func EvaluateCSP(p *CSPPolicy) []CSPFinding {
+	var findings []CSPFinding
+	add := func(directive, severity, value, format string, args ...any) {
+		findings = append(findings, CSPFinding{
+			Directive:   directive,
+			Severity:    severity,
+			Description: fmt.Sprintf(format, args...),
+			Value:       value,
+		})
+	}
+
+	findings = append(findings, evaluateCSPSyntax(p)...)
+	findings = append(findings, evaluateCSPScripts(p)...)
+
+	if from, sources, ok := p.EffectiveSources("object-src"); !ok {
+		add("object-src", CSPSeverityHigh, "", "Missing object-src (and default-src) allows plugin injection; set object-src 'none'")
+	} else {
+		for _, src := range sources {
+			if isCSPWildcard(src) || isCSPSchemeSource(src) {
+				add(from, CSPSeverityHigh, src, "Plugin sources allow %s, which permits loading arbitrary plugins; set object-src 'none'", src)
+			}
+		}
+	}
+
+	if !p.Has("base-uri") && cspUsesNonceOrHash(p) {
+		add("base-uri", CSPSeverityHigh, "", "Missing base-uri lets an injected <base> tag load nonce/hash-allowed scripts from an attacker origin; set base-uri 'none' or 'self'")
+	}
+
+	if from, sources, ok := p.EffectiveSources("style-src"); ok && !hasCSPNonceOrHash(sources) {
+		for _, src := range sources {
+			switch strings.ToLower(src) {
+			case "'unsafe-inline'":
+				add(from, CSPSeverityLow, src, "Style sources permit 'unsafe-inline', allowing injected styles (CSS exfiltration)")
+			case "data:":
+				add(from, CSPSeverityLow, src, "Style sources allow data: URIs, which may allow style injection")
+			}
+		}
+	}
+
+	if !p.Has("frame-ancestors") {
+		add("frame-ancestors", CSPSeverityInfo, "", "No frame-ancestors; framing is controlled only by X-Frame-Options")
+	}
+
+	switch reportURI, reportTo := p.Has("report-uri"), p.Has("report-to"); {
+	case !reportURI && !reportTo:
+		add("report-uri", CSPSeverityInfo, "", "No report-uri or report-to; policy violations go unreported")
+	case reportURI && !reportTo:
+		add("report-uri", CSPSeverityInfo, "", "report-uri is deprecated; add report-to alongside it")
+	case reportTo && !reportURI:
+		add("report-to", CSPSeverityInfo, "", "report-to is not supported by every browser; keep report-uri as a fallback")
+	}
+
+	return findings
+}
+
+// evaluateCSPSyntax flags duplicate, unknown and deprecated directives,
+// unquoted keywords and likely missing semicolons.
+func evaluateCSPSyntax(p *CSPPolicy) []CSPFinding {
+	var findings []CSPFinding
+	for _, name := range p.Duplicates {
+		findings = append(findings, CSPFinding{Directive: name, Severity: CSPSeverityMedium, Description: "Duplicate directive is ignored by browsers; merge its sources into the first occurrence"})
+	}
+	for _, d := range p.Directives {
+		switch {
+		case slices.Contains(cspDeprecatedDirectives, d.Name):
+			findings = append(findings, CSPFinding{Directive: d.Name, Severity: CSPSeverityInfo, Description: "Directive is deprecated and ignored by modern browsers"})
+			continue
+		case !slices.Contains(cspKnownDirectives, d.Name):
+			findings = append(findings, CSPFinding{Directive: d.Name, Severity: CSPSeverityInfo, Description: "Unknown directive"})
+			continue
+		}
+		for _, src := range d.Sources {
+			lower := strings.ToLower(src)
+			switch {
+			case slices.Contains(cspKnownDirectives, lower):
+				findings = append(findings, CSPFinding{Directive: d.Name, Severity: CSPSeverityMedium, Value: src,
+					Description: fmt.Sprintf("Source %q looks like a directive; is a semicolon missing?", src)})
+			case slices.Contains(cspBareKeywords, lower) && d.Name != "sandbox" && d.Name != "trusted-types":
+				findings = append(findings, CSPFinding{Directive: d.Name, Severity: CSPSeverityMedium, Value: src,
+					Description: fmt.Sprintf("Keyword %s must be single-quoted ('%s'); unquoted it is treated as a host name", src, lower)})
+			}
+		}
+	}
+	return findings
+}
+
+// evaluateCSPScripts checks the sources governing scripts, honouring the
+// CSP Level 2/3 rules that nonces and hashes disable 'unsafe-inline' and
+// 'strict-dynamic' disables host and scheme allowlists.
+func evaluateCSPScripts(p *CSPPolicy) []CSPFinding {
+	from, sources, ok := p.EffectiveSources("script-src")
+	if !ok {
+		return []CSPFinding{{
+			Directive:   "script-src",
+			Severity:    CSPSeverityHigh,
+			Description: "Missing script-src (and default-src) allows scripts from any origin",
+		}}
+	}
+
+	var findings []CSPFinding
+	add := func(severity, value, format string, args ...any) {
+		findings = append(findings, CSPFinding{Directive: from, Severity: severity, Description: fmt.Sprintf(format, args...), Value: value})
+	}
+
+	nonceOrHash := hasCSPNonceOrHash(sources)
+	strictDynamic := slices.ContainsFunc(sources, func(s string) bool { return strings.EqualFold(s, "'strict-dynamic'") })
+	allowlisted := false
+
+	for _, src := range sources {
+		lower := strings.ToLower(src)
+		switch {
+		case lower == "'unsafe-inline'":
+			if !nonceOrHash {
+				add(CSPSeverityHigh, src, "'unsafe-inline' allows execution of injected inline scripts; use nonces or hashes")
+			}
+		case lower == "'unsafe-eval'":
+			add(CSPSeverityMedium, src, "'unsafe-eval' allows eval() and similar functions")
+		case strings.HasPrefix(lower, "'nonce-"):
+			if nonce := strings.TrimSuffix(src[len("'nonce-"):], "'"); len(nonce) < minCSPNonceLength {
+				add(CSPSeverityMedium, src, "Nonce is shorter than %d characters and may be guessable", minCSPNonceLength)
+			}
+		case strings.HasPrefix(lower, "'sha"):
+			if !strings.HasPrefix(lower, "'sha256-") && !strings.HasPrefix(lower, "'sha384-") && !strings.HasPrefix(lower, "'sha512-") {
+				add(CSPSeverityMedium, src, "Hash uses an unsupported algorithm; browsers accept only sha256, sha384 and sha512")
+			}
+		case strictDynamic && (lower == "'self'" || isCSPWildcard(src) || isCSPSchemeSource(src) || isCSPHostSource(src)):
+			// Ignored by browsers that support 'strict-dynamic'.
+		case isCSPWildcard(src):
+			add(CSPSeverityHigh, src, "Wildcard (%s) allows scripts from any origin", src)
+		case lower == "data:":
+			add(CSPSeverityHigh, src, "Script sources allow data: URIs, which enable trivial CSP bypasses")
+		case lower == "blob:" || lower == "filesystem:":
+			add(CSPSeverityHigh, src, "Script sources allow %s URLs, which may enable CSP bypasses", lower)
+		case lower == "http:" || lower == "https:":
+			add(CSPSeverityHigh, src, "Scheme source %s allows scripts from any host", lower)
+		case strings.HasPrefix(lower, "http://"):
+			add(CSPSeverityMedium, src, "Script source %s is loaded over insecure http", src)
+		case lower == "'self'" || isCSPHostSource(src):
+			allowlisted = true
+		}
+	}
+
+	if strictDynamic && !nonceOrHash {
+		add(CSPSeverityInfo, "'strict-dynamic'", "'strict-dynamic' without a nonce or hash blocks every script")
+	}
+	if allowlisted && !strictDynamic {
+		add(CSPSeverityInfo, "", "'self' and host allowlists can be bypassed through JSONP endpoints or script gadgets on allowed origins; prefer nonces or hashes with 'strict-dynamic'")
+	}
+	return findings
+}
+
+// cspUsesNonceOrHash reports whether scripts are allowed by nonce or hash,
+// which makes base-uri relevant.
+func cspUsesNonceOrHash(p *CSPPolicy) bool {
+	_, sources, ok := p.EffectiveSources("script-src")
+	return ok && hasCSPNonceOrHash(sources)
+}
+
+func hasCSPNonceOrHash(sources []string) bool {
+	return slices.ContainsFunc(sources, func(s string) bool {
+		lower := strings.ToLower(s)
+		return strings.HasPrefix(lower, "'nonce-") || strings.HasPrefix(lower, "'sha")
+	})
+}
+
+// isCSPWildcard reports whether src allows any host, e.g. "*" or "https://*".
+func isCSPWildcard(src string) bool {
+	host := src
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	return host == "*" || strings.HasPrefix(host, "*:") || strings.HasPrefix(host, "*/")
+}
+
+// isCSPSchemeSource reports whether src is a bare scheme such as "https:".
+func isCSPSchemeSource(src string) bool {
+	return strings.HasSuffix(src, ":") && !strings.HasPrefix(src, "'") && !strings.Contains(src, "/")
+}
+
+// isCSPHostSource reports whether src names a host rather than a keyword or
+// scheme.
+func isCSPHostSource(src string) bool {
+	return !strings.HasPrefix(src, "'") && !isCSPSchemeSource(src) && !isCSPWildcard(src)
+}
+
+// cspFindingPenalty is the score deduction for a finding of each severity.
+var cspFindingPenalty = map[string]int{
+	CSPSeverityHigh:   5,
+	CSPSeverityMedium: 3,
+	CSPSeverityLow:    1,
+}
+
+// worstCSPSeverity returns the most severe non-info severity among findings,
+// or "" when there are none.
+func worstCSPSeverity(findings []CSPFinding) string {
+	for _, severity := range []string{CSPSeverityHigh, CSPSeverityMedium, CSPSeverityLow} {
+		if slices.ContainsFunc(findings, func(f CSPFinding) bool { return f.Severity == severity }) {
+			return severity
+		}
+	}
+	return ""
+}
@@ -0,0 +1,134 @@
+package checker
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func hasCSPFinding(findings []CSPFinding, directive, severity string) bool {
+	return slices.ContainsFunc(findings, func(f CSPFinding) bool {
+		return f.Directive == directive && f.Severity == severity
+	})
+}
+
+func TestParseCSP_FirstDirectiveWins(t *testing.T) {
+	p := ParseCSP("Script-Src 'self' 'nonce-AbCdEf123'; script-src *; ; object-src 'none'")
+
+	sources, ok := p.Directive("script-src")
+	if !ok || !slices.Equal(sources, []string{"'self'", "'nonce-AbCdEf123'"}) {
+		t.Fatalf("script-src = %v, %v; want first occurrence with original case", sources, ok)
+	}
+	if !slices.Equal(p.Duplicates, []string{"script-src"}) {
+		t.Fatalf("Duplicates = %v", p.Duplicates)
+	}
+	if !hasCSPFinding(EvaluateCSP(p), "script-src", CSPSeverityMedium) {
+		t.Fatal("expected a finding for the duplicate directive")
+	}
+}
+
+func TestCSPPolicy_EffectiveSources(t *testing.T) {
+	p := ParseCSP("default-src 'self'; child-src https://frames.example.com")
+
+	tests := []struct {
+		directive string
+		from      string
+		ok        bool
+	}{
+		{"script-src-elem", "default-src", true},
+		{"worker-src", "child-src", true},
+		{"frame-src", "child-src", true},
+		{"object-src", "default-src", true},
+		{"base-uri", "", false},
+		{"frame-ancestors", "", false},
+	}
+	for _, tt := range tests {
+		from, _, ok := p.EffectiveSources(tt.directive)
+		if from != tt.from || ok != tt.ok {
+			t.Errorf("EffectiveSources(%s) = %q, %v; want %q, %v", tt.directive, from, ok, tt.from, tt.ok)
+		}
+	}
+}
+
+func TestEvaluateCSP_NonceDisablesUnsafeInline(t *testing.T) {
+	findings := EvaluateCSP(ParseCSP("script-src 'nonce-r4nd0mV4lue' 'unsafe-inline'; object-src 'none'"))
+
+	for _, f := range findings {
+		if f.Value == "'unsafe-inline'" {
+			t.Fatalf("'unsafe-inline' alongside a nonce should be ignored, got %+v", f)
+		}
+	}
+	if !hasCSPFinding(findings, "base-uri", CSPSeverityHigh) {
+		t.Fatalf("expected missing base-uri to be high with nonces, got %+v", findings)
+	}
+}
+
+func TestEvaluateCSP_StrictDynamicIgnoresAllowlist(t *testing.T) {
+	findings := EvaluateCSP(ParseCSP("script-src 'strict-dynamic' 'nonce-r4nd0mV4lue' https: 'self' *; object-src 'none'; base-uri 'none'; report-uri /csp; report-to csp"))
+
+	if worst := worstCSPSeverity(findings); worst != "" {
+		t.Fatalf("strict policy should have no issues, worst = %q: %+v", worst, findings)
+	}
+}
+
+func TestEvaluateCSP_ScriptWeaknesses(t *testing.T) {
+	findings := EvaluateCSP(ParseCSP("default-src 'self' https: 'unsafe-eval' 'nonce-abc' 'sha1-xyz' http://cdn.example.com"))
+
+	want := map[string]string{
+		"https:":                 CSPSeverityHigh,
+		"'unsafe-eval'":          CSPSeverityMedium,
+		"'nonce-abc'":            CSPSeverityMedium,
+		"'sha1-xyz'":             CSPSeverityMedium,
+		"http://cdn.example.com": CSPSeverityMedium,
+	}
+	for value, severity := range want {
+		if !slices.ContainsFunc(findings, func(f CSPFinding) bool {
+			return f.Directive == "default-src" && f.Value == value && f.Severity == severity
+		}) {
+			t.Errorf("missing %s finding for %s in %+v", severity, value, findings)
+		}
+	}
+}
+
+func TestEvaluateCSP_MissingScriptAndObjectSrc(t *testing.T) {
+	findings := EvaluateCSP(ParseCSP("img-src 'self'"))
+
+	if !hasCSPFinding(findings, "script-src", CSPSeverityHigh) {
+		t.Error("expected missing script-src to be high")
+	}
+	if !hasCSPFinding(findings, "object-src", CSPSeverityHigh) {
+		t.Error("expected missing object-src to be high")
+	}
+	if !hasCSPFinding(findings, "report-uri", CSPSeverityInfo) {
+		t.Error("expected an advisory about missing reporting")
+	}
+}
+
+func TestEvaluateCSP_Syntax(t *testing.T) {
+	findings := EvaluateCSP(ParseCSP("script-src self object-src 'none'; plugin-types application/pdf; foo-src x"))
+
+	if !hasCSPFinding(findings, "script-src", CSPSeverityMedium) {
+		t.Errorf("expected unquoted keyword and missing semicolon findings, got %+v", findings)
+	}
+	if !hasCSPFinding(findings, "plugin-types", CSPSeverityInfo) || !hasCSPFinding(findings, "foo-src", CSPSeverityInfo) {
+		t.Errorf("expected deprecated and unknown directive notes, got %+v", findings)
+	}
+}
+
+func TestAnalyzeSecurityHeaders_CSPFindings(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("Content-Security-Policy", "script-src 'unsafe-inline'")
+
+	result := AnalyzeSecurityHeaders(headers)
+
+	if !hasCSPFinding(result.CSPFindings, "script-src", CSPSeverityHigh) {
+		t.Fatalf("expected per-directive findings on the result, got %+v", result.CSPFindings)
+	}
+	vulns := analyzeSecurityHeaders(result, "https://example.com")
+	idx := slices.IndexFunc(vulns, func(v Vulnerability) bool {
+		return v.Name == "Content Security Policy (CSP) Configuration Issue"
+	})
+	if idx < 0 || vulns[idx].Severity != "High" {
+		t.Fatalf("expected a High CSP configuration finding, got %+v", vulns)
+	}
+}
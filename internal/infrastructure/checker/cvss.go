@@ -0,0 +1,262 @@
+package checker
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"strings"
+)
+
+// cvssPrefix starts every CVSS v3.1 vector string.
+const cvssPrefix = "CVSS:3.1/"
+
+// cvssBaseMetrics lists the base metrics in vector order.
+var cvssBaseMetrics = []string{"AV", "AC", "PR", "UI", "S", "C", "I", "A"}
+
+// cvssWeights holds the CVSS v3.1 metric weights. PR depends on scope and is
+// handled in privilegesWeight.
+var cvssWeights = map[string]map[string]float64{
+	"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+	"AC": {"L": 0.77, "H": 0.44},
+	"PR": {"N": 0.85, "L": 0.62, "H": 0.27},
+	"UI": {"N": 0.85, "R": 0.62},
+	"S":  {"U": 0, "C": 0},
+	"C":  {"H": 0.56, "L": 0.22, "N": 0},
+	"I":  {"H": 0.56, "L": 0.22, "N": 0},
+	"A":  {"H": 0.56, "L": 0.22, "N": 0},
+}
+
+// CVSSVector is a parsed CVSS v3.1 base vector, keyed by metric
+// abbreviation (e.g. "AV" -> "N").
+type CVSSVector map[string]string
+
+// ParseCVSSVector parses a "CVSS:3.1/AV:N/AC:L/..." base vector. Every base
+// metric must be present exactly once; temporal and environmental metrics
+// are not accepted.
+func ParseCVSSVector(vector string) (CVSSVector, error) {
+	vector = strings.TrimSpace(vector)
+	if !strings.HasPrefix(vector, cvssPrefix) {
+		return nil, fmt.Errorf("CVSS vector %q must start with %q", vector, cvssPrefix)
+	}
+	v := make(CVSSVector, len(cvssBaseMetrics))
+	for _, part := range strings.Split(strings.TrimPrefix(vector, cvssPrefix), "/") {
+		metric, value, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("CVSS vector %q: malformed metric %q", vector, part)
+		}
+		weights, known := cvssWeights[metric]
+		if !known {
+			return nil, fmt.Errorf("CVSS vector %q: unsupported metric %q", vector, metric)
+		}
+		if _, valid := weights[value]; !valid {
+			return nil, fmt.Errorf("CVSS vector %q: invalid value %q for %s", vector, value, metric)
+		}
+		if _, dup := v[metric]; dup {
+			return nil, fmt.Errorf("CVSS vector %q: duplicate metric %s", vector, metric)
+		}
+		v[metric] = value
+	}
+	for _, metric := range cvssBaseMetrics {
+		if _, ok := v[metric]; !ok {
+			return nil, fmt.Errorf("CVSS vector %q: missing metric %s", vector, metric)
+		}
+	}
+	return v, nil
+}
+
+// String returns the vector in canonical metric order.
+func (v CVSSVector) String() string {
+	parts := make([]string, 0, len(cvssBaseMetrics))
+	for _, metric := range cvssBaseMetrics {
+		parts = append(parts, metric+":"+v[metric])
+	}
+	return cvssPrefix + strings.Join(parts, "/")
+}
+
+// BaseScore computes the CVSS v3.1 base score.
+func (v CVSSVector) BaseScore() float64 {
+	changed := v["S"] == "C"
+	iss := 1 - (1-cvssWeights["C"][v["C"]])*(1-cvssWeights["I"][v["I"]])*(1-cvssWeights["A"][v["A"]])
+	var impact float64
+	if changed {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	} else {
+		impact = 6.42 * iss
+	}
+	if impact <= 0 {
+		return 0
+	}
+	return combineCVSS(impact, v.exploitability(v["AV"]), changed)
+}
+
+// ModifiedAttackVectorScore computes the CVSS v3.1 environmental score with
+// only the Modified Attack Vector set (e.g. "A" for a target reachable from
+// the internal network only). Requirement and other modified metrics are
+// left undefined, so they take the base values.
+func (v CVSSVector) ModifiedAttackVectorScore(mav string) float64 {
+	changed := v["S"] == "C"
+	miss := math.Min(1-(1-cvssWeights["C"][v["C"]])*(1-cvssWeights["I"][v["I"]])*(1-cvssWeights["A"][v["A"]]), 0.915)
+	var impact float64
+	if changed {
+		impact = 7.52*(miss-0.029) - 3.25*math.Pow(miss*0.9731-0.02, 13)
+	} else {
+		impact = 6.42 * miss
+	}
+	if impact <= 0 {
+		return 0
+	}
+	return combineCVSS(impact, v.exploitability(mav), changed)
+}
+
+func (v CVSSVector) exploitability(av string) float64 {
+	return 8.22 * cvssWeights["AV"][av] * cvssWeights["AC"][v["AC"]] * v.privilegesWeight() * cvssWeights["UI"][v["UI"]]
+}
+
+func (v CVSSVector) privilegesWeight() float64 {
+	if v["S"] == "C" {
+		switch v["PR"] {
+		case "L":
+			return 0.68
+		case "H":
+			return 0.5
+		}
+	}
+	return cvssWeights["PR"][v["PR"]]
+}
+
+func combineCVSS(impact, exploitability float64, changed bool) float64 {
+	if changed {
+		return cvssRoundUp(math.Min(1.08*(impact+exploitability), 10))
+	}
+	return cvssRoundUp(math.Min(impact+exploitability, 10))
+}
+
+// cvssRoundUp is the Roundup function from the CVSS v3.1 specification
+// (Appendix A), which avoids floating-point artifacts such as 4.000001
+// rounding to 4.1.
+func cvssRoundUp(x float64) float64 {
+	i := int64(math.Round(x * 100000))
+	if i%10000 == 0 {
+		return float64(i) / 100000
+	}
+	return float64(i/10000+1) / 10
+}
+
+// CVSSSeverity returns the CVSS v3.1 qualitative rating for a score.
+func CVSSSeverity(score float64) string {
+	switch {
+	case score == 0:
+		return "NONE"
+	case score < 4:
+		return "LOW"
+	case score < 7:
+		return "MEDIUM"
+	case score < 9:
+		return "HIGH"
+	default:
+		return "CRITICAL"
+	}
+}
+
+// NewCVSSScore parses a CVSS v3.1 vector and computes its base score and
+// rating.
+func NewCVSSScore(vector string) (*CVSSScore, error) {
+	v, err := ParseCVSSVector(vector)
+	if err != nil {
+		return nil, err
+	}
+	score := v.BaseScore()
+	return &CVSSScore{BaseScore: score, Vector: v.String(), Severity: CVSSSeverity(score), Version: "3.1"}, nil
+}
+
+// cvssTemplates are the vectors for finding types whose analyzers report
+// only a severity. They describe the typical exploitation path of the
+// finding; exposure is adjusted per engagement by scoreCVSS.
+var cvssTemplates = map[string]string{
+	"Content Security Policy (CSP) Configuration Issue": "CVSS:3.1/AV:N/AC:H/PR:N/UI:R/S:C/C:L/I:L/A:N",
+	"Content-Type Header":                               "CVSS:3.1/AV:N/AC:H/PR:N/UI:R/S:U/C:L/I:L/A:N",
+	"Deprecated X-XSS-Protection Header":                "CVSS:3.1/AV:N/AC:H/PR:N/UI:R/S:U/C:L/I:N/A:N",
+	"CORS Misconfiguration":                             "CVSS:3.1/AV:N/AC:H/PR:N/UI:R/S:U/C:L/I:N/A:N",
+	"Access-Control-Allow-Origin Header":                "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:U/C:L/I:N/A:N",
+	"Cross-Origin-Resource-Policy Header":               "CVSS:3.1/AV:N/AC:H/PR:N/UI:R/S:U/C:L/I:N/A:N",
+	"Vary: Origin Header (CORS Caching)":                "CVSS:3.1/AV:N/AC:H/PR:N/UI:R/S:U/C:L/I:N/A:N",
+	"CSRF Protection Could Be Improved":                 "CVSS:3.1/AV:N/AC:H/PR:N/UI:R/S:U/C:N/I:L/A:N",
+}
+
+// scoreCVSS makes a finding's CVSS consistent and contextual: findings
+// without a vector get their type's template, the base score and rating are
+// recomputed from the vector, and findings that only affect internal
+// targets get an environmental score with the attack vector lowered to
+// Adjacent. Passed and informational findings are left as they are. The
+// CVSSScore is replaced rather than modified, so vulnerabilities copied from
+// one another never share a score.
+func scoreCVSS(v Vulnerability) Vulnerability {
+	if !strings.EqualFold(v.Status, "Failed") && !strings.EqualFold(v.Status, "Warning") {
+		return v
+	}
+	vector := ""
+	if v.CVSS != nil {
+		vector = v.CVSS.Vector
+	} else {
+		vector = cvssTemplates[v.Name]
+	}
+	if vector == "" {
+		return v
+	}
+	parsed, err := ParseCVSSVector(vector)
+	if err != nil {
+		return v
+	}
+
+	var score CVSSScore
+	if v.CVSS != nil {
+		score = *v.CVSS
+	}
+	score.Vector = parsed.String()
+	score.BaseScore = parsed.BaseScore()
+	score.Severity = CVSSSeverity(score.BaseScore)
+	score.Version = "3.1"
+	score.EnvironmentalScore, score.EnvironmentalVector, score.Context = 0, "", ""
+	if parsed["AV"] == "N" && targetsInternal(v.AffectedURLs) {
+		score.EnvironmentalScore = parsed.ModifiedAttackVectorScore("A")
+		score.EnvironmentalVector = score.Vector + "/MAV:A"
+		score.Context = "all affected targets are on private networks"
+	}
+	v.CVSS = &score
+	return v
+}
+
+// targetsInternal reports whether every target is a loopback or private
+// address, or a host name under a suffix reserved for internal networks.
+func targetsInternal(targets []string) bool {
+	if len(targets) == 0 {
+		return false
+	}
+	for _, target := range targets {
+		if !hostInternal(ExtractHost(target)) {
+			return false
+		}
+	}
+	return true
+}
+
+var internalHostSuffixes = []string{".internal", ".local", ".lan", ".corp", ".home.arpa", ".localhost"}
+
+func hostInternal(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "" {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast()
+	}
+	if host == "localhost" {
+		return true
+	}
+	for _, suffix := range internalHostSuffixes {
+		if strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package checker
+
+import "testing"
+
+func TestCVSSBaseScore(t *testing.T) {
+	tests := []struct {
+		vector   string
+		score    float64
+		severity string
+	}{
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", 9.8, "CRITICAL"},
+		{"CVSS:3.1/AV:N/AC:L/PR:L/UI:N/S:C/C:H/I:H/A:H", 9.9, "CRITICAL"},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N", 6.1, "MEDIUM"},
+		{"CVSS:3.1/AV:N/AC:H/PR:N/UI:N/S:U/C:H/I:H/A:N", 7.4, "HIGH"},
+		{"CVSS:3.1/AV:L/AC:L/PR:H/UI:N/S:U/C:L/I:N/A:N", 2.3, "LOW"},
+		{"CVSS:3.1/AV:P/AC:H/PR:H/UI:R/S:C/C:L/I:L/A:L", 4.3, "MEDIUM"},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N", 0, "NONE"},
+	}
+	for _, tt := range tests {
+		got, err := NewCVSSScore(tt.vector)
+		if err != nil {
+			t.Fatalf("NewCVSSScore(%s): %v", tt.vector, err)
+		}
+		if got.BaseScore != tt.score || got.Severity != tt.severity {
+			t.Errorf("%s: got %.1f %s, want %.1f %s", tt.vector, got.BaseScore, got.Severity, tt.score, tt.severity)
+		}
+	}
+}
+
+func TestParseCVSSVectorRejectsInvalid(t *testing.T) {
+	for _, bad := range []string{
+		"",
+		"AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+		"CVSS:3.0/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H",
+		"CVSS:3.1/AV:X/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+		"CVSS:3.1/AV:N/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/E:P",
+	} {
+		if _, err := ParseCVSSVector(bad); err == nil {
+			t.Errorf("ParseCVSSVector(%q): expected error", bad)
+		}
+	}
+}
+
+func TestScoreCVSS(t *testing.T) {
+	// A template fills in findings that only have a severity.
+	v := scoreCVSS(Vulnerability{Name: "CORS Misconfiguration", Status: "Warning", AffectedURLs: []string{"https://app.example.com"}})
+	if v.CVSS == nil || v.CVSS.BaseScore != 3.1 || v.CVSS.EnvironmentalVector != "" {
+		t.Fatalf("expected templated CVSS 3.1, got %+v", v.CVSS)
+	}
+
+	// Internal targets get an environmental score with MAV:A.
+	internal := Vulnerability{
+		Name:         "Exposed Service",
+		Status:       "Failed",
+		AffectedURLs: []string{"https://10.0.0.5", "http://intranet.corp:8080"},
+		CVSS:         &CVSSScore{BaseScore: 1, Vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"},
+	}
+	scored := scoreCVSS(internal)
+	if scored.CVSS.BaseScore != 9.8 || scored.CVSS.EnvironmentalScore != 8.8 {
+		t.Fatalf("unexpected scores: %+v", scored.CVSS)
+	}
+	if scored.CVSS.EnvironmentalVector != "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/MAV:A" {
+		t.Fatalf("unexpected environmental vector %q", scored.CVSS.EnvironmentalVector)
+	}
+	if internal.CVSS.BaseScore != 1 {
+		t.Fatal("scoreCVSS must not modify the input's CVSSScore")
+	}
+
+	// Passed findings and findings without a template are left alone.
+	if v := scoreCVSS(Vulnerability{Name: "CORS Misconfiguration", Status: "Passed"}); v.CVSS != nil {
+		t.Fatalf("passed finding got CVSS %+v", v.CVSS)
+	}
+	if v := scoreCVSS(Vulnerability{Name: "Unknown", Status: "Failed"}); v.CVSS != nil {
+		t.Fatalf("unknown finding got CVSS %+v", v.CVSS)
+	}
+}
@@ -0,0 +1,69 @@
+package checker
+
+import "net/http"
+
+// RequestDecorator adjusts every outgoing request made by a checker or the
+// crawler, e.g. to attach session credentials.
+type RequestDecorator func(req *http.Request)
+
+type decoratingTransport struct {
+	base     http.RoundTripper
+	decorate RequestDecorator
+}
+
+func (t *decoratingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	t.decorate(req)
+	return t.base.RoundTrip(req)
+}
+
+// WithDecorator wraps rt so decorate runs before each request, including
+// redirects. A nil decorate returns rt unchanged.
+func WithDecorator(rt http.RoundTripper, decorate RequestDecorator) http.RoundTripper {
+	if decorate == nil {
+		return rt
+	}
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &decoratingTransport{base: rt, decorate: decorate}
+}
+
+// HeaderDecorator sets every header in h on outgoing requests, replacing any
+// existing values. Use it for custom User-Agent or test-identification headers.
+func HeaderDecorator(h http.Header) RequestDecorator {
+	if len(h) == 0 {
+		return nil
+	}
+	headers := h.Clone()
+	return func(req *http.Request) {
+		for name, values := range headers {
+			req.Header.Del(name)
+			for _, v := range values {
+				req.Header.Add(name, v)
+			}
+		}
+	}
+}
+
+// ChainDecorators runs each non-nil decorator in order. It returns nil when
+// none are set.
+func ChainDecorators(decorators ...RequestDecorator) RequestDecorator {
+	active := make([]RequestDecorator, 0, len(decorators))
+	for _, d := range decorators {
+		if d != nil {
+			active = append(active, d)
+		}
+	}
+	switch len(active) {
+	case 0:
+		return nil
+	case 1:
+		return active[0]
+	}
+	return func(req *http.Request) {
+		for _, d := range active {
+			d(req)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPCheckerAppliesDecorator(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	h := &HTTPChecker{
+		Timeout: time.Second,
+		Decorate: func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer test")
+		},
+	}
+	res := h.Check(context.Background(), server.URL)
+	if res.Status != "ok" {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if gotAuth != "Bearer test" {
+		t.Fatalf("expected decorated request, got Authorization %q", gotAuth)
+	}
+}
+
+func TestWithDecoratorNil(t *testing.T) {
+	base := &http.Transport{}
+	if WithDecorator(base, nil) != http.RoundTripper(base) {
+		t.Fatal("expected nil decorator to leave the transport unchanged")
+	}
+}
+
+func TestHeaderAndChainDecorators(t *testing.T) {
+	if HeaderDecorator(nil) != nil || ChainDecorators(nil, nil) != nil {
+		t.Fatal("expected nil decorators when nothing is configured")
+	}
+
+	headers := http.Header{}
+	headers.Set("User-Agent", "acme-pentest/1.0")
+	headers.Set("X-Pentest-ID", "ENG-42")
+	decorate := ChainDecorators(HeaderDecorator(headers), func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer test")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/", nil)
+	req.Header.Set("User-Agent", "Go-http-client/1.1")
+	decorate(req)
+
+	if got := req.Header.Get("User-Agent"); got != "acme-pentest/1.0" {
+		t.Fatalf("expected User-Agent to be replaced, got %q", got)
+	}
+	if req.Header.Get("X-Pentest-ID") != "ENG-42" || req.Header.Get("Authorization") != "Bearer test" {
+		t.Fatalf("expected all decorators to run, got %v", req.Header)
+	}
+}
@@ -11,6 +11,10 @@ import (
 type DNSChecker struct {
 	Timeout    time.Duration
 	NameServer []string // Optional custom nameservers
+	// Hygiene, when set, also checks the zone's delegation and registration.
+	Hygiene *DomainHygieneChecker
+	// GeoIP, when set, records the AS and country of each resolved address.
+	GeoIP *GeoIPDatabase
 }
 
 // Check performs DNS resolution checks on the target
@@ -25,20 +29,7 @@ func (d *DNSChecker) Check(ctx context.Context, target string) CheckResult {
 	host := ExtractHost(target)
 
 	// Create resolver
-	resolver := &net.Resolver{
-		PreferGo: true,
-	}
-
-	// If custom nameservers provided, use them
-	if len(d.NameServer) > 0 {
-		dialer := &net.Dialer{
-			Timeout: d.Timeout,
-		}
-		resolver.Dial = func(ctx context.Context, network, address string) (net.Conn, error) {
-			// Use first nameserver for now
-			return dialer.DialContext(ctx, network, d.NameServer[0])
-		}
-	}
+	resolver := d.Resolver()
 
 	// Create context with timeout
 	lookupCtx, cancel := context.WithTimeout(ctx, d.Timeout)
@@ -139,9 +130,39 @@ func (d *DNSChecker) Check(ctx context.Context, target string) CheckResult {
 		}
 	}
 
+	if d.GeoIP != nil {
+		result.Hosting = d.GeoIP.Enrich(aRecords)
+	}
+
+	if d.Hygiene != nil {
+		result.DomainHygiene = d.Hygiene.Check(ctx, host)
+		if result.DomainHygiene != nil && len(result.DomainHygiene.Findings) > 0 {
+			result.Notes += fmt.Sprintf(", %d domain hygiene finding(s)", len(result.DomainHygiene.Findings))
+		}
+	}
+
 	return result
 }
 
+// Resolver returns a resolver that uses the custom nameservers, if any.
+func (d *DNSChecker) Resolver() *net.Resolver {
+	resolver := &net.Resolver{
+		PreferGo: true,
+	}
+
+	// If custom nameservers provided, use them
+	if len(d.NameServer) > 0 {
+		dialer := &net.Dialer{
+			Timeout: d.Timeout,
+		}
+		resolver.Dial = func(ctx context.Context, network, address string) (net.Conn, error) {
+			// Use first nameserver for now
+			return dialer.DialContext(ctx, network, d.NameServer[0])
+		}
+	}
+	return resolver
+}
+
 func (d *DNSChecker) Name() string {
 	return "check dns"
 }
@@ -0,0 +1,272 @@
+package checker
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"time"
+)
+
+// Minimal DNS message support for the questions net.Resolver cannot ask:
+// non-recursive queries sent to one nameserver, with the AA flag and the
+// authority section exposed.
+
+const (
+	dnsTypeNS  uint16 = 2
+	dnsClassIN uint16 = 1
+
+	dnsRcodeSuccess  = 0
+	dnsRcodeServFail = 2
+	dnsRcodeNXDomain = 3
+	dnsRcodeRefused  = 5
+
+	dnsHeaderLen  = 12
+	dnsMaxUDPSize = 4096
+)
+
+var errDNSShortMessage = errors.New("short DNS message")
+
+// dnsRecord is a resource record. Data holds the target name for NS records
+// and is empty for other types.
+type dnsRecord struct {
+	Name string
+	Type uint16
+	Data string
+}
+
+type dnsMessage struct {
+	ID            uint16
+	Authoritative bool
+	Truncated     bool
+	Rcode         int
+	Answers       []dnsRecord
+	Authority     []dnsRecord
+}
+
+// nameserversFor returns the NS targets for zone found in records.
+func nameserversFor(zone string, records []dnsRecord) []string {
+	var names []string
+	for _, rr := range records {
+		if rr.Type == dnsTypeNS && rr.Name == zone && rr.Data != "" {
+			names = append(names, rr.Data)
+		}
+	}
+	return names
+}
+
+func dnsRcodeName(rcode int) string {
+	switch rcode {
+	case dnsRcodeSuccess:
+		return "NOERROR"
+	case dnsRcodeServFail:
+		return "SERVFAIL"
+	case dnsRcodeNXDomain:
+		return "NXDOMAIN"
+	case dnsRcodeRefused:
+		return "REFUSED"
+	default:
+		return fmt.Sprintf("RCODE%d", rcode)
+	}
+}
+
+// buildDNSQuery encodes a single-question query with recursion not desired.
+func buildDNSQuery(id uint16, name string, qtype uint16) ([]byte, error) {
+	msg := make([]byte, dnsHeaderLen, 512)
+	binary.BigEndian.PutUint16(msg[0:], id)
+	binary.BigEndian.PutUint16(msg[4:], 1) // QDCOUNT
+
+	msg, err := appendDNSName(msg, name)
+	if err != nil {
+		return nil, err
+	}
+	msg = binary.BigEndian.AppendUint16(msg, qtype)
+	msg = binary.BigEndian.AppendUint16(msg, dnsClassIN)
+	return msg, nil
+}
+
+func appendDNSName(b []byte, name string) ([]byte, error) {
+	name = strings.TrimSuffix(name, ".")
+	if name != "" {
+		for _, label := range strings.Split(name, ".") {
+			if len(label) == 0 || len(label) > 63 {
+				return nil, fmt.Errorf("invalid DNS name %q", name)
+			}
+			b = append(b, byte(len(label)))
+			b = append(b, label...)
+		}
+	}
+	return append(b, 0), nil
+}
+
+func parseDNSMessage(msg []byte) (*dnsMessage, error) {
+	if len(msg) < dnsHeaderLen {
+		return nil, errDNSShortMessage
+	}
+	flags := binary.BigEndian.Uint16(msg[2:])
+	m := &dnsMessage{
+		ID:            binary.BigEndian.Uint16(msg[0:]),
+		Authoritative: flags&0x0400 != 0,
+		Truncated:     flags&0x0200 != 0,
+		Rcode:         int(flags & 0x000f),
+	}
+	qdCount := int(binary.BigEndian.Uint16(msg[4:]))
+	anCount := int(binary.BigEndian.Uint16(msg[6:]))
+	nsCount := int(binary.BigEndian.Uint16(msg[8:]))
+
+	off := dnsHeaderLen
+	for i := 0; i < qdCount; i++ {
+		var err error
+		if _, off, err = readDNSName(msg, off); err != nil {
+			return nil, err
+		}
+		off += 4 // QTYPE, QCLASS
+		if off > len(msg) {
+			return nil, errDNSShortMessage
+		}
+	}
+
+	var err error
+	if m.Answers, off, err = readDNSRecords(msg, off, anCount); err != nil {
+		return nil, err
+	}
+	if m.Authority, _, err = readDNSRecords(msg, off, nsCount); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func readDNSRecords(msg []byte, off, count int) ([]dnsRecord, int, error) {
+	records := make([]dnsRecord, 0, count)
+	for i := 0; i < count; i++ {
+		name, next, err := readDNSName(msg, off)
+		if err != nil {
+			return nil, 0, err
+		}
+		off = next
+		if off+10 > len(msg) {
+			return nil, 0, errDNSShortMessage
+		}
+		rr := dnsRecord{Name: name, Type: binary.BigEndian.Uint16(msg[off:])}
+		rdLen := int(binary.BigEndian.Uint16(msg[off+8:]))
+		off += 10
+		if off+rdLen > len(msg) {
+			return nil, 0, errDNSShortMessage
+		}
+		if rr.Type == dnsTypeNS {
+			if rr.Data, _, err = readDNSName(msg, off); err != nil {
+				return nil, 0, err
+			}
+		}
+		off += rdLen
+		records = append(records, rr)
+	}
+	return records, off, nil
+}
+
+// readDNSName decodes a possibly compressed name at off. It returns the name
+// in lower case without the trailing dot, and the offset just past it.
+func readDNSName(msg []byte, off int) (string, int, error) {
+	var labels []string
+	next := -1
+	for jumps := 0; ; {
+		if off >= len(msg) {
+			return "", 0, errDNSShortMessage
+		}
+		l := int(msg[off])
+		switch {
+		case l == 0:
+			if next < 0 {
+				next = off + 1
+			}
+			return strings.ToLower(strings.Join(labels, ".")), next, nil
+		case l&0xC0 == 0xC0:
+			if off+1 >= len(msg) {
+				return "", 0, errDNSShortMessage
+			}
+			if next < 0 {
+				next = off + 2
+			}
+			if jumps++; jumps > 16 {
+				return "", 0, errors.New("DNS name compression loop")
+			}
+			off = int(binary.BigEndian.Uint16(msg[off:]) & 0x3FFF)
+		case l&0xC0 != 0:
+			return "", 0, fmt.Errorf("unsupported DNS label type 0x%x", l&0xC0)
+		default:
+			if off+1+l > len(msg) {
+				return "", 0, errDNSShortMessage
+			}
+			labels = append(labels, string(msg[off+1:off+1+l]))
+			off += 1 + l
+		}
+	}
+}
+
+// exchangeDNS sends a non-recursive query for name to server (host:port)
+// over UDP, retrying over TCP when the answer is truncated.
+func exchangeDNS(ctx context.Context, server, name string, qtype uint16, timeout time.Duration) (*dnsMessage, error) {
+	id := uint16(rand.Uint32())
+	query, err := buildDNSQuery(id, name, qtype)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := exchangeDNSOver(ctx, "udp", server, query, timeout)
+	if err == nil && resp.Truncated {
+		resp, err = exchangeDNSOver(ctx, "tcp", server, query, timeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.ID != id {
+		return nil, fmt.Errorf("DNS response from %s has mismatched ID", server)
+	}
+	return resp, nil
+}
+
+func exchangeDNSOver(ctx context.Context, network, server string, query []byte, timeout time.Duration) (*dnsMessage, error) {
+	dialer := &net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, network, server)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.SetDeadline(deadline)
+
+	var buf []byte
+	if network == "tcp" {
+		framed := binary.BigEndian.AppendUint16(nil, uint16(len(query)))
+		if _, err := conn.Write(append(framed, query...)); err != nil {
+			return nil, err
+		}
+		var length [2]byte
+		if _, err := io.ReadFull(conn, length[:]); err != nil {
+			return nil, err
+		}
+		buf = make([]byte, binary.BigEndian.Uint16(length[:]))
+		if _, err := io.ReadFull(conn, buf); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := conn.Write(query); err != nil {
+			return nil, err
+		}
+		buf = make([]byte, dnsMaxUDPSize)
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		buf = buf[:n]
+	}
+	return parseDNSMessage(buf)
+}
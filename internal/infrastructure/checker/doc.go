@@ -10,6 +10,9 @@
 //   - Shared result structs (CheckResult, SecurityHeadersResult, TLSComplianceResult,
 //     NetworkSecurityResult, etc.) model the telemetry stored in http_results.json and
 //     consumed by reports.
+//   - Analyzers turn those structs into Findings, one shape for every result
+//     type. The Runner records them on each CheckResult so reports, exporters
+//     and issue sync read ResultFindings instead of special-casing structs.
 //   - Helper utilities (ParseTarget, AnalyzeSecurityHeaders, AnalyzeTLSCompliance,
 //     and so on) are factored here so CLI commands in cmd/ simply instantiate
 //     a checker and feed it into the runner.
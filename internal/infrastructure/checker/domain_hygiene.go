@@ -0,0 +1,450 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Domain hygiene finding types.
+const (
+	DomainHygieneLameDelegation = "lame_delegation"
+	DomainHygieneNSMismatch     = "ns_mismatch"
+	DomainHygieneExpiring       = "domain_expiring"
+	DomainHygieneExpired        = "domain_expired"
+)
+
+const (
+	// DefaultDomainExpiryWarning flags registrations expiring within 30 days.
+	DefaultDomainExpiryWarning = 30 * 24 * time.Hour
+
+	defaultHygieneTimeout = 5 * time.Second
+)
+
+// DomainHygieneResult records delegation and registration health for the
+// zone a DNS target belongs to.
+type DomainHygieneResult struct {
+	Zone         string                 `json:"zone"`
+	ParentNS     []string               `json:"parent_ns,omitempty"`
+	ChildNS      []string               `json:"child_ns,omitempty"`
+	Nameservers  []NameserverStatus     `json:"nameservers,omitempty"`
+	Registration *DomainRegistration    `json:"registration,omitempty"`
+	Findings     []DomainHygieneFinding `json:"findings,omitempty"`
+	Errors       []string               `json:"errors,omitempty"`
+}
+
+// NameserverStatus is how one delegated nameserver answered for the zone.
+type NameserverStatus struct {
+	Name          string   `json:"name"`
+	Addresses     []string `json:"addresses,omitempty"`
+	Authoritative bool     `json:"authoritative"`
+	Reason        string   `json:"reason,omitempty"`
+}
+
+// DomainHygieneFinding is a single delegation or registration problem.
+type DomainHygieneFinding struct {
+	Type     string `json:"type"`
+	Severity string `json:"severity"`
+	Detail   string `json:"detail"`
+}
+
+// DomainHygieneChecker checks that a zone's nameservers answer
+// authoritatively, that the parent and child agree on the NS set, and that
+// the domain registration is not about to lapse.
+type DomainHygieneChecker struct {
+	Timeout       time.Duration // Per-query timeout (default 5s)
+	Resolver      *net.Resolver // Resolves zone and nameserver names; nil uses the system resolver
+	Port          int           // Port for direct nameserver queries (default 53)
+	ExpiryWarning time.Duration // Flag registrations expiring within this window (default 30 days)
+	RDAP          *RDAPClient   // Registration lookups; nil uses the IANA bootstrap
+
+	mu    sync.Mutex
+	zones map[string]*DomainHygieneResult
+}
+
+// Check inspects the zone containing host. It returns nil when no enclosing
+// zone could be found. Each zone is checked once per checker, so targets in
+// the same zone share a result.
+func (h *DomainHygieneChecker) Check(ctx context.Context, host string) *DomainHygieneResult {
+	zone, resolvedNS := h.findZone(ctx, host)
+	if zone == "" {
+		return nil
+	}
+	h.mu.Lock()
+	cached, ok := h.zones[zone]
+	h.mu.Unlock()
+	if ok {
+		return cached
+	}
+
+	result := h.checkZone(ctx, zone, resolvedNS)
+	if ctx.Err() != nil {
+		return result // may be incomplete; let the next target retry
+	}
+	h.mu.Lock()
+	if h.zones == nil {
+		h.zones = make(map[string]*DomainHygieneResult)
+	}
+	h.zones[zone] = result
+	h.mu.Unlock()
+	return result
+}
+
+func (h *DomainHygieneChecker) checkZone(ctx context.Context, zone string, resolvedNS []string) *DomainHygieneResult {
+	result := &DomainHygieneResult{Zone: zone}
+
+	parentNS, err := h.parentNameservers(ctx, zone)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("parent delegation: %v", err))
+	}
+	result.ParentNS = parentNS
+
+	delegated := normalizeNameservers(append(slices.Clone(parentNS), resolvedNS...))
+	var childNS []string
+	result.Nameservers, childNS = h.probeNameservers(ctx, zone, delegated)
+	for _, ns := range result.Nameservers {
+		if !ns.Authoritative {
+			result.Findings = append(result.Findings, DomainHygieneFinding{
+				Type:     DomainHygieneLameDelegation,
+				Severity: "medium",
+				Detail:   fmt.Sprintf("%s does not answer authoritatively for %s: %s", ns.Name, zone, ns.Reason),
+			})
+		}
+	}
+	if len(childNS) == 0 {
+		childNS = normalizeNameservers(resolvedNS)
+	}
+	result.ChildNS = childNS
+
+	if len(parentNS) > 0 && len(childNS) > 0 {
+		if onlyParent, onlyChild := diffNameservers(parentNS, childNS); len(onlyParent)+len(onlyChild) > 0 {
+			var parts []string
+			if len(onlyParent) > 0 {
+				parts = append(parts, "delegated by parent only: "+strings.Join(onlyParent, ", "))
+			}
+			if len(onlyChild) > 0 {
+				parts = append(parts, "listed by zone only: "+strings.Join(onlyChild, ", "))
+			}
+			result.Findings = append(result.Findings, DomainHygieneFinding{
+				Type:     DomainHygieneNSMismatch,
+				Severity: "low",
+				Detail:   fmt.Sprintf("parent and child NS sets differ for %s (%s)", zone, strings.Join(parts, "; ")),
+			})
+		}
+	}
+
+	reg, err := h.rdapClient().Lookup(ctx, zone)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("rdap: %v", err))
+	} else {
+		result.Registration = reg
+		if finding, ok := h.expiryFinding(reg, time.Now()); ok {
+			result.Findings = append(result.Findings, finding)
+		}
+	}
+
+	return result
+}
+
+// findZone walks up from host to the closest name with NS records.
+func (h *DomainHygieneChecker) findZone(ctx context.Context, host string) (string, []string) {
+	name := strings.ToLower(strings.TrimSuffix(host, "."))
+	if net.ParseIP(name) != nil {
+		return "", nil
+	}
+	for name != "" {
+		nsCtx, cancel := context.WithTimeout(ctx, h.timeout())
+		records, err := h.resolver().LookupNS(nsCtx, name)
+		cancel()
+		if err == nil && len(records) > 0 {
+			names := make([]string, 0, len(records))
+			for _, ns := range records {
+				names = append(names, ns.Host)
+			}
+			return name, names
+		}
+		_, parent, found := strings.Cut(name, ".")
+		if !found {
+			break
+		}
+		name = parent
+	}
+	return "", nil
+}
+
+// parentNameservers asks the parent zone's servers for the delegation of
+// zone, as published in the referral.
+func (h *DomainHygieneChecker) parentNameservers(ctx context.Context, zone string) ([]string, error) {
+	_, parent, found := strings.Cut(zone, ".")
+	if !found {
+		return nil, nil // TLDs are delegated from the root; not checked
+	}
+
+	nsCtx, cancel := context.WithTimeout(ctx, h.timeout())
+	servers, err := h.resolver().LookupNS(nsCtx, parent)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("lookup NS for %s: %w", parent, err)
+	}
+
+	var lastErr error
+	for _, server := range servers {
+		for _, addr := range h.addresses(ctx, server.Host) {
+			resp, err := exchangeDNS(ctx, h.serverAddr(addr), zone, dnsTypeNS, h.timeout())
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			if resp.Rcode != dnsRcodeSuccess {
+				lastErr = fmt.Errorf("%s answered %s", server.Host, dnsRcodeName(resp.Rcode))
+				continue
+			}
+			names := nameserversFor(zone, resp.Authority)
+			if len(names) == 0 {
+				// The parent's servers may also serve the child zone.
+				names = nameserversFor(zone, resp.Answers)
+			}
+			if len(names) > 0 {
+				return normalizeNameservers(names), nil
+			}
+			lastErr = fmt.Errorf("%s returned no delegation", server.Host)
+		}
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no reachable nameserver for %s", parent)
+	}
+	return nil, lastErr
+}
+
+// probeNameservers queries every delegated nameserver directly. It also
+// returns the NS set the zone's authoritative servers publish.
+func (h *DomainHygieneChecker) probeNameservers(ctx context.Context, zone string, names []string) ([]NameserverStatus, []string) {
+	statuses := make([]NameserverStatus, len(names))
+	published := make([][]string, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i], published[i] = h.probeNameserver(ctx, zone, name)
+		}()
+	}
+	wg.Wait()
+
+	for _, ns := range published {
+		if len(ns) > 0 {
+			return statuses, normalizeNameservers(ns)
+		}
+	}
+	return statuses, nil
+}
+
+// probeNameserver reports whether any address of name answers
+// authoritatively for zone, and the NS set it returned. A nameserver that
+// only refuses, fails, or answers without the AA flag is lame.
+func (h *DomainHygieneChecker) probeNameserver(ctx context.Context, zone, name string) (NameserverStatus, []string) {
+	status := NameserverStatus{Name: name, Addresses: h.addresses(ctx, name)}
+	if len(status.Addresses) == 0 {
+		status.Reason = "name does not resolve"
+		return status, nil
+	}
+	for _, addr := range status.Addresses {
+		resp, err := exchangeDNS(ctx, h.serverAddr(addr), zone, dnsTypeNS, h.timeout())
+		switch {
+		case err != nil:
+			status.Reason = fmt.Sprintf("%s: no response", addr)
+		case resp.Rcode != dnsRcodeSuccess:
+			status.Reason = fmt.Sprintf("%s: %s", addr, dnsRcodeName(resp.Rcode))
+		case !resp.Authoritative:
+			status.Reason = fmt.Sprintf("%s: not authoritative", addr)
+		default:
+			status.Authoritative = true
+			status.Reason = ""
+			return status, nameserversFor(zone, resp.Answers)
+		}
+	}
+	return status, nil
+}
+
+func (h *DomainHygieneChecker) expiryFinding(reg *DomainRegistration, now time.Time) (DomainHygieneFinding, bool) {
+	if reg.Expires == nil {
+		return DomainHygieneFinding{}, false
+	}
+	warning := h.ExpiryWarning
+	if warning <= 0 {
+		warning = DefaultDomainExpiryWarning
+	}
+	left := reg.Expires.Sub(now)
+	switch {
+	case left <= 0:
+		return DomainHygieneFinding{
+			Type:     DomainHygieneExpired,
+			Severity: "high",
+			Detail:   fmt.Sprintf("%s registration expired on %s", reg.Domain, reg.Expires.Format("2006-01-02")),
+		}, true
+	case left <= warning:
+		return DomainHygieneFinding{
+			Type:     DomainHygieneExpiring,
+			Severity: "medium",
+			Detail:   fmt.Sprintf("%s registration expires on %s (%d days left)", reg.Domain, reg.Expires.Format("2006-01-02"), reg.DaysLeft),
+		}, true
+	}
+	return DomainHygieneFinding{}, false
+}
+
+func (h *DomainHygieneChecker) rdapClient() *RDAPClient {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.RDAP == nil {
+		h.RDAP = &RDAPClient{Timeout: h.timeout()}
+	}
+	return h.RDAP
+}
+
+func (h *DomainHygieneChecker) addresses(ctx context.Context, name string) []string {
+	lookupCtx, cancel := context.WithTimeout(ctx, h.timeout())
+	defer cancel()
+	addrs, err := h.resolver().LookupHost(lookupCtx, name)
+	if err != nil {
+		return nil
+	}
+	slices.Sort(addrs)
+	return addrs
+}
+
+func (h *DomainHygieneChecker) serverAddr(ip string) string {
+	port := h.Port
+	if port == 0 {
+		port = 53
+	}
+	return net.JoinHostPort(ip, strconv.Itoa(port))
+}
+
+func (h *DomainHygieneChecker) resolver() *net.Resolver {
+	if h.Resolver != nil {
+		return h.Resolver
+	}
+	return net.DefaultResolver
+}
+
+func (h *DomainHygieneChecker) timeout() time.Duration {
+	if h.Timeout > 0 {
+		return h.Timeout
+	}
+	return defaultHygieneTimeout
+}
+
+// normalizeNameservers lower-cases names, strips trailing dots, and returns
+// them sorted without duplicates.
+func normalizeNameservers(names []string) []string {
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		if name = strings.ToLower(strings.TrimSuffix(name, ".")); name != "" {
+			out = append(out, name)
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// diffNameservers returns the names only in parent and only in child. Both
+// inputs must be normalized.
+func diffNameservers(parent, child []string) (onlyParent, onlyChild []string) {
+	for _, name := range parent {
+		if !slices.Contains(child, name) {
+			onlyParent = append(onlyParent, name)
+		}
+	}
+	for _, name := range child {
+		if !slices.Contains(parent, name) {
+			onlyChild = append(onlyChild, name)
+		}
+	}
+	return onlyParent, onlyChild
+}
+
+// domainHygieneVulnerabilities maps each finding type to its report entry,
+// in report order.
+var domainHygieneVulnerabilities = []struct {
+	Type           string
+	Name           string
+	Severity       string
+	CVSS           float64
+	Vector         string
+	Description    string
+	Recommendation string
+}{
+	{
+		Type:           DomainHygieneExpired,
+		Name:           "Domain Registration Expired",
+		Severity:       "High",
+		CVSS:           7.5,
+		Vector:         "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:H",
+		Description:    "The registry reports the domain registration as expired. It may stop resolving at any time and can be re-registered by a third party once released.",
+		Recommendation: "Renew the domain immediately through the registrar, then enable auto-renewal.",
+	},
+	{
+		Type:           DomainHygieneExpiring,
+		Name:           "Domain Registration Expiring Soon",
+		Severity:       "Medium",
+		CVSS:           5.3,
+		Vector:         "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:L",
+		Description:    "The domain registration expires soon. If it lapses, every service under the domain stops resolving and the name can be re-registered by a third party.",
+		Recommendation: "Renew the domain and enable auto-renewal with a monitored billing contact.",
+	},
+	{
+		Type:           DomainHygieneLameDelegation,
+		Name:           "Lame DNS Delegation",
+		Severity:       "Medium",
+		CVSS:           5.3,
+		Vector:         "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:L",
+		Description:    "A delegated nameserver does not answer authoritatively for the zone. Resolution slows down or fails intermittently, and a nameserver whose own domain has lapsed can be registered by someone else to hijack the zone.",
+		Recommendation: "Remove the nameserver from the delegation at the registrar and from the zone's NS records, or configure it to serve the zone.",
+	},
+	{
+		Type:           DomainHygieneNSMismatch,
+		Name:           "Inconsistent NS Delegation",
+		Severity:       "Low",
+		CVSS:           3.7,
+		Vector:         "CVSS:3.1/AV:N/AC:H/PR:N/UI:N/S:U/C:N/I:N/A:L",
+		Description:    "The NS records in the parent zone differ from those the zone publishes. Resolvers see different nameserver sets depending on which they trust, and entries left on one side are often forgotten servers.",
+		Recommendation: "Make the registrar delegation and the zone's own NS records list the same nameservers.",
+	},
+}
+
+func analyzeDomainHygiene(hygiene *DomainHygieneResult) []Vulnerability {
+	var vulns []Vulnerability
+	for _, spec := range domainHygieneVulnerabilities {
+		var evidence []string
+		for _, f := range hygiene.Findings {
+			if f.Type == spec.Type {
+				evidence = append(evidence, "• "+f.Detail)
+			}
+		}
+		if len(evidence) == 0 {
+			continue
+		}
+		vulns = append(vulns, Vulnerability{
+			Name:           spec.Name,
+			Category:       "DNS",
+			Severity:       spec.Severity,
+			Score:          0,
+			MaxScore:       10,
+			Status:         "Failed",
+			Description:    spec.Description,
+			Recommendation: spec.Recommendation + "\n\nEvidence:\n" + strings.Join(evidence, "\n"),
+			CVSS: &CVSSScore{
+				BaseScore: spec.CVSS,
+				Severity:  strings.ToUpper(spec.Severity),
+				Vector:    spec.Vector,
+				Version:   "3.1",
+			},
+		})
+	}
+	return vulns
+}
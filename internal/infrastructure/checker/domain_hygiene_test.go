@@ -0,0 +1,264 @@
+package checker
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testDNSRecord struct {
+	name string
+	typ  uint16
+	data string // NS target or IPv4 address
+}
+
+type testDNSAnswer struct {
+	rcode     int
+	aa, ra    bool
+	answers   []testDNSRecord
+	authority []testDNSRecord
+}
+
+// serveTestDNS answers UDP queries on addr with handler until the test ends.
+func serveTestDNS(t *testing.T, addr string, handler func(name string, qtype uint16) testDNSAnswer) {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		t.Skipf("cannot listen on %s: %v", addr, err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			n, peer, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			query := buf[:n]
+			name, off, err := readDNSName(query, dnsHeaderLen)
+			if err != nil {
+				continue
+			}
+			qtype := binary.BigEndian.Uint16(query[off:])
+			_, _ = conn.WriteTo(encodeTestDNS(query[:off+4], handler(name, qtype)), peer)
+		}
+	}()
+}
+
+// encodeTestDNS builds a response echoing the question in query.
+func encodeTestDNS(query []byte, a testDNSAnswer) []byte {
+	msg := append([]byte(nil), query...)
+	flags := uint16(0x8000) | uint16(a.rcode)
+	if a.aa {
+		flags |= 0x0400
+	}
+	if a.ra {
+		flags |= 0x0080
+	}
+	flags |= binary.BigEndian.Uint16(query[2:]) & 0x0100 // echo RD
+	binary.BigEndian.PutUint16(msg[2:], flags)
+	binary.BigEndian.PutUint16(msg[6:], uint16(len(a.answers)))
+	binary.BigEndian.PutUint16(msg[8:], uint16(len(a.authority)))
+	binary.BigEndian.PutUint16(msg[10:], 0)
+
+	for _, rr := range append(append([]testDNSRecord(nil), a.answers...), a.authority...) {
+		msg, _ = appendDNSName(msg, rr.name)
+		msg = binary.BigEndian.AppendUint16(msg, rr.typ)
+		msg = binary.BigEndian.AppendUint16(msg, dnsClassIN)
+		msg = binary.BigEndian.AppendUint32(msg, 300)
+		var rdata []byte
+		if rr.typ == dnsTypeNS {
+			rdata, _ = appendDNSName(nil, rr.data)
+		} else {
+			rdata = net.ParseIP(rr.data).To4()
+		}
+		msg = binary.BigEndian.AppendUint16(msg, uint16(len(rdata)))
+		msg = append(msg, rdata...)
+	}
+	return msg
+}
+
+func TestParseDNSMessage_Compression(t *testing.T) {
+	query, err := buildDNSQuery(7, "Example.Test.", dnsTypeNS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := encodeTestDNS(query, testDNSAnswer{aa: true})
+	binary.BigEndian.PutUint16(msg[6:], 1)
+	// example.test NS ns1.<pointer to example.test in the question>
+	msg = append(msg, 0xC0, dnsHeaderLen)
+	msg = binary.BigEndian.AppendUint16(msg, dnsTypeNS)
+	msg = binary.BigEndian.AppendUint16(msg, dnsClassIN)
+	msg = binary.BigEndian.AppendUint32(msg, 300)
+	msg = binary.BigEndian.AppendUint16(msg, 6)
+	msg = append(msg, 3, 'n', 's', '1', 0xC0, dnsHeaderLen)
+
+	resp, err := parseDNSMessage(msg)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if resp.ID != 7 || !resp.Authoritative || resp.Rcode != dnsRcodeSuccess {
+		t.Fatalf("unexpected header %+v", resp)
+	}
+	if got := nameserversFor("example.test", resp.Answers); len(got) != 1 || got[0] != "ns1.example.test" {
+		t.Fatalf("nameservers = %v", got)
+	}
+
+	if _, err := parseDNSMessage(msg[:len(msg)-3]); err == nil {
+		t.Fatal("expected error for truncated message")
+	}
+	loop := append([]byte(nil), msg[:dnsHeaderLen]...)
+	binary.BigEndian.PutUint16(loop[4:], 1)
+	loop = append(loop, 0xC0, dnsHeaderLen)
+	if _, err := parseDNSMessage(loop); err == nil {
+		t.Fatal("expected error for compression loop")
+	}
+}
+
+func TestDomainHygieneChecker(t *testing.T) {
+	const (
+		recursive = "127.0.0.1"
+		ns1       = "127.0.0.2"
+		parent    = "127.0.0.3"
+		ns2       = "127.0.0.4"
+	)
+	probe, err := net.ListenPacket("udp", recursive+":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := probe.LocalAddr().(*net.UDPAddr).Port
+	probe.Close()
+	at := func(ip string) string { return net.JoinHostPort(ip, fmt.Sprint(port)) }
+
+	serveTestDNS(t, at(recursive), func(name string, qtype uint16) testDNSAnswer {
+		ns := func(zone string, targets ...string) testDNSAnswer {
+			a := testDNSAnswer{ra: true}
+			for _, target := range targets {
+				a.answers = append(a.answers, testDNSRecord{zone, dnsTypeNS, target})
+			}
+			return a
+		}
+		addr := map[string]string{"ns1.example.test": ns1, "ns2.example.test": ns2, "a.nic.test": parent}
+		switch {
+		case qtype == dnsTypeNS && name == "example.test":
+			return ns("example.test", "ns1.example.test", "ns2.example.test")
+		case qtype == dnsTypeNS && name == "test":
+			return ns("test", "a.nic.test")
+		case qtype == 1 && addr[name] != "":
+			return testDNSAnswer{ra: true, answers: []testDNSRecord{{name, 1, addr[name]}}}
+		case addr[name] != "":
+			return testDNSAnswer{ra: true} // no AAAA
+		}
+		return testDNSAnswer{ra: true, rcode: dnsRcodeNXDomain}
+	})
+	serveTestDNS(t, at(parent), func(name string, qtype uint16) testDNSAnswer {
+		return testDNSAnswer{authority: []testDNSRecord{
+			{"example.test", dnsTypeNS, "ns1.example.test"},
+			{"example.test", dnsTypeNS, "ns2.example.test"},
+		}}
+	})
+	serveTestDNS(t, at(ns1), func(name string, qtype uint16) testDNSAnswer {
+		return testDNSAnswer{aa: true, answers: []testDNSRecord{
+			{"example.test", dnsTypeNS, "ns1.example.test"},
+			{"example.test", dnsTypeNS, "ns3.example.test"},
+		}}
+	})
+	serveTestDNS(t, at(ns2), func(name string, qtype uint16) testDNSAnswer {
+		return testDNSAnswer{rcode: dnsRcodeRefused}
+	})
+
+	expires := time.Now().Add(10 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	var rdap *httptest.Server
+	rdap = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/dns.json":
+			fmt.Fprintf(w, `{"services":[[["test"],["%s/rdap/"]]]}`, rdap.URL)
+		case "/rdap/domain/example.test":
+			fmt.Fprintf(w, `{"ldhName":"EXAMPLE.TEST","status":["active"],
+				"events":[{"eventAction":"registration","eventDate":"2001-01-01T00:00:00Z"},{"eventAction":"expiration","eventDate":%q}],
+				"entities":[{"roles":["registrar"],"vcardArray":["vcard",[["version",{},"text","4.0"],["fn",{},"text","Test Registrar"]]]}]}`, expires)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer rdap.Close()
+
+	dialer := &net.Dialer{Timeout: time.Second}
+	h := &DomainHygieneChecker{
+		Timeout: time.Second,
+		Resolver: &net.Resolver{PreferGo: true, Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, at(recursive))
+		}},
+		Port: port,
+		RDAP: &RDAPClient{BootstrapURL: rdap.URL + "/dns.json"},
+	}
+
+	result := h.Check(context.Background(), "www.example.test")
+	if result == nil {
+		t.Fatal("expected a result")
+	}
+	if result.Zone != "example.test" {
+		t.Fatalf("zone = %q", result.Zone)
+	}
+	if got := strings.Join(result.ParentNS, ","); got != "ns1.example.test,ns2.example.test" {
+		t.Errorf("parent NS = %s (errors %v)", got, result.Errors)
+	}
+	if got := strings.Join(result.ChildNS, ","); got != "ns1.example.test,ns3.example.test" {
+		t.Errorf("child NS = %s", got)
+	}
+
+	byType := make(map[string][]string)
+	for _, f := range result.Findings {
+		byType[f.Type] = append(byType[f.Type], f.Detail)
+	}
+	if lame := byType[DomainHygieneLameDelegation]; len(lame) != 1 || !strings.Contains(lame[0], "ns2.example.test") || !strings.Contains(lame[0], "REFUSED") {
+		t.Errorf("lame delegation findings = %v", lame)
+	}
+	if mismatch := byType[DomainHygieneNSMismatch]; len(mismatch) != 1 || !strings.Contains(mismatch[0], "parent only: ns2.example.test") || !strings.Contains(mismatch[0], "zone only: ns3.example.test") {
+		t.Errorf("mismatch findings = %v", mismatch)
+	}
+	if len(byType[DomainHygieneExpiring]) != 1 {
+		t.Errorf("expected an expiry finding, got %+v (errors %v)", result.Findings, result.Errors)
+	}
+	if reg := result.Registration; reg == nil || reg.Registrar != "Test Registrar" || reg.Domain != "example.test" {
+		t.Errorf("registration = %+v", reg)
+	}
+
+	if again := h.Check(context.Background(), "api.example.test"); again != result {
+		t.Error("targets in the same zone should share the cached result")
+	}
+
+	vulns := analyzeDomainHygiene(result)
+	if len(vulns) != 3 || vulns[0].Name != "Domain Registration Expiring Soon" {
+		t.Fatalf("unexpected vulnerabilities %+v", vulns)
+	}
+}
+
+func TestDomainHygieneExpiryFinding(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *DomainRegistration {
+		t := now.Add(d)
+		return &DomainRegistration{Domain: "example.com", Expires: &t}
+	}
+	h := &DomainHygieneChecker{ExpiryWarning: 14 * 24 * time.Hour}
+
+	if f, ok := h.expiryFinding(at(-time.Hour), now); !ok || f.Type != DomainHygieneExpired {
+		t.Errorf("expired registration: %+v %v", f, ok)
+	}
+	if f, ok := h.expiryFinding(at(7*24*time.Hour), now); !ok || f.Type != DomainHygieneExpiring {
+		t.Errorf("expiring registration: %+v %v", f, ok)
+	}
+	if _, ok := h.expiryFinding(at(20*24*time.Hour), now); ok {
+		t.Error("registration outside the warning window should not be flagged")
+	}
+	if _, ok := h.expiryFinding(&DomainRegistration{}, now); ok {
+		t.Error("registration without expiry should not be flagged")
+	}
+}
@@ -0,0 +1,149 @@
+package checker
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ExpectedCertificate constrains the certificate a host may present during an
+// engagement. Empty fields are not checked.
+type ExpectedCertificate struct {
+	// Issuers lists accepted issuers, matched case-insensitively against the
+	// issuer DN (e.g. "Let's Encrypt" or "CN=R11").
+	Issuers []string `json:"issuers,omitempty"`
+	// SANs lists names the certificate must cover, wildcards included.
+	SANs []string `json:"sans,omitempty"`
+}
+
+// Evaluate returns how info deviates from the expectation, or nil when it
+// matches.
+func (e ExpectedCertificate) Evaluate(info *CertificateInfo) []string {
+	if info == nil {
+		return nil
+	}
+	var problems []string
+	if len(e.Issuers) > 0 {
+		issuer := strings.ToLower(info.Issuer)
+		matched := slices.ContainsFunc(e.Issuers, func(want string) bool {
+			return strings.Contains(issuer, strings.ToLower(want))
+		})
+		if !matched {
+			problems = append(problems, fmt.Sprintf("issuer %q is not one of %s", info.Issuer, strings.Join(e.Issuers, ", ")))
+		}
+	}
+	for _, name := range e.SANs {
+		if !certCoversName(info.DNSNames, name) {
+			problems = append(problems, fmt.Sprintf("certificate does not cover %s", name))
+		}
+	}
+	return problems
+}
+
+// certCoversName reports whether one of the certificate's DNS names matches
+// name, with a leading "*." matching exactly one label.
+func certCoversName(dnsNames []string, name string) bool {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	for _, dns := range dnsNames {
+		dns = strings.ToLower(dns)
+		if dns == name {
+			return true
+		}
+		if suffix, ok := strings.CutPrefix(dns, "*."); ok {
+			if label, rest, found := strings.Cut(name, "."); found && label != "" && rest == suffix {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CertDeviation records a host presenting a certificate that does not match
+// its expectation.
+type CertDeviation struct {
+	Host       string    `json:"host"`
+	Target     string    `json:"target"`
+	Issuer     string    `json:"issuer"`
+	DNSNames   []string  `json:"dns_names,omitempty"`
+	Problems   []string  `json:"problems"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// CertExpectations holds the expected certificate per host (lowercase, no
+// port) and the deviations seen by the latest run.
+type CertExpectations struct {
+	Hosts      map[string]ExpectedCertificate `json:"hosts"`
+	Deviations []CertDeviation                `json:"deviations,omitempty"`
+}
+
+// NewCertExpectations returns an empty set of expectations.
+func NewCertExpectations() *CertExpectations {
+	return &CertExpectations{Hosts: make(map[string]ExpectedCertificate)}
+}
+
+// HostKey returns the key target's expectation is stored under.
+func HostKey(target string) string {
+	host, _ := TLSAddress(target)
+	return strings.ToLower(host)
+}
+
+// Check compares the certificate seen on target with the host's expectation
+// and returns the deviation, if any.
+func (c *CertExpectations) Check(target string, info *CertificateInfo, at time.Time) *CertDeviation {
+	expected, ok := c.Hosts[HostKey(target)]
+	if !ok || info == nil {
+		return nil
+	}
+	problems := expected.Evaluate(info)
+	if len(problems) == 0 {
+		return nil
+	}
+	return &CertDeviation{
+		Host:       HostKey(target),
+		Target:     target,
+		Issuer:     info.Issuer,
+		DNSNames:   info.DNSNames,
+		Problems:   problems,
+		DetectedAt: at,
+	}
+}
+
+// Vulnerability converts the deviation into a report finding.
+func (d CertDeviation) Vulnerability() Vulnerability {
+	return Vulnerability{
+		Name:        "Unexpected TLS Certificate",
+		Category:    "Transport Layer Security (TLS)",
+		Severity:    "High",
+		Score:       0,
+		MaxScore:    10,
+		Status:      "Failed",
+		Description: fmt.Sprintf("%s presented a certificate that does not match the engagement's expectation: %s. This can indicate TLS interception, misrouted DNS, or a certificate issued outside the owner's normal process.", d.Host, strings.Join(d.Problems, "; ")),
+		Recommendation: `HIGH: Confirm the certificate with the system owner.
+
+1. Compare the presented issuer and names with the certificate the owner deployed.
+2. Check DNS resolution for the host from the scanning network.
+3. Check Certificate Transparency logs for unexpected issuance.
+4. If the change was planned, update the expectation:
+   seca engagement certs set --id <engagement> --host <host> --issuer <issuer>`,
+		CVSS: &CVSSScore{
+			BaseScore: 7.4,
+			Severity:  "HIGH",
+			Vector:    "CVSS:3.1/AV:N/AC:H/PR:N/UI:N/S:U/C:H/I:H/A:N",
+			Version:   "3.1",
+		},
+		AffectedURLs: []string{d.Target},
+		References: []string{
+			"https://owasp.org/www-project-web-security-testing-guide/latest/4-Web_Application_Security_Testing/09-Testing_for_Weak_Cryptography/01-Testing_for_Weak_Transport_Layer_Security",
+		},
+	}
+}
+
+// CertDeviationVulnerabilities converts recorded deviations into findings.
+func CertDeviationVulnerabilities(deviations []CertDeviation) []Vulnerability {
+	vulns := make([]Vulnerability, 0, len(deviations))
+	for _, d := range deviations {
+		vulns = append(vulns, d.Vulnerability())
+	}
+	return vulns
+}
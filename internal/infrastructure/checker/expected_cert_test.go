@@ -0,0 +1,55 @@
+package checker
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExpectedCertificateEvaluate(t *testing.T) {
+	info := &CertificateInfo{
+		Issuer:   "CN=R11,O=Let's Encrypt,C=US",
+		DNSNames: []string{"app.example.com", "*.api.example.com"},
+	}
+
+	tests := []struct {
+		name     string
+		expected ExpectedCertificate
+		problems int
+	}{
+		{"matching issuer", ExpectedCertificate{Issuers: []string{"let's encrypt"}}, 0},
+		{"one of several issuers", ExpectedCertificate{Issuers: []string{"DigiCert", "CN=R11"}}, 0},
+		{"unexpected issuer", ExpectedCertificate{Issuers: []string{"DigiCert"}}, 1},
+		{"covered names", ExpectedCertificate{SANs: []string{"APP.example.com", "v1.api.example.com"}}, 0},
+		{"wildcard covers one label only", ExpectedCertificate{SANs: []string{"a.b.api.example.com", "api.example.com"}}, 2},
+		{"issuer and name", ExpectedCertificate{Issuers: []string{"DigiCert"}, SANs: []string{"www.example.com"}}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.expected.Evaluate(info); len(got) != tt.problems {
+				t.Errorf("expected %d problems, got %v", tt.problems, got)
+			}
+		})
+	}
+}
+
+func TestCertExpectationsCheck(t *testing.T) {
+	expectations := NewCertExpectations()
+	expectations.Hosts["app.example.com"] = ExpectedCertificate{Issuers: []string{"DigiCert"}}
+	info := &CertificateInfo{Issuer: "CN=Proxy CA", DNSNames: []string{"app.example.com"}}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if d := expectations.Check("https://other.example.com", info, now); d != nil {
+		t.Fatalf("hosts without an expectation must not deviate, got %+v", d)
+	}
+
+	d := expectations.Check("https://App.Example.com:8443/login", info, now)
+	if d == nil || d.Host != "app.example.com" || d.Issuer != "CN=Proxy CA" {
+		t.Fatalf("expected deviation for app.example.com, got %+v", d)
+	}
+
+	vuln := d.Vulnerability()
+	if vuln.Severity != "High" || vuln.Status != "Failed" || !strings.Contains(vuln.Description, "Proxy CA") {
+		t.Fatalf("unexpected vulnerability %+v", vuln)
+	}
+}
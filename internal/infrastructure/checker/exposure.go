@@ -0,0 +1,373 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Exposure finding types.
+const (
+	ExposureDirectoryListing = "directory_listing"
+	ExposureGitConfig        = "git_config"
+	ExposureEnvFile          = "env_file"
+	ExposureBackupFile       = "backup_file"
+)
+
+const (
+	// defaultExposureInterval is the minimum gap between exposure requests
+	// across the whole run.
+	defaultExposureInterval = 500 * time.Millisecond
+	// defaultExposureMaxPerHost caps exposure requests per host and run.
+	defaultExposureMaxPerHost = 60
+	// exposureBodyLimit is how much of each response is inspected.
+	exposureBodyLimit = 8192
+)
+
+// backupSuffixes are appended to each crawled file path, e.g. index.php.bak.
+var backupSuffixes = []string{".bak", "~", ".old", ".orig"}
+
+// directoryListingMarkers identify auto-generated index pages (Apache, nginx,
+// IIS, Tomcat, Python http.server, lighttpd).
+var directoryListingMarkers = []string{
+	"<title>index of /",
+	"<h1>index of /",
+	"<title>directory listing for /",
+	"[to parent directory]",
+	"<h1>directory listing for /",
+}
+
+// envAssignment matches a KEY=value line of a dotenv file.
+var envAssignment = regexp.MustCompile(`(?m)^\s*(?:export\s+)?([A-Z][A-Z0-9_]*)\s*=`)
+
+// ExposureFinding is a file or directory listing served that should not be
+// public.
+type ExposureFinding struct {
+	Type        string `json:"type"` // "directory_listing", "git_config", "env_file", "backup_file"
+	URL         string `json:"url"`
+	ContentType string `json:"content_type,omitempty"`
+	Evidence    string `json:"evidence"`
+}
+
+// ExposureProbe looks for directory listings, exposed .git/config and .env
+// files, backup copies of crawled files and admin interfaces, using fixed
+// wordlists. One probe is shared by every target of a run: each directory
+// and file is probed once, requests are paced at Interval, and each host
+// gets at most MaxPerHost requests. Only GET requests are sent and nothing
+// is written.
+type ExposureProbe struct {
+	Interval   time.Duration
+	MaxPerHost int
+
+	mu      sync.Mutex
+	probed  map[string]bool
+	perHost map[string]int
+	next    time.Time
+}
+
+// NewExposureProbe returns a probe with the default pacing and per-host cap.
+func NewExposureProbe() *ExposureProbe {
+	return &ExposureProbe{
+		Interval:   defaultExposureInterval,
+		MaxPerHost: defaultExposureMaxPerHost,
+	}
+}
+
+// Probe checks the directory containing target and, when target names a
+// file, its backup copies.
+func (p *ExposureProbe) Probe(ctx context.Context, client *http.Client, target string) []ExposureFinding {
+	u, err := url.Parse(ParseTarget(target).FullURL)
+	if err != nil || u.Host == "" {
+		return nil
+	}
+	u.RawQuery, u.Fragment = "", ""
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	dir := *u
+	dir.Path = u.Path[:strings.LastIndex(u.Path, "/")+1]
+
+	var findings []ExposureFinding
+	softNotFound := false
+	if p.claim(dir.String()) {
+		// A random name that answers 200 means the server never returns 404
+		// here, so file guesses would be false positives.
+		if resp, ok := p.fetch(ctx, client, &dir, "seca-"+randomToken()); ok {
+			softNotFound = resp.status == http.StatusOK
+		}
+		if resp, ok := p.fetch(ctx, client, &dir, ""); ok && resp.status == http.StatusOK && isDirectoryListing(resp.body) {
+			findings = append(findings, ExposureFinding{Type: ExposureDirectoryListing, URL: resp.url, ContentType: resp.contentType, Evidence: "auto-generated index page"})
+		}
+		if !softNotFound {
+			findings = append(findings, p.probeSensitiveFiles(ctx, client, &dir)...)
+		}
+	}
+
+	if !strings.HasSuffix(u.Path, "/") && !softNotFound && p.claim(u.String()) {
+		for _, suffix := range backupSuffixes {
+			backup := *u
+			backup.Path += suffix
+			resp, ok := p.fetch(ctx, client, &backup, "")
+			if !ok {
+				break
+			}
+			if resp.status == http.StatusOK && len(resp.body) > 0 && !strings.HasPrefix(resp.contentType, "text/html") {
+				findings = append(findings, ExposureFinding{
+					Type:        ExposureBackupFile,
+					URL:         resp.url,
+					ContentType: resp.contentType,
+					Evidence:    fmt.Sprintf("backup copy of %s served (%d+ bytes)", u.Path, len(resp.body)),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// probeSensitiveFiles checks dir for .git/config and .env, confirming each
+// by content so error pages are not reported.
+func (p *ExposureProbe) probeSensitiveFiles(ctx context.Context, client *http.Client, dir *url.URL) []ExposureFinding {
+	var findings []ExposureFinding
+	if resp, ok := p.fetch(ctx, client, dir, ".git/config"); ok && resp.status == http.StatusOK && strings.Contains(resp.body, "[core]") {
+		evidence := "git repository configuration ([core] section)"
+		if strings.Contains(resp.body, "[remote ") {
+			evidence += " including remote URLs"
+		}
+		findings = append(findings, ExposureFinding{Type: ExposureGitConfig, URL: resp.url, ContentType: resp.contentType, Evidence: evidence})
+	}
+	if resp, ok := p.fetch(ctx, client, dir, ".env"); ok && resp.status == http.StatusOK && !strings.Contains(strings.ToLower(resp.body), "<html") {
+		if names := envVariableNames(resp.body); len(names) > 0 {
+			// Record variable names only; values are likely secrets.
+			findings = append(findings, ExposureFinding{Type: ExposureEnvFile, URL: resp.url, ContentType: resp.contentType, Evidence: "defines " + strings.Join(names, ", ")})
+		}
+	}
+	return findings
+}
+
+// exposureResponse is the part of a response the probe inspects.
+type exposureResponse struct {
+	url         string
+	status      int
+	contentType string
+	header      http.Header
+	body        string
+}
+
+// fetch requests base+name once the pacing interval allows, returning false
+// when the host's request cap is reached or the request fails.
+func (p *ExposureProbe) fetch(ctx context.Context, client *http.Client, base *url.URL, name string) (*exposureResponse, bool) {
+	if !p.wait(ctx, base.Host) {
+		return nil, false
+	}
+	target := *base
+	target.Path += name
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, false
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, false
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, exposureBodyLimit))
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return &exposureResponse{
+		url:         target.String(),
+		status:      resp.StatusCode,
+		contentType: strings.ToLower(resp.Header.Get("Content-Type")),
+		header:      resp.Header,
+		body:        string(body),
+	}, true
+}
+
+// claim marks key as probed, returning false when it already was.
+func (p *ExposureProbe) claim(key string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.probed == nil {
+		p.probed = make(map[string]bool)
+	}
+	if p.probed[key] {
+		return false
+	}
+	p.probed[key] = true
+	return true
+}
+
+// wait reserves a request slot for host, sleeping until the pacing interval
+// has passed. It returns false when the host's cap is reached or ctx ends.
+func (p *ExposureProbe) wait(ctx context.Context, host string) bool {
+	p.mu.Lock()
+	if p.perHost == nil {
+		p.perHost = make(map[string]int)
+	}
+	if p.MaxPerHost > 0 && p.perHost[host] >= p.MaxPerHost {
+		p.mu.Unlock()
+		return false
+	}
+	p.perHost[host]++
+	now := time.Now()
+	slot := p.next
+	if slot.Before(now) {
+		slot = now
+	}
+	p.next = slot.Add(p.Interval)
+	p.mu.Unlock()
+
+	timer := time.NewTimer(time.Until(slot))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// isDirectoryListing reports whether body is an auto-generated index page.
+func isDirectoryListing(body string) bool {
+	lower := strings.ToLower(body)
+	for _, marker := range directoryListingMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// envVariableNames returns the variable names assigned in a dotenv file.
+func envVariableNames(body string) []string {
+	var names []string
+	for _, m := range envAssignment.FindAllStringSubmatch(body, 10) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// exposureVulnerabilities describes each exposure type once, in report order.
+var exposureVulnerabilities = []struct {
+	Type           string
+	Name           string
+	Description    string
+	Recommendation string
+	References     []string
+}{
+	{
+		Type:        ExposureGitConfig,
+		Name:        "Exposed Git Repository (.git/config)",
+		Description: "The .git directory is served over HTTP. Attackers can reconstruct the repository, including source code, history and committed credentials.",
+		Recommendation: `HIGH: Git repository metadata is publicly accessible.
+
+Immediate Actions:
+1. Block access to /.git/ in the web server (e.g. nginx: location ~ /\.git { deny all; })
+2. Remove the .git directory from the deployed document root
+3. Rotate any credentials that were ever committed to the repository
+
+Prevention:
+• Deploy build artifacts rather than a working copy
+• Add a deployment check that fails if .git is present`,
+		References: []string{
+			"https://owasp.org/www-project-web-security-testing-guide/latest/4-Web_Application_Security_Testing/02-Configuration_and_Deployment_Management_Testing/04-Review_Old_Backup_and_Unreferenced_Files_for_Sensitive_Information",
+			"https://cwe.mitre.org/data/definitions/527.html",
+		},
+	},
+	{
+		Type:        ExposureEnvFile,
+		Name:        "Exposed Environment File (.env)",
+		Description: "A .env file is served over HTTP. These files usually hold database passwords, API keys and application secrets.",
+		Recommendation: `HIGH: Environment file is publicly accessible.
+
+Immediate Actions:
+1. Block access to dotfiles in the web server
+2. Move the .env file outside the document root
+3. Rotate every secret defined in the file
+
+Prevention:
+• Serve only the public/ directory of the application
+• Prefer a secrets manager over files in the deployment`,
+		References: []string{
+			"https://cwe.mitre.org/data/definitions/538.html",
+			"https://owasp.org/Top10/A05_2021-Security_Misconfiguration/",
+		},
+	},
+	{
+		Type:        ExposureBackupFile,
+		Name:        "Exposed Backup File",
+		Description: "Backup copies of application files are downloadable. They are served as plain files rather than executed, revealing source code and embedded configuration.",
+		Recommendation: `HIGH: Backup files are publicly accessible.
+
+Immediate Actions:
+1. Delete backup copies (.bak, ~, .old, .orig) from the document root
+2. Deny these extensions in the web server configuration
+3. Review the exposed files for credentials and rotate them
+
+Prevention:
+• Disable editor backup files on servers
+• Deploy from version control or build artifacts only`,
+		References: []string{
+			"https://owasp.org/www-project-web-security-testing-guide/latest/4-Web_Application_Security_Testing/02-Configuration_and_Deployment_Management_Testing/04-Review_Old_Backup_and_Unreferenced_Files_for_Sensitive_Information",
+			"https://cwe.mitre.org/data/definitions/530.html",
+		},
+	},
+	{
+		Type:        ExposureDirectoryListing,
+		Name:        "Directory Listing Enabled",
+		Description: "The web server generates index pages for directories without a default document, revealing file names that may not be linked anywhere.",
+		Recommendation: `HIGH: Directory listing is enabled.
+
+Immediate Actions:
+1. Disable automatic indexes (Apache: Options -Indexes; nginx: autoindex off; IIS: directoryBrowse enabled="false")
+2. Review the listed directories for sensitive files
+
+Prevention:
+• Place an index document in every served directory
+• Keep uploads and internal files outside the document root`,
+		References: []string{
+			"https://cwe.mitre.org/data/definitions/548.html",
+			"https://owasp.org/www-project-web-security-testing-guide/latest/4-Web_Application_Security_Testing/02-Configuration_and_Deployment_Management_Testing/04-Review_Old_Backup_and_Unreferenced_Files_for_Sensitive_Information",
+		},
+	},
+}
+
+// analyzeExposures converts exposure findings into one high-severity
+// vulnerability per exposure type.
+func analyzeExposures(exposures []ExposureFinding) []Vulnerability {
+	var vulns []Vulnerability
+	for _, spec := range exposureVulnerabilities {
+		var evidence []string
+		for _, exp := range exposures {
+			if exp.Type == spec.Type {
+				evidence = append(evidence, fmt.Sprintf("• %s: %s", exp.URL, exp.Evidence))
+			}
+		}
+		if len(evidence) == 0 {
+			continue
+		}
+		vulns = append(vulns, Vulnerability{
+			Name:           spec.Name,
+			Category:       "Information Disclosure",
+			Severity:       "High",
+			Score:          0,
+			MaxScore:       10,
+			Status:         "Failed",
+			Description:    spec.Description,
+			Recommendation: spec.Recommendation + "\n\nEvidence:\n" + strings.Join(evidence, "\n"),
+			CVSS: &CVSSScore{
+				BaseScore: 7.5,
+				Severity:  "HIGH",
+				Vector:    "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:N/A:N",
+				Version:   "3.1",
+			},
+			References: spec.References,
+		})
+	}
+	return vulns
+}
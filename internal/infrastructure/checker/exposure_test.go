@@ -0,0 +1,102 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func exposureTypes(findings []ExposureFinding) []string {
+	var types []string
+	for _, f := range findings {
+		types = append(types, f.Type)
+	}
+	return types
+}
+
+func TestExposureProbe_DetectsExposures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/app/":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte("<html><title>Index of /app</title></html>"))
+		case "/app/.git/config":
+			_, _ = w.Write([]byte("[core]\n\trepositoryformatversion = 0\n[remote \"origin\"]\n\turl = git@example.com:x.git\n"))
+		case "/app/.env":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("APP_KEY=supersecret\nDB_PASSWORD=hunter2\n"))
+		case "/app/index.php.bak":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write([]byte("<?php $db = 'secret';"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	probe := &ExposureProbe{}
+	findings := probe.Probe(context.Background(), server.Client(), server.URL+"/app/index.php?x=1")
+
+	for _, want := range []string{ExposureDirectoryListing, ExposureGitConfig, ExposureEnvFile, ExposureBackupFile} {
+		if !slices.Contains(exposureTypes(findings), want) {
+			t.Errorf("expected %s finding, got %+v", want, findings)
+		}
+	}
+	for _, f := range findings {
+		if f.Type == ExposureEnvFile {
+			if strings.Contains(f.Evidence, "supersecret") || !strings.Contains(f.Evidence, "DB_PASSWORD") {
+				t.Errorf(".env evidence should list names without values, got %q", f.Evidence)
+			}
+		}
+	}
+
+	vulns := analyzeExposures(findings)
+	if len(vulns) != 4 || vulns[0].Severity != "High" {
+		t.Fatalf("expected four high-severity vulnerabilities, got %+v", vulns)
+	}
+}
+
+func TestExposureProbe_SoftNotFoundSuppressesGuesses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("[core]\nAPP_KEY=x\n"))
+	}))
+	defer server.Close()
+
+	findings := (&ExposureProbe{}).Probe(context.Background(), server.Client(), server.URL+"/page.txt")
+	if len(findings) != 0 {
+		t.Fatalf("catch-all server should not produce findings, got %+v", findings)
+	}
+}
+
+func TestExposureProbe_DedupAndPacing(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	probe := &ExposureProbe{Interval: 20 * time.Millisecond, MaxPerHost: 5}
+	start := time.Now()
+	probe.Probe(context.Background(), server.Client(), server.URL+"/a/")
+	probe.Probe(context.Background(), server.Client(), server.URL+"/a/")
+
+	// Baseline, listing, .git/config and .env; the repeated target adds none.
+	if got := requests.Load(); got != 4 {
+		t.Fatalf("expected 4 requests for one directory probed twice, got %d", got)
+	}
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Fatalf("requests were not paced, took %v", elapsed)
+	}
+
+	probe.Probe(context.Background(), server.Client(), server.URL+"/b/c.txt")
+	if got := requests.Load(); got != 5 {
+		t.Fatalf("per-host cap should stop at 5 requests, got %d", got)
+	}
+}
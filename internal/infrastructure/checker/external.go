@@ -15,14 +15,17 @@ type ExternalCheckerConfig struct {
 	Args           []string
 	Env            map[string]string
 	TimeoutSeconds int
+	// ValidateOutput, when set, is applied to the raw plugin output before it is decoded.
+	ValidateOutput func([]byte) error
 }
 
 type ExternalChecker struct {
-	name    string
-	command string
-	args    []string
-	env     map[string]string
-	timeout time.Duration
+	name     string
+	command  string
+	args     []string
+	env      map[string]string
+	timeout  time.Duration
+	validate func([]byte) error
 }
 
 func NewExternalChecker(cfg ExternalCheckerConfig) *ExternalChecker {
@@ -31,11 +34,12 @@ func NewExternalChecker(cfg ExternalCheckerConfig) *ExternalChecker {
 		timeout = 10 * time.Second
 	}
 	return &ExternalChecker{
-		name:    cfg.Name,
-		command: cfg.Command,
-		args:    cfg.Args,
-		env:     cfg.Env,
-		timeout: timeout,
+		name:     cfg.Name,
+		command:  cfg.Command,
+		args:     cfg.Args,
+		env:      cfg.Env,
+		timeout:  timeout,
+		validate: cfg.ValidateOutput,
 	}
 }
 
@@ -80,6 +84,13 @@ func (e *ExternalChecker) Check(ctx context.Context, target string) CheckResult
 		return result
 	}
 
+	if e.validate != nil {
+		if err := e.validate(output); err != nil {
+			result.Error = fmt.Sprintf("invalid plugin output: %v", err)
+			return result
+		}
+	}
+
 	var pluginResult CheckResult
 	if err := json.Unmarshal(output, &pluginResult); err != nil {
 		result.Error = fmt.Sprintf("invalid plugin output: %v", err)
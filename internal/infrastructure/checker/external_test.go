@@ -0,0 +1,50 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func writeExternalScript(t *testing.T, output string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugin not supported on windows")
+	}
+	path := filepath.Join(t.TempDir(), "plugin.sh")
+	body := "#!/bin/sh\ncat <<'JSON'\n" + output + "\nJSON\n"
+	if err := os.WriteFile(path, []byte(body), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestExternalCheckerFillsDefaults(t *testing.T) {
+	script := writeExternalScript(t, `{"status":"ok","notes":"fine"}`)
+	c := NewExternalChecker(ExternalCheckerConfig{Name: "demo", Command: script})
+
+	result := c.Check(context.Background(), "example.com")
+	if result.Status != "ok" || result.Target != "example.com" || result.CheckedAt.IsZero() {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestExternalCheckerValidateOutput(t *testing.T) {
+	script := writeExternalScript(t, `{"status":"ok"}`)
+	c := NewExternalChecker(ExternalCheckerConfig{
+		Name:    "demo",
+		Command: script,
+		ValidateOutput: func([]byte) error {
+			return errors.New("$.status: bad")
+		},
+	})
+
+	result := c.Check(context.Background(), "example.com")
+	if result.Status != "error" || !strings.Contains(result.Error, "$.status: bad") {
+		t.Fatalf("expected validation error to surface, got %+v", result)
+	}
+}
@@ -0,0 +1,243 @@
+package checker
+
+import (
+	"context"
+	"crypto/md5" // #nosec G501 -- MD5 is the favicon hash Censys indexes, not a security control.
+	_ "embed"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/bits"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//go:embed data/favicons.json
+var faviconSnapshotJSON []byte
+
+// maxFaviconBytes caps the favicon download.
+const maxFaviconBytes = 256 * 1024
+
+// FaviconResult identifies a target's favicon. MMH3 is the hash Shodan
+// indexes (http.favicon.hash) and MD5 the one Censys indexes, so defenders
+// can pivot to other hosts serving the same icon.
+type FaviconResult struct {
+	URL      string   `json:"url"`
+	MMH3     int32    `json:"mmh3"`
+	MD5      string   `json:"md5"`
+	Size     int      `json:"size"`
+	Products []string `json:"products,omitempty"`
+}
+
+// FaviconDB maps favicon mmh3 hashes to the products that ship the icon.
+type FaviconDB map[int32][]string
+
+type faviconSnapshot struct {
+	Snapshot     string `json:"snapshot"`
+	Fingerprints []struct {
+		MMH3    int32  `json:"mmh3"`
+		Product string `json:"product"`
+	} `json:"fingerprints"`
+}
+
+var (
+	faviconOnce sync.Once
+	faviconDB   FaviconDB
+)
+
+// BundledFavicons returns the fingerprint set shipped with seca. It must not
+// be modified; use With to add fingerprints.
+func BundledFavicons() FaviconDB {
+	faviconOnce.Do(func() {
+		db, err := ParseFaviconDB(faviconSnapshotJSON)
+		if err != nil {
+			panic(fmt.Sprintf("invalid embedded favicon fingerprints: %v", err))
+		}
+		faviconDB = db
+	})
+	return faviconDB
+}
+
+// ParseFaviconDB decodes a fingerprint file in the format of the bundled
+// set: {"fingerprints": [{"mmh3": 116323821, "product": "Spring Boot"}]}.
+func ParseFaviconDB(data []byte) (FaviconDB, error) {
+	var snapshot faviconSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+	db := make(FaviconDB, len(snapshot.Fingerprints))
+	for i, fp := range snapshot.Fingerprints {
+		product := strings.TrimSpace(fp.Product)
+		if product == "" {
+			return nil, fmt.Errorf("fingerprint %d: product is required", i+1)
+		}
+		db.add(fp.MMH3, product)
+	}
+	return db, nil
+}
+
+func (db FaviconDB) add(hash int32, product string) {
+	for _, existing := range db[hash] {
+		if existing == product {
+			return
+		}
+	}
+	db[hash] = append(db[hash], product)
+}
+
+// With returns a new set holding the fingerprints of db and other.
+func (db FaviconDB) With(other FaviconDB) FaviconDB {
+	merged := make(FaviconDB, len(db)+len(other))
+	for _, src := range []FaviconDB{db, other} {
+		for hash, products := range src {
+			for _, product := range products {
+				merged.add(hash, product)
+			}
+		}
+	}
+	return merged
+}
+
+// Match returns the products whose favicon has the hash, in name order.
+func (db FaviconDB) Match(hash int32) []string {
+	products := append([]string(nil), db[hash]...)
+	sort.Strings(products)
+	return products
+}
+
+// FaviconHash returns the Shodan favicon hash of an icon: the signed
+// MurmurHash3 (x86, 32-bit) of its base64 encoding wrapped at 76 columns
+// with a trailing newline, as Python's base64.encodebytes produces.
+func FaviconHash(data []byte) int32 {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	var b strings.Builder
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76])
+		b.WriteByte('\n')
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded)
+	b.WriteByte('\n')
+	return int32(murmur3([]byte(b.String()), 0)) // #nosec G115 -- Shodan reports the hash as a signed 32-bit integer.
+}
+
+// murmur3 is MurmurHash3 x86 32-bit.
+func murmur3(data []byte, seed uint32) uint32 {
+	const (
+		c1 = 0xcc9e2d51
+		c2 = 0x1b873593
+	)
+	mix := func(k uint32) uint32 {
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		return k * c2
+	}
+
+	h := seed
+	n := len(data) / 4
+	for i := 0; i < n; i++ {
+		h ^= mix(binary.LittleEndian.Uint32(data[i*4:]))
+		h = bits.RotateLeft32(h, 13)
+		h = h*5 + 0xe6546b64
+	}
+
+	var k uint32
+	tail := data[n*4:]
+	switch len(tail) {
+	case 3:
+		k ^= uint32(tail[2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(tail[1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(tail[0])
+		h ^= mix(k)
+	}
+
+	h ^= uint32(len(data)) // #nosec G115 -- the length is folded in modulo 2^32 by design.
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
+
+var (
+	linkTagPattern  = regexp.MustCompile(`(?is)<link\b[^>]*>`)
+	linkRelPattern  = regexp.MustCompile(`(?i)\brel\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+	linkHrefPattern = regexp.MustCompile(`(?i)\bhref\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+)
+
+// faviconURL returns the icon a page declares with <link rel="icon">, or
+// /favicon.ico on the page's host.
+func faviconURL(page *url.URL, body string) *url.URL {
+	for _, tag := range linkTagPattern.FindAllString(body, -1) {
+		rel := firstGroup(linkRelPattern.FindStringSubmatch(tag))
+		if !strings.Contains(" "+strings.ToLower(rel)+" ", " icon ") {
+			continue
+		}
+		href := strings.TrimSpace(firstGroup(linkHrefPattern.FindStringSubmatch(tag)))
+		if href == "" || strings.HasPrefix(strings.ToLower(href), "data:") {
+			continue
+		}
+		ref, err := url.Parse(href)
+		if err != nil {
+			continue
+		}
+		if u := page.ResolveReference(ref); u.Scheme == "http" || u.Scheme == "https" {
+			return u
+		}
+	}
+	return &url.URL{Scheme: page.Scheme, Host: page.Host, Path: "/favicon.ico"}
+}
+
+func firstGroup(match []string) string {
+	for _, group := range match[min(1, len(match)):] {
+		if group != "" {
+			return group
+		}
+	}
+	return ""
+}
+
+// FetchFavicon downloads the page's favicon, hashes it, and matches the
+// hash against db. It returns nil when the page has no usable icon.
+func FetchFavicon(ctx context.Context, client *http.Client, page *url.URL, body string, db FaviconDB) *FaviconResult {
+	iconURL := faviconURL(page, body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, iconURL.String(), nil)
+	if err != nil {
+		return nil
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	// Soft 404 pages are HTML; an icon never is.
+	if resp.StatusCode != http.StatusOK || strings.Contains(strings.ToLower(resp.Header.Get("Content-Type")), "text/html") {
+		return nil
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFaviconBytes))
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+
+	sum := md5.Sum(data) // #nosec G401 -- see import.
+	hash := FaviconHash(data)
+	return &FaviconResult{
+		URL:      iconURL.String(),
+		MMH3:     hash,
+		MD5:      hex.EncodeToString(sum[:]),
+		Size:     len(data),
+		Products: db.Match(hash),
+	}
+}
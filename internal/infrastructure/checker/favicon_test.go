@@ -0,0 +1,83 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestMurmur3(t *testing.T) {
+	cases := map[string]uint32{
+		"":      0,
+		"hello": 0x248bfa47,
+		"The quick brown fox jumps over the lazy dog": 0x2e4ff723,
+	}
+	for input, want := range cases {
+		if got := murmur3([]byte(input), 0); got != want {
+			t.Errorf("murmur3(%q) = %#x, want %#x", input, got, want)
+		}
+	}
+}
+
+func TestFaviconHashWrapsBase64(t *testing.T) {
+	// 60 bytes encode to 80 base64 characters, which Shodan hashes as a
+	// 76-character line and a 4-character line, each newline-terminated.
+	icon := make([]byte, 60)
+	want := int32(murmur3([]byte("AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA\nAAAA\n"), 0))
+	if got := FaviconHash(icon); got != want {
+		t.Fatalf("FaviconHash = %d, want %d", got, want)
+	}
+}
+
+func TestFetchFavicon(t *testing.T) {
+	icon := []byte("\x00\x00\x01\x00icon-bytes")
+	mux := http.NewServeMux()
+	mux.HandleFunc("/static/app.ico", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/x-icon")
+		_, _ = w.Write(icon)
+	})
+	mux.HandleFunc("/favicon.ico", func(w http.ResponseWriter, r *http.Request) {
+		// Soft 404: an HTML page with status 200.
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html>not found</html>"))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	page, _ := url.Parse(srv.URL + "/home")
+
+	db := FaviconDB{}.With(FaviconDB{FaviconHash(icon): {"Example Portal"}})
+	body := `<head><link href="/static/app.ico" rel="shortcut icon"></head>`
+	got := FetchFavicon(context.Background(), srv.Client(), page, body, db)
+	if got == nil {
+		t.Fatal("expected the declared favicon to be fetched")
+	}
+	if got.URL != srv.URL+"/static/app.ico" || got.Size != len(icon) || len(got.MD5) != 32 {
+		t.Errorf("unexpected favicon %+v", got)
+	}
+	if len(got.Products) != 1 || got.Products[0] != "Example Portal" {
+		t.Errorf("expected a fingerprint match, got %v", got.Products)
+	}
+
+	if got := FetchFavicon(context.Background(), srv.Client(), page, "<html></html>", db); got != nil {
+		t.Errorf("expected soft-404 /favicon.ico to be ignored, got %+v", got)
+	}
+}
+
+func TestParseFaviconDB(t *testing.T) {
+	db, err := ParseFaviconDB([]byte(`{"fingerprints": [{"mmh3": 116323821, "product": "Custom"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	merged := BundledFavicons().With(db)
+	if products := merged.Match(116323821); len(products) != 2 || products[0] != "Custom" || products[1] != "Spring Boot" {
+		t.Errorf("unexpected products %v", products)
+	}
+	if len(BundledFavicons().Match(116323821)) != 1 {
+		t.Error("With must not modify the bundled set")
+	}
+	if _, err := ParseFaviconDB([]byte(`{"fingerprints": [{"mmh3": 1}]}`)); err == nil {
+		t.Error("expected an error for a fingerprint without a product")
+	}
+}
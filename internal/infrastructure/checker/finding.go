@@ -0,0 +1,291 @@
+package checker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/compliance"
+)
+
+// Finding is one issue found on one target, in the same shape whichever
+// analyzer raised it. The specialized result structs (SecurityHeaders,
+// TLSCompliance, CookieFindings, ...) keep the raw observations; Finding is
+// what reports, exporters, issue sync and the TUI consume, so they do not
+// need to know every result type.
+type Finding struct {
+	// ID is stable for the same check on the same target across runs, so
+	// it can key baselines and suppressions.
+	ID       string `json:"id"`
+	Check    string `json:"check"`
+	Category string `json:"category"`
+	Severity string `json:"severity"` // Critical, High, Medium, Low, Info
+	Status   string `json:"status"`   // Failed or Warning
+	Target   string `json:"target"`
+	// Description explains the issue; Evidence holds the observations that
+	// triggered it (header values, cookie names, open ports, ...).
+	Description string     `json:"description"`
+	Evidence    []string   `json:"evidence,omitempty"`
+	Remediation string     `json:"remediation,omitempty"`
+	References  []string   `json:"references,omitempty"`
+	CVSS        *CVSSScore `json:"cvss,omitempty"`
+	// Compliance maps framework IDs to the requirements the check covers.
+	Compliance map[string][]string `json:"compliance,omitempty"`
+	// Attachments are the stored artifacts that back the finding.
+	Attachments []EvidenceRef `json:"attachments,omitempty"`
+}
+
+// Evidence artifact kinds.
+const (
+	EvidenceRawCapture = "raw"
+	EvidenceScreenshot = "screenshot"
+	EvidenceHAR        = "har"
+	EvidenceDNS        = "dns"
+	EvidenceFile       = "file"
+)
+
+// EvidenceRef points to an evidence artifact stored in the engagement's
+// results directory. File is named by the artifact's SHA-256, so a reference
+// both locates the artifact and pins its content.
+type EvidenceRef struct {
+	Kind       string    `json:"kind"`
+	Target     string    `json:"target,omitempty"`
+	File       string    `json:"file"` // Relative to the engagement results directory
+	SHA256     string    `json:"sha256"`
+	MediaType  string    `json:"media_type,omitempty"`
+	Size       int64     `json:"size,omitempty"`
+	CapturedAt time.Time `json:"captured_at"`
+}
+
+// FindingID derives the stable ID of a check on a target.
+func FindingID(check, target string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(check)) + "\x00" + strings.TrimSpace(target)))
+	return "F-" + hex.EncodeToString(sum[:6])
+}
+
+// AnalyzeFindings runs every analyzer over a result and returns its failed
+// and warning findings, most severe first.
+func AnalyzeFindings(result CheckResult) []Finding {
+	var findings []Finding
+	for _, v := range resultVulnerabilities(result) {
+		if !vulnerabilityOpen(v) {
+			continue
+		}
+		findings = append(findings, newFinding(result, scoreCVSS(v)))
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		oi, oj := severityRank(findings[i].Severity), severityRank(findings[j].Severity)
+		if oi != oj {
+			return oi < oj
+		}
+		return findings[i].Check < findings[j].Check
+	})
+	return findings
+}
+
+// ResultFindings returns the findings recorded on a result by the Runner,
+// or analyzes the result when it was stored without them (older results
+// files, plugins run outside the Runner).
+func ResultFindings(result CheckResult) []Finding {
+	if result.Findings != nil {
+		return result.Findings
+	}
+	return AnalyzeFindings(result)
+}
+
+// resultVulnerabilities runs every analyzer that applies to the result. It
+// is the single place that knows which result structs produce findings;
+// each vulnerability's AffectedURLs is the result's target.
+func resultVulnerabilities(result CheckResult) []Vulnerability {
+	var vulns []Vulnerability
+	if result.SecurityHeaders != nil {
+		vulns = append(vulns, analyzeSecurityHeaders(result.SecurityHeaders, result.Target)...)
+		vulns = append(vulns, analyzeHeaderPolicyViolations(result.SecurityHeaders.PolicyViolations)...)
+	}
+	if result.TLSCompliance != nil {
+		vulns = append(vulns, analyzeTLSCompliance(result.TLSCompliance, result.Target)...)
+	}
+	if len(result.CookieFindings) > 0 {
+		vulns = append(vulns, analyzeCookieFindings(result.CookieFindings, result.Target)...)
+	}
+	if result.CORSInsights != nil && len(result.CORSInsights.Issues) > 0 {
+		vulns = append(vulns, analyzeCORSIssues(result.CORSInsights, result.Target)...)
+	}
+	if result.CachePolicy != nil && (len(result.CachePolicy.Issues) > 0 || len(result.CachePolicy.Findings) > 0) {
+		vulns = append(vulns, analyzeCachePolicy(result.CachePolicy, result.Target)...)
+	}
+	if result.ClientSecurity != nil {
+		vulns = append(vulns, analyzeClientSecurity(result.ClientSecurity, result.Target)...)
+	}
+	if result.DomainHygiene != nil {
+		vulns = append(vulns, analyzeDomainHygiene(result.DomainHygiene)...)
+	}
+	if result.NetworkSecurity != nil {
+		vulns = append(vulns, analyzeNetworkSecurity(result.NetworkSecurity, result.Target)...)
+	}
+	if !result.RobotsSitemap.Empty() {
+		vulns = append(vulns, analyzeRobotsSitemap(result.RobotsSitemap)...)
+	}
+	if result.Smuggling != nil {
+		vulns = append(vulns, analyzeSmugglingPosture(result.Smuggling)...)
+	}
+	for i := range vulns {
+		vulns[i].AffectedURLs = []string{result.Target}
+	}
+	return vulns
+}
+
+func vulnerabilityOpen(v Vulnerability) bool {
+	return strings.EqualFold(v.Status, "Failed") || strings.EqualFold(v.Status, "Warning")
+}
+
+func severityRank(severity string) int {
+	switch severity {
+	case "Critical":
+		return 0
+	case "High":
+		return 1
+	case "Medium":
+		return 2
+	case "Low":
+		return 3
+	default:
+		return 4
+	}
+}
+
+func newFinding(result CheckResult, v Vulnerability) Finding {
+	f := Finding{
+		ID:          FindingID(v.Name, result.Target),
+		Check:       v.Name,
+		Category:    v.Category,
+		Severity:    v.Severity,
+		Status:      v.Status,
+		Target:      result.Target,
+		Description: v.Description,
+		Evidence:    findingEvidence(result, v.Name),
+		Remediation: v.Recommendation,
+		References:  v.References,
+		CVSS:        v.CVSS,
+	}
+	if len(v.ComplianceMapping) > 0 {
+		f.Compliance = make(map[string][]string, len(v.ComplianceMapping))
+		for framework, details := range v.ComplianceMapping {
+			f.Compliance[framework] = details.Requirements
+		}
+	} else {
+		f.Compliance = complianceRequirements(v.Name)
+	}
+	return f
+}
+
+// findingHeaders names the response header behind findings whose name is
+// not the header itself.
+var findingHeaders = map[string]string{
+	"Content Security Policy (CSP)":                     "Content-Security-Policy",
+	"Content Security Policy (CSP) Configuration Issue": "Content-Security-Policy",
+	"Content Security Policy (CSP) Bypass":              "Content-Security-Policy",
+	"HTTP Strict Transport Security (HSTS)":             "Strict-Transport-Security",
+	"HSTS Configuration Issue":                          "Strict-Transport-Security",
+	"Cross-Origin-Embedder-Policy (COEP)":               "Cross-Origin-Embedder-Policy",
+	"Cross-Origin-Opener-Policy (COOP)":                 "Cross-Origin-Opener-Policy",
+	"Content-Type Header":                               "Content-Type",
+	"Deprecated X-XSS-Protection Header":                "X-XSS-Protection",
+}
+
+// findingEvidence collects the raw observations behind a finding from the
+// result it was raised on.
+func findingEvidence(result CheckResult, check string) []string {
+	var evidence []string
+	if sh := result.SecurityHeaders; sh != nil {
+		header, ok := findingHeaders[check]
+		if !ok {
+			header = check
+		}
+		if status, ok := sh.Headers[header]; ok {
+			if status.Present {
+				evidence = append(evidence, fmt.Sprintf("%s: %s", header, status.Value))
+			} else {
+				evidence = append(evidence, header+" header not set")
+			}
+		}
+	}
+	switch {
+	case check == "Server Information Disclosure" && result.ServerHeader != "":
+		evidence = append(evidence, "Server: "+result.ServerHeader)
+	case check == "Insecure Cookie Configuration":
+		for _, c := range result.CookieFindings {
+			var missing []string
+			if c.MissingSecure {
+				missing = append(missing, "Secure")
+			}
+			if c.MissingHTTPOnly {
+				missing = append(missing, "HttpOnly")
+			}
+			if len(missing) > 0 {
+				evidence = append(evidence, fmt.Sprintf("cookie %s missing %s", c.Name, strings.Join(missing, ", ")))
+			}
+		}
+	case strings.HasSuffix(check, "Ports Exposed") && result.NetworkSecurity != nil:
+		risk := "critical"
+		if strings.HasPrefix(check, "High-Risk") {
+			risk = "high"
+		}
+		for _, p := range result.NetworkSecurity.OpenPorts {
+			if p.Risk == risk {
+				evidence = append(evidence, fmt.Sprintf("%d/%s open (%s)", p.Port, p.Protocol, p.Service))
+			}
+		}
+	case strings.HasPrefix(check, "Vulnerable ") && result.ClientSecurity != nil:
+		for _, lib := range result.ClientSecurity.VulnerableLibraries {
+			if check == "Vulnerable JS Libraries" || check == fmt.Sprintf("Vulnerable %s Library", lib.Name) {
+				evidence = append(evidence, strings.TrimSpace(fmt.Sprintf("%s %s %s", lib.Name, lib.DetectedVersion, strings.Join(lib.VulnerabilityIDs, " "))))
+			}
+		}
+	case check == "Sensitive Paths Disclosed in robots.txt" && result.RobotsSitemap != nil:
+		for _, p := range result.RobotsSitemap.SensitivePaths {
+			evidence = append(evidence, fmt.Sprintf("robots.txt: %s (%s)", p.Path, p.Category))
+		}
+	case check == "Sitemap Lists Non-Production Hosts" && result.RobotsSitemap != nil:
+		for _, u := range result.RobotsSitemap.NonProductionURLs {
+			evidence = append(evidence, fmt.Sprintf("sitemap: %s (%s)", u.URL, u.Marker))
+		}
+	case check == "Lookalike Domain Links" && result.ClientSecurity != nil:
+		for _, link := range result.ClientSecurity.LookalikeLinks {
+			evidence = append(evidence, fmt.Sprintf("%s (%s) looks like %s: %s", link.Host, link.Punycode, link.LooksLike, link.URL))
+		}
+	case strings.HasPrefix(check, "TLS ") && result.TLSCompliance != nil && result.TLSCompliance.TLSVersion != "":
+		evidence = append(evidence, fmt.Sprintf("negotiated %s with %s", result.TLSCompliance.TLSVersion, result.TLSCompliance.CipherSuite))
+	}
+	return evidence
+}
+
+var (
+	complianceOnce  sync.Once
+	complianceIndex map[string]map[string][]string
+)
+
+// complianceRequirements looks up the compliance mapping for a check.
+// Mapping names and finding names differ in case ("HTTPS enabled" vs
+// "HTTPS Enabled"), so the lookup ignores it.
+func complianceRequirements(check string) map[string][]string {
+	complianceOnce.Do(func() {
+		complianceIndex = make(map[string]map[string][]string)
+		for name, mapping := range compliance.GetComplianceMappings() {
+			complianceIndex[strings.ToLower(name)] = mapping.Frameworks
+		}
+	})
+	frameworks := complianceIndex[strings.ToLower(check)]
+	if len(frameworks) == 0 {
+		return nil
+	}
+	out := make(map[string][]string, len(frameworks))
+	for framework, requirements := range frameworks {
+		out[framework] = append([]string(nil), requirements...)
+	}
+	return out
+}
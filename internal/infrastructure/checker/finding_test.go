@@ -0,0 +1,106 @@
+package checker
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func findingTestResult(target string) CheckResult {
+	return CheckResult{
+		Target: target,
+		Status: "ok",
+		SecurityHeaders: &SecurityHeadersResult{
+			Headers: map[string]HeaderStatus{
+				"Content-Security-Policy": {Present: false, Severity: "high", Recommendation: "Add a CSP"},
+				"X-Frame-Options":         {Present: true, Value: "DENY", Severity: "high", Score: 10, MaxScore: 10},
+			},
+			Missing: []string{"Content-Security-Policy"},
+		},
+		CookieFindings: []CookieFinding{{Name: "session", MissingSecure: true}},
+	}
+}
+
+func TestAnalyzeFindings(t *testing.T) {
+	findings := AnalyzeFindings(findingTestResult("https://app.example.com"))
+	byCheck := make(map[string]Finding)
+	for _, f := range findings {
+		if f.Status != "Failed" && f.Status != "Warning" {
+			t.Errorf("%s: unexpected status %q", f.Check, f.Status)
+		}
+		byCheck[f.Check] = f
+	}
+	if _, ok := byCheck["X-Frame-Options"]; ok {
+		t.Error("passed checks must not become findings")
+	}
+
+	csp, ok := byCheck["Content Security Policy (CSP)"]
+	if !ok {
+		t.Fatalf("expected a CSP finding, got %+v", findings)
+	}
+	if csp.Target != "https://app.example.com" || csp.ID != FindingID(csp.Check, csp.Target) {
+		t.Errorf("unexpected identity: %+v", csp)
+	}
+	if len(csp.Evidence) != 1 || csp.Evidence[0] != "Content-Security-Policy header not set" {
+		t.Errorf("evidence = %v", csp.Evidence)
+	}
+	if csp.Remediation == "" || csp.CVSS == nil {
+		t.Errorf("expected remediation and CVSS: %+v", csp)
+	}
+	if len(csp.Compliance["iso27001"]) == 0 {
+		t.Errorf("expected compliance requirements, got %v", csp.Compliance)
+	}
+
+	cookie, ok := byCheck["Insecure Cookie Configuration"]
+	if !ok || len(cookie.Evidence) != 1 || cookie.Evidence[0] != "cookie session missing Secure" {
+		t.Errorf("cookie finding = %+v", cookie)
+	}
+}
+
+func TestFindingIDIsStable(t *testing.T) {
+	a := FindingID("X-Frame-Options", "https://a.example.com")
+	if a != FindingID(" x-frame-options ", "https://a.example.com") {
+		t.Error("ID must ignore check name case and spacing")
+	}
+	if a == FindingID("X-Frame-Options", "https://b.example.com") {
+		t.Error("IDs must differ per target")
+	}
+}
+
+func TestResultFindingsPrefersRecorded(t *testing.T) {
+	result := findingTestResult("https://app.example.com")
+	result.Findings = []Finding{{ID: "F-1", Check: "Recorded"}}
+	if got := ResultFindings(result); len(got) != 1 || got[0].ID != "F-1" {
+		t.Fatalf("ResultFindings() = %+v", got)
+	}
+	result.Findings = nil
+	if got := ResultFindings(result); len(got) == 0 {
+		t.Fatal("expected findings to be analyzed when none were recorded")
+	}
+}
+
+type findingChecker struct{}
+
+func (findingChecker) Name() string { return "finding" }
+
+func (findingChecker) Check(ctx context.Context, target string) CheckResult {
+	return findingTestResult(target)
+}
+
+func TestRunnerRecordsFindings(t *testing.T) {
+	runner := &Runner{Concurrency: 1, RateLimit: 10, Timeout: time.Second}
+	results := runner.RunChecks(context.Background(), []string{"https://app.example.com"}, findingChecker{}, nil)
+	if len(results) != 1 || len(results[0].Findings) == 0 {
+		t.Fatalf("expected recorded findings, got %+v", results)
+	}
+	report := BuildVulnerabilityReport(results, "", "", "")
+	open := 0
+	for _, v := range report.Vulnerabilities {
+		if vulnerabilityOpen(v) {
+			open++
+		}
+	}
+	if open != len(results[0].Findings) {
+		t.Errorf("report has %d open findings, result recorded %d", open, len(results[0].Findings))
+	}
+}
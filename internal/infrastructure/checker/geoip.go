@@ -0,0 +1,219 @@
+package checker
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// IPHosting describes where a resolved address is hosted.
+type IPHosting struct {
+	IP      string `json:"ip"`
+	ASN     int    `json:"asn,omitempty"`
+	ASOrg   string `json:"as_org,omitempty"`
+	Country string `json:"country,omitempty"` // ISO 3166-1 alpha-2
+}
+
+// GeoIPDatabase maps IP ranges to their announcing AS and country. It is
+// loaded from an offline IP-to-ASN dataset, so lookups never leave the host.
+type GeoIPDatabase struct {
+	// Source is the dataset file name, recorded with the results.
+	Source string
+	ranges []geoIPRange
+}
+
+type geoIPRange struct {
+	start, end netip.Addr
+	asn        int
+	org        string
+	country    string
+}
+
+// LoadGeoIPDatabase reads an IP-to-ASN dataset in the tab-separated layout
+// published by iptoasn.com (ip2asn-v4.tsv, ip2asn-v6.tsv or
+// ip2asn-combined.tsv):
+//
+//	range_start  range_end  AS_number  country_code  AS_description
+//
+// Files ending in .gz are decompressed. Ranges with AS number 0 are
+// unrouted and skipped.
+func LoadGeoIPDatabase(path string) (*GeoIPDatabase, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("open %s: %w", path, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	db, err := parseGeoIPDataset(r)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	db.Source = filepath.Base(path)
+	return db, nil
+}
+
+func parseGeoIPDataset(r io.Reader) (*GeoIPDatabase, error) {
+	db := &GeoIPDatabase{}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.SplitN(text, "\t", 5)
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("line %d: expected at least 4 tab-separated fields", line)
+		}
+		start, err := netip.ParseAddr(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		end, err := netip.ParseAddr(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if start.Is4() != end.Is4() || end.Less(start) {
+			return nil, fmt.Errorf("line %d: invalid range %s-%s", line, start, end)
+		}
+		asn, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(fields[2]), "AS"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid AS number %q", line, fields[2])
+		}
+		if asn == 0 {
+			continue
+		}
+		rng := geoIPRange{start: start, end: end, asn: asn, country: strings.ToUpper(fields[3])}
+		if rng.country == "NONE" {
+			rng.country = ""
+		}
+		if len(fields) == 5 {
+			rng.org = fields[4]
+		}
+		db.ranges = append(db.ranges, rng)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(db.ranges) == 0 {
+		return nil, fmt.Errorf("no routed ranges found")
+	}
+	sort.Slice(db.ranges, func(i, j int) bool { return db.ranges[i].start.Less(db.ranges[j].start) })
+	return db, nil
+}
+
+// Lookup returns the hosting details for ip, or false when the address is
+// not covered by the dataset.
+func (db *GeoIPDatabase) Lookup(ip string) (IPHosting, bool) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return IPHosting{}, false
+	}
+	addr = addr.Unmap()
+	// First range starting after addr; the candidate is the one before it.
+	i := sort.Search(len(db.ranges), func(i int) bool { return addr.Less(db.ranges[i].start) })
+	if i == 0 {
+		return IPHosting{}, false
+	}
+	rng := db.ranges[i-1]
+	if rng.end.Less(addr) || rng.start.Is4() != addr.Is4() {
+		return IPHosting{}, false
+	}
+	return IPHosting{IP: addr.String(), ASN: rng.asn, ASOrg: rng.org, Country: rng.country}, true
+}
+
+// Enrich looks up every address in ips. Addresses missing from the dataset
+// are returned with only the IP set.
+func (db *GeoIPDatabase) Enrich(ips []string) []IPHosting {
+	hosting := make([]IPHosting, 0, len(ips))
+	for _, ip := range ips {
+		h, ok := db.Lookup(ip)
+		if !ok {
+			h = IPHosting{IP: ip}
+		}
+		hosting = append(hosting, h)
+	}
+	return hosting
+}
+
+// OutsideJurisdiction reports whether h is hosted in a country not listed in
+// allowed. Addresses with an unknown country are not flagged, and an empty
+// allow list disables the check.
+func OutsideJurisdiction(h IPHosting, allowed []string) bool {
+	if len(allowed) == 0 || h.Country == "" {
+		return false
+	}
+	for _, c := range allowed {
+		if strings.EqualFold(c, h.Country) {
+			return false
+		}
+	}
+	return true
+}
+
+// JurisdictionVulnerabilities reports targets whose addresses are hosted
+// outside the approved countries. hosting maps each target to its addresses.
+func JurisdictionVulnerabilities(hosting map[string][]IPHosting, allowed []string) []Vulnerability {
+	var (
+		evidence []string
+		affected []string
+	)
+	targets := make([]string, 0, len(hosting))
+	for target := range hosting {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	for _, target := range targets {
+		flagged := false
+		for _, h := range hosting[target] {
+			if !OutsideJurisdiction(h, allowed) {
+				continue
+			}
+			flagged = true
+			evidence = append(evidence, fmt.Sprintf("• %s resolves to %s (AS%d %s, %s)", target, h.IP, h.ASN, h.ASOrg, h.Country))
+		}
+		if flagged {
+			affected = append(affected, target)
+		}
+	}
+	if len(evidence) == 0 {
+		return nil
+	}
+	return []Vulnerability{{
+		Name:     "Hosting Outside Approved Jurisdiction",
+		Category: "Data Residency",
+		Severity: "Medium",
+		Score:    0,
+		MaxScore: 10,
+		Status:   "Failed",
+		Description: "One or more targets resolve to addresses registered in countries outside the engagement's approved jurisdictions (" +
+			strings.Join(allowed, ", ") + "). Personal data processed by these hosts may be transferred across borders without the safeguards data protection laws such as PDPA and GDPR require.",
+		Recommendation: "Confirm whether the hosting location is intended. Move the service to an approved region, or document the transfer and its safeguards (contractual clauses, adequacy, consent) before personal data is processed there. GeoIP data is approximate for anycast and CDN addresses; verify with the provider.\n\nEvidence:\n" +
+			strings.Join(evidence, "\n"),
+		AffectedURLs: affected,
+		CVSS: &CVSSScore{
+			BaseScore: 5.3,
+			Severity:  "MEDIUM",
+			Vector:    "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:L/I:N/A:N",
+			Version:   "3.1",
+		},
+	}}
+}
@@ -0,0 +1,90 @@
+package checker
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testGeoIPDataset = `1.0.0.0	1.0.0.255	13335	US	CLOUDFLARENET
+1.0.1.0	1.0.3.255	0	None	Not routed
+# comment
+103.1.0.0	103.1.3.255	AS4773	SG	MOBILEONELTD-AS-AP
+2001:db8::	2001:db8:ffff:ffff:ffff:ffff:ffff:ffff	64500	DE	EXAMPLE-V6
+`
+
+func TestLoadGeoIPDatabase(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ip2asn-combined.tsv.gz")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	if _, err := gz.Write([]byte(testGeoIPDataset)); err != nil {
+		t.Fatal(err)
+	}
+	gz.Close()
+	f.Close()
+
+	db, err := LoadGeoIPDatabase(path)
+	if err != nil {
+		t.Fatalf("LoadGeoIPDatabase: %v", err)
+	}
+	if db.Source != "ip2asn-combined.tsv.gz" {
+		t.Errorf("source = %q", db.Source)
+	}
+
+	tests := []struct {
+		ip      string
+		found   bool
+		asn     int
+		country string
+	}{
+		{"1.0.0.1", true, 13335, "US"},
+		{"1.0.2.1", false, 0, ""}, // unrouted
+		{"103.1.2.3", true, 4773, "SG"},
+		{"::ffff:103.1.0.1", true, 4773, "SG"},
+		{"2001:db8::1", true, 64500, "DE"},
+		{"9.9.9.9", false, 0, ""},
+		{"not-an-ip", false, 0, ""},
+	}
+	for _, tt := range tests {
+		h, ok := db.Lookup(tt.ip)
+		if ok != tt.found || h.ASN != tt.asn || h.Country != tt.country {
+			t.Errorf("Lookup(%s) = %+v, %v", tt.ip, h, ok)
+		}
+	}
+
+	enriched := db.Enrich([]string{"1.0.0.1", "9.9.9.9"})
+	if len(enriched) != 2 || enriched[0].ASOrg != "CLOUDFLARENET" || enriched[1].IP != "9.9.9.9" || enriched[1].ASN != 0 {
+		t.Errorf("Enrich = %+v", enriched)
+	}
+
+	if _, err := parseGeoIPDataset(strings.NewReader("1.0.0.0\t1.0.0.255\n")); err == nil {
+		t.Error("expected error for short line")
+	}
+	if _, err := parseGeoIPDataset(strings.NewReader("1.0.0.9\t1.0.0.1\t1\tUS\tX\n")); err == nil {
+		t.Error("expected error for inverted range")
+	}
+}
+
+func TestJurisdictionVulnerabilities(t *testing.T) {
+	hosting := map[string][]IPHosting{
+		"app.example.com": {{IP: "103.1.2.3", ASN: 4773, Country: "SG"}},
+		"cdn.example.com": {{IP: "1.0.0.1", ASN: 13335, ASOrg: "CLOUDFLARENET", Country: "US"}, {IP: "9.9.9.9"}},
+	}
+	if vulns := JurisdictionVulnerabilities(hosting, nil); len(vulns) != 0 {
+		t.Fatalf("no allow list should disable the check, got %+v", vulns)
+	}
+	vulns := JurisdictionVulnerabilities(hosting, []string{"SG", "vn"})
+	if len(vulns) != 1 {
+		t.Fatalf("expected one vulnerability, got %+v", vulns)
+	}
+	v := vulns[0]
+	if len(v.AffectedURLs) != 1 || v.AffectedURLs[0] != "cdn.example.com" || !strings.Contains(v.Recommendation, "1.0.0.1 (AS13335 CLOUDFLARENET, US)") {
+		t.Errorf("unexpected vulnerability %+v", v)
+	}
+}
@@ -0,0 +1,349 @@
+package checker
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// maxHARBodyBytes bounds how much of each response body is kept in a HAR.
+const maxHARBodyBytes = 256 * 1024
+
+// harRedacted replaces credential header values that are always masked.
+const harRedacted = "[REDACTED]"
+
+// HAR is an HTTP Archive 1.2 document.
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog is the root of a HAR document.
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+// HARCreator names the tool that wrote the archive.
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry is one request/response exchange.
+type HAREntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+// HARRequest describes the request as sent, after decorators ran.
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARResponse describes the response received. Status 0 means the request
+// failed before a response arrived; the entry's comment holds the error.
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARNameValue is a header, cookie, or query parameter.
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARPostData is a request body.
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARContent is a response body. Only text bodies are kept, truncated to
+// maxHARBodyBytes.
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// HARTimings splits an entry's time; seca only measures wait and receive.
+type HARTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// HARRecorder records the HTTP exchanges of checkers and the crawler. It is
+// safe for concurrent use; a nil *HARRecorder records nothing.
+type HARRecorder struct {
+	// RedactHeaders, when set, masks credentials in request and response
+	// headers. Authorization, Proxy-Authorization, Cookie and Set-Cookie are
+	// always masked.
+	RedactHeaders func(http.Header) http.Header
+	// SecretHeaders names further headers whose values are always masked,
+	// such as operator-supplied API key headers.
+	SecretHeaders []string
+	// RedactString, when set, masks credentials in URLs, header values, and
+	// bodies.
+	RedactString func(string) string
+
+	mu      sync.Mutex
+	entries []HAREntry
+}
+
+// Wrap returns rt with every round trip recorded. Wrap it inside
+// WithDecorator so entries show the request as sent.
+func (r *HARRecorder) Wrap(rt http.RoundTripper) http.RoundTripper {
+	if r == nil {
+		return rt
+	}
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &harTransport{base: rt, recorder: r}
+}
+
+// Len returns the number of recorded entries.
+func (r *HARRecorder) Len() int {
+	if r == nil {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.entries)
+}
+
+// HAR returns the recorded entries as a HAR document, in start order.
+func (r *HARRecorder) HAR(creatorVersion string) HAR {
+	har := HAR{Log: HARLog{
+		Version: "1.2",
+		Creator: HARCreator{Name: "seca-cli", Version: creatorVersion},
+		Entries: []HAREntry{},
+	}}
+	if r == nil {
+		return har
+	}
+	r.mu.Lock()
+	har.Log.Entries = append(har.Log.Entries, r.entries...)
+	r.mu.Unlock()
+	sortHAREntries(har.Log.Entries)
+	return har
+}
+
+// WriteTo writes the HAR document as indented JSON.
+func (r *HARRecorder) WriteTo(w io.Writer, creatorVersion string) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r.HAR(creatorVersion))
+}
+
+func (r *HARRecorder) add(entry HAREntry) {
+	r.mu.Lock()
+	r.entries = append(r.entries, entry)
+	r.mu.Unlock()
+}
+
+func (r *HARRecorder) redactString(s string) string {
+	if r.RedactString == nil {
+		return s
+	}
+	return r.RedactString(s)
+}
+
+func (r *HARRecorder) headerValues(h http.Header) []HARNameValue {
+	if r.RedactHeaders != nil {
+		h = r.RedactHeaders(h)
+	}
+	values := make([]HARNameValue, 0, len(h))
+	for name, vals := range h {
+		for _, v := range vals {
+			if r.secretHeader(name) {
+				v = harRedacted
+			}
+			values = append(values, HARNameValue{Name: name, Value: r.redactString(v)})
+		}
+	}
+	sortNameValues(values)
+	return values
+}
+
+// harSecretHeaders are masked in every recording.
+var harSecretHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+func (r *HARRecorder) secretHeader(name string) bool {
+	match := func(secret string) bool { return strings.EqualFold(secret, name) }
+	return slices.ContainsFunc(harSecretHeaders, match) || slices.ContainsFunc(r.SecretHeaders, match)
+}
+
+type harTransport struct {
+	base     http.RoundTripper
+	recorder *HARRecorder
+}
+
+func (t *harTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := t.recorder
+	entry := HAREntry{StartedDateTime: time.Now().UTC()}
+	entry.Request = HARRequest{
+		Method:      req.Method,
+		URL:         r.redactString(req.URL.String()),
+		HTTPVersion: req.Proto,
+		Cookies:     []HARNameValue{},
+		Headers:     r.headerValues(req.Header),
+		QueryString: []HARNameValue{},
+		HeadersSize: -1,
+		BodySize:    int(req.ContentLength),
+	}
+	if entry.Request.HTTPVersion == "" {
+		entry.Request.HTTPVersion = "HTTP/1.1"
+	}
+	for name, vals := range req.URL.Query() {
+		for _, v := range vals {
+			entry.Request.QueryString = append(entry.Request.QueryString, HARNameValue{Name: name, Value: r.redactString(v)})
+		}
+	}
+	sortNameValues(entry.Request.QueryString)
+	if req.GetBody != nil && req.ContentLength != 0 {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(io.LimitReader(body, maxHARBodyBytes))
+			body.Close()
+			entry.Request.PostData = &HARPostData{
+				MimeType: req.Header.Get("Content-Type"),
+				Text:     r.redactString(string(data)),
+			}
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	wait := time.Since(entry.StartedDateTime)
+	if err != nil {
+		entry.Time = msSince(wait)
+		entry.Timings = HARTimings{Wait: entry.Time}
+		entry.Response = HARResponse{
+			Cookies:     []HARNameValue{},
+			Headers:     []HARNameValue{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		}
+		entry.Comment = r.redactString(err.Error())
+		r.add(entry)
+		return nil, err
+	}
+
+	entry.Response = HARResponse{
+		Status:      resp.StatusCode,
+		StatusText:  http.StatusText(resp.StatusCode),
+		HTTPVersion: resp.Proto,
+		Cookies:     []HARNameValue{},
+		Headers:     r.headerValues(resp.Header),
+		RedirectURL: r.redactString(resp.Header.Get("Location")),
+		HeadersSize: -1,
+		BodySize:    -1,
+		Content:     HARContent{MimeType: resp.Header.Get("Content-Type")},
+	}
+
+	resp.Body = &harBody{
+		ReadCloser: resp.Body,
+		recorder:   r,
+		entry:      entry,
+		wait:       wait,
+	}
+	return resp, nil
+}
+
+// harBody captures the response body as the caller reads it and records the
+// entry on Close, so streaming and partial reads are not disturbed.
+type harBody struct {
+	io.ReadCloser
+	recorder *HARRecorder
+	entry    HAREntry
+	wait     time.Duration
+	buf      bytes.Buffer
+	size     int
+	once     sync.Once
+}
+
+func (b *harBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.size += n
+	if room := maxHARBodyBytes - b.buf.Len(); room > 0 && n > 0 {
+		b.buf.Write(p[:min(n, room)])
+	}
+	return n, err
+}
+
+func (b *harBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		total := time.Since(b.entry.StartedDateTime)
+		b.entry.Time = msSince(total)
+		b.entry.Timings = HARTimings{Wait: msSince(b.wait), Receive: msSince(total - b.wait)}
+		b.entry.Response.BodySize = b.size
+		b.entry.Response.Content = harContent(b.recorder, b.entry.Response.Content, b.buf.Bytes(), b.size)
+		b.recorder.add(b.entry)
+	})
+	return err
+}
+
+func harContent(r *HARRecorder, content HARContent, body []byte, size int) HARContent {
+	content.Size = size
+	if len(body) == 0 {
+		return content
+	}
+	mediaType, _, _ := mime.ParseMediaType(content.MimeType)
+	textual := strings.HasPrefix(mediaType, "text/") || strings.Contains(mediaType, "json") ||
+		strings.Contains(mediaType, "xml") || strings.Contains(mediaType, "javascript") || mediaType == ""
+	if !textual || !utf8.Valid(body) {
+		content.Comment = "binary body omitted"
+		return content
+	}
+	content.Text = r.redactString(string(body))
+	if size > len(body) {
+		content.Comment = "body truncated"
+	}
+	return content
+}
+
+func msSince(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+func sortNameValues(values []HARNameValue) {
+	sort.SliceStable(values, func(i, j int) bool { return values[i].Name < values[j].Name })
+}
+
+func sortHAREntries(entries []HAREntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].StartedDateTime.Before(entries[j].StartedDateTime)
+	})
+}
@@ -0,0 +1,110 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHARRecorderRecordsAndRedacts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Set-Cookie", "sid=secret-session")
+		fmt.Fprint(w, "<html>token=secret-session</html>")
+	}))
+	defer server.Close()
+
+	har := &HARRecorder{
+		RedactString:  func(s string) string { return strings.ReplaceAll(s, "secret-session", "[REDACTED]") },
+		SecretHeaders: []string{"x-api-key"},
+	}
+	client := &http.Client{Transport: WithDecorator(har.Wrap(nil), func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer abc")
+		req.Header.Set("X-API-Key", "key-123")
+	})}
+
+	resp, err := client.Get(server.URL + "/page?q=1")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	if _, err := client.Get("http://127.0.0.1:1/unreachable"); err == nil {
+		t.Fatal("expected unreachable request to fail")
+	}
+
+	doc := har.HAR("test")
+	if doc.Log.Version != "1.2" || len(doc.Log.Entries) != 2 {
+		t.Fatalf("expected 2 entries in a HAR 1.2 log, got %+v", doc.Log)
+	}
+
+	entry := doc.Log.Entries[0]
+	if entry.Request.URL != server.URL+"/page?q=1" || entry.Response.Status != http.StatusOK {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+	if len(entry.Request.QueryString) != 1 || entry.Request.QueryString[0].Name != "q" {
+		t.Errorf("expected query string to be parsed, got %+v", entry.Request.QueryString)
+	}
+	for _, h := range entry.Request.Headers {
+		if (h.Name == "Authorization" || h.Name == "X-Api-Key") && h.Value != harRedacted {
+			t.Errorf("%s header not masked: %q", h.Name, h.Value)
+		}
+	}
+	for _, h := range entry.Response.Headers {
+		if h.Name == "Set-Cookie" && h.Value != harRedacted {
+			t.Errorf("Set-Cookie header not masked: %q", h.Value)
+		}
+	}
+	if !strings.Contains(entry.Response.Content.Text, "token=[REDACTED]") {
+		t.Errorf("expected body to be recorded and redacted, got %q", entry.Response.Content.Text)
+	}
+	if entry.Response.Content.Size != len("<html>token=secret-session</html>") {
+		t.Errorf("unexpected content size %d", entry.Response.Content.Size)
+	}
+
+	failed := doc.Log.Entries[1]
+	if failed.Response.Status != 0 || failed.Comment == "" {
+		t.Errorf("expected failed request with error comment, got %+v", failed)
+	}
+
+	var buf bytes.Buffer
+	if err := har.WriteTo(&buf, "test"); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if strings.Contains(buf.String(), "secret-session") {
+		t.Fatal("HAR output leaked a redacted value")
+	}
+	var decoded HAR
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("HAR output is not valid JSON: %v", err)
+	}
+}
+
+func TestHTTPCheckerRecordsHAR(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+
+	har := &HARRecorder{}
+	checker := &HTTPChecker{Timeout: 2 * time.Second, HAR: har}
+	if result := checker.Check(context.Background(), server.URL); result.Status != "ok" {
+		t.Fatalf("check failed: %+v", result)
+	}
+	if har.Len() == 0 {
+		t.Fatal("expected checker traffic to be recorded")
+	}
+	for _, entry := range har.HAR("test").Log.Entries {
+		if !strings.HasPrefix(entry.Request.URL, server.URL) {
+			t.Errorf("unexpected request %s", entry.Request.URL)
+		}
+	}
+}
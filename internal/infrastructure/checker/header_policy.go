@@ -0,0 +1,147 @@
+package checker
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// HeaderPolicy is an engagement's baseline for response headers, checked in
+// addition to the generic best practices in AnalyzeSecurityHeaders.
+type HeaderPolicy struct {
+	// RequiredHeaders must be present on every response.
+	RequiredHeaders []string `json:"required_headers,omitempty"`
+	// RequiredCSPDirectives are directives the CSP must contain, optionally
+	// with sources, e.g. "object-src" or "frame-ancestors 'none'".
+	RequiredCSPDirectives []string `json:"required_csp_directives,omitempty"`
+	// AllowedCORSOrigins lists the only values Access-Control-Allow-Origin may
+	// take. Empty allows any origin.
+	AllowedCORSOrigins []string `json:"allowed_cors_origins,omitempty"`
+}
+
+// HeaderPolicyViolation is a response header that breaks the engagement's
+// header policy.
+type HeaderPolicyViolation struct {
+	Rule   string `json:"rule"` // "required_header", "required_csp_directive", "allowed_cors_origin"
+	Header string `json:"header"`
+	Detail string `json:"detail"`
+}
+
+// Header policy rules.
+const (
+	PolicyRuleRequiredHeader       = "required_header"
+	PolicyRuleRequiredCSPDirective = "required_csp_directive"
+	PolicyRuleAllowedCORSOrigin    = "allowed_cors_origin"
+)
+
+// Validate checks that the policy is well formed.
+func (p *HeaderPolicy) Validate() error {
+	for _, h := range p.RequiredHeaders {
+		if strings.TrimSpace(h) == "" || strings.ContainsAny(h, " :") {
+			return fmt.Errorf("invalid required header %q", h)
+		}
+	}
+	for _, d := range p.RequiredCSPDirectives {
+		if len(strings.Fields(d)) == 0 {
+			return fmt.Errorf("empty required CSP directive")
+		}
+	}
+	for _, origin := range p.AllowedCORSOrigins {
+		if origin == "*" || origin == "null" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			return fmt.Errorf("invalid CORS origin %q (want scheme://host[:port], * or null)", origin)
+		}
+	}
+	return nil
+}
+
+// IsZero reports whether the policy has no rules.
+func (p *HeaderPolicy) IsZero() bool {
+	return p == nil || (len(p.RequiredHeaders) == 0 && len(p.RequiredCSPDirectives) == 0 && len(p.AllowedCORSOrigins) == 0)
+}
+
+// Evaluate returns the response's violations of the policy. A nil policy has
+// none.
+func (p *HeaderPolicy) Evaluate(headers http.Header) []HeaderPolicyViolation {
+	if p.IsZero() {
+		return nil
+	}
+	var violations []HeaderPolicyViolation
+
+	for _, name := range p.RequiredHeaders {
+		if headers.Get(name) == "" {
+			violations = append(violations, HeaderPolicyViolation{
+				Rule:   PolicyRuleRequiredHeader,
+				Header: http.CanonicalHeaderKey(name),
+				Detail: fmt.Sprintf("required header %s is missing", http.CanonicalHeaderKey(name)),
+			})
+		}
+	}
+
+	if len(p.RequiredCSPDirectives) > 0 {
+		policies := headers.Values("Content-Security-Policy")
+		for _, required := range p.RequiredCSPDirectives {
+			if !cspSatisfies(policies, required) {
+				violations = append(violations, HeaderPolicyViolation{
+					Rule:   PolicyRuleRequiredCSPDirective,
+					Header: "Content-Security-Policy",
+					Detail: fmt.Sprintf("CSP does not contain required directive %q", required),
+				})
+			}
+		}
+	}
+
+	if len(p.AllowedCORSOrigins) > 0 {
+		if origin := headers.Get("Access-Control-Allow-Origin"); origin != "" && !slices.Contains(p.AllowedCORSOrigins, strings.TrimSuffix(origin, "/")) {
+			violations = append(violations, HeaderPolicyViolation{
+				Rule:   PolicyRuleAllowedCORSOrigin,
+				Header: "Access-Control-Allow-Origin",
+				Detail: fmt.Sprintf("CORS origin %q is not in the allowed list", origin),
+			})
+		}
+	}
+	return violations
+}
+
+// cspSatisfies reports whether any of the enforced policies contains the
+// required directive with every required source.
+func cspSatisfies(policies []string, required string) bool {
+	want := strings.Fields(strings.ToLower(required))
+	for _, policy := range policies {
+		sources, ok := ParseCSP(policy).Directive(want[0])
+		if !ok {
+			continue
+		}
+		missing := slices.ContainsFunc(want[1:], func(src string) bool {
+			return !slices.ContainsFunc(sources, func(s string) bool { return strings.EqualFold(s, src) })
+		})
+		if !missing {
+			return true
+		}
+	}
+	return false
+}
+
+// analyzeHeaderPolicyViolations converts policy violations into findings,
+// kept separate from the generic header findings.
+func analyzeHeaderPolicyViolations(violations []HeaderPolicyViolation) []Vulnerability {
+	vulns := make([]Vulnerability, 0, len(violations))
+	for _, v := range violations {
+		vulns = append(vulns, Vulnerability{
+			Name:           fmt.Sprintf("Header Policy Violation: %s", v.Detail),
+			Category:       "Engagement Header Policy",
+			Severity:       "Medium",
+			Score:          0,
+			MaxScore:       10,
+			Status:         "Failed",
+			Description:    fmt.Sprintf("The response breaks the engagement's header policy (%s): %s.", v.Rule, v.Detail),
+			Recommendation: fmt.Sprintf("Configure %s to meet the header baseline agreed for this engagement.", v.Header),
+		})
+	}
+	return vulns
+}
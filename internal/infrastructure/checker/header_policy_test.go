@@ -0,0 +1,95 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeaderPolicyEvaluate(t *testing.T) {
+	policy := &HeaderPolicy{
+		RequiredHeaders:       []string{"strict-transport-security", "X-Frame-Options"},
+		RequiredCSPDirectives: []string{"object-src 'none'", "frame-ancestors", "script-src 'self' 'strict-dynamic'"},
+		AllowedCORSOrigins:    []string{"https://app.example.com"},
+	}
+
+	headers := http.Header{}
+	headers.Set("X-Frame-Options", "DENY")
+	headers.Add("Content-Security-Policy", "default-src 'self'; object-src 'none'")
+	headers.Add("Content-Security-Policy", "frame-ancestors 'self'; script-src 'self'")
+	headers.Set("Access-Control-Allow-Origin", "https://evil.example.net")
+
+	violations := policy.Evaluate(headers)
+	rules := map[string]int{}
+	for _, v := range violations {
+		rules[v.Rule]++
+	}
+	if rules[PolicyRuleRequiredHeader] != 1 || rules[PolicyRuleRequiredCSPDirective] != 1 || rules[PolicyRuleAllowedCORSOrigin] != 1 {
+		t.Fatalf("unexpected violations %+v", violations)
+	}
+	if violations[0].Header != "Strict-Transport-Security" {
+		t.Errorf("expected canonical header name, got %q", violations[0].Header)
+	}
+
+	headers.Set("Strict-Transport-Security", "max-age=31536000")
+	headers.Set("Access-Control-Allow-Origin", "https://app.example.com")
+	headers.Add("Content-Security-Policy", "script-src 'strict-dynamic' 'self'")
+	if violations := policy.Evaluate(headers); len(violations) != 0 {
+		t.Fatalf("expected no violations, got %+v", violations)
+	}
+
+	var none *HeaderPolicy
+	if violations := none.Evaluate(headers); violations != nil {
+		t.Fatalf("nil policy must not report violations, got %+v", violations)
+	}
+}
+
+func TestHeaderPolicyValidate(t *testing.T) {
+	valid := &HeaderPolicy{RequiredHeaders: []string{"X-Frame-Options"}, AllowedCORSOrigins: []string{"https://a.example.com", "*", "null"}}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, p := range []*HeaderPolicy{
+		{RequiredHeaders: []string{"X-Frame-Options: DENY"}},
+		{RequiredCSPDirectives: []string{"  "}},
+		{AllowedCORSOrigins: []string{"app.example.com"}},
+		{AllowedCORSOrigins: []string{"https://app.example.com/path"}},
+	} {
+		if err := p.Validate(); err == nil {
+			t.Errorf("expected error for %+v", p)
+		}
+	}
+}
+
+func TestHTTPChecker_HeaderPolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+	}))
+	defer server.Close()
+
+	c := &HTTPChecker{
+		Timeout:      5 * time.Second,
+		HeaderPolicy: &HeaderPolicy{RequiredHeaders: []string{"Content-Security-Policy"}},
+	}
+	result := c.Check(context.Background(), server.URL)
+	if result.SecurityHeaders == nil || len(result.SecurityHeaders.PolicyViolations) != 1 {
+		t.Fatalf("expected one policy violation, got %+v", result.SecurityHeaders)
+	}
+	if !strings.Contains(result.Notes, "1 header policy violation(s)") {
+		t.Errorf("expected policy note, got %q", result.Notes)
+	}
+
+	report := BuildVulnerabilityReport([]CheckResult{result}, server.URL, "", "")
+	found := false
+	for _, v := range report.Vulnerabilities {
+		if v.Category == "Engagement Header Policy" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected a distinct header policy finding in the report")
+	}
+}
@@ -0,0 +1,97 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// defaultHealthTimeout limits a health pre-check when Timeout is zero.
+const defaultHealthTimeout = 5 * time.Second
+
+// HealthCheck is a quick reachability pre-check run before the full checker
+// suite: it resolves the target's host and opens (and closes) one TCP
+// connection to its port. Nothing is sent over the connection.
+type HealthCheck struct {
+	Timeout time.Duration
+	// Dialer, when set, resolves through custom nameservers and pins.
+	Dialer *Dialer
+}
+
+// Probe returns nil when target's host resolves and accepts a TCP
+// connection, or an error saying which step failed. Targets that are not
+// URLs or host names pass, as does every target when h is nil.
+func (h *HealthCheck) Probe(ctx context.Context, target string) error {
+	if h == nil {
+		return nil
+	}
+	info := ParseTarget(target)
+	if info == nil || info.Host == "" {
+		return nil
+	}
+	port := info.Port
+	if port == "" {
+		port = "80"
+		if info.Scheme == "https" {
+			port = "443"
+		}
+	}
+	timeout := h.Timeout
+	if timeout <= 0 {
+		timeout = defaultHealthTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Pinned hosts skip DNS: the pin is the address the checks will use.
+	if net.ParseIP(info.Host) == nil && len(h.Dialer.Pinned(info.Host)) == 0 {
+		resolver := net.DefaultResolver
+		if !h.Dialer.IsZero() {
+			resolver = h.Dialer.Resolver()
+		}
+		if _, err := resolver.LookupIPAddr(ctx, info.Host); err != nil {
+			return fmt.Errorf("DNS lookup of %s failed: %w", info.Host, unwrapDNSError(err))
+		}
+	}
+
+	addr := net.JoinHostPort(info.Host, port)
+	var conn net.Conn
+	var err error
+	if h.Dialer.IsZero() {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = h.Dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("TCP connect to %s timed out after %s", addr, timeout)
+		}
+		return fmt.Errorf("TCP connect to %s failed: %w", addr, err)
+	}
+	conn.Close()
+	return nil
+}
+
+// unwrapDNSError drops the resolver details (the nameserver address) that
+// net.DNSError adds, keeping "no such host" and similar.
+func unwrapDNSError(err error) error {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return errors.New(dnsErr.Err)
+	}
+	return err
+}
+
+// UnreachableResult is the result recorded for a target that failed its
+// health pre-check.
+func UnreachableResult(target string, err error) CheckResult {
+	return CheckResult{
+		Target:     target,
+		CheckedAt:  time.Now().UTC(),
+		Status:     StatusUnreachable,
+		Error:      err.Error(),
+		DNSRecords: make(map[string]interface{}),
+	}
+}
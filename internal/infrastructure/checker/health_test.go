@@ -0,0 +1,96 @@
+package checker
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHealthCheckProbe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	// A port nothing listens on: grab one and release it.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed := l.Addr().String()
+	l.Close()
+
+	h := &HealthCheck{Timeout: 2 * time.Second}
+	if err := h.Probe(context.Background(), server.URL); err != nil {
+		t.Errorf("a listening server should pass: %v", err)
+	}
+	if err := h.Probe(context.Background(), "http://"+closed); err == nil || !strings.Contains(err.Error(), "TCP connect") {
+		t.Errorf("a closed port should fail the connect step, got %v", err)
+	}
+	if err := h.Probe(context.Background(), "https://nonexistent.invalid"); err == nil || !strings.Contains(err.Error(), "DNS lookup") {
+		t.Errorf("an unresolvable host should fail the DNS step, got %v", err)
+	}
+
+	// Pinned hosts are dialed at the pin without a DNS lookup.
+	dialer := &Dialer{Timeout: time.Second}
+	host, port, _ := net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	dialer.Pin("app.example.invalid", host)
+	pinned := &HealthCheck{Timeout: 2 * time.Second, Dialer: dialer}
+	if err := pinned.Probe(context.Background(), "http://app.example.invalid:"+port); err != nil {
+		t.Errorf("a pinned host should be dialed at its pin: %v", err)
+	}
+
+	var nilCheck *HealthCheck
+	if err := nilCheck.Probe(context.Background(), "http://"+closed); err != nil {
+		t.Errorf("a nil health check should pass every target: %v", err)
+	}
+}
+
+func TestRunnerSkipsUnreachableTargets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	c := &countingChecker{budget: &Budget{}}
+	runner := &Runner{Concurrency: 2, RateLimit: 100, Timeout: 5 * time.Second, Health: &HealthCheck{Timeout: 2 * time.Second}}
+	results := runner.RunChecks(context.Background(), []string{server.URL, "https://nonexistent.invalid"}, c, nil)
+
+	if c.calls.Load() != 1 {
+		t.Errorf("the checker should only run for the reachable target, ran %d times", c.calls.Load())
+	}
+	for _, r := range results {
+		want := StatusOK
+		if strings.Contains(r.Target, "nonexistent") {
+			want = StatusUnreachable
+		}
+		if r.Status != want {
+			t.Errorf("%s: status %q, want %q (%s)", r.Target, r.Status, want, r.Error)
+		}
+	}
+}
+
+func TestRunnerSkipsTargetsAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := &countingChecker{budget: &Budget{}}
+	var audited []string
+	runner := &Runner{Concurrency: 1, RateLimit: 100, Timeout: time.Second}
+	results := runner.RunChecks(ctx, []string{"a", "b"}, c, func(target string, result CheckResult, duration float64) error {
+		audited = append(audited, result.Status)
+		return nil
+	})
+
+	if c.calls.Load() != 0 {
+		t.Errorf("no check should start after the run is canceled, ran %d", c.calls.Load())
+	}
+	if len(results) != 2 || len(audited) != 2 {
+		t.Fatalf("expected 2 results and 2 audit calls, got %d and %d", len(results), len(audited))
+	}
+	for _, r := range results {
+		if r.Status != StatusSkipped || r.Error != "run canceled" || r.Succeeded() {
+			t.Errorf("%s: got status %q (%s), want skipped", r.Target, r.Status, r.Error)
+		}
+	}
+}
@@ -3,7 +3,6 @@ package checker
 import (
 	"bufio"
 	"context"
-	"crypto/tls"
 	"fmt"
 	"io"
 	"net/http"
@@ -19,6 +18,24 @@ type HTTPChecker struct {
 	Timeout    time.Duration
 	CaptureRaw bool
 	RawHandler func(target string, headers http.Header, bodySnippet string) error
+	// Decorate, when set, is applied to every request (e.g. session credentials).
+	Decorate RequestDecorator
+	// Proxy, when set, routes every request through an HTTP(S) or SOCKS5 proxy.
+	Proxy *url.URL
+	// Dialer, when set, resolves targets through custom nameservers and pins.
+	Dialer *Dialer
+	// HAR, when set, records every request and response.
+	HAR *HARRecorder
+	// Budget, when set, counts requests and bytes against the run budget.
+	Budget *Budget
+	// Throttle, when set, backs off from hosts that rate limit or block.
+	Throttle *Throttle
+	// HeaderPolicy, when set, is the engagement's response header baseline.
+	HeaderPolicy *HeaderPolicy
+	// Favicons identifies products by favicon hash; nil uses the bundled set.
+	Favicons FaviconDB
+	// Phases limits each phase of every request; zero phases use Timeout.
+	Phases PhaseTimeouts
 }
 
 const bodySnippetLimit = 32768
@@ -31,8 +48,10 @@ func (h *HTTPChecker) Check(ctx context.Context, target string) (result CheckRes
 		CheckedAt:  time.Now().UTC(),
 		DNSRecords: make(map[string]interface{}),
 	}
+	tracer := &phaseTracer{}
 	defer func() {
 		result.ResponseTime = time.Since(startTime).Seconds() * 1000
+		result.Timings = tracer.finish()
 	}()
 
 	// Normalize URL using shared utility
@@ -40,19 +59,20 @@ func (h *HTTPChecker) Check(ctx context.Context, target string) (result CheckRes
 	u := targetInfo.FullURL
 	parsed, _ := url.Parse(u)
 
-	// Create HTTP client
+	timeout := h.Timeout
+	if o, ok := TargetOverrideFrom(ctx); ok && o.Timeout > 0 {
+		timeout = o.Timeout
+	}
+
+	// Create HTTP client. Each phase is limited on its own rather than the
+	// whole request, so a slow body does not fail a slow-to-connect target.
+	phases := h.Phases.WithDefault(timeout)
 	client := &http.Client{
-		Timeout: h.Timeout,
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: false,
-				MinVersion:         tls.VersionTLS12,
-			},
-		},
+		Transport: WithDecorator(h.Throttle.Wrap(h.Budget.Wrap(h.HAR.Wrap(phases.Wrap(phases.Apply(h.Dialer.Apply(NewTransport(h.Proxy))))))), h.Decorate),
 	}
 
 	// Try HEAD request first (safe, minimal side effects)
-	req, err := http.NewRequestWithContext(ctx, "HEAD", u, nil)
+	req, err := http.NewRequestWithContext(tracer.withTrace(ctx), "HEAD", u, nil)
 	if err != nil {
 		result.Status = "error"
 		result.Error = fmt.Sprintf("create request: %v", err)
@@ -62,8 +82,9 @@ func (h *HTTPChecker) Check(ctx context.Context, target string) (result CheckRes
 	resp, err := client.Do(req)
 	usedGET := false
 	if err != nil {
-		// Fallback to GET (some servers disallow HEAD)
-		req2, err2 := http.NewRequestWithContext(ctx, "GET", u, nil)
+		// Fallback to GET (some servers disallow HEAD); time it afresh.
+		tracer = &phaseTracer{}
+		req2, err2 := http.NewRequestWithContext(tracer.withTrace(ctx), "GET", u, nil)
 		if err2 != nil {
 			result.Status = "error"
 			result.Error = fmt.Sprintf("create GET request: %v", err2)
@@ -85,10 +106,29 @@ func (h *HTTPChecker) Check(ctx context.Context, target string) (result CheckRes
 	result.HTTPStatus = resp.StatusCode
 	result.ServerHeader = resp.Header.Get("Server")
 	result.Status = "ok"
+	if waf := DetectWAFBlock(resp); waf != "" {
+		// The page is the WAF's, not the site's; analyzing it would report
+		// the WAF's headers as the target's.
+		result.Status = StatusBlocked
+		result.Error = fmt.Sprintf("blocked by %s (HTTP %d)", waf, resp.StatusCode)
+		return result
+	}
+	if pins := h.Dialer.Pinned(resp.Request.URL.Hostname()); len(pins) > 0 && h.Proxy == nil {
+		appendNote(&result, "pinned to "+strings.Join(pins, ", "))
+	}
 
 	// Analyze security headers
 	result.SecurityHeaders = AnalyzeSecurityHeaders(resp.Header)
+	if violations := h.HeaderPolicy.Evaluate(resp.Header); len(violations) > 0 {
+		result.SecurityHeaders.PolicyViolations = violations
+		appendNote(&result, fmt.Sprintf("%d header policy violation(s)", len(violations)))
+	}
 	result.CachePolicy = AnalyzeCachePolicy(resp.Header)
+	AnalyzeCacheDeception(result.CachePolicy, resp)
+	result.Smuggling = AnalyzeSmugglingPosture(resp)
+	if n := len(result.Smuggling.KnownIssues); n > 0 {
+		appendNote(&result, fmt.Sprintf("%d known request smuggling flaw(s) in the proxy chain", n))
+	}
 
 	// Analyze cookies for Secure/HttpOnly flags (OWASP ASVS §3.4)
 	if cookieFindings := AnalyzeCookies(resp); len(cookieFindings) > 0 {
@@ -112,6 +152,18 @@ func (h *HTTPChecker) Check(ctx context.Context, target string) (result CheckRes
 	if resp.TLS != nil {
 		result.TLSCompliance = AnalyzeTLSCompliance(resp.TLS)
 
+		// Probe renegotiation, session tickets, compression, and DHE groups,
+		// which the Go TLS stack does not expose. A raw probe cannot go
+		// through the proxy.
+		if h.Proxy == nil {
+			host, addr := TLSAddress(resp.Request.URL.String())
+			addr = h.Dialer.Address(ctx, addr)
+			ProbeTLSExtensions(ctx, result.TLSCompliance, addr, host, timeout)
+			if result.TLSCompliance.Extensions.Probed {
+				ProbeDHE(ctx, result.TLSCompliance, addr, host, timeout)
+			}
+		}
+
 		// Legacy TLS expiry field for backward compatibility
 		if len(resp.TLS.PeerCertificates) > 0 {
 			cert := resp.TLS.PeerCertificates[0]
@@ -163,21 +215,33 @@ func (h *HTTPChecker) Check(ctx context.Context, target string) (result CheckRes
 		_, _ = io.Copy(io.Discard, resp.Body)
 	}
 	if bodyErr != nil {
-		appendNote(&result, fmt.Sprintf("warning: failed to read response body: %v", bodyErr))
+		appendWarning(&result, fmt.Sprintf("failed to read response body: %v", bodyErr))
 	}
+	// Fingerprint the page so later runs can spot significant content changes.
+	result.Content = FingerprintContent(bodySnippet)
 	if h.CaptureRaw && h.RawHandler != nil && len(bodySnippet) > 0 {
 		rawBytes := bodySnippet
 		if len(rawBytes) > consts.RawCaptureLimitBytes {
 			rawBytes = rawBytes[:consts.RawCaptureLimitBytes]
 		}
 		if err := h.RawHandler(target, resp.Header, string(rawBytes)); err != nil {
-			appendNote(&result, fmt.Sprintf("warning: failed to save raw capture: %v", err))
+			appendWarning(&result, fmt.Sprintf("failed to save raw capture: %v", err))
 		}
 	}
 
 	// Check for robots.txt (safe, small GET)
 	if parsed != nil {
 		checkRobotsAndSitemap(ctx, client, parsed, &result)
+		favicons := h.Favicons
+		if favicons == nil {
+			favicons = BundledFavicons()
+		}
+		if favicon := FetchFavicon(ctx, client, parsed, string(bodySnippet), favicons); favicon != nil {
+			result.Favicon = favicon
+			if len(favicon.Products) > 0 {
+				appendNote(&result, "favicon matches "+strings.Join(favicon.Products, ", "))
+			}
+		}
 		if len(bodySnippet) > 0 {
 			if scripts := AnalyzeThirdPartyScripts(string(bodySnippet), parsed); len(scripts) > 0 {
 				result.ThirdPartyScripts = scripts
@@ -214,6 +278,10 @@ func (h *HTTPChecker) Check(ctx context.Context, target string) (result CheckRes
 			clientSecurity := AnalyzeClientSecurity(string(bodySnippet), resp.Header, resp.Cookies())
 			if clientSecurity != nil {
 				result.ClientSecurity = clientSecurity
+				clientSecurity.LookalikeLinks = DetectLookalikeLinks(parsed, string(bodySnippet))
+				if n := len(clientSecurity.LookalikeLinks); n > 0 {
+					appendNote(&result, fmt.Sprintf("%d link(s) to lookalike IDN host(s)", n))
+				}
 
 				// Add notes for critical findings
 				if len(clientSecurity.VulnerableLibraries) > 0 {
@@ -238,6 +306,13 @@ func (h *HTTPChecker) Check(ctx context.Context, target string) (result CheckRes
 		}
 	}
 
+	// Probe for unkeyed request headers a cache could be poisoned through
+	ProbeUnkeyedHeaders(ctx, client, u, result.CachePolicy)
+	ProbeHostHeader(ctx, client, u, result.CachePolicy)
+	if n := len(result.CachePolicy.Findings); n > 0 {
+		appendNote(&result, fmt.Sprintf("%d cache deception/poisoning indicator(s)", n))
+	}
+
 	return result
 }
 
@@ -256,12 +331,14 @@ func checkRobotsAndSitemap(ctx context.Context, client *http.Client, parsed *url
 		return client.Do(req)
 	}
 
+	analysis := &RobotsSitemapAnalysis{}
 	robotsResp, err := checkRel("/robots.txt")
 	if err == nil {
 		defer robotsResp.Body.Close()
 		if robotsResp.StatusCode == http.StatusOK {
 			data, _ := io.ReadAll(io.LimitReader(robotsResp.Body, 8192))
 			summarizeRobots(string(data), result)
+			analysis.SensitivePaths = SensitiveRobotsPaths(string(data))
 		}
 		_, _ = io.Copy(io.Discard, robotsResp.Body)
 	}
@@ -272,10 +349,26 @@ func checkRobotsAndSitemap(ctx context.Context, client *http.Client, parsed *url
 		if sitemapResp.StatusCode == http.StatusOK {
 			body, _ := io.ReadAll(io.LimitReader(sitemapResp.Body, 20480))
 			discovered := analyzeSitemapURLs(string(body))
+			// Sitemaps written on one line, or truncated by the read
+			// limit, still yield their entries to the XML parser.
+			if pages, children, err := ParseSitemap(body); err == nil && len(pages)+len(children) > len(discovered) {
+				discovered = append(pages, children...)
+			}
 			addSitemapNote(result, discovered)
+			analysis.NonProductionURLs = NonProductionSitemapURLs(discovered)
 		}
 		_, _ = io.Copy(io.Discard, sitemapResp.Body)
 	}
+
+	if !analysis.Empty() {
+		result.RobotsSitemap = analysis
+		if n := len(analysis.SensitivePaths); n > 0 {
+			appendNote(result, fmt.Sprintf("robots.txt discloses %d sensitive path(s)", n))
+		}
+		if n := len(analysis.NonProductionURLs); n > 0 {
+			appendNote(result, fmt.Sprintf("sitemap lists %d non-production host(s)", n))
+		}
+	}
 }
 
 func summarizeRobots(content string, result *CheckResult) {
@@ -342,6 +435,15 @@ func appendNote(result *CheckResult, msg string) {
 	}
 }
 
+// appendWarning notes part of a check that could not complete and marks an
+// ok result as a warning.
+func appendWarning(result *CheckResult, msg string) {
+	appendNote(result, "warning: "+msg)
+	if result.Status == StatusOK {
+		result.Status = StatusWarning
+	}
+}
+
 func readBodySnippet(body io.ReadCloser, limit int64) ([]byte, error) {
 	data, err := io.ReadAll(io.LimitReader(body, limit))
 	if _, drainErr := io.Copy(io.Discard, body); err == nil && drainErr != nil {
@@ -66,6 +66,27 @@ func TestHTTPChecker_MockServer(t *testing.T) {
 	}
 }
 
+func TestHTTPChecker_WAFBlock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cf-Mitigated", "challenge")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	checker := &HTTPChecker{Timeout: 5 * time.Second}
+	result := checker.Check(context.Background(), server.URL)
+
+	if result.Status != StatusBlocked {
+		t.Fatalf("Expected status %q, got %q (%s)", StatusBlocked, result.Status, result.Error)
+	}
+	if result.Error != "blocked by Cloudflare (HTTP 403)" {
+		t.Errorf("Unexpected error: %q", result.Error)
+	}
+	if result.SecurityHeaders != nil {
+		t.Error("The WAF's block page should not be analyzed as the site")
+	}
+}
+
 func TestHTTPChecker_CORSDetection(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
@@ -510,3 +531,26 @@ func TestHTTPChecker_NoRobotsText(t *testing.T) {
 		t.Logf("Notes: %s", result.Notes)
 	}
 }
+
+func TestHTTPChecker_RecordsPhaseTimings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	checker := &HTTPChecker{Timeout: 5 * time.Second}
+	result := checker.Check(context.Background(), server.URL)
+	if result.Timings == nil {
+		t.Fatalf("expected phase timings, got none (status %s, error %q)", result.Status, result.Error)
+	}
+	if result.Timings.Connect <= 0 || result.Timings.TLS != 0 {
+		t.Errorf("expected a timed connect and no TLS phase, got %+v", *result.Timings)
+	}
+	if result.Timings.FirstByte < 20 {
+		t.Errorf("expected first byte to include the server delay, got %.2fms", result.Timings.FirstByte)
+	}
+	if result.Timings.Analysis <= 0 || result.Timings.Analysis > result.ResponseTime {
+		t.Errorf("expected analysis time within the total, got %.2fms of %.2fms", result.Timings.Analysis, result.ResponseTime)
+	}
+}
@@ -0,0 +1,179 @@
+package checker
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// maxLookalikeLinks caps the lookalike hosts reported per page.
+const maxLookalikeLinks = 20
+
+// ASCIIHost returns the punycode (A-label) form of an internationalized
+// host, which is what DNS and TLS use. ASCII hosts, IP addresses, and hosts
+// that are not valid IDNs are returned unchanged.
+func ASCIIHost(host string) string {
+	if isASCII(host) {
+		return host
+	}
+	ascii, err := idna.Lookup.ToASCII(host)
+	if err != nil {
+		return host
+	}
+	return ascii
+}
+
+// asciiURLHost converts the host of u to punycode in place.
+func asciiURLHost(u *url.URL) {
+	if host := u.Hostname(); !isASCII(host) {
+		u.Host = strings.Replace(u.Host, host, ASCIIHost(host), 1)
+	}
+}
+
+// UnicodeHost returns the Unicode (U-label) form of a punycode host for
+// display. Hosts without punycode labels are returned unchanged.
+func UnicodeHost(host string) string {
+	if !strings.Contains(strings.ToLower(host), "xn--") {
+		return host
+	}
+	unicodeHost, err := idna.Display.ToUnicode(host)
+	if err != nil {
+		return host
+	}
+	return unicodeHost
+}
+
+// DisplayTarget returns target with its host in Unicode form, for reports.
+func DisplayTarget(target string) string {
+	host := ExtractHost(target)
+	display := UnicodeHost(host)
+	if display == host {
+		return target
+	}
+	return strings.Replace(target, host, display, 1)
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// LookalikeLink is a link to a host whose Unicode form imitates an ASCII
+// host (an IDN homograph), as used in phishing.
+type LookalikeLink struct {
+	URL       string `json:"url"`
+	Host      string `json:"host"`     // Unicode form, as a visitor sees it
+	Punycode  string `json:"punycode"` // Form resolved by DNS
+	LooksLike string `json:"looks_like"`
+	// Reason is "mixed-script" when a label mixes Latin with Cyrillic or
+	// Greek letters, or "whole-script" when a label is written entirely in
+	// letters that look Latin.
+	Reason string `json:"reason"`
+}
+
+// confusables maps non-Latin letters to the Latin letters they are
+// commonly mistaken for.
+var confusables = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'с': 'c', 'ԁ': 'd', 'е': 'e', 'ё': 'e', 'ԍ': 'g', 'һ': 'h', 'і': 'i',
+	'ї': 'i', 'ј': 'j', 'к': 'k', 'ӏ': 'l', 'о': 'o', 'р': 'p', 'ԛ': 'q', 'ѕ': 's',
+	'ѵ': 'v', 'ԝ': 'w', 'х': 'x', 'у': 'y', 'ү': 'y',
+	// Greek
+	'α': 'a', 'ε': 'e', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p', 'υ': 'u',
+	'χ': 'x', 'ω': 'w',
+	// Armenian
+	'օ': 'o', 'ս': 'u', 'հ': 'h', 'ց': 'g', 'ք': 'p',
+}
+
+// LookalikeHost reports whether host (in either form) imitates an ASCII
+// host, returning the host it looks like and why.
+func LookalikeHost(host string) (looksLike, reason string, ok bool) {
+	display := UnicodeHost(ASCIIHost(host))
+	if isASCII(display) {
+		return "", "", false
+	}
+	labels := strings.Split(display, ".")
+	skeleton := make([]string, len(labels))
+	for i, label := range labels {
+		latin, other, mapped, letters := 0, 0, 0, 0
+		var b strings.Builder
+		for _, r := range label {
+			if latinRune, confusable := confusables[r]; confusable {
+				other++
+				mapped++
+				letters++
+				b.WriteRune(latinRune)
+				continue
+			}
+			if unicode.IsLetter(r) {
+				letters++
+				switch {
+				case unicode.Is(unicode.Latin, r):
+					latin++
+				case unicode.In(r, unicode.Cyrillic, unicode.Greek, unicode.Armenian):
+					other++
+				}
+			}
+			b.WriteRune(r)
+		}
+		skeleton[i] = b.String()
+		switch {
+		case latin > 0 && other > 0 && reason == "":
+			reason = "mixed-script"
+		case letters > 0 && mapped == letters && reason == "":
+			reason = "whole-script"
+		}
+	}
+	if reason == "" {
+		return "", "", false
+	}
+	looksLike = strings.Join(skeleton, ".")
+	if !isASCII(looksLike) {
+		// Some letters have no Latin lookalike, so the host does not pass
+		// for an ASCII one.
+		return "", "", false
+	}
+	return looksLike, reason, true
+}
+
+// DetectLookalikeLinks returns the links on a page whose hosts are IDN
+// homographs of ASCII hosts, one per host.
+func DetectLookalikeLinks(page *url.URL, body string) []LookalikeLink {
+	seen := make(map[string]bool)
+	var links []LookalikeLink
+	for _, raw := range extractLinks(page, []byte(body)) {
+		u, err := url.Parse(raw)
+		if err != nil {
+			continue
+		}
+		host := strings.ToLower(u.Hostname())
+		if host == "" || seen[host] {
+			continue
+		}
+		seen[host] = true
+		looksLike, reason, ok := LookalikeHost(host)
+		if !ok {
+			continue
+		}
+		links = append(links, LookalikeLink{
+			URL:       raw,
+			Host:      UnicodeHost(host),
+			Punycode:  host,
+			LooksLike: looksLike,
+			Reason:    reason,
+		})
+	}
+	sort.Slice(links, func(i, j int) bool { return links[i].Punycode < links[j].Punycode })
+	if len(links) > maxLookalikeLinks {
+		links = links[:maxLookalikeLinks]
+	}
+	return links
+}
@@ -0,0 +1,101 @@
+package checker
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestParseTargetIDN(t *testing.T) {
+	info := ParseTarget("https://bücher.example:8443/kategorie")
+	if info.Host != "xn--bcher-kva.example" {
+		t.Fatalf("expected punycode host, got %q", info.Host)
+	}
+	if info.FullURL != "https://xn--bcher-kva.example:8443/kategorie" {
+		t.Fatalf("unexpected full URL %q", info.FullURL)
+	}
+	if got := ExtractHost("bücher.example"); got != "xn--bcher-kva.example" {
+		t.Errorf("ExtractHost = %q", got)
+	}
+	if got := DisplayTarget(info.FullURL); got != "https://bücher.example:8443/kategorie" {
+		t.Errorf("DisplayTarget = %q", got)
+	}
+	if got := DisplayTarget("https://example.com/path"); got != "https://example.com/path" {
+		t.Errorf("ASCII targets should display unchanged, got %q", got)
+	}
+}
+
+func TestLookalikeHost(t *testing.T) {
+	cases := []struct {
+		host      string
+		looksLike string
+		reason    string
+	}{
+		{"аpple.com", "apple.com", "mixed-script"},        // Cyrillic а
+		{"xn--pple-43d.com", "apple.com", "mixed-script"}, // same host in punycode
+		{"раураl.com", "paypal.com", "mixed-script"},      // Cyrillic р, а, у with Latin l
+		{"аррӏе.com", "apple.com", "whole-script"},        // all Cyrillic
+		{"bücher.example", "", ""},                        // Latin with diacritics
+		{"пример.рф", "", ""},                             // Cyrillic without Latin lookalikes
+		{"example.com", "", ""},                           // ASCII
+		{"例え.jp", "", ""},                                 // Han
+	}
+	for _, tc := range cases {
+		looksLike, reason, ok := LookalikeHost(tc.host)
+		if ok != (tc.reason != "") || looksLike != tc.looksLike || reason != tc.reason {
+			t.Errorf("LookalikeHost(%q) = %q, %q, %v; want %q, %q", tc.host, looksLike, reason, ok, tc.looksLike, tc.reason)
+		}
+	}
+}
+
+func TestDetectLookalikeLinks(t *testing.T) {
+	page, _ := url.Parse("https://shop.example.com/")
+	body := `<a href="https://аpple.com/login">Sign in</a>
+		<a href="https://аpple.com/other">Again</a>
+		<a href="https://bücher.example/">Books</a>
+		<a href="/account">Account</a>`
+
+	links := DetectLookalikeLinks(page, body)
+	if len(links) != 1 {
+		t.Fatalf("expected one lookalike host, got %+v", links)
+	}
+	link := links[0]
+	if link.Host != "аpple.com" || link.Punycode != "xn--pple-43d.com" || link.LooksLike != "apple.com" {
+		t.Errorf("unexpected link %+v", link)
+	}
+	if link.URL != "https://xn--pple-43d.com/login" {
+		t.Errorf("expected the link URL in punycode, got %q", link.URL)
+	}
+
+	findings := AnalyzeFindings(CheckResult{Target: page.String(), ClientSecurity: &ClientSecurityResult{LookalikeLinks: links}})
+	var found *Finding
+	for i := range findings {
+		if findings[i].Check == "Lookalike Domain Links" {
+			found = &findings[i]
+		}
+	}
+	if found == nil || found.Severity != "Info" || len(found.Evidence) != 1 {
+		t.Fatalf("expected an informational lookalike finding, got %+v", findings)
+	}
+}
+
+func TestCrawlLinksUsePunycode(t *testing.T) {
+	base, _ := url.Parse("https://bücher.example/")
+	links := extractLinks(base, []byte(`<a href="https://bücher.example/a">a</a><a href="/b">b</a>`))
+	want := []string{"https://xn--bcher-kva.example/a", "https://xn--bcher-kva.example/b"}
+	if len(links) != len(want) {
+		t.Fatalf("unexpected links %v", links)
+	}
+	for i := range want {
+		if links[i] != want[i] {
+			t.Errorf("link %d = %q, want %q", i, links[i], want[i])
+		}
+	}
+
+	scope, err := NewCrawlScope([]string{"*.bücher.example"}, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !scope.HostAllowed("shop.xn--bcher-kva.example") || !scope.HostAllowed("shop.bücher.example") {
+		t.Errorf("expected Unicode and punycode hosts to match %v", scope.AllowHosts)
+	}
+}
@@ -0,0 +1,344 @@
+package checker
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// maxInventoryScripts bounds how many same-host scripts are fetched per crawl
+// to look for API endpoints.
+const maxInventoryScripts = 20
+
+// FormInfo describes an HTML form found while crawling.
+type FormInfo struct {
+	Page         string   `json:"page"`
+	Action       string   `json:"action"`
+	Method       string   `json:"method"`
+	Inputs       []string `json:"inputs,omitempty"`
+	HasCSRFToken bool     `json:"has_csrf_token"`
+	HasPassword  bool     `json:"has_password,omitempty"`
+	HasFileInput bool     `json:"has_file_upload,omitempty"`
+	// PasswordAutocomplete holds the autocomplete attribute of each password
+	// field, lowercased, with the form's own attribute applied to fields
+	// that have none ("" when neither sets it).
+	PasswordAutocomplete []string `json:"password_autocomplete,omitempty"`
+}
+
+// APIEndpoint is an XHR/fetch endpoint referenced from page or script source.
+type APIEndpoint struct {
+	URL    string `json:"url"`
+	Method string `json:"method,omitempty"`
+	Via    string `json:"via"`    // fetch, xhr, axios, or jquery
+	Source string `json:"source"` // page or script that references the endpoint
+}
+
+// AttackSurface is the passive inventory of forms and API endpoints gathered
+// while crawling. Nothing in it has been submitted or called.
+type AttackSurface struct {
+	Forms        []FormInfo    `json:"forms,omitempty"`
+	APIEndpoints []APIEndpoint `json:"api_endpoints,omitempty"`
+}
+
+// Empty reports whether the inventory holds nothing.
+func (a *AttackSurface) Empty() bool {
+	return a == nil || (len(a.Forms) == 0 && len(a.APIEndpoints) == 0)
+}
+
+// Merge adds forms and endpoints from other that are not already present.
+func (a *AttackSurface) Merge(other *AttackSurface) {
+	if other == nil {
+		return
+	}
+	inv := NewInventory()
+	for _, src := range []*AttackSurface{a, other} {
+		for _, f := range src.Forms {
+			inv.addForm(f)
+		}
+		for _, e := range src.APIEndpoints {
+			inv.addEndpoint(e)
+		}
+	}
+	*a = inv.Snapshot()
+}
+
+// Inventory collects an AttackSurface across crawled pages. It is safe for
+// concurrent use; a nil *Inventory ignores everything.
+type Inventory struct {
+	mu        sync.Mutex
+	forms     map[string]FormInfo
+	endpoints map[string]APIEndpoint
+	scripts   map[string]struct{}
+}
+
+// NewInventory returns an empty inventory.
+func NewInventory() *Inventory {
+	return &Inventory{
+		forms:     make(map[string]FormInfo),
+		endpoints: make(map[string]APIEndpoint),
+		scripts:   make(map[string]struct{}),
+	}
+}
+
+var (
+	formPattern       = regexp.MustCompile(`(?is)<form\b([^>]*)>(.*?)</form\s*>`)
+	fieldPattern      = regexp.MustCompile(`(?is)<(input|select|textarea|button)\b([^>]*)>`)
+	inlineScriptRegex = regexp.MustCompile(`(?is)<script\b([^>]*)>(.*?)</script\s*>`)
+	csrfFieldPattern  = regexp.MustCompile(`(?i)(csrf|xsrf|authenticity_token|requestverificationtoken|^_token$|nonce)`)
+
+	fetchCallPattern  = regexp.MustCompile("(?s)\\bfetch\\(\\s*(['\"`])([^'\"`]+)['\"`]\\s*(,\\s*\\{(.{0,300}?)\\})?")
+	xhrOpenPattern    = regexp.MustCompile("(?i)\\.open\\(\\s*['\"](GET|POST|PUT|PATCH|DELETE|HEAD|OPTIONS)['\"]\\s*,\\s*['\"`]([^'\"`]+)['\"`]")
+	axiosCallPattern  = regexp.MustCompile("(?i)\\baxios\\.(get|post|put|patch|delete|head|options)\\(\\s*['\"`]([^'\"`]+)['\"`]")
+	jqueryCallPattern = regexp.MustCompile("(?i)\\$\\.(get|post|getJSON|ajax)\\(\\s*['\"`]([^'\"`]+)['\"`]")
+	methodOptPattern  = regexp.MustCompile(`(?i)method\s*:\s*['"](\w+)['"]`)
+
+	htmlAttrPatterns = compileAttrPatterns("action", "autocomplete", "method", "name", "src", "type")
+)
+
+func compileAttrPatterns(names ...string) map[string]*regexp.Regexp {
+	patterns := make(map[string]*regexp.Regexp, len(names))
+	for _, name := range names {
+		patterns[name] = regexp.MustCompile(`(?i)(?:^|\s)` + name + `\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+	}
+	return patterns
+}
+
+// AddPage records the forms and inline-script API calls in an HTML page.
+func (inv *Inventory) AddPage(page *url.URL, body []byte) {
+	if inv == nil || page == nil {
+		return
+	}
+	content := string(body)
+
+	for _, m := range formPattern.FindAllStringSubmatch(content, -1) {
+		inv.addForm(parseForm(page, m[1], m[2]))
+	}
+	for _, m := range inlineScriptRegex.FindAllStringSubmatch(content, -1) {
+		if htmlAttr(m[1], "src") != "" {
+			continue
+		}
+		inv.addScriptEndpoints(page, page.String(), m[2])
+	}
+}
+
+// AddScript records the API calls in an external script included by page.
+// Relative endpoints resolve against the page, as they do in the browser.
+func (inv *Inventory) AddScript(page, script *url.URL, body []byte) {
+	if inv == nil || page == nil || script == nil {
+		return
+	}
+	inv.addScriptEndpoints(page, script.String(), string(body))
+}
+
+// claimScript reports whether script has not been fetched yet and the per-crawl
+// script budget allows fetching it.
+func (inv *Inventory) claimScript(script string) bool {
+	if inv == nil {
+		return false
+	}
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	if _, ok := inv.scripts[script]; ok || len(inv.scripts) >= maxInventoryScripts {
+		return false
+	}
+	inv.scripts[script] = struct{}{}
+	return true
+}
+
+// Snapshot returns the collected inventory in a stable order.
+func (inv *Inventory) Snapshot() AttackSurface {
+	if inv == nil {
+		return AttackSurface{}
+	}
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	var surface AttackSurface
+	for _, f := range inv.forms {
+		surface.Forms = append(surface.Forms, f)
+	}
+	for _, e := range inv.endpoints {
+		surface.APIEndpoints = append(surface.APIEndpoints, e)
+	}
+	sort.Slice(surface.Forms, func(i, j int) bool {
+		a, b := surface.Forms[i], surface.Forms[j]
+		if a.Action != b.Action {
+			return a.Action < b.Action
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		return a.Page < b.Page
+	})
+	sort.Slice(surface.APIEndpoints, func(i, j int) bool {
+		a, b := surface.APIEndpoints[i], surface.APIEndpoints[j]
+		if a.URL != b.URL {
+			return a.URL < b.URL
+		}
+		return a.Method < b.Method
+	})
+	return surface
+}
+
+// addForm keeps one entry per action, method, and field set.
+func (inv *Inventory) addForm(f FormInfo) {
+	key := f.Method + " " + f.Action + " " + strings.Join(f.Inputs, ",")
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	if _, ok := inv.forms[key]; !ok {
+		inv.forms[key] = f
+	}
+}
+
+// addEndpoint keeps one entry per method and URL.
+func (inv *Inventory) addEndpoint(e APIEndpoint) {
+	key := e.Method + " " + e.URL
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	if _, ok := inv.endpoints[key]; !ok {
+		inv.endpoints[key] = e
+	}
+}
+
+func (inv *Inventory) addScriptEndpoints(page *url.URL, source, script string) {
+	add := func(via, method, raw string) {
+		endpoint := resolveEndpoint(page, raw)
+		if endpoint == "" {
+			return
+		}
+		inv.addEndpoint(APIEndpoint{
+			URL:    endpoint,
+			Method: strings.ToUpper(method),
+			Via:    via,
+			Source: source,
+		})
+	}
+
+	for _, m := range fetchCallPattern.FindAllStringSubmatch(script, -1) {
+		method := "GET"
+		if opt := methodOptPattern.FindStringSubmatch(m[4]); opt != nil {
+			method = opt[1]
+		}
+		add("fetch", method, m[2])
+	}
+	for _, m := range xhrOpenPattern.FindAllStringSubmatch(script, -1) {
+		add("xhr", m[1], m[2])
+	}
+	for _, m := range axiosCallPattern.FindAllStringSubmatch(script, -1) {
+		add("axios", m[1], m[2])
+	}
+	for _, m := range jqueryCallPattern.FindAllStringSubmatch(script, -1) {
+		method := m[1]
+		switch strings.ToLower(method) {
+		case "getjson":
+			method = "GET"
+		case "ajax":
+			method = ""
+		}
+		add("jquery", method, m[2])
+	}
+}
+
+func parseForm(page *url.URL, attrs, inner string) FormInfo {
+	form := FormInfo{
+		Page:   page.String(),
+		Action: page.String(),
+		Method: strings.ToUpper(strings.TrimSpace(htmlAttr(attrs, "method"))),
+	}
+	if form.Method == "" {
+		form.Method = "GET"
+	}
+	if action := strings.TrimSpace(htmlAttr(attrs, "action")); action != "" {
+		if resolved, err := page.Parse(action); err == nil {
+			form.Action = resolved.String()
+		}
+	}
+
+	formAutocomplete := strings.ToLower(strings.TrimSpace(htmlAttr(attrs, "autocomplete")))
+	seen := make(map[string]struct{})
+	for _, m := range fieldPattern.FindAllStringSubmatch(inner, -1) {
+		fieldAttrs := m[2]
+		fieldType := strings.ToLower(htmlAttr(fieldAttrs, "type"))
+		switch fieldType {
+		case "password":
+			form.HasPassword = true
+			autocomplete := strings.ToLower(strings.TrimSpace(htmlAttr(fieldAttrs, "autocomplete")))
+			if autocomplete == "" {
+				autocomplete = formAutocomplete
+			}
+			form.PasswordAutocomplete = append(form.PasswordAutocomplete, autocomplete)
+		case "file":
+			form.HasFileInput = true
+		}
+		name := htmlAttr(fieldAttrs, "name")
+		if name == "" {
+			continue
+		}
+		if csrfFieldPattern.MatchString(name) && (fieldType == "hidden" || fieldType == "") {
+			form.HasCSRFToken = true
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		form.Inputs = append(form.Inputs, name)
+	}
+	sort.Strings(form.Inputs)
+	return form
+}
+
+// resolveEndpoint returns raw resolved against page, or "" when it is not an
+// HTTP(S) endpoint. Template-literal placeholders are kept verbatim.
+func resolveEndpoint(page *url.URL, raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.HasPrefix(raw, "#") {
+		return ""
+	}
+	if strings.Contains(raw, "${") {
+		if strings.HasPrefix(raw, "/") {
+			return page.Scheme + "://" + page.Host + raw
+		}
+		return raw
+	}
+	resolved, err := page.Parse(raw)
+	if err != nil || (resolved.Scheme != "http" && resolved.Scheme != "https") {
+		return ""
+	}
+	resolved.Fragment = ""
+	return resolved.String()
+}
+
+// htmlAttr returns the unescaped value of attribute name (one of
+// htmlAttrPatterns) in a tag's attribute text.
+func htmlAttr(attrs, name string) string {
+	m := htmlAttrPatterns[name].FindStringSubmatch(attrs)
+	if m == nil {
+		return ""
+	}
+	for _, v := range m[1:] {
+		if v != "" {
+			return html.UnescapeString(v)
+		}
+	}
+	return ""
+}
+
+// sameHostScripts returns the external same-host scripts referenced by a page.
+func sameHostScripts(page *url.URL, body []byte) []*url.URL {
+	var scripts []*url.URL
+	for _, m := range scriptSrcPattern.FindAllStringSubmatch(string(body), -1) {
+		resolved, err := resolveScriptURL(strings.TrimSpace(m[1]), page)
+		if err != nil || resolved == "" {
+			continue
+		}
+		u, err := url.Parse(resolved)
+		if err != nil || !hostsMatch(page, u) {
+			continue
+		}
+		scripts = append(scripts, u)
+	}
+	return scripts
+}
@@ -0,0 +1,120 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestInventoryAddPage(t *testing.T) {
+	page, _ := url.Parse("https://app.example.com/account/")
+	body := []byte(`
+<form action="/login" method="post">
+  <input type="hidden" name="csrf_token" value="abc">
+  <input type="text" name="username">
+  <input type="password" name="password">
+  <button type="submit">Sign in</button>
+</form>
+<form>
+  <input name="q">
+</form>
+<script>
+  fetch('/api/v1/profile');
+  fetch("api/v1/orders", { method: "POST", body: data });
+  var xhr = new XMLHttpRequest(); xhr.open('DELETE', '/api/v1/session');
+  axios.put(` + "`/api/v1/items/${id}`" + `);
+  $.getJSON('https://app.example.com/api/v1/config');
+</script>
+<script src="/static/app.js"></script>`)
+
+	inv := NewInventory()
+	inv.AddPage(page, body)
+	surface := inv.Snapshot()
+
+	if len(surface.Forms) != 2 {
+		t.Fatalf("expected 2 forms, got %+v", surface.Forms)
+	}
+	login := surface.Forms[1] // sorted by action: /account/ (search) before /login
+	if login.Action != "https://app.example.com/login" || login.Method != "POST" {
+		t.Fatalf("unexpected login form: %+v", login)
+	}
+	if !login.HasCSRFToken || !login.HasPassword {
+		t.Fatalf("expected CSRF token and password field on login form: %+v", login)
+	}
+	if len(login.Inputs) != 3 {
+		t.Fatalf("expected 3 named inputs, got %v", login.Inputs)
+	}
+	if len(login.PasswordAutocomplete) != 1 || login.PasswordAutocomplete[0] != "" {
+		t.Fatalf("expected one password field without an autocomplete hint, got %q", login.PasswordAutocomplete)
+	}
+
+	want := map[string]string{
+		"GET https://app.example.com/api/v1/profile":         "fetch",
+		"POST https://app.example.com/account/api/v1/orders": "fetch",
+		"DELETE https://app.example.com/api/v1/session":      "xhr",
+		"PUT https://app.example.com/api/v1/items/${id}":     "axios",
+		"GET https://app.example.com/api/v1/config":          "jquery",
+	}
+	if len(surface.APIEndpoints) != len(want) {
+		t.Fatalf("expected %d endpoints, got %+v", len(want), surface.APIEndpoints)
+	}
+	for _, e := range surface.APIEndpoints {
+		if via, ok := want[e.Method+" "+e.URL]; !ok || via != e.Via {
+			t.Errorf("unexpected endpoint %+v", e)
+		}
+	}
+}
+
+func TestAttackSurfaceMerge(t *testing.T) {
+	a := &AttackSurface{APIEndpoints: []APIEndpoint{{URL: "https://a.example.com/api", Method: "GET", Via: "fetch"}}}
+	a.Merge(&AttackSurface{
+		Forms:        []FormInfo{{Action: "https://a.example.com/login", Method: "POST"}},
+		APIEndpoints: []APIEndpoint{{URL: "https://a.example.com/api", Method: "GET", Via: "fetch"}},
+	})
+	if len(a.Forms) != 1 || len(a.APIEndpoints) != 1 {
+		t.Fatalf("expected deduplicated merge, got %+v", a)
+	}
+	var empty *AttackSurface
+	if !empty.Empty() || a.Empty() {
+		t.Fatal("unexpected Empty result")
+	}
+}
+
+func TestDiscoverInScopeLinks_RecordsInventory(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<a href="/contact">Contact</a><script src="/app.js"></script><script src="https://cdn.example.com/lib.js"></script>`)
+	})
+	mux.HandleFunc("/contact", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<form method="post" action="/contact"><input name="email"><textarea name="message"></textarea></form>`)
+	})
+	mux.HandleFunc("/app.js", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/javascript")
+		fmt.Fprint(w, `fetch("/api/search?q=" + term)`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	inv := NewInventory()
+	opts := CrawlOptions{MaxDepth: 2, MaxPages: 10, SameHostOnly: true, Timeout: time.Second, IgnoreRobots: true, Inventory: inv}
+	if _, err := DiscoverInScopeLinks(context.Background(), server.URL, opts); err != nil {
+		t.Fatalf("DiscoverInScopeLinks returned error: %v", err)
+	}
+
+	surface := inv.Snapshot()
+	if len(surface.Forms) != 1 || surface.Forms[0].Action != server.URL+"/contact" || surface.Forms[0].HasCSRFToken {
+		t.Fatalf("unexpected forms: %+v", surface.Forms)
+	}
+	if len(surface.APIEndpoints) != 1 || surface.APIEndpoints[0].URL != server.URL+"/api/search?q=" {
+		t.Fatalf("unexpected endpoints: %+v", surface.APIEndpoints)
+	}
+	if surface.APIEndpoints[0].Source != server.URL+"/app.js" {
+		t.Fatalf("expected endpoint source to be the script, got %q", surface.APIEndpoints[0].Source)
+	}
+}
@@ -3,10 +3,12 @@ package checker
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 	"time"
 
+	"github.com/chromedp/cdproto/network"
 	"github.com/chromedp/chromedp"
 )
 
@@ -15,6 +17,9 @@ type JSCrawlOptions struct {
 	CrawlOptions
 	EnableJavaScript bool
 	WaitTime         time.Duration // Time to wait for JavaScript to render
+	// Headers are sent by the headless browser on every request; a User-Agent
+	// entry replaces the browser's own.
+	Headers http.Header
 }
 
 // DiscoverInScopeLinksJS crawls JavaScript-rendered pages using a headless browser.
@@ -43,18 +48,22 @@ func DiscoverInScopeLinksJS(ctx context.Context, startURL string, opts JSCrawlOp
 		return nil, err
 	}
 
-	// Create chromedp context
-	allocCtx, allocCancel := chromedp.NewExecAllocator(ctx,
-		append(chromedp.DefaultExecAllocatorOptions[:],
-			chromedp.Flag("headless", true),
-			chromedp.Flag("disable-gpu", true),
-			chromedp.Flag("no-sandbox", true),
-			chromedp.Flag("disable-dev-shm-usage", true),
-		)...,
-	)
-	defer allocCancel()
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	client := newCrawlClient(opts.CrawlOptions)
+	gate, err := newRobotsGate(ctx, client, root, opts.CrawlOptions)
+	if err != nil {
+		return nil, err
+	}
+	if !gate.allowed(root) {
+		return nil, nil
+	}
 
-	browserCtx, browserCancel := chromedp.NewContext(allocCtx)
+	browserCtx, browserCancel, err := newBrowser(ctx, opts.Headers, opts.Proxy)
+	if err != nil {
+		return nil, err
+	}
 	defer browserCancel()
 
 	type queueItem struct {
@@ -66,6 +75,12 @@ func DiscoverInScopeLinksJS(ctx context.Context, startURL string, opts JSCrawlOp
 	seen := map[string]struct{}{canonicalURL(root): {}}
 	discovered := make([]string, 0, opts.MaxPages)
 
+	if opts.UseSitemaps {
+		discovered = seedFromSitemaps(ctx, client, root, gate, opts.CrawlOptions, seen, discovered, func(u *url.URL) {
+			queue = append(queue, queueItem{url: u, depth: 1})
+		})
+	}
+
 	for len(queue) > 0 && len(discovered) < opts.MaxPages {
 		if err := ctx.Err(); err != nil {
 			return discovered, err
@@ -78,21 +93,31 @@ func DiscoverInScopeLinksJS(ctx context.Context, startURL string, opts JSCrawlOp
 			continue
 		}
 
+		if err := gate.wait(ctx); err != nil {
+			return discovered, err
+		}
+
 		// Fetch page and extract links using headless browser
-		links, err := fetchPageWithJS(browserCtx, item.url.String(), opts.WaitTime)
+		links, rendered, err := fetchPageWithJS(browserCtx, item.url.String(), opts.WaitTime)
 		if err != nil {
 			continue
 		}
+		if opts.Inventory != nil {
+			opts.Inventory.AddPage(item.url, []byte(rendered))
+			if err := inventoryScripts(ctx, client, gate, opts.Inventory, item.url, []byte(rendered)); err != nil {
+				return discovered, err
+			}
+		}
 
 		for _, raw := range links {
 			u, err := url.Parse(raw)
 			if err != nil {
 				continue
 			}
-			if opts.SameHostOnly && !hostsMatch(root, u) {
+			if !opts.inScope(root, u) {
 				continue
 			}
-			if looksLikeAsset(u.Path) {
+			if looksLikeAsset(u.Path) || !gate.allowed(u) {
 				continue
 			}
 			key := canonicalURL(u)
@@ -117,7 +142,8 @@ func DiscoverInScopeLinksJS(ctx context.Context, startURL string, opts JSCrawlOp
 }
 
 // fetchPageWithJS fetches a page using headless Chrome and extracts all links after JavaScript execution.
-func fetchPageWithJS(ctx context.Context, targetURL string, waitTime time.Duration) ([]string, error) {
+// It also returns the rendered body HTML.
+func fetchPageWithJS(ctx context.Context, targetURL string, waitTime time.Duration) ([]string, string, error) {
 	var htmlContent string
 	var links []string
 
@@ -129,7 +155,7 @@ func fetchPageWithJS(ctx context.Context, targetURL string, waitTime time.Durati
 		chromedp.InnerHTML("body", &htmlContent, chromedp.ByQuery),
 	)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	// Also extract links from React Router and other SPA frameworks
@@ -160,7 +186,7 @@ func fetchPageWithJS(ctx context.Context, targetURL string, waitTime time.Durati
 	// Resolve relative URLs
 	base, err := url.Parse(targetURL)
 	if err != nil {
-		return allLinks, nil
+		return allLinks, htmlContent, nil
 	}
 
 	resolvedLinks := make([]string, 0, len(allLinks))
@@ -170,7 +196,7 @@ func fetchPageWithJS(ctx context.Context, targetURL string, waitTime time.Durati
 		}
 	}
 
-	return resolvedLinks, nil
+	return resolvedLinks, htmlContent, nil
 }
 
 // DiscoverInScopeLinksAuto automatically detects if a page requires JavaScript and uses the appropriate crawler.
@@ -254,3 +280,58 @@ func pageRequiresJavaScript(ctx context.Context, targetURL string) (bool, error)
 	// If we find SPA indicators and very few navigation links, or it's a minimal shell, it's likely a SPA
 	return (spaCount >= 2 && navigationLinks < 5) || isMinimalShell, nil
 }
+
+// newBrowser starts a headless Chrome that sends headers on every request and
+// routes traffic through proxy when set. The cancel func shuts the browser down.
+func newBrowser(ctx context.Context, headers http.Header, proxy *url.URL, extra ...chromedp.ExecAllocatorOption) (context.Context, context.CancelFunc, error) {
+	allocOpts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.Flag("headless", true),
+		chromedp.Flag("disable-gpu", true),
+		chromedp.Flag("no-sandbox", true),
+		chromedp.Flag("disable-dev-shm-usage", true),
+	)
+	allocOpts = append(allocOpts, extra...)
+	if ua := headers.Get("User-Agent"); ua != "" {
+		allocOpts = append(allocOpts, chromedp.UserAgent(ua))
+	}
+	if proxy != nil {
+		// Chrome cannot take proxy credentials on the command line; refuse
+		// rather than let the browser bypass the proxy.
+		if proxy.User != nil {
+			return nil, nil, fmt.Errorf("headless browser does not support authenticated proxies")
+		}
+		// Chrome always resolves names through SOCKS5 and has no socks5h scheme.
+		server := *proxy
+		if server.Scheme == "socks5h" {
+			server.Scheme = "socks5"
+		}
+		allocOpts = append(allocOpts, chromedp.ProxyServer(server.String()))
+	}
+	allocCtx, allocCancel := chromedp.NewExecAllocator(ctx, allocOpts...)
+	browserCtx, browserCancel := chromedp.NewContext(allocCtx)
+	cancel := func() {
+		browserCancel()
+		allocCancel()
+	}
+
+	if extra := browserHeaders(headers); len(extra) > 0 {
+		if err := chromedp.Run(browserCtx, network.Enable(), network.SetExtraHTTPHeaders(extra)); err != nil {
+			cancel()
+			return nil, nil, fmt.Errorf("set browser headers: %w", err)
+		}
+	}
+	return browserCtx, cancel, nil
+}
+
+// browserHeaders converts h to DevTools extra headers. User-Agent is applied
+// through the allocator instead.
+func browserHeaders(h http.Header) network.Headers {
+	extra := make(network.Headers, len(h))
+	for name, values := range h {
+		if name == "User-Agent" || len(values) == 0 {
+			continue
+		}
+		extra[name] = strings.Join(values, ", ")
+	}
+	return extra
+}
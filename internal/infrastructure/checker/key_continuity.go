@@ -0,0 +1,173 @@
+package checker
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// PublicKeyHash returns the certificate's SubjectPublicKeyInfo SHA-256 in the
+// "sha256/<base64>" form used by HPKP and most pinning libraries. It stays
+// the same when a certificate is renewed with the same key.
+func PublicKeyHash(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return "sha256/" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// KeyPin is the public key last seen on a TLS endpoint.
+type KeyPin struct {
+	Hash      string    `json:"hash"`
+	Subject   string    `json:"subject,omitempty"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// KeyChange records an endpoint presenting a different public key than the
+// previous run. Expected changes were allowlisted before they were seen.
+type KeyChange struct {
+	Endpoint     string    `json:"endpoint"`
+	Target       string    `json:"target"`
+	Previous     string    `json:"previous"`
+	Current      string    `json:"current"`
+	PreviousSeen time.Time `json:"previous_seen"`
+	DetectedAt   time.Time `json:"detected_at"`
+	Expected     bool      `json:"expected"`
+}
+
+// KeyPinStore tracks public-key continuity per TLS endpoint (host:port)
+// across runs. The first key seen is trusted; later changes are recorded
+// unless the new key was allowlisted for a planned rotation.
+type KeyPinStore struct {
+	Pins    map[string]KeyPin   `json:"pins"`
+	Allowed map[string][]string `json:"allowed,omitempty"` // Endpoint -> hashes expected to replace the pin
+	Changes []KeyChange         `json:"changes,omitempty"`
+}
+
+// NewKeyPinStore returns an empty store.
+func NewKeyPinStore() *KeyPinStore {
+	return &KeyPinStore{Pins: make(map[string]KeyPin)}
+}
+
+// Observe records hash for target's endpoint and returns the change when it
+// differs from the pinned key. The new key becomes the pin either way, so a
+// rotation is reported once. An allowlisted hash is consumed when seen.
+func (s *KeyPinStore) Observe(target, hash, subject string, at time.Time) *KeyChange {
+	if hash == "" {
+		return nil
+	}
+	if s.Pins == nil {
+		s.Pins = make(map[string]KeyPin)
+	}
+	_, endpoint := TLSAddress(target)
+
+	pin, ok := s.Pins[endpoint]
+	if !ok {
+		s.Pins[endpoint] = KeyPin{Hash: hash, Subject: subject, FirstSeen: at, LastSeen: at}
+		return nil
+	}
+	if pin.Hash == hash {
+		pin.LastSeen = at
+		s.Pins[endpoint] = pin
+		return nil
+	}
+
+	change := KeyChange{
+		Endpoint:     endpoint,
+		Target:       target,
+		Previous:     pin.Hash,
+		Current:      hash,
+		PreviousSeen: pin.LastSeen,
+		DetectedAt:   at,
+	}
+	if allowed := s.Allowed[endpoint]; slices.Contains(allowed, hash) {
+		change.Expected = true
+		s.Allowed[endpoint] = slices.DeleteFunc(allowed, func(h string) bool { return h == hash })
+		if len(s.Allowed[endpoint]) == 0 {
+			delete(s.Allowed, endpoint)
+		}
+	}
+	s.Pins[endpoint] = KeyPin{Hash: hash, Subject: subject, FirstSeen: at, LastSeen: at}
+	s.Changes = append(s.Changes, change)
+	return &change
+}
+
+// Allow lists hash as an expected replacement key for target's endpoint.
+func (s *KeyPinStore) Allow(target, hash string) string {
+	_, endpoint := TLSAddress(target)
+	if s.Allowed == nil {
+		s.Allowed = make(map[string][]string)
+	}
+	if !slices.Contains(s.Allowed[endpoint], hash) {
+		s.Allowed[endpoint] = append(s.Allowed[endpoint], hash)
+	}
+	return endpoint
+}
+
+// Reset forgets the pin, allowlist, and change history for target's
+// endpoint, so the next run trusts whatever key it sees.
+func (s *KeyPinStore) Reset(target string) string {
+	_, endpoint := TLSAddress(target)
+	delete(s.Pins, endpoint)
+	delete(s.Allowed, endpoint)
+	s.Changes = slices.DeleteFunc(s.Changes, func(c KeyChange) bool { return c.Endpoint == endpoint })
+	return endpoint
+}
+
+// Vulnerability converts the change into a report finding. Unexpected
+// changes are high severity: they can indicate interception or an
+// unannounced key rotation.
+func (c KeyChange) Vulnerability() Vulnerability {
+	description := fmt.Sprintf("%s presented public key %s on %s; the key last seen on %s was %s.",
+		c.Endpoint, c.Current, c.DetectedAt.Format(time.RFC3339), c.PreviousSeen.Format(time.RFC3339), c.Previous)
+
+	if c.Expected {
+		return Vulnerability{
+			Name:           "TLS Key Rotation (expected)",
+			Category:       "Transport Layer Security (TLS)",
+			Severity:       "Info",
+			Status:         "Info",
+			Description:    description + " The new key was allowlisted as a planned rotation.",
+			Recommendation: "No action required.",
+			AffectedURLs:   []string{c.Target},
+		}
+	}
+
+	return Vulnerability{
+		Name:        "TLS Public Key Changed Unexpectedly",
+		Category:    "Transport Layer Security (TLS)",
+		Severity:    "High",
+		Score:       0,
+		MaxScore:    10,
+		Status:      "Failed",
+		Description: description + " A key change that was not announced may mean traffic is being intercepted (MITM), or that the certificate was re-issued outside the planned rotation process.",
+		Recommendation: `HIGH: Confirm the key change with the system owner.
+
+1. Ask the owner whether the certificate was re-keyed and compare the new key hash with the one they deployed.
+2. Check Certificate Transparency logs for certificates issued for the host.
+3. If the change was planned, allowlist the new key before the next rotation:
+   seca engagement pins allow --id <engagement> --target <host> --hash <sha256/...>
+4. If the change was not planned, treat the endpoint as compromised until proven otherwise.`,
+		CVSS: &CVSSScore{
+			BaseScore: 7.4,
+			Severity:  "HIGH",
+			Vector:    "CVSS:3.1/AV:N/AC:H/PR:N/UI:N/S:U/C:H/I:H/A:N",
+			Version:   "3.1",
+		},
+		AffectedURLs: []string{c.Target},
+		References: []string{
+			"https://owasp.org/www-community/controls/Certificate_and_Public_Key_Pinning",
+		},
+	}
+}
+
+// KeyChangeVulnerabilities converts recorded key changes into findings.
+func KeyChangeVulnerabilities(changes []KeyChange) []Vulnerability {
+	vulns := make([]Vulnerability, 0, len(changes))
+	for _, c := range changes {
+		vulns = append(vulns, c.Vulnerability())
+	}
+	return vulns
+}
@@ -0,0 +1,78 @@
+package checker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPublicKeyHash(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	hash := PublicKeyHash(server.Certificate())
+	if !strings.HasPrefix(hash, "sha256/") || len(hash) != len("sha256/")+44 {
+		t.Fatalf("unexpected hash format %q", hash)
+	}
+	if info := analyzeCertificate(server.Certificate()); info.PublicKeySHA256 != hash {
+		t.Fatalf("expected certificate info to carry %s, got %s", hash, info.PublicKeySHA256)
+	}
+}
+
+func TestKeyPinStoreObserve(t *testing.T) {
+	day := func(n int) time.Time { return time.Date(2025, 1, n, 0, 0, 0, 0, time.UTC) }
+	store := NewKeyPinStore()
+
+	if change := store.Observe("https://app.example.com/login", "sha256/A", "CN=app", day(1)); change != nil {
+		t.Fatalf("first key should be trusted, got %+v", change)
+	}
+	if change := store.Observe("app.example.com", "sha256/A", "CN=app", day(2)); change != nil {
+		t.Fatalf("unchanged key should not be reported, got %+v", change)
+	}
+	if pin := store.Pins["app.example.com:443"]; !pin.FirstSeen.Equal(day(1)) || !pin.LastSeen.Equal(day(2)) {
+		t.Fatalf("unexpected pin %+v", pin)
+	}
+
+	change := store.Observe("https://app.example.com", "sha256/B", "CN=app", day(3))
+	if change == nil || change.Expected || change.Previous != "sha256/A" || change.Current != "sha256/B" {
+		t.Fatalf("expected unexpected change A->B, got %+v", change)
+	}
+	if !change.PreviousSeen.Equal(day(2)) {
+		t.Fatalf("expected previous key last seen on day 2, got %s", change.PreviousSeen)
+	}
+	if change := store.Observe("app.example.com", "sha256/B", "CN=app", day(4)); change != nil {
+		t.Fatalf("a change should be reported once, got %+v", change)
+	}
+
+	store.Allow("app.example.com:443", "sha256/C")
+	change = store.Observe("app.example.com", "sha256/C", "CN=app", day(5))
+	if change == nil || !change.Expected {
+		t.Fatalf("expected allowlisted rotation, got %+v", change)
+	}
+	if len(store.Allowed) != 0 {
+		t.Fatalf("allowlisted hash should be consumed, got %v", store.Allowed)
+	}
+	if len(store.Changes) != 2 {
+		t.Fatalf("expected 2 recorded changes, got %d", len(store.Changes))
+	}
+
+	if endpoint := store.Reset("https://app.example.com"); endpoint != "app.example.com:443" {
+		t.Fatalf("unexpected endpoint %s", endpoint)
+	}
+	if len(store.Pins) != 0 || len(store.Changes) != 0 {
+		t.Fatalf("expected reset to clear the endpoint, got %+v", store)
+	}
+}
+
+func TestKeyChangeVulnerability(t *testing.T) {
+	unexpected := KeyChange{Endpoint: "app.example.com:443", Target: "https://app.example.com", Previous: "sha256/A", Current: "sha256/B"}
+	if v := unexpected.Vulnerability(); v.Severity != "High" || v.Status != "Failed" {
+		t.Fatalf("unexpected change should be a high finding, got %s/%s", v.Severity, v.Status)
+	}
+	unexpected.Expected = true
+	if v := unexpected.Vulnerability(); v.Severity != "Info" {
+		t.Fatalf("expected rotation should be informational, got %s", v.Severity)
+	}
+}
@@ -0,0 +1,158 @@
+package checker
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Login form checks.
+const (
+	LoginCheckTransport    = "transport"
+	LoginCheckFormAction   = "form_action"
+	LoginCheckMethod       = "method"
+	LoginCheckAutocomplete = "autocomplete"
+	LoginCheckCSP          = "csp"
+	LoginCheckFraming      = "framing"
+)
+
+// LoginFormAssessment is the result of checking one password form found
+// while crawling.
+type LoginFormAssessment struct {
+	Page   string `json:"page"`
+	Action string `json:"action"`
+	Method string `json:"method"`
+	// HeadersChecked is false when the page's response headers were not
+	// checked (the page was crawled but not checked over HTTP), so the CSP
+	// and framing checks were skipped.
+	HeadersChecked bool         `json:"headers_checked"`
+	Issues         []LoginIssue `json:"issues,omitempty"`
+}
+
+// LoginIssue is one problem with a login form or the page serving it.
+type LoginIssue struct {
+	Check    string `json:"check"`
+	Severity string `json:"severity"` // "high", "medium", "low", or "info"
+	Detail   string `json:"detail"`
+}
+
+// passwordAutocompleteTokens are the autocomplete values that let password
+// managers fill and save a password field.
+var passwordAutocompleteTokens = map[string]bool{"current-password": true, "new-password": true}
+
+// AssessLoginForms checks the forms with password fields: the page and the
+// form action must use HTTPS, credentials must not be sent in the URL,
+// password fields should carry autocomplete hints for password managers,
+// and the page's CSP should restrict scripts and framing. headers returns
+// the checked security headers of a page, or nil when it was not checked.
+func AssessLoginForms(forms []FormInfo, headers func(page string) *SecurityHeadersResult) []LoginFormAssessment {
+	var assessments []LoginFormAssessment
+	for _, form := range forms {
+		if !form.HasPassword {
+			continue
+		}
+		a := LoginFormAssessment{Page: form.Page, Action: form.Action, Method: form.Method}
+		a.Issues = append(a.Issues, loginTransportIssues(form)...)
+		a.Issues = append(a.Issues, loginAutocompleteIssues(form.PasswordAutocomplete)...)
+		if headers != nil {
+			if sh := headers(form.Page); sh != nil {
+				a.HeadersChecked = true
+				a.Issues = append(a.Issues, loginHeaderIssues(sh)...)
+			}
+		}
+		assessments = append(assessments, a)
+	}
+	sort.SliceStable(assessments, func(i, j int) bool {
+		if assessments[i].Page != assessments[j].Page {
+			return assessments[i].Page < assessments[j].Page
+		}
+		return assessments[i].Action < assessments[j].Action
+	})
+	return assessments
+}
+
+func loginTransportIssues(form FormInfo) []LoginIssue {
+	var issues []LoginIssue
+	page, pageErr := url.Parse(form.Page)
+	if pageErr == nil && strings.EqualFold(page.Scheme, "http") {
+		issues = append(issues, LoginIssue{Check: LoginCheckTransport, Severity: "high",
+			Detail: "Login page is served over HTTP; the form can be altered in transit to send credentials elsewhere"})
+	}
+	if action, err := url.Parse(form.Action); err == nil {
+		switch {
+		case strings.EqualFold(action.Scheme, "http"):
+			issues = append(issues, LoginIssue{Check: LoginCheckFormAction, Severity: "high",
+				Detail: fmt.Sprintf("Form submits credentials over HTTP to %s", form.Action)})
+		case pageErr == nil && action.Host != "" && !strings.EqualFold(action.Host, page.Host):
+			issues = append(issues, LoginIssue{Check: LoginCheckFormAction, Severity: "info",
+				Detail: fmt.Sprintf("Form submits credentials to another host, %s; confirm it is the intended identity provider", action.Host)})
+		}
+	}
+	if form.Method == "GET" {
+		issues = append(issues, LoginIssue{Check: LoginCheckMethod, Severity: "medium",
+			Detail: "Form uses GET, so credentials are sent in the URL and end up in server logs, browser history, and Referer headers"})
+	}
+	return issues
+}
+
+func loginAutocompleteIssues(values []string) []LoginIssue {
+	var off, missing, unexpected int
+	for _, value := range values {
+		// The last token is the field name; earlier ones name a section
+		// ("section-login current-password").
+		fields := strings.Fields(value)
+		token := ""
+		if len(fields) > 0 {
+			token = fields[len(fields)-1]
+		}
+		switch {
+		case token == "off" || token == "false":
+			off++
+		case token == "":
+			missing++
+		case !passwordAutocompleteTokens[token]:
+			unexpected++
+		}
+	}
+	var issues []LoginIssue
+	if off > 0 {
+		issues = append(issues, LoginIssue{Check: LoginCheckAutocomplete, Severity: "low",
+			Detail: fmt.Sprintf("%d password field(s) set autocomplete=off, which discourages password managers and strong unique passwords (NIST SP 800-63B)", off)})
+	}
+	if missing > 0 {
+		issues = append(issues, LoginIssue{Check: LoginCheckAutocomplete, Severity: "info",
+			Detail: fmt.Sprintf("%d password field(s) have no autocomplete hint; use current-password or new-password", missing)})
+	}
+	if unexpected > 0 {
+		issues = append(issues, LoginIssue{Check: LoginCheckAutocomplete, Severity: "info",
+			Detail: fmt.Sprintf("%d password field(s) use an autocomplete value other than current-password or new-password", unexpected)})
+	}
+	return issues
+}
+
+func loginHeaderIssues(sh *SecurityHeadersResult) []LoginIssue {
+	var issues []LoginIssue
+	csp := sh.Headers["Content-Security-Policy"]
+	var policy *CSPPolicy
+	if csp.Present {
+		policy = ParseCSP(csp.Value)
+	}
+	if policy == nil {
+		issues = append(issues, LoginIssue{Check: LoginCheckCSP, Severity: "medium",
+			Detail: "No Content-Security-Policy; a script injected into the login page can read the credentials as they are typed"})
+	} else {
+		for _, f := range sh.CSPFindings {
+			if f.Severity == CSPSeverityHigh {
+				issues = append(issues, LoginIssue{Check: LoginCheckCSP, Severity: "medium",
+					Detail: "Content-Security-Policy does not stop injected scripts: " + f.String()})
+				break
+			}
+		}
+	}
+	if (policy == nil || !policy.Has("frame-ancestors")) && !sh.Headers["X-Frame-Options"].Present {
+		issues = append(issues, LoginIssue{Check: LoginCheckFraming, Severity: "medium",
+			Detail: "Page can be framed by other sites (no CSP frame-ancestors or X-Frame-Options), allowing clickjacking of the login form"})
+	}
+	return issues
+}
@@ -0,0 +1,80 @@
+package checker
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func loginIssueChecks(a LoginFormAssessment) string {
+	checks := make([]string, 0, len(a.Issues))
+	for _, issue := range a.Issues {
+		checks = append(checks, issue.Severity+":"+issue.Check)
+	}
+	return strings.Join(checks, ",")
+}
+
+func TestAssessLoginForms(t *testing.T) {
+	page, _ := url.Parse("https://app.example.com/login")
+	inv := NewInventory()
+	inv.AddPage(page, []byte(`
+<form action="http://app.example.com/session" method="get" autocomplete="off">
+  <input type="text" name="user">
+  <input type="password" name="pass">
+</form>
+<form action="/session" method="post">
+  <input type="password" name="password" autocomplete="current-password">
+</form>
+<form action="/search"><input name="q"></form>`))
+	forms := inv.Snapshot().Forms
+	secure := forms[2:] // Sorted by action: http://…/session, /search, /session
+
+	wellProtected := &SecurityHeadersResult{Headers: map[string]HeaderStatus{
+		"Content-Security-Policy": {Present: true, Value: "script-src 'self'; frame-ancestors 'none'"},
+	}}
+	assessments := AssessLoginForms(forms, func(page string) *SecurityHeadersResult { return wellProtected })
+	if len(assessments) != 2 {
+		t.Fatalf("expected only the password forms, got %+v", assessments)
+	}
+	// Sorted by action: the http:// form first.
+	if got := loginIssueChecks(assessments[0]); got != "high:form_action,medium:method,low:autocomplete" {
+		t.Errorf("insecure form: got issues %s", got)
+	}
+	if got := loginIssueChecks(assessments[1]); got != "" || !assessments[1].HeadersChecked {
+		t.Errorf("secure form: got issues %s (headers checked %v)", got, assessments[1].HeadersChecked)
+	}
+
+	unprotected := &SecurityHeadersResult{Headers: map[string]HeaderStatus{}}
+	assessments = AssessLoginForms(secure, func(page string) *SecurityHeadersResult { return unprotected })
+	if got := loginIssueChecks(assessments[0]); got != "medium:csp,medium:framing" {
+		t.Errorf("page without CSP: got issues %s", got)
+	}
+
+	weak := &SecurityHeadersResult{
+		Headers:     map[string]HeaderStatus{"Content-Security-Policy": {Present: true, Value: "script-src 'unsafe-inline'"}, "X-Frame-Options": {Present: true}},
+		CSPFindings: []CSPFinding{{Directive: "script-src", Severity: CSPSeverityHigh, Description: "'unsafe-inline' allows inline scripts"}},
+	}
+	assessments = AssessLoginForms(secure, func(page string) *SecurityHeadersResult { return weak })
+	if got := loginIssueChecks(assessments[0]); got != "medium:csp" {
+		t.Errorf("page with a weak CSP: got issues %s", got)
+	}
+
+	assessments = AssessLoginForms(secure, func(page string) *SecurityHeadersResult { return nil })
+	if assessments[0].HeadersChecked {
+		t.Error("expected unchecked page headers to be reported as such")
+	}
+}
+
+func TestAssessLoginFormsTransport(t *testing.T) {
+	forms := []FormInfo{
+		{Page: "http://app.example.com/login", Action: "http://app.example.com/login", Method: "POST", HasPassword: true, PasswordAutocomplete: []string{"section-a current-password"}},
+		{Page: "https://app.example.com/login", Action: "https://idp.example.net/authorize", Method: "POST", HasPassword: true, PasswordAutocomplete: []string{"password"}},
+	}
+	assessments := AssessLoginForms(forms, nil)
+	if got := loginIssueChecks(assessments[0]); got != "high:transport,high:form_action" {
+		t.Errorf("http login page: got issues %s", got)
+	}
+	if got := loginIssueChecks(assessments[1]); got != "info:form_action,info:autocomplete" {
+		t.Errorf("cross-host form: got issues %s", got)
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -15,6 +18,11 @@ type NetworkSecurityResult struct {
 	OpenPorts         []PortInfo       `json:"open_ports,omitempty"`
 	SubdomainTakeover *SubdomainCheck  `json:"subdomain_takeover,omitempty"`
 	PortScanDuration  float64          `json:"port_scan_duration_ms,omitempty"`
+	PortScanReused    bool             `json:"port_scan_reused,omitempty"` // Open ports copied from an earlier target on the same host
+	Exposures         []ExposureFinding `json:"exposures,omitempty"`
+	AdminPanels       []AdminPanelFinding `json:"admin_panels,omitempty"`
+	ServiceCVEs       []ServiceCVE     `json:"service_cves,omitempty"`
+	Path              *NetworkPath     `json:"path,omitempty"`
 	Issues            []string         `json:"issues,omitempty"`
 	Recommendations   []string         `json:"recommendations,omitempty"`
 }
@@ -50,6 +58,25 @@ type NetworkChecker struct {
 	EnablePortScan  bool
 	CommonPorts     []int  // Ports to scan (e.g., [80, 443, 22, 21, 25, 3306, 5432])
 	MaxPortWorkers  int    // Concurrent port scans
+	Decorate        RequestDecorator // Applied to fingerprint HTTP requests
+	Proxy           *url.URL         // Routes fingerprint HTTP requests; port scans always connect directly
+	HAR             *HARRecorder     // Records fingerprint HTTP requests
+	Budget          *Budget          // Counts fingerprint HTTP requests against the run budget
+	Throttle        *Throttle        // Backs off from hosts that rate limit or block fingerprint requests
+	Exposure        *ExposureProbe   // Probes for directory listings and exposed files; nil disables
+	AdminPanels     *ExposureProbe   // Probes for admin interfaces and default pages; nil disables
+	Pacing          *ScanPacing      // Per-host port scan limits shared across targets; nil disables
+	Traceroute      *Tracer          // Records the network path to each target; nil disables
+
+	scanMu sync.Mutex
+	scans  map[string]*portScan // Keyed by host and port list
+}
+
+// portScan is one host's port scan, shared by every target on the host.
+// done is closed when ports is set; failed scans are dropped from the map.
+type portScan struct {
+	done  chan struct{}
+	ports []PortInfo
 }
 
 // Check performs network security checks on the target
@@ -85,13 +112,21 @@ func (n *NetworkChecker) Check(ctx context.Context, target string) CheckResult {
 	// 2. Perform port scan if enabled
 	if n.EnablePortScan {
 		startTime := time.Now()
-		openPorts := n.scanPorts(ctx, host)
+		openPorts, reused := n.scanHost(ctx, host)
 		netSec.PortScanDuration = time.Since(startTime).Seconds() * 1000
+		netSec.PortScanReused = reused
 		netSec.OpenPorts = openPorts
 
 		// Analyze port risks
 		n.analyzePortRisks(netSec)
 
+		// Correlate banner versions with known CVEs
+		netSec.ServiceCVEs = CorrelateServiceCVEs(openPorts)
+		for _, cve := range netSec.ServiceCVEs {
+			netSec.Issues = append(netSec.Issues, fmt.Sprintf("%s %s on port %d is affected by %s (CVSS %.1f)",
+				cve.Product, cve.Version, cve.Port, cve.CVE, cve.CVSS))
+		}
+
 		if len(openPorts) > 0 {
 			if result.Notes != "" {
 				result.Notes += "; "
@@ -100,6 +135,51 @@ func (n *NetworkChecker) Check(ctx context.Context, target string) CheckResult {
 		}
 	}
 
+	probeClient := &http.Client{
+		Timeout:   n.Timeout,
+		Transport: WithDecorator(n.Throttle.Wrap(n.Budget.Wrap(n.HAR.Wrap(n.fingerprintTransport()))), n.Decorate),
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	// 3. Identify exposed admin interfaces (status and fingerprint only)
+	if n.AdminPanels != nil {
+		netSec.AdminPanels = n.AdminPanels.ProbeAdminPanels(ctx, probeClient, target)
+		for _, panel := range netSec.AdminPanels {
+			netSec.Issues = append(netSec.Issues, fmt.Sprintf("Admin interface exposed: %s at %s (HTTP %d)", panel.Provider, panel.URL, panel.HTTPStatusCode))
+		}
+		if len(netSec.AdminPanels) > 0 {
+			if result.Notes != "" {
+				result.Notes += "; "
+			}
+			result.Notes += fmt.Sprintf("%d admin interface(s) exposed", len(netSec.AdminPanels))
+		}
+	}
+
+	// 4. Probe for exposed directory listings, VCS/env files and backups
+	if n.Exposure != nil {
+		netSec.Exposures = n.Exposure.Probe(ctx, probeClient, target)
+		for _, exp := range netSec.Exposures {
+			netSec.Issues = append(netSec.Issues, fmt.Sprintf("Exposed %s: %s", strings.ReplaceAll(exp.Type, "_", " "), exp.URL))
+		}
+		if len(netSec.Exposures) > 0 {
+			if result.Notes != "" {
+				result.Notes += "; "
+			}
+			result.Notes += fmt.Sprintf("%d exposed file(s)/listing(s) found", len(netSec.Exposures))
+		}
+	}
+
+	// 5. Record the network path used to reach the target
+	if n.Traceroute != nil {
+		netSec.Path = n.Traceroute.Trace(ctx, host)
+		if result.Notes != "" {
+			result.Notes += "; "
+		}
+		result.Notes += "path " + netSec.Path.Summary()
+	}
+
 	result.NetworkSecurity = netSec
 	return result
 }
@@ -195,7 +275,8 @@ func (n *NetworkChecker) checkHTTPFingerprints(ctx context.Context, host, cname,
 		url := fmt.Sprintf("%s://%s", scheme, host)
 
 		client := &http.Client{
-			Timeout: n.Timeout,
+			Timeout:   n.Timeout,
+			Transport: WithDecorator(n.Throttle.Wrap(n.Budget.Wrap(n.HAR.Wrap(n.fingerprintTransport()))), n.Decorate),
 			CheckRedirect: func(req *http.Request, via []*http.Request) error {
 				return http.ErrUseLastResponse // Don't follow redirects
 			},
@@ -359,32 +440,37 @@ func detectProvider(cname string) string {
 	return "Unknown"
 }
 
+// Ports returns the ports a scan will probe: CommonPorts, or the built-in
+// set when none are configured.
+func (n *NetworkChecker) Ports() []int {
+	if len(n.CommonPorts) > 0 {
+		return n.CommonPorts
+	}
+	return []int{
+		21,   // FTP
+		22,   // SSH
+		23,   // Telnet
+		25,   // SMTP
+		53,   // DNS
+		80,   // HTTP
+		110,  // POP3
+		143,  // IMAP
+		443,  // HTTPS
+		445,  // SMB
+		3306, // MySQL
+		3389, // RDP
+		5432, // PostgreSQL
+		5900, // VNC
+		6379, // Redis
+		8080, // HTTP Alt
+		8443, // HTTPS Alt
+		27017, // MongoDB
+	}
+}
+
 // scanPorts performs a port scan on common ports
 func (n *NetworkChecker) scanPorts(ctx context.Context, host string) []PortInfo {
-	// Use default common ports if not specified
-	ports := n.CommonPorts
-	if len(ports) == 0 {
-		ports = []int{
-			21,   // FTP
-			22,   // SSH
-			23,   // Telnet
-			25,   // SMTP
-			53,   // DNS
-			80,   // HTTP
-			110,  // POP3
-			143,  // IMAP
-			443,  // HTTPS
-			445,  // SMB
-			3306, // MySQL
-			3389, // RDP
-			5432, // PostgreSQL
-			5900, // VNC
-			6379, // Redis
-			8080, // HTTP Alt
-			8443, // HTTPS Alt
-			27017, // MongoDB
-		}
-	}
+	ports := n.Pacing.order(n.portsFor(ctx))
 
 	maxWorkers := n.MaxPortWorkers
 	if maxWorkers == 0 {
@@ -402,7 +488,17 @@ func (n *NetworkChecker) scanPorts(ctx context.Context, host string) []PortInfo
 		go func() {
 			defer wg.Done()
 			for port := range portChan {
-				if portInfo := n.checkPort(ctx, host, port); portInfo != nil {
+				// Leave queued ports unscanned once the run is canceled.
+				if ctx.Err() != nil {
+					return
+				}
+				release, ok := n.Pacing.acquire(ctx, host)
+				if !ok {
+					continue
+				}
+				portInfo := n.checkPort(ctx, host, port)
+				release()
+				if portInfo != nil {
 					resultChan <- portInfo
 				}
 			}
@@ -411,6 +507,7 @@ func (n *NetworkChecker) scanPorts(ctx context.Context, host string) []PortInfo
 
 	// Send ports to workers
 	go func() {
+		defer close(portChan)
 		for _, port := range ports {
 			select {
 			case portChan <- port:
@@ -418,7 +515,6 @@ func (n *NetworkChecker) scanPorts(ctx context.Context, host string) []PortInfo
 				return
 			}
 		}
-		close(portChan)
 	}()
 
 	// Wait for workers and close result channel
@@ -432,10 +528,67 @@ func (n *NetworkChecker) scanPorts(ctx context.Context, host string) []PortInfo
 	for portInfo := range resultChan {
 		openPorts = append(openPorts, *portInfo)
 	}
+	sort.Slice(openPorts, func(i, j int) bool { return openPorts[i].Port < openPorts[j].Port })
 
 	return openPorts
 }
 
+// portsFor returns the ports to scan for the target in ctx.
+func (n *NetworkChecker) portsFor(ctx context.Context) []int {
+	if o, ok := TargetOverrideFrom(ctx); ok && len(o.Ports) > 0 {
+		return o.Ports
+	}
+	return n.Ports()
+}
+
+// scanHost scans host's ports once per checker: crawled pages on a host
+// all get the open ports found for the first of them, and reused reports
+// that this target did not scan. Targets arriving while the scan runs wait
+// for it. A scan cut short by cancellation is not kept. Each caller gets
+// its own copy, as analyzePortRisks fills in descriptions.
+func (n *NetworkChecker) scanHost(ctx context.Context, host string) (openPorts []PortInfo, reused bool) {
+	ports := append([]int(nil), n.portsFor(ctx)...)
+	sort.Ints(ports)
+	key := strings.ToLower(host) + " " + fmt.Sprint(ports)
+
+	for {
+		n.scanMu.Lock()
+		scan, ok := n.scans[key]
+		if !ok {
+			scan = &portScan{done: make(chan struct{})}
+			if n.scans == nil {
+				n.scans = make(map[string]*portScan)
+			}
+			n.scans[key] = scan
+		}
+		n.scanMu.Unlock()
+
+		if !ok {
+			scan.ports = n.scanPorts(ctx, host)
+			if ctx.Err() != nil {
+				n.scanMu.Lock()
+				delete(n.scans, key)
+				n.scanMu.Unlock()
+			}
+			close(scan.done)
+			return append([]PortInfo{}, scan.ports...), false
+		}
+
+		select {
+		case <-scan.done:
+		case <-ctx.Done():
+			return []PortInfo{}, false
+		}
+		n.scanMu.Lock()
+		kept := n.scans[key] == scan
+		n.scanMu.Unlock()
+		if kept {
+			return append([]PortInfo{}, scan.ports...), true
+		}
+		// The scan was canceled under another target; run our own.
+	}
+}
+
 // checkPort checks if a specific port is open
 func (n *NetworkChecker) checkPort(ctx context.Context, host string, port int) *PortInfo {
 	timeout := n.PortScanTimeout
@@ -443,15 +596,20 @@ func (n *NetworkChecker) checkPort(ctx context.Context, host string, port int) *
 		timeout = 2 * time.Second // Default port scan timeout
 	}
 
-	address := fmt.Sprintf("%s:%d", host, port)
+	address := net.JoinHostPort(host, strconv.Itoa(port))
 
 	// Use context with timeout
-	conn, err := net.DialTimeout("tcp", address, timeout)
+	dialer := &net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
 	if err != nil {
 		// Port is closed or filtered
 		return nil
 	}
 	defer conn.Close()
+	// Banner reads only have their own deadlines; closing the connection
+	// on cancellation ends them early.
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
 
 	// Port is open
 	portInfo := &PortInfo{
@@ -462,6 +620,12 @@ func (n *NetworkChecker) checkPort(ctx context.Context, host string, port int) *
 		Risk:     getPortRisk(port),
 	}
 
+	// HTTP servers speak second: use the Server header as the banner
+	if useTLS, ok := httpBannerPorts[port]; ok {
+		portInfo.Banner = grabHTTPServerHeader(conn, host, useTLS)
+		return portInfo
+	}
+
 	// Try to grab banner (with short timeout)
 	_ = conn.SetReadDeadline(time.Now().Add(1 * time.Second))
 	banner := make([]byte, 512)
@@ -581,3 +745,11 @@ func (n *NetworkChecker) analyzePortRisks(netSec *NetworkSecurityResult) {
 func (n *NetworkChecker) Name() string {
 	return "check network"
 }
+
+// fingerprintTransport returns nil (the default transport) unless a proxy is set.
+func (n *NetworkChecker) fingerprintTransport() http.RoundTripper {
+	if n.Proxy == nil {
+		return nil
+	}
+	return NewTransport(n.Proxy)
+}
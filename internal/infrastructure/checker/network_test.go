@@ -6,6 +6,8 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -361,6 +363,41 @@ func TestCheckPort_Open(t *testing.T) {
 	}
 }
 
+func TestScanPorts_StopsOnCancel(t *testing.T) {
+	// A listener that accepts but never speaks keeps banner grabs waiting.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to create test server: %v", err)
+	}
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	ports := make([]int, 50)
+	for i := range ports {
+		ports[i] = port
+	}
+	checker := &NetworkChecker{PortScanTimeout: 2 * time.Second, CommonPorts: ports, MaxPortWorkers: 2}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	checker.scanPorts(ctx, "127.0.0.1")
+
+	// Uncanceled, 50 one-second banner reads on 2 workers take 25s.
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("scanPorts kept scanning %s after cancellation", elapsed)
+	}
+}
+
 func TestScanPorts(t *testing.T) {
 	// Create multiple test TCP servers
 	listener1, err := net.Listen("tcp", "127.0.0.1:0")
@@ -426,6 +463,60 @@ func TestScanPorts(t *testing.T) {
 	}
 }
 
+func TestScanHost_ReusesScanPerHost(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to create test server: %v", err)
+	}
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	var accepted atomic.Int32
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			accepted.Add(1)
+			conn.Close()
+		}
+	}()
+
+	checker := &NetworkChecker{PortScanTimeout: 2 * time.Second, CommonPorts: []int{port}}
+
+	// Crawled pages on one host, some checked concurrently.
+	var wg sync.WaitGroup
+	var reusedCount atomic.Int32
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ports, reused := checker.scanHost(context.Background(), "127.0.0.1")
+			if len(ports) != 1 || ports[0].Port != port {
+				t.Errorf("expected port %d open, got %+v", port, ports)
+			}
+			if reused {
+				reusedCount.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := accepted.Load(); got != 1 {
+		t.Errorf("expected the host to be scanned once, got %d connections", got)
+	}
+	if got := reusedCount.Load(); got != 4 {
+		t.Errorf("expected 4 targets to reuse the scan, got %d", got)
+	}
+
+	// A per-target port override is a different scan.
+	ctx := WithTargetOverride(context.Background(), TargetOverride{Ports: []int{port, 54321}})
+	if _, reused := checker.scanHost(ctx, "127.0.0.1"); reused {
+		t.Error("a different port list should not reuse the scan")
+	}
+}
+
 func TestNetworkChecker_Check_Integration(t *testing.T) {
 	// Skip in short mode or if network is unavailable
 	if testing.Short() {
@@ -0,0 +1,124 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// PhaseTimeouts limits each phase of an HTTP request on its own, so a slow
+// body read does not use up the time a slow handshake needed. A zero phase
+// falls back to the checker's Timeout.
+type PhaseTimeouts struct {
+	Dial           time.Duration // TCP connect, proxy included
+	TLSHandshake   time.Duration
+	ResponseHeader time.Duration // request written to response headers received
+	BodyRead       time.Duration // response headers received to body fully read
+}
+
+// WithDefault returns p with its zero phases set to d.
+func (p PhaseTimeouts) WithDefault(d time.Duration) PhaseTimeouts {
+	for _, phase := range []*time.Duration{&p.Dial, &p.TLSHandshake, &p.ResponseHeader, &p.BodyRead} {
+		if *phase <= 0 {
+			*phase = d
+		}
+	}
+	return p
+}
+
+// Total is the longest a single request can take with every phase at its
+// limit.
+func (p PhaseTimeouts) Total() time.Duration {
+	return p.Dial + p.TLSHandshake + p.ResponseHeader + p.BodyRead
+}
+
+// CheckTimeout is the time one check with timeout as its request timeout
+// may take. With no phase set, timeout bounds the whole check. Otherwise the
+// check makes its own follow-up requests (robots.txt, favicon, probes), so it
+// gets timeout on top of one request with every phase at its limit.
+func (p PhaseTimeouts) CheckTimeout(timeout time.Duration) time.Duration {
+	if p == (PhaseTimeouts{}) {
+		return timeout
+	}
+	return p.WithDefault(timeout).Total() + timeout
+}
+
+// Apply sets the dial, TLS handshake, and response header timeouts on t and
+// returns t. Apply it after Dialer.Apply so pinned dials are limited too.
+func (p PhaseTimeouts) Apply(t *http.Transport) *http.Transport {
+	if p.Dial > 0 {
+		dial := t.DialContext
+		if dial == nil {
+			dial = (&net.Dialer{}).DialContext
+		}
+		limit := p.Dial
+		t.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			ctx, cancel := context.WithTimeout(ctx, limit)
+			defer cancel()
+			return dial(ctx, network, addr)
+		}
+	}
+	if p.TLSHandshake > 0 {
+		t.TLSHandshakeTimeout = p.TLSHandshake
+	}
+	if p.ResponseHeader > 0 {
+		t.ResponseHeaderTimeout = p.ResponseHeader
+	}
+	return t
+}
+
+// Wrap limits reading each response body to BodyRead from when the headers
+// arrived. It returns next unchanged when BodyRead is zero.
+func (p PhaseTimeouts) Wrap(next http.RoundTripper) http.RoundTripper {
+	if p.BodyRead <= 0 {
+		return next
+	}
+	return &bodyTimeoutTransport{next: next, limit: p.BodyRead}
+}
+
+type bodyTimeoutTransport struct {
+	next  http.RoundTripper
+	limit time.Duration
+}
+
+func (t *bodyTimeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.Body == nil || resp.Body == http.NoBody {
+		return resp, err
+	}
+	// The timer closes the original body rather than resp.Body, which is
+	// replaced below and may be wrapped again by the client.
+	orig := resp.Body
+	body := &deadlineBody{ReadCloser: orig, limit: t.limit}
+	body.timer = time.AfterFunc(t.limit, func() {
+		body.expired.Store(true)
+		orig.Close()
+	})
+	resp.Body = body
+	return resp, nil
+}
+
+// deadlineBody is a response body closed when its read deadline passes.
+type deadlineBody struct {
+	io.ReadCloser
+	limit   time.Duration
+	timer   *time.Timer
+	expired atomic.Bool
+}
+
+func (b *deadlineBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && err != io.EOF && b.expired.Load() {
+		err = fmt.Errorf("response body not read within %s: %w", b.limit, err)
+	}
+	return n, err
+}
+
+func (b *deadlineBody) Close() error {
+	b.timer.Stop()
+	return b.ReadCloser.Close()
+}
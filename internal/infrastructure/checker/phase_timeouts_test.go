@@ -0,0 +1,78 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPCheckerBodyReadTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead || r.URL.Path != "/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html>"))
+		w.(http.Flusher).Flush()
+		// The rest of the body never arrives within the body timeout.
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	h := &HTTPChecker{Timeout: 5 * time.Second, Phases: PhaseTimeouts{BodyRead: 100 * time.Millisecond}}
+	start := time.Now()
+	result := h.Check(context.Background(), server.URL+"/")
+	if result.Status != StatusWarning {
+		t.Fatalf("a slow body should leave the target checked with a warning: %+v", result)
+	}
+	if !strings.Contains(result.Notes, "response body not read within 100ms") {
+		t.Errorf("notes should record the body timeout, got %q", result.Notes)
+	}
+	if elapsed := time.Since(start); elapsed > 4*time.Second {
+		t.Errorf("check took %s; the body timeout should cut slow reads short", elapsed)
+	}
+}
+
+func TestHTTPCheckerResponseHeaderTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	h := &HTTPChecker{Timeout: 5 * time.Second, Phases: PhaseTimeouts{ResponseHeader: 100 * time.Millisecond}}
+	result := h.Check(context.Background(), server.URL)
+	if result.Status != "error" || !strings.Contains(result.Error, "timeout awaiting response headers") {
+		t.Errorf("expected a response header timeout, got %+v", result)
+	}
+}
+
+func TestPhaseTimeoutsWithDefault(t *testing.T) {
+	p := PhaseTimeouts{Dial: time.Second}.WithDefault(10 * time.Second)
+	if p.Dial != time.Second || p.TLSHandshake != 10*time.Second || p.ResponseHeader != 10*time.Second || p.BodyRead != 10*time.Second {
+		t.Errorf("unexpected phases: %+v", p)
+	}
+	if p.Total() != 31*time.Second {
+		t.Errorf("Total() = %s", p.Total())
+	}
+}
+
+func TestPhaseTimeoutsCheckTimeout(t *testing.T) {
+	if got := (PhaseTimeouts{}).CheckTimeout(10 * time.Second); got != 10*time.Second {
+		t.Errorf("CheckTimeout() = %s, want the timeout alone when no phase is set", got)
+	}
+	if got := (PhaseTimeouts{BodyRead: time.Minute}).CheckTimeout(10 * time.Second); got != 100*time.Second {
+		t.Errorf("CheckTimeout() = %s, want every phase plus the timeout", got)
+	}
+}
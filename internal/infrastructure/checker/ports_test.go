@@ -0,0 +1,50 @@
+package checker
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParsePortSpec(t *testing.T) {
+	ports, err := ParsePortSpec("443, 8000-8003,22,443")
+	if err != nil || !slices.Equal(ports, []int{22, 443, 8000, 8001, 8002, 8003}) {
+		t.Fatalf("ParsePortSpec = %v, %v", ports, err)
+	}
+
+	for preset, want := range map[string]int{"top100": 100, "TOP1000": 1000} {
+		ports, err := ParsePortSpec(preset)
+		if err != nil || len(ports) != want {
+			t.Errorf("%s expanded to %d ports (err %v), want %d", preset, len(ports), err, want)
+		}
+	}
+
+	ports, err = ParsePortSpec("top100,1-1024")
+	if err != nil || len(ports) != 1024+len(slices.DeleteFunc(mustParsePortSpec(t, "top100"), func(p int) bool { return p <= 1024 })) {
+		t.Fatalf("mixed spec should merge and de-duplicate, got %d ports (err %v)", len(ports), err)
+	}
+
+	for _, bad := range []string{"", "http", "0", "70000", "100-10", "1-", "top5"} {
+		if _, err := ParsePortSpec(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func mustParsePortSpec(t *testing.T, spec string) []int {
+	t.Helper()
+	ports, err := ParsePortSpec(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ports
+}
+
+func TestNetworkChecker_PortsDefault(t *testing.T) {
+	if got := len((&NetworkChecker{}).Ports()); got != 18 {
+		t.Fatalf("default port set has %d ports, want 18", got)
+	}
+	custom := []int{8080}
+	if got := (&NetworkChecker{CommonPorts: custom}).Ports(); !slices.Equal(got, custom) {
+		t.Fatalf("Ports() = %v, want %v", got, custom)
+	}
+}
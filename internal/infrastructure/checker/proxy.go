@@ -0,0 +1,62 @@
+package checker
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ParseProxyURL validates an upstream proxy URL. Supported schemes are http,
+// https, socks5, and socks5h; credentials may be given as user:password@.
+func ParseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q (expected http, https, socks5, or socks5h)", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("proxy URL %q has no host", u.Redacted())
+	}
+	if u.Path != "" && u.Path != "/" {
+		return nil, fmt.Errorf("proxy URL %q must not contain a path", u.Redacted())
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Path = ""
+	return u, nil
+}
+
+// NewTransport returns the transport used by checkers and the crawler: TLS 1.2+
+// with certificate verification, routed through proxy when it is non-nil.
+func NewTransport(proxy *url.URL) *http.Transport {
+	t := &http.Transport{
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: false,
+			MinVersion:         tls.VersionTLS12,
+		},
+	}
+	if proxy != nil {
+		t.Proxy = http.ProxyURL(proxy)
+	}
+	return t
+}
+
+// ProxyEnv returns the conventional proxy environment variables for proxy so
+// external plugins route their traffic the same way. It returns nil for nil.
+func ProxyEnv(proxy *url.URL) map[string]string {
+	if proxy == nil {
+		return nil
+	}
+	value := proxy.String()
+	env := map[string]string{"SECA_PROXY": value}
+	for _, name := range []string{"HTTP_PROXY", "HTTPS_PROXY", "ALL_PROXY"} {
+		env[name] = value
+		env[strings.ToLower(name)] = value
+	}
+	return env
+}
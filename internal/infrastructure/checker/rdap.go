@@ -0,0 +1,297 @@
+package checker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRDAPBootstrapURL is IANA's registry of RDAP servers per TLD.
+	DefaultRDAPBootstrapURL = "https://data.iana.org/rdap/dns.json"
+
+	defaultRDAPTimeout = 10 * time.Second
+	rdapMaxBodyBytes   = 1 << 20
+)
+
+var errRDAPNotFound = errors.New("domain not found in RDAP")
+
+// DomainRegistration is the registry data returned by RDAP.
+type DomainRegistration struct {
+	Domain        string     `json:"domain"`
+	Registrar     string     `json:"registrar,omitempty"`
+	RegistrantOrg string     `json:"registrant_org,omitempty"`
+	Created       *time.Time `json:"created,omitempty"`
+	Expires       *time.Time `json:"expires,omitempty"`
+	DaysLeft      int        `json:"days_left,omitempty"`
+	Status        []string   `json:"status,omitempty"`
+	Source        string     `json:"source"`
+	CheckedAt     time.Time  `json:"checked_at"`
+	// ExpiresDuringEngagement is set by the caller when the registration
+	// lapses before the engagement ends or shortly after.
+	ExpiresDuringEngagement bool `json:"expires_during_engagement,omitempty"`
+}
+
+// RDAPClient looks up domain registrations, finding each TLD's registry
+// through the IANA bootstrap file. It is safe for concurrent use.
+type RDAPClient struct {
+	BootstrapURL string        // Defaults to DefaultRDAPBootstrapURL
+	HTTPClient   *http.Client  // nil uses a client with Timeout
+	Timeout      time.Duration // Per-request timeout (default 10s)
+
+	mu        sync.Mutex
+	bootstrap map[string]string // TLD → RDAP base URL
+	cache     map[string]*DomainRegistration
+}
+
+// Lookup returns the registration covering name. Hosts and delegated subzones
+// are not registered themselves, so parent names are tried up to the TLD;
+// the first one the registry knows is the registered domain.
+func (c *RDAPClient) Lookup(ctx context.Context, name string) (*DomainRegistration, error) {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	c.mu.Lock()
+	cached, ok := c.cache[name]
+	c.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	bootstrap, err := c.loadBootstrap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	labels := strings.Split(name, ".")
+	tld := labels[len(labels)-1]
+	base, ok := bootstrap[tld]
+	if !ok {
+		return nil, fmt.Errorf("no RDAP service for .%s", tld)
+	}
+
+	for i := 0; i < len(labels)-1; i++ {
+		reg, err := c.fetch(ctx, base, strings.Join(labels[i:], "."))
+		if errors.Is(err, errRDAPNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		if c.cache == nil {
+			c.cache = make(map[string]*DomainRegistration)
+		}
+		c.cache[name] = reg
+		c.mu.Unlock()
+		return reg, nil
+	}
+	return nil, fmt.Errorf("%s: %w", name, errRDAPNotFound)
+}
+
+type rdapEntity struct {
+	Roles []string          `json:"roles"`
+	VCard []json.RawMessage `json:"vcardArray"`
+}
+
+type rdapDomain struct {
+	LDHName string   `json:"ldhName"`
+	Status  []string `json:"status"`
+	Events  []struct {
+		Action string `json:"eventAction"`
+		Date   string `json:"eventDate"`
+	} `json:"events"`
+	Entities []rdapEntity `json:"entities"`
+}
+
+func (c *RDAPClient) fetch(ctx context.Context, base, domain string) (*DomainRegistration, error) {
+	url := strings.TrimSuffix(base, "/") + "/domain/" + domain
+	body, status, err := c.get(ctx, url, "application/rdap+json")
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, errRDAPNotFound
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("%s returned HTTP %d", url, status)
+	}
+
+	var rd rdapDomain
+	if err := json.Unmarshal(body, &rd); err != nil {
+		return nil, fmt.Errorf("parse RDAP response: %w", err)
+	}
+
+	now := time.Now().UTC()
+	reg := &DomainRegistration{Domain: domain, Status: rd.Status, Source: url, CheckedAt: now}
+	if rd.LDHName != "" {
+		reg.Domain = strings.ToLower(rd.LDHName)
+	}
+	for _, ev := range rd.Events {
+		t, err := time.Parse(time.RFC3339, ev.Date)
+		if err != nil {
+			continue
+		}
+		t = t.UTC()
+		switch ev.Action {
+		case "registration":
+			reg.Created = &t
+		case "expiration":
+			reg.Expires = &t
+			reg.DaysLeft = int(t.Sub(now).Hours() / 24)
+		}
+	}
+	for _, entity := range rd.Entities {
+		switch {
+		case slices.Contains(entity.Roles, "registrar") && reg.Registrar == "":
+			reg.Registrar = vcardProperty(entity.VCard, "fn")
+		case slices.Contains(entity.Roles, "registrant") && reg.RegistrantOrg == "":
+			reg.RegistrantOrg = vcardProperty(entity.VCard, "org")
+			if reg.RegistrantOrg == "" && vcardProperty(entity.VCard, "kind") == "org" {
+				reg.RegistrantOrg = vcardProperty(entity.VCard, "fn")
+			}
+		}
+	}
+	return reg, nil
+}
+
+// vcardProperty returns the value of a jCard property
+// (["vcard", [["fn", {}, "text", "Name"], ...]]). Structured values such as
+// "org" are joined with "; ".
+func vcardProperty(vcard []json.RawMessage, property string) string {
+	if len(vcard) < 2 {
+		return ""
+	}
+	var props [][]json.RawMessage
+	if err := json.Unmarshal(vcard[1], &props); err != nil {
+		return ""
+	}
+	for _, prop := range props {
+		if len(prop) < 4 {
+			continue
+		}
+		var name string
+		if json.Unmarshal(prop[0], &name) != nil || name != property {
+			continue
+		}
+		var value string
+		if json.Unmarshal(prop[3], &value) == nil {
+			return value
+		}
+		var parts []string
+		if json.Unmarshal(prop[3], &parts) == nil {
+			return strings.Join(slices.DeleteFunc(parts, func(s string) bool { return s == "" }), "; ")
+		}
+	}
+	return ""
+}
+
+// loadBootstrap fetches the TLD → RDAP server map. A failed load is retried
+// on the next lookup.
+func (c *RDAPClient) loadBootstrap(ctx context.Context) (map[string]string, error) {
+	c.mu.Lock()
+	bootstrap := c.bootstrap
+	c.mu.Unlock()
+	if bootstrap != nil {
+		return bootstrap, nil
+	}
+
+	url := c.BootstrapURL
+	if url == "" {
+		url = DefaultRDAPBootstrapURL
+	}
+	body, status, err := c.get(ctx, url, "application/json")
+	if err != nil {
+		return nil, fmt.Errorf("load RDAP bootstrap: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("load RDAP bootstrap: HTTP %d", status)
+	}
+	var file struct {
+		Services [][][]string `json:"services"`
+	}
+	if err := json.Unmarshal(body, &file); err != nil {
+		return nil, fmt.Errorf("parse RDAP bootstrap: %w", err)
+	}
+
+	bootstrap = make(map[string]string)
+	for _, service := range file.Services {
+		if len(service) < 2 || len(service[1]) == 0 {
+			continue
+		}
+		base := service[1][0]
+		for _, u := range service[1] {
+			if strings.HasPrefix(u, "https://") {
+				base = u
+				break
+			}
+		}
+		for _, tld := range service[0] {
+			bootstrap[strings.ToLower(tld)] = base
+		}
+	}
+
+	c.mu.Lock()
+	c.bootstrap = bootstrap
+	c.mu.Unlock()
+	return bootstrap, nil
+}
+
+func (c *RDAPClient) get(ctx context.Context, url, accept string) ([]byte, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Accept", accept)
+
+	client := c.HTTPClient
+	if client == nil {
+		timeout := c.Timeout
+		if timeout <= 0 {
+			timeout = defaultRDAPTimeout
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, rdapMaxBodyBytes))
+	if err != nil {
+		return nil, 0, err
+	}
+	return body, resp.StatusCode, nil
+}
+
+// RegistrationVulnerabilities reports registrations flagged as expiring
+// during the engagement, or already expired.
+func RegistrationVulnerabilities(regs []DomainRegistration) []Vulnerability {
+	hygiene := &DomainHygieneResult{}
+	now := time.Now()
+	for _, reg := range regs {
+		if reg.Expires == nil {
+			continue
+		}
+		date := reg.Expires.Format("2006-01-02")
+		switch {
+		case reg.Expires.Before(now):
+			hygiene.Findings = append(hygiene.Findings, DomainHygieneFinding{
+				Type:     DomainHygieneExpired,
+				Severity: "high",
+				Detail:   fmt.Sprintf("%s registration expired on %s", reg.Domain, date),
+			})
+		case reg.ExpiresDuringEngagement:
+			hygiene.Findings = append(hygiene.Findings, DomainHygieneFinding{
+				Type:     DomainHygieneExpiring,
+				Severity: "medium",
+				Detail:   fmt.Sprintf("%s registration expires on %s, during or shortly after the engagement", reg.Domain, date),
+			})
+		}
+	}
+	return analyzeDomainHygiene(hygiene)
+}
@@ -0,0 +1,87 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRDAPClient_Lookup(t *testing.T) {
+	var bootstrapHits atomic.Int32
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/dns.json":
+			bootstrapHits.Add(1)
+			fmt.Fprintf(w, `{"services":[[["uk"],["%s/uk/"]]]}`, srv.URL)
+		case "/uk/domain/example.co.uk":
+			if r.Header.Get("Accept") != "application/rdap+json" {
+				t.Errorf("Accept = %q", r.Header.Get("Accept"))
+			}
+			fmt.Fprint(w, `{"ldhName":"example.co.uk",
+				"events":[{"eventAction":"registration","eventDate":"2004-03-01T10:00:00Z"},{"eventAction":"expiration","eventDate":"2027-03-01T10:00:00Z"}],
+				"entities":[
+					{"roles":["registrant"],"vcardArray":["vcard",[["version",{},"text","4.0"],["fn",{},"text","Jane Doe"],["org",{},"text",["Example Ltd",""]]]]},
+					{"roles":["registrar"],"vcardArray":["vcard",[["fn",{},"text","Example Registrar"]]]}
+				]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client := &RDAPClient{BootstrapURL: srv.URL + "/dns.json"}
+	reg, err := client.Lookup(context.Background(), "www.example.co.uk.")
+	if err != nil {
+		t.Fatalf("Lookup: %v", err)
+	}
+	if reg.Domain != "example.co.uk" || reg.Registrar != "Example Registrar" || reg.RegistrantOrg != "Example Ltd" {
+		t.Fatalf("unexpected registration %+v", reg)
+	}
+	if reg.Created == nil || reg.Created.Year() != 2004 || reg.Expires == nil || reg.Expires.Year() != 2027 {
+		t.Fatalf("unexpected dates %+v", reg)
+	}
+	if reg.Source != srv.URL+"/uk/domain/example.co.uk" {
+		t.Fatalf("source = %q", reg.Source)
+	}
+
+	again, err := client.Lookup(context.Background(), "www.example.co.uk")
+	if err != nil || again != reg {
+		t.Fatalf("expected cached registration, got %+v (%v)", again, err)
+	}
+	if _, err := client.Lookup(context.Background(), "missing.co.uk"); err == nil {
+		t.Fatal("expected not-found error")
+	}
+	if _, err := client.Lookup(context.Background(), "example.zz"); err == nil {
+		t.Fatal("expected error for TLD without RDAP service")
+	}
+	if n := bootstrapHits.Load(); n != 1 {
+		t.Fatalf("bootstrap fetched %d times, want 1", n)
+	}
+}
+
+func TestRegistrationVulnerabilities(t *testing.T) {
+	past := time.Now().Add(-48 * time.Hour)
+	soon := time.Now().Add(20 * 24 * time.Hour)
+	later := time.Now().Add(400 * 24 * time.Hour)
+
+	vulns := RegistrationVulnerabilities([]DomainRegistration{
+		{Domain: "lapsed.com", Expires: &past},
+		{Domain: "soon.com", Expires: &soon, ExpiresDuringEngagement: true},
+		{Domain: "fine.com", Expires: &later},
+		{Domain: "unknown.com"},
+	})
+	if len(vulns) != 2 {
+		t.Fatalf("expected 2 vulnerabilities, got %+v", vulns)
+	}
+	if vulns[0].Name != "Domain Registration Expired" || vulns[0].Severity != "High" {
+		t.Errorf("unexpected first vulnerability %+v", vulns[0])
+	}
+	if vulns[1].Name != "Domain Registration Expiring Soon" {
+		t.Errorf("unexpected second vulnerability %+v", vulns[1])
+	}
+}
@@ -0,0 +1,335 @@
+package checker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// maxNELSuccessFraction is the highest share of successful requests worth
+// reporting; more adds collector traffic and exposes browsing volume.
+const maxNELSuccessFraction = 0.1
+
+// reportToGroup is one endpoint group of a Report-To header.
+type reportToGroup struct {
+	Group     string `json:"group"`
+	MaxAge    *int64 `json:"max_age"`
+	Endpoints []struct {
+		URL string `json:"url"`
+	} `json:"endpoints"`
+}
+
+// nelPolicy is a Network Error Logging policy (NEL header).
+type nelPolicy struct {
+	ReportTo          string   `json:"report_to"`
+	MaxAge            *int64   `json:"max_age"`
+	IncludeSubdomains bool     `json:"include_subdomains"`
+	SuccessFraction   *float64 `json:"success_fraction"`
+	FailureFraction   *float64 `json:"failure_fraction"`
+}
+
+// reportingEndpointMember matches one member of the Reporting-Endpoints
+// structured-field dictionary, e.g. default="https://example.com/reports".
+var reportingEndpointMember = regexp.MustCompile(`^([a-z*][a-z0-9_.*-]*)="([^"]*)"$`)
+
+// parseReportTo decodes a Report-To header, a comma-separated list of JSON
+// objects. Groups without a name are the "default" group.
+func parseReportTo(value string) ([]reportToGroup, error) {
+	var groups []reportToGroup
+	if err := json.Unmarshal([]byte("["+value+"]"), &groups); err != nil {
+		return nil, err
+	}
+	for i := range groups {
+		if groups[i].Group == "" {
+			groups[i].Group = "default"
+		}
+	}
+	return groups, nil
+}
+
+// parseNEL decodes a NEL header.
+func parseNEL(value string) (*nelPolicy, error) {
+	var policy nelPolicy
+	if err := json.Unmarshal([]byte(value), &policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// parseReportingEndpoints decodes a Reporting-Endpoints header into endpoint
+// names and URLs, in header order.
+func parseReportingEndpoints(value string) (names, urls []string, err error) {
+	for _, member := range strings.Split(value, ",") {
+		member = strings.TrimSpace(member)
+		if member == "" {
+			continue
+		}
+		m := reportingEndpointMember.FindStringSubmatch(member)
+		if m == nil {
+			return nil, nil, fmt.Errorf("invalid member %q (want name=\"https://...\")", member)
+		}
+		names = append(names, m[1])
+		urls = append(urls, m[2])
+	}
+	return names, urls, nil
+}
+
+// isSecureReportingURL reports whether u is an absolute https URL, as the
+// Reporting API requires of endpoints.
+func isSecureReportingURL(u string) bool {
+	parsed, err := url.Parse(u)
+	return err == nil && parsed.Scheme == "https" && parsed.Host != ""
+}
+
+// checkReportTo validates the Report-To header
+func checkReportTo(value string) (int, []string, string) {
+	issues := []string{}
+	score := 5
+
+	groups, err := parseReportTo(value)
+	if err != nil {
+		return 0, []string{"Report-To is not valid JSON"}, "Declare endpoint groups as JSON objects, e.g. {\"group\":\"default\",\"max_age\":10886400,\"endpoints\":[{\"url\":\"https://example.com/reports\"}]}"
+	}
+
+	for _, g := range groups {
+		if len(g.Endpoints) == 0 {
+			issues = append(issues, fmt.Sprintf("Group %q has no endpoints", g.Group))
+			score -= 2
+		}
+		for _, ep := range g.Endpoints {
+			if !isSecureReportingURL(ep.URL) {
+				issues = append(issues, fmt.Sprintf("Group %q endpoint %q is not an https URL; browsers will not deliver to it", g.Group, ep.URL))
+				score -= 2
+			}
+		}
+		if g.MaxAge == nil || *g.MaxAge <= 0 {
+			issues = append(issues, fmt.Sprintf("Group %q has no positive max_age, so it expires immediately", g.Group))
+			score -= 1
+		}
+	}
+
+	if score < 0 {
+		score = 0
+	}
+	if len(issues) > 0 {
+		return score, issues, "Fix the Report-To endpoint groups so reports can be delivered"
+	}
+	return score, issues, "Report-To is deprecated for CSP reporting; prefer Reporting-Endpoints and keep Report-To for NEL"
+}
+
+// checkNEL validates the NEL (Network Error Logging) header
+func checkNEL(value string) (int, []string, string) {
+	issues := []string{}
+	score := 5
+
+	policy, err := parseNEL(value)
+	if err != nil {
+		return 0, []string{"NEL is not valid JSON"}, "Set NEL to a JSON policy, e.g. {\"report_to\":\"default\",\"max_age\":2592000}"
+	}
+
+	if policy.ReportTo == "" {
+		issues = append(issues, "NEL has no report_to group, so no reports are sent")
+		score -= 3
+	}
+	if policy.MaxAge == nil || *policy.MaxAge <= 0 {
+		issues = append(issues, "NEL max_age is missing or 0, which removes the policy")
+		score -= 2
+	}
+	for _, f := range []struct {
+		name  string
+		value *float64
+	}{{"success_fraction", policy.SuccessFraction}, {"failure_fraction", policy.FailureFraction}} {
+		if f.value != nil && (*f.value < 0 || *f.value > 1) {
+			issues = append(issues, fmt.Sprintf("NEL %s %.2f is outside 0.0-1.0", f.name, *f.value))
+			score -= 1
+		}
+	}
+	if policy.FailureFraction != nil && *policy.FailureFraction == 0 {
+		issues = append(issues, "NEL failure_fraction 0 disables network error reports")
+		score -= 1
+	}
+	if policy.SuccessFraction != nil && *policy.SuccessFraction > maxNELSuccessFraction && *policy.SuccessFraction <= 1 {
+		issues = append(issues, fmt.Sprintf("NEL success_fraction %.2f reports many successful requests, exposing browsing volume to the collector", *policy.SuccessFraction))
+		score -= 1
+	}
+
+	if score < 0 {
+		score = 0
+	}
+	if len(issues) > 0 {
+		return score, issues, "Fix the NEL policy so network errors are reported"
+	}
+	return score, issues, "NEL is properly configured"
+}
+
+// checkReportingEndpoints validates the Reporting-Endpoints header
+func checkReportingEndpoints(value string) (int, []string, string) {
+	issues := []string{}
+	score := 5
+
+	names, urls, err := parseReportingEndpoints(value)
+	if err != nil {
+		return 0, []string{fmt.Sprintf("Reporting-Endpoints is malformed: %v", err)}, "Use the structured-field syntax, e.g. default=\"https://example.com/reports\""
+	}
+	if len(names) == 0 {
+		return 0, []string{"Reporting-Endpoints declares no endpoints"}, "Declare at least one endpoint, e.g. default=\"https://example.com/reports\""
+	}
+	for i, u := range urls {
+		if !isSecureReportingURL(u) {
+			issues = append(issues, fmt.Sprintf("Endpoint %q (%s) is not an https URL; browsers will not deliver to it", names[i], u))
+			score -= 2
+		}
+	}
+
+	if score < 0 {
+		score = 0
+	}
+	if len(issues) > 0 {
+		return score, issues, "Use absolute https URLs for every reporting endpoint"
+	}
+	return score, issues, "Reporting-Endpoints is properly configured"
+}
+
+// checkReportingGroups cross-checks the groups that NEL and the CSP report-to
+// directive name against the endpoints the response declares. Issues are
+// added to the referring header; the returned penalty is deducted from the
+// total score.
+func checkReportingGroups(headers http.Header, result *SecurityHeadersResult) int {
+	reportToGroups := map[string]bool{}
+	if groups, err := parseReportTo(headers.Get("Report-To")); err == nil {
+		for _, g := range groups {
+			reportToGroups[g.Group] = true
+		}
+	}
+	endpointNames, _, _ := parseReportingEndpoints(headers.Get("Reporting-Endpoints"))
+
+	penalty := 0
+	addIssue := func(header, issue string, points int) {
+		status := result.Headers[header]
+		points = min(points, status.Score)
+		status.Issues = append(status.Issues, issue)
+		status.Score -= points
+		result.Headers[header] = status
+		penalty += points
+	}
+
+	if value := headers.Get("NEL"); value != "" {
+		// NEL delivers only to Report-To groups, not Reporting-Endpoints.
+		if policy, err := parseNEL(value); err == nil && policy.ReportTo != "" && !reportToGroups[policy.ReportTo] {
+			addIssue("NEL", fmt.Sprintf("NEL report_to group %q is not defined in Report-To", policy.ReportTo), 2)
+		}
+	}
+
+	if value := headers.Get("Content-Security-Policy"); value != "" {
+		if sources, ok := ParseCSP(value).Directive("report-to"); ok && len(sources) > 0 {
+			group := sources[0]
+			if !reportToGroups[group] && !slices.Contains(endpointNames, group) {
+				issue := fmt.Sprintf("report-to group %q is not defined in Reporting-Endpoints or Report-To", group)
+				result.CSPFindings = append(result.CSPFindings, CSPFinding{Directive: "report-to", Severity: CSPSeverityMedium, Description: issue, Value: group})
+				addIssue("Content-Security-Policy", "report-to: "+issue, cspFindingPenalty[CSPSeverityMedium])
+			}
+		}
+	}
+	return penalty
+}
+
+// reportingEndpointOrigins returns the distinct origins a reporting header
+// delivers to.
+func reportingEndpointOrigins(header, value string) []string {
+	var urls []string
+	switch header {
+	case "Report-To":
+		groups, _ := parseReportTo(value)
+		for _, g := range groups {
+			for _, ep := range g.Endpoints {
+				urls = append(urls, ep.URL)
+			}
+		}
+	case "Reporting-Endpoints":
+		_, urls, _ = parseReportingEndpoints(value)
+	}
+
+	var origins []string
+	for _, u := range urls {
+		parsed, err := url.Parse(u)
+		if err != nil || parsed.Host == "" {
+			continue
+		}
+		origin := parsed.Scheme + "://" + parsed.Host
+		if !slices.Contains(origins, origin) {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+// reportingHeaderDescriptions explains each reporting header in findings.
+var reportingHeaderDescriptions = map[string]string{
+	"Report-To":           "Report-To declares endpoint groups for the legacy Reporting API. It is still required by Network Error Logging.",
+	"NEL":                 "Network Error Logging (NEL) asks browsers to report failed connections (DNS, TLS, HTTP errors) to a Report-To group, revealing outages and interception that server logs never see.",
+	"Reporting-Endpoints": "Reporting-Endpoints declares where browsers send CSP violation, deprecation and intervention reports (Reporting API v1).",
+}
+
+// analyzeReportingHeaders converts the Report-To, NEL and Reporting-Endpoints
+// header results into findings. The headers are optional, so their absence is
+// informational; endpoints on another host than the target are noted because
+// the collector receives visitors' IPs and page URLs.
+func analyzeReportingHeaders(sh *SecurityHeadersResult, target string) []Vulnerability {
+	vulns := []Vulnerability{}
+	targetHost := ""
+	if u, err := url.Parse(target); err == nil {
+		targetHost = u.Hostname()
+	}
+
+	for _, name := range []string{"Reporting-Endpoints", "Report-To", "NEL"} {
+		header, ok := sh.Headers[name]
+		if !ok {
+			continue
+		}
+		vuln := Vulnerability{
+			Name:        name + " Header",
+			Category:    "Miscellaneous Headers",
+			Score:       header.Score,
+			MaxScore:    header.MaxScore,
+			Description: reportingHeaderDescriptions[name],
+		}
+
+		switch {
+		case !header.Present:
+			// Report-To is only needed alongside NEL.
+			if name == "Report-To" {
+				continue
+			}
+			vuln.Severity = "Info"
+			vuln.Status = "Info"
+			vuln.Recommendation = fmt.Sprintf("INFO: %s header not set.\n\n%s", name, securityHeaderSpecs[name].Recommendation)
+		case len(header.Issues) > 0:
+			vuln.Severity = "Low"
+			vuln.Status = "Warning"
+			vuln.Recommendation = fmt.Sprintf("LOW: %s header is misconfigured.\n\nCurrent value: %s\n\nIssues found:\n%s\n\n%s",
+				name, header.Value, strings.Join(header.Issues, "\n"), header.Recommendation)
+		default:
+			vuln.Severity = "Info"
+			vuln.Status = "Passed"
+			vuln.Recommendation = fmt.Sprintf("PASSED: %s header is properly configured.\n\nCurrent value: %s", name, header.Value)
+		}
+
+		if header.Present {
+			var thirdParty []string
+			for _, origin := range reportingEndpointOrigins(name, header.Value) {
+				if u, err := url.Parse(origin); err == nil && !strings.EqualFold(u.Hostname(), targetHost) {
+					thirdParty = append(thirdParty, origin)
+				}
+			}
+			if len(thirdParty) > 0 {
+				vuln.Recommendation += fmt.Sprintf("\n\nReports are delivered to another origin (%s), which receives visitors' IP addresses and page URLs. Confirm the collector is trusted.", strings.Join(thirdParty, ", "))
+			}
+		}
+		vulns = append(vulns, vuln)
+	}
+	return vulns
+}
@@ -0,0 +1,96 @@
+package checker
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCheckReportTo(t *testing.T) {
+	score, issues, _ := checkReportTo(`{"group":"default","max_age":10886400,"endpoints":[{"url":"https://example.com/reports"}]}`)
+	if score != 5 || len(issues) != 0 {
+		t.Fatalf("valid Report-To: score=%d issues=%v", score, issues)
+	}
+
+	score, issues, _ = checkReportTo(`{"max_age":0,"endpoints":[{"url":"http://example.com/reports"}]}, {"group":"csp","max_age":1}`)
+	if score >= 5 || len(issues) != 3 {
+		t.Fatalf("expected insecure endpoint, max_age and empty group issues, got score=%d issues=%v", score, issues)
+	}
+
+	if score, _, _ := checkReportTo(`not json`); score != 0 {
+		t.Fatalf("invalid Report-To should score 0, got %d", score)
+	}
+}
+
+func TestCheckNEL(t *testing.T) {
+	score, issues, _ := checkNEL(`{"report_to":"default","max_age":2592000,"failure_fraction":1.0}`)
+	if score != 5 || len(issues) != 0 {
+		t.Fatalf("valid NEL: score=%d issues=%v", score, issues)
+	}
+
+	_, issues, _ = checkNEL(`{"max_age":0,"success_fraction":0.5,"failure_fraction":0}`)
+	for _, want := range []string{"report_to", "max_age", "failure_fraction", "success_fraction"} {
+		if !slices.ContainsFunc(issues, func(issue string) bool { return strings.Contains(issue, want) }) {
+			t.Errorf("expected an issue mentioning %s, got %v", want, issues)
+		}
+	}
+}
+
+func TestCheckReportingEndpoints(t *testing.T) {
+	score, issues, _ := checkReportingEndpoints(`default="https://example.com/reports", csp="https://csp.example.com/"`)
+	if score != 5 || len(issues) != 0 {
+		t.Fatalf("valid Reporting-Endpoints: score=%d issues=%v", score, issues)
+	}
+
+	if _, issues, _ := checkReportingEndpoints(`default="http://example.com/reports"`); len(issues) != 1 {
+		t.Fatalf("expected an insecure endpoint issue, got %v", issues)
+	}
+	if score, _, _ := checkReportingEndpoints(`default=https://example.com/reports`); score != 0 {
+		t.Fatalf("unquoted URL should be malformed, got score %d", score)
+	}
+}
+
+func TestAnalyzeSecurityHeaders_ReportingHeadersOptional(t *testing.T) {
+	result := AnalyzeSecurityHeaders(http.Header{})
+
+	for _, name := range []string{"Report-To", "NEL", "Reporting-Endpoints"} {
+		if slices.Contains(result.Missing, name) {
+			t.Errorf("optional header %s should not be listed as missing", name)
+		}
+		if _, ok := result.Headers[name]; !ok {
+			t.Errorf("expected a status for %s", name)
+		}
+	}
+	if result.MaxScore != 105 {
+		t.Errorf("absent optional headers should not raise MaxScore, got %d", result.MaxScore)
+	}
+}
+
+func TestAnalyzeSecurityHeaders_ReportingGroups(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("Content-Security-Policy", "default-src 'self'; report-to csp")
+	headers.Set("Reporting-Endpoints", `default="https://collector.example.net/r"`)
+	headers.Set("NEL", `{"report_to":"network-errors","max_age":2592000}`)
+
+	result := AnalyzeSecurityHeaders(headers)
+
+	if result.MaxScore != 115 {
+		t.Errorf("present optional headers should count towards MaxScore, got %d", result.MaxScore)
+	}
+	if issues := result.Headers["NEL"].Issues; len(issues) != 1 || !strings.Contains(issues[0], "network-errors") {
+		t.Errorf("expected undefined NEL group issue, got %v", issues)
+	}
+	if !hasCSPFinding(result.CSPFindings, "report-to", CSPSeverityMedium) {
+		t.Errorf("expected undefined CSP report-to group finding, got %+v", result.CSPFindings)
+	}
+
+	vulns := analyzeReportingHeaders(result, "https://app.example.com")
+	idx := slices.IndexFunc(vulns, func(v Vulnerability) bool { return v.Name == "Reporting-Endpoints Header" })
+	if idx < 0 || vulns[idx].Status != "Passed" || !strings.Contains(vulns[idx].Recommendation, "https://collector.example.net") {
+		t.Fatalf("expected a passed Reporting-Endpoints finding noting the third-party collector, got %+v", vulns)
+	}
+	if slices.ContainsFunc(vulns, func(v Vulnerability) bool { return v.Name == "Report-To Header" }) {
+		t.Error("absent Report-To should not be reported")
+	}
+}
@@ -0,0 +1,176 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// happyEyeballsDelay is how long a dual-stack dial waits on the first
+// address family before racing the other (RFC 8305).
+const happyEyeballsDelay = 300 * time.Millisecond
+
+// Dialer connects checker traffic to its targets. Names are resolved through
+// Nameservers when set, otherwise the system resolver, and dual-stack hosts
+// are dialed happy-eyeballs style. Pinned hosts skip resolution and connect
+// to their pinned addresses, so a site can be checked on a staging IP before
+// its DNS changes; TLS still verifies the certificate against the host name.
+// Behind a proxy only the proxy itself is dialed, and the proxy resolves the
+// target. A nil *Dialer uses the transport's default dialing.
+type Dialer struct {
+	Nameservers []string            // host:port; empty means the system resolver
+	Pins        map[string][]string // Lowercase host -> IP addresses, tried in order
+	Timeout     time.Duration       // Per connection attempt; 0 means no timeout
+}
+
+// ParseResolvePin parses a "host:ip" pin as given to --resolve. IPv6
+// addresses may be bracketed.
+func ParseResolvePin(raw string) (host, ip string, err error) {
+	raw = strings.TrimSpace(raw)
+	host, addr, ok := strings.Cut(raw, ":")
+	host = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(host), "."))
+	addr = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(addr), "["), "]")
+	if !ok || host == "" || addr == "" {
+		return "", "", fmt.Errorf("invalid pin %q (expected host:ip)", raw)
+	}
+	if strings.ContainsAny(host, "/[]") {
+		return "", "", fmt.Errorf("invalid pin %q: %q is not a host name", raw, host)
+	}
+	parsed := net.ParseIP(addr)
+	if parsed == nil {
+		return "", "", fmt.Errorf("invalid pin %q: %q is not an IP address", raw, addr)
+	}
+	return host, parsed.String(), nil
+}
+
+// Pin adds ip to host's pinned addresses.
+func (d *Dialer) Pin(host, ip string) {
+	if d.Pins == nil {
+		d.Pins = make(map[string][]string)
+	}
+	host = strings.ToLower(host)
+	for _, existing := range d.Pins[host] {
+		if existing == ip {
+			return
+		}
+	}
+	d.Pins[host] = append(d.Pins[host], ip)
+}
+
+// Pinned returns host's pinned addresses, or nil when it is resolved
+// normally.
+func (d *Dialer) Pinned(host string) []string {
+	if d == nil {
+		return nil
+	}
+	return d.Pins[strings.TrimSuffix(strings.ToLower(host), ".")]
+}
+
+// PinnedHosts returns the pinned hosts in name order.
+func (d *Dialer) PinnedHosts() []string {
+	if d == nil {
+		return nil
+	}
+	hosts := make([]string, 0, len(d.Pins))
+	for host := range d.Pins {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// IsZero reports whether the dialer changes nothing about dialing.
+func (d *Dialer) IsZero() bool {
+	return d == nil || (len(d.Nameservers) == 0 && len(d.Pins) == 0)
+}
+
+// Resolver returns a resolver that queries Nameservers in order, falling
+// back to the next one when a server cannot be reached.
+func (d *Dialer) Resolver() *net.Resolver {
+	resolver := &net.Resolver{PreferGo: true}
+	if d == nil || len(d.Nameservers) == 0 {
+		return resolver
+	}
+	servers := append([]string(nil), d.Nameservers...)
+	dialer := &net.Dialer{Timeout: d.Timeout}
+	resolver.Dial = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		var errs []error
+		for _, server := range servers {
+			conn, err := dialer.DialContext(ctx, network, server)
+			if err == nil {
+				return conn, nil
+			}
+			errs = append(errs, err)
+		}
+		return nil, errors.Join(errs...)
+	}
+	return resolver
+}
+
+// DialContext connects to addr, a host:port, honoring pins and nameservers.
+func (d *Dialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	base := &net.Dialer{Timeout: d.Timeout, FallbackDelay: happyEyeballsDelay}
+	if len(d.Nameservers) > 0 {
+		base.Resolver = d.Resolver()
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return base.DialContext(ctx, network, addr)
+	}
+	pins := d.Pinned(host)
+	if len(pins) == 0 {
+		return base.DialContext(ctx, network, addr)
+	}
+	var errs []error
+	for _, ip := range pins {
+		conn, err := base.DialContext(ctx, network, net.JoinHostPort(ip, port))
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, err)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, fmt.Errorf("dial %s (pinned to %s): %w", addr, strings.Join(pins, ", "), errors.Join(errs...))
+}
+
+// Address returns addr with its host replaced by the address it connects
+// to: the first pin, or the first answer from Nameservers. Raw probes that
+// dial by themselves use it to reach the same server as the transport. On
+// failure addr is returned unchanged.
+func (d *Dialer) Address(ctx context.Context, addr string) string {
+	if d.IsZero() {
+		return addr
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || net.ParseIP(host) != nil {
+		return addr
+	}
+	if pins := d.Pinned(host); len(pins) > 0 {
+		return net.JoinHostPort(pins[0], port)
+	}
+	if len(d.Nameservers) == 0 {
+		return addr
+	}
+	ips, err := d.Resolver().LookupIPAddr(ctx, host)
+	if err != nil || len(ips) == 0 {
+		return addr
+	}
+	return net.JoinHostPort(ips[0].IP.String(), port)
+}
+
+// Apply makes t dial through d and returns t. It leaves t unchanged when d
+// is nil or empty.
+func (d *Dialer) Apply(t *http.Transport) *http.Transport {
+	if d.IsZero() {
+		return t
+	}
+	t.DialContext = d.DialContext
+	return t
+}
@@ -0,0 +1,78 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseResolvePin(t *testing.T) {
+	tests := []struct {
+		raw, host, ip string
+		wantErr       bool
+	}{
+		{raw: "App.Example.com:10.0.0.5", host: "app.example.com", ip: "10.0.0.5"},
+		{raw: "app.example.com.:2001:db8::1", host: "app.example.com", ip: "2001:db8::1"},
+		{raw: "app.example.com:[2001:db8::1]", host: "app.example.com", ip: "2001:db8::1"},
+		{raw: "app.example.com", wantErr: true},
+		{raw: "app.example.com:staging", wantErr: true},
+		{raw: ":10.0.0.5", wantErr: true},
+	}
+	for _, tt := range tests {
+		host, ip, err := ParseResolvePin(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ParseResolvePin(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+		}
+		if host != tt.host || ip != tt.ip {
+			t.Errorf("ParseResolvePin(%q) = %q, %q; want %q, %q", tt.raw, host, ip, tt.host, tt.ip)
+		}
+	}
+}
+
+func TestHTTPCheckerFollowsPins(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The Host header probe sends its own canary on purpose.
+		if r.Host != "" && !strings.HasPrefix(r.Host, "staging.example.invalid") && !strings.HasPrefix(r.Host, "seca-") {
+			t.Errorf("Host header %q should keep the pinned name", r.Host)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+
+	dialer := &Dialer{Timeout: time.Second}
+	// Nothing listens on the first pin, so the dial falls through to the second.
+	dialer.Pin("staging.example.invalid", "127.0.0.2")
+	dialer.Pin("staging.example.invalid", "127.0.0.1")
+
+	h := &HTTPChecker{Timeout: 5 * time.Second, Dialer: dialer}
+	result := h.Check(context.Background(), "http://staging.example.invalid:"+serverURL.Port()+"/")
+	if result.Status != "ok" || result.HTTPStatus != http.StatusOK {
+		t.Fatalf("expected the pinned host to answer, got %+v", result)
+	}
+	if !strings.Contains(result.Notes, "pinned to 127.0.0.2, 127.0.0.1") {
+		t.Errorf("notes should record the pin, got %q", result.Notes)
+	}
+
+	if got := dialer.Address(context.Background(), "staging.example.invalid:443"); got != "127.0.0.2:443" {
+		t.Errorf("Address() = %q, want the first pin", got)
+	}
+	if got := dialer.Address(context.Background(), "other.example.invalid:443"); got != "other.example.invalid:443" {
+		t.Errorf("Address() = %q, unpinned hosts should be unchanged", got)
+	}
+}
+
+func TestDialerApplyNil(t *testing.T) {
+	var d *Dialer
+	transport := NewTransport(nil)
+	if d.Apply(transport).DialContext != nil {
+		t.Error("a nil dialer should leave the transport's dialing alone")
+	}
+	if !(&Dialer{}).IsZero() {
+		t.Error("an empty dialer should be zero")
+	}
+}
@@ -0,0 +1,276 @@
+package checker
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RobotsUserAgent is the product token matched against robots.txt groups.
+const RobotsUserAgent = "seca-cli"
+
+// maxRobotsCrawlDelay caps Crawl-delay so a hostile robots.txt cannot stall a run.
+const maxRobotsCrawlDelay = 30 * time.Second
+
+const maxRobotsBytes = 512 * 1024
+
+// RobotsRules holds the robots.txt directives that apply to the crawler.
+type RobotsRules struct {
+	rules      []robotsRule
+	CrawlDelay time.Duration
+	// Sitemaps lists Sitemap directives, which apply to every user-agent.
+	Sitemaps []string
+}
+
+type robotsRule struct {
+	allow   bool
+	pattern string
+	re      *regexp.Regexp
+}
+
+// FetchRobots retrieves and parses /robots.txt for the origin of root. A
+// missing robots.txt (4xx) allows everything; other failures are returned.
+func FetchRobots(ctx context.Context, client *http.Client, root *url.URL) (*RobotsRules, error) {
+	robotsURL := &url.URL{Scheme: root.Scheme, Host: root.Host, Path: "/robots.txt"}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+		return &RobotsRules{}, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("robots.txt returned status %d", resp.StatusCode)
+	}
+	return ParseRobots(io.LimitReader(resp.Body, maxRobotsBytes), RobotsUserAgent), nil
+}
+
+// ParseRobots parses robots.txt content and returns the rules of the most
+// specific group matching userAgent, falling back to the "*" group.
+func ParseRobots(r io.Reader, userAgent string) *RobotsRules {
+	agent := strings.ToLower(userAgent)
+
+	type group struct {
+		agents []string
+		rules  []robotsRule
+		delay  time.Duration
+	}
+	var groups []*group
+	var current *group
+	var sitemaps []string
+	inAgents := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if !inAgents || current == nil {
+				current = &group{}
+				groups = append(groups, current)
+			}
+			current.agents = append(current.agents, strings.ToLower(value))
+			inAgents = true
+		case "allow", "disallow":
+			inAgents = false
+			if current == nil || (key == "disallow" && value == "") {
+				continue
+			}
+			current.rules = append(current.rules, robotsRule{allow: key == "allow", pattern: value, re: compileRobotsPattern(value)})
+		case "crawl-delay":
+			inAgents = false
+			if current == nil {
+				continue
+			}
+			if secs, err := strconv.ParseFloat(value, 64); err == nil && secs > 0 {
+				current.delay = time.Duration(secs * float64(time.Second))
+			}
+		case "sitemap":
+			if value != "" {
+				sitemaps = append(sitemaps, value)
+			}
+		default:
+			inAgents = false
+		}
+	}
+
+	var specific, wildcard []*group
+	for _, g := range groups {
+		for _, a := range g.agents {
+			switch {
+			case a == "*":
+				wildcard = append(wildcard, g)
+			case a != "" && strings.Contains(agent, a):
+				specific = append(specific, g)
+			}
+		}
+	}
+	selected := specific
+	if len(selected) == 0 {
+		selected = wildcard
+	}
+
+	rules := &RobotsRules{Sitemaps: sitemaps}
+	for _, g := range selected {
+		rules.rules = append(rules.rules, g.rules...)
+		if g.delay > rules.CrawlDelay {
+			rules.CrawlDelay = g.delay
+		}
+	}
+	if rules.CrawlDelay > maxRobotsCrawlDelay {
+		rules.CrawlDelay = maxRobotsCrawlDelay
+	}
+	return rules
+}
+
+// Allowed reports whether the crawler may fetch u. The longest matching rule
+// wins and Allow wins ties, as in RFC 9309.
+func (r *RobotsRules) Allowed(u *url.URL) bool {
+	if r == nil || u == nil {
+		return true
+	}
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	if path == "/robots.txt" {
+		return true
+	}
+
+	allowed, best := true, -1
+	for _, rule := range r.rules {
+		if !rule.re.MatchString(path) {
+			continue
+		}
+		length := len(rule.pattern)
+		if length > best || (length == best && rule.allow) {
+			best = length
+			allowed = rule.allow
+		}
+	}
+	return allowed
+}
+
+// compileRobotsPattern converts a robots.txt path pattern ('*' wildcards and
+// an optional trailing '$' anchor) into a regular expression.
+func compileRobotsPattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+	expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+	if anchored {
+		expr += "$"
+	}
+	return regexp.MustCompile(expr)
+}
+
+// robotsGate enforces robots rules and crawl-delay during a crawl. Rules for
+// hosts other than the start host (allowed by the crawl scope) are fetched on
+// first use; a host whose robots.txt is unavailable is not crawled.
+type robotsGate struct {
+	ctx       context.Context
+	client    *http.Client
+	root      *url.URL
+	rules     *RobotsRules
+	hosts     map[string]*RobotsRules
+	lastFetch time.Time
+}
+
+// newRobotsGate loads robots.txt for root unless opts.IgnoreRobots is set, in
+// which case it returns nil and every URL is allowed. Following RFC 9309, an
+// unreachable robots.txt means the site may not be crawled.
+func newRobotsGate(ctx context.Context, client *http.Client, root *url.URL, opts CrawlOptions) (*robotsGate, error) {
+	if opts.IgnoreRobots {
+		return nil, nil
+	}
+	rules, err := FetchRobots(ctx, client, root)
+	if err != nil {
+		return nil, fmt.Errorf("robots.txt unavailable, not crawling: %w", err)
+	}
+	return &robotsGate{ctx: ctx, client: client, root: root, rules: rules}, nil
+}
+
+func (g *robotsGate) allowed(u *url.URL) bool {
+	if g == nil {
+		return true
+	}
+	rules := g.rulesFor(u)
+	return rules != nil && rules.Allowed(u)
+}
+
+// rulesFor returns the rules for u's origin, or nil when they are unavailable.
+func (g *robotsGate) rulesFor(u *url.URL) *RobotsRules {
+	if g.root == nil || hostsMatch(g.root, u) {
+		return g.rules
+	}
+	origin := strings.ToLower(u.Scheme + "://" + u.Host)
+	if rules, ok := g.hosts[origin]; ok {
+		return rules
+	}
+	rules, err := FetchRobots(g.ctx, g.client, u)
+	if err != nil {
+		rules = nil
+	}
+	if g.hosts == nil {
+		g.hosts = make(map[string]*RobotsRules)
+	}
+	g.hosts[origin] = rules
+	return rules
+}
+
+// crawlDelay is the longest Crawl-delay of any host seen so far; the crawler
+// fetches sequentially, so pacing by the slowest host respects all of them.
+func (g *robotsGate) crawlDelay() time.Duration {
+	delay := g.rules.CrawlDelay
+	for _, rules := range g.hosts {
+		if rules != nil && rules.CrawlDelay > delay {
+			delay = rules.CrawlDelay
+		}
+	}
+	return delay
+}
+
+// wait blocks until Crawl-delay has passed since the previous fetch.
+func (g *robotsGate) wait(ctx context.Context) error {
+	if g == nil {
+		return nil
+	}
+	if delay := g.crawlDelay(); delay > 0 && !g.lastFetch.IsZero() {
+		if remaining := delay - time.Since(g.lastFetch); remaining > 0 {
+			timer := time.NewTimer(remaining)
+			defer timer.Stop()
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+	g.lastFetch = time.Now()
+	return nil
+}
@@ -0,0 +1,292 @@
+package checker
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+)
+
+// maxDisclosedEntries caps the paths and URLs kept per robots.txt or sitemap.
+const maxDisclosedEntries = 50
+
+// Sensitive path categories.
+const (
+	DisclosureAdmin    = "admin"
+	DisclosureBackup   = "backup"
+	DisclosureInternal = "internal"
+	DisclosureConfig   = "config"
+)
+
+// RobotsSitemapAnalysis lists what a site's robots.txt and sitemap reveal
+// beyond the pages meant to be public.
+type RobotsSitemapAnalysis struct {
+	// SensitivePaths are Disallow/Allow paths in robots.txt that name admin,
+	// backup, internal, or configuration locations.
+	SensitivePaths []DisclosedPath `json:"sensitive_paths,omitempty"`
+	// NonProductionURLs are sitemap entries on development, staging, or
+	// internal hosts.
+	NonProductionURLs []DisclosedURL `json:"non_production_urls,omitempty"`
+}
+
+// DisclosedPath is a robots.txt path and why it is sensitive.
+type DisclosedPath struct {
+	Path     string `json:"path"`
+	Category string `json:"category"`
+	Keyword  string `json:"keyword"`
+}
+
+// DisclosedURL is a sitemap entry and the marker of its non-production host.
+type DisclosedURL struct {
+	URL    string `json:"url"`
+	Host   string `json:"host"`
+	Marker string `json:"marker"`
+}
+
+// Empty reports whether nothing sensitive was found.
+func (a *RobotsSitemapAnalysis) Empty() bool {
+	return a == nil || (len(a.SensitivePaths) == 0 && len(a.NonProductionURLs) == 0)
+}
+
+// sensitivePathWords maps path words to their category.
+var sensitivePathWords = map[string]string{
+	"admin": DisclosureAdmin, "administrator": DisclosureAdmin, "wp-admin": DisclosureAdmin,
+	"manage": DisclosureAdmin, "manager": DisclosureAdmin, "management": DisclosureAdmin,
+	"console": DisclosureAdmin, "cpanel": DisclosureAdmin, "phpmyadmin": DisclosureAdmin,
+	"dashboard": DisclosureAdmin, "backend": DisclosureAdmin, "controlpanel": DisclosureAdmin,
+
+	"backup": DisclosureBackup, "backups": DisclosureBackup, "bak": DisclosureBackup,
+	"old": DisclosureBackup, "dump": DisclosureBackup, "dumps": DisclosureBackup,
+	"archive": DisclosureBackup, "archives": DisclosureBackup, "export": DisclosureBackup,
+
+	"internal": DisclosureInternal, "private": DisclosureInternal, "intranet": DisclosureInternal,
+	"staging": DisclosureInternal, "dev": DisclosureInternal, "test": DisclosureInternal,
+	"debug": DisclosureInternal, "tmp": DisclosureInternal, "temp": DisclosureInternal,
+	"secret": DisclosureInternal, "hidden": DisclosureInternal, "actuator": DisclosureInternal,
+	"server-status": DisclosureInternal, "server-info": DisclosureInternal, "metrics": DisclosureInternal,
+
+	"config": DisclosureConfig, "configuration": DisclosureConfig, "settings": DisclosureConfig,
+	"setup": DisclosureConfig, "install": DisclosureConfig, ".env": DisclosureConfig,
+	".git": DisclosureConfig, ".svn": DisclosureConfig, ".htpasswd": DisclosureConfig,
+}
+
+// sensitiveExtensions maps file extensions to their category.
+var sensitiveExtensions = map[string]string{
+	".bak": DisclosureBackup, ".old": DisclosureBackup, ".sql": DisclosureBackup,
+	".zip": DisclosureBackup, ".tar": DisclosureBackup, ".gz": DisclosureBackup,
+	".tgz": DisclosureBackup, ".7z": DisclosureBackup, ".rar": DisclosureBackup,
+	".conf": DisclosureConfig, ".ini": DisclosureConfig, ".yml": DisclosureConfig,
+	".yaml": DisclosureConfig, ".env": DisclosureConfig, ".log": DisclosureInternal,
+}
+
+// nonProductionLabels mark host labels of development and test environments.
+var nonProductionLabels = map[string]bool{
+	"dev": true, "develop": true, "development": true, "staging": true, "stage": true,
+	"stg": true, "test": true, "testing": true, "qa": true, "uat": true, "preprod": true,
+	"pre-prod": true, "sandbox": true, "demo": true, "local": true, "localhost": true,
+	"internal": true, "intranet": true,
+}
+
+// nonProductionSuffixes are domains that only resolve inside a network.
+var nonProductionSuffixes = []string{".local", ".internal", ".lan", ".corp", ".localdomain", ".test", ".invalid"}
+
+// robotsPaths returns the Disallow and Allow paths of robots.txt in order.
+func robotsPaths(content string) []string {
+	var paths []string
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "disallow", "allow":
+			if value = strings.TrimSpace(value); value != "" && value != "/" {
+				paths = append(paths, value)
+			}
+		}
+	}
+	return paths
+}
+
+// SensitiveRobotsPaths returns the robots.txt paths that disclose admin,
+// backup, internal, or configuration locations.
+func SensitiveRobotsPaths(content string) []DisclosedPath {
+	seen := make(map[string]bool)
+	var disclosed []DisclosedPath
+	for _, p := range robotsPaths(content) {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		if category, keyword := classifySensitivePath(p); category != "" {
+			disclosed = append(disclosed, DisclosedPath{Path: p, Category: category, Keyword: keyword})
+			if len(disclosed) == maxDisclosedEntries {
+				break
+			}
+		}
+	}
+	return disclosed
+}
+
+// classifySensitivePath matches each path segment, and the words in it,
+// against the sensitive keywords.
+func classifySensitivePath(p string) (category, keyword string) {
+	lower := strings.ToLower(strings.TrimRight(p, "*$"))
+	for _, segment := range strings.Split(lower, "/") {
+		segment = strings.Trim(segment, "*$?")
+		if segment == "" {
+			continue
+		}
+		if category, ok := sensitivePathWords[segment]; ok {
+			return category, segment
+		}
+		if ext := path.Ext(segment); ext != "" {
+			if category, ok := sensitiveExtensions[ext]; ok {
+				return category, ext
+			}
+		}
+		words := strings.FieldsFunc(segment, func(r rune) bool {
+			return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+		})
+		for _, word := range words {
+			if category, ok := sensitivePathWords[word]; ok {
+				return category, word
+			}
+		}
+	}
+	return "", ""
+}
+
+// NonProductionSitemapURLs returns the sitemap entries whose host looks like
+// a development, staging, or internal environment.
+func NonProductionSitemapURLs(urls []string) []DisclosedURL {
+	seen := make(map[string]bool)
+	var disclosed []DisclosedURL
+	for _, raw := range urls {
+		u, err := url.Parse(strings.TrimSpace(raw))
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+		host := strings.ToLower(u.Hostname())
+		if seen[host] {
+			continue
+		}
+		seen[host] = true
+		if marker := nonProductionMarker(host); marker != "" {
+			disclosed = append(disclosed, DisclosedURL{URL: raw, Host: host, Marker: marker})
+			if len(disclosed) == maxDisclosedEntries {
+				break
+			}
+		}
+	}
+	sort.Slice(disclosed, func(i, j int) bool { return disclosed[i].Host < disclosed[j].Host })
+	return disclosed
+}
+
+// nonProductionMarker returns what marks host as non-production, if
+// anything: a private address, an internal-only domain, or a label such as
+// "staging" or "dev" (also as a word within a label, e.g. "app-staging").
+func nonProductionMarker(host string) string {
+	if ip := net.ParseIP(host); ip != nil {
+		if ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+			return "private address"
+		}
+		return ""
+	}
+	for _, suffix := range nonProductionSuffixes {
+		if strings.HasSuffix(host, suffix) {
+			return suffix
+		}
+	}
+	labels := strings.Split(host, ".")
+	// The registrable domain and TLD describe the organization, not the
+	// environment (e.g. "test.com").
+	if len(labels) > 2 {
+		labels = labels[:len(labels)-2]
+	} else if host != "localhost" {
+		return ""
+	}
+	for _, label := range labels {
+		if nonProductionLabels[label] {
+			return label
+		}
+		for _, word := range strings.Split(label, "-") {
+			if nonProductionLabels[word] {
+				return word
+			}
+		}
+	}
+	return ""
+}
+
+// analyzeRobotsSitemap converts the robots.txt and sitemap disclosures into
+// informational vulnerabilities.
+func analyzeRobotsSitemap(a *RobotsSitemapAnalysis) []Vulnerability {
+	var vulns []Vulnerability
+	if len(a.SensitivePaths) > 0 {
+		lines := make([]string, 0, len(a.SensitivePaths))
+		for _, p := range a.SensitivePaths {
+			lines = append(lines, fmt.Sprintf("• %s (%s)", p.Path, p.Category))
+		}
+		vulns = append(vulns, Vulnerability{
+			Name:     "Sensitive Paths Disclosed in robots.txt",
+			Category: "Information Disclosure",
+			Severity: "Info",
+			Score:    8,
+			MaxScore: 10,
+			Status:   "Warning",
+			Description: fmt.Sprintf("robots.txt names %d admin, backup, internal, or configuration path(s). robots.txt is public, so it points attackers at the locations it asks crawlers to avoid.",
+				len(a.SensitivePaths)),
+			Recommendation: fmt.Sprintf(`INFO: robots.txt discloses sensitive paths.
+
+Disclosed paths:
+%s
+
+Recommended Actions:
+1. Protect these locations with authentication or network restrictions; robots.txt does not
+2. Remove backups and configuration files from the web root
+3. List only paths that are safe to reveal, or use X-Robots-Tag / noindex on the pages themselves`, strings.Join(lines, "\n")),
+			References: []string{
+				"https://owasp.org/www-project-web-security-testing-guide/latest/4-Web_Application_Security_Testing/01-Information_Gathering/03-Review_Webserver_Metafiles_for_Information_Leakage",
+				"https://www.rfc-editor.org/rfc/rfc9309#section-3",
+			},
+		})
+	}
+	if len(a.NonProductionURLs) > 0 {
+		lines := make([]string, 0, len(a.NonProductionURLs))
+		for _, u := range a.NonProductionURLs {
+			lines = append(lines, fmt.Sprintf("• %s (%s)", u.URL, u.Marker))
+		}
+		vulns = append(vulns, Vulnerability{
+			Name:     "Sitemap Lists Non-Production Hosts",
+			Category: "Information Disclosure",
+			Severity: "Info",
+			Score:    8,
+			MaxScore: 10,
+			Status:   "Warning",
+			Description: fmt.Sprintf("The sitemap lists pages on %d development, staging, or internal host(s), revealing environments that are usually less protected than production.",
+				len(a.NonProductionURLs)),
+			Recommendation: fmt.Sprintf(`INFO: Sitemap references non-production hosts.
+
+Disclosed URLs:
+%s
+
+Recommended Actions:
+1. Generate the production sitemap from production URLs only
+2. Check the listed environments are not reachable from the internet, or require authentication
+3. Keep non-production environments out of search engines with noindex or access control`, strings.Join(lines, "\n")),
+			References: []string{
+				"https://owasp.org/www-project-web-security-testing-guide/latest/4-Web_Application_Security_Testing/01-Information_Gathering/03-Review_Webserver_Metafiles_for_Information_Leakage",
+			},
+		})
+	}
+	return vulns
+}
@@ -0,0 +1,102 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSensitiveRobotsPaths(t *testing.T) {
+	robots := `User-agent: *
+Disallow: /wp-admin/
+Disallow: /backups/db-2024.sql
+Disallow: /search
+Allow: /api/internal-status   # health endpoint
+Disallow: /site-config.yml
+Disallow: /latest
+Disallow: /wp-admin/
+Disallow: /`
+
+	paths := SensitiveRobotsPaths(robots)
+	want := map[string]string{
+		"/wp-admin/":           DisclosureAdmin,
+		"/backups/db-2024.sql": DisclosureBackup,
+		"/api/internal-status": DisclosureInternal,
+		"/site-config.yml":     DisclosureConfig,
+	}
+	if len(paths) != len(want) {
+		t.Fatalf("expected %d sensitive paths, got %+v", len(want), paths)
+	}
+	for _, p := range paths {
+		if want[p.Path] != p.Category {
+			t.Errorf("%s: category %q, want %q", p.Path, p.Category, want[p.Path])
+		}
+	}
+}
+
+func TestNonProductionSitemapURLs(t *testing.T) {
+	urls := []string{
+		"https://www.example.com/",
+		"https://staging.example.com/pricing",
+		"https://app-dev.example.com/",
+		"https://staging.example.com/other",
+		"http://10.0.4.12/internal",
+		"https://cms.corp/page",
+		"https://test.com/",
+		"https://devices.example.com/",
+	}
+	got := NonProductionSitemapURLs(urls)
+	markers := make(map[string]string)
+	for _, u := range got {
+		markers[u.Host] = u.Marker
+	}
+	want := map[string]string{
+		"staging.example.com": "staging",
+		"app-dev.example.com": "dev",
+		"10.0.4.12":           "private address",
+		"cms.corp":            ".corp",
+	}
+	if len(markers) != len(want) {
+		t.Fatalf("expected %v, got %+v", want, got)
+	}
+	for host, marker := range want {
+		if markers[host] != marker {
+			t.Errorf("%s: marker %q, want %q", host, markers[host], marker)
+		}
+	}
+}
+
+func TestHTTPChecker_RobotsSitemapFindings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			_, _ = w.Write([]byte("User-agent: *\nDisallow: /admin/\nDisallow: /db.bak\n"))
+		case "/sitemap.xml":
+			_, _ = w.Write([]byte(`<urlset><url><loc>https://www.example.com/</loc></url><url><loc>https://uat.example.com/</loc></url></urlset>`))
+		default:
+			_, _ = w.Write([]byte("OK"))
+		}
+	}))
+	defer server.Close()
+
+	result := (&HTTPChecker{Timeout: 5 * time.Second}).Check(context.Background(), server.URL)
+	if result.RobotsSitemap == nil || len(result.RobotsSitemap.SensitivePaths) != 2 || len(result.RobotsSitemap.NonProductionURLs) != 1 {
+		t.Fatalf("unexpected analysis %+v", result.RobotsSitemap)
+	}
+
+	byCheck := make(map[string]Finding)
+	for _, f := range AnalyzeFindings(result) {
+		byCheck[f.Check] = f
+	}
+	robots, ok := byCheck["Sensitive Paths Disclosed in robots.txt"]
+	if !ok || robots.Severity != "Info" || len(robots.Evidence) != 2 || !strings.Contains(robots.Evidence[0], "/admin/") {
+		t.Errorf("unexpected robots finding %+v", robots)
+	}
+	sitemap, ok := byCheck["Sitemap Lists Non-Production Hosts"]
+	if !ok || len(sitemap.Evidence) != 1 || !strings.Contains(sitemap.Evidence[0], "uat.example.com") {
+		t.Errorf("unexpected sitemap finding %+v", sitemap)
+	}
+}
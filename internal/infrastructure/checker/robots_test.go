@@ -0,0 +1,159 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRobots_SelectsMatchingGroup(t *testing.T) {
+	robots := `
+User-agent: *
+Disallow: /
+
+User-agent: seca-cli
+Disallow: /private
+Allow: /private/public
+Crawl-delay: 2
+`
+	rules := ParseRobots(strings.NewReader(robots), RobotsUserAgent)
+
+	if rules.CrawlDelay != 2*time.Second {
+		t.Fatalf("expected 2s crawl delay, got %s", rules.CrawlDelay)
+	}
+
+	cases := map[string]bool{
+		"/":                     true,
+		"/blog":                 true,
+		"/private":              false,
+		"/private/admin":        false,
+		"/private/public/about": true,
+	}
+	for path, want := range cases {
+		if got := rules.Allowed(&url.URL{Path: path}); got != want {
+			t.Errorf("Allowed(%s) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestParseRobots_WildcardGroupAndPatterns(t *testing.T) {
+	robots := `
+# comment
+User-agent: Googlebot
+Disallow: /
+
+User-agent: *
+Disallow: /*.php$
+Disallow: /tmp*
+Disallow:
+`
+	rules := ParseRobots(strings.NewReader(robots), RobotsUserAgent)
+
+	cases := map[string]bool{
+		"/index.php":     false,
+		"/index.php?x=1": true,
+		"/tmpfiles/a":    false,
+		"/about":         true,
+	}
+	for raw, want := range cases {
+		u, err := url.Parse(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := rules.Allowed(u); got != want {
+			t.Errorf("Allowed(%s) = %v, want %v", raw, got, want)
+		}
+	}
+}
+
+func TestParseRobots_CapsCrawlDelay(t *testing.T) {
+	rules := ParseRobots(strings.NewReader("User-agent: *\nCrawl-delay: 3600\n"), RobotsUserAgent)
+	if rules.CrawlDelay != maxRobotsCrawlDelay {
+		t.Fatalf("expected crawl delay capped at %s, got %s", maxRobotsCrawlDelay, rules.CrawlDelay)
+	}
+}
+
+func TestDiscoverInScopeLinks_RespectsRobots(t *testing.T) {
+	var privateHits int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "User-agent: *\nDisallow: /private\n")
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<a href="/public">Public</a><a href="/private">Private</a>`)
+	})
+	mux.HandleFunc("/public", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<a href="/private/deeper">Deeper</a>`)
+	})
+	mux.HandleFunc("/private", func(w http.ResponseWriter, r *http.Request) {
+		privateHits++
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	opts := CrawlOptions{MaxDepth: 2, MaxPages: 10, SameHostOnly: true, Timeout: time.Second}
+	links, err := DiscoverInScopeLinks(context.Background(), server.URL, opts)
+	if err != nil {
+		t.Fatalf("crawl failed: %v", err)
+	}
+	if len(links) != 1 || links[0] != server.URL+"/public" {
+		t.Fatalf("expected only /public, got %v", links)
+	}
+	if privateHits != 0 {
+		t.Fatalf("disallowed path was fetched %d time(s)", privateHits)
+	}
+
+	opts.IgnoreRobots = true
+	links, err = DiscoverInScopeLinks(context.Background(), server.URL, opts)
+	if err != nil {
+		t.Fatalf("crawl failed: %v", err)
+	}
+	if len(links) != 3 {
+		t.Fatalf("expected robots.txt to be ignored, got %v", links)
+	}
+}
+
+func TestDiscoverInScopeLinks_RobotsServerErrorStopsCrawl(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<a href="/page">Page</a>`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	opts := CrawlOptions{MaxDepth: 1, MaxPages: 10, SameHostOnly: true, Timeout: time.Second}
+	links, err := DiscoverInScopeLinks(context.Background(), server.URL, opts)
+	if err == nil || len(links) != 0 {
+		t.Fatalf("expected crawl to stop when robots.txt is unavailable, got %v (%v)", links, err)
+	}
+}
+
+func TestRobotsGate_WaitHonoursCrawlDelay(t *testing.T) {
+	gate := &robotsGate{rules: &RobotsRules{CrawlDelay: 50 * time.Millisecond}}
+	ctx := context.Background()
+
+	if err := gate.wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if err := gate.wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("expected wait of about 50ms, got %s", elapsed)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := gate.wait(cancelled); err == nil {
+		t.Fatal("expected cancelled context to abort the wait")
+	}
+}
@@ -0,0 +1,106 @@
+package checker
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// ScanPacing keeps port scan traffic within agreed limits. It is shared by
+// every target of a run, so crawled pages on one host draw from the same
+// allowance. A nil *ScanPacing imposes no limits.
+type ScanPacing struct {
+	MaxPerHost int           // Concurrent connection attempts per host; 0 means unlimited
+	Delay      time.Duration // Minimum gap between connection attempts to one host
+	Randomize  bool          // Probe ports in random order
+
+	mu    sync.Mutex
+	hosts map[string]*hostPacer
+}
+
+type hostPacer struct {
+	slots chan struct{}
+	mu    sync.Mutex
+	next  time.Time
+}
+
+// order returns ports in probe order: a shuffled copy when Randomize is set,
+// otherwise ports unchanged.
+func (p *ScanPacing) order(ports []int) []int {
+	if p == nil || !p.Randomize {
+		return ports
+	}
+	shuffled := append([]int(nil), ports...)
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return shuffled
+}
+
+// acquire waits for a connection slot and the inter-probe delay for host.
+// The returned release must be called when the probe finishes. It returns
+// false if ctx ends first.
+func (p *ScanPacing) acquire(ctx context.Context, host string) (func(), bool) {
+	if p == nil {
+		return func() {}, true
+	}
+	hp := p.host(host)
+
+	release := func() {}
+	if hp.slots != nil {
+		select {
+		case hp.slots <- struct{}{}:
+			release = func() { <-hp.slots }
+		case <-ctx.Done():
+			return nil, false
+		}
+	}
+
+	if p.Delay > 0 {
+		hp.mu.Lock()
+		now := time.Now()
+		slot := hp.next
+		if slot.Before(now) {
+			slot = now
+		}
+		hp.next = slot.Add(p.Delay)
+		hp.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(slot))
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			release()
+			return nil, false
+		}
+	}
+	return release, true
+}
+
+func (p *ScanPacing) host(host string) *hostPacer {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.hosts == nil {
+		p.hosts = make(map[string]*hostPacer)
+	}
+	hp, ok := p.hosts[host]
+	if !ok {
+		hp = &hostPacer{}
+		if p.MaxPerHost > 0 {
+			hp.slots = make(chan struct{}, p.MaxPerHost)
+		}
+		p.hosts[host] = hp
+	}
+	return hp
+}
+
+// MinDuration estimates the shortest time pacing allows for probing n ports
+// on one host.
+func (p *ScanPacing) MinDuration(n int) time.Duration {
+	if p == nil || n <= 1 {
+		return 0
+	}
+	return time.Duration(n-1) * p.Delay
+}
@@ -0,0 +1,91 @@
+package checker
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScanPacing_LimitsConcurrencyPerHost(t *testing.T) {
+	pacing := &ScanPacing{MaxPerHost: 2}
+	var active, peak atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, ok := pacing.acquire(context.Background(), "db.example.com")
+			if !ok {
+				t.Error("acquire failed")
+				return
+			}
+			n := active.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			active.Add(-1)
+			release()
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got != 2 {
+		t.Fatalf("peak concurrency = %d, want 2", got)
+	}
+}
+
+func TestScanPacing_DelayIsPerHost(t *testing.T) {
+	pacing := &ScanPacing{Delay: 30 * time.Millisecond}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		release, _ := pacing.acquire(context.Background(), "a.example.com")
+		release()
+	}
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Fatalf("three probes to one host took %v, want at least 60ms", elapsed)
+	}
+
+	start = time.Now()
+	release, _ := pacing.acquire(context.Background(), "b.example.com")
+	release()
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Fatalf("first probe to another host should not wait, took %v", elapsed)
+	}
+}
+
+func TestScanPacing_CancelledContext(t *testing.T) {
+	pacing := &ScanPacing{MaxPerHost: 1}
+	release, _ := pacing.acquire(context.Background(), "h")
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, ok := pacing.acquire(ctx, "h"); ok {
+		t.Fatal("acquire should fail once the context is cancelled")
+	}
+}
+
+func TestScanPacing_Order(t *testing.T) {
+	ports := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	var nilPacing *ScanPacing
+	if got := nilPacing.order(ports); !slices.Equal(got, ports) {
+		t.Fatalf("nil pacing should keep order, got %v", got)
+	}
+
+	shuffled := (&ScanPacing{Randomize: true}).order(ports)
+	sorted := slices.Clone(shuffled)
+	slices.Sort(sorted)
+	if !slices.Equal(sorted, ports) {
+		t.Fatalf("shuffle must keep every port, got %v", shuffled)
+	}
+	if !slices.Equal(ports, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}) {
+		t.Fatal("shuffle must not modify the input")
+	}
+}
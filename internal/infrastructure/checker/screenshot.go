@@ -0,0 +1,205 @@
+package checker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+const (
+	defaultScreenshotWidth  = 1280
+	defaultScreenshotHeight = 800
+	// thumbnailScale shrinks the viewport for the JPEG embedded in reports.
+	thumbnailScale   = 0.25
+	thumbnailQuality = 60
+)
+
+// ErrScreenshotOutOfScope is returned when a page redirected outside the
+// engagement scope before it could be captured. Nothing of it is kept.
+var ErrScreenshotOutOfScope = errors.New("redirected out of scope")
+
+// ScreenshotOptions configures ScreenshotCapturer.
+type ScreenshotOptions struct {
+	Width    int           // Viewport width in CSS pixels (default 1280)
+	Height   int           // Viewport height in CSS pixels (default 800)
+	WaitTime time.Duration // Time to let the page render before capturing
+	Timeout  time.Duration // Per-page limit for navigation and capture
+	Headers  http.Header
+	Proxy    *url.URL
+	// InScope reports whether the page a target ended up on, after HTTP and
+	// JavaScript redirects, may be captured. When nil only the target's own
+	// host may be.
+	InScope func(pageURL string) bool
+}
+
+// Screenshot is visual evidence of a page as rendered by the headless browser.
+type Screenshot struct {
+	URL        string
+	CapturedAt time.Time
+	PNG        []byte // Full viewport capture
+	Thumbnail  []byte // Downscaled JPEG for embedding in reports
+	SHA256     string // Hex digest of PNG
+}
+
+// renderedPage is what a screenshotDriver captured of one page.
+type renderedPage struct {
+	URL       string // Where the page ended up after redirects
+	PNG       []byte
+	Thumbnail []byte
+}
+
+// screenshotDriver renders pages for ScreenshotCapturer. chromeDriver is the
+// only real implementation; tests substitute their own.
+type screenshotDriver interface {
+	render(ctx context.Context, targetURL string, opts ScreenshotOptions) (*renderedPage, error)
+	close()
+}
+
+// ScreenshotCapturer renders pages in one headless browser, the same one the
+// JavaScript crawler uses, and captures the viewport of each.
+type ScreenshotCapturer struct {
+	driver screenshotDriver
+	opts   ScreenshotOptions
+}
+
+// NewScreenshotCapturer starts the headless browser, failing when it cannot
+// be started. Call Close when done.
+func NewScreenshotCapturer(ctx context.Context, opts ScreenshotOptions) (*ScreenshotCapturer, error) {
+	driver, err := startChromeDriver(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return newScreenshotCapturer(driver, opts), nil
+}
+
+func newScreenshotCapturer(driver screenshotDriver, opts ScreenshotOptions) *ScreenshotCapturer {
+	if opts.Width <= 0 {
+		opts.Width = defaultScreenshotWidth
+	}
+	if opts.Height <= 0 {
+		opts.Height = defaultScreenshotHeight
+	}
+	if opts.WaitTime <= 0 {
+		opts.WaitTime = 2 * time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 30 * time.Second
+	}
+	return &ScreenshotCapturer{driver: driver, opts: opts}
+}
+
+// Capture navigates to target and screenshots the viewport. It returns an
+// error wrapping ErrScreenshotOutOfScope when the page redirected out of
+// scope.
+func (c *ScreenshotCapturer) Capture(target string) (*Screenshot, error) {
+	targetURL := NormalizeHTTPTarget(target)
+	if targetURL == "" {
+		return nil, fmt.Errorf("invalid target %q", target)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.opts.Timeout)
+	defer cancel()
+	rendered, err := c.driver.render(ctx, targetURL, c.opts)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("screenshot %s: timed out after %s", targetURL, c.opts.Timeout)
+		}
+		return nil, fmt.Errorf("screenshot %s: %w", targetURL, err)
+	}
+	if !c.inScope(targetURL, rendered.URL) {
+		return nil, fmt.Errorf("screenshot %s: %w (%s)", targetURL, ErrScreenshotOutOfScope, rendered.URL)
+	}
+
+	shot := &Screenshot{URL: targetURL, PNG: rendered.PNG, Thumbnail: rendered.Thumbnail, CapturedAt: time.Now().UTC()}
+	sum := sha256.Sum256(shot.PNG)
+	shot.SHA256 = hex.EncodeToString(sum[:])
+	return shot, nil
+}
+
+func (c *ScreenshotCapturer) inScope(targetURL, pageURL string) bool {
+	if pageURL == "" || pageURL == targetURL {
+		return true
+	}
+	if c.opts.InScope != nil {
+		return c.opts.InScope(pageURL)
+	}
+	target, err := url.Parse(targetURL)
+	if err != nil {
+		return false
+	}
+	landed, err := url.Parse(pageURL)
+	return err == nil && hostsMatch(target, landed)
+}
+
+// Close shuts the browser down.
+func (c *ScreenshotCapturer) Close() {
+	if c != nil && c.driver != nil {
+		c.driver.close()
+	}
+}
+
+// chromeDriver renders pages in tabs of one headless Chrome.
+type chromeDriver struct {
+	browserCtx context.Context
+	cancel     context.CancelFunc
+}
+
+// startChromeDriver launches the browser now, so a missing or broken browser
+// is reported once instead of for every page.
+func startChromeDriver(ctx context.Context, opts ScreenshotOptions, extra ...chromedp.ExecAllocatorOption) (*chromeDriver, error) {
+	browserCtx, cancel, err := newBrowser(ctx, opts.Headers, opts.Proxy, extra...)
+	if err != nil {
+		return nil, err
+	}
+	if err := chromedp.Run(browserCtx); err != nil {
+		cancel()
+		return nil, fmt.Errorf("start headless browser: %w", err)
+	}
+	return &chromeDriver{browserCtx: browserCtx, cancel: cancel}, nil
+}
+
+func (d *chromeDriver) render(ctx context.Context, targetURL string, opts ScreenshotOptions) (*renderedPage, error) {
+	tabCtx, tabCancel := chromedp.NewContext(d.browserCtx)
+	defer tabCancel()
+	// The tab must also end when ctx does.
+	stop := context.AfterFunc(ctx, tabCancel)
+	defer stop()
+
+	rendered := &renderedPage{}
+	err := chromedp.Run(tabCtx,
+		chromedp.EmulateViewport(int64(opts.Width), int64(opts.Height)),
+		chromedp.Navigate(targetURL),
+		chromedp.Sleep(opts.WaitTime),
+		chromedp.Location(&rendered.URL),
+		chromedp.CaptureScreenshot(&rendered.PNG),
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			var err error
+			rendered.Thumbnail, err = page.CaptureScreenshot().
+				WithFormat(page.CaptureScreenshotFormatJpeg).
+				WithQuality(thumbnailQuality).
+				WithClip(&page.Viewport{
+					Width:  float64(opts.Width),
+					Height: float64(opts.Height),
+					Scale:  thumbnailScale,
+				}).
+				Do(ctx)
+			return err
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return rendered, nil
+}
+
+func (d *chromeDriver) close() {
+	d.cancel()
+}
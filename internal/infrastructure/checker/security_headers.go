@@ -12,6 +12,9 @@ type SecurityHeaderSpec struct {
 	MaxScore       int
 	CheckFunc      func(value string) (int, []string, string)
 	Recommendation string
+	// Optional headers are scored only when present and are not reported as
+	// missing.
+	Optional bool
 }
 
 // securityHeaderSpecs defines all security headers to check
@@ -79,6 +82,30 @@ var securityHeaderSpecs = map[string]SecurityHeaderSpec{
 		CheckFunc:      checkContentType,
 		Recommendation: "Add 'Content-Type' header with appropriate charset (e.g., 'text/html; charset=utf-8')",
 	},
+	"Reporting-Endpoints": {
+		Name:           "Reporting-Endpoints",
+		Severity:       "low",
+		MaxScore:       5,
+		CheckFunc:      checkReportingEndpoints,
+		Recommendation: "Add 'Reporting-Endpoints: default=\"https://example.com/reports\"' and reference it from the CSP report-to directive",
+		Optional:       true,
+	},
+	"Report-To": {
+		Name:           "Report-To",
+		Severity:       "low",
+		MaxScore:       5,
+		CheckFunc:      checkReportTo,
+		Recommendation: "Add 'Report-To: {\"group\":\"default\",\"max_age\":10886400,\"endpoints\":[{\"url\":\"https://example.com/reports\"}]}' for NEL",
+		Optional:       true,
+	},
+	"NEL": {
+		Name:           "NEL",
+		Severity:       "low",
+		MaxScore:       5,
+		CheckFunc:      checkNEL,
+		Recommendation: "Add 'NEL: {\"report_to\":\"default\",\"max_age\":2592000}' with a matching Report-To group to collect network error reports",
+		Optional:       true,
+	},
 }
 
 // informationDisclosureHeaders lists headers that should be removed/obfuscated
@@ -114,7 +141,9 @@ func AnalyzeSecurityHeaders(headers http.Header) *SecurityHeadersResult {
 				MaxScore:       spec.MaxScore,
 				Recommendation: spec.Recommendation,
 			}
-			result.Missing = append(result.Missing, headerName)
+			if !spec.Optional {
+				result.Missing = append(result.Missing, headerName)
+			}
 		} else {
 			// Header is present, evaluate it
 			score, issues, recommendation := spec.CheckFunc(value)
@@ -131,9 +160,17 @@ func AnalyzeSecurityHeaders(headers http.Header) *SecurityHeadersResult {
 
 			result.Headers[headerName] = status
 			totalScore += score
+			if spec.Optional {
+				result.MaxScore += spec.MaxScore
+			}
 		}
 	}
 
+	if csp := headers.Get("Content-Security-Policy"); csp != "" {
+		result.CSPFindings = EvaluateCSP(ParseCSP(csp))
+	}
+	totalScore -= checkReportingGroups(headers, result)
+
 	// Check for deprecated headers
 	checkDeprecatedHeaders(headers, result)
 
@@ -193,72 +230,19 @@ func checkHSTS(value string) (int, []string, string) {
 	return score, issues, recommendation
 }
 
-// checkCSP validates the Content-Security-Policy header
+// checkCSP validates the Content-Security-Policy header. Each non-info
+// finding from EvaluateCSP becomes an issue and costs points by severity.
 func checkCSP(value string) (int, []string, string) {
 	issues := []string{}
 	score := 20
 	recommendation := ""
 
-	value = strings.ToLower(value)
-	directives := parseCSPDirectives(value)
-
-	// Check for unsafe practices
-	if strings.Contains(value, "'unsafe-inline'") {
-		issues = append(issues, "Contains 'unsafe-inline' which weakens CSP protection")
-		score -= 5
-	}
-
-	if strings.Contains(value, "'unsafe-eval'") {
-		issues = append(issues, "Contains 'unsafe-eval' which allows eval() and similar functions")
-		score -= 5
-	}
-
-	if strings.Contains(value, "*") {
-		issues = append(issues, "Contains wildcard (*) which is too permissive")
-		score -= 3
-	}
-
-	// Check for essential directives
-	if !strings.Contains(value, "default-src") {
-		issues = append(issues, "Missing 'default-src' directive (recommended fallback)")
-		score -= 3
-	}
-
-	if !strings.Contains(value, "script-src") {
-		issues = append(issues, "Consider adding 'script-src' directive for script control")
-		score -= 2
-	}
-
-	// Additional bypass heuristics for script/style directives
-	scriptTokens := directives["script-src"]
-	for _, token := range scriptTokens {
-		switch token {
-		case "data:":
-			issues = append(issues, "Script sources allow data: URIs which can enable CSP bypasses")
-			score -= 2
-		case "blob:":
-			issues = append(issues, "Script sources allow blob: URLs which may enable CSP bypasses")
-			score -= 2
-		case "filesystem:":
-			issues = append(issues, "Script sources allow filesystem: URLs which may enable CSP bypasses")
-			score -= 2
-		}
-		if strings.HasPrefix(token, "http:") {
-			issues = append(issues, "Script sources allow insecure http scheme")
-			score -= 2
-		}
-	}
-
-	styleTokens := directives["style-src"]
-	for _, token := range styleTokens {
-		if token == "data:" {
-			issues = append(issues, "Style sources allow data: URIs which may allow inline style injection")
-			score -= 1
-		}
-		if token == "'unsafe-inline'" {
-			issues = append(issues, "Style sources permit 'unsafe-inline' which weakens CSP")
-			score -= 2
+	for _, finding := range EvaluateCSP(ParseCSP(value)) {
+		if finding.Severity == CSPSeverityInfo {
+			continue
 		}
+		issues = append(issues, finding.String())
+		score -= cspFindingPenalty[finding.Severity]
 	}
 
 	if len(issues) == 0 {
@@ -267,29 +251,11 @@ func checkCSP(value string) (int, []string, string) {
 		recommendation = "Review and strengthen your Content-Security-Policy"
 	}
 
-	return score, issues, recommendation
-}
-
-func parseCSPDirectives(value string) map[string][]string {
-	result := make(map[string][]string)
-	parts := strings.Split(value, ";")
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		if part == "" {
-			continue
-		}
-		fields := strings.Fields(part)
-		if len(fields) == 0 {
-			continue
-		}
-		name := fields[0]
-		if len(fields) > 1 {
-			result[name] = fields[1:]
-		} else {
-			result[name] = []string{}
-		}
+	if score < 0 {
+		score = 0
 	}
-	return result
+
+	return score, issues, recommendation
 }
 
 // checkXFrameOptions validates the X-Frame-Options header
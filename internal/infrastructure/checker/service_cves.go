@@ -0,0 +1,321 @@
+package checker
+
+import (
+	"bufio"
+	"crypto/tls"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed data/service_cves.json
+var serviceCVESnapshotJSON []byte
+
+// ServiceCVE is a known vulnerability affecting a product version
+// advertised in a port banner.
+type ServiceCVE struct {
+	Port         int     `json:"port"`
+	Product      string  `json:"product"`
+	Version      string  `json:"version"`
+	CPE          string  `json:"cpe"`
+	CVE          string  `json:"cve"`
+	Summary      string  `json:"summary"`
+	CVSS         float64 `json:"cvss"`
+	Vector       string  `json:"vector"`
+	Severity     string  `json:"severity"` // "critical", "high", "medium", "low"
+	FixedVersion string  `json:"fixed_version,omitempty"`
+	Confidence   string  `json:"confidence"` // "high", or "medium" when the banner names a distribution that backports fixes
+}
+
+type serviceCVESnapshot struct {
+	Snapshot string              `json:"snapshot"`
+	Products []serviceCVEProduct `json:"products"`
+}
+
+type serviceCVEProduct struct {
+	CPE     string            `json:"cpe"`
+	Name    string            `json:"name"`
+	Pattern string            `json:"pattern"`
+	CVEs    []serviceCVEEntry `json:"cves"`
+
+	re *regexp.Regexp
+}
+
+type serviceCVEEntry struct {
+	ID       string               `json:"id"`
+	Summary  string               `json:"summary"`
+	CVSS     float64              `json:"cvss"`
+	Vector   string               `json:"vector"`
+	Affected []serviceCVEAffected `json:"affected"`
+}
+
+// serviceCVEAffected is a half-open version range [Introduced, Fixed). An
+// empty Introduced means every earlier version.
+type serviceCVEAffected struct {
+	Introduced string `json:"introduced,omitempty"`
+	Fixed      string `json:"fixed"`
+}
+
+var (
+	serviceCVEOnce sync.Once
+	serviceCVEData serviceCVESnapshot
+)
+
+// distroBackportMarkers appear in banners of distribution packages, which
+// often carry security fixes without changing the upstream version.
+var distroBackportMarkers = []string{"ubuntu", "debian", "deb", "red hat", "centos", "el7", "el8", "el9", "freebsd", "raspbian"}
+
+// loadServiceCVESnapshot parses the bundled CPE to CVE snapshot once.
+func loadServiceCVESnapshot() *serviceCVESnapshot {
+	serviceCVEOnce.Do(func() {
+		if err := json.Unmarshal(serviceCVESnapshotJSON, &serviceCVEData); err != nil {
+			panic(fmt.Sprintf("invalid embedded service CVE snapshot: %v", err))
+		}
+		for i := range serviceCVEData.Products {
+			product := &serviceCVEData.Products[i]
+			product.re = regexp.MustCompile("(?i)" + product.Pattern)
+		}
+	})
+	return &serviceCVEData
+}
+
+// ServiceCVESnapshotDate returns the date of the bundled CVE snapshot.
+func ServiceCVESnapshotDate() string {
+	return loadServiceCVESnapshot().Snapshot
+}
+
+// CorrelateServiceCVEs extracts product versions from the banners of ports
+// and returns the bundled CVEs affecting them, highest CVSS first. A banner
+// may name several products, e.g. "Apache/2.4.49 (Unix) OpenSSL/1.0.1f".
+func CorrelateServiceCVEs(ports []PortInfo) []ServiceCVE {
+	snapshot := loadServiceCVESnapshot()
+
+	var findings []ServiceCVE
+	for _, port := range ports {
+		if port.Banner == "" {
+			continue
+		}
+		confidence := "high"
+		lower := strings.ToLower(port.Banner)
+		for _, marker := range distroBackportMarkers {
+			if strings.Contains(lower, marker) {
+				confidence = "medium"
+				break
+			}
+		}
+		for _, product := range snapshot.Products {
+			m := product.re.FindStringSubmatch(port.Banner)
+			if m == nil {
+				continue
+			}
+			version := strings.ToLower(m[1])
+			for _, cve := range product.CVEs {
+				fixed, ok := cve.affects(version)
+				if !ok {
+					continue
+				}
+				findings = append(findings, ServiceCVE{
+					Port:         port.Port,
+					Product:      product.Name,
+					Version:      version,
+					CPE:          fmt.Sprintf("%s:%s", product.CPE, version),
+					CVE:          cve.ID,
+					Summary:      cve.Summary,
+					CVSS:         cve.CVSS,
+					Vector:       cve.Vector,
+					Severity:     cvssSeverity(cve.CVSS),
+					FixedVersion: fixed,
+					Confidence:   confidence,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].CVSS > findings[j].CVSS
+	})
+	return findings
+}
+
+// affects reports whether version falls in one of the affected ranges and
+// returns that range's fixed version.
+func (e serviceCVEEntry) affects(version string) (string, bool) {
+	for _, r := range e.Affected {
+		if r.Introduced != "" && compareServiceVersion(version, r.Introduced) < 0 {
+			continue
+		}
+		if compareServiceVersion(version, r.Fixed) < 0 {
+			return r.Fixed, true
+		}
+	}
+	return "", false
+}
+
+// compareServiceVersion compares versions that mix numbers and letter
+// suffixes, such as "8.9p1" or "1.0.1f". Returns -1, 0 or 1. A version with
+// extra components sorts after its prefix ("1.0.1" < "1.0.1f").
+func compareServiceVersion(v1, v2 string) int {
+	t1, t2 := versionTokens(v1), versionTokens(v2)
+	for i := 0; i < len(t1) && i < len(t2); i++ {
+		n1, err1 := strconv.Atoi(t1[i])
+		n2, err2 := strconv.Atoi(t2[i])
+		switch {
+		case err1 == nil && err2 == nil:
+			if n1 != n2 {
+				if n1 < n2 {
+					return -1
+				}
+				return 1
+			}
+		case err1 == nil:
+			return 1
+		case err2 == nil:
+			return -1
+		default:
+			if c := strings.Compare(t1[i], t2[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	switch {
+	case len(t1) < len(t2):
+		return -1
+	case len(t1) > len(t2):
+		return 1
+	}
+	return 0
+}
+
+// versionTokens splits a version into runs of digits and runs of letters.
+func versionTokens(v string) []string {
+	var tokens []string
+	var current strings.Builder
+	digit := false
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+	for _, r := range strings.ToLower(v) {
+		switch {
+		case r >= '0' && r <= '9':
+			if !digit {
+				flush()
+			}
+			digit = true
+			current.WriteRune(r)
+		case r >= 'a' && r <= 'z':
+			if digit {
+				flush()
+			}
+			digit = false
+			current.WriteRune(r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
+
+// cvssSeverity maps a CVSS v3 base score to its qualitative rating.
+func cvssSeverity(score float64) string {
+	switch {
+	case score >= 9.0:
+		return "critical"
+	case score >= 7.0:
+		return "high"
+	case score >= 4.0:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// httpBannerPorts maps ports where the server waits for a request to
+// whether they use TLS; their banner is the Server response header.
+var httpBannerPorts = map[int]bool{80: false, 8080: false, 443: true, 8443: true}
+
+// grabHTTPServerHeader sends a HEAD request over conn (wrapped in TLS when
+// useTLS is set) and returns the Server header.
+func grabHTTPServerHeader(conn net.Conn, host string, useTLS bool) string {
+	_ = conn.SetDeadline(time.Now().Add(2 * time.Second))
+	if useTLS {
+		tlsConn := tls.Client(conn, &tls.Config{
+			ServerName: host,
+			// #nosec G402 -- only the Server header is read; trust is not implied.
+			InsecureSkipVerify: true,
+		})
+		if err := tlsConn.Handshake(); err != nil {
+			return ""
+		}
+		conn = tlsConn
+	}
+	if _, err := fmt.Fprintf(conn, "HEAD / HTTP/1.0\r\nHost: %s\r\n\r\n", host); err != nil {
+		return ""
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	if err != nil {
+		return ""
+	}
+	_ = resp.Body.Close()
+	return resp.Header.Get("Server")
+}
+
+// analyzeServiceCVEs converts service CVE correlations into vulnerabilities,
+// one per CVE.
+func analyzeServiceCVEs(findings []ServiceCVE) []Vulnerability {
+	var vulns []Vulnerability
+	for _, f := range findings {
+		severity := strings.ToUpper(f.Severity[:1]) + f.Severity[1:]
+		note := ""
+		if f.Confidence != "high" {
+			note = "\n\nNote: the banner names a distribution package. Distributions often backport fixes without changing the upstream version, so confirm the installed package's patch level before remediating."
+		}
+		vulns = append(vulns, Vulnerability{
+			Name:     fmt.Sprintf("Known Vulnerable Service Version: %s (%s)", f.Product, f.CVE),
+			Category: "Network Security",
+			Severity: severity,
+			Score:    0,
+			MaxScore: 10,
+			Status:   "Failed",
+			Description: fmt.Sprintf("Port %d advertises %s %s, which is affected by %s: %s",
+				f.Port, f.Product, f.Version, f.CVE, f.Summary),
+			Recommendation: fmt.Sprintf(`%s: Known vulnerable service version detected from banner.
+
+Detected:
+• Port: %d
+• Product: %s %s
+• CPE: %s
+• CVE: %s (CVSS %.1f)
+• Fixed in: %s
+• Confidence: %s
+
+Recommended Actions:
+1. Upgrade %s to %s or later (or the vendor's patched package)
+2. Restrict network access to the service while patching
+3. Suppress version details in the banner once patched%s`,
+				strings.ToUpper(f.Severity), f.Port, f.Product, f.Version, f.CPE, f.CVE, f.CVSS,
+				f.FixedVersion, f.Confidence, f.Product, f.FixedVersion, note),
+			CVSS: &CVSSScore{
+				BaseScore: f.CVSS,
+				Severity:  strings.ToUpper(f.Severity),
+				Vector:    f.Vector,
+				Version:   "3.1",
+			},
+			References: []string{
+				"https://nvd.nist.gov/vuln/detail/" + f.CVE,
+			},
+		})
+	}
+	return vulns
+}
@@ -0,0 +1,94 @@
+package checker
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCompareServiceVersion(t *testing.T) {
+	tests := []struct {
+		v1, v2 string
+		want   int
+	}{
+		{"8.9p1", "9.8p1", -1},
+		{"9.8p1", "9.8p1", 0},
+		{"9.8", "9.8p1", -1},
+		{"1.0.1f", "1.0.1g", -1},
+		{"1.0.1", "1.0.1f", -1},
+		{"1.0.2zd", "1.0.2h", 1},
+		{"2.4.10", "2.4.9", 1},
+	}
+	for _, tt := range tests {
+		if got := compareServiceVersion(tt.v1, tt.v2); got != tt.want {
+			t.Errorf("compareServiceVersion(%q, %q) = %d, want %d", tt.v1, tt.v2, got, tt.want)
+		}
+	}
+}
+
+func TestCorrelateServiceCVEs(t *testing.T) {
+	ports := []PortInfo{
+		{Port: 22, Banner: "SSH-2.0-OpenSSH_8.9p1 Ubuntu-3ubuntu0.1"},
+		{Port: 443, Banner: "Apache/2.4.49 (Unix) OpenSSL/1.0.1f"},
+		{Port: 21, Banner: "220 (vsFTPd 3.0.5)"},
+	}
+
+	findings := CorrelateServiceCVEs(ports)
+
+	byCVE := make(map[string]ServiceCVE)
+	for _, f := range findings {
+		byCVE[f.CVE] = f
+	}
+	for _, id := range []string{"CVE-2024-6387", "CVE-2021-42013", "CVE-2021-41773", "CVE-2014-0160"} {
+		if _, ok := byCVE[id]; !ok {
+			t.Errorf("expected %s, got %+v", id, findings)
+		}
+	}
+	if f := byCVE["CVE-2024-6387"]; f.Confidence != "medium" || f.FixedVersion != "9.8p1" || f.CPE != "cpe:2.3:a:openbsd:openssh:8.9p1" {
+		t.Errorf("unexpected regreSSHion finding %+v", f)
+	}
+	if _, ok := byCVE["CVE-2018-15473"]; ok {
+		t.Error("OpenSSH 8.9p1 is not affected by CVE-2018-15473")
+	}
+	if _, ok := byCVE["CVE-2011-2523"]; ok {
+		t.Error("vsftpd 3.0.5 is not the backdoored release")
+	}
+	if findings[0].Severity != "critical" {
+		t.Errorf("findings should be sorted by CVSS, first is %+v", findings[0])
+	}
+
+	vulns := analyzeServiceCVEs(findings)
+	if len(vulns) != len(findings) || !strings.HasPrefix(vulns[0].Name, "Known Vulnerable Service Version: ") {
+		t.Fatalf("expected one vulnerability per CVE, got %+v", vulns)
+	}
+}
+
+func TestGrabHTTPServerHeader(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server", "nginx/1.18.0")
+	})
+
+	for _, tc := range []struct {
+		name   string
+		server *httptest.Server
+		tls    bool
+	}{
+		{"plain", httptest.NewServer(handler), false},
+		{"tls", httptest.NewTLSServer(handler), true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer tc.server.Close()
+			conn, err := net.Dial("tcp", tc.server.Listener.Addr().String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			if got := grabHTTPServerHeader(conn, "127.0.0.1", tc.tls); got != "nginx/1.18.0" {
+				t.Fatalf("Server header = %q", got)
+			}
+		})
+	}
+}
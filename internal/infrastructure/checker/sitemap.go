@@ -0,0 +1,170 @@
+package checker
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// maxSitemapFiles bounds how many sitemap and sitemap index files are read per target.
+	maxSitemapFiles = 10
+	maxSitemapBytes = 10 * 1024 * 1024
+)
+
+// sitemapDocument covers both <urlset> and <sitemapindex> documents.
+type sitemapDocument struct {
+	XMLName  xml.Name
+	URLs     []sitemapLoc `xml:"url"`
+	Sitemaps []sitemapLoc `xml:"sitemap"`
+}
+
+type sitemapLoc struct {
+	Loc string `xml:"loc"`
+}
+
+// ParseSitemap decodes a sitemap (optionally gzip-compressed). It returns the
+// page URLs of a <urlset> and the child sitemap URLs of a <sitemapindex>.
+func ParseSitemap(data []byte) (pages []string, children []string, err error) {
+	if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, nil, err
+		}
+		defer zr.Close()
+		data, err = io.ReadAll(io.LimitReader(zr, maxSitemapBytes))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var doc sitemapDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("parse sitemap: %w", err)
+	}
+	for _, u := range doc.URLs {
+		if loc := strings.TrimSpace(u.Loc); loc != "" {
+			pages = append(pages, loc)
+		}
+	}
+	for _, s := range doc.Sitemaps {
+		if loc := strings.TrimSpace(s.Loc); loc != "" {
+			children = append(children, loc)
+		}
+	}
+	return pages, children, nil
+}
+
+// sitemapSeeds reads the sitemaps advertised in robots.txt (or /sitemap.xml)
+// and returns up to limit canonical in-scope page URLs.
+func sitemapSeeds(ctx context.Context, client *http.Client, root *url.URL, gate *robotsGate, opts CrawlOptions, limit int) []string {
+	if limit <= 0 {
+		return nil
+	}
+
+	var queue []string
+	if gate != nil {
+		queue = append(queue, gate.rules.Sitemaps...)
+	} else if rules, err := FetchRobots(ctx, client, root); err == nil {
+		queue = append(queue, rules.Sitemaps...)
+	}
+	if len(queue) == 0 {
+		queue = append(queue, (&url.URL{Scheme: root.Scheme, Host: root.Host, Path: "/sitemap.xml"}).String())
+	}
+
+	fetched := make(map[string]struct{})
+	seen := map[string]struct{}{canonicalURL(root): {}}
+	seeds := make([]string, 0, limit)
+
+	for len(queue) > 0 && len(fetched) < maxSitemapFiles && len(seeds) < limit {
+		raw := queue[0]
+		queue = queue[1:]
+
+		sitemapURL, err := url.Parse(raw)
+		if err != nil || !hostsMatch(root, sitemapURL) {
+			continue
+		}
+		if _, ok := fetched[sitemapURL.String()]; ok {
+			continue
+		}
+		fetched[sitemapURL.String()] = struct{}{}
+
+		if err := gate.wait(ctx); err != nil {
+			break
+		}
+		data, err := fetchSitemap(ctx, client, sitemapURL.String())
+		if err != nil {
+			continue
+		}
+		pages, children, err := ParseSitemap(data)
+		if err != nil {
+			continue
+		}
+		queue = append(queue, children...)
+
+		for _, page := range pages {
+			u, err := url.Parse(page)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+				continue
+			}
+			if !opts.inScope(root, u) {
+				continue
+			}
+			if looksLikeAsset(u.Path) || !gate.allowed(u) {
+				continue
+			}
+			key := canonicalURL(u)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			seeds = append(seeds, key)
+			if len(seeds) >= limit {
+				break
+			}
+		}
+	}
+	return seeds
+}
+
+// seedFromSitemaps appends sitemap pages not yet seen to discovered and hands
+// each to enqueue (when MaxDepth allows following their links).
+func seedFromSitemaps(ctx context.Context, client *http.Client, root *url.URL, gate *robotsGate, opts CrawlOptions, seen map[string]struct{}, discovered []string, enqueue func(*url.URL)) []string {
+	for _, seed := range sitemapSeeds(ctx, client, root, gate, opts, opts.MaxPages-len(discovered)) {
+		if _, ok := seen[seed]; ok {
+			continue
+		}
+		u, err := url.Parse(seed)
+		if err != nil {
+			continue
+		}
+		seen[seed] = struct{}{}
+		discovered = append(discovered, seed)
+		if opts.MaxDepth > 1 {
+			enqueue(u)
+		}
+	}
+	return discovered
+}
+
+func fetchSitemap(ctx context.Context, client *http.Client, target string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxSitemapBytes))
+}
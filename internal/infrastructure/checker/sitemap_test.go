@@ -0,0 +1,128 @@
+package checker
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseSitemap_URLSetAndIndex(t *testing.T) {
+	pages, children, err := ParseSitemap([]byte(`<?xml version="1.0"?><urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><url><loc> https://example.com/a </loc></url><url><loc>https://example.com/b</loc></url></urlset>`))
+	if err != nil {
+		t.Fatalf("ParseSitemap: %v", err)
+	}
+	if len(pages) != 2 || pages[0] != "https://example.com/a" || len(children) != 0 {
+		t.Fatalf("unexpected urlset parse: pages=%v children=%v", pages, children)
+	}
+
+	pages, children, err = ParseSitemap([]byte(`<sitemapindex><sitemap><loc>https://example.com/s1.xml</loc></sitemap></sitemapindex>`))
+	if err != nil {
+		t.Fatalf("ParseSitemap: %v", err)
+	}
+	if len(pages) != 0 || len(children) != 1 || children[0] != "https://example.com/s1.xml" {
+		t.Fatalf("unexpected index parse: pages=%v children=%v", pages, children)
+	}
+}
+
+func TestParseSitemap_Gzip(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	fmt.Fprint(zw, `<urlset><url><loc>https://example.com/zipped</loc></url></urlset>`)
+	zw.Close()
+
+	pages, _, err := ParseSitemap(buf.Bytes())
+	if err != nil {
+		t.Fatalf("ParseSitemap: %v", err)
+	}
+	if len(pages) != 1 || pages[0] != "https://example.com/zipped" {
+		t.Fatalf("unexpected pages %v", pages)
+	}
+}
+
+func TestDiscoverInScopeLinks_SeedsFromSitemapIndex(t *testing.T) {
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "User-agent: *\nDisallow: /secret\nSitemap: %s/sitemap_index.xml\n", server.URL)
+	})
+	mux.HandleFunc("/sitemap_index.xml", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<sitemapindex><sitemap><loc>%s/pages.xml</loc></sitemap><sitemap><loc>https://elsewhere.example/pages.xml</loc></sitemap></sitemapindex>`, server.URL)
+	})
+	mux.HandleFunc("/pages.xml", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<urlset>
+<url><loc>%[1]s/deep/a/b/c</loc></url>
+<url><loc>%[1]s/secret/page</loc></url>
+<url><loc>%[1]s/logo.png</loc></url>
+<url><loc>https://other.example/page</loc></url>
+<url><loc>%[1]s/deep/x/y/z</loc></url>
+</urlset>`, server.URL)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<a href="/about">About</a>`)
+	})
+	server = httptest.NewServer(mux)
+	defer server.Close()
+
+	opts := CrawlOptions{MaxDepth: 1, MaxPages: 10, SameHostOnly: true, Timeout: time.Second, UseSitemaps: true}
+	links, err := DiscoverInScopeLinks(context.Background(), server.URL, opts)
+	if err != nil {
+		t.Fatalf("crawl failed: %v", err)
+	}
+	want := []string{server.URL + "/deep/a/b/c", server.URL + "/deep/x/y/z", server.URL + "/about"}
+	if len(links) != len(want) {
+		t.Fatalf("expected %v, got %v", want, links)
+	}
+	for i := range want {
+		if links[i] != want[i] {
+			t.Errorf("link %d: want %s, got %s", i, want[i], links[i])
+		}
+	}
+
+	opts.MaxPages = 1
+	links, err = DiscoverInScopeLinks(context.Background(), server.URL, opts)
+	if err != nil {
+		t.Fatalf("crawl failed: %v", err)
+	}
+	if len(links) != 1 {
+		t.Fatalf("expected page cap of 1 to be enforced, got %v", links)
+	}
+}
+
+func TestDiscoverInScopeLinks_DefaultSitemapLocation(t *testing.T) {
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	mux.HandleFunc("/sitemap.xml", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<urlset><url><loc>%s/from-sitemap</loc></url></urlset>`, server.URL)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `no links`)
+	})
+	server = httptest.NewServer(mux)
+	defer server.Close()
+
+	opts := CrawlOptions{MaxDepth: 1, MaxPages: 10, SameHostOnly: true, Timeout: time.Second}
+	links, err := DiscoverInScopeLinks(context.Background(), server.URL, opts)
+	if err != nil {
+		t.Fatalf("crawl failed: %v", err)
+	}
+	if len(links) != 0 {
+		t.Fatalf("expected no sitemap seeding when disabled, got %v", links)
+	}
+
+	opts.UseSitemaps = true
+	links, err = DiscoverInScopeLinks(context.Background(), server.URL, opts)
+	if err != nil {
+		t.Fatalf("crawl failed: %v", err)
+	}
+	if len(links) != 1 || links[0] != server.URL+"/from-sitemap" {
+		t.Fatalf("expected sitemap page, got %v", links)
+	}
+}
@@ -0,0 +1,299 @@
+package checker
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SmugglingPosture records what a response reveals about the HTTP chain in
+// front of a site: request smuggling needs a front end and a back end that
+// parse request boundaries differently. It is built from headers only; no
+// ambiguous requests are sent.
+type SmugglingPosture struct {
+	// Protocol is the protocol the front end answered with (e.g. HTTP/1.1).
+	Protocol string `json:"protocol"`
+	// KeepAlive is true when the connection stays open for further requests,
+	// which is what lets one request's leftover bytes prefix the next.
+	KeepAlive bool `json:"keep_alive"`
+	// Chunked is true when the response used chunked transfer encoding.
+	Chunked bool `json:"chunked"`
+	// Frontends are the proxies, caches, and CDNs seen in Via and
+	// vendor-specific headers, outermost first.
+	Frontends []string `json:"frontends,omitempty"`
+	// Backend is the origin server named by Server or X-Powered-By, when it
+	// is not one of the front ends.
+	Backend     string         `json:"backend,omitempty"`
+	KnownIssues []SmugglingCVE `json:"known_issues,omitempty"`
+}
+
+// SmugglingCVE is a published request smuggling flaw in a product version
+// named in the response headers.
+type SmugglingCVE struct {
+	Product      string `json:"product"`
+	Version      string `json:"version"`
+	CVE          string `json:"cve"`
+	Summary      string `json:"summary"`
+	FixedVersion string `json:"fixed_version"`
+}
+
+// MultiTier reports whether the response passed through a front end before
+// reaching the server that generated it.
+func (p *SmugglingPosture) MultiTier() bool {
+	return p != nil && len(p.Frontends) > 0
+}
+
+// frontendHeaders are headers only set by a particular proxy or CDN.
+var frontendHeaders = []struct{ header, name string }{
+	{"CF-Ray", "Cloudflare"},
+	{"X-Amz-Cf-Id", "Amazon CloudFront"},
+	{"X-Azure-Ref", "Azure Front Door"},
+	{"X-Akamai-Request-ID", "Akamai"},
+	{"X-Fastly-Request-ID", "Fastly"},
+	{"X-Varnish", "Varnish"},
+}
+
+// frontendServers are Server values sent by the front end itself rather
+// than by the origin.
+var frontendServers = map[string]string{
+	"cloudflare":      "Cloudflare",
+	"cloudfront":      "Amazon CloudFront",
+	"akamaighost":     "Akamai",
+	"google frontend": "Google Front End",
+	"gws":             "Google Front End",
+	"awselb":          "AWS Elastic Load Balancing",
+}
+
+// frontendKeys are the words that identify a front end also named in Via,
+// so one CDN is not listed twice ("CloudFront" and "Amazon CloudFront").
+var frontendKeys = map[string]string{
+	"Amazon CloudFront":          "cloudfront",
+	"Azure Front Door":           "azure",
+	"Google Front End":           "google",
+	"AWS Elastic Load Balancing": "awselb",
+}
+
+// smugglingProducts are proxies and servers with published request
+// smuggling flaws, matched against Server, Via, and X-Powered-By. The
+// pattern's first group is the version.
+var smugglingProducts = []struct {
+	name string
+	re   *regexp.Regexp
+	cves []serviceCVEEntry
+}{
+	{"Apache HTTP Server", regexp.MustCompile(`(?i)\bApache/(\d+\.\d+\.\d+)`), []serviceCVEEntry{
+		{ID: "CVE-2023-25690", Summary: "mod_proxy with RewriteRule or ProxyPassMatch forwards request splitting through unescaped URL data",
+			Affected: []serviceCVEAffected{{Introduced: "2.4.0", Fixed: "2.4.56"}}},
+		{ID: "CVE-2022-22720", Summary: "connection not closed when discarding a request body after an error",
+			Affected: []serviceCVEAffected{{Fixed: "2.4.53"}}},
+	}},
+	{"nginx", regexp.MustCompile(`(?i)\bnginx/(\d+\.\d+\.\d+)`), []serviceCVEEntry{
+		{ID: "CVE-2019-20372", Summary: "error_page redirects allow a smuggled second request",
+			Affected: []serviceCVEAffected{{Fixed: "1.17.7"}}},
+	}},
+	{"HAProxy", regexp.MustCompile(`(?i)\bhaproxy/(\d+\.\d+\.\d+)`), []serviceCVEEntry{
+		{ID: "CVE-2021-40346", Summary: "integer overflow in header length lets a Content-Length header bypass validation",
+			Affected: []serviceCVEAffected{
+				{Introduced: "2.0", Fixed: "2.0.25"},
+				{Introduced: "2.2", Fixed: "2.2.17"},
+				{Introduced: "2.3", Fixed: "2.3.14"},
+				{Introduced: "2.4", Fixed: "2.4.4"},
+			}},
+	}},
+	{"Apache Traffic Server", regexp.MustCompile(`(?i)\bATS/(\d+\.\d+\.\d+)`), []serviceCVEEntry{
+		{ID: "CVE-2021-37147", Summary: "improper header parsing allows request smuggling",
+			Affected: []serviceCVEAffected{{Fixed: "8.1.3"}, {Introduced: "9.0.0", Fixed: "9.1.1"}}},
+	}},
+	{"Varnish Cache", regexp.MustCompile(`(?i)\bvarnish/(\d+\.\d+(?:\.\d+)?)`), []serviceCVEEntry{
+		{ID: "CVE-2022-23959", Summary: "HTTP/1 request body handling allows request smuggling",
+			Affected: []serviceCVEAffected{{Fixed: "6.0.10"}, {Introduced: "6.1", Fixed: "7.0.2"}}},
+	}},
+	{"Squid", regexp.MustCompile(`(?i)\bsquid/(\d+\.\d+(?:\.\d+)?)`), []serviceCVEEntry{
+		{ID: "CVE-2020-15810", Summary: "header content validation allows HTTP request smuggling and cache poisoning",
+			Affected: []serviceCVEAffected{{Fixed: "4.13"}}},
+	}},
+	{"Gunicorn", regexp.MustCompile(`(?i)\bgunicorn/(\d+\.\d+\.\d+)`), []serviceCVEEntry{
+		{ID: "CVE-2024-1135", Summary: "Transfer-Encoding headers are not validated, allowing TE.CL desync",
+			Affected: []serviceCVEAffected{{Fixed: "22.0.0"}}},
+	}},
+}
+
+// AnalyzeSmugglingPosture reads the response's protocol, connection
+// handling, and the products in its headers. It only inspects the response.
+func AnalyzeSmugglingPosture(resp *http.Response) *SmugglingPosture {
+	if resp == nil {
+		return nil
+	}
+	p := &SmugglingPosture{
+		Protocol:  resp.Proto,
+		KeepAlive: !resp.Close && resp.ProtoAtLeast(1, 1),
+	}
+	for _, te := range resp.TransferEncoding {
+		if strings.EqualFold(te, "chunked") {
+			p.Chunked = true
+		}
+	}
+
+	addFrontend := func(name string) {
+		if name == "" {
+			return
+		}
+		key := strings.ToLower(name)
+		if k, ok := frontendKeys[name]; ok {
+			key = k
+		}
+		for _, existing := range p.Frontends {
+			if strings.Contains(strings.ToLower(existing), key) {
+				return
+			}
+		}
+		p.Frontends = append(p.Frontends, name)
+	}
+	// Via lists the proxies in the order they handled the response, so the
+	// one closest to the client comes last.
+	via := viaProxies(resp.Header.Values("Via"))
+	for i := len(via) - 1; i >= 0; i-- {
+		addFrontend(via[i])
+	}
+	for _, h := range frontendHeaders {
+		if resp.Header.Get(h.header) != "" {
+			addFrontend(h.name)
+		}
+	}
+	server := strings.TrimSpace(resp.Header.Get("Server"))
+	if name, ok := frontendServers[strings.ToLower(server)]; ok {
+		addFrontend(name)
+	} else if server != "" {
+		p.Backend = server
+	}
+	if p.Backend == "" {
+		p.Backend = strings.TrimSpace(resp.Header.Get("X-Powered-By"))
+	}
+
+	var banners []string
+	banners = append(banners, resp.Header.Values("Server")...)
+	banners = append(banners, resp.Header.Values("Via")...)
+	banners = append(banners, resp.Header.Values("X-Powered-By")...)
+	p.KnownIssues = smugglingCVEs(strings.Join(banners, " "))
+	return p
+}
+
+// viaProxies returns the name of each proxy in Via headers, preferring the
+// product in the comment ("1.1 varnish (Varnish/6.0)") over the pseudonym.
+func viaProxies(values []string) []string {
+	var proxies []string
+	for _, value := range values {
+		for _, entry := range strings.Split(value, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			if open := strings.Index(entry, "("); open >= 0 {
+				if comment := strings.Trim(strings.TrimSpace(entry[open:]), "()"); comment != "" {
+					proxies = append(proxies, comment)
+					continue
+				}
+				entry = strings.TrimSpace(entry[:open])
+			}
+			fields := strings.Fields(entry)
+			if len(fields) >= 2 {
+				proxies = append(proxies, fields[1])
+			}
+		}
+	}
+	return proxies
+}
+
+// smugglingCVEs matches the products and versions named in banners against
+// the known request smuggling flaws.
+func smugglingCVEs(banners string) []SmugglingCVE {
+	var issues []SmugglingCVE
+	for _, product := range smugglingProducts {
+		m := product.re.FindStringSubmatch(banners)
+		if m == nil {
+			continue
+		}
+		version := strings.ToLower(m[1])
+		for _, cve := range product.cves {
+			if fixed, ok := cve.affects(version); ok {
+				issues = append(issues, SmugglingCVE{
+					Product:      product.name,
+					Version:      version,
+					CVE:          cve.ID,
+					Summary:      cve.Summary,
+					FixedVersion: fixed,
+				})
+			}
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Product < issues[j].Product })
+	return issues
+}
+
+// analyzeSmugglingPosture converts the smuggling posture into informational
+// vulnerabilities: the multi-tier chain that smuggling depends on, and each
+// known flaw in a product of the chain.
+func analyzeSmugglingPosture(p *SmugglingPosture) []Vulnerability {
+	var vulns []Vulnerability
+	references := []string{
+		"https://portswigger.net/web-security/request-smuggling",
+		"https://www.rfc-editor.org/rfc/rfc9112#section-6.1",
+	}
+	if p.MultiTier() {
+		chain := strings.Join(p.Frontends, " -> ")
+		if p.Backend != "" {
+			chain += " -> " + p.Backend
+		}
+		var traits []string
+		if strings.HasPrefix(p.Protocol, "HTTP/2") {
+			traits = append(traits, "the front end speaks HTTP/2 to clients and likely downgrades to HTTP/1.1 for the back end (H2.CL/H2.TE desync)")
+		} else if p.KeepAlive {
+			traits = append(traits, p.Protocol+" with persistent connections")
+		}
+		if p.Chunked {
+			traits = append(traits, "chunked transfer encoding in use")
+		}
+		description := fmt.Sprintf("Requests pass through %d front end(s) before the origin (%s). Request smuggling needs two HTTP parsers that disagree on where a request ends; this chain has them.", len(p.Frontends), chain)
+		if len(traits) > 0 {
+			description += " Observed: " + strings.Join(traits, "; ") + "."
+		}
+		vulns = append(vulns, Vulnerability{
+			Name:        "HTTP Request Smuggling Posture: Multi-Tier Chain",
+			Category:    "Request Smuggling",
+			Severity:    "Info",
+			Score:       8,
+			MaxScore:    10,
+			Status:      "Warning",
+			Description: description,
+			Recommendation: fmt.Sprintf(`INFO: Proxy chain detected: %s
+
+Recommended Actions:
+1. Keep every tier patched; most smuggling bugs are fixed parser flaws
+2. Have the front end normalize ambiguous requests: reject both Content-Length and Transfer-Encoding, and obfuscated Transfer-Encoding values
+3. Use HTTP/2 end to end, or disable connection reuse between front end and back end
+4. Test with an authorized smuggling scanner in a controlled window; this check sends no ambiguous requests`, chain),
+			References: references,
+		})
+	}
+	for _, issue := range p.KnownIssues {
+		vulns = append(vulns, Vulnerability{
+			Name:     fmt.Sprintf("Request Smuggling Flaw in %s %s (%s)", issue.Product, issue.Version, issue.CVE),
+			Category: "Request Smuggling",
+			Severity: "Info",
+			Score:    8,
+			MaxScore: 10,
+			Status:   "Warning",
+			Description: fmt.Sprintf("The headers name %s %s, which is affected by %s: %s. Distributions may have backported the fix without changing the version.",
+				issue.Product, issue.Version, issue.CVE, issue.Summary),
+			Recommendation: fmt.Sprintf(`INFO: %s %s has a published request smuggling flaw (%s).
+
+Recommended Actions:
+1. Upgrade %s to %s or later, or confirm the installed package carries the fix
+2. Remove version numbers from Server and Via headers`, issue.Product, issue.Version, issue.CVE, issue.Product, issue.FixedVersion),
+			References: append([]string{"https://nvd.nist.gov/vuln/detail/" + issue.CVE}, references...),
+		})
+	}
+	return vulns
+}
@@ -0,0 +1,72 @@
+package checker
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeSmugglingPosture(t *testing.T) {
+	resp := &http.Response{
+		Proto:            "HTTP/1.1",
+		ProtoMajor:       1,
+		ProtoMinor:       1,
+		TransferEncoding: []string{"chunked"},
+		Header: http.Header{
+			"Via":         {"1.1 varnish (Varnish/6.0), 1.1 abc123.cloudfront.net (CloudFront)"},
+			"X-Amz-Cf-Id": {"abc"},
+			"X-Varnish":   {"12345"},
+			"Server":      {"Apache/2.4.49 (Unix)"},
+		},
+	}
+
+	p := AnalyzeSmugglingPosture(resp)
+	if !p.KeepAlive || !p.Chunked || p.Protocol != "HTTP/1.1" {
+		t.Errorf("unexpected connection traits: %+v", p)
+	}
+	if got := strings.Join(p.Frontends, ","); got != "CloudFront,Varnish/6.0" {
+		t.Errorf("frontends = %q, want the CDN first and no duplicates", got)
+	}
+	if p.Backend != "Apache/2.4.49 (Unix)" {
+		t.Errorf("backend = %q", p.Backend)
+	}
+
+	var cves []string
+	for _, issue := range p.KnownIssues {
+		cves = append(cves, issue.CVE)
+	}
+	// Varnish/6.0 is before 6.0.10; Apache 2.4.49 is before both fixes.
+	if got := strings.Join(cves, ","); got != "CVE-2023-25690,CVE-2022-22720,CVE-2022-23959" {
+		t.Errorf("known issues = %s", got)
+	}
+
+	vulns := analyzeSmugglingPosture(p)
+	if len(vulns) != 4 {
+		t.Fatalf("expected the chain and three flaws, got %d", len(vulns))
+	}
+	for _, v := range vulns {
+		if v.Severity != "Info" || v.Category != "Request Smuggling" {
+			t.Errorf("smuggling findings should be informational: %+v", v)
+		}
+	}
+	if !strings.Contains(vulns[0].Description, "CloudFront -> Varnish/6.0 -> Apache/2.4.49 (Unix)") {
+		t.Errorf("chain missing from %q", vulns[0].Description)
+	}
+}
+
+func TestAnalyzeSmugglingPosture_SingleTier(t *testing.T) {
+	resp := &http.Response{
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Close:      true,
+		Header:     http.Header{"Server": {"nginx/1.25.3"}},
+	}
+	p := AnalyzeSmugglingPosture(resp)
+	if p.MultiTier() || p.KeepAlive || len(p.KnownIssues) != 0 {
+		t.Errorf("a patched single server should raise nothing: %+v", p)
+	}
+	if vulns := analyzeSmugglingPosture(p); len(vulns) != 0 {
+		t.Errorf("expected no findings, got %+v", vulns)
+	}
+}
@@ -9,7 +9,7 @@ import (
 type TargetInfo struct {
 	Original string // Original target string
 	Scheme   string // http, https, or empty
-	Host     string // Hostname (without protocol, path, port)
+	Host     string // Hostname (without protocol, path, port), punycode for IDNs
 	Port     string // Port if specified
 	Path     string // Path if specified
 	FullURL  string // Full normalized URL (for HTTP requests)
@@ -21,6 +21,9 @@ type TargetInfo struct {
 //   - http://example.com
 //   - https://example.com:443/path
 //   - example.com:8080
+//
+// Internationalized hosts are converted to punycode, so Host and FullURL
+// are what DNS and TLS see; use DisplayTarget to show them in Unicode.
 func ParseTarget(target string) *TargetInfo {
 	info := &TargetInfo{
 		Original: target,
@@ -38,6 +41,7 @@ func ParseTarget(target string) *TargetInfo {
 
 	// Extract components
 	if parsed != nil {
+		asciiURLHost(parsed)
 		info.Scheme = parsed.Scheme
 		info.Host = parsed.Hostname()
 		info.Port = parsed.Port()
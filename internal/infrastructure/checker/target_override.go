@@ -0,0 +1,31 @@
+package checker
+
+import (
+	"context"
+	"time"
+)
+
+// TargetOverride adjusts how one target is checked, replacing the run-wide
+// setting for each field that is set. The Runner puts it in the context
+// passed to Check, where checkers read it with TargetOverrideFrom.
+type TargetOverride struct {
+	Ports       []int         // Ports to scan instead of the checker's list
+	Timeout     time.Duration // Check timeout instead of the Runner's
+	AuthProfile string        // Stored credentials to use; "none" for unauthenticated
+}
+
+type targetOverrideKey struct{}
+
+// WithTargetOverride returns ctx carrying o.
+func WithTargetOverride(ctx context.Context, o TargetOverride) context.Context {
+	return context.WithValue(ctx, targetOverrideKey{}, o)
+}
+
+// TargetOverrideFrom returns the override carried by ctx, if any.
+func TargetOverrideFrom(ctx context.Context) (TargetOverride, bool) {
+	o, ok := ctx.Value(targetOverrideKey{}).(TargetOverride)
+	return o, ok
+}
+
+// OverrideLookup returns the override for a target, if it has one.
+type OverrideLookup func(target string) (TargetOverride, bool)
@@ -0,0 +1,57 @@
+package checker
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type deadlineChecker struct{}
+
+func (deadlineChecker) Name() string { return "deadline" }
+
+func (deadlineChecker) Check(ctx context.Context, target string) CheckResult {
+	res := CheckResult{Target: target, Status: "ok"}
+	if deadline, ok := ctx.Deadline(); ok {
+		res.Notes = time.Until(deadline).Round(time.Minute).String()
+	}
+	if o, ok := TargetOverrideFrom(ctx); ok {
+		res.Notes += " " + o.AuthProfile
+	}
+	return res
+}
+
+func TestRunnerAppliesTargetOverrides(t *testing.T) {
+	runner := &Runner{
+		Concurrency: 1,
+		RateLimit:   100,
+		Timeout:     time.Minute,
+		Overrides: func(target string) (TargetOverride, bool) {
+			if target != "slow" {
+				return TargetOverride{}, false
+			}
+			return TargetOverride{Timeout: 5 * time.Minute, AuthProfile: "admin"}, true
+		},
+	}
+
+	results := runner.RunChecks(context.Background(), []string{"fast", "slow"}, deadlineChecker{}, nil)
+	byTarget := make(map[string]CheckResult)
+	for _, res := range results {
+		byTarget[res.Target] = res
+	}
+
+	if got := byTarget["fast"]; got.Notes != "1m0s" {
+		t.Errorf("fast: expected the run timeout and no override, got %+v", got)
+	}
+	if got := byTarget["slow"]; got.Notes != "5m0s admin" {
+		t.Errorf("slow: expected the overridden timeout and profile, got %+v", got)
+	}
+
+	// With phase timeouts set, the overridden timeout is widened the same
+	// way as the run's.
+	runner.Phases = PhaseTimeouts{Dial: time.Minute}
+	results = runner.RunChecks(context.Background(), []string{"slow"}, deadlineChecker{}, nil)
+	if got := results[0]; got.Notes != "21m0s admin" {
+		t.Errorf("slow: expected every phase plus the overridden timeout, got %+v", got)
+	}
+}
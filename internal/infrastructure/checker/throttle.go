@@ -0,0 +1,381 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrHostThrottled is returned when a request cannot wait out its host's
+// pause before the check deadline.
+var ErrHostThrottled = errors.New("host paused after rate limiting")
+
+// Reasons a host was throttled.
+const (
+	ThrottleRateLimited    = "rate_limited"    // HTTP 429
+	ThrottleForbiddenBurst = "forbidden_burst" // Consecutive HTTP 403 responses
+	ThrottleWAFChallenge   = "waf_challenge"   // A WAF block or challenge page
+)
+
+// Throttle defaults used when the corresponding field is zero.
+const (
+	defaultThrottleBurst    = 3
+	defaultThrottlePause    = 10 * time.Second
+	defaultThrottleMaxPause = 2 * time.Minute
+	defaultThrottleSpacing  = time.Second
+	// wafPeekBytes is how much of a blocked response body is searched for
+	// WAF signatures. The body is handed on intact.
+	wafPeekBytes = 8 << 10
+)
+
+// Throttle detects rate limiting and WAF blocks in HTTP responses and backs
+// off from the affected host: it pauses new requests to the host (for the
+// Retry-After time when given, otherwise doubling per event) and spaces
+// later requests to it for the rest of the run. It is shared by every
+// target of a run. A nil *Throttle detects nothing.
+type Throttle struct {
+	BurstThreshold int           // Consecutive 403 responses that count as a block; 0 means 3
+	Pause          time.Duration // First pause without Retry-After; 0 means 10s
+	MaxPause       time.Duration // Longest pause, Retry-After included; 0 means 2m
+	Spacing        time.Duration // Gap between requests to a throttled host; 0 means 1s
+	DetectOnly     bool          // Record events without pausing or slowing hosts
+	// OnEvent, when set, is called as each event is detected.
+	OnEvent func(ThrottleEvent)
+
+	mu     sync.Mutex
+	hosts  map[string]*throttleHost
+	events []ThrottleEvent
+}
+
+type throttleHost struct {
+	forbidden   int       // Consecutive 403 responses
+	events      int       // Events so far, for the backoff
+	pausedUntil time.Time // No request starts before this
+	slowed      bool      // Requests are spaced by Spacing
+	next        time.Time // Earliest start of the next spaced request
+}
+
+// ThrottleEvent records a host that rate limited or blocked the run.
+type ThrottleEvent struct {
+	Host       string    `json:"host"`
+	URL        string    `json:"url"`
+	Reason     string    `json:"reason"`
+	StatusCode int       `json:"status_code"`
+	WAF        string    `json:"waf,omitempty"`
+	DetectedAt time.Time `json:"detected_at"`
+	// PauseSeconds is how long the host was paused; 0 in detect-only mode.
+	PauseSeconds float64 `json:"pause_seconds"`
+}
+
+// Describe summarizes the event for console output and audit notes.
+func (e ThrottleEvent) Describe() string {
+	var what string
+	switch e.Reason {
+	case ThrottleRateLimited:
+		what = fmt.Sprintf("rate limited (HTTP %d)", e.StatusCode)
+	case ThrottleForbiddenBurst:
+		what = fmt.Sprintf("burst of HTTP %d responses", e.StatusCode)
+	case ThrottleWAFChallenge:
+		waf := e.WAF
+		if waf == "" {
+			waf = "WAF"
+		}
+		what = fmt.Sprintf("%s block/challenge page (HTTP %d)", waf, e.StatusCode)
+	default:
+		what = fmt.Sprintf("%s (HTTP %d)", e.Reason, e.StatusCode)
+	}
+	if e.PauseSeconds > 0 {
+		what += fmt.Sprintf("; paused %s", time.Duration(e.PauseSeconds*float64(time.Second)).Round(time.Second))
+	}
+	return what
+}
+
+// Events returns the events detected so far, in detection order.
+func (t *Throttle) Events() []ThrottleEvent {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]ThrottleEvent(nil), t.events...)
+}
+
+// Wait blocks until a request to host may start. If ctx ends, or its
+// deadline falls before the host's pause is over, it returns an error
+// wrapping ErrHostThrottled (or the context error) instead of waiting.
+func (t *Throttle) Wait(ctx context.Context, host string) error {
+	if t == nil || t.DetectOnly || host == "" {
+		return nil
+	}
+	host = strings.ToLower(host)
+
+	t.mu.Lock()
+	h := t.host(host)
+	now := time.Now()
+	slot := now
+	if h.pausedUntil.After(slot) {
+		slot = h.pausedUntil
+	}
+	if h.slowed {
+		if h.next.After(slot) {
+			slot = h.next
+		}
+	}
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(slot) {
+		t.mu.Unlock()
+		return fmt.Errorf("%w: %s until %s", ErrHostThrottled, host, slot.UTC().Format(time.RFC3339))
+	}
+	if h.slowed {
+		h.next = slot.Add(t.spacing())
+	}
+	t.mu.Unlock()
+
+	if wait := time.Until(slot); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Wrap returns a transport that waits out host pauses before each request
+// and inspects each response for rate limiting and WAF blocks.
+func (t *Throttle) Wrap(rt http.RoundTripper) http.RoundTripper {
+	if t == nil {
+		return rt
+	}
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &throttleTransport{base: rt, throttle: t}
+}
+
+type throttleTransport struct {
+	base     http.RoundTripper
+	throttle *Throttle
+}
+
+func (tt *throttleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := tt.throttle.Wait(req.Context(), req.URL.Hostname()); err != nil {
+		return nil, err
+	}
+	resp, err := tt.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	tt.throttle.Observe(req.URL.String(), resp)
+	return resp, nil
+}
+
+// Observe inspects a response from rawURL and, when it shows rate limiting
+// or a WAF block, records an event and pauses the host. A blocked
+// response's body is peeked for WAF signatures and left readable.
+func (t *Throttle) Observe(rawURL string, resp *http.Response) {
+	if t == nil || resp == nil {
+		return
+	}
+	host := strings.ToLower(ExtractHost(rawURL))
+	if host == "" {
+		return
+	}
+	waf := DetectWAFBlock(resp)
+
+	t.mu.Lock()
+	h := t.host(host)
+	reason := ""
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		reason = ThrottleRateLimited
+	case waf != "":
+		reason = ThrottleWAFChallenge
+	}
+	if resp.StatusCode == http.StatusForbidden {
+		h.forbidden++
+		if reason == "" && h.forbidden >= t.burstThreshold() {
+			reason = ThrottleForbiddenBurst
+		}
+	} else {
+		h.forbidden = 0
+	}
+	if reason == "" {
+		t.mu.Unlock()
+		return
+	}
+	h.forbidden = 0
+	h.events++
+
+	now := time.Now()
+	event := ThrottleEvent{
+		Host:       host,
+		URL:        rawURL,
+		Reason:     reason,
+		StatusCode: resp.StatusCode,
+		WAF:        waf,
+		DetectedAt: now.UTC(),
+	}
+	if !t.DetectOnly {
+		pause := t.backoff(h.events)
+		if after, ok := retryAfter(resp.Header.Get("Retry-After"), now); ok {
+			pause = min(after, t.maxPause())
+		}
+		if until := now.Add(pause); until.After(h.pausedUntil) {
+			h.pausedUntil = until
+		}
+		h.slowed = true
+		event.PauseSeconds = pause.Seconds()
+	}
+	t.events = append(t.events, event)
+	onEvent := t.OnEvent
+	t.mu.Unlock()
+
+	if onEvent != nil {
+		onEvent(event)
+	}
+}
+
+func (t *Throttle) host(name string) *throttleHost {
+	if t.hosts == nil {
+		t.hosts = make(map[string]*throttleHost)
+	}
+	h, ok := t.hosts[name]
+	if !ok {
+		h = &throttleHost{}
+		t.hosts[name] = h
+	}
+	return h
+}
+
+// backoff returns the pause for a host's nth event: Pause doubled per
+// earlier event, capped at MaxPause.
+func (t *Throttle) backoff(n int) time.Duration {
+	pause := t.Pause
+	if pause <= 0 {
+		pause = defaultThrottlePause
+	}
+	for i := 1; i < n && pause < t.maxPause(); i++ {
+		pause *= 2
+	}
+	return min(pause, t.maxPause())
+}
+
+func (t *Throttle) burstThreshold() int {
+	if t.BurstThreshold > 0 {
+		return t.BurstThreshold
+	}
+	return defaultThrottleBurst
+}
+
+func (t *Throttle) maxPause() time.Duration {
+	if t.MaxPause > 0 {
+		return t.MaxPause
+	}
+	return defaultThrottleMaxPause
+}
+
+func (t *Throttle) spacing() time.Duration {
+	if t.Spacing > 0 {
+		return t.Spacing
+	}
+	return defaultThrottleSpacing
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// wafSignature identifies a WAF block or challenge page by a response
+// header or a body marker.
+type wafSignature struct {
+	Name   string
+	Header string // Header whose presence marks a block
+	Value  string // Lowercase substring the header value must contain, if set
+	Body   string // Lowercase substring of the body
+}
+
+// wafSignatures are checked against 403, 406, 429 and 503 responses only,
+// so a product merely fronting a site is not mistaken for a block.
+var wafSignatures = []wafSignature{
+	{Name: "Cloudflare", Header: "Cf-Mitigated", Value: "challenge"},
+	{Name: "Cloudflare", Body: "attention required! | cloudflare"},
+	{Name: "Cloudflare", Body: "cf-chl-"},
+	{Name: "Cloudflare", Body: "<title>just a moment...</title>"},
+	{Name: "AWS WAF", Header: "X-Amzn-Waf-Action"},
+	{Name: "Akamai", Body: "you don't have permission to access"},
+	{Name: "Imperva Incapsula", Body: "_incapsula_resource"},
+	{Name: "Imperva Incapsula", Body: "request unsuccessful. incapsula incident"},
+	{Name: "Sucuri", Header: "X-Sucuri-Block"},
+	{Name: "Sucuri", Body: "sucuri website firewall - access denied"},
+	{Name: "F5 BIG-IP ASM", Body: "the requested url was rejected. please consult with your administrator"},
+	{Name: "ModSecurity", Body: "mod_security"},
+	{Name: "Azure Front Door", Body: "the request is blocked."},
+}
+
+// DetectWAFBlock returns the WAF product whose block or challenge page the
+// response is, or "" when it is not one.
+func DetectWAFBlock(resp *http.Response) string {
+	switch resp.StatusCode {
+	case http.StatusForbidden, http.StatusNotAcceptable, http.StatusTooManyRequests, http.StatusServiceUnavailable:
+	default:
+		return ""
+	}
+	for _, sig := range wafSignatures {
+		if sig.Header == "" {
+			continue
+		}
+		if value := resp.Header.Get(sig.Header); value != "" && (sig.Value == "" || strings.Contains(strings.ToLower(value), sig.Value)) {
+			return sig.Name
+		}
+	}
+	body := strings.ToLower(string(peekBody(resp, wafPeekBytes)))
+	if body == "" {
+		return ""
+	}
+	for _, sig := range wafSignatures {
+		if sig.Body != "" && strings.Contains(body, sig.Body) {
+			return sig.Name
+		}
+	}
+	return ""
+}
+
+// peekBody reads up to n bytes of the response body and puts them back in
+// front of the rest, so later readers see the whole body.
+func peekBody(resp *http.Response, n int64) []byte {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return nil
+	}
+	peeked, _ := io.ReadAll(io.LimitReader(resp.Body, n))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(peeked), resp.Body), resp.Body}
+	return peeked
+}
@@ -0,0 +1,121 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestThrottleRateLimitedPausesHost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	var seen []ThrottleEvent
+	throttle := &Throttle{OnEvent: func(e ThrottleEvent) { seen = append(seen, e) }}
+	client := &http.Client{Transport: throttle.Wrap(nil)}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	resp.Body.Close()
+
+	events := throttle.Events()
+	if len(events) != 1 || len(seen) != 1 {
+		t.Fatalf("expected one event, got %+v", events)
+	}
+	if e := events[0]; e.Reason != ThrottleRateLimited || e.StatusCode != 429 || e.PauseSeconds != 30 {
+		t.Fatalf("unexpected event %+v", e)
+	}
+	if !strings.Contains(events[0].Describe(), "paused 30s") {
+		t.Errorf("Describe() = %q", events[0].Describe())
+	}
+
+	// The next request must not wait 30s inside a 1s deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if _, err := client.Do(req); !errors.Is(err, ErrHostThrottled) {
+		t.Fatalf("expected ErrHostThrottled, got %v", err)
+	}
+}
+
+func TestThrottleForbiddenBurst(t *testing.T) {
+	throttle := &Throttle{BurstThreshold: 3, DetectOnly: true}
+	forbidden := &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}, Body: http.NoBody}
+	ok := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}
+
+	throttle.Observe("https://app.example.com/a", forbidden)
+	throttle.Observe("https://app.example.com/b", forbidden)
+	throttle.Observe("https://app.example.com/c", ok) // resets the run of 403s
+	throttle.Observe("https://app.example.com/d", forbidden)
+	throttle.Observe("https://app.example.com/e", forbidden)
+	if events := throttle.Events(); len(events) != 0 {
+		t.Fatalf("expected no event before three consecutive 403s, got %+v", events)
+	}
+	throttle.Observe("https://app.example.com/f", forbidden)
+
+	events := throttle.Events()
+	if len(events) != 1 || events[0].Reason != ThrottleForbiddenBurst || events[0].Host != "app.example.com" {
+		t.Fatalf("unexpected events %+v", events)
+	}
+	if events[0].PauseSeconds != 0 {
+		t.Errorf("detect-only events should not pause, got %v", events[0].PauseSeconds)
+	}
+	if err := throttle.Wait(context.Background(), "app.example.com"); err != nil {
+		t.Errorf("detect-only throttle should not hold requests: %v", err)
+	}
+}
+
+func TestThrottleBackoffDoubles(t *testing.T) {
+	throttle := &Throttle{Pause: 10 * time.Second, MaxPause: 30 * time.Second}
+	got := []time.Duration{throttle.backoff(1), throttle.backoff(2), throttle.backoff(3)}
+	want := []time.Duration{10 * time.Second, 20 * time.Second, 30 * time.Second}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("backoff(%d) = %s, want %s", i+1, got[i], want[i])
+		}
+	}
+}
+
+func TestDetectWAFBlockKeepsBody(t *testing.T) {
+	page := "<html><title>Attention Required! | Cloudflare</title><body>blocked</body></html>"
+	resp := &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(page))}
+	if waf := DetectWAFBlock(resp); waf != "Cloudflare" {
+		t.Fatalf("DetectWAFBlock() = %q, want Cloudflare", waf)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != page {
+		t.Fatalf("body was not preserved: %q", body)
+	}
+
+	aws := &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{"X-Amzn-Waf-Action": {"captcha"}}, Body: http.NoBody}
+	if waf := DetectWAFBlock(aws); waf != "AWS WAF" {
+		t.Errorf("DetectWAFBlock() = %q, want AWS WAF", waf)
+	}
+
+	served := &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Cf-Mitigated": {"challenge"}}, Body: http.NoBody}
+	if waf := DetectWAFBlock(served); waf != "" {
+		t.Errorf("a 200 response is never a block, got %q", waf)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	if d, ok := retryAfter("120", now); !ok || d != 2*time.Minute {
+		t.Errorf("seconds form: %s %v", d, ok)
+	}
+	if d, ok := retryAfter(now.Add(45*time.Second).Format(http.TimeFormat), now); !ok || d != 45*time.Second {
+		t.Errorf("date form: %s %v", d, ok)
+	}
+	if _, ok := retryAfter("soon", now); ok {
+		t.Error("invalid value should be ignored")
+	}
+}
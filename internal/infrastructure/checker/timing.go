@@ -0,0 +1,83 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// PhaseTimings breaks a check's duration into phases, in milliseconds.
+// Phases that did not happen (for example DNS and connect on a reused
+// connection, or TLS for plain HTTP) are zero.
+type PhaseTimings struct {
+	DNS       float64 `json:"dns_ms,omitempty"`
+	Connect   float64 `json:"connect_ms,omitempty"`
+	TLS       float64 `json:"tls_ms,omitempty"`
+	FirstByte float64 `json:"first_byte_ms,omitempty"` // request written to first response byte
+	Analysis  float64 `json:"analysis_ms,omitempty"`   // response received to check complete
+}
+
+// phaseTracer records the connection phases of the first request of a check
+// through net/http/httptrace. Follow-up requests made during analysis are
+// counted as analysis time, not as phases.
+type phaseTracer struct {
+	mu sync.Mutex
+
+	dnsStart, connectStart, tlsStart, wroteRequest time.Time
+	firstByte                                      time.Time
+
+	timings PhaseTimings
+}
+
+// withTrace returns ctx with hooks that record into p.
+func (p *phaseTracer) withTrace(ctx context.Context) context.Context {
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { p.mark(&p.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { p.since(&p.dnsStart, &p.timings.DNS) },
+		ConnectStart:         func(string, string) { p.mark(&p.connectStart) },
+		ConnectDone:          func(string, string, error) { p.since(&p.connectStart, &p.timings.Connect) },
+		TLSHandshakeStart:    func() { p.mark(&p.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { p.since(&p.tlsStart, &p.timings.TLS) },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { p.mark(&p.wroteRequest) },
+		GotFirstResponseByte: func() { p.mark(&p.firstByte); p.since(&p.wroteRequest, &p.timings.FirstByte) },
+	})
+}
+
+// mark stores the current time in t unless it was already set, so only the
+// first occurrence of a phase is kept (happy-eyeballs dials start twice).
+func (p *phaseTracer) mark(t *time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if t.IsZero() {
+		*t = time.Now()
+	}
+}
+
+// since records the milliseconds elapsed from start into ms, once.
+func (p *phaseTracer) since(start *time.Time, ms *float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if start.IsZero() || *ms != 0 {
+		return
+	}
+	*ms = durationMillis(time.Since(*start))
+}
+
+// finish returns the recorded phases with analysis time measured from the
+// first response byte until now. It returns nil when no response arrived.
+func (p *phaseTracer) finish() *PhaseTimings {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.firstByte.IsZero() {
+		return nil
+	}
+	timings := p.timings
+	timings.Analysis = durationMillis(time.Since(p.firstByte))
+	return &timings
+}
+
+func durationMillis(d time.Duration) float64 {
+	return d.Seconds() * 1000
+}
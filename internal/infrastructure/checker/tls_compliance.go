@@ -110,6 +110,10 @@ func AnalyzeTLSCompliance(connState *tls.ConnectionState) *TLSComplianceResult {
 		result.Recommendations = append(result.Recommendations, "Consider enabling OCSP stapling to improve certificate revocation checking performance and privacy")
 	}
 
+	// Check Must-Staple and Certificate Transparency (OWASP ASVS 9.2.1, 9.2.4)
+	result.Extensions = analyzeTLSExtensions(connState)
+	checkTLSExtensionCompliance(result.Extensions, result.CertificateInfo, result)
+
 	// Overall compliance determination
 	result.Compliant = result.Standards.OWASPASVS9.Compliant &&
 		result.Standards.PCIDSS41.Compliant &&
@@ -251,6 +255,7 @@ func analyzeCertificate(cert *x509.Certificate) *CertificateInfo {
 		DaysUntilExpiry: daysUntilExpiry,
 		SignatureAlg:    cert.SignatureAlgorithm.String(),
 		PublicKeyAlg:    cert.PublicKeyAlgorithm.String(),
+		PublicKeySHA256: PublicKeyHash(cert),
 	}
 
 	// Extract key size based on public key type
@@ -0,0 +1,136 @@
+package checker
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// minDHGroupBits is the smallest finite-field DH group accepted by NIST SP
+// 800-52r2 and the Logjam guidance.
+const minDHGroupBits = 2048
+
+// dheProbeSuites are the TLS 1.2 finite-field DHE suites, strongest first.
+// The Go TLS stack does not implement them, so they can only be observed
+// with the raw probe.
+var dheProbeSuites = []uint16{0x009f, 0x009e, 0xccaa, 0x006b, 0x0067, 0x0039, 0x0033, 0x00a3, 0x00a2, 0x0038, 0x0032, 0x0016}
+
+var dheSuiteNames = map[uint16]string{
+	0x009e: "TLS_DHE_RSA_WITH_AES_128_GCM_SHA256",
+	0x009f: "TLS_DHE_RSA_WITH_AES_256_GCM_SHA384",
+	0xccaa: "TLS_DHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+	0x0067: "TLS_DHE_RSA_WITH_AES_128_CBC_SHA256",
+	0x006b: "TLS_DHE_RSA_WITH_AES_256_CBC_SHA256",
+	0x0033: "TLS_DHE_RSA_WITH_AES_128_CBC_SHA",
+	0x0039: "TLS_DHE_RSA_WITH_AES_256_CBC_SHA",
+	0x0016: "TLS_DHE_RSA_WITH_3DES_EDE_CBC_SHA",
+	0x00a2: "TLS_DHE_DSS_WITH_AES_128_GCM_SHA256",
+	0x00a3: "TLS_DHE_DSS_WITH_AES_256_GCM_SHA384",
+	0x0032: "TLS_DHE_DSS_WITH_AES_128_CBC_SHA",
+	0x0038: "TLS_DHE_DSS_WITH_AES_256_CBC_SHA",
+}
+
+// DHEParameters describes the finite-field Diffie-Hellman group a server
+// uses for DHE key exchange.
+type DHEParameters struct {
+	Supported   bool   `json:"supported"`
+	CipherSuite string `json:"cipher_suite,omitempty"`
+	GroupBits   int    `json:"group_bits,omitempty"`
+	ProbeNote   string `json:"probe_note,omitempty"`
+}
+
+// ProbeDHEParameters offers only DHE suites and measures the prime of the
+// group in the server's ServerKeyExchange. A server that refuses every DHE
+// suite reports Supported=false.
+func ProbeDHEParameters(ctx context.Context, addr, serverName string, timeout time.Duration) *DHEParameters {
+	params := &DHEParameters{}
+	hello, err := ProbeServerHello(ctx, addr, serverName, timeout, dheProbeSuites)
+	if err != nil {
+		// An alert means no DHE suite is enabled.
+		if !errors.Is(err, errServerRefusedTLS12) {
+			params.ProbeNote = "probe failed: " + err.Error()
+		}
+		return params
+	}
+	name, ok := dheSuiteNames[hello.CipherSuite]
+	if !ok {
+		return params
+	}
+
+	params.Supported = true
+	params.CipherSuite = name
+	bits, err := dhGroupBits(hello.Handshake)
+	if err != nil {
+		params.ProbeNote = err.Error()
+		return params
+	}
+	params.GroupBits = bits
+	return params
+}
+
+// ProbeDHE runs the DHE probe against addr and folds its findings into a
+// compliance result from AnalyzeTLSCompliance.
+func ProbeDHE(ctx context.Context, result *TLSComplianceResult, addr, serverName string, timeout time.Duration) {
+	if result == nil {
+		return
+	}
+	result.DHE = ProbeDHEParameters(ctx, addr, serverName, timeout)
+	checkDHECompliance(result.DHE, result)
+}
+
+// dhGroupBits finds the ServerKeyExchange among the handshake messages after
+// the ServerHello and returns the bit length of its dh_p (RFC 5246 §7.4.3).
+func dhGroupBits(handshake []byte) (int, error) {
+	for len(handshake) >= 4 {
+		typ := handshake[0]
+		n := int(handshake[1])<<16 | int(handshake[2])<<8 | int(handshake[3])
+		if len(handshake) < 4+n {
+			break
+		}
+		if typ == 0x0c { // ServerKeyExchange
+			body := handshake[4 : 4+n]
+			if len(body) < 2 {
+				return 0, errors.New("truncated ServerKeyExchange")
+			}
+			pLen := int(binary.BigEndian.Uint16(body))
+			if pLen == 0 || len(body) < 2+pLen {
+				return 0, errors.New("truncated DH prime in ServerKeyExchange")
+			}
+			return new(big.Int).SetBytes(body[2 : 2+pLen]).BitLen(), nil
+		}
+		handshake = handshake[4+n:]
+	}
+	return 0, errors.New("server sent no ServerKeyExchange")
+}
+
+// checkDHECompliance flags DHE groups below 2048 bits, which are within reach
+// of Logjam-style precomputation (ASVS 9.1.2, PCI DSS 4.1, NIST SP 800-52r2).
+func checkDHECompliance(params *DHEParameters, result *TLSComplianceResult) {
+	if !params.Supported || params.GroupBits == 0 {
+		return
+	}
+	nist := &result.Standards.NIST80052r2
+	if params.GroupBits >= minDHGroupBits {
+		result.Standards.OWASPASVS9.Passed = append(result.Standards.OWASPASVS9.Passed, "9.1.2-DH-Group")
+		nist.Passed = append(nist.Passed, "3.3.1-DH-Group")
+		return
+	}
+
+	result.Issues = append(result.Issues, ComplianceIssue{
+		Standard:    "OWASP ASVS 9.1.2 / PCI DSS 4.1 / NIST SP 800-52r2",
+		Requirement: "9.1.2",
+		Severity:    "high",
+		Description: fmt.Sprintf("Weak Diffie-Hellman group: %s uses a %d-bit prime. Groups under %d bits are vulnerable to Logjam-class precomputation attacks that break the key exchange.", params.CipherSuite, params.GroupBits, minDHGroupBits),
+		Remediation: "Use a DH group of at least 2048 bits (e.g. the RFC 7919 ffdhe2048 group; nginx ssl_dhparam, Apache SSLOpenSSLConfCmd DHParameters), or disable DHE suites in favour of ECDHE.",
+	})
+	result.Standards.OWASPASVS9.Failed = append(result.Standards.OWASPASVS9.Failed, "9.1.2-DH-Group")
+	result.Standards.OWASPASVS9.Compliant = false
+	result.Standards.PCIDSS41.Failed = append(result.Standards.PCIDSS41.Failed, "4.1-DH-Group")
+	result.Standards.PCIDSS41.Compliant = false
+	nist.Failed = append(nist.Failed, "3.3.1-DH-Group")
+	nist.Compliant = false
+	result.Compliant = false
+}
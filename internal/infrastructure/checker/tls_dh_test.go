@@ -0,0 +1,76 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serverKeyExchange builds a DHE ServerKeyExchange with a prime of the given
+// size; g, Ys, and the signature are placeholders.
+func serverKeyExchange(primeBits int) []byte {
+	p := make([]byte, primeBits/8)
+	p[0] = 0x80
+	p[len(p)-1] = 0x01
+	body := []byte{byte(len(p) >> 8), byte(len(p))}
+	body = append(body, p...)
+	body = append(body, 0, 1, 2)       // g
+	body = append(body, 0, 1, 5)       // Ys
+	body = append(body, 4, 1, 0, 1, 0) // Signature
+	return append([]byte{0x0c, 0, byte(len(body) >> 8), byte(len(body))}, body...)
+}
+
+func TestProbeDHE(t *testing.T) {
+	tests := []struct {
+		name      string
+		bits      int
+		wantIssue bool
+	}{
+		{"logjam 1024-bit group", 1024, true},
+		{"2048-bit group", 2048, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := serveFakeServerHello(t, 0x009e, compressionNull, serverKeyExchange(tt.bits))
+			result := AnalyzeTLSCompliance(&tls.ConnectionState{
+				Version:     tls.VersionTLS12,
+				CipherSuite: tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			})
+			ProbeDHE(context.Background(), result, addr, "", 5*time.Second)
+
+			if !result.DHE.Supported || result.DHE.GroupBits != tt.bits {
+				t.Fatalf("unexpected DHE parameters %+v", result.DHE)
+			}
+			if result.DHE.CipherSuite != "TLS_DHE_RSA_WITH_AES_128_GCM_SHA256" {
+				t.Errorf("unexpected suite %s", result.DHE.CipherSuite)
+			}
+			var found bool
+			for _, issue := range result.Issues {
+				if strings.Contains(issue.Description, "Weak Diffie-Hellman") {
+					found = issue.Severity == "high"
+				}
+			}
+			if found != tt.wantIssue || result.Compliant == tt.wantIssue {
+				t.Errorf("wantIssue=%v, got issues %+v compliant=%v", tt.wantIssue, result.Issues, result.Compliant)
+			}
+		})
+	}
+}
+
+func TestProbeDHE_NotSupported(t *testing.T) {
+	// A Go TLS server implements no DHE suites and answers with an alert.
+	server := newTLS12Server(t)
+
+	params := ProbeDHEParameters(context.Background(), server, "", 5*time.Second)
+	if params.Supported || params.ProbeNote != "" {
+		t.Errorf("expected DHE unsupported without a note, got %+v", params)
+	}
+}
+
+func TestDHGroupBits_NoServerKeyExchange(t *testing.T) {
+	if _, err := dhGroupBits([]byte{0x0b, 0, 0, 0}); err == nil {
+		t.Error("expected error without a ServerKeyExchange")
+	}
+}
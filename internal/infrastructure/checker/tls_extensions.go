@@ -0,0 +1,478 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"time"
+)
+
+var (
+	// oidTLSFeature is the TLS Feature extension (RFC 7633); status_request
+	// (5) in it is OCSP Must-Staple.
+	oidTLSFeature = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 24}
+	// oidEmbeddedSCTs holds Signed Certificate Timestamps embedded by the CA.
+	oidEmbeddedSCTs = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+)
+
+// TLS extension numbers read from the probe's ServerHello.
+const (
+	extStatusRequest       uint16 = 0x0005
+	extSessionTicket       uint16 = 0x0023
+	extRenegotiationInfo   uint16 = 0xff01
+	compressionNull        byte   = 0x00
+	compressionDeflate     byte   = 0x01
+	tlsRecordHandshake     byte   = 0x16
+	tlsRecordAlert         byte   = 0x15
+	tlsHandshakeServerHelo byte   = 0x02
+)
+
+// TLSExtensions reports certificate and protocol extensions that affect
+// revocation, transparency, and session security.
+type TLSExtensions struct {
+	MustStaple  bool     `json:"must_staple"`
+	OCSPStapled bool     `json:"ocsp_stapled"`
+	SCTCount    int      `json:"sct_count"`
+	SCTSources  []string `json:"sct_sources,omitempty"` // "certificate", "tls_extension"
+
+	// Probed is set when a TLS 1.2 ServerHello probe ran. The fields below are
+	// only meaningful then; the Go TLS stack never offers compression and does
+	// not expose renegotiation or ticket support.
+	Probed              bool   `json:"probed"`
+	SecureRenegotiation bool   `json:"secure_renegotiation"`
+	SessionTickets      bool   `json:"session_tickets"`
+	Compression         string `json:"compression,omitempty"` // "null" or "deflate"
+	ProbeNote           string `json:"probe_note,omitempty"`
+}
+
+// ServerHello is the subset of a TLS 1.2 ServerHello the probe inspects.
+type ServerHello struct {
+	Version     uint16
+	CipherSuite uint16
+	Compression byte
+	Extensions  map[uint16][]byte
+	// Handshake holds every handshake message read after the ServerHello in
+	// the same flight, for callers that inspect later messages.
+	Handshake []byte
+}
+
+// analyzeTLSExtensions inspects the negotiated connection for Must-Staple,
+// stapled OCSP, and Certificate Transparency evidence.
+func analyzeTLSExtensions(connState *tls.ConnectionState) *TLSExtensions {
+	ext := &TLSExtensions{OCSPStapled: len(connState.OCSPResponse) > 0}
+	if len(connState.PeerCertificates) > 0 {
+		leaf := connState.PeerCertificates[0]
+		ext.MustStaple = hasMustStaple(leaf)
+		if n := embeddedSCTCount(leaf); n > 0 {
+			ext.SCTCount += n
+			ext.SCTSources = append(ext.SCTSources, "certificate")
+		}
+	}
+	if n := len(connState.SignedCertificateTimestamps); n > 0 {
+		ext.SCTCount += n
+		ext.SCTSources = append(ext.SCTSources, "tls_extension")
+	}
+	return ext
+}
+
+func hasMustStaple(cert *x509.Certificate) bool {
+	for _, e := range cert.Extensions {
+		if !e.Id.Equal(oidTLSFeature) {
+			continue
+		}
+		var features []int
+		if _, err := asn1.Unmarshal(e.Value, &features); err != nil {
+			return false
+		}
+		return slices.Contains(features, int(extStatusRequest))
+	}
+	return false
+}
+
+// embeddedSCTCount counts the SCTs in the certificate's SCT list extension
+// (RFC 6962 §3.3): an OCTET STRING wrapping a length-prefixed list of
+// length-prefixed SCTs.
+func embeddedSCTCount(cert *x509.Certificate) int {
+	for _, e := range cert.Extensions {
+		if !e.Id.Equal(oidEmbeddedSCTs) {
+			continue
+		}
+		var list []byte
+		if _, err := asn1.Unmarshal(e.Value, &list); err != nil || len(list) < 2 {
+			return 0
+		}
+		data := list[2:]
+		count := 0
+		for len(data) >= 2 {
+			n := int(binary.BigEndian.Uint16(data))
+			if len(data) < 2+n {
+				break
+			}
+			data = data[2+n:]
+			count++
+		}
+		return count
+	}
+	return 0
+}
+
+// tls12ProbeSuites are offered by the ServerHello probe: the common ECDHE,
+// DHE, and RSA suites, so any TLS 1.2 server can answer.
+var tls12ProbeSuites = []uint16{
+	0xc02f, 0xc030, 0xc02b, 0xc02c, 0xcca8, 0xcca9, // ECDHE AEAD
+	0xc013, 0xc014, 0xc009, 0xc00a, // ECDHE CBC
+	0x009e, 0x009f, 0xccaa, 0x0033, 0x0039, // DHE
+	0x009c, 0x009d, 0x002f, 0x0035, 0x000a, // RSA
+}
+
+// ProbeServerHello sends a TLS 1.2 ClientHello offering DEFLATE compression,
+// an empty session ticket, and the renegotiation indication, and returns the
+// server's ServerHello. suites overrides the offered cipher suites when set.
+// The connection is closed before the handshake completes.
+func ProbeServerHello(ctx context.Context, addr, serverName string, timeout time.Duration, suites []uint16) (*ServerHello, error) {
+	if len(suites) == 0 {
+		suites = tls12ProbeSuites
+	}
+	hello, err := buildClientHello(serverName, suites)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("connect %s: %w", addr, err)
+	}
+	defer conn.Close()
+	if timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(timeout))
+	}
+
+	if _, err := conn.Write(hello); err != nil {
+		return nil, fmt.Errorf("send ClientHello: %w", err)
+	}
+	return readServerHello(conn)
+}
+
+func buildClientHello(serverName string, suites []uint16) ([]byte, error) {
+	var body bytes.Buffer
+	body.Write([]byte{0x03, 0x03}) // TLS 1.2
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return nil, err
+	}
+	body.Write(random)
+	body.WriteByte(0) // Empty session ID
+
+	writeUint16(&body, uint16(2*len(suites)))
+	for _, s := range suites {
+		writeUint16(&body, s)
+	}
+	body.Write([]byte{2, compressionDeflate, compressionNull})
+
+	var exts bytes.Buffer
+	if serverName != "" && net.ParseIP(serverName) == nil {
+		name := []byte(serverName)
+		writeExtension(&exts, 0x0000, func(b *bytes.Buffer) {
+			writeUint16(b, uint16(3+len(name)))
+			b.WriteByte(0) // host_name
+			writeUint16(b, uint16(len(name)))
+			b.Write(name)
+		})
+	}
+	// supported_groups: x25519, P-256, P-384. No RFC 7919 ffdhe groups, so a
+	// DHE server sends the custom group legacy clients get.
+	writeExtension(&exts, 0x000a, func(b *bytes.Buffer) {
+		writeUint16(b, 6)
+		for _, g := range []uint16{0x001d, 0x0017, 0x0018} {
+			writeUint16(b, g)
+		}
+	})
+	writeExtension(&exts, 0x000b, func(b *bytes.Buffer) { b.Write([]byte{1, 0}) }) // ec_point_formats: uncompressed
+	writeExtension(&exts, 0x000d, func(b *bytes.Buffer) {                          // signature_algorithms
+		algs := []uint16{0x0403, 0x0503, 0x0603, 0x0804, 0x0805, 0x0806, 0x0401, 0x0501, 0x0601, 0x0201}
+		writeUint16(b, uint16(2*len(algs)))
+		for _, a := range algs {
+			writeUint16(b, a)
+		}
+	})
+	writeExtension(&exts, extStatusRequest, func(b *bytes.Buffer) { b.Write([]byte{1, 0, 0, 0, 0}) }) // OCSP, no responder IDs or extensions
+	writeExtension(&exts, extSessionTicket, func(*bytes.Buffer) {})
+	writeExtension(&exts, extRenegotiationInfo, func(b *bytes.Buffer) { b.WriteByte(0) })
+	writeUint16(&body, uint16(exts.Len()))
+	body.Write(exts.Bytes())
+
+	var msg bytes.Buffer
+	msg.WriteByte(0x01) // ClientHello
+	writeUint24(&msg, body.Len())
+	msg.Write(body.Bytes())
+
+	var record bytes.Buffer
+	record.Write([]byte{tlsRecordHandshake, 0x03, 0x01})
+	writeUint16(&record, uint16(msg.Len()))
+	record.Write(msg.Bytes())
+	return record.Bytes(), nil
+}
+
+func writeUint16(b *bytes.Buffer, v uint16) {
+	b.Write([]byte{byte(v >> 8), byte(v)})
+}
+
+func writeUint24(b *bytes.Buffer, v int) {
+	b.Write([]byte{byte(v >> 16), byte(v >> 8), byte(v)})
+}
+
+func writeExtension(b *bytes.Buffer, typ uint16, data func(*bytes.Buffer)) {
+	var payload bytes.Buffer
+	data(&payload)
+	writeUint16(b, typ)
+	writeUint16(b, uint16(payload.Len()))
+	b.Write(payload.Bytes())
+}
+
+// errServerRefusedTLS12 means the server answered the probe with an alert,
+// usually because it only speaks TLS 1.3.
+var errServerRefusedTLS12 = errors.New("server refused the TLS 1.2 handshake")
+
+// readServerHello reads handshake records until the ServerHello and the
+// messages that follow it in the first flight (up to ServerHelloDone) are in.
+func readServerHello(r io.Reader) (*ServerHello, error) {
+	var handshake []byte
+	for {
+		header := make([]byte, 5)
+		if _, err := io.ReadFull(r, header); err != nil {
+			if len(handshake) > 0 {
+				break
+			}
+			return nil, fmt.Errorf("read ServerHello: %w", err)
+		}
+		length := int(binary.BigEndian.Uint16(header[3:]))
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return nil, fmt.Errorf("read ServerHello: %w", err)
+		}
+		if header[0] == tlsRecordAlert {
+			if len(handshake) > 0 {
+				break
+			}
+			return nil, errServerRefusedTLS12
+		}
+		if header[0] != tlsRecordHandshake {
+			return nil, fmt.Errorf("unexpected TLS record type %d", header[0])
+		}
+		handshake = append(handshake, payload...)
+		if flightComplete(handshake) || len(handshake) > 64*1024 {
+			break
+		}
+	}
+	return parseServerHello(handshake)
+}
+
+// flightComplete reports whether the handshake bytes contain ServerHelloDone.
+func flightComplete(data []byte) bool {
+	for len(data) >= 4 {
+		n := int(data[1])<<16 | int(data[2])<<8 | int(data[3])
+		if len(data) < 4+n {
+			return false
+		}
+		if data[0] == 0x0e { // ServerHelloDone
+			return true
+		}
+		data = data[4+n:]
+	}
+	return false
+}
+
+func parseServerHello(data []byte) (*ServerHello, error) {
+	if len(data) < 4 || data[0] != tlsHandshakeServerHelo {
+		return nil, errors.New("first handshake message is not a ServerHello")
+	}
+	n := int(data[1])<<16 | int(data[2])<<8 | int(data[3])
+	if len(data) < 4+n || n < 38 {
+		return nil, errors.New("truncated ServerHello")
+	}
+	body := data[4 : 4+n]
+
+	hello := &ServerHello{
+		Version:    binary.BigEndian.Uint16(body),
+		Extensions: make(map[uint16][]byte),
+		Handshake:  data[4+n:],
+	}
+	body = body[34:] // version + random
+	sidLen := int(body[0])
+	if len(body) < 1+sidLen+3 {
+		return nil, errors.New("truncated ServerHello")
+	}
+	body = body[1+sidLen:]
+	hello.CipherSuite = binary.BigEndian.Uint16(body)
+	hello.Compression = body[2]
+	body = body[3:]
+
+	if len(body) < 2 {
+		return hello, nil
+	}
+	extLen := int(binary.BigEndian.Uint16(body))
+	body = body[2:]
+	if len(body) < extLen {
+		return nil, errors.New("truncated ServerHello extensions")
+	}
+	body = body[:extLen]
+	for len(body) >= 4 {
+		typ := binary.BigEndian.Uint16(body)
+		l := int(binary.BigEndian.Uint16(body[2:]))
+		if len(body) < 4+l {
+			return nil, errors.New("truncated ServerHello extension")
+		}
+		hello.Extensions[typ] = body[4 : 4+l]
+		body = body[4+l:]
+	}
+	return hello, nil
+}
+
+// ApplyServerHelloProbe records the probe's findings in ext. A probe error is
+// kept as a note; a TLS 1.3-only server is not a failure.
+func (ext *TLSExtensions) ApplyServerHelloProbe(hello *ServerHello, err error) {
+	if ext == nil {
+		return
+	}
+	if err != nil {
+		if errors.Is(err, errServerRefusedTLS12) {
+			ext.ProbeNote = "server does not accept TLS 1.2; renegotiation and compression do not exist in TLS 1.3"
+		} else {
+			ext.ProbeNote = "probe failed: " + err.Error()
+		}
+		return
+	}
+	ext.Probed = true
+	_, ext.SecureRenegotiation = hello.Extensions[extRenegotiationInfo]
+	_, ext.SessionTickets = hello.Extensions[extSessionTicket]
+	switch hello.Compression {
+	case compressionNull:
+		ext.Compression = "null"
+	case compressionDeflate:
+		ext.Compression = "deflate"
+	default:
+		ext.Compression = fmt.Sprintf("0x%02x", hello.Compression)
+	}
+}
+
+// checkTLSExtensionCompliance maps the passive extension findings to ASVS
+// 9.2.4 (revocation) and 9.2.1 (Certificate Transparency).
+func checkTLSExtensionCompliance(ext *TLSExtensions, certInfo *CertificateInfo, result *TLSComplianceResult) {
+	asvs := &result.Standards.OWASPASVS9
+	nist := &result.Standards.NIST80052r2
+
+	// Must-Staple: supporting clients hard-fail without a stapled response.
+	if ext.MustStaple {
+		if ext.OCSPStapled {
+			asvs.Passed = append(asvs.Passed, "9.2.4-Must-Staple")
+			nist.Passed = append(nist.Passed, "3.4.1-Status-Request")
+		} else {
+			result.Issues = append(result.Issues, ComplianceIssue{
+				Standard:    "OWASP ASVS 9.2.4 / NIST SP 800-52r2",
+				Requirement: "9.2.4",
+				Severity:    "high",
+				Description: "Certificate has the OCSP Must-Staple extension but the server did not staple an OCSP response. Supporting clients will refuse the connection.",
+				Remediation: "Enable OCSP stapling (e.g. nginx ssl_stapling on; Apache SSLUseStapling On) and make sure the server can reach the CA's OCSP responder.",
+			})
+			asvs.Failed = append(asvs.Failed, "9.2.4-Must-Staple")
+			asvs.Compliant = false
+			nist.Failed = append(nist.Failed, "3.4.1-Status-Request")
+			nist.Compliant = false
+		}
+	} else if ext.OCSPStapled {
+		nist.Passed = append(nist.Passed, "3.4.1-Status-Request")
+		result.Recommendations = append(result.Recommendations,
+			"Consider the OCSP Must-Staple certificate extension so clients reject responses without a valid revocation status")
+	}
+
+	// Certificate Transparency: publicly trusted certificates must carry SCTs.
+	// Self-signed certificates are already reported and never logged.
+	switch {
+	case ext.SCTCount > 0:
+		asvs.Passed = append(asvs.Passed, "9.2.1-Certificate-Transparency")
+		nist.Passed = append(nist.Passed, "4.1-Certificate-Transparency")
+	case certInfo == nil || certInfo.SelfSigned:
+	default:
+		result.Issues = append(result.Issues, ComplianceIssue{
+			Standard:    "OWASP ASVS 9.2.1 / NIST SP 800-52r2",
+			Requirement: "9.2.1",
+			Severity:    "medium",
+			Description: "No Certificate Transparency SCTs were found in the certificate or the TLS handshake. Browsers reject publicly trusted certificates without SCTs.",
+			Remediation: "Use a certificate from a CA that logs to Certificate Transparency, or serve SCTs via the signed_certificate_timestamp TLS extension. Certificates from a private CA are expected to have none.",
+		})
+		nist.Failed = append(nist.Failed, "4.1-Certificate-Transparency")
+	}
+}
+
+// checkTLSProbeCompliance maps the ServerHello probe findings to NIST SP
+// 800-52r2 §3.4.1 (renegotiation indication, session tickets) and the
+// compression ban shared by ASVS 9.1.2 and PCI DSS 4.1.
+func checkTLSProbeCompliance(ext *TLSExtensions, result *TLSComplianceResult) {
+	asvs := &result.Standards.OWASPASVS9
+	nist := &result.Standards.NIST80052r2
+
+	if ext.SecureRenegotiation {
+		nist.Passed = append(nist.Passed, "3.4.1-Renegotiation-Indication")
+	} else {
+		result.Issues = append(result.Issues, ComplianceIssue{
+			Standard:    "NIST SP 800-52r2 / OWASP ASVS 9.1.2",
+			Requirement: "3.4.1-Renegotiation-Indication",
+			Severity:    "high",
+			Description: "Server does not support the renegotiation indication extension (RFC 5746), leaving TLS 1.2 renegotiation open to prefix injection.",
+			Remediation: "Upgrade the TLS library so it supports secure renegotiation, or disable renegotiation entirely.",
+		})
+		nist.Failed = append(nist.Failed, "3.4.1-Renegotiation-Indication")
+		nist.Compliant = false
+	}
+
+	if ext.Compression != "null" {
+		result.Issues = append(result.Issues, ComplianceIssue{
+			Standard:    "OWASP ASVS 9.1.2 / PCI DSS 4.1 / NIST SP 800-52r2",
+			Requirement: "9.1.2",
+			Severity:    "high",
+			Description: fmt.Sprintf("Server accepted TLS compression (%s), which exposes cookies and tokens to the CRIME attack.", ext.Compression),
+			Remediation: "Disable TLS-level compression in the server's TLS library configuration.",
+		})
+		asvs.Failed = append(asvs.Failed, "9.1.2-Compression")
+		asvs.Compliant = false
+		result.Standards.PCIDSS41.Failed = append(result.Standards.PCIDSS41.Failed, "4.1-Compression")
+		result.Standards.PCIDSS41.Compliant = false
+		nist.Failed = append(nist.Failed, "3.4-Compression")
+		nist.Compliant = false
+	} else {
+		asvs.Passed = append(asvs.Passed, "9.1.2-Compression")
+		nist.Passed = append(nist.Passed, "3.4-Compression")
+	}
+
+	if ext.SessionTickets {
+		result.Recommendations = append(result.Recommendations,
+			"Session tickets are enabled; rotate ticket encryption keys at least daily so tickets do not undermine forward secrecy (NIST SP 800-52r2 §3.4.1)")
+	}
+}
+
+// ProbeTLSExtensions runs the ServerHello probe against addr and folds its
+// findings into a compliance result from AnalyzeTLSCompliance.
+func ProbeTLSExtensions(ctx context.Context, result *TLSComplianceResult, addr, serverName string, timeout time.Duration) {
+	if result == nil || result.Extensions == nil {
+		return
+	}
+	hello, err := ProbeServerHello(ctx, addr, serverName, timeout, nil)
+	result.Extensions.ApplyServerHelloProbe(hello, err)
+	if !result.Extensions.Probed {
+		return
+	}
+	checkTLSProbeCompliance(result.Extensions, result)
+	result.Compliant = result.Standards.OWASPASVS9.Compliant &&
+		result.Standards.PCIDSS41.Compliant &&
+		result.Standards.NIST80052r2.Compliant
+}
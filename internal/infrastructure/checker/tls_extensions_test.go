@@ -0,0 +1,203 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := asn1.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return data
+}
+
+func TestAnalyzeTLSExtensions(t *testing.T) {
+	// Two embedded SCTs of 3 and 1 bytes.
+	sctList := []byte{0, 8, 0, 3, 'a', 'b', 'c', 0, 1, 'd'}
+	cert := &x509.Certificate{
+		Subject: pkix.Name{CommonName: "example.com"},
+		Issuer:  pkix.Name{CommonName: "Example CA"},
+		Extensions: []pkix.Extension{
+			{Id: oidTLSFeature, Value: mustMarshal(t, []int{5})},
+			{Id: oidEmbeddedSCTs, Value: mustMarshal(t, sctList)},
+		},
+	}
+	connState := &tls.ConnectionState{
+		Version:                     tls.VersionTLS13,
+		CipherSuite:                 tls.TLS_AES_128_GCM_SHA256,
+		PeerCertificates:            []*x509.Certificate{cert},
+		SignedCertificateTimestamps: [][]byte{[]byte("sct")},
+	}
+
+	ext := analyzeTLSExtensions(connState)
+	if !ext.MustStaple {
+		t.Error("expected Must-Staple to be detected")
+	}
+	if ext.SCTCount != 3 {
+		t.Errorf("expected 3 SCTs, got %d", ext.SCTCount)
+	}
+	if strings.Join(ext.SCTSources, ",") != "certificate,tls_extension" {
+		t.Errorf("unexpected SCT sources %v", ext.SCTSources)
+	}
+
+	// Must-Staple without a stapled response fails ASVS 9.2.4.
+	result := AnalyzeTLSCompliance(connState)
+	if result.Standards.OWASPASVS9.Compliant {
+		t.Error("expected ASVS failure for Must-Staple without stapling")
+	}
+	if !containsString(strings.Join(result.Standards.OWASPASVS9.Passed, ","), "9.2.1-Certificate-Transparency") {
+		t.Errorf("expected CT pass, got %v", result.Standards.OWASPASVS9.Passed)
+	}
+
+	connState.OCSPResponse = []byte{0x30}
+	result = AnalyzeTLSCompliance(connState)
+	if !containsString(strings.Join(result.Standards.OWASPASVS9.Passed, ","), "9.2.4-Must-Staple") {
+		t.Errorf("expected Must-Staple pass, got %v", result.Standards.OWASPASVS9.Passed)
+	}
+}
+
+func TestAnalyzeTLSExtensions_MissingSCT(t *testing.T) {
+	connState := &tls.ConnectionState{
+		Version:     tls.VersionTLS13,
+		CipherSuite: tls.TLS_AES_128_GCM_SHA256,
+		PeerCertificates: []*x509.Certificate{{
+			Subject: pkix.Name{CommonName: "example.com"},
+			Issuer:  pkix.Name{CommonName: "Example CA"},
+		}},
+	}
+
+	result := AnalyzeTLSCompliance(connState)
+	found := false
+	for _, issue := range result.Issues {
+		if strings.Contains(issue.Description, "Certificate Transparency") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a Certificate Transparency issue, got %+v", result.Issues)
+	}
+
+	// Self-signed certificates are never logged; no CT issue.
+	connState.PeerCertificates[0].Issuer = connState.PeerCertificates[0].Subject
+	result = AnalyzeTLSCompliance(connState)
+	for _, issue := range result.Issues {
+		if strings.Contains(issue.Description, "Certificate Transparency") {
+			t.Errorf("unexpected CT issue for self-signed certificate: %+v", issue)
+		}
+	}
+}
+
+// newTLS12Server starts a TLS 1.2 test server and returns its address.
+func newTLS12Server(t *testing.T) string {
+	t.Helper()
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server.Listener.Addr().String()
+}
+
+func TestProbeServerHello(t *testing.T) {
+	addr := newTLS12Server(t)
+	hello, err := ProbeServerHello(context.Background(), addr, "example.com", 5*time.Second, nil)
+	if err != nil {
+		t.Fatalf("probe failed: %v", err)
+	}
+	if hello.Version != tls.VersionTLS12 {
+		t.Errorf("expected TLS 1.2, got %x", hello.Version)
+	}
+	if hello.Compression != compressionNull {
+		t.Errorf("Go servers never compress, got %d", hello.Compression)
+	}
+
+	ext := &TLSExtensions{}
+	ext.ApplyServerHelloProbe(hello, nil)
+	if !ext.Probed || !ext.SecureRenegotiation || !ext.SessionTickets || ext.Compression != "null" {
+		t.Errorf("unexpected probe result %+v", ext)
+	}
+}
+
+func TestProbeServerHello_TLS13Only(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{MinVersion: tls.VersionTLS13}
+	server.StartTLS()
+	defer server.Close()
+
+	_, err := ProbeServerHello(context.Background(), server.Listener.Addr().String(), "", 5*time.Second, nil)
+	ext := &TLSExtensions{}
+	ext.ApplyServerHelloProbe(nil, err)
+	if ext.Probed || !strings.Contains(ext.ProbeNote, "TLS 1.3") {
+		t.Errorf("expected TLS 1.3-only note, got %+v", ext)
+	}
+}
+
+// serveFakeServerHello answers one ClientHello with a ServerHello selecting
+// suite and compression, followed by extra handshake messages and
+// ServerHelloDone. It returns the listener address.
+func serveFakeServerHello(t *testing.T, suite uint16, compression byte, extra []byte) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		buf := make([]byte, 4096)
+		_, _ = conn.Read(buf)
+
+		body := []byte{0x03, 0x03}
+		body = append(body, make([]byte, 32)...)
+		body = append(body, 0) // Session ID
+		body = append(body, byte(suite>>8), byte(suite), compression)
+		msg := append([]byte{tlsHandshakeServerHelo, 0, 0, byte(len(body))}, body...)
+		msg = append(msg, extra...)
+		msg = append(msg, 0x0e, 0, 0, 0) // ServerHelloDone
+		record := append([]byte{tlsRecordHandshake, 0x03, 0x03, byte(len(msg) >> 8), byte(len(msg))}, msg...)
+		_, _ = conn.Write(record)
+	}()
+	return ln.Addr().String()
+}
+
+func TestProbeTLSExtensions_Compression(t *testing.T) {
+	// A fake server that selects DEFLATE and omits renegotiation_info.
+	addr := serveFakeServerHello(t, 0xc02f, compressionDeflate, nil)
+
+	result := AnalyzeTLSCompliance(&tls.ConnectionState{
+		Version:     tls.VersionTLS12,
+		CipherSuite: tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	})
+	ProbeTLSExtensions(context.Background(), result, addr, "", 5*time.Second)
+
+	if result.Extensions.Compression != "deflate" || result.Extensions.SecureRenegotiation {
+		t.Fatalf("unexpected extensions %+v", result.Extensions)
+	}
+	if result.Compliant || result.Standards.PCIDSS41.Compliant {
+		t.Error("expected compression to fail compliance")
+	}
+	var high int
+	for _, issue := range result.Issues {
+		if issue.Severity == "high" {
+			high++
+		}
+	}
+	if high != 2 {
+		t.Errorf("expected compression and renegotiation issues, got %+v", result.Issues)
+	}
+}
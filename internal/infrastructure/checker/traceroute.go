@@ -0,0 +1,217 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	TracerouteUDP = "udp"
+	TracerouteTCP = "tcp"
+
+	defaultTracerouteMaxHops = 30
+	defaultTracerouteTimeout = 2 * time.Second
+	// defaultTracerouteGap stops a trace after this many silent hops in a
+	// row; firewalls past that point rarely start answering again.
+	defaultTracerouteGap = 5
+	// tracerouteUDPBasePort is the traditional traceroute destination port;
+	// each hop adds its TTL so probes land on unlikely-to-be-open ports.
+	tracerouteUDPBasePort = 33434
+)
+
+var errTracerouteUnsupported = errors.New("traceroute is not supported on this platform")
+
+// NetworkPath is the route observed from the scanning host to a target.
+type NetworkPath struct {
+	Destination string    `json:"destination"`
+	Method      string    `json:"method"`
+	Port        int       `json:"port"`
+	Hops        []PathHop `json:"hops"`
+	HopCount    int       `json:"hop_count"` // TTL at which the destination answered; 0 when it never did
+	Reached     bool      `json:"reached"`
+	CapturedAt  time.Time `json:"captured_at"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// PathHop is one TTL step. Address is empty when nothing answered.
+type PathHop struct {
+	TTL     int     `json:"ttl"`
+	Address string  `json:"address,omitempty"`
+	RTT     float64 `json:"rtt_ms,omitempty"`
+}
+
+// Summary renders the path on one line, e.g. "3 hops: 10.0.0.1 → * → 203.0.113.5".
+func (p *NetworkPath) Summary() string {
+	if p == nil {
+		return ""
+	}
+	if p.Error != "" && len(p.Hops) == 0 {
+		return "path unavailable: " + p.Error
+	}
+	addrs := make([]string, 0, len(p.Hops))
+	for _, hop := range p.Hops {
+		if hop.Address == "" {
+			addrs = append(addrs, "*")
+		} else {
+			addrs = append(addrs, hop.Address)
+		}
+	}
+	if p.Reached {
+		return fmt.Sprintf("%d hops: %s", p.HopCount, strings.Join(addrs, " → "))
+	}
+	return fmt.Sprintf("destination not reached after %d hops: %s", len(p.Hops), strings.Join(addrs, " → "))
+}
+
+// probeReply is the outcome of a single TTL-limited probe. A zero Addr means
+// the probe timed out.
+type probeReply struct {
+	Addr    netip.Addr
+	Reached bool
+	RTT     time.Duration
+}
+
+// Tracer records the network path to targets with TTL-limited UDP datagrams
+// or TCP connection attempts. ICMP errors are read from the socket error
+// queue, so no raw socket or elevated privilege is needed. Paths are cached
+// per destination address.
+type Tracer struct {
+	Method  string        // TracerouteUDP (default) or TracerouteTCP
+	Port    int           // TCP destination port (default 443); ignored for UDP
+	MaxHops int           // Default 30
+	Timeout time.Duration // Per probe (default 2s)
+
+	probe func(ctx context.Context, network string, dst netip.AddrPort, ttl int, timeout time.Duration) (probeReply, error)
+
+	mu    sync.Mutex
+	paths map[netip.Addr]*NetworkPath
+}
+
+// Trace resolves host and records the path to its first address, preferring
+// IPv4.
+func (t *Tracer) Trace(ctx context.Context, host string) *NetworkPath {
+	path := &NetworkPath{Method: t.method(), CapturedAt: time.Now().UTC()}
+
+	dst, err := t.resolve(ctx, host)
+	if err != nil {
+		path.Error = err.Error()
+		return path
+	}
+	path.Destination = dst.String()
+
+	t.mu.Lock()
+	if cached, ok := t.paths[dst]; ok {
+		t.mu.Unlock()
+		return cached
+	}
+	t.mu.Unlock()
+
+	t.trace(ctx, dst, path)
+
+	if ctx.Err() == nil {
+		t.mu.Lock()
+		if t.paths == nil {
+			t.paths = make(map[netip.Addr]*NetworkPath)
+		}
+		t.paths[dst] = path
+		t.mu.Unlock()
+	}
+	return path
+}
+
+func (t *Tracer) trace(ctx context.Context, dst netip.Addr, path *NetworkPath) {
+	probe := t.probe
+	if probe == nil {
+		probe = traceProbe
+	}
+	port := t.Port
+	if t.method() == TracerouteUDP {
+		port = tracerouteUDPBasePort
+	} else if port == 0 {
+		port = 443
+	}
+	path.Port = port
+
+	silent := 0
+	for ttl := 1; ttl <= t.maxHops(); ttl++ {
+		if ctx.Err() != nil {
+			path.Error = ctx.Err().Error()
+			return
+		}
+		probePort := port
+		if t.method() == TracerouteUDP {
+			probePort += ttl
+		}
+		reply, err := probe(ctx, t.method(), netip.AddrPortFrom(dst, uint16(probePort)), ttl, t.timeout())
+		if err != nil {
+			path.Error = err.Error()
+			return
+		}
+
+		hop := PathHop{TTL: ttl}
+		if reply.Addr.IsValid() {
+			hop.Address = reply.Addr.String()
+			hop.RTT = float64(reply.RTT.Microseconds()) / 1000
+			silent = 0
+		} else {
+			silent++
+		}
+		path.Hops = append(path.Hops, hop)
+
+		if reply.Reached {
+			path.Reached = true
+			path.HopCount = ttl
+			return
+		}
+		if silent >= defaultTracerouteGap {
+			return
+		}
+	}
+}
+
+func (t *Tracer) resolve(ctx context.Context, host string) (netip.Addr, error) {
+	if addr, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil {
+		return addr.Unmap(), nil
+	}
+	lookupCtx, cancel := context.WithTimeout(ctx, t.timeout())
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupNetIP(lookupCtx, "ip", host)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	for _, addr := range addrs {
+		if addr.Unmap().Is4() {
+			return addr.Unmap(), nil
+		}
+	}
+	if len(addrs) == 0 {
+		return netip.Addr{}, fmt.Errorf("no addresses for %s", host)
+	}
+	return addrs[0], nil
+}
+
+func (t *Tracer) method() string {
+	if t.Method == TracerouteTCP {
+		return TracerouteTCP
+	}
+	return TracerouteUDP
+}
+
+func (t *Tracer) maxHops() int {
+	if t.MaxHops > 0 {
+		return t.MaxHops
+	}
+	return defaultTracerouteMaxHops
+}
+
+func (t *Tracer) timeout() time.Duration {
+	if t.Timeout > 0 {
+		return t.Timeout
+	}
+	return defaultTracerouteTimeout
+}
@@ -0,0 +1,136 @@
+//go:build linux
+
+package checker
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+const (
+	soEEOriginICMP  = 2
+	soEEOriginICMP6 = 3
+
+	// sockExtendedErrLen is sizeof(struct sock_extended_err); the offending
+	// address follows it in the control message.
+	sockExtendedErrLen = 16
+)
+
+// traceProbe sends one TTL-limited probe and waits for the ICMP error the
+// kernel queues on the socket (IP_RECVERR), which carries the address of the
+// router that dropped it. This works with ordinary datagram and stream
+// sockets.
+func traceProbe(ctx context.Context, network string, dst netip.AddrPort, ttl int, timeout time.Duration) (probeReply, error) {
+	family, level, ttlOpt, recvErrOpt := syscall.AF_INET, syscall.IPPROTO_IP, syscall.IP_TTL, syscall.IP_RECVERR
+	var sa syscall.Sockaddr = &syscall.SockaddrInet4{Port: int(dst.Port()), Addr: dst.Addr().As4()}
+	if dst.Addr().Is6() {
+		family, level, ttlOpt, recvErrOpt = syscall.AF_INET6, syscall.IPPROTO_IPV6, syscall.IPV6_UNICAST_HOPS, syscall.IPV6_RECVERR
+		sa = &syscall.SockaddrInet6{Port: int(dst.Port()), Addr: dst.Addr().As16()}
+	}
+	sotype := syscall.SOCK_DGRAM
+	if network == TracerouteTCP {
+		sotype = syscall.SOCK_STREAM
+	}
+
+	fd, err := syscall.Socket(family, sotype|syscall.SOCK_NONBLOCK|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return probeReply{}, fmt.Errorf("traceroute socket: %w", err)
+	}
+	defer syscall.Close(fd)
+	if err := syscall.SetsockoptInt(fd, level, ttlOpt, ttl); err != nil {
+		return probeReply{}, fmt.Errorf("set TTL: %w", err)
+	}
+	if err := syscall.SetsockoptInt(fd, level, recvErrOpt, 1); err != nil {
+		return probeReply{}, fmt.Errorf("enable error queue: %w", err)
+	}
+
+	start := time.Now()
+	reached := probeReply{Addr: dst.Addr(), Reached: true}
+	if network == TracerouteTCP {
+		err = syscall.Connect(fd, sa)
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			reached.RTT = time.Since(start)
+			return reached, nil
+		}
+		if err != nil && !errors.Is(err, syscall.EINPROGRESS) {
+			return probeReply{}, fmt.Errorf("connect: %w", err)
+		}
+	} else if err := syscall.Sendto(fd, []byte("seca-cli traceroute"), 0, sa); err != nil {
+		return probeReply{}, fmt.Errorf("send probe: %w", err)
+	}
+
+	deadline := start.Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	buf := make([]byte, 512)
+	oob := make([]byte, 512)
+	for {
+		if _, oobn, _, _, err := syscall.Recvmsg(fd, buf, oob, syscall.MSG_ERRQUEUE); err == nil {
+			if reply, ok := parseRecvErr(oob[:oobn], dst.Addr()); ok {
+				reply.RTT = time.Since(start)
+				return reply, nil
+			}
+		}
+		if network == TracerouteTCP {
+			soErr, err := syscall.GetsockoptInt(fd, syscall.SOL_SOCKET, syscall.SO_ERROR)
+			if err == nil && soErr == int(syscall.ECONNREFUSED) {
+				reached.RTT = time.Since(start)
+				return reached, nil
+			}
+			if err == nil && soErr == 0 {
+				if _, err := syscall.Getpeername(fd); err == nil {
+					reached.RTT = time.Since(start)
+					return reached, nil
+				}
+			}
+		}
+		if ctx.Err() != nil || time.Now().After(deadline) {
+			return probeReply{}, nil
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// parseRecvErr extracts the ICMP sender from an IP_RECVERR/IPV6_RECVERR
+// control message. The probe reached dst when dst itself sent the error,
+// typically "port unreachable" for a UDP probe.
+func parseRecvErr(oob []byte, dst netip.Addr) (probeReply, bool) {
+	msgs, err := syscall.ParseSocketControlMessage(oob)
+	if err != nil {
+		return probeReply{}, false
+	}
+	for _, m := range msgs {
+		isV4 := m.Header.Level == syscall.IPPROTO_IP && m.Header.Type == syscall.IP_RECVERR
+		isV6 := m.Header.Level == syscall.IPPROTO_IPV6 && m.Header.Type == syscall.IPV6_RECVERR
+		if !isV4 && !isV6 || len(m.Data) < sockExtendedErrLen+2 {
+			continue
+		}
+		origin := m.Data[4]
+		if origin != soEEOriginICMP && origin != soEEOriginICMP6 {
+			continue
+		}
+		offender := m.Data[sockExtendedErrLen:]
+		var from netip.Addr
+		switch binary.NativeEndian.Uint16(offender) {
+		case syscall.AF_INET:
+			if len(offender) >= 8 {
+				from = netip.AddrFrom4([4]byte(offender[4:8]))
+			}
+		case syscall.AF_INET6:
+			if len(offender) >= 24 {
+				from = netip.AddrFrom16([16]byte(offender[8:24])).Unmap()
+			}
+		}
+		if !from.IsValid() {
+			continue
+		}
+		return probeReply{Addr: from, Reached: from == dst}, true
+	}
+	return probeReply{}, false
+}
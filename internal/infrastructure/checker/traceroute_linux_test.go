@@ -0,0 +1,31 @@
+//go:build linux
+
+package checker
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestTracer_Loopback(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	defer ln.Close()
+
+	for _, tracer := range []*Tracer{
+		{Method: TracerouteUDP, MaxHops: 3, Timeout: time.Second},
+		{Method: TracerouteTCP, Port: ln.Addr().(*net.TCPAddr).Port, MaxHops: 3, Timeout: time.Second},
+	} {
+		path := tracer.Trace(context.Background(), "127.0.0.1")
+		if path.Error != "" {
+			t.Skipf("%s traceroute unavailable: %s", tracer.Method, path.Error)
+		}
+		if !path.Reached || path.HopCount != 1 || path.Hops[0].Address != "127.0.0.1" {
+			t.Errorf("%s: unexpected loopback path %+v", tracer.Method, path)
+		}
+	}
+}
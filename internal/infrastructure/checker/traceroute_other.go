@@ -0,0 +1,13 @@
+//go:build !linux
+
+package checker
+
+import (
+	"context"
+	"net/netip"
+	"time"
+)
+
+func traceProbe(ctx context.Context, network string, dst netip.AddrPort, ttl int, timeout time.Duration) (probeReply, error) {
+	return probeReply{}, errTracerouteUnsupported
+}
@@ -0,0 +1,81 @@
+package checker
+
+import (
+	"context"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTracer_Trace(t *testing.T) {
+	dst := netip.MustParseAddr("192.0.2.10")
+	routers := map[int]string{1: "10.0.0.1", 3: "198.51.100.1"}
+	probes := 0
+	var ports []uint16
+	tracer := &Tracer{
+		probe: func(_ context.Context, network string, to netip.AddrPort, ttl int, _ time.Duration) (probeReply, error) {
+			probes++
+			ports = append(ports, to.Port())
+			if ttl == 4 {
+				return probeReply{Addr: dst, Reached: true, RTT: 12 * time.Millisecond}, nil
+			}
+			if r, ok := routers[ttl]; ok {
+				return probeReply{Addr: netip.MustParseAddr(r), RTT: time.Millisecond}, nil
+			}
+			return probeReply{}, nil
+		},
+	}
+
+	path := tracer.Trace(context.Background(), "192.0.2.10")
+	if !path.Reached || path.HopCount != 4 || len(path.Hops) != 4 || path.Method != TracerouteUDP {
+		t.Fatalf("unexpected path %+v", path)
+	}
+	if ports[0] != tracerouteUDPBasePort+1 || ports[3] != tracerouteUDPBasePort+4 {
+		t.Errorf("UDP probes should use a port per hop, got %v", ports)
+	}
+	if got := path.Summary(); got != "4 hops: 10.0.0.1 → * → 198.51.100.1 → 192.0.2.10" {
+		t.Errorf("summary = %q", got)
+	}
+	if path.Hops[3].RTT != 12 {
+		t.Errorf("rtt = %v", path.Hops[3].RTT)
+	}
+
+	if again := tracer.Trace(context.Background(), "192.0.2.10"); again != path || probes != 4 {
+		t.Errorf("expected cached path, probes = %d", probes)
+	}
+}
+
+func TestTracer_StopsAfterSilentHops(t *testing.T) {
+	var ports []uint16
+	tracer := &Tracer{
+		Method: TracerouteTCP,
+		probe: func(_ context.Context, _ string, to netip.AddrPort, ttl int, _ time.Duration) (probeReply, error) {
+			ports = append(ports, to.Port())
+			if ttl == 1 {
+				return probeReply{Addr: netip.MustParseAddr("10.0.0.1")}, nil
+			}
+			return probeReply{}, nil
+		},
+	}
+	path := tracer.Trace(context.Background(), "192.0.2.20")
+	if path.Reached || len(path.Hops) != 1+defaultTracerouteGap {
+		t.Fatalf("unexpected path %+v", path)
+	}
+	if path.Port != 443 || ports[0] != 443 || ports[len(ports)-1] != 443 {
+		t.Errorf("TCP probes should use the fixed port, got %v", ports)
+	}
+	if !strings.HasPrefix(path.Summary(), "destination not reached after 6 hops") {
+		t.Errorf("summary = %q", path.Summary())
+	}
+}
+
+func TestTracer_Unsupported(t *testing.T) {
+	tracer := &Tracer{probe: func(context.Context, string, netip.AddrPort, int, time.Duration) (probeReply, error) {
+		return probeReply{}, errTracerouteUnsupported
+	}}
+	path := tracer.Trace(context.Background(), "192.0.2.30")
+	if path.Error == "" || !strings.HasPrefix(path.Summary(), "path unavailable") {
+		t.Fatalf("unexpected path %+v", path)
+	}
+}
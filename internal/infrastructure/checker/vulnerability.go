@@ -2,6 +2,8 @@ package checker
 
 import (
 	"fmt"
+	"iter"
+	"slices"
 	"sort"
 	"strings"
 )
@@ -22,6 +24,7 @@ type Vulnerability struct {
 	CodeExample       string                       `json:"code_example,omitempty"`     // Example fix code
 	TestingStrategy   string                       `json:"testing_strategy,omitempty"` // How to test
 	ComplianceMapping map[string]ComplianceDetails `json:"compliance_mapping,omitempty"` // Framework ID -> Compliance details
+	Attachments       []EvidenceRef                `json:"attachments,omitempty"`        // Stored evidence artifacts
 }
 
 // ComplianceDetails holds compliance-specific information for a security check
@@ -37,6 +40,12 @@ type CVSSScore struct {
 	Vector    string  `json:"vector"`
 	Severity  string  `json:"severity"`
 	Version   string  `json:"version"` // "3.1" or "4.0"
+	// EnvironmentalScore is the score adjusted for the engagement's
+	// context, with EnvironmentalVector naming the modified metrics and
+	// Context explaining why.
+	EnvironmentalScore  float64 `json:"environmental_score,omitempty"`
+	EnvironmentalVector string  `json:"environmental_vector,omitempty"`
+	Context             string  `json:"context,omitempty"`
 }
 
 // VulnerabilityReport contains all security findings
@@ -63,141 +72,69 @@ type VulnerabilitySummary struct {
 
 // BuildVulnerabilityReport analyzes CheckResults and generates vulnerability findings
 func BuildVulnerabilityReport(results []CheckResult, scanURL string, startTime, endTime string) *VulnerabilityReport {
+	return BuildVulnerabilityReportSeq(slices.Values(results), scanURL, startTime, endTime)
+}
+
+// BuildVulnerabilityReportSeq is BuildVulnerabilityReport for results read
+// one at a time; only the findings are kept.
+func BuildVulnerabilityReportSeq(results iter.Seq[CheckResult], scanURL string, startTime, endTime string) *VulnerabilityReport {
 	report := &VulnerabilityReport{
-		ScanDate:         startTime,
-		Duration:         endTime,
-		ScanURL:          scanURL,
-		Status:           "Completed",
-		TotalURLsScanned: len(results),
-		Vulnerabilities:  []Vulnerability{},
-		Summary:          VulnerabilitySummary{},
+		ScanDate:        startTime,
+		Duration:        endTime,
+		ScanURL:         scanURL,
+		Status:          "Completed",
+		Vulnerabilities: []Vulnerability{},
+		Summary:         VulnerabilitySummary{},
 	}
 
 	// Aggregate findings across all targets
 	findingDetails := make(map[string]*Vulnerability)
 
-	for _, result := range results {
-		// Analyze security headers
-		if result.SecurityHeaders != nil {
-			vulns := analyzeSecurityHeaders(result.SecurityHeaders, result.Target)
-			for _, vuln := range vulns {
-				key := vuln.Name
-				if existing, ok := findingDetails[key]; ok {
-					existing.AffectedURLs = append(existing.AffectedURLs, result.Target)
-				} else {
-					vuln.AffectedURLs = []string{result.Target}
-					findingDetails[key] = &vuln
-				}
-			}
-		}
-
-		// Analyze TLS compliance
-		if result.TLSCompliance != nil {
-			vulns := analyzeTLSCompliance(result.TLSCompliance, result.Target)
-			for _, vuln := range vulns {
-				key := vuln.Name
-				if existing, ok := findingDetails[key]; ok {
-					existing.AffectedURLs = append(existing.AffectedURLs, result.Target)
-				} else {
-					vuln.AffectedURLs = []string{result.Target}
-					findingDetails[key] = &vuln
-				}
-			}
-		}
-
-		// Analyze cookies
-		if len(result.CookieFindings) > 0 {
-			vulns := analyzeCookieFindings(result.CookieFindings, result.Target)
-			for _, vuln := range vulns {
-				key := vuln.Name
-				if existing, ok := findingDetails[key]; ok {
-					existing.AffectedURLs = append(existing.AffectedURLs, result.Target)
-				} else {
-					vuln.AffectedURLs = []string{result.Target}
-					findingDetails[key] = &vuln
-				}
-			}
-		}
-
-		// Analyze CORS
-		if result.CORSInsights != nil && len(result.CORSInsights.Issues) > 0 {
-			vulns := analyzeCORSIssues(result.CORSInsights, result.Target)
-			for _, vuln := range vulns {
-				key := vuln.Name
-				if existing, ok := findingDetails[key]; ok {
-					existing.AffectedURLs = append(existing.AffectedURLs, result.Target)
-				} else {
-					vuln.AffectedURLs = []string{result.Target}
-					findingDetails[key] = &vuln
-				}
-			}
-		}
-
-		// Analyze cache policy
-		if result.CachePolicy != nil && len(result.CachePolicy.Issues) > 0 {
-			vulns := analyzeCachePolicy(result.CachePolicy, result.Target)
-			for _, vuln := range vulns {
-				key := vuln.Name
-				if existing, ok := findingDetails[key]; ok {
-					existing.AffectedURLs = append(existing.AffectedURLs, result.Target)
-				} else {
-					vuln.AffectedURLs = []string{result.Target}
-					findingDetails[key] = &vuln
-				}
-			}
-		}
-
-		// Analyze client-side security (vulnerable libraries, CSRF, Trusted Types)
-		if result.ClientSecurity != nil {
-			vulns := analyzeClientSecurity(result.ClientSecurity, result.Target)
-			for _, vuln := range vulns {
-				key := vuln.Name
-				if existing, ok := findingDetails[key]; ok {
-					existing.AffectedURLs = append(existing.AffectedURLs, result.Target)
-				} else {
-					vuln.AffectedURLs = []string{result.Target}
-					findingDetails[key] = &vuln
-				}
-			}
-		}
-
-		// Analyze network security (open ports, subdomain takeover)
-		if result.NetworkSecurity != nil {
-			vulns := analyzeNetworkSecurity(result.NetworkSecurity, result.Target)
-			for _, vuln := range vulns {
-				key := vuln.Name
-				if existing, ok := findingDetails[key]; ok {
-					existing.AffectedURLs = append(existing.AffectedURLs, result.Target)
-				} else {
-					vuln.AffectedURLs = []string{result.Target}
-					findingDetails[key] = &vuln
-				}
+	for result := range results {
+		report.TotalURLsScanned++
+		for _, vuln := range resultVulnerabilities(result) {
+			if existing, ok := findingDetails[vuln.Name]; ok {
+				existing.AffectedURLs = append(existing.AffectedURLs, result.Target)
+			} else {
+				findingDetails[vuln.Name] = &vuln
 			}
 		}
 	}
 
 	// Convert map to slice and calculate summary
+	vulns := make([]Vulnerability, 0, len(findingDetails))
 	for _, vuln := range findingDetails {
-		report.Vulnerabilities = append(report.Vulnerabilities, *vuln)
+		vulns = append(vulns, *vuln)
+	}
+	report.Add(vulns...)
+
+	return report
+}
+
+// Add appends findings from outside the result analysis (e.g. manual
+// findings), updating the summary and keeping severity order. Each
+// finding's CVSS is completed and scored for its context.
+func (r *VulnerabilityReport) Add(vulns ...Vulnerability) {
+	for _, vuln := range vulns {
+		vuln = scoreCVSS(vuln)
+		r.Vulnerabilities = append(r.Vulnerabilities, vuln)
 		switch vuln.Severity {
 		case "Critical":
-			report.Summary.Critical++
+			r.Summary.Critical++
 		case "High":
-			report.Summary.High++
+			r.Summary.High++
 		case "Medium":
-			report.Summary.Medium++
+			r.Summary.Medium++
 		case "Low":
-			report.Summary.Low++
+			r.Summary.Low++
 		case "Info":
-			report.Summary.Info++
+			r.Summary.Info++
 		}
-		report.Summary.Total++
+		r.Summary.Total++
 	}
 
 	// Sort vulnerabilities by severity (Critical > High > Medium > Low > Info)
-	sortVulnerabilitiesBySeverity(report.Vulnerabilities)
-
-	return report
+	sortVulnerabilitiesBySeverity(r.Vulnerabilities)
 }
 
 // sortVulnerabilitiesBySeverity sorts vulnerabilities by severity in descending order
@@ -333,16 +270,23 @@ Testing Strategy:
 4. Switch to enforced mode once stable`,
 			})
 		} else if len(header.Issues) > 0 {
-			// CSP present but has issues
+			// CSP present but has issues; rate it by the worst directive finding
+			severity := "Medium"
+			switch worstCSPSeverity(sh.CSPFindings) {
+			case CSPSeverityHigh:
+				severity = "High"
+			case CSPSeverityLow:
+				severity = "Low"
+			}
 			vulns = append(vulns, Vulnerability{
 				Name:        "Content Security Policy (CSP) Configuration Issue",
 				Category:    "Content Security Policy (CSP)",
-				Severity:    "Medium",
+				Severity:    severity,
 				Score:       header.Score,
 				MaxScore:    header.MaxScore,
 				Status:      "Warning",
 				Description: "Content Security Policy is present but has configuration issues that may reduce its effectiveness.",
-				Recommendation: fmt.Sprintf(`MEDIUM: CSP header has configuration issues.
+				Recommendation: fmt.Sprintf(`%s: CSP header has configuration issues.
 
 Current value: %s
 
@@ -350,6 +294,7 @@ Issues found:
 %s
 
 Review and strengthen your CSP configuration to ensure maximum protection against XSS attacks.`,
+					strings.ToUpper(severity),
 					header.Value,
 					strings.Join(header.Issues, "\n")),
 			})
@@ -374,8 +319,18 @@ Your configuration is secure. No action required.`, header.Value),
 		}
 	}
 
-	// CSP Bypass check (add as info - would require deep analysis to detect actual bypasses)
+	// CSP Bypass check (add as info with the evaluator's advisory notes; JSONP
+	// and gadget bypasses on allowed hosts still need manual review)
 	if header, ok := sh.Headers["Content-Security-Policy"]; ok && header.Present {
+		advisories := ""
+		for _, f := range sh.CSPFindings {
+			if f.Severity == CSPSeverityInfo {
+				advisories += "• " + f.String() + "\n"
+			}
+		}
+		if advisories != "" {
+			advisories = "\nAdvisories for this policy:\n" + advisories
+		}
 		vulns = append(vulns, Vulnerability{
 			Name:        "Content Security Policy (CSP) Bypass",
 			Category:    "Content Security Policy (CSP)",
@@ -405,7 +360,7 @@ Review Checklist:
 6. Use CSP Evaluator tool: https://csp-evaluator.withgoogle.com/
 
 Current policy: ` + header.Value + `
-
+` + advisories + `
 Regular CSP auditing is recommended.`,
 		})
 	}
@@ -829,6 +784,9 @@ Your configuration is secure. No action required.`, header.Value),
 		}
 	}
 
+	// Reporting API and Network Error Logging headers (optional)
+	vulns = append(vulns, analyzeReportingHeaders(sh, target)...)
+
 	// Check for deprecated X-XSS-Protection header
 	if len(sh.Warnings) > 0 {
 		for _, warning := range sh.Warnings {
@@ -896,9 +854,9 @@ Implementation:
 
 Removing server information makes it harder for attackers to identify your stack and find specific exploits.`,
 					CVSS: &CVSSScore{
-						BaseScore: 2.0,
+						BaseScore: 3.7,
 						Severity:  "LOW",
-						Vector:    "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:L/I:N/A:N",
+						Vector:    "CVSS:3.1/AV:N/AC:H/PR:N/UI:N/S:U/C:L/I:N/A:N",
 						Version:   "3.1",
 					},
 				})
@@ -956,12 +914,15 @@ func analyzeTLSCompliance(tls *TLSComplianceResult, target string) []Vulnerabili
 	for _, issue := range tls.Issues {
 		severity := "Medium"
 		cvssScore := 5.3
+		vector := "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:L/I:N/A:N"
 		if issue.Severity == "critical" {
 			severity = "Critical"
 			cvssScore = 9.1
+			vector = "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:N"
 		} else if issue.Severity == "high" {
 			severity = "High"
 			cvssScore = 7.4
+			vector = "CVSS:3.1/AV:N/AC:H/PR:N/UI:N/S:U/C:H/I:H/A:N"
 		}
 
 		vulns = append(vulns, Vulnerability{
@@ -976,7 +937,7 @@ func analyzeTLSCompliance(tls *TLSComplianceResult, target string) []Vulnerabili
 			CVSS: &CVSSScore{
 				BaseScore: cvssScore,
 				Severity:  strings.ToUpper(severity),
-				Vector:    "CVSS:3.1/AV:N/AC:H/PR:N/UI:N/S:U/C:H/I:H/A:N",
+				Vector:    vector,
 				Version:   "3.1",
 			},
 		})
@@ -1728,6 +1689,55 @@ Note: Modern browsers primarily use Cache-Control, but Expires provides backward
 		}
 	}
 
+	for _, finding := range cache.Findings {
+		name := "Web Cache Deception"
+		recommendation := `Mark responses that depend on the user's session as private:
+
+Cache-Control: private, no-store
+
+Alternatively add "Vary: Cookie" (or Authorization) so shared caches key on the credentials, and make CDNs bypass the cache for authenticated traffic. Never cache responses that set cookies.`
+		cvss := &CVSSScore{BaseScore: 7.5, Severity: "HIGH", Vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:N/A:N", Version: "3.1"}
+		references := []string{
+			"https://portswigger.net/web-security/web-cache-poisoning",
+			"https://portswigger.net/web-security/web-cache-deception",
+			"https://www.rfc-editor.org/rfc/rfc9111",
+		}
+		switch finding.Type {
+		case CacheFindingUnkeyedHeader:
+			name = fmt.Sprintf("Web Cache Poisoning: Unkeyed %s Header", finding.Header)
+			recommendation = fmt.Sprintf(`Stop the application from using the %s request header, or add it to the cache key (e.g. "Vary: %s").
+
+Strip forwarding headers at the edge unless a trusted proxy sets them, and do not cache responses whose content or status depends on them.`, finding.Header, finding.Header)
+			cvss = &CVSSScore{BaseScore: 6.1, Severity: "MEDIUM", Vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N", Version: "3.1"}
+		case CacheFindingHostHeader:
+			name = "Host Header Injection"
+			recommendation = `Build absolute URLs (redirects, password reset links, canonical links) from a configured hostname, never from the Host request header.
+
+Validate Host against the site's own names (e.g. Django ALLOWED_HOSTS, Rails config.hosts) and have the default virtual host reject unknown names with 400 or 421. Keep the host in the cache key.`
+			cvss = &CVSSScore{BaseScore: 6.1, Severity: "MEDIUM", Vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N", Version: "3.1"}
+			if finding.Severity == "high" {
+				name = "Web Cache Poisoning: Host Header"
+			}
+			references = append([]string{"https://portswigger.net/web-security/host-header"}, references[0], references[2])
+		}
+		severity := "Medium"
+		if finding.Severity == "high" {
+			severity = "High"
+		}
+		vulns = append(vulns, Vulnerability{
+			Name:           name,
+			Category:       "Cache Configuration",
+			Severity:       severity,
+			Score:          0,
+			MaxScore:       10,
+			Status:         "Failed",
+			Description:    finding.Description + ".\n\nEvidence: " + finding.Evidence,
+			Recommendation: fmt.Sprintf("%s: %s\n\n%s", strings.ToUpper(severity), finding.Description, recommendation),
+			CVSS:           cvss,
+			References:     references,
+		})
+	}
+
 	return vulns
 }
 
@@ -1753,6 +1763,10 @@ func analyzeClientSecurity(cs *ClientSecurityResult, target string) []Vulnerabil
 		}
 
 		vulnName := fmt.Sprintf("Vulnerable %s Library", lib.Name)
+		vector := lib.CVSSVector
+		if vector == "" {
+			vector = "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:H/I:H/A:N"
+		}
 
 		vulns = append(vulns, Vulnerability{
 			Name:     vulnName,
@@ -1786,13 +1800,42 @@ Risk: Attackers can exploit known vulnerabilities in outdated JavaScript librari
 			CVSS: &CVSSScore{
 				BaseScore: cvssScore,
 				Severity:  strings.ToUpper(severity),
-				Vector:    "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:H/I:H/A:N",
+				Vector:    vector,
 				Version:   "3.1",
 			},
 			References: lib.VulnerabilityIDs,
 		})
 	}
 
+	// Links to IDN homographs of other hosts are a phishing risk for visitors
+	if len(cs.LookalikeLinks) > 0 {
+		hosts := make([]string, 0, len(cs.LookalikeLinks))
+		for _, link := range cs.LookalikeLinks {
+			hosts = append(hosts, fmt.Sprintf("%s (looks like %s)", link.Host, link.LooksLike))
+		}
+		vulns = append(vulns, Vulnerability{
+			Name:     "Lookalike Domain Links",
+			Category: "Client-Side Security",
+			Severity: "Info",
+			Score:    15,
+			MaxScore: 20,
+			Status:   "Warning",
+			Description: fmt.Sprintf("The page links to %d internationalized host(s) written with letters that imitate Latin ones: %s. Such homograph domains are used in phishing, and links to them may have been injected.",
+				len(cs.LookalikeLinks), strings.Join(hosts, ", ")),
+			Recommendation: `INFO: Links to lookalike (homograph) domains detected.
+
+Review each link:
+• Confirm the linked domain is owned by your organization or a trusted partner
+• Remove links injected through user content, compromised templates, or third-party widgets
+• Consider registering lookalike variants of your own domains defensively
+• Browsers may display these hosts in punycode (xn--) form, which confuses users`,
+			References: []string{
+				"https://www.unicode.org/reports/tr39/",
+				"https://en.wikipedia.org/wiki/IDN_homograph_attack",
+			},
+		})
+	}
+
 	// Add "No Vulnerable JS Libraries" check when none are found
 	if len(cs.VulnerableLibraries) == 0 {
 		vulns = append(vulns, Vulnerability{
@@ -2146,10 +2189,12 @@ Your configuration is secure. No action required.`,
 		confidenceLevel := ns.SubdomainTakeover.Confidence
 		severity := "High"
 		cvssScore := 7.5
+		vector := "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:H/A:N"
 
 		if confidenceLevel == "high" {
 			severity = "Critical"
 			cvssScore = 9.1
+			vector = "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:N"
 		}
 
 		vulns = append(vulns, Vulnerability{
@@ -2222,7 +2267,7 @@ Prevention:
 			CVSS: &CVSSScore{
 				BaseScore: cvssScore,
 				Severity:  strings.ToUpper(severity),
-				Vector:    "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:N",
+				Vector:    vector,
 				Version:   "3.1",
 			},
 			References: []string{
@@ -2260,5 +2305,9 @@ Your configuration is secure. No action required.`,
 		})
 	}
 
+	vulns = append(vulns, analyzeServiceCVEs(ns.ServiceCVEs)...)
+	vulns = append(vulns, analyzeAdminPanels(ns.AdminPanels)...)
+	vulns = append(vulns, analyzeExposures(ns.Exposures)...)
+
 	return vulns
 }
@@ -377,6 +377,165 @@ func GetComplianceMappings() map[string]ComplianceMapping {
 			},
 		},
 
+		"Known Vulnerable Service Versions": {
+			CheckName: "Known Vulnerable Service Versions",
+			Frameworks: map[string][]string{
+				"iso27001":  {"A.8.8"},
+				"jisq27001": {"A.8.8"},
+				"mtcs":      {"TVM-02"},
+				"kisms":     {"2.11.2"},
+				"fisc":      {"Network Security 2-2"},
+			},
+			Priority: map[string]string{
+				"iso27001": "High", "jisq27001": "High", "mtcs": "High",
+				"kisms": "High", "fisc": "High",
+			},
+		},
+		"Exposed Admin Interface": {
+			CheckName: "Exposed Admin Interface",
+			Frameworks: map[string][]string{
+				"iso27001":  {"A.8.2", "A.8.20"},
+				"jisq27001": {"A.8.20"},
+				"mtcs":      {"IVS-06"},
+				"kisms":     {"2.6.1"},
+				"fisc":      {"Network Security 2-2"},
+			},
+			Priority: map[string]string{
+				"iso27001": "High", "jisq27001": "High", "mtcs": "High",
+				"kisms": "High", "fisc": "High",
+			},
+		},
+
+		// DNS delegation and domain registration
+		"Lame DNS Delegation": {
+			CheckName: "Lame DNS Delegation",
+			Frameworks: map[string][]string{
+				"iso27001":  {"A.8.20", "A.8.21"},
+				"jisq27001": {"A.8.20", "A.8.21"},
+				"mtcs":      {"IVS-06"},
+				"kisms":     {"2.6.1"},
+				"fisc":      {"Network Security 2-2"},
+			},
+			Priority: map[string]string{
+				"iso27001": "Medium", "jisq27001": "Medium", "mtcs": "Medium",
+				"kisms": "Medium", "fisc": "Medium",
+			},
+		},
+		"Inconsistent NS Delegation": {
+			CheckName: "Inconsistent NS Delegation",
+			Frameworks: map[string][]string{
+				"iso27001":  {"A.8.21"},
+				"jisq27001": {"A.8.21"},
+				"mtcs":      {"IVS-06"},
+				"kisms":     {"2.6.1"},
+				"fisc":      {"Network Security 2-2"},
+			},
+			Priority: map[string]string{
+				"iso27001": "Low", "jisq27001": "Low", "mtcs": "Low",
+				"kisms": "Low", "fisc": "Low",
+			},
+		},
+		"Domain Registration Expiring Soon": {
+			CheckName: "Domain Registration Expiring Soon",
+			Frameworks: map[string][]string{
+				"iso27001":  {"A.5.9", "A.8.21"},
+				"jisq27001": {"A.5.9", "A.8.21"},
+				"mtcs":      {"IVS-06"},
+				"kisms":     {"2.1.3"},
+				"fisc":      {"Network Security 2-2"},
+			},
+			Priority: map[string]string{
+				"iso27001": "Medium", "jisq27001": "Medium", "mtcs": "Medium",
+				"kisms": "Medium", "fisc": "Medium",
+			},
+		},
+		"Domain Registration Expired": {
+			CheckName: "Domain Registration Expired",
+			Frameworks: map[string][]string{
+				"iso27001":  {"A.5.9", "A.8.21"},
+				"jisq27001": {"A.5.9", "A.8.21"},
+				"mtcs":      {"IVS-06"},
+				"kisms":     {"2.1.3"},
+				"fisc":      {"Network Security 2-2"},
+			},
+			Priority: map[string]string{
+				"iso27001": "High", "jisq27001": "High", "mtcs": "High",
+				"kisms": "High", "fisc": "High",
+			},
+		},
+
+		// Data residency
+		"Hosting Outside Approved Jurisdiction": {
+			CheckName: "Hosting Outside Approved Jurisdiction",
+			Frameworks: map[string][]string{
+				"iso27001": {"A.5.34"},
+				"iso27701": {"7.5.1", "7.5.2"},
+				"pdpa":     {"Transfer Limitation Obligation 26"},
+				"ismsp":    {"3.3.4"},
+			},
+			Priority: map[string]string{
+				"iso27001": "Medium", "iso27701": "High", "pdpa": "High", "ismsp": "High",
+			},
+		},
+
+		// Information Disclosure
+		"Directory Listing Enabled": {
+			CheckName: "Directory Listing Enabled",
+			Frameworks: map[string][]string{
+				"iso27001":  {"A.8.9", "A.8.12"},
+				"jisq27001": {"A.8.9"},
+				"mtcs":      {"IVS-06"},
+				"kisms":     {"2.10.1"},
+				"fisc":      {"Network Security 2-2"},
+			},
+			Priority: map[string]string{
+				"iso27001": "High", "jisq27001": "High", "mtcs": "High",
+				"kisms": "High", "fisc": "High",
+			},
+		},
+		"Exposed Git Repository (.git/config)": {
+			CheckName: "Exposed Git Repository (.git/config)",
+			Frameworks: map[string][]string{
+				"iso27001":  {"A.8.9", "A.8.12"},
+				"jisq27001": {"A.8.9"},
+				"mtcs":      {"IVS-06"},
+				"kisms":     {"2.10.1"},
+				"fisc":      {"Network Security 2-2"},
+			},
+			Priority: map[string]string{
+				"iso27001": "High", "jisq27001": "High", "mtcs": "High",
+				"kisms": "High", "fisc": "High",
+			},
+		},
+		"Exposed Environment File (.env)": {
+			CheckName: "Exposed Environment File (.env)",
+			Frameworks: map[string][]string{
+				"iso27001":  {"A.8.9", "A.8.12"},
+				"jisq27001": {"A.8.9"},
+				"mtcs":      {"IVS-06"},
+				"kisms":     {"2.10.1"},
+				"fisc":      {"Network Security 2-2"},
+			},
+			Priority: map[string]string{
+				"iso27001": "High", "jisq27001": "High", "mtcs": "High",
+				"kisms": "High", "fisc": "High",
+			},
+		},
+		"Exposed Backup File": {
+			CheckName: "Exposed Backup File",
+			Frameworks: map[string][]string{
+				"iso27001":  {"A.8.9", "A.8.12"},
+				"jisq27001": {"A.8.9"},
+				"mtcs":      {"IVS-06"},
+				"kisms":     {"2.10.1"},
+				"fisc":      {"Network Security 2-2"},
+			},
+			Priority: map[string]string{
+				"iso27001": "High", "jisq27001": "High", "mtcs": "High",
+				"kisms": "High", "fisc": "High",
+			},
+		},
+
 		// Miscellaneous Headers
 		"Referrer Policy": {
 			CheckName: "Referrer Policy",
@@ -428,6 +587,44 @@ func GetComplianceMappings() map[string]ComplianceMapping {
 				"pdpa": "Medium", "privacymark": "Medium",
 			},
 		},
+		"Reporting-Endpoints header": {
+			CheckName: "Reporting-Endpoints header",
+			Frameworks: map[string][]string{
+				"iso27001":  {"A.8.15", "A.8.16"},
+				"jisq27001": {"A.8.16"},
+				"kisms":     {"2.9.4"},
+				"ismsp":     {"2.9.4"},
+			},
+			Priority: map[string]string{
+				"iso27001": "Low", "jisq27001": "Low", "kisms": "Low", "ismsp": "Low",
+			},
+		},
+		"Report-To header": {
+			CheckName: "Report-To header",
+			Frameworks: map[string][]string{
+				"iso27001":  {"A.8.15", "A.8.16"},
+				"jisq27001": {"A.8.16"},
+				"kisms":     {"2.9.4"},
+				"ismsp":     {"2.9.4"},
+			},
+			Priority: map[string]string{
+				"iso27001": "Low", "jisq27001": "Low", "kisms": "Low", "ismsp": "Low",
+			},
+		},
+		"NEL header": {
+			CheckName: "NEL header",
+			Frameworks: map[string][]string{
+				"iso27001":  {"A.8.16"},
+				"jisq27001": {"A.8.16"},
+				"mtcs":      {"IVS-01"},
+				"kisms":     {"2.9.4"},
+				"fisc":      {"Network Security 2-3"},
+			},
+			Priority: map[string]string{
+				"iso27001": "Low", "jisq27001": "Low", "mtcs": "Low",
+				"kisms": "Low", "fisc": "Low",
+			},
+		},
 
 		// Miscellaneous
 		"Vulnerable JS Libraries": {
@@ -0,0 +1,125 @@
+package issuesync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const defaultGitHubAPI = "https://api.github.com"
+
+// GitHubTracker opens and closes issues through the GitHub REST API v3.
+type GitHubTracker struct {
+	client  *http.Client
+	baseURL string
+	owner   string
+	repo    string
+	token   string
+}
+
+func newGitHubTracker(client *http.Client, baseURL, repo, token string) (*GitHubTracker, error) {
+	parts := strings.Split(strings.Trim(repo, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("GitHub repository must be in owner/name form, got %q", repo)
+	}
+	if baseURL == "" {
+		baseURL = defaultGitHubAPI
+	}
+	return &GitHubTracker{
+		client:  client,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		owner:   parts[0],
+		repo:    parts[1],
+		token:   token,
+	}, nil
+}
+
+// Name returns the provider identifier.
+func (g *GitHubTracker) Name() string {
+	return "github"
+}
+
+type githubIssue struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+}
+
+// CreateIssue opens a new GitHub issue.
+func (g *GitHubTracker) CreateIssue(ctx context.Context, req IssueRequest) (*Issue, error) {
+	payload := map[string]interface{}{
+		"title":  req.Title,
+		"body":   req.Body,
+		"labels": req.Labels,
+	}
+	var created githubIssue
+	if err := g.do(ctx, http.MethodPost, g.issuesPath(), payload, &created); err != nil {
+		return nil, fmt.Errorf("create GitHub issue: %w", err)
+	}
+	return &Issue{
+		Number: created.Number,
+		URL:    created.HTMLURL,
+		Title:  created.Title,
+		State:  created.State,
+	}, nil
+}
+
+// CloseIssue comments on and closes a GitHub issue.
+func (g *GitHubTracker) CloseIssue(ctx context.Context, number int, comment string) error {
+	if comment != "" {
+		path := fmt.Sprintf("%s/%d/comments", g.issuesPath(), number)
+		if err := g.do(ctx, http.MethodPost, path, map[string]string{"body": comment}, nil); err != nil {
+			return fmt.Errorf("comment on GitHub issue #%d: %w", number, err)
+		}
+	}
+	path := fmt.Sprintf("%s/%d", g.issuesPath(), number)
+	if err := g.do(ctx, http.MethodPatch, path, map[string]string{"state": "closed"}, nil); err != nil {
+		return fmt.Errorf("close GitHub issue #%d: %w", number, err)
+	}
+	return nil
+}
+
+func (g *GitHubTracker) issuesPath() string {
+	return fmt.Sprintf("/repos/%s/%s/issues", g.owner, g.repo)
+}
+
+func (g *GitHubTracker) do(ctx context.Context, method, path string, payload, out interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, g.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+g.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	return doJSON(g.client, req, out)
+}
+
+// doJSON executes req and decodes a JSON response into out (when non-nil).
+func doJSON(client *http.Client, req *http.Request, out interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
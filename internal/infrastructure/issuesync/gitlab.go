@@ -0,0 +1,102 @@
+package issuesync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const defaultGitLabAPI = "https://gitlab.com/api/v4"
+
+// GitLabTracker opens and closes issues through the GitLab REST API v4.
+type GitLabTracker struct {
+	client  *http.Client
+	baseURL string
+	project string
+	token   string
+}
+
+func newGitLabTracker(client *http.Client, baseURL, project, token string) (*GitLabTracker, error) {
+	project = strings.Trim(project, "/")
+	if project == "" {
+		return nil, fmt.Errorf("GitLab project path is required")
+	}
+	if baseURL == "" {
+		baseURL = defaultGitLabAPI
+	}
+	return &GitLabTracker{
+		client:  client,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		project: project,
+		token:   token,
+	}, nil
+}
+
+// Name returns the provider identifier.
+func (g *GitLabTracker) Name() string {
+	return "gitlab"
+}
+
+type gitlabIssue struct {
+	IID    int    `json:"iid"`
+	WebURL string `json:"web_url"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+}
+
+// CreateIssue opens a new GitLab issue.
+func (g *GitLabTracker) CreateIssue(ctx context.Context, req IssueRequest) (*Issue, error) {
+	payload := map[string]string{
+		"title":       req.Title,
+		"description": req.Body,
+		"labels":      strings.Join(req.Labels, ","),
+	}
+	var created gitlabIssue
+	if err := g.do(ctx, http.MethodPost, g.issuesPath(), payload, &created); err != nil {
+		return nil, fmt.Errorf("create GitLab issue: %w", err)
+	}
+	return &Issue{
+		Number: created.IID,
+		URL:    created.WebURL,
+		Title:  created.Title,
+		State:  created.State,
+	}, nil
+}
+
+// CloseIssue adds a note to and closes a GitLab issue.
+func (g *GitLabTracker) CloseIssue(ctx context.Context, number int, comment string) error {
+	if comment != "" {
+		path := fmt.Sprintf("%s/%d/notes", g.issuesPath(), number)
+		if err := g.do(ctx, http.MethodPost, path, map[string]string{"body": comment}, nil); err != nil {
+			return fmt.Errorf("comment on GitLab issue #%d: %w", number, err)
+		}
+	}
+	path := fmt.Sprintf("%s/%d", g.issuesPath(), number)
+	if err := g.do(ctx, http.MethodPut, path, map[string]string{"state_event": "close"}, nil); err != nil {
+		return fmt.Errorf("close GitLab issue #%d: %w", number, err)
+	}
+	return nil
+}
+
+func (g *GitLabTracker) issuesPath() string {
+	return fmt.Sprintf("/projects/%s/issues", url.PathEscape(g.project))
+}
+
+func (g *GitLabTracker) do(ctx context.Context, method, path string, payload, out interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, g.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("PRIVATE-TOKEN", g.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	return doJSON(g.client, req, out)
+}
@@ -0,0 +1,274 @@
+package issuesync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+)
+
+// Finding is the minimal view of a vulnerability needed for issue sync.
+type Finding struct {
+	Name           string
+	Category       string
+	Severity       string
+	Description    string
+	Recommendation string
+	AffectedURLs   []string
+	// CVSSVector and CVSSScore are the finding's CVSS v3.1 base vector and
+	// score, when it has one.
+	CVSSVector string
+	CVSSScore  float64
+}
+
+// TrackedIssue records an issue that was opened for a finding.
+type TrackedIssue struct {
+	Fingerprint string    `json:"fingerprint"`
+	Finding     string    `json:"finding"`
+	Severity    string    `json:"severity"`
+	Number      int       `json:"number"`
+	URL         string    `json:"url,omitempty"`
+	OpenedAt    time.Time `json:"opened_at"`
+}
+
+// State is persisted per engagement so later runs can close resolved findings.
+type State struct {
+	Provider string                  `json:"provider"`
+	Repo     string                  `json:"repo"`
+	Issues   map[string]TrackedIssue `json:"issues"`
+}
+
+// ActionType describes what the syncer will do for a finding.
+type ActionType string
+
+const (
+	ActionOpen  ActionType = "open"
+	ActionClose ActionType = "close"
+)
+
+// Action is a single planned tracker mutation.
+type Action struct {
+	Type        ActionType
+	Fingerprint string
+	Finding     Finding
+	Issue       TrackedIssue // populated for close actions
+}
+
+// Options tune which findings are synced and how issues are labelled.
+type Options struct {
+	EngagementID string
+	MinSeverity  string // lowest severity to open issues for (default "High")
+	LabelPrefix  string // prefix for generated labels (default "seca")
+	DryRun       bool
+	// TargetLabel shows an affected target in issue bodies, e.g. with its
+	// display name. Targets are listed as they are when it is nil.
+	TargetLabel func(target string) string
+}
+
+var severityRank = map[string]int{
+	"info":     0,
+	"low":      1,
+	"medium":   2,
+	"high":     3,
+	"critical": 4,
+}
+
+// SeverityAtLeast reports whether severity meets the min threshold.
+func SeverityAtLeast(severity, min string) bool {
+	rank, ok := severityRank[strings.ToLower(strings.TrimSpace(severity))]
+	if !ok {
+		return false
+	}
+	minRank, ok := severityRank[strings.ToLower(strings.TrimSpace(min))]
+	if !ok {
+		minRank = severityRank["high"]
+	}
+	return rank >= minRank
+}
+
+// Fingerprint derives a stable identifier for a finding within an engagement.
+func Fingerprint(engagementID string, f Finding) string {
+	sum := sha256.Sum256([]byte(engagementID + "\x00" + f.Category + "\x00" + f.Name))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Plan compares current findings against tracked issues and returns the actions
+// required to open issues for new findings and close issues for resolved ones.
+// Findings below MinSeverity do not open issues, but keep their tracked
+// issues open while they are still reported.
+func Plan(state *State, findings []Finding, opts Options) []Action {
+	minSeverity := opts.MinSeverity
+	if minSeverity == "" {
+		minSeverity = "high"
+	}
+
+	current := make(map[string]Finding)
+	reported := make(map[string]bool, len(findings))
+	for _, f := range findings {
+		fp := Fingerprint(opts.EngagementID, f)
+		reported[fp] = true
+		if !SeverityAtLeast(f.Severity, minSeverity) {
+			continue
+		}
+		current[fp] = f
+	}
+
+	actions := make([]Action, 0)
+	for fp, f := range current {
+		if _, tracked := state.Issues[fp]; tracked {
+			continue
+		}
+		actions = append(actions, Action{Type: ActionOpen, Fingerprint: fp, Finding: f})
+	}
+	for fp, issue := range state.Issues {
+		if reported[fp] {
+			continue
+		}
+		actions = append(actions, Action{Type: ActionClose, Fingerprint: fp, Issue: issue})
+	}
+
+	sort.Slice(actions, func(i, j int) bool {
+		if actions[i].Type != actions[j].Type {
+			return actions[i].Type == ActionOpen
+		}
+		return actions[i].Fingerprint < actions[j].Fingerprint
+	})
+	return actions
+}
+
+// Apply executes planned actions against the tracker and updates state in place.
+// In dry-run mode the tracker is never contacted and state is left unchanged.
+func Apply(ctx context.Context, tracker Tracker, state *State, actions []Action, opts Options) error {
+	if opts.DryRun {
+		return nil
+	}
+	if tracker == nil {
+		return errors.New("tracker is required")
+	}
+
+	var errs []error
+	for _, action := range actions {
+		switch action.Type {
+		case ActionOpen:
+			issue, err := tracker.CreateIssue(ctx, BuildIssueRequest(action.Finding, opts))
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			state.Issues[action.Fingerprint] = TrackedIssue{
+				Fingerprint: action.Fingerprint,
+				Finding:     action.Finding.Name,
+				Severity:    action.Finding.Severity,
+				Number:      issue.Number,
+				URL:         issue.URL,
+				OpenedAt:    time.Now().UTC(),
+			}
+		case ActionClose:
+			comment := fmt.Sprintf("Finding %q was not observed in the latest run for engagement %s; closing automatically.", action.Issue.Finding, opts.EngagementID)
+			if err := tracker.CloseIssue(ctx, action.Issue.Number, comment); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			delete(state.Issues, action.Fingerprint)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BuildIssueRequest renders the issue title, body, and labels for a finding.
+func BuildIssueRequest(f Finding, opts Options) IssueRequest {
+	prefix := opts.LabelPrefix
+	if prefix == "" {
+		prefix = "seca"
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "**Severity:** %s\n", f.Severity)
+	if f.CVSSVector != "" {
+		fmt.Fprintf(&body, "**CVSS:** %.1f (`%s`)\n", f.CVSSScore, f.CVSSVector)
+	}
+	fmt.Fprintf(&body, "**Category:** %s\n", f.Category)
+	fmt.Fprintf(&body, "**Engagement:** %s\n\n", opts.EngagementID)
+	if f.Description != "" {
+		fmt.Fprintf(&body, "%s\n\n", f.Description)
+	}
+	if f.Recommendation != "" {
+		fmt.Fprintf(&body, "### Recommendation\n\n%s\n\n", f.Recommendation)
+	}
+	if len(f.AffectedURLs) > 0 {
+		body.WriteString("### Affected targets\n\n")
+		for _, u := range f.AffectedURLs {
+			if opts.TargetLabel != nil {
+				u = opts.TargetLabel(u)
+			}
+			fmt.Fprintf(&body, "- %s\n", u)
+		}
+		body.WriteString("\n")
+	}
+	fmt.Fprintf(&body, "_Fingerprint: %s_\n", Fingerprint(opts.EngagementID, f))
+
+	labels := []string{prefix, fmt.Sprintf("%s:severity:%s", prefix, strings.ToLower(f.Severity))}
+	if category := labelSlug(f.Category); category != "" {
+		labels = append(labels, fmt.Sprintf("%s:category:%s", prefix, category))
+	}
+
+	return IssueRequest{
+		Title:  fmt.Sprintf("[%s] %s", f.Severity, f.Name),
+		Body:   body.String(),
+		Labels: labels,
+	}
+}
+
+func labelSlug(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	var b strings.Builder
+	lastDash := false
+	for _, r := range s {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			lastDash = false
+			continue
+		}
+		if !lastDash && b.Len() > 0 {
+			b.WriteByte('-')
+			lastDash = true
+		}
+	}
+	return strings.TrimRight(b.String(), "-")
+}
+
+// LoadState reads persisted sync state, returning an empty state if none exists.
+func LoadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &State{Issues: make(map[string]TrackedIssue)}, nil
+		}
+		return nil, err
+	}
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parse issue sync state: %w", err)
+	}
+	if state.Issues == nil {
+		state.Issues = make(map[string]TrackedIssue)
+	}
+	return &state, nil
+}
+
+// SaveState persists sync state as indented JSON.
+func SaveState(path string, state *State, perm os.FileMode) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFile(path, data, perm)
+}
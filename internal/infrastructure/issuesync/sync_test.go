@@ -0,0 +1,229 @@
+package issuesync
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type fakeTracker struct {
+	mu      sync.Mutex
+	created []IssueRequest
+	closed  []int
+	next    int
+}
+
+func (f *fakeTracker) Name() string { return "fake" }
+
+func (f *fakeTracker) CreateIssue(ctx context.Context, req IssueRequest) (*Issue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.next++
+	f.created = append(f.created, req)
+	return &Issue{Number: f.next, Title: req.Title}, nil
+}
+
+func (f *fakeTracker) CloseIssue(ctx context.Context, number int, comment string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = append(f.closed, number)
+	return nil
+}
+
+func TestPlanOpensOnlyHighAndCritical(t *testing.T) {
+	state := &State{Issues: map[string]TrackedIssue{}}
+	findings := []Finding{
+		{Name: "Missing HSTS", Category: "Security Headers", Severity: "High"},
+		{Name: "Weak cipher", Category: "TLS", Severity: "Critical"},
+		{Name: "Missing Referrer-Policy", Category: "Security Headers", Severity: "Low"},
+	}
+
+	actions := Plan(state, findings, Options{EngagementID: "eng-1"})
+	if len(actions) != 2 {
+		t.Fatalf("expected 2 open actions, got %d", len(actions))
+	}
+	for _, a := range actions {
+		if a.Type != ActionOpen {
+			t.Fatalf("expected open action, got %s", a.Type)
+		}
+	}
+}
+
+func TestApplyOpensThenClosesResolvedFindings(t *testing.T) {
+	tracker := &fakeTracker{}
+	state := &State{Issues: map[string]TrackedIssue{}}
+	opts := Options{EngagementID: "eng-1"}
+
+	first := []Finding{
+		{Name: "Missing HSTS", Category: "Security Headers", Severity: "High"},
+		{Name: "Weak cipher", Category: "TLS", Severity: "Critical"},
+	}
+	if err := Apply(context.Background(), tracker, state, Plan(state, first, opts), opts); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(state.Issues) != 2 {
+		t.Fatalf("expected 2 tracked issues, got %d", len(state.Issues))
+	}
+
+	// Re-running with the same findings must be idempotent.
+	if actions := Plan(state, first, opts); len(actions) != 0 {
+		t.Fatalf("expected no actions on unchanged findings, got %d", len(actions))
+	}
+
+	second := first[:1]
+	actions := Plan(state, second, opts)
+	if len(actions) != 1 || actions[0].Type != ActionClose {
+		t.Fatalf("expected a single close action, got %+v", actions)
+	}
+	if err := Apply(context.Background(), tracker, state, actions, opts); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(tracker.closed) != 1 {
+		t.Fatalf("expected 1 closed issue, got %d", len(tracker.closed))
+	}
+	if len(state.Issues) != 1 {
+		t.Fatalf("expected 1 tracked issue after close, got %d", len(state.Issues))
+	}
+}
+
+func TestPlanKeepsIssuesOfFindingsBelowMinSeverity(t *testing.T) {
+	findings := []Finding{{Name: "Missing CSP", Category: "Security Headers", Severity: "Medium"}}
+	state := &State{Issues: map[string]TrackedIssue{
+		Fingerprint("eng-1", findings[0]): {Finding: "Missing CSP", Severity: "Medium", Number: 3},
+	}}
+
+	// The finding was opened under a lower threshold and is still reported.
+	opts := Options{EngagementID: "eng-1", MinSeverity: "high"}
+	if actions := Plan(state, findings, opts); len(actions) != 0 {
+		t.Fatalf("expected the filtered finding's issue to stay open, got %+v", actions)
+	}
+	if actions := Plan(state, nil, opts); len(actions) != 1 || actions[0].Type != ActionClose {
+		t.Fatalf("expected the issue to close once the finding is gone, got %+v", actions)
+	}
+}
+
+func TestApplyDryRunDoesNotMutate(t *testing.T) {
+	tracker := &fakeTracker{}
+	state := &State{Issues: map[string]TrackedIssue{}}
+	opts := Options{EngagementID: "eng-1", DryRun: true}
+
+	findings := []Finding{{Name: "Weak cipher", Category: "TLS", Severity: "Critical"}}
+	if err := Apply(context.Background(), tracker, state, Plan(state, findings, opts), opts); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(tracker.created) != 0 || len(state.Issues) != 0 {
+		t.Fatal("dry run must not contact tracker or update state")
+	}
+}
+
+func TestBuildIssueRequestLabels(t *testing.T) {
+	req := BuildIssueRequest(Finding{Name: "Open Redis", Category: "Network Security", Severity: "Critical"}, Options{EngagementID: "e"})
+	want := []string{"seca", "seca:severity:critical", "seca:category:network-security"}
+	if strings.Join(req.Labels, ",") != strings.Join(want, ",") {
+		t.Fatalf("labels = %v, want %v", req.Labels, want)
+	}
+	if !strings.HasPrefix(req.Title, "[Critical]") {
+		t.Fatalf("unexpected title %q", req.Title)
+	}
+	if strings.Contains(req.Body, "CVSS") {
+		t.Fatalf("body should omit CVSS when the finding has none:\n%s", req.Body)
+	}
+
+	req = BuildIssueRequest(Finding{Name: "Open Redis", Severity: "Critical", CVSSVector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", CVSSScore: 9.8}, Options{EngagementID: "e"})
+	if !strings.Contains(req.Body, "**CVSS:** 9.8 (`CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H`)") {
+		t.Fatalf("body missing CVSS:\n%s", req.Body)
+	}
+
+	label := func(target string) string { return "Customer Portal (" + target + ")" }
+	req = BuildIssueRequest(Finding{Name: "Open Redis", Severity: "Critical", AffectedURLs: []string{"https://portal.example.com"}}, Options{EngagementID: "e", TargetLabel: label})
+	if !strings.Contains(req.Body, "- Customer Portal (https://portal.example.com)\n") {
+		t.Fatalf("body missing labelled target:\n%s", req.Body)
+	}
+}
+
+func TestGitHubTrackerCreateAndClose(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		if got := r.Header.Get("Authorization"); got != "Bearer tok" {
+			t.Errorf("unexpected auth header %q", got)
+		}
+		if r.Method == http.MethodPost && r.URL.Path == "/repos/acme/web/issues" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"number": 7, "html_url": "https://example/7", "title": "t"})
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	tracker, err := NewTracker(TrackerConfig{Provider: "github", Repo: "acme/web", BaseURL: srv.URL, Token: "tok"})
+	if err != nil {
+		t.Fatalf("NewTracker() error = %v", err)
+	}
+	issue, err := tracker.CreateIssue(context.Background(), IssueRequest{Title: "t"})
+	if err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+	if issue.Number != 7 {
+		t.Fatalf("expected issue 7, got %d", issue.Number)
+	}
+	if err := tracker.CloseIssue(context.Background(), 7, "resolved"); err != nil {
+		t.Fatalf("CloseIssue() error = %v", err)
+	}
+	want := []string{"POST /repos/acme/web/issues", "POST /repos/acme/web/issues/7/comments", "PATCH /repos/acme/web/issues/7"}
+	if strings.Join(paths, "|") != strings.Join(want, "|") {
+		t.Fatalf("requests = %v, want %v", paths, want)
+	}
+}
+
+func TestGitLabTrackerEncodesProjectPath(t *testing.T) {
+	var rawPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawPath = r.URL.EscapedPath()
+		if r.Header.Get("PRIVATE-TOKEN") != "tok" {
+			t.Errorf("missing GitLab token header")
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"iid": 3, "web_url": "https://example/3"})
+	}))
+	defer srv.Close()
+
+	tracker, err := NewTracker(TrackerConfig{Provider: "gitlab", Repo: "group/project", BaseURL: srv.URL, Token: "tok"})
+	if err != nil {
+		t.Fatalf("NewTracker() error = %v", err)
+	}
+	issue, err := tracker.CreateIssue(context.Background(), IssueRequest{Title: "t"})
+	if err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+	if issue.Number != 3 {
+		t.Fatalf("expected iid 3, got %d", issue.Number)
+	}
+	if rawPath != "/projects/group%2Fproject/issues" {
+		t.Fatalf("unexpected path %q", rawPath)
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issue_sync.json")
+	state, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState() error = %v", err)
+	}
+	state.Issues["abc"] = TrackedIssue{Fingerprint: "abc", Number: 1}
+	if err := SaveState(path, state, 0o644); err != nil {
+		t.Fatalf("SaveState() error = %v", err)
+	}
+	loaded, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState() error = %v", err)
+	}
+	if loaded.Issues["abc"].Number != 1 {
+		t.Fatalf("state did not round-trip: %+v", loaded)
+	}
+}
@@ -0,0 +1,72 @@
+package issuesync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Issue is the tracker-agnostic view of an issue opened for a finding.
+type Issue struct {
+	Number int    `json:"number"`
+	URL    string `json:"url,omitempty"`
+	Title  string `json:"title"`
+	State  string `json:"state,omitempty"`
+}
+
+// IssueRequest describes an issue to open in the remote tracker.
+type IssueRequest struct {
+	Title  string
+	Body   string
+	Labels []string
+}
+
+// Tracker abstracts the remote issue tracker (GitHub, GitLab, ...).
+type Tracker interface {
+	// Name returns the provider identifier (e.g., "github").
+	Name() string
+
+	// CreateIssue opens a new issue and returns its reference.
+	CreateIssue(ctx context.Context, req IssueRequest) (*Issue, error)
+
+	// CloseIssue adds a closing comment and closes the issue.
+	CloseIssue(ctx context.Context, number int, comment string) error
+}
+
+// TrackerConfig captures the connection settings for a tracker.
+type TrackerConfig struct {
+	Provider string        // "github" or "gitlab"
+	Repo     string        // owner/name (GitHub) or group/project path (GitLab)
+	BaseURL  string        // API base URL override (GitHub Enterprise, self-hosted GitLab)
+	Token    string        // API token
+	Timeout  time.Duration // HTTP timeout per request
+}
+
+const defaultTrackerTimeout = 15 * time.Second
+
+// NewTracker builds a Tracker for the configured provider.
+func NewTracker(cfg TrackerConfig) (Tracker, error) {
+	if strings.TrimSpace(cfg.Repo) == "" {
+		return nil, fmt.Errorf("repository is required")
+	}
+	if strings.TrimSpace(cfg.Token) == "" {
+		return nil, fmt.Errorf("API token is required")
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTrackerTimeout
+	}
+	client := &http.Client{Timeout: timeout}
+
+	switch strings.ToLower(strings.TrimSpace(cfg.Provider)) {
+	case "github", "":
+		return newGitHubTracker(client, cfg.BaseURL, cfg.Repo, cfg.Token)
+	case "gitlab":
+		return newGitLabTracker(client, cfg.BaseURL, cfg.Repo, cfg.Token)
+	default:
+		return nil, fmt.Errorf("unsupported issue provider %q (use github or gitlab)", cfg.Provider)
+	}
+}
@@ -0,0 +1,247 @@
+// Package mailer delivers engagement reports over SMTP.
+//
+// Messages are assembled as multipart/mixed MIME documents with base64
+// attachments. Delivery uses STARTTLS by default and can optionally wrap the
+// message in an S/MIME detached signature produced by the system openssl
+// binary, mirroring how the CLI delegates GPG signing to external tooling.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds SMTP connection and signing settings.
+type Config struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	StartTLS bool // upgrade the connection with STARTTLS (required when credentials are set)
+	Timeout  time.Duration
+
+	// Optional S/MIME signing material (PEM files).
+	SMIMECert string
+	SMIMEKey  string
+}
+
+// Attachment is a file attached to a message.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Message is an email with a plain-text body and optional attachments.
+type Message struct {
+	To          []string
+	Subject     string
+	Body        string
+	Attachments []Attachment
+}
+
+// Validate checks the configuration for obviously missing values.
+func (c Config) Validate() error {
+	if strings.TrimSpace(c.Host) == "" {
+		return errors.New("SMTP host is required")
+	}
+	if c.Port <= 0 || c.Port > 65535 {
+		return fmt.Errorf("invalid SMTP port %d", c.Port)
+	}
+	if strings.TrimSpace(c.From) == "" {
+		return errors.New("sender address (from) is required")
+	}
+	if c.Username != "" && !c.StartTLS {
+		return errors.New("refusing to send SMTP credentials without STARTTLS")
+	}
+	if (c.SMIMECert == "") != (c.SMIMEKey == "") {
+		return errors.New("S/MIME signing requires both certificate and key")
+	}
+	return nil
+}
+
+// Build renders msg as an RFC 5322 message. When S/MIME material is configured,
+// the MIME body is signed with a detached signature.
+func Build(ctx context.Context, cfg Config, msg Message) ([]byte, error) {
+	if len(msg.To) == 0 {
+		return nil, errors.New("at least one recipient is required")
+	}
+	for _, addr := range append([]string{cfg.From}, msg.To...) {
+		if strings.ContainsAny(addr, "\r\n") {
+			return nil, fmt.Errorf("invalid address %q", addr)
+		}
+	}
+
+	entity, err := buildMIMEEntity(msg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.SMIMECert != "" {
+		entity, err = signSMIME(ctx, cfg.SMIMECert, cfg.SMIMEKey, entity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", cfg.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.Write(entity)
+	return buf.Bytes(), nil
+}
+
+// Send builds msg and delivers it through the configured SMTP server.
+func Send(ctx context.Context, cfg Config, msg Message) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	raw, err := Build(ctx, cfg, msg)
+	if err != nil {
+		return err
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	dialer := &net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("connect to SMTP server: %w", err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+
+	client, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("SMTP handshake: %w", err)
+	}
+	defer client.Close()
+
+	if cfg.StartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("SMTP server does not support STARTTLS")
+		}
+		if err := client.StartTLS(&tls.Config{ServerName: cfg.Host, MinVersion: tls.VersionTLS12}); err != nil {
+			return fmt.Errorf("STARTTLS: %w", err)
+		}
+	}
+	if cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)); err != nil {
+			return fmt.Errorf("SMTP auth: %w", err)
+		}
+	}
+	if err := client.Mail(cfg.From); err != nil {
+		return fmt.Errorf("MAIL FROM: %w", err)
+	}
+	for _, rcpt := range msg.To {
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("RCPT TO %s: %w", rcpt, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("DATA: %w", err)
+	}
+	if _, err := w.Write(raw); err != nil {
+		return fmt.Errorf("write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("finish message: %w", err)
+	}
+	return client.Quit()
+}
+
+// buildMIMEEntity renders the Content-Type header and body of a multipart/mixed entity.
+func buildMIMEEntity(msg Message) ([]byte, error) {
+	boundary, err := randomBoundary()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", boundary)
+
+	fmt.Fprintf(&buf, "--%s\r\n", boundary)
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+	writeBase64Lines(&buf, []byte(msg.Body))
+
+	for _, att := range msg.Attachments {
+		if strings.ContainsAny(att.Filename, "\r\n\"") {
+			return nil, fmt.Errorf("invalid attachment filename %q", att.Filename)
+		}
+		contentType := att.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		fmt.Fprintf(&buf, "--%s\r\n", boundary)
+		fmt.Fprintf(&buf, "Content-Type: %s; name=%q\r\n", contentType, att.Filename)
+		buf.WriteString("Content-Transfer-Encoding: base64\r\n")
+		fmt.Fprintf(&buf, "Content-Disposition: attachment; filename=%q\r\n\r\n", att.Filename)
+		writeBase64Lines(&buf, att.Data)
+	}
+	fmt.Fprintf(&buf, "--%s--\r\n", boundary)
+	return buf.Bytes(), nil
+}
+
+func writeBase64Lines(buf *bytes.Buffer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76])
+		buf.WriteString("\r\n")
+		encoded = encoded[76:]
+	}
+	if encoded != "" {
+		buf.WriteString(encoded)
+		buf.WriteString("\r\n")
+	}
+}
+
+func randomBoundary() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "seca-" + hex.EncodeToString(b), nil
+}
+
+// signSMIME signs a MIME entity with openssl, returning a multipart/signed entity.
+func signSMIME(ctx context.Context, certPath, keyPath string, entity []byte) ([]byte, error) {
+	for _, p := range []string{certPath, keyPath} {
+		if _, err := os.Stat(p); err != nil {
+			return nil, fmt.Errorf("S/MIME material unavailable: %w", err)
+		}
+	}
+	cmd := exec.CommandContext(ctx, "openssl", "smime", "-sign", "-signer", certPath, "-inkey", keyPath) // #nosec G204 -- fixed binary; paths come from operator configuration and are passed without a shell.
+	cmd.Stdin = bytes.NewReader(entity)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("openssl smime -sign failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	// openssl emits its own MIME-Version header; the caller already writes one.
+	signed := bytes.TrimPrefix(stdout.Bytes(), []byte("MIME-Version: 1.0\n"))
+	signed = bytes.TrimPrefix(signed, []byte("MIME-Version: 1.0\r\n"))
+	return signed, nil
+}
@@ -0,0 +1,197 @@
+package mailer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+func TestBuildIncludesBodyAndAttachment(t *testing.T) {
+	cfg := Config{From: "seca@example.com"}
+	msg := Message{
+		To:      []string{"a@example.com", "b@example.com"},
+		Subject: "Report for eng-1",
+		Body:    "Audit hash: abc123",
+		Attachments: []Attachment{
+			{Filename: "report.pdf", ContentType: "application/pdf", Data: []byte("%PDF-1.4 test")},
+		},
+	}
+
+	raw, err := Build(context.Background(), cfg, msg)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ReadMessage() error = %v", err)
+	}
+	if got := parsed.Header.Get("To"); got != "a@example.com, b@example.com" {
+		t.Fatalf("To = %q", got)
+	}
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("unexpected content type %q: %v", mediaType, err)
+	}
+
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	var parts []*multipart.Part
+	var bodies [][]byte
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("NextPart() error = %v", err)
+		}
+		data, _ := io.ReadAll(part)
+		parts = append(parts, part)
+		bodies = append(bodies, data)
+	}
+	if len(parts) != 2 {
+		t.Fatalf("expected 2 parts, got %d", len(parts))
+	}
+	if !strings.Contains(decodeBase64(t, bodies[0]), "Audit hash: abc123") {
+		t.Fatal("body part missing audit hash")
+	}
+	if parts[1].FileName() != "report.pdf" {
+		t.Fatalf("attachment filename = %q", parts[1].FileName())
+	}
+	if decodeBase64(t, bodies[1]) != "%PDF-1.4 test" {
+		t.Fatal("attachment content did not round-trip")
+	}
+}
+
+func TestBuildRejectsHeaderInjection(t *testing.T) {
+	_, err := Build(context.Background(), Config{From: "seca@example.com"}, Message{
+		To: []string{"a@example.com\r\nBcc: evil@example.com"},
+	})
+	if err == nil {
+		t.Fatal("expected error for recipient containing CRLF")
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	base := Config{Host: "smtp.example.com", Port: 587, From: "seca@example.com", StartTLS: true}
+	if err := base.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	noTLS := base
+	noTLS.StartTLS = false
+	noTLS.Username = "user"
+	if err := noTLS.Validate(); err == nil {
+		t.Fatal("expected error when sending credentials without STARTTLS")
+	}
+
+	halfSMIME := base
+	halfSMIME.SMIMECert = "cert.pem"
+	if err := halfSMIME.Validate(); err == nil {
+		t.Fatal("expected error when S/MIME key is missing")
+	}
+}
+
+func TestSendDeliversToSMTPServer(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	received := make(chan string, 1)
+	go serveFakeSMTP(ln, received)
+
+	port := ln.Addr().(*net.TCPAddr).Port
+	cfg := Config{Host: "127.0.0.1", Port: port, From: "seca@example.com"}
+	msg := Message{To: []string{"ops@example.com"}, Subject: "hi", Body: "hello"}
+	if err := Send(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	data := <-received
+	if !strings.Contains(data, "Subject: hi") {
+		t.Fatalf("server did not receive message headers: %q", data)
+	}
+}
+
+func TestSendRequiresStartTLSSupport(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+	go serveFakeSMTP(ln, make(chan string, 1))
+
+	port := ln.Addr().(*net.TCPAddr).Port
+	cfg := Config{Host: "127.0.0.1", Port: port, From: "seca@example.com", StartTLS: true}
+	err = Send(context.Background(), cfg, Message{To: []string{"ops@example.com"}})
+	if err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Fatalf("expected STARTTLS error, got %v", err)
+	}
+}
+
+// serveFakeSMTP implements just enough of SMTP for net/smtp to deliver one message.
+func serveFakeSMTP(ln net.Listener, received chan<- string) {
+	conn, err := ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	write := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+	write("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"):
+			write("250-localhost")
+			write("250 8BITMIME")
+		case strings.HasPrefix(cmd, "MAIL"), strings.HasPrefix(cmd, "RCPT"):
+			write("250 OK")
+		case cmd == "DATA":
+			write("354 go ahead")
+			var buf strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				buf.WriteString(l)
+			}
+			received <- buf.String()
+			write("250 queued")
+		case cmd == "QUIT":
+			write("221 bye")
+			return
+		default:
+			write("250 OK")
+		}
+	}
+}
+
+func decodeBase64(t *testing.T, data []byte) string {
+	t.Helper()
+	var out bytes.Buffer
+	dec := base64.NewDecoder(base64.StdEncoding, bytes.NewReader(data))
+	if _, err := io.Copy(&out, dec); err != nil {
+		t.Fatalf("decode base64: %v", err)
+	}
+	return out.String()
+}
@@ -1,6 +1,7 @@
 package json
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"crypto/sha512"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/khanhnv2901/seca-cli/internal/domain/audit"
 	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
 	"github.com/khanhnv2901/seca-cli/internal/shared/security"
 )
 
@@ -23,6 +25,7 @@ import (
 type AuditRepository struct {
 	resultsDir string
 	mu         sync.RWMutex
+	writer     *auditWriter
 }
 
 // NewAuditRepository creates a new CSV-based audit repository
@@ -36,13 +39,19 @@ func NewAuditRepository(resultsDir string) (*AuditRepository, error) {
 		return nil, fmt.Errorf("failed to create results directory: %w", err)
 	}
 
-	return &AuditRepository{
+	r := &AuditRepository{
 		resultsDir: resultsDir,
-	}, nil
+	}
+	r.writer = newAuditWriter(r.appendEntries)
+	return r, nil
 }
 
 // Save persists an audit trail
 func (r *AuditRepository) Save(ctx context.Context, auditTrail *audit.AuditTrail) error {
+	if err := r.Flush(ctx, auditTrail.EngagementID()); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -56,63 +65,18 @@ func (r *AuditRepository) Save(ctx context.Context, auditTrail *audit.AuditTrail
 		return fmt.Errorf("invalid file path: %s", filePath)
 	}
 
-	file, err := os.Create(filePath)
+	err := fileutil.WriteWith(filePath, 0644, func(w io.Writer) error {
+		return writeAuditCSV(w, auditTrail.Entries())
+	})
 	if err != nil {
-		return fmt.Errorf("failed to create audit file: %w", err)
-	}
-	defer file.Close()
-
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
-
-	// Write header
-	header := []string{
-		"timestamp",
-		"engagement_id",
-		"operator",
-		"command",
-		"target",
-		"status",
-		"http_status",
-		"tls_expiry",
-		"notes",
-		"error",
-		"duration_seconds",
-	}
-	if err := writer.Write(header); err != nil {
-		return fmt.Errorf("failed to write header: %w", err)
-	}
-
-	// Write entries
-	for _, entry := range auditTrail.Entries() {
-		record := []string{
-			entry.Timestamp.Format(time.RFC3339),
-			entry.EngagementID,
-			entry.Operator,
-			entry.Command,
-			entry.Target,
-			entry.Status,
-			strconv.Itoa(entry.HTTPStatus),
-			"",
-			entry.Notes,
-			entry.Error,
-			fmt.Sprintf("%.3f", entry.DurationSeconds),
-		}
-
-		if !entry.TLSExpiry.IsZero() {
-			record[7] = entry.TLSExpiry.Format(time.RFC3339)
-		}
-
-		if err := writer.Write(record); err != nil {
-			return fmt.Errorf("failed to write entry: %w", err)
-		}
+		return fmt.Errorf("failed to write audit file: %w", err)
 	}
 
 	// If sealed, write hash file
 	if auditTrail.IsSealed() {
 		hashFilePath := filePath + "." + auditTrail.HashAlgorithm()
 		hashContent := fmt.Sprintf("%s  %s\n", auditTrail.Hash(), filepath.Base(filePath))
-		if err := os.WriteFile(hashFilePath, []byte(hashContent), 0644); err != nil {
+		if err := fileutil.WriteFile(hashFilePath, []byte(hashContent), 0644); err != nil {
 			return fmt.Errorf("failed to write hash file: %w", err)
 		}
 	}
@@ -120,7 +84,7 @@ func (r *AuditRepository) Save(ctx context.Context, auditTrail *audit.AuditTrail
 	// If signed, write signature file
 	if auditTrail.IsSigned() {
 		sigFilePath := filePath + ".asc"
-		if err := os.WriteFile(sigFilePath, []byte(auditTrail.Signature()), 0644); err != nil {
+		if err := fileutil.WriteFile(sigFilePath, []byte(auditTrail.Signature()), 0644); err != nil {
 			return fmt.Errorf("failed to write signature file: %w", err)
 		}
 	}
@@ -128,8 +92,69 @@ func (r *AuditRepository) Save(ctx context.Context, auditTrail *audit.AuditTrail
 	return nil
 }
 
+// auditCSVHeader is the column layout of audit.csv.
+var auditCSVHeader = []string{
+	"timestamp",
+	"engagement_id",
+	"operator",
+	"command",
+	"target",
+	"status",
+	"http_status",
+	"tls_expiry",
+	"notes",
+	"error",
+	"duration_seconds",
+}
+
+// auditCSVRecord converts entry to an audit.csv row.
+func auditCSVRecord(entry *audit.Entry) []string {
+	record := []string{
+		entry.Timestamp.Format(time.RFC3339),
+		entry.EngagementID,
+		entry.Operator,
+		entry.Command,
+		entry.Target,
+		entry.Status,
+		strconv.Itoa(entry.HTTPStatus),
+		"",
+		entry.Notes,
+		entry.Error,
+		fmt.Sprintf("%.3f", entry.DurationSeconds),
+	}
+
+	if !entry.TLSExpiry.IsZero() {
+		record[7] = entry.TLSExpiry.Format(time.RFC3339)
+	}
+	return record
+}
+
+// writeAuditCSV writes the audit header and entries as CSV.
+func writeAuditCSV(out io.Writer, entries []*audit.Entry) error {
+	writer := csv.NewWriter(out)
+
+	// Write header
+	if err := writer.Write(auditCSVHeader); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	// Write entries
+	for _, entry := range entries {
+		if err := writer.Write(auditCSVRecord(entry)); err != nil {
+			return fmt.Errorf("failed to write entry: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
 // FindByEngagementID retrieves the audit trail for an engagement
 func (r *AuditRepository) FindByEngagementID(ctx context.Context, engagementID string) (*audit.AuditTrail, error) {
+	if err := r.Flush(ctx, engagementID); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -141,8 +166,30 @@ func (r *AuditRepository) FindByEngagementID(ctx context.Context, engagementID s
 	return r.loadFromFile(filePath, engagementID)
 }
 
-// AppendEntry appends a single entry to an existing audit trail
+// AppendEntry appends a single entry to an existing audit trail. Entries are
+// buffered and written in timestamp order by a single writer goroutine;
+// reads, Flush and Close write any buffered entries first. An entry normally
+// reaches audit.csv within auditFlushInterval plus auditReorderWindow (about
+// 300ms) and is lost if the process dies before then. AppendEntry only
+// reports the error of an earlier failed write for the engagement; callers
+// must check Flush or Close to know every entry was written.
 func (r *AuditRepository) AppendEntry(ctx context.Context, engagementID string, entry *audit.Entry) error {
+	return r.writer.enqueue(engagementID, entry)
+}
+
+// Flush writes the entries buffered for engagementID to audit.csv.
+func (r *AuditRepository) Flush(ctx context.Context, engagementID string) error {
+	return r.writer.flush(engagementID)
+}
+
+// Close writes all buffered entries and stops the writer goroutine.
+func (r *AuditRepository) Close() error {
+	return r.writer.close()
+}
+
+// appendEntries appends a batch of entries to the engagement's audit.csv,
+// writing the header first when the file is new.
+func (r *AuditRepository) appendEntries(engagementID string, entries []*audit.Entry) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -162,63 +209,39 @@ func (r *AuditRepository) AppendEntry(ctx context.Context, engagementID string,
 		fileExists = false
 	}
 
-	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to open audit file: %w", err)
-	}
-	defer file.Close()
-
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	// Build the batch first so it reaches the file in a single synced write.
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
 
 	// Write header if new file
 	if !fileExists {
-		header := []string{
-			"timestamp",
-			"engagement_id",
-			"operator",
-			"command",
-			"target",
-			"status",
-			"http_status",
-			"tls_expiry",
-			"notes",
-			"error",
-			"duration_seconds",
-		}
-		if err := writer.Write(header); err != nil {
+		if err := writer.Write(auditCSVHeader); err != nil {
 			return fmt.Errorf("failed to write header: %w", err)
 		}
 	}
 
-	// Write entry
-	record := []string{
-		entry.Timestamp.Format(time.RFC3339),
-		entry.EngagementID,
-		entry.Operator,
-		entry.Command,
-		entry.Target,
-		entry.Status,
-		strconv.Itoa(entry.HTTPStatus),
-		"",
-		entry.Notes,
-		entry.Error,
-		fmt.Sprintf("%.3f", entry.DurationSeconds),
-	}
-
-	if !entry.TLSExpiry.IsZero() {
-		record[7] = entry.TLSExpiry.Format(time.RFC3339)
+	for _, entry := range entries {
+		if err := writer.Write(auditCSVRecord(entry)); err != nil {
+			return fmt.Errorf("failed to write entry: %w", err)
+		}
 	}
-
-	if err := writer.Write(record); err != nil {
+	writer.Flush()
+	if err := writer.Error(); err != nil {
 		return fmt.Errorf("failed to write entry: %w", err)
 	}
 
+	if err := fileutil.Append(filePath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to append audit entries: %w", err)
+	}
 	return nil
 }
 
 // ComputeHash calculates the hash of the audit trail file
 func (r *AuditRepository) ComputeHash(ctx context.Context, engagementID, algorithm string) (string, error) {
+	if err := r.Flush(ctx, engagementID); err != nil {
+		return "", err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -252,6 +275,10 @@ func (r *AuditRepository) ComputeHash(ctx context.Context, engagementID, algorit
 
 // VerifyIntegrity verifies the integrity of an audit trail
 func (r *AuditRepository) VerifyIntegrity(ctx context.Context, engagementID string) (bool, error) {
+	if err := r.Flush(ctx, engagementID); err != nil {
+		return false, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
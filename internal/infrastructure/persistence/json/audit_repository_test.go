@@ -0,0 +1,131 @@
+package json
+
+import (
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/domain/audit"
+)
+
+func TestAuditWriter_ConcurrentEntriesWrittenInTimestampOrder(t *testing.T) {
+	var mu sync.Mutex
+	var written []*audit.Entry
+	batches := 0
+	w := newAuditWriter(func(_ string, entries []*audit.Entry) error {
+		mu.Lock()
+		defer mu.Unlock()
+		written = append(written, entries...)
+		batches++
+		return nil
+	})
+
+	const workers, perWorker = 8, 50
+	var wg sync.WaitGroup
+	for n := 0; n < workers; n++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				entry := &audit.Entry{Timestamp: time.Now(), Target: strconv.Itoa(n)}
+				// Workers are preempted between timing a check and recording it.
+				time.Sleep(time.Duration((n+i)%3) * time.Millisecond)
+				if err := w.enqueue("eng-1", entry); err != nil {
+					t.Errorf("enqueue: %v", err)
+				}
+			}
+		}(n)
+	}
+	wg.Wait()
+	if err := w.close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	if len(written) != workers*perWorker {
+		t.Fatalf("expected %d entries, got %d", workers*perWorker, len(written))
+	}
+	for i := 1; i < len(written); i++ {
+		if written[i].Timestamp.Before(written[i-1].Timestamp) {
+			t.Fatalf("entry %d written out of timestamp order", i)
+		}
+	}
+	if batches >= len(written) {
+		t.Fatalf("expected entries to be batched, got %d writes for %d entries", batches, len(written))
+	}
+}
+
+func TestAuditRepository_ReadsIncludeBufferedEntries(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := NewAuditRepository(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer repo.Close()
+	ctx := context.Background()
+	base := time.Now().UTC().Truncate(time.Second)
+	for i := 2; i >= 0; i-- {
+		entry := &audit.Entry{Timestamp: base.Add(time.Duration(i) * time.Second), EngagementID: "eng-1", Command: "check http", Target: "https://example.com/", Status: "ok"}
+		if err := repo.AppendEntry(ctx, "eng-1", entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Hashing must see every entry, buffered or not.
+	if _, err := repo.ComputeHash(ctx, "eng-1", "sha256"); err != nil {
+		t.Fatalf("ComputeHash: %v", err)
+	}
+	rows := readAuditRows(t, filepath.Join(dir, "eng-1", "audit.csv"))
+	if len(rows) != 4 || rows[0][0] != "timestamp" {
+		t.Fatalf("expected header and three entries, got %v", rows)
+	}
+	for i, row := range rows[1:] {
+		if want := base.Add(time.Duration(i) * time.Second).Format(time.RFC3339); row[0] != want {
+			t.Fatalf("row %d: expected timestamp %s, got %s", i, want, row[0])
+		}
+	}
+}
+
+func TestAuditRepository_CloseWritesBufferedEntries(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := NewAuditRepository(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := &audit.Entry{Timestamp: time.Now().UTC(), EngagementID: "eng-1", Command: "check dns", Target: "example.com", Status: "ok"}
+	if err := repo.AppendEntry(context.Background(), "eng-1", entry); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if rows := readAuditRows(t, filepath.Join(dir, "eng-1", "audit.csv")); len(rows) != 2 {
+		t.Fatalf("expected header and one entry, got %d rows", len(rows))
+	}
+
+	// Entries appended after Close are written straight away.
+	if err := repo.AppendEntry(context.Background(), "eng-1", entry); err != nil {
+		t.Fatal(err)
+	}
+	if rows := readAuditRows(t, filepath.Join(dir, "eng-1", "audit.csv")); len(rows) != 3 {
+		t.Fatalf("expected two entries after Close, got %d rows", len(rows)-1)
+	}
+}
+
+func readAuditRows(t *testing.T, path string) [][]string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
@@ -0,0 +1,186 @@
+package json
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/domain/audit"
+)
+
+const (
+	// auditFlushInterval is how often the writer goroutine drains buffered
+	// entries to audit.csv.
+	auditFlushInterval = 200 * time.Millisecond
+
+	// auditBatchSize wakes the writer early once this many entries are
+	// buffered for an engagement.
+	auditBatchSize = 128
+
+	// auditReorderWindow holds entries back this long before writing, so
+	// entries recorded slightly out of order by concurrent workers still reach
+	// the file sorted by timestamp.
+	auditReorderWindow = 100 * time.Millisecond
+)
+
+// auditWriter buffers audit entries per engagement and writes them from a
+// single goroutine, one append per batch, instead of opening audit.csv for
+// every entry.
+type auditWriter struct {
+	write func(engagementID string, entries []*audit.Entry) error
+	now   func() time.Time
+
+	mu      sync.Mutex
+	pending map[string][]*audit.Entry
+	errs    map[string]error
+	started bool
+	closed  bool
+
+	// writeMu serializes batch writes so batches reach the file in the
+	// order they were taken from pending.
+	writeMu sync.Mutex
+
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newAuditWriter(write func(engagementID string, entries []*audit.Entry) error) *auditWriter {
+	return &auditWriter{
+		write:   write,
+		now:     time.Now,
+		pending: make(map[string][]*audit.Entry),
+		errs:    make(map[string]error),
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// enqueue buffers entry for engagementID. It returns the error of an earlier
+// failed write for that engagement, so failures still reach the caller; the
+// entry is kept and retried with the rest of the batch.
+func (w *auditWriter) enqueue(engagementID string, entry *audit.Entry) error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return w.write(engagementID, []*audit.Entry{entry})
+	}
+	if !w.started {
+		w.started = true
+		go w.run()
+	}
+	w.pending[engagementID] = append(w.pending[engagementID], entry)
+	full := len(w.pending[engagementID]) >= auditBatchSize
+	err := w.errs[engagementID]
+	w.mu.Unlock()
+
+	if full {
+		select {
+		case w.wake <- struct{}{}:
+		default:
+		}
+	}
+	return err
+}
+
+func (w *auditWriter) run() {
+	defer close(w.done)
+	ticker := time.NewTicker(auditFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+		case <-w.wake:
+		}
+		w.drain(w.now().Add(-auditReorderWindow))
+	}
+}
+
+// drain writes every buffered entry recorded at or before cutoff. A zero
+// cutoff writes everything.
+func (w *auditWriter) drain(cutoff time.Time) {
+	w.writeMu.Lock()
+	defer w.writeMu.Unlock()
+
+	w.mu.Lock()
+	ids := make([]string, 0, len(w.pending))
+	for id := range w.pending {
+		ids = append(ids, id)
+	}
+	w.mu.Unlock()
+
+	for _, id := range ids {
+		w.drainEngagement(id, cutoff)
+	}
+}
+
+func (w *auditWriter) drainEngagement(engagementID string, cutoff time.Time) {
+	w.mu.Lock()
+	entries := w.pending[engagementID]
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+	n := len(entries)
+	if !cutoff.IsZero() {
+		n = sort.Search(len(entries), func(i int) bool { return entries[i].Timestamp.After(cutoff) })
+	}
+	batch := entries[:n:n]
+	if n == len(entries) {
+		delete(w.pending, engagementID)
+	} else {
+		w.pending[engagementID] = append([]*audit.Entry(nil), entries[n:]...)
+	}
+	w.mu.Unlock()
+
+	if len(batch) == 0 {
+		return
+	}
+	err := w.write(engagementID, batch)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err != nil {
+		// Keep the batch so a later flush can retry it.
+		w.pending[engagementID] = append(batch, w.pending[engagementID]...)
+		w.errs[engagementID] = err
+		return
+	}
+	delete(w.errs, engagementID)
+}
+
+// flush writes all buffered entries for engagementID and returns the last
+// write error for it.
+func (w *auditWriter) flush(engagementID string) error {
+	w.writeMu.Lock()
+	w.drainEngagement(engagementID, time.Time{})
+	w.writeMu.Unlock()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.errs[engagementID]
+}
+
+// close writes everything still buffered and stops the writer goroutine.
+// Entries enqueued afterwards are written directly.
+func (w *auditWriter) close() error {
+	w.mu.Lock()
+	started := w.started && !w.closed
+	w.closed = true
+	w.mu.Unlock()
+
+	if started {
+		close(w.stop)
+		<-w.done
+	}
+	w.drain(time.Time{})
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, err := range w.errs {
+		return err
+	}
+	return nil
+}
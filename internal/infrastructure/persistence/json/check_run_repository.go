@@ -6,16 +6,21 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/khanhnv2901/seca-cli/internal/domain/check"
+	consts "github.com/khanhnv2901/seca-cli/internal/shared/constants"
 	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
 	"github.com/khanhnv2901/seca-cli/internal/shared/security"
 )
 
 // checkRunDTO is the data transfer object for JSON serialization
 type checkRunDTO struct {
+	SchemaVersion  int              `json:"schema_version"`
 	ID             string           `json:"id"`
 	EngagementID   string           `json:"engagement_id"`
 	EngagementName string           `json:"engagement_name"`
@@ -28,10 +33,17 @@ type checkRunDTO struct {
 }
 
 type metadataDTO struct {
+	Operator             string `json:"operator,omitempty"`
+	EngagementID         string `json:"engagement_id,omitempty"`
+	EngagementName       string `json:"engagement_name,omitempty"`
+	StartedAt            string `json:"started_at,omitempty"`
+	CompletedAt          string `json:"completed_at,omitempty"`
 	AuditHash            string `json:"audit_hash,omitempty"`
 	HashAlgorithm        string `json:"hash_algorithm,omitempty"`
 	SignatureFingerprint string `json:"signature_fingerprint,omitempty"`
 	TotalTargets         int    `json:"total_targets"`
+	StopReason           string `json:"stop_reason,omitempty"`
+	CheckType            string `json:"check_type,omitempty"`
 }
 
 type resultDTO struct {
@@ -41,10 +53,19 @@ type resultDTO struct {
 	TLSExpiry    string       `json:"tls_expiry,omitempty"`
 	CheckedAt    string       `json:"checked_at"`
 	ResponseTime float64      `json:"response_time_ms,omitempty"`
+	Timings      *timingsDTO  `json:"timings,omitempty"`
 	Error        string       `json:"error,omitempty"`
 	Findings     findingsDTO  `json:"findings,omitempty"`
 }
 
+type timingsDTO struct {
+	DNS       float64 `json:"dns_ms,omitempty"`
+	Connect   float64 `json:"connect_ms,omitempty"`
+	TLS       float64 `json:"tls_ms,omitempty"`
+	FirstByte float64 `json:"first_byte_ms,omitempty"`
+	Analysis  float64 `json:"analysis_ms,omitempty"`
+}
+
 type findingsDTO struct {
 	SecurityHeaders  *securityHeadersDTO  `json:"security_headers,omitempty"`
 	TLSCompliance    *tlsComplianceDTO    `json:"tls_compliance,omitempty"`
@@ -150,18 +171,44 @@ func NewCheckRunRepository(resultsDir string) (*CheckRunRepository, error) {
 	}, nil
 }
 
-// Save persists a check run with all its results
+// Save persists a check run with all its results and removes the run's
+// snapshot, if it saved one
 func (r *CheckRunRepository) Save(ctx context.Context, checkRun *check.CheckRun) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	checkType := checkRun.Metadata().CheckType
+	if err := r.write(checkRun, check.ResultsFilename(checkType)); err != nil {
+		return fmt.Errorf("failed to save check run: %w", err)
+	}
+
+	snapshot := filepath.Join(r.resultsDir, checkRun.EngagementID(), check.SnapshotFilename(checkType, checkRun.ID()))
+	if err := os.Remove(snapshot); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove run snapshot: %w", err)
+	}
+	return nil
+}
+
+// SaveSnapshot persists the results a running check run has recorded so far
+func (r *CheckRunRepository) SaveSnapshot(ctx context.Context, checkRun *check.CheckRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.write(checkRun, check.SnapshotFilename(checkRun.Metadata().CheckType, checkRun.ID())); err != nil {
+		return fmt.Errorf("failed to save run snapshot: %w", err)
+	}
+	return nil
+}
+
+// write stores checkRun as filename in its engagement directory
+func (r *CheckRunRepository) write(checkRun *check.CheckRun, filename string) error {
 	engagementDir := filepath.Join(r.resultsDir, checkRun.EngagementID())
 	if err := os.MkdirAll(engagementDir, 0755); err != nil {
 		return fmt.Errorf("failed to create engagement directory: %w", err)
 	}
 
-	filePath := filepath.Join(engagementDir, "http_results.json")
-	if !security.IsValidPath(filePath) {
+	filePath := filepath.Join(engagementDir, filename)
+	if !security.IsValidPath(filePath) || filepath.Dir(filePath) != engagementDir {
 		return fmt.Errorf("invalid file path: %s", filePath)
 	}
 
@@ -172,11 +219,7 @@ func (r *CheckRunRepository) Save(ctx context.Context, checkRun *check.CheckRun)
 		return fmt.Errorf("failed to marshal check run: %w", err)
 	}
 
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
-		return fmt.Errorf("failed to save check run: %w", err)
-	}
-
-	return nil
+	return fileutil.WriteFile(filePath, data, 0644)
 }
 
 // FindByID retrieves a check run by its ID
@@ -196,14 +239,15 @@ func (r *CheckRunRepository) FindByID(ctx context.Context, id string) (*check.Ch
 			continue
 		}
 
-		filePath := filepath.Join(r.resultsDir, entry.Name(), "http_results.json")
-		checkRun, err := r.loadFromFile(filePath)
-		if err != nil {
-			continue
-		}
+		for _, filePath := range r.resultsFiles(entry.Name()) {
+			checkRun, err := r.loadFromFile(filePath)
+			if err != nil {
+				continue
+			}
 
-		if checkRun.ID() == id {
-			return checkRun, nil
+			if checkRun.ID() == id {
+				return checkRun, nil
+			}
 		}
 	}
 
@@ -215,19 +259,16 @@ func (r *CheckRunRepository) FindByEngagementID(ctx context.Context, engagementI
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	engagementDir := filepath.Join(r.resultsDir, engagementID)
-	filePath := filepath.Join(engagementDir, "http_results.json")
-
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return []*check.CheckRun{}, nil
-	}
-
-	checkRun, err := r.loadFromFile(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load check run: %w", err)
+	checkRuns := []*check.CheckRun{}
+	for _, filePath := range r.resultsFiles(engagementID) {
+		checkRun, err := r.loadFromFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load check run: %w", err)
+		}
+		checkRuns = append(checkRuns, checkRun)
 	}
 
-	return []*check.CheckRun{checkRun}, nil
+	return checkRuns, nil
 }
 
 // FindAll retrieves all check runs
@@ -246,13 +287,14 @@ func (r *CheckRunRepository) FindAll(ctx context.Context) ([]*check.CheckRun, er
 			continue
 		}
 
-		filePath := filepath.Join(r.resultsDir, entry.Name(), "http_results.json")
-		checkRun, err := r.loadFromFile(filePath)
-		if err != nil {
-			continue
-		}
+		for _, filePath := range r.resultsFiles(entry.Name()) {
+			checkRun, err := r.loadFromFile(filePath)
+			if err != nil {
+				continue
+			}
 
-		checkRuns = append(checkRuns, checkRun)
+			checkRuns = append(checkRuns, checkRun)
+		}
 	}
 
 	return checkRuns, nil
@@ -274,17 +316,18 @@ func (r *CheckRunRepository) Delete(ctx context.Context, id string) error {
 			continue
 		}
 
-		filePath := filepath.Join(r.resultsDir, entry.Name(), "http_results.json")
-		checkRun, err := r.loadFromFile(filePath)
-		if err != nil {
-			continue
-		}
+		for _, filePath := range r.resultsFiles(entry.Name()) {
+			checkRun, err := r.loadFromFile(filePath)
+			if err != nil {
+				continue
+			}
 
-		if checkRun.ID() == id {
-			if err := os.Remove(filePath); err != nil {
-				return fmt.Errorf("failed to delete check run: %w", err)
+			if checkRun.ID() == id {
+				if err := os.Remove(filePath); err != nil {
+					return fmt.Errorf("failed to delete check run: %w", err)
+				}
+				return nil
 			}
-			return nil
 		}
 	}
 
@@ -293,6 +336,17 @@ func (r *CheckRunRepository) Delete(ctx context.Context, id string) error {
 
 // Helper methods
 
+// resultsFiles lists an engagement's results files (one per check type),
+// sorted by name.
+func (r *CheckRunRepository) resultsFiles(engagementID string) []string {
+	matches, err := filepath.Glob(filepath.Join(r.resultsDir, engagementID, "*_results.json"))
+	if err != nil {
+		return nil
+	}
+	sort.Strings(matches)
+	return matches
+}
+
 func (r *CheckRunRepository) loadFromFile(filePath string) (*check.CheckRun, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
@@ -303,12 +357,17 @@ func (r *CheckRunRepository) loadFromFile(filePath string) (*check.CheckRun, err
 	if err := json.Unmarshal(data, &dto); err != nil {
 		return nil, err
 	}
+	if dto.Metadata.CheckType == "" {
+		// Older files did not record their type; it is in the file name.
+		dto.Metadata.CheckType = strings.TrimSuffix(filepath.Base(filePath), "_results.json")
+	}
 
 	return r.fromDTO(dto)
 }
 
 func (r *CheckRunRepository) toDTO(checkRun *check.CheckRun) checkRunDTO {
 	dto := checkRunDTO{
+		SchemaVersion:  consts.ResultsSchemaVersion,
 		ID:             checkRun.ID(),
 		EngagementID:   checkRun.EngagementID(),
 		EngagementName: checkRun.EngagementName(),
@@ -317,15 +376,22 @@ func (r *CheckRunRepository) toDTO(checkRun *check.CheckRun) checkRunDTO {
 		Status:         string(checkRun.Status()),
 		Results:        make([]resultDTO, 0),
 		Metadata: metadataDTO{
+			Operator:             checkRun.Operator(),
+			EngagementID:         checkRun.EngagementID(),
+			EngagementName:       checkRun.EngagementName(),
+			StartedAt:            checkRun.StartedAt().Format(time.RFC3339),
 			AuditHash:            checkRun.Metadata().AuditHash,
 			HashAlgorithm:        checkRun.Metadata().HashAlgorithm,
 			SignatureFingerprint: checkRun.Metadata().SignatureFingerprint,
 			TotalTargets:         checkRun.Metadata().TotalTargets,
+			StopReason:           checkRun.Metadata().StopReason,
+			CheckType:            checkRun.Metadata().CheckType,
 		},
 	}
 
 	if !checkRun.CompletedAt().IsZero() {
 		dto.CompletedAt = checkRun.CompletedAt().Format(time.RFC3339)
+		dto.Metadata.CompletedAt = dto.CompletedAt
 	}
 
 	for _, result := range checkRun.Results() {
@@ -350,6 +416,16 @@ func (r *CheckRunRepository) resultToDTO(result *check.Result) resultDTO {
 		dto.TLSExpiry = result.TLSExpiry().Format(time.RFC3339)
 	}
 
+	if timings := result.Timings(); !timings.IsZero() {
+		dto.Timings = &timingsDTO{
+			DNS:       timings.DNS,
+			Connect:   timings.Connect,
+			TLS:       timings.TLS,
+			FirstByte: timings.FirstByte,
+			Analysis:  timings.Analysis,
+		}
+	}
+
 	// Convert findings (simplified for now)
 	findings := result.Findings()
 	if findings.SecurityHeaders != nil {
@@ -393,6 +469,8 @@ func (r *CheckRunRepository) fromDTO(dto checkRunDTO) (*check.CheckRun, error) {
 		HashAlgorithm:        dto.Metadata.HashAlgorithm,
 		SignatureFingerprint: dto.Metadata.SignatureFingerprint,
 		TotalTargets:         dto.Metadata.TotalTargets,
+		StopReason:           dto.Metadata.StopReason,
+		CheckType:            dto.Metadata.CheckType,
 	}
 
 	return check.Reconstruct(
@@ -416,6 +494,15 @@ func (r *CheckRunRepository) resultFromDTO(dto resultDTO) (*check.Result, error)
 
 	result.SetHTTPStatus(dto.HTTPStatus)
 	result.SetResponseTime(dto.ResponseTime)
+	if dto.Timings != nil {
+		result.SetTimings(check.PhaseTimings{
+			DNS:       dto.Timings.DNS,
+			Connect:   dto.Timings.Connect,
+			TLS:       dto.Timings.TLS,
+			FirstByte: dto.Timings.FirstByte,
+			Analysis:  dto.Timings.Analysis,
+		})
+	}
 
 	if dto.TLSExpiry != "" {
 		tlsExpiry, err := time.Parse(time.RFC3339, dto.TLSExpiry)
@@ -0,0 +1,88 @@
+package json
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/khanhnv2901/seca-cli/internal/domain/check"
+)
+
+func TestCheckRunRepository_SavesOneFilePerCheckType(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := NewCheckRunRepository(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	for _, checkType := range []string{"", check.CheckTypeDNS, check.CheckTypeNetwork} {
+		run, err := check.NewCheckRun("eng-1", "Test", "alice")
+		if err != nil {
+			t.Fatal(err)
+		}
+		run.SetCheckType(checkType)
+		if err := run.Start(); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Save(ctx, run); err != nil {
+			t.Fatalf("Save(%q): %v", checkType, err)
+		}
+	}
+
+	for _, name := range []string{"http_results.json", "dns_results.json", "network_results.json"} {
+		if _, err := os.Stat(filepath.Join(dir, "eng-1", name)); err != nil {
+			t.Errorf("expected %s: %v", name, err)
+		}
+	}
+
+	runs, err := repo.FindByEngagementID(ctx, "eng-1")
+	if err != nil {
+		t.Fatalf("FindByEngagementID: %v", err)
+	}
+	types := map[string]bool{}
+	for _, run := range runs {
+		types[run.Metadata().CheckType] = true
+	}
+	if len(runs) != 3 || !types[check.CheckTypeHTTP] || !types[check.CheckTypeDNS] || !types[check.CheckTypeNetwork] {
+		t.Fatalf("expected one run per check type, got %v", types)
+	}
+}
+
+func TestCheckRunRepository_RoundTripsPhaseTimings(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := NewCheckRunRepository(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	run, err := check.NewCheckRun("eng-1", "Test", "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := run.Start(); err != nil {
+		t.Fatal(err)
+	}
+	result, err := check.NewResult("https://example.com/", check.CheckStatusOK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	timings := check.PhaseTimings{DNS: 1.5, Connect: 2, TLS: 10, FirstByte: 40, Analysis: 120}
+	result.SetTimings(timings)
+	if err := run.AddResult(result); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Save(ctx, run); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := repo.FindByID(ctx, run.ID())
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if got := loaded.Results()[0].Timings(); got != timings {
+		t.Fatalf("expected timings %+v, got %+v", timings, got)
+	}
+}
@@ -11,20 +11,59 @@ import (
 
 	"github.com/khanhnv2901/seca-cli/internal/domain/engagement"
 	sharedErrors "github.com/khanhnv2901/seca-cli/internal/shared/errors"
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
 	"github.com/khanhnv2901/seca-cli/internal/shared/security"
 )
 
 // engagementDTO is the data transfer object for JSON serialization
 type engagementDTO struct {
-	ID        string   `json:"id"`
-	Name      string   `json:"name"`
-	Owner     string   `json:"owner"`
-	Start     string   `json:"start,omitempty"`
-	End       string   `json:"end,omitempty"`
-	Scope     []string `json:"scope,omitempty"`
-	ROE       string   `json:"roe,omitempty"`
-	ROEAgree  bool     `json:"roe_agree"`
-	CreatedAt string   `json:"created_at"`
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Owner     string          `json:"owner"`
+	Start     string          `json:"start,omitempty"`
+	End       string          `json:"end,omitempty"`
+	Scope     []scopeEntryDTO `json:"scope,omitempty"`
+	ROE       string          `json:"roe,omitempty"`
+	ROEAgree  bool            `json:"roe_agree"`
+	CreatedAt string          `json:"created_at"`
+	ExpiresAt string          `json:"expires_at,omitempty"`
+	Status    string          `json:"status,omitempty"`
+}
+
+// scopeEntryDTO is a scope entry: a plain string, or an object with the
+// target and its overrides when it has any.
+type scopeEntryDTO struct {
+	Target    string
+	Overrides engagement.TargetOverrides
+}
+
+type scopeEntryObject struct {
+	Target string `json:"target"`
+	engagement.TargetOverrides
+}
+
+func (s scopeEntryDTO) MarshalJSON() ([]byte, error) {
+	if s.Overrides.IsZero() {
+		return json.Marshal(s.Target)
+	}
+	return json.Marshal(scopeEntryObject{Target: s.Target, TargetOverrides: s.Overrides})
+}
+
+func (s *scopeEntryDTO) UnmarshalJSON(data []byte) error {
+	var target string
+	if err := json.Unmarshal(data, &target); err == nil {
+		*s = scopeEntryDTO{Target: target}
+		return nil
+	}
+	var obj scopeEntryObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("scope entry must be a string or an object with a target: %w", err)
+	}
+	if obj.Target == "" {
+		return fmt.Errorf("scope entry object has no target")
+	}
+	*s = scopeEntryDTO{Target: obj.Target, Overrides: obj.TargetOverrides}
+	return nil
 }
 
 // EngagementRepository implements the engagement.Repository interface using JSON file storage
@@ -213,17 +252,23 @@ func (r *EngagementRepository) saveToFile(engagements []engagementDTO) error {
 		return err
 	}
 
-	return os.WriteFile(r.filePath, data, 0644)
+	return fileutil.WriteFile(r.filePath, data, 0644)
 }
 
 func (r *EngagementRepository) toDTO(eng *engagement.Engagement) engagementDTO {
+	var scope []scopeEntryDTO
+	for _, target := range eng.Scope() {
+		overrides, _ := eng.TargetOverrides(target)
+		scope = append(scope, scopeEntryDTO{Target: target, Overrides: overrides})
+	}
 	dto := engagementDTO{
 		ID:       eng.ID(),
 		Name:     eng.Name(),
 		Owner:    eng.Owner(),
-		Scope:    eng.Scope(),
+		Scope:    scope,
 		ROE:      eng.ROE(),
 		ROEAgree: eng.ROEAgreed(),
+		Status:   string(eng.Status()),
 	}
 
 	if !eng.Start().IsZero() {
@@ -235,6 +280,9 @@ func (r *EngagementRepository) toDTO(eng *engagement.Engagement) engagementDTO {
 	if !eng.CreatedAt().IsZero() {
 		dto.CreatedAt = eng.CreatedAt().Format("2006-01-02T15:04:05Z07:00")
 	}
+	if !eng.ExpiresAt().IsZero() {
+		dto.ExpiresAt = eng.ExpiresAt().Format("2006-01-02T15:04:05Z07:00")
+	}
 
 	return dto
 }
@@ -264,15 +312,47 @@ func (r *EngagementRepository) fromDTO(dto engagementDTO) (*engagement.Engagemen
 		}
 	}
 
-	return engagement.Reconstruct(
+	var scope []string
+	for _, entry := range dto.Scope {
+		scope = append(scope, entry.Target)
+	}
+	eng := engagement.Reconstruct(
 		dto.ID,
 		dto.Name,
 		dto.Owner,
 		dto.ROE,
-		dto.Scope,
+		scope,
 		dto.ROEAgree,
 		start,
 		end,
 		createdAt,
-	), nil
+	)
+
+	for _, entry := range dto.Scope {
+		if entry.Overrides.IsZero() {
+			continue
+		}
+		if err := eng.SetTargetOverrides(entry.Target, entry.Overrides); err != nil {
+			return nil, fmt.Errorf("scope overrides: %w", err)
+		}
+	}
+
+	// Engagements stored before statuses existed are active.
+	if dto.Status != "" {
+		if err := eng.RestoreStatus(engagement.Status(dto.Status)); err != nil {
+			return nil, err
+		}
+	}
+
+	if dto.ExpiresAt != "" {
+		expiresAt, err := time.Parse("2006-01-02T15:04:05Z07:00", dto.ExpiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse expires at time: %w", err)
+		}
+		if err := eng.SetExpiry(expiresAt); err != nil {
+			return nil, err
+		}
+	}
+
+	return eng, nil
 }
@@ -0,0 +1,67 @@
+package json
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/khanhnv2901/seca-cli/internal/domain/engagement"
+)
+
+func TestEngagementRepository_ScopeOverrides(t *testing.T) {
+	dir := t.TempDir()
+	// Scope entries may be plain strings or objects with overrides.
+	data := `[{"id":"eng-1","name":"Web","owner":"alice","roe_agree":true,"created_at":"2026-10-01T09:00:00Z",
+		"scope":["https://app.example.com",{"target":"https://admin.example.com","ports":[443,8443],"timeout_secs":60,"skip_crawl":true,"auth_profile":"admin","expected_issuer":"Let's Encrypt"}]}]`
+	if err := os.WriteFile(filepath.Join(dir, "engagements.json"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := NewEngagementRepository(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	eng, err := repo.FindByID(ctx, "eng-1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if scope := eng.Scope(); len(scope) != 2 || scope[1] != "https://admin.example.com" {
+		t.Fatalf("unexpected scope %v", scope)
+	}
+	o, ok := eng.TargetOverrides("https://admin.example.com")
+	if !ok || o.TimeoutSecs != 60 || !o.SkipCrawl || o.AuthProfile != "admin" || len(o.Ports) != 2 {
+		t.Fatalf("unexpected overrides %+v", o)
+	}
+	if _, ok := eng.TargetOverrides("https://app.example.com"); ok {
+		t.Error("plain entries have no overrides")
+	}
+
+	if err := eng.SetTargetOverrides("https://admin.example.com", engagement.TargetOverrides{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Save(ctx, eng); err != nil {
+		t.Fatal(err)
+	}
+	saved, _ := os.ReadFile(filepath.Join(dir, "engagements.json"))
+	if strings.Contains(string(saved), `"target"`) {
+		t.Errorf("entries without overrides should be saved as strings:\n%s", saved)
+	}
+}
+
+func TestEngagementRepository_RejectsInvalidOverrides(t *testing.T) {
+	dir := t.TempDir()
+	data := `[{"id":"eng-1","name":"Web","owner":"alice","created_at":"2026-10-01T09:00:00Z","scope":[{"target":"a.example.com","ports":[70000]}]}]`
+	if err := os.WriteFile(filepath.Join(dir, "engagements.json"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := NewEngagementRepository(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.FindByID(context.Background(), "eng-1"); err == nil {
+		t.Fatal("expected an error for an out-of-range port")
+	}
+}
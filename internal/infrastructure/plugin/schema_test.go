@@ -0,0 +1,77 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckResultSchemaIsValidJSON(t *testing.T) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(CheckResultSchema(), &doc); err != nil {
+		t.Fatalf("embedded schema is not valid JSON: %v", err)
+	}
+	if doc["title"] != "SECA-CLI CheckResult" {
+		t.Fatalf("unexpected schema title %v", doc["title"])
+	}
+}
+
+func TestValidateCheckResultAcceptsDocumentedOutput(t *testing.T) {
+	output := `{
+		"target": "example.com",
+		"checked_at": "2025-01-15T10:30:00Z",
+		"status": "success",
+		"http_status": 200,
+		"tls_expiry": "2026-01-15T00:00:00Z",
+		"response_time_ms": 12.5,
+		"cookie_findings": [{"name": "sid", "missing_secure": true}],
+		"notes": "All checks passed",
+		"error": ""
+	}`
+	if err := ValidateCheckResult([]byte(output)); err != nil {
+		t.Fatalf("ValidateCheckResult() error = %v", err)
+	}
+}
+
+func TestValidateCheckResultReportsFieldPaths(t *testing.T) {
+	output := `{
+		"status": "passed",
+		"http_status": "200",
+		"checked_at": "yesterday",
+		"HTTPStatus": 200,
+		"cookie_findings": [{"missing_secure": "yes"}]
+	}`
+	err := ValidateCheckResult([]byte(output))
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("expected *SchemaError, got %v", err)
+	}
+
+	msg := err.Error()
+	for _, want := range []string{
+		`$.status: value "passed" is not one of`,
+		"$.http_status: expected integer, got string",
+		"$.checked_at: value \"yesterday\" is not an RFC 3339 date-time",
+		`$.HTTPStatus: unknown field (did you mean "http_status"?)`,
+		"$.cookie_findings[0].name: required field is missing",
+		"$.cookie_findings[0].missing_secure: expected boolean, got string",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("error missing %q:\n%s", want, msg)
+		}
+	}
+}
+
+func TestValidateCheckResultRejectsNonObject(t *testing.T) {
+	if err := ValidateCheckResult([]byte(`not json`)); err == nil {
+		t.Fatal("expected error for invalid JSON")
+	}
+	if err := ValidateCheckResult([]byte(`{"status":"ok"} {"status":"ok"}`)); err == nil {
+		t.Fatal("expected error for multiple documents")
+	}
+	err := ValidateCheckResult([]byte(`{}`))
+	if err == nil || !strings.Contains(err.Error(), "$.status: required field is missing") {
+		t.Fatalf("expected missing status error, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+// Package remediation is the knowledge base behind report remediation
+// guidance.
+//
+// Each Guide covers one kind of finding: what it is, why it matters, how to
+// fix it on the servers and frameworks engagements usually meet, and where
+// to read more. Guides are embedded JSON so they can be reviewed and extended
+// without touching the checkers; a finding is matched to a guide by its name
+// (the checker's Vulnerability.Name or the security header it reports on).
+package remediation
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+//go:embed data/guides.json
+var guidesJSON []byte
+
+// Guide is the remediation guidance for one kind of finding.
+type Guide struct {
+	ID          string      `json:"id"`
+	Title       string      `json:"title"`
+	Findings    []string    `json:"findings"`
+	Description string      `json:"description"`
+	Impact      string      `json:"impact"`
+	Summary     string      `json:"summary"`
+	Fixes       []Fix       `json:"fixes"`
+	References  []Reference `json:"references"`
+}
+
+// Fix is the step-by-step procedure for one server, framework or platform.
+// Example is an optional configuration snippet.
+type Fix struct {
+	Platform string   `json:"platform"`
+	Steps    []string `json:"steps"`
+	Example  string   `json:"example,omitempty"`
+}
+
+// Reference points to OWASP, vendor or standards documentation.
+type Reference struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+type guideFile struct {
+	Version int     `json:"version"`
+	Guides  []Guide `json:"guides"`
+}
+
+var (
+	guidesOnce sync.Once
+	guides     []Guide
+	byFinding  map[string]*Guide
+)
+
+// loadGuides parses the bundled knowledge base once.
+func loadGuides() {
+	guidesOnce.Do(func() {
+		var file guideFile
+		if err := json.Unmarshal(guidesJSON, &file); err != nil {
+			panic(fmt.Sprintf("invalid embedded remediation guides: %v", err))
+		}
+		guides = file.Guides
+		byFinding = make(map[string]*Guide)
+		for i := range guides {
+			g := &guides[i]
+			for _, name := range g.Findings {
+				key := normalize(name)
+				if prev, ok := byFinding[key]; ok {
+					panic(fmt.Sprintf("remediation guides %s and %s both claim finding %q", prev.ID, g.ID, name))
+				}
+				byFinding[key] = g
+			}
+		}
+	})
+}
+
+// Lookup returns the guide for a finding name. Matching ignores case and
+// surrounding whitespace.
+func Lookup(finding string) (*Guide, bool) {
+	loadGuides()
+	g, ok := byFinding[normalize(finding)]
+	return g, ok
+}
+
+// All returns every guide in the knowledge base, in file order.
+func All() []Guide {
+	loadGuides()
+	out := make([]Guide, len(guides))
+	copy(out, guides)
+	return out
+}
+
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
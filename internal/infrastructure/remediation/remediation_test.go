@@ -0,0 +1,49 @@
+package remediation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGuidesAreComplete(t *testing.T) {
+	all := All()
+	if len(all) == 0 {
+		t.Fatal("expected embedded guides")
+	}
+	seen := make(map[string]bool)
+	for _, g := range all {
+		if g.ID == "" || seen[g.ID] {
+			t.Errorf("guide %q: missing or duplicate id", g.ID)
+		}
+		seen[g.ID] = true
+		if g.Title == "" || g.Description == "" || g.Impact == "" || g.Summary == "" {
+			t.Errorf("guide %s: title, description, impact and summary are required", g.ID)
+		}
+		if len(g.Findings) == 0 || len(g.Fixes) == 0 || len(g.References) == 0 {
+			t.Errorf("guide %s: needs findings, fixes and references", g.ID)
+		}
+		for _, fix := range g.Fixes {
+			if fix.Platform == "" || len(fix.Steps) == 0 {
+				t.Errorf("guide %s: fix %q has no platform or steps", g.ID, fix.Platform)
+			}
+		}
+		for _, ref := range g.References {
+			if ref.Title == "" || !strings.HasPrefix(ref.URL, "https://") {
+				t.Errorf("guide %s: reference %+v must have a title and an https URL", g.ID, ref)
+			}
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	g, ok := Lookup("  content security policy (csp) ")
+	if !ok || g.ID != "content-security-policy" {
+		t.Fatalf("Lookup() = %+v, %v", g, ok)
+	}
+	if g, ok := Lookup("Strict-Transport-Security"); !ok || g.ID != "strict-transport-security" {
+		t.Fatalf("header name lookup = %+v, %v", g, ok)
+	}
+	if _, ok := Lookup("Unknown Finding"); ok {
+		t.Fatal("expected no guide for an unknown finding")
+	}
+}
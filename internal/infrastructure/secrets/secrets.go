@@ -0,0 +1,201 @@
+// Package secrets reads credentials from external secret stores (HashiCorp
+// Vault, AWS Secrets Manager, or the process environment) by name, so that
+// engagement files only ever hold the names.
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Providers.
+const (
+	ProviderEnv   = "env"
+	ProviderVault = "vault"
+	ProviderAWS   = "aws"
+)
+
+// ErrNotFound is returned for a secret name that is not configured, or a
+// secret the store does not hold.
+var ErrNotFound = errors.New("secret not found")
+
+const defaultTimeout = 10 * time.Second
+
+// Ref says where a named secret is kept.
+type Ref struct {
+	Provider string // ProviderEnv, ProviderVault, or ProviderAWS
+	// Path is the environment variable (env), the KV v2 path including its
+	// mount, e.g. "secret/app/login" (vault), or the secret ID or ARN (aws).
+	Path string
+	// Key selects one field of a secret holding several: a key of the Vault
+	// secret's data, or of a JSON SecretString. Vault secrets require it.
+	Key string
+	// Region overrides AWSConfig.Region for this secret.
+	Region string
+}
+
+// Validate checks that the ref names a known provider and a path.
+func (r Ref) Validate() error {
+	switch r.Provider {
+	case ProviderEnv, ProviderAWS:
+	case ProviderVault:
+		if strings.TrimSpace(r.Key) == "" {
+			return errors.New("vault secrets require a key")
+		}
+		if !strings.Contains(strings.Trim(r.Path, "/"), "/") {
+			return fmt.Errorf("vault path %q must include the mount, e.g. secret/app", r.Path)
+		}
+	default:
+		return fmt.Errorf("unknown secret provider %q (expected %s, %s, or %s)", r.Provider, ProviderEnv, ProviderVault, ProviderAWS)
+	}
+	if strings.TrimSpace(r.Path) == "" {
+		return errors.New("path is required")
+	}
+	return nil
+}
+
+// VaultConfig holds the Vault server settings.
+type VaultConfig struct {
+	Address   string // e.g. https://vault.example.com:8200
+	Token     string
+	Namespace string // Vault Enterprise namespace, if any
+}
+
+// AWSConfig holds the AWS credentials and default region.
+type AWSConfig struct {
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Endpoint        string // Overrides https://secretsmanager.<region>.amazonaws.com
+}
+
+// Config captures the secret names and store settings.
+type Config struct {
+	Refs    map[string]Ref
+	Vault   VaultConfig
+	AWS     AWSConfig
+	Timeout time.Duration // Per-request timeout (default 10s)
+	Getenv  func(string) string
+}
+
+// Resolver looks secrets up by name. Each secret is fetched once, so a run
+// does not ask the store again for every profile that uses it. It is safe
+// for concurrent use.
+type Resolver struct {
+	cfg    Config
+	client *http.Client
+
+	mu     sync.Mutex
+	values map[string]string
+}
+
+// NewResolver returns a Resolver for cfg. Environment lookups use os.Getenv
+// unless cfg.Getenv is set.
+func NewResolver(cfg Config) *Resolver {
+	if cfg.Getenv == nil {
+		cfg.Getenv = os.Getenv
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &Resolver{cfg: cfg, client: &http.Client{Timeout: timeout}, values: make(map[string]string)}
+}
+
+// Names returns the configured secret names, sorted.
+func (r *Resolver) Names() []string {
+	names := make([]string, 0, len(r.cfg.Refs))
+	for name := range r.cfg.Refs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Ref returns where name is kept.
+func (r *Resolver) Ref(name string) (Ref, bool) {
+	ref, ok := r.cfg.Refs[name]
+	return ref, ok
+}
+
+// Lookup returns the value of the named secret. Errors name the secret and
+// its store but never include a value.
+func (r *Resolver) Lookup(ctx context.Context, name string) (string, error) {
+	r.mu.Lock()
+	value, ok := r.values[name]
+	r.mu.Unlock()
+	if ok {
+		return value, nil
+	}
+
+	ref, ok := r.cfg.Refs[name]
+	if !ok {
+		return "", fmt.Errorf("secret %q: %w (define secrets.%s in config)", name, ErrNotFound, name)
+	}
+	if err := ref.Validate(); err != nil {
+		return "", fmt.Errorf("secret %q: %w", name, err)
+	}
+
+	var err error
+	switch ref.Provider {
+	case ProviderEnv:
+		value, err = r.env(ref)
+	case ProviderVault:
+		value, err = r.vault(ctx, ref)
+	case ProviderAWS:
+		value, err = r.aws(ctx, ref)
+	}
+	if err == nil && strings.TrimSpace(value) == "" {
+		err = errors.New("value is empty")
+	}
+	if err != nil {
+		return "", fmt.Errorf("secret %q (%s %s): %w", name, ref.Provider, ref.Path, err)
+	}
+
+	r.mu.Lock()
+	r.values[name] = value
+	r.mu.Unlock()
+	return value, nil
+}
+
+func (r *Resolver) env(ref Ref) (string, error) {
+	value := r.cfg.Getenv(ref.Path)
+	if value == "" {
+		return "", fmt.Errorf("$%s is not set: %w", ref.Path, ErrNotFound)
+	}
+	if ref.Key != "" {
+		return jsonField(value, ref.Key)
+	}
+	return value, nil
+}
+
+// jsonField returns key of the JSON object in raw, for secrets that bundle
+// several values.
+func jsonField(raw, key string) (string, error) {
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+		return "", fmt.Errorf("key %q given but the secret is not a JSON object", key)
+	}
+	return stringField(fields, key)
+}
+
+func stringField(fields map[string]any, key string) (string, error) {
+	value, ok := fields[key]
+	if !ok {
+		return "", fmt.Errorf("key %q: %w", key, ErrNotFound)
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("key %q is not a string", key)
+	}
+	return s, nil
+}
@@ -0,0 +1,144 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLookupEnv(t *testing.T) {
+	env := map[string]string{"APP_TOKEN": "tok-123", "APP_BUNDLE": `{"password":"hunter22"}`}
+	r := NewResolver(Config{
+		Refs: map[string]Ref{
+			"token":    {Provider: ProviderEnv, Path: "APP_TOKEN"},
+			"password": {Provider: ProviderEnv, Path: "APP_BUNDLE", Key: "password"},
+			"missing":  {Provider: ProviderEnv, Path: "UNSET"},
+		},
+		Getenv: func(name string) string { return env[name] },
+	})
+
+	if got, err := r.Lookup(context.Background(), "token"); err != nil || got != "tok-123" {
+		t.Fatalf("Lookup(token) = %q, %v", got, err)
+	}
+	if got, err := r.Lookup(context.Background(), "password"); err != nil || got != "hunter22" {
+		t.Fatalf("Lookup(password) = %q, %v", got, err)
+	}
+	for _, name := range []string{"missing", "undefined"} {
+		if _, err := r.Lookup(context.Background(), name); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Lookup(%s) error = %v, want ErrNotFound", name, err)
+		}
+	}
+}
+
+func TestLookupVault(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("X-Vault-Token") != "s.root" || r.Header.Get("X-Vault-Namespace") != "team-a" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = io.WriteString(w, `{"errors":["permission denied"]}`)
+			return
+		}
+		if r.URL.Path != "/v1/secret/data/app/login" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, `{"data":{"data":{"password":"hunter22"},"metadata":{"version":3}}}`)
+	}))
+	defer server.Close()
+
+	cfg := Config{
+		Refs: map[string]Ref{
+			"password": {Provider: ProviderVault, Path: "secret/app/login", Key: "password"},
+			"gone":     {Provider: ProviderVault, Path: "secret/app/old", Key: "password"},
+		},
+		Vault: VaultConfig{Address: server.URL, Token: "s.root", Namespace: "team-a"},
+	}
+	r := NewResolver(cfg)
+	for i := 0; i < 2; i++ {
+		if got, err := r.Lookup(context.Background(), "password"); err != nil || got != "hunter22" {
+			t.Fatalf("Lookup() = %q, %v", got, err)
+		}
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected one request for a repeated lookup, got %d", requests.Load())
+	}
+	if _, err := r.Lookup(context.Background(), "gone"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	cfg.Vault.Token = "wrong"
+	_, err := NewResolver(cfg).Lookup(context.Background(), "password")
+	if err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("expected the vault error message, got %v", err)
+	}
+}
+
+func TestLookupAWS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") || !strings.Contains(auth, "/eu-west-1/secretsmanager/aws4_request") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" || r.Header.Get("X-Amz-Security-Token") != "session" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"SecretId":"prod/api"`) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"__type":"ResourceNotFoundException","message":"not found"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"Name":"prod/api","SecretString":"{\"token\":\"tok-456\"}"}`)
+	}))
+	defer server.Close()
+
+	r := NewResolver(Config{
+		Refs: map[string]Ref{
+			"api":     {Provider: ProviderAWS, Path: "prod/api", Key: "token", Region: "eu-west-1"},
+			"missing": {Provider: ProviderAWS, Path: "prod/missing", Region: "eu-west-1"},
+		},
+		AWS: AWSConfig{Region: "us-east-1", AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret", SessionToken: "session", Endpoint: server.URL},
+	})
+	if got, err := r.Lookup(context.Background(), "api"); err != nil || got != "tok-456" {
+		t.Fatalf("Lookup() = %q, %v", got, err)
+	}
+	if _, err := r.Lookup(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+// TestSignV4 checks the signer against the example request in the AWS
+// Signature Version 4 documentation.
+func TestSignV4(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	now := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+	signV4(req, nil, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1", "iam", now)
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRefValidate(t *testing.T) {
+	for _, ref := range []Ref{
+		{Provider: "keychain", Path: "x"},
+		{Provider: ProviderEnv},
+		{Provider: ProviderVault, Path: "secret/app"},
+		{Provider: ProviderVault, Path: "app", Key: "password"},
+	} {
+		if ref.Validate() == nil {
+			t.Errorf("expected %+v to be invalid", ref)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// vault reads ref.Key of a KV version 2 secret. The first segment of
+// ref.Path is the mount: "secret/app/login" reads /v1/secret/data/app/login.
+func (r *Resolver) vault(ctx context.Context, ref Ref) (string, error) {
+	cfg := r.cfg.Vault
+	if cfg.Address == "" {
+		return "", errors.New("vault address is not set (vault.address or $VAULT_ADDR)")
+	}
+	if cfg.Token == "" {
+		return "", errors.New("vault token is not set ($VAULT_TOKEN or vault.token_env)")
+	}
+	mount, path, _ := strings.Cut(strings.Trim(ref.Path, "/"), "/")
+	endpoint := strings.TrimRight(cfg.Address, "/") + "/v1/" + mount + "/data/" + path
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", cfg.Token)
+	if cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", cfg.Namespace)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", ErrNotFound
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return "", fmt.Errorf("vault returned %s: %s", resp.Status, vaultErrors(resp.Body))
+	}
+
+	var body struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decode vault response: %w", err)
+	}
+	return stringField(body.Data.Data, ref.Key)
+}
+
+// vaultErrors returns the messages of a Vault error response.
+func vaultErrors(body io.Reader) string {
+	var parsed struct {
+		Errors []string `json:"errors"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(body, 4096))
+	if json.Unmarshal(data, &parsed) == nil && len(parsed.Errors) > 0 {
+		return strings.Join(parsed.Errors, "; ")
+	}
+	return strings.TrimSpace(string(data))
+}
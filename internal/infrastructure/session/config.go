@@ -0,0 +1,229 @@
+// Package session holds per-engagement authentication settings (static
+// cookies, bearer tokens, or a scripted form login), stores them encrypted at
+// rest, and turns them into an authenticated session that checkers and the
+// crawler attach to outgoing requests.
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Authentication types.
+const (
+	TypeCookies = "cookies"
+	TypeBearer  = "bearer"
+	TypeForm    = "form"
+)
+
+// Config describes how to authenticate against an engagement's targets.
+// Credentials may be given as values or as the names of secrets held in an
+// external store (see ResolveSecrets); only the names are saved for those.
+type Config struct {
+	Type    string            `json:"type"`
+	Cookies map[string]string `json:"cookies,omitempty"`
+	Token   string            `json:"token,omitempty"`
+	Form    *FormLogin        `json:"form,omitempty"`
+	// CookieSecrets maps cookie names to the secrets holding their values.
+	CookieSecrets map[string]string `json:"cookie_secrets,omitempty"`
+	// TokenSecret names the secret holding the bearer token.
+	TokenSecret string `json:"token_secret,omitempty"`
+}
+
+// FormLogin is a scripted login: the login page is optionally fetched to pick
+// up a CSRF token, then Fields are POSTed and the resulting cookies are kept.
+type FormLogin struct {
+	URL string `json:"url"`
+	// Fields are submitted as application/x-www-form-urlencoded.
+	Fields map[string]string `json:"fields"`
+	// CSRFField names a hidden input copied from the login page into the submission.
+	CSRFField string `json:"csrf_field,omitempty"`
+	// SuccessText, when set, must appear in the login response body.
+	SuccessText string `json:"success_text,omitempty"`
+	// FieldSecrets maps field names to the secrets holding their values.
+	FieldSecrets map[string]string `json:"field_secrets,omitempty"`
+}
+
+// SecretLookup returns the value of the named secret.
+type SecretLookup func(ctx context.Context, name string) (string, error)
+
+// Validate checks that the fields required by the configured type are present.
+func (c *Config) Validate() error {
+	if c == nil {
+		return errors.New("authentication config is empty")
+	}
+	switch c.Type {
+	case TypeCookies:
+		if len(c.Cookies)+len(c.CookieSecrets) == 0 {
+			return errors.New("cookies authentication requires at least one cookie")
+		}
+		for _, names := range []map[string]string{c.Cookies, c.CookieSecrets} {
+			for name := range names {
+				if strings.TrimSpace(name) == "" || strings.ContainsAny(name, "=; \t") {
+					return fmt.Errorf("invalid cookie name %q", name)
+				}
+			}
+		}
+	case TypeBearer:
+		if strings.TrimSpace(c.Token) == "" && strings.TrimSpace(c.TokenSecret) == "" {
+			return errors.New("bearer authentication requires a token")
+		}
+	case TypeForm:
+		if c.Form == nil {
+			return errors.New("form authentication requires a login form")
+		}
+		u, err := url.Parse(c.Form.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("form login URL %q must be an absolute http(s) URL", c.Form.URL)
+		}
+		if len(c.Form.Fields)+len(c.Form.FieldSecrets) == 0 {
+			return errors.New("form authentication requires at least one field")
+		}
+	default:
+		return fmt.Errorf("unknown authentication type %q (expected %s, %s, or %s)", c.Type, TypeCookies, TypeBearer, TypeForm)
+	}
+	return nil
+}
+
+// Secrets returns every credential value in the config so it can be redacted
+// from captured evidence.
+func (c *Config) Secrets() []string {
+	if c == nil {
+		return nil
+	}
+	var secrets []string
+	add := func(v string) {
+		if v = strings.TrimSpace(v); len(v) >= 4 {
+			secrets = append(secrets, v)
+		}
+	}
+	for _, v := range c.Cookies {
+		add(v)
+	}
+	add(c.Token)
+	if c.Form != nil {
+		for _, v := range c.Form.Fields {
+			add(v)
+		}
+	}
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+	return secrets
+}
+
+// Summary describes the config without revealing credential values.
+func (c *Config) Summary() string {
+	if c == nil {
+		return "none"
+	}
+	switch c.Type {
+	case TypeCookies:
+		return fmt.Sprintf("static cookies (%s)", strings.Join(summarizeNames(c.Cookies, c.CookieSecrets), ", "))
+	case TypeBearer:
+		if c.TokenSecret != "" {
+			return fmt.Sprintf("bearer token (secret %s)", c.TokenSecret)
+		}
+		return "bearer token"
+	case TypeForm:
+		return fmt.Sprintf("form login at %s (fields: %s)", c.Form.URL, strings.Join(summarizeNames(c.Form.Fields, c.Form.FieldSecrets), ", "))
+	}
+	return c.Type
+}
+
+// summarizeNames lists the names in values and refs, sorted, with the
+// secret each ref names: "password (secret app_password)".
+func summarizeNames(values, refs map[string]string) []string {
+	names := make([]string, 0, len(values)+len(refs))
+	for name := range values {
+		if _, ok := refs[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	for name, secret := range refs {
+		names = append(names, fmt.Sprintf("%s (secret %s)", name, secret))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SecretNames returns the names of the secrets the config refers to,
+// sorted and without repeats.
+func (c *Config) SecretNames() []string {
+	if c == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if name = strings.TrimSpace(name); name != "" {
+			seen[name] = true
+		}
+	}
+	for _, name := range c.CookieSecrets {
+		add(name)
+	}
+	add(c.TokenSecret)
+	if c.Form != nil {
+		for _, name := range c.Form.FieldSecrets {
+			add(name)
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveSecrets returns a copy of c with every secret reference replaced by
+// the value lookup returns for it. c is not modified, so the values never
+// reach a saved config.
+func (c *Config) ResolveSecrets(ctx context.Context, lookup SecretLookup) (*Config, error) {
+	if c == nil {
+		return nil, nil
+	}
+	resolved := *c
+	resolved.CookieSecrets = nil
+	resolved.TokenSecret = ""
+	var err error
+	if resolved.Cookies, err = resolveRefs(ctx, lookup, "cookie", c.Cookies, c.CookieSecrets); err != nil {
+		return nil, err
+	}
+	if c.TokenSecret != "" {
+		if resolved.Token, err = lookup(ctx, c.TokenSecret); err != nil {
+			return nil, fmt.Errorf("bearer token: %w", err)
+		}
+	}
+	if c.Form != nil {
+		form := *c.Form
+		form.FieldSecrets = nil
+		if form.Fields, err = resolveRefs(ctx, lookup, "form field", c.Form.Fields, c.Form.FieldSecrets); err != nil {
+			return nil, err
+		}
+		resolved.Form = &form
+	}
+	return &resolved, nil
+}
+
+// resolveRefs returns values with the value of each ref's secret added.
+// values itself is not modified.
+func resolveRefs(ctx context.Context, lookup SecretLookup, kind string, values, refs map[string]string) (map[string]string, error) {
+	if len(refs) == 0 {
+		return values, nil
+	}
+	out := make(map[string]string, len(values)+len(refs))
+	for name, value := range values {
+		out[name] = value
+	}
+	for name, secret := range refs {
+		value, err := lookup(ctx, secret)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", kind, name, err)
+		}
+		out[name] = value
+	}
+	return out, nil
+}
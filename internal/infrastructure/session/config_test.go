@@ -0,0 +1,95 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestConfigValidate(t *testing.T) {
+	valid := []*Config{
+		{Type: TypeCookies, Cookies: map[string]string{"sid": "abc"}},
+		{Type: TypeBearer, Token: "token"},
+		{Type: TypeForm, Form: &FormLogin{URL: "https://app.example.com/login", Fields: map[string]string{"user": "a"}}},
+	}
+	for _, cfg := range valid {
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("expected %s config to be valid: %v", cfg.Type, err)
+		}
+	}
+
+	invalid := []*Config{
+		nil,
+		{Type: "basic"},
+		{Type: TypeCookies},
+		{Type: TypeCookies, Cookies: map[string]string{"bad name": "x"}},
+		{Type: TypeBearer, Token: "  "},
+		{Type: TypeForm},
+		{Type: TypeForm, Form: &FormLogin{URL: "/login", Fields: map[string]string{"user": "a"}}},
+		{Type: TypeForm, Form: &FormLogin{URL: "https://app.example.com/login"}},
+	}
+	for i, cfg := range invalid {
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("case %d: expected validation error", i)
+		}
+	}
+}
+
+func TestConfigSummaryHidesSecrets(t *testing.T) {
+	cfg := &Config{Type: TypeForm, Form: &FormLogin{
+		URL:    "https://app.example.com/login",
+		Fields: map[string]string{"username": "auditor", "password": "hunter22"},
+	}}
+	summary := cfg.Summary()
+	if strings.Contains(summary, "hunter22") || strings.Contains(summary, "auditor") {
+		t.Fatalf("summary leaks credentials: %s", summary)
+	}
+	if !strings.Contains(summary, "password") {
+		t.Fatalf("summary should list field names: %s", summary)
+	}
+
+	secrets := cfg.Secrets()
+	if len(secrets) != 2 || secrets[0] != "hunter22" {
+		t.Fatalf("expected secrets sorted longest first, got %v", secrets)
+	}
+}
+
+func TestConfigResolveSecrets(t *testing.T) {
+	cfg := &Config{Type: TypeForm, Form: &FormLogin{
+		URL:          "https://app.example.com/login",
+		Fields:       map[string]string{"username": "auditor"},
+		FieldSecrets: map[string]string{"password": "app_password"},
+	}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("a form login with a secret field should be valid: %v", err)
+	}
+	if !strings.Contains(cfg.Summary(), "password (secret app_password)") {
+		t.Errorf("summary should name the secret: %s", cfg.Summary())
+	}
+	if _, err := Establish(context.Background(), cfg, nil, nil); err == nil || !strings.Contains(err.Error(), "app_password") {
+		t.Errorf("Establish should refuse unresolved secrets, got %v", err)
+	}
+
+	lookup := func(ctx context.Context, name string) (string, error) {
+		if name == "app_password" {
+			return "hunter22", nil
+		}
+		return "", errors.New("not found")
+	}
+	resolved, err := cfg.ResolveSecrets(context.Background(), lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.Form.Fields["password"] != "hunter22" || resolved.Form.Fields["username"] != "auditor" || len(resolved.SecretNames()) != 0 {
+		t.Errorf("unexpected resolved form: %+v", resolved.Form)
+	}
+	if _, ok := cfg.Form.Fields["password"]; ok {
+		t.Error("ResolveSecrets must not modify the stored config")
+	}
+
+	bad := &Config{Type: TypeBearer, TokenSecret: "missing"}
+	if _, err := bad.ResolveSecrets(context.Background(), lookup); err == nil || !strings.Contains(err.Error(), "bearer token") {
+		t.Errorf("expected a bearer token lookup error, got %v", err)
+	}
+}
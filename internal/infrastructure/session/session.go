@@ -0,0 +1,326 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Redacted replaces credential values in captured evidence.
+const Redacted = "[REDACTED]"
+
+const maxLoginBodyBytes = 512 * 1024
+
+// Session attaches credentials to requests for in-scope hosts.
+type Session struct {
+	cfg     *Config
+	jar     http.CookieJar
+	hosts   map[string]struct{}
+	secrets []string
+	cookies map[string]struct{} // names of session cookies, redacted from Set-Cookie
+
+	// Set on sessions built by Route.
+	routed   bool
+	fallback *Session
+	profiles map[string]*Session
+	pick     func(*http.Request) (string, bool)
+}
+
+// Route returns a session that picks the credentials for each request:
+// pick names the request's profile, whose session from profiles is used (a
+// name missing from profiles, such as "none", sends no credentials); when
+// pick returns false the request gets fallback's credentials. Redaction
+// covers every session. fallback may be nil, and without profiles Route
+// returns fallback itself.
+func Route(fallback *Session, profiles map[string]*Session, pick func(*http.Request) (string, bool)) *Session {
+	if len(profiles) == 0 || pick == nil {
+		return fallback
+	}
+	return &Session{routed: true, fallback: fallback, profiles: profiles, pick: pick}
+}
+
+// all returns the sessions whose credentials s may send.
+func (s *Session) all() []*Session {
+	if !s.routed {
+		return []*Session{s}
+	}
+	names := make([]string, 0, len(s.profiles))
+	for name := range s.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	sessions := make([]*Session, 0, len(names)+1)
+	if s.fallback != nil {
+		sessions = append(sessions, s.fallback)
+	}
+	for _, name := range names {
+		if p := s.profiles[name]; p != nil {
+			sessions = append(sessions, p)
+		}
+	}
+	return sessions
+}
+
+// Establish builds a session from cfg. Credentials are only sent to hosts in
+// scopeHosts (all hosts when empty). Form logins are performed immediately
+// using client's transport and timeout and the session's own cookie jar.
+func Establish(ctx context.Context, cfg *Config, scopeHosts []string, client *http.Client) (*Session, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if names := cfg.SecretNames(); len(names) > 0 {
+		return nil, fmt.Errorf("secrets not resolved: %s", strings.Join(names, ", "))
+	}
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	s := &Session{
+		cfg:     cfg,
+		jar:     jar,
+		hosts:   make(map[string]struct{}, len(scopeHosts)),
+		secrets: cfg.Secrets(),
+		cookies: make(map[string]struct{}),
+	}
+	for _, h := range scopeHosts {
+		if h = strings.ToLower(strings.TrimSpace(h)); h != "" {
+			s.hosts[h] = struct{}{}
+		}
+	}
+	for name := range cfg.Cookies {
+		s.cookies[strings.ToLower(name)] = struct{}{}
+	}
+
+	if cfg.Type == TypeForm {
+		if err := s.login(ctx, client); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Type returns the authentication type of the session.
+func (s *Session) Type() string {
+	if s == nil {
+		return ""
+	}
+	if s.routed {
+		types := make([]string, 0, len(s.profiles)+1)
+		seen := make(map[string]bool)
+		for _, sess := range s.all() {
+			if t := sess.Type(); !seen[t] {
+				seen[t] = true
+				types = append(types, t)
+			}
+		}
+		return strings.Join(types, ", ")
+	}
+	return s.cfg.Type
+}
+
+// Decorate adds the session credentials to req when its host is in scope.
+// It satisfies checker.RequestDecorator.
+func (s *Session) Decorate(req *http.Request) {
+	if s == nil || req.URL == nil {
+		return
+	}
+	if s.routed {
+		if name, ok := s.pick(req); ok {
+			s.profiles[name].Decorate(req)
+		} else {
+			s.fallback.Decorate(req)
+		}
+		return
+	}
+	if !s.inScope(req.URL) {
+		return
+	}
+	switch s.cfg.Type {
+	case TypeBearer:
+		req.Header.Set("Authorization", "Bearer "+s.cfg.Token)
+	case TypeCookies:
+		for name, value := range s.cfg.Cookies {
+			req.AddCookie(&http.Cookie{Name: name, Value: value})
+		}
+	case TypeForm:
+		for _, c := range s.jar.Cookies(req.URL) {
+			req.AddCookie(c)
+		}
+	}
+}
+
+// RedactHeaders returns a copy of h with session cookies and credential values masked.
+func (s *Session) RedactHeaders(h http.Header) http.Header {
+	if s == nil {
+		return h
+	}
+	if s.routed {
+		for _, sess := range s.all() {
+			h = sess.RedactHeaders(h)
+		}
+		return h
+	}
+	out := make(http.Header, len(h))
+	for key, values := range h {
+		redacted := make([]string, len(values))
+		for i, v := range values {
+			switch {
+			case strings.EqualFold(key, "Authorization"):
+				v = Redacted
+			case strings.EqualFold(key, "Set-Cookie") || strings.EqualFold(key, "Cookie"):
+				v = s.redactCookieHeader(v)
+			}
+			redacted[i] = s.RedactString(v)
+		}
+		out[key] = redacted
+	}
+	return out
+}
+
+// RedactString masks every credential value occurring in text.
+func (s *Session) RedactString(text string) string {
+	if s == nil {
+		return text
+	}
+	if s.routed {
+		for _, sess := range s.all() {
+			text = sess.RedactString(text)
+		}
+		return text
+	}
+	for _, secret := range s.secrets {
+		text = strings.ReplaceAll(text, secret, Redacted)
+	}
+	return text
+}
+
+func (s *Session) redactCookieHeader(v string) string {
+	parts := strings.Split(v, ";")
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if name, _, ok := strings.Cut(part, "="); ok {
+			if _, sessionCookie := s.cookies[strings.ToLower(name)]; sessionCookie {
+				part = name + "=" + Redacted
+			}
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (s *Session) inScope(u *url.URL) bool {
+	if len(s.hosts) == 0 {
+		return true
+	}
+	_, ok := s.hosts[strings.ToLower(u.Hostname())]
+	return ok
+}
+
+func (s *Session) login(ctx context.Context, base *http.Client) error {
+	form := s.cfg.Form
+	loginURL, _ := url.Parse(form.URL)
+	if !s.inScope(loginURL) {
+		return fmt.Errorf("form login URL host %s is not in the engagement scope", loginURL.Hostname())
+	}
+
+	client := &http.Client{Jar: s.jar}
+	if base != nil {
+		client.Transport = base.Transport
+		client.Timeout = base.Timeout
+	}
+
+	values := url.Values{}
+	for name, value := range form.Fields {
+		values.Set(name, value)
+	}
+
+	if form.CSRFField != "" {
+		body, _, err := fetch(ctx, client, http.MethodGet, form.URL, nil)
+		if err != nil {
+			return fmt.Errorf("fetch login page: %w", err)
+		}
+		token := extractHiddenInput(body, form.CSRFField)
+		if token == "" {
+			return fmt.Errorf("login page has no %q field", form.CSRFField)
+		}
+		values.Set(form.CSRFField, token)
+	}
+
+	body, status, err := fetch(ctx, client, http.MethodPost, form.URL, values)
+	if err != nil {
+		return fmt.Errorf("submit login form: %w", err)
+	}
+	if status >= 400 {
+		return fmt.Errorf("login failed with status %d", status)
+	}
+	if form.SuccessText != "" && !strings.Contains(body, form.SuccessText) {
+		return fmt.Errorf("login response does not contain the expected success text")
+	}
+	if len(s.jar.Cookies(loginURL)) == 0 {
+		return fmt.Errorf("login did not set any session cookies")
+	}
+	for _, c := range s.jar.Cookies(loginURL) {
+		s.cookies[strings.ToLower(c.Name)] = struct{}{}
+		if len(c.Value) >= 4 {
+			s.secrets = append(s.secrets, c.Value)
+		}
+	}
+	sort.Slice(s.secrets, func(i, j int) bool { return len(s.secrets[i]) > len(s.secrets[j]) })
+	return nil
+}
+
+func fetch(ctx context.Context, client *http.Client, method, target string, form url.Values) (string, int, error) {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return "", 0, err
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxLoginBodyBytes))
+	if err != nil {
+		return "", resp.StatusCode, err
+	}
+	return string(data), resp.StatusCode, nil
+}
+
+var inputTagPattern = regexp.MustCompile(`(?is)<input\b[^>]*>`)
+
+func extractHiddenInput(page, name string) string {
+	for _, tag := range inputTagPattern.FindAllString(page, -1) {
+		if attr(tag, "name") == name {
+			return html.UnescapeString(attr(tag, "value"))
+		}
+	}
+	return ""
+}
+
+func attr(tag, name string) string {
+	re := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(name) + `\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+	m := re.FindStringSubmatch(tag)
+	if m == nil {
+		return ""
+	}
+	for _, v := range m[1:] {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
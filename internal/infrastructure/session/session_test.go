@@ -0,0 +1,161 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestDecorateBearerOnlyInScope(t *testing.T) {
+	sess, err := Establish(context.Background(), &Config{Type: TypeBearer, Token: "tok-123"}, []string{"app.example.com"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inScope, _ := http.NewRequest(http.MethodGet, "https://app.example.com/account", nil)
+	sess.Decorate(inScope)
+	if got := inScope.Header.Get("Authorization"); got != "Bearer tok-123" {
+		t.Fatalf("expected bearer token, got %q", got)
+	}
+
+	outOfScope, _ := http.NewRequest(http.MethodGet, "https://cdn.other.com/x.js", nil)
+	sess.Decorate(outOfScope)
+	if got := outOfScope.Header.Get("Authorization"); got != "" {
+		t.Fatalf("credentials leaked to out-of-scope host: %q", got)
+	}
+}
+
+func TestDecorateStaticCookies(t *testing.T) {
+	sess, err := Establish(context.Background(), &Config{Type: TypeCookies, Cookies: map[string]string{"sid": "abcd1234"}}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://app.example.com/", nil)
+	sess.Decorate(req)
+	if c, err := req.Cookie("sid"); err != nil || c.Value != "abcd1234" {
+		t.Fatalf("expected sid cookie, got %v (%v)", c, err)
+	}
+}
+
+func TestFormLoginWithCSRF(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, `<form><input type="hidden" name="csrf_token" value="csrf-xyz"><input name="username"></form>`)
+			return
+		}
+		_ = r.ParseForm()
+		if r.Form.Get("csrf_token") != "csrf-xyz" || r.Form.Get("password") != "hunter22" {
+			http.Error(w, "bad login", http.StatusUnauthorized)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "sess-value-42", Path: "/"})
+		fmt.Fprint(w, "Welcome back. Sign out")
+	})
+	mux.HandleFunc("/account", func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie("session"); err != nil || c.Value != "sess-value-42" {
+			http.Error(w, "login required", http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, "account page")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	host := mustHost(t, server.URL)
+	cfg := &Config{Type: TypeForm, Form: &FormLogin{
+		URL:         server.URL + "/login",
+		Fields:      map[string]string{"username": "auditor", "password": "hunter22"},
+		CSRFField:   "csrf_token",
+		SuccessText: "Sign out",
+	}}
+	sess, err := Establish(context.Background(), cfg, []string{host}, server.Client())
+	if err != nil {
+		t.Fatalf("Establish: %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/account", nil)
+	sess.Decorate(req)
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected authenticated request to succeed, got %d", resp.StatusCode)
+	}
+
+	headers := http.Header{"Set-Cookie": {"session=sess-value-42; Path=/; HttpOnly"}, "X-Debug": {"pw=hunter22"}}
+	redacted := sess.RedactHeaders(headers)
+	if strings.Contains(redacted.Get("Set-Cookie"), "sess-value-42") || !strings.Contains(redacted.Get("Set-Cookie"), "HttpOnly") {
+		t.Fatalf("unexpected redacted Set-Cookie %q", redacted.Get("Set-Cookie"))
+	}
+	if strings.Contains(redacted.Get("X-Debug"), "hunter22") {
+		t.Fatalf("password not redacted: %q", redacted.Get("X-Debug"))
+	}
+	if got := sess.RedactString("token sess-value-42 echoed"); strings.Contains(got, "sess-value-42") {
+		t.Fatalf("session cookie not redacted from body: %q", got)
+	}
+}
+
+func TestFormLoginFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "Invalid credentials")
+	}))
+	defer server.Close()
+
+	cfg := &Config{Type: TypeForm, Form: &FormLogin{URL: server.URL + "/login", Fields: map[string]string{"u": "a"}, SuccessText: "Sign out"}}
+	if _, err := Establish(context.Background(), cfg, nil, server.Client()); err == nil {
+		t.Fatal("expected missing success text to fail the login")
+	}
+
+	cfg.Form.SuccessText = ""
+	if _, err := Establish(context.Background(), cfg, nil, server.Client()); err == nil || !strings.Contains(err.Error(), "cookies") {
+		t.Fatalf("expected login without cookies to fail, got %v", err)
+	}
+
+	if _, err := Establish(context.Background(), cfg, []string{"other.example.com"}, server.Client()); err == nil || !strings.Contains(err.Error(), "scope") {
+		t.Fatalf("expected out-of-scope login URL to be rejected, got %v", err)
+	}
+}
+
+func mustHost(t *testing.T, raw string) string {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u.Hostname()
+}
+
+func TestRouteByProfile(t *testing.T) {
+	def, _ := Establish(context.Background(), &Config{Type: TypeBearer, Token: "user-token"}, nil, nil)
+	admin, _ := Establish(context.Background(), &Config{Type: TypeBearer, Token: "admin-token"}, nil, nil)
+	sess := Route(def, map[string]*Session{"admin": admin}, func(req *http.Request) (string, bool) {
+		switch req.URL.Path {
+		case "/admin":
+			return "admin", true
+		case "/public":
+			return "none", true
+		}
+		return "", false
+	})
+
+	for path, want := range map[string]string{"/admin": "Bearer admin-token", "/public": "", "/account": "Bearer user-token"} {
+		req, _ := http.NewRequest(http.MethodGet, "https://app.example.com"+path, nil)
+		sess.Decorate(req)
+		if got := req.Header.Get("Authorization"); got != want {
+			t.Errorf("%s: Authorization = %q, want %q", path, got, want)
+		}
+	}
+	if got := sess.RedactString("user-token admin-token"); strings.Contains(got, "token") {
+		t.Errorf("every profile's secrets should be redacted, got %q", got)
+	}
+	if Route(def, nil, nil) != def {
+		t.Error("Route without profiles should return the fallback session")
+	}
+}
@@ -0,0 +1,148 @@
+package session
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+)
+
+// KeySize is the length of the AES-256 key protecting stored credentials.
+const KeySize = 32
+
+const storeVersion = 1
+
+// sealedConfig is the on-disk format of an encrypted Config.
+type sealedConfig struct {
+	Version    int    `json:"version"`
+	Algorithm  string `json:"algorithm"`
+	Nonce      string `json:"nonce"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+// Save encrypts cfg with key (AES-256-GCM, bound to engagementID) and writes it to path.
+func Save(path, engagementID string, cfg *Config, key []byte, perm os.FileMode) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	plaintext, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	sealed := sealedConfig{
+		Version:    storeVersion,
+		Algorithm:  "AES-256-GCM",
+		Nonce:      base64.StdEncoding.EncodeToString(nonce),
+		Ciphertext: base64.StdEncoding.EncodeToString(gcm.Seal(nil, nonce, plaintext, []byte(engagementID))),
+	}
+	data, err := json.MarshalIndent(sealed, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFile(path, data, perm)
+}
+
+// Load decrypts the config stored at path. It returns nil, nil when no config exists.
+func Load(path, engagementID string, key []byte) (*Config, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is resolved within the engagement results directory.
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var sealed sealedConfig
+	if err := json.Unmarshal(data, &sealed); err != nil {
+		return nil, fmt.Errorf("parse stored credentials: %w", err)
+	}
+	if sealed.Version != storeVersion {
+		return nil, fmt.Errorf("unsupported credential store version %d", sealed.Version)
+	}
+	nonce, err := base64.StdEncoding.DecodeString(sealed.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("decode nonce: %w", err)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(sealed.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("decode ciphertext: %w", err)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, errors.New("invalid nonce length")
+	}
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, []byte(engagementID))
+	if err != nil {
+		return nil, errors.New("decrypt stored credentials: wrong key or tampered file")
+	}
+	var cfg Config
+	if err := json.Unmarshal(plaintext, &cfg); err != nil {
+		return nil, fmt.Errorf("parse decrypted credentials: %w", err)
+	}
+	return &cfg, nil
+}
+
+// LoadOrCreateKey returns the key from envValue (base64) when set, otherwise
+// reads the key file at path, generating it with 0600 permissions on first use.
+func LoadOrCreateKey(path, envValue string) ([]byte, error) {
+	if envValue = strings.TrimSpace(envValue); envValue != "" {
+		key, err := base64.StdEncoding.DecodeString(envValue)
+		if err != nil || len(key) != KeySize {
+			return nil, fmt.Errorf("credential key must be %d bytes, base64-encoded", KeySize)
+		}
+		return key, nil
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 -- key path is derived from the data directory.
+	if err == nil {
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil || len(key) != KeySize {
+			return nil, fmt.Errorf("credential key file %s is corrupt", path)
+		}
+		return key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	if err := fileutil.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0o600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("credential key must be %d bytes", KeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,92 @@
+package session
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testKey() []byte {
+	return bytes.Repeat([]byte{7}, KeySize)
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth.enc")
+	cfg := &Config{Type: TypeBearer, Token: "super-secret-token"}
+
+	if err := Save(path, "eng-1", cfg, testKey(), 0o600); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(raw, []byte("super-secret-token")) {
+		t.Fatal("credentials stored in plaintext")
+	}
+
+	loaded, err := Load(path, "eng-1", testKey())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if loaded.Type != TypeBearer || loaded.Token != "super-secret-token" {
+		t.Fatalf("unexpected config %+v", loaded)
+	}
+}
+
+func TestLoadRejectsWrongKeyOrEngagement(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth.enc")
+	if err := Save(path, "eng-1", &Config{Type: TypeBearer, Token: "token"}, testKey(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path, "eng-2", testKey()); err == nil {
+		t.Fatal("expected a file copied to another engagement to fail to decrypt")
+	}
+	if _, err := Load(path, "eng-1", bytes.Repeat([]byte{8}, KeySize)); err == nil {
+		t.Fatal("expected wrong key to fail")
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "missing.enc"), "eng-1", testKey())
+	if err != nil || cfg != nil {
+		t.Fatalf("expected nil, nil for missing file, got %v, %v", cfg, err)
+	}
+}
+
+func TestLoadOrCreateKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys", "auth.key")
+
+	first, err := LoadOrCreateKey(path, "")
+	if err != nil {
+		t.Fatalf("LoadOrCreateKey: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected 0600 key file, got %v", info.Mode().Perm())
+	}
+
+	second, err := LoadOrCreateKey(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Fatal("expected the stored key to be reused")
+	}
+
+	envKey := base64.StdEncoding.EncodeToString(testKey())
+	fromEnv, err := LoadOrCreateKey(path, envKey)
+	if err != nil || !bytes.Equal(fromEnv, testKey()) {
+		t.Fatalf("expected env key to take precedence, got %v", err)
+	}
+	if _, err := LoadOrCreateKey(path, "c2hvcnQ="); err == nil {
+		t.Fatal("expected short env key to be rejected")
+	}
+}
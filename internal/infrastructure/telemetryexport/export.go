@@ -0,0 +1,115 @@
+// Package telemetryexport pushes check run telemetry to external monitoring
+// systems so recurring scans can be observed beyond the local telemetry.jsonl
+// history.
+//
+// Two destinations are supported, both spoken over plain HTTP without extra
+// dependencies: a Prometheus Pushgateway (text exposition format) and an
+// OpenTelemetry collector (OTLP/HTTP with JSON encoding). Each run is exported
+// as a set of gauges plus one span per target check under a run span.
+package telemetryexport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Check is the telemetry of a single target check within a run.
+type Check struct {
+	Target    string
+	Status    string // "ok", "warning", "error", "blocked", "unreachable", or "skipped"
+	Error     string
+	StartedAt time.Time
+	Duration  time.Duration
+	Findings  int
+}
+
+// Succeeded reports whether the target's checks ran.
+func (c Check) Succeeded() bool {
+	return c.Status == "ok" || c.Status == "warning"
+}
+
+// Run is the telemetry of one check run.
+type Run struct {
+	EngagementID string
+	Command      string // checker name, e.g. "http" or "dns"
+	StartedAt    time.Time
+	Duration     time.Duration
+	Targets      int // Skipped targets included
+	Success      int
+	Errors       int // Blocked and unreachable targets included
+	Findings     int
+	Checks       []Check
+}
+
+// SuccessRatio returns the fraction of successful checks (0..1). Skipped
+// targets were never started and are left out.
+func (r Run) SuccessRatio() float64 {
+	if r.Success+r.Errors == 0 {
+		return 0
+	}
+	return float64(r.Success) / float64(r.Success+r.Errors)
+}
+
+// Config selects the destinations a run is exported to. Empty endpoints are
+// skipped.
+type Config struct {
+	PushgatewayURL string
+	PushgatewayJob string // defaults to "seca"
+	OTLPEndpoint   string // collector base URL, e.g. http://localhost:4318
+	OTLPHeaders    map[string]string
+	Timeout        time.Duration
+}
+
+const (
+	defaultJob     = "seca"
+	defaultTimeout = 10 * time.Second
+)
+
+// Enabled reports whether any destination is configured.
+func (c Config) Enabled() bool {
+	return strings.TrimSpace(c.PushgatewayURL) != "" || strings.TrimSpace(c.OTLPEndpoint) != ""
+}
+
+// Export sends run to every configured destination. A failing destination
+// does not stop the others; their errors are joined.
+func Export(ctx context.Context, cfg Config, run Run) error {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	client := &http.Client{Timeout: timeout}
+
+	var errs []error
+	if url := strings.TrimSpace(cfg.PushgatewayURL); url != "" {
+		job := strings.TrimSpace(cfg.PushgatewayJob)
+		if job == "" {
+			job = defaultJob
+		}
+		if err := PushGateway(ctx, client, url, job, run); err != nil {
+			errs = append(errs, fmt.Errorf("pushgateway: %w", err))
+		}
+	}
+	if endpoint := strings.TrimSpace(cfg.OTLPEndpoint); endpoint != "" {
+		if err := ExportOTLP(ctx, client, endpoint, cfg.OTLPHeaders, run); err != nil {
+			errs = append(errs, fmt.Errorf("otlp: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkStatus sends req and turns non-2xx responses into errors.
+func checkStatus(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s %s: unexpected status %s", req.Method, req.URL.Redacted(), resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package telemetryexport
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func sampleRun() Run {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	return Run{
+		EngagementID: "eng-1",
+		Command:      "http",
+		StartedAt:    start,
+		Duration:     3 * time.Second,
+		Targets:      2,
+		Success:      1,
+		Errors:       1,
+		Findings:     4,
+		Checks: []Check{
+			{Target: "https://example.com/", Status: "ok", StartedAt: start, Duration: 250 * time.Millisecond, Findings: 4},
+			{Target: `https://bad"host/`, Status: "error", Error: "timeout", StartedAt: start, Duration: time.Second},
+		},
+	}
+}
+
+type recordedRequest struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   string
+}
+
+func recordingServer(t *testing.T) (*httptest.Server, func() []recordedRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var reqs []recordedRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		reqs = append(reqs, recordedRequest{Method: r.Method, Path: r.URL.EscapedPath(), Header: r.Header.Clone(), Body: string(body)})
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []recordedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]recordedRequest(nil), reqs...)
+	}
+}
+
+func TestPushGatewayReplacesGroup(t *testing.T) {
+	srv, requests := recordingServer(t)
+	if err := PushGateway(context.Background(), srv.Client(), srv.URL+"/", "seca", sampleRun()); err != nil {
+		t.Fatalf("PushGateway: %v", err)
+	}
+
+	reqs := requests()
+	if len(reqs) != 1 {
+		t.Fatalf("expected one request, got %d", len(reqs))
+	}
+	req := reqs[0]
+	if req.Method != http.MethodPut {
+		t.Errorf("expected PUT, got %s", req.Method)
+	}
+	if want := "/metrics/job/seca/engagement_id/eng-1/command/http"; req.Path != want {
+		t.Errorf("expected path %s, got %s", want, req.Path)
+	}
+	for _, line := range []string{
+		"seca_run_duration_seconds 3",
+		"seca_run_success_ratio 0.5",
+		"seca_run_findings 4",
+		`seca_check_up{target="https://example.com/"} 1`,
+		`seca_check_up{target="https://bad\"host/"} 0`,
+		`seca_check_duration_seconds{target="https://example.com/"} 0.25`,
+	} {
+		if !strings.Contains(req.Body, line+"\n") {
+			t.Errorf("expected metric line %q in:\n%s", line, req.Body)
+		}
+	}
+}
+
+func TestGroupingSegmentEncodesSlashes(t *testing.T) {
+	if got := groupingSegment("engagement_id", "team/eng"); got != "/engagement_id@base64/dGVhbS9lbmc" {
+		t.Errorf("unexpected segment %q", got)
+	}
+	if got := groupingSegment("command", ""); got != "/command@base64/=" {
+		t.Errorf("unexpected segment %q", got)
+	}
+}
+
+func TestExportOTLPSendsMetricsAndSpans(t *testing.T) {
+	srv, requests := recordingServer(t)
+	headers := map[string]string{"Authorization": "Bearer secret"}
+	if err := ExportOTLP(context.Background(), srv.Client(), srv.URL, headers, sampleRun()); err != nil {
+		t.Fatalf("ExportOTLP: %v", err)
+	}
+
+	reqs := requests()
+	if len(reqs) != 2 || reqs[0].Path != "/v1/metrics" || reqs[1].Path != "/v1/traces" {
+		t.Fatalf("expected metrics then traces requests, got %+v", reqs)
+	}
+	for _, req := range reqs {
+		if req.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("%s: configured header not sent", req.Path)
+		}
+	}
+
+	var metrics otlpMetricsRequest
+	if err := json.Unmarshal([]byte(reqs[0].Body), &metrics); err != nil {
+		t.Fatal(err)
+	}
+	names := map[string]float64{}
+	for _, m := range metrics.ResourceMetrics[0].ScopeMetrics[0].Metrics {
+		names[m.Name] = m.Gauge.DataPoints[0].AsDouble
+	}
+	if names["seca.run.duration"] != 3 || names["seca.run.findings"] != 4 {
+		t.Errorf("unexpected metric values %v", names)
+	}
+
+	var traces otlpTracesRequest
+	if err := json.Unmarshal([]byte(reqs[1].Body), &traces); err != nil {
+		t.Fatal(err)
+	}
+	spans := traces.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 3 {
+		t.Fatalf("expected run span and two check spans, got %d", len(spans))
+	}
+	root := spans[0]
+	if len(root.TraceID) != 32 || len(root.SpanID) != 16 || root.ParentSpanID != "" {
+		t.Errorf("unexpected run span ids %+v", root)
+	}
+	for _, span := range spans[1:] {
+		if span.TraceID != root.TraceID || span.ParentSpanID != root.SpanID {
+			t.Errorf("check span %q is not a child of the run span", span.Name)
+		}
+	}
+	if spans[2].Status.Code != spanStatusError || spans[2].Status.Message != "timeout" {
+		t.Errorf("expected failed check span to carry the error, got %+v", spans[2].Status)
+	}
+}
+
+func TestExportJoinsDestinationErrors(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusBadRequest)
+	}))
+	defer failing.Close()
+	ok, requests := recordingServer(t)
+
+	err := Export(context.Background(), Config{PushgatewayURL: failing.URL, OTLPEndpoint: ok.URL}, sampleRun())
+	if err == nil || !strings.Contains(err.Error(), "pushgateway") {
+		t.Fatalf("expected pushgateway error, got %v", err)
+	}
+	if len(requests()) != 2 {
+		t.Fatalf("expected OTLP export to run despite the pushgateway failure")
+	}
+}
@@ -0,0 +1,241 @@
+package telemetryexport
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	scopeName = "seca-cli"
+
+	// OTLP span status codes.
+	spanStatusOK    = 1
+	spanStatusError = 2
+
+	// spanKindInternal marks spans that are not RPC client or server calls.
+	spanKindInternal = 1
+)
+
+// ExportOTLP sends run to an OpenTelemetry collector over OTLP/HTTP using the
+// JSON encoding: run-level gauges to /v1/metrics, and a run span with one
+// child span per target check to /v1/traces.
+func ExportOTLP(ctx context.Context, client *http.Client, endpoint string, headers map[string]string, run Run) error {
+	base := strings.TrimRight(endpoint, "/")
+	if err := postOTLP(ctx, client, base+"/v1/metrics", headers, otlpMetrics(run)); err != nil {
+		return err
+	}
+	return postOTLP(ctx, client, base+"/v1/traces", headers, otlpTraces(run))
+}
+
+func postOTLP(ctx context.Context, client *http.Client, url string, headers map[string]string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	return checkStatus(client, req)
+}
+
+// The types below mirror the parts of the OTLP JSON protobuf mapping that
+// seca-cli emits. 64-bit integers are encoded as strings, as the mapping
+// requires.
+
+type otlpAnyValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpDataPoint struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	AsDouble     float64        `json:"asDouble"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpMetric struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Unit        string `json:"unit,omitempty"`
+	Gauge       struct {
+		DataPoints []otlpDataPoint `json:"dataPoints"`
+	} `json:"gauge"`
+}
+
+type otlpScopeMetrics struct {
+	Scope   otlpScope    `json:"scope"`
+	Metrics []otlpMetric `json:"metrics"`
+}
+
+type otlpResourceMetrics struct {
+	Resource     otlpResource       `json:"resource"`
+	ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+}
+
+type otlpMetricsRequest struct {
+	ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpTracesRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+func stringAttr(key, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{StringValue: &value}}
+}
+
+func intAttr(key string, value int) otlpKeyValue {
+	s := strconv.Itoa(value)
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{IntValue: &s}}
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func runResource(run Run) otlpResource {
+	return otlpResource{Attributes: []otlpKeyValue{
+		stringAttr("service.name", scopeName),
+		stringAttr("seca.engagement_id", run.EngagementID),
+	}}
+}
+
+func otlpMetrics(run Run) otlpMetricsRequest {
+	at := unixNano(run.StartedAt.Add(run.Duration))
+	attrs := []otlpKeyValue{stringAttr("seca.command", run.Command)}
+	gauge := func(name, description, unit string, value float64) otlpMetric {
+		m := otlpMetric{Name: name, Description: description, Unit: unit}
+		m.Gauge.DataPoints = []otlpDataPoint{{TimeUnixNano: at, AsDouble: value, Attributes: attrs}}
+		return m
+	}
+
+	metrics := []otlpMetric{
+		gauge("seca.run.duration", "Wall-clock duration of the check run.", "s", run.Duration.Seconds()),
+		gauge("seca.run.targets", "Targets checked in the run.", "{target}", float64(run.Targets)),
+		gauge("seca.run.success", "Successful target checks in the run.", "{check}", float64(run.Success)),
+		gauge("seca.run.errors", "Failed target checks in the run.", "{check}", float64(run.Errors)),
+		gauge("seca.run.success_ratio", "Fraction of successful target checks in the run.", "1", run.SuccessRatio()),
+		gauge("seca.run.findings", "Findings reported by the run.", "{finding}", float64(run.Findings)),
+	}
+	return otlpMetricsRequest{ResourceMetrics: []otlpResourceMetrics{{
+		Resource:     runResource(run),
+		ScopeMetrics: []otlpScopeMetrics{{Scope: otlpScope{Name: scopeName}, Metrics: metrics}},
+	}}}
+}
+
+func otlpTraces(run Run) otlpTracesRequest {
+	traceID := randomHex(16)
+	runSpanID := randomHex(8)
+
+	runStatus := otlpStatus{Code: spanStatusOK}
+	if run.Errors > 0 {
+		runStatus = otlpStatus{Code: spanStatusError, Message: strconv.Itoa(run.Errors) + " target check(s) failed"}
+	}
+	spans := make([]otlpSpan, 0, len(run.Checks)+1)
+	spans = append(spans, otlpSpan{
+		TraceID:           traceID,
+		SpanID:            runSpanID,
+		Name:              "seca check " + run.Command,
+		Kind:              spanKindInternal,
+		StartTimeUnixNano: unixNano(run.StartedAt),
+		EndTimeUnixNano:   unixNano(run.StartedAt.Add(run.Duration)),
+		Attributes: []otlpKeyValue{
+			stringAttr("seca.command", run.Command),
+			intAttr("seca.targets", run.Targets),
+			intAttr("seca.success", run.Success),
+			intAttr("seca.errors", run.Errors),
+			intAttr("seca.findings", run.Findings),
+		},
+		Status: runStatus,
+	})
+
+	for _, c := range run.Checks {
+		start := c.StartedAt
+		if start.IsZero() {
+			start = run.StartedAt
+		}
+		status := otlpStatus{Code: spanStatusOK}
+		if !c.Succeeded() {
+			status = otlpStatus{Code: spanStatusError, Message: c.Error}
+		}
+		spans = append(spans, otlpSpan{
+			TraceID:           traceID,
+			SpanID:            randomHex(8),
+			ParentSpanID:      runSpanID,
+			Name:              "check " + c.Target,
+			Kind:              spanKindInternal,
+			StartTimeUnixNano: unixNano(start),
+			EndTimeUnixNano:   unixNano(start.Add(c.Duration)),
+			Attributes: []otlpKeyValue{
+				stringAttr("seca.target", c.Target),
+				stringAttr("seca.status", c.Status),
+				intAttr("seca.findings", c.Findings),
+			},
+			Status: status,
+		})
+	}
+
+	return otlpTracesRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource:   runResource(run),
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: scopeName}, Spans: spans}},
+	}}}
+}
+
+// randomHex returns n random bytes hex-encoded, for trace and span IDs.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
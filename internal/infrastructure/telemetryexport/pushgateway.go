@@ -0,0 +1,105 @@
+package telemetryexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PushGateway replaces the metrics of run's group on a Prometheus
+// Pushgateway. The group is keyed by job, engagement and command, so each
+// recurring scan keeps one up-to-date series set and targets that drop out of
+// scope disappear with the next push.
+func PushGateway(ctx context.Context, client *http.Client, baseURL, job string, run Run) error {
+	endpoint := strings.TrimRight(baseURL, "/") + "/metrics" +
+		groupingSegment("job", job) +
+		groupingSegment("engagement_id", run.EngagementID) +
+		groupingSegment("command", run.Command)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, bytes.NewReader(PrometheusText(run)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+	return checkStatus(client, req)
+}
+
+// groupingSegment encodes one label of the Pushgateway grouping key. Values
+// that are empty or contain a slash use the base64 form the Pushgateway
+// defines for them.
+func groupingSegment(name, value string) string {
+	if value == "" || strings.Contains(value, "/") {
+		return "/" + name + "@base64/" + encodeGroupingValue(value)
+	}
+	return "/" + name + "/" + url.PathEscape(value)
+}
+
+func encodeGroupingValue(value string) string {
+	if value == "" {
+		// The Pushgateway spells the empty value as a single "=".
+		return "="
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// PrometheusText renders run in the Prometheus text exposition format.
+func PrometheusText(run Run) []byte {
+	var b bytes.Buffer
+	gauge := func(name, help string, value float64) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n%s %s\n", name, help, name, name, formatFloat(value))
+	}
+
+	gauge("seca_run_duration_seconds", "Wall-clock duration of the last check run.", run.Duration.Seconds())
+	gauge("seca_run_targets", "Targets checked in the last run.", float64(run.Targets))
+	gauge("seca_run_success", "Successful target checks in the last run.", float64(run.Success))
+	gauge("seca_run_errors", "Failed target checks in the last run.", float64(run.Errors))
+	gauge("seca_run_success_ratio", "Fraction of successful target checks in the last run.", run.SuccessRatio())
+	gauge("seca_run_findings", "Findings reported by the last run.", float64(run.Findings))
+	gauge("seca_run_last_timestamp_seconds", "Unix time the last run started.", float64(run.StartedAt.Unix()))
+
+	if len(run.Checks) == 0 {
+		return b.Bytes()
+	}
+	perTarget := []struct {
+		name, help string
+		value      func(Check) float64
+	}{
+		{"seca_check_duration_seconds", "Duration of the target check.", func(c Check) float64 { return c.Duration.Seconds() }},
+		{"seca_check_up", "1 when the target check succeeded, 0 otherwise.", func(c Check) float64 {
+			if c.Succeeded() {
+				return 1
+			}
+			return 0
+		}},
+		{"seca_check_findings", "Findings reported for the target.", func(c Check) float64 { return float64(c.Findings) }},
+	}
+	for _, metric := range perTarget {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", metric.name, metric.help, metric.name)
+		// Duplicate series are rejected by the Pushgateway; keep the first
+		// check of a target that was checked twice.
+		seen := make(map[string]bool, len(run.Checks))
+		for _, c := range run.Checks {
+			if seen[c.Target] {
+				continue
+			}
+			seen[c.Target] = true
+			fmt.Fprintf(&b, "%s{target=\"%s\"} %s\n", metric.name, escapeLabelValue(c.Target), formatFloat(metric.value(c)))
+		}
+	}
+	return b.Bytes()
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,429 @@
+// Package telemetrylog stores an engagement's telemetry history as JSONL
+// that rotates by size or age.
+//
+// Records are appended to the active file, e.g. telemetry.jsonl. Beside it,
+// telemetry.jsonl.idx holds the time the file was started and the byte
+// offset of every record, so the newest records can be read without
+// scanning the file. When the active file is due for rotation it is
+// compacted (blank and malformed lines dropped), gzipped to
+// telemetry-<UTC time>.jsonl.gz, and a new active file is started. Only the
+// newest Options.Retain rotated segments are kept.
+//
+// Readers tolerate a missing or stale index, such as one left by an older
+// release or an interrupted write, by scanning the active file instead.
+package telemetrylog
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/khanhnv2901/seca-cli/internal/shared/fileutil"
+)
+
+// IndexSuffix is appended to the active file's path to name its index.
+const IndexSuffix = ".idx"
+
+// Default rotation settings.
+const (
+	DefaultMaxBytes = 10 << 20
+	DefaultRetain   = 5
+)
+
+const segmentTimeFormat = "20060102T150405.000Z"
+
+// Options control rotation. Zero MaxBytes or MaxAge disables that trigger.
+type Options struct {
+	MaxBytes int64         // Rotate once the active file reaches this size
+	MaxAge   time.Duration // Rotate once the active file was started this long ago
+	Retain   int           // Rotated segments to keep; older ones are deleted
+	Perm     os.FileMode   // Permissions of new files (default 0600)
+}
+
+// Log is the telemetry history at one path.
+type Log struct {
+	path string
+	opts Options
+	now  func() time.Time
+}
+
+// Open returns the log whose active file is path. Nothing is read or
+// created until the first call.
+func Open(path string, opts Options) *Log {
+	if opts.Perm == 0 {
+		opts.Perm = 0o600
+	}
+	return &Log{path: path, opts: opts, now: time.Now}
+}
+
+// index is the decoded contents of the index file.
+type index struct {
+	started time.Time
+	offsets []int64
+}
+
+// Append writes record as one line, rotating the active file first if it
+// is due.
+func (l *Log) Append(record []byte) error {
+	if bytes.ContainsAny(record, "\r\n") {
+		return errors.New("telemetry record must be a single line")
+	}
+	if err := l.rotateIfDue(); err != nil {
+		return err
+	}
+
+	size, err := fileSize(l.path)
+	if err != nil {
+		return err
+	}
+	idx, ok := l.readIndex(size)
+	if !ok {
+		if idx, err = l.rebuildIndex(); err != nil {
+			return err
+		}
+		if err := l.writeIndex(idx); err != nil {
+			return err
+		}
+	}
+
+	// Terminate a line left unfinished by an interrupted write, so the
+	// record starts a line of its own
+	line := append(append(make([]byte, 0, len(record)+2), record...), '\n')
+	if size > 0 {
+		terminated, err := endsWithNewline(l.path, size)
+		if err != nil {
+			return err
+		}
+		if !terminated {
+			line = append([]byte{'\n'}, line...)
+			size++
+		}
+	}
+	if err := fileutil.Append(l.path, line, l.opts.Perm); err != nil {
+		return err
+	}
+	var entry [8]byte
+	binary.LittleEndian.PutUint64(entry[:], uint64(size))
+	return fileutil.Append(l.path+IndexSuffix, entry[:], l.opts.Perm)
+}
+
+// Tail returns the last n records for which keep reports true, oldest
+// first. A nil keep accepts every record. Rotated segments are read only
+// when the active file holds fewer than n such records.
+func (l *Log) Tail(n int, keep func(record []byte) bool) ([][]byte, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	if keep == nil {
+		keep = func([]byte) bool { return true }
+	}
+
+	records, err := l.tailActive(n, keep)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) >= n {
+		return records[len(records)-n:], nil
+	}
+
+	segments, err := l.Segments()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(segments) - 1; i >= 0 && len(records) < n; i-- {
+		data, err := readSegment(segments[i])
+		if err != nil {
+			return nil, err
+		}
+		older := filterLines(data, keep)
+		records = append(older, records...)
+	}
+	if len(records) > n {
+		records = records[len(records)-n:]
+	}
+	return records, nil
+}
+
+// tailActive reads at least n kept records from the end of the active file
+// when it has them, using the index to skip the rest.
+func (l *Log) tailActive(n int, keep func([]byte) bool) ([][]byte, error) {
+	f, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	idx, ok := l.readIndex(info.Size())
+	if !ok {
+		data, err := io.ReadAll(f)
+		if err != nil {
+			return nil, err
+		}
+		return filterLines(data, keep), nil
+	}
+
+	// Widen the window until it holds n kept records or the whole file
+	for want := n; ; want *= 2 {
+		from := int64(0)
+		if want < len(idx.offsets) {
+			from = idx.offsets[len(idx.offsets)-want]
+		}
+		data := make([]byte, info.Size()-from)
+		if _, err := f.ReadAt(data, from); err != nil && err != io.EOF {
+			return nil, err
+		}
+		records := filterLines(data, keep)
+		if len(records) >= n || from == 0 {
+			return records, nil
+		}
+	}
+}
+
+// Rotate compacts and gzips the active file into a new segment and prunes
+// old segments. It does nothing when the active file is empty.
+func (l *Log) Rotate() error {
+	data, err := os.ReadFile(l.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return l.reset()
+	}
+
+	segment := l.segmentPath(l.now())
+	err = fileutil.WriteWith(segment, l.opts.Perm, func(w io.Writer) error {
+		zw := gzip.NewWriter(w)
+		for _, line := range filterLines(data, nil) {
+			if _, err := zw.Write(append(line, '\n')); err != nil {
+				return err
+			}
+		}
+		return zw.Close()
+	})
+	if err != nil {
+		return fmt.Errorf("compact %s: %w", l.path, err)
+	}
+	if err := l.reset(); err != nil {
+		return err
+	}
+	return l.prune()
+}
+
+// Segments returns the rotated segments, oldest first.
+func (l *Log) Segments() ([]string, error) {
+	matches, err := filepath.Glob(l.segmentPrefix() + "*.jsonl.gz")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+func (l *Log) rotateIfDue() error {
+	info, err := os.Stat(l.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	due := l.opts.MaxBytes > 0 && info.Size() >= l.opts.MaxBytes
+	if !due && l.opts.MaxAge > 0 {
+		started := info.ModTime()
+		if idx, ok := l.readIndex(info.Size()); ok {
+			started = idx.started
+		}
+		due = l.now().Sub(started) >= l.opts.MaxAge
+	}
+	if !due {
+		return nil
+	}
+	return l.Rotate()
+}
+
+func (l *Log) reset() error {
+	for _, path := range []string{l.path, l.path + IndexSuffix} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *Log) prune() error {
+	segments, err := l.Segments()
+	if err != nil {
+		return err
+	}
+	for len(segments) > max(l.opts.Retain, 0) {
+		if err := os.Remove(segments[0]); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		segments = segments[1:]
+	}
+	return nil
+}
+
+// segmentPrefix is the path of rotated segments up to their timestamp,
+// e.g. ".../telemetry-" for ".../telemetry.jsonl".
+func (l *Log) segmentPrefix() string {
+	return strings.TrimSuffix(l.path, filepath.Ext(l.path)) + "-"
+}
+
+func (l *Log) segmentPath(at time.Time) string {
+	base := l.segmentPrefix() + at.UTC().Format(segmentTimeFormat)
+	path := base + ".jsonl.gz"
+	for i := 1; fileExists(path); i++ {
+		path = fmt.Sprintf("%s.%d.jsonl.gz", base, i)
+	}
+	return path
+}
+
+// readIndex loads the index and reports whether it matches an active file
+// of the given size.
+func (l *Log) readIndex(size int64) (index, bool) {
+	data, err := os.ReadFile(l.path + IndexSuffix)
+	if err != nil || len(data) < 8 || len(data)%8 != 0 {
+		return index{}, false
+	}
+	idx := index{started: time.Unix(0, int64(binary.LittleEndian.Uint64(data)))}
+	last := int64(-1)
+	for i := 8; i < len(data); i += 8 {
+		offset := int64(binary.LittleEndian.Uint64(data[i:]))
+		if offset <= last || offset >= size {
+			return index{}, false
+		}
+		idx.offsets = append(idx.offsets, offset)
+		last = offset
+	}
+	if (len(idx.offsets) == 0) != (size == 0) {
+		return index{}, false
+	}
+	return idx, true
+}
+
+// rebuildIndex indexes the active file by scanning it. Its start time is
+// taken from the file's modification time, the best guess available.
+func (l *Log) rebuildIndex() (index, error) {
+	idx := index{started: l.now()}
+	f, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return idx, nil
+	}
+	if err != nil {
+		return index{}, err
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err == nil && info.Size() > 0 {
+		idx.started = info.ModTime()
+	}
+
+	r := bufio.NewReader(f)
+	var offset int64
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(line) > 0 {
+			idx.offsets = append(idx.offsets, offset)
+		}
+		offset += int64(len(line))
+		if err == io.EOF {
+			return idx, nil
+		}
+		if err != nil {
+			return index{}, err
+		}
+	}
+}
+
+func (l *Log) writeIndex(idx index) error {
+	buf := make([]byte, 8*(len(idx.offsets)+1))
+	binary.LittleEndian.PutUint64(buf, uint64(idx.started.UnixNano()))
+	for i, offset := range idx.offsets {
+		binary.LittleEndian.PutUint64(buf[8*(i+1):], uint64(offset))
+	}
+	return fileutil.WriteFile(l.path+IndexSuffix, buf, l.opts.Perm)
+}
+
+// filterLines splits JSONL data into records, skipping blank and malformed
+// lines and those keep rejects.
+func filterLines(data []byte, keep func([]byte) bool) [][]byte {
+	var records [][]byte
+	for _, line := range bytes.Split(data, []byte{'\n'}) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 || !json.Valid(line) {
+			continue
+		}
+		if keep != nil && !keep(line) {
+			continue
+		}
+		records = append(records, line)
+	}
+	return records
+}
+
+func readSegment(path string) ([]byte, error) {
+	f, err := os.Open(path) // #nosec G304 -- segments are found next to the active file.
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	defer zr.Close()
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return data, nil
+}
+
+func fileSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+func endsWithNewline(path string, size int64) (bool, error) {
+	f, err := os.Open(path) // #nosec G304 -- the active file of the log.
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	last := make([]byte, 1)
+	if _, err := f.ReadAt(last, size-1); err != nil {
+		return false, err
+	}
+	return last[0] == '\n', nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
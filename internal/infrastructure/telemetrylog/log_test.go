@@ -0,0 +1,147 @@
+package telemetrylog
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func record(i int) []byte {
+	return []byte(fmt.Sprintf(`{"n":%d}`, i))
+}
+
+func appendRecords(t *testing.T, l *Log, from, to int) {
+	t.Helper()
+	for i := from; i < to; i++ {
+		if err := l.Append(record(i)); err != nil {
+			t.Fatalf("Append(%d): %v", i, err)
+		}
+	}
+}
+
+func assertTail(t *testing.T, got [][]byte, from, to int) {
+	t.Helper()
+	if len(got) != to-from {
+		t.Fatalf("expected %d records, got %d: %q", to-from, len(got), got)
+	}
+	for i, rec := range got {
+		if !bytes.Equal(rec, record(from+i)) {
+			t.Fatalf("record %d: expected %s, got %s", i, record(from+i), rec)
+		}
+	}
+}
+
+func TestTailUsesIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.jsonl")
+	l := Open(path, Options{})
+	appendRecords(t, l, 0, 20)
+
+	got, err := l.Tail(3, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTail(t, got, 17, 20)
+
+	// Only every other record is kept: the window widens to find them
+	odd := func(rec []byte) bool { return bytes.ContainsAny(rec[len(rec)-2:len(rec)-1], "13579") }
+	got, err = l.Tail(4, odd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 4 || string(got[0]) != `{"n":13}` || string(got[3]) != `{"n":19}` {
+		t.Errorf("unexpected filtered tail: %q", got)
+	}
+}
+
+func TestTailWithoutIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.jsonl")
+	// A history written before the index existed, ending in a torn line
+	if err := os.WriteFile(path, []byte("{\"n\":0}\n\n{\"n\":1}\n{\"n\""), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	l := Open(path, Options{})
+	got, err := l.Tail(5, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTail(t, got, 0, 2)
+
+	// Appending indexes the file and starts the record on a new line
+	appendRecords(t, l, 2, 4)
+	if _, ok := l.readIndex(mustSize(t, path)); !ok {
+		t.Fatal("expected a valid index after appending")
+	}
+	got, err = l.Tail(3, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTail(t, got, 1, 4)
+}
+
+func TestRotateBySizeKeepsRetainedSegments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.jsonl")
+	l := Open(path, Options{MaxBytes: 40, Retain: 2})
+	now := time.Date(2025, 6, 4, 10, 0, 0, 0, time.UTC)
+	l.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	// Records are 8 or 9 bytes a line, so each segment holds five
+	appendRecords(t, l, 0, 22)
+
+	segments, err := l.Segments()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) != 2 {
+		t.Fatalf("expected 2 retained segments, got %v", segments)
+	}
+	got, err := l.Tail(12, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The oldest two segments (records 0-9) were pruned
+	assertTail(t, got, 10, 22)
+	got, err = l.Tail(100, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTail(t, got, 10, 22)
+}
+
+func TestRotateByAge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.jsonl")
+	l := Open(path, Options{MaxAge: 24 * time.Hour, Retain: 5})
+	now := time.Date(2025, 6, 4, 10, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	appendRecords(t, l, 0, 3)
+	now = now.Add(23 * time.Hour)
+	appendRecords(t, l, 3, 4)
+	if segments, _ := l.Segments(); len(segments) != 0 {
+		t.Fatalf("expected no rotation within a day, got %v", segments)
+	}
+	now = now.Add(time.Hour)
+	appendRecords(t, l, 4, 5)
+	segments, _ := l.Segments()
+	if len(segments) != 1 {
+		t.Fatalf("expected one segment after a day, got %v", segments)
+	}
+	got, err := l.Tail(2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTail(t, got, 3, 5)
+}
+
+func mustSize(t *testing.T, path string) int64 {
+	t.Helper()
+	size, err := fileSize(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return size
+}
@@ -18,3 +18,8 @@ const (
 	// TLSSoonExpiryWindow warns operators when a certificate expires inside this window.
 	TLSSoonExpiryWindow = 14 * 24 * time.Hour
 )
+
+// ResultsSchemaVersion is the layout version written to results files
+// (schema_version). Bump it and add a migration whenever a stored field is
+// renamed or moved.
+const ResultsSchemaVersion = 2
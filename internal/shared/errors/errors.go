@@ -15,6 +15,9 @@ var (
 	ErrTargetNotInScope        = errors.New("target not in engagement scope")
 	ErrDuplicateTarget         = errors.New("target already in scope")
 	ErrEngagementInactive      = errors.New("engagement is not active")
+	ErrEngagementExpired       = errors.New("engagement has expired and is read-only")
+	ErrEngagementClosed        = errors.New("engagement is closed and immutable")
+	ErrEngagementNotActive     = errors.New("engagement status does not allow checks")
 
 	// Check errors
 	ErrCheckRunNotFound     = errors.New("check run not found")
@@ -0,0 +1,99 @@
+// Package fileutil provides crash-safe file writes for results and audit data.
+//
+// Results, hashes and reports are rewritten in place between runs. Writing
+// them through a temporary file that is synced and then renamed over the
+// target means a crash or SIGKILL leaves either the old or the new content,
+// never a truncated file that breaks report generation or hash verification.
+package fileutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// WriteFile writes data to path atomically: readers see the previous
+// content until the new content is complete and on disk.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	return WriteWith(path, perm, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// WriteWith atomically replaces path with whatever write produces. The
+// temporary file lives next to path so the rename never crosses devices,
+// and it is removed when write or any later step fails.
+func WriteWith(path string, perm os.FileMode, write func(w io.Writer) error) (err error) {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	buf := bufio.NewWriter(tmp)
+	if err = write(buf); err != nil {
+		return err
+	}
+	if err = buf.Flush(); err != nil {
+		return err
+	}
+	if err = tmp.Chmod(perm); err != nil && runtime.GOOS != "windows" {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return fmt.Errorf("sync %s: %w", path, err)
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("replace %s: %w", path, err)
+	}
+	syncDir(dir)
+	return nil
+}
+
+// Append writes data to the end of path, creating it with perm when
+// missing, and syncs it so the appended record survives a crash. Callers
+// should pass whole records so an interrupted append can only lose the last
+// one.
+func Append(path string, data []byte, perm os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, perm) // #nosec G304 -- callers resolve paths under the results directory.
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("sync %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// syncDir flushes the directory entry so a completed rename survives a
+// power loss. Directories cannot be synced on Windows; errors are ignored
+// because the data itself is already on disk.
+func syncDir(dir string) {
+	if runtime.GOOS == "windows" {
+		return
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	_ = d.Sync()
+	_ = d.Close()
+}
@@ -0,0 +1,80 @@
+package fileutil
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestWriteFile_ReplacesContent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	if err := os.WriteFile(path, []byte(`{"old":true}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteFile(path, []byte(`{"new":true}`), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != `{"new":true}` {
+		t.Fatalf("unexpected content %q (%v)", data, err)
+	}
+	if runtime.GOOS != "windows" {
+		if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+			t.Errorf("expected mode 0600, got %v", info.Mode().Perm())
+		}
+	}
+	assertNoTempFiles(t, filepath.Dir(path))
+}
+
+func TestWriteWith_FailureKeepsOriginal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.csv")
+	if err := os.WriteFile(path, []byte("header\nrow\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	boom := errors.New("boom")
+	err := WriteWith(path, 0o644, func(w io.Writer) error {
+		_, _ = w.Write([]byte("header\n"))
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected the writer's error, got %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "header\nrow\n" {
+		t.Fatalf("original content was modified: %q", data)
+	}
+	assertNoTempFiles(t, filepath.Dir(path))
+}
+
+func TestAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.jsonl")
+	for _, line := range []string{"a\n", "b\n"} {
+		if err := Append(path, []byte(line), 0o644); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "a\nb\n" {
+		t.Fatalf("unexpected content %q", data)
+	}
+}
+
+func assertNoTempFiles(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		t.Fatalf("expected only the target file, found %v", names)
+	}
+}
@@ -0,0 +1,186 @@
+// Package i18n translates report output.
+//
+// Catalogs are embedded JSON files, one per language, holding three tables:
+// messages (headings and labels, keyed by a dotted ID), findings (remediation
+// text keyed by the finding name), and recommendations (the built-in header
+// recommendation strings, keyed by their English text). Every lookup falls
+// back to English, so a catalog may be partial and a missing translation never
+// drops content from a report.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultLanguage is the language of the source strings.
+const DefaultLanguage = "en"
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// Catalog holds the translations for one language.
+type Catalog struct {
+	lang            string
+	messages        map[string]string
+	findings        map[string]string
+	recommendations map[string]string
+	fallback        *Catalog
+}
+
+type catalogFile struct {
+	Messages        map[string]string `json:"messages"`
+	Findings        map[string]string `json:"findings"`
+	Recommendations map[string]string `json:"recommendations"`
+}
+
+var (
+	loadOnce sync.Once
+	catalogs map[string]*Catalog
+	loadErr  error
+)
+
+func loadCatalogs() {
+	entries, err := localeFS.ReadDir("locales")
+	if err != nil {
+		loadErr = err
+		return
+	}
+	catalogs = make(map[string]*Catalog, len(entries))
+	for _, entry := range entries {
+		lang := strings.TrimSuffix(entry.Name(), ".json")
+		data, err := localeFS.ReadFile("locales/" + entry.Name())
+		if err != nil {
+			loadErr = err
+			return
+		}
+		var file catalogFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			loadErr = fmt.Errorf("parse locale %s: %w", entry.Name(), err)
+			return
+		}
+		catalogs[lang] = &Catalog{
+			lang:            lang,
+			messages:        file.Messages,
+			findings:        file.Findings,
+			recommendations: file.Recommendations,
+		}
+	}
+	base, ok := catalogs[DefaultLanguage]
+	if !ok {
+		loadErr = fmt.Errorf("missing %s locale", DefaultLanguage)
+		return
+	}
+	for lang, c := range catalogs {
+		if lang != DefaultLanguage {
+			c.fallback = base
+		}
+	}
+}
+
+// Languages returns the supported language codes, sorted.
+func Languages() []string {
+	loadOnce.Do(loadCatalogs)
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Load returns the catalog for lang (e.g. "ja"). An empty lang selects
+// English.
+func Load(lang string) (*Catalog, error) {
+	loadOnce.Do(loadCatalogs)
+	if loadErr != nil {
+		return nil, loadErr
+	}
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if lang == "" {
+		lang = DefaultLanguage
+	}
+	c, ok := catalogs[lang]
+	if !ok {
+		return nil, fmt.Errorf("unsupported language %q (supported: %s)", lang, strings.Join(Languages(), ", "))
+	}
+	return c, nil
+}
+
+// Default returns the English catalog.
+func Default() *Catalog {
+	c, err := Load(DefaultLanguage)
+	if err != nil {
+		panic(err) // the embedded English catalog is part of the build
+	}
+	return c
+}
+
+// Language returns the catalog's language code.
+func (c *Catalog) Language() string {
+	return c.lang
+}
+
+// T returns the message for key, formatted with args when given. Unknown keys
+// are returned unchanged so gaps are visible rather than blank.
+func (c *Catalog) T(key string, args ...any) string {
+	msg, ok := c.lookup(key)
+	if !ok {
+		msg = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// Severity translates a severity label such as "High" or "critical". The
+// English catalog has no severity entries, so English output keeps the
+// label as written.
+func (c *Catalog) Severity(severity string) string {
+	return c.label("severity.", severity)
+}
+
+// Status translates a finding status such as "Passed" or "Failed".
+func (c *Catalog) Status(status string) string {
+	return c.label("status.", status)
+}
+
+// FindingRecommendation returns the translated remediation for the named
+// finding, or fallback when the catalog has none.
+func (c *Catalog) FindingRecommendation(name, fallback string) string {
+	if msg, ok := c.findings[name]; ok {
+		return msg
+	}
+	return fallback
+}
+
+// Recommendation translates a built-in recommendation given in English,
+// returning it unchanged when the catalog has no translation.
+func (c *Catalog) Recommendation(text string) string {
+	if msg, ok := c.recommendations[text]; ok {
+		return msg
+	}
+	return text
+}
+
+func (c *Catalog) label(prefix, value string) string {
+	msg, ok := c.lookup(prefix + strings.ToLower(value))
+	if !ok {
+		return value
+	}
+	return msg
+}
+
+func (c *Catalog) lookup(key string) (string, bool) {
+	for cat := c; cat != nil; cat = cat.fallback {
+		if msg, ok := cat.messages[key]; ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
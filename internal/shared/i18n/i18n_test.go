@@ -0,0 +1,69 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	for _, lang := range []string{"", "en", "ja", "KO", " vi "} {
+		if _, err := Load(lang); err != nil {
+			t.Errorf("Load(%q) error = %v", lang, err)
+		}
+	}
+	if _, err := Load("xx"); err == nil || !strings.Contains(err.Error(), "en, ja, ko, vi") {
+		t.Fatalf("Load(xx) error = %v, want unsupported language listing the choices", err)
+	}
+}
+
+func TestCatalogsCoverEnglishMessages(t *testing.T) {
+	en, _ := Load(DefaultLanguage)
+	for _, lang := range Languages() {
+		c, _ := Load(lang)
+		for key, msg := range en.messages {
+			translated, ok := c.messages[key]
+			if !ok {
+				t.Errorf("%s: missing message %q", lang, key)
+				continue
+			}
+			if strings.Count(translated, "%s") != strings.Count(msg, "%s") {
+				t.Errorf("%s: message %q has different format verbs than English", lang, key)
+			}
+		}
+		if lang == DefaultLanguage {
+			continue
+		}
+		for _, key := range []string{"severity.critical", "severity.high", "severity.medium", "severity.low", "severity.info"} {
+			if _, ok := c.messages[key]; !ok {
+				t.Errorf("%s: missing %q", lang, key)
+			}
+		}
+	}
+}
+
+func TestCatalogFallsBack(t *testing.T) {
+	ja, _ := Load("ja")
+	if got := ja.T("section.summary"); got != "概要" {
+		t.Fatalf("T(section.summary) = %q", got)
+	}
+	if got := ja.T("report.footer", "2026-01-02"); !strings.Contains(got, "2026-01-02") {
+		t.Fatalf("T(report.footer) = %q", got)
+	}
+	if got := ja.T("no.such.key"); got != "no.such.key" {
+		t.Fatalf("unknown key = %q", got)
+	}
+	if got := ja.Severity("HIGH"); got != "高" {
+		t.Fatalf("Severity(HIGH) = %q", got)
+	}
+	if got := ja.Recommendation("Add 'X-Content-Type-Options: nosniff'"); !strings.Contains(got, "してください") {
+		t.Fatalf("Recommendation() = %q", got)
+	}
+	if got := ja.FindingRecommendation("Unknown Finding", "fallback"); got != "fallback" {
+		t.Fatalf("FindingRecommendation() = %q", got)
+	}
+
+	en := Default()
+	if got := en.Severity("high"); got != "high" {
+		t.Fatalf("English severity should be kept as written, got %q", got)
+	}
+}
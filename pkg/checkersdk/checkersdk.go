@@ -0,0 +1,95 @@
+// Package checkersdk is the public API for writing checkers that are compiled
+// into a custom seca-cli build.
+//
+// A checker implements Checker and is registered from an init function:
+//
+//	func init() {
+//		checkersdk.MustRegister(checkersdk.New("banner", func(ctx context.Context, target string) checkersdk.CheckResult {
+//			...
+//		}), checkersdk.WithDescription("Grab service banners"))
+//	}
+//
+// A custom binary blank-imports the checker package next to the seca-cli
+// command package; every registered checker becomes available as
+// "seca check <name>" and runs with the same engagement, ROE, audit, and
+// telemetry handling as the built-in checks.
+//
+// The types in this package are aliases of the ones used by seca-cli itself,
+// so results produced by SDK checkers are stored and reported exactly like
+// built-in results.
+package checkersdk
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/khanhnv2901/seca-cli/internal/infrastructure/checker"
+)
+
+// Result statuses understood by the runner, reports, and audit trail. Only
+// StatusOK and StatusWarning mean the checks ran. A checker returns one of
+// the first four; the runner sets StatusUnreachable and StatusSkipped for
+// targets it never handed to the checker.
+const (
+	StatusOK          = checker.StatusOK
+	StatusWarning     = checker.StatusWarning     // checked, but part of the check could not complete; see Notes
+	StatusError       = checker.StatusError       // the checker ran and failed
+	StatusBlocked     = checker.StatusBlocked     // a WAF answered with its block or challenge page
+	StatusUnreachable = checker.StatusUnreachable // failed the health pre-check, so the checker never ran
+	StatusSkipped     = checker.StatusSkipped     // never started: the run was stopped or its budget spent
+)
+
+// Checker is implemented by every check. Check is called once per target and
+// must honour ctx cancellation; Name identifies the checker in the audit trail.
+type Checker = checker.Checker
+
+// CheckResult is the outcome of checking a single target.
+type CheckResult = checker.CheckResult
+
+// Structured result sections that checkers may populate.
+type (
+	SecurityHeadersResult = checker.SecurityHeadersResult
+	HeaderStatus          = checker.HeaderStatus
+	TLSComplianceResult   = checker.TLSComplianceResult
+	CookieFinding         = checker.CookieFinding
+	CORSReport            = checker.CORSReport
+	CachePolicy           = checker.CachePolicy
+	NetworkSecurityResult = checker.NetworkSecurityResult
+	ClientSecurityResult  = checker.ClientSecurityResult
+	ComplianceIssue       = checker.ComplianceIssue
+)
+
+// CheckFunc performs a check against a single target.
+type CheckFunc func(ctx context.Context, target string) CheckResult
+
+// New returns a Checker that calls fn for every target.
+func New(name string, fn CheckFunc) Checker {
+	return &funcChecker{name: name, fn: fn}
+}
+
+type funcChecker struct {
+	name string
+	fn   CheckFunc
+}
+
+func (c *funcChecker) Name() string { return c.name }
+
+func (c *funcChecker) Check(ctx context.Context, target string) CheckResult {
+	return c.fn(ctx, target)
+}
+
+// AnalyzeSecurityHeaders grades HTTP response headers using the same rules as
+// "seca check http".
+func AnalyzeSecurityHeaders(headers http.Header) *SecurityHeadersResult {
+	return checker.AnalyzeSecurityHeaders(headers)
+}
+
+// AnalyzeCookies reports insecure Set-Cookie attributes in a response.
+func AnalyzeCookies(resp *http.Response) []CookieFinding {
+	return checker.AnalyzeCookies(resp)
+}
+
+// AnalyzeCachePolicy summarises the caching headers of a response.
+func AnalyzeCachePolicy(headers http.Header) *CachePolicy {
+	return checker.AnalyzeCachePolicy(headers)
+}
@@ -0,0 +1,36 @@
+package checkersdk
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStatuses(t *testing.T) {
+	statuses := map[string]string{
+		StatusOK:          "ok",
+		StatusWarning:     "warning",
+		StatusError:       "error",
+		StatusBlocked:     "blocked",
+		StatusUnreachable: "unreachable",
+		StatusSkipped:     "skipped",
+	}
+	for got, want := range statuses {
+		if got != want {
+			t.Errorf("status %q, want %q", got, want)
+		}
+	}
+	if len(statuses) != 6 {
+		t.Fatalf("expected six distinct statuses, got %d", len(statuses))
+	}
+
+	// A checker's warning and blocked results are understood by the runner.
+	c := New("waf", func(ctx context.Context, target string) CheckResult {
+		return CheckResult{Target: target, Status: StatusBlocked}
+	})
+	if res := c.Check(context.Background(), "https://example.com"); res.Succeeded() {
+		t.Errorf("a blocked result must not count as checked: %+v", res)
+	}
+	if res := (CheckResult{Status: StatusWarning}); !res.Succeeded() {
+		t.Errorf("a warning result must count as checked: %+v", res)
+	}
+}
@@ -0,0 +1,87 @@
+package checkersdk
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Registration describes a checker compiled into the binary.
+type Registration struct {
+	Checker     Checker
+	Name        string
+	Description string
+	// Timeout bounds a single target check. Zero uses the configured check timeout.
+	Timeout time.Duration
+}
+
+// Option customises a Registration.
+type Option func(*Registration)
+
+// WithDescription sets the short help text shown for "seca check <name>".
+func WithDescription(description string) Option {
+	return func(r *Registration) { r.Description = description }
+}
+
+// WithTimeout overrides the per-target timeout for the checker.
+func WithTimeout(timeout time.Duration) Option {
+	return func(r *Registration) { r.Timeout = timeout }
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]Registration{}
+)
+
+// Register adds a checker to the build. The command name is c.Name(), which
+// must be lower-case letters, digits, '-' or '_', and unique.
+func Register(c Checker, opts ...Option) error {
+	if c == nil {
+		return fmt.Errorf("checkersdk: nil checker")
+	}
+	reg := Registration{Checker: c, Name: c.Name()}
+	for _, opt := range opts {
+		opt(&reg)
+	}
+	if !namePattern.MatchString(reg.Name) {
+		return fmt.Errorf("checkersdk: invalid checker name %q (use lower-case letters, digits, '-' or '_')", reg.Name)
+	}
+	if reg.Timeout < 0 {
+		return fmt.Errorf("checkersdk: checker %s has a negative timeout", reg.Name)
+	}
+	if reg.Description == "" {
+		reg.Description = fmt.Sprintf("Run the %s checker", reg.Name)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := registry[reg.Name]; exists {
+		return fmt.Errorf("checkersdk: checker %q is already registered", reg.Name)
+	}
+	registry[reg.Name] = reg
+	return nil
+}
+
+// MustRegister is like Register but panics on error. It is intended for init functions.
+func MustRegister(c Checker, opts ...Option) {
+	if err := Register(c, opts...); err != nil {
+		panic(err)
+	}
+}
+
+// Registered returns every registered checker, sorted by name.
+func Registered() []Registration {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	out := make([]Registration, 0, len(registry))
+	for _, reg := range registry {
+		out = append(out, reg)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
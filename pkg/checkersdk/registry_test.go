@@ -0,0 +1,73 @@
+package checkersdk
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func unregister(name string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	delete(registry, name)
+}
+
+func noopCheck(ctx context.Context, target string) CheckResult {
+	return NewResult(target).Build()
+}
+
+func TestRegister_AddsChecker(t *testing.T) {
+	t.Cleanup(func() { unregister("sdk-test") })
+
+	if err := Register(New("sdk-test", noopCheck), WithDescription("SDK test"), WithTimeout(3*time.Second)); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	var found *Registration
+	for _, reg := range Registered() {
+		if reg.Name == "sdk-test" {
+			reg := reg
+			found = &reg
+		}
+	}
+	if found == nil {
+		t.Fatal("registered checker not returned by Registered")
+	}
+	if found.Description != "SDK test" || found.Timeout != 3*time.Second {
+		t.Fatalf("unexpected registration: %+v", found)
+	}
+}
+
+func TestRegister_RejectsDuplicate(t *testing.T) {
+	t.Cleanup(func() { unregister("sdk-dup") })
+
+	MustRegister(New("sdk-dup", noopCheck))
+	err := Register(New("sdk-dup", noopCheck))
+	if err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Fatalf("expected duplicate error, got %v", err)
+	}
+}
+
+func TestRegister_RejectsInvalidName(t *testing.T) {
+	for _, name := range []string{"", "Bad Name", "-leading", "http/v2"} {
+		if err := Register(New(name, noopCheck)); err == nil {
+			unregister(name)
+			t.Errorf("expected error for name %q", name)
+		}
+	}
+	if err := Register(nil); err == nil {
+		t.Error("expected error for nil checker")
+	}
+}
+
+func TestRegister_DefaultDescription(t *testing.T) {
+	t.Cleanup(func() { unregister("sdk-default") })
+
+	MustRegister(New("sdk-default", noopCheck))
+	for _, reg := range Registered() {
+		if reg.Name == "sdk-default" && reg.Description == "" {
+			t.Fatal("expected a default description")
+		}
+	}
+}
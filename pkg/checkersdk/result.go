@@ -0,0 +1,97 @@
+package checkersdk
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ResultBuilder assembles a CheckResult. The zero status is StatusOK; calling
+// Fail or Failf switches it to StatusError.
+type ResultBuilder struct {
+	result CheckResult
+	notes  []string
+}
+
+// NewResult starts a successful result for target, stamped with the current time.
+func NewResult(target string) *ResultBuilder {
+	return &ResultBuilder{
+		result: CheckResult{
+			Target:    target,
+			CheckedAt: time.Now().UTC(),
+			Status:    StatusOK,
+		},
+	}
+}
+
+// Fail marks the result as failed with err. A nil err leaves the result unchanged.
+func (b *ResultBuilder) Fail(err error) *ResultBuilder {
+	if err == nil {
+		return b
+	}
+	b.result.Status = StatusError
+	b.result.Error = err.Error()
+	return b
+}
+
+// Failf marks the result as failed with a formatted message.
+func (b *ResultBuilder) Failf(format string, args ...interface{}) *ResultBuilder {
+	return b.Fail(fmt.Errorf(format, args...))
+}
+
+// Note appends a formatted note. Notes are joined with "; " in the final result.
+func (b *ResultBuilder) Note(format string, args ...interface{}) *ResultBuilder {
+	b.notes = append(b.notes, fmt.Sprintf(format, args...))
+	return b
+}
+
+// HTTPStatus records the HTTP status code returned by the target.
+func (b *ResultBuilder) HTTPStatus(code int) *ResultBuilder {
+	b.result.HTTPStatus = code
+	return b
+}
+
+// ServerHeader records the Server header returned by the target.
+func (b *ResultBuilder) ServerHeader(server string) *ResultBuilder {
+	b.result.ServerHeader = server
+	return b
+}
+
+// ResponseTime records how long the target took to respond.
+func (b *ResultBuilder) ResponseTime(d time.Duration) *ResultBuilder {
+	b.result.ResponseTime = float64(d) / float64(time.Millisecond)
+	return b
+}
+
+// TLSExpiry records the expiry of the target's leaf certificate.
+func (b *ResultBuilder) TLSExpiry(t time.Time) *ResultBuilder {
+	if !t.IsZero() {
+		b.result.TLSExpiry = t.UTC().Format(time.RFC3339)
+	}
+	return b
+}
+
+// SecurityHeaders attaches a security header analysis.
+func (b *ResultBuilder) SecurityHeaders(r *SecurityHeadersResult) *ResultBuilder {
+	b.result.SecurityHeaders = r
+	return b
+}
+
+// Cookies appends cookie findings.
+func (b *ResultBuilder) Cookies(findings ...CookieFinding) *ResultBuilder {
+	b.result.CookieFindings = append(b.result.CookieFindings, findings...)
+	return b
+}
+
+// Build returns the assembled result.
+func (b *ResultBuilder) Build() CheckResult {
+	out := b.result
+	if len(b.notes) > 0 {
+		notes := strings.Join(b.notes, "; ")
+		if out.Notes != "" {
+			notes = out.Notes + "; " + notes
+		}
+		out.Notes = notes
+	}
+	return out
+}
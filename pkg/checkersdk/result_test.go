@@ -0,0 +1,55 @@
+package checkersdk
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestResultBuilder_OK(t *testing.T) {
+	expiry := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	res := NewResult("https://example.com").
+		HTTPStatus(200).
+		ServerHeader("nginx").
+		ResponseTime(1500*time.Microsecond).
+		TLSExpiry(expiry).
+		Note("first").
+		Note("second %d", 2).
+		Build()
+
+	if res.Status != StatusOK {
+		t.Fatalf("expected status ok, got %s", res.Status)
+	}
+	if res.Target != "https://example.com" || res.HTTPStatus != 200 || res.ServerHeader != "nginx" {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if res.ResponseTime != 1.5 {
+		t.Fatalf("expected 1.5ms response time, got %v", res.ResponseTime)
+	}
+	if res.TLSExpiry != "2030-01-02T03:04:05Z" {
+		t.Fatalf("unexpected TLS expiry %q", res.TLSExpiry)
+	}
+	if res.Notes != "first; second 2" {
+		t.Fatalf("unexpected notes %q", res.Notes)
+	}
+	if res.CheckedAt.IsZero() {
+		t.Fatal("expected CheckedAt to be set")
+	}
+}
+
+func TestResultBuilder_Fail(t *testing.T) {
+	res := NewResult("example.com").Fail(nil).Build()
+	if res.Status != StatusOK || res.Error != "" {
+		t.Fatalf("Fail(nil) should not change the result: %+v", res)
+	}
+
+	res = NewResult("example.com").Fail(errors.New("connection refused")).Build()
+	if res.Status != StatusError || res.Error != "connection refused" {
+		t.Fatalf("unexpected failed result: %+v", res)
+	}
+
+	res = NewResult("example.com").Failf("port %d closed", 443).Build()
+	if res.Status != StatusError || res.Error != "port 443 closed" {
+		t.Fatalf("unexpected failed result: %+v", res)
+	}
+}